@@ -66,6 +66,7 @@ type SimulatedBackend struct {
 	mu           sync.Mutex
 	pendingBlock *types.Block   // Currently pending block that will be imported on request
 	pendingState *state.StateDB // Currently pending state that will be the active on request
+	timeOffset   int64          // Seconds added to the time of the pending block by IncreaseTime
 
 	events *filters.EventSystem // Event system for filtering log events live
 
@@ -130,6 +131,7 @@ func (b *SimulatedBackend) Rollback() {
 }
 
 func (b *SimulatedBackend) rollback() {
+	b.timeOffset = 0
 	blocks, _ := blockchain.GenerateChain(b.config, b.blockchain.CurrentBlock(), gxhash.NewFaker(), b.database, 1, func(int, *blockchain.BlockGen) {})
 	stateDB, _ := b.blockchain.State()
 
@@ -137,6 +139,33 @@ func (b *SimulatedBackend) rollback() {
 	b.pendingState, _ = state.New(b.pendingBlock.Root(), stateDB.Database(), nil, nil)
 }
 
+// Snapshot returns the id of the last committed block, which is used to revert the
+// chain to it by RevertToSnapshot. The chain cannot be reverted to the genesis block,
+// so a block should be committed before taking the first snapshot.
+func (b *SimulatedBackend) Snapshot() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.blockchain.CurrentBlock().NumberU64()
+}
+
+// RevertToSnapshot discards the blocks committed after the snapshot and all pending
+// transactions. The rewards minted in the discarded blocks and the time increased after
+// the snapshot are reverted as well.
+func (b *SimulatedBackend) RevertToSnapshot(id uint64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if id > b.blockchain.CurrentBlock().NumberU64() {
+		return errors.New("unknown snapshot")
+	}
+	if err := b.blockchain.SetHead(id); err != nil {
+		return err
+	}
+	b.rollback()
+	return nil
+}
+
 // stateByBlockNumber retrieves a state by a given blocknumber.
 func (b *SimulatedBackend) stateByBlockNumber(ctx context.Context, blockNumber *big.Int) (*state.StateDB, error) {
 	if blockNumber == nil || blockNumber.Cmp(b.blockchain.CurrentBlock().Number()) == 0 {
@@ -501,6 +530,9 @@ func (b *SimulatedBackend) SendTransaction(_ context.Context, tx *types.Transact
 			block.AddTxWithChain(b.blockchain, tx)
 		}
 		block.AddTxWithChain(b.blockchain, tx)
+		if b.timeOffset != 0 {
+			block.OffsetTime(b.timeOffset)
+		}
 	})
 	stateDB, _ := b.blockchain.State()
 
@@ -620,7 +652,34 @@ func (b *SimulatedBackend) AdjustTime(adjustment time.Duration) error {
 	}
 
 	blocks, _ := blockchain.GenerateChain(b.config, b.blockchain.CurrentBlock(), gxhash.NewFaker(), b.database, 1, func(number int, block *blockchain.BlockGen) {
-		block.OffsetTime(int64(adjustment.Seconds()))
+		block.OffsetTime(b.timeOffset + int64(adjustment.Seconds()))
+	})
+	stateDB, _ := b.blockchain.State()
+
+	b.pendingBlock = blocks[0]
+	b.pendingState, _ = state.New(b.pendingBlock.Root(), stateDB.Database(), nil, nil)
+
+	return nil
+}
+
+// IncreaseTime moves the simulated clock forward. Unlike AdjustTime, it can be called on
+// non-empty blocks and the time shift survives the transactions sent afterwards, so the
+// pending block and all the blocks committed after it are shifted.
+func (b *SimulatedBackend) IncreaseTime(adjustment time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	seconds := int64(adjustment.Seconds())
+	if seconds <= 0 {
+		return errors.New("time can only be increased")
+	}
+	b.timeOffset += seconds
+
+	blocks, _ := blockchain.GenerateChain(b.config, b.blockchain.CurrentBlock(), gxhash.NewFaker(), b.database, 1, func(number int, block *blockchain.BlockGen) {
+		for _, tx := range b.pendingBlock.Transactions() {
+			block.AddTxWithChain(b.blockchain, tx)
+		}
+		block.OffsetTime(b.timeOffset)
 	})
 	stateDB, _ := b.blockchain.State()
 
@@ -197,6 +197,92 @@ func TestNewSimulatedBackend_AdjustTimeFail(t *testing.T) {
 	}
 }
 
+func TestSimulatedBackend_IncreaseTime(t *testing.T) {
+	testAddr := crypto.PubkeyToAddress(testKey.PublicKey)
+	sim := simTestBackend(testAddr)
+	defer sim.Close()
+
+	prevTime := sim.pendingBlock.Time().Uint64()
+	if err := sim.IncreaseTime(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.IncreaseTime(-time.Hour); err == nil {
+		t.Error("Expected increase time to error on negative adjustment")
+	}
+
+	// The time shift survives the transactions sent afterwards
+	tx := types.NewTransaction(0, testAddr, big.NewInt(1000), params.TxGas, big.NewInt(1), nil)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(sim.config.ChainID), testKey)
+	if err != nil {
+		t.Fatalf("could not sign tx: %v", err)
+	}
+	sim.SendTransaction(context.Background(), signedTx)
+	if newTime := sim.pendingBlock.Time().Uint64(); newTime-prevTime != uint64(time.Hour.Seconds()) {
+		t.Errorf("increased time not equal to an hour. prev: %v, new: %v", prevTime, newTime)
+	}
+	sim.Commit()
+
+	// The following blocks are built on top of the shifted block
+	if newTime := sim.pendingBlock.Time().Uint64(); newTime-prevTime <= uint64(time.Hour.Seconds()) {
+		t.Errorf("time not increased after commit. prev: %v, new: %v", prevTime, newTime)
+	}
+}
+
+func TestSimulatedBackend_RevertToSnapshot(t *testing.T) {
+	testAddr := crypto.PubkeyToAddress(testKey.PublicKey)
+	sim := simTestBackend(testAddr)
+	defer sim.Close()
+	bgCtx := context.Background()
+	sim.Commit()
+
+	snapshot := sim.Snapshot()
+	prevTime := sim.pendingBlock.Time().Uint64()
+	prevBalance, err := sim.BalanceAt(bgCtx, params.AuthorAddressForTesting, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := types.NewTransaction(0, testAddr, big.NewInt(1000), params.TxGas, big.NewInt(1), nil)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(sim.config.ChainID), testKey)
+	if err != nil {
+		t.Fatalf("could not sign tx: %v", err)
+	}
+	sim.SendTransaction(bgCtx, signedTx)
+	sim.Commit()
+	if err := sim.IncreaseTime(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	// The reward is minted for each committed block
+	balance, err := sim.BalanceAt(bgCtx, params.AuthorAddressForTesting, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Cmp(prevBalance) <= 0 {
+		t.Errorf("reward not minted. prev: %v, new: %v", prevBalance, balance)
+	}
+
+	if err := sim.RevertToSnapshot(snapshot + 10); err == nil {
+		t.Error("Expected revert to error on unknown snapshot")
+	}
+	if err := sim.RevertToSnapshot(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if number := sim.blockchain.CurrentBlock().NumberU64(); number != snapshot {
+		t.Errorf("chain not reverted. want: %v, got: %v", snapshot, number)
+	}
+	if newTime := sim.pendingBlock.Time().Uint64(); newTime != prevTime {
+		t.Errorf("time not reverted. prev: %v, new: %v", prevTime, newTime)
+	}
+	if balance, _ := sim.BalanceAt(bgCtx, params.AuthorAddressForTesting, nil); balance.Cmp(prevBalance) != 0 {
+		t.Errorf("reward not reverted. prev: %v, new: %v", prevBalance, balance)
+	}
+	if nonce, _ := sim.PendingNonceAt(bgCtx, testAddr); nonce != 0 {
+		t.Errorf("transaction not reverted. nonce: %v", nonce)
+	}
+}
+
 func TestSimulatedBackend_BalanceAt(t *testing.T) {
 	testAddr := crypto.PubkeyToAddress(testKey.PublicKey)
 	expectedBal := big.NewInt(10000000000)
@@ -0,0 +1,53 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomError(t *testing.T) {
+	const definition = `[{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"","type":"uint256"}]}]`
+	parsed, err := JSON(strings.NewReader(definition))
+	require.NoError(t, err)
+
+	customErr, ok := parsed.Errors["InsufficientBalance"]
+	require.True(t, ok)
+	assert.Equal(t, "InsufficientBalance(uint256,uint256)", customErr.Sig)
+	assert.Equal(t, "error InsufficientBalance(uint256 available, uint256 arg1)", customErr.String())
+
+	data, err := customErr.Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	require.NoError(t, err)
+	data = append(crypto.Keccak256([]byte(customErr.Sig))[:4], data...)
+
+	found, err := parsed.ErrorByID(data)
+	require.NoError(t, err)
+	values, err := found.Unpack(data)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{big.NewInt(1), big.NewInt(2)}, values)
+
+	_, err = parsed.ErrorByID([]byte{0x01, 0x02, 0x03, 0x04})
+	assert.Error(t, err)
+	_, err = found.Unpack(data[:3])
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"errors"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// The common errors of the klay APIs. Their codes and reasons are stable, see rpc.APIError.
+var (
+	errIndexNotEnabled     = rpc.NewAPIError(rpc.CodeResourceUnavailable, "indexNotEnabled", "the index is not enabled")
+	errBlockNotIndexed     = rpc.NewAPIError(rpc.CodeResourceUnavailable, "blockNotIndexed", "the block is not indexed")
+	errInvalidBlockRange   = rpc.NewAPIError(rpc.CodeInvalidInput, "invalidBlockRange", "invalid block range")
+	errTooManyItems        = rpc.NewAPIError(rpc.CodeLimitExceeded, "tooManyItems", "too many items are requested")
+	errInvalidTxArgs       = rpc.NewAPIError(rpc.CodeInvalidParams, "invalidTxArgs", "invalid transaction arguments")
+	errInvalidGasFee       = rpc.NewAPIError(rpc.CodeInvalidInput, "invalidGasFee", "invalid gas fee")
+	errTxNotFound          = rpc.NewAPIError(rpc.CodeResourceNotFound, "transactionNotFound", "can't find the transaction")
+	errBlockNotFound       = rpc.NewAPIError(rpc.CodeResourceNotFound, "blockNotFound", "the block does not exist")
+	errTransactionRejected = rpc.NewAPIError(rpc.CodeTransactionRejected, "transactionRejected", "transaction rejected")
+)
+
+// txRejectReasons is the reasons of the errors of the transaction pool, in the order of
+// matching. The errors not listed here are reported as transactionRejected.
+var txRejectReasons = []struct {
+	err    error
+	reason string
+}{
+	{blockchain.ErrNonceTooLow, "nonceTooLow"},
+	{blockchain.ErrNonceTooHigh, "nonceTooHigh"},
+	{blockchain.ErrAlreadyNonceExistInPool, "nonceAlreadyInPool"},
+	{blockchain.ErrReplaceUnderpriced, "replacementUnderpriced"},
+	{blockchain.ErrUnderpriced, "underpriced"},
+	{blockchain.ErrInsufficientFunds, "insufficientFunds"},
+	{blockchain.ErrInsufficientFundsFrom, "insufficientFunds"},
+	{blockchain.ErrInsufficientFundsFeePayer, "insufficientFeePayerFunds"},
+	{blockchain.ErrFeePayerAllowanceSender, "feePayerAllowanceDenied"},
+	{blockchain.ErrFeePayerAllowanceGasCap, "feePayerAllowanceExceeded"},
+	{blockchain.ErrIntrinsicGas, "intrinsicGasTooLow"},
+	{blockchain.ErrGasLimit, "gasLimitExceeded"},
+	{blockchain.ErrOversizedData, "oversizedData"},
+	{blockchain.ErrMaxInitCodeSizeExceeded, "initCodeTooLarge"},
+	{blockchain.ErrNegativeValue, "negativeValue"},
+	{blockchain.ErrInvalidSender, "invalidSender"},
+	{blockchain.ErrInvalidFeePayer, "invalidFeePayer"},
+	{blockchain.ErrInvalidUnitPrice, "invalidGasPrice"},
+	{blockchain.ErrGasPriceBelowBaseFee, "invalidGasPrice"},
+	{blockchain.ErrFeeCapBelowBaseFee, "invalidGasFeeCap"},
+	{blockchain.ErrInvalidGasFeeCap, "invalidGasFeeCap"},
+	{blockchain.ErrInvalidGasTipCap, "invalidGasTipCap"},
+	{blockchain.ErrTipAboveFeeCap, "invalidGasTipCap"},
+	{blockchain.ErrTipVeryHigh, "invalidGasTipCap"},
+	{blockchain.ErrFeeCapVeryHigh, "invalidGasFeeCap"},
+	{blockchain.ErrInvalidChainId, "invalidChainId"},
+	{blockchain.ErrGaslessSenderLimit, "gaslessSenderLimit"},
+	{types.ErrInvalidSig, "invalidSignature"},
+	{types.ErrInvalidSigSender, "invalidSignature"},
+	{types.ErrInvalidSigFeePayer, "invalidSignature"},
+	{types.ErrInvalidChainId, "invalidChainId"},
+	{types.ErrTxTypeNotSupported, "txTypeNotSupported"},
+}
+
+// txRejectedError returns the API error of the transaction rejected by the transaction
+// pool, which is still matched with the error of the pool by errors.Is.
+func txRejectedError(err error) error {
+	var apiErr *rpc.APIError
+	if errors.As(err, &apiErr) {
+		return err
+	}
+	for _, r := range txRejectReasons {
+		if errors.Is(err, r.err) {
+			return rpc.NewAPIError(rpc.CodeTransactionRejected, r.reason, "").Wrap(err)
+		}
+	}
+	return errTransactionRejected.Wrap(err)
+}
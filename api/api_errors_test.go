@@ -0,0 +1,52 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxRejectedError(t *testing.T) {
+	testcases := []struct {
+		err    error
+		reason string
+	}{
+		{blockchain.ErrNonceTooLow, "nonceTooLow"},
+		{fmt.Errorf("%w: address %s", blockchain.ErrInsufficientFundsFeePayer, "0x01"), "insufficientFeePayerFunds"},
+		{blockchain.ErrReplaceUnderpriced, "replacementUnderpriced"},
+		{errors.New("txpool is full"), "transactionRejected"},
+	}
+	for _, tc := range testcases {
+		err := txRejectedError(tc.err)
+		apiErr, ok := rpc.AsAPIError(err)
+		require.True(t, ok, tc.err)
+		assert.Equal(t, rpc.CodeTransactionRejected, apiErr.Code)
+		assert.Equal(t, tc.reason, apiErr.Reason)
+		assert.Equal(t, tc.err.Error(), err.Error())
+		assert.True(t, errors.Is(err, tc.err), "the error of the pool should be matched")
+	}
+
+	// the API errors are returned as they are
+	assert.Equal(t, errTxArgNilNonce, txRejectedError(errTxArgNilNonce))
+}
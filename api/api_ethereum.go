@@ -192,8 +192,8 @@ func (api *EthereumAPI) GetHashrate() uint64 {
 // `eth_getFilterChanges` polling method that is also used for log filters.
 //
 // https://eth.wiki/json-rpc/API#eth_newpendingtransactionfilter
-func (api *EthereumAPI) NewPendingTransactionFilter() rpc.ID {
-	return api.publicFilterAPI.NewPendingTransactionFilter()
+func (api *EthereumAPI) NewPendingTransactionFilter(ctx context.Context) (rpc.ID, error) {
+	return api.publicFilterAPI.NewPendingTransactionFilter(ctx)
 }
 
 // NewPendingTransactions creates a subscription that is triggered each time a transaction
@@ -206,8 +206,8 @@ func (api *EthereumAPI) NewPendingTransactions(ctx context.Context) (*rpc.Subscr
 // It is part of the filter package since polling goes with eth_getFilterChanges.
 //
 // https://eth.wiki/json-rpc/API#eth_newblockfilter
-func (api *EthereumAPI) NewBlockFilter() rpc.ID {
-	return api.publicFilterAPI.NewBlockFilter()
+func (api *EthereumAPI) NewBlockFilter(ctx context.Context) (rpc.ID, error) {
+	return api.publicFilterAPI.NewBlockFilter(ctx)
 }
 
 // NewHeads send a notification each time a new (header) block is appended to the chain.
@@ -216,9 +216,14 @@ func (api *EthereumAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	release, err := api.publicFilterAPI.ReserveSubscription(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	rpcSub := notifier.CreateSubscription()
 	go func() {
+		defer release()
 		headers := make(chan *types.Header)
 		headersSub := api.publicFilterAPI.Events().SubscribeNewHeads(headers)
 
@@ -231,7 +236,10 @@ func (api *EthereumAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error)
 					headersSub.Unsubscribe()
 					return
 				}
-				notifier.Notify(rpcSub.ID, header)
+				if err := notifier.Notify(rpcSub.ID, header); err != nil {
+					headersSub.Unsubscribe()
+					return
+				}
 			case <-rpcSub.Err():
 				headersSub.Unsubscribe()
 				return
@@ -263,8 +271,8 @@ func (api *EthereumAPI) Logs(ctx context.Context, crit filters.FilterCriteria) (
 // In case "fromBlock" > "toBlock" an error is returned.
 //
 // https://eth.wiki/json-rpc/API#eth_newfilter
-func (api *EthereumAPI) NewFilter(crit filters.FilterCriteria) (rpc.ID, error) {
-	return api.publicFilterAPI.NewFilter(crit)
+func (api *EthereumAPI) NewFilter(ctx context.Context, crit filters.FilterCriteria) (rpc.ID, error) {
+	return api.publicFilterAPI.NewFilter(ctx, crit)
 }
 
 // GetLogs returns logs matching the given argument that are stored within the state.
@@ -601,19 +609,57 @@ func (diff *EthStateOverride) Apply(state *state.StateDB) error {
 	return nil
 }
 
+// EthBlockOverrides is a set of header fields to override for a message call, e.g. to simulate
+// the call at a future block number or timestamp, or with a different base fee.
+// BlockOverrides in go-ethereum has been renamed to EthBlockOverrides.
+type EthBlockOverrides struct {
+	Number  *hexutil.Big    `json:"number"`
+	Time    *hexutil.Uint64 `json:"time"`
+	BaseFee *hexutil.Big    `json:"baseFee"`
+	Random  *common.Hash    `json:"random"`
+}
+
+// Apply returns a copy of the header with the overridden fields and the block context of the
+// call based on the given header. The block hashes and the author are still taken from the
+// chain of the given header. It returns the given header and nil if there are no overrides.
+func (diff *EthBlockOverrides) Apply(ctx context.Context, b Backend, header *types.Header) (*types.Header, *vm.BlockContext) {
+	if diff == nil {
+		return header, nil
+	}
+	blockCtx := blockchain.NewEVMBlockContext(header, newChainContext(ctx, b), nil)
+
+	header = types.CopyHeader(header)
+	if diff.Number != nil {
+		header.Number = new(big.Int).Set(diff.Number.ToInt())
+		blockCtx.BlockNumber = new(big.Int).Set(header.Number)
+	}
+	if diff.Time != nil {
+		header.Time = new(big.Int).SetUint64(uint64(*diff.Time))
+		blockCtx.Time = new(big.Int).Set(header.Time)
+	}
+	// The base fee of the header decides the gas price of the message, so the call
+	// is executed as in a Magma block if the base fee is overridden.
+	if diff.BaseFee != nil {
+		header.BaseFee = new(big.Int).Set(diff.BaseFee.ToInt())
+		blockCtx.BaseFee = new(big.Int).Set(header.BaseFee)
+	}
+	blockCtx.Random = diff.Random
+	return header, &blockCtx
+}
+
 // Call executes the given transaction on the state for the given block number.
 //
 // Additionally, the caller can specify a batch of contract for fields overriding.
 //
 // Note, this function doesn't make and changes in the state/blockchain and is
 // useful to execute and retrieve values.
-func (api *EthereumAPI) Call(ctx context.Context, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride) (hexutil.Bytes, error) {
+func (api *EthereumAPI) Call(ctx context.Context, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride, blockOverrides *EthBlockOverrides) (hexutil.Bytes, error) {
 	bcAPI := api.publicBlockChainAPI.b
 	gasCap := uint64(0)
 	if rpcGasCap := bcAPI.RPCGasCap(); rpcGasCap != nil {
 		gasCap = rpcGasCap.Uint64()
 	}
-	result, err := EthDoCall(ctx, bcAPI, args, blockNrOrHash, overrides, bcAPI.RPCEVMTimeout(), gasCap)
+	result, err := EthDoCall(ctx, bcAPI, args, blockNrOrHash, overrides, blockOverrides, bcAPI.RPCEVMTimeout(), gasCap)
 	if err != nil {
 		return nil, err
 	}
@@ -626,7 +672,9 @@ func (api *EthereumAPI) Call(ctx context.Context, args EthTransactionArgs, block
 
 // EstimateGas returns an estimate of the amount of gas needed to execute the
 // given transaction against the current pending block.
-func (api *EthereumAPI) EstimateGas(ctx context.Context, args EthTransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+//
+// Additionally, the caller can specify a batch of accounts for fields overriding.
+func (api *EthereumAPI) EstimateGas(ctx context.Context, args EthTransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *EthStateOverride) (hexutil.Uint64, error) {
 	bcAPI := api.publicBlockChainAPI.b
 	bNrOrHash := rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
 	if blockNrOrHash != nil {
@@ -636,7 +684,7 @@ func (api *EthereumAPI) EstimateGas(ctx context.Context, args EthTransactionArgs
 	if rpcGasCap := bcAPI.RPCGasCap(); rpcGasCap != nil {
 		gasCap = rpcGasCap.Uint64()
 	}
-	return EthDoEstimateGas(ctx, bcAPI, args, bNrOrHash, gasCap)
+	return EthDoEstimateGas(ctx, bcAPI, args, bNrOrHash, overrides, gasCap)
 }
 
 // GetBlockTransactionCountByNumber returns the number of transactions in the block with the given block number.
@@ -1269,6 +1317,9 @@ func (api *EthereumAPI) rpcMarshalHeader(head *types.Header, inclMiner bool) (ma
 		result["randomReveal"] = hexutil.Bytes(head.RandomReveal)
 		result["mixHash"] = hexutil.Bytes(head.MixHash)
 	}
+	if b.ChainConfig().IsStakingCommitmentForkEnabled(head.Number) {
+		result["stakingInfoHash"] = head.StakingInfoHash
+	}
 	return result, nil
 }
 
@@ -1305,7 +1356,7 @@ func (api *EthereumAPI) rpcMarshalBlock(block *types.Block, inclMiner, inclTx, f
 	return fields, nil
 }
 
-func EthDoCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride, timeout time.Duration, globalGasCap uint64) (*blockchain.ExecutionResult, error) {
+func EthDoCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride, blockOverrides *EthBlockOverrides, timeout time.Duration, globalGasCap uint64) (*blockchain.ExecutionResult, error) {
 	defer func(start time.Time) { logger.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
 
 	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
@@ -1315,6 +1366,7 @@ func EthDoCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrO
 	if err := overrides.Apply(state); err != nil {
 		return nil, err
 	}
+	header, blockCtx := blockOverrides.Apply(ctx, b, header)
 	// Setup context so it may be cancelled the call has completed
 	// or, in case of unmetered gas, setup a context with a timeout.
 	var cancel context.CancelFunc
@@ -1358,7 +1410,7 @@ func EthDoCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrO
 	if msg.Gas() < intrinsicGas {
 		return nil, fmt.Errorf("%w: msg.gas %d, want %d", blockchain.ErrIntrinsicGas, msg.Gas(), intrinsicGas)
 	}
-	evm, vmError, err := b.GetEVM(ctx, msg, state, header, vm.Config{})
+	evm, vmError, err := b.GetEVM(ctx, msg, state, header, vm.Config{ComputationCostBudget: rpc.ComputeBudget(ctx)}, blockCtx)
 	if err != nil {
 		return nil, err
 	}
@@ -1378,13 +1430,16 @@ func EthDoCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrO
 	if evm.Cancelled() {
 		return nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
 	}
+	if err == vm.ErrComputationCostBudgetExceeded {
+		return nil, &rpc.ComputeBudgetExceededError{Budget: rpc.ComputeBudget(ctx)}
+	}
 	if err != nil {
 		return result, fmt.Errorf("err: %w (supplied gas %d)", err, msg.Gas())
 	}
 	return result, nil
 }
 
-func EthDoEstimateGas(ctx context.Context, b Backend, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, gasCap uint64) (hexutil.Uint64, error) {
+func EthDoEstimateGas(ctx context.Context, b Backend, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride, gasCap uint64) (hexutil.Uint64, error) {
 	// Use zero address if sender unspecified.
 	if args.From == nil {
 		args.From = new(common.Address)
@@ -1409,11 +1464,14 @@ func EthDoEstimateGas(ctx context.Context, b Backend, args EthTransactionArgs, b
 	if err != nil {
 		return 0, err
 	}
+	if err := overrides.Apply(state); err != nil {
+		return 0, err
+	}
 	balance := state.GetBalance(*args.From) // from can't be nil
 
 	executable := func(gas uint64) (bool, *blockchain.ExecutionResult, error) {
 		args.Gas = (*hexutil.Uint64)(&gas)
-		result, err := EthDoCall(ctx, b, args, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil, 0, gasCap)
+		result, err := EthDoCall(ctx, b, args, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), overrides, nil, 0, gasCap)
 		if err != nil {
 			if errors.Is(err, blockchain.ErrIntrinsicGas) {
 				return true, nil, nil // Special case, raise gas limit
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
@@ -287,7 +286,7 @@ func testGetHeader(t *testing.T, testAPIName string, config *params.ChainConfig)
 		"parentHash": "0xc8036293065bacdfce87debec0094a71dbbe40345b078d21dcc47adb4513f348",
 		"receiptsRoot": "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
 		"sha3Uncles": "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
-		"size": "0x244",
+		"size": "0x24c",
 		"stateRoot": "0xad31c32942fa033166e4ef588ab973dbe26657c594de4ba98192108becf0fec9",
 		"timestamp": "0x61d53854",
 		"totalDifficulty": "0x5",
@@ -301,7 +300,7 @@ func testGetHeader(t *testing.T, testAPIName string, config *params.ChainConfig)
 		expected["randomReveal"] = "0x94516a8bc695b5bf43aa077cd682d9475a3a6bed39a633395b78ed8f276e7c5bb00bb26a77825013c6718579f1b3ee2275b158801705ea77989e3acc849ee9c524bd1822bde3cba7be2aae04347f0d91508b7b7ce2f11ec36cbf763173421ae7"
 		expected["mixHash"] = "0xdf117d1245dceaae0a47f05371b23cd0d0db963ff9d5c8ba768dc989f4c31883"
 		expected["hash"] = "0x4179dd7e323cde164e287045857cbc930892f92479d8c375d5d622ddde59f912"
-		expected["size"] = "0x2c4"
+		expected["size"] = "0x2cc"
 	}
 	assert.Equal(t, stringifyMap(expected), stringifyMap(ethHeader))
 }
@@ -2123,7 +2122,7 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			dynamicFeeParamsSet: false,
 			nonceSet:            false,
 			chainIdSet:          false,
-			expectedError:       fmt.Errorf("only %s is allowed to be used as maxFeePerGas and maxPriorityPerGas", unitPrice.Text(16)),
+			expectedError:       errInvalidGasFee.Errorf("only %s is allowed to be used as maxFeePerGas and maxPriorityPerGas", unitPrice.Text(16)),
 		},
 		{
 			txArgs: EthTransactionArgs{
@@ -2178,7 +2177,7 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			dynamicFeeParamsSet: false,
 			nonceSet:            false,
 			chainIdSet:          false,
-			expectedError:       fmt.Errorf("only %s is allowed to be used as maxFeePerGas and maxPriorityPerGas", unitPrice.Text(16)),
+			expectedError:       errInvalidGasFee.Errorf("only %s is allowed to be used as maxFeePerGas and maxPriorityPerGas", unitPrice.Text(16)),
 		},
 		{
 			txArgs: EthTransactionArgs{
@@ -2199,7 +2198,7 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			dynamicFeeParamsSet: false,
 			nonceSet:            false,
 			chainIdSet:          false,
-			expectedError:       errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified"),
+			expectedError:       errInvalidGasFee.Errorf("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified"),
 		},
 		{
 			txArgs: EthTransactionArgs{
@@ -2442,28 +2441,11 @@ func (mc *testChainContext) GetHeader(common.Hash, uint64) *types.Header {
 var codeRevertHello = "0x6080604052348015600f57600080fd5b5060405162461bcd60e51b815260206004820152600560248201526468656c6c6f60d81b604482015260640160405180910390fdfe"
 
 func testEstimateGas(t *testing.T, mockBackend *mock_api.MockBackend, fnEstimateGas func(EthTransactionArgs) (hexutil.Uint64, error)) {
-	chainConfig := &params.ChainConfig{}
-	chainConfig.IstanbulCompatibleBlock = common.Big0
-	chainConfig.LondonCompatibleBlock = common.Big0
-	chainConfig.EthTxTypeCompatibleBlock = common.Big0
-	chainConfig.MagmaCompatibleBlock = common.Big0
 	var (
 		// genesis
 		account1 = common.HexToAddress("0xaaaa")
 		account2 = common.HexToAddress("0xbbbb")
 		account3 = common.HexToAddress("0xcccc")
-		gspec    = &blockchain.Genesis{Alloc: blockchain.GenesisAlloc{
-			account1: {Balance: big.NewInt(params.KLAY * 2)},
-			account2: {Balance: common.Big0},
-			account3: {Balance: common.Big0, Code: hexutil.MustDecode(codeRevertHello)},
-		}, Config: chainConfig}
-
-		// blockchain
-		dbm    = database.NewMemoryDBManager()
-		db     = state.NewDatabase(dbm)
-		block  = gspec.MustCommit(dbm)
-		header = block.Header()
-		chain  = &testChainContext{header: header}
 
 		// tx arguments
 		KLAY     = hexutil.Big(*big.NewInt(params.KLAY))
@@ -2473,25 +2455,11 @@ func testEstimateGas(t *testing.T, mockBackend *mock_api.MockBackend, fnEstimate
 		gas40000 = hexutil.Uint64(40000)
 		baddata  = hexutil.Bytes(hexutil.MustDecode("0xdeadbeef"))
 	)
-
-	any := gomock.Any()
-	getStateAndHeader := func(...interface{}) (*state.StateDB, *types.Header, error) {
-		// Return a new state for each call because the state is modified by EstimateGas.
-		state, err := state.New(block.Root(), db, nil, nil)
-		return state, header, err
-	}
-	getEVM := func(_ context.Context, msg blockchain.Message, state *state.StateDB, header *types.Header, vmConfig vm.Config) (*vm.EVM, func() error, error) {
-		// Taken from node/cn/api_backend.go
-		vmError := func() error { return nil }
-		txContext := blockchain.NewEVMTxContext(msg, header)
-		blockContext := blockchain.NewEVMBlockContext(header, chain, nil)
-		return vm.NewEVM(blockContext, txContext, state, chainConfig, &vmConfig), vmError, nil
-	}
-	mockBackend.EXPECT().ChainConfig().Return(chainConfig).AnyTimes()
-	mockBackend.EXPECT().RPCGasCap().Return(common.Big0).AnyTimes()
-	mockBackend.EXPECT().StateAndHeaderByNumber(any, any).DoAndReturn(getStateAndHeader).AnyTimes()
-	mockBackend.EXPECT().StateAndHeaderByNumberOrHash(any, any).DoAndReturn(getStateAndHeader).AnyTimes()
-	mockBackend.EXPECT().GetEVM(any, any, any, any, any).DoAndReturn(getEVM).AnyTimes()
+	mockEstimateGasBackend(mockBackend, blockchain.GenesisAlloc{
+		account1: {Balance: big.NewInt(params.KLAY * 2)},
+		account2: {Balance: common.Big0},
+		account3: {Balance: common.Big0, Code: hexutil.MustDecode(codeRevertHello)},
+	})
 
 	testcases := []struct {
 		args      EthTransactionArgs
@@ -2586,11 +2554,57 @@ func testEstimateGas(t *testing.T, mockBackend *mock_api.MockBackend, fnEstimate
 	}
 }
 
+// mockEstimateGasBackend makes the backend execute the calls on the genesis state of the given accounts.
+// It returns the chain config of the backend and the genesis header.
+func mockEstimateGasBackend(mockBackend *mock_api.MockBackend, alloc blockchain.GenesisAlloc) (*params.ChainConfig, *types.Header) {
+	chainConfig := &params.ChainConfig{}
+	chainConfig.IstanbulCompatibleBlock = common.Big0
+	chainConfig.LondonCompatibleBlock = common.Big0
+	chainConfig.EthTxTypeCompatibleBlock = common.Big0
+	chainConfig.MagmaCompatibleBlock = common.Big0
+	chainConfig.KoreCompatibleBlock = big.NewInt(100)
+	var (
+		gspec  = &blockchain.Genesis{Alloc: alloc, Config: chainConfig}
+		dbm    = database.NewMemoryDBManager()
+		db     = state.NewDatabase(dbm)
+		block  = gspec.MustCommit(dbm)
+		header = block.Header()
+		chain  = &testChainContext{header: header}
+	)
+
+	any := gomock.Any()
+	getStateAndHeader := func(...interface{}) (*state.StateDB, *types.Header, error) {
+		// Return a new state for each call because the state is modified by EstimateGas.
+		state, err := state.New(block.Root(), db, nil, nil)
+		return state, header, err
+	}
+	getEVM := func(_ context.Context, msg blockchain.Message, state *state.StateDB, header *types.Header, vmConfig vm.Config, blockCtx *vm.BlockContext) (*vm.EVM, func() error, error) {
+		// Taken from node/cn/api_backend.go
+		vmError := func() error { return nil }
+		txContext := blockchain.NewEVMTxContext(msg, header)
+		var blockContext vm.BlockContext
+		if blockCtx != nil {
+			blockContext = *blockCtx
+		} else {
+			blockContext = blockchain.NewEVMBlockContext(header, chain, nil)
+		}
+		return vm.NewEVM(blockContext, txContext, state, chainConfig, &vmConfig), vmError, nil
+	}
+	mockBackend.EXPECT().ChainConfig().Return(chainConfig).AnyTimes()
+	mockBackend.EXPECT().RPCGasCap().Return(common.Big0).AnyTimes()
+	mockBackend.EXPECT().StateAndHeaderByNumber(any, any).DoAndReturn(getStateAndHeader).AnyTimes()
+	mockBackend.EXPECT().StateAndHeaderByNumberOrHash(any, any).DoAndReturn(getStateAndHeader).AnyTimes()
+	mockBackend.EXPECT().GetEVM(any, any, any, any, any, any).DoAndReturn(getEVM).AnyTimes()
+	mockBackend.EXPECT().Engine().Return(chain.Engine()).AnyTimes()
+	mockBackend.EXPECT().HeaderByHash(any, header.Hash()).Return(header, nil).AnyTimes()
+	return chainConfig, header
+}
+
 func TestEthereumAPI_EstimateGas(t *testing.T) {
 	mockCtrl, mockBackend, api := testInitForEthApi(t)
 	defer mockCtrl.Finish()
 
 	testEstimateGas(t, mockBackend, func(args EthTransactionArgs) (hexutil.Uint64, error) {
-		return api.EstimateGas(context.Background(), args, nil)
+		return api.EstimateGas(context.Background(), args, nil, nil)
 	})
 }
@@ -0,0 +1,160 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// roleNames are the names of the roles of a role-based key in GetAccountInfo.
+var roleNames = [accountkey.RoleLast]string{"transaction", "accountUpdate", "feePayer"}
+
+// AccountInfo is the Klaytn account model of an address returned by GetAccountInfo.
+// An address without an account is reported as an EOA having a legacy key.
+type AccountInfo struct {
+	Address       common.Address      `json:"address"`
+	Exists        bool                `json:"exists"`
+	AccType       account.AccountType `json:"accType"`
+	Type          string              `json:"type"` // "EOA", "SCA" or "Legacy"
+	Nonce         hexutil.Uint64      `json:"nonce"`
+	Balance       *hexutil.Big        `json:"balance"`
+	HumanReadable bool                `json:"humanReadable"`
+	Key           *AccountKeyInfo     `json:"key"`
+	CodeHash      hexutil.Bytes       `json:"codeHash,omitempty"`    // Only for the accounts having a program
+	StorageRoot   *common.Hash        `json:"storageRoot,omitempty"` // Only for the accounts having a program
+}
+
+// AccountKeyInfo is an account key decoded into its structure.
+type AccountKeyInfo struct {
+	KeyType   accountkey.AccountKeyType `json:"keyType"`
+	Summary   string                    `json:"summary"`             // Human-readable description of the key
+	PublicKey hexutil.Bytes             `json:"publicKey,omitempty"` // Compressed public key of a public key
+	Address   *common.Address           `json:"address,omitempty"`   // Address derived from the public key
+	Threshold *hexutil.Uint             `json:"threshold,omitempty"`
+	Keys      []WeightedKeyInfo         `json:"keys,omitempty"`
+	Roles     []RoleKeyInfo             `json:"roles,omitempty"`
+}
+
+// WeightedKeyInfo is a key of a weighted multisig key.
+type WeightedKeyInfo struct {
+	Weight    hexutil.Uint   `json:"weight"`
+	PublicKey hexutil.Bytes  `json:"publicKey"`
+	Address   common.Address `json:"address"`
+}
+
+// RoleKeyInfo is the key validating the transactions of a role of a role-based key.
+type RoleKeyInfo struct {
+	Role    string          `json:"role"`
+	Default bool            `json:"default"` // True if the role is not set and validated by the transaction key
+	Key     *AccountKeyInfo `json:"key"`
+}
+
+// GetAccountInfo returns the account model of an address in one call: the account type,
+// the decoded account key, the nonce, the balance and the program of the account.
+func (s *PublicBlockChainAPI) GetAccountInfo(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*AccountInfo, error) {
+	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	info := &AccountInfo{
+		Address: address,
+		AccType: account.ExternallyOwnedAccountType,
+		Nonce:   hexutil.Uint64(state.GetNonce(address)),
+		Balance: (*hexutil.Big)(state.GetBalance(address)),
+		Key:     newAccountKeyInfo(state.GetKey(address)),
+	}
+	if acc := state.GetAccount(address); acc != nil {
+		info.Exists = true
+		info.AccType = acc.Type()
+		info.HumanReadable = acc.GetHumanReadable()
+		if pa := account.GetProgramAccount(acc); pa != nil {
+			root := pa.GetStorageRoot().Unextend()
+			info.CodeHash = pa.GetCodeHash()
+			info.StorageRoot = &root
+		}
+	}
+	switch info.AccType {
+	case account.LegacyAccountType:
+		info.Type = "Legacy"
+	case account.ExternallyOwnedAccountType:
+		info.Type = "EOA"
+	case account.SmartContractAccountType:
+		info.Type = "SCA"
+	}
+	return info, state.Error()
+}
+
+// newAccountKeyInfo decodes the given account key.
+func newAccountKeyInfo(key accountkey.AccountKey) *AccountKeyInfo {
+	info := &AccountKeyInfo{KeyType: key.Type()}
+	switch k := key.(type) {
+	case *accountkey.AccountKeyNil:
+		info.Summary = "nil"
+	case *accountkey.AccountKeyLegacy:
+		info.Summary = "legacy: signed by the key the address is derived from"
+	case *accountkey.AccountKeyFail:
+		info.Summary = "fail: no signature is valid"
+	case *accountkey.AccountKeyPublic:
+		addr := crypto.PubkeyToAddress(*(*ecdsa.PublicKey)(k.PublicKeySerializable))
+		info.PublicKey = crypto.CompressPubkey((*ecdsa.PublicKey)(k.PublicKeySerializable))
+		info.Address = &addr
+		info.Summary = fmt.Sprintf("public: signed by %s", addr.Hex())
+	case *accountkey.AccountKeyWeightedMultiSig:
+		threshold := hexutil.Uint(k.Threshold)
+		info.Threshold = &threshold
+		var total uint
+		for _, wk := range k.Keys {
+			pubkey := (*ecdsa.PublicKey)(wk.Key)
+			info.Keys = append(info.Keys, WeightedKeyInfo{
+				Weight:    hexutil.Uint(wk.Weight),
+				PublicKey: crypto.CompressPubkey(pubkey),
+				Address:   crypto.PubkeyToAddress(*pubkey),
+			})
+			total += wk.Weight
+		}
+		info.Summary = fmt.Sprintf("weightedMultiSig: threshold %d of total weight %d by %d keys", k.Threshold, total, len(k.Keys))
+	case *accountkey.AccountKeyRoleBased:
+		summaries := make([]string, 0, accountkey.RoleLast)
+		for role := accountkey.RoleTransaction; role < accountkey.RoleLast; role++ {
+			roleKey := RoleKeyInfo{Role: roleNames[role]}
+			if int(role) < len(*k) {
+				roleKey.Key = newAccountKeyInfo((*k)[role])
+			} else if len(*k) > 0 {
+				roleKey.Default = true
+				roleKey.Key = newAccountKeyInfo((*k)[accountkey.RoleTransaction])
+			} else {
+				continue
+			}
+			info.Roles = append(info.Roles, roleKey)
+			summaries = append(summaries, fmt.Sprintf("%s=(%s)", roleKey.Role, roleKey.Key.Summary))
+		}
+		info.Summary = "roleBased: " + strings.Join(summaries, ", ")
+	default:
+		info.Summary = key.String()
+	}
+	return info
+}
@@ -26,9 +26,12 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/system"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/blockchain/types/account"
 	"github.com/klaytn/klaytn/blockchain/types/accountkey"
@@ -41,6 +44,7 @@ import (
 	"github.com/klaytn/klaytn/node/cn/filters"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
 )
 
 var logger = log.NewModuleLogger(log.API)
@@ -127,6 +131,56 @@ func (s *PublicBlockChainAPI) GetBalance(ctx context.Context, address common.Add
 	return (*hexutil.Big)(state.GetBalance(address)), state.Error()
 }
 
+// RewardVestingTranche is the block rewards of a vesting recipient locked in a period, which
+// are vested linearly from VestingStart to VestingEnd.
+type RewardVestingTranche struct {
+	VestingStart hexutil.Uint64 `json:"vestingStart"`
+	VestingEnd   hexutil.Uint64 `json:"vestingEnd"`
+	Amount       *hexutil.Big   `json:"amount"`
+	Released     *hexutil.Big   `json:"released"`
+}
+
+// RewardVestingResult is the block rewards of a vesting recipient vested and locked at a block.
+type RewardVestingResult struct {
+	Period   hexutil.Uint64          `json:"period"`
+	Vested   *hexutil.Big            `json:"vested"`
+	Locked   *hexutil.Big            `json:"locked"`
+	Tranches []*RewardVestingTranche `json:"tranches"`
+}
+
+// GetRewardVesting returns the block rewards of the vesting recipient vested, i.e. credited to
+// its balance, and still locked in the vesting vault after the RewardVesting fork.
+func (s *PublicBlockChainAPI) GetRewardVesting(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*RewardVestingResult, error) {
+	vesting := s.b.ChainConfig().RewardVesting
+	if vesting == nil || vesting.Period == 0 {
+		return nil, errRewardVestingNotConfigured
+	}
+	if !vesting.IsRecipient(address) {
+		return nil, errNotVestingRecipient
+	}
+	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	status := system.ReadVestingStatus(state, address)
+	result := &RewardVestingResult{
+		Period:   hexutil.Uint64(vesting.Period),
+		Vested:   (*hexutil.Big)(status.Vested),
+		Locked:   (*hexutil.Big)(status.Locked),
+		Tranches: make([]*RewardVestingTranche, 0, len(status.Tranches)),
+	}
+	for _, tranche := range status.Tranches {
+		result.Tranches = append(result.Tranches, &RewardVestingTranche{
+			VestingStart: hexutil.Uint64((tranche.Period + 1) * vesting.Period),
+			VestingEnd:   hexutil.Uint64((tranche.Period+2)*vesting.Period - 1),
+			Amount:       (*hexutil.Big)(tranche.Amount),
+			Released:     (*hexutil.Big)(tranche.Released),
+		})
+	}
+	return result, state.Error()
+}
+
 // AccountCreated returns true if the account associated with the address is created.
 // It returns false otherwise.
 func (s *PublicBlockChainAPI) AccountCreated(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (bool, error) {
@@ -271,6 +325,195 @@ func (s *PublicBlockChainAPI) IsSenderTxHashIndexingEnabled() bool {
 	return s.b.IsSenderTxHashIndexingEnabled()
 }
 
+// maxBalanceHistoryPoints is the maximum number of balances returned by GetBalanceHistory.
+const maxBalanceHistoryPoints = 1000
+
+var (
+	errBalanceHistoryNotIndexed   = errIndexNotEnabled.Errorf("balance history is not indexed, enable it with --balancehistoryindexing").WithDetail("index", "balanceHistory")
+	errInvalidBalanceHistoryRange = errInvalidBlockRange.Errorf("invalid block range of the balance history")
+	errInvalidFeeRatio            = rpc.NewAPIError(rpc.CodeInvalidParams, "invalidFeeRatio", "feeRatio must be in [1, 99] with feePayer")
+	errRewardVestingNotConfigured = rpc.NewAPIError(rpc.CodeResourceUnavailable, "rewardVestingNotConfigured", "the reward vesting is not configured")
+	errNotVestingRecipient        = rpc.NewAPIError(rpc.CodeInvalidParams, "notVestingRecipient", "the address is not a vesting recipient")
+)
+
+// BalanceHistoryEntry is the balance of an account at a block.
+type BalanceHistoryEntry struct {
+	Number  hexutil.Uint64 `json:"number"`
+	Balance *hexutil.Big   `json:"balance"`
+}
+
+// GetBalanceHistory returns the balances of the given address at every step blocks in the block
+// range [fromBlock, toBlock]. It is served from the balance history index, so the range should not
+// precede the block from which the index has been built.
+func (s *PublicBlockChainAPI) GetBalanceHistory(ctx context.Context, address common.Address, fromBlock, toBlock rpc.BlockNumber, step *hexutil.Uint64) ([]BalanceHistoryEntry, error) {
+	db := s.b.ChainDB()
+	indexStart, ok := db.ReadBalanceHistoryIndexStart()
+	if !ok {
+		return nil, errBalanceHistoryNotIndexed
+	}
+
+	head := s.b.CurrentBlock().NumberU64()
+	from, to := resolveHistoryBlockNumber(fromBlock, head), resolveHistoryBlockNumber(toBlock, head)
+	if from > to || to > head {
+		return nil, errInvalidBalanceHistoryRange
+	}
+	if from < indexStart {
+		return nil, errBlockNotIndexed.Errorf("balance history is indexed from block %d", indexStart).WithDetail("indexStart", indexStart)
+	}
+	interval := uint64(1)
+	if step != nil && *step > 0 {
+		interval = uint64(*step)
+	}
+	if (to-from)/interval+1 > maxBalanceHistoryPoints {
+		return nil, errTooManyItems.Errorf("too many balances are requested, the maximum is %d", maxBalanceHistoryPoints).WithDetail("maxItems", maxBalanceHistoryPoints)
+	}
+
+	changes := db.ReadBalanceChanges(address, indexStart, to)
+	next := db.ReadBalanceChangeAfter(address, to)
+	var latest *big.Int
+	if len(changes) == 0 && next == nil {
+		// The balance has not been changed since the index started.
+		state, _, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		latest = state.GetBalance(address)
+	}
+
+	history := make([]BalanceHistoryEntry, 0, (to-from)/interval+1)
+	for number := from; number <= to; number += interval {
+		history = append(history, BalanceHistoryEntry{
+			Number:  hexutil.Uint64(number),
+			Balance: (*hexutil.Big)(balanceAt(changes, next, latest, number)),
+		})
+		if number+interval < number { // overflow
+			break
+		}
+	}
+	return history, nil
+}
+
+// resolveHistoryBlockNumber converts the given block number to a block number not above the head.
+func resolveHistoryBlockNumber(number rpc.BlockNumber, head uint64) uint64 {
+	if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
+		return head
+	}
+	return uint64(number.Int64())
+}
+
+// balanceAt returns the balance at the given block number from the ascending balance changes up to
+// a block, the first change after that block and the latest balance used if there is no change.
+func balanceAt(changes []*database.BalanceChange, next *database.BalanceChange, latest *big.Int, number uint64) *big.Int {
+	// find the first change after the given block number
+	i := sort.Search(len(changes), func(i int) bool { return changes[i].Number > number })
+	switch {
+	case i > 0:
+		return changes[i-1].Balance
+	case i < len(changes):
+		return changes[i].Prev
+	case next != nil:
+		return next.Prev
+	default:
+		return latest
+	}
+}
+
+var errRewardsNotIndexed = errIndexNotEnabled.Errorf("rewards are not indexed, enable it with --rewardindexing").WithDetail("index", "reward")
+
+// AccumulatedRewards is the rewards paid to an address in a block range.
+type AccumulatedRewards struct {
+	Address   common.Address `json:"address"`
+	FromBlock hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   hexutil.Uint64 `json:"toBlock"`
+	Minted    *hexutil.Big   `json:"minted"` // rewards from the minted amount
+	Fee       *hexutil.Big   `json:"fee"`    // rewards from the tx fees
+	Total     *hexutil.Big   `json:"total"`
+}
+
+// GetAccumulatedRewards returns the total rewards paid to the given address in the block range
+// [fromBlock, toBlock]. It is served from the reward index, so the range should be within the blocks
+// indexed since the index has been enabled. The latest block is the last block indexed.
+func (s *PublicBlockChainAPI) GetAccumulatedRewards(address common.Address, fromBlock, toBlock rpc.BlockNumber) (*AccumulatedRewards, error) {
+	db := s.b.ChainDB()
+	indexStart, ok := db.ReadRewardIndexStart()
+	if !ok {
+		return nil, errRewardsNotIndexed
+	}
+	indexHead, _ := db.ReadRewardIndexHead()
+
+	from, to := resolveHistoryBlockNumber(fromBlock, indexHead), resolveHistoryBlockNumber(toBlock, indexHead)
+	if from > to {
+		return nil, errInvalidBlockRange.Errorf("invalid block range of the rewards")
+	}
+	if from < indexStart || to > indexHead {
+		return nil, errBlockNotIndexed.Errorf("rewards are indexed from block %d to %d", indexStart, indexHead).
+			WithDetail("indexStart", indexStart).WithDetail("indexHead", indexHead)
+	}
+
+	minted, fee := accumulatedRewardsIn(db, address, from, to)
+	return &AccumulatedRewards{
+		Address:   address,
+		FromBlock: hexutil.Uint64(from),
+		ToBlock:   hexutil.Uint64(to),
+		Minted:    (*hexutil.Big)(minted),
+		Fee:       (*hexutil.Big)(fee),
+		Total:     (*hexutil.Big)(new(big.Int).Add(minted, fee)),
+	}, nil
+}
+
+// accumulatedRewardsIn returns the minted and fee rewards paid to the address in the block range
+// [from, to] from the reward index.
+func accumulatedRewardsIn(db database.DBManager, address common.Address, from, to uint64) (*big.Int, *big.Int) {
+	minted, fee := new(big.Int), new(big.Int)
+	if acc := db.ReadAccumulatedReward(address, to); acc != nil {
+		minted.Set(acc.Minted)
+		fee.Set(acc.Fee)
+	}
+	if from > 0 {
+		if acc := db.ReadAccumulatedReward(address, from-1); acc != nil {
+			minted.Sub(minted, acc.Minted)
+			fee.Sub(fee, acc.Fee)
+		}
+	}
+	return minted, fee
+}
+
+// EpochSummaryResult is the summary of an epoch returned by GetEpochSummary.
+type EpochSummaryResult struct {
+	Epoch      hexutil.Uint64         `json:"epoch"`
+	StartBlock hexutil.Uint64         `json:"startBlock"`
+	EndBlock   hexutil.Uint64         `json:"endBlock"`
+	Completed  bool                   `json:"completed"`
+	Params     map[string]interface{} `json:"params"`
+	Validators []common.Address       `json:"validators"`
+	Minted     *hexutil.Big           `json:"minted"`
+	TotalFee   *hexutil.Big           `json:"totalFee"`
+	Rewards    *hexutil.Big           `json:"rewards"`
+	Burnt      *hexutil.Big           `json:"burnt"`
+}
+
+// GetEpochSummary returns the governance parameters, the validators, the total rewards and
+// the total burnt fees of the given epoch. The summary of the epoch in progress is updated
+// as the blocks are inserted, and Completed is set when the last block of the epoch is summarized.
+func (s *PublicBlockChainAPI) GetEpochSummary(epoch hexutil.Uint64) (*EpochSummaryResult, error) {
+	summary := s.b.ChainDB().ReadEpochSummary(uint64(epoch))
+	if summary == nil {
+		return nil, errIndexNotEnabled.Errorf("epoch %d is not summarized, enable it with --epochsummaryindexing", epoch).WithDetail("index", "epochSummary")
+	}
+	return &EpochSummaryResult{
+		Epoch:      hexutil.Uint64(summary.Epoch),
+		StartBlock: hexutil.Uint64(summary.StartBlock),
+		EndBlock:   hexutil.Uint64(summary.EndBlock),
+		Completed:  summary.Completed,
+		Params:     summary.Params,
+		Validators: summary.Validators,
+		Minted:     (*hexutil.Big)(summary.Minted),
+		TotalFee:   (*hexutil.Big)(summary.TotalFee),
+		Rewards:    (*hexutil.Big)(summary.Rewards),
+		Burnt:      (*hexutil.Big)(summary.Burnt),
+	}, nil
+}
+
 // CallArgs represents the arguments for a call.
 type CallArgs struct {
 	From                 common.Address  `json:"from"`
@@ -286,6 +529,11 @@ type CallArgs struct {
 	// Introduced by AccessListTxType transaction.
 	AccessList *types.AccessList `json:"accessList,omitempty"`
 	ChainID    *hexutil.Big      `json:"chainId,omitempty"`
+
+	// Introduced by fee-delegated transaction types. FeeRatio is the percentage of the fee
+	// paid by FeePayer, which pays the whole fee if FeeRatio is not given.
+	FeePayer *common.Address `json:"feePayer,omitempty"`
+	FeeRatio *types.FeeRatio `json:"feeRatio,omitempty"`
 }
 
 func (args *CallArgs) InputData() []byte {
@@ -298,13 +546,17 @@ func (args *CallArgs) InputData() []byte {
 	return nil
 }
 
-func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, vmCfg vm.Config, timeout time.Duration, globalGasCap *big.Int) (*blockchain.ExecutionResult, uint64, error) {
+func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride, blockOverrides *EthBlockOverrides, vmCfg vm.Config, timeout time.Duration, globalGasCap *big.Int) (*blockchain.ExecutionResult, uint64, error) {
 	defer func(start time.Time) { logger.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
 
 	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
 		return nil, 0, err
 	}
+	if err := overrides.Apply(state); err != nil {
+		return nil, 0, err
+	}
+	header, blockCtx := blockOverrides.Apply(ctx, b, header)
 	// Setup context so it may be cancelled the call has completed
 	// or, in case of unmetered gas, setup a context with a timeout.
 	var cancel context.CancelFunc
@@ -349,7 +601,8 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 	if msg.Gas() < intrinsicGas {
 		return nil, 0, fmt.Errorf("%w: msg.gas %d, want %d", blockchain.ErrIntrinsicGas, msg.Gas(), intrinsicGas)
 	}
-	evm, vmError, err := b.GetEVM(ctx, msg, state, header, vmCfg)
+	vmCfg.ComputationCostBudget = rpc.ComputeBudget(ctx)
+	evm, vmError, err := b.GetEVM(ctx, msg, state, header, vmCfg, blockCtx)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -369,6 +622,9 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 	if evm.Cancelled() {
 		return nil, 0, fmt.Errorf("execution aborted (timeout = %v)", timeout)
 	}
+	if err == vm.ErrComputationCostBudgetExceeded {
+		return nil, 0, &rpc.ComputeBudgetExceededError{Budget: vmCfg.ComputationCostBudget}
+	}
 	if err != nil {
 		return result, 0, fmt.Errorf("err: %w (supplied gas %d)", err, msg.Gas())
 	}
@@ -377,12 +633,13 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 
 // Call executes the given transaction on the state for the given block number or hash.
 // It doesn't make and changes in the state/blockchain and is useful to execute and retrieve values.
-func (s *PublicBlockChainAPI) Call(ctx context.Context, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+// The accounts and the header fields of the block can be overridden for the call.
+func (s *PublicBlockChainAPI) Call(ctx context.Context, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride, blockOverrides *EthBlockOverrides) (hexutil.Bytes, error) {
 	gasCap := big.NewInt(0)
 	if rpcGasCap := s.b.RPCGasCap(); rpcGasCap != nil {
 		gasCap = rpcGasCap
 	}
-	result, _, err := DoCall(ctx, s.b, args, blockNrOrHash, vm.Config{}, s.b.RPCEVMTimeout(), gasCap)
+	result, _, err := DoCall(ctx, s.b, args, blockNrOrHash, overrides, blockOverrides, vm.Config{}, s.b.RPCEVMTimeout(), gasCap)
 	if err != nil {
 		return nil, err
 	}
@@ -398,20 +655,22 @@ func (s *PublicBlockChainAPI) EstimateComputationCost(ctx context.Context, args
 	if rpcGasCap := s.b.RPCGasCap(); rpcGasCap != nil {
 		gasCap = rpcGasCap
 	}
-	_, computationCost, err := DoCall(ctx, s.b, args, blockNrOrHash, vm.Config{UseOpcodeComputationCost: true}, s.b.RPCEVMTimeout(), gasCap)
+	_, computationCost, err := DoCall(ctx, s.b, args, blockNrOrHash, nil, nil, vm.Config{UseOpcodeComputationCost: true}, s.b.RPCEVMTimeout(), gasCap)
 	return (hexutil.Uint64)(computationCost), err
 }
 
 // EstimateGas returns an estimate of the amount of gas needed to execute the given transaction against the latest block.
-func (s *PublicBlockChainAPI) EstimateGas(ctx context.Context, args CallArgs) (hexutil.Uint64, error) {
+// The accounts can be overridden for the estimation, e.g. to fund the sender or the fee payer, and
+// the header fields of the block can be overridden, e.g. to estimate at a future timestamp.
+func (s *PublicBlockChainAPI) EstimateGas(ctx context.Context, args CallArgs, overrides *EthStateOverride, blockOverrides *EthBlockOverrides) (hexutil.Uint64, error) {
 	gasCap := uint64(0)
 	if rpcGasCap := s.b.RPCGasCap(); rpcGasCap != nil {
 		gasCap = rpcGasCap.Uint64()
 	}
-	return s.DoEstimateGas(ctx, s.b, args, big.NewInt(int64(gasCap)))
+	return s.DoEstimateGas(ctx, s.b, args, big.NewInt(int64(gasCap)), overrides, blockOverrides)
 }
 
-func (s *PublicBlockChainAPI) DoEstimateGas(ctx context.Context, b Backend, args CallArgs, gasCap *big.Int) (hexutil.Uint64, error) {
+func (s *PublicBlockChainAPI) DoEstimateGas(ctx context.Context, b Backend, args CallArgs, gasCap *big.Int, overrides *EthStateOverride, blockOverrides *EthBlockOverrides) (hexutil.Uint64, error) {
 	var feeCap *big.Int
 	if args.GasPrice != nil {
 		feeCap = args.GasPrice.ToInt()
@@ -419,26 +678,90 @@ func (s *PublicBlockChainAPI) DoEstimateGas(ctx context.Context, b Backend, args
 		feeCap = common.Big0
 	}
 
-	state, _, err := b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	state, header, err := b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return 0, err
+	}
+	if err := overrides.Apply(state); err != nil {
+		return 0, err
+	}
+	funds := blockchain.GasFunds{Sender: state.GetBalance(args.From)} // from can't be nil
+	if args.FeeRatio != nil && (args.FeePayer == nil || !args.FeeRatio.IsValid()) {
+		return 0, errInvalidFeeRatio
+	}
+	if args.FeePayer != nil {
+		funds.FeePayer = state.GetBalance(*args.FeePayer)
+		if args.FeeRatio != nil {
+			funds.FeeRatio = uint8(*args.FeeRatio)
+		}
+	}
+	// The gas charged by the transaction in addition to the execution of the message
+	blockNumber := header.Number
+	if blockOverrides != nil && blockOverrides.Number != nil {
+		blockNumber = blockOverrides.Number.ToInt()
+	}
+	extraGas, err := args.extraIntrinsicGas(state, blockNumber.Uint64())
 	if err != nil {
 		return 0, err
 	}
-	balance := state.GetBalance(args.From) // from can't be nil
 
 	// Create a helper to check if a gas allowance results in an executable transaction
 	executable := func(gas uint64) (bool, *blockchain.ExecutionResult, error) {
-		args.Gas = hexutil.Uint64(gas)
-		result, _, err := DoCall(ctx, b, args, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), vm.Config{}, 0, gasCap)
+		if gas < extraGas {
+			return true, nil, nil // raise gas limit
+		}
+		args.Gas = hexutil.Uint64(gas - extraGas)
+		result, _, err := DoCall(ctx, b, args, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), overrides, blockOverrides, vm.Config{}, 0, gasCap)
 		if err != nil {
 			if errors.Is(err, blockchain.ErrIntrinsicGas) {
 				return true, nil, nil // Special case, raise gas limit
 			}
 			return true, nil, err // Bail out
 		}
+		result.UsedGas += extraGas // in terms of the gas of the transaction
 		return result.Failed(), result, nil
 	}
 
-	return blockchain.DoEstimateGas(ctx, uint64(args.Gas), gasCap.Uint64(), args.Value.ToInt(), feeCap, balance, executable)
+	return blockchain.DoEstimateGasWithFunds(ctx, uint64(args.Gas), gasCap.Uint64(), args.Value.ToInt(), feeCap, funds, executable)
+}
+
+// extraIntrinsicGas returns the intrinsic gas of the transaction not charged by the execution of
+// the message: the gas of the fee delegation and the signature validation gas of the sender and
+// the fee payer. The validation gas assumes that all keys of the multisig account keys sign.
+func (args *CallArgs) extraIntrinsicGas(statedb *state.StateDB, blockNumber uint64) (uint64, error) {
+	gas, err := sigValidationGas(statedb.GetKey(args.From), accountkey.RoleTransaction, blockNumber)
+	if err != nil {
+		return 0, err
+	}
+	if args.FeePayer != nil {
+		if args.FeeRatio != nil {
+			gas += params.TxGasFeeDelegatedWithRatio
+		} else {
+			gas += params.TxGasFeeDelegated
+		}
+		feePayerGas, err := sigValidationGas(statedb.GetKey(*args.FeePayer), accountkey.RoleFeePayer, blockNumber)
+		if err != nil {
+			return 0, err
+		}
+		gas += feePayerGas
+	}
+	return gas, nil
+}
+
+// sigValidationGas returns the gas validating the signatures made by all keys of the role.
+func sigValidationGas(key accountkey.AccountKey, role accountkey.RoleType, blockNumber uint64) (uint64, error) {
+	roleKey := key
+	if roleBased, ok := key.(*accountkey.AccountKeyRoleBased); ok {
+		roleKey = (*roleBased)[accountkey.RoleTransaction]
+		if len(*roleBased) > int(role) {
+			roleKey = (*roleBased)[role]
+		}
+	}
+	numKeys := 1
+	if multiSig, ok := roleKey.(*accountkey.AccountKeyWeightedMultiSig); ok {
+		numKeys = len(multiSig.Keys)
+	}
+	return key.SigValidationGas(blockNumber, role, numKeys)
 }
 
 // ExecutionResult groups all structured logs emitted by the EVM
@@ -588,6 +911,9 @@ func RpcOutputBlock(b *types.Block, td *big.Int, inclTx bool, fullTx bool, rules
 		fields["randomReveal"] = hexutil.Bytes(head.RandomReveal)
 		fields["mixHash"] = hexutil.Bytes(head.MixHash)
 	}
+	if rules.IsStakingCommitment {
+		fields["stakingInfoHash"] = head.StakingInfoHash
+	}
 
 	return fields, nil
 }
@@ -668,10 +994,10 @@ func newRPCTransactionFromBlockHash(b *types.Block, hash common.Hash) map[string
 
 func (args *CallArgs) ToMessage(globalGasCap uint64, baseFee *big.Int, intrinsicGas uint64) (*types.Transaction, error) {
 	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
-		return nil, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+		return nil, errInvalidGasFee.Errorf("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
 	} else if args.MaxFeePerGas != nil && args.MaxPriorityFeePerGas != nil {
 		if args.MaxFeePerGas.ToInt().Cmp(args.MaxPriorityFeePerGas.ToInt()) < 0 {
-			return nil, errors.New("MaxPriorityFeePerGas is greater than MaxFeePerGas")
+			return nil, errInvalidGasFee.Errorf("MaxPriorityFeePerGas is greater than MaxFeePerGas")
 		}
 	}
 
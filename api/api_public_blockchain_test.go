@@ -18,13 +18,25 @@ package api
 
 import (
 	"context"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	mock_api "github.com/klaytn/klaytn/api/mocks"
 	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/fork"
+	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func testInitForKlayApi(t *testing.T) (*gomock.Controller, *mock_api.MockBackend, *PublicBlockChainAPI) {
@@ -57,6 +69,472 @@ func TestKlaytnAPI_EstimateGas(t *testing.T) {
 		if ethArgs.Value != nil {
 			args.Value = *ethArgs.Value
 		}
-		return api.EstimateGas(context.Background(), args)
+		return api.EstimateGas(context.Background(), args, nil, nil)
 	})
 }
+
+func TestKlaytnAPI_EstimateGasFeeDelegation(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForKlayApi(t)
+	defer mockCtrl.Finish()
+
+	var (
+		sender   = common.HexToAddress("0xaaaa")
+		poor     = common.HexToAddress("0xbbbb")
+		feePayer = common.HexToAddress("0xcccc")
+		KLAY     = hexutil.Big(*big.NewInt(params.KLAY))
+		price    = hexutil.Big(*big.NewInt(25 * params.Ston))
+		ratio30  = types.FeeRatio(30)
+		ratio100 = types.FeeRatio(100)
+		funded   = &KLAY
+	)
+	mockEstimateGasBackend(mockBackend, blockchain.GenesisAlloc{
+		sender:   {Balance: big.NewInt(params.KLAY)},
+		poor:     {Balance: big.NewInt(params.KLAY / 10000)},
+		feePayer: {Balance: big.NewInt(params.KLAY)},
+	})
+
+	testcases := []struct {
+		args      CallArgs
+		overrides *EthStateOverride
+		expectErr string
+		expectGas uint64
+	}{
+		{ // the fee payer pays the whole fee
+			args:      CallArgs{From: poor, To: &sender, GasPrice: &price, FeePayer: &feePayer},
+			expectGas: params.TxGas + params.TxGasFeeDelegated,
+		},
+		{ // the fee is shared in the ratio
+			args:      CallArgs{From: sender, To: &poor, GasPrice: &price, FeePayer: &feePayer, FeeRatio: &ratio30},
+			expectGas: params.TxGas + params.TxGasFeeDelegatedWithRatio,
+		},
+		{ // capped by the balance of the fee payer, allowance = 0.0001 KLAY / 25 ston = 4000 gas
+			args:      CallArgs{From: sender, To: &poor, GasPrice: &price, FeePayer: &poor},
+			expectErr: "gas required exceeds allowance",
+		},
+		{ // capped by the share of the sender, allowance = 0.0001 KLAY / (25 ston * 0.7) = 5714 gas
+			args:      CallArgs{From: poor, To: &sender, GasPrice: &price, FeePayer: &feePayer, FeeRatio: &ratio30},
+			expectErr: "gas required exceeds allowance",
+		},
+		{ // invalid fee ratio
+			args:      CallArgs{From: sender, To: &poor, FeePayer: &feePayer, FeeRatio: &ratio100},
+			expectErr: errInvalidFeeRatio.Error(),
+		},
+		{ // fee ratio without the fee payer
+			args:      CallArgs{From: sender, To: &poor, FeeRatio: &ratio30},
+			expectErr: errInvalidFeeRatio.Error(),
+		},
+		{ // insufficient funds without overrides
+			args:      CallArgs{From: poor, To: &sender, Value: KLAY},
+			expectErr: "insufficient balance for transfer",
+		},
+		{ // funded by the overrides
+			args:      CallArgs{From: poor, To: &sender, Value: KLAY, GasPrice: &price, FeePayer: &feePayer},
+			overrides: &EthStateOverride{poor: EthOverrideAccount{Balance: &funded}},
+			expectGas: params.TxGas + params.TxGasFeeDelegated,
+		},
+	}
+	for i, tc := range testcases {
+		gas, err := api.EstimateGas(context.Background(), tc.args, tc.overrides, nil)
+		if len(tc.expectErr) > 0 {
+			require.Error(t, err, i)
+			assert.Contains(t, err.Error(), tc.expectErr, i)
+		} else {
+			require.NoError(t, err, i)
+			assert.Equal(t, tc.expectGas, uint64(gas), i)
+		}
+	}
+}
+
+func TestKlaytnAPI_PreviewFee(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForKlayApi(t)
+	defer mockCtrl.Finish()
+
+	var (
+		sender   = common.HexToAddress("0xaaaa")
+		to       = common.HexToAddress("0xbbbb")
+		feePayer = common.HexToAddress("0xcccc")
+		ratio30  = types.FeeRatio(30)
+		ratio100 = types.FeeRatio(100)
+	)
+	config, genesis := mockEstimateGasBackend(mockBackend, blockchain.GenesisAlloc{
+		sender:   {Balance: big.NewInt(params.KLAY)},
+		feePayer: {Balance: big.NewInt(params.KLAY)},
+	})
+	config.SetDefaults()
+	mockBackend.EXPECT().HeaderByNumber(gomock.Any(), rpc.LatestBlockNumber).Return(genesis, nil).AnyTimes()
+	baseFee := new(big.Int).SetUint64(config.Governance.KIP71.LowerBoundBaseFee)
+
+	// the fee is paid to the proposer after burning half of it since Magma
+	preview, err := api.PreviewFee(context.Background(), CallArgs{From: sender, To: &to})
+	require.NoError(t, err)
+	totalFee := new(big.Int).Mul(big.NewInt(int64(params.TxGas)), baseFee)
+	assert.Equal(t, hexutil.Uint64(params.TxGas), preview.Gas)
+	assert.Equal(t, baseFee, preview.GasPrice.ToInt())
+	assert.Equal(t, baseFee, preview.BaseFee.ToInt())
+	assert.Equal(t, totalFee, preview.TotalFee.ToInt())
+	assert.Equal(t, new(big.Int).Div(totalFee, big.NewInt(2)), preview.BurntFee.ToInt())
+	assert.Equal(t, new(big.Int).Div(totalFee, big.NewInt(2)), preview.Proposer.ToInt())
+	assert.Equal(t, totalFee, preview.SenderFee.ToInt())
+	assert.Equal(t, int64(0), preview.FeePayerFee.ToInt().Int64())
+
+	// the fee is shared by the fee payer in the ratio
+	preview, err = api.PreviewFee(context.Background(), CallArgs{From: sender, To: &to, FeePayer: &feePayer, FeeRatio: &ratio30})
+	require.NoError(t, err)
+	totalFee = new(big.Int).Mul(big.NewInt(int64(params.TxGas+params.TxGasFeeDelegatedWithRatio)), baseFee)
+	assert.Equal(t, hexutil.Uint64(params.TxGas+params.TxGasFeeDelegatedWithRatio), preview.Gas)
+	assert.Equal(t, feePayer, *preview.FeePayer)
+	assert.Equal(t, new(big.Int).Div(new(big.Int).Mul(totalFee, big.NewInt(30)), big.NewInt(100)), preview.FeePayerFee.ToInt())
+	assert.Equal(t, totalFee, new(big.Int).Add(preview.SenderFee.ToInt(), preview.FeePayerFee.ToInt()))
+
+	// the deferred fee is split among the recipients
+	config.Governance.Reward.DeferredTxFee = true
+	preview, err = api.PreviewFee(context.Background(), CallArgs{From: sender, To: &to, FeePayer: &feePayer})
+	require.NoError(t, err)
+	totalFee = preview.TotalFee.ToInt()
+	assert.Equal(t, new(big.Int).Div(totalFee, big.NewInt(2)), preview.BurntFee.ToInt())
+	sum := new(big.Int).Add(preview.BurntFee.ToInt(), preview.Proposer.ToInt())
+	sum.Add(sum, preview.Stakers.ToInt()).Add(sum, preview.KFF.ToInt()).Add(sum, preview.KCF.ToInt())
+	assert.Equal(t, totalFee, sum)
+	assert.Equal(t, totalFee, preview.FeePayerFee.ToInt())
+	assert.Equal(t, int64(0), preview.SenderFee.ToInt().Int64())
+
+	_, err = api.PreviewFee(context.Background(), CallArgs{From: sender, To: &to, FeePayer: &feePayer, FeeRatio: &ratio100})
+	assert.Equal(t, errInvalidFeeRatio, err)
+}
+
+func TestKlaytnAPI_CallBlockOverrides(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForKlayApi(t)
+	defer mockCtrl.Finish()
+
+	var (
+		sender   = common.HexToAddress("0xaaaa")
+		blockEnv = common.HexToAddress("0xbbbb")
+		timeLock = common.HexToAddress("0xcccc")
+		latest   = rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+		number    = hexutil.Big(*big.NewInt(100)) // Kore hardfork block of the test chain
+		timestamp = hexutil.Uint64(1700000000)
+		baseFee   = hexutil.Big(*big.NewInt(50 * params.Ston))
+		random    = common.HexToHash("0x1234")
+	)
+	mockEstimateGasBackend(mockBackend, blockchain.GenesisAlloc{
+		sender: {Balance: big.NewInt(params.KLAY)},
+		// returns (NUMBER, TIMESTAMP, BASEFEE, PREVRANDAO)
+		blockEnv: {Code: hexutil.MustDecode("0x4360005242602052486040524460605260806000f3"), Balance: common.Big0},
+		// reverts if TIMESTAMP < 1700000000
+		timeLock: {Code: hexutil.MustDecode("0x42636553f10011600b57005b60006000fd"), Balance: common.Big0},
+	})
+	mockBackend.EXPECT().RPCEVMTimeout().Return(time.Duration(0)).AnyTimes()
+
+	ret, err := api.Call(context.Background(), CallArgs{From: sender, To: &blockEnv}, latest, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, ret, 128)
+	assert.Equal(t, uint64(0), new(big.Int).SetBytes(ret[:32]).Uint64())
+	assert.NotEqual(t, random, common.BytesToHash(ret[96:]))
+
+	ret, err = api.Call(context.Background(), CallArgs{From: sender, To: &blockEnv}, latest, nil,
+		&EthBlockOverrides{Number: &number, Time: &timestamp, BaseFee: &baseFee, Random: &random})
+	require.NoError(t, err)
+	require.Len(t, ret, 128)
+	assert.Equal(t, number.ToInt(), new(big.Int).SetBytes(ret[:32]))
+	assert.Equal(t, uint64(timestamp), new(big.Int).SetBytes(ret[32:64]).Uint64())
+	assert.Equal(t, baseFee.ToInt(), new(big.Int).SetBytes(ret[64:96]))
+	assert.Equal(t, random, common.BytesToHash(ret[96:]))
+
+	_, err = api.EstimateGas(context.Background(), CallArgs{From: sender, To: &timeLock}, nil, nil)
+	assert.ErrorContains(t, err, "execution reverted")
+	gas, err := api.EstimateGas(context.Background(), CallArgs{From: sender, To: &timeLock}, nil, &EthBlockOverrides{Time: &timestamp})
+	require.NoError(t, err)
+	assert.Greater(t, uint64(gas), params.TxGas)
+}
+
+func TestSigValidationGas(t *testing.T) {
+	require.NoError(t, fork.SetHardForkBlockNumberConfig(&params.ChainConfig{IstanbulCompatibleBlock: common.Big0}))
+
+	multiSig := &accountkey.AccountKeyWeightedMultiSig{Threshold: 2, Keys: make(accountkey.WeightedPublicKeys, 3)}
+	gas, err := sigValidationGas(multiSig, accountkey.RoleTransaction, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2*params.TxValidationGasPerKey, gas)
+
+	roleBased := &accountkey.AccountKeyRoleBased{
+		multiSig,
+		accountkey.NewAccountKeyLegacy(),
+		&accountkey.AccountKeyWeightedMultiSig{Threshold: 1, Keys: make(accountkey.WeightedPublicKeys, 2)},
+	}
+	gas, err = sigValidationGas(roleBased, accountkey.RoleFeePayer, 1)
+	require.NoError(t, err)
+	assert.Equal(t, params.TxValidationGasPerKey, gas)
+
+	gas, err = sigValidationGas(accountkey.NewAccountKeyLegacy(), accountkey.RoleTransaction, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), gas)
+}
+
+func TestKlaytnAPI_GetBalanceHistory(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForKlayApi(t)
+	defer mockCtrl.Finish()
+
+	db := database.NewMemoryDBManager()
+	addr := common.HexToAddress("0x1234")
+	mockBackend.EXPECT().ChainDB().Return(db).AnyTimes()
+	mockBackend.EXPECT().CurrentBlock().Return(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(20)})).AnyTimes()
+
+	// not indexed
+	_, err := api.GetBalanceHistory(context.Background(), addr, 0, 10, nil)
+	assert.Equal(t, errBalanceHistoryNotIndexed, err)
+
+	db.WriteBalanceHistoryIndexStart(2)
+	batch := db.NewBalanceHistoryBatch()
+	assert.NoError(t, db.PutBalanceChangeToBatch(batch, addr, &database.BalanceChange{Number: 4, Prev: big.NewInt(1), Balance: big.NewInt(5)}))
+	assert.NoError(t, db.PutBalanceChangeToBatch(batch, addr, &database.BalanceChange{Number: 9, Prev: big.NewInt(5), Balance: big.NewInt(3)}))
+	assert.NoError(t, db.PutBalanceChangeToBatch(batch, addr, &database.BalanceChange{Number: 15, Prev: big.NewInt(3), Balance: big.NewInt(8)}))
+	assert.NoError(t, batch.Write())
+	batch.Release()
+
+	step := hexutil.Uint64(3)
+	history, err := api.GetBalanceHistory(context.Background(), addr, 2, 12, &step)
+	assert.NoError(t, err)
+	expected := []int64{1, 5, 5, 3} // at block 2, 5, 8 and 11
+	assert.Len(t, history, len(expected))
+	for i, balance := range expected {
+		assert.Equal(t, hexutil.Uint64(2+3*i), history[i].Number)
+		assert.Equal(t, big.NewInt(balance), history[i].Balance.ToInt())
+	}
+
+	history, err = api.GetBalanceHistory(context.Background(), addr, 15, rpc.LatestBlockNumber, &step)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, big.NewInt(8), history[1].Balance.ToInt())
+
+	// invalid ranges
+	_, err = api.GetBalanceHistory(context.Background(), addr, 1, 10, nil)
+	assert.Error(t, err)
+	_, err = api.GetBalanceHistory(context.Background(), addr, 10, 5, nil)
+	assert.Equal(t, errInvalidBalanceHistoryRange, err)
+	_, err = api.GetBalanceHistory(context.Background(), addr, 10, 21, nil)
+	assert.Equal(t, errInvalidBalanceHistoryRange, err)
+}
+
+func TestKlaytnAPI_GetAccumulatedRewards(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForKlayApi(t)
+	defer mockCtrl.Finish()
+
+	db := database.NewMemoryDBManager()
+	addr := common.HexToAddress("0x1234")
+	mockBackend.EXPECT().ChainDB().Return(db).AnyTimes()
+
+	// not indexed
+	_, err := api.GetAccumulatedRewards(addr, 0, 10)
+	assert.Equal(t, errRewardsNotIndexed, err)
+
+	db.WriteRewardIndexStart(2)
+	db.WriteRewardIndexHead(20)
+	batch := db.NewRewardIndexBatch()
+	assert.NoError(t, db.PutAccumulatedRewardToBatch(batch, addr, &database.AccumulatedReward{Number: 4, Minted: big.NewInt(10), Fee: big.NewInt(1)}))
+	assert.NoError(t, db.PutAccumulatedRewardToBatch(batch, addr, &database.AccumulatedReward{Number: 9, Minted: big.NewInt(20), Fee: big.NewInt(3)}))
+	assert.NoError(t, db.PutAccumulatedRewardToBatch(batch, addr, &database.AccumulatedReward{Number: 15, Minted: big.NewInt(30), Fee: big.NewInt(3)}))
+	assert.NoError(t, batch.Write())
+	batch.Release()
+
+	testcases := []struct {
+		from, to     rpc.BlockNumber
+		minted, fee  int64
+		resolvedFrom uint64
+		resolvedTo   uint64
+	}{
+		{2, 20, 30, 3, 2, 20},
+		{2, 3, 0, 0, 2, 3},
+		{4, 4, 10, 1, 4, 4},
+		{5, 14, 10, 2, 5, 14},
+		{5, rpc.LatestBlockNumber, 20, 2, 5, 20},
+		{16, 20, 0, 0, 16, 20},
+	}
+	for _, tc := range testcases {
+		rewards, err := api.GetAccumulatedRewards(addr, tc.from, tc.to)
+		require.NoError(t, err)
+		assert.Equal(t, hexutil.Uint64(tc.resolvedFrom), rewards.FromBlock)
+		assert.Equal(t, hexutil.Uint64(tc.resolvedTo), rewards.ToBlock)
+		assert.Equal(t, tc.minted, rewards.Minted.ToInt().Int64(), tc)
+		assert.Equal(t, tc.fee, rewards.Fee.ToInt().Int64(), tc)
+		assert.Equal(t, tc.minted+tc.fee, rewards.Total.ToInt().Int64(), tc)
+	}
+
+	// invalid ranges
+	_, err = api.GetAccumulatedRewards(addr, 1, 10)
+	assert.Error(t, err)
+	_, err = api.GetAccumulatedRewards(addr, 10, 5)
+	assert.Error(t, err)
+	_, err = api.GetAccumulatedRewards(addr, 10, 21)
+	assert.Error(t, err)
+}
+
+func TestKlaytnAPI_GetStakerRewards(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForKlayApi(t)
+	defer mockCtrl.Finish()
+
+	db := database.NewMemoryDBManager()
+	addr := common.HexToAddress("0x1234")
+	mockBackend.EXPECT().ChainDB().Return(db).AnyTimes()
+	config := params.TestChainConfig.Copy()
+	config.Istanbul = &params.IstanbulConfig{Epoch: 10}
+	mockBackend.EXPECT().ChainConfig().Return(config).AnyTimes()
+	// blocks 0-5 on day 0, 6-7 on day 2 and 8-20 on day 3
+	mockBackend.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+		day := int64(0)
+		switch {
+		case number >= 8:
+			day = 3
+		case number >= 6:
+			day = 2
+		}
+		return &types.Header{Number: big.NewInt(number.Int64()), Time: big.NewInt(day*secondsPerDay + number.Int64())}, nil
+	}).AnyTimes()
+
+	// not indexed
+	_, err := api.GetStakerRewards(context.Background(), addr, 0, 10, StakerRewardsWindowEpoch)
+	assert.Equal(t, errRewardsNotIndexed, err)
+
+	db.WriteRewardIndexStart(1)
+	db.WriteRewardIndexHead(20)
+	batch := db.NewRewardIndexBatch()
+	assert.NoError(t, db.PutAccumulatedRewardToBatch(batch, addr, &database.AccumulatedReward{Number: 4, Minted: big.NewInt(10), Fee: big.NewInt(1)}))
+	assert.NoError(t, db.PutAccumulatedRewardToBatch(batch, addr, &database.AccumulatedReward{Number: 7, Minted: big.NewInt(20), Fee: big.NewInt(3)}))
+	assert.NoError(t, db.PutAccumulatedRewardToBatch(batch, addr, &database.AccumulatedReward{Number: 15, Minted: big.NewInt(30), Fee: big.NewInt(3)}))
+	assert.NoError(t, batch.Write())
+	batch.Release()
+
+	type window struct {
+		from, to uint64
+		total    int64
+	}
+	check := func(rewards *StakerRewards, expected []window) {
+		require.Equal(t, len(expected), len(rewards.Windows))
+		for i, w := range expected {
+			assert.Equal(t, hexutil.Uint64(w.from), rewards.Windows[i].FromBlock, i)
+			assert.Equal(t, hexutil.Uint64(w.to), rewards.Windows[i].ToBlock, i)
+			assert.Equal(t, w.total, rewards.Windows[i].Total.ToInt().Int64(), i)
+		}
+	}
+
+	rewards, err := api.GetStakerRewards(context.Background(), addr, 1, rpc.LatestBlockNumber, StakerRewardsWindowEpoch)
+	require.NoError(t, err)
+	check(rewards, []window{{1, 9, 23}, {10, 19, 10}, {20, 20, 0}})
+	assert.Equal(t, hexutil.Uint64(1), *rewards.Windows[1].Epoch)
+	assert.Equal(t, int64(33), rewards.Total.ToInt().Int64())
+
+	rewards, err = api.GetStakerRewards(context.Background(), addr, 3, 20, StakerRewardsWindowDay)
+	require.NoError(t, err)
+	check(rewards, []window{{3, 5, 11}, {6, 7, 12}, {8, 20, 10}})
+	assert.Equal(t, "1970-01-01", rewards.Windows[0].Day)
+	assert.Equal(t, "1970-01-03", rewards.Windows[1].Day)
+	assert.Equal(t, "1970-01-04", rewards.Windows[2].Day)
+
+	_, err = api.GetStakerRewards(context.Background(), addr, 1, 10, "week")
+	assert.Equal(t, errInvalidStakerRewardsWindow, err)
+	_, err = api.GetStakerRewards(context.Background(), addr, 0, 10, StakerRewardsWindowDay)
+	assert.Error(t, err)
+}
+
+func TestKlaytnAPI_GetEpochSummary(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForKlayApi(t)
+	defer mockCtrl.Finish()
+
+	db := database.NewMemoryDBManager()
+	mockBackend.EXPECT().ChainDB().Return(db).AnyTimes()
+
+	_, err := api.GetEpochSummary(1)
+	assert.Error(t, err)
+
+	validator := common.HexToAddress("0x1234")
+	db.WriteEpochSummary(&database.EpochSummary{
+		Epoch:      1,
+		StartBlock: 30,
+		EndBlock:   59,
+		Completed:  true,
+		Params:     map[string]interface{}{"istanbul.epoch": float64(30)},
+		Validators: []common.Address{validator},
+		Minted:     big.NewInt(300),
+		TotalFee:   big.NewInt(20),
+		Rewards:    big.NewInt(310),
+		Burnt:      big.NewInt(10),
+	})
+	summary, err := api.GetEpochSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.Uint64(30), summary.StartBlock)
+	assert.Equal(t, hexutil.Uint64(59), summary.EndBlock)
+	assert.True(t, summary.Completed)
+	assert.Equal(t, []common.Address{validator}, summary.Validators)
+	assert.Equal(t, float64(30), summary.Params["istanbul.epoch"])
+	assert.Equal(t, big.NewInt(310), summary.Rewards.ToInt())
+	assert.Equal(t, big.NewInt(10), summary.Burnt.ToInt())
+}
+
+func TestKlaytnAPI_GetAccountInfo(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForKlayApi(t)
+	defer mockCtrl.Finish()
+
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	require.NoError(t, err)
+	header := &types.Header{Number: big.NewInt(1)}
+	mockBackend.EXPECT().StateAndHeaderByNumberOrHash(gomock.Any(), gomock.Any()).Return(statedb, header, nil).AnyTimes()
+	latest := rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	k1, _ := crypto.GenerateKey()
+	k2, _ := crypto.GenerateKey()
+	k3, _ := crypto.GenerateKey()
+	eoa, sca := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	statedb.CreateEOA(eoa, false, accountkey.NewAccountKeyRoleBasedWithValues([]accountkey.AccountKey{
+		accountkey.NewAccountKeyPublicWithValue(&k1.PublicKey),
+		accountkey.NewAccountKeyWeightedMultiSigWithValues(2, accountkey.WeightedPublicKeys{
+			accountkey.NewWeightedPublicKey(1, (*accountkey.PublicKeySerializable)(&k2.PublicKey)),
+			accountkey.NewWeightedPublicKey(1, (*accountkey.PublicKeySerializable)(&k3.PublicKey)),
+		}),
+	}))
+	statedb.SetNonce(eoa, 3)
+	statedb.AddBalance(eoa, big.NewInt(100))
+	statedb.CreateSmartContractAccount(sca, params.CodeFormatEVM, params.TestChainConfig.Rules(header.Number))
+	require.NoError(t, statedb.SetCode(sca, []byte{0x60, 0x00}))
+
+	// an EOA with a role-based key whose fee payer role is not set
+	info, err := api.GetAccountInfo(context.Background(), eoa, latest)
+	require.NoError(t, err)
+	assert.True(t, info.Exists)
+	assert.Equal(t, "EOA", info.Type)
+	assert.Equal(t, hexutil.Uint64(3), info.Nonce)
+	assert.Equal(t, int64(100), info.Balance.ToInt().Int64())
+	assert.Nil(t, info.StorageRoot)
+	assert.Equal(t, accountkey.AccountKeyTypeRoleBased, info.Key.KeyType)
+	require.Len(t, info.Key.Roles, 3)
+
+	txKey := info.Key.Roles[0]
+	assert.Equal(t, "transaction", txKey.Role)
+	assert.Equal(t, crypto.PubkeyToAddress(k1.PublicKey), *txKey.Key.Address)
+	assert.Equal(t, hexutil.Bytes(crypto.CompressPubkey(&k1.PublicKey)), txKey.Key.PublicKey)
+
+	updateKey := info.Key.Roles[1]
+	assert.Equal(t, "accountUpdate", updateKey.Role)
+	assert.False(t, updateKey.Default)
+	assert.Equal(t, hexutil.Uint(2), *updateKey.Key.Threshold)
+	require.Len(t, updateKey.Key.Keys, 2)
+	assert.Equal(t, crypto.PubkeyToAddress(k3.PublicKey), updateKey.Key.Keys[1].Address)
+
+	feePayerKey := info.Key.Roles[2]
+	assert.Equal(t, "feePayer", feePayerKey.Role)
+	assert.True(t, feePayerKey.Default)
+	assert.Equal(t, txKey.Key, feePayerKey.Key)
+	assert.Contains(t, info.Key.Summary, "threshold 2 of total weight 2 by 2 keys")
+
+	// a smart contract account
+	info, err = api.GetAccountInfo(context.Background(), sca, latest)
+	require.NoError(t, err)
+	assert.Equal(t, "SCA", info.Type)
+	assert.Equal(t, hexutil.Bytes(crypto.Keccak256([]byte{0x60, 0x00})), info.CodeHash)
+	assert.NotNil(t, info.StorageRoot)
+
+	// an address without an account
+	info, err = api.GetAccountInfo(context.Background(), common.HexToAddress("0x3"), latest)
+	require.NoError(t, err)
+	assert.False(t, info.Exists)
+	assert.Equal(t, "EOA", info.Type)
+	assert.Equal(t, accountkey.AccountKeyTypeLegacy, info.Key.KeyType)
+}
@@ -18,7 +18,6 @@ package api
 
 import (
 	"context"
-	"errors"
 	"strings"
 
 	"github.com/klaytn/klaytn/accounts/abi"
@@ -32,7 +31,7 @@ const cypressCreditABI = "[{\"constant\":true,\"inputs\":[],\"name\":\"getPhoto\
 // CypressCredit contract is stored in the address zero.
 var cypressCreditContractAddress = common.HexToAddress("0x0000000000000000000000000000000000000000")
 
-var errNoCypressCreditContract = errors.New("no cypress credit contract")
+var errNoCypressCreditContract = rpc.NewAPIError(rpc.CodeResourceUnavailable, "noCypressCreditContract", "no cypress credit contract")
 
 type CreditOutput struct {
 	Photo string `json:"photo"`
@@ -50,7 +49,7 @@ func (s *PublicBlockChainAPI) callCypressCreditGetFunc(ctx context.Context, pars
 		To:   &cypressCreditContractAddress,
 		Data: abiGet,
 	}
-	ret, err := s.Call(ctx, args, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	ret, err := s.Call(ctx, args, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil, nil)
 	if err != nil {
 		return nil, err
 	}
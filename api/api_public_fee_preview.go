@@ -0,0 +1,129 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/misc"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+)
+
+// FeePreview is the expected fee of a transaction and its breakdown returned by PreviewFee.
+type FeePreview struct {
+	Gas      hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big   `json:"gasPrice"`          // Price per gas charged in the next block
+	BaseFee  *hexutil.Big   `json:"baseFee,omitempty"` // Base fee of the next block since Magma, which is the whole gas price
+	TotalFee *hexutil.Big   `json:"totalFee"`
+
+	// Breakdown of the total fee, as if the transaction is the only one in the block.
+	BurntFee *hexutil.Big                    `json:"burntFee"`
+	Proposer *hexutil.Big                    `json:"proposer"`
+	Stakers  *hexutil.Big                    `json:"stakers"`
+	KFF      *hexutil.Big                    `json:"kff"`
+	KCF      *hexutil.Big                    `json:"kcf"`
+	Rewards  map[common.Address]*hexutil.Big `json:"rewards,omitempty"` // Deferred fee rewards by recipient
+
+	// Payment of the total fee by the sender and the fee payer.
+	SenderFee   *hexutil.Big    `json:"senderFee"`
+	FeePayer    *common.Address `json:"feePayer,omitempty"`
+	FeePayerFee *hexutil.Big    `json:"feePayerFee"`
+}
+
+// PreviewFee returns the expected gas and fee of a transaction not sent yet and the breakdown of
+// the fee under the rules of the next block: the burnt fee, the portions rewarded to the
+// proposer, the stakers, KFF and KCF, and the portions paid by the sender and the fee payer.
+func (s *PublicBlockChainAPI) PreviewFee(ctx context.Context, args CallArgs) (*FeePreview, error) {
+	if args.FeeRatio != nil && (args.FeePayer == nil || !args.FeeRatio.IsValid()) {
+		return nil, errInvalidFeeRatio
+	}
+	gasCap := uint64(0)
+	if rpcGasCap := s.b.RPCGasCap(); rpcGasCap != nil {
+		gasCap = rpcGasCap.Uint64()
+	}
+	gas, err := s.DoEstimateGas(ctx, s.b, args, new(big.Int).SetUint64(gasCap), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.b.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	config := s.b.ChainConfig()
+	header := &types.Header{
+		ParentHash: latest.Hash(),
+		Number:     new(big.Int).Add(latest.Number, common.Big1),
+		GasUsed:    uint64(gas),
+		Rewardbase: latest.Rewardbase,
+	}
+	rules := config.Rules(header.Number)
+	if rules.IsMagma {
+		header.BaseFee = misc.NextMagmaBlockBaseFee(latest, config.Governance.KIP71)
+	}
+	pset, err := params.NewGovParamSetChainConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	var stakingInfo *reward.StakingInfo
+	if reward.GetRewardPolicy(header.Number, pset).UseStakingInfo() && reward.GetStakingManager() != nil {
+		stakingInfo = reward.GetStakingInfo(header.Number.Uint64())
+	}
+	split, err := reward.CalcTxFeeSplit(header, rules, pset, stakingInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &FeePreview{
+		Gas:         gas,
+		GasPrice:    (*hexutil.Big)(new(big.Int).SetUint64(config.UnitPrice)),
+		TotalFee:    (*hexutil.Big)(split.TotalFee),
+		BurntFee:    (*hexutil.Big)(split.BurntFee),
+		Proposer:    (*hexutil.Big)(split.Proposer),
+		Stakers:     (*hexutil.Big)(split.Stakers),
+		KFF:         (*hexutil.Big)(split.KFF),
+		KCF:         (*hexutil.Big)(split.KCF),
+		SenderFee:   (*hexutil.Big)(split.TotalFee),
+		FeePayer:    args.FeePayer,
+		FeePayerFee: (*hexutil.Big)(new(big.Int)),
+	}
+	if header.BaseFee != nil {
+		preview.GasPrice = (*hexutil.Big)(header.BaseFee)
+		preview.BaseFee = (*hexutil.Big)(header.BaseFee)
+	}
+	if len(split.Rewards) > 0 {
+		preview.Rewards = make(map[common.Address]*hexutil.Big, len(split.Rewards))
+		for addr, amount := range split.Rewards {
+			preview.Rewards[addr] = (*hexutil.Big)(amount)
+		}
+	}
+	if args.FeePayer != nil {
+		if args.FeeRatio != nil {
+			feePayerFee, senderFee := types.CalcFeeWithRatio(*args.FeeRatio, split.TotalFee)
+			preview.FeePayerFee, preview.SenderFee = (*hexutil.Big)(feePayerFee), (*hexutil.Big)(senderFee)
+		} else {
+			preview.FeePayerFee, preview.SenderFee = (*hexutil.Big)(split.TotalFee), (*hexutil.Big)(new(big.Int))
+		}
+	}
+	return preview, nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+var (
+	errNotMultiSigAccount   = rpc.NewAPIError(rpc.CodeInvalidInput, "notMultiSigAccount", "the sender does not have a weighted multisig key for the transaction")
+	errMultiSigNotSatisfied = rpc.NewAPIError(rpc.CodeInvalidInput, "multiSigNotSatisfied", "the signatures do not satisfy the threshold of the sender")
+	errTooManySignatures    = errTooManyItems.Errorf("the number of signatures exceeds %d", accountkey.MaxNumKeysForMultiSig).
+				WithDetail("maxItems", accountkey.MaxNumKeysForMultiSig)
+)
+
+// MultiSigSigner is a key of the multisig account which signed the transaction.
+type MultiSigSigner struct {
+	Index  hexutil.Uint   `json:"index"` // Index of the key in the account key
+	Weight hexutil.Uint   `json:"weight"`
+	Signer common.Address `json:"signer"` // Address derived from the key
+}
+
+// MultiSigStatus is the weight of the signatures of a transaction gathered for the
+// weighted multisig key of the sender.
+type MultiSigStatus struct {
+	From      common.Address   `json:"from"`
+	Role      hexutil.Uint     `json:"role"` // Role of the key validating the transaction; 0 for transaction, 1 for account update
+	Threshold hexutil.Uint     `json:"threshold"`
+	Weight    hexutil.Uint     `json:"weight"`
+	Signers   []MultiSigSigner `json:"signers"`
+	Unknown   hexutil.Uint     `json:"unknown"` // Signatures by the keys not in the account key, which invalidate the transaction
+	Satisfied bool             `json:"satisfied"`
+}
+
+// MultiSigTransactionResult is a transaction with the collected signatures.
+type MultiSigTransactionResult struct {
+	Raw    hexutil.Bytes      `json:"raw"`
+	Tx     *types.Transaction `json:"tx"`
+	Status *MultiSigStatus    `json:"status"`
+}
+
+// AppendSignature appends the signatures to the transaction signed by some keys of a
+// weighted multisig account, and returns the transaction with the weight of the signatures
+// collected so far. The signatures already in the transaction are ignored, so the partial
+// signatures from the key holders can be appended in any order.
+func (s *PublicTransactionPoolAPI) AppendSignature(ctx context.Context, encodedTx hexutil.Bytes, sigs types.TxSignaturesJSON) (*MultiSigTransactionResult, error) {
+	tx, err := decodeKlaytnTx(encodedTx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := s.multiSigKey(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := types.LatestSignerForChainID(s.b.ChainConfig().ChainID)
+	merged := make(types.TxSignatures, 0, len(tx.RawSignatureValues())+len(sigs))
+	seen := make(map[common.Address]bool)
+	for _, sig := range append(tx.RawSignatureValues(), sigs.ToTxSignatures()...) {
+		if sig == nil || sig.R == nil || sig.S == nil || (sig.R.Sign() == 0 && sig.S.Sign() == 0) {
+			continue // the empty signature of an unsigned transaction
+		}
+		pubkey, err := recoverSignerKey(signer, tx, sig)
+		if err != nil {
+			return nil, err
+		}
+		if addr := crypto.PubkeyToAddress(*pubkey); !seen[addr] {
+			seen[addr] = true
+			merged = append(merged, sig)
+		}
+	}
+	if uint64(len(merged)) > accountkey.MaxNumKeysForMultiSig {
+		return nil, errTooManySignatures
+	}
+	tx.SetSignature(merged)
+
+	// Decode the encoded transaction again not to return the caches of the original signatures
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return nil, err
+	}
+	if tx, err = decodeKlaytnTx(raw); err != nil {
+		return nil, err
+	}
+	status, err := multiSigStatus(signer, tx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &MultiSigTransactionResult{Raw: raw, Tx: tx, Status: status}, nil
+}
+
+// GetSignatureWeight returns the weight of the signatures of the transaction for the
+// weighted multisig key of the sender.
+func (s *PublicTransactionPoolAPI) GetSignatureWeight(ctx context.Context, encodedTx hexutil.Bytes) (*MultiSigStatus, error) {
+	tx, err := decodeKlaytnTx(encodedTx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := s.multiSigKey(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return multiSigStatus(types.LatestSignerForChainID(s.b.ChainConfig().ChainID), tx, key)
+}
+
+// SendMultiSigTransaction submits the transaction if its signatures satisfy the threshold
+// of the weighted multisig key of the sender.
+func (s *PublicTransactionPoolAPI) SendMultiSigTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
+	status, err := s.GetSignatureWeight(ctx, encodedTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if !status.Satisfied {
+		return common.Hash{}, errMultiSigNotSatisfied.Errorf("%s: weight %d, threshold %d, unknown signatures %d",
+			errMultiSigNotSatisfied, status.Weight, status.Threshold, status.Unknown).
+			WithDetail("weight", status.Weight).WithDetail("threshold", status.Threshold)
+	}
+	return s.SendRawTransaction(ctx, encodedTx)
+}
+
+// decodeKlaytnTx decodes a Klaytn transaction, which has the sender and the multiple signatures.
+func decodeKlaytnTx(encodedTx hexutil.Bytes) (*types.Transaction, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		return nil, err
+	}
+	if tx.IsEthereumTransaction() {
+		return nil, fmt.Errorf("%v is not a Klaytn transaction type", tx.Type())
+	}
+	return tx, nil
+}
+
+// multiSigKey returns the key of the sender used to validate the transaction at the latest block.
+func (s *PublicTransactionPoolAPI) multiSigKey(ctx context.Context, tx *types.Transaction) (*accountkey.AccountKeyWeightedMultiSig, error) {
+	from, err := tx.From()
+	if err != nil {
+		return nil, err
+	}
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	key := state.GetKey(from)
+	if roleBased, ok := key.(*accountkey.AccountKeyRoleBased); ok {
+		role := tx.GetRoleTypeForValidation()
+		if int(role) >= len(*roleBased) {
+			role = accountkey.RoleTransaction
+		}
+		key = (*roleBased)[role]
+	}
+	multiSig, ok := key.(*accountkey.AccountKeyWeightedMultiSig)
+	if !ok {
+		return nil, errNotMultiSigAccount
+	}
+	return multiSig, nil
+}
+
+// recoverSignerKey recovers the public key of a signature of the transaction.
+func recoverSignerKey(signer types.Signer, tx *types.Transaction, sig *types.TxSignature) (*ecdsa.PublicKey, error) {
+	orig := tx.RawSignatureValues()
+	defer tx.SetSignature(orig)
+
+	tx.SetSignature(types.TxSignatures{sig})
+	pubkeys, err := signer.SenderPubkey(tx)
+	if err != nil {
+		return nil, err
+	}
+	return pubkeys[0], nil
+}
+
+// multiSigStatus sums the weights of the keys which signed the transaction.
+func multiSigStatus(signer types.Signer, tx *types.Transaction, key *accountkey.AccountKeyWeightedMultiSig) (*MultiSigStatus, error) {
+	from, _ := tx.From()
+	status := &MultiSigStatus{
+		From:      from,
+		Role:      hexutil.Uint(tx.GetRoleTypeForValidation()),
+		Threshold: hexutil.Uint(key.Threshold),
+		Signers:   []MultiSigSigner{},
+	}
+
+	pubkeys, err := signer.SenderPubkey(tx)
+	if err != nil {
+		return nil, err
+	}
+	signed := make(map[common.Address]bool, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		signed[crypto.PubkeyToAddress(*pubkey)] = true
+	}
+	for i, k := range key.Keys {
+		addr := crypto.PubkeyToAddress(*(*ecdsa.PublicKey)(k.Key))
+		if signed[addr] {
+			delete(signed, addr)
+			status.Weight += hexutil.Uint(k.Weight)
+			status.Signers = append(status.Signers, MultiSigSigner{Index: hexutil.Uint(i), Weight: hexutil.Uint(k.Weight), Signer: addr})
+		}
+	}
+	status.Unknown = hexutil.Uint(len(signed))
+	status.Satisfied = status.Weight >= status.Threshold && status.Unknown == 0
+	return status, nil
+}
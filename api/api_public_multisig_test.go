@@ -0,0 +1,126 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiSigTransaction(t *testing.T) {
+	chainConf := &params.ChainConfig{ChainID: big.NewInt(1)}
+	signer := types.LatestSignerForChainID(chainConf.ChainID)
+
+	var keys []*ecdsa.PrivateKey
+	var weighted accountkey.WeightedPublicKeys
+	for _, weight := range []uint{1, 1, 2} {
+		key, _ := crypto.GenerateKey()
+		keys = append(keys, key)
+		weighted = append(weighted, accountkey.NewWeightedPublicKey(weight, (*accountkey.PublicKeySerializable)(&key.PublicKey)))
+	}
+	stranger, _ := crypto.GenerateKey()
+	from, legacy := common.HexToAddress("0x1000"), common.HexToAddress("0x2000")
+
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	require.NoError(t, err)
+	statedb.CreateEOA(from, true, accountkey.NewAccountKeyWeightedMultiSigWithValues(2, weighted))
+	statedb.CreateEOA(legacy, false, accountkey.NewAccountKeyLegacy())
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().ChainConfig().Return(chainConf).AnyTimes()
+	mockBackend.EXPECT().StateAndHeaderByNumber(gomock.Any(), rpc.LatestBlockNumber).Return(statedb, nil, nil).AnyTimes()
+	api := PublicTransactionPoolAPI{b: mockBackend, nonceLock: new(AddrLocker)}
+
+	// signed returns the transaction of the sender signed by the keys
+	signed := func(from common.Address, keys ...*ecdsa.PrivateKey) hexutil.Bytes {
+		tx, err := types.NewTransactionWithMap(types.TxTypeValueTransfer, map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyNonce:    uint64(0),
+			types.TxValueKeyFrom:     from,
+			types.TxValueKeyTo:       common.HexToAddress("0x3000"),
+			types.TxValueKeyAmount:   big.NewInt(1),
+			types.TxValueKeyGasLimit: uint64(100000),
+			types.TxValueKeyGasPrice: big.NewInt(25 * params.Ston),
+		})
+		require.NoError(t, err)
+		require.NoError(t, tx.SignWithKeys(signer, keys))
+		raw, err := rlp.EncodeToBytes(tx)
+		require.NoError(t, err)
+		return raw
+	}
+	sigs := func(key *ecdsa.PrivateKey) types.TxSignaturesJSON {
+		tx, err := decodeKlaytnTx(signed(from, key))
+		require.NoError(t, err)
+		return tx.RawSignatureValues().ToJSON()
+	}
+	ctx := context.Background()
+
+	// a key of the weight 1 does not satisfy the threshold 2
+	res, err := api.AppendSignature(ctx, signed(from, keys[0]), nil)
+	require.NoError(t, err)
+	assert.Len(t, res.Tx.RawSignatureValues(), 1)
+	assert.Equal(t, hexutil.Uint(1), res.Status.Weight)
+	assert.False(t, res.Status.Satisfied)
+	_, err = api.SendMultiSigTransaction(ctx, res.Raw)
+	assert.True(t, errors.Is(err, errMultiSigNotSatisfied), err)
+
+	// the duplicated signature is ignored
+	res, err = api.AppendSignature(ctx, res.Raw, append(sigs(keys[0]), sigs(keys[1])...))
+	require.NoError(t, err)
+	assert.Len(t, res.Tx.RawSignatureValues(), 2)
+	assert.Equal(t, hexutil.Uint(2), res.Status.Weight)
+	assert.Equal(t, []hexutil.Uint{0, 1}, []hexutil.Uint{res.Status.Signers[0].Index, res.Status.Signers[1].Index})
+	assert.True(t, res.Status.Satisfied)
+
+	mockBackend.EXPECT().SendTx(ctx, gomock.Any()).Return(nil).Times(1)
+	hash, err := api.SendMultiSigTransaction(ctx, res.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, res.Tx.Hash(), hash)
+
+	// a signature by a key not in the account key invalidates the transaction
+	res, err = api.AppendSignature(ctx, res.Raw, sigs(stranger))
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Uint(1), res.Status.Unknown)
+	assert.False(t, res.Status.Satisfied)
+
+	status, err := api.GetSignatureWeight(ctx, signed(from, keys[2]))
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Uint(2), status.Weight)
+	assert.True(t, status.Satisfied)
+
+	_, err = api.GetSignatureWeight(ctx, signed(legacy, keys[0]))
+	assert.Equal(t, errNotMultiSigAccount, err)
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// The aggregation windows of GetStakerRewards.
+const (
+	StakerRewardsWindowDay   = "day"   // a UTC calendar day by the block timestamps
+	StakerRewardsWindowEpoch = "epoch" // a governance epoch of istanbul.epoch blocks
+)
+
+// maxStakerRewardsWindows is the maximum number of the windows returned by GetStakerRewards.
+const maxStakerRewardsWindows = 1000
+
+const secondsPerDay = 24 * 60 * 60
+
+var errInvalidStakerRewardsWindow = rpc.NewAPIError(rpc.CodeInvalidParams, "invalidWindow",
+	"window should be either day or epoch").WithDetail("windows", []string{StakerRewardsWindowDay, StakerRewardsWindowEpoch})
+
+// StakerRewardsWindow is the rewards paid to an address in an aggregation window. The first and
+// the last windows are clipped by the requested block range.
+type StakerRewardsWindow struct {
+	Day       string          `json:"day,omitempty"`   // the UTC date of the day window, e.g. 2023-01-02
+	Epoch     *hexutil.Uint64 `json:"epoch,omitempty"` // the number of the epoch window
+	FromBlock hexutil.Uint64  `json:"fromBlock"`
+	ToBlock   hexutil.Uint64  `json:"toBlock"`
+	Minted    *hexutil.Big    `json:"minted"` // rewards from the minted amount
+	Fee       *hexutil.Big    `json:"fee"`    // rewards from the tx fees
+	Total     *hexutil.Big    `json:"total"`
+}
+
+// StakerRewards is the rewards paid to an address in a block range aggregated per window.
+type StakerRewards struct {
+	Address   common.Address         `json:"address"`
+	FromBlock hexutil.Uint64         `json:"fromBlock"`
+	ToBlock   hexutil.Uint64         `json:"toBlock"`
+	Window    string                 `json:"window"`
+	Windows   []*StakerRewardsWindow `json:"windows"`
+	Total     *hexutil.Big           `json:"total"`
+}
+
+// GetStakerRewards returns the rewards paid to the given reward address in the block range
+// [fromBlock, toBlock] aggregated per day or per epoch. Like GetAccumulatedRewards, it is served
+// from the reward index, so the range should be within the blocks indexed.
+func (s *PublicBlockChainAPI) GetStakerRewards(ctx context.Context, rewardAddr common.Address, fromBlock, toBlock rpc.BlockNumber, window string) (*StakerRewards, error) {
+	db := s.b.ChainDB()
+	indexStart, ok := db.ReadRewardIndexStart()
+	if !ok {
+		return nil, errRewardsNotIndexed
+	}
+	indexHead, _ := db.ReadRewardIndexHead()
+
+	from, to := resolveHistoryBlockNumber(fromBlock, indexHead), resolveHistoryBlockNumber(toBlock, indexHead)
+	if from > to {
+		return nil, errInvalidBlockRange.Errorf("invalid block range of the rewards")
+	}
+	if from < indexStart || to > indexHead {
+		return nil, errBlockNotIndexed.Errorf("rewards are indexed from block %d to %d", indexStart, indexHead).
+			WithDetail("indexStart", indexStart).WithDetail("indexHead", indexHead)
+	}
+
+	var (
+		windows []*StakerRewardsWindow
+		err     error
+	)
+	switch window {
+	case StakerRewardsWindowDay:
+		windows, err = s.dayWindows(ctx, from, to)
+	case StakerRewardsWindowEpoch:
+		windows, err = s.epochWindows(from, to)
+	default:
+		return nil, errInvalidStakerRewardsWindow
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	total := new(big.Int)
+	for _, w := range windows {
+		minted, fee := accumulatedRewardsIn(db, rewardAddr, uint64(w.FromBlock), uint64(w.ToBlock))
+		w.Minted, w.Fee = (*hexutil.Big)(minted), (*hexutil.Big)(fee)
+		w.Total = (*hexutil.Big)(new(big.Int).Add(minted, fee))
+		total.Add(total, w.Total.ToInt())
+	}
+	return &StakerRewards{
+		Address:   rewardAddr,
+		FromBlock: hexutil.Uint64(from),
+		ToBlock:   hexutil.Uint64(to),
+		Window:    window,
+		Windows:   windows,
+		Total:     (*hexutil.Big)(total),
+	}, nil
+}
+
+// epochWindows splits the block range [from, to] by the epochs.
+func (s *PublicBlockChainAPI) epochWindows(from, to uint64) ([]*StakerRewardsWindow, error) {
+	config := s.b.ChainConfig()
+	if config.Istanbul == nil || config.Istanbul.Epoch == 0 {
+		return nil, errInvalidStakerRewardsWindow.Errorf("the chain has no epoch")
+	}
+	epochSize := config.Istanbul.Epoch
+	if to/epochSize-from/epochSize+1 > maxStakerRewardsWindows {
+		return nil, errTooManyItems.Errorf("too many windows are requested, the maximum is %d", maxStakerRewardsWindows).WithDetail("maxItems", maxStakerRewardsWindows)
+	}
+
+	var windows []*StakerRewardsWindow
+	for start := from; start <= to; {
+		epoch := start / epochSize
+		end := (epoch+1)*epochSize - 1
+		if end > to {
+			end = to
+		}
+		windows = append(windows, &StakerRewardsWindow{
+			Epoch:     (*hexutil.Uint64)(&epoch),
+			FromBlock: hexutil.Uint64(start),
+			ToBlock:   hexutil.Uint64(end),
+		})
+		start = end + 1
+	}
+	return windows, nil
+}
+
+// dayWindows splits the block range [from, to] by the UTC days of the block timestamps.
+// The first block of each day is searched by bisection, as the block interval varies.
+func (s *PublicBlockChainAPI) dayWindows(ctx context.Context, from, to uint64) ([]*StakerRewardsWindow, error) {
+	blockTime := func(number uint64) (uint64, error) {
+		header, err := s.b.HeaderByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return 0, err
+		}
+		if header == nil {
+			return 0, errBlockNotFound.WithDetail("blockNumber", number)
+		}
+		return header.Time.Uint64(), nil
+	}
+	fromTime, err := blockTime(from)
+	if err != nil {
+		return nil, err
+	}
+	toTime, err := blockTime(to)
+	if err != nil {
+		return nil, err
+	}
+	if toTime/secondsPerDay-fromTime/secondsPerDay+1 > maxStakerRewardsWindows {
+		return nil, errTooManyItems.Errorf("too many windows are requested, the maximum is %d", maxStakerRewardsWindows).WithDetail("maxItems", maxStakerRewardsWindows)
+	}
+
+	var windows []*StakerRewardsWindow
+	start := from
+	for day := fromTime / secondsPerDay; day <= toTime/secondsPerDay; day++ {
+		// the first block of the next day in [start, to+1], which is start if the day has no block
+		next := to + 1
+		if day < toTime/secondsPerDay {
+			var searchErr error
+			next = start + uint64(sort.Search(int(to-start+1), func(i int) bool {
+				t, err := blockTime(start + uint64(i))
+				if err != nil {
+					searchErr = err
+					return true
+				}
+				return t >= (day+1)*secondsPerDay
+			}))
+			if searchErr != nil {
+				return nil, searchErr
+			}
+		}
+		if next > start {
+			windows = append(windows, &StakerRewardsWindow{
+				Day:       time.Unix(int64(day*secondsPerDay), 0).UTC().Format("2006-01-02"),
+				FromBlock: hexutil.Uint64(start),
+				ToBlock:   hexutil.Uint64(next - 1),
+			})
+		}
+		start = next
+	}
+	return windows, nil
+}
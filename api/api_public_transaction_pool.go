@@ -155,7 +155,7 @@ func (s *PublicTransactionPoolAPI) GetDecodedAnchoringTransactionByHash(ctx cont
 	if tx = s.b.GetPoolTransaction(hash); tx != nil {
 		goto decode
 	}
-	return nil, errors.New("can't find the transaction")
+	return nil, errTxNotFound
 
 decode:
 
@@ -304,7 +304,7 @@ func submitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 	// log.Error("### submitTransaction","tx",submitTxCount)
 
 	if err := b.SendTx(ctx, tx); err != nil {
-		return common.Hash{}, err
+		return common.Hash{}, txRejectedError(err)
 	}
 	// TODO-Klaytn only enable on logging
 	//if tx.To() == nil {
@@ -550,7 +550,7 @@ func resend(s *PublicTransactionPoolAPI, ctx context.Context, sendArgs NewTxArgs
 		}
 	}
 
-	return common.Hash{}, fmt.Errorf("Transaction %#x not found", matchTx.Hash())
+	return common.Hash{}, errTxNotFound.Errorf("Transaction %#x not found", matchTx.Hash()).WithDetail("txHash", matchTx.Hash())
 }
 
 // RecoverFromTransaction recovers the sender address from a signed raw transaction.
@@ -21,10 +21,14 @@
 package api
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
 
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
 )
 
 // PublicTxPoolAPI offers and API for the transaction pool. It only operates on data that is non confidential.
@@ -64,6 +68,67 @@ func (s *PublicTxPoolAPI) Content() map[string]map[string]map[string]map[string]
 	return content
 }
 
+// TxPoolContentItem is a transaction in the pool returned by ContentPaged.
+type TxPoolContentItem struct {
+	Status string                 `json:"status"` // "pending" or "queued"
+	Tx     map[string]interface{} `json:"tx"`
+}
+
+// txPoolCursor is the position of the first transaction of the next page of ContentPaged.
+type txPoolCursor struct {
+	Queued bool
+	Sender common.Address
+	Nonce  uint64
+}
+
+// ContentPaged returns a page of the transactions contained within the transaction pool.
+// The pending transactions precede the queued ones, and the transactions are ordered by
+// the sender and the nonce, so that the pages are consistent while the pool changes.
+func (s *PublicTxPoolAPI) ContentPaged(page *rpc.PageArgs) (*rpc.Page, error) {
+	limit, err := page.PageLimit()
+	if err != nil {
+		return nil, err
+	}
+	cursor := new(txPoolCursor)
+	if _, err := page.DecodeCursor(cursor); err != nil {
+		return nil, err
+	}
+
+	pending, queue := s.b.TxPoolContent()
+	items := make([]TxPoolContentItem, 0, limit)
+	for _, queued := range []bool{false, true} {
+		content, status := pending, "pending"
+		if queued {
+			content, status = queue, "queued"
+		}
+		if !queued && cursor.Queued {
+			continue // the pending transactions have been returned
+		}
+		senders := make([]common.Address, 0, len(content))
+		for sender := range content {
+			if queued != cursor.Queued || bytes.Compare(sender.Bytes(), cursor.Sender.Bytes()) >= 0 {
+				senders = append(senders, sender)
+			}
+		}
+		sort.Slice(senders, func(i, j int) bool { return bytes.Compare(senders[i].Bytes(), senders[j].Bytes()) < 0 })
+
+		for _, sender := range senders {
+			txs := content[sender]
+			sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce() < txs[j].Nonce() })
+			for _, tx := range txs {
+				if queued == cursor.Queued && sender == cursor.Sender && tx.Nonce() < cursor.Nonce {
+					continue
+				}
+				if len(items) == limit {
+					return rpc.NewPage(items, &txPoolCursor{Queued: queued, Sender: sender, Nonce: tx.Nonce()})
+				}
+				items = append(items, TxPoolContentItem{Status: status, Tx: newRPCPendingTransaction(tx)})
+			}
+		}
+	}
+	return rpc.NewPage(items, nil)
+}
+
 // Status returns the number of pending and queued transaction in the pool.
 func (s *PublicTxPoolAPI) Status() map[string]hexutil.Uint {
 	pending, queue := s.b.Stats()
@@ -0,0 +1,82 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxPoolAPI_ContentPaged(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	api := NewPublicTxPoolAPI(mockBackend)
+
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	pending := make(map[common.Address]types.Transactions)
+	queued := make(map[common.Address]types.Transactions)
+	for i := 0; i < 3; i++ {
+		key, _ := crypto.GenerateKey()
+		sender := crypto.PubkeyToAddress(key.PublicKey)
+		for nonce := uint64(0); nonce < 3; nonce++ {
+			tx, err := types.SignTx(types.NewTransaction(nonce, sender, big.NewInt(1), 21000, big.NewInt(1), nil), signer, key)
+			require.NoError(t, err)
+			pending[sender] = append(pending[sender], tx)
+		}
+		tx, err := types.SignTx(types.NewTransaction(5, sender, big.NewInt(1), 21000, big.NewInt(1), nil), signer, key)
+		require.NoError(t, err)
+		queued[sender] = types.Transactions{tx}
+	}
+	mockBackend.EXPECT().TxPoolContent().Return(pending, queued).AnyTimes()
+
+	limit := hexutil.Uint64(4)
+	args := &rpc.PageArgs{Limit: &limit}
+	var items []TxPoolContentItem
+	for {
+		page, err := api.ContentPaged(args)
+		require.NoError(t, err)
+		items = append(items, page.Items.([]TxPoolContentItem)...)
+		if !page.HasMore {
+			break
+		}
+		args.Cursor = page.Cursor
+	}
+
+	require.Len(t, items, 12)
+	for i, item := range items {
+		if i < 9 {
+			assert.Equal(t, "pending", item.Status)
+			assert.Equal(t, hexutil.Uint64(i%3), item.Tx["nonce"])
+		} else {
+			assert.Equal(t, "queued", item.Status)
+			assert.Equal(t, hexutil.Uint64(5), item.Tx["nonce"])
+		}
+	}
+	assert.NotEqual(t, items[0].Tx["from"], items[3].Tx["from"])
+}
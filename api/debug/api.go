@@ -64,6 +64,8 @@ type HandlerT struct {
 
 	logDir    string   // log directory path
 	vmLogFile *os.File // a file descriptor of the vmlog output file
+
+	watchdog *watchdog
 }
 
 // Verbosity sets the log verbosity ceiling. The verbosity of individual packages
@@ -27,11 +27,15 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/fjl/memsize/memsizeui"
+	"github.com/klaytn/klaytn/common/fdlimit"
 	"github.com/klaytn/klaytn/log"
 	"github.com/mattn/go-colorable"
 	"github.com/mattn/go-isatty"
+	"github.com/rcrowley/go-metrics"
 	"github.com/urfave/cli/v2"
 	"github.com/urfave/cli/v2/altsrc"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -124,6 +128,27 @@ var (
 		EnvVars:  []string{"KLAYTN_LOGCOMPRESS"},
 		Category: "LOGGING AND DEBUGGING",
 	}
+	logRotateIntervalFlag = &cli.DurationFlag{
+		Name:     "log.rotate.interval",
+		Usage:    "Interval of the time-based log file rotation in addition to the size-based one (0 = disabled, use with --log.rotate flag)",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_LOGROTATE_INTERVAL"},
+		Category: "LOGGING AND DEBUGGING",
+	}
+	logModulesFlag = &cli.StringFlag{
+		Name:     "log.modules",
+		Usage:    "Per-module log files instead of --log.file: comma-separated list of <module>=<file> (e.g. consensus/*=consensus.log,contracts/reward=reward.log,networks/rpc=rpc.log)",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_LOGMODULES"},
+		Category: "LOGGING AND DEBUGGING",
+	}
+	logBufferFlag = &cli.IntFlag{
+		Name:     "log.buffer",
+		Usage:    "Number of log records buffered by the non-blocking writer, which drops the records on overflow (0 = blocking writes)",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_LOGBUFFER"},
+		Category: "LOGGING AND DEBUGGING",
+	}
 	pprofFlag = &cli.BoolFlag{
 		Name:     "pprof",
 		Usage:    "Enable the pprof HTTP server",
@@ -183,6 +208,59 @@ var (
 		EnvVars:  []string{"KLAYTN_TRACE"},
 		Category: "LOGGING AND DEBUGGING",
 	}
+	watchdogFlag = &cli.BoolFlag{
+		Name:     "watchdog",
+		Usage:    "Enable the watchdog writing the goroutine profile when the goroutines, the open files or the subscriptions exceed the thresholds",
+		Aliases:  []string{"debug-profile.watchdog.enable"},
+		EnvVars:  []string{"KLAYTN_WATCHDOG"},
+		Category: "LOGGING AND DEBUGGING",
+	}
+	watchdogIntervalFlag = &cli.DurationFlag{
+		Name:     "watchdog.interval",
+		Usage:    "Interval between the resource checks of the watchdog",
+		Value:    time.Minute,
+		Aliases:  []string{"debug-profile.watchdog.interval"},
+		EnvVars:  []string{"KLAYTN_WATCHDOG_INTERVAL"},
+		Category: "LOGGING AND DEBUGGING",
+	}
+	watchdogGoroutinesFlag = &cli.Int64Flag{
+		Name:     "watchdog.goroutines",
+		Usage:    "Number of goroutines regarded as a leak by the watchdog (0 = disabled)",
+		Value:    20000,
+		Aliases:  []string{"debug-profile.watchdog.goroutines"},
+		EnvVars:  []string{"KLAYTN_WATCHDOG_GOROUTINES"},
+		Category: "LOGGING AND DEBUGGING",
+	}
+	watchdogFDsFlag = &cli.Int64Flag{
+		Name:     "watchdog.fds",
+		Usage:    "Number of open file descriptors regarded as a leak by the watchdog (0 = 90% of the process limit)",
+		Aliases:  []string{"debug-profile.watchdog.fds"},
+		EnvVars:  []string{"KLAYTN_WATCHDOG_FDS"},
+		Category: "LOGGING AND DEBUGGING",
+	}
+	watchdogSubscriptionsFlag = &cli.Int64Flag{
+		Name:     "watchdog.subscriptions",
+		Usage:    "Number of subscriptions of a subsystem regarded as a leak by the watchdog (0 = disabled)",
+		Value:    10000,
+		Aliases:  []string{"debug-profile.watchdog.subscriptions"},
+		EnvVars:  []string{"KLAYTN_WATCHDOG_SUBSCRIPTIONS"},
+		Category: "LOGGING AND DEBUGGING",
+	}
+	watchdogDumpDirFlag = &cli.StringFlag{
+		Name:     "watchdog.dumpdir",
+		Usage:    "Directory the goroutine profiles are written to by the watchdog (default: <tmpdir>/klaytn-watchdog)",
+		Aliases:  []string{"debug-profile.watchdog.dump-dir"},
+		EnvVars:  []string{"KLAYTN_WATCHDOG_DUMPDIR"},
+		Category: "LOGGING AND DEBUGGING",
+	}
+	watchdogDumpIntervalFlag = &cli.DurationFlag{
+		Name:     "watchdog.dumpinterval",
+		Usage:    "Minimum interval between the goroutine profiles written by the watchdog",
+		Value:    30 * time.Minute,
+		Aliases:  []string{"debug-profile.watchdog.dump-interval"},
+		EnvVars:  []string{"KLAYTN_WATCHDOG_DUMPINTERVAL"},
+		Category: "LOGGING AND DEBUGGING",
+	}
 )
 
 // Flags holds all command-line flags required for debugging.
@@ -198,6 +276,9 @@ var Flags = []cli.Flag{
 	altsrc.NewIntFlag(logMaxBackupsFlag),
 	altsrc.NewIntFlag(logMaxAgeFlag),
 	altsrc.NewBoolFlag(logCompressFlag),
+	altsrc.NewDurationFlag(logRotateIntervalFlag),
+	altsrc.NewStringFlag(logModulesFlag),
+	altsrc.NewIntFlag(logBufferFlag),
 	altsrc.NewBoolFlag(pprofFlag),
 	altsrc.NewStringFlag(pprofAddrFlag),
 	altsrc.NewIntFlag(pprofPortFlag),
@@ -206,6 +287,13 @@ var Flags = []cli.Flag{
 	altsrc.NewIntFlag(blockprofilerateFlag),
 	altsrc.NewStringFlag(cpuprofileFlag),
 	altsrc.NewStringFlag(traceFlag),
+	altsrc.NewBoolFlag(watchdogFlag),
+	altsrc.NewDurationFlag(watchdogIntervalFlag),
+	altsrc.NewInt64Flag(watchdogGoroutinesFlag),
+	altsrc.NewInt64Flag(watchdogFDsFlag),
+	altsrc.NewInt64Flag(watchdogSubscriptionsFlag),
+	altsrc.NewStringFlag(watchdogDumpDirFlag),
+	altsrc.NewDurationFlag(watchdogDumpIntervalFlag),
 }
 
 var glogger *log.GlogHandler
@@ -266,6 +354,13 @@ func Setup(ctx *cli.Context) error {
 		logFile  = ctx.String(logFileFlag.Name)
 		rotation = ctx.Bool(logRotateFlag.Name)
 		context  = []interface{}{"rotate", rotation}
+		files    = &logFileOpener{
+			rotation:   rotation,
+			maxSize:    ctx.Int(logMaxSizeMBsFlag.Name),
+			maxBackups: ctx.Int(logMaxBackupsFlag.Name),
+			maxAge:     ctx.Int(logMaxAgeFlag.Name),
+			compress:   ctx.Bool(logCompressFlag.Name),
+		}
 	)
 
 	// if logFile is not set when rotation, set default log name
@@ -274,24 +369,35 @@ func Setup(ctx *cli.Context) error {
 	}
 	if len(logFile) > 0 {
 		context = append(context, "format", logFmtFlag, "location", logFile)
-		if err := validateLogLocation(logFile); err != nil {
-			return fmt.Errorf("tried to create a temporary file to verify that the log path is writable, but it failed: %v", err)
+		logOutputStream, err := files.open(logFile, logfmt)
+		if err != nil {
+			return err
 		}
-		if rotation {
-			ostream = log.StreamHandler(&lumberjack.Logger{
-				Filename:   logFile,
-				MaxSize:    ctx.Int(logMaxSizeMBsFlag.Name),
-				MaxBackups: ctx.Int(logMaxBackupsFlag.Name),
-				MaxAge:     ctx.Int(logMaxAgeFlag.Name),
-				Compress:   ctx.Bool(logCompressFlag.Name),
-			}, logfmt)
-		} else {
-			logOutputStream, err := log.FileHandler(logFile, logfmt)
-			if err != nil {
-				return err
-			}
-			ostream = logOutputStream
+		ostream = logOutputStream
+	}
+	if modules := ctx.String(logModulesFlag.Name); modules != "" {
+		fileFmt := logfmt
+		if logFmtFlag == "terminal" {
+			fileFmt = log.TerminalFormat(false)
+		}
+		routes, err := parseLogModules(modules, filepath.Dir(logFile), func(path string) (log.Handler, error) {
+			return files.open(path, fileFmt)
+		})
+		if err != nil {
+			return err
 		}
+		ostream = log.ModuleRouteHandler(routes, ostream)
+		context = append(context, "modules", modules)
+	}
+	if interval := ctx.Duration(logRotateIntervalFlag.Name); rotation && interval > 0 {
+		go files.rotateEvery(interval)
+		context = append(context, "interval", interval)
+	}
+	if bufSize := ctx.Int(logBufferFlag.Name); bufSize > 0 {
+		async := log.NewAsyncHandler(bufSize, ostream)
+		metrics.NewRegisteredFunctionalGauge("klay/log/dropped", nil, func() int64 { return int64(async.Dropped()) })
+		ostream = async
+		context = append(context, "buffer", bufSize)
 	}
 	glogger = log.NewGlogHandler(ostream)
 
@@ -331,7 +437,31 @@ func Setup(ctx *cli.Context) error {
 		port := ctx.Int(pprofPortFlag.Name)
 		Handler.StartPProf(&addr, &port)
 	}
-	if len(logFile) > 0 {
+
+	// resource leak watchdog
+	if ctx.Bool(watchdogFlag.Name) {
+		config := WatchdogConfig{
+			Interval:         ctx.Duration(watchdogIntervalFlag.Name),
+			MaxGoroutines:    ctx.Int64(watchdogGoroutinesFlag.Name),
+			MaxFDs:           ctx.Int64(watchdogFDsFlag.Name),
+			MaxSubscriptions: ctx.Int64(watchdogSubscriptionsFlag.Name),
+			DumpDir:          ctx.String(watchdogDumpDirFlag.Name),
+			DumpInterval:     ctx.Duration(watchdogDumpIntervalFlag.Name),
+		}
+		if config.Interval <= 0 {
+			return fmt.Errorf("invalid watchdog interval: %v", config.Interval)
+		}
+		if config.MaxFDs == 0 {
+			if limit, err := fdlimit.Current(); err == nil {
+				config.MaxFDs = int64(limit) * 9 / 10
+			}
+		}
+		if config.DumpDir == "" {
+			config.DumpDir = filepath.Join(os.TempDir(), "klaytn-watchdog")
+		}
+		Handler.startWatchdog(config)
+	}
+	if len(logFile) > 0 || ctx.IsSet(logModulesFlag.Name) {
 		logger.Info("Logging configured", context...)
 	}
 	return nil
@@ -350,6 +480,93 @@ func Exit() {
 	Handler.StopCPUProfile()
 	Handler.StopGoTrace()
 	Handler.StopPProf()
+	Handler.stopWatchdog()
+}
+
+// logFileOpener opens the log files with the rotation settings of the CLI flags.
+type logFileOpener struct {
+	rotation   bool
+	maxSize    int
+	maxBackups int
+	maxAge     int
+	compress   bool
+
+	rotators []*lumberjack.Logger
+}
+
+func (o *logFileOpener) open(path string, logfmt log.Format) (log.Handler, error) {
+	if err := validateLogLocation(path); err != nil {
+		return nil, fmt.Errorf("tried to create a temporary file to verify that the log path is writable, but it failed: %v", err)
+	}
+	if !o.rotation {
+		return log.FileHandler(path, logfmt)
+	}
+	rotator := &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    o.maxSize,
+		MaxBackups: o.maxBackups,
+		MaxAge:     o.maxAge,
+		Compress:   o.compress,
+	}
+	o.rotators = append(o.rotators, rotator)
+	return log.StreamHandler(rotator, logfmt), nil
+}
+
+// rotateEvery rotates the log files periodically regardless of their sizes.
+func (o *logFileOpener) rotateEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for _, rotator := range o.rotators {
+			if err := rotator.Rotate(); err != nil {
+				logger.Warn("Failed to rotate the log file", "file", rotator.Filename, "err", err)
+			}
+		}
+	}
+}
+
+// parseLogModules parses the per-module log files, a comma-separated list of <module>=<file>.
+// The relative paths are resolved against dir, and the modules sharing a file share a handler.
+func parseLogModules(spec, dir string, open func(path string) (log.Handler, error)) ([]log.ModuleRoute, error) {
+	var (
+		routes   []log.ModuleRoute
+		handlers = make(map[string]log.Handler)
+	)
+	for _, entry := range strings.Split(spec, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), "=")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid per-module log file %q, expected <module>=<file>", entry)
+		}
+		route := log.ModuleRoute{Pattern: parts[0]}
+		if !knownLogModule(route) {
+			return nil, fmt.Errorf("no log module matches %q", route.Pattern)
+		}
+
+		path := parts[1]
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if handlers[path] == nil {
+			h, err := open(path)
+			if err != nil {
+				return nil, err
+			}
+			handlers[path] = h
+		}
+		route.Handler = handlers[path]
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func knownLogModule(route log.ModuleRoute) bool {
+	for mi := log.BaseLogger; mi < log.ModuleNameLen; mi++ {
+		if route.Match(log.GetModuleName(mi)) {
+			return true
+		}
+	}
+	return false
 }
 
 func validateLogLocation(path string) error {
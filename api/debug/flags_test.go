@@ -0,0 +1,45 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package debug
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogModules(t *testing.T) {
+	var opened []string
+	open := func(path string) (log.Handler, error) {
+		opened = append(opened, path)
+		return log.DiscardHandler(), nil
+	}
+
+	routes, err := parseLogModules("consensus/*=consensus.log, networks/rpc=/var/log/rpc.log,consensus/istanbul=consensus.log", "/data/log", open)
+	require.NoError(t, err)
+	assert.Len(t, routes, 3)
+	assert.Equal(t, "consensus/*", routes[0].Pattern)
+	assert.Equal(t, "networks/rpc", routes[1].Pattern)
+	assert.Equal(t, []string{"/data/log/consensus.log", "/var/log/rpc.log"}, opened) // the same file is opened once
+
+	for _, spec := range []string{"consensus/*", "=rpc.log", "networks/rpc=", "unknown=unknown.log", "consensus/ist/*=consensus.log"} {
+		_, err := parseLogModules(spec, "", open)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package debug
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+)
+
+var (
+	watchdogGoroutinesGauge = metrics.NewRegisteredGauge("system/watchdog/goroutines", nil)
+	watchdogFDsGauge        = metrics.NewRegisteredGauge("system/watchdog/fds", nil)
+	watchdogViolationsMeter = metrics.NewRegisteredMeter("system/watchdog/violations", nil)
+	watchdogDumpsCounter    = metrics.NewRegisteredCounter("system/watchdog/dumps", nil)
+
+	subscriptionCountersMu sync.Mutex
+	subscriptionCounters   = make(map[string]func() int64)
+)
+
+// RegisterSubscriptionCounter registers the function returning the number of the live
+// subscriptions of the subsystem, which is watched by the watchdog. The function
+// registered earlier under the same name is replaced.
+func RegisterSubscriptionCounter(subsystem string, count func() int64) {
+	subscriptionCountersMu.Lock()
+	defer subscriptionCountersMu.Unlock()
+	subscriptionCounters[subsystem] = count
+}
+
+// WatchdogConfig is the configuration of the resource leak watchdog.
+// A zero threshold disables the corresponding check.
+type WatchdogConfig struct {
+	Interval         time.Duration // the interval between the checks
+	MaxGoroutines    int64         // the threshold of the goroutines
+	MaxFDs           int64         // the threshold of the open file descriptors
+	MaxSubscriptions int64         // the threshold of the subscriptions of each subsystem
+	DumpDir          string        // the directory the goroutine profiles are written to
+	DumpInterval     time.Duration // the minimum interval between the goroutine profiles
+}
+
+// watchdog periodically checks the goroutines, the open file descriptors and the
+// subscriptions of the subsystems, and writes the goroutine profile to the disk when
+// any of them exceeds the threshold, to catch the leaks before the node crashes.
+type watchdog struct {
+	config   WatchdogConfig
+	lastDump time.Time
+	gauges   map[string]metrics.Gauge // the subscriptions by the subsystem
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newWatchdog(config WatchdogConfig) *watchdog {
+	return &watchdog{
+		config: config,
+		gauges: make(map[string]metrics.Gauge),
+		quit:   make(chan struct{}),
+	}
+}
+
+func (w *watchdog) start() {
+	w.wg.Add(1)
+	go w.loop()
+	logger.Info("Started the resource watchdog", "interval", w.config.Interval, "maxGoroutines", w.config.MaxGoroutines,
+		"maxFDs", w.config.MaxFDs, "maxSubscriptions", w.config.MaxSubscriptions, "dumpDir", w.config.DumpDir)
+}
+
+func (w *watchdog) stop() {
+	close(w.quit)
+	w.wg.Wait()
+}
+
+func (w *watchdog) loop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			w.check(now)
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+// check updates the metrics of the resources and writes the goroutine profile if any
+// threshold is exceeded. It returns the exceeded thresholds.
+func (w *watchdog) check(now time.Time) []string {
+	var violations []string
+
+	goroutines := int64(runtime.NumGoroutine())
+	watchdogGoroutinesGauge.Update(goroutines)
+	if w.config.MaxGoroutines > 0 && goroutines > w.config.MaxGoroutines {
+		violations = append(violations, fmt.Sprintf("goroutines=%d", goroutines))
+	}
+
+	if fds, err := countFDs(); err == nil {
+		watchdogFDsGauge.Update(fds)
+		if w.config.MaxFDs > 0 && fds > w.config.MaxFDs {
+			violations = append(violations, fmt.Sprintf("fds=%d", fds))
+		}
+	}
+
+	subscriptionCountersMu.Lock()
+	subsystems := make([]string, 0, len(subscriptionCounters))
+	for subsystem := range subscriptionCounters {
+		subsystems = append(subsystems, subsystem)
+	}
+	sort.Strings(subsystems)
+	for _, subsystem := range subsystems {
+		subscriptions := subscriptionCounters[subsystem]()
+		gauge, ok := w.gauges[subsystem]
+		if !ok {
+			gauge = metrics.GetOrRegisterGauge("system/watchdog/subscriptions/"+subsystem, nil)
+			w.gauges[subsystem] = gauge
+		}
+		gauge.Update(subscriptions)
+		if w.config.MaxSubscriptions > 0 && subscriptions > w.config.MaxSubscriptions {
+			violations = append(violations, fmt.Sprintf("subscriptions/%s=%d", subsystem, subscriptions))
+		}
+	}
+	subscriptionCountersMu.Unlock()
+
+	if len(violations) == 0 {
+		return nil
+	}
+	watchdogViolationsMeter.Mark(1)
+	if !w.lastDump.IsZero() && now.Sub(w.lastDump) < w.config.DumpInterval {
+		logger.Warn("Resource usage exceeds the watchdog threshold", "exceeded", strings.Join(violations, ","))
+		return violations
+	}
+	file, err := w.dump(now)
+	if err != nil {
+		logger.Error("Failed to write the goroutine profile", "exceeded", strings.Join(violations, ","), "err", err)
+		return violations
+	}
+	w.lastDump = now
+	watchdogDumpsCounter.Inc(1)
+	logger.Warn("Resource usage exceeds the watchdog threshold, wrote the goroutine profile", "exceeded", strings.Join(violations, ","), "file", file)
+	return violations
+}
+
+// dump writes the goroutine profile in the pprof format to the dump directory.
+func (w *watchdog) dump(now time.Time) (string, error) {
+	if err := os.MkdirAll(w.config.DumpDir, 0o700); err != nil {
+		return "", err
+	}
+	file := filepath.Join(w.config.DumpDir, "goroutine-"+now.Format("20060102-150405")+".pprof")
+	f, err := os.Create(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if err := pprof.Lookup("goroutine").WriteTo(f, 0); err != nil {
+		return "", err
+	}
+	return file, nil
+}
+
+// countFDs returns the number of the file descriptors opened by the process.
+func countFDs() (int64, error) {
+	for _, dir := range []string{"/proc/self/fd", "/dev/fd"} {
+		if entries, err := os.ReadDir(dir); err == nil {
+			return int64(len(entries)), nil
+		}
+	}
+	return 0, fmt.Errorf("counting the file descriptors is not supported on %s", runtime.GOOS)
+}
+
+// startWatchdog starts the resource leak watchdog. The watchdog started before is stopped.
+func (h *HandlerT) startWatchdog(config WatchdogConfig) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.watchdog != nil {
+		h.watchdog.stop()
+	}
+	h.watchdog = newWatchdog(config)
+	h.watchdog.start()
+}
+
+// stopWatchdog stops the resource leak watchdog.
+func (h *HandlerT) stopWatchdog() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.watchdog != nil {
+		h.watchdog.stop()
+		h.watchdog = nil
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package debug
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchdog_Check(t *testing.T) {
+	subscriptions := int64(5)
+	RegisterSubscriptionCounter("test", func() int64 { return subscriptions })
+
+	dir := t.TempDir()
+	w := newWatchdog(WatchdogConfig{
+		Interval:         time.Minute,
+		MaxGoroutines:    1,
+		MaxSubscriptions: 5,
+		DumpDir:          dir,
+		DumpInterval:     time.Hour,
+	})
+	now := time.Now()
+
+	// the goroutines exceed the threshold
+	violations := w.check(now)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0], "goroutines=")
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Regexp(t, `^goroutine-\d{8}-\d{6}\.pprof$`, files[0].Name())
+
+	// the profile is not written again within the dump interval
+	subscriptions = 6
+	violations = w.check(now.Add(time.Minute))
+	assert.Contains(t, violations, "subscriptions/test=6")
+	files, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	violations = w.check(now.Add(time.Hour))
+	assert.Len(t, violations, 2)
+	files, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	// nothing is exceeded
+	w.config.MaxGoroutines, w.config.MaxSubscriptions = 0, 0
+	assert.Empty(t, w.check(now.Add(2*time.Hour)))
+}
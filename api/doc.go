@@ -37,6 +37,7 @@ Source Files
   - api_public_cypress.go          : provides public APIs to return specific information of Klaytn Cypress network.
   - api_public_debug.go            : provides public APIs exposed over the debugging node.
   - api_public_klay.go             : provides public APIs to access Klaytn related data.
+  - api_public_multisig.go         : provides public APIs to collect the signatures of weighted multisig accounts.
   - api_public_net.go              : provides public APIs to offer network related RPC methods.
   - api_public_transaction_pool.go : provides public APIs having "klay" namespace to access transaction pool data.
   - api_public_tx_pool.go          : provides public APIs having "txpool" namespace to access transaction pool data.
@@ -24,7 +24,6 @@ import (
 	"bytes"
 	"context"
 	"errors"
-	"fmt"
 	"math/big"
 	"reflect"
 
@@ -39,15 +38,15 @@ import (
 )
 
 var (
-	errTxArgInvalidInputData = errors.New(`Both "data" and "input" are set and not equal. Please use "input" to pass transaction call data.`)
-	errTxArgInvalidFeePayer  = errors.New("invalid fee payer is set")
-	errTxArgNilTxType        = errors.New("tx should have a type value")
-	errTxArgNilContractData  = errors.New(`contract creation without any data provided`)
-	errTxArgNilSenderSig     = errors.New("sender signature is not set")
-	errTxArgNilNonce         = errors.New("nonce of the sender is not set")
-	errTxArgNilGas           = errors.New("gas limit is not set")
-	errTxArgNilGasPrice      = errors.New("gas price is not set")
-	errNotForFeeDelegationTx = errors.New("fee-delegation type transactions are not allowed to use this API")
+	errTxArgInvalidInputData = errInvalidTxArgs.Errorf(`Both "data" and "input" are set and not equal. Please use "input" to pass transaction call data.`)
+	errTxArgInvalidFeePayer  = errInvalidTxArgs.Errorf("invalid fee payer is set")
+	errTxArgNilTxType        = errInvalidTxArgs.Errorf("tx should have a type value")
+	errTxArgNilContractData  = errInvalidTxArgs.Errorf(`contract creation without any data provided`)
+	errTxArgNilSenderSig     = errInvalidTxArgs.Errorf("sender signature is not set")
+	errTxArgNilNonce         = errInvalidTxArgs.Errorf("nonce of the sender is not set")
+	errTxArgNilGas           = errInvalidTxArgs.Errorf("gas limit is not set")
+	errTxArgNilGasPrice      = errInvalidTxArgs.Errorf("gas price is not set")
+	errNotForFeeDelegationTx = rpc.NewAPIError(rpc.CodeMethodNotSupported, "feeDelegationNotAllowed", "fee-delegation type transactions are not allowed to use this API")
 )
 
 // isTxField checks whether the string is a field name of the specific txType.
@@ -192,13 +191,13 @@ func (args *SendTxArgs) setDefaults(ctx context.Context, b Backend) error {
 		}
 		if isMagma {
 			if args.MaxFeePerGas.ToInt().Cmp(new(big.Int).Div(gasPrice, common.Big2)) < 0 {
-				return fmt.Errorf("maxFeePerGas (%v) < BaseFee (%v)", args.MaxFeePerGas, gasPrice)
+				return errInvalidGasFee.Errorf("maxFeePerGas (%v) < BaseFee (%v)", args.MaxFeePerGas, gasPrice)
 			}
 		} else if args.MaxPriorityFeePerGas.ToInt().Cmp(gasPrice) != 0 || args.MaxFeePerGas.ToInt().Cmp(gasPrice) != 0 {
-			return fmt.Errorf("only %s is allowed to be used as maxFeePerGas and maxPriorityPerGas", gasPrice.Text(16))
+			return errInvalidGasFee.Errorf("only %s is allowed to be used as maxFeePerGas and maxPriorityPerGas", gasPrice.Text(16))
 		}
 		if args.MaxFeePerGas.ToInt().Cmp(args.MaxPriorityFeePerGas.ToInt()) < 0 {
-			return fmt.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", args.MaxFeePerGas, args.MaxPriorityFeePerGas)
+			return errInvalidGasFee.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", args.MaxFeePerGas, args.MaxPriorityFeePerGas)
 		}
 	}
 	if args.AccountNonce == nil {
@@ -236,12 +235,12 @@ func (args *SendTxArgs) checkArgs() error {
 			if (*args.TypeInt).IsContractDeploy() && argsType.Field(i).Name == "Recipient" {
 				continue
 			}
-			return errors.New((string)(argsType.Field(i).Tag) + " is required for " + (*args.TypeInt).String())
+			return errInvalidTxArgs.Errorf("%s is required for %s", argsType.Field(i).Tag, *args.TypeInt).WithDetail("field", argsType.Field(i).Tag.Get("json"))
 		}
 
 		// An args field has a value but the field name doesn't exist on the tx type
 		if !argsValue.Field(i).IsNil() && !isTxField[*args.TypeInt][argsType.Field(i).Name] {
-			return errors.New((string)(argsType.Field(i).Tag) + " is not a field of " + (*args.TypeInt).String())
+			return errInvalidTxArgs.Errorf("%s is not a field of %s", argsType.Field(i).Tag, *args.TypeInt).WithDetail("field", argsType.Field(i).Tag.Get("json"))
 		}
 	}
 
@@ -596,7 +595,7 @@ func (args *EthTransactionArgs) setGasPrice(gasPrice *hexutil.Big) {
 // setDefaults fills in default values for unspecified tx fields.
 func (args *EthTransactionArgs) setDefaults(ctx context.Context, b Backend) error {
 	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
-		return errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+		return errInvalidGasFee.Errorf("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
 	}
 	// After london, default to 1559 uncles gasPrice is set
 	head := b.CurrentBlock().Header()
@@ -632,17 +631,17 @@ func (args *EthTransactionArgs) setDefaults(ctx context.Context, b Backend) erro
 			}
 			if isMagma {
 				if args.MaxFeePerGas.ToInt().Cmp(new(big.Int).Div(gasPrice, common.Big2)) < 0 {
-					return fmt.Errorf("maxFeePerGas (%v) < BaseFee (%v)", args.MaxFeePerGas, gasPrice)
+					return errInvalidGasFee.Errorf("maxFeePerGas (%v) < BaseFee (%v)", args.MaxFeePerGas, gasPrice)
 				}
 			} else if args.MaxPriorityFeePerGas.ToInt().Cmp(gasPrice) != 0 || args.MaxFeePerGas.ToInt().Cmp(gasPrice) != 0 {
-				return fmt.Errorf("only %s is allowed to be used as maxFeePerGas and maxPriorityPerGas", gasPrice.Text(16))
+				return errInvalidGasFee.Errorf("only %s is allowed to be used as maxFeePerGas and maxPriorityPerGas", gasPrice.Text(16))
 			}
 			if args.MaxFeePerGas.ToInt().Cmp(args.MaxPriorityFeePerGas.ToInt()) < 0 {
-				return fmt.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", args.MaxFeePerGas, args.MaxPriorityFeePerGas)
+				return errInvalidGasFee.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", args.MaxFeePerGas, args.MaxPriorityFeePerGas)
 			}
 		} else {
 			if args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil {
-				return errors.New("maxFeePerGas or maxPriorityFeePerGas specified but london is not active yet")
+				return errInvalidGasFee.Errorf("maxFeePerGas or maxPriorityFeePerGas specified but london is not active yet")
 			}
 			if args.GasPrice == nil {
 				// TODO-Klaytn: Original logic of Ethereum uses b.SuggestTipCap which suggests TipCap, not a GasPrice.
@@ -660,11 +659,11 @@ func (args *EthTransactionArgs) setDefaults(ctx context.Context, b Backend) erro
 		// Both maxPriorityFee and maxFee set by caller. Sanity-check their internal relation
 		if isMagma {
 			if args.MaxFeePerGas.ToInt().Cmp(new(big.Int).Div(gasPrice, common.Big2)) < 0 {
-				return fmt.Errorf("maxFeePerGas (%v) < BaseFee (%v)", args.MaxFeePerGas, gasPrice)
+				return errInvalidGasFee.Errorf("maxFeePerGas (%v) < BaseFee (%v)", args.MaxFeePerGas, gasPrice)
 			}
 		} else {
 			if args.MaxFeePerGas.ToInt().Cmp(args.MaxPriorityFeePerGas.ToInt()) < 0 {
-				return fmt.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", args.MaxFeePerGas, args.MaxPriorityFeePerGas)
+				return errInvalidGasFee.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", args.MaxFeePerGas, args.MaxPriorityFeePerGas)
 			}
 		}
 	}
@@ -701,7 +700,7 @@ func (args *EthTransactionArgs) setDefaults(ctx context.Context, b Backend) erro
 		if rpcGasCap := b.RPCGasCap(); rpcGasCap != nil {
 			gasCap = rpcGasCap.Uint64()
 		}
-		estimated, err := EthDoEstimateGas(ctx, b, callArgs, pendingBlockNr, gasCap)
+		estimated, err := EthDoEstimateGas(ctx, b, callArgs, pendingBlockNr, nil, gasCap)
 		if err != nil {
 			return err
 		}
@@ -719,7 +718,7 @@ func (args *EthTransactionArgs) setDefaults(ctx context.Context, b Backend) erro
 func (args *EthTransactionArgs) ToMessage(globalGasCap uint64, baseFee *big.Int, intrinsicGas uint64) (*types.Transaction, error) {
 	// Reject invalid combinations of pre- and post-1559 fee styles
 	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
-		return nil, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+		return nil, errInvalidGasFee.Errorf("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
 	} else if args.MaxFeePerGas != nil && args.MaxPriorityFeePerGas != nil {
 		if args.MaxFeePerGas.ToInt().Cmp(args.MaxPriorityFeePerGas.ToInt()) < 0 {
 			return nil, errors.New("MaxPriorityFeePerGas is greater than MaxFeePerGas")
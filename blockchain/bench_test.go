@@ -96,6 +96,18 @@ func BenchmarkInsertChain_ring1000_badgerDB(b *testing.B) {
 	benchInsertChain(b, database.BadgerDB, genTxRing(1000))
 }
 
+func BenchmarkInsertChain_logs_memDB(b *testing.B) {
+	benchInsertChain(b, database.MemoryDB, genLogTx(20))
+}
+
+func BenchmarkInsertChain_logs_levelDB(b *testing.B) {
+	benchInsertChain(b, database.LevelDB, genLogTx(20))
+}
+
+func BenchmarkInsertChain_logs_badgerDB(b *testing.B) {
+	benchInsertChain(b, database.BadgerDB, genLogTx(20))
+}
+
 var (
 	// This is the content of the genesis block used by the benchmarks.
 	benchRootKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
@@ -116,6 +128,29 @@ func genValueTx(nbytes int) func(int, *BlockGen) {
 	}
 }
 
+// logEmitterCode is the creation code of a contract emitting 100 logs with four topics on every call.
+//
+//	PUSH1 100; JUMPDEST; DUP1; NUMBER; CALLER; DUP3; PUSH1 32; PUSH1 0; LOG4;
+//	PUSH1 1; SWAP1; SUB; DUP1; PUSH1 2; JUMPI; STOP
+var logEmitterCode = common.FromHex("0x6015600c60003960156000f3" + "60645b8043338260206000a46001900380600257" + "00")
+
+// genLogTx returns a block generator that includes n calls to a contract
+// emitting 100 logs each, which is deployed in the first block.
+func genLogTx(n int) func(int, *BlockGen) {
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	emitter := crypto.CreateAddress(benchRootAddr, 0)
+	return func(i int, gen *BlockGen) {
+		if i == 0 {
+			tx, _ := types.SignTx(types.NewContractCreation(gen.TxNonce(benchRootAddr), common.Big0, 100000, nil, logEmitterCode), signer, benchRootKey)
+			gen.AddTx(tx)
+		}
+		for j := 0; j < n; j++ {
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(benchRootAddr), emitter, common.Big0, 1000000, nil, nil), signer, benchRootKey)
+			gen.AddTx(tx)
+		}
+	}
+}
+
 var (
 	ringKeys  = make([]*ecdsa.PrivateKey, 1000)
 	ringAddrs = make([]common.Address, len(ringKeys))
@@ -50,6 +50,7 @@ import (
 	"github.com/klaytn/klaytn/fork"
 	"github.com/klaytn/klaytn/log"
 	klaytnmetrics "github.com/klaytn/klaytn/metrics"
+	metricutils "github.com/klaytn/klaytn/metrics/utils"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
 	"github.com/klaytn/klaytn/storage/database"
@@ -104,6 +105,8 @@ const (
 	//  Currently, this value is taken to cache all 10 million accounts
 	//  and should be optimized considering memory size and performance.
 	maxAccountForCache = 10000000
+	// persistCountersInterval is the interval between the writes of the persistent counters.
+	persistCountersInterval = time.Minute
 )
 
 const (
@@ -357,6 +360,9 @@ func NewBlockChain(db database.DBManager, cacheConfig *CacheConfig, chainConfig
 	}
 	logger.Info("prefetchTxWorkers are started", "num", bc.cacheConfig.TrieNodeCacheConfig.NumFetcherPrefetchWorker)
 
+	// Continue the lifetime values of the persistent counters
+	metricutils.LoadPersistentCounters(bc.db)
+
 	// Take ownership of this particular state
 	go bc.update()
 	bc.gcCachedNodeLoop()
@@ -627,7 +633,7 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, root common.Hash, repair bo
 		if params.IsCheckpointInterval(num) {
 			bc.db.DeleteIstanbulSnapshot(hash)
 		}
-		if bc.Config().Istanbul.ProposerPolicy == params.WeightedRandom && params.IsStakingUpdateInterval(num) {
+		if bc.Config().Istanbul != nil && bc.Config().Istanbul.ProposerPolicy == params.WeightedRandom && params.IsStakingUpdateInterval(num) {
 			bc.db.DeleteStakingInfo(num)
 		}
 	}
@@ -648,24 +654,26 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, root common.Hash, repair bo
 	}
 
 	// Delete istanbul snapshot database further two epochs
-	var (
-		curBlkNum   = bc.CurrentBlock().Number().Uint64()
-		epoch       = bc.Config().Istanbul.Epoch
-		votingEpoch = curBlkNum - (curBlkNum % epoch)
-	)
-	if votingEpoch == 0 {
-		votingEpoch = 1
-	}
-	// Delete the snapshot state beyond the block number of the previous epoch on the right
-	for i := curBlkNum; i >= votingEpoch; i-- {
-		if params.IsCheckpointInterval(i) {
-			// delete from sethead number to previous two epoch block nums
-			// to handle a block that contains non-empty vote data to make sure
-			// the `HandleGovernanceVote()` cannot be skipped
-			bc.db.DeleteIstanbulSnapshot(bc.GetBlockByNumber(i).Hash())
+	if bc.Config().Istanbul != nil {
+		var (
+			curBlkNum   = bc.CurrentBlock().Number().Uint64()
+			epoch       = bc.Config().Istanbul.Epoch
+			votingEpoch = curBlkNum - (curBlkNum % epoch)
+		)
+		if votingEpoch == 0 {
+			votingEpoch = 1
+		}
+		// Delete the snapshot state beyond the block number of the previous epoch on the right
+		for i := curBlkNum; i >= votingEpoch; i-- {
+			if params.IsCheckpointInterval(i) {
+				// delete from sethead number to previous two epoch block nums
+				// to handle a block that contains non-empty vote data to make sure
+				// the `HandleGovernanceVote()` cannot be skipped
+				bc.db.DeleteIstanbulSnapshot(bc.GetBlockByNumber(i).Hash())
+			}
 		}
+		logger.Trace("[SetHead] Snapshot database deleted", "from", originLatestBlkNum, "to", votingEpoch)
 	}
-	logger.Trace("[SetHead] Snapshot database deleted", "from", originLatestBlkNum, "to", votingEpoch)
 
 	// Clear out any stale content from the caches
 	bc.futureBlocks.Purge()
@@ -1075,6 +1083,7 @@ func (bc *BlockChain) Stop() {
 	atomic.StoreInt32(&bc.procInterrupt, 1)
 
 	bc.wg.Wait()
+	metricutils.PersistPersistentCounters(bc.db)
 
 	// Ensure that the entirety of the state snapshot is journalled to disk.
 	var snapBase common.Hash
@@ -1881,6 +1890,9 @@ func (bc *BlockChain) insertChain(chain types.Blocks) (int, []interface{}, []*ty
 	if bc.engine.CanVerifyHeadersConcurrently() {
 		abort, results = bc.engine.VerifyHeaders(bc, headers, seals)
 	} else {
+		if verifier, ok := bc.engine.(consensus.CheckpointVerifier); ok {
+			verifier.VerifyCheckpoints(bc, headers)
+		}
 		abort, results = bc.engine.PreprocessHeaderVerification(headers)
 	}
 	defer close(abort)
@@ -2475,6 +2487,7 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 		}
 		logFn("Chain split detected", "number", commonBlock.Number(), "hash", commonBlock.Hash(),
 			"drop", len(oldChain), "dropfrom", oldChain[0].Hash(), "add", len(newChain), "addfrom", newChain[0].Hash())
+		reorgCounter.Inc(1)
 	} else {
 		logger.Error("Impossible reorg, please file an issue", "oldnum", oldBlock.Number(), "oldhash", oldBlock.Hash(), "newnum", newBlock.Number(), "newhash", newBlock.Hash())
 	}
@@ -2533,10 +2546,14 @@ func (bc *BlockChain) PostChainEvents(events []interface{}, logs []*types.Log) {
 func (bc *BlockChain) update() {
 	futureTimer := time.NewTicker(5 * time.Second)
 	defer futureTimer.Stop()
+	persistTimer := time.NewTicker(persistCountersInterval)
+	defer persistTimer.Stop()
 	for {
 		select {
 		case <-futureTimer.C:
 			bc.procFutureBlocks()
+		case <-persistTimer.C:
+			metricutils.PersistPersistentCounters(bc.db)
 		case <-bc.quit:
 			return
 		}
@@ -2730,6 +2747,28 @@ func (bc *BlockChain) SaveTrieNodeCacheToDisk() error {
 // for the transaction, gas used and an error if the transaction failed,
 // indicating the block was invalid.
 func (bc *BlockChain) ApplyTransaction(chainConfig *params.ChainConfig, author *common.Address, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, vmConfig *vm.Config) (*types.Receipt, *vm.InternalTxTrace, error) {
+	// bc is nil if the blocks are generated without a chain, e.g. by GenerateChain
+	var chain ChainContext
+	if bc != nil {
+		chain = bc
+	}
+	return ApplyTransaction(chainConfig, chain, author, statedb, header, tx, usedGas, vmConfig)
+}
+
+// ApplyTransaction is the same as BlockChain.ApplyTransaction except that the headers
+// of the ancestors are retrieved from the given chain context.
+func ApplyTransaction(chainConfig *params.ChainConfig, chain ChainContext, author *common.Address, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, vmConfig *vm.Config) (*types.Receipt, *vm.InternalTxTrace, error) {
+	receipt, internalTrace, err := applyTransaction(chainConfig, chain, author, statedb, header, tx, usedGas, vmConfig)
+	if receipt != nil {
+		// Create a bloom for filtering
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	}
+	return receipt, internalTrace, err
+}
+
+// applyTransaction is the same as ApplyTransaction except that the bloom of the receipt
+// is left empty, so that the blooms of a block can be derived concurrently afterwards.
+func applyTransaction(chainConfig *params.ChainConfig, chain ChainContext, author *common.Address, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, vmConfig *vm.Config) (*types.Receipt, *vm.InternalTxTrace, error) {
 	// TODO-Klaytn We reject transactions with unexpected gasPrice and do not put the transaction into TxPool.
 	//         And we run transactions regardless of gasPrice if we push transactions in the TxPool.
 	/*
@@ -2751,7 +2790,7 @@ func (bc *BlockChain) ApplyTransaction(chainConfig *params.ChainConfig, author *
 		return nil, nil, err
 	}
 	// Create a new context to be used in the EVM environment
-	blockContext := NewEVMBlockContext(header, bc, author)
+	blockContext := NewEVMBlockContextWithBurnRatio(header, chain, author)
 	txContext := NewEVMTxContext(msg, header)
 	// Create a new environment which holds all relevant information
 	// about the transaction and calling mechanisms.
@@ -2776,9 +2815,8 @@ func (bc *BlockChain) ApplyTransaction(chainConfig *params.ChainConfig, author *
 	receipt := types.NewReceipt(result.VmExecutionStatus, tx.Hash(), result.UsedGas)
 	// if the transaction created a contract, store the creation address in the receipt.
 	msg.FillContractAddress(vmenv.Origin, receipt)
-	// Set the receipt logs and create a bloom for filtering
+	// Set the receipt logs
 	receipt.Logs = statedb.GetLogs(tx.Hash())
-	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
 
 	return receipt, internalTrace, err
 }
@@ -2205,3 +2205,43 @@ func TestTransientStorageReset(t *testing.T) {
 		t.Fatalf("Unexpected dirty storage slot")
 	}
 }
+
+// TestGaslessChain checks that a gasless chain charges nothing for the gas even if the transaction has a price.
+func TestGaslessChain(t *testing.T) {
+	var (
+		db      = database.NewMemoryDBManager()
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(params.KLAY)
+		config  = params.TestChainConfig.Copy()
+		gspec   = &Genesis{
+			Config: config,
+			Alloc:  GenesisAlloc{address: {Balance: funds}},
+		}
+	)
+	config.Gasless = true
+
+	// the unit price must be zero
+	_, err := gspec.Commit(common.Hash{}, database.NewMemoryDBManager())
+	assert.Error(t, err)
+
+	config.UnitPrice = 0
+	genesis := gspec.MustCommit(db)
+
+	blockchain, _ := NewBlockChain(db, nil, gspec.Config, gxhash.NewFaker(), vm.Config{})
+	defer blockchain.Stop()
+
+	signer := types.LatestSignerForChainID(config.ChainID)
+	blocks, receipts := GenerateChain(config, genesis, gxhash.NewFaker(), db, 1, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), common.Address{1}, big.NewInt(1), 21000, big.NewInt(25*params.Ston), nil), signer, key)
+		require.NoError(t, err)
+		block.AddTx(tx)
+	})
+	_, err = blockchain.InsertChain(blocks)
+	require.NoError(t, err)
+	assert.Equal(t, params.TxGas, receipts[0][0].GasUsed)
+
+	state, err := blockchain.State()
+	require.NoError(t, err)
+	assert.Equal(t, new(big.Int).Sub(funds, big.NewInt(1)), state.GetBalance(address))
+}
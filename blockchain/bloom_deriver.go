@@ -0,0 +1,91 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+)
+
+// bloomDeriver is a concurrent receipt bloom deriver.
+var bloomDeriver = newReceiptBloomDeriver(calcNumSenderCachers())
+
+// receiptBloomDeriverRequest is a request for deriving the blooms of receipts.
+//
+// The inc field defines the number of receipts to skip after each derivation,
+// which is used to feed the same underlying input array to different threads.
+type receiptBloomDeriverRequest struct {
+	receipts types.Receipts
+	inc      int
+	done     *sync.WaitGroup
+}
+
+// receiptBloomDeriver is a helper structure to concurrently derive the blooms
+// of receipts on background threads, so that the hashing of logs overlaps with
+// the hashing and committing of the state.
+type receiptBloomDeriver struct {
+	threads int
+	tasks   chan *receiptBloomDeriverRequest
+}
+
+// newReceiptBloomDeriver creates a new receipt bloom deriver and starts the given
+// number of processing goroutines on construction.
+func newReceiptBloomDeriver(threads int) *receiptBloomDeriver {
+	deriver := &receiptBloomDeriver{
+		tasks:   make(chan *receiptBloomDeriverRequest, threads),
+		threads: threads,
+	}
+	for i := 0; i < threads; i++ {
+		go deriver.loop()
+	}
+	return deriver
+}
+
+// loop is an infinite loop, deriving the blooms of receipts.
+func (d *receiptBloomDeriver) loop() {
+	for task := range d.tasks {
+		for i := 0; i < len(task.receipts); i += task.inc {
+			receipt := task.receipts[i]
+			receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		}
+		task.done.Done()
+	}
+}
+
+// derive derives the blooms of the receipts from their logs in the background.
+// The receipts must not be read or written until the returned wait group is done.
+func (d *receiptBloomDeriver) derive(receipts types.Receipts) *sync.WaitGroup {
+	done := new(sync.WaitGroup)
+	if len(receipts) == 0 {
+		return done
+	}
+	// Ensure we have meaningful task sizes and schedule the derivations
+	tasks := d.threads
+	if len(receipts) < tasks*4 {
+		tasks = (len(receipts) + 3) / 4
+	}
+	done.Add(tasks)
+	for i := 0; i < tasks; i++ {
+		d.tasks <- &receiptBloomDeriverRequest{
+			receipts: receipts[i:],
+			inc:      tasks,
+			done:     done,
+		}
+	}
+	return done
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiptBloomDeriver(t *testing.T) {
+	deriver := newReceiptBloomDeriver(3)
+
+	for _, n := range []int{0, 1, 5, 12, 100} {
+		receipts := make(types.Receipts, n)
+		for i := range receipts {
+			receipts[i] = &types.Receipt{}
+			for j := 0; j < i%4; j++ {
+				receipts[i].Logs = append(receipts[i].Logs, &types.Log{
+					Address: common.BigToAddress(common.Big1),
+					Topics:  []common.Hash{common.BytesToHash([]byte{byte(i)}), common.BytesToHash([]byte{byte(j)})},
+				})
+			}
+		}
+		deriver.derive(receipts).Wait()
+
+		for i, receipt := range receipts {
+			assert.Equal(t, types.CreateBloom(types.Receipts{receipt}), receipt.Bloom, "receipts: %d, index: %d", n, i)
+		}
+	}
+}
+
+// TestProcess_ReceiptBlooms checks that the receipt blooms derived during the import
+// are the same as the ones derived while generating the blocks.
+func TestProcess_ReceiptBlooms(t *testing.T) {
+	db := database.NewMemoryDBManager()
+	gspec := Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  GenesisAlloc{benchRootAddr: {Balance: benchRootFunds}},
+	}
+	genesis := gspec.MustCommit(db)
+	blocks, receipts := GenerateChain(gspec.Config, genesis, gxhash.NewFaker(), db, 3, genLogTx(5))
+
+	chain, err := NewBlockChain(db, nil, gspec.Config, gxhash.NewFaker(), vm.Config{})
+	require.NoError(t, err)
+	defer chain.Stop()
+
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	for i, block := range blocks {
+		assert.Equal(t, types.CreateBloom(receipts[i]), block.Bloom())
+		assert.NotEqual(t, types.Bloom{}, block.Bloom())
+
+		imported := chain.GetReceiptsByBlockHash(block.Hash())
+		require.Len(t, imported, len(receipts[i]))
+		for j, receipt := range imported {
+			if i > 0 || j > 0 { // except the deployment of the emitter
+				assert.Len(t, receipt.Logs, 100)
+			}
+			assert.Equal(t, receipts[i][j].Bloom, receipt.Bloom)
+		}
+	}
+}
@@ -80,6 +80,14 @@ var (
 	// the balance of the fee payer's account.
 	ErrInsufficientFundsFeePayer = errors.New("insufficient funds of the fee payer for gas * price")
 
+	// ErrFeePayerAllowanceSender is returned if the fee payer of a fee-delegated transaction
+	// does not allow the sender in its allowance policy.
+	ErrFeePayerAllowanceSender = errors.New("sender not allowed by the allowance policy of the fee payer")
+
+	// ErrFeePayerAllowanceGasCap is returned if a fee-delegated transaction exceeds the daily
+	// gas cap of the allowance policy of the fee payer.
+	ErrFeePayerAllowanceGasCap = errors.New("exceeds the daily gas cap of the allowance policy of the fee payer")
+
 	// ErrIntrinsicGas is returned if the transaction is specified to use less gas
 	// than required to start the invocation.
 	ErrIntrinsicGas = errors.New("intrinsic gas too low")
@@ -121,6 +129,10 @@ var (
 	// ErrAccountCreationPrevented is returned if account creation is inserted in the service chain's txpool.
 	ErrAccountCreationPrevented = errors.New("account creation is prevented for the service chain")
 
+	// ErrTxAdmissionPaused is returned if the transaction pool does not admit new transactions
+	// while the node is under maintenance.
+	ErrTxAdmissionPaused = errors.New("transaction admission is paused for maintenance")
+
 	// ErrInvalidTracer is returned if the tracer type is not vm.InternalTxTracer
 	ErrInvalidTracer = errors.New("tracer type is invalid for internal transaction tracing")
 
@@ -145,4 +157,8 @@ var (
 
 	// ErrGasPriceBelowBaseFee is returned if gas price of transaction is lower than gas unit price.
 	ErrGasPriceBelowBaseFee = errors.New("invalid gas price. It must be set to value greater than or equal to baseFee")
+
+	// ErrGaslessSenderLimit is returned if the sender of a transaction has added too many transactions
+	// to the tx pool of a gasless chain since the last block.
+	ErrGaslessSenderLimit = errors.New("too many transactions from the sender in this block on the gasless chain")
 )
@@ -82,6 +82,23 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 	}
 }
 
+// NewEVMBlockContextWithBurnRatio is the same as NewEVMBlockContext except that the context
+// carries the burn ratio in effect at the block if the engine governs it, so that the
+// transactions of a block are executed with the burn ratio of the block even if it is
+// changed later. It must not be used to read the governance parameters, since the burn
+// ratio is read from them.
+func NewEVMBlockContextWithBurnRatio(header *types.Header, chain ChainContext, author *common.Address) vm.BlockContext {
+	blockContext := NewEVMBlockContext(header, chain, author)
+	if chain != nil {
+		if reader, ok := chain.Engine().(consensus.BurnRatioReader); ok {
+			if burnRatio, err := reader.BurnRatio(header.Number); err == nil {
+				blockContext.BurnRatio = &burnRatio
+			}
+		}
+	}
+	return blockContext
+}
+
 // NewEVMTxContext creates a new transaction context for a single transaction.
 func NewEVMTxContext(msg Message, header *types.Header) vm.TxContext {
 	effectiveGasPrice := msg.GasPrice()
@@ -102,6 +119,10 @@ func GetHashFn(ref *types.Header, chain ChainContext) func(n uint64) common.Hash
 	var cache []common.Hash
 
 	return func(n uint64) common.Hash {
+		// The reference can be lower than the block number of the context if it is overridden.
+		if ref.Number.Uint64() <= n {
+			return common.Hash{}
+		}
 		// If there's no hash cache yet, make one
 		if len(cache) == 0 {
 			cache = append(cache, ref.ParentHash)
@@ -142,7 +163,58 @@ func Transfer(db vm.StateDB, sender, recipient common.Address, amount *big.Int)
 	db.AddBalance(recipient, amount)
 }
 
+// GasFunds is the balances paying for a message whose fee can be delegated to a fee payer.
+type GasFunds struct {
+	Sender   *big.Int // the balance of the sender, which pays the value and its share of the fee
+	FeePayer *big.Int // the balance of the fee payer, nil if the fee is not delegated
+	FeeRatio uint8    // the percentage of the fee paid by the fee payer, 0 if it pays the whole fee
+}
+
+// feePayerRatio returns the percentage of the fee paid by the fee payer.
+func (f GasFunds) feePayerRatio() int64 {
+	switch {
+	case f.FeePayer == nil:
+		return 0
+	case f.FeeRatio == 0:
+		return 100
+	default:
+		return int64(f.FeeRatio)
+	}
+}
+
+// allowance returns the highest gas whose fee at the given gas price is fundable by both the
+// sender and the fee payer after the value is transferred.
+func (f GasFunds) allowance(txValue, gasPrice *big.Int) (*big.Int, error) {
+	available := new(big.Int).Sub(f.Sender, txValue)
+	ratio := f.feePayerRatio()
+	if available.Sign() < 0 || (ratio < 100 && available.Sign() == 0) {
+		return nil, errors.New("insufficient funds for transfer")
+	}
+
+	var allowance *big.Int
+	if ratio < 100 {
+		share := new(big.Int).Mul(gasPrice, big.NewInt(100-ratio))
+		allowance = new(big.Int).Div(available.Mul(available, big.NewInt(100)), share)
+	}
+	if ratio > 0 {
+		share := new(big.Int).Mul(gasPrice, big.NewInt(ratio))
+		payerAllowance := new(big.Int).Div(new(big.Int).Mul(f.FeePayer, big.NewInt(100)), share)
+		if allowance == nil || payerAllowance.Cmp(allowance) < 0 {
+			allowance = payerAllowance
+		}
+	}
+	return allowance, nil
+}
+
+// DoEstimateGas estimates the gas of a message whose fee is paid by the sender with the given balance.
 func DoEstimateGas(ctx context.Context, gasLimit, rpcGasCap uint64, txValue, gasPrice, balance *big.Int, test func(gas uint64) (bool, *ExecutionResult, error)) (hexutil.Uint64, error) {
+	return DoEstimateGasWithFunds(ctx, gasLimit, rpcGasCap, txValue, gasPrice, GasFunds{Sender: balance}, test)
+}
+
+// DoEstimateGasWithFunds binary searches the lowest gas with which the test succeeds. The search
+// is bounded above by the gas fundable by the given funds and the caps, and below by the gas
+// used when the message is executed with the highest allowance.
+func DoEstimateGasWithFunds(ctx context.Context, gasLimit, rpcGasCap uint64, txValue, gasPrice *big.Int, funds GasFunds, test func(gas uint64) (bool, *ExecutionResult, error)) (hexutil.Uint64, error) {
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
 		lo  uint64 = params.TxGas - 1
@@ -157,27 +229,25 @@ func DoEstimateGas(ctx context.Context, gasLimit, rpcGasCap uint64, txValue, gas
 	if gasPrice == nil {
 		gasPrice = big.NewInt(0)
 	}
-	if balance == nil {
-		balance = big.NewInt(0)
+	if funds.Sender == nil {
+		funds.Sender = big.NewInt(0)
 	}
 
 	if gasLimit >= params.TxGas {
 		hi = gasLimit
 	}
 
-	// recap the highest gas limit with account's available balance.
+	// recap the highest gas limit with the available balances of the sender and the fee payer.
 	if gasPrice.BitLen() != 0 {
-		available := new(big.Int).Set(balance)
-		if txValue.Cmp(available) >= 0 {
-			return 0, errors.New("insufficient funds for transfer")
+		allowance, err := funds.allowance(txValue, gasPrice)
+		if err != nil {
+			return 0, err
 		}
-		available.Sub(available, txValue)
-		allowance := new(big.Int).Div(available, gasPrice)
 
 		// If the allowance is larger than maximum uint64, skip checking
 		if allowance.IsUint64() && hi > allowance.Uint64() {
-			logger.Warn("Gas estimation capped by limited funds", "original", hi, "balance", balance,
-				"sent", txValue, "maxFeePerGas", gasPrice, "fundable", allowance)
+			logger.Warn("Gas estimation capped by limited funds", "original", hi, "balance", funds.Sender, "feePayerBalance", funds.FeePayer,
+				"feeRatio", funds.FeeRatio, "sent", txValue, "maxFeePerGas", gasPrice, "fundable", allowance)
 			hi = allowance.Uint64()
 		}
 	}
@@ -188,6 +258,43 @@ func DoEstimateGas(ctx context.Context, gasLimit, rpcGasCap uint64, txValue, gas
 	}
 	cap = hi
 
+	// Reject the transaction as invalid if it fails at the highest allowance
+	failed, result, err := test(hi)
+	if err != nil {
+		return 0, err
+	}
+	if failed {
+		if result != nil && result.VmExecutionStatus != types.ReceiptStatusErrOutOfGas {
+			if len(result.Revert()) > 0 {
+				return 0, NewRevertError(result)
+			}
+			return 0, result.Unwrap()
+		}
+		// Otherwise, the specified gas cap is too low
+		return 0, fmt.Errorf("gas required exceeds allowance (%d)", cap)
+	}
+
+	if result != nil {
+		// Any gas less than the used gas runs out of gas before the refund.
+		if result.UsedGas > lo+1 {
+			lo = result.UsedGas - 1
+		}
+		// The requirement is usually slightly above the used gas due to the refund and the 63/64
+		// rule of the calls, so try the optimistic limit first to narrow the search.
+		optimistic := (result.UsedGas + params.CallStipend) * 64 / 63
+		if lo < optimistic && optimistic < hi {
+			failed, _, err := test(optimistic)
+			if err != nil {
+				return 0, err
+			}
+			if failed {
+				lo = optimistic
+			} else {
+				hi = optimistic
+			}
+		}
+	}
+
 	// Execute the binary search and hone in on an executable gas limit
 	for lo+1 < hi {
 		mid := (hi + lo) / 2
@@ -202,23 +309,6 @@ func DoEstimateGas(ctx context.Context, gasLimit, rpcGasCap uint64, txValue, gas
 			hi = mid
 		}
 	}
-	// Reject the transaction as invalid if it still fails at the highest allowance
-	if hi == cap {
-		failed, result, err := test(hi)
-		if err != nil {
-			return 0, err
-		}
-		if failed {
-			if result != nil && result.VmExecutionStatus != types.ReceiptStatusErrOutOfGas {
-				if len(result.Revert()) > 0 {
-					return 0, NewRevertError(result)
-				}
-				return 0, result.Unwrap()
-			}
-			// Otherwise, the specified gas cap is too low
-			return 0, fmt.Errorf("gas required exceeds allowance (%d)", cap)
-		}
-	}
 	return hexutil.Uint64(hi), nil
 }
 
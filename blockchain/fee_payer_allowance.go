@@ -0,0 +1,137 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+const (
+	// The storage slots of the mappings of the allowance registry contract.
+	// See contracts/feepayer_allowance/FeePayerAllowance.sol.
+	allowancePoliciesSlot       = 0
+	allowanceAllowedSendersSlot = 1
+
+	secondsPerDay = 24 * 60 * 60
+)
+
+// feePayerPolicy is the allowance policy a fee payer published in the registry.
+type feePayerPolicy struct {
+	enabled         bool
+	allowAllSenders bool
+	dailyGasCap     uint64 // zero means no cap
+}
+
+// feePayerUsage is the gas of the transactions admitted for a fee payer on a day.
+type feePayerUsage struct {
+	day uint64
+	gas uint64
+}
+
+// feePayerAllowance checks the fee-delegated transactions against the allowance policies
+// published by their fee payers in the allowance registry contract. The daily gas usage
+// is accounted by the txpool itself, so the cap applies to each node separately.
+type feePayerAllowance struct {
+	registry common.Address
+	usage    map[common.Address]*feePayerUsage
+}
+
+func newFeePayerAllowance(registry common.Address) *feePayerAllowance {
+	return &feePayerAllowance{
+		registry: registry,
+		usage:    make(map[common.Address]*feePayerUsage),
+	}
+}
+
+// policy reads the policy of the fee payer from the registry storage.
+func (a *feePayerAllowance) policy(st *state.StateDB, feePayer common.Address) feePayerPolicy {
+	base := mappingSlot(feePayer.Hash(), common.BigToHash(big.NewInt(allowancePoliciesSlot)))
+	flags := st.GetState(a.registry, base)
+	gasCap := st.GetState(a.registry, common.BigToHash(new(big.Int).Add(base.Big(), common.Big1))).Big()
+	policy := feePayerPolicy{
+		enabled:         flags[common.HashLength-1] != 0,
+		allowAllSenders: flags[common.HashLength-2] != 0,
+		dailyGasCap:     gasCap.Uint64(),
+	}
+	if !gasCap.IsUint64() {
+		policy.dailyGasCap = 0 // a cap not fitting in uint64 never limits
+	}
+	return policy
+}
+
+// senderAllowed reads whether the fee payer allowed the sender in the registry storage.
+func (a *feePayerAllowance) senderAllowed(st *state.StateDB, feePayer, sender common.Address) bool {
+	inner := mappingSlot(feePayer.Hash(), common.BigToHash(big.NewInt(allowanceAllowedSendersSlot)))
+	return st.GetState(a.registry, mappingSlot(sender.Hash(), inner))[common.HashLength-1] != 0
+}
+
+// check returns an error if the transaction of the sender with the given gas violates the
+// policy of the fee payer at the block time.
+func (a *feePayerAllowance) check(st *state.StateDB, feePayer, sender common.Address, gas, time uint64) error {
+	if feePayer == sender {
+		return nil
+	}
+	policy := a.policy(st, feePayer)
+	if !policy.enabled {
+		return nil
+	}
+	if !policy.allowAllSenders && !a.senderAllowed(st, feePayer, sender) {
+		return ErrFeePayerAllowanceSender
+	}
+	if policy.dailyGasCap > 0 && a.used(feePayer, time)+gas > policy.dailyGasCap {
+		return ErrFeePayerAllowanceGasCap
+	}
+	return nil
+}
+
+// used returns the gas admitted for the fee payer on the day of the block time.
+func (a *feePayerAllowance) used(feePayer common.Address, time uint64) uint64 {
+	if usage := a.usage[feePayer]; usage != nil && usage.day == time/secondsPerDay {
+		return usage.gas
+	}
+	return 0
+}
+
+// consume adds the gas of an admitted transaction to the usage of the fee payer.
+func (a *feePayerAllowance) consume(feePayer common.Address, gas, time uint64) {
+	day := time / secondsPerDay
+	usage := a.usage[feePayer]
+	if usage == nil || usage.day != day {
+		usage = &feePayerUsage{day: day}
+		a.usage[feePayer] = usage
+	}
+	usage.gas += gas
+}
+
+// prune removes the usages of the past days.
+func (a *feePayerAllowance) prune(time uint64) {
+	day := time / secondsPerDay
+	for feePayer, usage := range a.usage {
+		if usage.day != day {
+			delete(a.usage, feePayer)
+		}
+	}
+}
+
+// mappingSlot returns the storage slot of the mapping element, keccak256(key . slot).
+func mappingSlot(key, slot common.Hash) common.Hash {
+	return crypto.Keccak256Hash(key.Bytes(), slot.Bytes())
+}
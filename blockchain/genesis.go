@@ -239,6 +239,9 @@ func SetupGenesisBlock(db database.DBManager, genesis *Genesis, networkId uint64
 	if err := newcfg.CheckConfigForkOrder(); err != nil {
 		return newcfg, common.Hash{}, err
 	}
+	if err := newcfg.CheckGasless(); err != nil {
+		return newcfg, common.Hash{}, err
+	}
 	storedcfg := db.ReadChainConfig(stored)
 	if storedcfg == nil {
 		logger.Info("Found genesis block without chain config")
@@ -366,6 +369,9 @@ func (g *Genesis) Commit(baseStateRoot common.Hash, db database.DBManager) (*typ
 	if err := config.CheckConfigForkOrder(); err != nil {
 		return nil, err
 	}
+	if err := config.CheckGasless(); err != nil {
+		return nil, err
+	}
 	db.WriteChainConfig(block.Hash(), config)
 	return block, nil
 }
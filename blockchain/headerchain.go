@@ -213,6 +213,9 @@ func (hc *HeaderChain) ValidateHeaderChain(chain []*types.Header, checkFreq int)
 	if hc.engine.CanVerifyHeadersConcurrently() {
 		abort, results = hc.engine.VerifyHeaders(hc, chain, seals)
 	} else {
+		if verifier, ok := hc.engine.(consensus.CheckpointVerifier); ok {
+			verifier.VerifyCheckpoints(hc, chain)
+		}
 		abort, results = hc.engine.PreprocessHeaderVerification(chain)
 	}
 	defer close(abort)
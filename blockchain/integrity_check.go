@@ -0,0 +1,227 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+)
+
+// IntegrityCheckMode decides how thoroughly the chain data is verified on startup.
+type IntegrityCheckMode string
+
+const (
+	IntegrityCheckOff    IntegrityCheckMode = "off"    // no verification
+	IntegrityCheckSample IntegrityCheckMode = "sample" // verify the head and some sampled recent blocks, and only warn on issues
+	IntegrityCheckStrict IntegrityCheckMode = "strict" // verify every recent block, and refuse to start on issues
+)
+
+const (
+	// DefaultIntegrityCheckDepth is the number of recent blocks covered by the integrity check.
+	DefaultIntegrityCheckDepth = 128
+	// DefaultIntegrityCheckSamples is the number of blocks sampled in IntegrityCheckSample mode.
+	DefaultIntegrityCheckSamples = 16
+)
+
+// Names of the checks reported in IntegrityIssue.
+const (
+	IntegrityCheckHeader     = "header"
+	IntegrityCheckBody       = "body"
+	IntegrityCheckReceipts   = "receipts"
+	IntegrityCheckState      = "state"
+	IntegrityCheckGovernance = "governance-index"
+	IntegrityCheckStaking    = "staking-snapshot"
+)
+
+// ParseIntegrityCheckMode converts the given string into IntegrityCheckMode.
+func ParseIntegrityCheckMode(mode string) (IntegrityCheckMode, error) {
+	switch m := IntegrityCheckMode(mode); m {
+	case IntegrityCheckOff, IntegrityCheckSample, IntegrityCheckStrict:
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid integrity check mode %q, it should be one of %q, %q or %q",
+		mode, IntegrityCheckStrict, IntegrityCheckSample, IntegrityCheckOff)
+}
+
+// IntegrityIssue describes a corruption found by the integrity check.
+type IntegrityIssue struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	Check  string      `json:"check"`
+	Detail string      `json:"detail"`
+}
+
+// IntegrityReport is the structured result of the integrity check.
+type IntegrityReport struct {
+	Mode    IntegrityCheckMode `json:"mode"`
+	Head    uint64             `json:"head"`
+	Checked []uint64           `json:"checked"` // numbers of the verified blocks in ascending order
+	Issues  []IntegrityIssue   `json:"issues"`
+	Elapsed time.Duration      `json:"elapsed"`
+}
+
+// OK reports whether no issue has been found.
+func (r *IntegrityReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// AddIssue appends an issue to the report.
+func (r *IntegrityReport) AddIssue(number uint64, hash common.Hash, check, detail string) {
+	r.Issues = append(r.Issues, IntegrityIssue{Number: number, Hash: hash, Check: check, Detail: detail})
+}
+
+// Log prints the report. Issues are logged as errors in strict mode and as warnings otherwise.
+func (r *IntegrityReport) Log() {
+	for _, issue := range r.Issues {
+		ctx := []interface{}{"check", issue.Check, "number", issue.Number, "hash", issue.Hash, "detail", issue.Detail}
+		if r.Mode == IntegrityCheckStrict {
+			logger.Error("Chain data integrity issue", ctx...)
+		} else {
+			logger.Warn("Chain data integrity issue", ctx...)
+		}
+	}
+	logger.Info("Chain data integrity check finished", "mode", r.Mode, "head", r.Head,
+		"checked", len(r.Checked), "issues", len(r.Issues), "elapsed", r.Elapsed)
+}
+
+// VerifyIntegrity cross-checks the headers, bodies and receipts of recent blocks, the state of
+// the head block and the governance index history to catch silent disk corruption.
+// It should be called after InitDeriveShaWithGov, since the roots are recomputed with DeriveSha.
+func (bc *BlockChain) VerifyIntegrity(mode IntegrityCheckMode, depth uint64) *IntegrityReport {
+	start := time.Now()
+	head := bc.CurrentBlock()
+	report := &IntegrityReport{Mode: mode, Head: head.NumberU64()}
+	if mode == IntegrityCheckOff {
+		return report
+	}
+	if depth == 0 {
+		depth = DefaultIntegrityCheckDepth
+	}
+
+	report.Checked = integrityCheckTargets(mode, head.NumberU64(), depth)
+	for _, number := range report.Checked {
+		bc.verifyBlockIntegrity(report, number)
+	}
+
+	if !bc.HasState(head.Root()) {
+		report.AddIssue(head.NumberU64(), head.Hash(), IntegrityCheckState,
+			fmt.Sprintf("missing state trie of root %s", head.Root().String()))
+	}
+	bc.verifyGovernanceIndex(report, head.NumberU64())
+
+	report.Elapsed = time.Since(start)
+	return report
+}
+
+// integrityCheckTargets returns the block numbers to be verified in ascending order.
+// The head and its parent are always included.
+func integrityCheckTargets(mode IntegrityCheckMode, head, depth uint64) []uint64 {
+	lowest := uint64(0)
+	if head+1 > depth {
+		lowest = head + 1 - depth
+	}
+
+	if mode == IntegrityCheckStrict || head-lowest+1 <= DefaultIntegrityCheckSamples {
+		targets := make([]uint64, 0, head-lowest+1)
+		for n := lowest; n <= head; n++ {
+			targets = append(targets, n)
+		}
+		return targets
+	}
+
+	picked := map[uint64]struct{}{head: {}, head - 1: {}}
+	for len(picked) < DefaultIntegrityCheckSamples {
+		picked[lowest+uint64(rand.Int63n(int64(head-lowest+1)))] = struct{}{}
+	}
+	targets := make([]uint64, 0, len(picked))
+	for n := range picked {
+		targets = append(targets, n)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+	return targets
+}
+
+// verifyBlockIntegrity checks that the header, body and receipts of the canonical block
+// stored in the database are consistent with each other.
+func (bc *BlockChain) verifyBlockIntegrity(report *IntegrityReport, number uint64) {
+	hash := bc.db.ReadCanonicalHash(number)
+	if common.EmptyHash(hash) {
+		report.AddIssue(number, hash, IntegrityCheckHeader, "missing canonical hash")
+		return
+	}
+	header := bc.db.ReadHeader(hash, number)
+	if header == nil {
+		report.AddIssue(number, hash, IntegrityCheckHeader, "missing header")
+		return
+	}
+	if header.Hash() != hash {
+		report.AddIssue(number, hash, IntegrityCheckHeader, fmt.Sprintf("header hash mismatch, have %s", header.Hash().String()))
+		return
+	}
+	if number > 0 {
+		if parent := bc.db.ReadCanonicalHash(number - 1); header.ParentHash != parent {
+			report.AddIssue(number, hash, IntegrityCheckHeader,
+				fmt.Sprintf("parent hash mismatch, have %s, canonical %s", header.ParentHash.String(), parent.String()))
+		}
+	}
+
+	body := bc.db.ReadBody(hash, number)
+	if body == nil {
+		report.AddIssue(number, hash, IntegrityCheckBody, "missing body")
+		return
+	}
+	if txHash := types.DeriveSha(types.Transactions(body.Transactions), header.Number); txHash != header.TxHash {
+		report.AddIssue(number, hash, IntegrityCheckBody,
+			fmt.Sprintf("transaction root mismatch, have %s, header %s", txHash.String(), header.TxHash.String()))
+	}
+
+	receipts := bc.db.ReadReceipts(hash, number)
+	if len(receipts) != len(body.Transactions) {
+		report.AddIssue(number, hash, IntegrityCheckReceipts,
+			fmt.Sprintf("receipt count mismatch, have %d, transactions %d", len(receipts), len(body.Transactions)))
+		return
+	}
+	if receiptHash := types.DeriveSha(receipts, header.Number); receiptHash != header.ReceiptHash {
+		report.AddIssue(number, hash, IntegrityCheckReceipts,
+			fmt.Sprintf("receipt root mismatch, have %s, header %s", receiptHash.String(), header.ReceiptHash.String()))
+	}
+}
+
+// verifyGovernanceIndex checks that the governance index history, which the reward and
+// governance parameters are read from, is strictly increasing and does not exceed the head.
+func (bc *BlockChain) verifyGovernanceIndex(report *IntegrityReport, head uint64) {
+	// ReadRecentGovernanceIdx sorts the indices, so duplicated indices are the only sign of a broken order.
+	indices, err := bc.db.ReadRecentGovernanceIdx(0)
+	if err != nil {
+		// A chain without governance data has no index history.
+		return
+	}
+	for i, idx := range indices {
+		if i > 0 && idx == indices[i-1] {
+			report.AddIssue(idx, common.Hash{}, IntegrityCheckGovernance, "duplicated governance index")
+		}
+		if idx > head {
+			report.AddIssue(idx, common.Hash{}, IntegrityCheckGovernance,
+				fmt.Sprintf("governance index beyond the head block %d", head))
+		}
+	}
+}
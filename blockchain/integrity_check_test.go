@@ -0,0 +1,85 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIntegrityCheckMode(t *testing.T) {
+	for _, mode := range []string{"off", "sample", "strict"} {
+		m, err := ParseIntegrityCheckMode(mode)
+		assert.NoError(t, err)
+		assert.Equal(t, IntegrityCheckMode(mode), m)
+	}
+	_, err := ParseIntegrityCheckMode("full")
+	assert.Error(t, err)
+}
+
+func TestIntegrityCheckTargets(t *testing.T) {
+	// strict mode covers the whole window
+	targets := integrityCheckTargets(IntegrityCheckStrict, 200, 128)
+	assert.Len(t, targets, 128)
+	assert.Equal(t, uint64(73), targets[0])
+	assert.Equal(t, uint64(200), targets[len(targets)-1])
+
+	// sample mode always includes the head and its parent
+	targets = integrityCheckTargets(IntegrityCheckSample, 200, 128)
+	assert.Len(t, targets, DefaultIntegrityCheckSamples)
+	assert.Equal(t, []uint64{199, 200}, targets[len(targets)-2:])
+	for _, n := range targets {
+		assert.True(t, n >= 73 && n <= 200)
+	}
+
+	// a short chain is fully verified even in sample mode
+	assert.Equal(t, []uint64{0, 1, 2}, integrityCheckTargets(IntegrityCheckSample, 2, 128))
+}
+
+func TestBlockChain_VerifyIntegrity(t *testing.T) {
+	db, bc, err := newCanonical(gxhash.NewFaker(), 10, true)
+	require.NoError(t, err)
+	defer bc.Stop()
+
+	report := bc.VerifyIntegrity(IntegrityCheckOff, 0)
+	assert.True(t, report.OK())
+	assert.Empty(t, report.Checked)
+
+	report = bc.VerifyIntegrity(IntegrityCheckStrict, 0)
+	assert.True(t, report.OK(), "issues: %v", report.Issues)
+	assert.Len(t, report.Checked, 11)
+	assert.Equal(t, uint64(10), report.Head)
+
+	// corrupt the receipts and the body of recent blocks
+	block5, block7 := bc.GetBlockByNumber(5), bc.GetBlockByNumber(7)
+	db.WriteReceipts(block5.Hash(), 5, types.Receipts{&types.Receipt{Status: types.ReceiptStatusSuccessful}})
+	db.DeleteBody(block7.Hash(), 7)
+
+	report = bc.VerifyIntegrity(IntegrityCheckStrict, 0)
+	require.Len(t, report.Issues, 2)
+	assert.Equal(t, IntegrityIssue{Number: 5, Hash: block5.Hash(), Check: IntegrityCheckReceipts, Detail: "receipt count mismatch, have 1, transactions 0"}, report.Issues[0])
+	assert.Equal(t, IntegrityIssue{Number: 7, Hash: block7.Hash(), Check: IntegrityCheckBody, Detail: "missing body"}, report.Issues[1])
+
+	// only the recent blocks within the depth are verified
+	report = bc.VerifyIntegrity(IntegrityCheckStrict, 3)
+	assert.True(t, report.OK(), "issues: %v", report.Issues)
+	assert.Equal(t, []uint64{8, 9, 10}, report.Checked)
+}
@@ -21,6 +21,7 @@
 package blockchain
 
 import (
+	metricutils "github.com/klaytn/klaytn/metrics/utils"
 	"github.com/rcrowley/go-metrics"
 )
 
@@ -38,7 +39,9 @@ var (
 	blockTxCountsGauge   = metrics.NewRegisteredGauge("blockchain/block/tx/gauge", nil)
 	blockTxCountsCounter = metrics.NewRegisteredCounter("blockchain/block/tx/counter", nil)
 	// the counter to record a bad block, increases 1 if bad block occurs
-	badBlockCounter = metrics.NewRegisteredCounter("blockchain/bad/block/counter", nil)
+	badBlockCounter = metricutils.NewRegisteredPersistentCounter("blockchain/bad/block/counter")
+	// the counter to record a chain reorganization, increases 1 if the canonical chain is replaced
+	reorgCounter = metricutils.NewRegisteredPersistentCounter("blockchain/reorg/counter")
 
 	txPoolPendingGauge = metrics.NewRegisteredGauge("tx/pool/pending/gauge", nil)
 	txPoolQueueGauge   = metrics.NewRegisteredGauge("tx/pool/queue/gauge", nil)
@@ -0,0 +1,103 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// BundleVersion is the version of the bundle format written by this package.
+const BundleVersion = 1
+
+var errUnsupportedVersion = errors.New("unsupported replay bundle version")
+
+// Account is an account of the parent state read by the block.
+type Account struct {
+	Address common.Address
+	Data    []byte // RLP encoded account, empty if the account does not exist
+	Code    []byte
+}
+
+// Slot is a storage slot of the parent state read by the block.
+type Slot struct {
+	Address common.Address
+	Key     common.Hash
+	Value   common.Hash
+}
+
+// Result is the outcome of a block execution.
+type Result struct {
+	GasUsed     uint64
+	ReceiptHash common.Hash
+
+	// StateRoot is the root of the partial state made of the accounts the block
+	// modified. It is only comparable between the executions of the same bundle.
+	StateRoot common.Hash
+
+	// Err is the error which made the block invalid, empty if the block was executed.
+	Err string
+}
+
+// Bundle holds everything needed to execute a block again. The chain config, the
+// governance parameters and the staking information are kept in JSON, which is their
+// canonical encoding in the APIs and the genesis.
+type Bundle struct {
+	Version     uint64
+	ChainConfig []byte // JSON encoded params.ChainConfig
+	Params      []byte // JSON encoded governance parameters effective at the block
+	StakingInfo []byte // JSON encoded reward.StakingInfo, empty if not used by the block
+
+	Block     *types.Block
+	Author    common.Address
+	Ancestors []*types.Header
+	Accounts  []Account
+	Storage   []Slot
+
+	// Captured is the result observed by the node which captured the bundle.
+	Captured Result
+}
+
+// WriteFile writes the RLP encoded bundle to the file.
+func (b *Bundle) WriteFile(file string) error {
+	enc, err := rlp.EncodeToBytes(b)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, enc, 0o644)
+}
+
+// ReadFile reads the bundle written by WriteFile.
+func ReadFile(file string) (*Bundle, error) {
+	enc, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	b := new(Bundle)
+	if err := rlp.DecodeBytes(enc, b); err != nil {
+		return nil, fmt.Errorf("invalid replay bundle: %v", err)
+	}
+	if b.Version != BundleVersion {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedVersion, b.Version)
+	}
+	return b, nil
+}
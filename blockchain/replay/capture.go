@@ -0,0 +1,215 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// Chain is the chain which the bundles are captured from.
+type Chain interface {
+	blockchain.ChainContext
+	Config() *params.ChainConfig
+	StateAt(root common.Hash) (*state.StateDB, error)
+}
+
+// Governance provides the governance parameters effective at a block.
+type Governance interface {
+	EffectiveParams(num uint64) (*params.GovParamSet, error)
+}
+
+// Capture executes the block on the state of its parent and records the parts of the
+// parent state and the ancestor headers read by the execution into a bundle. The block
+// does not need to be in the chain, so the bad blocks can be captured as well as long as
+// their parent state is available.
+func Capture(chain Chain, gov Governance, block *types.Block) (*Bundle, error) {
+	header := block.Header()
+	if header.Number.Sign() == 0 {
+		return nil, fmt.Errorf("the genesis block cannot be replayed")
+	}
+	config := chain.Config()
+	if err := checkSupported(config, header); err != nil {
+		return nil, err
+	}
+	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent block #%d not found", header.Number.Uint64()-1)
+	}
+	parentState, err := chain.StateAt(parent.Root)
+	if err != nil {
+		return nil, fmt.Errorf("parent state of block #%d not available: %v", header.Number.Uint64(), err)
+	}
+	author, err := chain.Engine().Author(header)
+	if err != nil {
+		return nil, err
+	}
+	pset, err := gov.EffectiveParams(header.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	var stakingInfo *reward.StakingInfo
+	if reward.GetRewardPolicy(header.Number, pset).UseStakingInfo() {
+		stakingInfo = reward.GetStakingInfo(header.Number.Uint64())
+	}
+
+	recorder := newStateRecorder(parentState)
+	st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	st.SetRemoteState(recorder)
+	headers := &headerRecorder{chain: chain, headers: make(map[common.Hash]*types.Header)}
+	result, _ := execute(config, headers, author, st, block, pset, stakingInfo)
+
+	b := &Bundle{
+		Version:  BundleVersion,
+		Block:    block,
+		Author:   author,
+		Captured: *result,
+	}
+	if b.ChainConfig, err = json.Marshal(config); err != nil {
+		return nil, err
+	}
+	if b.Params, err = json.Marshal(pset.StrMap()); err != nil {
+		return nil, err
+	}
+	if stakingInfo != nil {
+		if b.StakingInfo, err = json.Marshal(stakingInfo); err != nil {
+			return nil, err
+		}
+	}
+	if b.Accounts, b.Storage, err = recorder.slice(); err != nil {
+		return nil, err
+	}
+	b.Ancestors = headers.ancestors()
+	return b, nil
+}
+
+// stateRecorder implements state.RemoteState by reading the parent state, and records
+// the accounts and the storage slots read.
+type stateRecorder struct {
+	parent   *state.StateDB
+	accounts map[common.Address]account.Account
+	storage  map[common.Address]map[common.Hash]common.Hash
+}
+
+func newStateRecorder(parent *state.StateDB) *stateRecorder {
+	return &stateRecorder{
+		parent:   parent,
+		accounts: make(map[common.Address]account.Account),
+		storage:  make(map[common.Address]map[common.Hash]common.Hash),
+	}
+}
+
+func (r *stateRecorder) Account(addr common.Address) (account.Account, []byte, error) {
+	var acc account.Account
+	if parentAcc := r.parent.GetAccount(addr); parentAcc != nil {
+		acc = parentAcc.DeepCopy()
+	}
+	r.accounts[addr] = acc
+	if acc == nil {
+		return nil, nil, nil
+	}
+	return acc.DeepCopy(), r.parent.GetCode(addr), r.parent.Error()
+}
+
+func (r *stateRecorder) Storage(addr common.Address, key common.Hash) (common.Hash, error) {
+	value := r.parent.GetState(addr, key)
+	if r.storage[addr] == nil {
+		r.storage[addr] = make(map[common.Hash]common.Hash)
+	}
+	r.storage[addr][key] = value
+	return value, r.parent.Error()
+}
+
+// slice returns the recorded accounts and storage slots sorted by the address and the key.
+func (r *stateRecorder) slice() ([]Account, []Slot, error) {
+	accounts := make([]Account, 0, len(r.accounts))
+	for addr, acc := range r.accounts {
+		entry := Account{Address: addr}
+		if acc != nil {
+			data, err := rlp.EncodeToBytes(account.NewAccountSerializerWithAccount(acc))
+			if err != nil {
+				return nil, nil, err
+			}
+			entry.Data = data
+			entry.Code = r.parent.GetCode(addr)
+		}
+		accounts = append(accounts, entry)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].Address[:], accounts[j].Address[:]) < 0
+	})
+
+	var storage []Slot
+	for addr, slots := range r.storage {
+		for key, value := range slots {
+			storage = append(storage, Slot{Address: addr, Key: key, Value: value})
+		}
+	}
+	sort.Slice(storage, func(i, j int) bool {
+		if c := bytes.Compare(storage[i].Address[:], storage[j].Address[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(storage[i].Key[:], storage[j].Key[:]) < 0
+	})
+	return accounts, storage, nil
+}
+
+// headerRecorder serves the headers of the chain and records the headers served.
+type headerRecorder struct {
+	chain   blockchain.ChainContext
+	headers map[common.Hash]*types.Header
+}
+
+func (r *headerRecorder) Engine() consensus.Engine {
+	return r.chain.Engine()
+}
+
+func (r *headerRecorder) GetHeader(hash common.Hash, number uint64) *types.Header {
+	header := r.chain.GetHeader(hash, number)
+	if header != nil {
+		r.headers[hash] = header
+	}
+	return header
+}
+
+// ancestors returns the recorded headers sorted by the block number.
+func (r *headerRecorder) ancestors() []*types.Header {
+	headers := make([]*types.Header, 0, len(r.headers))
+	for _, header := range r.headers {
+		headers = append(headers, header)
+	}
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].Number.Cmp(headers[j].Number) < 0
+	})
+	return headers
+}
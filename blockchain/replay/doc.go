@@ -0,0 +1,30 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package replay implements the replay bundles which make a block execution reproducible
+without the datadir of the node which executed it. A bundle holds the block, the slice of
+the parent state touched by the block, the ancestor headers read by BLOCKHASH, the chain
+config, the governance parameters and the staking information applied to the block, and
+the result observed by the node which captured it.
+
+Source Files
+
+  - bundle.go  : defines Bundle and its file format
+  - capture.go : implements Capture which records the execution inputs of a block from a chain
+  - replay.go  : implements Replay which executes a bundle and the execution shared with Capture
+*/
+package replay
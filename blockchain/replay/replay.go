@@ -0,0 +1,255 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/fork"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+var (
+	errUnsupportedBlock = errors.New("the block calls the system contracts, which is not supported by the replay")
+	errMissingAccount   = errors.New("account not in the replay bundle")
+	errMissingSlot      = errors.New("storage slot not in the replay bundle")
+)
+
+// Replay executes the block of the bundle on the state slice of the bundle. The bundle
+// chain config is installed as the hard fork config of the process, so a bundle should
+// be replayed in a process which does not run a node.
+func Replay(b *Bundle) (*Result, types.Receipts, error) {
+	config := new(params.ChainConfig)
+	if err := json.Unmarshal(b.ChainConfig, config); err != nil {
+		return nil, nil, fmt.Errorf("invalid chain config: %v", err)
+	}
+	var items map[string]interface{}
+	if err := json.Unmarshal(b.Params, &items); err != nil {
+		return nil, nil, fmt.Errorf("invalid governance parameters: %v", err)
+	}
+	pset, err := params.NewGovParamSetStrMap(items)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid governance parameters: %v", err)
+	}
+	var stakingInfo *reward.StakingInfo
+	if len(b.StakingInfo) > 0 {
+		stakingInfo = new(reward.StakingInfo)
+		if err := json.Unmarshal(b.StakingInfo, stakingInfo); err != nil {
+			return nil, nil, fmt.Errorf("invalid staking info: %v", err)
+		}
+	}
+
+	if err := fork.SetHardForkBlockNumberConfig(config); err != nil {
+		return nil, nil, err
+	}
+	blockchain.InitDeriveShaWithGov(config, &paramsGov{pset})
+
+	remote, err := newBundleState(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	st.SetRemoteState(remote)
+
+	chain := &bundleChain{headers: make(map[common.Hash]*types.Header, len(b.Ancestors))}
+	for _, header := range b.Ancestors {
+		chain.headers[header.Hash()] = header
+	}
+	result, receipts := execute(config, chain, b.Author, st, b.Block, pset, stakingInfo)
+	return result, receipts, nil
+}
+
+// Verify compares the result of a replay with the block header and the captured result.
+// It returns the list of the differences, which is empty if the replay matches both.
+func Verify(b *Bundle, result *Result) []string {
+	var diffs []string
+	header := b.Block.Header()
+	if result.Err != "" {
+		diffs = append(diffs, fmt.Sprintf("execution failed: %s", result.Err))
+	} else {
+		if result.GasUsed != header.GasUsed {
+			diffs = append(diffs, fmt.Sprintf("gas used %d, header has %d", result.GasUsed, header.GasUsed))
+		}
+		if result.ReceiptHash != header.ReceiptHash {
+			diffs = append(diffs, fmt.Sprintf("receipt hash %s, header has %s", result.ReceiptHash.Hex(), header.ReceiptHash.Hex()))
+		}
+	}
+	captured := b.Captured
+	if result.Err != captured.Err {
+		diffs = append(diffs, fmt.Sprintf("error %q, captured %q", result.Err, captured.Err))
+	}
+	if result.GasUsed != captured.GasUsed {
+		diffs = append(diffs, fmt.Sprintf("gas used %d, captured %d", result.GasUsed, captured.GasUsed))
+	}
+	if result.ReceiptHash != captured.ReceiptHash {
+		diffs = append(diffs, fmt.Sprintf("receipt hash %s, captured %s", result.ReceiptHash.Hex(), captured.ReceiptHash.Hex()))
+	}
+	if result.StateRoot != captured.StateRoot {
+		diffs = append(diffs, fmt.Sprintf("state root %s, captured %s", result.StateRoot.Hex(), captured.StateRoot.Hex()))
+	}
+	return diffs
+}
+
+// execute applies the transactions and the block reward of the block to the state the
+// same way as the block processing and the istanbul Finalize do.
+func execute(config *params.ChainConfig, chain blockchain.ChainContext, author common.Address, st *state.StateDB,
+	block *types.Block, pset *params.GovParamSet, stakingInfo *reward.StakingInfo,
+) (*Result, types.Receipts) {
+	var (
+		header   = block.Header()
+		receipts types.Receipts
+		usedGas  uint64
+		vmConfig = &vm.Config{UseOpcodeComputationCost: true}
+	)
+	for i, tx := range block.Transactions() {
+		st.SetTxContext(tx.Hash(), block.Hash(), i)
+		receipt, _, err := blockchain.ApplyTransaction(config, chain, &author, st, header, tx, &usedGas, vmConfig)
+		if err != nil {
+			return &Result{GasUsed: usedGas, Err: fmt.Sprintf("tx %d (%s): %v", i, tx.Hash().Hex(), err)}, receipts
+		}
+		receipts = append(receipts, receipt)
+	}
+	result := &Result{GasUsed: usedGas, ReceiptHash: types.DeriveSha(receipts, header.Number)}
+
+	var (
+		spec  *reward.RewardSpec
+		err   error
+		rules = config.Rules(header.Number)
+	)
+	spec, err = reward.GetRewardPolicy(header.Number, pset).CalcDeferredReward(header, rules, pset, stakingInfo)
+	if err != nil {
+		result.Err = err.Error()
+		return result, receipts
+	}
+	reward.DistributeBlockReward(st, spec.Rewards)
+
+	if err := st.Error(); err != nil {
+		result.Err = err.Error()
+		return result, receipts
+	}
+	result.StateRoot = st.IntermediateRoot(true)
+	return result, receipts
+}
+
+// checkSupported returns an error if the block runs the logic of a hard fork block
+// which calls the system contracts through the chain, which is not captured.
+func checkSupported(config *params.ChainConfig, header *types.Header) error {
+	if config.IsKIP103ForkBlock(header.Number) || config.IsRandaoForkBlockParent(header.Number) {
+		return errUnsupportedBlock
+	}
+	return nil
+}
+
+// paramsGov serves the governance parameters of a bundle regardless of the block number.
+type paramsGov struct {
+	pset *params.GovParamSet
+}
+
+func (g *paramsGov) EffectiveParams(num uint64) (*params.GovParamSet, error) {
+	return g.pset, nil
+}
+
+// bundleChain serves the ancestor headers of a bundle.
+type bundleChain struct {
+	headers map[common.Hash]*types.Header
+}
+
+// Engine returns nil, since the author of the block is given explicitly.
+func (c *bundleChain) Engine() consensus.Engine {
+	return nil
+}
+
+func (c *bundleChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header, ok := c.headers[hash]; ok && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+
+type bundleAccount struct {
+	acc  account.Account // nil if the account does not exist
+	code []byte
+}
+
+// bundleState implements state.RemoteState with the state slice of a bundle. Reading an
+// account or a slot which was not read at the capture is an error, since it means the
+// replay diverged from the captured execution.
+type bundleState struct {
+	accounts map[common.Address]*bundleAccount
+	storage  map[common.Address]map[common.Hash]common.Hash
+}
+
+func newBundleState(b *Bundle) (*bundleState, error) {
+	s := &bundleState{
+		accounts: make(map[common.Address]*bundleAccount, len(b.Accounts)),
+		storage:  make(map[common.Address]map[common.Hash]common.Hash),
+	}
+	for _, a := range b.Accounts {
+		entry := &bundleAccount{code: a.Code}
+		if len(a.Data) > 0 {
+			serializer := account.NewAccountSerializer()
+			if err := rlp.DecodeBytes(a.Data, serializer); err != nil {
+				return nil, fmt.Errorf("invalid account %s: %v", a.Address.Hex(), err)
+			}
+			entry.acc = serializer.GetAccount()
+		}
+		s.accounts[a.Address] = entry
+	}
+	for _, slot := range b.Storage {
+		if s.storage[slot.Address] == nil {
+			s.storage[slot.Address] = make(map[common.Hash]common.Hash)
+		}
+		s.storage[slot.Address][slot.Key] = slot.Value
+	}
+	return s, nil
+}
+
+// Account returns a copy of the account, since the account is modified by the state
+// which loads it.
+func (s *bundleState) Account(addr common.Address) (account.Account, []byte, error) {
+	entry, ok := s.accounts[addr]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", errMissingAccount, addr.Hex())
+	}
+	if entry.acc == nil {
+		return nil, nil, nil
+	}
+	return entry.acc.DeepCopy(), common.CopyBytes(entry.code), nil
+}
+
+func (s *bundleState) Storage(addr common.Address, key common.Hash) (common.Hash, error) {
+	value, ok := s.storage[addr][key]
+	if !ok {
+		return common.Hash{}, fmt.Errorf("%w: %s %s", errMissingSlot, addr.Hex(), key.Hex())
+	}
+	return value, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockHashStorer is the init code which stores the hash of the grandparent block at the
+// slot 0, which is looked up through the header of the parent block.
+var blockHashStorer = common.FromHex("0x43600290034060005500")
+
+// newTestChain returns a chain whose blocks transfer the values, and the third block
+// deploys a contract reading BLOCKHASH.
+func newTestChain(t *testing.T) (*blockchain.BlockChain, *params.GovParamSet) {
+	config := *params.TestChainConfig
+	config.Istanbul = params.GetDefaultIstanbulConfig()
+	config.Governance = params.GetDefaultGovernanceConfig()
+	pset, err := params.NewGovParamSetChainConfig(&config)
+	require.NoError(t, err)
+	blockchain.InitDeriveShaWithGov(&config, &paramsGov{pset})
+
+	var (
+		key, _ = crypto.GenerateKey()
+		from   = crypto.PubkeyToAddress(key.PublicKey)
+		to     = common.HexToAddress("0x1234")
+		signer = types.LatestSignerForChainID(config.ChainID)
+		gspec  = &blockchain.Genesis{Config: &config, Alloc: blockchain.GenesisAlloc{from: {Balance: big.NewInt(params.KLAY)}}}
+		engine = gxhash.NewFaker()
+		db     = database.NewMemoryDBManager()
+	)
+	gspec.MustCommit(db)
+	cacheConfig := &blockchain.CacheConfig{
+		CacheSize:           512,
+		BlockInterval:       blockchain.DefaultBlockInterval,
+		TriesInMemory:       blockchain.DefaultTriesInMemory,
+		TrieNodeCacheConfig: statedb.GetEmptyTrieNodeCacheConfig(),
+		SnapshotCacheSize:   512,
+		ArchiveMode:         true,
+	}
+	chain, err := blockchain.NewBlockChain(db, cacheConfig, &config, engine, vm.Config{})
+	require.NoError(t, err)
+	t.Cleanup(chain.Stop)
+
+	// The blocks are generated one by one on the chain, since BLOCKHASH needs the chain.
+	for i := 0; i < 3; i++ {
+		blocks, _ := blockchain.GenerateChain(&config, chain.CurrentBlock(), engine, db, 1, func(_ int, b *blockchain.BlockGen) {
+			nonce := b.TxNonce(from)
+			tx := types.NewTransaction(nonce, to, big.NewInt(1000), params.TxGas, big.NewInt(1), nil)
+			if i == 2 {
+				tx = types.NewContractCreation(nonce, common.Big0, 100000, big.NewInt(1), blockHashStorer)
+			}
+			signed, err := types.SignTx(tx, signer, key)
+			require.NoError(t, err)
+			b.AddTxWithChain(chain, signed)
+		})
+		_, err = chain.InsertChain(blocks)
+		require.NoError(t, err)
+	}
+	return chain, pset
+}
+
+func TestCaptureAndReplay(t *testing.T) {
+	chain, pset := newTestChain(t)
+	block := chain.GetBlockByNumber(3)
+
+	b, err := Capture(chain, &paramsGov{pset}, block)
+	require.NoError(t, err)
+	assert.Empty(t, b.Captured.Err)
+	assert.Equal(t, block.GasUsed(), b.Captured.GasUsed)
+	assert.Equal(t, block.ReceiptHash(), b.Captured.ReceiptHash)
+	assert.NotEmpty(t, b.Accounts)
+	// The parent header is the only ancestor read to resolve BLOCKHASH.
+	require.Len(t, b.Ancestors, 1)
+	assert.Equal(t, block.ParentHash(), b.Ancestors[0].Hash())
+
+	file := filepath.Join(t.TempDir(), "bundle.bin")
+	require.NoError(t, b.WriteFile(file))
+	read, err := ReadFile(file)
+	require.NoError(t, err)
+
+	result, receipts, err := Replay(read)
+	require.NoError(t, err)
+	assert.Equal(t, b.Captured, *result)
+	assert.Len(t, receipts, len(block.Transactions()))
+	assert.Empty(t, Verify(read, result))
+}
+
+func TestReplay_Divergence(t *testing.T) {
+	chain, pset := newTestChain(t)
+	b, err := Capture(chain, &paramsGov{pset}, chain.GetBlockByNumber(2))
+	require.NoError(t, err)
+
+	// A captured result which differs from the replay is reported.
+	b.Captured.GasUsed++
+	result, _, err := Replay(b)
+	require.NoError(t, err)
+	assert.Len(t, Verify(b, result), 1)
+
+	// An account which was not read at the capture fails the replay.
+	b.Accounts = b.Accounts[1:]
+	result, _, err = Replay(b)
+	require.NoError(t, err)
+	assert.Contains(t, result.Err, errMissingAccount.Error())
+	assert.NotEmpty(t, Verify(b, result))
+}
+
+func TestReadFile_Version(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "bundle.bin")
+	b := &Bundle{Version: BundleVersion + 1, Block: types.NewBlockWithHeader(&types.Header{Number: common.Big1})}
+	require.NoError(t, b.WriteFile(file))
+
+	_, err := ReadFile(file)
+	assert.True(t, errors.Is(err, errUnsupportedVersion))
+}
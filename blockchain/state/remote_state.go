@@ -0,0 +1,70 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+// RemoteState provides the accounts and the storage of a remote state which the local
+// state is forked from. It is consulted only for the accounts and the storage slots that
+// have never been written locally, so the remote state is loaded lazily.
+//
+// A forked state must not be committed, since the objects loaded from the remote state
+// are kept only in the memory of the StateDB and its copies.
+type RemoteState interface {
+	// Account returns the account and its code, or a nil account if it does not exist.
+	Account(addr common.Address) (account.Account, []byte, error)
+
+	// Storage returns the value of the storage slot of the account.
+	Storage(addr common.Address, key common.Hash) (common.Hash, error)
+}
+
+// SetRemoteState sets the remote state which the accounts and the storage missing in the
+// trie are loaded from.
+func (s *StateDB) SetRemoteState(remote RemoteState) {
+	s.remote = remote
+}
+
+// getRemoteStateObject loads the account from the remote state and inserts it into the
+// live set. It returns nil if the account does not exist in the remote state.
+func (s *StateDB) getRemoteStateObject(addr common.Address) *stateObject {
+	acc, code, err := s.remote.Account(addr)
+	if err != nil {
+		s.setError(err)
+		return nil
+	}
+	if acc == nil {
+		return nil
+	}
+	obj := newObject(s, addr, acc)
+	obj.remote = true
+	if pa := account.GetProgramAccount(acc); pa != nil {
+		// The remote storage trie is not available locally, so an empty storage trie is
+		// opened and the slots are loaded on demand.
+		pa.SetStorageRoot(common.ExtHash{})
+		if len(code) > 0 {
+			pa.SetCodeHash(crypto.Keccak256(code))
+			obj.code = code
+			obj.dirtyCode = true
+		}
+	}
+	s.setStateObject(obj)
+	return obj
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRemoteState struct {
+	accounts map[common.Address]account.Account
+	codes    map[common.Address][]byte
+	storage  map[common.Address]map[common.Hash]common.Hash
+	reads    int
+}
+
+func (r *testRemoteState) Account(addr common.Address) (account.Account, []byte, error) {
+	r.reads++
+	acc, ok := r.accounts[addr]
+	if !ok {
+		return nil, nil, nil
+	}
+	return acc.DeepCopy(), r.codes[addr], nil
+}
+
+func (r *testRemoteState) Storage(addr common.Address, key common.Hash) (common.Hash, error) {
+	r.reads++
+	return r.storage[addr][key], nil
+}
+
+func TestStateDB_RemoteState(t *testing.T) {
+	var (
+		eoa      = common.HexToAddress("0x1")
+		contract = common.HexToAddress("0x2")
+		code     = []byte{0x60, 0x00}
+		slot1    = common.HexToHash("0x1")
+		slot2    = common.HexToHash("0x2")
+	)
+	eoaAcc, err := account.NewAccountWithMap(account.ExternallyOwnedAccountType, map[account.AccountValueKeyType]interface{}{
+		account.AccountValueKeyNonce:      uint64(3),
+		account.AccountValueKeyBalance:    big.NewInt(100),
+		account.AccountValueKeyAccountKey: accountkey.NewAccountKeyLegacy(),
+	})
+	require.NoError(t, err)
+	contractAcc, err := account.NewAccountWithMap(account.SmartContractAccountType, map[account.AccountValueKeyType]interface{}{
+		account.AccountValueKeyNonce:       uint64(1),
+		account.AccountValueKeyBalance:     big.NewInt(0),
+		account.AccountValueKeyAccountKey:  accountkey.NewAccountKeyFail(),
+		account.AccountValueKeyStorageRoot: common.HexToHash("0x1234"), // not available locally
+		account.AccountValueKeyCodeHash:    crypto.Keccak256(code),
+		account.AccountValueKeyCodeInfo:    params.CodeInfo(0),
+	})
+	require.NoError(t, err)
+	remote := &testRemoteState{
+		accounts: map[common.Address]account.Account{eoa: eoaAcc, contract: contractAcc},
+		codes:    map[common.Address][]byte{contract: code},
+		storage:  map[common.Address]map[common.Hash]common.Hash{contract: {slot1: common.HexToHash("0xa"), slot2: common.HexToHash("0xb")}},
+	}
+
+	state, err := New(common.Hash{}, NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	require.NoError(t, err)
+	state.SetRemoteState(remote)
+
+	// the accounts and the storage are loaded lazily
+	assert.Equal(t, big.NewInt(100), state.GetBalance(eoa))
+	assert.Equal(t, uint64(3), state.GetNonce(eoa))
+	assert.Equal(t, code, state.GetCode(contract))
+	assert.Equal(t, common.HexToHash("0xa"), state.GetState(contract, slot1))
+	assert.False(t, state.Exist(common.HexToAddress("0x3")))
+	reads := remote.reads
+	assert.Equal(t, big.NewInt(100), state.GetBalance(eoa))
+	assert.Equal(t, common.HexToHash("0xa"), state.GetState(contract, slot1))
+	assert.Equal(t, reads, remote.reads)
+
+	// the local changes take precedence over the remote state across the transactions
+	state.SetBalance(eoa, big.NewInt(5))
+	state.SetState(contract, slot1, common.Hash{})
+	state.SetState(contract, slot2, common.HexToHash("0xc"))
+	state.IntermediateRoot(true)
+	require.NoError(t, state.Error())
+	copied := state.Copy()
+	for _, s := range []*StateDB{state, copied} {
+		assert.Equal(t, big.NewInt(5), s.GetBalance(eoa))
+		assert.Equal(t, common.Hash{}, s.GetState(contract, slot1))
+		assert.Equal(t, common.HexToHash("0xc"), s.GetState(contract, slot2))
+		assert.Equal(t, code, s.GetCode(contract))
+	}
+
+	// the self-destructed account is not resurrected by the remote state
+	state.SelfDestruct(eoa)
+	state.IntermediateRoot(true)
+	assert.False(t, state.Exist(eoa))
+	assert.False(t, state.Copy().Exist(eoa))
+}
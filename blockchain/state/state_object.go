@@ -110,6 +110,10 @@ type stateObject struct {
 	// Flag whether the object was created in the current transaction
 	created bool
 
+	// Flag whether the object was loaded from the remote state. The storage slots missing
+	// in the storage trie of such an object are loaded from the remote state as well.
+	remote bool
+
 	encoded atomic.Value // RLP-encoded data
 }
 
@@ -254,6 +258,14 @@ func (s *stateObject) GetCommittedState(db Database, key common.Hash) common.Has
 			return common.Hash{}
 		}
 	}
+	if len(enc) == 0 && s.remote && s.db.remote != nil {
+		// The slot has never been written locally, so the value of the remote state is used.
+		if value, err = s.db.remote.Storage(s.address, key); err != nil {
+			s.setError(err)
+		}
+		s.originStorage[key] = value
+		return value
+	}
 	if len(enc) > 0 {
 		_, content, _, err := rlp.Split(enc)
 		if err != nil {
@@ -478,6 +490,7 @@ func (s *stateObject) deepCopy(db *StateDB) *stateObject {
 	stateObject.selfDestructed = s.selfDestructed
 	stateObject.dirtyCode = s.dirtyCode
 	stateObject.deleted = s.deleted
+	stateObject.remote = s.remote
 	return stateObject
 }
 
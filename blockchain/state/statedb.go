@@ -106,6 +106,9 @@ type StateDB struct {
 
 	prefetching bool
 
+	// remote provides the accounts and the storage missing in the trie, if the state is forked.
+	remote RemoteState
+
 	// Measurements gathered during execution for debugging purposes
 	AccountReads         time.Duration
 	AccountHashes        time.Duration
@@ -651,6 +654,9 @@ func (s *StateDB) getDeletedStateObject(addr common.Address) *stateObject {
 		enc, err := s.trie.TryGet(addr[:])
 		if len(enc) == 0 {
 			s.setError(err)
+			if err == nil && s.remote != nil {
+				return s.getRemoteStateObject(addr)
+			}
 			return nil
 		}
 		serializer := account.NewAccountSerializer()
@@ -839,6 +845,7 @@ func (s *StateDB) Copy() *StateDB {
 		logSize:           s.logSize,
 		preimages:         make(map[common.Hash][]byte),
 		journal:           newJournal(),
+		remote:            s.remote,
 	}
 	// Copy the dirty states, logs, and preimages
 	for addr := range s.journal.dirties {
@@ -82,7 +82,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
 		statedb.SetTxContext(tx.Hash(), block.Hash(), i)
-		receipt, internalTxTrace, err := p.bc.ApplyTransaction(p.config, &author, statedb, header, tx, usedGas, &cfg)
+		receipt, internalTxTrace, err := applyTransaction(p.config, p.bc, &author, statedb, header, tx, usedGas, &cfg)
 		if err != nil {
 			return nil, nil, 0, nil, processStats, err
 		}
@@ -92,6 +92,12 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	}
 	processStats.AfterApplyTxs = time.Now()
 
+	// Derive the receipt blooms in the background while hashing the state changes of the transactions,
+	// which leaves only the changes made by Finalize to be hashed.
+	blooms := bloomDeriver.derive(receipts)
+	statedb.IntermediateRoot(true)
+	blooms.Wait()
+
 	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
 	if _, err := p.engine.Finalize(p.bc, header, statedb, block.Transactions(), receipts); err != nil {
 		return nil, nil, 0, nil, processStats, err
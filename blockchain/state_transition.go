@@ -28,6 +28,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/math"
 	"github.com/klaytn/klaytn/kerrors"
 	"github.com/klaytn/klaytn/params"
 )
@@ -190,6 +191,10 @@ func NewStateTransition(evm *vm.EVM, msg Message) *StateTransition {
 	// before magma hardfork, effectiveGasPrice is GasPrice of tx
 	// after magma hardfork, effectiveGasPrice is BaseFee
 	effectiveGasPrice := evm.GasPrice
+	// a gasless chain charges nothing for the gas regardless of the price of the transaction
+	if evm.ChainConfig().Gasless {
+		effectiveGasPrice = common.Big0
+	}
 
 	return &StateTransition{
 		evm:       evm,
@@ -225,7 +230,9 @@ func (st *StateTransition) to() common.Address {
 func (st *StateTransition) buyGas() error {
 	// st.gasPrice : gasPrice user set before magma hardfork
 	// st.gasPrice : BaseFee after magma hardfork
-	mgval := new(big.Int).Mul(new(big.Int).SetUint64(st.msg.Gas()), st.gasPrice)
+	arena := math.NewBigArena()
+	defer arena.Free()
+	mgval := arena.New().Mul(arena.NewUint64(st.msg.Gas()), st.gasPrice)
 
 	validatedFeePayer := st.msg.ValidatedFeePayer()
 	validatedSender := st.msg.ValidatedSender()
@@ -365,6 +372,10 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	if vmerr == vm.ErrTotalTimeLimitReached {
 		return nil, vm.ErrTotalTimeLimitReached
 	}
+	// Likewise, the computation cost budget is set only for the RPC calls, so it is not a vm error.
+	if vmerr == vm.ErrComputationCostBudgetExceeded {
+		return nil, vm.ErrComputationCostBudgetExceeded
+	}
 
 	if rules.IsKore {
 		// After EIP-3529: refunds are capped to gasUsed / 5
@@ -374,16 +385,23 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 		st.refundGas(params.RefundQuotient)
 	}
 
-	// Defer transferring Tx fee when DeferredTxFee is true
-	if st.evm.ChainConfig().Governance == nil || !st.evm.ChainConfig().Governance.DeferredTxFee() {
+	// Defer transferring Tx fee when DeferredTxFee is true, and no Tx fee on a gasless chain
+	config := st.evm.ChainConfig()
+	if !config.Gasless && (config.Governance == nil || !config.Governance.DeferredTxFee()) {
+		arena := math.NewBigArena()
 		if rules.IsMagma {
 			effectiveGasPrice := st.gasPrice
-			txFee := getBurnAmountMagma(new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), effectiveGasPrice))
-			st.state.AddBalance(st.evm.Context.Rewardbase, txFee)
+			txFee := arena.New().Mul(arena.NewUint64(st.gasUsed()), effectiveGasPrice)
+			burnRatio := config.Governance.BurnRatio()
+			if st.evm.Context.BurnRatio != nil {
+				burnRatio = *st.evm.Context.BurnRatio
+			}
+			st.state.AddBalance(st.evm.Context.Rewardbase, getRewardAmountMagma(txFee, txFee, burnRatio))
 		} else {
 			effectiveGasPrice := msg.EffectiveGasPrice(nil)
-			st.state.AddBalance(st.evm.Context.Coinbase, new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), effectiveGasPrice))
+			st.state.AddBalance(st.evm.Context.Coinbase, arena.New().Mul(arena.NewUint64(st.gasUsed()), effectiveGasPrice))
 		}
+		arena.Free()
 	}
 
 	return &ExecutionResult{
@@ -467,7 +485,9 @@ func (st *StateTransition) refundGas(refundQuotient uint64) {
 	st.gas += refund
 
 	// Return KLAY for remaining gas, exchanged at the original rate.
-	remaining := new(big.Int).Mul(new(big.Int).SetUint64(st.gas), st.gasPrice)
+	arena := math.NewBigArena()
+	defer arena.Free()
+	remaining := arena.New().Mul(arena.NewUint64(st.gas), st.gasPrice)
 
 	validatedFeePayer := st.msg.ValidatedFeePayer()
 	validatedSender := st.msg.ValidatedSender()
@@ -488,6 +508,9 @@ func (st *StateTransition) gasUsed() uint64 {
 	return st.initialGas - st.gas
 }
 
-func getBurnAmountMagma(fee *big.Int) *big.Int {
-	return new(big.Int).Div(fee, big.NewInt(2))
+// getRewardAmountMagma sets z to the part of the fee which is not burnt, and returns z.
+// The burnt part is the remainder so that the half is paid if the burn ratio is 50.
+func getRewardAmountMagma(z, fee *big.Int, burnRatio uint64) *big.Int {
+	z.Mul(fee, new(big.Int).SetUint64(100-burnRatio))
+	return z.Div(z, common.Big100)
 }
@@ -19,6 +19,7 @@ package blockchain
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/klaytn/klaytn/blockchain/types"
@@ -64,6 +65,25 @@ func TestGetReceiptStatusFromVMerr(t *testing.T) {
 	}
 }
 
+func TestGetRewardAmountMagma(t *testing.T) {
+	testData := []struct {
+		fee       int64
+		burnRatio uint64
+		reward    int64
+	}{
+		{1001, 50, 500}, // the remainder is burnt
+		{1000, 0, 1000},
+		{1000, 30, 700},
+		{1000, 100, 0},
+		{0, 50, 0},
+	}
+
+	for _, tc := range testData {
+		fee := big.NewInt(tc.fee)
+		assert.Equal(t, tc.reward, getRewardAmountMagma(fee, fee, tc.burnRatio).Int64())
+	}
+}
+
 // TestPrintErrorCodeTable prints the error code table in a format of a markdown table.
 func TestPrintErrorCodeTable(t *testing.T) {
 	if testing.Verbose() {
@@ -48,6 +48,13 @@ var (
 	// Some system contracts are allocated at special addresses.
 	AddressBookAddr = common.HexToAddress("0x0000000000000000000000000000000000000400") // TODO: replace contracts/reward/contract/utils.go
 	RegistryAddr    = common.HexToAddress("0x0000000000000000000000000000000000000401")
+	// RewardLedgerAddr keeps the block rewards failed to be paid to the contract recipients after the RewardPayout fork.
+	RewardLedgerAddr = common.HexToAddress("0x0000000000000000000000000000000000000404")
+	// RemainderCarryAddr keeps the rounding remainder of the block reward carried over to the next block by the carry remainder policy.
+	// The amount carried over is kept in its storage, not by its balance.
+	RemainderCarryAddr = common.HexToAddress("0x0000000000000000000000000000000000000405")
+	// RewardVestingAddr keeps the block rewards of the vesting recipients locked after the RewardVesting fork.
+	RewardVestingAddr = common.HexToAddress("0x0000000000000000000000000000000000000406")
 	// The following addresses are only used for testing.
 	Kip113ProxyAddrMock = common.HexToAddress("0x0000000000000000000000000000000000000402")
 	Kip113LogicAddrMock = common.HexToAddress("0x0000000000000000000000000000000000000403")
@@ -21,5 +21,7 @@ Package system deals with system contracts in Klaytn.
 System contracts are smart contracts that affects the protocol.
 
 - Registry: Stores the canonical system contract addresses.
+- RewardLedger: Keeps the block rewards failed to be paid to the contract recipients until claimed.
+- RewardVesting: Keeps the block rewards of the vesting recipients locked until vested.
 
 */
@@ -0,0 +1,51 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package system
+
+import (
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+)
+
+// Storage layout of the KIP-103 TreasuryRebalance contract (contracts/kip103).
+// Slot 0 is the owner, and slots 1 and 2 are the retirees and the newbies.
+const (
+	kip103StatusSlot = 3
+	kip103MemoSlot   = 5
+)
+
+// Kip103StatusFinalized is the Finalized value of TreasuryRebalance.Status.
+const Kip103StatusFinalized = uint8(3)
+
+// ReadKip103Status returns the status of the KIP-103 contract.
+func ReadKip103Status(state *state.StateDB, contract common.Address) uint8 {
+	return state.GetState(contract, lpad32(kip103StatusSlot)).Bytes()[common.HashLength-1]
+}
+
+// ReadKip103Memo returns the memo of the KIP-103 contract, which is empty until finalized.
+func ReadKip103Memo(state *state.StateDB, contract common.Address) []byte {
+	return readDynamicData(state, contract, kip103MemoSlot)
+}
+
+// WriteKip103Memo records the memo and finalizes the KIP-103 contract,
+// the same as the owner calling finalizeContract(memo).
+func WriteKip103Memo(state *state.StateDB, contract common.Address, memo []byte) {
+	for slot, value := range allocDynamicData(kip103MemoSlot, memo) {
+		state.SetState(contract, slot, value)
+	}
+	state.SetState(contract, lpad32(kip103StatusSlot), lpad32(uint64(Kip103StatusFinalized)))
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package system
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKip103Memo(t *testing.T) {
+	addr := common.HexToAddress("0x1030")
+	for _, memo := range []string{
+		"",
+		"rebalanced",
+		strings.Repeat("a", 31),
+		strings.Repeat("b", 32),
+		strings.Repeat("c", 100),
+	} {
+		state, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+		assert.Empty(t, ReadKip103Memo(state, addr))
+		assert.Equal(t, uint8(0), ReadKip103Status(state, addr))
+
+		WriteKip103Memo(state, addr, []byte(memo))
+		assert.Equal(t, memo, string(ReadKip103Memo(state, addr)))
+		assert.Equal(t, Kip103StatusFinalized, ReadKip103Status(state, addr))
+	}
+}
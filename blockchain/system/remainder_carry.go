@@ -0,0 +1,59 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package system
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/params"
+)
+
+// RemainderCarryCode is the runtime code installed at RemainderCarryAddr. The carry is only
+// managed by the protocol, so any call to it reverts.
+//
+//	00 PUSH1 0 DUP1 REVERT
+var RemainderCarryCode = hexutil.MustDecode("0x600080fd")
+
+// remainderCarrySlot keeps the carried remainder. The slot is only written when a block is
+// finalized, so the KLAY sent to RemainderCarryAddr by other means is never carried in.
+var remainderCarrySlot = common.Hash{}
+
+// ReadCarriedRemainder returns the remainder carried over to the next block.
+func ReadCarriedRemainder(state *state.StateDB) *big.Int {
+	return state.GetState(RemainderCarryAddr, remainderCarrySlot).Big()
+}
+
+// SettleRemainderCarry withdraws the remainder carried in, which is shared by the stakers of
+// the block, and keeps the one carried over to the next block. If KLAY was sent to the address
+// before the code is installed, the account is recreated as a smart contract keeping the balance.
+func SettleRemainderCarry(state *state.StateDB, rules params.Rules, carriedIn, carriedOver *big.Int) error {
+	if state.GetCodeSize(RemainderCarryAddr) == 0 {
+		if !state.IsProgramAccount(RemainderCarryAddr) {
+			state.CreateSmartContractAccount(RemainderCarryAddr, params.CodeFormatEVM, rules)
+		}
+		if err := state.SetCode(RemainderCarryAddr, RemainderCarryCode); err != nil {
+			return err
+		}
+	}
+	state.SubBalance(RemainderCarryAddr, carriedIn)
+	state.AddBalance(RemainderCarryAddr, carriedOver)
+	state.SetState(RemainderCarryAddr, remainderCarrySlot, common.BigToHash(carriedOver))
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package system
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+// RewardLedgerCode is the runtime code of the reward ledger installed at RewardLedgerAddr.
+// The ledger keeps mapping(address => uint256) claimable at slot 0. Any call to the ledger
+// pays the whole claimable amount of the caller with all the remaining gas, and reverts if
+// a value is sent, if nothing is claimable, or if the payment fails.
+//
+//	00 CALLVALUE ISZERO PUSH1 0x09 JUMPI PUSH1 0 DUP1 REVERT
+//	09 JUMPDEST CALLER PUSH1 0 MSTORE PUSH1 0 PUSH1 0x20 MSTORE PUSH1 0x40 PUSH1 0 SHA3 // slot = keccak256(caller . 0)
+//	18 DUP1 SLOAD DUP1 PUSH1 0x22 JUMPI PUSH1 0 DUP1 REVERT                            // amount = sload(slot), require(amount != 0)
+//	22 JUMPDEST PUSH1 0 DUP3 SSTORE                                                    // sstore(slot, 0)
+//	27 PUSH1 0 DUP1 DUP1 DUP1 DUP5 CALLER GAS CALL PUSH1 0x37 JUMPI PUSH1 0 DUP1 REVERT // require(caller.call{value: amount}(""))
+//	37 JUMPDEST STOP
+var RewardLedgerCode = hexutil.MustDecode("0x3415600957600080fd5b3360005260006020526040600020805480602257600080fd5b60008255600080808084335af1603757600080fd5b00")
+
+// InstallRewardLedger installs the reward ledger code if it is not installed yet.
+func InstallRewardLedger(state *state.StateDB) error {
+	if state.GetCodeSize(RewardLedgerAddr) > 0 {
+		return nil
+	}
+	return state.SetCode(RewardLedgerAddr, RewardLedgerCode)
+}
+
+// ReadClaimableReward returns the amount the recipient can claim from the reward ledger.
+func ReadClaimableReward(state *state.StateDB, recipient common.Address) *big.Int {
+	return state.GetState(RewardLedgerAddr, calcMappingSlot(0, recipient, 0)).Big()
+}
+
+// AddClaimableReward records that the recipient can claim the amount more from the reward ledger.
+// The amount must be already held by the ledger.
+func AddClaimableReward(state *state.StateDB, recipient common.Address, amount *big.Int) {
+	claimable := new(big.Int).Add(ReadClaimableReward(state, recipient), amount)
+	state.SetState(RewardLedgerAddr, calcMappingSlot(0, recipient, 0), common.BigToHash(claimable))
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package system
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+// RewardVestingCode is the runtime code of the vesting vault installed at RewardVestingAddr.
+// The vault is only managed by the protocol, so any call to it reverts. The code keeps the
+// vault from being deleted as an empty account while nothing is locked.
+//
+//	00 PUSH1 0 DUP1 REVERT
+var RewardVestingCode = hexutil.MustDecode("0x600080fd")
+
+// The vault keeps mapping(address => Vesting) at slot 0, where
+//
+//	struct Vesting { Tranche[2] tranches; uint256 vested; }
+//	struct Tranche { uint256 period; uint256 amount; uint256 released; }
+//
+// The tranche of a period is kept at the index of the parity of the period, since a tranche
+// is fully vested by the end of the next period.
+const (
+	vestingTrancheSize   = 3
+	vestingVestedOffset  = 2 * vestingTrancheSize
+	vestingPeriodField   = 0
+	vestingAmountField   = 1
+	vestingReleasedField = 2
+)
+
+// VestingTranche is the rewards of a recipient locked in a period of blocks, which are vested
+// linearly over the next period.
+type VestingTranche struct {
+	Period   uint64   `json:"period"`
+	Amount   *big.Int `json:"amount"`
+	Released *big.Int `json:"released"`
+}
+
+// VestingStatus is the rewards of a recipient vested and locked in the vesting vault.
+type VestingStatus struct {
+	Vested   *big.Int          `json:"vested"` // the total rewards released to the recipient
+	Locked   *big.Int          `json:"locked"` // the rewards not released yet
+	Tranches []*VestingTranche `json:"tranches"`
+}
+
+func vestingSlot(recipient common.Address, offset int) common.Hash {
+	return calcMappingSlot(0, recipient, offset)
+}
+
+func readVestingTranche(state *state.StateDB, recipient common.Address, period uint64) *VestingTranche {
+	base := int(period%2) * vestingTrancheSize
+	return &VestingTranche{
+		Period:   state.GetState(RewardVestingAddr, vestingSlot(recipient, base+vestingPeriodField)).Big().Uint64(),
+		Amount:   state.GetState(RewardVestingAddr, vestingSlot(recipient, base+vestingAmountField)).Big(),
+		Released: state.GetState(RewardVestingAddr, vestingSlot(recipient, base+vestingReleasedField)).Big(),
+	}
+}
+
+func writeVestingTranche(state *state.StateDB, recipient common.Address, tranche *VestingTranche) {
+	base := int(tranche.Period%2) * vestingTrancheSize
+	state.SetState(RewardVestingAddr, vestingSlot(recipient, base+vestingPeriodField), common.BigToHash(new(big.Int).SetUint64(tranche.Period)))
+	state.SetState(RewardVestingAddr, vestingSlot(recipient, base+vestingAmountField), common.BigToHash(tranche.Amount))
+	state.SetState(RewardVestingAddr, vestingSlot(recipient, base+vestingReleasedField), common.BigToHash(tranche.Released))
+}
+
+// release credits the amount from the vault to the recipient.
+func release(state *state.StateDB, recipient common.Address, amount *big.Int) {
+	state.SubBalance(RewardVestingAddr, amount)
+	state.AddBalance(recipient, amount)
+
+	slot := vestingSlot(recipient, vestingVestedOffset)
+	vested := new(big.Int).Add(state.GetState(RewardVestingAddr, slot).Big(), amount)
+	state.SetState(RewardVestingAddr, slot, common.BigToHash(vested))
+}
+
+// LockVestingReward locks the reward of the recipient paid at the block in the vesting vault.
+// The period is the vesting period in blocks.
+func LockVestingReward(state *state.StateDB, recipient common.Address, number, period uint64, amount *big.Int) error {
+	if state.GetCodeSize(RewardVestingAddr) == 0 {
+		if err := state.SetCode(RewardVestingAddr, RewardVestingCode); err != nil {
+			return err
+		}
+	}
+
+	p := number / period
+	tranche := readVestingTranche(state, recipient, p)
+	if tranche.Period != p || tranche.Amount.Sign() == 0 {
+		// The slot keeps the tranche of two periods ago, which is fully vested by now.
+		if rest := new(big.Int).Sub(tranche.Amount, tranche.Released); rest.Sign() > 0 {
+			release(state, recipient, rest)
+		}
+		tranche = &VestingTranche{Period: p, Amount: new(big.Int), Released: new(big.Int)}
+	}
+	tranche.Amount.Add(tranche.Amount, amount)
+	writeVestingTranche(state, recipient, tranche)
+	state.AddBalance(RewardVestingAddr, amount)
+	return nil
+}
+
+// ReleaseVestedReward credits the rewards of the recipient vested until the block, and returns
+// the amount credited. The rewards locked in a period are vested by 1/period of them every block
+// of the next period, so they are fully vested at the last block of the next period.
+func ReleaseVestedReward(state *state.StateDB, recipient common.Address, number, period uint64) *big.Int {
+	current := number / period
+	if current == 0 {
+		return new(big.Int)
+	}
+	tranche := readVestingTranche(state, recipient, current-1)
+	if tranche.Period != current-1 || tranche.Amount.Sign() == 0 {
+		return new(big.Int)
+	}
+
+	elapsed := new(big.Int).SetUint64(number - current*period + 1)
+	vested := new(big.Int).Mul(tranche.Amount, elapsed)
+	vested.Div(vested, new(big.Int).SetUint64(period))
+	amount := new(big.Int).Sub(vested, tranche.Released)
+	if amount.Sign() <= 0 {
+		return new(big.Int)
+	}
+	release(state, recipient, amount)
+	tranche.Released = vested
+	writeVestingTranche(state, recipient, tranche)
+	return amount
+}
+
+// ReadVestingStatus returns the rewards of the recipient vested and locked in the vesting vault.
+// The tranches are the ones not fully released yet, in the order of the periods.
+func ReadVestingStatus(state *state.StateDB, recipient common.Address) *VestingStatus {
+	status := &VestingStatus{
+		Vested:   state.GetState(RewardVestingAddr, vestingSlot(recipient, vestingVestedOffset)).Big(),
+		Locked:   new(big.Int),
+		Tranches: []*VestingTranche{},
+	}
+	for parity := uint64(0); parity < 2; parity++ {
+		tranche := readVestingTranche(state, recipient, parity)
+		if tranche.Amount.Cmp(tranche.Released) <= 0 {
+			continue
+		}
+		status.Locked.Add(status.Locked, new(big.Int).Sub(tranche.Amount, tranche.Released))
+		status.Tranches = append(status.Tranches, tranche)
+	}
+	if len(status.Tranches) == 2 && status.Tranches[0].Period > status.Tranches[1].Period {
+		status.Tranches[0], status.Tranches[1] = status.Tranches[1], status.Tranches[0]
+	}
+	return status
+}
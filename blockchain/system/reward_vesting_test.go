@@ -0,0 +1,63 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package system
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewardVesting(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000b01")
+	const period = 4
+
+	// Locked at the block 2 in the period 0, so vested over the blocks 4 to 7.
+	require.NoError(t, LockVestingReward(statedb, recipient, 2, period, big.NewInt(10)))
+	assert.Zero(t, ReleaseVestedReward(statedb, recipient, 3, period).Sign())
+
+	// The vested amounts are rounded down, and the rest is vested at the last block.
+	var released []int64
+	for number := uint64(4); number < 8; number++ {
+		released = append(released, ReleaseVestedReward(statedb, recipient, number, period).Int64())
+	}
+	assert.Equal(t, []int64{2, 3, 2, 3}, released)
+	assert.Zero(t, ReleaseVestedReward(statedb, recipient, 8, period).Sign())
+	assert.Equal(t, big.NewInt(10), statedb.GetBalance(recipient))
+	assert.Zero(t, statedb.GetBalance(RewardVestingAddr).Sign())
+
+	status := ReadVestingStatus(statedb, recipient)
+	assert.Equal(t, big.NewInt(10), status.Vested)
+	assert.Zero(t, status.Locked.Sign())
+	assert.Empty(t, status.Tranches)
+
+	// A tranche left unreleased is credited before its slot is reused two periods later.
+	require.NoError(t, LockVestingReward(statedb, recipient, 8, period, big.NewInt(4)))
+	require.NoError(t, LockVestingReward(statedb, recipient, 16, period, big.NewInt(1)))
+	assert.Equal(t, big.NewInt(14), statedb.GetBalance(recipient))
+	status = ReadVestingStatus(statedb, recipient)
+	assert.Equal(t, big.NewInt(14), status.Vested)
+	assert.Equal(t, big.NewInt(1), status.Locked)
+	require.Len(t, status.Tranches, 1)
+	assert.Equal(t, uint64(4), status.Tranches[0].Period)
+}
@@ -19,6 +19,7 @@ package system
 import (
 	"math/big"
 
+	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/crypto"
 )
@@ -133,6 +134,39 @@ func allocDynamicData(baseSlot interface{}, data []byte) map[common.Hash]common.
 	return storage
 }
 
+// Reads a dynamic string or bytes data that begins at `baseSlot` of the contract.
+// It is the inverse of allocDynamicData.
+func readDynamicData(state *state.StateDB, contract common.Address, baseSlot interface{}) []byte {
+	base := lpad32(baseSlot)
+	head := state.GetState(contract, base)
+
+	// Short string
+	if head[31]%2 == 0 {
+		return common.CopyBytes(head[:head[31]/2])
+	}
+
+	// Long string
+	length := new(big.Int).SetBytes(head.Bytes())
+	length.Rsh(length, 1)
+	if !length.IsInt64() {
+		return nil
+	}
+	data := make([]byte, 0, length.Int64())
+
+	baseHash := crypto.Keccak256Hash(base.Bytes())
+	bigSlot := new(big.Int).SetBytes(baseHash.Bytes())
+	for remain := int(length.Int64()); remain > 0; remain -= common.HashLength {
+		word := state.GetState(contract, common.BytesToHash(bigSlot.Bytes()))
+		if remain < common.HashLength {
+			data = append(data, word[:remain]...)
+		} else {
+			data = append(data, word[:]...)
+		}
+		bigSlot = new(big.Int).Add(bigSlot, common.Big1)
+	}
+	return data
+}
+
 // MergeStorage merges multiple storage maps into one.
 func MergeStorage(ss ...map[common.Hash]common.Hash) map[common.Hash]common.Hash {
 	out := make(map[common.Hash]common.Hash)
@@ -27,6 +27,7 @@ import (
 	"math/big"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/klaytn/klaytn/blockchain/state"
@@ -86,10 +87,12 @@ var (
 	queuedNofundsCounter   = metrics.NewRegisteredCounter("txpool/queued/nofunds", nil)   // Dropped due to out-of-funds
 
 	// General tx metrics
-	invalidTxCounter     = metrics.NewRegisteredCounter("txpool/invalid", nil)
-	underpricedTxCounter = metrics.NewRegisteredCounter("txpool/underpriced", nil)
-	refusedTxCounter     = metrics.NewRegisteredCounter("txpool/refuse", nil)
-	slotsGauge           = metrics.NewRegisteredGauge("txpool/slots", nil)
+	invalidTxCounter      = metrics.NewRegisteredCounter("txpool/invalid", nil)
+	underpricedTxCounter  = metrics.NewRegisteredCounter("txpool/underpriced", nil)
+	refusedTxCounter      = metrics.NewRegisteredCounter("txpool/refuse", nil)
+	pausedTxCounter       = metrics.NewRegisteredCounter("txpool/paused", nil)          // Refused while the admission is paused
+	gaslessDiscardCounter = metrics.NewRegisteredCounter("txpool/gasless/discard", nil) // Dropped to make room in the full pool of a gasless chain
+	slotsGauge            = metrics.NewRegisteredGauge("txpool/slots", nil)
 )
 
 // TxStatus is the current status of a transaction as seen by the pool.
@@ -134,6 +137,15 @@ type TxPoolConfig struct {
 
 	NoAccountCreation            bool // Whether account creation transactions should be disabled
 	EnableSpamThrottlerAtRuntime bool // Enable txpool spam throttler at runtime
+
+	// FeePayerAllowanceRegistry is the address of the registry contract where the fee payers
+	// publish their allowance policies. The fee-delegated transactions violating the policy of
+	// their fee payer are rejected. Nil disables the check.
+	FeePayerAllowanceRegistry *common.Address `toml:",omitempty"`
+
+	// GaslessSenderLimit is the maximum number of remote transactions a sender can submit
+	// per block on a gasless chain, where the gas price cannot rank the transactions. 0 is unlimited.
+	GaslessSenderLimit uint64
 }
 
 // DefaultTxPoolConfig contains the default configurations for the transaction
@@ -152,6 +164,8 @@ var DefaultTxPoolConfig = TxPoolConfig{
 
 	KeepLocals: false,
 	Lifetime:   5 * time.Minute,
+
+	GaslessSenderLimit: 100,
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -193,6 +207,7 @@ type TxPool struct {
 	mu           sync.RWMutex
 
 	currentBlockNumber uint64                    // Current block number
+	currentBlockTime   uint64                    // Timestamp of the current block
 	currentState       *state.StateDB            // Current state in the blockchain head
 	pendingNonce       map[common.Address]uint64 // Pending nonce tracking virtual nonces
 
@@ -213,6 +228,12 @@ type TxPool struct {
 	txMsgCh chan types.Transactions
 
 	rules params.Rules // Fork indicator
+
+	allowance *feePayerAllowance // nil if the fee payer allowance check is disabled
+
+	gaslessSenderTxs map[common.Address]uint64 // Number of remote transactions added by each sender since the last block, nil if not gasless
+
+	admissionPaused int32 // 1 if new transactions are refused for maintenance, accessed atomically
 }
 
 // NewTxPool creates a new transaction pool to gather, sort and filter inbound
@@ -236,6 +257,12 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 		gasPrice:     new(big.Int).SetUint64(chainconfig.UnitPrice),
 		txMsgCh:      make(chan types.Transactions, txMsgChSize),
 	}
+	if config.FeePayerAllowanceRegistry != nil {
+		pool.allowance = newFeePayerAllowance(*config.FeePayerAllowanceRegistry)
+	}
+	if chainconfig.Gasless && config.GaslessSenderLimit > 0 {
+		pool.gaslessSenderTxs = make(map[common.Address]uint64)
+	}
 	pool.locals = newAccountSet(pool.signer)
 	pool.priced = newTxPricedList(pool.all)
 	pool.reset(nil, chain.CurrentBlock().Header())
@@ -450,6 +477,10 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.currentState = stateDB
 	pool.pendingNonce = make(map[common.Address]uint64)
 	pool.currentBlockNumber = newHead.Number.Uint64()
+	pool.currentBlockTime = newHead.Time.Uint64()
+	if pool.allowance != nil {
+		pool.allowance.prune(pool.currentBlockTime)
+	}
 
 	// Inject any transactions discarded due to reorgs
 	logger.Debug("Reinjecting stale transactions", "count", len(reinject))
@@ -487,6 +518,11 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	if pool.rules.IsMagma {
 		pool.gasPrice = misc.NextMagmaBlockBaseFee(newHead, pool.chainconfig.Governance.KIP71)
 	}
+
+	// Start counting the transactions of the senders again for the new block
+	if pool.gaslessSenderTxs != nil {
+		pool.gaslessSenderTxs = make(map[common.Address]uint64)
+	}
 }
 
 // Stop terminates the transaction pool.
@@ -684,6 +720,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 
 	// NOTE-Klaytn Drop transactions with unexpected gasPrice
 	// If the transaction type is DynamicFee tx, Compare transaction's GasFeeCap(MaxFeePerGas) and GasTipCap with tx pool's gasPrice to check to have same value.
+	// On a gasless chain, the prices must be zero even after magma hardfork, so that no price can rank the transactions.
 	if tx.Type() == types.TxTypeEthereumDynamicFee {
 		// Sanity check for extremely large numbers
 		if tx.GasTipCap().BitLen() > 256 {
@@ -699,7 +736,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 			return ErrTipAboveFeeCap
 		}
 
-		if pool.rules.IsMagma {
+		if pool.rules.IsMagma && !pool.chainconfig.Gasless {
 			// Ensure transaction's gasFeeCap is greater than or equal to transaction pool's gasPrice(baseFee).
 			if pool.gasPrice.Cmp(tx.GasFeeCap()) > 0 {
 				logger.Trace("fail to validate maxFeePerGas", "pool.gasPrice", pool.gasPrice, "maxFeePerGas", tx.GasFeeCap())
@@ -719,14 +756,14 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 		}
 
 	} else {
-		if pool.rules.IsMagma {
+		if pool.rules.IsMagma && !pool.chainconfig.Gasless {
 			if pool.gasPrice.Cmp(tx.GasPrice()) > 0 {
 				// Ensure transaction's gasPrice is greater than or equal to transaction pool's gasPrice(baseFee).
 				logger.Trace("fail to validate gasprice", "pool.gasPrice", pool.gasPrice, "tx.gasPrice", tx.GasPrice())
 				return ErrGasPriceBelowBaseFee
 			}
 		} else {
-			// Unitprice policy before magma hardfork
+			// Unitprice policy before magma hardfork, which also applies to a gasless chain with zero unit price
 			if pool.gasPrice.Cmp(tx.GasPrice()) != 0 {
 				logger.Trace("fail to validate unitprice", "unitPrice", pool.gasPrice, "txUnitPrice", tx.GasPrice())
 				return ErrInvalidUnitPrice
@@ -810,6 +847,13 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 			logger.Trace("[tx_pool] insufficient funds for cost(gas * price + value)", "from", from, "balance", senderBalance, "cost", tx.Cost())
 			return ErrInsufficientFundsFrom
 		}
+		// reject the transactions the fee payer does not want to pay for
+		if pool.allowance != nil {
+			if err := pool.allowance.check(pool.currentState, feePayer, from, tx.Gas(), pool.currentBlockTime); err != nil {
+				logger.Trace("[tx_pool] rejected by the allowance policy of the fee payer", "from", from, "feePayer", feePayer, "err", err)
+				return err
+			}
+		}
 	} else {
 		// balance check for non-fee-delegated tx
 		if senderBalance.Cmp(tx.Cost()) < 0 {
@@ -879,12 +923,29 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		return false, err
 	}
 
+	// On a gasless chain, limit the number of remote transactions of a sender per block instead of pricing them.
+	// The transaction is counted only after it is added.
+	if !local && pool.gaslessSenderTxs != nil {
+		from, _ := types.Sender(pool.signer, tx) // already validated
+		if pool.gaslessSenderTxs[from] >= pool.config.GaslessSenderLimit {
+			logger.Trace("Discarding transaction over the gasless sender limit", "hash", hash, "from", from)
+			refusedTxCounter.Inc(1)
+			return false, ErrGaslessSenderLimit
+		}
+	}
+
 	// If the transaction pool is full and new Tx is valid,
 	// (1) discard a new Tx if there is no room for the account of the Tx
 	// (2) remove an old Tx with the largest nonce from queue to make a room for a new Tx with missing nonce
 	// (3) discard a new Tx if the new Tx does not have a missing nonce
 	// (4) discard underpriced transactions
-	if uint64(pool.all.Slots()+numSlots(tx)) > pool.config.ExecSlotsAll+pool.config.NonExecSlotsAll {
+	// On a gasless chain, where every transaction has the same zero price, the transactions of
+	// the sender holding the most slots are discarded instead.
+	if uint64(pool.all.Slots()+numSlots(tx)) > pool.config.ExecSlotsAll+pool.config.NonExecSlotsAll && pool.chainconfig.Gasless {
+		if err := pool.discardGasless(tx, local); err != nil {
+			return false, err
+		}
+	} else if uint64(pool.all.Slots()+numSlots(tx)) > pool.config.ExecSlotsAll+pool.config.NonExecSlotsAll {
 		// (1) discard a new Tx if there is no room for the account of the Tx
 		from, _ := types.Sender(pool.signer, tx)
 		if pool.queue[from] == nil {
@@ -938,6 +999,8 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
 		pool.journalTx(from, tx)
+		pool.consumeAllowance(tx)
+		pool.countGaslessTx(from, local)
 
 		logger.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
@@ -956,11 +1019,85 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		pool.locals.add(from)
 	}
 	pool.journalTx(from, tx)
+	pool.consumeAllowance(tx)
+	pool.countGaslessTx(from, local)
 
 	logger.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replace, nil
 }
 
+// countGaslessTx counts an added remote transaction of the sender against the gasless sender limit.
+func (pool *TxPool) countGaslessTx(from common.Address, local bool) {
+	if !local && pool.gaslessSenderTxs != nil {
+		pool.gaslessSenderTxs[from]++
+	}
+}
+
+// discardGasless makes room for a new transaction in the full pool of a gasless chain, where
+// the price cannot rank the transactions. The transactions with the largest nonces of the
+// remote sender holding the most slots are discarded, so that a sender flooding the pool
+// cannot keep the others out. The new transaction is rejected if its sender would hold the
+// most slots.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) discardGasless(tx *types.Transaction, local bool) error {
+	from, _ := types.Sender(pool.signer, tx) // already validated
+	for uint64(pool.all.Slots()+numSlots(tx)) > pool.config.ExecSlotsAll+pool.config.NonExecSlotsAll {
+		victim, slots := common.Address{}, 0
+		for addr := range pool.pending {
+			if n := pool.senderSlots(addr); n > slots && !pool.locals.contains(addr) {
+				victim, slots = addr, n
+			}
+		}
+		for addr := range pool.queue {
+			if n := pool.senderSlots(addr); n > slots && !pool.locals.contains(addr) {
+				victim, slots = addr, n
+			}
+		}
+		if slots == 0 || victim == from || (!local && pool.senderSlots(from)+numSlots(tx) > slots) {
+			logger.Trace("Rejecting a new Tx, because the gasless TxPool is full", "hash", tx.Hash(), "account", from)
+			refusedTxCounter.Inc(1)
+			return fmt.Errorf("txpool is full: %d", uint64(pool.all.Count()))
+		}
+
+		// the queued transactions have larger nonces than the pending ones
+		list := pool.queue[victim]
+		if list == nil {
+			list = pool.pending[victim]
+		}
+		txs := list.Flatten()
+		drop := txs[len(txs)-1]
+		logger.Trace("Discarding a transaction of the sender holding the most slots", "hash", drop.Hash(), "account", victim, "slots", slots)
+		gaslessDiscardCounter.Inc(1)
+		pool.removeTx(drop.Hash(), true)
+	}
+	return nil
+}
+
+// senderSlots returns the number of the slots taken by the pending and queued transactions of the sender.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) senderSlots(addr common.Address) int {
+	slots := 0
+	for _, list := range []*txList{pool.pending[addr], pool.queue[addr]} {
+		if list == nil {
+			continue
+		}
+		for _, tx := range list.Flatten() {
+			slots += numSlots(tx)
+		}
+	}
+	return slots
+}
+
+// consumeAllowance accounts the gas of an admitted fee-delegated transaction to the daily
+// usage of its fee payer.
+func (pool *TxPool) consumeAllowance(tx *types.Transaction) {
+	if pool.allowance != nil && tx.IsFeeDelegatedTransaction() {
+		pool.allowance.consume(tx.ValidatedFeePayer(), tx.Gas(), pool.currentBlockTime)
+	}
+}
+
 // enqueueTx inserts a new transaction into the non-executable transaction queue.
 //
 // Note, this method assumes the pool lock is held!
@@ -1048,6 +1185,10 @@ func (pool *TxPool) HandleTxMsg(txs types.Transactions) {
 	if pool.config.DenyRemoteTx {
 		return
 	}
+	if pool.AdmissionPaused() {
+		pausedTxCounter.Inc(int64(len(txs)))
+		return
+	}
 
 	// Filter spam txs based on to-address of failed txs
 	spamThrottler := GetSpamThrottler()
@@ -1158,6 +1299,21 @@ func (pool *TxPool) StopSpamThrottler() {
 	throttlerDropCount.Clear()
 }
 
+// SetAdmissionPaused makes the pool refuse or admit new transactions. The transactions
+// already in the pool are kept and processed while the admission is paused.
+func (pool *TxPool) SetAdmissionPaused(paused bool) {
+	if paused {
+		atomic.StoreInt32(&pool.admissionPaused, 1)
+	} else {
+		atomic.StoreInt32(&pool.admissionPaused, 0)
+	}
+}
+
+// AdmissionPaused returns true if the pool refuses new transactions.
+func (pool *TxPool) AdmissionPaused() bool {
+	return atomic.LoadInt32(&pool.admissionPaused) == 1
+}
+
 // handleTxMsg calls TxPool.AddRemotes by retrieving transactions from TxPool.txMsgCh.
 func (pool *TxPool) handleTxMsg() {
 	defer pool.wg.Done()
@@ -1179,6 +1335,10 @@ func (pool *TxPool) AddLocal(tx *types.Transaction) error {
 	if tx.Type().IsChainDataAnchoring() && !pool.config.AllowLocalAnchorTx {
 		return errNotAllowedAnchoringTx
 	}
+	if pool.AdmissionPaused() {
+		pausedTxCounter.Inc(1)
+		return ErrTxAdmissionPaused
+	}
 
 	pool.mu.RLock()
 	poolSize := uint64(pool.all.Count())
@@ -1193,6 +1353,10 @@ func (pool *TxPool) AddLocal(tx *types.Transaction) error {
 // sender is not among the locally tracked ones, full pricing constraints will
 // apply.
 func (pool *TxPool) AddRemote(tx *types.Transaction) error {
+	if pool.AdmissionPaused() {
+		pausedTxCounter.Inc(1)
+		return ErrTxAdmissionPaused
+	}
 	return pool.addTx(tx, false)
 }
 
@@ -1214,6 +1378,15 @@ func (pool *TxPool) AddRemotes(txs []*types.Transaction) []error {
 // If given transactions exceed the capacity of TxPool, it slices the given transactions
 // so it can fit into TxPool's capacity.
 func (pool *TxPool) checkAndAddTxs(txs []*types.Transaction, local bool) []error {
+	if pool.AdmissionPaused() {
+		pausedTxCounter.Inc(int64(len(txs)))
+		errs := make([]error, len(txs))
+		for i := range errs {
+			errs[i] = ErrTxAdmissionPaused
+		}
+		return errs
+	}
+
 	pool.mu.RLock()
 	poolSize := uint64(pool.all.Count())
 	pool.mu.RUnlock()
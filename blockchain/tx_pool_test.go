@@ -707,6 +707,31 @@ func TestTransactionNonceRecovery(t *testing.T) {
 	}
 }
 
+// Tests that no transaction is admitted while the admission is paused, keeping the
+// transactions already in the pool.
+func TestTransactionAdmissionPaused(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000000))
+	assert.NoError(t, pool.AddRemote(transaction(0, 100000, key)))
+
+	pool.SetAdmissionPaused(true)
+	assert.True(t, pool.AdmissionPaused())
+	assert.Equal(t, ErrTxAdmissionPaused, pool.AddLocal(transaction(1, 100000, key)))
+	assert.Equal(t, ErrTxAdmissionPaused, pool.AddRemote(transaction(1, 100000, key)))
+	assert.Equal(t, []error{ErrTxAdmissionPaused, ErrTxAdmissionPaused}, pool.AddRemotes([]*types.Transaction{transaction(1, 100000, key), transaction(2, 100000, key)}))
+	pending, queued := pool.Stats()
+	assert.Equal(t, 1, pending)
+	assert.Equal(t, 0, queued)
+
+	pool.SetAdmissionPaused(false)
+	assert.NoError(t, pool.AddRemote(transaction(1, 100000, key)))
+}
+
 // Tests that if an account runs out of funds, any pending and queued transactions
 // are dropped.
 func TestTransactionDropping(t *testing.T) {
@@ -2824,3 +2849,139 @@ func benchmarkPoolBatchInsert(b *testing.B, size int) {
 		pool.AddRemotes(batch)
 	}
 }
+
+// TestFeePayerAllowance checks that the fee-delegated transactions violating the allowance
+// policy of the fee payer are rejected.
+func TestFeePayerAllowance(t *testing.T) {
+	t.Parallel()
+
+	registry := common.HexToAddress("0x0000000000000000000000000000000000000fee")
+	config := testTxPoolConfig
+	config.FeePayerAllowanceRegistry = &registry
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	pool := NewTxPool(config, params.TestChainConfig, &testBlockChain{statedb, 10000000, new(event.Feed)})
+	defer pool.Stop()
+
+	senderKey, _ := crypto.GenerateKey()
+	feePayerKey, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(senderKey.PublicKey)
+	feePayer := crypto.PubkeyToAddress(feePayerKey.PublicKey)
+	testAddBalance(pool, sender, big.NewInt(1000000))
+	testAddBalance(pool, feePayer, big.NewInt(1000000))
+
+	// no policy, any transaction is admitted
+	assert.NoError(t, pool.AddRemote(feeDelegatedTx(0, 40000, big.NewInt(1), big.NewInt(1), senderKey, feePayerKey)))
+
+	// the policy allowing only the listed senders up to 100k gas a day
+	policy := mappingSlot(feePayer.Hash(), common.BigToHash(big.NewInt(allowancePoliciesSlot)))
+	allowed := mappingSlot(sender.Hash(), mappingSlot(feePayer.Hash(), common.BigToHash(big.NewInt(allowanceAllowedSendersSlot))))
+	pool.mu.Lock()
+	pool.currentState.SetState(registry, policy, common.BigToHash(big.NewInt(1)))
+	pool.currentState.SetState(registry, common.BigToHash(new(big.Int).Add(policy.Big(), common.Big1)), common.BigToHash(big.NewInt(100000)))
+	pool.mu.Unlock()
+	assert.Equal(t, ErrFeePayerAllowanceSender, pool.AddRemote(feeDelegatedTx(1, 40000, big.NewInt(1), big.NewInt(1), senderKey, feePayerKey)))
+
+	pool.mu.Lock()
+	pool.currentState.SetState(registry, allowed, common.BigToHash(big.NewInt(1)))
+	pool.mu.Unlock()
+	assert.NoError(t, pool.AddRemote(feeDelegatedTx(1, 40000, big.NewInt(1), big.NewInt(1), senderKey, feePayerKey)))
+	assert.Equal(t, ErrFeePayerAllowanceGasCap, pool.AddRemote(feeDelegatedTx(2, 40000, big.NewInt(1), big.NewInt(1), senderKey, feePayerKey)))
+
+	// the usage is reset on the next day
+	pool.mu.Lock()
+	pool.currentBlockTime += secondsPerDay
+	pool.mu.Unlock()
+	assert.NoError(t, pool.AddRemote(feeDelegatedTx(2, 40000, big.NewInt(1), big.NewInt(1), senderKey, feePayerKey)))
+}
+
+func TestGaslessTxPool(t *testing.T) {
+	t.Parallel()
+
+	config := testTxPoolConfig
+	config.GaslessSenderLimit = 2
+	chainConfig := kip71Config.Copy()
+	chainConfig.Gasless = true
+	chainConfig.UnitPrice = 0
+	chainConfig.Governance.KIP71.LowerBoundBaseFee = 0
+	chainConfig.Governance.KIP71.UpperBoundBaseFee = 0
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	pool := NewTxPool(config, chainConfig, &testBlockChain{statedb, 10000000, new(event.Feed)})
+	defer pool.Stop()
+	assert.Zero(t, pool.GasPrice().Sign())
+
+	key1, _ := crypto.GenerateKey()
+	key2, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key1.PublicKey), big.NewInt(1000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(key2.PublicKey), big.NewInt(1000000))
+
+	// no price is accepted even after magma hardfork
+	assert.Equal(t, ErrInvalidUnitPrice, pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(1), key1)))
+	assert.Equal(t, ErrInvalidGasTipCap, pool.AddRemote(dynamicFeeTx(0, 100000, big.NewInt(1), big.NewInt(1), key1)))
+	assert.Equal(t, ErrInvalidGasFeeCap, pool.AddRemote(dynamicFeeTx(0, 100000, big.NewInt(1), big.NewInt(0), key1)))
+
+	// a sender can submit up to the limit per block
+	assert.NoError(t, pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(0), key1)))
+	assert.NoError(t, pool.AddRemote(pricedTransaction(1, 100000, big.NewInt(0), key1)))
+	assert.Equal(t, ErrGaslessSenderLimit, pool.AddRemote(pricedTransaction(2, 100000, big.NewInt(0), key1)))
+	assert.NoError(t, pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(0), key2)))
+
+	// a rejected transaction is not counted
+	assert.Error(t, pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(0), key2)))
+	assert.NoError(t, pool.AddRemote(pricedTransaction(1, 100000, big.NewInt(0), key2)))
+
+	// the local transactions are not limited
+	assert.NoError(t, pool.AddLocal(pricedTransaction(2, 100000, big.NewInt(0), key1)))
+
+	// the limit is renewed on a new block
+	pool.lockedReset(nil, nil)
+	assert.NoError(t, pool.AddRemote(pricedTransaction(3, 100000, big.NewInt(0), key1)))
+
+	pending, queued := pool.Stats()
+	assert.Equal(t, 6, pending)
+	assert.Equal(t, 0, queued)
+}
+
+func TestGaslessTxPoolFull(t *testing.T) {
+	t.Parallel()
+
+	config := testTxPoolConfig
+	config.ExecSlotsAll = 3
+	config.NonExecSlotsAll = 0
+	chainConfig := kip71Config.Copy()
+	chainConfig.Gasless = true
+	chainConfig.UnitPrice = 0
+	chainConfig.Governance.KIP71.LowerBoundBaseFee = 0
+	chainConfig.Governance.KIP71.UpperBoundBaseFee = 0
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	pool := NewTxPool(config, chainConfig, &testBlockChain{statedb, 10000000, new(event.Feed)})
+	defer pool.Stop()
+
+	key1, _ := crypto.GenerateKey()
+	key2, _ := crypto.GenerateKey()
+	key3, _ := crypto.GenerateKey()
+	for _, key := range []*ecdsa.PrivateKey{key1, key2, key3} {
+		testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+	}
+
+	// a sender fills up the pool
+	for i := uint64(0); i < 3; i++ {
+		assert.NoError(t, pool.AddRemote(pricedTransaction(i, 100000, big.NewInt(0), key1)))
+	}
+
+	// the other senders take the room of the sender holding the most slots
+	tx1 := pricedTransaction(2, 100000, big.NewInt(0), key1)
+	assert.NoError(t, pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(0), key2)))
+	assert.Nil(t, pool.Get(tx1.Hash()))
+	assert.Error(t, pool.AddRemote(pricedTransaction(3, 100000, big.NewInt(0), key1)))
+	assert.NoError(t, pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(0), key3)))
+
+	// a sender cannot take more slots than the others
+	assert.Error(t, pool.AddRemote(pricedTransaction(1, 100000, big.NewInt(0), key2)))
+
+	pending, queued := pool.Stats()
+	assert.Equal(t, 3, pending)
+	assert.Equal(t, 0, queued)
+}
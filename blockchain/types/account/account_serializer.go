@@ -169,6 +169,12 @@ func UnextendSerializedAccount(b []byte) (result []byte) {
 	return result
 }
 
+// DecodeSerializedAccount returns the account of an RLP-encoded account,
+// or nil if the supplied bytes is not an RLP-encoded account.
+func DecodeSerializedAccount(b []byte) Account {
+	return safeDecodeRLP(b)
+}
+
 // Account RLP decoder that does not panic
 func safeDecodeRLP(b []byte) Account {
 	if len(b) == 0 {
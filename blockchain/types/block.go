@@ -70,6 +70,9 @@ type Header struct {
 	RandomReveal []byte `json:"randomReveal,omitempty" rlp:"optional"` // 96 byte BLS signature
 	MixHash      []byte `json:"mixHash,omitempty" rlp:"optional"`      // 32 byte RANDAO mix
 
+	// Added with StakingCommitment hardfork, the hash of the staking information used for the block reward.
+	StakingInfoHash *common.Hash `json:"stakingInfoHash,omitempty" rlp:"optional"`
+
 	// New header fields must be added at tail for backward compatibility.
 }
 
@@ -128,6 +131,9 @@ func (h *Header) HashNoNonce() common.Hash {
 func (h *Header) Size() common.StorageSize {
 	constantSize := common.StorageSize(reflect.TypeOf(Header{}).Size())
 	byteSize := common.StorageSize(len(h.Extra) + len(h.Governance) + len(h.Vote) + len(h.RandomReveal) + len(h.MixHash))
+	if h.StakingInfoHash != nil {
+		byteSize += common.HashLength
+	}
 	bigIntSize := common.StorageSize((h.BlockScore.BitLen() + h.Number.BitLen() + h.Time.BitLen()) / 8)
 	if h.BaseFee != nil {
 		return constantSize + byteSize + bigIntSize + common.StorageSize(h.BaseFee.BitLen()/8)
@@ -256,6 +262,11 @@ func CopyHeader(h *Header) *Header {
 		// This field exists after magma hardfork
 		cpy.BaseFee = new(big.Int).Set(h.BaseFee)
 	}
+	if h.StakingInfoHash != nil {
+		// This field exists after staking commitment hardfork
+		stakingInfoHash := *h.StakingInfoHash
+		cpy.StakingInfoHash = &stakingInfoHash
+	}
 	if len(h.Extra) > 0 {
 		cpy.Extra = make([]byte, len(h.Extra))
 		copy(cpy.Extra, h.Extra)
@@ -415,6 +426,9 @@ func (h *Header) String() string {
 		str += fmt.Sprintf("		RandomReveal:     %x\n", h.RandomReveal)
 		str += fmt.Sprintf("		MixHash:          %x\n", h.MixHash)
 	}
+	if h.StakingInfoHash != nil {
+		str += fmt.Sprintf("		StakingInfoHash:  %x\n", *h.StakingInfoHash)
+	}
 
 	str += "]"
 	return str
@@ -162,14 +162,37 @@ func genBlock() *Block {
 	}
 }
 
+func TestHeaderStakingInfoHashEncoding(t *testing.T) {
+	header := genHeader()
+	hash := header.Hash()
+
+	// the optional field is encoded after the fork
+	stakingInfoHash := common.HexToHash("0x1234")
+	header.StakingInfoHash = &stakingInfoHash
+	encoded, err := rlp.EncodeToBytes(header)
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash, header.Hash())
+
+	decoded := new(Header)
+	assert.Nil(t, rlp.DecodeBytes(encoded, decoded))
+	assert.Equal(t, &stakingInfoHash, decoded.StakingInfoHash)
+	assert.Equal(t, header.Hash(), decoded.Hash())
+
+	// the copy does not share the field
+	cpy := CopyHeader(header)
+	cpy.StakingInfoHash[0] = 0xff
+	assert.Equal(t, stakingInfoHash, *header.StakingInfoHash)
+}
+
 func TestHeaderSizeCalc(t *testing.T) {
 	// constantSize
 	// = required fields (520)
 	// + BaseFee pointer (8)
 	// + RandomReveal slice (24)
 	// + MixHash slice (24)
+	// + StakingInfoHash pointer (8)
 	constantSize := int(reflect.TypeOf(Header{}).Size())
-	assert.Equal(t, 520+8+24+24, constantSize)
+	assert.Equal(t, 520+8+24+24+8, constantSize)
 
 	// Test header.Size() while adding fields one by one
 	// Start from a header without any variable length fields.
@@ -212,6 +235,11 @@ func TestHeaderSizeCalc(t *testing.T) {
 	h.BaseFee = big.NewInt(0x5d21dba00) // 35 bits ~= 4 bytes
 	expectSize += 4
 	assert.Equal(t, expectSize, int(h.Size()))
+
+	// StakingInfoHash added for its length
+	h.StakingInfoHash = &common.Hash{}
+	expectSize += common.HashLength
+	assert.Equal(t, expectSize, int(h.Size()))
 }
 
 func TestHeaderJSON(t *testing.T) {
@@ -96,3 +96,13 @@ func getType(num *big.Int) int {
 		return int(params.DefaultDeriveShaImpl)
 	}
 }
+
+// DeriveShaWithImpl calculates the root hash of the list with the given DeriveShaImpl,
+// regardless of the chain config and the governance, e.g. to verify a root on a light client.
+func DeriveShaWithImpl(implType int, list types.DerivableList) common.Hash {
+	impl, ok := impls[implType]
+	if !ok {
+		impl = impls[int(params.DefaultDeriveShaImpl)]
+	}
+	return impl.DeriveSha(list)
+}
@@ -16,24 +16,25 @@ var _ = (*headerMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (h Header) MarshalJSON() ([]byte, error) {
 	type Header struct {
-		ParentHash   common.Hash    `json:"parentHash"       gencodec:"required"`
-		Rewardbase   common.Address `json:"reward"           gencodec:"required"`
-		Root         common.Hash    `json:"stateRoot"        gencodec:"required"`
-		TxHash       common.Hash    `json:"transactionsRoot" gencodec:"required"`
-		ReceiptHash  common.Hash    `json:"receiptsRoot"     gencodec:"required"`
-		Bloom        Bloom          `json:"logsBloom"        gencodec:"required"`
-		BlockScore   *hexutil.Big   `json:"blockScore"       gencodec:"required"`
-		Number       *hexutil.Big   `json:"number"           gencodec:"required"`
-		GasUsed      hexutil.Uint64 `json:"gasUsed"          gencodec:"required"`
-		Time         *hexutil.Big   `json:"timestamp"        gencodec:"required"`
-		TimeFoS      hexutil.Uint   `json:"timestampFoS"              gencodec:"required"`
-		Extra        hexutil.Bytes  `json:"extraData"                 gencodec:"required"`
-		Governance   hexutil.Bytes  `json:"governanceData"            gencodec:"required"`
-		Vote         hexutil.Bytes  `json:"voteData,omitempty"`
-		BaseFee      *hexutil.Big   `json:"baseFeePerGas,omitempty" rlp:"optional"`
-		RandomReveal hexutil.Bytes  `json:"randomReveal,omitempty" rlp:"optional"`
-		MixHash      hexutil.Bytes  `json:"mixHash,omitempty" rlp:"optional"`
-		Hash         common.Hash    `json:"hash"`
+		ParentHash      common.Hash    `json:"parentHash"       gencodec:"required"`
+		Rewardbase      common.Address `json:"reward"           gencodec:"required"`
+		Root            common.Hash    `json:"stateRoot"        gencodec:"required"`
+		TxHash          common.Hash    `json:"transactionsRoot" gencodec:"required"`
+		ReceiptHash     common.Hash    `json:"receiptsRoot"     gencodec:"required"`
+		Bloom           Bloom          `json:"logsBloom"        gencodec:"required"`
+		BlockScore      *hexutil.Big   `json:"blockScore"       gencodec:"required"`
+		Number          *hexutil.Big   `json:"number"           gencodec:"required"`
+		GasUsed         hexutil.Uint64 `json:"gasUsed"          gencodec:"required"`
+		Time            *hexutil.Big   `json:"timestamp"        gencodec:"required"`
+		TimeFoS         hexutil.Uint   `json:"timestampFoS"              gencodec:"required"`
+		Extra           hexutil.Bytes  `json:"extraData"                 gencodec:"required"`
+		Governance      hexutil.Bytes  `json:"governanceData"            gencodec:"required"`
+		Vote            hexutil.Bytes  `json:"voteData,omitempty"`
+		BaseFee         *hexutil.Big   `json:"baseFeePerGas,omitempty" rlp:"optional"`
+		RandomReveal    hexutil.Bytes  `json:"randomReveal,omitempty" rlp:"optional"`
+		MixHash         hexutil.Bytes  `json:"mixHash,omitempty" rlp:"optional"`
+		StakingInfoHash *common.Hash   `json:"stakingInfoHash,omitempty" rlp:"optional"`
+		Hash            common.Hash    `json:"hash"`
 	}
 	var enc Header
 	enc.ParentHash = h.ParentHash
@@ -53,6 +54,7 @@ func (h Header) MarshalJSON() ([]byte, error) {
 	enc.BaseFee = (*hexutil.Big)(h.BaseFee)
 	enc.RandomReveal = h.RandomReveal
 	enc.MixHash = h.MixHash
+	enc.StakingInfoHash = h.StakingInfoHash
 	enc.Hash = h.Hash()
 	return json.Marshal(&enc)
 }
@@ -60,23 +62,24 @@ func (h Header) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (h *Header) UnmarshalJSON(input []byte) error {
 	type Header struct {
-		ParentHash   *common.Hash    `json:"parentHash"       gencodec:"required"`
-		Rewardbase   *common.Address `json:"reward"           gencodec:"required"`
-		Root         *common.Hash    `json:"stateRoot"        gencodec:"required"`
-		TxHash       *common.Hash    `json:"transactionsRoot" gencodec:"required"`
-		ReceiptHash  *common.Hash    `json:"receiptsRoot"     gencodec:"required"`
-		Bloom        *Bloom          `json:"logsBloom"        gencodec:"required"`
-		BlockScore   *hexutil.Big    `json:"blockScore"       gencodec:"required"`
-		Number       *hexutil.Big    `json:"number"           gencodec:"required"`
-		GasUsed      *hexutil.Uint64 `json:"gasUsed"          gencodec:"required"`
-		Time         *hexutil.Big    `json:"timestamp"        gencodec:"required"`
-		TimeFoS      *hexutil.Uint   `json:"timestampFoS"              gencodec:"required"`
-		Extra        *hexutil.Bytes  `json:"extraData"                 gencodec:"required"`
-		Governance   *hexutil.Bytes  `json:"governanceData"            gencodec:"required"`
-		Vote         *hexutil.Bytes  `json:"voteData,omitempty"`
-		BaseFee      *hexutil.Big    `json:"baseFeePerGas,omitempty" rlp:"optional"`
-		RandomReveal *hexutil.Bytes  `json:"randomReveal,omitempty" rlp:"optional"`
-		MixHash      *hexutil.Bytes  `json:"mixHash,omitempty" rlp:"optional"`
+		ParentHash      *common.Hash    `json:"parentHash"       gencodec:"required"`
+		Rewardbase      *common.Address `json:"reward"           gencodec:"required"`
+		Root            *common.Hash    `json:"stateRoot"        gencodec:"required"`
+		TxHash          *common.Hash    `json:"transactionsRoot" gencodec:"required"`
+		ReceiptHash     *common.Hash    `json:"receiptsRoot"     gencodec:"required"`
+		Bloom           *Bloom          `json:"logsBloom"        gencodec:"required"`
+		BlockScore      *hexutil.Big    `json:"blockScore"       gencodec:"required"`
+		Number          *hexutil.Big    `json:"number"           gencodec:"required"`
+		GasUsed         *hexutil.Uint64 `json:"gasUsed"          gencodec:"required"`
+		Time            *hexutil.Big    `json:"timestamp"        gencodec:"required"`
+		TimeFoS         *hexutil.Uint   `json:"timestampFoS"              gencodec:"required"`
+		Extra           *hexutil.Bytes  `json:"extraData"                 gencodec:"required"`
+		Governance      *hexutil.Bytes  `json:"governanceData"            gencodec:"required"`
+		Vote            *hexutil.Bytes  `json:"voteData,omitempty"`
+		BaseFee         *hexutil.Big    `json:"baseFeePerGas,omitempty" rlp:"optional"`
+		RandomReveal    *hexutil.Bytes  `json:"randomReveal,omitempty" rlp:"optional"`
+		MixHash         *hexutil.Bytes  `json:"mixHash,omitempty" rlp:"optional"`
+		StakingInfoHash *common.Hash    `json:"stakingInfoHash,omitempty" rlp:"optional"`
 	}
 	var dec Header
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -146,5 +149,8 @@ func (h *Header) UnmarshalJSON(input []byte) error {
 	if dec.MixHash != nil {
 		h.MixHash = *dec.MixHash
 	}
+	if dec.StakingInfoHash != nil {
+		h.StakingInfoHash = dec.StakingInfoHash
+	}
 	return nil
 }
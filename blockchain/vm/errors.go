@@ -37,6 +37,7 @@ var (
 	ErrTotalTimeLimitReached             = errors.New("reached the total execution time limit for txs in a block")
 	ErrOpcodeComputationCostLimitReached = errors.New(fmt.Sprintf("reached the opcode computation cost limit (%d) for tx", params.OpcodeComputationCostLimit))
 	ErrFailedOnSetCode                   = errors.New("failed on setting code to an account")
+	ErrComputationCostBudgetExceeded     = errors.New("exceeded the computation cost budget of the call")
 
 	// EVM internal errors
 	ErrWriteProtection       = errors.New("evm: write protection")
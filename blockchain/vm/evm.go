@@ -109,6 +109,8 @@ type BlockContext struct {
 	Time        *big.Int       // Provides information for TIME
 	BlockScore  *big.Int       // Provides information for DIFFICULTY
 	BaseFee     *big.Int       // Provides information for BASEFEE
+	Random      *common.Hash   // Provides information for PREVRANDAO if not nil, otherwise the previous block hash
+	BurnRatio   *uint64        // Provides the burn ratio of the tx fee if not nil, otherwise that of the chain config
 }
 
 // TxContext provides the EVM with information about a transaction.
@@ -480,6 +480,10 @@ func opDifficulty(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext)
 }
 
 func opRandom(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	if random := interpreter.evm.Context.Random; random != nil {
+		scope.Stack.push(new(uint256.Int).SetBytes(random.Bytes()))
+		return nil, nil
+	}
 	// evm.BlockNumber.Uint64() is always greater than or equal to 1
 	// since evm will not run on the genesis block
 	prevBlockHash := interpreter.evm.Context.GetHash(interpreter.evm.Context.BlockNumber.Uint64() - 1)
@@ -47,6 +47,10 @@ type Config struct {
 	// UseOpcodeComputationCost is to enable applying the opcode computation cost limit.
 	UseOpcodeComputationCost bool
 
+	// ComputationCostBudget limits the sum of computation cost of opcodes below the opcode
+	// computation cost limit. It is set by the RPC calls, and zero means no budget.
+	ComputationCostBudget uint64
+
 	// Enables collecting internal transaction data during processing a block
 	EnableInternalTxTracing bool
 
@@ -238,11 +242,14 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte) (ret []byte, err
 		}
 
 		// We limit tx's execution time using the sum of computation cost of opcodes.
-		if in.evm.Config.UseOpcodeComputationCost {
+		if in.evm.Config.UseOpcodeComputationCost || in.evm.Config.ComputationCostBudget > 0 {
 			in.evm.opcodeComputationCostSum += operation.computationCost
-			if in.evm.opcodeComputationCostSum > params.OpcodeComputationCostLimit {
+			if in.evm.Config.UseOpcodeComputationCost && in.evm.opcodeComputationCostSum > params.OpcodeComputationCostLimit {
 				return nil, ErrOpcodeComputationCostLimitReached
 			}
+			if in.evm.Config.ComputationCostBudget > 0 && in.evm.opcodeComputationCostSum > in.evm.Config.ComputationCostBudget {
+				return nil, ErrComputationCostBudgetExceeded
+			}
 		}
 		var memorySize uint64
 		var extraSize uint64
@@ -101,6 +101,18 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestExecute_ComputationCostBudget(t *testing.T) {
+	loop := []byte{
+		byte(vm.JUMPDEST),
+		byte(vm.PUSH1), 0,
+		byte(vm.JUMP),
+	}
+	cfg := &Config{GasLimit: 100000000, EVMConfig: vm.Config{ComputationCostBudget: 10000}}
+	if _, _, err := Execute(loop, nil, cfg); err != vm.ErrComputationCostBudgetExceeded {
+		t.Fatalf("expected %v, got %v", vm.ErrComputationCostBudgetExceeded, err)
+	}
+}
+
 func TestCall(t *testing.T) {
 	state, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
 	address := common.HexToAddress("0x0a00")
@@ -69,8 +69,16 @@ func init() {
 		// See utils/nodecmd/util.go:
 		nodecmd.UtilCommand,
 
+		// See utils/nodecmd/loadtestcmd.go:
+		nodecmd.LoadTestCommand,
+		nodecmd.ForkCommand,
+		nodecmd.ReplayCommand,
+
 		// See utils/nodecmd/snapshot.go:
 		nodecmd.SnapshotCommand,
+
+		// See utils/nodecmd/dbcmd.go:
+		nodecmd.DBCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
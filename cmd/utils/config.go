@@ -58,8 +58,11 @@ import (
 	"github.com/klaytn/klaytn/node/cn"
 	"github.com/klaytn/klaytn/node/cn/filters"
 	"github.com/klaytn/klaytn/node/cn/tracers"
+	"github.com/klaytn/klaytn/node/faucet"
 	"github.com/klaytn/klaytn/node/sc"
+	"github.com/klaytn/klaytn/node/subproxy"
 	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/klaytn/klaytn/storage/statedb"
 	"github.com/naoina/toml"
@@ -96,6 +99,8 @@ type KlayConfig struct {
 	DB               dbsyncer.DBConfig
 	ChainDataFetcher chaindatafetcher.ChainDataFetcherConfig
 	ServiceChain     sc.SCConfig
+	Faucet           faucet.Config
+	SubProxy         subproxy.Config
 }
 
 func LoadConfig(file string, cfg *KlayConfig) error {
@@ -131,6 +136,8 @@ func MakeConfigNode(ctx *cli.Context) (*node.Node, KlayConfig) {
 		DB:               *dbsyncer.DefaultDBConfig(),
 		ChainDataFetcher: *chaindatafetcher.DefaultChainDataFetcherConfig(),
 		ServiceChain:     *sc.DefaultServiceChainConfig(),
+		Faucet:           *faucet.DefaultConfig(),
+		SubProxy:         *subproxy.DefaultConfig(),
 	}
 
 	// NOTE-Klaytn : klaytn loads the flags from yaml, not toml
@@ -152,6 +159,8 @@ func MakeConfigNode(ctx *cli.Context) (*node.Node, KlayConfig) {
 	cfg.SetDBSyncerConfig(ctx)
 	cfg.SetChainDataFetcherConfig(ctx)
 	cfg.SetServiceChainConfig(ctx)
+	cfg.SetFaucetConfig(ctx)
+	cfg.SetSubProxyConfig(ctx)
 
 	// SetShhConfig(ctx, stack, &cfg.Shh)
 	// SetDashboardConfig(ctx, &cfg.Dashboard)
@@ -203,6 +212,17 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 		cfg.NetRestrict = list
 	}
 
+	cfg.Diversity.MaxPerSubnet = ctx.Int(DiversityMaxPerSubnetFlag.Name)
+	cfg.Diversity.MaxPerASN = ctx.Int(DiversityMaxPerASNFlag.Name)
+	cfg.Diversity.MaxPerRegion = ctx.Int(DiversityMaxPerRegionFlag.Name)
+	if netinfo := ctx.String(DiversityNetInfoFlag.Name); netinfo != "" {
+		prefixes, err := p2p.LoadDiversityPrefixes(netinfo)
+		if err != nil {
+			log.Fatalf("Option %q: %v", DiversityNetInfoFlag.Name, err)
+		}
+		cfg.Diversity.Prefixes = prefixes
+	}
+
 	common.MaxRequestContentLength = ctx.Int(MaxRequestContentLengthFlag.Name)
 
 	cfg.NetworkID, _ = getNetworkId(ctx)
@@ -368,6 +388,7 @@ func (kCfg *KlayConfig) SetNodeConfig(ctx *cli.Context) {
 
 	SetP2PConfig(ctx, &cfg.P2P)
 	setIPC(ctx, cfg)
+	setAdmin(ctx, cfg)
 
 	// httptype is http
 	// fasthttp type is deprecated
@@ -427,6 +448,16 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(RPCVirtualHostsFlag.Name) {
 		cfg.HTTPVirtualHosts = SplitAndTrim(ctx.String(RPCVirtualHostsFlag.Name))
 	}
+	if ctx.IsSet(RPCVirtualEndpointsFlag.Name) {
+		endpoints, err := rpc.LoadVirtualEndpoints(ctx.String(RPCVirtualEndpointsFlag.Name))
+		if err != nil {
+			log.Fatalf("Option %q: %v", RPCVirtualEndpointsFlag.Name, err)
+		}
+		cfg.HTTPVirtualEndpoints = endpoints
+	}
+	if ctx.IsSet(RPCComputeBudgetFlag.Name) {
+		cfg.HTTPComputeBudget = ctx.Uint64(RPCComputeBudgetFlag.Name)
+	}
 	if ctx.IsSet(RPCConcurrencyLimit.Name) {
 		rpc.ConcurrencyLimit = ctx.Int(RPCConcurrencyLimit.Name)
 		logger.Info("Set the concurrency limit of RPC-HTTP server", "limit", rpc.ConcurrencyLimit)
@@ -468,6 +499,16 @@ func setWS(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(WSApiFlag.Name) {
 		cfg.WSModules = SplitAndTrim(ctx.String(WSApiFlag.Name))
 	}
+	if ctx.IsSet(WSVirtualEndpointsFlag.Name) {
+		endpoints, err := rpc.LoadVirtualEndpoints(ctx.String(WSVirtualEndpointsFlag.Name))
+		if err != nil {
+			log.Fatalf("Option %q: %v", WSVirtualEndpointsFlag.Name, err)
+		}
+		cfg.WSVirtualEndpoints = endpoints
+	}
+	if ctx.IsSet(WSComputeBudgetFlag.Name) {
+		cfg.WSComputeBudget = ctx.Uint64(WSComputeBudgetFlag.Name)
+	}
 	rpc.MaxSubscriptionPerWSConn = int32(ctx.Int(WSMaxSubscriptionPerConn.Name))
 	rpc.WebsocketReadDeadline = ctx.Int64(WSReadDeadLine.Name)
 	rpc.WebsocketWriteDeadline = ctx.Int64(WSWriteDeadLine.Name)
@@ -486,6 +527,31 @@ func setIPC(ctx *cli.Context, cfg *node.Config) {
 	}
 }
 
+// setAdmin creates the authenticated admin endpoint configuration from the set command line flags.
+func setAdmin(ctx *cli.Context, cfg *node.Config) {
+	if ctx.IsSet(AdminPathFlag.Name) {
+		cfg.AdminPath = ctx.String(AdminPathFlag.Name)
+	}
+	if ctx.IsSet(AdminApiFlag.Name) {
+		cfg.AdminModules = SplitAndTrim(ctx.String(AdminApiFlag.Name))
+	}
+	if ctx.IsSet(AdminTokenFileFlag.Name) {
+		cfg.AdminTokenFile = ctx.String(AdminTokenFileFlag.Name)
+	}
+	if ctx.IsSet(AdminTLSCertFlag.Name) {
+		cfg.AdminTLSCert = ctx.String(AdminTLSCertFlag.Name)
+	}
+	if ctx.IsSet(AdminTLSKeyFlag.Name) {
+		cfg.AdminTLSKey = ctx.String(AdminTLSKeyFlag.Name)
+	}
+	if ctx.IsSet(AdminTLSClientCAFlag.Name) {
+		cfg.AdminTLSClientCA = ctx.String(AdminTLSClientCAFlag.Name)
+	}
+	if ctx.IsSet(AdminExclusiveFlag.Name) {
+		cfg.AdminExclusive = ctx.Bool(AdminExclusiveFlag.Name)
+	}
+}
+
 // setgRPC creates the gRPC listener interface string from the set
 // command line flags, returning empty if the gRPC endpoint is disabled.
 func setgRPC(ctx *cli.Context, cfg *node.Config) {
@@ -505,6 +571,10 @@ func setgRPC(ctx *cli.Context, cfg *node.Config) {
 func setAPIConfig(ctx *cli.Context) {
 	filters.GetLogsDeadline = ctx.Duration(APIFilterGetLogsDeadlineFlag.Name)
 	filters.GetLogsMaxItems = ctx.Int(APIFilterGetLogsMaxItemsFlag.Name)
+	filters.FilterTimeout = ctx.Duration(APIFilterTimeoutFlag.Name)
+	filters.MaxFilterItems = ctx.Int(APIFilterMaxItemsFlag.Name)
+	filters.MaxFiltersPerClient = ctx.Int(APIFilterMaxFiltersPerClientFlag.Name)
+	filters.MaxSubscriptionsPerClient = ctx.Int(APIFilterMaxSubscriptionsPerClientFlag.Name)
 }
 
 // setNodeUserIdent creates the user identifier from CLI flags.
@@ -569,6 +639,7 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	cfg.LevelDBBufferPool = !ctx.Bool(LevelDBNoBufferPoolFlag.Name)
 	cfg.EnableDBPerfMetrics = !ctx.Bool(DBNoPerformanceMetricsFlag.Name)
 	cfg.LevelDBCacheSize = ctx.Int(LevelDBCacheSizeFlag.Name)
+	cfg.HeaderMmapCacheSize = ctx.Int(HeaderMmapCacheSizeFlag.Name)
 
 	cfg.RocksDBConfig.Secondary = ctx.Bool(RocksDBSecondaryFlag.Name)
 	cfg.RocksDBConfig.MaxOpenFiles = ctx.Int(RocksDBMaxOpenFilesFlag.Name)
@@ -600,6 +671,11 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	cfg.NoPruning = ctx.String(GCModeFlag.Name) == "archive"
 	logger.Info("Archiving mode of this node", "isArchiveMode", cfg.NoPruning)
 
+	cfg.Istanbul.CheckpointInterval = ctx.Uint64(CheckpointIntervalFlag.Name)
+	cfg.Istanbul.StallTimeout = ctx.Uint64(StallTimeoutFlag.Name)
+	cfg.Istanbul.Standby = ctx.Bool(StandbyFlag.Name)
+	cfg.Istanbul.StandbyWindow = ctx.Uint64(StandbyWindowFlag.Name)
+
 	cfg.AnchoringPeriod = ctx.Uint64(AnchoringPeriodFlag.Name)
 	cfg.SentChainTxsLimit = ctx.Uint64(SentChainTxsLimit.Name)
 
@@ -637,6 +713,45 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	}
 
 	cfg.SenderTxHashIndexing = ctx.Bool(SenderTxHashIndexingFlag.Name)
+	cfg.BalanceHistoryIndexing = ctx.Bool(BalanceHistoryIndexingFlag.Name)
+	cfg.EpochSummaryIndexing = ctx.Bool(EpochSummaryIndexingFlag.Name)
+	cfg.RewardIndexing = ctx.Bool(RewardIndexingFlag.Name)
+	setOverrideForks(ctx, cfg)
+	cfg.RewardPolicy = ctx.String(RewardPolicyFlag.Name)
+	cfg.RewardPolicyBlock = ctx.Uint64(RewardPolicyBlockFlag.Name)
+	cfg.RewardVerify = ctx.Bool(RewardVerifyFlag.Name)
+	cfg.RewardCheckInvariants = ctx.Bool(RewardCheckInvariantsFlag.Name)
+	cfg.RewardAuditFee = ctx.Bool(RewardAuditFeeFlag.Name)
+	cfg.RewardHistory = ctx.Bool(RewardHistoryFlag.Name)
+	cfg.RewardHistoryRetention = ctx.Uint64(RewardHistoryRetentionFlag.Name)
+	cfg.RewardMonitor = ctx.Bool(RewardMonitorFlag.Name)
+	cfg.RewardMonitorThresholds = reward.RewardAnomalyThresholds{
+		Minted:    ctx.Float64(RewardMonitorMintedFlag.Name),
+		BurnRatio: ctx.Float64(RewardMonitorBurnRatioFlag.Name),
+		Share:     ctx.Float64(RewardMonitorShareFlag.Name),
+	}
+	if format, err := reward.ParseRewardReportFormat(ctx.String(RewardReportFormatFlag.Name)); err != nil {
+		log.Fatalf("--%s: %v", RewardReportFormatFlag.Name, err)
+	} else {
+		cfg.RewardReportFormat = format
+	}
+	cfg.Attestation = cn.AttestationPolicy{
+		Enabled:          ctx.Bool(AttestationFlag.Name) || ctx.Bool(AttestationRequireFlag.Name),
+		Required:         ctx.Bool(AttestationRequireFlag.Name),
+		ApprovedVersions: ctx.StringSlice(AttestationVersionsFlag.Name),
+	}
+	if mode, err := blockchain.ParseIntegrityCheckMode(ctx.String(VerifyOnStartFlag.Name)); err != nil {
+		log.Fatalf("--%s: %v", VerifyOnStartFlag.Name, err)
+	} else {
+		cfg.VerifyOnStart = mode
+	}
+	cfg.AutoCompaction = ctx.Bool(AutoCompactionFlag.Name)
+	cfg.CompactionScheduler = database.CompactionSchedulerConfig{
+		Schedule:           ctx.String(AutoCompactionScheduleFlag.Name),
+		MaxPendingRequests: ctx.Int64(AutoCompactionMaxPendingRequestsFlag.Name),
+		RecentBlocks:       ctx.Uint64(AutoCompactionRecentBlocksFlag.Name),
+		ChunkBlocks:        ctx.Uint64(AutoCompactionChunkBlocksFlag.Name),
+	}
 	cfg.ParallelDBWrite = !ctx.Bool(NoParallelDBWriteFlag.Name)
 	cfg.TrieNodeCacheConfig = statedb.TrieNodeCacheConfig{
 		CacheType: statedb.TrieNodeCacheType(ctx.String(TrieNodeCacheTypeFlag.
@@ -663,6 +778,13 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	}
 	cfg.EnableInternalTxTracing = ctx.Bool(VMTraceInternalTxFlag.Name)
 	cfg.EnableOpDebug = ctx.Bool(VMOpDebugFlag.Name)
+	cfg.TraceSinks = tracers.SinkConfig{
+		Dir:          ctx.String(VMTraceSinkDirFlag.Name),
+		MaxFileSize:  ctx.Uint64(VMTraceSinkMaxFileSizeFlag.Name),
+		MaxFiles:     ctx.Int(VMTraceSinkMaxFilesFlag.Name),
+		Socket:       ctx.String(VMTraceSinkSocketFlag.Name),
+		OTLPEndpoint: ctx.String(VMTraceSinkOTLPFlag.Name),
+	}
 
 	cfg.AutoRestartFlag = ctx.Bool(AutoRestartFlag.Name)
 	cfg.RestartTimeOutFlag = ctx.Duration(RestartTimeOutFlag.Name)
@@ -677,6 +799,18 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	if ctx.IsSet(RPCGlobalEthTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalEthTxFeeCapFlag.Name)
 	}
+	cfg.AnnotateAddressLabels = ctx.Bool(RPCAnnotateAddressLabelsFlag.Name)
+	cfg.AddressLabelsFile = ctx.String(RPCAddressLabelsFlag.Name)
+	if ctx.IsSet(RPCContractRegistryFlag.Name) {
+		registry := ctx.String(RPCContractRegistryFlag.Name)
+		if !common.IsHexAddress(registry) {
+			log.Fatalf("Option %q: invalid address %q", RPCContractRegistryFlag.Name, registry)
+		}
+		addr := common.HexToAddress(registry)
+		cfg.ContractRegistry = &addr
+	}
+	cfg.PreconfMaxBlocks = ctx.Uint64(RPCPreconfMaxBlocksFlag.Name)
+	cfg.EventMetricsFile = ctx.String(EventMetricsFlag.Name)
 
 	// Only CNs could set BlockGenerationIntervalFlag and BlockGenerationTimeLimitFlag
 	if ctx.IsSet(BlockGenerationIntervalFlag.Name) {
@@ -691,6 +825,21 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 
 	params.OpcodeComputationCostLimit = ctx.Uint64(OpcodeComputationCostLimitFlag.Name)
 
+	// Only SCNs could set IdleBlockSuppressionFlag and IdleBlockIntervalFlag
+	if ctx.IsSet(IdleBlockSuppressionFlag.Name) {
+		params.IdleBlockSuppression = ctx.Bool(IdleBlockSuppressionFlag.Name)
+		params.IdleBlockInterval = ctx.Duration(IdleBlockIntervalFlag.Name)
+		if params.IdleBlockInterval < 0 {
+			logger.Crit("Idle block interval should not be negative", "interval", params.IdleBlockInterval)
+		}
+		if params.IdleBlockSuppression {
+			logger.Info("Idle block suppression is enabled", "interval", params.IdleBlockInterval)
+			if params.IdleBlockInterval == 0 && ctx.Bool(ServiceChainAnchoringFlag.Name) {
+				logger.Warn("Anchoring is delayed until a block with transactions is sealed, set --idle-block-interval to bound the delay")
+			}
+		}
+	}
+
 	if ctx.IsSet(SnapshotFlag.Name) {
 		cfg.SnapshotCacheSize = ctx.Int(SnapshotCacheSizeFlag.Name)
 		if cfg.StartBlockNumber != 0 {
@@ -821,6 +970,17 @@ func setTxPool(ctx *cli.Context, cfg *blockchain.TxPoolConfig) {
 	if ctx.IsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.Duration(TxPoolLifetimeFlag.Name)
 	}
+	if ctx.IsSet(TxPoolFeePayerAllowanceRegistryFlag.Name) {
+		registry := ctx.String(TxPoolFeePayerAllowanceRegistryFlag.Name)
+		if !common.IsHexAddress(registry) {
+			log.Fatalf("Option %q: invalid address %q", TxPoolFeePayerAllowanceRegistryFlag.Name, registry)
+		}
+		addr := common.HexToAddress(registry)
+		cfg.FeePayerAllowanceRegistry = &addr
+	}
+	if ctx.IsSet(TxPoolGaslessSenderLimitFlag.Name) {
+		cfg.GaslessSenderLimit = ctx.Uint64(TxPoolGaslessSenderLimitFlag.Name)
+	}
 
 	// PN specific txpool setting
 	if NodeTypeFlag.Value == "pn" {
@@ -861,6 +1021,21 @@ func getNetworkId(ctx *cli.Context) (uint64, bool) {
 	}
 }
 
+// setOverrideForks adds the activation blocks of the hardforks overridden by --override.<fork>
+// to the ones of the config file.
+func setOverrideForks(ctx *cli.Context, cfg *cn.Config) {
+	for _, fork := range params.OverridableForks {
+		name := "override." + fork
+		if !ctx.IsSet(name) {
+			continue
+		}
+		if cfg.OverrideForks == nil {
+			cfg.OverrideForks = make(map[string]uint64)
+		}
+		cfg.OverrideForks[fork] = ctx.Uint64(name)
+	}
+}
+
 func setTxResendConfig(ctx *cli.Context, cfg *cn.Config) {
 	// Set the Tx resending related configuration variables
 	cfg.TxResendInterval = ctx.Uint64(TxResendIntervalFlag.Name)
@@ -1081,6 +1256,14 @@ func (kCfg *KlayConfig) SetServiceChainConfig(ctx *cli.Context) {
 	cfg.ServiceChainConsensus = ServiceChainConsensusFlag.Value
 	cfg.ServiceChainParentOperatorGasLimit = ctx.Uint64(ServiceChainParentOperatorTxGasLimitFlag.Name)
 	cfg.ServiceChainChildOperatorGasLimit = ctx.Uint64(ServiceChainChildOperatorTxGasLimitFlag.Name)
+	cfg.ParentRPCHedgeSize = ctx.Int(ParentRPCHedgeSizeFlag.Name)
+	cfg.ParentRPCHealthTimeout = ctx.Duration(ParentRPCHealthTimeoutFlag.Name)
+	cfg.ParentGasPriceSync = ctx.Bool(ParentGasPriceSyncFlag.Name)
+	cfg.ParentGasPriceSyncInterval = ctx.Uint64(ParentGasPriceSyncIntervalFlag.Name)
+	cfg.ParentGasPriceSyncSmoothing = ctx.Uint64(ParentGasPriceSyncSmoothingFlag.Name)
+	cfg.ParentGasPriceSyncMaxChange = ctx.Uint64(ParentGasPriceSyncMaxChangeFlag.Name)
+	cfg.ParentGasPriceSyncMin = ctx.Uint64(ParentGasPriceSyncMinFlag.Name)
+	cfg.ParentGasPriceSyncMax = ctx.Uint64(ParentGasPriceSyncMaxFlag.Name)
 
 	cfg.KASAnchor = ctx.Bool(KASServiceChainAnchorFlag.Name)
 	if cfg.KASAnchor {
@@ -1121,3 +1304,45 @@ func (kCfg *KlayConfig) SetServiceChainConfig(ctx *cli.Context) {
 	cfg.DataDir = kCfg.Node.DataDir
 	cfg.Name = kCfg.Node.Name
 }
+
+func (kCfg *KlayConfig) SetFaucetConfig(ctx *cli.Context) {
+	cfg := &kCfg.Faucet
+	if !ctx.Bool(EnableFaucetFlag.Name) {
+		return
+	}
+	cfg.Enabled = true
+
+	account := ctx.String(FaucetAccountFlag.Name)
+	if !common.IsHexAddress(account) {
+		log.Fatalf("Option %q: invalid address %q", FaucetAccountFlag.Name, account)
+	}
+	cfg.Account = common.HexToAddress(account)
+	cfg.PasswordFile = ctx.String(FaucetPasswordFileFlag.Name)
+	cfg.Amount = ctx.Uint64(FaucetAmountFlag.Name)
+	cfg.Period = ctx.Duration(FaucetPeriodFlag.Name)
+	cfg.CaptchaURL = ctx.String(FaucetCaptchaURLFlag.Name)
+	cfg.CaptchaSecret = ctx.String(FaucetCaptchaSecretFlag.Name)
+	cfg.WebhookURL = ctx.String(FaucetWebhookFlag.Name)
+	if cfg.CaptchaURL != "" && cfg.CaptchaSecret == "" {
+		log.Fatalf("Option %q is required with %q", FaucetCaptchaSecretFlag.Name, FaucetCaptchaURLFlag.Name)
+	}
+}
+
+func (kCfg *KlayConfig) SetSubProxyConfig(ctx *cli.Context) {
+	cfg := &kCfg.SubProxy
+	if !ctx.Bool(EnableSubProxyFlag.Name) {
+		return
+	}
+	cfg.Enabled = true
+
+	cfg.Upstream = ctx.String(SubProxyUpstreamFlag.Name)
+	if cfg.Upstream == "" {
+		log.Fatalf("Option %q is required with %q", SubProxyUpstreamFlag.Name, EnableSubProxyFlag.Name)
+	}
+	cfg.MaxClients = ctx.Int(SubProxyMaxClientsFlag.Name)
+	cfg.ClientBuffer = ctx.Int(SubProxyClientBufferFlag.Name)
+	cfg.ReconnectDelay = ctx.Duration(SubProxyReconnectFlag.Name)
+	if cfg.ClientBuffer <= 0 {
+		log.Fatalf("Option %q must be positive", SubProxyClientBufferFlag.Name)
+	}
+}
@@ -45,6 +45,10 @@ var FlagGroups = []FlagGroup{
 			IdentityFlag,
 			SyncModeFlag,
 			GCModeFlag,
+			CheckpointIntervalFlag,
+			StallTimeoutFlag,
+			StandbyFlag,
+			StandbyWindowFlag,
 			SrvTypeFlag,
 			ExtraDataFlag,
 			ConfigFileFlag,
@@ -80,6 +84,8 @@ var FlagGroups = []FlagGroup{
 			TxPoolNonExecSlotsAllFlag,
 			TxPoolLifetimeFlag,
 			TxPoolKeepLocalsFlag,
+			TxPoolFeePayerAllowanceRegistryFlag,
+			TxPoolGaslessSenderLimitFlag,
 			TxResendIntervalFlag,
 			TxResendCountFlag,
 			TxResendUseLegacyFlag,
@@ -93,6 +99,7 @@ var FlagGroups = []FlagGroup{
 			NumStateTrieShardsFlag,
 			LevelDBCompressionTypeFlag,
 			LevelDBNoBufferPoolFlag,
+			HeaderMmapCacheSizeFlag,
 			RocksDBSecondaryFlag,
 			RocksDBCacheSizeFlag,
 			RocksDBDumpMallocStatFlag,
@@ -110,6 +117,15 @@ var FlagGroups = []FlagGroup{
 			DynamoDBReadOnlyFlag,
 			NoParallelDBWriteFlag,
 			SenderTxHashIndexingFlag,
+			BalanceHistoryIndexingFlag,
+			EpochSummaryIndexingFlag,
+			RewardIndexingFlag,
+			VerifyOnStartFlag,
+			AutoCompactionFlag,
+			AutoCompactionScheduleFlag,
+			AutoCompactionMaxPendingRequestsFlag,
+			AutoCompactionRecentBlocksFlag,
+			AutoCompactionChunkBlocksFlag,
 			DBNoPerformanceMetricsFlag,
 		},
 	},
@@ -224,6 +240,18 @@ var FlagGroups = []FlagGroup{
 		Flags: []cli.Flag{
 			ServiceChainSignerFlag,
 			RewardbaseFlag,
+			RewardPolicyFlag,
+			RewardPolicyBlockFlag,
+			RewardVerifyFlag,
+			RewardCheckInvariantsFlag,
+			RewardAuditFeeFlag,
+			RewardHistoryFlag,
+			RewardHistoryRetentionFlag,
+			RewardMonitorFlag,
+			RewardMonitorMintedFlag,
+			RewardMonitorBurnRatioFlag,
+			RewardMonitorShareFlag,
+			RewardReportFormatFlag,
 		},
 	},
 	{
@@ -241,6 +269,13 @@ var FlagGroups = []FlagGroup{
 			RWTimerWaitTimeFlag,
 			RWTimerIntervalFlag,
 			NetrestrictFlag,
+			DiversityMaxPerSubnetFlag,
+			DiversityMaxPerASNFlag,
+			DiversityMaxPerRegionFlag,
+			DiversityNetInfoFlag,
+			AttestationFlag,
+			AttestationRequireFlag,
+			AttestationVersionsFlag,
 			NodeKeyFileFlag,
 			NodeKeyHexFlag,
 			NetworkIdFlag,
@@ -254,6 +289,7 @@ var FlagGroups = []FlagGroup{
 			MetricsEnabledFlag,
 			PrometheusExporterFlag,
 			PrometheusExporterPortFlag,
+			EventMetricsFlag,
 		},
 	},
 	{
@@ -263,6 +299,11 @@ var FlagGroups = []FlagGroup{
 			VMLogTargetFlag,
 			VMTraceInternalTxFlag,
 			VMOpDebugFlag,
+			VMTraceSinkDirFlag,
+			VMTraceSinkMaxFileSizeFlag,
+			VMTraceSinkMaxFilesFlag,
+			VMTraceSinkSocketFlag,
+			VMTraceSinkOTLPFlag,
 		},
 	},
 	{
@@ -275,10 +316,16 @@ var FlagGroups = []FlagGroup{
 			RPCPortFlag,
 			RPCCORSDomainFlag,
 			RPCVirtualHostsFlag,
+			RPCVirtualEndpointsFlag,
+			RPCComputeBudgetFlag,
 			RPCApiFlag,
 			RPCGlobalGasCap,
 			RPCGlobalEVMTimeoutFlag,
 			RPCGlobalEthTxFeeCapFlag,
+			RPCAnnotateAddressLabelsFlag,
+			RPCAddressLabelsFlag,
+			RPCContractRegistryFlag,
+			RPCPreconfMaxBlocksFlag,
 			RPCConcurrencyLimit,
 			RPCNonEthCompatibleFlag,
 			RPCExecutionTimeoutFlag,
@@ -289,10 +336,19 @@ var FlagGroups = []FlagGroup{
 			UnsafeDebugDisableFlag,
 			IPCDisabledFlag,
 			IPCPathFlag,
+			AdminPathFlag,
+			AdminApiFlag,
+			AdminTokenFileFlag,
+			AdminTLSCertFlag,
+			AdminTLSKeyFlag,
+			AdminTLSClientCAFlag,
+			AdminExclusiveFlag,
 			WSEnabledFlag,
 			WSListenAddrFlag,
 			WSPortFlag,
 			WSApiFlag,
+			WSVirtualEndpointsFlag,
+			WSComputeBudgetFlag,
 			WSAllowedOriginsFlag,
 			WSMaxConnections,
 			WSMaxSubscriptionPerConn,
@@ -307,6 +363,10 @@ var FlagGroups = []FlagGroup{
 			MaxRequestContentLengthFlag,
 			APIFilterGetLogsDeadlineFlag,
 			APIFilterGetLogsMaxItemsFlag,
+			APIFilterTimeoutFlag,
+			APIFilterMaxItemsFlag,
+			APIFilterMaxFiltersPerClientFlag,
+			APIFilterMaxSubscriptionsPerClientFlag,
 		},
 	},
 	{
@@ -323,6 +383,8 @@ var FlagGroups = []FlagGroup{
 			SubBridgeListenPortFlag,
 			AnchoringPeriodFlag,
 			SentChainTxsLimit,
+			IdleBlockSuppressionFlag,
+			IdleBlockIntervalFlag,
 			ParentChainIDFlag,
 			VTRecoveryFlag,
 			VTRecoveryIntervalFlag,
@@ -330,6 +392,14 @@ var FlagGroups = []FlagGroup{
 			ServiceChainNewAccountFlag,
 			ServiceChainParentOperatorTxGasLimitFlag,
 			ServiceChainChildOperatorTxGasLimitFlag,
+			ParentRPCHedgeSizeFlag,
+			ParentRPCHealthTimeoutFlag,
+			ParentGasPriceSyncFlag,
+			ParentGasPriceSyncIntervalFlag,
+			ParentGasPriceSyncSmoothingFlag,
+			ParentGasPriceSyncMaxChangeFlag,
+			ParentGasPriceSyncMinFlag,
+			ParentGasPriceSyncMaxFlag,
 			KASServiceChainAnchorFlag,
 			KASServiceChainAnchorPeriodFlag,
 			KASServiceChainAnchorUrlFlag,
@@ -340,6 +410,77 @@ var FlagGroups = []FlagGroup{
 			KASServiceChainAnchorRequestTimeoutFlag,
 		},
 	},
+	{
+		Name: "LOAD TEST",
+		Flags: []cli.Flag{
+			LoadTestKeyFileFlag,
+			LoadTestFeePayerKeyFileFlag,
+			LoadTestMixFlag,
+			LoadTestTPSFlag,
+			LoadTestDurationFlag,
+			LoadTestToFlag,
+			LoadTestContractFlag,
+			LoadTestCallDataFlag,
+			LoadTestGasLimitFlag,
+			LoadTestGasPriceFlag,
+		},
+	},
+	{
+		Name: "FORKED NETWORK",
+		Flags: []cli.Flag{
+			ForkBlockFlag,
+			ForkListenAddrFlag,
+			ForkCORSDomainFlag,
+			ForkVirtualHostsFlag,
+		},
+	},
+	{
+		Name:  "HARDFORK OVERRIDE",
+		Flags: OverrideForkFlags,
+	},
+	{
+		Name: "VALIDATOR ONBOARDING",
+		Flags: []cli.Flag{
+			ValidatorEndpointFlag,
+			ValidatorRewardAddressFlag,
+			ValidatorStakingContractFlag,
+			ValidatorKIP113Flag,
+			ValidatorOutputFlag,
+		},
+	},
+	{
+		Name: "REWARD EXPORT",
+		Flags: []cli.Flag{
+			RewardExportFromFlag,
+			RewardExportToFlag,
+			RewardExportFormatFlag,
+			RewardExportOutputFlag,
+			RewardExportAmountFormatFlag,
+		},
+	},
+	{
+		Name: "FAUCET",
+		Flags: []cli.Flag{
+			EnableFaucetFlag,
+			FaucetAccountFlag,
+			FaucetPasswordFileFlag,
+			FaucetAmountFlag,
+			FaucetPeriodFlag,
+			FaucetCaptchaURLFlag,
+			FaucetCaptchaSecretFlag,
+			FaucetWebhookFlag,
+		},
+	},
+	{
+		Name: "SUBSCRIPTION PROXY",
+		Flags: []cli.Flag{
+			EnableSubProxyFlag,
+			SubProxyUpstreamFlag,
+			SubProxyMaxClientsFlag,
+			SubProxyClientBufferFlag,
+			SubProxyReconnectFlag,
+		},
+	},
 	{
 		Name: "MISC",
 		Flags: []cli.Flag{
@@ -38,8 +38,12 @@ import (
 	"github.com/klaytn/klaytn/node"
 	"github.com/klaytn/klaytn/node/cn"
 	"github.com/klaytn/klaytn/node/cn/filters"
+	"github.com/klaytn/klaytn/node/cn/tracers"
+	"github.com/klaytn/klaytn/node/faucet"
 	"github.com/klaytn/klaytn/node/sc"
+	"github.com/klaytn/klaytn/node/subproxy"
 	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/klaytn/klaytn/storage/statedb"
 	"github.com/urfave/cli/v2"
@@ -173,6 +177,37 @@ var (
 		EnvVars:  []string{"KLAYTN_GCMODE"},
 		Category: "KLAY",
 	}
+	CheckpointIntervalFlag = &cli.Uint64Flag{
+		Name:     "istanbul.checkpoint-interval",
+		Usage:    "Interval of the checkpoint blocks whose committed seals authenticate the headers in between during the sync (0 = disabled)",
+		Value:    0,
+		Aliases:  []string{"common.istanbul-checkpoint-interval"},
+		EnvVars:  []string{"KLAYTN_ISTANBUL_CHECKPOINT_INTERVAL"},
+		Category: "KLAY",
+	}
+	StallTimeoutFlag = &cli.Uint64Flag{
+		Name:     "istanbul.stall-timeout",
+		Usage:    "Seconds without a sealed block after which the consensus log levels are raised to debug and the consensus messages are traced (0 = disabled)",
+		Value:    0,
+		Aliases:  []string{"common.istanbul-stall-timeout"},
+		EnvVars:  []string{"KLAYTN_ISTANBUL_STALL_TIMEOUT"},
+		Category: "KLAY",
+	}
+	StandbyFlag = &cli.BoolFlag{
+		Name:     "istanbul.standby",
+		Usage:    "Run as a hot standby sharing the validator key of a primary node, which stays passive while the primary is alive",
+		Aliases:  []string{"common.istanbul-standby"},
+		EnvVars:  []string{"KLAYTN_ISTANBUL_STANDBY"},
+		Category: "KLAY",
+	}
+	StandbyWindowFlag = &cli.Uint64Flag{
+		Name:     "istanbul.standby-window",
+		Usage:    "Seconds without a consensus message or a block signed by the primary after which the standby takes over proposing and committing",
+		Value:    30,
+		Aliases:  []string{"common.istanbul-standby-window"},
+		EnvVars:  []string{"KLAYTN_ISTANBUL_STANDBY_WINDOW"},
+		Category: "KLAY",
+	}
 	LightKDFFlag = &cli.BoolFlag{
 		Name:     "lightkdf",
 		Usage:    "Reduce key-derivation RAM & CPU usage at some expense of KDF strength",
@@ -295,6 +330,19 @@ var (
 		EnvVars:  []string{"KLAYTN_TXPOOL_LIFETIME"},
 		Category: "TXPOOL",
 	}
+	TxPoolFeePayerAllowanceRegistryFlag = &cli.StringFlag{
+		Name:     "txpool.feepayer-allowance-registry",
+		Usage:    "Address of the registry contract where the fee payers publish the allowance policies checked before admitting fee-delegated transactions",
+		EnvVars:  []string{"KLAYTN_TXPOOL_FEEPAYER_ALLOWANCE_REGISTRY"},
+		Category: "TXPOOL",
+	}
+	TxPoolGaslessSenderLimitFlag = &cli.Uint64Flag{
+		Name:     "txpool.gasless-sender-limit",
+		Usage:    "Maximum number of remote transactions a sender can submit per block on a gasless chain (0 = unlimited)",
+		Value:    cn.GetDefaultConfig().TxPool.GaslessSenderLimit,
+		EnvVars:  []string{"KLAYTN_TXPOOL_GASLESS_SENDER_LIMIT"},
+		Category: "TXPOOL",
+	}
 	// PN specific txpool settings
 	TxPoolSpamThrottlerDisableFlag = &cli.BoolFlag{
 		Name:    "txpool.spamthrottler.disable",
@@ -350,6 +398,14 @@ var (
 		EnvVars:  []string{"KLAYTN_DB_LEVELDB_NO_BUFFER_POOL"},
 		Category: "DATABASE",
 	}
+	HeaderMmapCacheSizeFlag = &cli.IntFlag{
+		Name:     "db.header-mmap-size",
+		Usage:    "Size of the memory-mapped cache of block headers and canonical hashes (MiB). 0 disables the cache",
+		Value:    0,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_DB_HEADER_MMAP_SIZE"},
+		Category: "DATABASE",
+	}
 	RocksDBSecondaryFlag = &cli.BoolFlag{
 		Name:     "db.rocksdb.secondary",
 		Usage:    "Enable rocksdb secondary mode (read-only and catch-up with primary node dynamically)",
@@ -645,6 +701,69 @@ var (
 		EnvVars:  []string{"KLAYTN_SENDERTXHASHINDEXING"},
 		Category: "DATABASE",
 	}
+	BalanceHistoryIndexingFlag = &cli.BoolFlag{
+		Name:     "balancehistoryindexing",
+		Usage:    "Enables storing the balance changes of accounts to serve klay_getBalanceHistory (not supported by DynamoDB and BadgerDB)",
+		Aliases:  []string{"common.balance-history-indexing"},
+		EnvVars:  []string{"KLAYTN_BALANCEHISTORYINDEXING"},
+		Category: "DATABASE",
+	}
+	EpochSummaryIndexingFlag = &cli.BoolFlag{
+		Name:     "epochsummaryindexing",
+		Usage:    "Enables storing the summary of each epoch (parameters, validators, rewards and burnt fees) to serve klay_getEpochSummary",
+		Aliases:  []string{"common.epoch-summary-indexing"},
+		EnvVars:  []string{"KLAYTN_EPOCHSUMMARYINDEXING"},
+		Category: "DATABASE",
+	}
+	RewardIndexingFlag = &cli.BoolFlag{
+		Name:     "rewardindexing",
+		Usage:    "Enables accumulating the minted and fee rewards of each recipient to serve klay_getAccumulatedRewards (not supported by DynamoDB and BadgerDB)",
+		Aliases:  []string{"common.reward-indexing"},
+		EnvVars:  []string{"KLAYTN_REWARDINDEXING"},
+		Category: "DATABASE",
+	}
+	VerifyOnStartFlag = &cli.StringFlag{
+		Name:     "verify-on-start",
+		Usage:    `Integrity check of the recent chain data on startup ("strict", "sample", "off"). "strict" refuses to start on any issue`,
+		Value:    string(blockchain.IntegrityCheckOff),
+		Aliases:  []string{"common.verify-on-start"},
+		EnvVars:  []string{"KLAYTN_VERIFY_ON_START"},
+		Category: "DATABASE",
+	}
+	AutoCompactionFlag = &cli.BoolFlag{
+		Name:     "db.auto-compaction",
+		Usage:    "Enables the compaction of the cold block data during the scheduled low-traffic windows (LevelDB and RocksDB only)",
+		EnvVars:  []string{"KLAYTN_DB_AUTO_COMPACTION"},
+		Category: "DATABASE",
+	}
+	AutoCompactionScheduleFlag = &cli.StringFlag{
+		Name:     "db.auto-compaction.schedule",
+		Usage:    "Cron expression (minute hour day-of-month month day-of-week, local time) of the minutes when a chunk of cold blocks can be compacted",
+		Value:    database.DefaultCompactionSchedulerConfig.Schedule,
+		EnvVars:  []string{"KLAYTN_DB_AUTO_COMPACTION_SCHEDULE"},
+		Category: "DATABASE",
+	}
+	AutoCompactionMaxPendingRequestsFlag = &cli.Int64Flag{
+		Name:     "db.auto-compaction.max-pending-requests",
+		Usage:    "The scheduled compaction is skipped if more RPC requests are in flight",
+		Value:    database.DefaultCompactionSchedulerConfig.MaxPendingRequests,
+		EnvVars:  []string{"KLAYTN_DB_AUTO_COMPACTION_MAX_PENDING_REQUESTS"},
+		Category: "DATABASE",
+	}
+	AutoCompactionRecentBlocksFlag = &cli.Uint64Flag{
+		Name:     "db.auto-compaction.recent-blocks",
+		Usage:    "Number of recent blocks which are not compacted by the scheduler",
+		Value:    database.DefaultCompactionSchedulerConfig.RecentBlocks,
+		EnvVars:  []string{"KLAYTN_DB_AUTO_COMPACTION_RECENT_BLOCKS"},
+		Category: "DATABASE",
+	}
+	AutoCompactionChunkBlocksFlag = &cli.Uint64Flag{
+		Name:     "db.auto-compaction.chunk-blocks",
+		Usage:    "Number of blocks compacted at once by the scheduler",
+		Value:    database.DefaultCompactionSchedulerConfig.ChunkBlocks,
+		EnvVars:  []string{"KLAYTN_DB_AUTO_COMPACTION_CHUNK_BLOCKS"},
+		Category: "DATABASE",
+	}
 	ChildChainIndexingFlag = &cli.BoolFlag{
 		Name:     "childchainindexing",
 		Usage:    "Enables storing transaction hash of child chain transaction for fast access to child chain data",
@@ -676,6 +795,95 @@ var (
 		EnvVars:  []string{"KLAYTN_REWARDBASE"},
 		Category: "CONSENSUS",
 	}
+	RewardPolicyFlag = &cli.StringFlag{
+		Name:     "reward.policy",
+		Usage:    "Name of the custom reward policy, registered in the binary, replacing the built-in ones (private network only)",
+		Aliases:  []string{"common.reward.policy"},
+		EnvVars:  []string{"KLAYTN_REWARD_POLICY"},
+		Category: "CONSENSUS",
+	}
+	RewardPolicyBlockFlag = &cli.Uint64Flag{
+		Name:     "reward.policyblock",
+		Usage:    "Block number from which the custom reward policy is used",
+		Aliases:  []string{"common.reward.policy-block"},
+		EnvVars:  []string{"KLAYTN_REWARD_POLICYBLOCK"},
+		Category: "CONSENSUS",
+	}
+	RewardVerifyFlag = &cli.BoolFlag{
+		Name:     "reward.verify",
+		Usage:    "Verifies the rewards paid in each imported block against the reward spec, logging the divergences",
+		Aliases:  []string{"common.reward.verify"},
+		EnvVars:  []string{"KLAYTN_REWARD_VERIFY"},
+		Category: "CONSENSUS",
+	}
+	RewardCheckInvariantsFlag = &cli.BoolFlag{
+		Name:     "reward.checkinvariants",
+		Usage:    "Rejects a block whose reward spec violates the reward invariants (e.g. minted + fee - burnt == sum of rewards) instead of distributing the rewards",
+		Aliases:  []string{"common.reward.check-invariants"},
+		EnvVars:  []string{"KLAYTN_REWARD_CHECKINVARIANTS"},
+		Category: "CONSENSUS",
+	}
+	RewardAuditFeeFlag = &cli.BoolFlag{
+		Name:     "reward.auditfee",
+		Usage:    "Audits the total fee of each imported block estimated by the reward module against the fees collected from its transactions, logging the divergences",
+		Aliases:  []string{"common.reward.audit-fee"},
+		EnvVars:  []string{"KLAYTN_REWARD_AUDITFEE"},
+		Category: "CONSENSUS",
+	}
+	RewardHistoryFlag = &cli.BoolFlag{
+		Name:     "reward.history",
+		Usage:    "Persists the reward of each imported block as a checkpoint, serving klay_getRewards of the blocks whose staking information is pruned",
+		Aliases:  []string{"common.reward.history"},
+		EnvVars:  []string{"KLAYTN_REWARD_HISTORY"},
+		Category: "CONSENSUS",
+	}
+	RewardHistoryRetentionFlag = &cli.Uint64Flag{
+		Name:     "reward.history.retention",
+		Usage:    "Number of recent blocks whose reward checkpoints are kept by --reward.history (0 = keep all)",
+		Value:    0,
+		Aliases:  []string{"common.reward.history.retention"},
+		EnvVars:  []string{"KLAYTN_REWARD_HISTORY_RETENTION"},
+		Category: "CONSENSUS",
+	}
+	RewardMonitorFlag = &cli.BoolFlag{
+		Name:     "reward.monitor",
+		Usage:    "Compares the reward of each imported block with the previous one, alerting the changes beyond the thresholds by logs and metrics",
+		Aliases:  []string{"common.reward.monitor"},
+		EnvVars:  []string{"KLAYTN_REWARD_MONITOR"},
+		Category: "CONSENSUS",
+	}
+	RewardMonitorMintedFlag = &cli.Float64Flag{
+		Name:     "reward.monitor.minted",
+		Usage:    "Relative change of the minted amount alerted by --reward.monitor, in percent (0 = disabled)",
+		Value:    reward.DefaultRewardAnomalyThresholds.Minted,
+		Aliases:  []string{"common.reward.monitor.minted"},
+		EnvVars:  []string{"KLAYTN_REWARD_MONITOR_MINTED"},
+		Category: "CONSENSUS",
+	}
+	RewardMonitorBurnRatioFlag = &cli.Float64Flag{
+		Name:     "reward.monitor.burnratio",
+		Usage:    "Change of the ratio of the burnt fee to the total fee alerted by --reward.monitor, in percentage points (0 = disabled)",
+		Value:    reward.DefaultRewardAnomalyThresholds.BurnRatio,
+		Aliases:  []string{"common.reward.monitor.burn-ratio"},
+		EnvVars:  []string{"KLAYTN_REWARD_MONITOR_BURNRATIO"},
+		Category: "CONSENSUS",
+	}
+	RewardMonitorShareFlag = &cli.Float64Flag{
+		Name:     "reward.monitor.share",
+		Usage:    "Change of the shares of the proposer, stakers, KFF and KCF portions alerted by --reward.monitor, in percentage points (0 = disabled)",
+		Value:    reward.DefaultRewardAnomalyThresholds.Share,
+		Aliases:  []string{"common.reward.monitor.share"},
+		EnvVars:  []string{"KLAYTN_REWARD_MONITOR_SHARE"},
+		Category: "CONSENSUS",
+	}
+	RewardReportFormatFlag = &cli.StringFlag{
+		Name:     "reward.report-format",
+		Usage:    `Default format of klay_getRewardsReport re-expressing the reward amounts as "unit[:decimals[:rounding]]", e.g. "klay:6:half-even" (unit: peb, ston, klay; rounding: down, up, half-up, half-even)`,
+		Value:    reward.DefaultRewardReportFormat.Unit,
+		Aliases:  []string{"common.reward.report-format"},
+		EnvVars:  []string{"KLAYTN_REWARD_REPORT_FORMAT"},
+		Category: "CONSENSUS",
+	}
 	ExtraDataFlag = &cli.StringFlag{
 		Name:     "extradata",
 		Usage:    "Block extra data set by the work (default = client version)",
@@ -755,6 +963,42 @@ var (
 		EnvVars:  []string{"KLAYTN_VM_OPDEBUG"},
 		Category: "VIRTUAL MACHINE",
 	}
+	VMTraceSinkDirFlag = &cli.StringFlag{
+		Name:     "vm.tracesink.dir",
+		Usage:    "Directory of the rotating files the debug traces can be streamed to by the file sink",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_VM_TRACESINK_DIR"},
+		Category: "VIRTUAL MACHINE",
+	}
+	VMTraceSinkMaxFileSizeFlag = &cli.Uint64Flag{
+		Name:     "vm.tracesink.maxfilesize",
+		Usage:    "Size in bytes of a trace file after which the file sink rotates it",
+		Value:    tracers.DefaultSinkMaxFileSize,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_VM_TRACESINK_MAXFILESIZE"},
+		Category: "VIRTUAL MACHINE",
+	}
+	VMTraceSinkMaxFilesFlag = &cli.IntFlag{
+		Name:     "vm.tracesink.maxfiles",
+		Usage:    "Number of the rotated files kept per trace by the file sink (0 = unlimited)",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_VM_TRACESINK_MAXFILES"},
+		Category: "VIRTUAL MACHINE",
+	}
+	VMTraceSinkSocketFlag = &cli.StringFlag{
+		Name:     "vm.tracesink.socket",
+		Usage:    "Path of the Unix socket the debug traces can be streamed to by the socket sink",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_VM_TRACESINK_SOCKET"},
+		Category: "VIRTUAL MACHINE",
+	}
+	VMTraceSinkOTLPFlag = &cli.StringFlag{
+		Name:     "vm.tracesink.otlp",
+		Usage:    "URL of the OTLP/HTTP logs endpoint the debug traces can be streamed to by the otlp sink (e.g. http://localhost:4318/v1/logs)",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_VM_TRACESINK_OTLP"},
+		Category: "VIRTUAL MACHINE",
+	}
 
 	// Logging and debug settings
 	MetricsEnabledFlag = &cli.BoolFlag{
@@ -779,6 +1023,13 @@ var (
 		EnvVars:  []string{"KLAYTN_METRICUTILS_PROMETHEUSEXPORTERPORTFLAG"},
 		Category: "METRIC",
 	}
+	EventMetricsFlag = &cli.StringFlag{
+		Name:     "metrics.events",
+		Usage:    "JSON file of the rules counting, summing or gauging the matching logs of the imported blocks as custom metrics ([{\"name\": .., \"type\": \"counter\", \"event\": \"Deposit(address,uint256)\"}])",
+		Aliases:  []string{"metrics-collection-reporting.events"},
+		EnvVars:  []string{"KLAYTN_METRICS_EVENTS"},
+		Category: "METRIC",
+	}
 
 	// RPC settings
 	RPCEnabledFlag = &cli.BoolFlag{
@@ -820,6 +1071,14 @@ var (
 		EnvVars:  []string{"KLAYTN_RPCVHOSTS"},
 		Category: "API AND CONSOLE",
 	}
+	RPCVirtualEndpointsFlag = &cli.StringFlag{
+		Name:     "rpc.virtual-endpoints",
+		Usage:    "Path to the JSON file defining virtual HTTP-RPC endpoints (paths or hostnames), each with its own API modules, CORS domains and rate limit",
+		Value:    "",
+		Aliases:  []string{"http-rpc.virtual-endpoints"},
+		EnvVars:  []string{"KLAYTN_RPC_VIRTUAL_ENDPOINTS"},
+		Category: "API AND CONSOLE",
+	}
 	RPCApiFlag = &cli.StringFlag{
 		Name:     "rpcapi",
 		Usage:    "API's offered over the HTTP-RPC interface",
@@ -835,6 +1094,13 @@ var (
 		EnvVars:  []string{"KLAYTN_RPC_GASCAP"},
 		Category: "API AND CONSOLE",
 	}
+	RPCComputeBudgetFlag = &cli.Uint64Flag{
+		Name:     "rpc.computebudget",
+		Usage:    "Limits the computation cost of an EVM execution served by a HTTP-RPC call like klay_call and the traces (0 = no budget)",
+		Aliases:  []string{"http-rpc.compute-budget"},
+		EnvVars:  []string{"KLAYTN_RPC_COMPUTEBUDGET"},
+		Category: "API AND CONSOLE",
+	}
 	RPCGlobalEVMTimeoutFlag = &cli.DurationFlag{
 		Name:     "rpc.evmtimeout",
 		Usage:    "Sets a timeout used for eth_call (0=infinite)",
@@ -842,6 +1108,30 @@ var (
 		EnvVars:  []string{"KLAYTN_RPC_EVMTIMEOUT"},
 		Category: "API AND CONSOLE",
 	}
+	RPCAnnotateAddressLabelsFlag = &cli.BoolFlag{
+		Name:     "rpc.annotate-address-labels",
+		Usage:    "Annotates addresses in the reward and staking information RPC responses with labels",
+		EnvVars:  []string{"KLAYTN_RPC_ANNOTATE_ADDRESS_LABELS"},
+		Category: "API AND CONSOLE",
+	}
+	RPCAddressLabelsFlag = &cli.StringFlag{
+		Name:     "rpc.address-labels",
+		Usage:    "JSON file mapping addresses to labels ({\"0x..\": {\"name\": .., \"role\": ..}}) used with --rpc.annotate-address-labels",
+		EnvVars:  []string{"KLAYTN_RPC_ADDRESS_LABELS"},
+		Category: "API AND CONSOLE",
+	}
+	RPCContractRegistryFlag = &cli.StringFlag{
+		Name:     "rpc.contract-registry",
+		Usage:    "Address of the registry contract whose ContractRegistered events are synced into the contract metadata store",
+		EnvVars:  []string{"KLAYTN_RPC_CONTRACT_REGISTRY"},
+		Category: "API AND CONSOLE",
+	}
+	RPCPreconfMaxBlocksFlag = &cli.Uint64Flag{
+		Name:     "rpc.preconf.maxblocks",
+		Usage:    "Enables the transaction preconfirmation admin APIs of a CN promising the inclusion within at most this number of blocks (0 = disabled)",
+		EnvVars:  []string{"KLAYTN_RPC_PRECONF_MAXBLOCKS"},
+		Category: "API AND CONSOLE",
+	}
 	RPCGlobalEthTxFeeCapFlag = &cli.Float64Flag{
 		Name:     "rpc.ethtxfeecap",
 		Usage:    "Sets a cap on transaction fee (in klay) that can be sent via the eth namespace RPC APIs (0 = no cap)",
@@ -935,6 +1225,21 @@ var (
 		EnvVars:  []string{"KLAYTN_WSAPI"},
 		Category: "API AND CONSOLE",
 	}
+	WSComputeBudgetFlag = &cli.Uint64Flag{
+		Name:     "ws.computebudget",
+		Usage:    "Limits the computation cost of an EVM execution served by a WS-RPC call like klay_call and the traces (0 = no budget)",
+		Aliases:  []string{"ws-rpc.compute-budget"},
+		EnvVars:  []string{"KLAYTN_WS_COMPUTEBUDGET"},
+		Category: "API AND CONSOLE",
+	}
+	WSVirtualEndpointsFlag = &cli.StringFlag{
+		Name:     "ws.virtual-endpoints",
+		Usage:    "Path to the JSON file defining virtual WS-RPC endpoints (paths or hostnames), each with its own API modules, allowed origins and handshake rate limit",
+		Value:    "",
+		Aliases:  []string{"ws-rpc.virtual-endpoints"},
+		EnvVars:  []string{"KLAYTN_WS_VIRTUAL_ENDPOINTS"},
+		Category: "API AND CONSOLE",
+	}
 	WSAllowedOriginsFlag = &cli.StringFlag{
 		Name:     "wsorigins",
 		Usage:    "Origins from which to accept websockets requests",
@@ -1012,6 +1317,49 @@ var (
 		EnvVars:  []string{"KLAYTN_IPCPATH"},
 		Category: "API AND CONSOLE",
 	}
+	AdminPathFlag = &cli.PathFlag{
+		Name:     "admin.path",
+		Usage:    "Filename for the authenticated admin socket/pipe within the datadir (explicit paths escape it). Disabled if empty",
+		EnvVars:  []string{"KLAYTN_ADMIN_PATH"},
+		Category: "API AND CONSOLE",
+	}
+	AdminApiFlag = &cli.StringFlag{
+		Name:     "admin.api",
+		Usage:    "API's offered over the admin interface",
+		Value:    strings.Join(rpc.DefaultAdminModules, ","),
+		EnvVars:  []string{"KLAYTN_ADMIN_API"},
+		Category: "API AND CONSOLE",
+	}
+	AdminTokenFileFlag = &cli.PathFlag{
+		Name:     "admin.tokenfile",
+		Usage:    "JSON file mapping the caller names to the bearer tokens of the admin interface",
+		EnvVars:  []string{"KLAYTN_ADMIN_TOKENFILE"},
+		Category: "API AND CONSOLE",
+	}
+	AdminTLSCertFlag = &cli.PathFlag{
+		Name:     "admin.tls.cert",
+		Usage:    "TLS certificate of the admin interface (mutual TLS requires admin.tls.key and admin.tls.clientca)",
+		EnvVars:  []string{"KLAYTN_ADMIN_TLS_CERT"},
+		Category: "API AND CONSOLE",
+	}
+	AdminTLSKeyFlag = &cli.PathFlag{
+		Name:     "admin.tls.key",
+		Usage:    "TLS private key of the admin interface",
+		EnvVars:  []string{"KLAYTN_ADMIN_TLS_KEY"},
+		Category: "API AND CONSOLE",
+	}
+	AdminTLSClientCAFlag = &cli.PathFlag{
+		Name:     "admin.tls.clientca",
+		Usage:    "CA certificate verifying the client certificates of the admin interface",
+		EnvVars:  []string{"KLAYTN_ADMIN_TLS_CLIENTCA"},
+		Category: "API AND CONSOLE",
+	}
+	AdminExclusiveFlag = &cli.BoolFlag{
+		Name:     "admin.exclusive",
+		Usage:    "Serve the admin interface API's only via the admin interface, removing them from IPC, HTTP-RPC, WS-RPC and gRPC",
+		EnvVars:  []string{"KLAYTN_ADMIN_EXCLUSIVE"},
+		Category: "API AND CONSOLE",
+	}
 
 	// ATM the url is left to the user and deployment to
 	JSpathFlag = &cli.StringFlag{
@@ -1052,6 +1400,38 @@ var (
 		EnvVars:  []string{"KLAYTN_API_FILTER_GETLOGS_MAXITEMS"},
 		Category: "API AND CONSOLE",
 	}
+	APIFilterTimeoutFlag = &cli.DurationFlag{
+		Name:     "api.filter.timeout",
+		Usage:    "Duration after which a polling filter not polled expires",
+		Value:    filters.FilterTimeout,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_API_FILTER_TIMEOUT"},
+		Category: "API AND CONSOLE",
+	}
+	APIFilterMaxItemsFlag = &cli.IntFlag{
+		Name:     "api.filter.maxitems",
+		Usage:    "Maximum number of hashes or logs left unpolled in a polling filter before it expires (0 = unlimited)",
+		Value:    filters.MaxFilterItems,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_API_FILTER_MAXITEMS"},
+		Category: "API AND CONSOLE",
+	}
+	APIFilterMaxFiltersPerClientFlag = &cli.IntFlag{
+		Name:     "api.filter.maxfilters",
+		Usage:    "Maximum number of polling filters installed by a client host (0 = unlimited)",
+		Value:    filters.MaxFiltersPerClient,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_API_FILTER_MAXFILTERS"},
+		Category: "API AND CONSOLE",
+	}
+	APIFilterMaxSubscriptionsPerClientFlag = &cli.IntFlag{
+		Name:     "api.filter.maxsubscriptions",
+		Usage:    "Maximum number of subscriptions made by a client host (0 = unlimited)",
+		Value:    filters.MaxSubscriptionsPerClient,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_API_FILTER_MAXSUBSCRIPTIONS"},
+		Category: "API AND CONSOLE",
+	}
 	UnsafeDebugDisableFlag = &cli.BoolFlag{
 		Name:     "rpc.unsafe-debug.disable",
 		Usage:    "Disable unsafe debug APIs (traceTransaction, traceChain, ...).",
@@ -1189,6 +1569,55 @@ var (
 		EnvVars:  []string{"KLAYTN_NETRESTRICT"},
 		Category: "NETWORK",
 	}
+	DiversityMaxPerSubnetFlag = &cli.IntFlag{
+		Name:     "p2p.diversity.maxpersubnet",
+		Usage:    "Maximum number of peers of a CN/PN in the same /16 subnet (0 = unlimited)",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_P2P_DIVERSITY_MAXPERSUBNET"},
+		Category: "NETWORK",
+	}
+	DiversityMaxPerASNFlag = &cli.IntFlag{
+		Name:     "p2p.diversity.maxperasn",
+		Usage:    "Maximum number of peers of a CN/PN in the same AS given by p2p.diversity.netinfo (0 = unlimited)",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_P2P_DIVERSITY_MAXPERASN"},
+		Category: "NETWORK",
+	}
+	DiversityMaxPerRegionFlag = &cli.IntFlag{
+		Name:     "p2p.diversity.maxperregion",
+		Usage:    "Maximum number of peers of a CN/PN in the same region given by p2p.diversity.netinfo (0 = unlimited)",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_P2P_DIVERSITY_MAXPERREGION"},
+		Category: "NETWORK",
+	}
+	DiversityNetInfoFlag = &cli.StringFlag{
+		Name:     "p2p.diversity.netinfo",
+		Usage:    "File mapping network prefixes to AS numbers and region hints, one \"<cidr> <asn> [region]\" per line",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_P2P_DIVERSITY_NETINFO"},
+		Category: "NETWORK",
+	}
+	AttestationFlag = &cli.BoolFlag{
+		Name:     "p2p.attestation",
+		Usage:    "Send an attestation of the chain, the version and the hardforks of the node signed by the nodekey at the handshake",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_P2P_ATTESTATION"},
+		Category: "NETWORK",
+	}
+	AttestationRequireFlag = &cli.BoolFlag{
+		Name:     "p2p.attestation.require",
+		Usage:    "Reject the peers without a valid attestation (implies --p2p.attestation)",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_P2P_ATTESTATION_REQUIRE"},
+		Category: "NETWORK",
+	}
+	AttestationVersionsFlag = &cli.StringSliceFlag{
+		Name:     "p2p.attestation.versions",
+		Usage:    "Versions approved in the attestations of the peers, e.g. v1.12.0+1ab2c3d4e5 (default = any version)",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_P2P_ATTESTATION_VERSIONS"},
+		Category: "NETWORK",
+	}
 	RWTimerIntervalFlag = &cli.Uint64Flag{
 		Name:     "rwtimerinterval",
 		Usage:    "Interval of using rw timer to check if it works well",
@@ -1268,6 +1697,24 @@ var (
 		EnvVars:  []string{"KLAYTN_CHAINTXPERIOD"},
 		Category: "SERVICECHAIN",
 	}
+	IdleBlockSuppressionFlag = &cli.BoolFlag{
+		Name: "idle-block-suppression",
+		Usage: "(experimental option) Do not seal blocks without transactions on the service chain. " +
+			"It only takes effect on a service chain with a single validator, and the anchoring and the APIs " +
+			"assuming a block per period see no block for the suppressed slots. This flag is only applicable to SCN.",
+		Aliases:  []string{"servicechain.idle-block-suppression"},
+		EnvVars:  []string{"KLAYTN_IDLE_BLOCK_SUPPRESSION"},
+		Category: "SERVICECHAIN",
+	}
+	IdleBlockIntervalFlag = &cli.DurationFlag{
+		Name: "idle-block-interval",
+		Usage: "(experimental option) Seal an empty block if no block has been sealed for this duration " +
+			"while idle block suppression is enabled. Zero means that empty blocks are never sealed.",
+		Value:    params.DefaultIdleBlockInterval,
+		Aliases:  []string{"servicechain.idle-block-interval"},
+		EnvVars:  []string{"KLAYTN_IDLE_BLOCK_INTERVAL"},
+		Category: "SERVICECHAIN",
+	}
 	SentChainTxsLimit = &cli.Uint64Flag{
 		Name:     "chaintxlimit",
 		Usage:    "Number of service chain transactions stored for resending",
@@ -1329,6 +1776,69 @@ var (
 		EnvVars:  []string{"KLAYTN_VTRECOVERYINTERVAL"},
 		Category: "SERVICECHAIN",
 	}
+	ParentRPCHedgeSizeFlag = &cli.IntFlag{
+		Name: "parentrpc.hedge-size",
+		Usage: "The number of main-bridges which a read-only RPC request to the parent chain is sent to at once. " +
+			"Multiple main-bridges should be configured in main-bridges.json to use it",
+		Value:    sc.DefaultParentRPCHedgeSize,
+		Aliases:  []string{"servicechain.parent-rpc-hedge-size"},
+		EnvVars:  []string{"KLAYTN_PARENTRPC_HEDGE_SIZE"},
+		Category: "SERVICECHAIN",
+	}
+	ParentRPCHealthTimeoutFlag = &cli.DurationFlag{
+		Name:     "parentrpc.health-timeout",
+		Usage:    "The duration after which a main-bridge not responding to RPC requests is failed over",
+		Value:    sc.DefaultParentRPCHealthTimeout,
+		Aliases:  []string{"servicechain.parent-rpc-health-timeout"},
+		EnvVars:  []string{"KLAYTN_PARENTRPC_HEALTH_TIMEOUT"},
+		Category: "SERVICECHAIN",
+	}
+	ParentGasPriceSyncFlag = &cli.BoolFlag{
+		Name: "sc.gaspricesync",
+		Usage: "Vote the unit price or the base fee bounds following the parent chain's values received by the sub-bridge. " +
+			"The node must be allowed to vote, e.g. the governing node",
+		Aliases:  []string{"servicechain.gas-price-sync"},
+		EnvVars:  []string{"KLAYTN_SC_GASPRICESYNC"},
+		Category: "SERVICECHAIN",
+	}
+	ParentGasPriceSyncIntervalFlag = &cli.Uint64Flag{
+		Name:     "sc.gaspricesync.interval",
+		Usage:    "The interval in blocks to request the parent chain's gas price",
+		Value:    sc.DefaultParentGasPriceSyncInterval,
+		Aliases:  []string{"servicechain.gas-price-sync-interval"},
+		EnvVars:  []string{"KLAYTN_SC_GASPRICESYNC_INTERVAL"},
+		Category: "SERVICECHAIN",
+	}
+	ParentGasPriceSyncSmoothingFlag = &cli.Uint64Flag{
+		Name:     "sc.gaspricesync.smoothing",
+		Usage:    "The percentage of the gap to the parent chain's gas price applied at a time (1-100)",
+		Value:    sc.DefaultParentGasPriceSyncSmoothing,
+		Aliases:  []string{"servicechain.gas-price-sync-smoothing"},
+		EnvVars:  []string{"KLAYTN_SC_GASPRICESYNC_SMOOTHING"},
+		Category: "SERVICECHAIN",
+	}
+	ParentGasPriceSyncMaxChangeFlag = &cli.Uint64Flag{
+		Name:     "sc.gaspricesync.maxchange",
+		Usage:    "The maximum percentage change of a gas price at a time (0 = unlimited)",
+		Value:    sc.DefaultParentGasPriceSyncMaxChange,
+		Aliases:  []string{"servicechain.gas-price-sync-max-change"},
+		EnvVars:  []string{"KLAYTN_SC_GASPRICESYNC_MAXCHANGE"},
+		Category: "SERVICECHAIN",
+	}
+	ParentGasPriceSyncMinFlag = &cli.Uint64Flag{
+		Name:     "sc.gaspricesync.min",
+		Usage:    "The lower cap of the synced gas prices in peb (0 = no cap)",
+		Aliases:  []string{"servicechain.gas-price-sync-min"},
+		EnvVars:  []string{"KLAYTN_SC_GASPRICESYNC_MIN"},
+		Category: "SERVICECHAIN",
+	}
+	ParentGasPriceSyncMaxFlag = &cli.Uint64Flag{
+		Name:     "sc.gaspricesync.max",
+		Usage:    "The upper cap of the synced gas prices in peb (0 = no cap)",
+		Aliases:  []string{"servicechain.gas-price-sync-max"},
+		EnvVars:  []string{"KLAYTN_SC_GASPRICESYNC_MAX"},
+		Category: "SERVICECHAIN",
+	}
 	ServiceChainParentOperatorTxGasLimitFlag = &cli.Uint64Flag{
 		Name:     "sc.parentoperator.gaslimit",
 		Usage:    "Set the default value of gas limit for transactions made by bridge parent operator",
@@ -1935,6 +2445,263 @@ var (
 		Category: "DATABASE MIGRATION",
 	}
 
+	// Load test
+	LoadTestKeyFileFlag = &cli.PathFlag{
+		Name:     "loadtest.keyfile",
+		Usage:    "File of the hex private keys of the funded senders, one key per line",
+		EnvVars:  []string{"KLAYTN_LOADTEST_KEYFILE"},
+		Category: "LOAD TEST",
+	}
+	LoadTestFeePayerKeyFileFlag = &cli.PathFlag{
+		Name:     "loadtest.feepayer.keyfile",
+		Usage:    "File of the hex private key of the fee payer of the fee-delegated transactions",
+		EnvVars:  []string{"KLAYTN_LOADTEST_FEEPAYER_KEYFILE"},
+		Category: "LOAD TEST",
+	}
+	LoadTestMixFlag = &cli.StringFlag{
+		Name:     "loadtest.mix",
+		Usage:    "Weights of the transaction kinds (legacy, feedelegated, contract), e.g. legacy=60,feedelegated=20,contract=20",
+		Value:    "legacy=100",
+		EnvVars:  []string{"KLAYTN_LOADTEST_MIX"},
+		Category: "LOAD TEST",
+	}
+	LoadTestTPSFlag = &cli.IntFlag{
+		Name:     "loadtest.tps",
+		Usage:    "Target transactions per second in total (0 = as fast as possible)",
+		Value:    100,
+		EnvVars:  []string{"KLAYTN_LOADTEST_TPS"},
+		Category: "LOAD TEST",
+	}
+	LoadTestDurationFlag = &cli.DurationFlag{
+		Name:     "loadtest.duration",
+		Usage:    "Duration of the load test",
+		Value:    time.Minute,
+		EnvVars:  []string{"KLAYTN_LOADTEST_DURATION"},
+		Category: "LOAD TEST",
+	}
+	LoadTestToFlag = &cli.StringFlag{
+		Name:     "loadtest.to",
+		Usage:    "Recipient of the value transfers (default: the sender itself)",
+		EnvVars:  []string{"KLAYTN_LOADTEST_TO"},
+		Category: "LOAD TEST",
+	}
+	LoadTestContractFlag = &cli.StringFlag{
+		Name:     "loadtest.contract",
+		Usage:    "Address of the contract called by the contract calls",
+		EnvVars:  []string{"KLAYTN_LOADTEST_CONTRACT"},
+		Category: "LOAD TEST",
+	}
+	LoadTestCallDataFlag = &cli.StringFlag{
+		Name:     "loadtest.calldata",
+		Usage:    "Hex input data of the contract calls",
+		EnvVars:  []string{"KLAYTN_LOADTEST_CALLDATA"},
+		Category: "LOAD TEST",
+	}
+	LoadTestGasLimitFlag = &cli.Uint64Flag{
+		Name:     "loadtest.gaslimit",
+		Usage:    "Gas limit of the contract calls",
+		Value:    100000,
+		EnvVars:  []string{"KLAYTN_LOADTEST_GASLIMIT"},
+		Category: "LOAD TEST",
+	}
+	LoadTestGasPriceFlag = &cli.Uint64Flag{
+		Name:     "loadtest.gasprice",
+		Usage:    "Gas price of the transactions in peb (0 = the gas price suggested by the endpoint)",
+		EnvVars:  []string{"KLAYTN_LOADTEST_GASPRICE"},
+		Category: "LOAD TEST",
+	}
+
+	// Forked network
+	ForkBlockFlag = &cli.Uint64Flag{
+		Name:     "fork.block",
+		Usage:    "Block number of the remote network to fork the state at (0 = the latest block)",
+		EnvVars:  []string{"KLAYTN_FORK_BLOCK"},
+		Category: "FORKED NETWORK",
+	}
+	ForkListenAddrFlag = &cli.StringFlag{
+		Name:     "fork.listen",
+		Usage:    "HTTP-RPC listening address of the forked network",
+		Value:    "127.0.0.1:8551",
+		EnvVars:  []string{"KLAYTN_FORK_LISTEN"},
+		Category: "FORKED NETWORK",
+	}
+	ForkCORSDomainFlag = &cli.StringFlag{
+		Name:     "fork.corsdomain",
+		Usage:    "Comma separated list of domains from which to accept cross origin requests to the forked network",
+		EnvVars:  []string{"KLAYTN_FORK_CORSDOMAIN"},
+		Category: "FORKED NETWORK",
+	}
+	ForkVirtualHostsFlag = &cli.StringFlag{
+		Name:     "fork.vhosts",
+		Usage:    "Comma separated list of virtual hostnames from which to accept requests to the forked network. Accepts '*' wildcard.",
+		Value:    "localhost",
+		EnvVars:  []string{"KLAYTN_FORK_VHOSTS"},
+		Category: "FORKED NETWORK",
+	}
+
+	// Hardfork overrides, one --override.<fork> flag for each of params.OverridableForks
+	OverrideForkFlags = newOverrideForkFlags()
+
+	// Validator onboarding
+	ValidatorEndpointFlag = &cli.StringFlag{
+		Name:     "validator.endpoint",
+		Usage:    "RPC endpoint of a node of the network to check the registration preconditions against (empty = skip the checks)",
+		EnvVars:  []string{"KLAYTN_VALIDATOR_ENDPOINT"},
+		Category: "VALIDATOR ONBOARDING",
+	}
+	ValidatorRewardAddressFlag = &cli.StringFlag{
+		Name:     "validator.rewardaddress",
+		Usage:    "Reward address of the new CN",
+		EnvVars:  []string{"KLAYTN_VALIDATOR_REWARDADDRESS"},
+		Category: "VALIDATOR ONBOARDING",
+	}
+	ValidatorStakingContractFlag = &cli.StringFlag{
+		Name:     "validator.staking",
+		Usage:    "Address of the CnStakingContract of the new CN, if deployed",
+		EnvVars:  []string{"KLAYTN_VALIDATOR_STAKING"},
+		Category: "VALIDATOR ONBOARDING",
+	}
+	ValidatorKIP113Flag = &cli.StringFlag{
+		Name:     "validator.kip113",
+		Usage:    "Address of the KIP-113 contract registering the BLS public keys, if deployed",
+		EnvVars:  []string{"KLAYTN_VALIDATOR_KIP113"},
+		Category: "VALIDATOR ONBOARDING",
+	}
+	ValidatorOutputFlag = &cli.PathFlag{
+		Name:     "validator.output",
+		Usage:    "Directory of the transaction templates and the report (default: validator directory in the datadir)",
+		EnvVars:  []string{"KLAYTN_VALIDATOR_OUTPUT"},
+		Category: "VALIDATOR ONBOARDING",
+	}
+
+	// Reward export
+	RewardExportFromFlag = &cli.Uint64Flag{
+		Name:     "export.from",
+		Usage:    "First block number whose rewards are exported",
+		Value:    1,
+		Aliases:  []string{"from"},
+		EnvVars:  []string{"KLAYTN_EXPORT_FROM"},
+		Category: "REWARD EXPORT",
+	}
+	RewardExportToFlag = &cli.Uint64Flag{
+		Name:     "export.to",
+		Usage:    "Last block number whose rewards are exported (0 = the head block)",
+		Aliases:  []string{"to"},
+		EnvVars:  []string{"KLAYTN_EXPORT_TO"},
+		Category: "REWARD EXPORT",
+	}
+	RewardExportFormatFlag = &cli.StringFlag{
+		Name:     "export.format",
+		Usage:    `File format of the exported rewards ("csv", "parquet")`,
+		Value:    reward.ExportFormatCSV,
+		Aliases:  []string{"format"},
+		EnvVars:  []string{"KLAYTN_EXPORT_FORMAT"},
+		Category: "REWARD EXPORT",
+	}
+	RewardExportOutputFlag = &cli.PathFlag{
+		Name:     "export.output",
+		Usage:    "File the rewards are exported to (default: stdout)",
+		Aliases:  []string{"output"},
+		EnvVars:  []string{"KLAYTN_EXPORT_OUTPUT"},
+		Category: "REWARD EXPORT",
+	}
+	RewardExportAmountFormatFlag = &cli.StringFlag{
+		Name:     "export.amount-format",
+		Usage:    `Format of the exported amounts as "unit[:decimals[:rounding]]", e.g. "klay:6:half-even" (unit: peb, ston, klay; rounding: down, up, half-up, half-even)`,
+		Value:    reward.DefaultRewardReportFormat.Unit,
+		EnvVars:  []string{"KLAYTN_EXPORT_AMOUNT_FORMAT"},
+		Category: "REWARD EXPORT",
+	}
+
+	// Faucet
+	EnableFaucetFlag = &cli.BoolFlag{
+		Name:     "faucet",
+		Usage:    "Enable the faucet service dispensing the KLAY of the funding account (testnets only; add faucet to --rpcapi to serve it)",
+		Aliases:  []string{"faucet.enable"},
+		EnvVars:  []string{"KLAYTN_FAUCET"},
+		Category: "FAUCET",
+	}
+	FaucetAccountFlag = &cli.StringFlag{
+		Name:     "faucet.account",
+		Usage:    "Address of the funding account in the keystore",
+		EnvVars:  []string{"KLAYTN_FAUCET_ACCOUNT"},
+		Category: "FAUCET",
+	}
+	FaucetPasswordFileFlag = &cli.StringFlag{
+		Name:     "faucet.password",
+		Usage:    "Password file of the funding account",
+		EnvVars:  []string{"KLAYTN_FAUCET_PASSWORD"},
+		Category: "FAUCET",
+	}
+	FaucetAmountFlag = &cli.Uint64Flag{
+		Name:     "faucet.amount",
+		Usage:    "KLAY dispensed per request",
+		Value:    faucet.DefaultAmount,
+		EnvVars:  []string{"KLAYTN_FAUCET_AMOUNT"},
+		Category: "FAUCET",
+	}
+	FaucetPeriodFlag = &cli.DurationFlag{
+		Name:     "faucet.period",
+		Usage:    "Minimum interval between the requests of a recipient or a remote address",
+		Value:    faucet.DefaultPeriod,
+		EnvVars:  []string{"KLAYTN_FAUCET_PERIOD"},
+		Category: "FAUCET",
+	}
+	FaucetCaptchaURLFlag = &cli.StringFlag{
+		Name:     "faucet.captcha.url",
+		Usage:    "Siteverify endpoint of the captcha service, e.g. https://www.google.com/recaptcha/api/siteverify (no captcha if empty)",
+		EnvVars:  []string{"KLAYTN_FAUCET_CAPTCHA_URL"},
+		Category: "FAUCET",
+	}
+	FaucetCaptchaSecretFlag = &cli.StringFlag{
+		Name:     "faucet.captcha.secret",
+		Usage:    "Secret key of the captcha service",
+		EnvVars:  []string{"KLAYTN_FAUCET_CAPTCHA_SECRET"},
+		Category: "FAUCET",
+	}
+	FaucetWebhookFlag = &cli.StringFlag{
+		Name:     "faucet.webhook",
+		Usage:    "URL to which the dispensed transactions are posted",
+		EnvVars:  []string{"KLAYTN_FAUCET_WEBHOOK"},
+		Category: "FAUCET",
+	}
+
+	// Subscription proxy
+	EnableSubProxyFlag = &cli.BoolFlag{
+		Name:     "subproxy",
+		Usage:    "Enable the subscription proxy relaying the new heads, logs and rewards of the upstream node to the downstream subscribers (add subproxy to --wsapi to serve it)",
+		Aliases:  []string{"subproxy.enable"},
+		EnvVars:  []string{"KLAYTN_SUBPROXY"},
+		Category: "SUBSCRIPTION PROXY",
+	}
+	SubProxyUpstreamFlag = &cli.StringFlag{
+		Name:     "subproxy.upstream",
+		Usage:    "WebSocket or IPC endpoint of the upstream full node",
+		EnvVars:  []string{"KLAYTN_SUBPROXY_UPSTREAM"},
+		Category: "SUBSCRIPTION PROXY",
+	}
+	SubProxyMaxClientsFlag = &cli.IntFlag{
+		Name:     "subproxy.maxclients",
+		Usage:    "Maximum number of downstream subscriptions (0 = unlimited)",
+		Value:    subproxy.DefaultMaxClients,
+		EnvVars:  []string{"KLAYTN_SUBPROXY_MAXCLIENTS"},
+		Category: "SUBSCRIPTION PROXY",
+	}
+	SubProxyClientBufferFlag = &cli.IntFlag{
+		Name:     "subproxy.buffer",
+		Usage:    "Notifications buffered per downstream subscription, the excess is dropped for slow subscribers",
+		Value:    subproxy.DefaultClientBuffer,
+		EnvVars:  []string{"KLAYTN_SUBPROXY_BUFFER"},
+		Category: "SUBSCRIPTION PROXY",
+	}
+	SubProxyReconnectFlag = &cli.DurationFlag{
+		Name:     "subproxy.reconnect",
+		Usage:    "Delay before reconnecting to the upstream node",
+		Value:    subproxy.DefaultReconnectDelay,
+		EnvVars:  []string{"KLAYTN_SUBPROXY_RECONNECT"},
+		Category: "SUBSCRIPTION PROXY",
+	}
+
 	// Config
 	ConfigFileFlag = &cli.StringFlag{
 		Name:     "config",
@@ -1976,6 +2743,20 @@ var (
 	// TODO-Klaytn-Bootnode: Implements bootnode's RPC
 )
 
+// newOverrideForkFlags returns the flags overriding the activation block of each overridable hardfork.
+func newOverrideForkFlags() []cli.Flag {
+	flags := make([]cli.Flag, 0, len(params.OverridableForks))
+	for _, fork := range params.OverridableForks {
+		flags = append(flags, &cli.Uint64Flag{
+			Name:     "override." + fork,
+			Usage:    fmt.Sprintf("Locally override the %s hardfork activation block (non-consensus, for private testing only)", fork),
+			EnvVars:  []string{"KLAYTN_OVERRIDE_" + strings.ToUpper(fork)},
+			Category: "HARDFORK OVERRIDE",
+		})
+	}
+	return flags
+}
+
 // MakeDataDir retrieves the currently requested data directory, terminating
 // if none (or the empty string) is specified. If the node is starting a baobab,
 // the a subdirectory of the specified datadir will be used.
@@ -2067,6 +2848,30 @@ func RegisterChainDataFetcherService(stack *node.Node, cfg *chaindatafetcher.Cha
 	}
 }
 
+// RegisterFaucetService adds a Faucet to the stack
+func RegisterFaucetService(stack *node.Node, cfg *faucet.Config) {
+	if cfg.Enabled {
+		err := stack.RegisterSubService(func(ctx *node.ServiceContext) (node.Service, error) {
+			return faucet.NewFaucet(ctx, cfg)
+		})
+		if err != nil {
+			log.Fatalf("Failed to register the faucet service: %v", err)
+		}
+	}
+}
+
+// RegisterSubProxyService adds a subscription Proxy to the stack
+func RegisterSubProxyService(stack *node.Node, cfg *subproxy.Config) {
+	if cfg.Enabled {
+		err := stack.RegisterSubService(func(ctx *node.ServiceContext) (node.Service, error) {
+			return subproxy.NewProxy(ctx, cfg)
+		})
+		if err != nil {
+			log.Fatalf("Failed to register the subscription proxy service: %v", err)
+		}
+	}
+}
+
 // RegisterDBSyncerService adds a DBSyncer to the stack
 func RegisterDBSyncerService(stack *node.Node, cfg *dbsyncer.DBConfig) {
 	if cfg.EnabledDBSyncer {
@@ -0,0 +1,138 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+	"github.com/urfave/cli/v2"
+)
+
+var DBCommand = &cli.Command{
+	Name:     "db",
+	Usage:    "A set of commands maintaining the chain database",
+	Category: "DB COMMANDS",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "dedup-report",
+			Usage:  "Estimate the storage taken by the duplicate trie nodes",
+			Action: utils.MigrateFlags(dedupReport),
+			Flags:  utils.SnapshotFlags,
+			Description: `
+klay db dedup-report
+scans the state trie database and counts the trie nodes stored more than once.
+With live pruning, a node written again by a later block is stored under a new
+extended hash even if the same node is already stored. The duplicate size is an
+estimate of the space dedup-compact reclaims.
+`,
+		},
+		{
+			Name:   "dedup-compact",
+			Usage:  "Rewrite the state trie database sharing the duplicate trie nodes",
+			Action: utils.MigrateFlags(dedupCompact),
+			Flags:  utils.SnapshotFlags,
+			Description: `
+klay db dedup-compact
+rewrites the state tries of all canonical blocks so that every trie node is stored
+once under its merkle hash, deletes the copies under the extended hashes, and
+reports the reclaimed space. The state roots do not change.
+
+The command is for archive nodes. It refuses a database pruned by live pruning,
+and the node must not run with live pruning afterwards. Stop the node before
+running the command. It is safe to stop the command and run it again.
+`,
+		},
+	},
+}
+
+func dedupReport(ctx *cli.Context) error {
+	stack := MakeFullNode(ctx)
+	dbm := stack.OpenDatabase(getConfig(ctx))
+	defer dbm.Close()
+
+	start := time.Now()
+	report := statedb.DedupTrieNodes(dbm.GetStateTrieDB(), func(r *statedb.DedupReport) {
+		logger.Info("Scanning trie nodes", "nodes", r.Nodes, "duplicates", r.DupNodes, "elapsed", common.PrettyDuration(time.Since(start)))
+	})
+
+	fmt.Printf("Trie nodes:      %d (%v)\n", report.Nodes, common.StorageSize(report.Bytes))
+	fmt.Printf("Extended nodes:  %d\n", report.ExtendedNodes)
+	fmt.Printf("Unique nodes:    %d\n", report.UniqueNodes)
+	fmt.Printf("Duplicate nodes: %d (%v)\n", report.DupNodes, common.StorageSize(report.DupBytes))
+	if report.Bytes > 0 {
+		fmt.Printf("Reclaimable:     %.2f%%\n", float64(report.DupBytes)*100/float64(report.Bytes))
+	}
+	return nil
+}
+
+func dedupCompact(ctx *cli.Context) error {
+	stack := MakeFullNode(ctx)
+	dbm := stack.OpenDatabase(getConfig(ctx))
+	defer dbm.Close()
+
+	head := dbm.ReadHeadBlockHash()
+	number := dbm.ReadHeaderNumber(head)
+	if number == nil {
+		return errors.New("empty database")
+	}
+	compactor, err := statedb.NewTrieCompactor(dbm)
+	if err != nil {
+		return err
+	}
+
+	var (
+		start  = time.Now()
+		logged = time.Now()
+	)
+	for num := uint64(0); num <= *number; num++ {
+		header := dbm.ReadHeader(dbm.ReadCanonicalHash(num), num)
+		if header == nil {
+			return fmt.Errorf("header missing: %d", num)
+		}
+		if err := compactor.CompactRoot(header.Root); err != nil {
+			return fmt.Errorf("failed to compact the state of block %d: %v", num, err)
+		}
+		if time.Since(logged) > 8*time.Second {
+			r := compactor.Report()
+			logger.Info("Compacting trie nodes", "block", num, "head", *number, "written", r.WrittenNodes, "rewritten", r.RewrittenNodes, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+
+	logger.Info("Deleting duplicate trie nodes", "elapsed", common.PrettyDuration(time.Since(start)))
+	report, err := compactor.DeleteDuplicates()
+	if err != nil {
+		return err
+	}
+	logger.Info("Compacting the state trie database", "elapsed", common.PrettyDuration(time.Since(start)))
+	if err := dbm.CompactRange(database.StateTrieDB, nil, nil); err != nil {
+		logger.Warn("Failed to compact the state trie database", "err", err)
+	}
+
+	fmt.Printf("State roots:     %d\n", report.Roots)
+	fmt.Printf("Written nodes:   %d (%v)\n", report.WrittenNodes, common.StorageSize(report.WrittenBytes))
+	fmt.Printf("Rewritten nodes: %d\n", report.RewrittenNodes)
+	fmt.Printf("Deleted nodes:   %d (%v)\n", report.DeletedNodes, common.StorageSize(report.DeletedBytes))
+	fmt.Printf("Reclaimed:       %v\n", common.StorageSize(report.ReclaimedBytes()))
+	return nil
+}
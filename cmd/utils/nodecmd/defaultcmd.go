@@ -71,6 +71,8 @@ func MakeFullNode(ctx *cli.Context) *node.Node {
 	utils.RegisterService(stack, &cfg.ServiceChain)
 	utils.RegisterDBSyncerService(stack, &cfg.DB)
 	utils.RegisterChainDataFetcherService(stack, &cfg.ChainDataFetcher)
+	utils.RegisterFaucetService(stack, &cfg.Faucet)
+	utils.RegisterSubProxyService(stack, &cfg.SubProxy)
 	return stack
 }
 
@@ -0,0 +1,89 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/forknet"
+	"github.com/urfave/cli/v2"
+)
+
+var ForkCommand = &cli.Command{
+	Action:    utils.MigrateFlags(runFork),
+	Name:      "fork",
+	Usage:     "Run a local network whose state is forked from a remote network",
+	ArgsUsage: "<endpoint>",
+	Flags:     utils.ForkFlags,
+	Category:  "MISCELLANEOUS COMMANDS",
+	Description: `
+The fork command runs a local network on top of a block of the remote network at
+the given endpoint, so that the dapps can be tested against the state of a live
+network. The accounts and the storage are requested to the remote network on the
+first access, and the transactions are mined instantly into the local blocks.
+
+Besides the klay (and eth) APIs used by the dapps, the following cheatcodes are
+served under the fork namespace:
+
+    fork_impersonateAccount, fork_stopImpersonatingAccount
+    fork_setBalance, fork_setNonce, fork_setCode, fork_setStorageAt
+
+The transactions from the impersonated accounts are sent by klay_sendTransaction
+without signatures. The blocks and the clock are controlled by the following APIs,
+which are served under the evm namespace as well for the Hardhat test suites:
+
+    klay_snapshot, klay_revert, klay_increaseTime, klay_mine
+
+Every mined block mints the block reward to the rewardbase of the fork block.`,
+}
+
+func runFork(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return errors.New("the endpoint of the remote network is required")
+	}
+	f, err := forknet.New(context.Background(), forknet.Config{
+		RemoteURL:   ctx.Args().First(),
+		BlockNumber: ctx.Uint64(utils.ForkBlockFlag.Name),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fork the remote network: %v", err)
+	}
+	defer f.Close()
+
+	cors := utils.SplitAndTrim(ctx.String(utils.ForkCORSDomainFlag.Name))
+	vhosts := utils.SplitAndTrim(ctx.String(utils.ForkVirtualHostsFlag.Name))
+	listener, handler, err := rpc.StartHTTPEndpoint(ctx.String(utils.ForkListenAddrFlag.Name), f.APIs(), nil, cors, vhosts, rpc.DefaultHTTPTimeouts)
+	if err != nil {
+		return fmt.Errorf("failed to start the HTTP-RPC endpoint: %v", err)
+	}
+	defer handler.Stop()
+	defer listener.Close()
+	fmt.Printf("Serving the forked network at http://%s\n", listener.Addr())
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+	<-sigc
+	return nil
+}
@@ -0,0 +1,490 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/client"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+)
+
+// loadTxKind is a kind of the transactions generated by the load test.
+type loadTxKind int
+
+const (
+	loadTxLegacy loadTxKind = iota
+	loadTxFeeDelegated
+	loadTxContractCall
+	numLoadTxKinds
+)
+
+var loadTxKindNames = [numLoadTxKinds]string{"legacy", "feedelegated", "contract"}
+
+func (k loadTxKind) String() string {
+	return loadTxKindNames[k]
+}
+
+var (
+	errNoLoadTestKeys     = errors.New("no sender key is given")
+	errEmptyLoadTxMix     = errors.New("the transaction mix has no positive weight")
+	errNoLoadTestFeePayer = errors.New("a fee payer key is required for fee-delegated transactions")
+	errNoLoadTestContract = errors.New("a contract address is required for contract calls")
+
+	loadTestValue = big.NewInt(1) // 1 peb is transferred by the value transfers
+)
+
+const (
+	maxLoadTestErrorKinds = 10     // the number of distinct errors reported
+	maxLoadTestScanBlocks = 100000 // the number of blocks scanned for the committed transactions
+)
+
+// parseLoadTxMix parses the weights of the transaction kinds, e.g. "legacy=60,feedelegated=20,contract=20".
+func parseLoadTxMix(mix string) ([numLoadTxKinds]int, error) {
+	var weights [numLoadTxKinds]int
+	total := 0
+	for _, item := range strings.Split(mix, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 {
+			return weights, fmt.Errorf("invalid transaction mix %q, expected kind=weight", item)
+		}
+		kind := -1
+		for k, name := range loadTxKindNames {
+			if strings.TrimSpace(kv[0]) == name {
+				kind = k
+			}
+		}
+		if kind < 0 {
+			return weights, fmt.Errorf("unknown transaction kind %q, expected one of %s", kv[0], strings.Join(loadTxKindNames[:], ", "))
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil || weight < 0 {
+			return weights, fmt.Errorf("invalid weight of %s: %q", kv[0], kv[1])
+		}
+		weights[kind] = weight
+		total += weight
+	}
+	if total == 0 {
+		return weights, errEmptyLoadTxMix
+	}
+	return weights, nil
+}
+
+// loadTestConfig is the configuration of a load test.
+type loadTestConfig struct {
+	Keys        []*ecdsa.PrivateKey // each sender sends its transactions in order
+	FeePayerKey *ecdsa.PrivateKey   // pays the fees of the fee-delegated transactions
+	Mix         [numLoadTxKinds]int // weights of the transaction kinds
+	TPS         int                 // target transactions per second in total (0 = unlimited)
+	Duration    time.Duration
+	To          *common.Address // recipient of the value transfers (nil = the sender itself)
+	Contract    common.Address  // callee of the contract calls
+	CallData    []byte
+	GasLimit    uint64   // gas limit of the contract calls
+	GasPrice    *big.Int // nil = the gas price suggested by the endpoint
+}
+
+func (c *loadTestConfig) validate() error {
+	if len(c.Keys) == 0 {
+		return errNoLoadTestKeys
+	}
+	total := 0
+	for _, weight := range c.Mix {
+		total += weight
+	}
+	if total == 0 {
+		return errEmptyLoadTxMix
+	}
+	if c.Mix[loadTxFeeDelegated] > 0 && c.FeePayerKey == nil {
+		return errNoLoadTestFeePayer
+	}
+	if c.Mix[loadTxContractCall] > 0 && common.EmptyAddress(c.Contract) {
+		return errNoLoadTestContract
+	}
+	return nil
+}
+
+// loadTestBackend is the endpoint the load test is run against.
+type loadTestBackend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendRawTransaction(ctx context.Context, tx *types.Transaction) (common.Hash, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockTransactionCount(ctx context.Context, number uint64) (uint, error)
+}
+
+// loadTestClient is the load test backend connected by RPC.
+type loadTestClient struct {
+	*client.Client
+	rpc *rpc.Client
+}
+
+func newLoadTestClient(c *rpc.Client) *loadTestClient {
+	return &loadTestClient{Client: client.NewClient(c), rpc: c}
+}
+
+func (c *loadTestClient) BlockTransactionCount(ctx context.Context, number uint64) (uint, error) {
+	var count hexutil.Uint
+	err := c.rpc.CallContext(ctx, &count, "klay_getBlockTransactionCountByNumber", hexutil.Uint64(number))
+	return uint(count), err
+}
+
+// loadTxStats is the statistics of the transactions of a kind.
+type loadTxStats struct {
+	sent      int
+	failed    int
+	latencies []time.Duration // latencies of the accepted submissions
+}
+
+// loadTester generates the transactions of the configured mix against the backend.
+type loadTester struct {
+	backend  loadTestBackend
+	config   loadTestConfig
+	signer   types.Signer
+	gasPrice *big.Int
+
+	mu     sync.Mutex
+	stats  [numLoadTxKinds]*loadTxStats
+	errors map[string]int
+}
+
+func newLoadTester(backend loadTestBackend, config loadTestConfig) (*loadTester, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	t := &loadTester{backend: backend, config: config, errors: make(map[string]int)}
+	for i := range t.stats {
+		t.stats[i] = new(loadTxStats)
+	}
+	return t, nil
+}
+
+// run generates the load for the configured duration and returns the report.
+func (t *loadTester) run(ctx context.Context) (*loadTestReport, error) {
+	chainID, err := t.backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the chain ID: %v", err)
+	}
+	t.signer = types.LatestSignerForChainID(chainID)
+	if t.gasPrice = t.config.GasPrice; t.gasPrice == nil {
+		if t.gasPrice, err = t.backend.SuggestGasPrice(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get the gas price: %v", err)
+		}
+	}
+	startHeader, err := t.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the latest block: %v", err)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, t.config.Duration)
+	defer cancel()
+
+	// the tokens pace the workers to the target rate in total
+	var tokens chan struct{}
+	if t.config.TPS > 0 {
+		tokens = make(chan struct{})
+		go func() {
+			ticker := time.NewTicker(time.Second / time.Duration(t.config.TPS))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					select {
+					case tokens <- struct{}{}:
+					case <-runCtx.Done():
+						return
+					}
+				case <-runCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i, key := range t.config.Keys {
+		wg.Add(1)
+		go func(seed int64, key *ecdsa.PrivateKey) {
+			defer wg.Done()
+			t.sendLoop(runCtx, rand.New(rand.NewSource(seed)), key, tokens)
+		}(start.UnixNano()+int64(i), key)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	report := t.report(elapsed)
+	if err := t.collectCommitted(ctx, startHeader, report); err != nil {
+		logger.Warn("Failed to count the committed transactions", "err", err)
+	}
+	return report, nil
+}
+
+// sendLoop sends the transactions of a sender until the context is done.
+func (t *loadTester) sendLoop(ctx context.Context, rnd *rand.Rand, key *ecdsa.PrivateKey, tokens <-chan struct{}) {
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	nonce, err := t.backend.PendingNonceAt(ctx, from)
+	if err != nil {
+		t.recordError(err)
+		return
+	}
+	for {
+		if tokens != nil {
+			select {
+			case <-tokens:
+			case <-ctx.Done():
+				return
+			}
+		} else if ctx.Err() != nil {
+			return
+		}
+
+		kind := t.pickKind(rnd)
+		tx, err := t.newTransaction(kind, key, nonce)
+		if err != nil {
+			t.recordError(err)
+			return
+		}
+		sentAt := time.Now()
+		_, err = t.backend.SendRawTransaction(ctx, tx)
+		if err != nil && ctx.Err() != nil {
+			return // interrupted at the end of the test
+		}
+		t.record(kind, time.Since(sentAt), err)
+		if err == nil {
+			nonce++
+		} else if pending, err := t.backend.PendingNonceAt(ctx, from); err == nil {
+			nonce = pending // resynchronize, e.g. after a nonce conflict
+		}
+	}
+}
+
+// pickKind picks a transaction kind randomly by the weights.
+func (t *loadTester) pickKind(rnd *rand.Rand) loadTxKind {
+	total := 0
+	for _, weight := range t.config.Mix {
+		total += weight
+	}
+	n := rnd.Intn(total)
+	for kind, weight := range t.config.Mix {
+		if n < weight {
+			return loadTxKind(kind)
+		}
+		n -= weight
+	}
+	return loadTxLegacy
+}
+
+func (t *loadTester) newTransaction(kind loadTxKind, key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := from
+	if t.config.To != nil {
+		to = *t.config.To
+	}
+
+	switch kind {
+	case loadTxFeeDelegated:
+		tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransfer, map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyNonce:    nonce,
+			types.TxValueKeyTo:       to,
+			types.TxValueKeyAmount:   loadTestValue,
+			types.TxValueKeyGasLimit: params.TxGasValueTransfer + params.TxGasFeeDelegated,
+			types.TxValueKeyGasPrice: t.gasPrice,
+			types.TxValueKeyFrom:     from,
+			types.TxValueKeyFeePayer: crypto.PubkeyToAddress(t.config.FeePayerKey.PublicKey),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.SignWithKeys(t.signer, []*ecdsa.PrivateKey{key}); err != nil {
+			return nil, err
+		}
+		if err := tx.SignFeePayer(t.signer, t.config.FeePayerKey); err != nil {
+			return nil, err
+		}
+		return tx, nil
+	case loadTxContractCall:
+		tx := types.NewTransaction(nonce, t.config.Contract, common.Big0, t.config.GasLimit, t.gasPrice, t.config.CallData)
+		return types.SignTx(tx, t.signer, key)
+	default:
+		tx := types.NewTransaction(nonce, to, loadTestValue, params.TxGas, t.gasPrice, nil)
+		return types.SignTx(tx, t.signer, key)
+	}
+}
+
+func (t *loadTester) record(kind loadTxKind, latency time.Duration, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.stats[kind]
+	if err != nil {
+		stats.failed++
+		t.recordErrorLocked(err)
+		return
+	}
+	stats.sent++
+	stats.latencies = append(stats.latencies, latency)
+}
+
+func (t *loadTester) recordError(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.recordErrorLocked(err)
+}
+
+func (t *loadTester) recordErrorLocked(err error) {
+	if _, ok := t.errors[err.Error()]; ok || len(t.errors) < maxLoadTestErrorKinds {
+		t.errors[err.Error()]++
+	}
+}
+
+// loadKindReport is the result of the transactions of a kind.
+type loadKindReport struct {
+	Kind                     string
+	Sent, Failed             int
+	Mean, P50, P95, P99, Max time.Duration // latencies of the accepted submissions
+}
+
+// loadTestReport is the result of a load test.
+type loadTestReport struct {
+	Elapsed   time.Duration
+	Kinds     []loadKindReport
+	Sent      int
+	Failed    int
+	SubmitTPS float64 // accepted transactions per second
+
+	Blocks       uint64  // blocks generated during the test
+	Committed    uint64  // transactions in the blocks, including the ones not sent by the test
+	CommittedTPS float64 // committed transactions per second of the block time
+	Errors       map[string]int
+}
+
+func (t *loadTester) report(elapsed time.Duration) *loadTestReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := &loadTestReport{Elapsed: elapsed, Errors: make(map[string]int)}
+	for kind, stats := range t.stats {
+		if t.config.Mix[kind] == 0 {
+			continue
+		}
+		kr := loadKindReport{Kind: loadTxKind(kind).String(), Sent: stats.sent, Failed: stats.failed}
+		if n := len(stats.latencies); n > 0 {
+			sorted := append([]time.Duration(nil), stats.latencies...)
+			sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+			var sum time.Duration
+			for _, latency := range sorted {
+				sum += latency
+			}
+			kr.Mean = sum / time.Duration(n)
+			kr.P50 = sorted[(n-1)*50/100]
+			kr.P95 = sorted[(n-1)*95/100]
+			kr.P99 = sorted[(n-1)*99/100]
+			kr.Max = sorted[n-1]
+		}
+		report.Kinds = append(report.Kinds, kr)
+		report.Sent += stats.sent
+		report.Failed += stats.failed
+	}
+	if elapsed > 0 {
+		report.SubmitTPS = float64(report.Sent) / elapsed.Seconds()
+	}
+	for msg, count := range t.errors {
+		report.Errors[msg] = count
+	}
+	return report
+}
+
+// collectCommitted counts the transactions of the blocks generated after the start of the test.
+func (t *loadTester) collectCommitted(ctx context.Context, start *types.Header, report *loadTestReport) error {
+	end, err := t.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	first, last := start.Number.Uint64()+1, end.Number.Uint64()
+	if last < first {
+		return nil
+	}
+	if last-first+1 > maxLoadTestScanBlocks {
+		first = last - maxLoadTestScanBlocks + 1
+	}
+	for num := first; num <= last; num++ {
+		count, err := t.backend.BlockTransactionCount(ctx, num)
+		if err != nil {
+			return err
+		}
+		report.Committed += uint64(count)
+	}
+	report.Blocks = last - first + 1
+
+	// the committed throughput is measured over the block time from the start block
+	span := time.Duration(new(big.Int).Sub(end.Time, start.Time).Int64()) * time.Second
+	if span <= 0 {
+		span = report.Elapsed
+	}
+	if span > 0 {
+		report.CommittedTPS = float64(report.Committed) / span.Seconds()
+	}
+	return nil
+}
+
+func (r *loadTestReport) print(w io.Writer) {
+	fmt.Fprintf(w, "Elapsed: %v\n\n", r.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "%-14s %10s %10s %10s %10s %10s %10s %10s\n", "KIND", "SENT", "FAILED", "MEAN", "P50", "P95", "P99", "MAX")
+	for _, k := range r.Kinds {
+		fmt.Fprintf(w, "%-14s %10d %10d %10v %10v %10v %10v %10v\n", k.Kind, k.Sent, k.Failed,
+			roundLatency(k.Mean), roundLatency(k.P50), roundLatency(k.P95), roundLatency(k.P99), roundLatency(k.Max))
+	}
+	fmt.Fprintf(w, "%-14s %10d %10d\n\n", "total", r.Sent, r.Failed)
+	fmt.Fprintf(w, "Submitted throughput: %.2f tx/s\n", r.SubmitTPS)
+	fmt.Fprintf(w, "Committed throughput: %.2f tx/s (%d transactions in %d blocks)\n", r.CommittedTPS, r.Committed, r.Blocks)
+	if len(r.Errors) > 0 {
+		fmt.Fprintln(w, "\nErrors:")
+		msgs := make([]string, 0, len(r.Errors))
+		for msg := range r.Errors {
+			msgs = append(msgs, msg)
+		}
+		sort.Strings(msgs)
+		for _, msg := range msgs {
+			fmt.Fprintf(w, "  %6d  %s\n", r.Errors[msg], msg)
+		}
+	}
+}
+
+func roundLatency(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLoadTestBackend accepts the transactions of the expected nonces and puts each of them in a block.
+type testLoadTestBackend struct {
+	mu     sync.Mutex
+	signer types.Signer
+	nonces map[common.Address]uint64
+	txs    map[types.TxType]int
+	blocks uint64
+}
+
+func newTestLoadTestBackend() *testLoadTestBackend {
+	return &testLoadTestBackend{
+		signer: types.LatestSignerForChainID(big.NewInt(1000)),
+		nonces: make(map[common.Address]uint64),
+		txs:    make(map[types.TxType]int),
+	}
+}
+
+func (b *testLoadTestBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1000), nil
+}
+
+func (b *testLoadTestBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(25e9), nil
+}
+
+func (b *testLoadTestBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.nonces[account], nil
+}
+
+func (b *testLoadTestBackend) SendRawTransaction(ctx context.Context, tx *types.Transaction) (common.Hash, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from, err := types.Sender(b.signer, tx)
+	if tx.Type().IsFeeDelegatedTransaction() {
+		if from, err = tx.From(); err != nil {
+			return common.Hash{}, err
+		}
+		feePayer, err := b.signer.SenderFeePayer(tx)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if payer, _ := tx.FeePayer(); crypto.PubkeyToAddress(*feePayer[0]) != payer {
+			return common.Hash{}, errors.New("invalid fee payer signature")
+		}
+	}
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if tx.Nonce() != b.nonces[from] {
+		return common.Hash{}, errors.New("nonce too low")
+	}
+	b.nonces[from]++
+	b.txs[tx.Type()]++
+	b.blocks++
+	return tx.Hash(), nil
+}
+
+func (b *testLoadTestBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &types.Header{Number: new(big.Int).SetUint64(b.blocks), Time: new(big.Int).SetUint64(b.blocks)}, nil
+}
+
+func (b *testLoadTestBackend) BlockTransactionCount(ctx context.Context, number uint64) (uint, error) {
+	return 1, nil
+}
+
+func TestParseLoadTxMix(t *testing.T) {
+	mix, err := parseLoadTxMix("legacy=60, feedelegated=20,contract=20")
+	require.NoError(t, err)
+	assert.Equal(t, [numLoadTxKinds]int{60, 20, 20}, mix)
+
+	mix, err = parseLoadTxMix("contract=1")
+	require.NoError(t, err)
+	assert.Equal(t, [numLoadTxKinds]int{0, 0, 1}, mix)
+
+	_, err = parseLoadTxMix("legacy=0")
+	assert.Equal(t, errEmptyLoadTxMix, err)
+	for _, invalid := range []string{"legacy", "unknown=1", "legacy=-1", "legacy=a"} {
+		_, err := parseLoadTxMix(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestLoadTestConfig_Validate(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	keys := []*ecdsa.PrivateKey{key}
+
+	assert.Equal(t, errNoLoadTestKeys, (&loadTestConfig{Mix: [numLoadTxKinds]int{1, 0, 0}}).validate())
+	assert.Equal(t, errEmptyLoadTxMix, (&loadTestConfig{Keys: keys}).validate())
+	assert.Equal(t, errNoLoadTestFeePayer, (&loadTestConfig{Keys: keys, Mix: [numLoadTxKinds]int{0, 1, 0}}).validate())
+	assert.Equal(t, errNoLoadTestContract, (&loadTestConfig{Keys: keys, Mix: [numLoadTxKinds]int{0, 0, 1}}).validate())
+	assert.NoError(t, (&loadTestConfig{Keys: keys, Mix: [numLoadTxKinds]int{1, 0, 0}}).validate())
+}
+
+func TestLoadTester_Run(t *testing.T) {
+	var keys []*ecdsa.PrivateKey
+	for i := 0; i < 3; i++ {
+		key, _ := crypto.GenerateKey()
+		keys = append(keys, key)
+	}
+	feePayer, _ := crypto.GenerateKey()
+	backend := newTestLoadTestBackend()
+
+	tester, err := newLoadTester(backend, loadTestConfig{
+		Keys:        keys,
+		FeePayerKey: feePayer,
+		Mix:         [numLoadTxKinds]int{2, 1, 1},
+		Duration:    300 * time.Millisecond,
+		Contract:    common.HexToAddress("0x1000"),
+		CallData:    []byte{0x01, 0x02},
+		GasLimit:    100000,
+	})
+	require.NoError(t, err)
+	report, err := tester.run(context.Background())
+	require.NoError(t, err)
+
+	// all transactions are accepted in order
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, 0, report.Failed)
+	assert.Equal(t, backend.txs[types.TxTypeLegacyTransaction], report.Kinds[0].Sent+report.Kinds[2].Sent)
+	assert.Equal(t, backend.txs[types.TxTypeFeeDelegatedValueTransfer], report.Kinds[1].Sent)
+	assert.Equal(t, uint64(report.Sent), report.Committed)
+	assert.Equal(t, uint64(report.Sent), report.Blocks)
+	for _, kind := range report.Kinds {
+		assert.Positive(t, kind.Sent, kind.Kind)
+		assert.LessOrEqual(t, kind.P50, kind.P99, kind.Kind)
+		assert.LessOrEqual(t, kind.P99, kind.Max, kind.Kind)
+	}
+
+	var out bytes.Buffer
+	report.print(&out)
+	assert.Contains(t, out.String(), "feedelegated")
+	assert.Contains(t, out.String(), "Committed throughput")
+}
+
+func TestLoadTester_RunWithRate(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tester, err := newLoadTester(newTestLoadTestBackend(), loadTestConfig{
+		Keys:     []*ecdsa.PrivateKey{key},
+		Mix:      [numLoadTxKinds]int{1, 0, 0},
+		TPS:      50,
+		Duration: time.Second,
+	})
+	require.NoError(t, err)
+	report, err := tester.run(context.Background())
+	require.NoError(t, err)
+
+	// the contract calls and fee-delegated transactions are not reported if not in the mix
+	require.Len(t, report.Kinds, 1)
+	assert.InDelta(t, 50, report.Sent, 10)
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+var LoadTestCommand = &cli.Command{
+	Action:    utils.MigrateFlags(loadTest),
+	Name:      "loadtest",
+	Usage:     "Generate a transaction load against a node and report the latency and throughput",
+	ArgsUsage: "[endpoint]",
+	Flags:     utils.LoadTestFlags,
+	Category:  "MISCELLANEOUS COMMANDS",
+	Description: `
+The loadtest command sends a mix of legacy value transfers, fee-delegated value
+transfers and contract calls to the given endpoint at the target rate, so that
+the capacity of a network can be validated before changing its parameters.
+Each sender key sends its transactions in order, so use multiple funded keys to
+generate a high load. The submission latency of each transaction kind and the
+submitted and committed throughput are reported at the end.`,
+}
+
+func loadTest(ctx *cli.Context) error {
+	config, err := makeLoadTestConfig(ctx)
+	if err != nil {
+		return err
+	}
+	c, err := dialRPC(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("failed to connect to the endpoint: %v", err)
+	}
+	defer c.Close()
+
+	tester, err := newLoadTester(newLoadTestClient(c), *config)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Sending %s from %d senders at %d tx/s for %v\n", ctx.String(utils.LoadTestMixFlag.Name),
+		len(config.Keys), config.TPS, config.Duration)
+	report, err := tester.run(context.Background())
+	if err != nil {
+		return err
+	}
+	report.print(os.Stdout)
+	return nil
+}
+
+func makeLoadTestConfig(ctx *cli.Context) (*loadTestConfig, error) {
+	mix, err := parseLoadTxMix(ctx.String(utils.LoadTestMixFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	config := &loadTestConfig{
+		Mix:      mix,
+		TPS:      ctx.Int(utils.LoadTestTPSFlag.Name),
+		Duration: ctx.Duration(utils.LoadTestDurationFlag.Name),
+		GasLimit: ctx.Uint64(utils.LoadTestGasLimitFlag.Name),
+	}
+	if path := ctx.String(utils.LoadTestKeyFileFlag.Name); path != "" {
+		if config.Keys, err = loadKeyFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if path := ctx.String(utils.LoadTestFeePayerKeyFileFlag.Name); path != "" {
+		if config.FeePayerKey, err = crypto.LoadECDSA(path); err != nil {
+			return nil, fmt.Errorf("failed to load the fee payer key: %v", err)
+		}
+	}
+	if to := ctx.String(utils.LoadTestToFlag.Name); to != "" {
+		if !common.IsHexAddress(to) {
+			return nil, fmt.Errorf("invalid recipient address %q", to)
+		}
+		addr := common.HexToAddress(to)
+		config.To = &addr
+	}
+	if contract := ctx.String(utils.LoadTestContractFlag.Name); contract != "" {
+		if !common.IsHexAddress(contract) {
+			return nil, fmt.Errorf("invalid contract address %q", contract)
+		}
+		config.Contract = common.HexToAddress(contract)
+	}
+	if data := ctx.String(utils.LoadTestCallDataFlag.Name); data != "" {
+		if config.CallData, err = hexutil.Decode(data); err != nil {
+			return nil, fmt.Errorf("invalid call data: %v", err)
+		}
+	}
+	if gasPrice := ctx.Uint64(utils.LoadTestGasPriceFlag.Name); gasPrice > 0 {
+		config.GasPrice = new(big.Int).SetUint64(gasPrice)
+	}
+	return config, config.validate()
+}
+
+// loadKeyFile loads the hex private keys in the file, one key per line.
+func loadKeyFile(path string) ([]*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var keys []*ecdsa.PrivateKey
+	for i, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(line, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid key at line %d of %s: %v", i+1, path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
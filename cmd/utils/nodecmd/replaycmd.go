@@ -0,0 +1,72 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/klaytn/klaytn/blockchain/replay"
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var ReplayCommand = &cli.Command{
+	Action:    utils.MigrateFlags(runReplay),
+	Name:      "replay",
+	Usage:     "Execute a block again from a replay bundle",
+	ArgsUsage: "<bundle>",
+	Category:  "MISCELLANEOUS COMMANDS",
+	Description: `
+The replay command executes the block of a replay bundle on the slice of the parent
+state kept in the bundle, without any datadir. A bundle is exported from a node by
+debug.exportReplayBundle(hash, file), which accepts the bad blocks as well.
+
+The gas used and the receipt hash of the replay are compared with the block header,
+and the result including the partial state root is compared with the result observed
+by the node which exported the bundle. The command fails if any of them differs.`,
+}
+
+func runReplay(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return errors.New("the replay bundle file is required")
+	}
+	bundle, err := replay.ReadFile(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	result, receipts, err := replay.Replay(bundle)
+	if err != nil {
+		return fmt.Errorf("failed to replay the bundle: %v", err)
+	}
+
+	fmt.Printf("Block:        #%d (%s)\n", bundle.Block.NumberU64(), bundle.Block.Hash().Hex())
+	fmt.Printf("Transactions: %d executed of %d\n", len(receipts), len(bundle.Block.Transactions()))
+	fmt.Printf("Gas used:     %d\n", result.GasUsed)
+	fmt.Printf("Receipt hash: %s\n", result.ReceiptHash.Hex())
+	fmt.Printf("State root:   %s\n", result.StateRoot.Hex())
+	if result.Err != "" {
+		fmt.Printf("Error:        %s\n", result.Err)
+	}
+
+	if diffs := replay.Verify(bundle, result); len(diffs) > 0 {
+		return fmt.Errorf("the replay diverged:\n  %s", strings.Join(diffs, "\n  "))
+	}
+	fmt.Println("The replay matches the block and the captured result")
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	istanbulBackend "github.com/klaytn/klaytn/consensus/istanbul/backend"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/urfave/cli/v2"
+)
+
+// rewardExportLogInterval is the interval between the progress logs of the reward export.
+const rewardExportLogInterval = 8 * time.Second
+
+// exportRewards writes the rewards of the blocks in the range to the output.
+func exportRewards(ctx *cli.Context) error {
+	amountFormat, err := reward.ParseRewardReportFormat(ctx.String(utils.RewardExportAmountFormatFlag.Name))
+	if err != nil {
+		return fmt.Errorf("--%s: %v", utils.RewardExportAmountFormatFlag.Name, err)
+	}
+
+	var out io.Writer = os.Stdout
+	if path := ctx.String(utils.RewardExportOutputFlag.Name); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	buffered := bufio.NewWriter(out)
+	w, err := reward.NewRewardExportWriter(buffered, ctx.String(utils.RewardExportFormatFlag.Name))
+	if err != nil {
+		return fmt.Errorf("--%s: %v", utils.RewardExportFormatFlag.Name, err)
+	}
+
+	stack := MakeFullNode(ctx)
+	dbm := stack.OpenDatabase(getConfig(ctx))
+	defer dbm.Close()
+
+	bc, api, err := openRewardChain(dbm)
+	if err != nil {
+		return err
+	}
+	defer bc.Stop()
+
+	from, to := ctx.Uint64(utils.RewardExportFromFlag.Name), ctx.Uint64(utils.RewardExportToFlag.Name)
+	head := bc.CurrentBlock().NumberU64()
+	if to == 0 {
+		to = head
+	}
+	if to > head {
+		return fmt.Errorf("the last block %d is ahead of the head block %d", to, head)
+	}
+	if from > to {
+		return fmt.Errorf("the first block %d is after the last block %d", from, to)
+	}
+
+	var (
+		start  = time.Now()
+		logged = time.Now()
+		rows   int
+	)
+	for number := from; number <= to; number++ {
+		header := bc.GetHeaderByNumber(number)
+		if header == nil {
+			return fmt.Errorf("block %d not found", number)
+		}
+		num := rpc.BlockNumber(number)
+		spec, err := api.GetRewards(&num)
+		if err != nil {
+			return fmt.Errorf("failed to get the rewards of block %d: %v", number, err)
+		}
+		for _, row := range reward.NewRewardExportRows(header, spec, amountFormat) {
+			if err := w.Write(row); err != nil {
+				return err
+			}
+			rows++
+		}
+		if time.Since(logged) > rewardExportLogInterval {
+			logger.Info("Exporting the rewards", "number", number, "to", to, "rows", rows, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	logger.Info("Exported the rewards", "from", from, "to", to, "rows", rows, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// openRewardChain opens the blockchain of the database with the governance and the staking
+// manager as the node does, returning the governance API calculating the block rewards.
+// The consensus engine is only for reading the chain, so it is set up with a temporary key.
+func openRewardChain(dbm database.DBManager) (*blockchain.BlockChain, *governance.GovernanceKlayAPI, error) {
+	genesisHash := dbm.ReadCanonicalHash(0)
+	if genesisHash == (common.Hash{}) {
+		return nil, nil, errors.New("empty database")
+	}
+	chainConfig := dbm.ReadChainConfig(genesisHash)
+	if chainConfig == nil {
+		return nil, nil, errors.New("chain config not found")
+	}
+	chainConfig.SetDefaults()
+	gov := governance.NewMixedEngine(chainConfig, dbm)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	istanbulConfig := *istanbul.DefaultConfig
+	engine := istanbulBackend.New(common.Address{}, &istanbulConfig, key, dbm, gov, common.ENDPOINTNODE)
+
+	bc, err := blockchain.NewBlockChain(dbm, nil, chainConfig, engine, vm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+	gov.SetBlockchain(bc)
+	blockchain.InitDeriveShaWithGov(chainConfig, gov)
+	reward.NewStakingManager(bc, gov, dbm)
+
+	api := governance.NewGovernanceKlayAPI(gov, bc)
+	api.SetRewardCheckpoints(dbm)
+	return bc, api, nil
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/klaytn/klaytn/accounts/keystore"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/cmd/utils"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus/istanbul"
@@ -43,6 +44,8 @@ const (
 	DECODE_VOTE  = "decode-vote"
 	DECODE_GOV   = "decode-gov"
 	DECRYPT_KEY  = "decrypt-keystore"
+
+	EXPORT_REWARDS = "export-rewards"
 )
 
 var ErrInvalidCmd = errors.New("Invalid command. Check usage through --help command")
@@ -76,6 +79,20 @@ var UtilCommand = &cli.Command{
 			Action:      action,
 			Description: "Decrypt keystore",
 		},
+		{
+			Name:   EXPORT_REWARDS,
+			Usage:  "Export the rewards of a block range as CSV or Parquet",
+			Action: utils.MigrateFlags(exportRewards),
+			Flags:  utils.RewardExportFlags,
+			Description: `
+    kcn util export-rewards --datadir <datadir> --from <block> --to <block> --format csv|parquet
+
+The export-rewards command writes a row for each recipient of the rewards of each
+block in the range, with the amount and its portions re-expressed by
+--export.amount-format. The rewards are calculated from the chain data, reusing the
+staking information and the reward checkpoints stored by the node, so the node
+has to be stopped while the command runs.`,
+		},
 	},
 }
 
@@ -221,17 +238,18 @@ func decodeVote(bytes []byte) (map[string]interface{}, error) {
 		m["validator"] = vote.Validator.String()
 		m["key"] = vote.Key
 		switch governance.GovernanceKeyMap[vote.Key] {
-		case params.GovernanceMode, params.MintingAmount, params.MinimumStake, params.Ratio, params.Kip82Ratio:
+		case params.GovernanceMode, params.MintingAmount, params.MinimumStake, params.Ratio, params.Kip82Ratio, params.KFFSplit, params.KCFSplit, params.RemainderPolicy, params.ProposerSplit:
 			m["value"] = string(vote.Value.([]uint8))
-		case params.GoverningNode, params.GovParamContract:
+		case params.GoverningNode, params.GovParamContract, params.RewardRedirectAddress:
 			m["value"] = common.BytesToAddress(vote.Value.([]uint8)).String()
 		case params.Epoch, params.CommitteeSize, params.UnitPrice, params.DeriveShaImpl, params.StakeUpdateInterval,
 			params.ProposerRefreshInterval, params.ConstTxGasHumanReadable, params.Policy, params.Timeout,
-			params.LowerBoundBaseFee, params.UpperBoundBaseFee, params.GasTarget, params.MaxBlockGasUsedForBaseFee, params.BaseFeeDenominator:
+			params.LowerBoundBaseFee, params.UpperBoundBaseFee, params.GasTarget, params.MaxBlockGasUsedForBaseFee, params.BaseFeeDenominator,
+			params.BurnRatio, params.PayoutGasStipend:
 			v := vote.Value.([]uint8)
 			v = append(make([]byte, 8-len(v)), v...)
 			m["value"] = binary.BigEndian.Uint64(v)
-		case params.UseGiniCoeff, params.DeferredTxFee:
+		case params.UseGiniCoeff, params.DeferredTxFee, params.MinStakeInclusive:
 			v := vote.Value.([]uint8)
 			v = append(make([]byte, 8-len(v)), v...)
 			if binary.BigEndian.Uint64(v) != uint64(0) {
@@ -0,0 +1,471 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klaytn/klaytn/accounts/abi"
+	"github.com/klaytn/klaytn/accounts/abi/bind"
+	"github.com/klaytn/klaytn/client"
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/contracts/reward/contract"
+	"github.com/klaytn/klaytn/contracts/system_contracts"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/crypto/bls"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node"
+	"github.com/klaytn/klaytn/params"
+)
+
+// cnStakingABI is the part of the CnStakingContract ABI used by the onboarding.
+const cnStakingABI = `[
+	{"constant":true,"inputs":[],"name":"nodeId","outputs":[{"name":"","type":"address"}],"payable":false,"stateMutability":"view","type":"function"},
+	{"constant":true,"inputs":[],"name":"rewardAddress","outputs":[{"name":"","type":"address"}],"payable":false,"stateMutability":"view","type":"function"},
+	{"constant":true,"inputs":[],"name":"isInitialized","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"view","type":"function"},
+	{"constant":false,"inputs":[],"name":"stakeKlay","outputs":[],"payable":true,"stateMutability":"payable","type":"function"}
+]`
+
+const (
+	checkOK   = "ok"
+	checkTodo = "todo"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// validatorOnboardingConfig is the configuration of the onboarding of a new CN.
+type validatorOnboardingConfig struct {
+	DataDir         string
+	OutputDir       string         // directory of the transaction templates and the report
+	RewardAddress   common.Address // the reward address of the CN
+	StakingContract common.Address // the CnStakingContract of the CN (zero = not deployed yet)
+	KIP113          common.Address // the KIP-113 contract (zero = unknown)
+	AddressBook     common.Address
+}
+
+// validatorBackend is the network the CN joins, used to check the registration preconditions.
+type validatorBackend interface {
+	bind.ContractCaller
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	// MinimumStake returns the minimum staking amount of a CN in KLAY.
+	MinimumStake(ctx context.Context) (*big.Int, error)
+}
+
+// validatorClient is the validator backend connected by RPC.
+type validatorClient struct {
+	*client.Client
+	rpc *rpc.Client
+}
+
+func newValidatorClient(c *rpc.Client) *validatorClient {
+	return &validatorClient{Client: client.NewClient(c), rpc: c}
+}
+
+func (c *validatorClient) MinimumStake(ctx context.Context) (*big.Int, error) {
+	var items map[string]interface{}
+	if err := c.rpc.CallContext(ctx, &items, "klay_getParams", "latest"); err != nil {
+		return nil, err
+	}
+	str, ok := items["reward.minimumstake"].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid minimum stake %v", items["reward.minimumstake"])
+	}
+	minStake, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid minimum stake %q", str)
+	}
+	return minStake, nil
+}
+
+// validatorKeys is the keys of a CN kept in its data directory.
+type validatorKeys struct {
+	NodeKey        *ecdsa.PrivateKey
+	BlsKey         bls.SecretKey
+	NodeKeyFile    string
+	BlsKeyFile     string
+	NodeKeyCreated bool
+	BlsKeyCreated  bool
+}
+
+// prepareValidatorKeys loads the node key and the BLS key from the data directory where
+// the node reads them, generating and saving the missing ones. A new BLS key is derived
+// from the node key as kcn account bls-info does.
+func prepareValidatorKeys(datadir string) (*validatorKeys, error) {
+	cfg := &node.Config{DataDir: datadir, Name: utils.ClientIdentifier}
+	keys := &validatorKeys{
+		NodeKeyFile: cfg.ResolvePath("nodekey"),
+		BlsKeyFile:  cfg.ResolvePath("bls-nodekey"),
+	}
+	if err := os.MkdirAll(filepath.Dir(keys.NodeKeyFile), 0o700); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if common.FileExist(keys.NodeKeyFile) {
+		if keys.NodeKey, err = crypto.LoadECDSA(keys.NodeKeyFile); err != nil {
+			return nil, fmt.Errorf("failed to load the node key: %v", err)
+		}
+	} else {
+		if keys.NodeKey, err = crypto.GenerateKey(); err != nil {
+			return nil, fmt.Errorf("failed to generate the node key: %v", err)
+		}
+		if err := crypto.SaveECDSA(keys.NodeKeyFile, keys.NodeKey); err != nil {
+			return nil, fmt.Errorf("failed to save the node key: %v", err)
+		}
+		keys.NodeKeyCreated = true
+	}
+
+	if common.FileExist(keys.BlsKeyFile) {
+		content, err := os.ReadFile(keys.BlsKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		blsBytes, err := hex.DecodeString(strings.TrimSpace(string(content)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the BLS key: %v", err)
+		}
+		if keys.BlsKey, err = bls.SecretKeyFromBytes(blsBytes); err != nil {
+			return nil, fmt.Errorf("failed to load the BLS key: %v", err)
+		}
+	} else {
+		if keys.BlsKey, err = bls.GenerateKey(crypto.FromECDSA(keys.NodeKey)); err != nil {
+			return nil, fmt.Errorf("failed to generate the BLS key: %v", err)
+		}
+		if err := os.WriteFile(keys.BlsKeyFile, []byte(hex.EncodeToString(keys.BlsKey.Marshal())), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to save the BLS key: %v", err)
+		}
+		keys.BlsKeyCreated = true
+	}
+	return keys, nil
+}
+
+// onboardingTxTemplate is an unsigned transaction to be sent by the given party.
+type onboardingTxTemplate struct {
+	Description string          `json:"description"`
+	From        string          `json:"from"`
+	To          *common.Address `json:"to"`
+	Value       *hexutil.Big    `json:"value,omitempty"`
+	Data        hexutil.Bytes   `json:"data"`
+}
+
+// onboardingCheck is an item of the onboarding checklist.
+type onboardingCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// onboardingReport is the result of the onboarding of a CN.
+type onboardingReport struct {
+	NodeID          common.Address                   `json:"nodeId"`
+	NodeKeyFile     string                           `json:"nodeKeyFile"`
+	BlsKeyFile      string                           `json:"blsKeyFile"`
+	BlsPublicKey    hexutil.Bytes                    `json:"blsPublicKey"`
+	BlsPop          hexutil.Bytes                    `json:"blsPop"`
+	RewardAddress   common.Address                   `json:"rewardAddress"`
+	StakingContract common.Address                   `json:"stakingContract"`
+	MinimumStake    *big.Int                         `json:"minimumStake,omitempty"`
+	Templates       map[string]*onboardingTxTemplate `json:"-"`
+	Checks          []onboardingCheck                `json:"checks"`
+}
+
+func (r *onboardingReport) check(name, status, format string, args ...interface{}) {
+	r.Checks = append(r.Checks, onboardingCheck{Name: name, Status: status, Detail: fmt.Sprintf(format, args...)})
+}
+
+// ready returns whether there is nothing left to do before the registration.
+func (r *onboardingReport) ready() bool {
+	for _, c := range r.Checks {
+		if c.Status != checkOK {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *onboardingReport) print(w io.Writer) {
+	fmt.Fprintf(w, "Node ID:          %s\n", r.NodeID.Hex())
+	fmt.Fprintf(w, "Node key:         %s\n", r.NodeKeyFile)
+	fmt.Fprintf(w, "BLS key:          %s\n", r.BlsKeyFile)
+	fmt.Fprintf(w, "BLS public key:   %s\n", r.BlsPublicKey)
+	fmt.Fprintf(w, "BLS PoP:          %s\n", r.BlsPop)
+	fmt.Fprintf(w, "Reward address:   %s\n", r.RewardAddress.Hex())
+	fmt.Fprintf(w, "Staking contract: %s\n\n", r.StakingContract.Hex())
+	fmt.Fprintln(w, "Checklist:")
+	for _, c := range r.Checks {
+		fmt.Fprintf(w, "  [%-4s] %-26s %s\n", c.Status, c.Name, c.Detail)
+	}
+	if r.ready() {
+		fmt.Fprintln(w, "\nThe node is ready to be registered to the AddressBook.")
+	}
+}
+
+// initValidator prepares the keys of a new CN, checks the registration preconditions against
+// the backend if any, and writes the transaction templates and the report to the output directory.
+func initValidator(ctx context.Context, backend validatorBackend, config validatorOnboardingConfig) (*onboardingReport, error) {
+	keys, err := prepareValidatorKeys(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+	report := &onboardingReport{
+		NodeID:          crypto.PubkeyToAddress(keys.NodeKey.PublicKey),
+		NodeKeyFile:     keys.NodeKeyFile,
+		BlsKeyFile:      keys.BlsKeyFile,
+		BlsPublicKey:    keys.BlsKey.PublicKey().Marshal(),
+		BlsPop:          bls.PopProve(keys.BlsKey).Marshal(),
+		RewardAddress:   config.RewardAddress,
+		StakingContract: config.StakingContract,
+	}
+	report.check("node key", checkOK, "%s", keyFileStatus(keys.NodeKeyCreated))
+	report.check("BLS key", checkOK, "%s", keyFileStatus(keys.BlsKeyCreated))
+	if common.EmptyAddress(config.RewardAddress) {
+		report.check("reward address", checkFail, "set the reward address with --%s", utils.ValidatorRewardAddressFlag.Name)
+	} else {
+		report.check("reward address", checkOK, "%s", config.RewardAddress.Hex())
+	}
+	if common.EmptyAddress(config.StakingContract) {
+		report.check("staking contract", checkTodo, "deploy a CnStakingContract of the node ID and the reward address, and set it with --%s", utils.ValidatorStakingContractFlag.Name)
+	}
+
+	if backend == nil {
+		report.check("network", checkSkip, "set the endpoint with --%s to check the registration preconditions", utils.ValidatorEndpointFlag.Name)
+	} else if err := checkValidatorPreconditions(ctx, backend, config, report); err != nil {
+		return nil, err
+	}
+
+	if report.Templates, err = makeOnboardingTemplates(config, report); err != nil {
+		return nil, err
+	}
+	return report, writeOnboardingFiles(config.OutputDir, report)
+}
+
+func keyFileStatus(created bool) string {
+	if created {
+		return "generated"
+	}
+	return "loaded the existing key"
+}
+
+// checkValidatorPreconditions checks the AddressBook, the staking contract and the KIP-113 contract
+// against the conditions to be met before the CN is registered to the AddressBook.
+func checkValidatorPreconditions(ctx context.Context, backend validatorBackend, config validatorOnboardingConfig, report *onboardingReport) error {
+	opts := &bind.CallOpts{Context: ctx}
+	staking := config.StakingContract
+
+	code, err := backend.CodeAt(ctx, config.AddressBook, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the network: %v", err)
+	}
+	if len(code) == 0 {
+		report.check("AddressBook", checkFail, "no AddressBook at %s", config.AddressBook.Hex())
+	} else if addressBook, err := contract.NewAddressBookCaller(config.AddressBook, backend); err != nil {
+		return err
+	} else {
+		if activated, err := addressBook.IsActivated(opts); err != nil {
+			report.check("AddressBook", checkFail, "failed to call the AddressBook: %v", err)
+		} else if !activated {
+			report.check("AddressBook", checkFail, "the AddressBook is not activated")
+		} else {
+			report.check("AddressBook", checkOK, "activated")
+		}
+
+		nodeIds, stakingContracts, _, _, _, err := addressBook.GetAllAddressInfo(opts)
+		if err != nil {
+			return fmt.Errorf("failed to read the AddressBook: %v", err)
+		}
+		status, detail := checkOK, "the node and the staking contract are not registered yet"
+		for i, nodeId := range nodeIds {
+			if nodeId == report.NodeID && stakingContracts[i] == staking {
+				status, detail = checkOK, "already registered"
+			} else if nodeId == report.NodeID {
+				status, detail = checkFail, fmt.Sprintf("the node is registered with the staking contract %s", stakingContracts[i].Hex())
+			} else if stakingContracts[i] == staking {
+				status, detail = checkFail, fmt.Sprintf("the staking contract is registered by the node %s", nodeId.Hex())
+			} else {
+				continue
+			}
+			break
+		}
+		report.check("AddressBook registration", status, "%s", detail)
+	}
+
+	if !common.EmptyAddress(staking) {
+		if err := checkStakingContract(ctx, backend, staking, report); err != nil {
+			return err
+		}
+	}
+
+	if common.EmptyAddress(config.KIP113) {
+		report.check("KIP-113", checkSkip, "set the KIP-113 contract with --%s to register the BLS public key", utils.ValidatorKIP113Flag.Name)
+	} else if code, err := backend.CodeAt(ctx, config.KIP113, nil); err != nil {
+		return err
+	} else if len(code) == 0 {
+		report.check("KIP-113", checkFail, "no contract at %s", config.KIP113.Hex())
+	} else {
+		report.check("KIP-113", checkTodo, "register the BLS public key after the AddressBook registration")
+	}
+	return nil
+}
+
+// checkStakingContract checks that the staking contract belongs to the node and holds the minimum stake.
+func checkStakingContract(ctx context.Context, backend validatorBackend, staking common.Address, report *onboardingReport) error {
+	code, err := backend.CodeAt(ctx, staking, nil)
+	if err != nil {
+		return err
+	}
+	if len(code) == 0 {
+		report.check("staking contract", checkFail, "no contract at %s", staking.Hex())
+	} else {
+		parsed, err := abi.JSON(strings.NewReader(cnStakingABI))
+		if err != nil {
+			return err
+		}
+		cnStaking := bind.NewBoundContract(staking, parsed, backend, nil, nil)
+		call := func(method string) (interface{}, error) {
+			var out []interface{}
+			if err := cnStaking.Call(&bind.CallOpts{Context: ctx}, &out, method); err != nil {
+				return nil, err
+			}
+			return out[0], nil
+		}
+
+		nodeId, errNodeId := call("nodeId")
+		rewardAddr, errRewardAddr := call("rewardAddress")
+		initialized, errInitialized := call("isInitialized")
+		switch {
+		case errNodeId != nil || errRewardAddr != nil || errInitialized != nil:
+			report.check("staking contract", checkFail, "%s is not a CnStakingContract", staking.Hex())
+		case nodeId.(common.Address) != report.NodeID:
+			report.check("staking contract", checkFail, "the contract is of the node %s", nodeId.(common.Address).Hex())
+		case rewardAddr.(common.Address) != report.RewardAddress:
+			report.check("staking contract", checkFail, "the contract is of the reward address %s", rewardAddr.(common.Address).Hex())
+		case !initialized.(bool):
+			report.check("staking contract", checkTodo, "the contract is not initialized by its admins yet")
+		default:
+			report.check("staking contract", checkOK, "initialized")
+		}
+	}
+
+	minStake, err := backend.MinimumStake(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the minimum stake: %v", err)
+	}
+	report.MinimumStake = minStake
+	balance, err := backend.BalanceAt(ctx, staking, nil)
+	if err != nil {
+		return err
+	}
+	staked := new(big.Int).Div(balance, big.NewInt(params.KLAY))
+	if staked.Cmp(minStake) >= 0 {
+		report.check("staking amount", checkOK, "%v KLAY staked", staked)
+	} else {
+		report.check("staking amount", checkTodo, "%v KLAY staked, stake %v KLAY more", staked, new(big.Int).Sub(minStake, staked))
+	}
+	return nil
+}
+
+// makeOnboardingTemplates returns the unsigned transactions staking KLAY, registering the node
+// to the AddressBook and registering the BLS public key to the KIP-113 contract.
+func makeOnboardingTemplates(config validatorOnboardingConfig, report *onboardingReport) (map[string]*onboardingTxTemplate, error) {
+	templates := make(map[string]*onboardingTxTemplate)
+
+	cnStaking, err := abi.JSON(strings.NewReader(cnStakingABI))
+	if err != nil {
+		return nil, err
+	}
+	data, err := cnStaking.Pack("stakeKlay")
+	if err != nil {
+		return nil, err
+	}
+	stake := &onboardingTxTemplate{
+		Description: "Stake KLAY to the CnStakingContract of the node",
+		From:        "a staking account",
+		Data:        data,
+	}
+	if !common.EmptyAddress(config.StakingContract) {
+		stake.To = &config.StakingContract
+	}
+	if report.MinimumStake != nil {
+		stake.Value = (*hexutil.Big)(new(big.Int).Mul(report.MinimumStake, big.NewInt(params.KLAY)))
+	}
+	templates["stake.json"] = stake
+
+	if !common.EmptyAddress(config.StakingContract) {
+		addressBook, err := abi.JSON(strings.NewReader(contract.AddressBookABI))
+		if err != nil {
+			return nil, err
+		}
+		data, err := addressBook.Pack("submitRegisterCnStakingContract", report.NodeID, config.StakingContract, config.RewardAddress)
+		if err != nil {
+			return nil, err
+		}
+		templates["register-cn.json"] = &onboardingTxTemplate{
+			Description: "Register the node to the AddressBook; to be sent by the admins of the AddressBook",
+			From:        "an AddressBook admin",
+			To:          &config.AddressBook,
+			Data:        data,
+		}
+	}
+
+	kip113, err := abi.JSON(strings.NewReader(system_contracts.KIP113ABI))
+	if err != nil {
+		return nil, err
+	}
+	if data, err = kip113.Pack("register", report.NodeID, []byte(report.BlsPublicKey), []byte(report.BlsPop)); err != nil {
+		return nil, err
+	}
+	registerBls := &onboardingTxTemplate{
+		Description: "Register the BLS public key of the node to the KIP-113 contract after the AddressBook registration",
+		From:        "the owner of the KIP-113 contract",
+		Data:        data,
+	}
+	if !common.EmptyAddress(config.KIP113) {
+		registerBls.To = &config.KIP113
+	}
+	templates["register-bls.json"] = registerBls
+	return templates, nil
+}
+
+func writeOnboardingFiles(dir string, report *onboardingReport) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	write := func(name string, v interface{}) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, name), data, 0o600)
+	}
+	for name, template := range report.Templates {
+		if err := write(name, template); err != nil {
+			return err
+		}
+	}
+	return write("report.json", report)
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/accounts/abi/bind"
+	"github.com/klaytn/klaytn/accounts/abi/bind/backends"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/contracts/reward/contract"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testValidatorBackend struct {
+	*backends.SimulatedBackend
+	minStake *big.Int
+}
+
+func (b *testValidatorBackend) MinimumStake(ctx context.Context) (*big.Int, error) {
+	return b.minStake, nil
+}
+
+func checkStatuses(report *onboardingReport) map[string]string {
+	statuses := make(map[string]string)
+	for _, c := range report.Checks {
+		statuses[c.Name] = c.Status
+	}
+	return statuses
+}
+
+func TestInitValidator_Offline(t *testing.T) {
+	dir := t.TempDir()
+	config := validatorOnboardingConfig{
+		DataDir:         dir,
+		OutputDir:       filepath.Join(dir, "validator"),
+		RewardAddress:   common.HexToAddress("0xaaaa"),
+		StakingContract: common.HexToAddress("0xbbbb"),
+		AddressBook:     common.HexToAddress(contract.AddressBookContractAddress),
+	}
+
+	report, err := initValidator(context.Background(), nil, config)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "klay", "nodekey"), report.NodeKeyFile)
+	assert.Equal(t, filepath.Join(dir, "klay", "bls-nodekey"), report.BlsKeyFile)
+	assert.Equal(t, "generated", report.Checks[0].Detail)
+	assert.Equal(t, checkSkip, checkStatuses(report)["network"])
+	for _, name := range []string{"stake.json", "register-cn.json", "register-bls.json", "report.json"} {
+		assert.FileExists(t, filepath.Join(config.OutputDir, name))
+	}
+	assert.Equal(t, &config.AddressBook, report.Templates["register-cn.json"].To)
+	assert.Nil(t, report.Templates["register-bls.json"].To)
+	assert.Nil(t, report.Templates["stake.json"].Value)
+
+	// the node key is the one read by the node
+	key, err := crypto.LoadECDSA(report.NodeKeyFile)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), report.NodeID)
+
+	// the existing keys are kept
+	again, err := initValidator(context.Background(), nil, config)
+	require.NoError(t, err)
+	assert.Equal(t, report.NodeID, again.NodeID)
+	assert.Equal(t, report.BlsPublicKey, again.BlsPublicKey)
+	assert.Equal(t, "loaded the existing key", again.Checks[0].Detail)
+
+	// the templates without the staking contract
+	require.NoError(t, os.RemoveAll(config.OutputDir))
+	config.StakingContract = common.Address{}
+	config.RewardAddress = common.Address{}
+	report, err = initValidator(context.Background(), nil, config)
+	require.NoError(t, err)
+	assert.Nil(t, report.Templates["register-cn.json"])
+	assert.NoFileExists(t, filepath.Join(config.OutputDir, "register-cn.json"))
+	assert.Equal(t, checkFail, checkStatuses(report)["reward address"])
+
+	var buf bytes.Buffer
+	report.print(&buf)
+	assert.Contains(t, buf.String(), report.NodeID.Hex())
+	assert.NotContains(t, buf.String(), "ready to be registered")
+}
+
+func TestInitValidator_Preconditions(t *testing.T) {
+	var (
+		dir        = t.TempDir()
+		key, _     = crypto.GenerateKey()
+		auth       = bind.NewKeyedTransactor(key)
+		sim        = backends.NewSimulatedBackend(blockchain.GenesisAlloc{auth.From: {Balance: big.NewInt(params.KLAY)}})
+		backend    = &testValidatorBackend{SimulatedBackend: sim, minStake: big.NewInt(5000000)}
+		otherNode  = common.HexToAddress("0x1111")
+		staking    = common.HexToAddress("0xbbbb")
+		rewardAddr = common.HexToAddress("0xaaaa")
+	)
+	defer sim.Close()
+
+	config := validatorOnboardingConfig{
+		DataDir:         dir,
+		OutputDir:       filepath.Join(dir, "validator"),
+		RewardAddress:   rewardAddr,
+		StakingContract: staking,
+		AddressBook:     common.HexToAddress(contract.AddressBookContractAddress),
+	}
+
+	// no AddressBook
+	report, err := initValidator(context.Background(), backend, config)
+	require.NoError(t, err)
+	assert.Equal(t, checkFail, checkStatuses(report)["AddressBook"])
+
+	addr, _, addressBook, err := contract.DeployAddressBookMock(auth, sim)
+	require.NoError(t, err)
+	sim.Commit()
+	config.AddressBook = addr
+	_, err = addressBook.ConstructContract(auth, []common.Address{}, big.NewInt(1))
+	require.NoError(t, err)
+	sim.Commit()
+
+	// inactive AddressBook
+	report, err = initValidator(context.Background(), backend, config)
+	require.NoError(t, err)
+	statuses := checkStatuses(report)
+	assert.Equal(t, checkFail, statuses["AddressBook"])
+	assert.Equal(t, checkOK, statuses["AddressBook registration"])
+	assert.Equal(t, checkFail, statuses["staking contract"])
+	assert.Equal(t, checkTodo, statuses["staking amount"])
+	assert.Equal(t, checkSkip, statuses["KIP-113"])
+	assert.Equal(t, big.NewInt(5000000), report.MinimumStake)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(5000000), big.NewInt(params.KLAY)), report.Templates["stake.json"].Value.ToInt())
+
+	// the staking contract registered by another node
+	_, err = addressBook.UpdatePocContract(auth, common.HexToAddress("0xc1"), big.NewInt(1))
+	require.NoError(t, err)
+	_, err = addressBook.UpdateKirContract(auth, common.HexToAddress("0xc2"), big.NewInt(1))
+	require.NoError(t, err)
+	_, err = addressBook.RegisterCnStakingContract(auth, otherNode, staking, rewardAddr)
+	require.NoError(t, err)
+	sim.Commit()
+	_, err = addressBook.ActivateAddressBook(auth)
+	require.NoError(t, err)
+	sim.Commit()
+
+	config.KIP113 = addr
+	report, err = initValidator(context.Background(), backend, config)
+	require.NoError(t, err)
+	statuses = checkStatuses(report)
+	assert.Equal(t, checkOK, statuses["AddressBook"])
+	assert.Equal(t, checkFail, statuses["AddressBook registration"])
+	assert.Equal(t, checkTodo, statuses["KIP-113"])
+	assert.Equal(t, &addr, report.Templates["register-bls.json"].To)
+
+	// the node registered with another staking contract
+	_, err = addressBook.RegisterCnStakingContract(auth, report.NodeID, common.HexToAddress("0xcccc"), rewardAddr)
+	require.NoError(t, err)
+	sim.Commit()
+	config.StakingContract = common.Address{}
+	report, err = initValidator(context.Background(), backend, config)
+	require.NoError(t, err)
+	statuses = checkStatuses(report)
+	assert.Equal(t, checkFail, statuses["AddressBook registration"])
+	assert.Equal(t, checkTodo, statuses["staking contract"])
+	assert.NotContains(t, statuses, "staking amount")
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/contracts/reward/contract"
+	"github.com/urfave/cli/v2"
+)
+
+var ValidatorCommand = &cli.Command{
+	Name:     "validator",
+	Usage:    "Manage the onboarding of a validator",
+	Category: "MISCELLANEOUS COMMANDS",
+	Subcommands: []*cli.Command{
+		{
+			Action: utils.MigrateFlags(validatorInit),
+			Name:   "init",
+			Usage:  "Prepare the keys, the transaction templates and the checklist of a new CN",
+			Flags:  utils.ValidatorFlags,
+			Description: `
+    kcn validator init --datadir <datadir> --validator.rewardaddress <address>
+
+The init command walks through the onboarding of a new CN:
+
+1. The node key and the BLS key are generated in the data directory unless they
+   exist. The BLS key is derived from the node key.
+2. The unsigned transaction templates are written to the output directory:
+   stake.json stakes KLAY to the CnStakingContract, register-cn.json registers
+   the node to the AddressBook and register-bls.json registers the BLS public
+   key to the KIP-113 contract.
+3. If an endpoint is given, the AddressBook registration preconditions are
+   checked against the network: the AddressBook is activated, the node is not
+   registered yet, and the staking contract belongs to the node and holds the
+   minimum stake.
+
+The checklist is printed and written to report.json in the output directory.
+Run the command again after each step to update the checklist.`,
+		},
+	},
+}
+
+func validatorInit(ctx *cli.Context) error {
+	config, err := makeValidatorOnboardingConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	var backend validatorBackend
+	if endpoint := ctx.String(utils.ValidatorEndpointFlag.Name); endpoint != "" {
+		c, err := dialRPC(endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to connect to the endpoint: %v", err)
+		}
+		defer c.Close()
+		backend = newValidatorClient(c)
+	}
+
+	report, err := initValidator(context.Background(), backend, *config)
+	if err != nil {
+		return err
+	}
+	report.print(os.Stdout)
+	fmt.Printf("\nThe transaction templates and the report are written to %s\n", config.OutputDir)
+	return nil
+}
+
+func makeValidatorOnboardingConfig(ctx *cli.Context) (*validatorOnboardingConfig, error) {
+	config := &validatorOnboardingConfig{
+		DataDir:     ctx.String(utils.DataDirFlag.Name),
+		OutputDir:   ctx.String(utils.ValidatorOutputFlag.Name),
+		AddressBook: common.HexToAddress(contract.AddressBookContractAddress),
+	}
+	if config.OutputDir == "" {
+		config.OutputDir = filepath.Join(config.DataDir, "validator")
+	}
+
+	addrFlags := map[string]*common.Address{
+		utils.ValidatorRewardAddressFlag.Name:   &config.RewardAddress,
+		utils.ValidatorStakingContractFlag.Name: &config.StakingContract,
+		utils.ValidatorKIP113Flag.Name:          &config.KIP113,
+	}
+	for name, addr := range addrFlags {
+		if str := ctx.String(name); str != "" {
+			if !common.IsHexAddress(str) {
+				return nil, fmt.Errorf("invalid address %q of --%s", str, name)
+			}
+			*addr = common.HexToAddress(str)
+		}
+	}
+	return config, nil
+}
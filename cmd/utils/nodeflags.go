@@ -35,6 +35,8 @@ func AllNodeFlags() []cli.Flag {
 	nodeFlags = append(nodeFlags, ConsoleFlags...)
 	nodeFlags = append(nodeFlags, debug.Flags...)
 	nodeFlags = append(nodeFlags, ChainDataFetcherFlags...)
+	nodeFlags = append(nodeFlags, FaucetFlags...)
+	nodeFlags = append(nodeFlags, SubProxyFlags...)
 	nodeFlags = union(nodeFlags, SnapshotFlags)
 	nodeFlags = union(nodeFlags, DBMigrationSrcFlags)
 	nodeFlags = union(nodeFlags, DBMigrationDstFlags)
@@ -124,6 +126,8 @@ func KenAppFlags() []cli.Flag {
 	flags = append(flags, debug.Flags...)
 	flags = append(flags, DBMigrationDstFlags...)
 	flags = append(flags, ChainDataFetcherFlags...)
+	flags = append(flags, FaucetFlags...)
+	flags = append(flags, SubProxyFlags...)
 	return flags
 }
 
@@ -160,7 +164,7 @@ var ConsoleFlags = []cli.Flag{
 }
 
 // Common flags that configure the node
-var CommonNodeFlags = []cli.Flag{
+var CommonNodeFlags = append([]cli.Flag{
 	ConfFlag,
 	altsrc.NewBoolFlag(NtpDisableFlag),
 	altsrc.NewStringFlag(NtpServerFlag),
@@ -187,14 +191,21 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewUint64Flag(TxPoolNonExecSlotsAccountFlag),
 	altsrc.NewUint64Flag(TxPoolNonExecSlotsAllFlag),
 	altsrc.NewDurationFlag(TxPoolLifetimeFlag),
+	altsrc.NewStringFlag(TxPoolFeePayerAllowanceRegistryFlag),
+	altsrc.NewUint64Flag(TxPoolGaslessSenderLimitFlag),
 	altsrc.NewBoolFlag(TxPoolKeepLocalsFlag),
 	NewWrappedTextMarshalerFlag(SyncModeFlag),
 	altsrc.NewStringFlag(GCModeFlag),
+	altsrc.NewUint64Flag(CheckpointIntervalFlag),
+	altsrc.NewUint64Flag(StallTimeoutFlag),
+	altsrc.NewBoolFlag(StandbyFlag),
+	altsrc.NewUint64Flag(StandbyWindowFlag),
 	altsrc.NewBoolFlag(LightKDFFlag),
 	altsrc.NewBoolFlag(SingleDBFlag),
 	altsrc.NewUintFlag(NumStateTrieShardsFlag),
 	altsrc.NewIntFlag(LevelDBCompressionTypeFlag),
 	altsrc.NewBoolFlag(LevelDBNoBufferPoolFlag),
+	altsrc.NewIntFlag(HeaderMmapCacheSizeFlag),
 	altsrc.NewBoolFlag(DBNoPerformanceMetricsFlag),
 	altsrc.NewBoolFlag(RocksDBSecondaryFlag),
 	altsrc.NewUint64Flag(RocksDBCacheSizeFlag),
@@ -214,6 +225,27 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewIntFlag(LevelDBCacheSizeFlag),
 	altsrc.NewBoolFlag(NoParallelDBWriteFlag),
 	altsrc.NewBoolFlag(SenderTxHashIndexingFlag),
+	altsrc.NewBoolFlag(BalanceHistoryIndexingFlag),
+	altsrc.NewBoolFlag(EpochSummaryIndexingFlag),
+	altsrc.NewBoolFlag(RewardIndexingFlag),
+	altsrc.NewStringFlag(RewardPolicyFlag),
+	altsrc.NewUint64Flag(RewardPolicyBlockFlag),
+	altsrc.NewBoolFlag(RewardVerifyFlag),
+	altsrc.NewBoolFlag(RewardCheckInvariantsFlag),
+	altsrc.NewBoolFlag(RewardAuditFeeFlag),
+	altsrc.NewBoolFlag(RewardHistoryFlag),
+	altsrc.NewUint64Flag(RewardHistoryRetentionFlag),
+	altsrc.NewBoolFlag(RewardMonitorFlag),
+	altsrc.NewFloat64Flag(RewardMonitorMintedFlag),
+	altsrc.NewFloat64Flag(RewardMonitorBurnRatioFlag),
+	altsrc.NewFloat64Flag(RewardMonitorShareFlag),
+	altsrc.NewStringFlag(RewardReportFormatFlag),
+	altsrc.NewStringFlag(VerifyOnStartFlag),
+	altsrc.NewBoolFlag(AutoCompactionFlag),
+	altsrc.NewStringFlag(AutoCompactionScheduleFlag),
+	altsrc.NewInt64Flag(AutoCompactionMaxPendingRequestsFlag),
+	altsrc.NewUint64Flag(AutoCompactionRecentBlocksFlag),
+	altsrc.NewUint64Flag(AutoCompactionChunkBlocksFlag),
 	altsrc.NewIntFlag(TrieMemoryCacheSizeFlag),
 	altsrc.NewUintFlag(TrieBlockIntervalFlag),
 	altsrc.NewUint64Flag(TriesInMemoryFlag),
@@ -246,14 +278,23 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewStringFlag(NetrestrictFlag),
 	altsrc.NewStringFlag(NodeKeyFileFlag),
 	altsrc.NewStringFlag(NodeKeyHexFlag),
+	altsrc.NewBoolFlag(AttestationFlag),
+	altsrc.NewBoolFlag(AttestationRequireFlag),
+	altsrc.NewStringSliceFlag(AttestationVersionsFlag),
 	altsrc.NewBoolFlag(VMEnableDebugFlag),
 	altsrc.NewIntFlag(VMLogTargetFlag),
 	altsrc.NewBoolFlag(VMTraceInternalTxFlag),
 	altsrc.NewBoolFlag(VMOpDebugFlag),
+	altsrc.NewStringFlag(VMTraceSinkDirFlag),
+	altsrc.NewUint64Flag(VMTraceSinkMaxFileSizeFlag),
+	altsrc.NewIntFlag(VMTraceSinkMaxFilesFlag),
+	altsrc.NewStringFlag(VMTraceSinkSocketFlag),
+	altsrc.NewStringFlag(VMTraceSinkOTLPFlag),
 	altsrc.NewUint64Flag(NetworkIdFlag),
 	altsrc.NewBoolFlag(MetricsEnabledFlag),
 	altsrc.NewBoolFlag(PrometheusExporterFlag),
 	altsrc.NewIntFlag(PrometheusExporterPortFlag),
+	altsrc.NewStringFlag(EventMetricsFlag),
 	altsrc.NewStringFlag(ExtraDataFlag),
 	altsrc.NewStringFlag(SrvTypeFlag),
 	altsrc.NewBoolFlag(AutoRestartFlag),
@@ -262,11 +303,15 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewStringFlag(ConfigFileFlag),
 	altsrc.NewIntFlag(APIFilterGetLogsMaxItemsFlag),
 	altsrc.NewDurationFlag(APIFilterGetLogsDeadlineFlag),
+	altsrc.NewDurationFlag(APIFilterTimeoutFlag),
+	altsrc.NewIntFlag(APIFilterMaxItemsFlag),
+	altsrc.NewIntFlag(APIFilterMaxFiltersPerClientFlag),
+	altsrc.NewIntFlag(APIFilterMaxSubscriptionsPerClientFlag),
 	altsrc.NewUint64Flag(OpcodeComputationCostLimitFlag),
 	altsrc.NewBoolFlag(SnapshotFlag),
 	altsrc.NewIntFlag(SnapshotCacheSizeFlag),
 	altsrc.NewBoolFlag(SnapshotAsyncGen),
-}
+}, OverrideForkFlags...)
 
 // Common RPC flags
 var CommonRPCFlags = []cli.Flag{
@@ -276,8 +321,13 @@ var CommonRPCFlags = []cli.Flag{
 	altsrc.NewStringFlag(RPCApiFlag),
 	altsrc.NewUint64Flag(RPCGlobalGasCap),
 	altsrc.NewFloat64Flag(RPCGlobalEthTxFeeCapFlag),
+	altsrc.NewBoolFlag(RPCAnnotateAddressLabelsFlag),
+	altsrc.NewStringFlag(RPCAddressLabelsFlag),
+	altsrc.NewStringFlag(RPCContractRegistryFlag),
 	altsrc.NewStringFlag(RPCCORSDomainFlag),
 	altsrc.NewStringFlag(RPCVirtualHostsFlag),
+	altsrc.NewStringFlag(RPCVirtualEndpointsFlag),
+	altsrc.NewUint64Flag(RPCComputeBudgetFlag),
 	altsrc.NewBoolFlag(RPCNonEthCompatibleFlag),
 	altsrc.NewDurationFlag(RPCGlobalEVMTimeoutFlag),
 	altsrc.NewBoolFlag(WSEnabledFlag),
@@ -288,6 +338,8 @@ var CommonRPCFlags = []cli.Flag{
 	altsrc.NewIntFlag(GRPCPortFlag),
 	altsrc.NewIntFlag(RPCConcurrencyLimit),
 	altsrc.NewStringFlag(WSApiFlag),
+	altsrc.NewStringFlag(WSVirtualEndpointsFlag),
+	altsrc.NewUint64Flag(WSComputeBudgetFlag),
 	altsrc.NewStringFlag(WSAllowedOriginsFlag),
 	altsrc.NewIntFlag(WSMaxSubscriptionPerConn),
 	altsrc.NewInt64Flag(WSReadDeadLine),
@@ -295,6 +347,13 @@ var CommonRPCFlags = []cli.Flag{
 	altsrc.NewIntFlag(WSMaxConnections),
 	altsrc.NewBoolFlag(IPCDisabledFlag),
 	altsrc.NewPathFlag(IPCPathFlag),
+	altsrc.NewPathFlag(AdminPathFlag),
+	altsrc.NewStringFlag(AdminApiFlag),
+	altsrc.NewPathFlag(AdminTokenFileFlag),
+	altsrc.NewPathFlag(AdminTLSCertFlag),
+	altsrc.NewPathFlag(AdminTLSKeyFlag),
+	altsrc.NewPathFlag(AdminTLSClientCAFlag),
+	altsrc.NewBoolFlag(AdminExclusiveFlag),
 	altsrc.NewIntFlag(RPCReadTimeout),
 	altsrc.NewIntFlag(RPCWriteTimeoutFlag),
 	altsrc.NewIntFlag(RPCIdleTimeoutFlag),
@@ -324,6 +383,11 @@ var BNFlags = []cli.Flag{
 
 var KCNFlags = []cli.Flag{
 	altsrc.NewStringFlag(RewardbaseFlag),
+	altsrc.NewIntFlag(DiversityMaxPerSubnetFlag),
+	altsrc.NewIntFlag(DiversityMaxPerASNFlag),
+	altsrc.NewIntFlag(DiversityMaxPerRegionFlag),
+	altsrc.NewStringFlag(DiversityNetInfoFlag),
+	altsrc.NewUint64Flag(RPCPreconfMaxBlocksFlag),
 	altsrc.NewBoolFlag(CypressFlag),
 	altsrc.NewBoolFlag(BaobabFlag),
 	altsrc.NewInt64Flag(BlockGenerationIntervalFlag),
@@ -337,6 +401,10 @@ var KPNFlags = []cli.Flag{
 	altsrc.NewBoolFlag(CypressFlag),
 	altsrc.NewBoolFlag(BaobabFlag),
 	altsrc.NewBoolFlag(TxPoolSpamThrottlerDisableFlag),
+	altsrc.NewIntFlag(DiversityMaxPerSubnetFlag),
+	altsrc.NewIntFlag(DiversityMaxPerASNFlag),
+	altsrc.NewIntFlag(DiversityMaxPerRegionFlag),
+	altsrc.NewStringFlag(DiversityNetInfoFlag),
 }
 
 var KENFlags = []cli.Flag{
@@ -375,6 +443,8 @@ var KSCNFlags = []cli.Flag{
 	altsrc.NewInt64Flag(BlockGenerationIntervalFlag),
 	altsrc.NewDurationFlag(BlockGenerationTimeLimitFlag),
 	altsrc.NewStringFlag(ServiceChainSignerFlag),
+	altsrc.NewBoolFlag(IdleBlockSuppressionFlag),
+	altsrc.NewDurationFlag(IdleBlockIntervalFlag),
 	altsrc.NewUint64Flag(AnchoringPeriodFlag),
 	altsrc.NewUint64Flag(SentChainTxsLimit),
 	altsrc.NewBoolFlag(MainBridgeFlag),
@@ -389,6 +459,14 @@ var KSCNFlags = []cli.Flag{
 	altsrc.NewBoolFlag(ServiceChainAnchoringFlag),
 	altsrc.NewUint64Flag(ServiceChainParentOperatorTxGasLimitFlag),
 	altsrc.NewUint64Flag(ServiceChainChildOperatorTxGasLimitFlag),
+	altsrc.NewIntFlag(ParentRPCHedgeSizeFlag),
+	altsrc.NewDurationFlag(ParentRPCHealthTimeoutFlag),
+	altsrc.NewBoolFlag(ParentGasPriceSyncFlag),
+	altsrc.NewUint64Flag(ParentGasPriceSyncIntervalFlag),
+	altsrc.NewUint64Flag(ParentGasPriceSyncSmoothingFlag),
+	altsrc.NewUint64Flag(ParentGasPriceSyncMaxChangeFlag),
+	altsrc.NewUint64Flag(ParentGasPriceSyncMinFlag),
+	altsrc.NewUint64Flag(ParentGasPriceSyncMaxFlag),
 	// KAS
 	altsrc.NewBoolFlag(KASServiceChainAnchorFlag),
 	altsrc.NewUint64Flag(KASServiceChainAnchorPeriodFlag),
@@ -420,6 +498,8 @@ var KSPNFlags = []cli.Flag{
 	altsrc.NewBoolFlag(ServiceChainAnchoringFlag),
 	altsrc.NewUint64Flag(ServiceChainParentOperatorTxGasLimitFlag),
 	altsrc.NewUint64Flag(ServiceChainChildOperatorTxGasLimitFlag),
+	altsrc.NewIntFlag(ParentRPCHedgeSizeFlag),
+	altsrc.NewDurationFlag(ParentRPCHealthTimeoutFlag),
 	// KAS
 	altsrc.NewBoolFlag(KASServiceChainAnchorFlag),
 	altsrc.NewUint64Flag(KASServiceChainAnchorPeriodFlag),
@@ -447,6 +527,8 @@ var KSENFlags = []cli.Flag{
 	altsrc.NewBoolFlag(KESNodeTypeServiceFlag),
 	altsrc.NewUint64Flag(ServiceChainParentOperatorTxGasLimitFlag),
 	altsrc.NewUint64Flag(ServiceChainChildOperatorTxGasLimitFlag),
+	altsrc.NewIntFlag(ParentRPCHedgeSizeFlag),
+	altsrc.NewDurationFlag(ParentRPCHealthTimeoutFlag),
 	// KAS
 	altsrc.NewBoolFlag(KASServiceChainAnchorFlag),
 	altsrc.NewUint64Flag(KASServiceChainAnchorPeriodFlag),
@@ -526,6 +608,62 @@ var DBMigrationSrcFlags = []cli.Flag{
 	altsrc.NewBoolFlag(RocksDBCacheIndexAndFilterFlag),
 }
 
+var LoadTestFlags = []cli.Flag{
+	altsrc.NewPathFlag(LoadTestKeyFileFlag),
+	altsrc.NewPathFlag(LoadTestFeePayerKeyFileFlag),
+	altsrc.NewStringFlag(LoadTestMixFlag),
+	altsrc.NewIntFlag(LoadTestTPSFlag),
+	altsrc.NewDurationFlag(LoadTestDurationFlag),
+	altsrc.NewStringFlag(LoadTestToFlag),
+	altsrc.NewStringFlag(LoadTestContractFlag),
+	altsrc.NewStringFlag(LoadTestCallDataFlag),
+	altsrc.NewUint64Flag(LoadTestGasLimitFlag),
+	altsrc.NewUint64Flag(LoadTestGasPriceFlag),
+}
+
+var ForkFlags = []cli.Flag{
+	altsrc.NewUint64Flag(ForkBlockFlag),
+	altsrc.NewStringFlag(ForkListenAddrFlag),
+	altsrc.NewStringFlag(ForkCORSDomainFlag),
+	altsrc.NewStringFlag(ForkVirtualHostsFlag),
+}
+
+var ValidatorFlags = []cli.Flag{
+	altsrc.NewPathFlag(DataDirFlag),
+	altsrc.NewStringFlag(ValidatorEndpointFlag),
+	altsrc.NewStringFlag(ValidatorRewardAddressFlag),
+	altsrc.NewStringFlag(ValidatorStakingContractFlag),
+	altsrc.NewStringFlag(ValidatorKIP113Flag),
+	altsrc.NewPathFlag(ValidatorOutputFlag),
+}
+
+var RewardExportFlags = union([]cli.Flag{
+	altsrc.NewUint64Flag(RewardExportFromFlag),
+	altsrc.NewUint64Flag(RewardExportToFlag),
+	altsrc.NewStringFlag(RewardExportFormatFlag),
+	altsrc.NewPathFlag(RewardExportOutputFlag),
+	altsrc.NewStringFlag(RewardExportAmountFormatFlag),
+}, SnapshotFlags)
+
+var FaucetFlags = []cli.Flag{
+	altsrc.NewBoolFlag(EnableFaucetFlag),
+	altsrc.NewStringFlag(FaucetAccountFlag),
+	altsrc.NewStringFlag(FaucetPasswordFileFlag),
+	altsrc.NewUint64Flag(FaucetAmountFlag),
+	altsrc.NewDurationFlag(FaucetPeriodFlag),
+	altsrc.NewStringFlag(FaucetCaptchaURLFlag),
+	altsrc.NewStringFlag(FaucetCaptchaSecretFlag),
+	altsrc.NewStringFlag(FaucetWebhookFlag),
+}
+
+var SubProxyFlags = []cli.Flag{
+	altsrc.NewBoolFlag(EnableSubProxyFlag),
+	altsrc.NewStringFlag(SubProxyUpstreamFlag),
+	altsrc.NewIntFlag(SubProxyMaxClientsFlag),
+	altsrc.NewIntFlag(SubProxyClientBufferFlag),
+	altsrc.NewDurationFlag(SubProxyReconnectFlag),
+}
+
 var DBMigrationDstFlags = []cli.Flag{
 	altsrc.NewStringFlag(DstDbTypeFlag),
 	altsrc.NewPathFlag(DstDataDirFlag),
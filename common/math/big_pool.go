@@ -0,0 +1,65 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package math
+
+import (
+	"math/big"
+	"sync"
+)
+
+var bigArenaPool = sync.Pool{
+	New: func() interface{} { return new(BigArena) },
+}
+
+// BigArena hands out the temporary big.Ints of a computation, e.g. the fee of a
+// transaction, and returns all of them to a pool at once when the computation is done.
+// The big.Ints are reused by the next computations taking the arena from the pool, which
+// removes the allocations of the hot paths executed for every transaction.
+//
+// The big.Ints of an arena must not be referenced after Free, so the results kept by
+// the caller, e.g. the balances in the state, must be copied out of the arena. An arena
+// is not safe for concurrent use.
+type BigArena struct {
+	ints []*big.Int
+	used int // ints[:used] are handed out
+}
+
+// NewBigArena returns an empty arena. Free must be called when the arena is done.
+func NewBigArena() *BigArena {
+	return bigArenaPool.Get().(*BigArena)
+}
+
+// New returns a zero big.Int valid until Free.
+func (a *BigArena) New() *big.Int {
+	if a.used == len(a.ints) {
+		a.ints = append(a.ints, new(big.Int))
+	}
+	x := a.ints[a.used].SetUint64(0)
+	a.used++
+	return x
+}
+
+// NewUint64 returns a big.Int set to v valid until Free.
+func (a *BigArena) NewUint64(v uint64) *big.Int {
+	return a.New().SetUint64(v)
+}
+
+// Free returns the arena to the pool with its big.Ints.
+func (a *BigArena) Free() {
+	a.used = 0
+	bigArenaPool.Put(a)
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package math
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBigArena(t *testing.T) {
+	a := NewBigArena()
+	x := a.NewUint64(21000)
+	y := a.New().Mul(x, big.NewInt(25000000000))
+	if y.Cmp(big.NewInt(21000*25000000000)) != 0 {
+		t.Fatalf("wrong product: %v", y)
+	}
+	a.Free()
+
+	// the recycled big.Ints are zero
+	a = NewBigArena()
+	defer a.Free()
+	for i := 0; i < 4; i++ {
+		if z := a.New(); z.Sign() != 0 {
+			t.Fatalf("recycled big.Int is not zero: %v", z)
+		}
+	}
+}
+
+// BenchmarkTxFee computes the fee of a transaction as the state transition does.
+func BenchmarkTxFee(b *testing.B) {
+	gasPrice := big.NewInt(25000000000)
+
+	b.Run("alloc", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fee := new(big.Int).Mul(new(big.Int).SetUint64(uint64(i)), gasPrice)
+			_ = new(big.Int).Div(fee, big.NewInt(2))
+		}
+	})
+	b.Run("arena", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			a := NewBigArena()
+			fee := a.New().Mul(a.NewUint64(uint64(i)), gasPrice)
+			_ = a.New().Div(fee, a.NewUint64(2))
+			a.Free()
+		}
+	})
+}
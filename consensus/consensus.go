@@ -150,6 +150,22 @@ type Handler interface {
 	RegisterConsensusMsgCode(Peer)
 }
 
+// CheckpointVerifier is implemented by the engines which verify a batch of headers faster
+// with the checkpoints in the batch.
+type CheckpointVerifier interface {
+	// VerifyCheckpoints authenticates the headers of the batch with the checkpoints in it
+	// before the headers are verified one by one. It is an optimization, so the headers
+	// not authenticated are verified as usual.
+	VerifyCheckpoints(chain ChainReader, headers []*types.Header)
+}
+
+// BurnRatioReader is implemented by the engines whose burn ratio of the tx fee is governed.
+type BurnRatioReader interface {
+	// BurnRatio returns the percentage of the tx fee burnt in effect at the block, so that
+	// a block is executed with its own burn ratio even if the ratio is changed later.
+	BurnRatio(num *big.Int) (uint64, error)
+}
+
 // Istanbul is a consensus engine to avoid byzantine failure
 type Istanbul interface {
 	Engine
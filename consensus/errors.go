@@ -37,4 +37,8 @@ var (
 
 	// ErrInvalidBaseFee is returned if a block before fork has a base fee field, not nil
 	ErrInvalidBaseFee = errors.New("invalid baseFee before fork")
+
+	// ErrInvalidStakingInfoHash is returned if a block before fork has a staking info hash field,
+	// or a block after fork does not have it.
+	ErrInvalidStakingInfoHash = errors.New("invalid stakingInfoHash")
 )
@@ -0,0 +1,248 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	inmemoryAnnouncements = 256                // the number of the latest announcements kept in memory
+	maxAnnouncementLength = 4096               // the maximum length of the message of an announcement
+	maxAnnouncementAge    = 7 * 24 * time.Hour // announcements older than this are dropped
+	maxAnnouncementSkew   = 5 * time.Minute    // the tolerance of announcements timestamped in the future
+)
+
+// The kinds of the announcements.
+const (
+	AnnouncementNotice    = "notice"
+	AnnouncementUpgrade   = "upgrade"
+	AnnouncementEmergency = "emergency"
+)
+
+// announcementMsgHash is the PrevHash of the ConsensusMsg carrying an announcement instead of
+// a consensus message. The nodes unaware of the announcements drop it as an undecodable
+// consensus message without disconnecting the peer.
+var announcementMsgHash = crypto.Keccak256Hash([]byte("istanbul-announcement"))
+
+var (
+	errUnknownAnnouncementKind = errors.New("unknown announcement kind")
+	errAnnouncementTooLong     = errors.New("announcement message too long")
+	errAnnouncementExpired     = errors.New("announcement expired")
+	errAnnouncementFuture      = errors.New("announcement timestamped in the future")
+	errNotCouncilMember        = errors.New("the signer is not a council member")
+
+	announcementReceivedCounter = metrics.NewRegisteredCounter("consensus/istanbul/announcement/received", nil)
+	announcementRejectedCounter = metrics.NewRegisteredCounter("consensus/istanbul/announcement/rejected", nil)
+)
+
+// Announcement is an operational notice signed by a council member, e.g. an upgrade
+// schedule or an emergency alert, broadcast over the consensus network.
+type Announcement struct {
+	Kind      string         `json:"kind"`
+	Message   string         `json:"message"`
+	Timestamp uint64         `json:"timestamp"` // unix time in seconds when the announcement was signed
+	Signer    common.Address `json:"signer"`
+	Signature hexutil.Bytes  `json:"signature"`
+	Hash      common.Hash    `json:"hash"`
+}
+
+// announcementRLP is the announcement sent over the network. The signer is recovered from the signature.
+type announcementRLP struct {
+	Kind      string
+	Message   string
+	Timestamp uint64
+	Signature []byte
+}
+
+// sigData returns the data signed by the signer of the announcement.
+func (a *announcementRLP) sigData() ([]byte, error) {
+	return rlp.EncodeToBytes([]interface{}{a.Kind, a.Message, a.Timestamp})
+}
+
+// validate checks the contents of the announcement, not its signer.
+func (a *announcementRLP) validate(now time.Time) error {
+	switch a.Kind {
+	case AnnouncementNotice, AnnouncementUpgrade, AnnouncementEmergency:
+	default:
+		return errUnknownAnnouncementKind
+	}
+	if len(a.Message) > maxAnnouncementLength {
+		return errAnnouncementTooLong
+	}
+	signed := time.Unix(int64(a.Timestamp), 0)
+	if now.Sub(signed) > maxAnnouncementAge {
+		return errAnnouncementExpired
+	}
+	if signed.Sub(now) > maxAnnouncementSkew {
+		return errAnnouncementFuture
+	}
+	return nil
+}
+
+// announcementStore keeps the latest announcements in memory.
+type announcementStore struct {
+	mu    sync.RWMutex
+	items map[common.Hash]*Announcement
+}
+
+func newAnnouncementStore() *announcementStore {
+	return &announcementStore{items: make(map[common.Hash]*Announcement)}
+}
+
+// add stores the announcement, evicting the oldest one if the store is full.
+// It returns false if the announcement is already stored.
+func (s *announcementStore) add(a *Announcement) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.items[a.Hash]; ok {
+		return false
+	}
+	if len(s.items) >= inmemoryAnnouncements {
+		var oldest *Announcement
+		for _, item := range s.items {
+			if oldest == nil || item.Timestamp < oldest.Timestamp {
+				oldest = item
+			}
+		}
+		delete(s.items, oldest.Hash)
+	}
+	s.items[a.Hash] = a
+	return true
+}
+
+// list returns the announcements not expired, the latest first.
+func (s *announcementStore) list(now time.Time) []*Announcement {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]*Announcement, 0, len(s.items))
+	for _, a := range s.items {
+		if now.Sub(time.Unix(int64(a.Timestamp), 0)) <= maxAnnouncementAge {
+			list = append(list, a)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Timestamp != list[j].Timestamp {
+			return list[i].Timestamp > list[j].Timestamp
+		}
+		return bytes.Compare(list[i].Hash[:], list[j].Hash[:]) < 0
+	})
+	return list
+}
+
+// Announce signs an announcement with the node key, stores it and broadcasts it to the
+// consensus nodes. The node should be a council member for the peers to accept it.
+func (sb *backend) Announce(kind, message string) (*Announcement, error) {
+	msg := &announcementRLP{Kind: kind, Message: message, Timestamp: uint64(time.Now().Unix())}
+	if err := msg.validate(time.Now()); err != nil {
+		return nil, err
+	}
+	if !sb.isCouncilMember(sb.address) {
+		return nil, errNotCouncilMember
+	}
+	data, err := msg.sigData()
+	if err != nil {
+		return nil, err
+	}
+	if msg.Signature, err = sb.Sign(data); err != nil {
+		return nil, err
+	}
+	payload, err := rlp.EncodeToBytes(msg)
+	if err != nil {
+		return nil, err
+	}
+	a := &Announcement{Kind: msg.Kind, Message: msg.Message, Timestamp: msg.Timestamp, Signer: sb.address, Signature: msg.Signature, Hash: istanbul.RLPHash(payload)}
+	sb.announcements.add(a)
+	sb.gossip(announcementMsgHash, payload)
+	return a, nil
+}
+
+// Announcements returns the announcements received or sent, the latest first.
+func (sb *backend) Announcements() []*Announcement {
+	return sb.announcements.list(time.Now())
+}
+
+// handleAnnouncement verifies the announcement received from a peer, and stores and relays it
+// if it is signed by a council member at the current block.
+func (sb *backend) handleAnnouncement(payload []byte) {
+	a, err := sb.verifyAnnouncement(payload, time.Now())
+	if err != nil {
+		announcementRejectedCounter.Inc(1)
+		logger.Debug("Rejected an announcement", "err", err)
+		return
+	}
+	if !sb.announcements.add(a) {
+		return
+	}
+	announcementReceivedCounter.Inc(1)
+	logger.Info("Received an announcement", "kind", a.Kind, "signer", a.Signer, "timestamp", a.Timestamp, "message", a.Message)
+	sb.gossip(announcementMsgHash, payload)
+}
+
+// verifyAnnouncement decodes the announcement and checks that its signer is a council member.
+func (sb *backend) verifyAnnouncement(payload []byte, now time.Time) (*Announcement, error) {
+	var msg announcementRLP
+	if err := rlp.DecodeBytes(payload, &msg); err != nil {
+		return nil, err
+	}
+	if err := msg.validate(now); err != nil {
+		return nil, err
+	}
+	data, err := msg.sigData()
+	if err != nil {
+		return nil, err
+	}
+	signer, err := istanbul.GetSignatureAddress(data, msg.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if !sb.isCouncilMember(signer) {
+		return nil, errNotCouncilMember
+	}
+	return &Announcement{Kind: msg.Kind, Message: msg.Message, Timestamp: msg.Timestamp, Signer: signer, Signature: msg.Signature, Hash: istanbul.RLPHash(payload)}, nil
+}
+
+// isCouncilMember returns true if the address is a validator or a demoted validator at the current block.
+func (sb *backend) isCouncilMember(addr common.Address) bool {
+	if sb.chain == nil {
+		return false
+	}
+	header := sb.chain.CurrentHeader()
+	valSet := sb.getValidators(header.Number.Uint64(), header.Hash())
+	if valSet == nil {
+		return false
+	}
+	for _, vals := range [][]istanbul.Validator{valSet.List(), valSet.DemotedList()} {
+		for _, val := range vals {
+			if val.Address() == addr {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func signTestAnnouncement(t *testing.T, key *ecdsa.PrivateKey, kind, message string, ts time.Time) []byte {
+	msg := &announcementRLP{Kind: kind, Message: message, Timestamp: uint64(ts.Unix())}
+	data, err := msg.sigData()
+	assert.NoError(t, err)
+	msg.Signature, err = crypto.Sign(crypto.Keccak256(data), key)
+	assert.NoError(t, err)
+	payload, err := rlp.EncodeToBytes(msg)
+	assert.NoError(t, err)
+	return payload
+}
+
+func TestAnnouncement_Validate(t *testing.T) {
+	now := time.Now()
+	testcases := []struct {
+		kind    string
+		message string
+		signed  time.Time
+		err     error
+	}{
+		{AnnouncementNotice, "maintenance", now, nil},
+		{AnnouncementUpgrade, "v1.12.0", now.Add(-maxAnnouncementAge + time.Second), nil},
+		{AnnouncementEmergency, "halt", now.Add(maxAnnouncementSkew), nil},
+		{"gossip", "hello", now, errUnknownAnnouncementKind},
+		{AnnouncementNotice, strings.Repeat("a", maxAnnouncementLength+1), now, errAnnouncementTooLong},
+		{AnnouncementNotice, "stale", now.Add(-maxAnnouncementAge - time.Minute), errAnnouncementExpired},
+		{AnnouncementNotice, "early", now.Add(maxAnnouncementSkew + time.Minute), errAnnouncementFuture},
+	}
+	for _, tc := range testcases {
+		msg := &announcementRLP{Kind: tc.kind, Message: tc.message, Timestamp: uint64(tc.signed.Unix())}
+		assert.Equal(t, tc.err, msg.validate(now), tc.message)
+	}
+}
+
+func TestAnnouncementStore(t *testing.T) {
+	now := time.Now()
+	store := newAnnouncementStore()
+
+	for i := 0; i < inmemoryAnnouncements+1; i++ {
+		a := &Announcement{Timestamp: uint64(now.Unix()) - uint64(inmemoryAnnouncements-i), Hash: common.BigToHash(big.NewInt(int64(i)))}
+		assert.True(t, store.add(a))
+	}
+	// the duplicated one is not added
+	assert.False(t, store.add(&Announcement{Hash: common.BigToHash(big.NewInt(inmemoryAnnouncements))}))
+
+	// the oldest one is evicted, and the latest comes first
+	list := store.list(now)
+	assert.Equal(t, inmemoryAnnouncements, len(list))
+	assert.Equal(t, uint64(now.Unix()), list[0].Timestamp)
+	assert.Equal(t, uint64(now.Unix())-inmemoryAnnouncements+1, list[len(list)-1].Timestamp)
+
+	// the expired ones are not listed
+	list = store.list(now.Add(maxAnnouncementAge).Add(time.Second))
+	assert.Equal(t, 0, len(list))
+}
+
+func TestBackend_VerifyAnnouncement(t *testing.T) {
+	_, engine := newBlockChain(4)
+	defer engine.Stop()
+
+	now := time.Now()
+
+	// signed by a validator
+	payload := signTestAnnouncement(t, nodeKeys[1], AnnouncementUpgrade, "upgrade to v1.12.0 at block 1000", now)
+	a, err := engine.verifyAnnouncement(payload, now)
+	assert.NoError(t, err)
+	assert.Equal(t, addrs[1], a.Signer)
+	assert.Equal(t, AnnouncementUpgrade, a.Kind)
+	assert.Equal(t, "upgrade to v1.12.0 at block 1000", a.Message)
+
+	// signed by a node outside the council
+	key, _ := crypto.GenerateKey()
+	payload = signTestAnnouncement(t, key, AnnouncementNotice, "hello", now)
+	_, err = engine.verifyAnnouncement(payload, now)
+	assert.Equal(t, errNotCouncilMember, err)
+
+	// tampered message
+	var msg announcementRLP
+	assert.NoError(t, rlp.DecodeBytes(signTestAnnouncement(t, nodeKeys[2], AnnouncementNotice, "hello", now), &msg))
+	msg.Message = "goodbye"
+	payload, _ = rlp.EncodeToBytes(&msg)
+	a, err = engine.verifyAnnouncement(payload, now)
+	if err == nil {
+		assert.NotEqual(t, addrs[2], a.Signer)
+	}
+
+	// handled announcements are queryable
+	engine.handleAnnouncement(signTestAnnouncement(t, nodeKeys[3], AnnouncementEmergency, "halt", now))
+	list := engine.Announcements()
+	if assert.Equal(t, 1, len(list)) {
+		assert.Equal(t, addrs[3], list[0].Signer)
+	}
+}
+
+func TestBackend_Announce(t *testing.T) {
+	_, engine := newBlockChain(1)
+	defer engine.Stop()
+
+	a, err := engine.Announce(AnnouncementNotice, "maintenance at 10:00 UTC")
+	assert.NoError(t, err)
+	assert.Equal(t, engine.address, a.Signer)
+
+	_, err = engine.Announce("gossip", "hello")
+	assert.Equal(t, errUnknownAnnouncementKind, err)
+
+	list := engine.Announcements()
+	if assert.Equal(t, 1, len(list)) {
+		assert.Equal(t, a.Hash, list[0].Hash)
+	}
+}
@@ -21,7 +21,6 @@
 package backend
 
 import (
-	"errors"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -30,9 +29,12 @@ import (
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus"
 	"github.com/klaytn/klaytn/consensus/istanbul"
+	istanbulCore "github.com/klaytn/klaytn/consensus/istanbul/core"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/reward"
 )
 
 // API is a user facing RPC API to dump Istanbul state
@@ -56,7 +58,7 @@ func (api *API) GetSnapshot(number *rpc.BlockNumber) (*Snapshot, error) {
 func (api *API) GetSnapshotAtHash(hash common.Hash) (*Snapshot, error) {
 	header := api.chain.GetHeaderByHash(hash)
 	if header == nil {
-		return nil, errUnknownBlock
+		return nil, errBlockNotFound.WithDetail("blockHash", hash)
 	}
 	return api.istanbul.snapshot(api.chain, header.Number.Uint64(), header.Hash(), nil, false)
 }
@@ -90,7 +92,7 @@ func (api *API) GetValidators(number *rpc.BlockNumber) ([]common.Address, error)
 func (api *API) GetValidatorsAtHash(hash common.Hash) ([]common.Address, error) {
 	header := api.chain.GetHeaderByHash(hash)
 	if header == nil {
-		return nil, errUnknownBlock
+		return nil, errBlockNotFound.WithDetail("blockHash", hash)
 	}
 
 	blockNumber := header.Number.Uint64()
@@ -140,7 +142,7 @@ func (api *API) GetDemoteValidators(number *rpc.BlockNumber) ([]common.Address,
 func (api *API) GetDemotedValidatorsAtHash(hash common.Hash) ([]common.Address, error) {
 	header := api.chain.GetHeaderByHash(hash)
 	if header == nil {
-		return nil, errUnknownBlock
+		return nil, errBlockNotFound.WithDetail("blockHash", hash)
 	}
 
 	blockNumber := header.Number.Uint64()
@@ -191,25 +193,103 @@ func (api *API) Discard(address common.Address) {
 	delete(api.istanbul.candidates, address)
 }
 
+// GetAnnouncements returns the announcements of the council members received or sent by
+// the node within the last week, the latest first.
+func (api *API) GetAnnouncements() []*Announcement {
+	return api.istanbul.Announcements()
+}
+
+// Announce broadcasts an operational notice signed by the node key to the consensus nodes.
+// The kind is one of notice, upgrade and emergency. The node should be a council member.
+func (api *API) Announce(kind, message string) (*Announcement, error) {
+	return api.istanbul.Announce(kind, message)
+}
+
+// GetStallStatus returns the state of the watchdog raising the log levels when no block has
+// been sealed for the stall timeout, with the consensus messages traced during the last stall.
+func (api *API) GetStallStatus() *StallStatus {
+	return api.istanbul.StallStatus()
+}
+
+// GetStandbyStatus returns whether the standby node is passive or has taken over from the
+// primary, with the latest heartbeat and view of the primary.
+func (api *API) GetStandbyStatus() *StandbyStatus {
+	return api.istanbul.StandbyStatus()
+}
+
 // API extended by Klaytn developers
 type APIExtension struct {
 	chain    consensus.ChainReader
 	istanbul *backend
 }
 
+// The errors of the istanbul APIs. Their codes and reasons are stable, see rpc.APIError.
 var (
-	errPendingNotAllowed       = errors.New("pending is not allowed")
-	errInternalError           = errors.New("internal error")
-	errStartNotPositive        = errors.New("start block number should be positive")
-	errEndLargetThanLatest     = errors.New("end block number should be smaller than the latest block number")
-	errStartLargerThanEnd      = errors.New("start should be smaller than end")
-	errRequestedBlocksTooLarge = errors.New("number of requested blocks should be smaller than 50")
-	errRangeNil                = errors.New("range values should not be nil")
-	errExtractIstanbulExtra    = errors.New("extract Istanbul Extra from block header of the given block number")
-	errNoBlockExist            = errors.New("block with the given block number is not existed")
-	errNoBlockNumber           = errors.New("block number is not assigned")
+	errPendingNotAllowed       = rpc.NewAPIError(rpc.CodeInvalidInput, "pendingNotAllowed", "pending is not allowed")
+	errInternalError           = rpc.NewAPIError(rpc.CodeInternalError, "internalError", "internal error")
+	errStartNotPositive        = rpc.NewAPIError(rpc.CodeInvalidInput, "invalidBlockRange", "start block number should be positive")
+	errEndLargetThanLatest     = rpc.NewAPIError(rpc.CodeInvalidInput, "invalidBlockRange", "end block number should be smaller than the latest block number")
+	errStartLargerThanEnd      = rpc.NewAPIError(rpc.CodeInvalidInput, "invalidBlockRange", "start should be smaller than end")
+	errRequestedBlocksTooLarge = rpc.NewAPIError(rpc.CodeLimitExceeded, "blockRangeTooLarge", "number of requested blocks should be smaller than 50")
+	errRangeNil                = rpc.NewAPIError(rpc.CodeInvalidParams, "missingBlockRange", "range values should not be nil")
+	errExtractIstanbulExtra    = rpc.NewAPIError(rpc.CodeInternalError, "invalidIstanbulExtra", "extract Istanbul Extra from block header of the given block number")
+	errNoBlockExist            = rpc.NewAPIError(rpc.CodeResourceNotFound, "blockNotFound", "block with the given block number is not existed")
+	errNoBlockNumber           = rpc.NewAPIError(rpc.CodeInvalidParams, "missingBlockNumber", "block number is not assigned")
+	errBlockNotFound           = rpc.NewAPIError(rpc.CodeResourceNotFound, "blockNotFound", "unknown block")
+	errGenesisNotAllowed       = rpc.NewAPIError(rpc.CodeInvalidInput, "genesisNotAllowed", "the genesis block has no proposer selection")
+	errNoStakingInfo           = rpc.NewAPIError(rpc.CodeResourceNotFound, "stakingInfoNotFound", "staking information is not available")
+	errPageSizeOutOfRange      = rpc.NewAPIError(rpc.CodeInvalidParams, "invalidPageSize",
+		fmt.Sprintf("page size should be between 1 and %d", maxConsensusInfoPageSize)).WithDetail("maxPageSize", maxConsensusInfoPageSize)
 )
 
+const (
+	// maxConsensusInfoPageSize is the maximum number of blocks returned by
+	// GetBlockWithConsensusInfoByNumberPage at once.
+	maxConsensusInfoPageSize = 50
+)
+
+// committeeSeal is the committed seal status of a committee member of a block.
+type committeeSeal struct {
+	Address common.Address `json:"address"`
+	Signed  bool           `json:"signed"`
+}
+
+// committedSealsResult is the verification result of the committed seals of a block.
+type committedSealsResult struct {
+	Committee      []committeeSeal  `json:"committee"`      // whether each committee member sealed the block
+	OutsiderSeals  []common.Address `json:"outsiderSeals"`  // signers who are not the committee members
+	DuplicateSeals []common.Address `json:"duplicateSeals"` // signers who sealed the block more than once
+	InvalidSeals   int              `json:"invalidSeals"`   // the number of seals whose signer cannot be recovered
+	ValidSeals     int              `json:"validSeals"`
+	Quorum         int              `json:"quorum"`
+	Valid          bool             `json:"valid"`
+}
+
+// councilMember is how a council member is weighed in the proposer selection and the staking reward.
+type councilMember struct {
+	Address           common.Address `json:"address"`
+	RewardAddress     common.Address `json:"rewardAddress"`
+	Demoted           bool           `json:"demoted"`           // whether the member is demoted by the minimum stake
+	Weight            uint64         `json:"weight"`            // the proposer-selection weight, zero after the Kore hardfork
+	ProposerSlots     int            `json:"proposerSlots"`     // the number of slots in the proposer candidates, zero if not weighted random
+	Stake             *big.Int       `json:"stake"`             // the stake consolidated by the reward address
+	EffectiveStake    *big.Int       `json:"effectiveStake"`    // the stake above the minimum stake
+	AboveMinimumStake bool           `json:"aboveMinimumStake"` // whether the member is eligible for the staking reward
+}
+
+// councilDetails is the council members of a block with their weights. The stakes are in the
+// stake unit, KLAY or peb after the PebStaking hardfork.
+type councilDetails struct {
+	BlockNumber       uint64           `json:"blockNumber"`
+	ProposerPolicy    uint64           `json:"proposerPolicy"`
+	ProposerSlots     int              `json:"proposerSlots"` // the total number of slots in the proposer candidates
+	StakingInfoBlock  uint64           `json:"stakingInfoBlock"`
+	StakeUnit         string           `json:"stakeUnit"`
+	MinimumStake      *big.Int         `json:"minimumStake"`
+	MinStakeInclusive bool             `json:"minStakeInclusive"`
+	Members           []*councilMember `json:"members"`
+}
+
 // GetCouncil retrieves the list of authorized validators at the specified block.
 func (api *APIExtension) GetCouncil(number *rpc.BlockNumber) ([]common.Address, error) {
 	header, err := headerByRpcNumber(api.chain, number)
@@ -265,6 +345,21 @@ func (api *APIExtension) GetCommittee(number *rpc.BlockNumber) ([]common.Address
 	if err != nil {
 		return nil, err
 	}
+	committee, err := committeeOf(header, snap)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]common.Address, len(committee))
+	for i, v := range committee {
+		addresses[i] = v.Address()
+	}
+
+	return addresses, nil
+}
+
+// committeeOf returns the committee of the block from the snapshot of its parent.
+func committeeOf(header *types.Header, snap *Snapshot) ([]istanbul.Validator, error) {
+	blockNumber := header.Number.Uint64()
 	round := header.Round()
 	view := &istanbul.View{
 		Sequence: new(big.Int).SetUint64(blockNumber),
@@ -280,13 +375,118 @@ func (api *APIExtension) GetCommittee(number *rpc.BlockNumber) ([]common.Address
 	parentHash := header.ParentHash
 
 	// get the committee list of this block at the view (blockNumber, round)
-	committee := snap.ValSet.SubListWithProposer(parentHash, proposer, view)
-	addresses := make([]common.Address, len(committee))
-	for i, v := range committee {
-		addresses[i] = v.Address()
+	return snap.ValSet.SubListWithProposer(parentHash, proposer, view), nil
+}
+
+// GetCouncilDetails returns the council members of the specified block with their proposer-selection
+// weights and effective stakes, calculated the same way as the proposer selection and the reward
+// distribution of the block.
+func (api *APIExtension) GetCouncilDetails(number *rpc.BlockNumber) (*councilDetails, error) {
+	header, snap, err := api.parentSnapshot(number)
+	if err != nil {
+		return nil, err
 	}
+	members := append(snap.ValSet.List(), snap.ValSet.DemotedList()...)
+	return api.councilDetails(header, snap, members)
+}
 
-	return addresses, nil
+// GetCommitteeDetails returns the committee members of the specified block with their
+// proposer-selection weights and effective stakes. See GetCouncilDetails.
+func (api *APIExtension) GetCommitteeDetails(number *rpc.BlockNumber) (*councilDetails, error) {
+	header, snap, err := api.parentSnapshot(number)
+	if err != nil {
+		return nil, err
+	}
+	committee, err := committeeOf(header, snap)
+	if err != nil {
+		return nil, err
+	}
+	return api.councilDetails(header, snap, committee)
+}
+
+// parentSnapshot returns the header of the specified block and the snapshot its validators are from.
+func (api *APIExtension) parentSnapshot(number *rpc.BlockNumber) (*types.Header, *Snapshot, error) {
+	header, err := headerByRpcNumber(api.chain, number)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.Number.Sign() == 0 {
+		return nil, nil, errGenesisNotAllowed
+	}
+	snap, err := api.istanbul.snapshot(api.chain, header.Number.Uint64()-1, header.ParentHash, nil, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, snap, nil
+}
+
+// councilDetails weighs the members of the block: the weights and the proposer candidates are those
+// of the validator set of the snapshot, and the effective stakes are by reward.CalcEffectiveStakes
+// with the staking information and the parameters the rewards of the block are distributed with.
+func (api *APIExtension) councilDetails(header *types.Header, snap *Snapshot, members []istanbul.Validator) (*councilDetails, error) {
+	blockNumber := header.Number.Uint64()
+	pset, err := api.istanbul.governance.EffectiveParams(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	stakingInfo := reward.GetStakingInfo(blockNumber)
+	if stakingInfo == nil {
+		return nil, errNoStakingInfo.WithDetail("blockNumber", blockNumber)
+	}
+	rules := api.chain.Config().Rules(header.Number)
+	stakes := reward.CalcEffectiveStakes(stakingInfo, pset.MinimumStakeBig().Uint64(), reward.GetMinStakeInclusive(rules, pset))
+
+	slots := make(map[common.Address]int)
+	proposers := 0
+	if snap.ValSet.Policy() == istanbul.WeightedRandom {
+		for _, proposer := range snap.ValSet.Proposers() {
+			slots[proposer.Address()]++
+			proposers++
+		}
+	}
+
+	details := &councilDetails{
+		BlockNumber:       blockNumber,
+		ProposerPolicy:    uint64(snap.ValSet.Policy()),
+		ProposerSlots:     proposers,
+		StakingInfoBlock:  stakes.StakingInfoBlock,
+		StakeUnit:         stakes.StakeUnit,
+		MinimumStake:      stakes.MinimumStake,
+		MinStakeInclusive: stakes.MinStakeInclusive,
+		Members:           make([]*councilMember, 0, len(members)),
+	}
+	for _, val := range members {
+		demotedIdx, _ := snap.ValSet.GetDemotedByAddress(val.Address())
+		member := &councilMember{
+			Address:        val.Address(),
+			RewardAddress:  val.RewardAddress(),
+			Demoted:        demotedIdx >= 0,
+			Weight:         val.Weight(),
+			ProposerSlots:  slots[val.Address()],
+			Stake:          big.NewInt(0),
+			EffectiveStake: big.NewInt(0),
+		}
+		if node := nodeEffectiveStake(stakes, val.Address()); node != nil {
+			member.RewardAddress = node.RewardAddr
+			member.Stake = node.Stake
+			member.EffectiveStake = node.EffectiveStake
+			member.AboveMinimumStake = node.Eligible
+		}
+		details.Members = append(details.Members, member)
+	}
+	return details, nil
+}
+
+// nodeEffectiveStake returns the effective stake of the consolidated CN the node belongs to, nil if none.
+func nodeEffectiveStake(stakes *reward.EffectiveStakes, nodeAddr common.Address) *reward.NodeEffectiveStake {
+	for _, node := range stakes.Nodes {
+		for _, addr := range node.NodeAddrs {
+			if addr == nodeAddr {
+				return node
+			}
+		}
+	}
+	return nil
 }
 
 func (api *APIExtension) GetCommitteeSize(number *rpc.BlockNumber) (int, error) {
@@ -325,11 +525,70 @@ func (api *APIExtension) makeRPCBlockOutput(b *types.Block,
 	r["proposer"] = cInfo.Proposer
 	r["round"] = cInfo.Round
 	r["originProposer"] = cInfo.OriginProposer
+	r["roundChanged"] = cInfo.Round > 0
 	r["transactions"] = rpcTransactions
 
+	if b.NumberU64() > 0 {
+		seals, err := api.verifyCommittedSeals(head, cInfo.Committee)
+		if err != nil {
+			logger.Error("failed to verify committed seals", "blockNum", b.NumberU64(), "err", err)
+		}
+		r["committedSeals"] = seals
+	}
+
 	return r
 }
 
+// verifyCommittedSeals checks the committed seals of the given header one by one and reports
+// which committee members have sealed the block. Unlike the header verification, it does not
+// stop at the first invalid seal so that every seal is accounted for.
+func (api *APIExtension) verifyCommittedSeals(header *types.Header, committee []common.Address) (*committedSealsResult, error) {
+	snap, err := api.istanbul.snapshot(api.chain, header.Number.Uint64()-1, header.ParentHash, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		return nil, errExtractIstanbulExtra
+	}
+
+	result := &committedSealsResult{
+		Committee:      make([]committeeSeal, len(committee)),
+		OutsiderSeals:  []common.Address{},
+		DuplicateSeals: []common.Address{},
+		// The number of valid seals should be larger than 2F, the same as the header verification.
+		Quorum: 2*snap.ValSet.F() + 1,
+	}
+	index := make(map[common.Address]int, len(committee))
+	for i, addr := range committee {
+		result.Committee[i].Address = addr
+		index[addr] = i
+	}
+
+	proposalSeal := istanbulCore.PrepareCommittedSeal(header.Hash())
+	for _, seal := range extra.CommittedSeal {
+		addr, err := cacheSignatureAddresses(proposalSeal, seal)
+		if err != nil {
+			result.InvalidSeals++
+			continue
+		}
+		i, ok := index[addr]
+		switch {
+		case !ok:
+			result.OutsiderSeals = append(result.OutsiderSeals, addr)
+		case result.Committee[i].Signed:
+			result.DuplicateSeals = append(result.DuplicateSeals, addr)
+		default:
+			result.Committee[i].Signed = true
+			result.ValidSeals++
+		}
+	}
+
+	result.Valid = result.InvalidSeals == 0 && len(result.OutsiderSeals) == 0 &&
+		len(result.DuplicateSeals) == 0 && result.ValidSeals >= result.Quorum
+	return result, nil
+}
+
 // TODO-Klaytn: This API functions should be managed with API functions with namespace "klay"
 func (api *APIExtension) GetBlockWithConsensusInfoByNumber(number *rpc.BlockNumber) (map[string]interface{}, error) {
 	b, ok := api.chain.(*blockchain.BlockChain)
@@ -361,7 +620,7 @@ func (api *APIExtension) GetBlockWithConsensusInfoByNumber(number *rpc.BlockNumb
 
 	if block == nil {
 		logger.Trace("Finding a block by number failed.", "blockNum", blockNumber)
-		return nil, fmt.Errorf("the block does not exist (block number: %d)", blockNumber)
+		return nil, errBlockNotFound.Errorf("the block does not exist (block number: %d)", blockNumber).WithDetail("blockNumber", blockNumber)
 	}
 	blockHash := block.Hash()
 
@@ -428,6 +687,62 @@ func (api *APIExtension) GetBlockWithConsensusInfoByNumberRange(start *rpc.Block
 	return blocks, nil
 }
 
+// GetBlockWithConsensusInfoByNumberPage returns at most size blocks with consensus information
+// in ascending order from the start block, along with the block number to request the next page.
+// The next block number is nil if the page reaches the latest block.
+func (api *APIExtension) GetBlockWithConsensusInfoByNumberPage(start *rpc.BlockNumber, size *hexutil.Uint) (map[string]interface{}, error) {
+	if start == nil {
+		logger.Trace("the start value should not be nil.")
+		return nil, errRangeNil
+	}
+	s := start.Int64()
+	if s < 0 {
+		logger.Trace("start should be positive", "start", s)
+		return nil, errStartNotPositive
+	}
+
+	pageSize := uint64(maxConsensusInfoPageSize)
+	if size != nil {
+		pageSize = uint64(*size)
+	}
+	if pageSize == 0 || pageSize > maxConsensusInfoPageSize {
+		return nil, errPageSizeOutOfRange
+	}
+
+	latest := api.chain.CurrentHeader().Number.Uint64()
+	if uint64(s) > latest {
+		logger.Trace("start should be smaller than the lastest block number", "start", s, "latest", latest)
+		return nil, errEndLargetThanLatest
+	}
+
+	end := uint64(s) + pageSize - 1
+	if end > latest {
+		end = latest
+	}
+
+	blocks := make([]map[string]interface{}, 0, end-uint64(s)+1)
+	for i := uint64(s); i <= end; i++ {
+		blockNum := rpc.BlockNumber(i)
+		b, err := api.GetBlockWithConsensusInfoByNumber(&blockNum)
+		if err != nil {
+			logger.Error("error on GetBlockWithConsensusInfoByNumber", "err", err)
+			b = nil
+		}
+		blocks = append(blocks, b)
+	}
+
+	var next *hexutil.Uint64
+	if end < latest {
+		n := hexutil.Uint64(end + 1)
+		next = &n
+	}
+
+	return map[string]interface{}{
+		"blocks": blocks,
+		"next":   next,
+	}, nil
+}
+
 func (api *APIExtension) GetBlockWithConsensusInfoByHash(blockHash common.Hash) (map[string]interface{}, error) {
 	b, ok := api.chain.(*blockchain.BlockChain)
 	if !ok {
@@ -438,7 +753,7 @@ func (api *APIExtension) GetBlockWithConsensusInfoByHash(blockHash common.Hash)
 	block := b.GetBlockByHash(blockHash)
 	if block == nil {
 		logger.Trace("Finding a block failed.", "blockHash", blockHash)
-		return nil, fmt.Errorf("the block does not exist (block hash: %s)", blockHash.String())
+		return nil, errBlockNotFound.Errorf("the block does not exist (block hash: %s)", blockHash.String()).WithDetail("blockHash", blockHash)
 	}
 
 	cInfo, err := api.istanbul.GetConsensusInfo(block)
@@ -472,7 +787,7 @@ func headerByRpcNumber(chain consensus.ChainReader, number *rpc.BlockNumber) (*t
 	}
 	// Ensure we have an actually valid block and return its snapshot
 	if header == nil {
-		return nil, errUnknownBlock
+		return nil, errBlockNotFound.WithDetail("blockNumber", number.Int64())
 	}
 	return header, nil
 }
@@ -0,0 +1,121 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIExtension_VerifyCommittedSeals(t *testing.T) {
+	chain, engine := newBlockChain(4)
+	defer engine.Stop()
+
+	api := &APIExtension{chain: chain, istanbul: engine}
+	block := makeBlockWithSeal(chain, engine, chain.Genesis())
+
+	// every validator has sealed the block
+	result, err := api.verifyCommittedSeals(block.Header(), addrs)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, len(addrs), result.ValidSeals)
+	for _, seal := range result.Committee {
+		assert.True(t, seal.Signed)
+	}
+
+	// a seal of a validator who is not in the committee
+	committee := append([]common.Address{common.HexToAddress("0x1")}, addrs[1:]...)
+	result, err = api.verifyCommittedSeals(block.Header(), committee)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.False(t, result.Committee[0].Signed)
+	assert.Equal(t, []common.Address{addrs[0]}, result.OutsiderSeals)
+	assert.Equal(t, len(addrs)-1, result.ValidSeals)
+}
+
+func TestAPIExtension_GetCouncilDetails(t *testing.T) {
+	configItems := makeSnapshotTestConfigItems()
+	configItems = append(configItems, minimumStake(big.NewInt(5500000)), istanbulCompatibleBlock(big.NewInt(0)))
+	chain, engine := newBlockChain(4, configItems...)
+	defer engine.Stop()
+
+	oldStakingManager := reward.GetStakingManager()
+	defer reward.SetTestStakingManager(oldStakingManager)
+	stakingInfo := makeFakeStakingInfo(0, nodeKeys, []uint64{5000000, 5500000, 6000000, 7000000})
+	reward.SetTestStakingManagerWithStakingInfoCache(stakingInfo)
+
+	block := makeBlockWithSeal(chain, engine, chain.Genesis())
+	_, err := chain.InsertChain(types.Blocks{block})
+	require.NoError(t, err)
+
+	// the members of the next block are of the snapshot of the block
+	api := &APIExtension{chain: chain, istanbul: engine}
+	snap, err := engine.snapshot(chain, block.NumberU64(), block.Hash(), nil, true)
+	require.NoError(t, err)
+	header := &types.Header{Number: new(big.Int).Add(block.Number(), common.Big1)}
+	details, err := api.councilDetails(header, snap, append(snap.ValSet.List(), snap.ValSet.DemotedList()...))
+	require.NoError(t, err)
+	assert.Equal(t, header.Number.Uint64(), details.BlockNumber)
+	assert.Equal(t, "KLAY", details.StakeUnit)
+	assert.Equal(t, big.NewInt(5500000), details.MinimumStake)
+	require.Len(t, details.Members, 4)
+
+	testcases := []struct {
+		demoted           bool
+		effectiveStake    int64
+		aboveMinimumStake bool
+	}{
+		{true, 0, false},
+		{false, 0, false}, // a validator at the minimum stake has no staking reward
+		{false, 500000, true},
+		{false, 1500000, true},
+	}
+	slots := 0
+	for i, tc := range testcases {
+		var member *councilMember
+		for _, m := range details.Members {
+			if m.Address == addrs[i] {
+				member = m
+			}
+		}
+		require.NotNil(t, member, "member %d", i)
+		assert.Equal(t, stakingInfo.CouncilRewardAddrs[i], member.RewardAddress, "member %d", i)
+		assert.Equal(t, tc.demoted, member.Demoted, "member %d", i)
+		assert.Equal(t, new(big.Int).SetUint64(stakingInfo.CouncilStakingAmounts[i]), member.Stake, "member %d", i)
+		assert.Equal(t, tc.effectiveStake, member.EffectiveStake.Int64(), "member %d", i)
+		assert.Equal(t, tc.aboveMinimumStake, member.AboveMinimumStake, "member %d", i)
+		if tc.demoted {
+			assert.Zero(t, member.ProposerSlots, "member %d", i)
+		} else {
+			assert.NotZero(t, member.Weight, "member %d", i)
+			assert.Equal(t, int(member.Weight), member.ProposerSlots, "member %d", i)
+		}
+		slots += member.ProposerSlots
+	}
+	assert.Equal(t, details.ProposerSlots, slots)
+
+	genesis := rpc.BlockNumber(0)
+	_, err = api.GetCouncilDetails(&genesis)
+	assert.Equal(t, errGenesisNotAllowed, err)
+}
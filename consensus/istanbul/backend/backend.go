@@ -54,6 +54,7 @@ func New(rewardbase common.Address, config *istanbul.Config, privateKey *ecdsa.P
 	recents, _ := lru.NewARC(inmemorySnapshots)
 	recentMessages, _ := lru.NewARC(inmemoryPeers)
 	knownMessages, _ := lru.NewARC(inmemoryMessages)
+	checkpointed, _ := lru.NewARC(inmemoryCheckpointed)
 	backend := &backend{
 		config:            config,
 		istanbulEventMux:  new(event.TypeMux),
@@ -67,6 +68,8 @@ func New(rewardbase common.Address, config *istanbul.Config, privateKey *ecdsa.P
 		coreStarted:       false,
 		recentMessages:    recentMessages,
 		knownMessages:     knownMessages,
+		checkpointed:      checkpointed,
+		announcements:     newAnnouncementStore(),
 		rewardbase:        rewardbase,
 		governance:        governance,
 		nodetype:          nodetype,
@@ -110,6 +113,8 @@ type backend struct {
 
 	recentMessages *lru.ARCCache // the cache of peer's messages
 	knownMessages  *lru.ARCCache // the cache of self messages
+	checkpointed   *lru.ARCCache // the hashes of the headers authenticated by the checkpoints
+	announcements  *announcementStore
 
 	rewardbase  common.Address
 	currentView atomic.Value //*istanbul.View
@@ -121,6 +126,14 @@ type backend struct {
 
 	rewardDistributor *reward.RewardDistributor
 
+	// the watchdog escalating the log levels on a consensus stall
+	stall   *stallWatchdog
+	stallMu sync.RWMutex
+
+	// the monitor keeping a standby node passive while the primary is alive
+	standby   *standbyMonitor
+	standbyMu sync.RWMutex
+
 	// Node type
 	nodetype common.ConnType
 }
@@ -133,6 +146,16 @@ func (sb *backend) GetRewardBase() common.Address {
 	return sb.rewardbase
 }
 
+// BurnRatio implements consensus.BurnRatioReader. It returns the burn ratio of the
+// governance parameters in effect at the block.
+func (sb *backend) BurnRatio(num *big.Int) (uint64, error) {
+	pset, err := sb.governance.EffectiveParams(num.Uint64())
+	if err != nil {
+		return 0, err
+	}
+	return reward.GetBurnRatio(pset), nil
+}
+
 func (sb *backend) SetCurrentView(view *istanbul.View) {
 	sb.currentView.Store(view)
 }
@@ -147,12 +170,18 @@ func (sb *backend) Validators(proposal istanbul.Proposal) istanbul.ValidatorSet
 	return sb.getValidators(proposal.Number().Uint64(), proposal.Hash())
 }
 
+// ValidatorCount returns the number of the validators of the block after the parent.
+func (sb *backend) ValidatorCount(parent *types.Header) uint64 {
+	return sb.getValidators(parent.Number.Uint64(), parent.Hash()).Size()
+}
+
 // Broadcast implements istanbul.Backend.Broadcast
 func (sb *backend) Broadcast(prevHash common.Hash, valSet istanbul.ValidatorSet, payload []byte) error {
 	// send to others
 	// TODO Check gossip again in event handle
 	// sb.Gossip(valSet, payload)
 	// send to self
+	sb.traceStall(sb.address, payload)
 	msg := istanbul.MessageEvent{
 		Hash:    prevHash,
 		Payload: payload,
@@ -163,6 +192,12 @@ func (sb *backend) Broadcast(prevHash common.Hash, valSet istanbul.ValidatorSet,
 
 // Broadcast implements istanbul.Backend.Gossip
 func (sb *backend) Gossip(valSet istanbul.ValidatorSet, payload []byte) error {
+	sb.gossip(common.Hash{}, payload)
+	return nil
+}
+
+// gossip sends the payload with the prevHash to all the consensus node peers which have not seen it.
+func (sb *backend) gossip(prevHash common.Hash, payload []byte) {
 	hash := istanbul.RLPHash(payload)
 	sb.knownMessages.Add(hash, true)
 
@@ -185,7 +220,7 @@ func (sb *backend) Gossip(valSet istanbul.ValidatorSet, payload []byte) error {
 			sb.recentMessages.Add(addr, m)
 
 			cmsg := &istanbul.ConsensusMsg{
-				PrevHash: common.Hash{},
+				PrevHash: prevHash,
 				Payload:  payload,
 			}
 
@@ -193,7 +228,6 @@ func (sb *backend) Gossip(valSet istanbul.ValidatorSet, payload []byte) error {
 			go p.Send(IstanbulMsg, cmsg)
 		}
 	}
-	return nil
 }
 
 // checkInSubList checks if the node is in a sublist
@@ -345,6 +379,9 @@ func (sb *backend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
 
 // Sign implements istanbul.Backend.Sign
 func (sb *backend) Sign(data []byte) ([]byte, error) {
+	if err := sb.checkStandby(); err != nil {
+		return nil, err
+	}
 	hashData := crypto.Keccak256([]byte(data))
 	return crypto.Sign(hashData, sb.privateKey)
 }
@@ -0,0 +1,122 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/rcrowley/go-metrics"
+)
+
+// inmemoryCheckpointed is the number of the headers authenticated by the checkpoints
+// kept in memory until they are verified.
+const inmemoryCheckpointed = 8192
+
+var (
+	checkpointVerifiedCounter = metrics.NewRegisteredCounter("consensus/istanbul/checkpoint/verified", nil)
+	checkpointFailedCounter   = metrics.NewRegisteredCounter("consensus/istanbul/checkpoint/failed", nil)
+)
+
+// VerifyCheckpoints implements consensus.CheckpointVerifier. The headers whose numbers are
+// multiples of the checkpoint interval are the checkpoints of the batch. A checkpoint is
+// verified if its committed seals are signed by a quorum of the council at its parent,
+// built by applying the headers in the range to the council trusted at the start of the
+// range, i.e. at the parent of the batch or at the previous checkpoint. Since the
+// checkpoint hash commits to all its ancestors, the headers in the range are authenticated
+// by checking that they are linked to the checkpoint, and the signatures of the proposers
+// and the committees of them are not verified.
+//
+// Since the votes in the range are not authenticated yet, the council built by them is
+// trusted only if it is the same as the trusted one. If the council changes within a
+// range, the checkpoint is ignored and the headers in the range are verified as usual.
+func (sb *backend) VerifyCheckpoints(chain consensus.ChainReader, headers []*types.Header) {
+	interval := sb.config.CheckpointInterval
+	if interval == 0 || len(headers) < 2 {
+		return
+	}
+	first := headers[0].Number.Uint64()
+	if first == 0 {
+		return
+	}
+
+	start := 0 // headers[start:] are not authenticated yet
+	trustedNum, trustedHash := first-1, headers[0].ParentHash
+	for i := 1; i < len(headers); i++ {
+		if headers[i].ParentHash != headers[i-1].Hash() {
+			return // not a chain; the headers are rejected by the usual verification
+		}
+		number := headers[i].Number.Uint64()
+		if number%interval != 0 {
+			continue
+		}
+
+		trusted, err := sb.snapshot(chain, trustedNum, trustedHash, headers[:start], true)
+		if err != nil {
+			return
+		}
+		snap, err := sb.snapshot(chain, number-1, headers[i-1].Hash(), headers[:i], true)
+		if err != nil {
+			return
+		}
+		if !sameCouncil(trusted.ValSet, snap.ValSet) {
+			logger.Debug("The council is changed within a checkpoint range, verifying the headers one by one",
+				"number", number, "trustedNumber", trustedNum)
+			return
+		}
+		if err := verifyCommittedSealsWithValSet(headers[i], snap.ValSet); err != nil {
+			checkpointFailedCounter.Inc(1)
+			logger.Debug("Failed to verify a checkpoint, verifying the headers one by one",
+				"number", number, "trustedNumber", trustedNum, "err", err)
+			return
+		}
+		checkpointVerifiedCounter.Inc(1)
+		for _, header := range headers[start:i] {
+			sb.checkpointed.Add(header.Hash(), true)
+		}
+		logger.Trace("Verified a checkpoint", "number", number, "hash", headers[i].Hash(), "headers", i-start)
+
+		// the checkpoint itself is verified as usual
+		start, trustedNum, trustedHash = i+1, number, headers[i].Hash()
+	}
+}
+
+// sameCouncil returns true if the validator sets have the same validators and demoted validators.
+func sameCouncil(a, b istanbul.ValidatorSet) bool {
+	sameAddrs := func(x, y []istanbul.Validator) bool {
+		if len(x) != len(y) {
+			return false
+		}
+		addrs := make(map[common.Address]bool, len(x))
+		for _, val := range x {
+			addrs[val.Address()] = true
+		}
+		for _, val := range y {
+			if !addrs[val.Address()] {
+				return false
+			}
+		}
+		return true
+	}
+	return sameAddrs(a.List(), b.List()) && sameAddrs(a.DemotedList(), b.DemotedList())
+}
+
+// isCheckpointed returns true if the header is authenticated by a verified checkpoint.
+func (sb *backend) isCheckpointed(hash common.Hash) bool {
+	return sb.checkpointed != nil && sb.checkpointed.Contains(hash)
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCheckpoints(t *testing.T) {
+	chain, engine := newBlockChain(1)
+	defer engine.Stop()
+	engine.config.CheckpointInterval = 4
+
+	// only the checkpoints, the blocks 4 and 8, have the committed seals
+	var (
+		parent  = chain.Genesis()
+		headers []*types.Header
+	)
+	for i := 1; i <= 10; i++ {
+		var block *types.Block
+		if i%4 == 0 {
+			block = makeBlockWithSeal(chain, engine, parent)
+		} else {
+			block = makeBlockWithoutSeal(chain, engine, parent)
+			block, _ = engine.updateBlock(block)
+		}
+		engine.db.WriteHeader(block.Header())
+		headers = append(headers, block.Header())
+		parent = block
+	}
+	now = func() time.Time { return time.Unix(headers[len(headers)-1].Time.Int64(), 0) }
+	defer func() { now = time.Now }()
+
+	assert.Equal(t, errEmptyCommittedSeals, engine.verifyHeader(chain, headers[1], headers[:1]))
+
+	// a tampered checkpoint authenticates nothing
+	tampered := append([]*types.Header{}, headers...)
+	tampered[3] = types.CopyHeader(headers[3])
+	writeCommittedSeals(tampered[3], [][]byte{make([]byte, types.IstanbulExtraSeal)})
+	engine.VerifyCheckpoints(chain, tampered)
+	for _, header := range headers {
+		assert.False(t, engine.isCheckpointed(header.Hash()), header.Number)
+	}
+
+	engine.VerifyCheckpoints(chain, headers)
+	for i, header := range headers {
+		number := header.Number.Uint64()
+		checkpointed := number%4 != 0 && number < 8
+		assert.Equal(t, checkpointed, engine.isCheckpointed(header.Hash()), number)
+
+		err := engine.verifyHeader(chain, header, headers[:i])
+		if checkpointed || number%4 == 0 {
+			assert.NoError(t, err, number)
+		} else {
+			assert.Equal(t, errEmptyCommittedSeals, err, number)
+		}
+	}
+}
+
+func TestVerifyCheckpoints_CouncilChanged(t *testing.T) {
+	// the block period is set to istanbul.DefaultConfig shared with the other tests
+	defer func(period uint64) { istanbul.DefaultConfig.BlockPeriod = period }(istanbul.DefaultConfig.BlockPeriod)
+
+	var configItems []interface{}
+	configItems = append(configItems, proposerPolicy(params.WeightedRandom))
+	configItems = append(configItems, proposerUpdateInterval(1))
+	configItems = append(configItems, epoch(3))
+	configItems = append(configItems, subGroupSize(4))
+	configItems = append(configItems, governanceMode("single"))
+	configItems = append(configItems, minimumStake(new(big.Int).SetUint64(4000000)))
+	configItems = append(configItems, istanbulCompatibleBlock(new(big.Int).SetUint64(0)))
+	configItems = append(configItems, blockPeriod(0)) // set block period to 0 to prevent creating future block
+	chain, engine := newBlockChain(4, configItems...)
+	defer engine.Stop()
+	engine.config.CheckpointInterval = 4
+
+	oldStakingManager := reward.GetStakingManager()
+	defer reward.SetTestStakingManager(oldStakingManager)
+	reward.SetTestStakingManagerWithStakingInfoCache(makeFakeStakingInfo(0, nodeKeys, []uint64{4000000, 4000000, 4000000, 4000000}))
+
+	// the block 2 removes a validator, so the checkpoint 4 is sealed by the rest of the council
+	var (
+		removed = addrs[3]
+		parent  = chain.Genesis()
+		headers []*types.Header
+	)
+	for i := 1; i <= 4; i++ {
+		if i == 2 {
+			engine.governance.AddVote("governance.removevalidator", removed)
+		}
+		block := makeBlockWithSeal(chain, engine, parent)
+		_, err := chain.InsertChain(types.Blocks{block})
+		require.NoError(t, err)
+		if i == 2 {
+			excludeNodeByAddr(removed)
+		}
+		headers = append(headers, block.Header())
+		parent = block
+	}
+
+	// the seals reach the quorum of the council trusted at the start of the range,
+	// but the checkpoint is ignored since the council is changed within the range
+	trusted, err := engine.snapshot(chain, 0, chain.Genesis().Hash(), nil, true)
+	require.NoError(t, err)
+	assert.NoError(t, verifyCommittedSealsWithValSet(headers[3], trusted.ValSet))
+
+	engine.VerifyCheckpoints(chain, headers)
+	for _, header := range headers {
+		assert.False(t, engine.isCheckpointed(header.Hash()), header.Number)
+	}
+}
@@ -22,6 +22,7 @@ required objects to get information for making a consensus, recent messages and
 Source Files
 
 Implementation of Backend interface and APIs are included in this package
+ - `announcement.go`: Implements the signed announcements of the council members broadcast over the consensus network
  - `api.go`: Implements APIs which provide the states of Istanbul
  - `backend.go`: Defines backend struct which implements Backend interface working as a backbone of the consensus engine
  - `engine.go`: Implements various backend methods especially for verifying and building header information
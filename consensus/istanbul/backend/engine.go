@@ -88,6 +88,9 @@ var (
 	errEmptyCommittedSeals = errors.New("zero committed seals")
 	// errMismatchTxhashes is returned if the TxHash in header is mismatch.
 	errMismatchTxhashes = errors.New("mismatch transactions hashes")
+	// errMismatchStakingInfoHash is returned if the StakingInfoHash in header is not the hash of
+	// the local staking information.
+	errMismatchStakingInfoHash = errors.New("mismatch staking info hash")
 )
 
 var (
@@ -150,6 +153,9 @@ func (sb *backend) computeSignatureAddrs(header *types.Header) error {
 	if err != nil {
 		return err
 	}
+	if sb.isCheckpointed(header.Hash()) {
+		return nil
+	}
 
 	// Retrieve the signature from the header extra-data
 	istanbulExtra, err := types.ExtractIstanbulExtra(header)
@@ -247,9 +253,15 @@ func (sb *backend) verifyCascadingFields(chain consensus.ChainReader, header *ty
 	if parent.Time.Uint64()+sb.config.BlockPeriod > header.Time.Uint64() {
 		return errInvalidTimestamp
 	}
-	if err := sb.verifySigner(chain, header, parents); err != nil {
+	if err := verifyStakingInfoHash(chain.Config(), header); err != nil {
 		return err
 	}
+	checkpointed := sb.isCheckpointed(header.Hash())
+	if !checkpointed {
+		if err := sb.verifySigner(chain, header, parents); err != nil {
+			return err
+		}
+	}
 
 	// At every epoch governance data will come in block header. Verify it.
 	pset, err := sb.governance.EffectiveParams(number)
@@ -262,9 +274,36 @@ func (sb *backend) verifyCascadingFields(chain consensus.ChainReader, header *ty
 			return err
 		}
 	}
+	if checkpointed {
+		return nil
+	}
 	return sb.verifyCommittedSeals(chain, header, parents)
 }
 
+// verifyStakingInfoHash checks that the header commits to the local staking information after the
+// StakingCommitment hardfork, so that the nodes having different staking information fail to reach
+// consensus. If the local staking information is not available, only the commitment to no staking
+// information is accepted, so that the verification fails closed.
+func verifyStakingInfoHash(config *params.ChainConfig, header *types.Header) error {
+	if !config.IsStakingCommitmentForkEnabled(header.Number) {
+		if header.StakingInfoHash != nil {
+			return consensus.ErrInvalidStakingInfoHash
+		}
+		return nil
+	}
+	if header.StakingInfoHash == nil {
+		return consensus.ErrInvalidStakingInfoHash
+	}
+	stakingInfo := reward.GetStakingInfo(header.Number.Uint64())
+	if reward.StakingInfoHash(stakingInfo) != *header.StakingInfoHash {
+		if stakingInfo == nil {
+			logger.Warn("The staking info hash cannot be verified without the staking information", "number", header.Number)
+		}
+		return errMismatchStakingInfoHash
+	}
+	return nil
+}
+
 // VerifyHeaders is similar to VerifyHeader, but verifies a batch of headers
 // concurrently. The method returns a quit channel to abort the operations and
 // a results channel to retrieve the async verifications (the order is that of
@@ -326,7 +365,12 @@ func (sb *backend) verifyCommittedSeals(chain consensus.ChainReader, header *typ
 	if err != nil {
 		return err
 	}
+	return verifyCommittedSealsWithValSet(header, snap.ValSet)
+}
 
+// verifyCommittedSealsWithValSet checks whether the committed seals are signed by a quorum
+// of the given validators.
+func verifyCommittedSealsWithValSet(header *types.Header, valSet istanbul.ValidatorSet) error {
 	extra, err := types.ExtractIstanbulExtra(header)
 	if err != nil {
 		return err
@@ -336,7 +380,7 @@ func (sb *backend) verifyCommittedSeals(chain consensus.ChainReader, header *typ
 		return errEmptyCommittedSeals
 	}
 
-	validators := snap.ValSet.Copy()
+	validators := valSet.Copy()
 	// Check whether the committed seals are generated by parent's validators
 	validSeal := 0
 	proposalSeal := istanbulCore.PrepareCommittedSeal(header.Hash())
@@ -357,7 +401,7 @@ func (sb *backend) verifyCommittedSeals(chain consensus.ChainReader, header *typ
 	}
 
 	// The length of validSeal should be larger than number of faulty node + 1
-	if validSeal <= 2*snap.ValSet.F() {
+	if validSeal <= 2*valSet.F() {
 		return errInvalidCommittedSeals
 	}
 
@@ -395,6 +439,12 @@ func (sb *backend) Prepare(chain consensus.ChainReader, header *types.Header) er
 	// use the same blockscore for all blocks
 	header.BlockScore = defaultBlockScore
 
+	// commit the staking information used for the block reward
+	if chain.Config().IsStakingCommitmentForkEnabled(header.Number) {
+		stakingInfoHash := reward.StakingInfoHash(reward.GetStakingInfo(number))
+		header.StakingInfoHash = &stakingInfoHash
+	}
+
 	// Assemble the voting snapshot
 	snap, err := sb.snapshot(chain, number-1, header.ParentHash, nil, true)
 	if err != nil {
@@ -494,24 +544,71 @@ func (sb *backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 			}
 			logger.Trace(logMsg, "header.Number", header.Number.Uint64(), "node address", sb.address, "rewardbase", header.Rewardbase)
 		}
+	}
 
-		rewardSpec, err = reward.CalcDeferredReward(header, rules, pset)
+	policy := reward.GetRewardPolicy(header.Number, pset)
+	if policy.UseStakingInfo() && sb.chain == nil {
+		// The staking information is not available until the backend is initialized.
+		rewardSpec, err = reward.SimpleRewardPolicy{}.CalcDeferredReward(header, rules, pset, nil)
 	} else {
-		rewardSpec, err = reward.CalcDeferredRewardSimple(header, rules, pset)
+		var stakingInfo *reward.StakingInfo
+		if policy.UseStakingInfo() {
+			stakingInfo = reward.GetStakingInfo(header.Number.Uint64())
+		}
+		rewardSpec, err = reward.CalcDeferredRewardCached(policy, header, rules, pset, stakingInfo)
 	}
-
 	if err != nil {
 		return nil, err
 	}
+	if reward.IsInvariantCheckEnabled() {
+		if err := reward.CheckRewardSpec(rewardSpec); err != nil {
+			logger.Error("The reward spec violates the invariants", "number", header.Number, "err", err)
+			return nil, err
+		}
+	}
+	if !common.EmptyHash(header.Root) {
+		reward.UpdateDistributionMetrics(rewardSpec)
+	}
+
+	var locked map[common.Address]*big.Int
+	rewards := rewardSpec.Rewards
+	if vesting := rewardVesting(rules, chain.Config()); vesting != nil {
+		if rewards, locked, err = vestBlockReward(header, state, rewards, vesting); err != nil {
+			return nil, err
+		}
+	}
 
-	reward.DistributeBlockReward(state, rewardSpec.Rewards)
+	balances := reward.SnapshotRecipientBalances(state, rewards)
+	if stipend := rewardPayoutStipend(rules, pset); stipend > 0 {
+		// The contract recipients see the block proposer as the coinbase.
+		// When mining, the header is not signed yet.
+		author := sb.address
+		if !common.EmptyHash(header.Root) {
+			if author, err = sb.Author(header); err != nil {
+				return nil, err
+			}
+		}
+		payBlockReward(chain, header, author, state, rewards, stipend)
+	} else {
+		reward.DistributeBlockReward(state, rewards)
+	}
+	paidRewards := reward.BalanceChanges(state, balances)
+	if paidRewards != nil {
+		// The rewards locked in the vesting vault are paid to the vesting recipients.
+		for addr, amount := range locked {
+			paidRewards[addr] = amount
+		}
+	}
+	if err := reward.SettleCarriedRemainder(state, rules, rewardSpec); err != nil {
+		return nil, err
+	}
 
 	// Only on the KIP-103 hardfork block, the following logic should be executed
 	if chain.Config().IsKIP103ForkBlock(header.Number) {
 		// RebalanceTreasury can modify the global state (state),
 		// so the existing state db should be used to apply the rebalancing result.
-		c := &Kip103ContractCaller{state, chain, header}
-		result, err := RebalanceTreasury(state, chain, header, c)
+		c := reward.NewKip103ContractCaller(state, chain, header)
+		result, err := reward.RebalanceTreasury(state, chain, header, c)
 		if err != nil {
 			logger.Error("failed to execute treasury rebalancing (KIP-103). State not changed", "err", err)
 		} else {
@@ -530,6 +627,7 @@ func (sb *backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 	}
 
 	header.Root = state.IntermediateRoot(true)
+	reward.RecordPaidRewards(header.Number.Uint64(), header.Root, paidRewards)
 
 	// Assemble and return the final block for sealing
 	return types.NewBlock(header, txs, receipts), nil
@@ -666,6 +764,8 @@ func (sb *backend) Start(chain consensus.ChainReader, currentBlock func() *types
 		return err
 	}
 
+	sb.startStallWatchdog()
+	sb.startStandbyMonitor()
 	sb.coreStarted = true
 	return nil
 }
@@ -680,6 +780,8 @@ func (sb *backend) Stop() error {
 	if err := sb.core.Stop(); err != nil {
 		return err
 	}
+	sb.stopStallWatchdog()
+	sb.stopStandbyMonitor()
 	sb.coreStarted = false
 	return nil
 }
@@ -793,30 +895,6 @@ func (sb *backend) GetConsensusInfo(block *types.Block) (consensus.ConsensusInfo
 		committeeAddrs[i] = v.Address()
 	}
 
-	// verify the committee list of the block using istanbul
-	//proposalSeal := istanbulCore.PrepareCommittedSeal(block.Hash())
-	//extra, err := types.ExtractIstanbulExtra(block.Header())
-	//istanbulAddrs := make([]common.Address, len(committeeAddrs))
-	//for i, seal := range extra.CommittedSeal {
-	//	addr, err := istanbul.GetSignatureAddress(proposalSeal, seal)
-	//	istanbulAddrs[i] = addr
-	//	if err != nil {
-	//		return proposer, []common.Address{}, err
-	//	}
-	//
-	//	var found bool = false
-	//	for _, v := range committeeAddrs {
-	//		if addr == v {
-	//			found = true
-	//			break
-	//		}
-	//	}
-	//	if found == false {
-	//		logger.Trace("validator is different!", "snap", committeeAddrs, "istanbul", istanbulAddrs)
-	//		return proposer, committeeAddrs, errors.New("validator set is different from Istanbul engine!!")
-	//	}
-	//}
-
 	cInfo := consensus.ConsensusInfo{
 		Proposer:       proposer,
 		OriginProposer: originProposer,
@@ -43,6 +43,7 @@ import (
 	"github.com/klaytn/klaytn/reward"
 	"github.com/klaytn/klaytn/rlp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // These variables are the global variables of the test blockchain.
@@ -59,6 +60,9 @@ type (
 	EthTxTypeCompatibleBlock *big.Int
 	magmaCompatibleBlock     *big.Int
 	koreCompatibleBlock      *big.Int
+
+	stakingCommitmentCompatibleBlock *big.Int
+	rewardPayoutCompatibleBlock      *big.Int
 )
 
 type (
@@ -71,6 +75,7 @@ type (
 	epoch                  uint64
 	subGroupSize           uint64
 	blockPeriod            uint64
+	payoutGasStipend       uint64
 )
 
 // makeCommittedSeals returns a list of committed seals for the global variable nodeKeys.
@@ -136,6 +141,10 @@ func newBlockChain(n int, items ...interface{}) (*blockchain.BlockChain, *backen
 			genesis.Config.MagmaCompatibleBlock = v
 		case koreCompatibleBlock:
 			genesis.Config.KoreCompatibleBlock = v
+		case stakingCommitmentCompatibleBlock:
+			genesis.Config.StakingCommitmentCompatibleBlock = v
+		case rewardPayoutCompatibleBlock:
+			genesis.Config.RewardPayoutCompatibleBlock = v
 		case proposerPolicy:
 			genesis.Config.Istanbul.ProposerPolicy = uint64(v)
 		case epoch:
@@ -150,6 +159,8 @@ func newBlockChain(n int, items ...interface{}) (*blockchain.BlockChain, *backen
 			genesis.Config.Governance.Reward.ProposerUpdateInterval = uint64(v)
 		case mintingAmount:
 			genesis.Config.Governance.Reward.MintingAmount = v
+		case payoutGasStipend:
+			genesis.Config.Governance.Reward.PayoutGasStipend = uint64(v)
 		case governanceMode:
 			genesis.Config.Governance.GovernanceMode = string(v)
 		case *ecdsa.PrivateKey:
@@ -395,6 +406,66 @@ func TestVerifyHeader(t *testing.T) {
 	// TODO-Klaytn: add more tests for header.Governance, header.Rewardbase, header.Vote
 }
 
+func TestVerifyHeader_StakingInfoHash(t *testing.T) {
+	// before the fork, the staking info hash is not allowed
+	chain, engine := newBlockChain(1, istanbulCompatibleBlock(common.Big0))
+	block := makeBlockWithoutSeal(chain, engine, chain.Genesis())
+	assert.Nil(t, block.Header().StakingInfoHash)
+	header := block.Header()
+	header.StakingInfoHash = &common.Hash{}
+	assert.Equal(t, consensus.ErrInvalidStakingInfoHash, engine.VerifyHeader(chain, header, false))
+	engine.Stop()
+
+	chain, engine = newBlockChain(1, istanbulCompatibleBlock(common.Big0), stakingCommitmentCompatibleBlock(common.Big0))
+	defer engine.Stop()
+
+	oldStakingManager := reward.GetStakingManager()
+	defer reward.SetTestStakingManager(oldStakingManager)
+	stakingInfo := makeFakeStakingInfo(0, nodeKeys, []uint64{5000000})
+	reward.SetTestStakingManagerWithStakingInfoCache(stakingInfo)
+
+	// the proposer commits to its staking information
+	block = makeBlockWithoutSeal(chain, engine, chain.Genesis())
+	require.NotNil(t, block.Header().StakingInfoHash)
+	assert.Equal(t, reward.StakingInfoHash(stakingInfo), *block.Header().StakingInfoHash)
+	block, _ = engine.updateBlock(block)
+	assert.Equal(t, errEmptyCommittedSeals, engine.VerifyHeader(chain, block.Header(), false))
+
+	// the commitment is required after the fork
+	header = block.Header()
+	header.StakingInfoHash = nil
+	assert.Equal(t, consensus.ErrInvalidStakingInfoHash, engine.VerifyHeader(chain, header, false))
+
+	// the commitment to different staking information is rejected
+	header = block.Header()
+	header.StakingInfoHash = &common.Hash{1}
+	assert.Equal(t, errMismatchStakingInfoHash, engine.VerifyHeader(chain, header, false))
+
+	// without the local staking information, the commitment to staking information is rejected
+	reward.SetTestStakingManager(nil)
+	assert.Equal(t, errMismatchStakingInfoHash, engine.VerifyHeader(chain, block.Header(), false))
+	header = block.Header()
+	header.StakingInfoHash = &common.Hash{}
+	assert.Equal(t, errEmptyCommittedSeals, engine.VerifyHeader(chain, header, false))
+}
+
+func TestBurnRatio(t *testing.T) {
+	chain, engine := newBlockChain(1, istanbulCompatibleBlock(common.Big0))
+	defer engine.Stop()
+
+	burnRatio, err := engine.BurnRatio(common.Big1)
+	require.NoError(t, err)
+	assert.Equal(t, params.DefaultBurnRatio, burnRatio)
+
+	// the block is executed with the burn ratio in effect at it, not with the latest one
+	latest := uint64(30)
+	chain.Config().Governance.Reward.BurnRatio = &latest
+	block := makeBlockWithoutSeal(chain, engine, chain.Genesis())
+	blockContext := blockchain.NewEVMBlockContextWithBurnRatio(block.Header(), chain, &common.Address{})
+	require.NotNil(t, blockContext.BurnRatio)
+	assert.Equal(t, params.DefaultBurnRatio, *blockContext.BurnRatio)
+}
+
 func TestVerifySeal(t *testing.T) {
 	chain, engine := newBlockChain(1)
 	defer engine.Stop()
@@ -489,6 +560,7 @@ OUT1:
 	}
 	// abort cases
 	abort, results := engine.VerifyHeaders(chain, headers, nil)
+	close(abort)
 	timeout = time.NewTimer(timeoutDura)
 	index = 0
 OUT2:
@@ -502,9 +574,6 @@ OUT2:
 				}
 			}
 			index++
-			if index == 5 {
-				abort <- struct{}{}
-			}
 			if index >= size {
 				t.Errorf("verifyheaders should be aborted")
 				break OUT2
@@ -739,6 +808,44 @@ func TestRewardDistribution(t *testing.T) {
 	}
 }
 
+func TestRewardDistribution_PaidRewards(t *testing.T) {
+	reward.SetPaidRewardRecording(true)
+	defer reward.SetPaidRewardRecording(false)
+
+	chain, engine := newBlockChain(1, mintingAmount(big.NewInt(5)), blockPeriod(0))
+	defer engine.Stop()
+
+	block := chain.Genesis()
+	for num := uint64(1); num <= 3; num++ {
+		block = makeBlockWithSeal(chain, engine, block)
+		_, err := chain.InsertChain(types.Blocks{block})
+		assert.NoError(t, err)
+
+		// The sealed block is finalized again on insertion, with its state root.
+		paid, ok := reward.GetPaidRewards(num, block.Root())
+		assert.True(t, ok, "not recorded at block %d", num)
+		assert.Equal(t, map[common.Address]*big.Int{block.Rewardbase(): big.NewInt(5)}, paid, "wrong at block %d", num)
+	}
+}
+
+func TestRewardDistribution_Payout(t *testing.T) {
+	chain, engine := newBlockChain(1, mintingAmount(big.NewInt(5)), blockPeriod(0),
+		rewardPayoutCompatibleBlock(common.Big0), payoutGasStipend(2300))
+	defer engine.Stop()
+
+	block := chain.Genesis()
+	for num := uint64(1); num <= 3; num++ {
+		// The block is finalized without the proposer seal when sealed, and with it when inserted.
+		block = makeBlockWithSeal(chain, engine, block)
+		_, err := chain.InsertChain(types.Blocks{block})
+		require.NoError(t, err)
+
+		state, err := chain.StateAt(block.Root())
+		require.NoError(t, err)
+		assert.Equal(t, new(big.Int).SetUint64(5*num), state.GetBalance(block.Rewardbase()), "wrong at block %d", num)
+	}
+}
+
 func makeSnapshotTestConfigItems() []interface{} {
 	return []interface{}{
 		stakingUpdateInterval(1),
@@ -89,6 +89,13 @@ func (sb *backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 		}
 		sb.knownMessages.Add(hash, true)
 
+		if cmsg.PrevHash == announcementMsgHash {
+			go sb.handleAnnouncement(data)
+			return true, nil
+		}
+
+		sb.traceStall(addr, data)
+		sb.heartbeatMessage(data)
 		go sb.istanbulEventMux.Post(istanbul.MessageEvent{
 			Payload: data,
 			Hash:    cmsg.PrevHash,
@@ -140,6 +147,10 @@ func (sb *backend) NewChainHead() error {
 		return istanbul.ErrStoppedEngine
 	}
 
+	sb.sealedStall()
+	if sb.currentBlock != nil {
+		sb.heartbeatBlock(sb.currentBlock().Header())
+	}
 	go sb.istanbulEventMux.Post(istanbul.FinalCommittedEvent{})
 	return nil
 }
@@ -0,0 +1,86 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/params"
+)
+
+// rewardPayoutStipend returns the gas given to a contract recipient to handle its reward.
+// Zero means the rewards are credited to the balances as before the RewardPayout fork.
+func rewardPayoutStipend(rules params.Rules, pset *params.GovParamSet) uint64 {
+	if !rules.IsRewardPayout {
+		return 0
+	}
+	if v, ok := pset.Get(params.PayoutGasStipend); ok {
+		return v.(uint64)
+	}
+	return 0
+}
+
+// payBlockReward pays the block rewards after the RewardPayout fork. A contract recipient is paid
+// by calling it from the reward ledger with the reward and the gas stipend, so that the contract
+// can account for the reward. If the call fails, the reward is kept in the ledger for the recipient
+// to claim later. The other recipients are credited as before.
+// The recipients are paid in the order of their addresses since the calls may affect each other.
+// The logs of the calls are discarded since there is no receipt for them.
+func payBlockReward(chain consensus.ChainReader, header *types.Header, author common.Address, state *state.StateDB, rewards map[common.Address]*big.Int, stipend uint64) {
+	recipients := make([]common.Address, 0, len(rewards))
+	for addr := range rewards {
+		recipients = append(recipients, addr)
+	}
+	sort.Slice(recipients, func(i, j int) bool {
+		return bytes.Compare(recipients[i].Bytes(), recipients[j].Bytes()) < 0
+	})
+
+	var evm *vm.EVM
+	for _, addr := range recipients {
+		amount := rewards[addr]
+		if amount.Sign() == 0 || state.GetCodeSize(addr) == 0 {
+			state.AddBalance(addr, amount)
+			continue
+		}
+		if evm == nil {
+			if err := system.InstallRewardLedger(state); err != nil {
+				logger.Error("Failed to install the reward ledger, credit the reward instead", "recipient", addr, "err", err)
+				state.AddBalance(addr, amount)
+				continue
+			}
+			blockContext := blockchain.NewEVMBlockContext(header, chain, &author)
+			txContext := vm.TxContext{Origin: system.RewardLedgerAddr, GasPrice: big.NewInt(0)}
+			evm = vm.NewEVM(blockContext, txContext, state, chain.Config(), &vm.Config{})
+		}
+
+		state.AddBalance(system.RewardLedgerAddr, amount)
+		if _, _, err := evm.Call(vm.AccountRef(system.RewardLedgerAddr), addr, nil, stipend, amount); err != nil {
+			logger.Debug("Failed to pay the reward to the contract, record it to the reward ledger",
+				"number", header.Number, "recipient", addr, "amount", amount, "err", err)
+			system.AddClaimableReward(state, addr, amount)
+		}
+	}
+}
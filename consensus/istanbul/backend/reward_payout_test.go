@@ -0,0 +1,93 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayBlockReward(t *testing.T) {
+	chain, engine := newBlockChain(1)
+	defer engine.Stop()
+
+	state, err := chain.State()
+	require.NoError(t, err)
+
+	var (
+		eoa      = common.HexToAddress("0x0000000000000000000000000000000000000a01")
+		acceptor = common.HexToAddress("0x0000000000000000000000000000000000000a02") // STOP
+		reverter = common.HexToAddress("0x0000000000000000000000000000000000000a03") // REVERT
+		storer   = common.HexToAddress("0x0000000000000000000000000000000000000a04") // SSTORE, over the stipend
+		stipend  = uint64(2300)
+		header   = types.CopyHeader(chain.CurrentHeader())
+	)
+	header.Number = big.NewInt(1)
+	require.NoError(t, state.SetCode(acceptor, hexutil.MustDecode("0x00")))
+	require.NoError(t, state.SetCode(reverter, hexutil.MustDecode("0x600080fd")))
+	require.NoError(t, state.SetCode(storer, hexutil.MustDecode("0x600160005500")))
+
+	rewards := map[common.Address]*big.Int{
+		eoa:      big.NewInt(1),
+		acceptor: big.NewInt(2),
+		reverter: big.NewInt(3),
+		storer:   big.NewInt(4),
+	}
+	payBlockReward(chain, header, engine.address, state, rewards, stipend)
+	payBlockReward(chain, header, engine.address, state, rewards, stipend)
+
+	// The failed payouts are kept in the ledger.
+	assert.Equal(t, big.NewInt(2), state.GetBalance(eoa))
+	assert.Equal(t, big.NewInt(4), state.GetBalance(acceptor))
+	assert.Zero(t, state.GetBalance(reverter).Sign())
+	assert.Zero(t, state.GetBalance(storer).Sign())
+	assert.Equal(t, big.NewInt(14), state.GetBalance(system.RewardLedgerAddr))
+	assert.Equal(t, big.NewInt(6), system.ReadClaimableReward(state, reverter))
+	assert.Equal(t, big.NewInt(8), system.ReadClaimableReward(state, storer))
+	assert.Equal(t, system.RewardLedgerCode, state.GetCode(system.RewardLedgerAddr))
+
+	// The recipient claims the reward by calling the ledger with enough gas.
+	blockContext := blockchain.NewEVMBlockContext(header, chain, &engine.address)
+	evm := vm.NewEVM(blockContext, vm.TxContext{GasPrice: big.NewInt(0)}, state, chain.Config(), &vm.Config{})
+	claim := func(recipient common.Address, value *big.Int) error {
+		_, _, err := evm.Call(vm.AccountRef(recipient), system.RewardLedgerAddr, nil, 100000, value)
+		return err
+	}
+
+	assert.NoError(t, claim(storer, big.NewInt(0)))
+	assert.Equal(t, big.NewInt(8), state.GetBalance(storer))
+	assert.Zero(t, system.ReadClaimableReward(state, storer).Sign())
+	assert.Equal(t, big.NewInt(6), state.GetBalance(system.RewardLedgerAddr))
+
+	// Nothing left to claim.
+	assert.Error(t, claim(storer, big.NewInt(0)))
+	// The payment fails if the recipient does not accept it.
+	assert.Error(t, claim(reverter, big.NewInt(0)))
+	assert.Equal(t, big.NewInt(6), system.ReadClaimableReward(state, reverter))
+	// The ledger does not accept a value.
+	assert.Error(t, claim(storer, big.NewInt(1)))
+	assert.Equal(t, big.NewInt(6), state.GetBalance(system.RewardLedgerAddr))
+}
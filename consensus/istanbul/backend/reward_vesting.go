@@ -0,0 +1,59 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+)
+
+// rewardVesting returns the vesting schedule of the block rewards, nil if they are not vested.
+func rewardVesting(rules params.Rules, config *params.ChainConfig) *params.RewardVestingConfig {
+	if !rules.IsRewardVesting || config.RewardVesting == nil || config.RewardVesting.Period == 0 {
+		return nil
+	}
+	return config.RewardVesting
+}
+
+// vestBlockReward credits the rewards of the vesting recipients vested at the block, and locks
+// their rewards of the block in the vesting vault after the RewardVesting fork. It returns the
+// rest of the rewards, which are credited as before, and the locked rewards.
+func vestBlockReward(header *types.Header, state *state.StateDB, rewards map[common.Address]*big.Int, vesting *params.RewardVestingConfig) (rest, locked map[common.Address]*big.Int, err error) {
+	number := header.Number.Uint64()
+	for _, recipient := range vesting.Recipients {
+		system.ReleaseVestedReward(state, recipient, number, vesting.Period)
+	}
+
+	rest = make(map[common.Address]*big.Int, len(rewards))
+	locked = make(map[common.Address]*big.Int)
+	for addr, amount := range rewards {
+		if !vesting.IsRecipient(addr) || amount.Sign() == 0 {
+			rest[addr] = amount
+			continue
+		}
+		if err := system.LockVestingReward(state, addr, number, vesting.Period, amount); err != nil {
+			return nil, nil, err
+		}
+		locked[addr] = amount
+	}
+	return rest, locked, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVestBlockReward(t *testing.T) {
+	chain, engine := newBlockChain(1)
+	defer engine.Stop()
+
+	state, err := chain.State()
+	require.NoError(t, err)
+
+	var (
+		kgf     = common.HexToAddress("0x0000000000000000000000000000000000000b01")
+		cn      = common.HexToAddress("0x0000000000000000000000000000000000000b02")
+		vesting = &params.RewardVestingConfig{Recipients: []common.Address{kgf}, Period: 4}
+		header  = types.CopyHeader(chain.CurrentHeader())
+	)
+	finalize := func(number int64) map[common.Address]*big.Int {
+		header.Number = big.NewInt(number)
+		rewards := map[common.Address]*big.Int{kgf: big.NewInt(100), cn: big.NewInt(1)}
+		rest, locked, err := vestBlockReward(header, state, rewards, vesting)
+		require.NoError(t, err)
+		reward.DistributeBlockReward(state, rest)
+		assert.Equal(t, map[common.Address]*big.Int{kgf: big.NewInt(100)}, locked)
+		return rest
+	}
+
+	// The rewards of the first period are locked, and the others are credited as before.
+	for number := int64(0); number < 4; number++ {
+		assert.Equal(t, map[common.Address]*big.Int{cn: big.NewInt(1)}, finalize(number))
+	}
+	assert.Zero(t, state.GetBalance(kgf).Sign())
+	assert.Equal(t, big.NewInt(4), state.GetBalance(cn))
+	assert.Equal(t, big.NewInt(400), state.GetBalance(system.RewardVestingAddr))
+	assert.Equal(t, system.RewardVestingCode, state.GetCode(system.RewardVestingAddr))
+
+	// They are vested over the next period, while the rewards of the period are locked.
+	finalize(4)
+	finalize(5)
+	status := system.ReadVestingStatus(state, kgf)
+	assert.Equal(t, big.NewInt(200), state.GetBalance(kgf))
+	assert.Equal(t, big.NewInt(200), status.Vested)
+	assert.Equal(t, big.NewInt(400), status.Locked)
+	require.Len(t, status.Tranches, 2)
+	assert.Equal(t, uint64(0), status.Tranches[0].Period)
+	assert.Equal(t, uint64(1), status.Tranches[1].Period)
+
+	for number := int64(6); number < 12; number++ {
+		finalize(number)
+	}
+	status = system.ReadVestingStatus(state, kgf)
+	assert.Equal(t, big.NewInt(800), state.GetBalance(kgf))
+	assert.Equal(t, big.NewInt(800), status.Vested)
+	assert.Equal(t, big.NewInt(400), status.Locked)
+	require.Len(t, status.Tranches, 1)
+	assert.Equal(t, uint64(2), status.Tranches[0].Period)
+	assert.Equal(t, big.NewInt(400), state.GetBalance(system.RewardVestingAddr))
+}
+
+func TestRewardVesting(t *testing.T) {
+	config := &params.ChainConfig{
+		RewardVestingCompatibleBlock: big.NewInt(10),
+		RewardVesting:                &params.RewardVestingConfig{Recipients: []common.Address{{1}}, Period: 4},
+	}
+	assert.Nil(t, rewardVesting(config.Rules(big.NewInt(9)), config))
+	assert.Equal(t, config.RewardVesting, rewardVesting(config.Rules(big.NewInt(10)), config))
+
+	config.RewardVesting.Period = 0
+	assert.Nil(t, rewardVesting(config.Rules(big.NewInt(10)), config))
+	config.RewardVesting = nil
+	assert.Nil(t, rewardVesting(config.Rules(big.NewInt(10)), config))
+}
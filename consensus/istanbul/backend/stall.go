@@ -0,0 +1,256 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	istanbulCore "github.com/klaytn/klaytn/consensus/istanbul/core"
+	"github.com/klaytn/klaytn/log"
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	inmemoryStallTraces = 4096        // the number of the latest consensus messages traced during a stall
+	stallCheckInterval  = time.Second // the interval between the checks of the stall watchdog
+)
+
+// stallModules are the modules whose log levels are raised during a stall.
+var stallModules = []log.ModuleID{
+	log.Blockchain,
+	log.ConsensusIstanbul,
+	log.ConsensusIstanbulBackend,
+	log.ConsensusIstanbulCore,
+	log.ConsensusIstanbulValidator,
+	log.Reward,
+}
+
+var (
+	stallEscalationCounter = metrics.NewRegisteredCounter("consensus/istanbul/stall/escalations", nil)
+	stallTracedCounter     = metrics.NewRegisteredCounter("consensus/istanbul/stall/traced", nil)
+)
+
+// MessageTrace is a consensus message sent or received by the node during a stall.
+type MessageTrace struct {
+	Time time.Time      `json:"time"`
+	Peer common.Address `json:"peer"` // the peer the message is received from, the node itself if sent
+	*istanbulCore.MessageInfo
+}
+
+// StallStatus is the state of the stall watchdog. The traces of the last stall are kept
+// after the consensus is healed until the next stall.
+type StallStatus struct {
+	Enabled    bool            `json:"enabled"`
+	Stalled    bool            `json:"stalled"`
+	LastSealed time.Time       `json:"lastSealed"`
+	StalledAt  *time.Time      `json:"stalledAt,omitempty"`
+	HealedAt   *time.Time      `json:"healedAt,omitempty"`
+	Traces     []*MessageTrace `json:"traces"`
+}
+
+// stallWatchdog raises the log levels of the consensus related modules to debug and
+// traces the consensus messages when no block has been sealed for the timeout, and
+// restores the log levels when a block is sealed again.
+type stallWatchdog struct {
+	timeout time.Duration
+
+	mu         sync.Mutex
+	lastSealed time.Time
+	stalledAt  time.Time // zero if the consensus is not stalled
+	healedAt   time.Time
+	restore    func() // restores the log levels raised during the stall
+	traces     []*MessageTrace
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newStallWatchdog(timeout time.Duration, now time.Time) *stallWatchdog {
+	return &stallWatchdog{
+		timeout:    timeout,
+		lastSealed: now,
+		quit:       make(chan struct{}),
+	}
+}
+
+func (w *stallWatchdog) start() {
+	w.wg.Add(1)
+	go w.loop()
+}
+
+// stop stops the watchdog, restoring the log levels if the consensus is stalled.
+func (w *stallWatchdog) stop() {
+	close(w.quit)
+	w.wg.Wait()
+	w.heal(time.Now())
+}
+
+func (w *stallWatchdog) loop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(stallCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			w.check(now)
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+// check escalates the log levels if no block has been sealed for the timeout.
+// It returns true if the consensus is stalled.
+func (w *stallWatchdog) check(now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.stalledAt.IsZero() {
+		return true
+	}
+	if now.Sub(w.lastSealed) < w.timeout {
+		return false
+	}
+	restore, err := log.RaiseModuleLevels(log.LvlDebug, stallModules...)
+	if err != nil {
+		logger.Error("Failed to raise the log levels on the consensus stall", "err", err)
+		restore = func() {}
+	}
+	w.stalledAt, w.restore, w.traces = now, restore, nil
+	stallEscalationCounter.Inc(1)
+	logger.Warn("No block has been sealed, raised the log levels and started tracing the consensus messages",
+		"lastSealed", w.lastSealed, "timeout", w.timeout)
+	return true
+}
+
+// sealed records that a block is sealed, restoring the log levels if the consensus is stalled.
+func (w *stallWatchdog) sealed(now time.Time) {
+	w.mu.Lock()
+	w.lastSealed = now
+	w.mu.Unlock()
+	w.heal(now)
+}
+
+func (w *stallWatchdog) heal(now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stalledAt.IsZero() {
+		return
+	}
+	w.restore()
+	logger.Warn("The consensus is healed, restored the log levels", "stalledAt", w.stalledAt,
+		"elapsed", now.Sub(w.stalledAt), "traced", len(w.traces))
+	w.stalledAt, w.healedAt, w.restore = time.Time{}, now, nil
+}
+
+// trace records the consensus message if the consensus is stalled.
+func (w *stallWatchdog) trace(now time.Time, peer common.Address, payload []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stalledAt.IsZero() {
+		return
+	}
+	info, err := istanbulCore.DecodeMessageInfo(payload)
+	if err != nil {
+		return
+	}
+	if len(w.traces) >= inmemoryStallTraces {
+		w.traces = w.traces[1:]
+	}
+	w.traces = append(w.traces, &MessageTrace{Time: now, Peer: peer, MessageInfo: info})
+	stallTracedCounter.Inc(1)
+}
+
+func (w *stallWatchdog) status() *StallStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	status := &StallStatus{
+		Enabled:    true,
+		Stalled:    !w.stalledAt.IsZero(),
+		LastSealed: w.lastSealed,
+		Traces:     make([]*MessageTrace, len(w.traces)),
+	}
+	if !w.stalledAt.IsZero() {
+		stalledAt := w.stalledAt
+		status.StalledAt = &stalledAt
+	}
+	if !w.healedAt.IsZero() {
+		healedAt := w.healedAt
+		status.HealedAt = &healedAt
+	}
+	copy(status.Traces, w.traces)
+	return status
+}
+
+// StallStatus returns the state of the stall watchdog.
+func (sb *backend) StallStatus() *StallStatus {
+	sb.stallMu.RLock()
+	defer sb.stallMu.RUnlock()
+
+	if sb.stall == nil {
+		return &StallStatus{Traces: []*MessageTrace{}}
+	}
+	return sb.stall.status()
+}
+
+// traceStall records the consensus message if the consensus is stalled.
+func (sb *backend) traceStall(peer common.Address, payload []byte) {
+	sb.stallMu.RLock()
+	defer sb.stallMu.RUnlock()
+
+	if sb.stall != nil {
+		sb.stall.trace(time.Now(), peer, payload)
+	}
+}
+
+// startStallWatchdog starts the stall watchdog if the stall timeout is configured.
+func (sb *backend) startStallWatchdog() {
+	if sb.config.StallTimeout == 0 {
+		return
+	}
+	sb.stallMu.Lock()
+	defer sb.stallMu.Unlock()
+
+	sb.stall = newStallWatchdog(time.Duration(sb.config.StallTimeout)*time.Second, time.Now())
+	sb.stall.start()
+}
+
+// stopStallWatchdog stops the stall watchdog. The traces are kept until it is started again.
+func (sb *backend) stopStallWatchdog() {
+	sb.stallMu.RLock()
+	defer sb.stallMu.RUnlock()
+
+	if sb.stall != nil {
+		sb.stall.stop()
+	}
+}
+
+// sealedStall records that a block is sealed to the stall watchdog.
+func (sb *backend) sealedStall() {
+	sb.stallMu.RLock()
+	defer sb.stallMu.RUnlock()
+
+	if sb.stall != nil {
+		sb.stall.sealed(time.Now())
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	istanbulCore "github.com/klaytn/klaytn/consensus/istanbul/core"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestConsensusPayload(t *testing.T, code uint64, sender common.Address) []byte {
+	subject, err := rlp.EncodeToBytes(&istanbul.Subject{
+		View:   &istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(10)},
+		Digest: common.Hash{},
+	})
+	assert.NoError(t, err)
+	payload, err := rlp.EncodeToBytes(&struct {
+		Hash          common.Hash
+		Code          uint64
+		Msg           []byte
+		Address       common.Address
+		Signature     []byte
+		CommittedSeal []byte
+	}{Code: code, Msg: subject, Address: sender})
+	assert.NoError(t, err)
+	return payload
+}
+
+func TestStallWatchdog(t *testing.T) {
+	now := time.Now()
+	sender := common.HexToAddress("0x1")
+	payload := newTestConsensusPayload(t, 1, sender) // prepare
+	w := newStallWatchdog(10*time.Second, now)
+
+	// the messages are not traced before the stall
+	assert.False(t, w.check(now.Add(9*time.Second)))
+	w.trace(now, sender, payload)
+	assert.Empty(t, w.status().Traces)
+
+	// the messages are traced during the stall
+	assert.True(t, w.check(now.Add(10*time.Second)))
+	w.trace(now.Add(11*time.Second), sender, payload)
+	w.trace(now.Add(11*time.Second), sender, []byte{0x1}) // undecodable
+	status := w.status()
+	assert.True(t, status.Stalled)
+	assert.Equal(t, 1, len(status.Traces))
+	assert.Equal(t, &istanbulCore.MessageInfo{
+		Code:   "prepare",
+		Sender: sender,
+		View:   &istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(10)},
+	}, status.Traces[0].MessageInfo)
+
+	// the traces are kept after the consensus is healed
+	w.sealed(now.Add(12 * time.Second))
+	w.trace(now.Add(13*time.Second), sender, payload)
+	status = w.status()
+	assert.False(t, status.Stalled)
+	assert.NotNil(t, status.HealedAt)
+	assert.Equal(t, 1, len(status.Traces))
+	assert.False(t, w.check(now.Add(21*time.Second)))
+
+	// the traces are reset on the next stall
+	assert.True(t, w.check(now.Add(22*time.Second)))
+	assert.Empty(t, w.status().Traces)
+}
@@ -0,0 +1,303 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	istanbulCore "github.com/klaytn/klaytn/consensus/istanbul/core"
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	standbyCheckInterval = time.Second // the interval between the checks of the standby monitor
+	defaultStandbyWindow = 30          // the seconds without a heartbeat of the primary before the standby takes over
+)
+
+var (
+	// errStandbyPassive is returned if the standby node is asked to sign while the primary is alive.
+	errStandbyPassive = errors.New("standby node is passive")
+	// errStandbyDoubleSign is returned if the standby node is asked to sign a view the primary has signed.
+	errStandbyDoubleSign = errors.New("view already signed by the primary")
+)
+
+var (
+	standbyTakeoverCounter = metrics.NewRegisteredCounter("consensus/istanbul/standby/takeovers", nil)
+	standbyStepBackCounter = metrics.NewRegisteredCounter("consensus/istanbul/standby/stepbacks", nil)
+	standbyRefusedCounter  = metrics.NewRegisteredCounter("consensus/istanbul/standby/refused", nil)
+)
+
+// StandbyStatus is the state of the standby monitor.
+type StandbyStatus struct {
+	Enabled       bool           `json:"enabled"`
+	Active        bool           `json:"active"` // true if the node has taken over from the primary
+	Window        uint64         `json:"window"` // in seconds
+	LastHeartbeat time.Time      `json:"lastHeartbeat"`
+	TakenOverAt   *time.Time     `json:"takenOverAt,omitempty"`
+	SteppedBackAt *time.Time     `json:"steppedBackAt,omitempty"`
+	PrimaryView   *istanbul.View `json:"primaryView,omitempty"` // the latest view signed by the primary
+}
+
+// standbyMonitor keeps a standby node sharing the validator key of a primary node passive
+// while the primary is alive, i.e. a consensus message or a block signed by the key is
+// received from a peer within the window. It takes over when the primary has been silent
+// for the window, and steps back as soon as the primary is seen again, so that the two
+// nodes never sign at the same time for longer than a message round trip.
+type standbyMonitor struct {
+	window time.Duration
+
+	mu            sync.Mutex
+	lastHeartbeat time.Time
+	takenOverAt   time.Time // zero if the node is passive
+	steppedBackAt time.Time
+	primaryView   *istanbul.View
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newStandbyMonitor(window time.Duration, now time.Time) *standbyMonitor {
+	return &standbyMonitor{
+		window:        window,
+		lastHeartbeat: now,
+		quit:          make(chan struct{}),
+	}
+}
+
+func (m *standbyMonitor) start() {
+	m.wg.Add(1)
+	go m.loop()
+}
+
+func (m *standbyMonitor) stop() {
+	close(m.quit)
+	m.wg.Wait()
+}
+
+func (m *standbyMonitor) loop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(standbyCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			m.check(now)
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// check takes over from the primary if no heartbeat has been received for the window.
+// It returns true if the node is active.
+func (m *standbyMonitor) check(now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.takenOverAt.IsZero() {
+		return true
+	}
+	if now.Sub(m.lastHeartbeat) < m.window {
+		return false
+	}
+	m.takenOverAt = now
+	standbyTakeoverCounter.Inc(1)
+	logger.Warn("No heartbeat of the primary, the standby node takes over proposing and committing",
+		"lastHeartbeat", m.lastHeartbeat, "window", m.window, "primaryView", m.primaryView)
+	return true
+}
+
+// active returns true if the node has taken over from the primary.
+func (m *standbyMonitor) active() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return !m.takenOverAt.IsZero()
+}
+
+// heartbeat records that a message or a block signed by the validator key is received from
+// a peer, stepping back to passive if the node has taken over. The view is nil for a block.
+func (m *standbyMonitor) heartbeat(now time.Time, view *istanbul.View) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastHeartbeat = now
+	if view != nil && (m.primaryView == nil || view.Cmp(m.primaryView) > 0) {
+		m.primaryView = view
+	}
+	if m.takenOverAt.IsZero() {
+		return
+	}
+	standbyStepBackCounter.Inc(1)
+	logger.Error("The primary is alive, the standby node steps back to passive",
+		"takenOverAt", m.takenOverAt, "primaryView", m.primaryView)
+	m.takenOverAt, m.steppedBackAt = time.Time{}, now
+}
+
+// allowSign returns nil if the node may sign in the given view: it has taken over, and the
+// primary has not signed in the view or a later one.
+func (m *standbyMonitor) allowSign(view *istanbul.View) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.takenOverAt.IsZero() {
+		standbyRefusedCounter.Inc(1)
+		return errStandbyPassive
+	}
+	if m.primaryView != nil && view != nil && view.Cmp(m.primaryView) <= 0 {
+		standbyRefusedCounter.Inc(1)
+		return errStandbyDoubleSign
+	}
+	return nil
+}
+
+func (m *standbyMonitor) status() *StandbyStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := &StandbyStatus{
+		Enabled:       true,
+		Active:        !m.takenOverAt.IsZero(),
+		Window:        uint64(m.window / time.Second),
+		LastHeartbeat: m.lastHeartbeat,
+	}
+	if !m.takenOverAt.IsZero() {
+		takenOverAt := m.takenOverAt
+		status.TakenOverAt = &takenOverAt
+	}
+	if !m.steppedBackAt.IsZero() {
+		steppedBackAt := m.steppedBackAt
+		status.SteppedBackAt = &steppedBackAt
+	}
+	if m.primaryView != nil {
+		status.PrimaryView = &istanbul.View{
+			Sequence: new(big.Int).Set(m.primaryView.Sequence),
+			Round:    new(big.Int).Set(m.primaryView.Round),
+		}
+	}
+	return status
+}
+
+// StandbyStatus returns the state of the standby monitor.
+func (sb *backend) StandbyStatus() *StandbyStatus {
+	sb.standbyMu.RLock()
+	defer sb.standbyMu.RUnlock()
+
+	if sb.standby == nil {
+		return &StandbyStatus{}
+	}
+	return sb.standby.status()
+}
+
+// checkStandby returns nil if the node may sign now, which is always the case unless the
+// node is a standby. A standby whose engine is stopped never signs.
+func (sb *backend) checkStandby() error {
+	if !sb.config.Standby {
+		return nil
+	}
+	sb.standbyMu.RLock()
+	defer sb.standbyMu.RUnlock()
+
+	if sb.standby == nil {
+		return errStandbyPassive
+	}
+	view, _ := sb.currentView.Load().(*istanbul.View)
+	return sb.standby.allowSign(view)
+}
+
+// heartbeatMessage records a heartbeat of the primary if the consensus message received
+// from the peer is signed by the validator key of the node.
+func (sb *backend) heartbeatMessage(payload []byte) {
+	sb.standbyMu.RLock()
+	defer sb.standbyMu.RUnlock()
+
+	if sb.standby == nil {
+		return
+	}
+	info, err := istanbulCore.DecodeMessageInfo(payload)
+	if err != nil || info.Sender != sb.address {
+		return
+	}
+	sb.standby.heartbeat(time.Now(), info.View)
+}
+
+// heartbeatBlock records a heartbeat of the primary if the passive node has received a block
+// proposed or committed by the validator key of the node.
+func (sb *backend) heartbeatBlock(header *types.Header) {
+	sb.standbyMu.RLock()
+	defer sb.standbyMu.RUnlock()
+
+	if sb.standby == nil || sb.standby.active() {
+		// an active node cannot tell its own blocks from those of the primary
+		return
+	}
+	if sb.signedBy(header, sb.address) {
+		sb.standby.heartbeat(time.Now(), nil)
+	}
+}
+
+// signedBy returns true if the header is proposed or committed by the given address.
+func (sb *backend) signedBy(header *types.Header, addr common.Address) bool {
+	if proposer, err := ecrecover(header); err == nil && proposer == addr {
+		return true
+	}
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		return false
+	}
+	proposalSeal := istanbulCore.PrepareCommittedSeal(header.Hash())
+	for _, seal := range extra.CommittedSeal {
+		if signer, err := cacheSignatureAddresses(proposalSeal, seal); err == nil && signer == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// startStandbyMonitor starts the standby monitor if the node is a standby. The node is passive
+// for a window after every start.
+func (sb *backend) startStandbyMonitor() {
+	if !sb.config.Standby {
+		return
+	}
+	window := sb.config.StandbyWindow
+	if window == 0 {
+		window = defaultStandbyWindow
+	}
+	sb.standbyMu.Lock()
+	defer sb.standbyMu.Unlock()
+
+	sb.standby = newStandbyMonitor(time.Duration(window)*time.Second, time.Now())
+	sb.standby.start()
+}
+
+// stopStandbyMonitor stops the standby monitor.
+func (sb *backend) stopStandbyMonitor() {
+	sb.standbyMu.Lock()
+	defer sb.standbyMu.Unlock()
+
+	if sb.standby != nil {
+		sb.standby.stop()
+		sb.standby = nil
+	}
+}
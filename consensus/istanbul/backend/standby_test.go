@@ -0,0 +1,89 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandbyMonitor(t *testing.T) {
+	now := time.Now()
+	view := func(seq, round int64) *istanbul.View {
+		return &istanbul.View{Sequence: big.NewInt(seq), Round: big.NewInt(round)}
+	}
+	m := newStandbyMonitor(10*time.Second, now)
+
+	// passive while the primary is alive
+	assert.False(t, m.check(now.Add(9*time.Second)))
+	m.heartbeat(now.Add(5*time.Second), view(10, 0))
+	assert.False(t, m.check(now.Add(14*time.Second)))
+	assert.ErrorIs(t, m.allowSign(view(11, 0)), errStandbyPassive)
+
+	// takes over after the window, but never signs a view the primary has signed
+	assert.True(t, m.check(now.Add(15*time.Second)))
+	assert.ErrorIs(t, m.allowSign(view(10, 0)), errStandbyDoubleSign)
+	assert.ErrorIs(t, m.allowSign(view(9, 3)), errStandbyDoubleSign)
+	assert.NoError(t, m.allowSign(view(10, 1)))
+	assert.NoError(t, m.allowSign(view(11, 0)))
+	status := m.status()
+	assert.True(t, status.Active)
+	assert.NotNil(t, status.TakenOverAt)
+	assert.Equal(t, uint64(10), status.Window)
+	assert.Equal(t, view(10, 0), status.PrimaryView)
+
+	// steps back as soon as the primary is seen again, and waits another window
+	m.heartbeat(now.Add(16*time.Second), view(12, 0))
+	status = m.status()
+	assert.False(t, status.Active)
+	assert.NotNil(t, status.SteppedBackAt)
+	assert.ErrorIs(t, m.allowSign(view(13, 0)), errStandbyPassive)
+	assert.False(t, m.check(now.Add(25*time.Second)))
+	assert.True(t, m.check(now.Add(26*time.Second)))
+}
+
+func TestBackend_Standby(t *testing.T) {
+	b := newTestBackend()
+	config := *b.config
+	config.Standby = true
+	b.config = &config
+
+	// a standby never signs while the engine is stopped
+	_, err := b.Sign([]byte("data"))
+	assert.ErrorIs(t, err, errStandbyPassive)
+
+	b.standby = newStandbyMonitor(time.Second, time.Now())
+	_, err = b.Sign([]byte("data"))
+	assert.ErrorIs(t, err, errStandbyPassive)
+
+	// takes over after the window, and steps back on a message signed by the key
+	assert.True(t, b.standby.check(time.Now().Add(time.Second)))
+	_, err = b.Sign([]byte("data"))
+	assert.NoError(t, err)
+
+	b.heartbeatMessage(newTestConsensusPayload(t, 1, common.HexToAddress("0x1"))) // other validator
+	assert.True(t, b.StandbyStatus().Active)
+	b.heartbeatMessage(newTestConsensusPayload(t, 1, b.address))
+	assert.False(t, b.StandbyStatus().Active)
+	_, err = b.Sign([]byte("data"))
+	assert.ErrorIs(t, err, errStandbyPassive)
+}
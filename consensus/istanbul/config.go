@@ -34,6 +34,12 @@ type Config struct {
 	ProposerPolicy ProposerPolicy `toml:",omitempty"` // The policy for proposer selection
 	Epoch          uint64         `toml:",omitempty"` // The number of blocks after which to checkpoint and reset the pending votes
 	SubGroupSize   uint64         `toml:",omitempty"`
+
+	CheckpointInterval uint64 `toml:",omitempty"` // The interval of the checkpoints verifying the headers in between at once (0 = disabled)
+	StallTimeout       uint64 `toml:",omitempty"` // The seconds without a sealed block after which the consensus logs are escalated (0 = disabled)
+
+	Standby       bool   `toml:",omitempty"` // Whether the node is a hot standby sharing the validator key of a primary node
+	StandbyWindow uint64 `toml:",omitempty"` // The seconds without a heartbeat of the primary after which the standby takes over (0 = 30 seconds)
 }
 
 // TODO-Klaytn-Istanbul: Do not use DefaultConfig except for assigning new config
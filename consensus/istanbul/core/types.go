@@ -184,6 +184,37 @@ func (m *message) GetView() (*istanbul.View, error) {
 	return msgView, nil
 }
 
+// MessageInfo is the summary of a consensus message, which is used to trace the messages.
+type MessageInfo struct {
+	Code   string         `json:"code"`
+	Sender common.Address `json:"sender"`
+	View   *istanbul.View `json:"view,omitempty"`
+}
+
+// DecodeMessageInfo decodes the summary of the payload of a consensus message without
+// validating its signature.
+func DecodeMessageInfo(payload []byte) (*MessageInfo, error) {
+	msg := new(message)
+	if err := msg.FromPayload(payload, nil); err != nil {
+		return nil, err
+	}
+	info := &MessageInfo{Code: fmt.Sprintf("unknown(%d)", msg.Code), Sender: msg.Address}
+	switch msg.Code {
+	case msgPreprepare:
+		info.Code = "preprepare"
+	case msgPrepare:
+		info.Code = "prepare"
+	case msgCommit:
+		info.Code = "commit"
+	case msgRoundChange:
+		info.Code = "roundChange"
+	}
+	if view, err := msg.GetView(); err == nil {
+		info.View = view
+	}
+	return info, nil
+}
+
 // ==============================================
 //
 // helper functions
@@ -35,6 +35,8 @@ var Modules = map[string]string{
 	"governance":       Governance_JS,
 	"bootnode":         Bootnode_JS,
 	"chaindatafetcher": ChainDataFetcher_JS,
+	"faucet":           Faucet_JS,
+	"subproxy":         SubProxy_JS,
 	"eth":              Eth_JS,
 }
 
@@ -84,6 +86,60 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getBalanceHistory',
+			call: 'klay_getBalanceHistory',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.utils.fromDecimal],
+		}),
+		new web3._extend.Method({
+			name: 'getDecodedLogs',
+			call: 'klay_getDecodedLogs',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getContractMetadata',
+			call: 'klay_getContractMetadata',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'decodeCallData',
+			call: 'klay_decodeCallData',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'decodeRevertReason',
+			call: 'klay_decodeRevertReason',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getContractABI',
+			call: 'klay_getContractABI',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getAccumulatedRewards',
+			call: 'klay_getAccumulatedRewards',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'getStakerRewards',
+			call: 'klay_getStakerRewards',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null],
+		}),
+		new web3._extend.Method({
+			name: 'getEpochSummary',
+			call: 'klay_getEpochSummary',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal],
+		}),
 		new web3._extend.Method({
 			name: 'getHeaderByNumber',
 			call: 'eth_getHeaderByNumber',
@@ -218,6 +274,40 @@ web3._extend({
 });
 `
 
+const Faucet_JS = `
+web3._extend({
+	property: 'faucet',
+	methods: [
+		new web3._extend.Method({
+			name: 'request',
+			call: 'faucet_request',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'status',
+			call: 'faucet_status',
+			params: 0
+		})
+	],
+	properties: []
+});
+`
+
+const SubProxy_JS = `
+web3._extend({
+	property: 'subproxy',
+	methods: [
+		new web3._extend.Method({
+			name: 'status',
+			call: 'subproxy_status',
+			params: 0
+		})
+	],
+	properties: []
+});
+`
+
 const Bootnode_JS = `
 web3._extend({
 	property: 'bootnode',
@@ -333,6 +423,27 @@ web3._extend({
 			call: 'governance_getRewardsAccumulated',
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'diffParams',
+			call: 'governance_diffParams',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'checkHardforkReadiness',
+			call: 'governance_checkHardforkReadiness',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'addValidator',
+			call: 'governance_addValidator',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'removeValidator',
+			call: 'governance_removeValidator',
+			params: 1
 		})
 	],
 	properties: [
@@ -356,6 +467,14 @@ web3._extend({
 			name: 'nodeAddress',
 			getter: 'governance_nodeAddress',
 		}),
+		new web3._extend.Property({
+			name: 'pendingValidatorChanges',
+			getter: 'governance_pendingValidatorChanges',
+		}),
+		new web3._extend.Property({
+			name: 'validatorChanges',
+			getter: 'governance_validatorChanges',
+		}),
 		new web3._extend.Property({
 			name: 'pendingChanges',
 			getter: 'governance_pendingChanges',
@@ -390,6 +509,44 @@ web3._extend({
 			call: 'admin_removePeer',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'setPeerDiversityEnabled',
+			call: 'admin_setPeerDiversityEnabled',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'addPeerDiversityExemption',
+			call: 'admin_addPeerDiversityExemption',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'removePeerDiversityExemption',
+			call: 'admin_removePeerDiversityExemption',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'registerContractABI',
+			call: 'admin_registerContractABI',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'registerContractMetadata',
+			call: 'admin_registerContractMetadata',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'removeContractMetadata',
+			call: 'admin_removeContractMetadata',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'removeContractABI',
+			call: 'admin_removeContractABI',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'exportChain',
 			call: 'admin_exportChain',
@@ -411,6 +568,11 @@ web3._extend({
 			call: 'admin_sleepBlocks',
 			params: 2
 		}),
+		new web3._extend.Method({
+			name: 'verifyAttestation',
+			call: 'admin_verifyAttestation',
+			params: 2
+		}),
 		new web3._extend.Method({
 			name: 'startHTTP',
 			call: 'admin_startHTTP',
@@ -496,6 +658,35 @@ web3._extend({
 			name: 'syncStakingInfoStatus',
 			call: 'admin_syncStakingInfoStatus',
 		}),
+		new web3._extend.Method({
+			name: 'preconfirmTransaction',
+			call: 'admin_preconfirmTransaction',
+			params: 2,
+			inputFormatter: [null, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getPreconfirmation',
+			call: 'admin_getPreconfirmation',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'pendingPreconfirmations',
+			call: 'admin_pendingPreconfirmations',
+		}),
+		new web3._extend.Method({
+			name: 'preconfirmationBreaches',
+			call: 'admin_preconfirmationBreaches',
+		}),
+		new web3._extend.Method({
+			name: 'scheduleMaintenance',
+			call: 'admin_scheduleMaintenance',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'resumeMaintenance',
+			call: 'admin_resumeMaintenance',
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -506,6 +697,14 @@ web3._extend({
 			name: 'peers',
 			getter: 'admin_peers'
 		}),
+		new web3._extend.Property({
+			name: 'peerDiversity',
+			getter: 'admin_peerDiversity'
+		}),
+		new web3._extend.Property({
+			name: 'nodeAttestation',
+			getter: 'admin_nodeAttestation'
+		}),
 		new web3._extend.Property({
 			name: 'datadir',
 			getter: 'admin_datadir'
@@ -522,6 +721,10 @@ web3._extend({
 			name: 'nodeConfig',
 			getter: 'admin_nodeConfig',
 		}),
+		new web3._extend.Property({
+			name: 'nodeOverview',
+			getter: 'admin_nodeOverview',
+		}),
 	]
 });
 `
@@ -568,6 +771,21 @@ web3._extend({
 			call: 'debug_getBadBlocks',
 			params: 0,
 		}),
+		new web3._extend.Method({
+			name: 'exportReplayBundle',
+			call: 'debug_exportReplayBundle',
+			params: 2,
+		}),
+		new web3._extend.Method({
+			name: 'intermediateRoots',
+			call: 'debug_intermediateRoots',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'findDivergence',
+			call: 'debug_findDivergence',
+			params: 1,
+		}),
 		new web3._extend.Method({
 			name: 'printBlock',
 			call: 'debug_printBlock',
@@ -621,6 +839,14 @@ web3._extend({
 			call: 'debug_metrics',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'persistentCounters',
+			call: 'debug_persistentCounters',
+		}),
+		new web3._extend.Method({
+			name: 'rpcStats',
+			call: 'debug_rpcStats',
+		}),
 		new web3._extend.Method({
 			name: 'verbosity',
 			call: 'debug_verbosity',
@@ -789,6 +1015,18 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceReward',
+			call: 'debug_traceReward',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'traceBlockByNumberPaged',
+			call: 'debug_traceBlockByNumberPaged',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'traceBlockByNumberRange',
 			call: 'debug_traceBlockByNumberRange',
@@ -908,18 +1146,130 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getCouncilDetails',
+			call: 'klay_getCouncilDetails',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getCommitteeDetails',
+			call: 'klay_getCommitteeDetails',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'getRewards',
 			call: 'klay_getRewards',
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'getRewardsReport',
+			call: 'klay_getRewardsReport',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null],
+		}),
+		new web3._extend.Method({
+			name: 'getRewardsPaged',
+			call: 'klay_getRewardsPaged',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null],
+		}),
+		new web3._extend.Method({
+			name: 'getRewardsRange',
+			call: 'klay_getRewardsRange',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'getRewardSum',
+			call: 'klay_getRewardSum',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'getTopProposers',
+			call: 'klay_getTopProposers',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null],
+		}),
+		new web3._extend.Method({
+			name: 'getBurntFeesPerDay',
+			call: 'klay_getBurntFeesPerDay',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'simulateReward',
+			call: 'klay_simulateReward',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'getRewardStatement',
+			call: 'klay_getRewardStatement',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'exportRewardStatement',
+			call: 'klay_exportRewardStatement',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getAddressLabels',
+			call: 'klay_getAddressLabels',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getChainEconomics',
+			call: 'klay_getChainEconomics',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getTreasuryRebalance',
+			call: 'klay_getTreasuryRebalance',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getRewardProof',
+			call: 'klay_getRewardProof',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getRewardMerkleProof',
+			call: 'klay_getRewardMerkleProof',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'getStakingInfo',
 			call: 'klay_getStakingInfo',
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getStakingInfoPaged',
+			call: 'klay_getStakingInfoPaged',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getEffectiveStakes',
+			call: 'klay_getEffectiveStakes',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getLogsPaged',
+			call: 'klay_getLogsPaged',
+			params: 2,
+		}),
 		new web3._extend.Method({
 			name: 'getParams',
 			call: 'klay_getParams',
@@ -944,6 +1294,18 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'getAccountInfo',
+			call: 'klay_getAccountInfo',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'previewFee',
+			call: 'klay_previewFee',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputCallFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'getHeaderByNumber',
 			call: 'klay_getHeaderByNumber',
@@ -967,6 +1329,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getBlockWithConsensusInfoPage',
+			call: 'klay_getBlockWithConsensusInfoByNumberPage',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'isContractAccount',
 			call: 'klay_isContractAccount',
@@ -996,6 +1364,16 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getRewardVesting',
+			call: 'klay_getRewardVesting',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'maintenanceStatus',
+			call: 'klay_maintenanceStatus',
+		}),
 		new web3._extend.Method({
 			name: 'getRawTransactionFromBlock',
 			call: function(args) {
@@ -1032,6 +1410,21 @@ web3._extend({
 			call: 'klay_recoverFromMessage',
 			params: 4
 		}),
+		new web3._extend.Method({
+			name: 'appendSignature',
+			call: 'klay_appendSignature',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'getSignatureWeight',
+			call: 'klay_getSignatureWeight',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'sendMultiSigTransaction',
+			call: 'klay_sendMultiSigTransaction',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'getCypressCredit',
 			call: 'klay_getCypressCredit',
@@ -1218,7 +1611,13 @@ web3._extend({
 const TxPool_JS = `
 web3._extend({
 	property: 'txpool',
-	methods: [],
+	methods: [
+		new web3._extend.Method({
+			name: 'contentPaged',
+			call: 'txpool_contentPaged',
+			params: 1,
+		}),
+	],
 	properties:
 	[
 		new web3._extend.Property({
@@ -1284,6 +1683,11 @@ web3._extend({
 			name: 'discard',
 			call: 'istanbul_discard',
 			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'announce',
+			call: 'istanbul_announce',
+			params: 2
 		})
 	],
 	properties:
@@ -1295,6 +1699,18 @@ web3._extend({
 		new web3._extend.Property({
 			name: 'timeout',
 			getter: 'istanbul_getTimeout'
+		}),
+		new web3._extend.Property({
+			name: 'announcements',
+			getter: 'istanbul_getAnnouncements'
+		}),
+		new web3._extend.Property({
+			name: 'stallStatus',
+			getter: 'istanbul_getStallStatus'
+		}),
+		new web3._extend.Property({
+			name: 'standbyStatus',
+			getter: 'istanbul_getStandbyStatus'
 		})
 	]
 });
@@ -1358,6 +1774,11 @@ web3._extend({
 			call: 'subbridge_registerOperator',
 			params: 2
 		}),
+		new web3._extend.Method({
+			name: 'deregisterOperator',
+			call: 'subbridge_deregisterOperator',
+			params: 2
+		}),
 		new web3._extend.Method({
 			name: 'getOperators',
 			call: 'subbridge_getRegisteredOperators',
@@ -1373,6 +1794,41 @@ web3._extend({
 			call: 'subbridge_setValueTransferOperatorThreshold',
 			params: 2
 		}),
+		new web3._extend.Method({
+			name: 'getConfigurationOperatorThreshold',
+			call: 'subbridge_getConfigurationOperatorThreshold',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'setConfigurationOperatorThreshold',
+			call: 'subbridge_setConfigurationOperatorThreshold',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'isValueTransferVoteClosed',
+			call: 'subbridge_isValueTransferVoteClosed',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'registerMessageBridge',
+			call: 'subbridge_registerMessageBridge',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'deregisterMessageBridge',
+			call: 'subbridge_deregisterMessageBridge',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'registerMessageHandler',
+			call: 'subbridge_registerMessageHandler',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'deregisterMessageHandler',
+			call: 'subbridge_deregisterMessageHandler',
+			params: 2
+		}),
 		new web3._extend.Method({
 			name: 'deployBridge',
 			call: 'subbridge_deployBridge',
@@ -1586,6 +2042,10 @@ web3._extend({
 			name: 'listBridge',
 			getter: 'subbridge_listBridge'
 		}),
+		new web3._extend.Property({
+			name: 'messageBridges',
+			getter: 'subbridge_getMessageBridges'
+		}),
 		new web3._extend.Property({
 			name: 'txPendingCount',
 			getter: 'subbridge_txPendingCount'
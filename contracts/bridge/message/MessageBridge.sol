@@ -0,0 +1,142 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+pragma solidity 0.5.6;
+
+import "../BridgeOperator.sol";
+import "../BridgeCounterPart.sol";
+import "../BridgeHandledRequests.sol";
+import "./IMessageReceiver.sol";
+
+
+// MessageBridge relays arbitrary call data between the contracts of a parent chain and a child chain.
+// A message requested on a chain is handled on the counterpart chain once the operator threshold
+// of votes is reached, and each request nonce and request tx hash can be handled only once.
+contract MessageBridge is BridgeCounterPart, BridgeOperator, BridgeHandledRequests {
+    uint64 public constant VERSION = 1;
+    uint256 public constant MAX_HANDLER_GAS_LIMIT = 10000000;
+    uint256 public constant HANDLER_GAS_MARGIN = 50000; // gas for the event and the return after the handler call
+
+    uint64 public requestNonce;
+    uint64 public lowerHandleNonce; // all messages whose nonce is lower than it have been handled
+
+    uint256 public handlerGasLimit = 1000000;
+    mapping(address => bool) public handlers; // receivers allowed to be called by this bridge
+    mapping(uint64 => bytes32) public requestedMessageHashes; // <request nonce, message hash>
+
+    event RequestMessage(
+        uint64 indexed requestNonce,
+        address indexed from,
+        address indexed to,
+        bytes data
+    );
+
+    event HandleMessage(
+        bytes32 requestTxHash,
+        uint64 indexed handleNonce,
+        address indexed from,
+        address indexed to,
+        bool success
+    );
+
+    event HandlerRegistered(address indexed handler);
+    event HandlerDeregistered(address indexed handler);
+
+    // registerHandler allows the contract to receive messages from the counterpart chain.
+    function registerHandler(address _handler)
+        external
+        onlyOwner
+    {
+        require(!handlers[_handler], "registered handler");
+        handlers[_handler] = true;
+        emit HandlerRegistered(_handler);
+    }
+
+    // deregisterHandler disallows the contract to receive messages from the counterpart chain.
+    function deregisterHandler(address _handler)
+        external
+        onlyOwner
+    {
+        require(handlers[_handler], "unregistered handler");
+        delete handlers[_handler];
+        emit HandlerDeregistered(_handler);
+    }
+
+    // setHandlerGasLimit sets the gas limit for calling a message handler.
+    function setHandlerGasLimit(uint256 _gasLimit)
+        external
+        onlyOwner
+    {
+        require(_gasLimit <= MAX_HANDLER_GAS_LIMIT, "too large gas limit");
+        handlerGasLimit = _gasLimit;
+    }
+
+    // messageHash returns the hash committing to a message requested on the bridge.
+    // Relayers check it against requestedMessageHashes of the requesting bridge before handling the message.
+    function messageHash(address _bridge, uint64 _requestNonce, address _from, address _to, bytes memory _data)
+        public
+        pure
+        returns(bytes32)
+    {
+        return keccak256(abi.encode(_bridge, _requestNonce, _from, _to, _data));
+    }
+
+    // requestMessage requests to relay the data to the contract on the counterpart chain.
+    function requestMessage(address _to, bytes calldata _data)
+        external
+    {
+        requestedMessageHashes[requestNonce] = messageHash(address(this), requestNonce, msg.sender, _to, _data);
+        emit RequestMessage(requestNonce, msg.sender, _to, _data);
+        requestNonce++;
+    }
+
+    // handleMessage calls the handler with the message requested on the counterpart chain.
+    // A failure of the handler does not revert the vote, so that the message is not replayed.
+    // The closing vote reverts unless it leaves enough gas for the handler, so that an underfunded
+    // transaction cannot close the vote without the handler being called with handlerGasLimit.
+    function handleMessage(
+        bytes32 _requestTxHash,
+        address _from,
+        address _to,
+        bytes calldata _data,
+        uint64 _requestedNonce,
+        uint64 _requestedBlockNumber
+    )
+        external
+        onlyOperators
+    {
+        require(_requestedNonce >= lowerHandleNonce, "lower handle nonce");
+        require(!handledRequestTx[_requestTxHash], "handled request");
+        require(handlers[_to], "unregistered handler");
+
+        if (!_voteValueTransfer(_requestedNonce)) {
+            return;
+        }
+
+        _setHandledRequestTxHash(_requestTxHash);
+        while (closedValueTransferVotes[lowerHandleNonce]) {
+            lowerHandleNonce++;
+        }
+
+        // A call forwards at most 63/64 of the remaining gas.
+        require(gasleft() >= handlerGasLimit * 64 / 63 + HANDLER_GAS_MARGIN, "insufficient gas for the handler");
+        (bool success, ) = _to.call.gas(handlerGasLimit)(
+            abi.encodeWithSelector(IMessageReceiver(_to).onMessageReceived.selector, _from, _data)
+        );
+
+        emit HandleMessage(_requestTxHash, _requestedNonce, _from, _to, success);
+    }
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+pragma solidity ^0.8.0;
+
+// FeePayerAllowance is the registry where the fee payers publish the policies of the
+// fee-delegated transactions they are willing to pay for. The txpools configured with
+// the registry (--txpool.feepayer-allowance-registry) reject the fee-delegated
+// transactions violating the policy of their fee payer before admitting them.
+//
+// The txpool reads the storage of the registry directly, so the storage layout must not
+// be changed.
+contract FeePayerAllowance {
+    struct Policy {
+        bool enabled;          // slot p, the lowest-order byte
+        bool allowAllSenders;  // slot p, the second lowest-order byte
+        uint256 dailyGasCap;   // slot p + 1, zero means no cap
+    }
+
+    // slot 0
+    mapping(address => Policy) public policies;
+    // slot 1, fee payer => sender => allowed
+    mapping(address => mapping(address => bool)) public allowedSenders;
+
+    event PolicySet(address indexed feePayer, bool allowAllSenders, uint256 dailyGasCap);
+    event PolicyRemoved(address indexed feePayer);
+    event SenderSet(address indexed feePayer, address indexed sender, bool allowed);
+
+    // setPolicy publishes the policy of the caller. The daily gas cap limits the sum of
+    // the gas limits of the transactions admitted by a txpool per UTC day of the block time.
+    function setPolicy(bool allowAllSenders, uint256 dailyGasCap) external {
+        policies[msg.sender] = Policy(true, allowAllSenders, dailyGasCap);
+        emit PolicySet(msg.sender, allowAllSenders, dailyGasCap);
+    }
+
+    // removePolicy removes the policy of the caller, so that any transaction is admitted.
+    function removePolicy() external {
+        delete policies[msg.sender];
+        emit PolicyRemoved(msg.sender);
+    }
+
+    // setSenders allows or disallows the senders for the caller.
+    function setSenders(address[] calldata senders, bool allowed) external {
+        for (uint256 i = 0; i < senders.length; i++) {
+            allowedSenders[msg.sender][senders[i]] = allowed;
+            emit SenderSet(msg.sender, senders[i], allowed);
+        }
+    }
+}
@@ -17,22 +17,25 @@
 package governance
 
 import (
-	"errors"
-	"fmt"
+	"encoding/json"
 	"math/big"
 	"runtime"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
 )
 
 type GovernanceAPI struct {
-	governance Engine // Node interfaced by this API
+	governance       Engine                       // Node interfaced by this API
+	labels           *reward.AddressLabelRegistry // Annotates addresses of responses if not nil
+	validatorChanges *validatorChangeLog          // Validator changes requested through the admin endpoint
 }
 
 type returnTally struct {
@@ -42,35 +45,196 @@ type returnTally struct {
 }
 
 func NewGovernanceAPI(gov Engine) *GovernanceAPI {
-	return &GovernanceAPI{governance: gov}
+	return &GovernanceAPI{governance: gov, validatorChanges: &validatorChangeLog{}}
+}
+
+// SetAddressLabels makes the reward and staking information responses annotated with the given labels.
+func (api *GovernanceAPI) SetAddressLabels(labels *reward.AddressLabelRegistry) {
+	api.labels = labels
 }
 
 type GovernanceKlayAPI struct {
-	governance Engine
-	chain      blockChain
+	governance  Engine
+	chain       blockChain
+	labels      *reward.AddressLabelRegistry
+	sign        func(data []byte) ([]byte, error) // Signs reward statements if not nil
+	checkpoints database.DBManager                // Serves block rewards from the reward checkpoints if not nil
+	index       database.DBManager                // Serves the reward aggregations from the reward index if not nil
+	pending     func() *types.Block               // Returns the pending block for the projected block reward if not nil
+	report      *reward.RewardReportFormat        // Re-expresses the amounts of the reward reports
 }
 
 func NewGovernanceKlayAPI(gov Engine, chain blockChain) *GovernanceKlayAPI {
-	return &GovernanceKlayAPI{governance: gov, chain: chain}
+	return &GovernanceKlayAPI{governance: gov, chain: chain, report: reward.DefaultRewardReportFormat}
+}
+
+// SetAddressLabels makes the reward and staking information responses annotated with the given labels.
+func (api *GovernanceKlayAPI) SetAddressLabels(labels *reward.AddressLabelRegistry) {
+	api.labels = labels
+}
+
+// SetRewardCheckpoints makes the block rewards served from the reward checkpoints in the given database
+// if they exist, so that the rewards of the blocks whose staking information is pruned are served.
+func (api *GovernanceKlayAPI) SetRewardCheckpoints(db database.DBManager) {
+	api.checkpoints = db
+}
+
+// SetRewardIndex makes the reward aggregations served from the reward index in the given database.
+func (api *GovernanceKlayAPI) SetRewardIndex(db database.DBManager) {
+	api.index = db
+}
+
+// SetStatementSigner makes the reward statements signed by the given function, which signs the
+// Keccak256 hash of the data with the node key.
+func (api *GovernanceKlayAPI) SetStatementSigner(sign func(data []byte) ([]byte, error)) {
+	api.sign = sign
 }
 
+// SetRewardReportFormat sets the default format of the reward reports.
+func (api *GovernanceKlayAPI) SetRewardReportFormat(format *reward.RewardReportFormat) {
+	api.report = format
+}
+
+// SetPendingBlock makes the block reward of the pending block projected from the block returned by the given function.
+func (api *GovernanceKlayAPI) SetPendingBlock(pending func() *types.Block) {
+	api.pending = pending
+}
+
+// GovernanceDebugAPI is the governance APIs served in the debug namespace.
+type GovernanceDebugAPI struct {
+	klay *GovernanceKlayAPI
+}
+
+func NewGovernanceDebugAPI(klay *GovernanceKlayAPI) *GovernanceDebugAPI {
+	return &GovernanceDebugAPI{klay: klay}
+}
+
+// TraceReward recalculates the block reward at a given block number, returning each intermediate
+// value of the calculation: the fee burnt, the ratio and KIP-82 splits, the effective stakes and
+// the shares of the CNs, and the remainders. Unlike GetRewards, it is not served from the reward
+// checkpoints, so the staking information of the block should not be pruned.
+func (api *GovernanceDebugAPI) TraceReward(num *rpc.BlockNumber) (*reward.RewardTrace, error) {
+	if num != nil && *num == rpc.PendingBlockNumber {
+		return nil, errInvalidParamValue.Errorf("the pending block cannot be traced").WithDetail("param", "blockNumber")
+	}
+	blockNumber := api.klay.chain.CurrentBlock().NumberU64()
+	if num != nil && *num != rpc.LatestBlockNumber {
+		blockNumber = uint64(num.Int64())
+	}
+	header := api.klay.chain.GetHeaderByNumber(blockNumber)
+	if header == nil {
+		return nil, blockNotFoundError(blockNumber)
+	}
+	rules, rewardParamSet, err := api.klay.rewardParams(header)
+	if err != nil {
+		return nil, err
+	}
+	return reward.TraceBlockReward(header, rules, rewardParamSet)
+}
+
+// The errors of the governance APIs. Their codes and reasons are stable, see rpc.APIError.
 var (
-	errUnknownBlock           = errors.New("Unknown block")
-	errNotAvailableInThisMode = errors.New("In current governance mode, voting power is not available")
-	errSetDefaultFailure      = errors.New("Failed to set a default value")
-	errPermissionDenied       = errors.New("You don't have the right to vote")
-	errRemoveSelf             = errors.New("You can't vote on removing yourself")
-	errInvalidKeyValue        = errors.New("Your vote couldn't be placed. Please check your vote's key and value")
-	errInvalidLowerBound      = errors.New("lowerboundbasefee cannot be set exceeding upperboundbasefee")
-	errInvalidUpperBound      = errors.New("upperboundbasefee cannot be set lower than lowerboundbasefee")
+	errUnknownBlock           = rpc.NewAPIError(rpc.CodeResourceNotFound, "unknownBlock", "Unknown block")
+	errNotAvailableInThisMode = rpc.NewAPIError(rpc.CodeMethodNotSupported, "notAvailableInThisMode", "In current governance mode, voting power is not available")
+	errSetDefaultFailure      = rpc.NewAPIError(rpc.CodeInternalError, "setDefaultFailure", "Failed to set a default value")
+	errPermissionDenied       = rpc.NewAPIError(rpc.CodePermissionDenied, "permissionDenied", "You don't have the right to vote")
+	errRemoveSelf             = rpc.NewAPIError(rpc.CodeInvalidInput, "removeSelf", "You can't vote on removing yourself")
+	errInvalidKeyValue        = rpc.NewAPIError(rpc.CodeInvalidInput, "invalidVote", "Your vote couldn't be placed. Please check your vote's key and value")
+	errInvalidLowerBound      = rpc.NewAPIError(rpc.CodeInvalidInput, "invalidLowerBoundBaseFee", "lowerboundbasefee cannot be set exceeding upperboundbasefee")
+	errInvalidUpperBound      = rpc.NewAPIError(rpc.CodeInvalidInput, "invalidUpperBoundBaseFee", "upperboundbasefee cannot be set lower than lowerboundbasefee")
+	errNoAddressLabels        = rpc.NewAPIError(rpc.CodeResourceUnavailable, "addressLabelsDisabled", "address labels are not enabled")
+	errNoPendingBlock         = rpc.NewAPIError(rpc.CodeResourceUnavailable, "pendingBlockUnavailable", "pending block is not available")
+	errBlockNotFound          = rpc.NewAPIError(rpc.CodeResourceNotFound, "blockNotFound", "the block does not exist")
+	errInvalidBlockRange      = rpc.NewAPIError(rpc.CodeInvalidInput, "invalidBlockRange", "invalid block range")
+	errBlockRangeTooLarge     = rpc.NewAPIError(rpc.CodeLimitExceeded, "blockRangeTooLarge", "block range is too large")
+	errInvalidParamValue      = rpc.NewAPIError(rpc.CodeInvalidParams, "invalidParamValue", "invalid value of the parameter")
 )
 
+// blockNotFoundError returns the error of the missing block of the given number.
+func blockNotFoundError(num uint64) error {
+	return errBlockNotFound.Errorf("the block does not exist (block number: %d)", num).WithDetail("blockNumber", num)
+}
+
 func (api *GovernanceKlayAPI) GetChainConfig(num *rpc.BlockNumber) *params.ChainConfig {
 	return getChainConfig(api.governance, num)
 }
 
-func (api *GovernanceKlayAPI) GetStakingInfo(num *rpc.BlockNumber) (*reward.StakingInfo, error) {
-	return getStakingInfo(api.governance, num)
+func (api *GovernanceKlayAPI) GetStakingInfo(num *rpc.BlockNumber) (interface{}, error) {
+	return getStakingInfo(api.governance, api.labels, num)
+}
+
+// CouncilStaking is the staking information of a council member returned by GetStakingInfoPaged.
+type CouncilStaking struct {
+	NodeAddress    common.Address `json:"nodeAddress"`
+	StakingAddress common.Address `json:"stakingAddress"`
+	RewardAddress  common.Address `json:"rewardAddress"`
+	StakingAmount  uint64         `json:"stakingAmount"`
+}
+
+// GetStakingInfoPaged returns a page of the council members in the staking information at a
+// given block number. The cursor is the index of the next member.
+func (api *GovernanceKlayAPI) GetStakingInfoPaged(num *rpc.BlockNumber, page *rpc.PageArgs) (*rpc.Page, error) {
+	limit, err := page.PageLimit()
+	if err != nil {
+		return nil, err
+	}
+	var start uint64
+	if _, err := page.DecodeCursor(&start); err != nil {
+		return nil, err
+	}
+	stakingInfo := reward.GetStakingInfo(resolveBlockNumber(api.chain, num))
+	if stakingInfo == nil {
+		return nil, errUnknownBlock
+	}
+
+	items := make([]CouncilStaking, 0, limit)
+	for i := int(start); i < len(stakingInfo.CouncilNodeAddrs); i++ {
+		if len(items) == limit {
+			return rpc.NewPage(items, uint64(i))
+		}
+		item := CouncilStaking{
+			NodeAddress:    stakingInfo.CouncilNodeAddrs[i],
+			StakingAddress: stakingInfo.CouncilStakingAddrs[i],
+			RewardAddress:  stakingInfo.CouncilRewardAddrs[i],
+		}
+		if i < len(stakingInfo.CouncilStakingAmounts) {
+			item.StakingAmount = stakingInfo.CouncilStakingAmounts[i]
+		}
+		items = append(items, item)
+	}
+	return rpc.NewPage(items, nil)
+}
+
+// GetEffectiveStakes returns, for each CN, the stake, the minimum stake and the effective stake
+// by which the stakers portion of the block reward at a given block number is shared.
+func (api *GovernanceKlayAPI) GetEffectiveStakes(num *rpc.BlockNumber) (*reward.EffectiveStakes, error) {
+	if num != nil && *num == rpc.PendingBlockNumber {
+		return nil, errInvalidParamValue.Errorf("the pending block has no effective stakes").WithDetail("param", "blockNumber")
+	}
+	blockNumber := resolveBlockNumber(api.chain, num)
+	header := api.chain.GetHeaderByNumber(blockNumber)
+	if header == nil {
+		return nil, blockNotFoundError(blockNumber)
+	}
+	rules, rewardParamSet, err := api.rewardParams(header)
+	if err != nil {
+		return nil, err
+	}
+	stakingInfo := reward.GetStakingInfo(blockNumber)
+	if stakingInfo == nil {
+		return nil, errUnknownBlock
+	}
+	stakes := reward.CalcEffectiveStakes(stakingInfo, rewardParamSet.MinimumStakeBig().Uint64(), reward.GetMinStakeInclusive(rules, rewardParamSet))
+	stakes.BlockNumber = blockNumber
+	return stakes, nil
+}
+
+// GetAddressLabels returns the labels of the addresses known at a given block number.
+func (api *GovernanceKlayAPI) GetAddressLabels(num *rpc.BlockNumber) (map[common.Address]reward.AddressLabel, error) {
+	if api.labels == nil {
+		return nil, errNoAddressLabels
+	}
+	return api.labels.All(reward.GetStakingInfo(resolveBlockNumber(api.chain, num))), nil
 }
 
 func (api *GovernanceKlayAPI) GetParams(num *rpc.BlockNumber) (map[string]interface{}, error) {
@@ -82,7 +246,12 @@ func (api *GovernanceKlayAPI) NodeAddress() common.Address {
 }
 
 // GetRewards returns detailed information of the block reward at a given block number.
+// The block reward of the pending block is projected from the txs in it, which may change until it is sealed.
 func (api *GovernanceKlayAPI) GetRewards(num *rpc.BlockNumber) (*reward.RewardSpec, error) {
+	if num != nil && *num == rpc.PendingBlockNumber {
+		return api.pendingReward()
+	}
+
 	blockNumber := uint64(0)
 	if num == nil || *num == rpc.LatestBlockNumber {
 		blockNumber = api.chain.CurrentBlock().NumberU64()
@@ -92,21 +261,172 @@ func (api *GovernanceKlayAPI) GetRewards(num *rpc.BlockNumber) (*reward.RewardSp
 
 	header := api.chain.GetHeaderByNumber(blockNumber)
 	if header == nil {
-		return nil, fmt.Errorf("the block does not exist (block number: %d)", blockNumber)
+		return nil, blockNotFoundError(blockNumber)
+	}
+
+	spec, err := api.blockReward(header)
+	if err != nil {
+		return nil, err
+	}
+	api.attachRewardSlot(header, spec)
+	if api.labels == nil {
+		return spec, nil
 	}
+	spec.Labels = api.labels.Labels(reward.GetStakingInfo(blockNumber), rewardRecipients(spec.Rewards)...)
+	return spec, nil
+}
 
+// GetRewardsReport returns the block reward at a given block number re-expressed by the report
+// format of "unit[:decimals[:rounding]]", e.g. "klay:6:half-even", for the accounting of the rewards.
+// The format defaults to the one configured by --reward.report-format.
+func (api *GovernanceKlayAPI) GetRewardsReport(num *rpc.BlockNumber, format *string) (*reward.RewardReport, error) {
+	reportFormat := api.report
+	if format != nil {
+		var err error
+		if reportFormat, err = reward.ParseRewardReportFormat(*format); err != nil {
+			return nil, errInvalidParamValue.Wrap(err).WithDetail("param", "format")
+		}
+	}
+	spec, err := api.GetRewards(num)
+	if err != nil {
+		return nil, err
+	}
+	return reward.NewRewardReport(spec, reportFormat), nil
+}
+
+// pendingReward returns the block reward of the pending block with the latest staking information.
+// The proposer of the pending block is the node itself.
+func (api *GovernanceKlayAPI) pendingReward() (*reward.RewardSpec, error) {
+	if api.pending == nil {
+		return nil, errNoPendingBlock
+	}
+	block := api.pending()
+	if block == nil {
+		return nil, errNoPendingBlock
+	}
+	header := block.Header()
+	rules, rewardParamSet, err := api.rewardParams(header)
+	if err != nil {
+		return nil, err
+	}
+	stakingInfo := reward.GetStakingInfo(header.Number.Uint64())
+	spec, err := reward.GetPendingBlockReward(header, rules, rewardParamSet, stakingInfo, api.governance.NodeAddress())
+	if err != nil || api.labels == nil {
+		return spec, err
+	}
+	spec.Labels = api.labels.Labels(stakingInfo, rewardRecipients(spec.Rewards)...)
+	return spec, nil
+}
+
+// BlockRewards is the block reward of a block returned by GetRewardsPaged.
+type BlockRewards struct {
+	Number  uint64             `json:"number"`
+	Rewards *reward.RewardSpec `json:"rewards"`
+}
+
+// GetRewardsPaged returns a page of the block rewards in the block range of [first, last].
+// The cursor is the number of the next block.
+func (api *GovernanceKlayAPI) GetRewardsPaged(first, last rpc.BlockNumber, page *rpc.PageArgs) (*rpc.Page, error) {
+	limit, err := page.PageLimit()
+	if err != nil {
+		return nil, err
+	}
+	firstBlock, lastBlock, err := api.resolveBlockRange(first, last)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := page.DecodeCursor(&firstBlock); err != nil {
+		return nil, err
+	}
+
+	calc := newRewardCalculator(api)
+	items := make([]BlockRewards, 0, limit)
+	for num := firstBlock; num <= lastBlock; num++ {
+		if len(items) == limit {
+			return rpc.NewPage(items, num)
+		}
+		header := api.chain.GetHeaderByNumber(num)
+		if header == nil {
+			return nil, blockNotFoundError(num)
+		}
+		spec, err := calc.blockReward(header)
+		if err != nil {
+			return nil, err
+		}
+		api.attachRewardSlot(header, spec)
+		items = append(items, BlockRewards{Number: num, Rewards: spec})
+	}
+	return rpc.NewPage(items, nil)
+}
+
+// blockReward returns the block reward of the given header with the parameters in effect.
+func (api *GovernanceKlayAPI) blockReward(header *types.Header) (*reward.RewardSpec, error) {
+	if spec := api.checkpointedReward(header); spec != nil {
+		return spec, nil
+	}
+	rules, rewardParamSet, err := api.rewardParams(header)
+	if err != nil {
+		return nil, err
+	}
+	return reward.GetBlockReward(header, rules, rewardParamSet)
+}
+
+// checkpointedReward returns the block reward of the given header persisted in the reward checkpoint.
+// It returns nil if the checkpoint does not exist or was written for a block abandoned by a reorg.
+func (api *GovernanceKlayAPI) checkpointedReward(header *types.Header) *reward.RewardSpec {
+	if api.checkpoints == nil {
+		return nil
+	}
+	checkpoint := api.checkpoints.ReadRewardCheckpoint(header.Number.Uint64())
+	if checkpoint == nil || checkpoint.Hash != header.Hash() {
+		return nil
+	}
+	spec := new(reward.RewardSpec)
+	if err := json.Unmarshal(checkpoint.Spec, spec); err != nil {
+		logger.Error("Invalid reward checkpoint", "blockNum", header.Number, "err", err)
+		return nil
+	}
+	return spec
+}
+
+// attachRewardSlot sets the proposer slot of the spec if the block was produced after a round change.
+// The reward is served without the slot if the consensus information is not available.
+func (api *GovernanceKlayAPI) attachRewardSlot(header *types.Header, spec *reward.RewardSpec) {
+	if spec.Slot != nil {
+		return
+	}
+	slot, err := reward.GetRewardSlot(api.chain, header)
+	if err != nil {
+		logger.Debug("Failed to get the proposer slot", "blockNum", header.Number, "err", err)
+		return
+	}
+	spec.Slot = slot
+}
+
+// rewardParams returns the hardfork rules and the governance parameters with which
+// the block reward of the given header is calculated.
+func (api *GovernanceKlayAPI) rewardParams(header *types.Header) (params.Rules, *params.GovParamSet, error) {
+	blockNumber := header.Number.Uint64()
 	rules := api.chain.Config().Rules(new(big.Int).SetUint64(blockNumber))
 	pset, err := api.governance.EffectiveParams(blockNumber)
 	if err != nil {
-		return nil, err
+		return rules, nil, err
 	}
 	rewardParamNum := reward.CalcRewardParamBlock(header.Number.Uint64(), pset.Epoch(), rules)
 	rewardParamSet, err := api.governance.EffectiveParams(rewardParamNum)
 	if err != nil {
-		return nil, err
+		return rules, nil, err
 	}
+	return rules, rewardParamSet, nil
+}
 
-	return reward.GetBlockReward(header, rules, rewardParamSet)
+// rewardRecipients returns the addresses of the given rewards.
+func rewardRecipients(rewards map[common.Address]*big.Int) []common.Address {
+	addrs := make([]common.Address, 0, len(rewards))
+	for addr := range rewards {
+		addrs = append(addrs, addr)
+	}
+	return addrs
 }
 
 type AccumulatedRewards struct {
@@ -124,6 +444,8 @@ type AccumulatedRewards struct {
 	TotalKFFRewards      *big.Int                    `json:"totalKFFRewards"`
 	TotalKCFRewards      *big.Int                    `json:"totalKCFRewards"`
 	Rewards              map[common.Address]*big.Int `json:"rewards"`
+
+	Labels map[common.Address]reward.AddressLabel `json:"labels,omitempty"`
 }
 
 // GetRewardsAccumulated returns accumulated rewards data in the block range of [first, last].
@@ -144,16 +466,16 @@ func (api *GovernanceAPI) GetRewardsAccumulated(first rpc.BlockNumber, last rpc.
 	}
 
 	if firstBlock > lastBlock {
-		return nil, errors.New("the last block number should be equal or larger the first block number")
+		return nil, errInvalidBlockRange.Errorf("the last block number should be equal or larger the first block number")
 	}
 
 	if lastBlock > currentBlock {
-		return nil, errors.New("the last block number should be equal or less than the current block number")
+		return nil, errInvalidBlockRange.Errorf("the last block number should be equal or less than the current block number")
 	}
 
 	blockCount := lastBlock - firstBlock + 1
 	if blockCount > 604800 { // 7 days. naive resource protection
-		return nil, errors.New("block range should be equal or less than 604800")
+		return nil, errBlockRangeTooLarge.Errorf("block range should be equal or less than 604800")
 	}
 
 	// initialize structures before request a job
@@ -191,7 +513,7 @@ func (api *GovernanceAPI) GetRewardsAccumulated(first rpc.BlockNumber, last rpc.
 	// write the information of the first block
 	header := blockchain.GetHeaderByNumber(firstBlock)
 	if header == nil {
-		return nil, fmt.Errorf("the block does not exist (block number: %d)", firstBlock)
+		return nil, blockNotFoundError(firstBlock)
 	}
 	accumRewards.FirstBlock = header.Number
 	accumRewards.FirstBlockTime = time.Unix(header.Time.Int64(), 0).String()
@@ -199,7 +521,7 @@ func (api *GovernanceAPI) GetRewardsAccumulated(first rpc.BlockNumber, last rpc.
 	// write the information of the last block
 	header = blockchain.GetHeaderByNumber(lastBlock)
 	if header == nil {
-		return nil, fmt.Errorf("the block does not exist (block number: %d)", lastBlock)
+		return nil, blockNotFoundError(lastBlock)
 	}
 	accumRewards.LastBlock = header.Number
 	accumRewards.LastBlockTime = time.Unix(header.Time.Int64(), 0).String()
@@ -229,6 +551,10 @@ func (api *GovernanceAPI) GetRewardsAccumulated(first rpc.BlockNumber, last rpc.
 	accumRewards.TotalKFFRewards = blockRewards.KFF
 	accumRewards.TotalKCFRewards = blockRewards.KCF
 
+	if api.labels != nil {
+		accumRewards.Labels = api.labels.Labels(reward.GetStakingInfo(lastBlock), rewardRecipients(accumRewards.Rewards)...)
+	}
+
 	return accumRewards, nil
 }
 
@@ -321,18 +647,46 @@ func getParams(governance Engine, num *rpc.BlockNumber) (map[string]interface{},
 	return pset.StrMap(), nil
 }
 
-func (api *GovernanceAPI) GetStakingInfo(num *rpc.BlockNumber) (*reward.StakingInfo, error) {
-	return getStakingInfo(api.governance, num)
+func (api *GovernanceAPI) GetStakingInfo(num *rpc.BlockNumber) (interface{}, error) {
+	return getStakingInfo(api.governance, api.labels, num)
 }
 
-func getStakingInfo(governance Engine, num *rpc.BlockNumber) (*reward.StakingInfo, error) {
-	blockNumber := uint64(0)
+// labeledStakingInfo is the staking information annotated with the labels of its addresses.
+type labeledStakingInfo struct {
+	*reward.StakingInfo
+	Labels map[common.Address]reward.AddressLabel
+}
+
+// MarshalJSON adds the labels to the JSON object of the staking information.
+func (l *labeledStakingInfo) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(l.StakingInfo)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields["labels"], err = json.Marshal(l.Labels); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// getStakingInfo returns the staking information, which is annotated if the labels are given.
+func getStakingInfo(governance Engine, labels *reward.AddressLabelRegistry, num *rpc.BlockNumber) (interface{}, error) {
+	stakingInfo := reward.GetStakingInfo(resolveBlockNumber(governance.BlockChain(), num))
+	if stakingInfo == nil || labels == nil {
+		return stakingInfo, nil
+	}
+	return &labeledStakingInfo{StakingInfo: stakingInfo, Labels: labels.All(stakingInfo)}, nil
+}
+
+func resolveBlockNumber(chain blockChain, num *rpc.BlockNumber) uint64 {
 	if num == nil || *num == rpc.LatestBlockNumber || *num == rpc.PendingBlockNumber {
-		blockNumber = governance.BlockChain().CurrentBlock().NumberU64()
-	} else {
-		blockNumber = uint64(num.Int64())
+		return chain.CurrentBlock().NumberU64()
 	}
-	return reward.GetStakingInfo(blockNumber), nil
+	return uint64(num.Int64())
 }
 
 func (api *GovernanceAPI) PendingChanges() map[string]interface{} {
@@ -422,11 +776,27 @@ func getChainConfig(governance Engine, num *rpc.BlockNumber) *params.ChainConfig
 	config.CancunCompatibleBlock = latestConfig.CancunCompatibleBlock
 	config.Kip103CompatibleBlock = latestConfig.Kip103CompatibleBlock
 	config.Kip103ContractAddress = latestConfig.Kip103ContractAddress
+	config.Kip103RecordMemo = latestConfig.Kip103RecordMemo
 	config.RandaoCompatibleBlock = latestConfig.RandaoCompatibleBlock
+	config.RewardRedirectCompatibleBlock = latestConfig.RewardRedirectCompatibleBlock
+	config.StakingCommitmentCompatibleBlock = latestConfig.StakingCommitmentCompatibleBlock
+	config.RewardPayoutCompatibleBlock = latestConfig.RewardPayoutCompatibleBlock
+	config.PebStakingCompatibleBlock = latestConfig.PebStakingCompatibleBlock
+	config.RewardSplitCompatibleBlock = latestConfig.RewardSplitCompatibleBlock
+	config.RewardVestingCompatibleBlock = latestConfig.RewardVestingCompatibleBlock
+	config.RewardVesting = latestConfig.RewardVesting
+	config.Gasless = latestConfig.Gasless
 
 	return config
 }
 
+// CheckHardforkReadiness checks the prerequisites of the named hardfork on this node, such
+// as the required parameters, the contracts and the BLS keys, for the council to confirm
+// that the nodes are ready before the fork block.
+func (api *GovernanceAPI) CheckHardforkReadiness(name string) (*HardforkReadiness, error) {
+	return checkHardforkReadiness(api.governance, name)
+}
+
 func (api *GovernanceAPI) NodeAddress() common.Address {
 	return api.governance.NodeAddress()
 }
@@ -10,13 +10,16 @@ import (
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/reward/proof"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/klaytn/klaytn/work/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type testBlockChain struct {
@@ -161,10 +164,373 @@ func TestGetRewards(t *testing.T) {
 				Rewards: map[common.Address]*big.Int{
 					proposer: minted,
 				},
+				Breakdown: map[common.Address]*reward.RewardBreakdown{
+					proposer: {Proposer: minted},
+				},
 			}
 			assert.Equal(t, expectedRewardSpec, rewardSpec, "wrong at block %d", num)
+
+			// the reward recipients are annotated if the labels are set
+			labeledApi := NewGovernanceKlayAPI(e, bc)
+			labeledApi.SetAddressLabels(reward.NewAddressLabelRegistry(map[common.Address]reward.AddressLabel{
+				proposer: {Name: "proposer"},
+			}))
+			rewardSpec, err = labeledApi.GetRewards(&latestNum)
+			assert.Nil(t, err)
+			assert.Equal(t, map[common.Address]reward.AddressLabel{proposer: {Name: "proposer"}}, rewardSpec.Labels)
+		}
+	}
+}
+
+func TestGetRewardsPaged(t *testing.T) {
+	config := getTestConfig()
+	config.Governance.Reward.MintingAmount = big.NewInt(1)
+	config.Istanbul.Epoch = 3
+	config.KoreCompatibleBlock = big.NewInt(9)
+
+	bc := newTestBlockchain(config)
+	e := NewMixedEngine(config, database.NewMemoryDBManager())
+	e.SetBlockchain(bc)
+	pset, _ := params.NewGovParamSetChainConfig(config)
+	gset := NewGovernanceSet()
+	gset.Import(pset.StrMap())
+	e.headerGov.WriteGovernance(0, NewGovernanceSet(), gset)
+	bc.SetBlockNum(12)
+	e.UpdateParams(bc.CurrentBlock().NumberU64())
+
+	api := NewGovernanceKlayAPI(e, bc)
+	limit := hexutil.Uint64(5)
+	args := &rpc.PageArgs{Limit: &limit}
+	var items []BlockRewards
+	for pages := 1; ; pages++ {
+		page, err := api.GetRewardsPaged(1, rpc.LatestBlockNumber, args)
+		assert.NoError(t, err)
+		items = append(items, page.Items.([]BlockRewards)...)
+		if !page.HasMore {
+			assert.Equal(t, 3, pages)
+			break
 		}
+		args.Cursor = page.Cursor
+	}
+	assert.Len(t, items, 12)
+	for i, item := range items {
+		assert.Equal(t, uint64(i+1), item.Number)
+		assert.Equal(t, big.NewInt(1), item.Rewards.Minted)
+	}
+
+	_, err := api.GetRewardsPaged(5, 13, nil)
+	assert.Error(t, err)
+}
+
+func TestGetRewardsRange(t *testing.T) {
+	config := getTestConfig()
+	config.Governance.Reward.MintingAmount = big.NewInt(1)
+	config.Istanbul.Epoch = 3
+	config.KoreCompatibleBlock = big.NewInt(9)
+
+	bc := newTestBlockchain(config)
+	e := NewMixedEngine(config, database.NewMemoryDBManager())
+	e.SetBlockchain(bc)
+	pset, _ := params.NewGovParamSetChainConfig(config)
+	gset := NewGovernanceSet()
+	gset.Import(pset.StrMap())
+	e.headerGov.WriteGovernance(0, NewGovernanceSet(), gset)
+	for i, amount := range []string{"2", "3"} {
+		override := NewGovernanceSet()
+		override.Import(map[string]interface{}{"reward.mintingamount": amount})
+		e.headerGov.WriteGovernance(uint64(3*(i+1)), gset, override)
+	}
+	bc.SetBlockNum(12)
+	e.UpdateParams(bc.CurrentBlock().NumberU64())
+
+	// the rewards are the same with the ones returned block by block
+	api := NewGovernanceKlayAPI(e, bc)
+	specs, err := api.GetRewardsRange(1, rpc.LatestBlockNumber)
+	assert.NoError(t, err)
+	assert.Len(t, specs, 12)
+	for i, spec := range specs {
+		num := rpc.BlockNumber(i + 1)
+		expected, err := api.GetRewards(&num)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, spec, "wrong at block %d", num)
+	}
+	assert.Equal(t, big.NewInt(1), specs[5].Minted)
+	assert.Equal(t, big.NewInt(2), specs[6].Minted)
+	assert.Equal(t, big.NewInt(3), specs[8].Minted)
+
+	_, err = api.GetRewardsRange(5, 13)
+	assert.Error(t, err)
+	_, err = api.GetRewardsRange(6, 5)
+	assert.Error(t, err)
+	bc.SetBlockNum(maxRewardsRange + 1)
+	_, err = api.GetRewardsRange(1, maxRewardsRange+1)
+	assert.Equal(t, errRewardsRangeTooLarge, err)
+}
+
+func TestGetRewards_Checkpoint(t *testing.T) {
+	config := getTestConfig()
+	config.Governance.Reward.MintingAmount = big.NewInt(1)
+
+	bc := newTestBlockchain(config)
+	db := database.NewMemoryDBManager()
+	e := NewMixedEngine(config, db)
+	e.SetBlockchain(bc)
+	pset, _ := params.NewGovParamSetChainConfig(config)
+	gset := NewGovernanceSet()
+	gset.Import(pset.StrMap())
+	e.headerGov.WriteGovernance(0, NewGovernanceSet(), gset)
+	bc.SetBlockNum(12)
+	e.UpdateParams(bc.CurrentBlock().NumberU64())
+
+	api := NewGovernanceKlayAPI(e, bc)
+	num := rpc.BlockNumber(5)
+	expected, err := api.GetRewards(&num)
+	assert.NoError(t, err)
+
+	// the checkpoint of block 5 differs from the calculated one to tell where the reward is served from
+	checkpointed := &reward.RewardSpec{Minted: big.NewInt(42), Rewards: map[common.Address]*big.Int{}}
+	data, err := json.Marshal(checkpointed)
+	assert.NoError(t, err)
+	db.WriteRewardCheckpoint(&database.RewardCheckpoint{Number: 5, Hash: bc.GetHeaderByNumber(5).Hash(), Spec: data})
+	db.WriteRewardCheckpoint(&database.RewardCheckpoint{Number: 6, Hash: common.HexToHash("0xabandoned"), Spec: data})
+
+	// the checkpoints are not served unless enabled
+	spec, err := api.GetRewards(&num)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, spec)
+
+	api.SetRewardCheckpoints(db)
+	spec, err = api.GetRewards(&num)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), spec.Minted)
+
+	// the checkpoint of a block abandoned by a reorg is ignored
+	num = rpc.BlockNumber(6)
+	spec, err = api.GetRewards(&num)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), spec.Minted)
+
+	// the block without a checkpoint is calculated
+	num = rpc.BlockNumber(7)
+	spec, err = api.GetRewards(&num)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), spec.Minted)
+}
+
+func TestGetRewards_Pending(t *testing.T) {
+	config := getTestConfig()
+	config.Governance.Reward.MintingAmount = big.NewInt(1)
+
+	bc := newTestBlockchain(config)
+	e := NewMixedEngine(config, database.NewMemoryDBManager())
+	e.SetBlockchain(bc)
+	pset, _ := params.NewGovParamSetChainConfig(config)
+	gset := NewGovernanceSet()
+	gset.Import(pset.StrMap())
+	e.headerGov.WriteGovernance(0, NewGovernanceSet(), gset)
+	e.SetNodeAddress(common.HexToAddress("0x0000000000000000000000000000000000000bbb"))
+	bc.SetBlockNum(12)
+	e.UpdateParams(bc.CurrentBlock().NumberU64())
+
+	api := NewGovernanceKlayAPI(e, bc)
+	num := rpc.PendingBlockNumber
+
+	// the pending block is not available without the miner
+	_, err := api.GetRewards(&num)
+	assert.Equal(t, errNoPendingBlock, err)
+
+	api.SetPendingBlock(func() *types.Block { return nil })
+	_, err = api.GetRewards(&num)
+	assert.Equal(t, errNoPendingBlock, err)
+
+	rewardbase := common.HexToAddress("0x0000000000000000000000000000000000000aaa")
+	api.SetPendingBlock(func() *types.Block {
+		return types.NewBlockWithHeader(&types.Header{
+			Number:     big.NewInt(13),
+			Rewardbase: rewardbase,
+			BaseFee:    big.NewInt(1),
+		})
+	})
+	spec, err := api.GetRewards(&num)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), spec.Minted)
+	assert.Equal(t, big.NewInt(1), spec.Rewards[rewardbase])
+}
+
+func TestGetRewardMerkleProof(t *testing.T) {
+	config := getTestConfig()
+	config.Governance.Reward.MintingAmount = big.NewInt(1)
+
+	bc := newTestBlockchain(config)
+	e := NewMixedEngine(config, database.NewMemoryDBManager())
+	e.SetBlockchain(bc)
+	pset, _ := params.NewGovParamSetChainConfig(config)
+	gset := NewGovernanceSet()
+	gset.Import(pset.StrMap())
+	e.headerGov.WriteGovernance(0, NewGovernanceSet(), gset)
+	bc.SetBlockNum(12)
+	e.UpdateParams(bc.CurrentBlock().NumberU64())
+
+	api := NewGovernanceKlayAPI(e, bc)
+	num := rpc.BlockNumber(5)
+	spec, err := api.GetRewards(&num)
+	assert.NoError(t, err)
+
+	// the proposer of the test blocks is the zero address
+	p, err := api.GetRewardMerkleProof(common.Address{}, &num)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), p.Amount.ToInt())
+	assert.NoError(t, p.Verify(proof.RewardsRoot(spec.Rewards)))
+
+	_, err = api.GetRewardMerkleProof(common.HexToAddress("0x1"), &num)
+	assert.Error(t, err)
+}
+
+func TestSimulateReward(t *testing.T) {
+	config := getTestConfig()
+	config.Governance.Reward.MintingAmount = big.NewInt(1)
+	config.Istanbul.Epoch = 3
+	config.KoreCompatibleBlock = big.NewInt(9)
+
+	bc := newTestBlockchain(config)
+	e := NewMixedEngine(config, database.NewMemoryDBManager())
+	e.SetBlockchain(bc)
+	pset, _ := params.NewGovParamSetChainConfig(config)
+	gset := NewGovernanceSet()
+	gset.Import(pset.StrMap())
+	e.headerGov.WriteGovernance(0, NewGovernanceSet(), gset)
+	bc.SetBlockNum(12)
+	e.UpdateParams(bc.CurrentBlock().NumberU64())
+
+	api := NewGovernanceKlayAPI(e, bc)
+	num := rpc.BlockNumber(5)
+
+	// without overrides, the reward is the one in effect
+	expected, err := api.GetRewards(&num)
+	assert.NoError(t, err)
+	spec, err := api.SimulateReward(nil, &num)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, spec)
+	spec, err = api.SimulateReward(&RewardParamOverrides{}, &num)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, spec)
+
+	// the overridden minting amount is paid
+	amount, ratio, deferred := "7", "50/20/30", true
+	spec, err = api.SimulateReward(&RewardParamOverrides{MintingAmount: &amount, Ratio: &ratio, DeferredTxFee: &deferred}, &num)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(7), spec.Minted)
+	latest, err := api.GetRewards(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), latest.Minted)
+
+	// invalid overrides are rejected
+	for _, o := range []*RewardParamOverrides{
+		{Ratio: func(s string) *string { return &s }("50/20/20")},
+		{Kip82Ratio: func(s string) *string { return &s }("20/80/0")},
+		{MintingAmount: func(s string) *string { return &s }("-1")},
+	} {
+		_, err = api.SimulateReward(o, &num)
+		assert.Error(t, err)
+	}
+}
+
+func TestGetStakingInfoPaged(t *testing.T) {
+	nodes := make([]common.Address, 5)
+	amounts := make([]uint64, 5)
+	for i := range nodes {
+		nodes[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+		amounts[i] = uint64(i+1) * 5000000
+	}
+	oldSm := reward.GetStakingManager()
+	defer reward.SetTestStakingManager(oldSm)
+	reward.SetTestStakingManagerWithStakingInfoCache(&reward.StakingInfo{
+		BlockNum:              0,
+		CouncilNodeAddrs:      nodes,
+		CouncilStakingAddrs:   nodes,
+		CouncilRewardAddrs:    nodes,
+		CouncilStakingAmounts: amounts,
+	})
+
+	api := NewGovernanceKlayAPI(nil, newTestBlockchain(getTestConfig()))
+	num := rpc.BlockNumber(0)
+	limit := hexutil.Uint64(2)
+	args := &rpc.PageArgs{Limit: &limit}
+	var items []CouncilStaking
+	for {
+		page, err := api.GetStakingInfoPaged(&num, args)
+		assert.NoError(t, err)
+		items = append(items, page.Items.([]CouncilStaking)...)
+		if !page.HasMore {
+			break
+		}
+		args.Cursor = page.Cursor
+	}
+	assert.Len(t, items, len(nodes))
+	for i, item := range items {
+		assert.Equal(t, nodes[i], item.NodeAddress)
+		assert.Equal(t, amounts[i], item.StakingAmount)
+	}
+}
+
+func TestGetEffectiveStakes(t *testing.T) {
+	config := getTestConfig()
+	config.Governance.Reward.MinimumStake = big.NewInt(3000000)
+	bc := newTestBlockchain(config)
+	e := NewMixedEngine(config, database.NewMemoryDBManager())
+	e.SetBlockchain(bc)
+	pset, _ := params.NewGovParamSetChainConfig(config)
+	gset := NewGovernanceSet()
+	gset.Import(pset.StrMap())
+	e.headerGov.WriteGovernance(0, NewGovernanceSet(), gset)
+	bc.SetBlockNum(2)
+	e.UpdateParams(bc.CurrentBlock().NumberU64())
+
+	nodes := []common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")}
+	oldSm := reward.GetStakingManager()
+	defer reward.SetTestStakingManager(oldSm)
+	reward.SetTestStakingManagerWithStakingInfoCache(&reward.StakingInfo{
+		CouncilNodeAddrs:      nodes,
+		CouncilStakingAddrs:   nodes,
+		CouncilRewardAddrs:    nodes,
+		CouncilStakingAmounts: []uint64{5000000, 2000000},
+	})
+
+	api := NewGovernanceKlayAPI(e, bc)
+	num := rpc.BlockNumber(1)
+	stakes, err := api.GetEffectiveStakes(&num)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), stakes.BlockNumber)
+	assert.Equal(t, "KLAY", stakes.StakeUnit)
+	assert.Equal(t, big.NewInt(3000000), stakes.MinimumStake)
+	assert.Equal(t, big.NewInt(2000000), stakes.TotalEffectiveStake)
+	require.Len(t, stakes.Nodes, 2)
+	assert.Equal(t, big.NewInt(5000000), stakes.Nodes[0].Stake)
+	assert.Equal(t, big.NewInt(2000000), stakes.Nodes[0].EffectiveStake)
+	assert.Equal(t, big.NewInt(2000000), stakes.Nodes[1].Stake)
+	assert.Equal(t, big.NewInt(0), stakes.Nodes[1].EffectiveStake)
+
+	pending := rpc.PendingBlockNumber
+	_, err = api.GetEffectiveStakes(&pending)
+	assert.Error(t, err)
+}
+
+func TestLabeledStakingInfo_MarshalJSON(t *testing.T) {
+	kff := common.HexToAddress("0x1")
+	labeled := &labeledStakingInfo{
+		StakingInfo: &reward.StakingInfo{BlockNum: 1, KFFAddr: kff},
+		Labels:      map[common.Address]reward.AddressLabel{kff: {Role: reward.AddressRoleKFF}},
 	}
+	data, err := json.Marshal(labeled)
+	assert.NoError(t, err)
+
+	var fields map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, float64(1), fields["blockNum"])
+	assert.Equal(t, kff.Hex(), common.HexToAddress(fields["kffAddr"].(string)).Hex())
+	assert.Equal(t, map[string]interface{}{
+		"0x0000000000000000000000000000000000000001": map[string]interface{}{"role": "kff"},
+	}, fields["labels"])
 }
 
 func TestGetRewardsAccumulated(t *testing.T) {
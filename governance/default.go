@@ -68,6 +68,14 @@ var (
 		"reward.minimumstake":             params.MinimumStake,
 		"reward.stakingupdateinterval":    params.StakeUpdateInterval,
 		"reward.proposerupdateinterval":   params.ProposerRefreshInterval,
+		"reward.redirectaddress":          params.RewardRedirectAddress,
+		"reward.kffsplit":                 params.KFFSplit,
+		"reward.burnratio":                params.BurnRatio,
+		"reward.payoutgasstipend":         params.PayoutGasStipend,
+		"reward.kcfsplit":                 params.KCFSplit,
+		"reward.remainderpolicy":          params.RemainderPolicy,
+		"reward.proposersplit":            params.ProposerSplit,
+		"reward.minstakeinclusive":        params.MinStakeInclusive,
 		"governance.addvalidator":         params.AddValidator,
 		"governance.removevalidator":      params.RemoveValidator,
 		"param.txgashumanreadable":        params.ConstTxGasHumanReadable,
@@ -80,6 +88,22 @@ var (
 		"reward.proposerupdateinterval": params.ProposerRefreshInterval,
 	}
 
+	// gaslessParams are the prices fixed to zero on a gasless chain
+	gaslessParams = map[int]interface{}{
+		params.UnitPrice:         uint64(0),
+		params.LowerBoundBaseFee: uint64(0),
+		params.UpperBoundBaseFee: uint64(0),
+	}
+
+	// forkGatedParams are the params which can be voted only after their optional hardfork
+	forkGatedParams = map[int]func(config *params.ChainConfig, num *big.Int) bool{
+		params.KFFSplit:          (*params.ChainConfig).IsRewardSplitForkEnabled,
+		params.KCFSplit:          (*params.ChainConfig).IsRewardSplitForkEnabled,
+		params.ProposerSplit:     (*params.ChainConfig).IsRewardSplitForkEnabled,
+		params.RemainderPolicy:   (*params.ChainConfig).IsRewardSplitForkEnabled,
+		params.MinStakeInclusive: (*params.ChainConfig).IsPebStakingForkEnabled,
+	}
+
 	GovernanceKeyMapReverse = map[int]string{
 		params.GovernanceMode:            "governance.governancemode",
 		params.GoverningNode:             "governance.governingnode",
@@ -102,6 +126,14 @@ var (
 		params.MinimumStake:              "reward.minimumstake",
 		params.StakeUpdateInterval:       "reward.stakingupdateinterval",
 		params.ProposerRefreshInterval:   "reward.proposerupdateinterval",
+		params.RewardRedirectAddress:     "reward.redirectaddress",
+		params.KFFSplit:                  "reward.kffsplit",
+		params.BurnRatio:                 "reward.burnratio",
+		params.PayoutGasStipend:          "reward.payoutgasstipend",
+		params.KCFSplit:                  "reward.kcfsplit",
+		params.RemainderPolicy:           "reward.remainderpolicy",
+		params.ProposerSplit:             "reward.proposersplit",
+		params.MinStakeInclusive:         "reward.minstakeinclusive",
 		params.AddValidator:              "governance.addvalidator",
 		params.RemoveValidator:           "governance.removevalidator",
 		params.ConstTxGasHumanReadable:   "param.txgashumanreadable",
@@ -557,13 +589,13 @@ func (g *Governance) ParseVoteValue(gVote *GovernanceVote) (*GovernanceVote, err
 	}
 
 	switch k {
-	case params.GovernanceMode, params.MintingAmount, params.MinimumStake, params.Ratio, params.Kip82Ratio:
+	case params.GovernanceMode, params.MintingAmount, params.MinimumStake, params.Ratio, params.Kip82Ratio, params.KFFSplit, params.KCFSplit, params.RemainderPolicy, params.ProposerSplit:
 		v, ok := gVote.Value.([]uint8)
 		if !ok {
 			return nil, ErrValueTypeMismatch
 		}
 		val = string(v)
-	case params.GoverningNode, params.GovParamContract:
+	case params.GoverningNode, params.GovParamContract, params.RewardRedirectAddress:
 		v, ok := gVote.Value.([]uint8)
 		if !ok {
 			return nil, ErrValueTypeMismatch
@@ -591,14 +623,15 @@ func (g *Governance) ParseVoteValue(gVote *GovernanceVote) (*GovernanceVote, err
 		}
 	case params.Epoch, params.CommitteeSize, params.UnitPrice, params.DeriveShaImpl, params.StakeUpdateInterval,
 		params.ProposerRefreshInterval, params.ConstTxGasHumanReadable, params.Policy, params.Timeout,
-		params.LowerBoundBaseFee, params.UpperBoundBaseFee, params.GasTarget, params.MaxBlockGasUsedForBaseFee, params.BaseFeeDenominator:
+		params.LowerBoundBaseFee, params.UpperBoundBaseFee, params.GasTarget, params.MaxBlockGasUsedForBaseFee, params.BaseFeeDenominator,
+		params.BurnRatio, params.PayoutGasStipend:
 		v, ok := gVote.Value.([]uint8)
 		if !ok {
 			return nil, ErrValueTypeMismatch
 		}
 		v = append(make([]byte, 8-len(v)), v...)
 		val = binary.BigEndian.Uint64(v)
-	case params.UseGiniCoeff, params.DeferredTxFee:
+	case params.UseGiniCoeff, params.DeferredTxFee, params.MinStakeInclusive:
 		v, ok := gVote.Value.([]uint8)
 		if !ok {
 			return nil, ErrValueTypeMismatch
@@ -622,21 +655,22 @@ func (gov *Governance) ReflectVotes(vote GovernanceVote) {
 
 func (gov *Governance) updateChangeSet(vote GovernanceVote) bool {
 	switch GovernanceKeyMap[vote.Key] {
-	case params.GoverningNode, params.GovParamContract:
+	case params.GoverningNode, params.GovParamContract, params.RewardRedirectAddress:
 		gov.changeSet.SetValue(GovernanceKeyMap[vote.Key], vote.Value.(common.Address))
 		return true
-	case params.GovernanceMode, params.Ratio, params.Kip82Ratio:
+	case params.GovernanceMode, params.Ratio, params.Kip82Ratio, params.KFFSplit, params.KCFSplit, params.RemainderPolicy, params.ProposerSplit:
 		gov.changeSet.SetValue(GovernanceKeyMap[vote.Key], vote.Value.(string))
 		return true
 	case params.Epoch, params.StakeUpdateInterval, params.ProposerRefreshInterval, params.CommitteeSize,
 		params.UnitPrice, params.DeriveShaImpl, params.ConstTxGasHumanReadable, params.Policy, params.Timeout,
-		params.LowerBoundBaseFee, params.UpperBoundBaseFee, params.GasTarget, params.MaxBlockGasUsedForBaseFee, params.BaseFeeDenominator:
+		params.LowerBoundBaseFee, params.UpperBoundBaseFee, params.GasTarget, params.MaxBlockGasUsedForBaseFee, params.BaseFeeDenominator,
+		params.BurnRatio, params.PayoutGasStipend:
 		gov.changeSet.SetValue(GovernanceKeyMap[vote.Key], vote.Value.(uint64))
 		return true
 	case params.MintingAmount, params.MinimumStake:
 		gov.changeSet.SetValue(GovernanceKeyMap[vote.Key], vote.Value.(string))
 		return true
-	case params.UseGiniCoeff, params.DeferredTxFee:
+	case params.UseGiniCoeff, params.DeferredTxFee, params.MinStakeInclusive:
 		gov.changeSet.SetValue(GovernanceKeyMap[vote.Key], vote.Value.(bool))
 		return true
 	default:
@@ -843,11 +877,16 @@ func (g *Governance) ReadGovernance(num uint64) (uint64, map[string]interface{},
 }
 
 func CalcGovernanceInfoBlock(num uint64, epoch uint64) uint64 {
-	governanceInfoBlock := num - (num % epoch)
-	if governanceInfoBlock >= epoch {
-		governanceInfoBlock -= epoch
+	return params.CalcGovernanceBlockNumber(num, epoch)
+}
+
+// governanceReadNumber returns the block number to read the governance data used for
+// generating the block num. Before Kore, the data effective at the parent block was used.
+func governanceReadNumber(config *params.ChainConfig, num uint64) uint64 {
+	if num != 0 && !config.IsKoreForkEnabled(new(big.Int).SetUint64(num)) {
+		return num - 1
 	}
-	return governanceInfoBlock
+	return num
 }
 
 func (g *Governance) GetGovernanceChange() map[string]interface{} {
@@ -933,7 +972,8 @@ func adjustDecodedSet(src map[string]interface{}) map[string]interface{} {
 			src[k] = uint64(v.(float64))
 		}
 		if GovernanceKeyMap[k] == params.GoverningNode ||
-			GovernanceKeyMap[k] == params.GovParamContract {
+			GovernanceKeyMap[k] == params.GovParamContract ||
+			GovernanceKeyMap[k] == params.RewardRedirectAddress {
 			if reflect.TypeOf(v) == stringT {
 				src[k] = common.HexToAddress(v.(string))
 			} else {
@@ -970,7 +1010,8 @@ func (gov *Governance) VerifyGovernance(received []byte) error {
 
 	for k, v := range rChangeSet {
 		if GovernanceKeyMap[k] == params.GoverningNode ||
-			GovernanceKeyMap[k] == params.GovParamContract {
+			GovernanceKeyMap[k] == params.GovParamContract ||
+			GovernanceKeyMap[k] == params.RewardRedirectAddress {
 			if reflect.TypeOf(v) == stringT {
 				v = common.HexToAddress(v.(string))
 			}
@@ -1159,6 +1200,77 @@ func GetGovernanceItemsFromChainConfig(config *params.ChainConfig) GovernanceSet
 		appendGovSet(governanceMap)
 	}
 
+	// reward redirect params
+	if config.IsRewardRedirectForkEnabled(common.Big0) &&
+		config.Governance != nil && config.Governance.Reward != nil &&
+		!common.EmptyAddress(config.Governance.Reward.RedirectAddress) {
+		appendGovSet(map[int]interface{}{
+			params.RewardRedirectAddress: config.Governance.Reward.RedirectAddress,
+		})
+	}
+
+	// KFF split params
+	if config.IsRewardSplitForkEnabled(common.Big0) &&
+		config.Governance != nil && config.Governance.Reward != nil &&
+		config.Governance.Reward.KFFSplit != "" {
+		appendGovSet(map[int]interface{}{
+			params.KFFSplit: config.Governance.Reward.KFFSplit,
+		})
+	}
+
+	// KCF split params
+	if config.IsRewardSplitForkEnabled(common.Big0) &&
+		config.Governance != nil && config.Governance.Reward != nil &&
+		config.Governance.Reward.KCFSplit != "" {
+		appendGovSet(map[int]interface{}{
+			params.KCFSplit: config.Governance.Reward.KCFSplit,
+		})
+	}
+
+	// remainder policy params
+	if config.IsRewardSplitForkEnabled(common.Big0) &&
+		config.Governance != nil && config.Governance.Reward != nil &&
+		config.Governance.Reward.RemainderPolicy != "" {
+		appendGovSet(map[int]interface{}{
+			params.RemainderPolicy: config.Governance.Reward.RemainderPolicy,
+		})
+	}
+
+	// proposer split params
+	if config.IsRewardSplitForkEnabled(common.Big0) &&
+		config.Governance != nil && config.Governance.Reward != nil &&
+		config.Governance.Reward.ProposerSplit != "" {
+		appendGovSet(map[int]interface{}{
+			params.ProposerSplit: config.Governance.Reward.ProposerSplit,
+		})
+	}
+
+	// min stake inclusive params
+	if config.IsPebStakingForkEnabled(common.Big0) &&
+		config.Governance != nil && config.Governance.Reward != nil &&
+		config.Governance.Reward.MinStakeInclusive {
+		appendGovSet(map[int]interface{}{
+			params.MinStakeInclusive: config.Governance.Reward.MinStakeInclusive,
+		})
+	}
+
+	// burn ratio params
+	if config.Governance != nil && config.Governance.Reward != nil &&
+		config.Governance.Reward.BurnRatio != nil {
+		appendGovSet(map[int]interface{}{
+			params.BurnRatio: *config.Governance.Reward.BurnRatio,
+		})
+	}
+
+	// reward payout params
+	if config.IsRewardPayoutForkEnabled(common.Big0) &&
+		config.Governance != nil && config.Governance.Reward != nil &&
+		config.Governance.Reward.PayoutGasStipend != 0 {
+		appendGovSet(map[int]interface{}{
+			params.PayoutGasStipend: config.Governance.Reward.PayoutGasStipend,
+		})
+	}
+
 	return govSet
 }
 
@@ -1246,13 +1358,8 @@ func (gov *Governance) CurrentParams() *params.GovParamSet {
 func (gov *Governance) EffectiveParams(num uint64) (*params.GovParamSet, error) {
 	// TODO-Klaytn: Either handle epoch change, or permanently forbid epoch change.
 	epoch := gov.epochWithFallback()
+	num = governanceReadNumber(gov.ChainConfig, num)
 
-	bignum := new(big.Int).SetUint64(num)
-
-	// Before Kore, ReadGovernance(num - 1) is used to generate block num
-	if !gov.ChainConfig.IsKoreForkEnabled(bignum) && num != 0 {
-		num -= 1
-	}
 	// Should be equivalent to Governance.ReadGovernance(), but without in-memory caches.
 	// Not using in-memory caches to make it stateless, hence less error-prone.
 	_, strMap, err := gov.db.ReadGovernanceAtNumber(num, epoch)
@@ -123,14 +123,48 @@ var tstData = []voteValue{
 	{k: "reward.ratio", v: "0/100/0", e: true},
 	{k: "reward.ratio", v: "100/0/0", e: true},
 	{k: "reward.ratio", v: "0/0/0", e: false},
-	{k: "reward.ratio", v: "30.5/40/29.5", e: false},
+	{k: "reward.ratio", v: "30.5/40/29.5", e: true},
 	{k: "reward.ratio", v: "30.5/40/30.5", e: false},
+	{k: "reward.ratio", v: "3050/4000/2950", e: true},
+	{k: "reward.ratio", v: "3050/4000/2951", e: false},
+	{k: "reward.ratio", v: "30.05/40/29.95", e: true},
+	{k: "reward.ratio", v: "30.005/40/29.995", e: false},
 	{k: "reward.kip82ratio", v: "20/80", e: true},
 	{k: "reward.kip82ratio", v: "10/90", e: true},
 	{k: "reward.kip82ratio", v: "30/80", e: false},
 	{k: "reward.kip82ratio", v: "30/30/40", e: false},
-	{k: "reward.kip82ratio", v: "49.5/50.5", e: false},
+	{k: "reward.kip82ratio", v: "49.5/50.5", e: true},
+	{k: "reward.kip82ratio", v: "4950/5050", e: true},
 	{k: "reward.kip82ratio", v: "50.5/50.5", e: false},
+	{k: "reward.kffsplit", v: "0x0000000000000000000000000000000000000bb8:70,0x0000000000000000000000000000000000000bb9:30", e: true},
+	{k: "reward.kffsplit", v: "0x0000000000000000000000000000000000000bb8:1", e: true},
+	{k: "reward.kffsplit", v: "", e: true},
+	{k: "reward.kffsplit", v: "0x0000000000000000000000000000000000000bb8:0", e: false},
+	{k: "reward.kffsplit", v: "0x0000000000000000000000000000000000000bb8:70,0x0000000000000000000000000000000000000bb8:30", e: false},
+	{k: "reward.kffsplit", v: "0x0000000000000000000000000000000000000000:100", e: false},
+	{k: "reward.kffsplit", v: "0xbb8:100", e: false},
+	{k: "reward.kffsplit", v: "70/30", e: false},
+	{k: "reward.burnratio", v: uint64(0), e: true},
+	{k: "reward.burnratio", v: uint64(100), e: true},
+	{k: "reward.burnratio", v: uint64(101), e: false},
+	{k: "reward.burnratio", v: "50", e: false},
+	{k: "reward.burnratio", v: true, e: false},
+	{k: "reward.payoutgasstipend", v: uint64(0), e: true},
+	{k: "reward.payoutgasstipend", v: uint64(2300), e: true},
+	{k: "reward.payoutgasstipend", v: "2300", e: false},
+	{k: "reward.payoutgasstipend", v: -1, e: false},
+	{k: "reward.kcfsplit", v: "0x0000000000000000000000000000000000000bb8:50,0x0000000000000000000000000000000000000bb9:50", e: true},
+	{k: "reward.kcfsplit", v: "", e: true},
+	{k: "reward.kcfsplit", v: "0x0000000000000000000000000000000000000bb8:0", e: false},
+	{k: "reward.kcfsplit", v: "50/50", e: false},
+	{k: "reward.remainderpolicy", v: "carry", e: true},
+	{k: "reward.remainderpolicy", v: "", e: true},
+	{k: "reward.remainderpolicy", v: "kgf", e: false},
+	{k: "reward.remainderpolicy", v: 1, e: false},
+	{k: "reward.proposersplit", v: "0x0000000000000000000000000000000000000bb7=0x0000000000000000000000000000000000000bb8:80,0x0000000000000000000000000000000000000bb9:20", e: true},
+	{k: "reward.proposersplit", v: "", e: true},
+	{k: "reward.proposersplit", v: "0x0000000000000000000000000000000000000bb7=", e: false},
+	{k: "reward.proposersplit", v: "0x0000000000000000000000000000000000000bb8:80", e: false},
 	{k: "kip71.lowerboundbasefee", v: uint64(25000000000), e: true},
 	{k: "kip71.lowerboundbasefee", v: 25000000, e: false},
 	{k: "kip71.lowerboundbasefee", v: "250000000", e: false},
@@ -161,6 +195,10 @@ var tstData = []voteValue{
 	{k: "reward.deferredtxfee", v: 0, e: false},
 	{k: "reward.deferredtxfee", v: 1, e: false},
 	{k: "reward.deferredtxfee", v: "true", e: false},
+	{k: "reward.minstakeinclusive", v: true, e: true},
+	{k: "reward.minstakeinclusive", v: false, e: true},
+	{k: "reward.minstakeinclusive", v: uint64(1), e: false},
+	{k: "reward.minstakeinclusive", v: "true", e: false},
 	{k: "reward.minimumstake", v: "2000000000000000000000000", e: true},
 	{k: "reward.minimumstake", v: 200000000000000, e: false},
 	{k: "reward.minimumstake", v: "-1", e: false},
@@ -207,6 +245,11 @@ var goodVotes = []voteValue{
 	{k: "reward.mintingamount", v: "9600000000000000000", e: true},
 	{k: "reward.ratio", v: "10/10/80", e: true},
 	{k: "reward.kip82ratio", v: "20/80", e: true},
+	{k: "reward.kffsplit", v: "0x0000000000000000000000000000000000000bb8:70,0x0000000000000000000000000000000000000bb9:30", e: true},
+	{k: "reward.burnratio", v: uint64(100), e: true},
+	{k: "reward.kcfsplit", v: "0x0000000000000000000000000000000000000bb8:50,0x0000000000000000000000000000000000000bb9:50", e: true},
+	{k: "reward.remainderpolicy", v: "burn", e: true},
+	{k: "reward.proposersplit", v: "0x0000000000000000000000000000000000000bb7=0x0000000000000000000000000000000000000bb8:80,0x0000000000000000000000000000000000000bb9:20", e: true},
 	{k: "istanbul.timeout", v: uint64(5000), e: true},
 	{k: "governance.addvalidator", v: "0x639e5ebfc483716fbac9810b230ff6ad487f366c,0x828880c5f09cc1cc6a58715e3fe2b4c4cf3c5869", e: true},
 }
@@ -215,6 +258,8 @@ func getTestConfig() *params.ChainConfig {
 	config := params.TestChainConfig.Copy()
 	config.Governance = params.GetDefaultGovernanceConfig()
 	config.Istanbul = params.GetDefaultIstanbulConfig()
+	config.RewardSplitCompatibleBlock = big.NewInt(0)
+	config.PebStakingCompatibleBlock = big.NewInt(0)
 	return config
 }
 
@@ -278,6 +323,36 @@ func TestGovernance_AddVote(t *testing.T) {
 	}
 }
 
+func TestGovernance_AddVote_ForkGated(t *testing.T) {
+	dbm := database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB})
+	config := getTestConfig()
+	config.RewardSplitCompatibleBlock = nil
+	config.PebStakingCompatibleBlock = nil
+	gov := NewGovernanceInitialize(config, dbm)
+
+	split := "0x0000000000000000000000000000000000000bb8:70,0x0000000000000000000000000000000000000bb9:30"
+	proposerSplit := "0x0000000000000000000000000000000000000bb7=0x0000000000000000000000000000000000000bb8:80,0x0000000000000000000000000000000000000bb9:20"
+
+	// the split and remainder policy votes are valid values, but rejected before the RewardSplit hardfork,
+	// and so is the min stake inclusive vote before the PebStaking hardfork
+	for _, val := range []voteValue{
+		{k: "reward.kffsplit", v: split},
+		{k: "reward.kcfsplit", v: split},
+		{k: "reward.proposersplit", v: proposerSplit},
+		{k: "reward.remainderpolicy", v: "burn"},
+		{k: "reward.minstakeinclusive", v: true},
+	} {
+		_, ok := gov.ValidateVote(&GovernanceVote{Key: val.k, Value: val.v})
+		assert.True(t, ok, val.k)
+		assert.False(t, gov.AddVote(val.k, val.v), val.k)
+	}
+
+	config.RewardSplitCompatibleBlock = big.NewInt(0)
+	assert.True(t, gov.AddVote("reward.kffsplit", split))
+	config.PebStakingCompatibleBlock = big.NewInt(0)
+	assert.True(t, gov.AddVote("reward.minstakeinclusive", true))
+}
+
 func TestGovernance_RemoveVote(t *testing.T) {
 	gov := getGovernance()
 
@@ -546,6 +621,54 @@ func TestCalcGovernanceInfoBlock(t *testing.T) {
 	}
 }
 
+// TestEffectiveParams_Boundary checks the parameters effective at every block around the
+// epoch blocks and the Kore fork block, where the data written at an epoch block takes
+// effect after the next epoch block (or the block after it before Kore).
+func TestEffectiveParams_Boundary(t *testing.T) {
+	var (
+		epoch     = uint64(3)
+		koreBlock = uint64(9)
+	)
+	config := getTestConfig()
+	config.Istanbul.Epoch = epoch
+	config.KoreCompatibleBlock = new(big.Int).SetUint64(koreBlock)
+	config.Governance.Reward.MintingAmount = big.NewInt(1)
+
+	dbm := database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB})
+	gov := NewGovernance(config, dbm)
+	pset, err := params.NewGovParamSetChainConfig(config)
+	assert.Nil(t, err)
+	gset := NewGovernanceSet()
+	gset.Import(pset.StrMap())
+	assert.Nil(t, gov.WriteGovernance(0, NewGovernanceSet(), gset))
+
+	// the minting amount is changed to the epoch block number at every epoch block
+	for num := epoch; num <= 5*epoch; num += epoch {
+		_, data, err := gov.ReadGovernance(num)
+		assert.Nil(t, err)
+		prev := NewGovernanceSet()
+		prev.Import(data)
+		delta := NewGovernanceSet()
+		delta.Import(map[string]interface{}{"reward.mintingamount": fmt.Sprint(num)})
+		assert.Nil(t, gov.WriteGovernance(num, prev, delta))
+	}
+
+	expected := map[uint64]uint64{
+		0: 1, 1: 1, 2: 1, 3: 1, 4: 1, 5: 1, 6: 1,
+		7: 3, 8: 3, // before Kore, the data of the parent block is used
+		9: 6, 10: 6, 11: 6,
+		12: 9, 13: 9, 14: 9,
+		15: 12, 16: 12, 17: 12,
+		18: 15, 19: 15, 20: 15,
+		21: 15, // no data is written after 15
+	}
+	for num := uint64(0); num <= 7*epoch; num++ {
+		pset, err := gov.EffectiveParams(num)
+		assert.Nil(t, err)
+		assert.Equal(t, new(big.Int).SetUint64(expected[num]), pset.MintingAmountBig(), "wrong at block %d", num)
+	}
+}
+
 func TestVoteValueNilInterface(t *testing.T) {
 	gov := getGovernance()
 	gVote := new(GovernanceVote)
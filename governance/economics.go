@@ -0,0 +1,97 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+)
+
+// ChainEconomics is the economic parameters of the chain effective at a block and the
+// amounts minted, collected and burnt at the block.
+type ChainEconomics struct {
+	BlockNumber *big.Int `json:"blockNumber"`
+
+	UnitPrice uint64               `json:"unitPrice"`
+	BaseFee   *big.Int             `json:"baseFee,omitempty"` // Nil before Magma
+	KIP71     *params.KIP71Config  `json:"kip71"`
+	Reward    *params.RewardConfig `json:"reward"`
+
+	Minted   *big.Int `json:"minted"`
+	TotalFee *big.Int `json:"totalFee"`
+	BurntFee *big.Int `json:"burntFee"`
+
+	Staking *StakingTotals `json:"staking,omitempty"` // Nil if the staking information is not available
+}
+
+// StakingTotals is the summary of the staking information used at a block.
+type StakingTotals struct {
+	BlockNum           uint64 `json:"blockNum"` // Block number where the staking information is fetched
+	CouncilSize        int    `json:"councilSize"`
+	TotalStakingAmount uint64 `json:"totalStakingAmount"` // In KLAY like the staking amounts of StakingInfo
+}
+
+// GetChainEconomics returns the economic parameters effective at a given block number and
+// the amounts minted, collected and burnt at the block, which are otherwise assembled from
+// the chain config, the governance parameters, the rewards and the staking information.
+func (api *GovernanceKlayAPI) GetChainEconomics(num *rpc.BlockNumber) (*ChainEconomics, error) {
+	blockNumber := resolveBlockNumber(api.chain, num)
+	header := api.chain.GetHeaderByNumber(blockNumber)
+	if header == nil {
+		return nil, blockNotFoundError(blockNumber)
+	}
+
+	pset, err := api.governance.EffectiveParams(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	rules := api.chain.Config().Rules(header.Number)
+	rewardParamSet, err := api.governance.EffectiveParams(reward.CalcRewardParamBlock(blockNumber, pset.Epoch(), rules))
+	if err != nil {
+		return nil, err
+	}
+	spec, err := api.blockReward(header)
+	if err != nil {
+		return nil, err
+	}
+
+	economics := &ChainEconomics{
+		BlockNumber: header.Number,
+		UnitPrice:   pset.UnitPrice(),
+		BaseFee:     header.BaseFee,
+		KIP71:       pset.ToKIP71Config(),
+		Reward:      rewardParamSet.ToRewardConfig(),
+		Minted:      spec.Minted,
+		TotalFee:    spec.TotalFee,
+		BurntFee:    spec.BurntFee,
+	}
+	if reward.GetStakingManager() != nil {
+		if stakingInfo := reward.GetStakingInfo(blockNumber); stakingInfo != nil {
+			economics.Staking = &StakingTotals{
+				BlockNum:    stakingInfo.BlockNum,
+				CouncilSize: len(stakingInfo.CouncilNodeAddrs),
+			}
+			for _, amount := range stakingInfo.CouncilStakingAmounts {
+				economics.Staking.TotalStakingAmount += amount
+			}
+		}
+	}
+	return economics, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChainEconomics(t *testing.T) {
+	config := getTestConfig()
+	config.Governance.Reward.MintingAmount = big.NewInt(params.KLAY)
+	config.Governance.Reward.Ratio = "50/40/10"
+
+	dbm := database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB})
+	e := NewMixedEngine(config, dbm)
+	bc := newTestBlockchain(config)
+	bc.SetBlockNum(10)
+	e.SetBlockchain(bc)
+	pset, err := params.NewGovParamSetChainConfig(config)
+	require.NoError(t, err)
+	gset := NewGovernanceSet()
+	gset.Import(pset.StrMap())
+	require.NoError(t, e.headerGov.WriteGovernance(0, NewGovernanceSet(), gset))
+	api := NewGovernanceKlayAPI(e, bc)
+
+	latest := rpc.LatestBlockNumber
+	economics, err := api.GetChainEconomics(&latest)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(10), economics.BlockNumber)
+	assert.Equal(t, config.UnitPrice, economics.UnitPrice)
+	assert.Nil(t, economics.BaseFee)
+	assert.Equal(t, params.GetDefaultKIP71Config(), economics.KIP71)
+	assert.Equal(t, big.NewInt(params.KLAY), economics.Reward.MintingAmount)
+	assert.Equal(t, "50/40/10", economics.Reward.Ratio)
+	assert.Equal(t, big.NewInt(params.KLAY), economics.Minted)
+	assert.Zero(t, economics.BurntFee.Sign())
+	assert.Nil(t, economics.Staking)
+
+	// the staking totals are included if the staking information is available
+	defer reward.SetTestStakingManager(reward.GetStakingManager())
+	reward.SetTestStakingManagerWithStakingInfoCache(&reward.StakingInfo{
+		CouncilNodeAddrs:      []common.Address{common.HexToAddress("0xa"), common.HexToAddress("0xb")},
+		CouncilStakingAmounts: []uint64{5000000, 7000000},
+	})
+	number := rpc.BlockNumber(5)
+	economics, err = api.GetChainEconomics(&number)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(5), economics.BlockNumber)
+	assert.Equal(t, &StakingTotals{BlockNum: 0, CouncilSize: 2, TotalStakingAmount: 12000000}, economics.Staking)
+}
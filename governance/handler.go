@@ -19,7 +19,6 @@ package governance
 import (
 	"math/big"
 	"reflect"
-	"strconv"
 	"strings"
 	"sync/atomic"
 
@@ -65,6 +64,14 @@ var GovernanceItems = map[int]check{
 	params.MinimumStake:              {stringT, checkBigInt, nil},
 	params.StakeUpdateInterval:       {uint64T, checkUint64andBool, nil},
 	params.ProposerRefreshInterval:   {uint64T, checkUint64andBool, nil},
+	params.RewardRedirectAddress:     {addressT, checkAddress, nil},
+	params.KFFSplit:                  {stringT, checkKFFSplit, nil},
+	params.KCFSplit:                  {stringT, checkKCFSplit, nil},
+	params.RemainderPolicy:           {stringT, checkRemainderPolicy, nil},
+	params.ProposerSplit:             {stringT, checkProposerSplit, nil},
+	params.MinStakeInclusive:         {boolT, checkBool, nil},
+	params.BurnRatio:                 {uint64T, checkBurnRatio, nil},
+	params.PayoutGasStipend:          {uint64T, checkUint64andBool, nil},
 	params.Epoch:                     {uint64T, checkUint64andBool, nil},
 	params.Policy:                    {uint64T, checkUint64andBool, nil},
 	params.CommitteeSize:             {uint64T, checkCommitteeSize, nil},
@@ -88,7 +95,7 @@ func (g *Governance) AddVote(key string, val interface{}) bool {
 
 	vote := &GovernanceVote{Key: key, Value: val}
 	var ok bool
-	if vote, ok = g.ValidateVote(vote); ok {
+	if vote, ok = g.ValidateVote(vote); ok && g.checkFork(GovernanceKeyMap[vote.Key], g.nextBlockNumber()) {
 		g.voteMap.SetValue(key, VoteStatus{
 			Value:  vote.Value,
 			Casted: false,
@@ -183,49 +190,68 @@ func (gov *Governance) ValidateVote(vote *GovernanceVote) (*GovernanceVote, bool
 	vote.Value = gov.adjustValueType(vote.Key, vote.Value)
 
 	if checkKey(vote.Key) && checkValueType(vote.Value, GovernanceItems[key].t) {
-		return vote, GovernanceItems[key].validator(vote.Key, vote.Value)
+		return vote, GovernanceItems[key].validator(vote.Key, vote.Value) && gov.checkGasless(key, vote.Value)
 	}
 	return vote, false
 }
 
-func checkRatio(k string, v interface{}) bool {
-	x := strings.Split(v.(string), "/")
-	if len(x) != params.RewardSliceCount {
-		return false
+// checkGasless rejects a vote putting a price on the gas of a gasless chain.
+func (gov *Governance) checkGasless(key int, v interface{}) bool {
+	if gov.ChainConfig == nil || !gov.ChainConfig.Gasless {
+		return true
 	}
-	var sum uint64
-	for _, item := range x {
-		v, err := strconv.ParseUint(item, 10, 64)
-		if err != nil {
-			return false
-		}
-		sum += v
+	if fixed, ok := gaslessParams[key]; ok {
+		return v == fixed
 	}
-	if sum == 100 {
+	return true
+}
+
+// checkFork rejects a vote on a param whose optional hardfork is not enabled at the block num.
+func (gov *Governance) checkFork(key int, num *big.Int) bool {
+	isForkEnabled, ok := forkGatedParams[key]
+	if !ok {
 		return true
-	} else {
-		return false
 	}
+	return gov.ChainConfig != nil && isForkEnabled(gov.ChainConfig, num)
 }
 
-func checkKip82Ratio(k string, v interface{}) bool {
-	x := strings.Split(v.(string), "/")
-	if len(x) != params.RewardKip82SliceCount {
-		return false
-	}
-	var sum uint64
-	for _, item := range x {
-		v, err := strconv.ParseUint(item, 10, 64)
-		if err != nil {
-			return false
-		}
-		sum += v
-	}
-	if sum == 100 {
-		return true
-	} else {
-		return false
+// nextBlockNumber returns the number of the block where a vote added now is cast at the earliest.
+func (gov *Governance) nextBlockNumber() *big.Int {
+	if gov.blockChain == nil {
+		return new(big.Int)
 	}
+	return new(big.Int).Add(gov.blockChain.CurrentHeader().Number, common.Big1)
+}
+
+func checkRatio(k string, v interface{}) bool {
+	return params.IsValidRatio(v.(string), params.RewardSliceCount)
+}
+
+func checkKip82Ratio(k string, v interface{}) bool {
+	return params.IsValidRatio(v.(string), params.RewardKip82SliceCount)
+}
+
+func checkKFFSplit(k string, v interface{}) bool {
+	_, err := params.ParseKFFSplit(v.(string))
+	return err == nil
+}
+
+func checkKCFSplit(k string, v interface{}) bool {
+	_, err := params.ParseKCFSplit(v.(string))
+	return err == nil
+}
+
+func checkRemainderPolicy(k string, v interface{}) bool {
+	return params.ValidateRemainderPolicy(v.(string)) == nil
+}
+
+func checkProposerSplit(k string, v interface{}) bool {
+	_, err := params.ParseProposerSplit(v.(string))
+	return err == nil
+}
+
+func checkBurnRatio(k string, v interface{}) bool {
+	return checkUint64andBool(k, v) && v.(uint64) <= 100
 }
 
 func checkGovernanceMode(k string, v interface{}) bool {
@@ -253,6 +279,10 @@ func checkUint64andBool(k string, v interface{}) bool {
 	return false
 }
 
+func checkBool(k string, v interface{}) bool {
+	return reflect.TypeOf(v) == boolT
+}
+
 func checkProposerPolicy(k string, v interface{}) bool {
 	if _, ok := ProposerPolicyMap[v.(string)]; ok {
 		return true
@@ -369,7 +399,7 @@ func (gov *Governance) HandleGovernanceVote(valset istanbul.ValidatorSet, votes
 
 		number := header.Number.Uint64()
 		// Check vote's validity
-		if gVote, ok := gov.ValidateVote(gVote); ok {
+		if gVote, ok := gov.ValidateVote(gVote); ok && gov.checkFork(GovernanceKeyMap[gVote.Key], header.Number) {
 			pset, err := gov.EffectiveParams(number)
 			if err != nil {
 				logger.Error("EffectiveParams failed", "number", number)
@@ -0,0 +1,291 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/klaytn/klaytn/accounts/abi/bind/backends"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+)
+
+// HardforkCheck is the result of checking a prerequisite of a hardfork.
+type HardforkCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// HardforkReadiness reports whether this node is ready for a hardfork. The node is ready
+// if all the prerequisites are met.
+type HardforkReadiness struct {
+	Name      string           `json:"name"`
+	Block     *big.Int         `json:"block"`
+	Activated bool             `json:"activated"`
+	Ready     bool             `json:"ready"`
+	Checks    []*HardforkCheck `json:"checks"`
+}
+
+// hardfork describes the prerequisites of a hardfork supported by this binary.
+type hardfork struct {
+	name   string
+	block  func(config *params.ChainConfig) *big.Int
+	params []string                                   // Governance parameters required by the fork
+	checks func(c *readinessChecker) []*HardforkCheck // Additional prerequisites
+}
+
+var hardforks = []hardfork{
+	{name: "istanbul", block: func(c *params.ChainConfig) *big.Int { return c.IstanbulCompatibleBlock }},
+	{name: "london", block: func(c *params.ChainConfig) *big.Int { return c.LondonCompatibleBlock }},
+	{name: "ethTxType", block: func(c *params.ChainConfig) *big.Int { return c.EthTxTypeCompatibleBlock }},
+	{
+		name:  "magma",
+		block: func(c *params.ChainConfig) *big.Int { return c.MagmaCompatibleBlock },
+		params: []string{
+			"kip71.lowerboundbasefee", "kip71.upperboundbasefee", "kip71.gastarget",
+			"kip71.maxblockgasusedforbasefee", "kip71.basefeedenominator",
+		},
+	},
+	{
+		name:   "kore",
+		block:  func(c *params.ChainConfig) *big.Int { return c.KoreCompatibleBlock },
+		params: []string{"reward.kip82ratio"},
+	},
+	{name: "shanghai", block: func(c *params.ChainConfig) *big.Int { return c.ShanghaiCompatibleBlock }},
+	{name: "cancun", block: func(c *params.ChainConfig) *big.Int { return c.CancunCompatibleBlock }},
+	{
+		name:   "kip103",
+		block:  func(c *params.ChainConfig) *big.Int { return c.Kip103CompatibleBlock },
+		checks: (*readinessChecker).kip103Checks,
+	},
+	{
+		name:   "randao",
+		block:  func(c *params.ChainConfig) *big.Int { return c.RandaoCompatibleBlock },
+		checks: (*readinessChecker).randaoChecks,
+	},
+	{name: "rewardRedirect", block: func(c *params.ChainConfig) *big.Int { return c.RewardRedirectCompatibleBlock }},
+	{name: "stakingCommitment", block: func(c *params.ChainConfig) *big.Int { return c.StakingCommitmentCompatibleBlock }},
+	{name: "rewardPayout", block: func(c *params.ChainConfig) *big.Int { return c.RewardPayoutCompatibleBlock }},
+	{name: "pebStaking", block: func(c *params.ChainConfig) *big.Int { return c.PebStakingCompatibleBlock }},
+	{name: "rewardSplit", block: func(c *params.ChainConfig) *big.Int { return c.RewardSplitCompatibleBlock }},
+	{
+		name:   "rewardVesting",
+		block:  func(c *params.ChainConfig) *big.Int { return c.RewardVestingCompatibleBlock },
+		checks: (*readinessChecker).rewardVestingChecks,
+	},
+}
+
+// ScheduledHardfork is a hardfork scheduled in the chain config.
+type ScheduledHardfork struct {
+	Name  string
+	Block *big.Int
+}
+
+// HardforkSchedule returns the hardforks supported by this binary and scheduled in the chain
+// config, in the order of the forks.
+func HardforkSchedule(config *params.ChainConfig) []ScheduledHardfork {
+	var schedule []ScheduledHardfork
+	for _, fork := range hardforks {
+		if block := fork.block(config); block != nil {
+			schedule = append(schedule, ScheduledHardfork{Name: fork.name, Block: block})
+		}
+	}
+	return schedule
+}
+
+// readBlsPublicKeyInfos reads the BLS public keys registered in the KIP-113 contract.
+var readBlsPublicKeyInfos = func(chain blockChain, contract common.Address, num *big.Int) (system.BlsPublicKeyInfos, error) {
+	return system.ReadKip113All(backends.NewBlockchainContractBackend(chain, nil, nil), contract, num)
+}
+
+// readinessChecker checks the prerequisites of a hardfork at the head block.
+type readinessChecker struct {
+	governance Engine
+	config     *params.ChainConfig
+	head       *big.Int
+	state      *state.StateDB
+}
+
+// checkHardforkReadiness checks the prerequisites of the named hardfork on this node.
+// The name is the prefix of the fork block field in the chain config, e.g. "magma".
+func checkHardforkReadiness(governance Engine, name string) (*HardforkReadiness, error) {
+	chain := governance.BlockChain()
+	c := &readinessChecker{
+		governance: governance,
+		config:     chain.Config(),
+		head:       chain.CurrentBlock().Number(),
+	}
+
+	report := &HardforkReadiness{Name: name}
+	var fork *hardfork
+	for i := range hardforks {
+		if strings.EqualFold(hardforks[i].name, name) {
+			fork = &hardforks[i]
+			break
+		}
+	}
+	if fork == nil {
+		report.Checks = append(report.Checks, &HardforkCheck{Name: "binary", Detail: "the fork is not supported by this binary"})
+		return report, nil
+	}
+	report.Name = fork.name
+	report.Checks = append(report.Checks, &HardforkCheck{Name: "binary", Passed: true})
+
+	report.Block = fork.block(c.config)
+	scheduled := &HardforkCheck{Name: "scheduled", Passed: report.Block != nil}
+	if report.Block == nil {
+		scheduled.Detail = "the fork block is not configured"
+	} else {
+		report.Activated = c.head.Cmp(report.Block) >= 0
+		scheduled.Detail = fmt.Sprintf("block %v", report.Block)
+	}
+	report.Checks = append(report.Checks, scheduled)
+
+	if len(fork.params) > 0 {
+		// The parameters for the next block are checked, since the pending votes may not be
+		// applied before the fork block.
+		pset, err := governance.EffectiveParams(c.head.Uint64() + 1)
+		if err != nil {
+			return nil, err
+		}
+		values := pset.StrMap()
+		for _, param := range fork.params {
+			check := &HardforkCheck{Name: "param " + param}
+			if value, ok := values[param]; ok {
+				check.Passed, check.Detail = true, fmt.Sprint(value)
+			} else {
+				check.Detail = "the parameter is not set"
+			}
+			report.Checks = append(report.Checks, check)
+		}
+	}
+	if fork.checks != nil {
+		report.Checks = append(report.Checks, fork.checks(c)...)
+	}
+
+	report.Ready = true
+	for _, check := range report.Checks {
+		report.Ready = report.Ready && check.Passed
+	}
+	return report, nil
+}
+
+// checkContract checks whether a contract is deployed at the address in the head state.
+func (c *readinessChecker) checkContract(name string, addr common.Address) *HardforkCheck {
+	check := &HardforkCheck{Name: name}
+	if common.EmptyAddress(addr) {
+		check.Detail = "the contract address is not configured"
+		return check
+	}
+	if c.state == nil {
+		st, err := c.governance.BlockChain().State()
+		if err != nil || st == nil {
+			check.Detail = fmt.Sprintf("the head state is not available: %v", err)
+			return check
+		}
+		c.state = st
+	}
+	if c.state.GetCodeSize(addr) == 0 {
+		check.Detail = fmt.Sprintf("no contract is deployed at %s", addr.Hex())
+		return check
+	}
+	check.Passed, check.Detail = true, addr.Hex()
+	return check
+}
+
+func (c *readinessChecker) kip103Checks() []*HardforkCheck {
+	return []*HardforkCheck{c.checkContract("kip103 contract", c.config.Kip103ContractAddress)}
+}
+
+func (c *readinessChecker) randaoChecks() []*HardforkCheck {
+	registry := &HardforkCheck{Name: "randao registry"}
+	var kip113 common.Address
+	switch {
+	case c.config.RandaoRegistry == nil:
+		registry.Detail = "the registry is not configured"
+	case common.EmptyAddress(c.config.RandaoRegistry.Owner):
+		registry.Detail = "the registry owner is not configured"
+	case common.EmptyAddress(c.config.RandaoRegistry.Records[system.Kip113Name]):
+		registry.Detail = fmt.Sprintf("the %s record is not configured", system.Kip113Name)
+	default:
+		registry.Passed = true
+		kip113 = c.config.RandaoRegistry.Records[system.Kip113Name]
+	}
+	checks := []*HardforkCheck{registry}
+	contract := c.checkContract("kip113 contract", kip113)
+	checks = append(checks, contract)
+	if !contract.Passed {
+		return checks
+	}
+
+	nodeKey := &HardforkCheck{Name: "bls key of this node"}
+	checks = append(checks, nodeKey)
+	infos, err := readBlsPublicKeyInfos(c.governance.BlockChain(), kip113, c.head)
+	if err != nil {
+		nodeKey.Detail = fmt.Sprintf("failed to read the BLS keys: %v", err)
+		return checks
+	}
+	if _, ok := infos[c.governance.NodeAddress()]; ok {
+		nodeKey.Passed = true
+	} else {
+		nodeKey.Detail = fmt.Sprintf("no valid BLS key is registered for %s", c.governance.NodeAddress().Hex())
+	}
+
+	// The keys of the other council nodes are checked if the staking information is available.
+	if reward.GetStakingManager() == nil {
+		return checks
+	}
+	if stakingInfo := reward.GetStakingInfo(c.head.Uint64()); stakingInfo != nil {
+		councilKeys := &HardforkCheck{Name: "bls keys of council nodes"}
+		var missing []string
+		for _, node := range stakingInfo.CouncilNodeAddrs {
+			if _, ok := infos[node]; !ok {
+				missing = append(missing, node.Hex())
+			}
+		}
+		sort.Strings(missing)
+		if len(missing) == 0 {
+			councilKeys.Passed = true
+		} else {
+			councilKeys.Detail = "no valid BLS key is registered for " + strings.Join(missing, ", ")
+		}
+		checks = append(checks, councilKeys)
+	}
+	return checks
+}
+
+func (c *readinessChecker) rewardVestingChecks() []*HardforkCheck {
+	schedule := &HardforkCheck{Name: "vesting schedule"}
+	switch {
+	case c.config.RewardVesting == nil:
+		schedule.Detail = "the vesting schedule is not configured"
+	case len(c.config.RewardVesting.Recipients) == 0:
+		schedule.Detail = "no vesting recipient is configured"
+	case c.config.RewardVesting.Period == 0:
+		schedule.Detail = "the vesting period is not configured"
+	default:
+		schedule.Passed = true
+	}
+	return []*HardforkCheck{schedule}
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStateBlockChain is a testBlockChain with the head state.
+type testStateBlockChain struct {
+	*testBlockChain
+	state *state.StateDB
+}
+
+func (bc *testStateBlockChain) State() (*state.StateDB, error) { return bc.state, nil }
+
+func TestCheckHardforkReadiness(t *testing.T) {
+	var (
+		nodeAddr   = common.HexToAddress("0x52d41ca72af615a1ac3301b0a93efa222ecc7541")
+		kip103Addr = common.HexToAddress("0x1030")
+		kip113Addr = common.HexToAddress("0x1130")
+	)
+	config := params.TestChainConfig.Copy()
+	config.Istanbul = params.GetDefaultIstanbulConfig()
+	config.Governance = params.GetDefaultGovernanceConfig()
+	config.MagmaCompatibleBlock = big.NewInt(100)
+	config.Kip103CompatibleBlock = big.NewInt(100)
+	config.Kip103ContractAddress = kip103Addr
+	config.RandaoCompatibleBlock = big.NewInt(100)
+
+	st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	require.NoError(t, err)
+	gov := NewMixedEngine(config, database.NewMemoryDBManager())
+	gov.SetNodeAddress(nodeAddr)
+	bc := &testStateBlockChain{testBlockChain: newTestBlockchain(config), state: st}
+	bc.SetBlockNum(50)
+	gov.SetBlockchain(bc)
+	api := NewGovernanceAPI(gov)
+
+	var blsInfos system.BlsPublicKeyInfos
+	defer func(read func(blockChain, common.Address, *big.Int) (system.BlsPublicKeyInfos, error)) {
+		readBlsPublicKeyInfos = read
+	}(readBlsPublicKeyInfos)
+	readBlsPublicKeyInfos = func(chain blockChain, contract common.Address, num *big.Int) (system.BlsPublicKeyInfos, error) {
+		assert.Equal(t, kip113Addr, contract)
+		return blsInfos, nil
+	}
+
+	failed := func(report *HardforkReadiness) []string {
+		var names []string
+		for _, check := range report.Checks {
+			if !check.Passed {
+				names = append(names, check.Name)
+			}
+		}
+		return names
+	}
+	check := func(name string) *HardforkReadiness {
+		report, err := api.CheckHardforkReadiness(name)
+		require.NoError(t, err)
+		return report
+	}
+
+	// the fork unknown to this binary
+	report := check("prague")
+	assert.False(t, report.Ready)
+	assert.Equal(t, []string{"binary"}, failed(report))
+
+	// the fork is scheduled and the parameters are set
+	report = check("Magma")
+	assert.Equal(t, "magma", report.Name)
+	assert.Equal(t, big.NewInt(100), report.Block)
+	assert.False(t, report.Activated)
+	assert.True(t, report.Ready)
+	assert.Len(t, report.Checks, 7)
+
+	// the fork is not scheduled
+	report = check("kore")
+	assert.False(t, report.Ready)
+	assert.Nil(t, report.Block)
+	assert.Equal(t, []string{"scheduled"}, failed(report))
+
+	// the contract is deployed later
+	assert.Equal(t, []string{"kip103 contract"}, failed(check("kip103")))
+	st.SetCode(kip103Addr, []byte{0x00})
+	assert.True(t, check("kip103").Ready)
+
+	// the registry, the contract and the BLS key of this node are required
+	assert.Equal(t, []string{"randao registry", "kip113 contract"}, failed(check("randao")))
+	config.RandaoRegistry = &params.RegistryConfig{
+		Records: map[string]common.Address{system.Kip113Name: kip113Addr},
+		Owner:   common.HexToAddress("0x0123"),
+	}
+	assert.Equal(t, []string{"kip113 contract"}, failed(check("randao")))
+	st.SetCode(kip113Addr, []byte{0x00})
+	assert.Equal(t, []string{"bls key of this node"}, failed(check("randao")))
+	blsInfos = system.BlsPublicKeyInfos{nodeAddr: {}}
+	assert.True(t, check("randao").Ready)
+
+	// the BLS keys of the other council nodes are checked with the staking information
+	otherAddr := common.HexToAddress("0xc0ffee")
+	defer reward.SetTestStakingManager(reward.GetStakingManager())
+	reward.SetTestStakingManagerWithStakingInfoCache(&reward.StakingInfo{CouncilNodeAddrs: []common.Address{nodeAddr, otherAddr}})
+	report = check("randao")
+	assert.Equal(t, []string{"bls keys of council nodes"}, failed(report))
+	assert.Contains(t, report.Checks[len(report.Checks)-1].Detail, otherAddr.Hex())
+	blsInfos[otherAddr] = system.BlsPublicKeyInfo{}
+	assert.True(t, check("randao").Ready)
+
+	// the activated fork
+	bc.SetBlockNum(100)
+	report = check("randao")
+	assert.True(t, report.Activated)
+	assert.True(t, report.Ready)
+}
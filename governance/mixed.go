@@ -30,6 +30,7 @@ import (
 //
 // Each parameter is added to a parameter set from one of the following sources:
 // The highest priority is 1, and falls back to lower ones if non-existent
+//  0. gaslessParams:  zero prices fixed on a gasless chain
 //  1. contractParams: ContractEngine items (when enabled)
 //  2. headerParams:   Header Governance items
 //  3. initialParams:  initial ChainConfig from genesis.json
@@ -41,6 +42,7 @@ type MixedEngine struct {
 
 	initialParams *params.GovParamSet // initial ChainConfig
 	defaultParams *params.GovParamSet // default constants used as last fallback
+	gaslessParams *params.GovParamSet // zero prices overriding all the others on a gasless chain
 
 	currentParams *params.GovParamSet // latest params to be returned by CurrentParams()
 
@@ -88,6 +90,14 @@ func newMixedEngine(config *params.ChainConfig, db database.DBManager, doInit bo
 		params.BaseFeeDenominator:        params.DefaultBaseFeeDenominator,
 		params.GovParamContract:          params.DefaultGovParamContract,
 		params.Kip82Ratio:                params.DefaultKip82Ratio,
+		params.RewardRedirectAddress:     params.DefaultRewardRedirectAddress,
+		params.KFFSplit:                  params.DefaultKFFSplit,
+		params.BurnRatio:                 params.DefaultBurnRatio,
+		params.PayoutGasStipend:          params.DefaultPayoutGasStipend,
+		params.KCFSplit:                  params.DefaultKCFSplit,
+		params.RemainderPolicy:           params.DefaultRemainderPolicy,
+		params.ProposerSplit:             params.DefaultProposerSplit,
+		params.MinStakeInclusive:         params.DefaultMinStakeInclusive,
 	}
 	if p, err := params.NewGovParamSetIntMap(defaultMap); err == nil {
 		e.defaultParams = p
@@ -95,6 +105,12 @@ func newMixedEngine(config *params.ChainConfig, db database.DBManager, doInit bo
 		logger.Crit("Error parsing initial ParamSet", "err", err)
 	}
 
+	if p, err := params.NewGovParamSetIntMap(gaslessParams); err == nil {
+		e.gaslessParams = p
+	} else {
+		logger.Crit("Error parsing gasless ParamSet", "err", err)
+	}
+
 	// Setup subordinate engines
 	if doInit {
 		e.headerGov = NewGovernanceInitialize(config, db)
@@ -182,6 +198,9 @@ func (e *MixedEngine) assembleParams(headerParams, contractParams *params.GovPar
 	p = params.NewGovParamSetMerged(p, e.initialParams)
 	p = params.NewGovParamSetMerged(p, headerParams)
 	p = params.NewGovParamSetMerged(p, contractParams)
+	if e.config.Gasless {
+		p = params.NewGovParamSetMerged(p, e.gaslessParams)
+	}
 	return p
 }
 
@@ -223,6 +242,23 @@ func (e *MixedEngine) handleParamUpdate(old, new *params.GovParamSet) {
 			case params.ProposerRefreshInterval:
 				e.config.Governance.Reward.ProposerUpdateInterval = new.ProposerRefreshInterval()
 				params.SetProposerUpdateInterval(new.ProposerRefreshInterval())
+			case params.RewardRedirectAddress:
+				e.config.Governance.Reward.RedirectAddress = new.RewardRedirectAddress()
+			case params.KFFSplit:
+				e.config.Governance.Reward.KFFSplit = new.KFFSplit()
+			case params.BurnRatio:
+				burnRatio := new.BurnRatio()
+				e.config.Governance.Reward.BurnRatio = &burnRatio
+			case params.PayoutGasStipend:
+				e.config.Governance.Reward.PayoutGasStipend = new.PayoutGasStipend()
+			case params.KCFSplit:
+				e.config.Governance.Reward.KCFSplit = new.KCFSplit()
+			case params.RemainderPolicy:
+				e.config.Governance.Reward.RemainderPolicy = new.RemainderPolicy()
+			case params.ProposerSplit:
+				e.config.Governance.Reward.ProposerSplit = new.ProposerSplit()
+			case params.MinStakeInclusive:
+				e.config.Governance.Reward.MinStakeInclusive = new.MinStakeInclusive()
 			// config.Governance.KIP17
 			case params.LowerBoundBaseFee:
 				e.config.Governance.KIP71.LowerBoundBaseFee = new.LowerBoundBaseFee()
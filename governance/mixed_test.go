@@ -180,6 +180,43 @@ func TestMixedEngine_Params(t *testing.T) {
 	require.Equal(t, valueC, e.CurrentParams().GasTarget(), "fallback to contractGov failed")
 }
 
+// Without ContractGov, check that the prices are fixed to zero on a gasless chain
+func TestMixedEngine_Header_Gasless(t *testing.T) {
+	valueA := uint64(0x11)
+
+	config := getTestConfig()
+	config.Gasless = true
+	config.UnitPrice = 0
+	config.Governance.KIP71.LowerBoundBaseFee = 0
+	config.Governance.KIP71.UpperBoundBaseFee = 0
+	e := newTestMixedEngineNoContractEngine(t, config)
+
+	// no vote can put a price on the gas
+	assert.False(t, e.AddVote("governance.unitprice", uint64(25)))
+	assert.False(t, e.AddVote("kip71.lowerboundbasefee", uint64(25)))
+	assert.False(t, e.AddVote("kip71.upperboundbasefee", uint64(25)))
+	assert.True(t, e.AddVote("governance.unitprice", uint64(0)))
+	assert.True(t, e.AddVote("kip71.gastarget", valueA))
+
+	// the prices stay zero even if a price is written to the header governance
+	items := e.CurrentParams().StrMap()
+	items["governance.unitprice"] = uint64(25)
+	items["kip71.upperboundbasefee"] = uint64(25)
+	items["kip71.gastarget"] = valueA
+	gset := NewGovernanceSet()
+	gset.Import(items)
+	err := e.headerGov.WriteGovernance(e.CurrentParams().Epoch(), NewGovernanceSet(), gset)
+	assert.Nil(t, err)
+	err = e.UpdateParams(e.CurrentParams().Epoch() * 2)
+	assert.Nil(t, err)
+
+	assert.Equal(t, uint64(0), e.CurrentParams().UnitPrice())
+	assert.Equal(t, uint64(0), e.CurrentParams().UpperBoundBaseFee())
+	assert.Equal(t, valueA, e.CurrentParams().GasTarget())
+	assert.Equal(t, uint64(0), config.UnitPrice)
+	assert.Equal(t, uint64(0), config.Governance.KIP71.UpperBoundBaseFee)
+}
+
 // TestMixedEngine_ParamsAt tests if EffectiveParams() returns correct values
 // given headerBlock and contractBlock;
 //
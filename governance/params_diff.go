@@ -0,0 +1,294 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+const (
+	ParamSourceHeader   = "header"   // The change was voted in the block headers
+	ParamSourceContract = "contract" // The change was made in the GovParam contract
+	ParamSourceUnknown  = "unknown"  // The change cannot be attributed, e.g. a derived parameter
+)
+
+var errFutureBlock = rpc.NewAPIError(rpc.CodeInvalidInput, "futureBlock",
+	"the block number should be equal or less than the current block number")
+
+// ParamChangeVote is a vote for a parameter change cast in a block header.
+type ParamChangeVote struct {
+	BlockNumber uint64         `json:"blockNumber"`
+	Validator   common.Address `json:"validator"`
+	Value       interface{}    `json:"value"`
+}
+
+// ParamChange is the difference of a governance parameter between two blocks.
+type ParamChange struct {
+	Key    string      `json:"key"`
+	From   interface{} `json:"from"`
+	To     interface{} `json:"to"`
+	Source string      `json:"source"`
+
+	// For the changes voted in the headers, the epoch block whose header carried the
+	// last change between the two blocks, the block from which it is effective and the
+	// votes cast for it during the epoch.
+	EpochBlock     *uint64            `json:"epochBlock,omitempty"`
+	EffectiveBlock *uint64            `json:"effectiveBlock,omitempty"`
+	Votes          []*ParamChangeVote `json:"votes,omitempty"`
+}
+
+// ParamsDiff is the list of the governance parameter changes between two blocks.
+type ParamsDiff struct {
+	From    uint64         `json:"from"`
+	To      uint64         `json:"to"`
+	Changes []*ParamChange `json:"changes"`
+}
+
+// DiffParams returns the differences of the governance parameters in effect at blockA and
+// blockB, with the epoch and the votes which introduced each of them.
+func (api *GovernanceAPI) DiffParams(blockA, blockB rpc.BlockNumber) (*ParamsDiff, error) {
+	return diffParams(api.governance, blockA, blockB)
+}
+
+func diffParams(governance Engine, blockA, blockB rpc.BlockNumber) (*ParamsDiff, error) {
+	chain := governance.BlockChain()
+	current := chain.CurrentBlock().NumberU64()
+	from, to := resolveBlockNumber(chain, &blockA), resolveBlockNumber(chain, &blockB)
+	if from > current || to > current {
+		return nil, errFutureBlock
+	}
+
+	psetFrom, err := governance.EffectiveParams(from)
+	if err != nil {
+		return nil, err
+	}
+	psetTo, err := governance.EffectiveParams(to)
+	if err != nil {
+		return nil, err
+	}
+
+	valuesFrom, valuesTo := psetFrom.StrMap(), psetTo.StrMap()
+	changes := make(map[string]*ParamChange)
+	for key, value := range valuesTo {
+		if prev, ok := valuesFrom[key]; !ok || !sameParamValue(prev, value) {
+			changes[key] = &ParamChange{Key: key, From: prev, To: value, Source: ParamSourceUnknown}
+		}
+	}
+	for key, prev := range valuesFrom {
+		if _, ok := valuesTo[key]; !ok {
+			changes[key] = &ParamChange{Key: key, From: prev, Source: ParamSourceUnknown}
+		}
+	}
+
+	diff := &ParamsDiff{From: from, To: to, Changes: make([]*ParamChange, 0, len(changes))}
+	if len(changes) > 0 {
+		lo, hi := from, to
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		if err := attributeContractChanges(governance, changes, lo, hi); err != nil {
+			return nil, err
+		}
+		if err := attributeHeaderChanges(governance, changes, lo, hi); err != nil {
+			return nil, err
+		}
+	}
+	for _, change := range changes {
+		diff.Changes = append(diff.Changes, change)
+	}
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		return diff.Changes[i].Key < diff.Changes[j].Key
+	})
+	return diff, nil
+}
+
+// attributeContractChanges marks the changes made in the GovParam contract between the blocks.
+func attributeContractChanges(governance Engine, changes map[string]*ParamChange, lo, hi uint64) error {
+	contractParams := func(num uint64) (map[string]interface{}, error) {
+		if !governance.BlockChain().Config().IsKoreForkEnabled(new(big.Int).SetUint64(num)) {
+			return nil, nil
+		}
+		pset, err := governance.ContractGov().EffectiveParams(num)
+		if err != nil {
+			return nil, err
+		}
+		return pset.StrMap(), nil
+	}
+	valuesLo, err := contractParams(lo)
+	if err != nil {
+		return err
+	}
+	valuesHi, err := contractParams(hi)
+	if err != nil {
+		return err
+	}
+	for key, change := range changes {
+		prev, okLo := valuesLo[key]
+		value, okHi := valuesHi[key]
+		if okLo != okHi || (okHi && !sameParamValue(prev, value)) {
+			change.Source = ParamSourceContract
+		}
+	}
+	return nil
+}
+
+// attributeHeaderChanges finds the epoch blocks which carried the changes voted in the
+// headers between the blocks, and the votes for them.
+func attributeHeaderChanges(governance Engine, changes map[string]*ParamChange, lo, hi uint64) error {
+	var (
+		db     = governance.DB()
+		config = governance.BlockChain().Config()
+		epoch  = governance.CurrentParams().Epoch()
+	)
+	indices, err := db.ReadRecentGovernanceIdx(0)
+	if err != nil {
+		return err
+	}
+	idxLo, idxHi := governanceIdxAt(config, indices, epoch, lo), governanceIdxAt(config, indices, epoch, hi)
+	if idxLo == idxHi {
+		return nil
+	}
+
+	prev, err := db.ReadGovernance(idxLo)
+	if err != nil {
+		return err
+	}
+	prev = adjustDecodedSet(prev)
+	var (
+		epochBlocks = make(map[uint64][]*ParamChange)
+		votedValues = make(map[*ParamChange]interface{}) // The values carried by the epoch blocks
+	)
+	for _, idx := range indices {
+		if idx <= idxLo || idx > idxHi {
+			continue
+		}
+		items, err := db.ReadGovernance(idx)
+		if err != nil {
+			return err
+		}
+		items = adjustDecodedSet(items)
+		for key, change := range changes {
+			if change.Source == ParamSourceContract || sameParamValue(prev[key], items[key]) {
+				continue
+			}
+			// Keep the last change of the key between the blocks.
+			if change.EpochBlock != nil {
+				epochBlocks[*change.EpochBlock] = removeParamChange(epochBlocks[*change.EpochBlock], change)
+			}
+			epochBlock, effectiveBlock := idx, governanceEffectiveBlock(config, idx, epoch)
+			change.Source = ParamSourceHeader
+			change.EpochBlock, change.EffectiveBlock = &epochBlock, &effectiveBlock
+			epochBlocks[idx] = append(epochBlocks[idx], change)
+			votedValues[change] = items[key]
+		}
+		prev = items
+	}
+
+	for idx, changed := range epochBlocks {
+		if len(changed) > 0 {
+			collectParamChangeVotes(governance, changed, votedValues, idx, epoch)
+		}
+	}
+	return nil
+}
+
+// collectParamChangeVotes collects the votes for the changes cast in the headers of the
+// epoch ending at the given epoch block.
+func collectParamChangeVotes(governance Engine, changed []*ParamChange, votedValues map[*ParamChange]interface{}, epochBlock, epoch uint64) {
+	headerGov, ok := governance.HeaderGov().(*Governance)
+	if !ok {
+		return
+	}
+	first := uint64(0)
+	if epochBlock > epoch {
+		first = epochBlock - epoch
+	}
+	chain := governance.BlockChain()
+	for num := first; num < epochBlock; num++ {
+		header := chain.GetHeaderByNumber(num)
+		if header == nil || len(header.Vote) == 0 {
+			continue
+		}
+		vote, ok := decodeHeaderVote(headerGov, header)
+		if !ok {
+			continue
+		}
+		for _, change := range changed {
+			if vote.Key == change.Key && sameParamValue(vote.Value, votedValues[change]) {
+				change.Votes = append(change.Votes, &ParamChangeVote{
+					BlockNumber: num,
+					Validator:   vote.Validator,
+					Value:       vote.Value,
+				})
+			}
+		}
+	}
+}
+
+func decodeHeaderVote(gov *Governance, header *types.Header) (*GovernanceVote, bool) {
+	vote := new(GovernanceVote)
+	if err := rlp.DecodeBytes(header.Vote, vote); err != nil {
+		return nil, false
+	}
+	vote, err := gov.ParseVoteValue(vote)
+	if err != nil {
+		return nil, false
+	}
+	return vote, true
+}
+
+// governanceIdxAt returns the governance index in effect at the given block number.
+func governanceIdxAt(config *params.ChainConfig, indices []uint64, epoch, num uint64) uint64 {
+	minimum := CalcGovernanceInfoBlock(governanceReadNumber(config, num), epoch)
+	for i := len(indices) - 1; i >= 0; i-- {
+		if indices[i] <= minimum {
+			return indices[i]
+		}
+	}
+	return 0
+}
+
+// governanceEffectiveBlock returns the first block number where the governance index
+// is in effect.
+func governanceEffectiveBlock(config *params.ChainConfig, idx, epoch uint64) uint64 {
+	num := idx + epoch
+	if !config.IsKoreForkEnabled(new(big.Int).SetUint64(num)) {
+		num++
+	}
+	return num
+}
+
+func removeParamChange(changes []*ParamChange, change *ParamChange) []*ParamChange {
+	for i, c := range changes {
+		if c == change {
+			return append(changes[:i], changes[i+1:]...)
+		}
+	}
+	return changes
+}
+
+func sameParamValue(a, b interface{}) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
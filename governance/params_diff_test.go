@@ -0,0 +1,132 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// voteTestBlockChain is a testBlockChain whose headers carry the given votes.
+type voteTestBlockChain struct {
+	*testBlockChain
+	votes map[uint64][]byte
+}
+
+func (bc *voteTestBlockChain) GetHeaderByNumber(val uint64) *types.Header {
+	header := bc.testBlockChain.GetHeaderByNumber(val)
+	header.Vote = bc.votes[val]
+	return header
+}
+
+func TestDiffParams(t *testing.T) {
+	var (
+		config     = getTestConfig()
+		validator1 = common.HexToAddress("0x0000000000000000000000000000000000000001")
+		validator2 = common.HexToAddress("0x0000000000000000000000000000000000000002")
+	)
+	config.Governance.Reward.MintingAmount = big.NewInt(1)
+	config.Istanbul.Epoch = 3
+	config.KoreCompatibleBlock = big.NewInt(1000)
+
+	encodeVote := func(validator common.Address, key string, value interface{}) []byte {
+		data, err := rlp.EncodeToBytes(&GovernanceVote{Validator: validator, Key: key, Value: value})
+		require.NoError(t, err)
+		return data
+	}
+	bc := &voteTestBlockChain{
+		testBlockChain: newTestBlockchain(config),
+		votes: map[uint64][]byte{
+			1: encodeVote(validator1, "reward.mintingamount", "2"),
+			2: encodeVote(validator2, "reward.mintingamount", "2"),
+			4: encodeVote(validator1, "reward.ratio", "40/30/30"),
+			5: encodeVote(validator2, "reward.mintingamount", "5"), // not passed
+		},
+	}
+	bc.SetBlockNum(12)
+
+	dbm := database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB})
+	e := NewMixedEngine(config, dbm)
+	e.SetBlockchain(bc)
+
+	pset, err := e.EffectiveParams(0)
+	require.NoError(t, err)
+	gset := NewGovernanceSet()
+	gset.Import(pset.StrMap())
+	e.headerGov.WriteGovernance(0, NewGovernanceSet(), gset)
+	for idx, items := range map[uint64]map[string]interface{}{
+		3: {"reward.mintingamount": "2"},
+		6: {"reward.mintingamount": "2", "reward.ratio": "40/30/30"},
+	} {
+		delta := NewGovernanceSet()
+		delta.Import(items)
+		e.headerGov.WriteGovernance(idx, gset, delta)
+	}
+
+	api := NewGovernanceAPI(e)
+	u64 := func(v uint64) *uint64 { return &v }
+
+	// The changes are attributed to the epoch blocks and the votes which introduced them.
+	diff, err := api.DiffParams(rpc.BlockNumber(1), rpc.LatestBlockNumber)
+	require.NoError(t, err)
+	assert.Equal(t, &ParamsDiff{
+		From: 1,
+		To:   12,
+		Changes: []*ParamChange{
+			{
+				Key: "reward.mintingamount", From: "1", To: "2", Source: ParamSourceHeader,
+				EpochBlock: u64(3), EffectiveBlock: u64(7),
+				Votes: []*ParamChangeVote{
+					{BlockNumber: 1, Validator: validator1, Value: "2"},
+					{BlockNumber: 2, Validator: validator2, Value: "2"},
+				},
+			},
+			{
+				Key: "reward.ratio", From: pset.Ratio(), To: "40/30/30", Source: ParamSourceHeader,
+				EpochBlock: u64(6), EffectiveBlock: u64(10),
+				Votes: []*ParamChangeVote{
+					{BlockNumber: 4, Validator: validator1, Value: "40/30/30"},
+				},
+			},
+		},
+	}, diff)
+
+	// The blocks can be given in the reverse order.
+	diff, err = api.DiffParams(rpc.BlockNumber(9), rpc.BlockNumber(6))
+	require.NoError(t, err)
+	require.Len(t, diff.Changes, 1)
+	assert.Equal(t, "reward.mintingamount", diff.Changes[0].Key)
+	assert.Equal(t, "2", diff.Changes[0].From)
+	assert.Equal(t, "1", diff.Changes[0].To)
+	assert.Equal(t, u64(3), diff.Changes[0].EpochBlock)
+
+	// No change in the same epoch.
+	diff, err = api.DiffParams(rpc.BlockNumber(7), rpc.BlockNumber(9))
+	require.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+
+	_, err = api.DiffParams(rpc.BlockNumber(1), rpc.BlockNumber(13))
+	assert.Equal(t, errFutureBlock, err)
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+const (
+	secondsPerDay       = 24 * 60 * 60
+	defaultTopProposers = 10
+)
+
+// RewardSum is the sum of the rewards paid to an address in a block range.
+type RewardSum struct {
+	Address    common.Address `json:"address"`
+	FirstBlock uint64         `json:"firstBlock"`
+	LastBlock  uint64         `json:"lastBlock"`
+	Blocks     uint64         `json:"blocks"` // the number of blocks rewarding the address
+	Total      *big.Int       `json:"total"`
+}
+
+// ProposerRank is the proposer portion received by a rewardbase in a block range.
+type ProposerRank struct {
+	Rewardbase common.Address `json:"rewardbase"`
+	Blocks     uint64         `json:"blocks"` // the number of blocks proposed
+	Proposer   *big.Int       `json:"proposer"`
+}
+
+// BurntFeeBucket is the tx fee burnt in the blocks of a day in UTC.
+type BurntFeeBucket struct {
+	Day        uint64   `json:"day"` // the unix time of the start of the day
+	FirstBlock uint64   `json:"firstBlock"`
+	LastBlock  uint64   `json:"lastBlock"`
+	BurntFee   *big.Int `json:"burntFee"`
+}
+
+var (
+	errRewardsNotIndexed = rpc.NewAPIError(rpc.CodeResourceUnavailable, "rewardsNotIndexed", "rewards are not indexed, enable it with --rewardindexing")
+	errBlockNotIndexed   = rpc.NewAPIError(rpc.CodeResourceUnavailable, "blockNotIndexed", "the block is not indexed")
+)
+
+// resolveIndexedRange returns the block range of [first, last] if it is within the reward index.
+// The reward aggregations are served from the reward index instead of recalculating the reward
+// of each block, so their block ranges are not limited. They are served as the JSON-RPC methods
+// of the klay namespace, e.g. klay_getRewardSum, since the node has no GraphQL endpoint.
+func (api *GovernanceKlayAPI) resolveIndexedRange(first, last rpc.BlockNumber) (uint64, uint64, error) {
+	if api.index == nil {
+		return 0, 0, errRewardsNotIndexed
+	}
+	indexStart, ok := api.index.ReadRewardIndexStart()
+	if !ok {
+		return 0, 0, errRewardsNotIndexed
+	}
+	indexHead, _ := api.index.ReadRewardIndexHead()
+
+	firstBlock, lastBlock, err := api.resolveBlockRange(first, last)
+	if err != nil {
+		return 0, 0, err
+	}
+	if firstBlock < indexStart || lastBlock > indexHead {
+		return 0, 0, errBlockNotIndexed.Errorf("rewards are indexed from block %d to %d", indexStart, indexHead).
+			WithDetail("indexStart", indexStart).WithDetail("indexHead", indexHead)
+	}
+	return firstBlock, lastBlock, nil
+}
+
+// forEachIndexedBlock calls fn with the header and the reward index record of each block in the
+// range of [first, last].
+func (api *GovernanceKlayAPI) forEachIndexedBlock(first, last rpc.BlockNumber, fn func(*types.Header, *database.RewardIndexBlock)) error {
+	firstBlock, lastBlock, err := api.resolveIndexedRange(first, last)
+	if err != nil {
+		return err
+	}
+	for num := firstBlock; num <= lastBlock; num++ {
+		header := api.chain.GetHeaderByNumber(num)
+		if header == nil {
+			return blockNotFoundError(num)
+		}
+		block := api.index.ReadRewardIndexBlock(num)
+		if block == nil {
+			return errBlockNotIndexed.WithDetail("blockNumber", num)
+		}
+		fn(header, block)
+	}
+	return nil
+}
+
+// GetRewardSum returns the sum of the rewards paid to the address in the blocks of the range
+// of [first, last].
+func (api *GovernanceKlayAPI) GetRewardSum(address common.Address, first, last rpc.BlockNumber) (*RewardSum, error) {
+	firstBlock, lastBlock, err := api.resolveIndexedRange(first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	total := big.NewInt(0)
+	if acc := api.index.ReadAccumulatedReward(address, lastBlock); acc != nil {
+		total.Add(acc.Minted, acc.Fee)
+	}
+	if acc := api.index.ReadAccumulatedReward(address, firstBlock-1); firstBlock > 0 && acc != nil {
+		total.Sub(total, acc.Minted)
+		total.Sub(total, acc.Fee)
+	}
+	return &RewardSum{
+		Address:    address,
+		FirstBlock: firstBlock,
+		LastBlock:  lastBlock,
+		Blocks:     api.index.CountAccumulatedRewards(address, firstBlock, lastBlock),
+		Total:      total,
+	}, nil
+}
+
+// GetTopProposers returns the rewardbases which received the largest proposer portions in the
+// blocks of the range of [first, last], at most limit of them. The limit defaults to 10.
+func (api *GovernanceKlayAPI) GetTopProposers(first, last rpc.BlockNumber, limit *int) ([]*ProposerRank, error) {
+	n := defaultTopProposers
+	if limit != nil {
+		if *limit <= 0 {
+			return nil, errInvalidParamValue.Errorf("limit should be positive")
+		}
+		n = *limit
+	}
+
+	ranks := make(map[common.Address]*ProposerRank)
+	err := api.forEachIndexedBlock(first, last, func(header *types.Header, block *database.RewardIndexBlock) {
+		rank, ok := ranks[header.Rewardbase]
+		if !ok {
+			rank = &ProposerRank{Rewardbase: header.Rewardbase, Proposer: big.NewInt(0)}
+			ranks[header.Rewardbase] = rank
+		}
+		rank.Blocks++
+		if block.Proposer != nil {
+			rank.Proposer.Add(rank.Proposer, block.Proposer)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*ProposerRank, 0, len(ranks))
+	for _, rank := range ranks {
+		ret = append(ret, rank)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if c := ret[i].Proposer.Cmp(ret[j].Proposer); c != 0 {
+			return c > 0
+		}
+		if ret[i].Blocks != ret[j].Blocks {
+			return ret[i].Blocks > ret[j].Blocks
+		}
+		return bytes.Compare(ret[i].Rewardbase[:], ret[j].Rewardbase[:]) < 0
+	})
+	if len(ret) > n {
+		ret = ret[:n]
+	}
+	return ret, nil
+}
+
+// GetBurntFeesPerDay returns the tx fees burnt in the blocks of the range of [first, last],
+// bucketed by the day in UTC of the block time.
+func (api *GovernanceKlayAPI) GetBurntFeesPerDay(first, last rpc.BlockNumber) ([]*BurntFeeBucket, error) {
+	var buckets []*BurntFeeBucket
+	err := api.forEachIndexedBlock(first, last, func(header *types.Header, block *database.RewardIndexBlock) {
+		number := header.Number.Uint64()
+		var day uint64
+		if header.Time != nil {
+			day = header.Time.Uint64() / secondsPerDay * secondsPerDay
+		}
+		if len(buckets) == 0 || buckets[len(buckets)-1].Day != day {
+			buckets = append(buckets, &BurntFeeBucket{Day: day, FirstBlock: number, BurntFee: big.NewInt(0)})
+		}
+		bucket := buckets[len(buckets)-1]
+		bucket.LastBlock = number
+		if block.BurntFee != nil {
+			bucket.BurntFee.Add(bucket.BurntFee, block.BurntFee)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRewardAggregationAPI(t *testing.T, num uint64) *GovernanceKlayAPI {
+	config := getTestConfig()
+	config.Governance.Reward.MintingAmount = big.NewInt(10)
+	config.Istanbul.Epoch = 3
+
+	bc := newTestBlockchain(config)
+	e := NewMixedEngine(config, database.NewMemoryDBManager())
+	e.SetBlockchain(bc)
+	pset, err := params.NewGovParamSetChainConfig(config)
+	require.NoError(t, err)
+	gset := NewGovernanceSet()
+	gset.Import(pset.StrMap())
+	e.headerGov.WriteGovernance(0, NewGovernanceSet(), gset)
+	bc.SetBlockNum(num)
+	e.UpdateParams(bc.CurrentBlock().NumberU64())
+	return NewGovernanceKlayAPI(e, bc)
+}
+
+// indexTestRewards indexes the rewards of the blocks in the range of [first, last] as the reward
+// indexer does, and makes the aggregations served from the index.
+func indexTestRewards(t *testing.T, api *GovernanceKlayAPI, first, last uint64) {
+	db := database.NewMemoryDBManager()
+	db.WriteRewardIndexStart(first)
+	specs, err := api.GetRewardsRange(rpc.BlockNumber(first), rpc.BlockNumber(last))
+	require.NoError(t, err)
+
+	batch := db.NewRewardIndexBatch()
+	defer batch.Release()
+	for i, spec := range specs {
+		num := first + uint64(i)
+		block := &database.RewardIndexBlock{Number: num, Proposer: spec.Proposer, BurntFee: spec.BurntFee}
+		for addr, amount := range spec.Rewards {
+			acc := &database.AccumulatedReward{Number: num, Minted: new(big.Int).Set(amount), Fee: big.NewInt(0)}
+			if prev := db.ReadAccumulatedReward(addr, num-1); prev != nil {
+				acc.Minted.Add(acc.Minted, prev.Minted)
+			}
+			require.NoError(t, db.PutAccumulatedRewardToBatch(batch, addr, acc))
+			block.Recipients = append(block.Recipients, addr)
+		}
+		require.NoError(t, db.PutRewardIndexBlockToBatch(batch, block))
+		require.NoError(t, batch.Write())
+		batch.Reset()
+	}
+	db.WriteRewardIndexHead(last)
+	api.SetRewardIndex(db)
+}
+
+func TestGetRewardSum(t *testing.T) {
+	api := newTestRewardAggregationAPI(t, 6)
+
+	// the rewards are not indexed
+	_, err := api.GetRewardSum(common.Address{}, 2, 5)
+	assert.Equal(t, errRewardsNotIndexed, err)
+
+	specs, err := api.GetRewardsRange(2, 5)
+	require.NoError(t, err)
+	expected := big.NewInt(0)
+	for _, spec := range specs {
+		expected.Add(expected, spec.Rewards[common.Address{}])
+	}
+	require.NotZero(t, expected.Sign())
+
+	indexTestRewards(t, api, 1, 6)
+	sum, err := api.GetRewardSum(common.Address{}, 2, 5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), sum.FirstBlock)
+	assert.Equal(t, uint64(5), sum.LastBlock)
+	assert.Equal(t, uint64(4), sum.Blocks)
+	assert.Equal(t, expected.String(), sum.Total.String())
+
+	sum, err = api.GetRewardSum(common.HexToAddress("0x1"), 2, 5)
+	require.NoError(t, err)
+	assert.Zero(t, sum.Blocks)
+	assert.Zero(t, sum.Total.Sign())
+
+	_, err = api.GetRewardSum(common.Address{}, 5, 7)
+	assert.Error(t, err)
+}
+
+func TestGetTopProposers(t *testing.T) {
+	api := newTestRewardAggregationAPI(t, 6)
+
+	specs, err := api.GetRewardsRange(1, 6)
+	require.NoError(t, err)
+	expected := big.NewInt(0)
+	for _, spec := range specs {
+		expected.Add(expected, spec.Proposer)
+	}
+
+	indexTestRewards(t, api, 1, 6)
+	ranks, err := api.GetTopProposers(1, rpc.LatestBlockNumber, nil)
+	require.NoError(t, err)
+	require.Len(t, ranks, 1)
+	assert.Equal(t, common.Address{}, ranks[0].Rewardbase)
+	assert.Equal(t, uint64(6), ranks[0].Blocks)
+	assert.Equal(t, expected.String(), ranks[0].Proposer.String())
+
+	zero := 0
+	_, err = api.GetTopProposers(1, 6, &zero)
+	assert.Error(t, err)
+}
+
+func TestGetBurntFeesPerDay(t *testing.T) {
+	api := newTestRewardAggregationAPI(t, maxRewardsRange+1)
+	indexTestRewards(t, api, 1, 6)
+
+	buckets, err := api.GetBurntFeesPerDay(1, 6)
+	require.NoError(t, err)
+	require.Len(t, buckets, 1)
+	assert.Equal(t, uint64(0), buckets[0].Day)
+	assert.Equal(t, uint64(1), buckets[0].FirstBlock)
+	assert.Equal(t, uint64(6), buckets[0].LastBlock)
+	assert.NotNil(t, buckets[0].BurntFee)
+
+	// the range should be within the blocks indexed, but is not limited otherwise
+	_, err = api.GetBurntFeesPerDay(0, 6)
+	assert.ErrorIs(t, err, errBlockNotIndexed)
+	_, err = api.GetBurntFeesPerDay(1, 7)
+	assert.ErrorIs(t, err, errBlockNotIndexed)
+
+	index := api.index
+	index.WriteRewardIndexHead(maxRewardsRange + 1)
+	batch := index.NewRewardIndexBatch()
+	defer batch.Release()
+	for num := uint64(7); num <= maxRewardsRange+1; num++ {
+		require.NoError(t, index.PutRewardIndexBlockToBatch(batch, &database.RewardIndexBlock{Number: num, BurntFee: big.NewInt(1)}))
+	}
+	require.NoError(t, batch.Write())
+	buckets, err = api.GetBurntFeesPerDay(7, maxRewardsRange+1)
+	require.NoError(t, err)
+	require.Len(t, buckets, 1)
+	assert.Equal(t, big.NewInt(maxRewardsRange-5), buckets[0].BurntFee)
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/reward/proof"
+)
+
+var errNoReceipts = rpc.NewAPIError(rpc.CodeResourceUnavailable, "receiptsUnavailable",
+	"the receipts are not available")
+
+// receiptReader is implemented by the blockchain, which is not a part of blockChain
+// to keep the mocks of blockChain small.
+type receiptReader interface {
+	GetReceiptsByBlockHash(blockHash common.Hash) types.Receipts
+}
+
+// GetRewardProof returns the data with which a light client recomputes and verifies the
+// block reward at a given block number without the chain database. See the proof package.
+func (api *GovernanceKlayAPI) GetRewardProof(num *rpc.BlockNumber) (*proof.RewardProof, error) {
+	blockNumber := resolveBlockNumber(api.chain, num)
+	header := api.chain.GetHeaderByNumber(blockNumber)
+	if header == nil {
+		return nil, blockNotFoundError(blockNumber)
+	}
+
+	reader, ok := api.chain.(receiptReader)
+	if !ok {
+		return nil, errNoReceipts
+	}
+	receipts := reader.GetReceiptsByBlockHash(header.Hash())
+	if receipts == nil && header.GasUsed > 0 {
+		return nil, errNoReceipts
+	}
+
+	pset, err := api.governance.EffectiveParams(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	rules, rewardParamSet, err := api.rewardParams(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var stakingInfo *reward.StakingInfo
+	if reward.GetRewardPolicy(header.Number, rewardParamSet).UseStakingInfo() && reward.GetStakingManager() != nil {
+		stakingInfo = reward.GetStakingInfo(blockNumber)
+	}
+	commitment, err := proof.StakingCommitment(stakingInfo)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := reward.GetBlockRewardWithStakingInfo(header, rules, rewardParamSet, stakingInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proof.RewardProof{
+		Header:            header,
+		Receipts:          proof.NewReceipts(receipts),
+		DeriveShaImpl:     pset.DeriveShaImpl(),
+		RewardParams:      rewardParamSet.StrMap(),
+		StakingInfo:       stakingInfo,
+		StakingCommitment: commitment,
+		Rewards:           spec,
+	}, nil
+}
+
+// GetRewardMerkleProof returns the merkle proof of the reward of the address at a given block
+// number against the merkle root over the rewards of the block. The root is not anchored on
+// the chain, so the verifier checks it against the rewards recomputed from GetRewardProof or
+// reported by the other nodes it trusts. See proof.RewardsRoot.
+func (api *GovernanceKlayAPI) GetRewardMerkleProof(addr common.Address, num *rpc.BlockNumber) (*proof.RewardMerkleProof, error) {
+	blockNumber := resolveBlockNumber(api.chain, num)
+	header := api.chain.GetHeaderByNumber(blockNumber)
+	if header == nil {
+		return nil, blockNotFoundError(blockNumber)
+	}
+	spec, err := api.blockReward(header)
+	if err != nil {
+		return nil, err
+	}
+	return proof.NewRewardMerkleProof(spec.Rewards, addr)
+}
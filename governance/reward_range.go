@@ -0,0 +1,140 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+)
+
+// maxRewardsRange is the maximum number of blocks whose rewards GetRewardsRange returns.
+const maxRewardsRange = 3600
+
+var errRewardsRangeTooLarge = errBlockRangeTooLarge.Errorf("block range should be equal or less than %d", maxRewardsRange).
+	WithDetail("maxRange", maxRewardsRange)
+
+// rewardCalculator calculates the block rewards of consecutive blocks, reusing the
+// governance parameters and the staking information looked up for the previous blocks.
+type rewardCalculator struct {
+	api     *GovernanceKlayAPI
+	psets   map[uint64]*params.GovParamSet // by block number
+	staking map[uint64]*reward.StakingInfo // by staking block number
+}
+
+func newRewardCalculator(api *GovernanceKlayAPI) *rewardCalculator {
+	return &rewardCalculator{
+		api:     api,
+		psets:   make(map[uint64]*params.GovParamSet),
+		staking: make(map[uint64]*reward.StakingInfo),
+	}
+}
+
+func (c *rewardCalculator) effectiveParams(num uint64) (*params.GovParamSet, error) {
+	if pset, ok := c.psets[num]; ok {
+		return pset, nil
+	}
+	pset, err := c.api.governance.EffectiveParams(num)
+	if err != nil {
+		return nil, err
+	}
+	c.psets[num] = pset
+	return pset, nil
+}
+
+func (c *rewardCalculator) stakingInfo(num uint64) *reward.StakingInfo {
+	stakingNum := params.CalcStakingBlockNumber(num)
+	if info, ok := c.staking[stakingNum]; ok {
+		return info
+	}
+	info := reward.GetStakingInfoOnStakingBlock(stakingNum)
+	c.staking[stakingNum] = info
+	return info
+}
+
+// blockReward is GovernanceKlayAPI.blockReward with the cached lookups.
+func (c *rewardCalculator) blockReward(header *types.Header) (*reward.RewardSpec, error) {
+	if spec := c.api.checkpointedReward(header); spec != nil {
+		return spec, nil
+	}
+	blockNumber := header.Number.Uint64()
+	rules := c.api.chain.Config().Rules(header.Number)
+	pset, err := c.effectiveParams(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	rewardParamSet, err := c.effectiveParams(reward.CalcRewardParamBlock(blockNumber, pset.Epoch(), rules))
+	if err != nil {
+		return nil, err
+	}
+	var stakingInfo *reward.StakingInfo
+	if reward.GetRewardPolicy(header.Number, rewardParamSet).UseStakingInfo() {
+		stakingInfo = c.stakingInfo(blockNumber)
+	}
+	return reward.GetBlockRewardWithStakingInfo(header, rules, rewardParamSet, stakingInfo)
+}
+
+// resolveBlockRange returns the block numbers of the range [first, last] whose pending or
+// latest ends are resolved to the current block.
+func (api *GovernanceKlayAPI) resolveBlockRange(first, last rpc.BlockNumber) (uint64, uint64, error) {
+	currentBlock := api.chain.CurrentBlock().NumberU64()
+	firstBlock, lastBlock := currentBlock, currentBlock
+	if first >= rpc.EarliestBlockNumber {
+		firstBlock = uint64(first.Int64())
+	}
+	if last >= rpc.EarliestBlockNumber {
+		lastBlock = uint64(last.Int64())
+	}
+	if firstBlock > lastBlock {
+		return 0, 0, errInvalidBlockRange.Errorf("the last block number should be equal or larger the first block number")
+	}
+	if lastBlock > currentBlock {
+		return 0, 0, errInvalidBlockRange.Errorf("the last block number should be equal or less than the current block number")
+	}
+	return firstBlock, lastBlock, nil
+}
+
+// GetRewardsRange returns the block rewards of the blocks in the range of [first, last] in a
+// single call, as GetRewards returns for each of the blocks.
+func (api *GovernanceKlayAPI) GetRewardsRange(first, last rpc.BlockNumber) ([]*reward.RewardSpec, error) {
+	firstBlock, lastBlock, err := api.resolveBlockRange(first, last)
+	if err != nil {
+		return nil, err
+	}
+	if lastBlock-firstBlock+1 > maxRewardsRange {
+		return nil, errRewardsRangeTooLarge
+	}
+
+	calc := newRewardCalculator(api)
+	specs := make([]*reward.RewardSpec, 0, lastBlock-firstBlock+1)
+	for num := firstBlock; num <= lastBlock; num++ {
+		header := api.chain.GetHeaderByNumber(num)
+		if header == nil {
+			return nil, blockNotFoundError(num)
+		}
+		spec, err := calc.blockReward(header)
+		if err != nil {
+			return nil, err
+		}
+		if api.labels != nil {
+			spec.Labels = api.labels.Labels(calc.stakingInfo(num), rewardRecipients(spec.Rewards)...)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
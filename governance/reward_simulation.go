@@ -0,0 +1,83 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+)
+
+// RewardParamOverrides are the reward parameters replaced in SimulateReward.
+// The parameters not given keep the values in effect at the block.
+type RewardParamOverrides struct {
+	Ratio         *string `json:"ratio"`
+	Kip82Ratio    *string `json:"kip82ratio"`
+	MintingAmount *string `json:"mintingAmount"`
+	DeferredTxFee *bool   `json:"deferredTxFee"`
+}
+
+// paramSet returns the overridden parameters, validated as the votes on them are.
+func (o *RewardParamOverrides) paramSet() (*params.GovParamSet, error) {
+	items := make(map[string]interface{})
+	if o != nil {
+		if o.Ratio != nil {
+			items["reward.ratio"] = *o.Ratio
+		}
+		if o.Kip82Ratio != nil {
+			items["reward.kip82ratio"] = *o.Kip82Ratio
+		}
+		if o.MintingAmount != nil {
+			items["reward.mintingamount"] = *o.MintingAmount
+		}
+		if o.DeferredTxFee != nil {
+			items["reward.deferredtxfee"] = *o.DeferredTxFee
+		}
+	}
+	for name, value := range items {
+		if !GovernanceItems[GovernanceKeyMap[name]].validator(name, value) {
+			return nil, errInvalidParamValue.Errorf("invalid value of %s: %v", name, value).WithDetail("param", name)
+		}
+	}
+	return params.NewGovParamSetStrMap(items)
+}
+
+// SimulateReward returns the block reward of a given block number as it would be with the
+// given reward parameters instead of the ones in effect at the block.
+func (api *GovernanceKlayAPI) SimulateReward(overrides *RewardParamOverrides, num *rpc.BlockNumber) (*reward.RewardSpec, error) {
+	override, err := overrides.paramSet()
+	if err != nil {
+		return nil, err
+	}
+
+	blockNumber := resolveBlockNumber(api.chain, num)
+	header := api.chain.GetHeaderByNumber(blockNumber)
+	if header == nil {
+		return nil, blockNotFoundError(blockNumber)
+	}
+	rules, rewardParamSet, err := api.rewardParams(header)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := reward.GetBlockReward(header, rules, params.NewGovParamSetMerged(rewardParamSet, override))
+	if err != nil || api.labels == nil {
+		return spec, err
+	}
+	spec.Labels = api.labels.Labels(reward.GetStakingInfo(blockNumber), rewardRecipients(spec.Rewards)...)
+	return spec, nil
+}
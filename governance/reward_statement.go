@@ -0,0 +1,294 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"encoding/json"
+	"math/big"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/reward"
+)
+
+// maxRewardStatementBlocks is the maximum length of an epoch a reward statement can be generated for.
+const maxRewardStatementBlocks = 604800
+
+var (
+	errEpochNotFinished        = rpc.NewAPIError(rpc.CodeInvalidInput, "epochNotFinished", "the epoch is not finished yet")
+	errUnknownStatementFormat  = rpc.NewAPIError(rpc.CodeInvalidParams, "unknownStatementFormat", "unknown reward statement format, expected json or pdf")
+	errStatementNotSigned      = rpc.NewAPIError(rpc.CodeInvalidInput, "statementNotSigned", "the reward statement is not signed")
+	errStatementSignerMismatch = rpc.NewAPIError(rpc.CodeInvalidInput, "statementSignerMismatch", "the reward statement is not signed by the signer")
+	errInvalidEpochLength      = rpc.NewAPIError(rpc.CodeMethodNotSupported, "invalidEpochLength", "invalid epoch length")
+)
+
+// RewardStatement is the rewards of a validator in an epoch for compliance reporting.
+// It is signed by the node which generated it, so that the statement can be verified later.
+// The epoch n consists of the blocks from n*epoch to (n+1)*epoch-1, where the epoch is the
+// istanbul.epoch in effect at the latest block.
+type RewardStatement struct {
+	NodeAddress    common.Address `json:"nodeAddress"`
+	RewardAddress  common.Address `json:"rewardAddress"` // reward address of the node at the first block
+	Epoch          uint64         `json:"epoch"`
+	FirstBlock     uint64         `json:"firstBlock"`
+	LastBlock      uint64         `json:"lastBlock"`
+	FirstBlockTime string         `json:"firstBlockTime"`
+	LastBlockTime  string         `json:"lastBlockTime"`
+
+	BlocksProposed     uint64  `json:"blocksProposed"`
+	StakingAmount      uint64  `json:"stakingAmount"`      // staked KLAY of the node at the first block
+	TotalStakingAmount uint64  `json:"totalStakingAmount"` // staked KLAY of all validators at the first block
+	StakingShare       float64 `json:"stakingShare"`       // StakingAmount / TotalStakingAmount
+
+	// Rewards paid to the reward address of the node in peb. The rewards redirected by
+	// governance are not paid to the node, so they are not included.
+	ProposerRewards *big.Int `json:"proposerRewards"`
+	StakingRewards  *big.Int `json:"stakingRewards"`
+	TotalRewards    *big.Int `json:"totalRewards"`
+
+	Params map[string]interface{} `json:"params"` // governance parameters in effect at the first block
+
+	Signer    common.Address `json:"signer"`
+	Signature hexutil.Bytes  `json:"signature,omitempty"`
+}
+
+// signingData returns the data signed by the signer, which is the statement without the signature.
+func (s *RewardStatement) signingData() ([]byte, error) {
+	unsigned := *s
+	unsigned.Signature = nil
+	return json.Marshal(&unsigned)
+}
+
+// Verify checks that the statement is signed by its signer.
+func (s *RewardStatement) Verify() error {
+	if len(s.Signature) == 0 {
+		return errStatementNotSigned
+	}
+	data, err := s.signingData()
+	if err != nil {
+		return err
+	}
+	pubkey, err := crypto.SigToPub(crypto.Keccak256(data), s.Signature)
+	if err != nil {
+		return err
+	}
+	if crypto.PubkeyToAddress(*pubkey) != s.Signer {
+		return errStatementSignerMismatch
+	}
+	return nil
+}
+
+// GetRewardStatement returns the reward statement of the validator in the given epoch.
+func (api *GovernanceKlayAPI) GetRewardStatement(nodeAddr common.Address, epoch uint64) (*RewardStatement, error) {
+	current := api.chain.CurrentBlock().NumberU64()
+	pset, err := api.governance.EffectiveParams(current)
+	if err != nil {
+		return nil, err
+	}
+	length := pset.Epoch()
+	if length == 0 || length > maxRewardStatementBlocks {
+		return nil, errInvalidEpochLength.Errorf("epoch should be between 1 and %d, but %d", maxRewardStatementBlocks, length)
+	}
+	if epoch >= (current+1)/length {
+		return nil, errEpochNotFinished
+	}
+	first, last := epoch*length, (epoch+1)*length-1
+
+	statement, err := api.newRewardStatement(nodeAddr, epoch, first, last)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.signRewardStatement(statement); err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
+
+// ExportRewardStatement returns the reward statement of the validator in the given epoch as a
+// document of the given format, which is either "json" or "pdf".
+func (api *GovernanceKlayAPI) ExportRewardStatement(nodeAddr common.Address, epoch uint64, format string) (hexutil.Bytes, error) {
+	statement, err := api.GetRewardStatement(nodeAddr, epoch)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case "json":
+		return json.MarshalIndent(statement, "", "  ")
+	case "pdf":
+		return renderRewardStatementPDF(statement), nil
+	default:
+		return nil, errUnknownStatementFormat
+	}
+}
+
+func (api *GovernanceKlayAPI) newRewardStatement(nodeAddr common.Address, epoch, first, last uint64) (*RewardStatement, error) {
+	firstHeader, lastHeader := api.chain.GetHeaderByNumber(first), api.chain.GetHeaderByNumber(last)
+	if firstHeader == nil || lastHeader == nil {
+		return nil, errBlockNotFound.Errorf("the epoch does not exist (blocks: %d-%d)", first, last)
+	}
+	pset, err := api.governance.EffectiveParams(first)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &RewardStatement{
+		NodeAddress:    nodeAddr,
+		Epoch:          epoch,
+		FirstBlock:     first,
+		LastBlock:      last,
+		FirstBlockTime: time.Unix(firstHeader.Time.Int64(), 0).UTC().String(),
+		LastBlockTime:  time.Unix(lastHeader.Time.Int64(), 0).UTC().String(),
+		Params:         pset.StrMap(),
+		Signer:         api.governance.NodeAddress(),
+	}
+	if stakingInfo := reward.GetStakingInfo(first); stakingInfo != nil {
+		if idx, err := stakingInfo.GetIndexByNodeAddress(nodeAddr); err == nil {
+			statement.RewardAddress = stakingInfo.CouncilRewardAddrs[idx]
+			statement.StakingAmount = stakingInfo.CouncilStakingAmounts[idx]
+		}
+		for _, amount := range stakingInfo.CouncilStakingAmounts {
+			statement.TotalStakingAmount += amount
+		}
+		if statement.TotalStakingAmount > 0 {
+			statement.StakingShare = float64(statement.StakingAmount) / float64(statement.TotalStakingAmount)
+		}
+	}
+
+	// the blocks are split into contiguous ranges scanned concurrently
+	var (
+		workers = uint64(runtime.NumCPU())
+		size    = (last - first + workers) / workers
+		parts   = make([]*epochRewards, 0, workers)
+		errs    = make([]error, workers)
+		wg      sync.WaitGroup
+	)
+	for from := first; from <= last; from += size {
+		to := from + size - 1
+		if to > last {
+			to = last
+		}
+		part, i := newEpochRewards(), len(parts)
+		parts = append(parts, part)
+		wg.Add(1)
+		go func(from, to uint64) {
+			defer wg.Done()
+			errs[i] = api.collectRewards(nodeAddr, from, to, part)
+		}(from, to)
+	}
+	wg.Wait()
+
+	total := newEpochRewards()
+	for i, part := range parts {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		total.proposed += part.proposed
+		total.proposer.Add(total.proposer, part.proposer)
+		total.staking.Add(total.staking, part.staking)
+	}
+	statement.BlocksProposed = total.proposed
+	statement.ProposerRewards = total.proposer
+	statement.StakingRewards = total.staking
+	statement.TotalRewards = new(big.Int).Add(total.proposer, total.staking)
+	return statement, nil
+}
+
+// epochRewards is the rewards of a validator accumulated over blocks.
+type epochRewards struct {
+	proposed uint64
+	proposer *big.Int
+	staking  *big.Int
+}
+
+func newEpochRewards() *epochRewards {
+	return &epochRewards{proposer: big.NewInt(0), staking: big.NewInt(0)}
+}
+
+// collectRewards accumulates the rewards paid to the validator in the blocks [from, to].
+func (api *GovernanceKlayAPI) collectRewards(nodeAddr common.Address, from, to uint64, acc *epochRewards) error {
+	for num := from; num <= to; num++ {
+		if num == 0 {
+			continue // the genesis block has no reward
+		}
+		header := api.chain.GetHeaderByNumber(num)
+		if header == nil {
+			return blockNotFoundError(num)
+		}
+		proposed, err := api.isProposer(header, nodeAddr)
+		if err != nil {
+			return err
+		}
+		if proposed {
+			acc.proposed++
+		}
+		spec, err := api.blockReward(header)
+		if err != nil {
+			return err
+		}
+		if spec.RedirectedTo != nil {
+			continue // nothing is paid to the validators
+		}
+
+		var rewardAddr common.Address
+		if stakingInfo := reward.GetStakingInfo(num); stakingInfo != nil {
+			if idx, err := stakingInfo.GetIndexByNodeAddress(nodeAddr); err == nil {
+				rewardAddr = stakingInfo.CouncilRewardAddrs[idx]
+			}
+		}
+		staking := big.NewInt(0)
+		if amount, ok := spec.Rewards[rewardAddr]; ok && !common.EmptyAddress(rewardAddr) {
+			staking.Set(amount)
+		}
+		if proposed {
+			acc.proposer.Add(acc.proposer, spec.Proposer)
+			// the proposer reward is paid to the rewardbase, which is usually the reward address
+			if header.Rewardbase == rewardAddr {
+				staking.Sub(staking, spec.Proposer)
+			}
+		}
+		if staking.Sign() > 0 {
+			acc.staking.Add(acc.staking, staking)
+		}
+	}
+	return nil
+}
+
+func (api *GovernanceKlayAPI) isProposer(header *types.Header, nodeAddr common.Address) (bool, error) {
+	author, err := api.chain.Engine().Author(header)
+	if err != nil {
+		return false, err
+	}
+	return author == nodeAddr, nil
+}
+
+func (api *GovernanceKlayAPI) signRewardStatement(statement *RewardStatement) error {
+	if api.sign == nil {
+		return nil
+	}
+	data, err := statement.signingData()
+	if err != nil {
+		return err
+	}
+	statement.Signature, err = api.sign(data)
+	return err
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/klaytn/klaytn/params"
+)
+
+const (
+	pdfLinesPerPage = 64 // lines of an A4 page with 11pt leading
+	pdfLineWidth    = 96 // characters of a line in 9pt Courier
+)
+
+// rewardStatementLines returns the text lines of the statement document.
+func rewardStatementLines(s *RewardStatement) []string {
+	lines := []string{
+		"Klaytn Validator Reward Statement",
+		"",
+		fmt.Sprintf("Node address:         %s", s.NodeAddress.Hex()),
+		fmt.Sprintf("Reward address:       %s", s.RewardAddress.Hex()),
+		fmt.Sprintf("Epoch:                %d (blocks %d - %d)", s.Epoch, s.FirstBlock, s.LastBlock),
+		fmt.Sprintf("Period:               %s - %s", s.FirstBlockTime, s.LastBlockTime),
+		"",
+		fmt.Sprintf("Blocks proposed:      %d", s.BlocksProposed),
+		fmt.Sprintf("Staking amount:       %d KLAY of %d KLAY (%.4f%%)", s.StakingAmount, s.TotalStakingAmount, s.StakingShare*100),
+		fmt.Sprintf("Proposer rewards:     %s KLAY", pebToKLAY(s.ProposerRewards)),
+		fmt.Sprintf("Staking rewards:      %s KLAY", pebToKLAY(s.StakingRewards)),
+		fmt.Sprintf("Total rewards:        %s KLAY", pebToKLAY(s.TotalRewards)),
+		"",
+		"Parameters in effect:",
+	}
+	keys := make([]string, 0, len(s.Params))
+	for key := range s.Params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("  %-32s %v", key, s.Params[key]))
+	}
+	lines = append(lines, "", fmt.Sprintf("Signer:               %s", s.Signer.Hex()))
+	if len(s.Signature) > 0 {
+		lines = append(lines, "Signature:")
+		sig := s.Signature.String()
+		for len(sig) > pdfLineWidth-2 {
+			lines = append(lines, "  "+sig[:pdfLineWidth-2])
+			sig = sig[pdfLineWidth-2:]
+		}
+		lines = append(lines, "  "+sig)
+	} else {
+		lines = append(lines, "Signature:            (not signed)")
+	}
+	return lines
+}
+
+// pebToKLAY formats the amount in peb as KLAY.
+func pebToKLAY(peb *big.Int) string {
+	if peb == nil {
+		return "0"
+	}
+	klay := new(big.Rat).SetFrac(peb, big.NewInt(params.KLAY)).FloatString(18)
+	return strings.TrimRight(strings.TrimRight(klay, "0"), ".")
+}
+
+// renderRewardStatementPDF renders the statement as a plain text PDF document.
+func renderRewardStatementPDF(s *RewardStatement) []byte {
+	lines := rewardStatementLines(s)
+	var pages [][]string
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	// objects: 1 catalog, 2 page tree, 3 font, then a page and its content for each page
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>",
+	)
+	for i, page := range pages {
+		var content bytes.Buffer
+		content.WriteString("BT /F1 9 Tf 11 TL 40 800 Td\n")
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", escapePDFText(line))
+		}
+		content.WriteString("ET")
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", 5+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		)
+	}
+
+	var doc bytes.Buffer
+	doc.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = doc.Len()
+		fmt.Fprintf(&doc, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := doc.Len()
+	fmt.Fprintf(&doc, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&doc, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&doc, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return doc.Bytes()
+}
+
+// escapePDFText escapes the characters which are special in a PDF string.
+func escapePDFText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
@@ -0,0 +1,187 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	consensusmocks "github.com/klaytn/klaytn/consensus/mocks"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/work/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRewardStatement(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockBlockchain := mocks.NewMockBlockChain(mockCtrl)
+	mockConsensus := consensusmocks.NewMockEngine(mockCtrl)
+	mockGovEngine := NewMockEngine(mockCtrl)
+	db := database.NewMemoryDBManager()
+
+	chainConfig := params.CypressChainConfig.Copy()
+	chainConfig.KoreCompatibleBlock = big.NewInt(0)
+	chainConfig.Istanbul.Epoch = 5
+	chainConfig.Governance.Reward.Ratio = "50/20/30"
+	chainConfig.Governance.Reward.Kip82Ratio = params.DefaultKip82Ratio
+	govParamSet, err := params.NewGovParamSetChainConfig(chainConfig)
+	require.NoError(t, err)
+
+	oldSm := reward.GetStakingManager()
+	defer reward.SetTestStakingManager(oldSm)
+	reward.SetTestStakingManagerWithChain(mockBlockchain, mockGovEngine, db)
+
+	nodes := []common.Address{
+		common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		common.HexToAddress("0x3333333333333333333333333333333333333333"),
+		common.HexToAddress("0x4444444444444444444444444444444444444444"),
+	}
+	stInfo := reward.StakingInfo{
+		CouncilNodeAddrs:      nodes,
+		CouncilStakingAddrs:   nodes,
+		CouncilRewardAddrs:    nodes,
+		KCFAddr:               common.HexToAddress("0xCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCC"),
+		KFFAddr:               common.HexToAddress("0xFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"),
+		CouncilStakingAmounts: []uint64{5000000, 10000000, 15000000, 20000000},
+	}
+	siBytes, _ := json.Marshal(stInfo)
+	require.NoError(t, db.WriteStakingInfo(0, siBytes))
+
+	// the nodes propose the blocks in a round-robin way
+	headers := make([]*types.Header, 11)
+	for i := range headers {
+		headers[i] = &types.Header{
+			Number:     big.NewInt(int64(i)),
+			Rewardbase: nodes[i%4],
+			GasUsed:    uint64(1000),
+			BaseFee:    big.NewInt(25 * params.Ston),
+			Time:       big.NewInt(int64(1000 + i)),
+		}
+		mockBlockchain.EXPECT().GetHeaderByNumber(uint64(i)).Return(headers[i]).AnyTimes()
+		mockConsensus.EXPECT().Author(headers[i]).Return(nodes[i%4], nil).AnyTimes()
+	}
+
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+
+	mockBlockchain.EXPECT().Config().Return(chainConfig).AnyTimes()
+	mockBlockchain.EXPECT().CurrentBlock().Return(types.NewBlockWithHeader(headers[10])).AnyTimes()
+	mockBlockchain.EXPECT().Engine().Return(mockConsensus).AnyTimes()
+	mockGovEngine.EXPECT().EffectiveParams(gomock.Any()).Return(govParamSet, nil).AnyTimes()
+	mockGovEngine.EXPECT().BlockChain().Return(mockBlockchain).AnyTimes()
+	mockGovEngine.EXPECT().NodeAddress().Return(signer).AnyTimes()
+
+	api := NewGovernanceKlayAPI(mockGovEngine, mockBlockchain)
+	api.SetStatementSigner(func(data []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(data), key)
+	})
+
+	// the blocks 10-14 of the epoch 2 are not generated yet
+	_, err = api.GetRewardStatement(nodes[1], 2)
+	assert.Equal(t, errEpochNotFinished, err)
+
+	statement, err := api.GetRewardStatement(nodes[1], 1)
+	require.NoError(t, err)
+	assert.Equal(t, nodes[1], statement.RewardAddress)
+	assert.Equal(t, uint64(5), statement.FirstBlock)
+	assert.Equal(t, uint64(9), statement.LastBlock)
+	assert.Equal(t, uint64(2), statement.BlocksProposed) // blocks 5 and 9
+	assert.Equal(t, uint64(10000000), statement.StakingAmount)
+	assert.Equal(t, uint64(50000000), statement.TotalStakingAmount)
+	assert.Equal(t, 0.2, statement.StakingShare)
+	assert.Equal(t, chainConfig.Istanbul.Epoch, statement.Params["istanbul.epoch"])
+
+	// proposer: 0.96 KLAY = 9.6 KLAY * 0.5 * 0.2 for each proposed block
+	// staking: 0.64 KLAY = 9.6 KLAY * 0.5 * 0.8 * 5M / 30M for each block, where 5M KLAY is the minimum stake
+	proposerRewards, _ := new(big.Int).SetString("1920000000000000000", 10)
+	stakingRewards, _ := new(big.Int).SetString("3200000000000000000", 10)
+	assert.Equal(t, proposerRewards, statement.ProposerRewards)
+	assert.Equal(t, stakingRewards, statement.StakingRewards)
+	assert.Equal(t, new(big.Int).Add(proposerRewards, stakingRewards), statement.TotalRewards)
+
+	// signed by the node
+	assert.Equal(t, signer, statement.Signer)
+	assert.NoError(t, statement.Verify())
+	statement.BlocksProposed++
+	assert.Equal(t, errStatementSignerMismatch, statement.Verify())
+
+	// the exported statement can be verified
+	exported, err := api.ExportRewardStatement(nodes[1], 1, "json")
+	require.NoError(t, err)
+	var decoded RewardStatement
+	require.NoError(t, json.Unmarshal(exported, &decoded))
+	assert.NoError(t, decoded.Verify())
+
+	_, err = api.ExportRewardStatement(nodes[1], 1, "xml")
+	assert.Equal(t, errUnknownStatementFormat, err)
+}
+
+func TestRenderRewardStatementPDF(t *testing.T) {
+	statement := &RewardStatement{
+		NodeAddress:     common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Epoch:           1,
+		ProposerRewards: big.NewInt(1.5e18),
+		StakingRewards:  big.NewInt(0),
+		TotalRewards:    big.NewInt(1.5e18),
+		Params:          map[string]interface{}{"reward.ratio": "(50/20/30)"},
+	}
+	for i := 0; i < 100; i++ {
+		statement.Params[fmt.Sprintf("test.param%d", i)] = i
+	}
+	doc := renderRewardStatementPDF(statement)
+	assert.True(t, bytes.HasPrefix(doc, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(doc, []byte("%%EOF\n")))
+	assert.Contains(t, string(doc), "/Count 2")
+	assert.Contains(t, string(doc), "1.5 KLAY")
+	assert.Contains(t, string(doc), `\(50/20/30\)`)
+
+	// the cross-reference table points to the objects
+	var xref int
+	_, err := fmt.Sscanf(string(doc[bytes.LastIndex(doc, []byte("startxref\n")):]), "startxref\n%d", &xref)
+	require.NoError(t, err)
+	var first, count int
+	_, err = fmt.Sscanf(string(doc[xref:]), "xref\n%d %d\n", &first, &count)
+	require.NoError(t, err)
+	entries := bytes.Split(doc[bytes.Index(doc[xref:], []byte("f \n"))+xref+3:], []byte("\n"))
+	for i := 1; i < count; i++ {
+		var offset int
+		_, err := fmt.Sscanf(string(entries[i-1]), "%010d", &offset)
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(doc[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i))), "object %d", i)
+	}
+}
+
+func TestPebToKLAY(t *testing.T) {
+	assert.Equal(t, "0", pebToKLAY(nil))
+	assert.Equal(t, "0", pebToKLAY(big.NewInt(0)))
+	assert.Equal(t, "9.6", pebToKLAY(new(big.Int).SetUint64(9.6e18)))
+	assert.Equal(t, "0.000000000000000001", pebToKLAY(big.NewInt(1)))
+	assert.Equal(t, "25", pebToKLAY(new(big.Int).Mul(big.NewInt(25), big.NewInt(params.KLAY))))
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/reward"
+)
+
+var (
+	errNoTreasuryRebalance = rpc.NewAPIError(rpc.CodeResourceUnavailable, "treasuryRebalanceNotConfigured", "treasury rebalancing (KIP-103) is not configured")
+	errNoRebalanceContract = rpc.NewAPIError(rpc.CodeResourceNotFound, "rebalanceContractNotFound", "no treasury rebalance contract is deployed")
+)
+
+// kip103StatusNames are the names of TreasuryRebalance.Status in the KIP-103 contract.
+var kip103StatusNames = []string{"Initialized", "Registered", "Approved", "Finalized"}
+
+// TreasuryRebalance is the treasury rebalancing (KIP-103) of the chain as recorded in
+// the KIP-103 contract at the head block.
+type TreasuryRebalance struct {
+	BlockNumber     *big.Int       `json:"blockNumber"`
+	ContractAddress common.Address `json:"contractAddress"`
+	Executed        bool           `json:"executed"` // Whether the head block is at or after the rebalancing block
+	Status          string         `json:"status"`   // Status of the contract
+
+	Memo   string                          `json:"memo,omitempty"`   // Empty until the contract is finalized
+	Result *reward.TreasuryRebalanceResult `json:"result,omitempty"` // Nil if the memo is not a rebalancing result
+}
+
+// GetTreasuryRebalance returns the treasury rebalancing (KIP-103) configured in the chain
+// and its result recorded as the memo of the KIP-103 contract.
+func (api *GovernanceKlayAPI) GetTreasuryRebalance() (*TreasuryRebalance, error) {
+	config := api.chain.Config()
+	if config.Kip103CompatibleBlock == nil {
+		return nil, errNoTreasuryRebalance
+	}
+	st, err := api.chain.State()
+	if err != nil {
+		return nil, err
+	}
+
+	rebalance := &TreasuryRebalance{
+		BlockNumber:     config.Kip103CompatibleBlock,
+		ContractAddress: config.Kip103ContractAddress,
+		Executed:        api.chain.CurrentHeader().Number.Cmp(config.Kip103CompatibleBlock) >= 0,
+	}
+	if st.GetCodeSize(rebalance.ContractAddress) == 0 {
+		return nil, errNoRebalanceContract.Errorf("no contract is deployed at %s", rebalance.ContractAddress.Hex()).
+			WithDetail("address", rebalance.ContractAddress)
+	}
+	if status := system.ReadKip103Status(st, rebalance.ContractAddress); int(status) < len(kip103StatusNames) {
+		rebalance.Status = kip103StatusNames[status]
+	} else {
+		rebalance.Status = fmt.Sprintf("Unknown(%d)", status)
+	}
+
+	memo := system.ReadKip103Memo(st, rebalance.ContractAddress)
+	rebalance.Memo = string(memo)
+	result := new(reward.TreasuryRebalanceResult)
+	if len(memo) > 0 && json.Unmarshal(memo, result) == nil {
+		rebalance.Result = result
+	}
+	return rebalance, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTreasuryRebalance(t *testing.T) {
+	kip103Addr := common.HexToAddress("0x1030")
+	config := params.TestChainConfig.Copy()
+	config.Istanbul = params.GetDefaultIstanbulConfig()
+	config.Governance = params.GetDefaultGovernanceConfig()
+
+	st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	require.NoError(t, err)
+	bc := &testStateBlockChain{testBlockChain: newTestBlockchain(config), state: st}
+	bc.SetBlockNum(50)
+	api := NewGovernanceKlayAPI(NewMixedEngine(config, database.NewMemoryDBManager()), bc)
+
+	// not configured
+	_, err = api.GetTreasuryRebalance()
+	assert.Equal(t, errNoTreasuryRebalance, err)
+
+	// no contract
+	config.Kip103CompatibleBlock = big.NewInt(100)
+	config.Kip103ContractAddress = kip103Addr
+	_, err = api.GetTreasuryRebalance()
+	assert.Error(t, err)
+
+	// before the rebalancing
+	st.SetCode(kip103Addr, []byte{0x00})
+	st.SetState(kip103Addr, common.BigToHash(big.NewInt(3)), common.BigToHash(big.NewInt(2)))
+	rebalance, err := api.GetTreasuryRebalance()
+	require.NoError(t, err)
+	assert.Equal(t, &TreasuryRebalance{
+		BlockNumber:     big.NewInt(100),
+		ContractAddress: kip103Addr,
+		Status:          "Approved",
+	}, rebalance)
+
+	// after the rebalancing
+	result := &reward.TreasuryRebalanceResult{
+		Retired: map[common.Address]*big.Int{common.HexToAddress("0xaaaa"): big.NewInt(300)},
+		Newbie:  map[common.Address]*big.Int{common.HexToAddress("0xbbbb"): big.NewInt(200)},
+		Burnt:   big.NewInt(100),
+		Success: true,
+	}
+	memo, err := json.Marshal(result)
+	require.NoError(t, err)
+	system.WriteKip103Memo(st, kip103Addr, memo)
+	bc.SetBlockNum(100)
+	rebalance, err = api.GetTreasuryRebalance()
+	require.NoError(t, err)
+	assert.True(t, rebalance.Executed)
+	assert.Equal(t, "Finalized", rebalance.Status)
+	assert.Equal(t, string(memo), rebalance.Memo)
+	assert.Equal(t, result, rebalance.Result)
+
+	// a memo finalized by the owner may not be a rebalancing result
+	system.WriteKip103Memo(st, kip103Addr, []byte("rebalanced"))
+	rebalance, err = api.GetTreasuryRebalance()
+	require.NoError(t, err)
+	assert.Equal(t, "rebalanced", rebalance.Memo)
+	assert.Nil(t, rebalance.Result)
+}
@@ -0,0 +1,264 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/accounts/abi/bind/backends"
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+)
+
+const (
+	ValidatorChangeAdd    = "add"
+	ValidatorChangeRemove = "remove"
+
+	ValidatorChangePending    = "pending"    // The change is not reflected in the validator set yet
+	ValidatorChangeApplied    = "applied"    // The change is reflected in the validator set
+	ValidatorChangeSuperseded = "superseded" // A later change of the same validator was requested
+
+	maxValidatorChanges = 256 // The number of the validator changes kept in memory
+)
+
+var (
+	errNoAdminCaller       = rpc.NewAPIError(rpc.CodePermissionDenied, "notAdminCaller", "validator changes are only allowed through the authenticated admin endpoint")
+	errAlreadyValidator    = rpc.NewAPIError(rpc.CodeInvalidInput, "alreadyValidator", "the node is already a validator")
+	errNotValidator        = rpc.NewAPIError(rpc.CodeInvalidInput, "notValidator", "the node is not a validator")
+	errNoValidatorSet      = rpc.NewAPIError(rpc.CodeResourceUnavailable, "validatorSetUnavailable", "the validator set is not available")
+	errNoStakingInfo       = rpc.NewAPIError(rpc.CodeResourceUnavailable, "stakingInfoUnavailable", "the staking information is not available")
+	errNotStaked           = rpc.NewAPIError(rpc.CodeInvalidInput, "notStaked", "the node is not staked enough")
+	errNoBlsKey            = rpc.NewAPIError(rpc.CodeInvalidInput, "noBlsKey", "no valid BLS key is registered for the node")
+	errVoteValidatorChange = rpc.NewAPIError(rpc.CodeInternalError, "voteFailure", "failed to vote on the validator change")
+)
+
+// ValidatorChange is a validator addition or removal requested through the admin endpoint.
+type ValidatorChange struct {
+	Action         string         `json:"action"`
+	Validator      common.Address `json:"validator"`
+	Requester      string         `json:"requester"` // The authenticated caller, e.g. "token:ops"
+	RequestedBlock uint64         `json:"requestedBlock"`
+	RequestedTime  time.Time      `json:"requestedTime"`
+	Status         string         `json:"status"`
+}
+
+// validatorChangeLog keeps the recent validator changes requested to this node.
+type validatorChangeLog struct {
+	mu      sync.Mutex
+	changes []*ValidatorChange
+}
+
+func (l *validatorChangeLog) add(change *ValidatorChange) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, c := range l.changes {
+		if c.Validator == change.Validator && c.Status == ValidatorChangePending {
+			c.Status = ValidatorChangeSuperseded
+		}
+	}
+	l.changes = append(l.changes, change)
+	if len(l.changes) > maxValidatorChanges {
+		l.changes = l.changes[len(l.changes)-maxValidatorChanges:]
+	}
+}
+
+// update updates the status of the pending changes with the validator set, and returns
+// the copies of the changes.
+func (l *validatorChangeLog) update(valset istanbul.ValidatorSet) []ValidatorChange {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	changes := make([]ValidatorChange, 0, len(l.changes))
+	for _, c := range l.changes {
+		if c.Status == ValidatorChangePending && valset != nil && isValidator(valset, c.Validator) == (c.Action == ValidatorChangeAdd) {
+			c.Status = ValidatorChangeApplied
+		}
+		changes = append(changes, *c)
+	}
+	return changes
+}
+
+func isValidator(valset istanbul.ValidatorSet, addr common.Address) bool {
+	if _, v := valset.GetByAddress(addr); v != nil {
+		return true
+	}
+	_, v := valset.GetDemotedByAddress(addr)
+	return v != nil
+}
+
+// currentValidators returns the validator set of the head block.
+var currentValidators = func(chain blockChain) istanbul.ValidatorSet {
+	engine, ok := chain.Engine().(interface {
+		Validators(proposal istanbul.Proposal) istanbul.ValidatorSet
+	})
+	if !ok {
+		return nil
+	}
+	return engine.Validators(chain.CurrentBlock())
+}
+
+// readValidatorBlsKeys reads the BLS public keys registered in the active KIP-113 contract.
+var readValidatorBlsKeys = func(chain blockChain, num *big.Int) (system.BlsPublicKeyInfos, error) {
+	kip113, err := system.ReadRegistryActiveAddr(backends.NewBlockchainContractBackend(chain, nil, nil), system.Kip113Name, num)
+	if err != nil {
+		return nil, err
+	}
+	return readBlsPublicKeyInfos(chain, kip113, num)
+}
+
+// AddValidator votes on adding the node to the validator set. The node must be staked
+// and have its BLS key registered if they are required. It is only allowed through the
+// authenticated admin endpoint, and the caller is recorded.
+func (api *GovernanceAPI) AddValidator(ctx context.Context, addr common.Address) (*ValidatorChange, error) {
+	return api.changeValidator(ctx, ValidatorChangeAdd, addr)
+}
+
+// RemoveValidator votes on removing the node from the validator set. It is only allowed
+// through the authenticated admin endpoint, and the caller is recorded.
+func (api *GovernanceAPI) RemoveValidator(ctx context.Context, addr common.Address) (*ValidatorChange, error) {
+	return api.changeValidator(ctx, ValidatorChangeRemove, addr)
+}
+
+// PendingValidatorChanges returns the requested validator changes not reflected in the
+// validator set yet.
+func (api *GovernanceAPI) PendingValidatorChanges() []ValidatorChange {
+	pending := make([]ValidatorChange, 0)
+	for _, c := range api.ValidatorChanges() {
+		if c.Status == ValidatorChangePending {
+			pending = append(pending, c)
+		}
+	}
+	return pending
+}
+
+// ValidatorChanges returns the recent validator changes requested to this node.
+func (api *GovernanceAPI) ValidatorChanges() []ValidatorChange {
+	return api.validatorChanges.update(currentValidators(api.governance.BlockChain()))
+}
+
+func (api *GovernanceAPI) changeValidator(ctx context.Context, action string, addr common.Address) (*ValidatorChange, error) {
+	caller, ok := rpc.AdminCaller(ctx)
+	if !ok {
+		return nil, errNoAdminCaller
+	}
+	chain := api.governance.BlockChain()
+	head := chain.CurrentBlock().Number()
+	pset, err := api.governance.EffectiveParams(head.Uint64() + 1)
+	if err != nil {
+		return nil, err
+	}
+	if pset.GovernanceModeInt() == params.GovernanceMode_Single && pset.GoverningNode() != api.governance.NodeAddress() {
+		return nil, errPermissionDenied
+	}
+	valset := currentValidators(chain)
+	if valset == nil {
+		return nil, errNoValidatorSet
+	}
+
+	if action == ValidatorChangeAdd {
+		err = api.checkValidatorCandidate(valset, pset, head, addr)
+	} else {
+		err = api.checkValidatorToRemove(valset, addr)
+	}
+	if err != nil {
+		logger.Warn("[Validator audit] Rejected a validator change", "action", action, "validator", addr, "requester", caller, "err", err)
+		return nil, err
+	}
+
+	// The vote of a key replaces the previous one, so all the pending changes of the same
+	// action are voted together.
+	addrs := []string{addr.Hex()}
+	for _, c := range api.validatorChanges.update(valset) {
+		if c.Action == action && c.Status == ValidatorChangePending && c.Validator != addr {
+			addrs = append(addrs, c.Validator.Hex())
+		}
+	}
+	key := "governance.addvalidator"
+	if action == ValidatorChangeRemove {
+		key = "governance.removevalidator"
+	}
+	if !api.governance.AddVote(key, strings.Join(addrs, ",")) {
+		return nil, errVoteValidatorChange
+	}
+
+	change := &ValidatorChange{
+		Action:         action,
+		Validator:      addr,
+		Requester:      caller,
+		RequestedBlock: head.Uint64(),
+		RequestedTime:  time.Now(),
+		Status:         ValidatorChangePending,
+	}
+	ret := *change
+	api.validatorChanges.add(change)
+	logger.Info("[Validator audit] Requested a validator change", "action", action, "validator", addr, "requester", caller, "block", head)
+	return &ret, nil
+}
+
+func (api *GovernanceAPI) checkValidatorCandidate(valset istanbul.ValidatorSet, pset *params.GovParamSet, head *big.Int, addr common.Address) error {
+	if isValidator(valset, addr) {
+		return errAlreadyValidator
+	}
+
+	// The stake is required if the staking information is used to elect the proposers.
+	var stakingInfo *reward.StakingInfo
+	if reward.GetStakingManager() != nil {
+		stakingInfo = reward.GetStakingInfo(head.Uint64())
+	}
+	if stakingInfo == nil {
+		if pset.Policy() == params.WeightedRandom {
+			return errNoStakingInfo
+		}
+	} else {
+		node := stakingInfo.GetConsolidatedStakingInfo().GetConsolidatedNode(addr)
+		if node == nil || new(big.Int).SetUint64(node.StakingAmount).Cmp(pset.MinimumStakeBig()) < 0 {
+			return errNotStaked
+		}
+	}
+
+	chain := api.governance.BlockChain()
+	if chain.Config().IsRandaoForkEnabled(head) {
+		infos, err := readValidatorBlsKeys(chain, head)
+		if err != nil {
+			return fmt.Errorf("failed to read the BLS keys: %v", err)
+		}
+		if _, ok := infos[addr]; !ok {
+			return errNoBlsKey
+		}
+	}
+	return nil
+}
+
+func (api *GovernanceAPI) checkValidatorToRemove(valset istanbul.ValidatorSet, addr common.Address) error {
+	if !isValidator(valset, addr) {
+		return errNotValidator
+	}
+	if addr == api.governance.NodeAddress() {
+		return errRemoveSelf
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/consensus/istanbul/validator"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGovernanceAPI_ValidatorChanges(t *testing.T) {
+	var (
+		council    = getTestValidators()
+		nodeAddr   = council[0]
+		candidate  = common.HexToAddress("0xa")
+		unstaked   = common.HexToAddress("0xb")
+		unkeyed    = common.HexToAddress("0xc")
+		adminCtx   = rpc.WithAdminCaller(context.Background(), "token:operator")
+		validators = []common.Address{council[0], council[1], council[2]}
+	)
+	config := params.TestChainConfig.Copy()
+	config.Istanbul = params.GetDefaultIstanbulConfig()
+	config.Governance = params.GetDefaultGovernanceConfig()
+	config.RandaoCompatibleBlock = big.NewInt(0)
+
+	gov := NewMixedEngine(config, database.NewMemoryDBManager())
+	gov.SetNodeAddress(nodeAddr)
+	bc := newTestBlockchain(config)
+	bc.SetBlockNum(50)
+	gov.SetBlockchain(bc)
+	api := NewGovernanceAPI(gov)
+
+	valset := validator.NewWeightedCouncil(validators, []common.Address{council[3]}, nil, getTestVotingPowers(len(validators)), nil, istanbul.WeightedRandom, 21, 0, 0, nil)
+	defer func(f func(blockChain) istanbul.ValidatorSet) { currentValidators = f }(currentValidators)
+	currentValidators = func(blockChain) istanbul.ValidatorSet { return valset }
+	defer func(f func(blockChain, *big.Int) (system.BlsPublicKeyInfos, error)) { readValidatorBlsKeys = f }(readValidatorBlsKeys)
+	readValidatorBlsKeys = func(blockChain, *big.Int) (system.BlsPublicKeyInfos, error) {
+		return system.BlsPublicKeyInfos{candidate: {}, unstaked: {}}, nil
+	}
+	defer reward.SetTestStakingManager(reward.GetStakingManager())
+	reward.SetTestStakingManagerWithStakingInfoCache(&reward.StakingInfo{
+		CouncilNodeAddrs:      []common.Address{candidate, unstaked, unkeyed},
+		CouncilStakingAddrs:   []common.Address{{0x1}, {0x2}, {0x3}},
+		CouncilRewardAddrs:    []common.Address{{0x1}, {0x2}, {0x3}},
+		CouncilStakingAmounts: []uint64{5000000, 1000, 5000000},
+	})
+
+	// only allowed through the admin endpoint
+	_, err := api.AddValidator(context.Background(), candidate)
+	assert.Equal(t, errNoAdminCaller, err)
+
+	// the candidate is validated
+	_, err = api.AddValidator(adminCtx, council[1])
+	assert.Equal(t, errAlreadyValidator, err)
+	_, err = api.AddValidator(adminCtx, council[3])
+	assert.Equal(t, errAlreadyValidator, err)
+	_, err = api.AddValidator(adminCtx, unstaked)
+	assert.Equal(t, errNotStaked, err)
+	_, err = api.AddValidator(adminCtx, unkeyed)
+	assert.Equal(t, errNoBlsKey, err)
+	_, err = api.RemoveValidator(adminCtx, candidate)
+	assert.Equal(t, errNotValidator, err)
+	_, err = api.RemoveValidator(adminCtx, nodeAddr)
+	assert.Equal(t, errRemoveSelf, err)
+	assert.Empty(t, api.ValidatorChanges())
+
+	change, err := api.AddValidator(adminCtx, candidate)
+	require.NoError(t, err)
+	assert.Equal(t, ValidatorChangeAdd, change.Action)
+	assert.Equal(t, "token:operator", change.Requester)
+	assert.Equal(t, uint64(50), change.RequestedBlock)
+	assert.Equal(t, ValidatorChangePending, change.Status)
+
+	// the pending removals are voted together
+	_, err = api.RemoveValidator(adminCtx, council[1])
+	require.NoError(t, err)
+	_, err = api.RemoveValidator(adminCtx, council[2])
+	require.NoError(t, err)
+	votes := gov.headerGov.voteMap.Copy()
+	assert.Equal(t, candidate, votes["governance.addvalidator"].Value)
+	assert.Equal(t, []common.Address{council[2], council[1]}, votes["governance.removevalidator"].Value)
+	assert.Len(t, api.PendingValidatorChanges(), 3)
+
+	// the applied change is no longer pending
+	valset.AddValidator(candidate)
+	changes := api.ValidatorChanges()
+	require.Len(t, changes, 3)
+	assert.Equal(t, ValidatorChangeApplied, changes[0].Status)
+	assert.Len(t, api.PendingValidatorChanges(), 2)
+
+	// a later request of the same validator supersedes the pending change
+	_, err = api.RemoveValidator(adminCtx, council[2])
+	require.NoError(t, err)
+	changes = api.ValidatorChanges()
+	require.Len(t, changes, 4)
+	assert.Equal(t, ValidatorChangeSuperseded, changes[2].Status)
+	assert.Equal(t, ValidatorChangePending, changes[3].Status)
+	assert.Len(t, api.PendingValidatorChanges(), 2)
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package log
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// asyncRecord is a record queued in AsyncHandler. done is closed after the record is
+// written if it is not nil.
+type asyncRecord struct {
+	r    *Record
+	done chan struct{}
+}
+
+// AsyncHandler writes the records to the wrapped handler in the background, so that
+// a slow disk never blocks the logging goroutines. The records are dropped when the
+// buffer is full, and the number of the dropped records is written to the wrapped
+// handler as a warning once the buffer has room again. The critical records are never
+// dropped, and the logging goroutine waits for them to be written before exiting.
+type AsyncHandler struct {
+	recs chan asyncRecord
+	h    Handler
+
+	dropped    uint64 // The number of the dropped records since the start
+	unreported uint64 // The number of the dropped records not written to h yet
+}
+
+// NewAsyncHandler returns an AsyncHandler which buffers up to bufSize records.
+func NewAsyncHandler(bufSize int, h Handler) *AsyncHandler {
+	a := &AsyncHandler{recs: make(chan asyncRecord, bufSize), h: h}
+	go a.loop()
+	return a
+}
+
+func (a *AsyncHandler) Log(r *Record) error {
+	if r.Lvl == LvlCrit {
+		done := make(chan struct{})
+		a.recs <- asyncRecord{r, done}
+		<-done
+		return nil
+	}
+
+	select {
+	case a.recs <- asyncRecord{r: r}:
+	default:
+		atomic.AddUint64(&a.dropped, 1)
+		atomic.AddUint64(&a.unreported, 1)
+	}
+	return nil
+}
+
+// Dropped returns the number of the records dropped since the start.
+func (a *AsyncHandler) Dropped() uint64 {
+	return atomic.LoadUint64(&a.dropped)
+}
+
+func (a *AsyncHandler) loop() {
+	for rec := range a.recs {
+		if n := atomic.SwapUint64(&a.unreported, 0); n > 0 {
+			_ = a.h.Log(&Record{
+				Time:     time.Now(),
+				Lvl:      LvlWarn,
+				Msg:      "Dropped log records since the log buffer is full",
+				Ctx:      []interface{}{module, BaseLogger, "dropped", n, "total", a.Dropped()},
+				KeyNames: rec.r.KeyNames,
+			})
+		}
+		_ = a.h.Log(rec.r)
+		if rec.done != nil {
+			close(rec.done)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package log
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordCollector struct {
+	mu   sync.Mutex
+	recs []*Record
+}
+
+func (c *recordCollector) Log(r *Record) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recs = append(c.recs, r)
+	return nil
+}
+
+func (c *recordCollector) msgs() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	msgs := make([]string, len(c.recs))
+	for i, r := range c.recs {
+		msgs[i] = r.Msg
+	}
+	return msgs
+}
+
+func TestAsyncHandler(t *testing.T) {
+	var (
+		unblock   = make(chan struct{})
+		collector = new(recordCollector)
+		blocked   = FuncHandler(func(r *Record) error {
+			if r.Msg == "first" {
+				<-unblock // a slow disk
+			}
+			return collector.Log(r)
+		})
+		h = NewAsyncHandler(2, blocked)
+	)
+
+	h.Log(&Record{Lvl: LvlInfo, Msg: "first"})
+	assert.Eventually(t, func() bool { return len(h.recs) == 0 }, time.Second, time.Millisecond)
+	for _, msg := range []string{"second", "third", "dropped", "dropped"} {
+		h.Log(&Record{Lvl: LvlInfo, Msg: msg})
+	}
+	assert.Equal(t, uint64(2), h.Dropped())
+	close(unblock)
+
+	// the critical record is written before Log returns, and the drops are reported
+	// before the next record written
+	h.Log(&Record{Lvl: LvlCrit, Msg: "crit"})
+	assert.Equal(t, []string{"first", "Dropped log records since the log buffer is full", "second", "third", "crit"}, collector.msgs())
+	assert.Equal(t, uint64(2), collector.recs[1].Ctx[3])
+}
+
+func TestModuleRouteHandler(t *testing.T) {
+	var consensus, reward, others recordCollector
+	h := ModuleRouteHandler([]ModuleRoute{
+		{Pattern: "consensus/*", Handler: &consensus},
+		{Pattern: "contracts/reward", Handler: &reward},
+	}, &others)
+
+	for _, mi := range []ModuleID{ConsensusIstanbul, ConsensusIstanbulCore, Reward, NodeCN} {
+		h.Log(&Record{Msg: GetModuleName(mi), Ctx: []interface{}{module, mi}})
+	}
+	h.Log(&Record{Msg: "root"})
+
+	assert.Equal(t, []string{"consensus/istanbul", "consensus/istanbul/core"}, consensus.msgs())
+	assert.Equal(t, []string{"contracts/reward"}, reward.msgs())
+	assert.Equal(t, []string{"node/cn", "root"}, others.msgs())
+
+	assert.False(t, ModuleRoute{Pattern: "consensus/*"}.Match("consensusx"))
+	assert.False(t, ModuleRoute{Pattern: "node"}.Match("node/cn"))
+}
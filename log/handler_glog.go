@@ -51,6 +51,9 @@ type GlogHandler struct {
 	siteCache map[uintptr]Lvl // Cache of callsite pattern evaluations
 	location  string          // file:line location where to do a stackdump at
 	lock      sync.RWMutex    // Lock protecting the override pattern list
+
+	modules        map[ModuleID]Lvl // Verbosity of the modules overriding the global level
+	moduleOverride uint32           // Flag whether module overrides are used, atomically accessible
 }
 
 // NewGlogHandler creates a new log handler with filtering functionality similar
@@ -142,6 +145,37 @@ func (h *GlogHandler) Vmodule(ruleset string) error {
 	return nil
 }
 
+// ModuleVerbosity returns the verbosity of the module set by SetModuleVerbosity.
+func (h *GlogHandler) ModuleVerbosity(mi ModuleID) (Lvl, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	lvl, ok := h.modules[mi]
+	return lvl, ok
+}
+
+// SetModuleVerbosity raises the verbosity of the records of the module above the
+// global level. Unlike Vmodule, the module is given by the module logger, not the file.
+func (h *GlogHandler) SetModuleVerbosity(mi ModuleID, level Lvl) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.modules == nil {
+		h.modules = make(map[ModuleID]Lvl)
+	}
+	h.modules[mi] = level
+	atomic.StoreUint32(&h.moduleOverride, uint32(len(h.modules)))
+}
+
+// ResetModuleVerbosity removes the verbosity of the module set by SetModuleVerbosity.
+func (h *GlogHandler) ResetModuleVerbosity(mi ModuleID) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.modules, mi)
+	atomic.StoreUint32(&h.moduleOverride, uint32(len(h.modules)))
+}
+
 // BacktraceAt sets the glog backtrace location. When set to a file and line
 // number holding a logging statement, a stack trace will be written to the Info
 // log whenever execution hits that statement.
@@ -200,6 +234,17 @@ func (h *GlogHandler) Log(r *Record) error {
 	if atomic.LoadUint32(&h.level) >= uint32(r.Lvl) {
 		return h.origin.Log(r)
 	}
+	// Check the verbosity of the module of the record
+	if atomic.LoadUint32(&h.moduleOverride) > 0 {
+		if mi, ok := recordModule(r); ok {
+			h.lock.RLock()
+			lvl, ok := h.modules[mi]
+			h.lock.RUnlock()
+			if ok && lvl >= r.Lvl {
+				return h.origin.Log(r)
+			}
+		}
+	}
 	// If no local overrides are present, fast track skipping
 	if atomic.LoadUint32(&h.override) == 0 {
 		return nil
@@ -229,3 +274,14 @@ func (h *GlogHandler) Log(r *Record) error {
 	}
 	return nil
 }
+
+// recordModule returns the module of the record written by a module logger.
+func recordModule(r *Record) (ModuleID, bool) {
+	for i := 0; i+1 < len(r.Ctx); i += 2 {
+		if r.Ctx[i] == module {
+			mi, ok := r.Ctx[i+1].(ModuleID)
+			return mi, ok
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package log
+
+import "strings"
+
+// ModuleRoute is a destination of the records of the modules matching the pattern.
+// The pattern is either a module name such as "networks/rpc", or a module name followed by
+// "/*" such as "consensus/*" which also matches all the modules under it.
+type ModuleRoute struct {
+	Pattern string
+	Handler Handler
+}
+
+// Match reports whether the module name matches the pattern of the route.
+func (route ModuleRoute) Match(moduleName string) bool {
+	if prefix := strings.TrimSuffix(route.Pattern, "/*"); prefix != route.Pattern {
+		return moduleName == prefix || strings.HasPrefix(moduleName, prefix+"/")
+	}
+	return moduleName == route.Pattern
+}
+
+// ModuleRouteHandler writes the records of a module logger to the handler of the first
+// matching route, and all the others to the default handler h.
+func ModuleRouteHandler(routes []ModuleRoute, h Handler) Handler {
+	return FuncHandler(func(r *Record) error {
+		if len(r.Ctx) >= 2 && r.Ctx[0] == module {
+			if mi, ok := r.Ctx[1].(ModuleID); ok && mi < ModuleNameLen {
+				name := GetModuleName(mi)
+				for _, route := range routes {
+					if route.Match(name) {
+						return route.Handler.Log(r)
+					}
+				}
+			}
+		}
+		return h.Log(r)
+	})
+}
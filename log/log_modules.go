@@ -126,6 +126,9 @@ const (
 	KAS
 	FORK
 	NodeCnGasPrice
+	NodeForkNet
+	NodeFaucet
+	NodeSubProxy
 
 	// ModuleNameLen should be placed at the end of the list.
 	ModuleNameLen
@@ -203,4 +206,7 @@ var moduleNames = [ModuleNameLen]string{
 	"kas",
 	"fork",
 	"node/cn/gasprice",
+	"node/forknet",
+	"node/faucet",
+	"node/subproxy",
 }
@@ -0,0 +1,69 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package log
+
+import "sync/atomic"
+
+// RaiseModuleLevels raises the log levels of the modules to lvl on both the zap loggers
+// and the glog handler of the root logger, leaving the modules already logging at lvl
+// as they are. It returns the function restoring the previous levels.
+func RaiseModuleLevels(lvl Lvl, modules ...ModuleID) (restore func(), err error) {
+	if err := levelCheck(lvl); err != nil {
+		return nil, err
+	}
+	for _, mi := range modules {
+		if err := idCheck(mi); err != nil {
+			return nil, err
+		}
+	}
+
+	var restores []func()
+	for _, mi := range modules {
+		for _, zl := range zlManager.loggersMap[mi] {
+			zl := zl
+			if prev := zl.cfg.Level.Level(); prev > lvlToZapLevel(lvl) {
+				zl.setLevel(lvl)
+				restores = append(restores, func() { zl.cfg.Level.SetLevel(prev) })
+			}
+		}
+	}
+	if glogger, ok := root.GetHandler().(*GlogHandler); ok {
+		for _, mi := range modules {
+			mi := mi
+			if Lvl(atomic.LoadUint32(&glogger.level)) >= lvl {
+				continue
+			}
+			prev, ok := glogger.ModuleVerbosity(mi)
+			if ok && prev >= lvl {
+				continue
+			}
+			glogger.SetModuleVerbosity(mi, lvl)
+			restores = append(restores, func() {
+				if ok {
+					glogger.SetModuleVerbosity(mi, prev)
+				} else {
+					glogger.ResetModuleVerbosity(mi)
+				}
+			})
+		}
+	}
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}, nil
+}
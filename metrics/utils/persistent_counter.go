@@ -0,0 +1,114 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package metricutils
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/rcrowley/go-metrics"
+)
+
+// PersistentCounterStore stores the lifetime values of the persistent counters.
+type PersistentCounterStore interface {
+	ReadPersistentCounter(name string) (int64, bool)
+	WritePersistentCounter(name string, value int64)
+}
+
+// PersistentCounter is a counter whose lifetime value survives restarts. The counter itself
+// counts the session since the node started, and the lifetime value, exported as the gauge
+// of the name prefixed by "lifetime/", adds the value stored before the session.
+type PersistentCounter struct {
+	metrics.Counter
+
+	mu       sync.Mutex
+	base     int64 // the lifetime value loaded from the store
+	baseline int64 // the session value when the lifetime value is loaded
+}
+
+// PersistentCounterValues is the values of a persistent counter.
+type PersistentCounterValues struct {
+	Lifetime int64 `json:"lifetime"`
+	Session  int64 `json:"session"`
+}
+
+var (
+	persistentCountersMu sync.Mutex
+	persistentCounters   = make(map[string]*PersistentCounter)
+)
+
+// NewRegisteredPersistentCounter returns a persistent counter registered by the name in the
+// default registry. The lifetime value stays the session value until it is loaded.
+func NewRegisteredPersistentCounter(name string) *PersistentCounter {
+	c := &PersistentCounter{Counter: metrics.NewRegisteredCounter(name, nil)}
+	metrics.NewRegisteredFunctionalGauge("lifetime/"+name, nil, c.Lifetime)
+
+	persistentCountersMu.Lock()
+	defer persistentCountersMu.Unlock()
+	persistentCounters[name] = c
+	return c
+}
+
+// Lifetime returns the value counted since the counter is first persisted.
+func (c *PersistentCounter) Lifetime() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.base + c.Count() - c.baseline
+}
+
+// load sets the lifetime value to the stored value, counting the session from now on.
+func (c *PersistentCounter) load(value int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.base, c.baseline = value, c.Count()
+}
+
+// LoadPersistentCounters loads the lifetime values of the persistent counters from the store.
+func LoadPersistentCounters(store PersistentCounterStore) {
+	persistentCountersMu.Lock()
+	defer persistentCountersMu.Unlock()
+	for name, c := range persistentCounters {
+		if value, ok := store.ReadPersistentCounter(name); ok {
+			c.load(value)
+		}
+	}
+}
+
+// PersistPersistentCounters writes the lifetime values of the persistent counters to the store.
+func PersistPersistentCounters(store PersistentCounterStore) {
+	persistentCountersMu.Lock()
+	defer persistentCountersMu.Unlock()
+	names := make([]string, 0, len(persistentCounters))
+	for name := range persistentCounters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		store.WritePersistentCounter(name, persistentCounters[name].Lifetime())
+	}
+}
+
+// GetPersistentCounters returns the lifetime and the session values of the persistent counters.
+func GetPersistentCounters() map[string]PersistentCounterValues {
+	persistentCountersMu.Lock()
+	defer persistentCountersMu.Unlock()
+	values := make(map[string]PersistentCounterValues, len(persistentCounters))
+	for name, c := range persistentCounters {
+		values[name] = PersistentCounterValues{Lifetime: c.Lifetime(), Session: c.Count()}
+	}
+	return values
+}
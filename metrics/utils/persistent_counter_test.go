@@ -0,0 +1,64 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package metricutils
+
+import (
+	"testing"
+
+	"github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCounterStore map[string]int64
+
+func (s testCounterStore) ReadPersistentCounter(name string) (int64, bool) {
+	value, ok := s[name]
+	return value, ok
+}
+
+func (s testCounterStore) WritePersistentCounter(name string, value int64) {
+	s[name] = value
+}
+
+func TestPersistentCounter(t *testing.T) {
+	c := NewRegisteredPersistentCounter("test/persistent/counter")
+	lifetime := metrics.Get("lifetime/test/persistent/counter").(metrics.Gauge)
+
+	// counts the session until the lifetime value is loaded
+	c.Inc(2)
+	assert.Equal(t, int64(2), c.Lifetime())
+
+	store := testCounterStore{}
+	LoadPersistentCounters(store)
+	assert.Equal(t, int64(2), c.Lifetime())
+	PersistPersistentCounters(store)
+	assert.Equal(t, int64(2), store["test/persistent/counter"])
+
+	// the stored lifetime value is continued by the counts after it is loaded
+	store["test/persistent/counter"] = 100
+	LoadPersistentCounters(store)
+	c.Inc(5)
+	assert.Equal(t, int64(105), c.Lifetime())
+	assert.Equal(t, int64(105), lifetime.Value())
+	assert.Equal(t, PersistentCounterValues{Lifetime: 105, Session: 7}, GetPersistentCounters()["test/persistent/counter"])
+
+	// loading the persisted value again does not count the session twice
+	PersistPersistentCounters(store)
+	LoadPersistentCounters(store)
+	c.Inc(1)
+	assert.Equal(t, int64(106), c.Lifetime())
+}
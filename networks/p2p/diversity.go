@@ -0,0 +1,297 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+)
+
+const (
+	diversitySubnet = "subnet"
+	diversityASN    = "asn"
+	diversityRegion = "region"
+)
+
+// DiversityConfig limits how many peers of a consensus or proxy node may share
+// the same network location. A zero limit disables the corresponding check.
+type DiversityConfig struct {
+	// MaxPerSubnet is the maximum number of peers in the same /16 IPv4
+	// (or /32 IPv6) subnet.
+	MaxPerSubnet int `toml:",omitempty"`
+
+	// MaxPerASN is the maximum number of peers announced by the same
+	// autonomous system. It requires Prefixes.
+	MaxPerASN int `toml:",omitempty"`
+
+	// MaxPerRegion is the maximum number of peers hinted to be in the same
+	// region. It requires Prefixes.
+	MaxPerRegion int `toml:",omitempty"`
+
+	// Prefixes maps network prefixes to their AS number and region hint.
+	Prefixes []DiversityPrefix `toml:"-"`
+}
+
+// DiversityPrefix is an entry of the network information used by the
+// diversity policy to find the AS number and region of a peer.
+type DiversityPrefix struct {
+	Net    *net.IPNet
+	ASN    uint32
+	Region string
+}
+
+// DiversityInfo is the current state of the peer diversity policy.
+type DiversityInfo struct {
+	Enabled      bool              `json:"enabled"`
+	MaxPerSubnet int               `json:"maxPerSubnet"`
+	MaxPerASN    int               `json:"maxPerASN"`
+	MaxPerRegion int               `json:"maxPerRegion"`
+	Subnets      map[string]int    `json:"subnets"`
+	ASNs         map[string]int    `json:"asns"`
+	Regions      map[string]int    `json:"regions"`
+	Exemptions   []string          `json:"exemptions"`
+	Rejected     map[string]uint64 `json:"rejected"`
+}
+
+// LoadDiversityPrefixes reads the network information of the diversity policy
+// from the given file.
+func LoadDiversityPrefixes(file string) ([]DiversityPrefix, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseDiversityPrefixes(f)
+}
+
+// ParseDiversityPrefixes parses the network information of the diversity
+// policy. Each line holds a CIDR, an AS number and an optional region hint
+// separated by whitespace, e.g. "203.0.113.0/24 AS64500 ap-northeast-2".
+// Empty lines and lines starting with '#' are ignored.
+func ParseDiversityPrefixes(r io.Reader) ([]DiversityPrefix, error) {
+	var (
+		prefixes []DiversityPrefix
+		scanner  = bufio.NewScanner(r)
+		line     = 0
+	)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("line %d: expected \"<cidr> <asn> [region]\"", line)
+		}
+		_, ipnet, err := net.ParseCIDR(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(fields[1]), "AS"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid AS number %q", line, fields[1])
+		}
+		prefix := DiversityPrefix{Net: ipnet, ASN: uint32(asn)}
+		if len(fields) == 3 {
+			prefix.Region = fields[2]
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, scanner.Err()
+}
+
+// peerLocation is where a peer is in the network from the diversity policy's
+// point of view. Empty fields are unknown and never limited.
+type peerLocation struct {
+	subnet string
+	asn    string
+	region string
+}
+
+// diversityPolicy rejects connections that would make too many peers share
+// a subnet, an AS or a region. It is consulted by the run loop of the server
+// while the admin API may change the overrides concurrently.
+type diversityPolicy struct {
+	config   DiversityConfig
+	connType common.ConnType
+
+	mu       sync.RWMutex
+	disabled bool
+	exempt   map[discover.NodeID]bool
+	rejected map[string]uint64
+}
+
+func newDiversityPolicy(config DiversityConfig, connType common.ConnType) *diversityPolicy {
+	return &diversityPolicy{
+		config:   config,
+		connType: connType,
+		exempt:   make(map[discover.NodeID]bool),
+		rejected: make(map[string]uint64),
+	}
+}
+
+// enabled returns true if the policy has any limit and applies to the node.
+func (p *diversityPolicy) enabled() bool {
+	if p.connType != common.CONSENSUSNODE && p.connType != common.PROXYNODE {
+		return false
+	}
+	if p.config.MaxPerSubnet <= 0 && p.config.MaxPerASN <= 0 && p.config.MaxPerRegion <= 0 {
+		return false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return !p.disabled
+}
+
+func (p *diversityPolicy) setEnabled(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.disabled = !enabled
+}
+
+func (p *diversityPolicy) setExempt(id discover.NodeID, exempt bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if exempt {
+		p.exempt[id] = true
+	} else {
+		delete(p.exempt, id)
+	}
+}
+
+func (p *diversityPolicy) isExempt(id discover.NodeID) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.exempt[id]
+}
+
+// locate returns the location of the given address.
+func (p *diversityPolicy) locate(addr net.Addr) (loc peerLocation) {
+	tcp, ok := addr.(*net.TCPAddr)
+	if !ok || tcp.IP == nil {
+		return loc
+	}
+	ip := tcp.IP
+	if ip4 := ip.To4(); ip4 != nil {
+		loc.subnet = (&net.IPNet{IP: ip4.Mask(net.CIDRMask(16, 32)), Mask: net.CIDRMask(16, 32)}).String()
+	} else {
+		loc.subnet = (&net.IPNet{IP: ip.Mask(net.CIDRMask(32, 128)), Mask: net.CIDRMask(32, 128)}).String()
+	}
+	// Use the longest matching prefix.
+	best := -1
+	for _, prefix := range p.config.Prefixes {
+		if !prefix.Net.Contains(ip) {
+			continue
+		}
+		if ones, _ := prefix.Net.Mask.Size(); ones > best {
+			best = ones
+			loc.asn = "AS" + strconv.FormatUint(uint64(prefix.ASN), 10)
+			loc.region = prefix.Region
+		}
+	}
+	return loc
+}
+
+// check returns DiscTooManyPeers if adding the connection would exceed any
+// of the diversity limits. Trusted and static peers as well as the peers
+// exempted by the admin are always allowed.
+func (p *diversityPolicy) check(peers map[discover.NodeID]*Peer, c *conn) error {
+	if p == nil || !p.enabled() || c.is(trustedConn|staticDialedConn) || p.isExempt(c.id) {
+		return nil
+	}
+	loc := p.locate(c.fd.RemoteAddr())
+	var subnets, asns, regions int
+	for _, peer := range peers {
+		ploc := p.locate(peer.RemoteAddr())
+		if loc.subnet != "" && ploc.subnet == loc.subnet {
+			subnets++
+		}
+		if loc.asn != "" && ploc.asn == loc.asn {
+			asns++
+		}
+		if loc.region != "" && ploc.region == loc.region {
+			regions++
+		}
+	}
+	var kind, value string
+	switch {
+	case p.config.MaxPerSubnet > 0 && subnets >= p.config.MaxPerSubnet:
+		kind, value = diversitySubnet, loc.subnet
+		diversitySubnetRejectCounter.Inc(1)
+	case p.config.MaxPerASN > 0 && asns >= p.config.MaxPerASN:
+		kind, value = diversityASN, loc.asn
+		diversityASNRejectCounter.Inc(1)
+	case p.config.MaxPerRegion > 0 && regions >= p.config.MaxPerRegion:
+		kind, value = diversityRegion, loc.region
+		diversityRegionRejectCounter.Inc(1)
+	default:
+		return nil
+	}
+	p.mu.Lock()
+	p.rejected[kind]++
+	p.mu.Unlock()
+	logger.Debug("Rejected peer by diversity policy", "id", c.id, "addr", c.fd.RemoteAddr(), kind, value)
+	return DiscTooManyPeers
+}
+
+// info returns the state of the policy with the distribution of the given peers.
+func (p *diversityPolicy) info(peers []*Peer) *DiversityInfo {
+	info := &DiversityInfo{
+		Enabled:      p.enabled(),
+		MaxPerSubnet: p.config.MaxPerSubnet,
+		MaxPerASN:    p.config.MaxPerASN,
+		MaxPerRegion: p.config.MaxPerRegion,
+		Subnets:      make(map[string]int),
+		ASNs:         make(map[string]int),
+		Regions:      make(map[string]int),
+		Exemptions:   []string{},
+		Rejected:     make(map[string]uint64),
+	}
+	for _, peer := range peers {
+		loc := p.locate(peer.RemoteAddr())
+		if loc.subnet != "" {
+			info.Subnets[loc.subnet]++
+		}
+		if loc.asn != "" {
+			info.ASNs[loc.asn]++
+		}
+		if loc.region != "" {
+			info.Regions[loc.region]++
+		}
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for id := range p.exempt {
+		info.Exemptions = append(info.Exemptions, id.String())
+	}
+	sort.Strings(info.Exemptions)
+	for kind, count := range p.rejected {
+		info.Rejected[kind] = count
+	}
+	return info
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addrConn is a net.Conn reporting the given remote address.
+type addrConn struct {
+	net.Conn
+	remote net.Addr
+}
+
+func (c *addrConn) RemoteAddr() net.Addr { return c.remote }
+
+func diversityConn(ip string, flags connFlag) *conn {
+	addr := &net.TCPAddr{IP: net.ParseIP(ip), Port: 32323}
+	return &conn{fd: &addrConn{remote: addr}, id: randomID(), flags: flags}
+}
+
+func diversityPeers(ips ...string) map[discover.NodeID]*Peer {
+	peers := make(map[discover.NodeID]*Peer)
+	for _, ip := range ips {
+		c := diversityConn(ip, inboundConn)
+		peers[c.id] = &Peer{rws: []*conn{c}}
+	}
+	return peers
+}
+
+func TestParseDiversityPrefixes(t *testing.T) {
+	prefixes, err := ParseDiversityPrefixes(strings.NewReader(`
+# cidr asn region
+10.0.0.0/8      AS64500 kr
+10.1.0.0/16     64501
+2001:db8::/32   as64502 us
+`))
+	require.NoError(t, err)
+	require.Len(t, prefixes, 3)
+	assert.Equal(t, "10.0.0.0/8", prefixes[0].Net.String())
+	assert.Equal(t, uint32(64500), prefixes[0].ASN)
+	assert.Equal(t, "kr", prefixes[0].Region)
+	assert.Equal(t, uint32(64501), prefixes[1].ASN)
+	assert.Equal(t, "", prefixes[1].Region)
+	assert.Equal(t, uint32(64502), prefixes[2].ASN)
+
+	for _, input := range []string{
+		"10.0.0.0/8",
+		"10.0.0.0 AS1",
+		"10.0.0.0/8 ASx",
+		"10.0.0.0/8 AS1 kr extra",
+	} {
+		_, err := ParseDiversityPrefixes(strings.NewReader(input))
+		assert.Error(t, err, input)
+	}
+}
+
+func TestDiversityPolicyLocate(t *testing.T) {
+	prefixes, err := ParseDiversityPrefixes(strings.NewReader("10.0.0.0/8 AS1 kr\n10.1.0.0/16 AS2 jp\n"))
+	require.NoError(t, err)
+	p := newDiversityPolicy(DiversityConfig{Prefixes: prefixes}, common.CONSENSUSNODE)
+
+	assert.Equal(t, peerLocation{"10.2.0.0/16", "AS1", "kr"}, p.locate(&net.TCPAddr{IP: net.ParseIP("10.2.3.4")}))
+	assert.Equal(t, peerLocation{"10.1.0.0/16", "AS2", "jp"}, p.locate(&net.TCPAddr{IP: net.ParseIP("10.1.3.4")}))
+	assert.Equal(t, peerLocation{"192.168.0.0/16", "", ""}, p.locate(&net.TCPAddr{IP: net.ParseIP("192.168.3.4")}))
+	assert.Equal(t, peerLocation{"2001:db8::/32", "", ""}, p.locate(&net.TCPAddr{IP: net.ParseIP("2001:db8:1::1")}))
+	assert.Equal(t, peerLocation{}, p.locate(&net.UnixAddr{Name: "pipe"}))
+}
+
+func TestDiversityPolicyCheck(t *testing.T) {
+	prefixes, err := ParseDiversityPrefixes(strings.NewReader("10.0.0.0/8 AS1 kr\n20.0.0.0/8 AS2 kr\n"))
+	require.NoError(t, err)
+	config := DiversityConfig{MaxPerSubnet: 2, MaxPerASN: 3, MaxPerRegion: 4, Prefixes: prefixes}
+
+	p := newDiversityPolicy(config, common.CONSENSUSNODE)
+	peers := diversityPeers("10.1.0.1", "10.1.0.2", "20.1.0.1")
+
+	// The subnet 10.1.0.0/16 is full.
+	assert.Equal(t, DiscTooManyPeers, p.check(peers, diversityConn("10.1.0.3", inboundConn)))
+	// AS1 has room for another subnet.
+	assert.NoError(t, p.check(peers, diversityConn("10.3.0.1", inboundConn)))
+	// Unknown prefixes are limited by subnet only.
+	assert.NoError(t, p.check(peers, diversityConn("30.1.0.1", inboundConn)))
+
+	peers = diversityPeers("10.1.0.1", "10.2.0.1", "10.3.0.1", "20.1.0.1")
+	// AS1 is full.
+	assert.Equal(t, DiscTooManyPeers, p.check(peers, diversityConn("10.4.0.1", inboundConn)))
+	// The region kr is full.
+	peers = diversityPeers("10.1.0.1", "10.2.0.1", "20.1.0.1", "20.2.0.1")
+	assert.Equal(t, DiscTooManyPeers, p.check(peers, diversityConn("20.3.0.1", inboundConn)))
+
+	// Trusted, static and exempted peers are always allowed.
+	assert.NoError(t, p.check(peers, diversityConn("20.3.0.1", trustedConn|inboundConn)))
+	assert.NoError(t, p.check(peers, diversityConn("20.3.0.1", staticDialedConn)))
+	c := diversityConn("20.3.0.1", inboundConn)
+	p.setExempt(c.id, true)
+	assert.NoError(t, p.check(peers, c))
+	p.setExempt(c.id, false)
+	assert.Equal(t, DiscTooManyPeers, p.check(peers, c))
+
+	// The admin can turn the policy off.
+	p.setEnabled(false)
+	assert.NoError(t, p.check(peers, c))
+	p.setEnabled(true)
+
+	info := p.info(peerList(peers))
+	assert.True(t, info.Enabled)
+	assert.Equal(t, map[string]int{"AS1": 2, "AS2": 2}, info.ASNs)
+	assert.Equal(t, map[string]int{"kr": 4}, info.Regions)
+	assert.Len(t, info.Subnets, 4)
+	assert.Equal(t, map[string]uint64{diversitySubnet: 1, diversityASN: 1, diversityRegion: 2}, info.Rejected)
+
+	// The policy only applies to consensus and proxy nodes.
+	p = newDiversityPolicy(config, common.ENDPOINTNODE)
+	assert.NoError(t, p.check(peers, diversityConn("20.3.0.1", inboundConn)))
+	assert.False(t, p.info(nil).Enabled)
+}
+
+func peerList(peers map[discover.NodeID]*Peer) []*Peer {
+	var list []*Peer
+	for _, p := range peers {
+		list = append(list, p)
+	}
+	return list
+}
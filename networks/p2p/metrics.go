@@ -51,6 +51,11 @@ var (
 	dialFailCounter = metrics.NewRegisteredCounter("p2p/DialFailCounter", nil)
 
 	writeMsgTimeOutCounter = metrics.NewRegisteredCounter("p2p/WriteMsgTimeOutCounter", nil)
+
+	// Connections rejected by the peer diversity policy, by the exceeded limit.
+	diversitySubnetRejectCounter = metrics.NewRegisteredCounter("p2p/DiversitySubnetRejectCounter", nil)
+	diversityASNRejectCounter    = metrics.NewRegisteredCounter("p2p/DiversityASNRejectCounter", nil)
+	diversityRegionRejectCounter = metrics.NewRegisteredCounter("p2p/DiversityRegionRejectCounter", nil)
 )
 
 // meteredConn is a wrapper around a network TCP connection that meters both the
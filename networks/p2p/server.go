@@ -55,7 +55,10 @@ const (
 	frameWriteTimeout = 20 * time.Second
 )
 
-var errServerStopped = errors.New("server stopped")
+var (
+	errServerStopped     = errors.New("server stopped")
+	errNoDiversityPolicy = errors.New("peer diversity policy is not available")
+)
 
 // Config holds Server options.
 type Config struct {
@@ -163,12 +166,17 @@ type Config struct {
 
 	// NetworkID to use for selecting peers to connect to
 	NetworkID uint64
+
+	// Diversity limits how many peers of a consensus or proxy node may share
+	// the same subnet, AS or region.
+	Diversity DiversityConfig
 }
 
 // NewServer returns a new Server interface.
 func NewServer(config Config) Server {
 	bServer := &BaseServer{
-		Config: config,
+		Config:    config,
+		diversity: newDiversityPolicy(config.Diversity, config.ConnectionType),
 	}
 
 	if config.EnableMultiChannelServer {
@@ -271,6 +279,16 @@ type Server interface {
 	// Peers returns all connected peers.
 	Peers() []*Peer
 
+	// PeerDiversity returns the state of the peer diversity policy.
+	PeerDiversity() *DiversityInfo
+
+	// SetPeerDiversityEnabled turns the peer diversity policy on or off.
+	SetPeerDiversityEnabled(enabled bool) error
+
+	// SetPeerDiversityExempt exempts the given node from the peer diversity
+	// policy or removes the exemption.
+	SetPeerDiversityExempt(id discover.NodeID, exempt bool) error
+
 	// NodeDialer is used to connect to nodes in the network, typically by using
 	// an underlying net.Dialer but also using net.Pipe in tests.
 	NodeDialer
@@ -938,6 +956,8 @@ type BaseServer struct {
 	loopWG        sync.WaitGroup // loop, listenLoop
 	peerFeed      event.Feed
 	logger        log.Logger
+
+	diversity *diversityPolicy
 }
 
 type peerOpFunc func(map[discover.NodeID]*Peer)
@@ -1059,6 +1079,34 @@ func (srv *BaseServer) Peers() []*Peer {
 	return ps
 }
 
+// PeerDiversity returns the state of the peer diversity policy.
+func (srv *BaseServer) PeerDiversity() *DiversityInfo {
+	if srv.diversity == nil {
+		return nil
+	}
+	return srv.diversity.info(srv.Peers())
+}
+
+// SetPeerDiversityEnabled turns the peer diversity policy on or off.
+// Peers connected already are kept even if they exceed the limits.
+func (srv *BaseServer) SetPeerDiversityEnabled(enabled bool) error {
+	if srv.diversity == nil {
+		return errNoDiversityPolicy
+	}
+	srv.diversity.setEnabled(enabled)
+	return nil
+}
+
+// SetPeerDiversityExempt exempts the given node from the peer diversity
+// policy or removes the exemption.
+func (srv *BaseServer) SetPeerDiversityExempt(id discover.NodeID, exempt bool) error {
+	if srv.diversity == nil {
+		return errNoDiversityPolicy
+	}
+	srv.diversity.setExempt(id, exempt)
+	return nil
+}
+
 // PeerCount returns the number of connected peers.
 func (srv *BaseServer) PeerCount() int {
 	var count int
@@ -1537,7 +1585,7 @@ func (srv *BaseServer) encHandshakeChecks(peers map[discover.NodeID]*Peer, inbou
 	case c.id == srv.Self().ID:
 		return DiscSelf
 	default:
-		return nil
+		return srv.diversity.check(peers, c)
 	}
 }
 
@@ -0,0 +1,228 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// DefaultAdminModules are the dangerous API modules served by the admin endpoint by default.
+var DefaultAdminModules = []string{"admin", "debug", "personal", "governance"}
+
+var (
+	errNoAdminCredential  = errors.New("admin endpoint requires tokens or a client CA")
+	errEmptyAdminToken    = errors.New("admin token must not be empty")
+	errIncompleteAdminTLS = errors.New("admin TLS requires a certificate, a key and a client CA")
+)
+
+// AdminAuthConfig is the authentication setting of the admin endpoint. A caller is
+// authenticated by a bearer token or, if the client CA is given, a client certificate
+// verified by the CA. The caller identity is the token name or the certificate subject.
+type AdminAuthConfig struct {
+	Tokens      map[string]string // token name to token
+	TLSCert     string
+	TLSKey      string
+	TLSClientCA string
+}
+
+func (c *AdminAuthConfig) useTLS() bool {
+	return c.TLSCert != "" || c.TLSKey != "" || c.TLSClientCA != ""
+}
+
+func (c *AdminAuthConfig) validate() error {
+	if c.useTLS() && (c.TLSCert == "" || c.TLSKey == "" || c.TLSClientCA == "") {
+		return errIncompleteAdminTLS
+	}
+	if !c.useTLS() && len(c.Tokens) == 0 {
+		return errNoAdminCredential
+	}
+	for name, token := range c.Tokens {
+		if token == "" {
+			return fmt.Errorf("%v (name: %s)", errEmptyAdminToken, name)
+		}
+	}
+	return nil
+}
+
+// tlsConfig returns the server TLS configuration requiring the client certificate.
+func (c *AdminAuthConfig) tlsConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+	if err != nil {
+		return nil, err
+	}
+	pem, err := os.ReadFile(c.TLSClientCA)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in the client CA %s", c.TLSClientCA)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// LoadAdminTokens reads the admin tokens from the JSON file which maps names to tokens, e.g.
+//
+//	{"operator": "2c9ae4..."}
+func LoadAdminTokens(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make(map[string]string)
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("invalid admin token file %s: %v", path, err)
+	}
+	return tokens, nil
+}
+
+// adminAuthHandler authenticates the caller and writes an audit log of every invocation.
+type adminAuthHandler struct {
+	tokens map[string]string
+	next   http.Handler
+}
+
+// ServeHTTP serves JSON-RPC requests of the authenticated callers, implements http.Handler
+func (h *adminAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	caller, ok := h.authenticate(r)
+	if !ok {
+		logger.Warn("[Admin audit] Unauthorized request", "remote", r.RemoteAddr)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, int64(common.MaxRequestContentLength)+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, method := range requestMethods(body) {
+		logger.Info("[Admin audit] RPC invoked", "caller", caller, "remote", r.RemoteAddr, "method", method)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	h.next.ServeHTTP(w, r.WithContext(WithAdminCaller(r.Context(), caller)))
+}
+
+type adminCallerKey struct{}
+
+// WithAdminCaller returns a copy of the context carrying the caller identity authenticated
+// by the admin endpoint.
+func WithAdminCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, adminCallerKey{}, caller)
+}
+
+// AdminCaller returns the identity of the caller authenticated by the admin endpoint.
+// It returns false if the request has not been served via the admin endpoint.
+func AdminCaller(ctx context.Context) (string, bool) {
+	caller, ok := ctx.Value(adminCallerKey{}).(string)
+	return caller, ok
+}
+
+// authenticate returns the caller identity of the request.
+func (h *adminAuthHandler) authenticate(r *http.Request) (string, bool) {
+	// the client certificate is verified during the handshake
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return "cert:" + r.TLS.PeerCertificates[0].Subject.String(), true
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return "", false
+	}
+	token := []byte(strings.TrimPrefix(auth, "Bearer "))
+	for name, t := range h.tokens {
+		if subtle.ConstantTimeCompare(token, []byte(t)) == 1 {
+			return "token:" + name, true
+		}
+	}
+	return "", false
+}
+
+// requestMethods returns the methods of a single or batch JSON-RPC request.
+// An invalid request is recorded as it is rejected by the server anyway.
+func requestMethods(body []byte) []string {
+	type request struct {
+		Method string `json:"method"`
+	}
+	var batch []request
+	if err := json.Unmarshal(body, &batch); err == nil {
+		methods := make([]string, len(batch))
+		for i, req := range batch {
+			methods[i] = req.Method
+		}
+		return methods
+	}
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return []string{"<invalid>"}
+	}
+	return []string{req.Method}
+}
+
+// NewAdminHandler wraps the handler with the authentication and the audit logging.
+func NewAdminHandler(tokens map[string]string, srv http.Handler) http.Handler {
+	return &adminAuthHandler{tokens: tokens, next: srv}
+}
+
+// StartAdminEndpoint starts the authenticated admin endpoint on the Unix socket (named pipe
+// on Windows). JSON-RPC requests are served over HTTP, over TLS if the client CA is given.
+func StartAdminEndpoint(endpoint string, apis []API, modules []string, auth AdminAuthConfig, timeouts HTTPTimeouts) (net.Listener, *Server, error) {
+	if err := auth.validate(); err != nil {
+		return nil, nil, err
+	}
+	var tlsConfig *tls.Config
+	if auth.useTLS() {
+		var err error
+		if tlsConfig, err = auth.tlsConfig(); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(modules) == 0 {
+		modules = DefaultAdminModules
+	}
+	handler, err := newServerWithModules(apis, modules, false, "Admin")
+	if err != nil {
+		return nil, nil, err
+	}
+	listener, err := ipcListen(endpoint)
+	if err != nil {
+		handler.Stop()
+		return nil, nil, err
+	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	go newHTTPServer(NewAdminHandler(auth.Tokens, handler), timeouts).Serve(listener)
+	return listener, handler, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !windows
+// +build !windows
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestMethods(t *testing.T) {
+	assert.Equal(t, []string{"admin_peers"}, requestMethods([]byte(`{"jsonrpc":"2.0","id":1,"method":"admin_peers"}`)))
+	assert.Equal(t, []string{"debug_gcStats", "personal_listAccounts"},
+		requestMethods([]byte(`[{"method":"debug_gcStats"},{"method":"personal_listAccounts"}]`)))
+	assert.Equal(t, []string{"<invalid>"}, requestMethods([]byte(`{`)))
+}
+
+func TestAdminCaller(t *testing.T) {
+	var caller string
+	handler := NewAdminHandler(map[string]string{"operator": "secret"}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, _ = AdminCaller(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodPost, "http://admin/", strings.NewReader(`{"method":"admin_peers"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "token:operator", caller)
+
+	_, ok := AdminCaller(context.Background())
+	assert.False(t, ok)
+}
+
+func TestAdminAuthConfig_Validate(t *testing.T) {
+	assert.Equal(t, errNoAdminCredential, (&AdminAuthConfig{}).validate())
+	assert.Error(t, (&AdminAuthConfig{Tokens: map[string]string{"operator": ""}}).validate())
+	assert.Equal(t, errIncompleteAdminTLS, (&AdminAuthConfig{TLSCert: "cert.pem"}).validate())
+	assert.NoError(t, (&AdminAuthConfig{Tokens: map[string]string{"operator": "secret"}}).validate())
+}
+
+func TestStartAdminEndpoint(t *testing.T) {
+	endpoint := filepath.Join(t.TempDir(), "admin.ipc")
+	apis := []API{
+		{Namespace: "admin", Service: new(Service)},
+		{Namespace: "klay", Service: new(Service), Public: true},
+	}
+	auth := AdminAuthConfig{Tokens: map[string]string{"operator": "secret"}}
+	listener, handler, err := StartAdminEndpoint(endpoint, apis, nil, auth, DefaultHTTPTimeouts)
+	require.NoError(t, err)
+	defer handler.Stop()
+	defer listener.Close()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, "unix", endpoint)
+		},
+	}}
+	call := func(token string) (int, map[string]string) {
+		req, err := http.NewRequest(http.MethodPost, "http://admin/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"rpc_modules"}`))
+		require.NoError(t, err)
+		req.Header.Set("content-type", contentType)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, nil
+		}
+		var result struct {
+			Result map[string]string `json:"result"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result.Result
+	}
+
+	code, _ := call("")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = call("wrong")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	// only the default admin modules are served
+	code, modules := call("secret")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, modules, "admin")
+	assert.NotContains(t, modules, "klay")
+}
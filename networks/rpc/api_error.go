@@ -0,0 +1,130 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The codes of the errors returned by the API methods. The codes are stable, so that the
+// clients can branch on the failures by the code and the reason of the error data. The
+// codes from -32000 to -32005 are the ones defined by EIP-1474.
+const (
+	CodeInvalidInput        = -32000 // the parameters are well-formed but cannot be served
+	CodeResourceNotFound    = -32001 // the requested block, transaction or item does not exist
+	CodeResourceUnavailable = -32002 // the requested resource is not available in this node
+	CodeTransactionRejected = -32003 // the transaction is rejected by the transaction pool
+	CodeMethodNotSupported  = -32004 // the method is not supported in the current mode
+	CodeLimitExceeded       = -32005 // the request exceeds a limit of the node
+	CodePermissionDenied    = -32010 // the node is not allowed to perform the request
+	CodeInvalidParams       = -32602 // the parameters are malformed
+	CodeInternalError       = -32603 // the node failed to serve the request
+)
+
+// APIError is an error returned by an API method. In addition to the message, it carries
+// a stable code and the error data of the form {"reason": <reason>, <detail>: <value>...},
+// where the reason is a stable camelCase identifier of the failure.
+type APIError struct {
+	Code    int
+	Reason  string
+	Message string
+	Details map[string]interface{}
+
+	cause error // the underlying error, see Wrap
+}
+
+// NewAPIError returns an API error of the given code, reason and message.
+func NewAPIError(code int, reason, message string) *APIError {
+	return &APIError{Code: code, Reason: reason, Message: message}
+}
+
+func (e *APIError) Error() string { return e.Message }
+
+// ErrorCode returns the code of the error.
+func (e *APIError) ErrorCode() int { return e.Code }
+
+// ErrorData returns the reason and the details of the error.
+func (e *APIError) ErrorData() interface{} {
+	data := make(map[string]interface{}, len(e.Details)+1)
+	for k, v := range e.Details {
+		data[k] = v
+	}
+	data["reason"] = e.Reason
+	return data
+}
+
+// Unwrap returns the underlying error given by Wrap.
+func (e *APIError) Unwrap() error { return e.cause }
+
+// Is reports whether the target is an API error of the same code and reason, so that
+// the errors derived by WithDetail and Errorf still match the original one.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && t.Code == e.Code && t.Reason == e.Reason
+}
+
+// WithDetail returns a copy of the error carrying the detail in the error data.
+func (e *APIError) WithDetail(key string, value interface{}) *APIError {
+	details := make(map[string]interface{}, len(e.Details)+1)
+	for k, v := range e.Details {
+		details[k] = v
+	}
+	details[key] = value
+	return &APIError{Code: e.Code, Reason: e.Reason, Message: e.Message, Details: details, cause: e.cause}
+}
+
+// Errorf returns a copy of the error with the formatted message.
+func (e *APIError) Errorf(format string, args ...interface{}) *APIError {
+	return &APIError{Code: e.Code, Reason: e.Reason, Message: fmt.Sprintf(format, args...), Details: e.Details, cause: e.cause}
+}
+
+// Wrap returns a copy of the error with the message of the underlying error, which is
+// still matched by errors.Is and errors.As.
+func (e *APIError) Wrap(err error) *APIError {
+	return &APIError{Code: e.Code, Reason: e.Reason, Message: err.Error(), Details: e.Details, cause: err}
+}
+
+// AsAPIError returns the API error carried by err. It accepts both the API errors
+// returned by the methods of the server and the errors received by the client, whose
+// reason and details are restored from the error data.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	var rpcErr Error
+	if !errors.As(err, &rpcErr) {
+		return nil, false
+	}
+	apiErr = &APIError{Code: rpcErr.ErrorCode(), Message: rpcErr.Error()}
+	if de, ok := rpcErr.(DataError); ok {
+		if data, ok := de.ErrorData().(map[string]interface{}); ok {
+			for k, v := range data {
+				if k == "reason" {
+					apiErr.Reason, _ = v.(string)
+					continue
+				}
+				if apiErr.Details == nil {
+					apiErr.Details = make(map[string]interface{})
+				}
+				apiErr.Details[k] = v
+			}
+		}
+	}
+	return apiErr, true
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestNotFound = NewAPIError(CodeResourceNotFound, "itemNotFound", "the item does not exist")
+
+type apiErrorService struct{}
+
+func (s *apiErrorService) Item(id uint64) (string, error) {
+	return "", errTestNotFound.Errorf("the item does not exist (id: %d)", id).WithDetail("id", id)
+}
+
+func TestAPIError(t *testing.T) {
+	err := errTestNotFound.Errorf("the item does not exist (id: %d)", 1).WithDetail("id", 1)
+	assert.Equal(t, CodeResourceNotFound, err.ErrorCode())
+	assert.Equal(t, "the item does not exist (id: 1)", err.Error())
+	assert.Equal(t, map[string]interface{}{"reason": "itemNotFound", "id": 1}, err.ErrorData())
+
+	// the derived errors match the original one, but not the other reasons
+	assert.True(t, errors.Is(err, errTestNotFound))
+	assert.True(t, errors.Is(fmt.Errorf("wrapped: %w", err), errTestNotFound))
+	assert.False(t, errors.Is(err, NewAPIError(CodeResourceNotFound, "otherNotFound", "")))
+	assert.Nil(t, errTestNotFound.Details, "the original error should not be modified")
+
+	// the wrapped error is matched by the underlying one
+	cause := errors.New("nonce too low")
+	wrapped := NewAPIError(CodeTransactionRejected, "nonceTooLow", "").Wrap(cause)
+	assert.Equal(t, cause.Error(), wrapped.Error())
+	assert.True(t, errors.Is(wrapped, cause))
+}
+
+func TestAsAPIError(t *testing.T) {
+	server := NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("test", new(apiErrorService)))
+	client := DialInProc(server)
+	defer client.Close()
+
+	// the client restores the code, the reason and the details from the error data
+	var result string
+	err := client.Call(&result, "test_item", 1)
+	apiErr, ok := AsAPIError(err)
+	require.True(t, ok)
+	assert.Equal(t, CodeResourceNotFound, apiErr.Code)
+	assert.Equal(t, "itemNotFound", apiErr.Reason)
+	assert.Equal(t, "the item does not exist (id: 1)", apiErr.Message)
+	assert.Equal(t, float64(1), apiErr.Details["id"])
+	assert.True(t, errors.Is(apiErr, errTestNotFound))
+
+	// the errors without code are not API errors
+	_, ok = AsAPIError(errors.New("plain"))
+	assert.False(t, ok)
+}
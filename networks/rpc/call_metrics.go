@@ -0,0 +1,170 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+)
+
+// unknownMethod is the name the calls of the unregistered methods are collected under,
+// so that the metrics are not flooded by arbitrary method names.
+const unknownMethod = "unknown"
+
+var (
+	callMetricsMu    sync.Mutex
+	methodMetrics    = make(map[string]*callMetrics)
+	namespaceMetrics = make(map[string]*callMetrics)
+)
+
+// callMetrics is the metrics of the calls of a method or the methods of a namespace.
+type callMetrics struct {
+	prefix   string
+	requests metrics.Counter
+	inFlight metrics.Counter
+	latency  metrics.Timer
+	errors   map[int]metrics.Counter // by the error code, guarded by callMetricsMu
+}
+
+func newCallMetrics(prefix string) *callMetrics {
+	return &callMetrics{
+		prefix:   prefix,
+		requests: metrics.GetOrRegisterCounter(prefix+"/requests", nil),
+		inFlight: metrics.GetOrRegisterCounter(prefix+"/inflight", nil),
+		latency:  metrics.GetOrRegisterTimer(prefix+"/latency", nil),
+		errors:   make(map[int]metrics.Counter),
+	}
+}
+
+func getCallMetrics(registry map[string]*callMetrics, prefix, name string) *callMetrics {
+	callMetricsMu.Lock()
+	defer callMetricsMu.Unlock()
+
+	m, ok := registry[name]
+	if !ok {
+		m = newCallMetrics(prefix + name)
+		registry[name] = m
+	}
+	return m
+}
+
+func (m *callMetrics) markError(code int) {
+	callMetricsMu.Lock()
+	counter, ok := m.errors[code]
+	if !ok {
+		counter = metrics.GetOrRegisterCounter(fmt.Sprintf("%s/errors/%d", m.prefix, code), nil)
+		m.errors[code] = counter
+	}
+	callMetricsMu.Unlock()
+	counter.Inc(1)
+}
+
+// trackCall starts measuring a call of the method and returns the function which completes
+// the measurement with the response. The response is nil for notifications.
+func trackCall(method string) func(resp *jsonrpcMessage) {
+	namespace := method
+	if method != unknownMethod {
+		namespace = strings.SplitN(method, serviceMethodSeparator, 2)[0]
+	}
+	ms := [2]*callMetrics{
+		getCallMetrics(methodMetrics, "rpc/methods/", method),
+		getCallMetrics(namespaceMetrics, "rpc/namespaces/", namespace),
+	}
+	for _, m := range ms {
+		m.requests.Inc(1)
+		m.inFlight.Inc(1)
+	}
+	start := time.Now()
+	return func(resp *jsonrpcMessage) {
+		elapsed := time.Since(start)
+		for _, m := range ms {
+			m.inFlight.Dec(1)
+			m.latency.Update(elapsed)
+			if resp != nil && resp.Error != nil {
+				m.markError(resp.Error.Code)
+			}
+		}
+	}
+}
+
+// CallStats is a snapshot of the metrics of the calls of a method or a namespace.
+// The latencies are in milliseconds.
+type CallStats struct {
+	Requests  int64           `json:"requests"`
+	InFlight  int64           `json:"inFlight"`
+	Errors    map[int]int64   `json:"errors,omitempty"`
+	LatencyMs LatencySnapshot `json:"latencyMs"`
+}
+
+// LatencySnapshot is the distribution of the latencies of the recent calls.
+type LatencySnapshot struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// Stats is a snapshot of the metrics of the calls served by the RPC servers.
+type Stats struct {
+	Namespaces map[string]*CallStats `json:"namespaces"`
+	Methods    map[string]*CallStats `json:"methods"`
+}
+
+// GetStats returns a snapshot of the metrics of the calls served since the node started.
+func GetStats() *Stats {
+	callMetricsMu.Lock()
+	defer callMetricsMu.Unlock()
+
+	return &Stats{
+		Namespaces: snapshotCallMetrics(namespaceMetrics),
+		Methods:    snapshotCallMetrics(methodMetrics),
+	}
+}
+
+func snapshotCallMetrics(registry map[string]*callMetrics) map[string]*CallStats {
+	const ms = float64(time.Millisecond)
+
+	stats := make(map[string]*CallStats, len(registry))
+	for name, m := range registry {
+		latency := m.latency.Snapshot()
+		ps := latency.Percentiles([]float64{0.5, 0.95, 0.99})
+		s := &CallStats{
+			Requests: m.requests.Count(),
+			InFlight: m.inFlight.Count(),
+			LatencyMs: LatencySnapshot{
+				Mean: latency.Mean() / ms,
+				P50:  ps[0] / ms,
+				P95:  ps[1] / ms,
+				P99:  ps[2] / ms,
+				Max:  float64(latency.Max()) / ms,
+			},
+		}
+		if len(m.errors) > 0 {
+			s.Errors = make(map[int]int64, len(m.errors))
+			for code, counter := range m.errors {
+				s.Errors[code] = counter.Count()
+			}
+		}
+		stats[name] = s
+	}
+	return stats
+}
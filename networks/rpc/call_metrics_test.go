@@ -0,0 +1,56 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallMetrics(t *testing.T) {
+	server := newTestServer("metricstest", new(Service))
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, client.Call(nil, "metricstest_noArgsRets"))
+	}
+	assert.Error(t, client.Call(nil, "metricstest_echo", "wrong"))
+	assert.Error(t, client.Call(nil, "metricstest_notExisting"))
+
+	stats := GetStats()
+	require.Contains(t, stats.Methods, "metricstest_noArgsRets")
+	assert.Equal(t, int64(2), stats.Methods["metricstest_noArgsRets"].Requests)
+	assert.Equal(t, int64(0), stats.Methods["metricstest_noArgsRets"].InFlight)
+	assert.Empty(t, stats.Methods["metricstest_noArgsRets"].Errors)
+
+	require.Contains(t, stats.Methods, "metricstest_echo")
+	assert.Equal(t, map[int]int64{-32602: 1}, stats.Methods["metricstest_echo"].Errors)
+
+	// the unregistered methods are measured together
+	assert.NotContains(t, stats.Methods, "metricstest_notExisting")
+	require.Contains(t, stats.Methods, unknownMethod)
+	assert.GreaterOrEqual(t, stats.Methods[unknownMethod].Errors[-32601], int64(1))
+
+	require.Contains(t, stats.Namespaces, "metricstest")
+	assert.Equal(t, int64(3), stats.Namespaces["metricstest"].Requests)
+	assert.Equal(t, int64(1), stats.Namespaces["metricstest"].Errors[-32602])
+	assert.GreaterOrEqual(t, stats.Namespaces["metricstest"].LatencyMs.Max, stats.Namespaces["metricstest"].LatencyMs.P50)
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// ComputeBudgetExceededError is returned if an EVM execution served by a call exceeds the
+// compute budget of the listener which served the call.
+type ComputeBudgetExceededError struct {
+	Budget uint64
+}
+
+func (e *ComputeBudgetExceededError) Error() string {
+	return fmt.Sprintf("exceeded the compute budget (%d) of the RPC listener", e.Budget)
+}
+
+// ErrorCode returns the code of the limit exceeded error defined by EIP-1474.
+func (e *ComputeBudgetExceededError) ErrorCode() int { return CodeLimitExceeded }
+
+// ErrorData returns the reason and the exceeded budget in the form of the API errors.
+func (e *ComputeBudgetExceededError) ErrorData() interface{} {
+	return map[string]interface{}{"reason": "computeBudgetExceeded", "budget": e.Budget}
+}
+
+type computeBudgetKey struct{}
+
+// WithComputeBudget returns a copy of the context carrying the compute budget, which is
+// the limit of the sum of computation cost of opcodes of an EVM execution served by a call.
+func WithComputeBudget(ctx context.Context, budget uint64) context.Context {
+	return context.WithValue(ctx, computeBudgetKey{}, budget)
+}
+
+// ComputeBudget returns the compute budget of the call. Zero means no budget.
+func ComputeBudget(ctx context.Context) uint64 {
+	budget, _ := ctx.Value(computeBudgetKey{}).(uint64)
+	return budget
+}
+
+// SetComputeBudget sets the compute budget of the calls served by the server, limiting the
+// read-only EVM executions like klay_call and the traces. Zero means no budget.
+func (s *Server) SetComputeBudget(budget uint64) {
+	atomic.StoreUint64(&s.computeBudget, budget)
+}
+
+// callContext returns the context of the calls served by the server.
+func (s *Server) callContext(ctx context.Context) context.Context {
+	if budget := atomic.LoadUint64(&s.computeBudget); budget > 0 {
+		return WithComputeBudget(ctx, budget)
+	}
+	return ctx
+}
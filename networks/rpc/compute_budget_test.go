@@ -0,0 +1,57 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type computeBudgetService struct{}
+
+func (s *computeBudgetService) Budget(ctx context.Context) uint64 {
+	return ComputeBudget(ctx)
+}
+
+func TestServer_ComputeBudget(t *testing.T) {
+	server := NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("test", new(computeBudgetService)))
+
+	// no budget by default
+	client := DialInProc(server)
+	defer client.Close()
+	var budget uint64
+	require.NoError(t, client.Call(&budget, "test_budget"))
+	assert.Equal(t, uint64(0), budget)
+
+	// the budget is applied to the connections made after it is set
+	server.SetComputeBudget(1000)
+	budgeted := DialInProc(server)
+	defer budgeted.Close()
+	require.NoError(t, budgeted.Call(&budget, "test_budget"))
+	assert.Equal(t, uint64(1000), budget)
+}
+
+func TestComputeBudgetExceededError(t *testing.T) {
+	var err Error = &ComputeBudgetExceededError{Budget: 1000}
+	assert.Equal(t, -32005, err.ErrorCode())
+	assert.Contains(t, err.Error(), "1000")
+}
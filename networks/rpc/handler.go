@@ -227,6 +227,7 @@ func (h *handler) addSubscriptions(nn []*Notifier) {
 	for _, n := range nn {
 		if sub := n.takeSubscription(); sub != nil {
 			h.serverSubs[sub.ID] = sub
+			atomic.AddInt64(&activeSubscriptionCount, 1)
 		}
 	}
 }
@@ -240,6 +241,7 @@ func (h *handler) cancelServerSubscriptions(err error) {
 		s.err <- err
 		close(s.err)
 		delete(h.serverSubs, id)
+		atomic.AddInt64(&activeSubscriptionCount, -1)
 	}
 }
 
@@ -327,11 +329,15 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 	start := time.Now()
 	switch {
 	case msg.isNotification():
+		done := trackCall(h.metricsMethod(msg))
 		h.handleCall(ctx, msg)
+		done(nil)
 		logger.Trace("Served "+msg.Method, "duration", time.Since(start))
 		return nil
 	case msg.isCall():
+		done := trackCall(h.metricsMethod(msg))
 		resp := h.handleCall(ctx, msg)
+		done(resp)
 		var ctx []interface{}
 		ctx = append(ctx, "reqid", idForLog{msg.ID}, "duration", time.Since(start))
 		if resp.Error != nil {
@@ -353,6 +359,19 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 	}
 }
 
+// metricsMethod returns the method name the call is measured under. The calls of the
+// methods not served by the handler are measured together.
+func (h *handler) metricsMethod(msg *jsonrpcMessage) string {
+	if msg.isSubscribe() || msg.isUnsubscribe() {
+		if h.reg.hasService(msg.namespace()) {
+			return msg.Method
+		}
+	} else if h.reg.callback(msg.Method) != nil {
+		return msg.Method
+	}
+	return unknownMethod
+}
+
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
 	if msg.isSubscribe() {
@@ -489,6 +508,7 @@ func (h *handler) unsubscribe(ctx context.Context, id ID) (bool, error) {
 	}
 	close(s.err)
 	delete(h.serverSubs, id)
+	atomic.AddInt64(&activeSubscriptionCount, -1)
 	return true, nil
 }
 
@@ -266,10 +266,15 @@ func (t *httpReadWriteNopCloser) Close() error {
 //
 // Deprecated: Server implements http.Handler
 func NewHTTPServer(cors []string, vhosts []string, timeouts HTTPTimeouts, srv http.Handler) *http.Server {
-	timeouts = sanitizeTimeouts(timeouts)
 	// Wrap the CORS-handler within a host-handler
 	handler := newCorsHandler(srv, cors)
 	handler = newVHostHandler(vhosts, handler)
+	return newHTTPServer(handler, timeouts)
+}
+
+// newHTTPServer creates a new HTTP server around the handler with the timeouts and tracers.
+func newHTTPServer(handler http.Handler, timeouts HTTPTimeouts) *http.Server {
+	timeouts = sanitizeTimeouts(timeouts)
 	handler = http.TimeoutHandler(handler, timeouts.ExecutionTimeout, "timeout")
 
 	// If os environment variables for NewRelic exist, register the NewRelicHTTPHandler
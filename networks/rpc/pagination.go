@@ -0,0 +1,94 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// The methods returning large result sets take an optional PageArgs and return a Page.
+// A client requests the first page without a cursor, and the next pages with the cursor
+// of the previous page until HasMore is false. The cursor is opaque to the clients: it
+// is the RLP encoding of the position of the next item defined by each method.
+const (
+	DefaultPageLimit = 100  // the number of the items in a page if the limit is not given
+	MaxPageLimit     = 1000 // the maximum number of the items in a page
+)
+
+var (
+	errInvalidCursor    = NewAPIError(CodeInvalidParams, "invalidCursor", "invalid cursor")
+	errInvalidPageLimit = NewAPIError(CodeInvalidParams, "invalidPageLimit",
+		fmt.Sprintf("page limit should be equal or less than %d", MaxPageLimit)).WithDetail("maxLimit", MaxPageLimit)
+)
+
+// PageArgs is the arguments to request a page of a result set.
+type PageArgs struct {
+	Cursor string          `json:"cursor"` // cursor of the previous page, empty for the first page
+	Limit  *hexutil.Uint64 `json:"limit"`  // the maximum number of the items in the page
+}
+
+// PageLimit returns the maximum number of the items in the requested page.
+// args can be nil to request the first page of the default size.
+func (args *PageArgs) PageLimit() (int, error) {
+	if args == nil || args.Limit == nil || *args.Limit == 0 {
+		return DefaultPageLimit, nil
+	}
+	if *args.Limit > MaxPageLimit {
+		return 0, errInvalidPageLimit
+	}
+	return int(*args.Limit), nil
+}
+
+// DecodeCursor decodes the cursor into v. It returns false without touching v if the
+// first page is requested.
+func (args *PageArgs) DecodeCursor(v interface{}) (bool, error) {
+	if args == nil || args.Cursor == "" {
+		return false, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(args.Cursor)
+	if err != nil {
+		return false, errInvalidCursor
+	}
+	if err := rlp.DecodeBytes(data, v); err != nil {
+		return false, errInvalidCursor
+	}
+	return true, nil
+}
+
+// Page is a page of a result set.
+type Page struct {
+	Items   interface{} `json:"items"`
+	Cursor  string      `json:"cursor,omitempty"` // cursor to request the next page, empty if HasMore is false
+	HasMore bool        `json:"hasMore"`
+}
+
+// NewPage returns a page of the given items. next is the position of the first item of
+// the next page, which is encoded into the cursor, or nil if the page is the last one.
+func NewPage(items interface{}, next interface{}) (*Page, error) {
+	if next == nil {
+		return &Page{Items: items}, nil
+	}
+	data, err := rlp.EncodeToBytes(next)
+	if err != nil {
+		return nil, err
+	}
+	return &Page{Items: items, Cursor: base64.RawURLEncoding.EncodeToString(data), HasMore: true}, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageArgs(t *testing.T) {
+	var args *PageArgs
+	limit, err := args.PageLimit()
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPageLimit, limit)
+	ok, err := args.DecodeCursor(new(uint64))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"limit":"0x3e9"}`), &args))
+	_, err = args.PageLimit()
+	assert.Equal(t, errInvalidPageLimit, err)
+	*args.Limit = hexutil.Uint64(10)
+	limit, err = args.PageLimit()
+	assert.NoError(t, err)
+	assert.Equal(t, 10, limit)
+
+	type cursor struct {
+		Number uint64
+		Index  uint64
+	}
+	page, err := NewPage([]int{1, 2}, &cursor{Number: 5, Index: 1})
+	assert.NoError(t, err)
+	assert.True(t, page.HasMore)
+
+	args.Cursor = page.Cursor
+	decoded := new(cursor)
+	ok, err = args.DecodeCursor(decoded)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, &cursor{Number: 5, Index: 1}, decoded)
+
+	args.Cursor = "!"
+	_, err = args.DecodeCursor(decoded)
+	assert.Equal(t, errInvalidCursor, err)
+
+	page, err = NewPage([]int{}, nil)
+	assert.NoError(t, err)
+	data, _ := json.Marshal(page)
+	assert.JSONEq(t, `{"items":[],"hasMore":false}`, string(data))
+}
@@ -54,6 +54,9 @@ var (
 	// pendingRequestCount is a total number of concurrent RPC method calls
 	pendingRequestCount int64 = 0
 
+	// activeSubscriptionCount is a total number of subscriptions served by the servers
+	activeSubscriptionCount int64 = 0
+
 	// TODO-Klaytn: move websocket configurations to Config struct in /network/rpc/server.go
 	// MaxSubscriptionPerWSConn is a maximum number of subscription for a websocket connection
 	MaxSubscriptionPerWSConn int32 = 3000
@@ -75,6 +78,16 @@ var (
 	UpstreamArchiveEN string
 )
 
+// PendingRequests returns the total number of concurrent RPC method calls.
+func PendingRequests() int64 {
+	return atomic.LoadInt64(&pendingRequestCount)
+}
+
+// ActiveSubscriptions returns the total number of subscriptions served by the servers.
+func ActiveSubscriptions() int64 {
+	return atomic.LoadInt64(&activeSubscriptionCount)
+}
+
 // Server is an RPC server.
 type Server struct {
 	services    serviceRegistry
@@ -82,6 +95,8 @@ type Server struct {
 	codecs      mapset.Set
 	run         int32
 	wsConnCount int32
+
+	computeBudget uint64 // accessed atomically
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -145,7 +160,13 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
 
-	c := initClient(codec, s.idgen, &s.services)
+	// The remote address is given to the calls as HTTP does, so that the APIs can tell
+	// the clients apart, e.g. for limiting the filters of a client.
+	ctx := context.Background()
+	if remote := codec.remoteAddr(); remote != "" {
+		ctx = context.WithValue(ctx, "remote", remote)
+	}
+	c := initClient(s.callContext(ctx), codec, s.idgen, &s.services)
 	<-codec.closed()
 	c.Close()
 }
@@ -158,7 +179,7 @@ func (s *Server) ServeSingleRequest(ctx context.Context, codec ServerCodec) {
 	if atomic.LoadInt32(&s.run) == 0 {
 		return
 	}
-	h := newHandler(ctx, codec, s.idgen, &s.services)
+	h := newHandler(s.callContext(ctx), codec, s.idgen, &s.services)
 	h.allowSubscribe = false
 	defer h.close(io.EOF, nil)
 
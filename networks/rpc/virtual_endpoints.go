@@ -0,0 +1,299 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/time/rate"
+)
+
+var (
+	errNoVirtualEndpointMatcher = errors.New("virtual endpoint must have a path or hosts")
+	errInvalidVirtualPath       = errors.New("virtual endpoint path must start with '/'")
+	errInvalidRateLimit         = errors.New("virtual endpoint rate limit must not be negative")
+)
+
+// VirtualEndpoint is a tenant served on the same listener as the default HTTP or WebSocket
+// endpoint. A request is routed to the virtual endpoint if its URL path has the given path
+// prefix and its Host header matches one of the given hosts. A virtual endpoint has its own
+// API modules, CORS domains (the allowed origins for WebSocket), rate limit and compute budget.
+type VirtualEndpoint struct {
+	Name  string   `json:"name" toml:",omitempty"`
+	Path  string   `json:"path,omitempty" toml:",omitempty"`
+	Hosts []string `json:"hosts,omitempty" toml:",omitempty"`
+
+	// Modules is a list of API modules to expose. If the module list is empty,
+	// all RPC API endpoints designated public will be exposed.
+	Modules []string `json:"modules,omitempty" toml:",omitempty"`
+	Cors    []string `json:"cors,omitempty" toml:",omitempty"`
+
+	// RateLimit is the number of requests per second (WebSocket handshakes for WebSocket)
+	// allowed for the virtual endpoint. Zero means unlimited.
+	RateLimit float64 `json:"rateLimit,omitempty" toml:",omitempty"`
+	RateBurst int     `json:"rateBurst,omitempty" toml:",omitempty"`
+
+	// ComputeBudget is the compute budget of the calls served by the virtual endpoint.
+	// Zero means no budget.
+	ComputeBudget uint64 `json:"computeBudget,omitempty" toml:",omitempty"`
+}
+
+func (e *VirtualEndpoint) validate() error {
+	if e.Path == "" && len(e.Hosts) == 0 {
+		return errNoVirtualEndpointMatcher
+	}
+	if e.Path != "" && !strings.HasPrefix(e.Path, "/") {
+		return errInvalidVirtualPath
+	}
+	if e.RateLimit < 0 || e.RateBurst < 0 {
+		return errInvalidRateLimit
+	}
+	return nil
+}
+
+// match returns true if the request is routed to the virtual endpoint.
+func (e *VirtualEndpoint) match(r *http.Request) bool {
+	if e.Path != "" {
+		path := strings.TrimSuffix(e.Path, "/")
+		if r.URL.Path != path && !strings.HasPrefix(r.URL.Path, path+"/") {
+			return false
+		}
+	}
+	if len(e.Hosts) == 0 {
+		return true
+	}
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		// Either invalid (too many colons) or no port specified
+		host = r.Host
+	}
+	for _, h := range e.Hosts {
+		if strings.EqualFold(h, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadVirtualEndpoints reads the virtual endpoints from the JSON file, e.g.
+//
+//	[{"name": "public", "path": "/public", "modules": ["klay", "net"], "rateLimit": 100}]
+func LoadVirtualEndpoints(path string) ([]VirtualEndpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []VirtualEndpoint
+	if err := json.Unmarshal(data, &endpoints); err != nil {
+		return nil, fmt.Errorf("invalid virtual endpoint file %s: %v", path, err)
+	}
+	return endpoints, nil
+}
+
+type virtualEndpointHandler struct {
+	config  VirtualEndpoint
+	server  *Server
+	handler http.Handler
+}
+
+// VirtualEndpoints routes the requests to the virtual endpoints. The first virtual endpoint
+// matched in the configured order serves the request, and the requests not matched by any
+// virtual endpoint are served by the default endpoint.
+type VirtualEndpoints struct {
+	endpoints []*virtualEndpointHandler
+	fallback  http.Handler
+}
+
+// NewVirtualEndpoints creates the RPC servers of the virtual endpoints. For HTTP, a virtual
+// endpoint without hosts validates the Host header against vhosts like the default endpoint.
+func NewVirtualEndpoints(apis []API, configs []VirtualEndpoint, websocket bool, vhosts []string) (*VirtualEndpoints, error) {
+	v := &VirtualEndpoints{}
+	names := make(map[string]bool)
+	for _, config := range configs {
+		if err := config.validate(); err != nil {
+			v.Stop()
+			return nil, fmt.Errorf("%v (name: %s)", err, config.Name)
+		}
+		if names[config.Name] {
+			v.Stop()
+			return nil, fmt.Errorf("duplicated virtual endpoint name: %s", config.Name)
+		}
+		names[config.Name] = true
+
+		server, err := newServerWithModules(apis, config.Modules, false, "Virtual endpoint "+config.Name)
+		if err != nil {
+			v.Stop()
+			return nil, err
+		}
+		server.SetComputeBudget(config.ComputeBudget)
+		var handler http.Handler
+		if websocket {
+			handler = server.WebsocketHandler(config.Cors)
+		} else {
+			handler = newCorsHandler(server, config.Cors)
+			if len(config.Hosts) == 0 {
+				handler = newVHostHandler(vhosts, handler)
+			}
+		}
+		v.endpoints = append(v.endpoints, &virtualEndpointHandler{
+			config:  config,
+			server:  server,
+			handler: newRateLimitHandler(config.RateLimit, config.RateBurst, handler),
+		})
+	}
+	return v, nil
+}
+
+// Handler returns a handler which serves the unmatched requests with the given handler.
+func (v *VirtualEndpoints) Handler(fallback http.Handler) http.Handler {
+	v.fallback = fallback
+	return v
+}
+
+// ServeHTTP routes the request to the matched virtual endpoint, implements http.Handler
+func (v *VirtualEndpoints) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, e := range v.endpoints {
+		if e.config.match(r) {
+			e.handler.ServeHTTP(w, r)
+			return
+		}
+	}
+	if v.fallback == nil {
+		http.NotFound(w, r)
+		return
+	}
+	v.fallback.ServeHTTP(w, r)
+}
+
+// Names returns the names of the virtual endpoints.
+func (v *VirtualEndpoints) Names() []string {
+	names := make([]string, 0, len(v.endpoints))
+	for _, e := range v.endpoints {
+		names = append(names, e.config.Name)
+	}
+	return names
+}
+
+// Stop stops the RPC servers of the virtual endpoints.
+func (v *VirtualEndpoints) Stop() {
+	for _, e := range v.endpoints {
+		e.server.Stop()
+	}
+}
+
+// rateLimitHandler rejects the requests exceeding the rate limit with 429 Too Many Requests.
+type rateLimitHandler struct {
+	limiter *rate.Limiter
+	next    http.Handler
+}
+
+func (h *rateLimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.limiter.Allow() {
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+func newRateLimitHandler(limit float64, burst int, next http.Handler) http.Handler {
+	if limit == 0 {
+		return next
+	}
+	if burst == 0 {
+		// allow a second worth of requests at once by default
+		burst = int(limit)
+		if burst < 1 {
+			burst = 1
+		}
+	}
+	return &rateLimitHandler{rate.NewLimiter(rate.Limit(limit), burst), next}
+}
+
+// newServerWithModules creates a server which exposes the whitelisted modules.
+func newServerWithModules(apis []API, modules []string, exposeAll bool, kind string) (*Server, error) {
+	// Generate the whitelist based on the allowed modules
+	whitelist := make(map[string]bool)
+	for _, module := range modules {
+		whitelist[module] = true
+	}
+	// Register all the APIs exposed by the services
+	handler := NewServer()
+	for _, api := range apis {
+		if exposeAll || whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
+			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+				handler.Stop()
+				return nil, err
+			}
+			logger.Debug(kind+" registered", "namespace", api.Namespace)
+		}
+	}
+	return handler, nil
+}
+
+// StartVirtualHTTPEndpoint starts the HTTP RPC endpoint serving the virtual endpoints
+// in addition to the default endpoint configured with cors/vhosts/modules.
+func StartVirtualHTTPEndpoint(endpoint string, apis []API, modules []string, cors []string, vhosts []string, timeouts HTTPTimeouts, configs []VirtualEndpoint) (net.Listener, *Server, *VirtualEndpoints, error) {
+	handler, err := newServerWithModules(apis, modules, false, "HTTP")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	vendpoints, err := NewVirtualEndpoints(apis, configs, false, vhosts)
+	if err != nil {
+		handler.Stop()
+		return nil, nil, nil, err
+	}
+	// All APIs registered, start the HTTP listener
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		vendpoints.Stop()
+		handler.Stop()
+		return nil, nil, nil, err
+	}
+	fallback := newVHostHandler(vhosts, newCorsHandler(handler, cors))
+	go newHTTPServer(vendpoints.Handler(fallback), timeouts).Serve(listener)
+	return listener, handler, vendpoints, nil
+}
+
+// StartVirtualWSEndpoint starts the websocket endpoint serving the virtual endpoints
+// in addition to the default endpoint.
+func StartVirtualWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []string, exposeAll bool, configs []VirtualEndpoint) (net.Listener, *Server, *VirtualEndpoints, error) {
+	handler, err := newServerWithModules(apis, modules, exposeAll, "WebSocket")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	vendpoints, err := NewVirtualEndpoints(apis, configs, true, nil)
+	if err != nil {
+		handler.Stop()
+		return nil, nil, nil, err
+	}
+	// All APIs registered, start the HTTP listener
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		vendpoints.Stop()
+		handler.Stop()
+		return nil, nil, nil, err
+	}
+	server := &http.Server{Handler: vendpoints.Handler(handler.WebsocketHandler(wsOrigins))}
+	go server.Serve(listener)
+	return listener, handler, vendpoints, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadVirtualEndpoints(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "endpoints.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name": "public", "path": "/public", "modules": ["klay"], "rateLimit": 10.5}]`), 0o600))
+	endpoints, err := LoadVirtualEndpoints(path)
+	require.NoError(t, err)
+	assert.Equal(t, []VirtualEndpoint{{Name: "public", Path: "/public", Modules: []string{"klay"}, RateLimit: 10.5}}, endpoints)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"name": "public"}`), 0o600))
+	_, err = LoadVirtualEndpoints(path)
+	assert.Error(t, err)
+}
+
+func TestNewVirtualEndpoints_Invalid(t *testing.T) {
+	for _, configs := range [][]VirtualEndpoint{
+		{{Name: "a"}},
+		{{Name: "a", Path: "admin"}},
+		{{Name: "a", Path: "/admin", RateLimit: -1}},
+		{{Name: "a", Path: "/admin"}, {Name: "a", Path: "/public"}},
+	} {
+		_, err := NewVirtualEndpoints(nil, configs, false, nil)
+		assert.Error(t, err, configs)
+	}
+}
+
+func TestVirtualEndpoints_Routing(t *testing.T) {
+	apis := []API{
+		{Namespace: "admin", Service: new(Service)},
+		{Namespace: "klay", Service: new(Service), Public: true},
+	}
+	vendpoints, err := NewVirtualEndpoints(apis, []VirtualEndpoint{
+		{Name: "admin", Hosts: []string{"admin.example.com"}, Modules: []string{"admin", "klay"}},
+		{Name: "public", Path: "/public", Modules: []string{"klay"}, RateLimit: 1, RateBurst: 2},
+	}, false, []string{"localhost"})
+	require.NoError(t, err)
+	defer vendpoints.Stop()
+	assert.Equal(t, []string{"admin", "public"}, vendpoints.Names())
+
+	fallback := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := vendpoints.Handler(fallback)
+
+	modules := func(host, path string) (int, map[string]string) {
+		body := `{"jsonrpc":"2.0","id":1,"method":"rpc_modules"}`
+		req := httptest.NewRequest(http.MethodPost, "http://"+host+path, strings.NewReader(body))
+		req.Header.Set("content-type", contentType)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			return rec.Code, nil
+		}
+		var resp struct {
+			Result map[string]string `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return rec.Code, resp.Result
+	}
+
+	// the admin surface is routed by the host
+	code, result := modules("admin.example.com:8551", "/")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, result, "admin")
+	assert.Contains(t, result, "klay")
+
+	// the public surface is routed by the path and does not expose the admin module
+	code, result = modules("localhost", "/public")
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, result, "admin")
+	assert.Contains(t, result, "klay")
+
+	// the virtual hosts of the default endpoint are enforced for a path-only virtual endpoint
+	code, _ = modules("evil.example.com", "/public/")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	// the burst is exhausted
+	code, _ = modules("localhost", "/public")
+	assert.Equal(t, http.StatusTooManyRequests, code)
+
+	// unmatched requests are served by the default endpoint
+	code, _ = modules("localhost", "/publicity")
+	assert.Equal(t, http.StatusTeapot, code)
+}
@@ -57,7 +57,9 @@ func newWebsocketCodec(conn *websocket.Conn) ServerCodec {
 	if WebsocketWriteDeadline != 0 {
 		conn.SetWriteDeadline(time.Now().Add(time.Duration(WebsocketWriteDeadline) * time.Second))
 	}
-	return NewFuncCodec(conn, conn.WriteJSON, conn.ReadJSON)
+	codec := NewFuncCodec(conn, conn.WriteJSON, conn.ReadJSON)
+	codec.(*jsonCodec).remote = conn.RemoteAddr().String()
+	return codec
 }
 
 // WebsocketHandler returns a handler that serves JSON-RPC to WebSocket connections.
@@ -28,6 +28,7 @@ import (
 
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/crypto"
+	metricutils "github.com/klaytn/klaytn/metrics/utils"
 	"github.com/klaytn/klaytn/networks/p2p"
 	"github.com/klaytn/klaytn/networks/p2p/discover"
 	"github.com/klaytn/klaytn/networks/rpc"
@@ -89,6 +90,46 @@ func (api *PrivateAdminAPI) RemovePeer(url string) (bool, error) {
 	return true, nil
 }
 
+// SetPeerDiversityEnabled turns the peer diversity policy of the node on or off.
+func (api *PrivateAdminAPI) SetPeerDiversityEnabled(enabled bool) (bool, error) {
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	if err := server.SetPeerDiversityEnabled(enabled); err != nil {
+		return false, err
+	}
+	logger.Info("Set peer diversity policy", "enabled", enabled)
+	return true, nil
+}
+
+// AddPeerDiversityExemption lets the given node connect regardless of the peer
+// diversity policy. The node can be given by its ID or kni URL.
+func (api *PrivateAdminAPI) AddPeerDiversityExemption(url string) (bool, error) {
+	return api.setPeerDiversityExempt(url, true)
+}
+
+// RemovePeerDiversityExemption removes the exemption of the given node from
+// the peer diversity policy.
+func (api *PrivateAdminAPI) RemovePeerDiversityExemption(url string) (bool, error) {
+	return api.setPeerDiversityExempt(url, false)
+}
+
+func (api *PrivateAdminAPI) setPeerDiversityExempt(url string, exempt bool) (bool, error) {
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	node, err := discover.ParseNode(url)
+	if err != nil {
+		return false, fmt.Errorf("invalid kni: %v", err)
+	}
+	if err := server.SetPeerDiversityExempt(node.ID, exempt); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // PeerEvents creates an RPC subscription which receives peer events from the
 // node's p2p.Server
 func (api *PrivateAdminAPI) PeerEvents(ctx context.Context) (*rpc.Subscription, error) {
@@ -301,6 +342,16 @@ func (api *PublicAdminAPI) NodeInfo() (*p2p.NodeInfo, error) {
 	return server.NodeInfo(), nil
 }
 
+// PeerDiversity retrieves the state of the peer diversity policy and how the
+// connected peers are distributed over subnets, ASes and regions.
+func (api *PublicAdminAPI) PeerDiversity() (*p2p.DiversityInfo, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	return server.PeerDiversity(), nil
+}
+
 // Datadir retrieves the current data directory the node is using.
 func (api *PublicAdminAPI) Datadir() string {
 	return api.node.DataDir()
@@ -318,6 +369,18 @@ func NewPublicDebugAPI(node *Node) *PublicDebugAPI {
 	return &PublicDebugAPI{node: node}
 }
 
+// RpcStats returns the request counts, the error counts by the error code, the in-flight
+// requests and the latency distribution of the RPC calls by namespace and by method.
+func (api *PublicDebugAPI) RpcStats() *rpc.Stats {
+	return rpc.GetStats()
+}
+
+// PersistentCounters returns the lifetime values, which survive restarts, and the values
+// since the node started of the persistent counter metrics.
+func (api *PublicDebugAPI) PersistentCounters() map[string]metricutils.PersistentCounterValues {
+	return metricutils.GetPersistentCounters()
+}
+
 // Metrics retrieves all the known system metric collected by the node.
 func (api *PublicDebugAPI) Metrics(raw bool) (map[string]interface{}, error) {
 	// Create a rate formatter
@@ -184,6 +184,7 @@ func runTestWithServerType(t *testing.T, test test, httpServerType string) {
 	// Setting test node config
 	config := test.cfg
 	config.P2P.NoDiscovery = true
+	config.DataDir = t.TempDir()
 
 	// Create Node.
 	stack, err := New(&config)
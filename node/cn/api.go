@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -32,11 +33,13 @@ import (
 	"time"
 
 	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/replay"
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/contractmeta"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
 	"github.com/klaytn/klaytn/storage/statedb"
@@ -271,6 +274,21 @@ func (api *PrivateAdminAPI) GetSpamThrottlerCandidateList(ctx context.Context) (
 	return throttler.GetCandidates(), nil
 }
 
+// RegisterContractABI stores the ABI of the contract used by klay_getDecodedLogs.
+// The ABI is given as a JSON array or a string of the JSON array.
+func (api *PrivateAdminAPI) RegisterContractABI(addr common.Address, contractABI json.RawMessage) (bool, error) {
+	meta := &contractmeta.Metadata{Address: addr, ABI: contractABI}
+	if err := api.cn.contractMetadata.Register(meta, contractmeta.OriginRPC); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveContractABI removes the registered ABI of the contract.
+func (api *PrivateAdminAPI) RemoveContractABI(addr common.Address) bool {
+	return api.cn.contractMetadata.Remove(addr)
+}
+
 // PublicDebugAPI is the collection of Klaytn full node APIs exposed
 // over the public debugging endpoint.
 type PublicDebugAPI struct {
@@ -411,6 +429,31 @@ func (api *PublicDebugAPI) GetBadBlocks(ctx context.Context) ([]blockchain.BadBl
 	return api.cn.BlockChain().BadBlocks()
 }
 
+// ExportReplayBundle captures the execution inputs of the block, which is either in the
+// chain or a bad block, into a replay bundle file which can be executed by `replay`.
+func (api *PrivateDebugAPI) ExportReplayBundle(hash common.Hash, file string) (bool, error) {
+	if _, err := os.Stat(file); err == nil {
+		// File already exists. Allowing overwrite could be a DoS vecotor,
+		// since the 'file' may point to arbitrary paths on the drive
+		return false, errors.New("location would overwrite an existing file")
+	}
+	block := api.cn.blockchain.GetBlockByHash(hash)
+	if block == nil {
+		block = api.cn.ChainDB().ReadBadBlock(hash)
+	}
+	if block == nil {
+		return false, fmt.Errorf("block %#x not found", hash)
+	}
+	bundle, err := replay.Capture(api.cn.blockchain, api.cn.governance, block)
+	if err != nil {
+		return false, err
+	}
+	if err := bundle.WriteFile(file); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // StorageRangeResult is the result of a debug_storageRangeAt API call.
 type StorageRangeResult struct {
 	Storage storageMap   `json:"storage"`
@@ -0,0 +1,304 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/reward"
+)
+
+// divergenceReexec is the number of blocks re-executed to regenerate the parent state
+// of a diverged block if it has been pruned.
+const divergenceReexec = uint64(128)
+
+// The operations of a block whose state root is compared with the peer.
+const (
+	DivergenceOpTransaction = "transaction"
+	DivergenceOpFinalize    = "finalize"
+)
+
+var errNoDivergence = errors.New("no state root divergence from the peer")
+
+// DivergenceResult is the result of a debug_findDivergence API call. It describes the first block
+// whose state root differs from the peer and the first operation of the block diverging in it.
+type DivergenceResult struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	LocalHash   common.Hash    `json:"localHash"`
+	PeerHash    common.Hash    `json:"peerHash"`
+	LocalRoot   common.Hash    `json:"localRoot"`
+	PeerRoot    common.Hash    `json:"peerRoot"`
+
+	// Operation is either DivergenceOpTransaction or DivergenceOpFinalize.
+	Operation    string          `json:"operation"`
+	TxIndex      *hexutil.Uint64 `json:"txIndex,omitempty"`
+	TxHash       *common.Hash    `json:"txHash,omitempty"`
+	LocalOpRoot  common.Hash     `json:"localOperationRoot"`
+	PeerOpRoot   common.Hash     `json:"peerOperationRoot"`
+	BalanceDiffs []BalanceDiff   `json:"balanceDiffs,omitempty"`
+}
+
+// BalanceDiff is an account whose balance after the diverged block differs from the peer.
+type BalanceDiff struct {
+	Address common.Address `json:"address"`
+	Local   *hexutil.Big   `json:"local"`
+	Peer    *hexutil.Big   `json:"peer"`
+}
+
+// peerBlockHeader is the part of klay_getBlockByNumber used to compare a block with the peer.
+type peerBlockHeader struct {
+	Hash common.Hash `json:"hash"`
+	Root common.Hash `json:"stateRoot"`
+}
+
+// IntermediateRoots re-executes the block, which is either in the chain or a bad block, and returns
+// the state root after each transaction followed by the one after the block is finalized.
+func (api *PrivateDebugAPI) IntermediateRoots(blockHash common.Hash) ([]common.Hash, error) {
+	block := api.cn.blockchain.GetBlockByHash(blockHash)
+	if block == nil {
+		block = api.cn.ChainDB().ReadBadBlock(blockHash)
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %#x not found", blockHash)
+	}
+	roots, _, err := api.intermediateRoots(block)
+	return roots, err
+}
+
+// intermediateRoots is IntermediateRoots also returning the state after the block is finalized.
+func (api *PrivateDebugAPI) intermediateRoots(block *types.Block) ([]common.Hash, *state.StateDB, error) {
+	if block.NumberU64() == 0 {
+		return nil, nil, errors.New("genesis is not executed")
+	}
+	bc := api.cn.blockchain
+	parent := bc.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, err := api.cn.stateAtBlock(parent, divergenceReexec, nil, true, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Mirrors StateProcessor.Process, taking the root after each step.
+	var (
+		header   = block.Header()
+		usedGas  = new(uint64)
+		receipts types.Receipts
+		roots    []common.Hash
+		vmConfig = &vm.Config{UseOpcodeComputationCost: true}
+	)
+	author, _ := bc.Engine().Author(header)
+	for i, tx := range block.Transactions() {
+		statedb.SetTxContext(tx.Hash(), block.Hash(), i)
+		receipt, _, err := blockchain.ApplyTransaction(bc.Config(), bc, &author, statedb, header, tx, usedGas, vmConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("transaction %#x failed: %v", tx.Hash(), err)
+		}
+		receipts = append(receipts, receipt)
+		roots = append(roots, statedb.IntermediateRoot(true))
+	}
+	if _, err := bc.Engine().Finalize(bc, header, statedb, block.Transactions(), receipts); err != nil {
+		return nil, nil, err
+	}
+	return append(roots, statedb.IntermediateRoot(true)), statedb, nil
+}
+
+// FindDivergence locates the first block whose state root differs from the peer of the given RPC endpoint
+// by bisecting the chain, and then the first transaction or the block finalization, distributing the block reward,
+// whose state root differs by comparing the intermediate roots of the block re-executed by both nodes.
+// The peer is required to serve debug_intermediateRoots.
+func (api *PrivateDebugAPI) FindDivergence(ctx context.Context, peerRPC string) (*DivergenceResult, error) {
+	c, err := rpc.DialContext(ctx, peerRPC)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	block, peer, err := api.findDivergedBlock(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	result := &DivergenceResult{
+		BlockNumber: hexutil.Uint64(block.NumberU64()),
+		LocalHash:   block.Hash(),
+		PeerHash:    peer.Hash,
+		LocalRoot:   block.Root(),
+		PeerRoot:    peer.Root,
+	}
+
+	localRoots, statedb, err := api.intermediateRoots(block)
+	if err != nil {
+		return nil, err
+	}
+	var peerRoots []common.Hash
+	if err := c.CallContext(ctx, &peerRoots, "debug_intermediateRoots", peer.Hash); err != nil {
+		return nil, fmt.Errorf("failed to get the intermediate roots of block %d from the peer: %v", block.NumberU64(), err)
+	}
+	if len(peerRoots) != len(localRoots) {
+		return nil, fmt.Errorf("block %d of the peer has %d transactions, not %d", block.NumberU64(), len(peerRoots)-1, len(localRoots)-1)
+	}
+
+	// Once diverged, the roots of all the following operations differ.
+	step := sort.Search(len(localRoots), func(i int) bool { return localRoots[i] != peerRoots[i] })
+	if step == len(localRoots) {
+		// Both nodes agree on the re-executed block, i.e. the stored state of a node is corrupted.
+		step--
+	}
+	result.LocalOpRoot, result.PeerOpRoot = localRoots[step], peerRoots[step]
+	if txs := block.Transactions(); step < len(txs) {
+		index, hash := hexutil.Uint64(step), txs[step].Hash()
+		result.Operation, result.TxIndex, result.TxHash = DivergenceOpTransaction, &index, &hash
+		return result, nil
+	}
+
+	result.Operation = DivergenceOpFinalize
+	for _, addr := range api.rewardRecipients(block.Header()) {
+		var peerBalance hexutil.Big
+		if err := c.CallContext(ctx, &peerBalance, "klay_getBalance", addr, hexutil.EncodeUint64(block.NumberU64())); err != nil {
+			return nil, fmt.Errorf("failed to get the balance of %x from the peer: %v", addr, err)
+		}
+		if local := statedb.GetBalance(addr); local.Cmp(peerBalance.ToInt()) != 0 {
+			result.BalanceDiffs = append(result.BalanceDiffs, BalanceDiff{Address: addr, Local: (*hexutil.Big)(local), Peer: &peerBalance})
+		}
+	}
+	return result, nil
+}
+
+// findDivergedBlock returns the first local block whose state root differs from the peer, along with
+// the peer's block of the same number. The local block may be a bad block following the current block.
+func (api *PrivateDebugAPI) findDivergedBlock(ctx context.Context, c *rpc.Client) (*types.Block, *peerBlockHeader, error) {
+	bc := api.cn.blockchain
+
+	var peerHead hexutil.Uint64
+	if err := c.CallContext(ctx, &peerHead, "klay_blockNumber"); err != nil {
+		return nil, nil, err
+	}
+	peerGenesis, err := peerHeaderAt(ctx, c, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if genesis := bc.GetHeaderByNumber(0); genesis.Hash() != peerGenesis.Hash {
+		return nil, nil, fmt.Errorf("the peer has a different genesis %x", peerGenesis.Hash)
+	}
+
+	// Bisect the common blocks, keeping lo agreed and hi diverged.
+	lo, hi := uint64(0), bc.CurrentBlock().NumberU64()
+	if uint64(peerHead) < hi {
+		hi = uint64(peerHead)
+	}
+	peer, err := peerHeaderAt(ctx, c, hi)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bc.GetHeaderByNumber(hi).Root == peer.Root {
+		return api.findDivergedBadBlock(ctx, c, hi, uint64(peerHead))
+	}
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		midPeer, err := peerHeaderAt(ctx, c, mid)
+		if err != nil {
+			return nil, nil, err
+		}
+		if bc.GetHeaderByNumber(mid).Root == midPeer.Root {
+			lo = mid
+		} else {
+			hi, peer = mid, midPeer
+		}
+	}
+	return bc.GetBlockByNumber(hi), peer, nil
+}
+
+// findDivergedBadBlock returns the bad block following the given agreed head, e.g. the one which stopped
+// the chain by a state root mismatch, if the state root differs from the peer's block of the same number.
+func (api *PrivateDebugAPI) findDivergedBadBlock(ctx context.Context, c *rpc.Client, head, peerHead uint64) (*types.Block, *peerBlockHeader, error) {
+	local := api.cn.blockchain.GetHeaderByNumber(head)
+	if head >= peerHead || local.Hash() != api.cn.blockchain.CurrentBlock().Hash() {
+		return nil, nil, errNoDivergence
+	}
+	badBlocks, err := api.cn.blockchain.BadBlocks()
+	if err != nil {
+		return nil, nil, err
+	}
+	peer, err := peerHeaderAt(ctx, c, head+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, bad := range badBlocks {
+		if bad.Block.ParentHash() == local.Hash() && bad.Block.Root() != peer.Root {
+			return bad.Block, peer, nil
+		}
+	}
+	return nil, nil, errNoDivergence
+}
+
+// rewardRecipients returns the accounts credited when the block is finalized, in the order of the addresses.
+// They are the proposer and the rewardbase, with the recipients of the reward spec if it can be calculated.
+func (api *PrivateDebugAPI) rewardRecipients(header *types.Header) []common.Address {
+	recipients := map[common.Address]struct{}{header.Rewardbase: {}}
+	if author, err := api.cn.blockchain.Engine().Author(header); err == nil {
+		recipients[author] = struct{}{}
+	}
+	if api.cn.governance != nil {
+		spec, err := api.blockRewardSpec(header)
+		if err != nil {
+			logger.Debug("Failed to calculate the reward spec of the diverged block", "blockNum", header.Number, "err", err)
+		} else {
+			for addr := range spec.Rewards {
+				recipients[addr] = struct{}{}
+			}
+		}
+	}
+
+	addrs := make([]common.Address, 0, len(recipients))
+	for addr := range recipients {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+	return addrs
+}
+
+func (api *PrivateDebugAPI) blockRewardSpec(header *types.Header) (*reward.RewardSpec, error) {
+	config := api.cn.blockchain.Config()
+	pset, err := rewardParams(config, api.cn.governance, header.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	return reward.GetBlockReward(header, config.Rules(header.Number), pset)
+}
+
+func peerHeaderAt(ctx context.Context, c *rpc.Client, num uint64) (*peerBlockHeader, error) {
+	var header *peerBlockHeader
+	if err := c.CallContext(ctx, &header, "klay_getBlockByNumber", hexutil.EncodeUint64(num), false); err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %d not found in the peer", num)
+	}
+	return header, nil
+}
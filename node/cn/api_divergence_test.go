@@ -0,0 +1,193 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDivergencePeer serves the APIs used by debug_findDivergence from the local chain,
+// except that the blocks from the diverged one have the roots diverged from the given step.
+type testDivergencePeer struct {
+	api      *PrivateDebugAPI
+	diverged uint64
+	step     int
+}
+
+func divergedHash(h common.Hash) common.Hash {
+	return crypto.Keccak256Hash(h[:])
+}
+
+func (p *testDivergencePeer) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(p.api.cn.blockchain.CurrentBlock().NumberU64())
+}
+
+func (p *testDivergencePeer) GetBlockByNumber(num hexutil.Uint64, fullTx bool) map[string]interface{} {
+	header := p.api.cn.blockchain.GetHeaderByNumber(uint64(num))
+	if header == nil {
+		return nil
+	}
+	hash, root := header.Hash(), header.Root
+	if uint64(num) >= p.diverged {
+		hash, root = divergedHash(hash), divergedHash(root)
+	}
+	return map[string]interface{}{"hash": hash, "stateRoot": root}
+}
+
+func (p *testDivergencePeer) GetBalance(addr common.Address, num hexutil.Uint64) (*hexutil.Big, error) {
+	header := p.api.cn.blockchain.GetHeaderByNumber(uint64(num))
+	st, err := p.api.cn.blockchain.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	balance := new(big.Int).Set(st.GetBalance(addr))
+	if uint64(num) >= p.diverged && addr == header.Rewardbase {
+		balance.Add(balance, common.Big1)
+	}
+	return (*hexutil.Big)(balance), nil
+}
+
+func (p *testDivergencePeer) IntermediateRoots(hash common.Hash) ([]common.Hash, error) {
+	block := p.api.cn.blockchain.GetBlockByNumber(p.diverged)
+	if hash != divergedHash(block.Hash()) {
+		return nil, fmt.Errorf("block %#x not found", hash)
+	}
+	roots, err := p.api.IntermediateRoots(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	for i := p.step; i < len(roots); i++ {
+		roots[i] = divergedHash(roots[i])
+	}
+	return roots, nil
+}
+
+func newDivergenceTestAPI(t *testing.T, n int) *PrivateDebugAPI {
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x1234")
+
+	var (
+		config  = params.TestChainConfig
+		engine  = gxhash.NewFaker()
+		db      = database.NewMemoryDBManager()
+		gendb   = database.NewMemoryDBManager()
+		gspec   = &blockchain.Genesis{Config: config, Alloc: blockchain.GenesisAlloc{from: {Balance: big.NewInt(params.KLAY)}}}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSignerForChainID(config.ChainID)
+	)
+	blocks, _ := blockchain.GenerateChain(config, genesis, engine, gendb, n, func(i int, b *blockchain.BlockGen) {
+		for j := 0; j < 2; j++ {
+			tx, err := types.SignTx(types.NewTransaction(uint64(2*i+j), to, big.NewInt(1000), params.TxGas, big.NewInt(0), nil), signer, key)
+			require.NoError(t, err)
+			b.AddTx(tx)
+		}
+	})
+	gspec.MustCommit(db)
+	cacheConfig := &blockchain.CacheConfig{
+		CacheSize:           512,
+		BlockInterval:       blockchain.DefaultBlockInterval,
+		TriesInMemory:       blockchain.DefaultTriesInMemory,
+		TrieNodeCacheConfig: statedb.GetEmptyTrieNodeCacheConfig(),
+		SnapshotCacheSize:   512,
+		ArchiveMode:         true,
+	}
+	chain, err := blockchain.NewBlockChain(db, cacheConfig, config, engine, vm.Config{})
+	require.NoError(t, err)
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	t.Cleanup(chain.Stop)
+
+	return NewPrivateDebugAPI(config, &CN{blockchain: chain, chainDB: db})
+}
+
+func serveDivergencePeer(t *testing.T, peer *testDivergencePeer) string {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("klay", peer))
+	require.NoError(t, server.RegisterName("debug", peer))
+	httpsrv := httptest.NewServer(server)
+	t.Cleanup(httpsrv.Close)
+	return httpsrv.URL
+}
+
+func TestPrivateDebugAPI_IntermediateRoots(t *testing.T) {
+	api := newDivergenceTestAPI(t, 3)
+
+	block := api.cn.blockchain.GetBlockByNumber(2)
+	roots, err := api.IntermediateRoots(block.Hash())
+	require.NoError(t, err)
+	require.Len(t, roots, len(block.Transactions())+1)
+	assert.NotEqual(t, roots[0], roots[1])
+	assert.Equal(t, block.Root(), roots[len(roots)-1])
+
+	_, err = api.IntermediateRoots(common.HexToHash("0x1"))
+	assert.Error(t, err)
+}
+
+func TestPrivateDebugAPI_FindDivergence(t *testing.T) {
+	api := newDivergenceTestAPI(t, 5)
+
+	// The second transaction of block 3 diverged.
+	peer := &testDivergencePeer{api: api, diverged: 3, step: 1}
+	result, err := api.FindDivergence(context.Background(), serveDivergencePeer(t, peer))
+	require.NoError(t, err)
+	block := api.cn.blockchain.GetBlockByNumber(3)
+	assert.Equal(t, hexutil.Uint64(3), result.BlockNumber)
+	assert.Equal(t, block.Hash(), result.LocalHash)
+	assert.Equal(t, divergedHash(block.Hash()), result.PeerHash)
+	assert.Equal(t, DivergenceOpTransaction, result.Operation)
+	require.NotNil(t, result.TxIndex)
+	assert.Equal(t, hexutil.Uint64(1), *result.TxIndex)
+	assert.Equal(t, block.Transactions()[1].Hash(), *result.TxHash)
+	assert.Equal(t, divergedHash(result.LocalOpRoot), result.PeerOpRoot)
+	assert.Empty(t, result.BalanceDiffs)
+
+	// The block reward of block 1 diverged, crediting the rewardbase one more peb.
+	peer = &testDivergencePeer{api: api, diverged: 1, step: 2}
+	result, err = api.FindDivergence(context.Background(), serveDivergencePeer(t, peer))
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Uint64(1), result.BlockNumber)
+	assert.Equal(t, DivergenceOpFinalize, result.Operation)
+	assert.Nil(t, result.TxIndex)
+	require.Len(t, result.BalanceDiffs, 1)
+	diff := result.BalanceDiffs[0]
+	assert.Equal(t, api.cn.blockchain.GetHeaderByNumber(1).Rewardbase, diff.Address)
+	assert.Equal(t, new(big.Int).Add(diff.Local.ToInt(), common.Big1), diff.Peer.ToInt())
+
+	// No block diverged.
+	peer = &testDivergencePeer{api: api, diverged: 6}
+	_, err = api.FindDivergence(context.Background(), serveDivergencePeer(t, peer))
+	assert.Equal(t, errNoDivergence, err)
+}
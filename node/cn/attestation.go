@@ -0,0 +1,182 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+var (
+	errNoAttestation          = errors.New("no attestation")
+	errAttestationSigner      = errors.New("attestation is not signed by the node")
+	errAttestationChainID     = errors.New("attestation chain id mismatch")
+	errAttestationGenesis     = errors.New("attestation genesis hash mismatch")
+	errAttestationForks       = errors.New("attestation hardfork schedule mismatch")
+	errAttestationNotApproved = errors.New("attested version is not approved")
+)
+
+// AttestedFork is the activation block of a hardfork attested by a node.
+type AttestedFork struct {
+	Name  string `json:"name"`
+	Block uint64 `json:"block"`
+}
+
+// Attestation is a statement of the build a node runs, signed by its nodekey. It is sent
+// in the status message of the handshake, so that the operators of a permissioned network
+// can reject the peers not running an approved build.
+type Attestation struct {
+	ChainID     *big.Int       `json:"chainId"`
+	GenesisHash common.Hash    `json:"genesisHash"`
+	Version     string         `json:"version"`
+	Forks       []AttestedFork `json:"forks"` // the hardforks scheduled in the chain config
+	Timestamp   uint64         `json:"timestamp"`
+	Signature   hexutil.Bytes  `json:"signature"`
+}
+
+// AttestationPolicy is how a node attests itself and verifies the attestations of its peers.
+type AttestationPolicy struct {
+	Enabled          bool     // sends the attestation of the node at the handshake
+	Required         bool     // rejects the peers without a valid attestation
+	ApprovedVersions []string // the versions accepted from the peers, any version if empty
+}
+
+// attestedForks returns the hardforks scheduled in the chain config.
+func attestedForks(config *params.ChainConfig) []AttestedFork {
+	forks := []AttestedFork{}
+	for _, fork := range governance.HardforkSchedule(config) {
+		forks = append(forks, AttestedFork{Name: fork.Name, Block: fork.Block.Uint64()})
+	}
+	return forks
+}
+
+// NewAttestation returns the attestation of the node signed by the nodekey.
+func NewAttestation(key *ecdsa.PrivateKey, config *params.ChainConfig, genesis common.Hash, version string) (*Attestation, error) {
+	a := &Attestation{
+		ChainID:     new(big.Int).Set(config.ChainID),
+		GenesisHash: genesis,
+		Version:     version,
+		Forks:       attestedForks(config),
+		Timestamp:   uint64(time.Now().Unix()),
+	}
+	sig, err := crypto.Sign(a.sigHash().Bytes(), key)
+	if err != nil {
+		return nil, err
+	}
+	a.Signature = sig
+	return a, nil
+}
+
+// sigHash returns the hash of the attestation signed, which excludes the signature.
+func (a *Attestation) sigHash() common.Hash {
+	b, _ := rlp.EncodeToBytes([]interface{}{a.ChainID, a.GenesisHash, a.Version, a.Forks, a.Timestamp})
+	return crypto.Keccak256Hash(b)
+}
+
+// Signer returns the id of the node which signed the attestation.
+func (a *Attestation) Signer() (discover.NodeID, error) {
+	pub, err := crypto.SigToPub(a.sigHash().Bytes(), a.Signature)
+	if err != nil {
+		return discover.NodeID{}, err
+	}
+	return discover.PubkeyID(pub), nil
+}
+
+// Verify checks that the attestation is signed by the node of the given id and that it
+// attests the same chain and hardfork schedule as this node runs with an approved version.
+func (a *Attestation) Verify(id discover.NodeID, config *params.ChainConfig, genesis common.Hash, approvedVersions []string) error {
+	if a == nil {
+		return errNoAttestation
+	}
+	if signer, err := a.Signer(); err != nil || signer != id {
+		return errAttestationSigner
+	}
+	if a.ChainID == nil || a.ChainID.Cmp(config.ChainID) != 0 {
+		return fmt.Errorf("%w: %v (!= %v)", errAttestationChainID, a.ChainID, config.ChainID)
+	}
+	if a.GenesisHash != genesis {
+		return fmt.Errorf("%w: %x (!= %x)", errAttestationGenesis, a.GenesisHash[:8], genesis[:8])
+	}
+	forks := attestedForks(config)
+	if len(a.Forks) != len(forks) {
+		return fmt.Errorf("%w: %v (!= %v)", errAttestationForks, a.Forks, forks)
+	}
+	for i := range forks {
+		if a.Forks[i] != forks[i] {
+			return fmt.Errorf("%w: %v (!= %v)", errAttestationForks, a.Forks, forks)
+		}
+	}
+	if len(approvedVersions) == 0 {
+		return nil
+	}
+	for _, version := range approvedVersions {
+		if a.Version == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", errAttestationNotApproved, a.Version)
+}
+
+// verifyAttestation checks the attestation sent by the peer at the handshake. A peer
+// without an attestation is accepted unless the attestation is required.
+func (pm *ProtocolManager) verifyAttestation(p Peer) error {
+	a := p.GetAttestation()
+	if a == nil && !pm.attestationPolicy.Required {
+		return nil
+	}
+	err := a.Verify(p.GetP2PPeerID(), pm.blockchain.Config(), pm.blockchain.Genesis().Hash(), pm.attestationPolicy.ApprovedVersions)
+	if err != nil {
+		attestationRejectedCounter.Inc(1)
+		return errResp(ErrInvalidAttestation, "%v", err)
+	}
+	attestationVerifiedCounter.Inc(1)
+	return nil
+}
+
+// SetAttestation sets the attestation of the node sent at the handshake and the policy
+// verifying the attestations of the peers.
+func (pm *ProtocolManager) SetAttestation(attestation *Attestation, policy AttestationPolicy) {
+	pm.attestation = attestation
+	pm.attestationPolicy = policy
+}
+
+// NodeAttestation returns the attestation of the node freshly signed by the nodekey.
+func (api *PrivateAdminAPI) NodeAttestation() (*Attestation, error) {
+	bc := api.cn.BlockChain()
+	return NewAttestation(api.cn.nodeKey, bc.Config(), bc.Genesis().Hash(), api.cn.nodeVersion)
+}
+
+// VerifyAttestation checks the attestation of the given node against the chain and the
+// approved versions of this node.
+func (api *PrivateAdminAPI) VerifyAttestation(id discover.NodeID, attestation *Attestation) (bool, error) {
+	bc := api.cn.BlockChain()
+	if err := attestation.Verify(id, bc.Config(), bc.Genesis().Hash(), api.cn.config.Attestation.ApprovedVersions); err != nil {
+		return false, err
+	}
+	return true, nil
+}
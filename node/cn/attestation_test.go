@@ -0,0 +1,144 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestation_Verify(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	id := discover.PubkeyID(&key.PublicKey)
+	other, _ := crypto.GenerateKey()
+
+	config := &params.ChainConfig{
+		ChainID:                 big.NewInt(1000),
+		IstanbulCompatibleBlock: big.NewInt(0),
+		MagmaCompatibleBlock:    big.NewInt(100),
+	}
+	genesis := common.HexToHash("0x1234")
+
+	a, err := NewAttestation(key, config, genesis, "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []AttestedFork{{"istanbul", 0}, {"magma", 100}}, a.Forks)
+
+	signer, err := a.Signer()
+	require.NoError(t, err)
+	assert.Equal(t, id, signer)
+
+	assert.NoError(t, a.Verify(id, config, genesis, nil))
+	assert.NoError(t, a.Verify(id, config, genesis, []string{"v0.9.0", "v1.0.0"}))
+	assert.ErrorIs(t, a.Verify(id, config, genesis, []string{"v0.9.0"}), errAttestationNotApproved)
+	assert.ErrorIs(t, a.Verify(discover.PubkeyID(&other.PublicKey), config, genesis, nil), errAttestationSigner)
+	assert.ErrorIs(t, a.Verify(id, config, common.HexToHash("0x5678"), nil), errAttestationGenesis)
+	assert.ErrorIs(t, (*Attestation)(nil).Verify(id, config, genesis, nil), errNoAttestation)
+
+	otherChain := *config
+	otherChain.ChainID = big.NewInt(1001)
+	assert.ErrorIs(t, a.Verify(id, &otherChain, genesis, nil), errAttestationChainID)
+
+	otherForks := *config
+	otherForks.MagmaCompatibleBlock = big.NewInt(200)
+	assert.ErrorIs(t, a.Verify(id, &otherForks, genesis, nil), errAttestationForks)
+
+	// a tampered attestation is not signed by the node anymore
+	tampered := *a
+	tampered.Version = "v9.9.9"
+	assert.ErrorIs(t, tampered.Verify(id, config, genesis, nil), errAttestationSigner)
+}
+
+func TestStatusData_Attestation(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	config := &params.ChainConfig{ChainID: big.NewInt(1000)}
+	a, err := NewAttestation(key, config, common.Hash{}, "v1.0.0")
+	require.NoError(t, err)
+
+	legacy := struct {
+		ProtocolVersion uint32
+		NetworkId       uint64
+		TD              *big.Int
+		CurrentBlock    common.Hash
+		GenesisBlock    common.Hash
+		ChainID         *big.Int
+	}{65, 1000, big.NewInt(1), common.Hash{}, common.Hash{}, big.NewInt(1000)}
+
+	// the status without an attestation is encoded as before
+	status := &statusData{65, 1000, big.NewInt(1), common.Hash{}, common.Hash{}, big.NewInt(1000), nil}
+	b, err := rlp.EncodeToBytes(status)
+	require.NoError(t, err)
+	lb, err := rlp.EncodeToBytes(&legacy)
+	require.NoError(t, err)
+	assert.Equal(t, lb, b)
+
+	status.Attestation = a
+	b, err = rlp.EncodeToBytes(status)
+	require.NoError(t, err)
+	var decoded statusData
+	require.NoError(t, rlp.DecodeBytes(b, &decoded))
+	require.NotNil(t, decoded.Attestation)
+	assert.NoError(t, decoded.Attestation.Verify(discover.PubkeyID(&key.PublicKey), config, common.Hash{}, []string{"v1.0.0"}))
+}
+
+func TestProtocolManager_verifyAttestation(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	id := discover.PubkeyID(&key.PublicKey)
+	config := &params.ChainConfig{ChainID: big.NewInt(1000)}
+	genesis := newBlock(0)
+	a, err := NewAttestation(key, config, genesis.Hash(), "v1.0.0")
+	require.NoError(t, err)
+
+	mockCtrl, _, mockBlockChain, _ := newMocks(t)
+	defer mockCtrl.Finish()
+	mockBlockChain.EXPECT().Config().Return(config).AnyTimes()
+	mockBlockChain.EXPECT().Genesis().Return(genesis).AnyTimes()
+
+	testcases := []struct {
+		attestation *Attestation
+		policy      AttestationPolicy
+		valid       bool
+	}{
+		{nil, AttestationPolicy{}, true},
+		{nil, AttestationPolicy{Required: true}, false},
+		{a, AttestationPolicy{}, true},
+		{a, AttestationPolicy{Required: true, ApprovedVersions: []string{"v1.0.0"}}, true},
+		{a, AttestationPolicy{ApprovedVersions: []string{"v1.0.1"}}, false},
+	}
+	for i, tc := range testcases {
+		pm := &ProtocolManager{blockchain: mockBlockChain}
+		pm.SetAttestation(nil, tc.policy)
+
+		mockPeer := NewMockPeer(mockCtrl)
+		mockPeer.EXPECT().GetAttestation().Return(tc.attestation).AnyTimes()
+		mockPeer.EXPECT().GetP2PPeerID().Return(id).AnyTimes()
+
+		err := pm.verifyAttestation(mockPeer)
+		if tc.valid {
+			assert.NoError(t, err, i)
+		} else {
+			assert.Error(t, err, i)
+		}
+	}
+}
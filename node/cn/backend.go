@@ -21,6 +21,7 @@
 package cn
 
 import (
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -32,6 +33,7 @@ import (
 	"github.com/klaytn/klaytn"
 	"github.com/klaytn/klaytn/accounts"
 	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/api/debug"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/bloombits"
 	"github.com/klaytn/klaytn/blockchain/state"
@@ -48,6 +50,8 @@ import (
 	"github.com/klaytn/klaytn/networks/p2p"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/node"
+	"github.com/klaytn/klaytn/node/cn/contractmeta"
+	"github.com/klaytn/klaytn/node/cn/eventmetrics"
 	"github.com/klaytn/klaytn/node/cn/filters"
 	"github.com/klaytn/klaytn/node/cn/gasprice"
 	"github.com/klaytn/klaytn/node/cn/tracers"
@@ -58,7 +62,10 @@ import (
 	"github.com/klaytn/klaytn/work"
 )
 
-var errCNLightSync = errors.New("can't run cn.CN in light sync mode")
+var (
+	errCNLightSync        = errors.New("can't run cn.CN in light sync mode")
+	errPublicRewardPolicy = errors.New("custom reward policy is allowed on private networks only")
+)
 
 //go:generate mockgen -destination=node/cn/mocks/lesserver_mock.go -package=mocks github.com/klaytn/klaytn/node/cn LesServer
 type LesServer interface {
@@ -88,6 +95,7 @@ type Miner interface {
 type BackendProtocolManager interface {
 	Downloader() ProtocolManagerDownloader
 	SetWsEndPoint(wsep string)
+	SetAttestation(attestation *Attestation, policy AttestationPolicy)
 	GetSubProtocols() []p2p.Protocol
 	ProtocolVersion() int
 	ReBroadcastTxs(transactions types.Transactions)
@@ -135,6 +143,19 @@ type CN struct {
 	components []interface{}
 
 	governance governance.Engine
+
+	addressLabels *reward.AddressLabelRegistry // labels annotating RPC responses, nil if disabled
+
+	contractMetadata *contractmeta.Registry // verified source and ABI bundles of the contracts
+
+	compactionScheduler *database.CompactionScheduler // nil if the auto compaction is disabled
+
+	preconfs *preconfTracker // nil if the transaction preconfirmations are disabled
+
+	maintenance *maintenanceMode // nil if the node cannot be halted for maintenance
+
+	nodeKey     *ecdsa.PrivateKey // signs the attestation of the node
+	nodeVersion string            // the version of the node attested
 }
 
 func (s *CN) AddLesServer(ls LesServer) {
@@ -190,6 +211,17 @@ func checkSyncMode(config *Config) error {
 	return nil
 }
 
+// setRewardPolicy makes the custom reward policy of the config used instead of the built-in ones.
+func setRewardPolicy(config *Config) error {
+	if config.RewardPolicy == "" {
+		return reward.SetCustomRewardPolicy("", nil)
+	}
+	if !config.IsPrivate {
+		return errPublicRewardPolicy
+	}
+	return reward.SetCustomRewardPolicy(config.RewardPolicy, new(big.Int).SetUint64(config.RewardPolicyBlock))
+}
+
 func setEngineType(chainConfig *params.ChainConfig) {
 	if chainConfig.Clique != nil {
 		types.EngineType = types.Engine_Clique
@@ -213,6 +245,18 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 		return nil, genesisErr
 	}
 
+	// The chain config stored in the database, which the local fork overrides are never written to
+	genesisChainConfig := chainConfig
+	if len(config.OverrideForks) > 0 {
+		overridden, err := chainConfig.OverrideForks(config.OverrideForks)
+		if err != nil {
+			return nil, err
+		}
+		logger.Warn("Overriding the hardfork activation blocks locally. This is not a consensus change, "+
+			"so the node rejects the blocks of the network if they differ. Use it for private testing only", "overrides", config.OverrideForks)
+		chainConfig = overridden
+	}
+
 	setEngineType(chainConfig)
 
 	// load governance state
@@ -223,6 +267,11 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 
 	config.GasPrice = new(big.Int).SetUint64(chainConfig.UnitPrice)
 
+	if err := setRewardPolicy(config); err != nil {
+		return nil, err
+	}
+	reward.SetInvariantCheck(config.RewardCheckInvariants)
+
 	cn := &CN{
 		config:            config,
 		chainDB:           chainDB,
@@ -237,6 +286,8 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 		bloomIndexer:      NewBloomIndexer(chainDB, params.BloomBitsBlocks),
 		closeBloomHandler: make(chan struct{}),
 		governance:        governance,
+		nodeKey:           ctx.NodeKey(),
+		nodeVersion:       ctx.NodeVersion(),
 	}
 
 	// istanbul BFT. Derive and set node's address using nodekey
@@ -306,11 +357,79 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 		go senderTxHashIndexer(chainDB, ch, chainEventSubscription)
 	}
 
+	if config.BalanceHistoryIndexing {
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go balanceHistoryIndexer(chainDB, bc, governance, ch, chainEventSubscription)
+	}
+
+	if config.EpochSummaryIndexing {
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go epochSummaryIndexer(chainDB, bc, governance, ch, chainEventSubscription)
+	}
+
+	if config.RewardIndexing {
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go rewardIndexer(chainDB, bc, governance, ch, chainEventSubscription)
+	}
+
+	if config.RewardVerify {
+		reward.SetPaidRewardRecording(true)
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go rewardVerifier(bc, governance, ch, chainEventSubscription)
+	}
+
+	if config.RewardAuditFee {
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go feeAuditor(bc, governance, ch, chainEventSubscription)
+	}
+
+	if config.RewardHistory {
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go rewardCheckpointer(chainDB, bc, governance, config.RewardHistoryRetention, ch, chainEventSubscription)
+	}
+
+	if config.RewardMonitor {
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go rewardMonitor(bc, governance, config.RewardMonitorThresholds, ch, chainEventSubscription)
+	}
+
+	cn.contractMetadata = contractmeta.NewRegistry(chainDB, func(addr common.Address) []byte {
+		statedb, err := bc.State()
+		if err != nil {
+			return nil
+		}
+		return statedb.GetCode(addr)
+	})
+	if config.ContractRegistry != nil {
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go contractMetadataSyncer(cn.contractMetadata, *config.ContractRegistry, ch, chainEventSubscription)
+	}
+
+	if config.EventMetricsFile != "" {
+		registry, err := eventmetrics.Load(config.EventMetricsFile)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Loaded the event metrics", "file", config.EventMetricsFile, "rules", registry.Len())
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go eventMetricsUpdater(registry, ch, chainEventSubscription)
+	}
+
 	// Rewind the chain in case of an incompatible config upgrade.
 	if compat, ok := genesisErr.(*params.ConfigCompatError); ok {
 		logger.Error("Rewinding chain to upgrade configuration", "err", compat)
 		cn.blockchain.SetHead(compat.RewindTo)
-		chainDB.WriteChainConfig(genesisHash, cn.chainConfig)
+		genesisChainConfig.SetDefaults()
+		chainDB.WriteChainConfig(genesisHash, genesisChainConfig)
 	}
 	cn.bloomIndexer.Start(cn.blockchain)
 
@@ -334,17 +453,51 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 
 	cn.protocolManager.SetWsEndPoint(config.WsEndpoint)
 
+	var attestation *Attestation
+	if config.Attestation.Enabled {
+		if attestation, err = NewAttestation(cn.nodeKey, cn.chainConfig, bc.Genesis().Hash(), cn.nodeVersion); err != nil {
+			return nil, err
+		}
+		logger.Info("Attesting the node at the handshake", "version", cn.nodeVersion, "forks", len(attestation.Forks))
+	}
+	cn.protocolManager.SetAttestation(attestation, config.Attestation)
+
 	if ctx.NodeType() == common.CONSENSUSNODE {
 		if _, err := cn.Rewardbase(); err != nil {
 			logger.Error("Cannot determine the rewardbase address", "err", err)
 		}
 	}
 
+	if config.AnnotateAddressLabels {
+		if cn.addressLabels, err = reward.LoadAddressLabelRegistry(config.AddressLabelsFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.PreconfMaxBlocks > 0 && ctx.NodeType() == common.CONSENSUSNODE {
+		if istBackend, ok := cn.engine.(istanbul.Backend); ok {
+			signer := crypto.PubkeyToAddress(ctx.NodeKey().PublicKey)
+			cn.preconfs = newPreconfTracker(chainConfig.ChainID, config.PreconfMaxBlocks, signer, istBackend.Sign, cn.blockchain, cn.txPool)
+			ch := make(chan blockchain.ChainEvent, 255)
+			go cn.preconfs.loop(ch, cn.blockchain.SubscribeChainEvent(ch))
+		}
+	}
+
+	if config.AutoCompaction {
+		if cn.compactionScheduler, err = database.NewCompactionScheduler(chainDB, config.CompactionScheduler, cn.currentBlockNumber, cn.compactionWorkload); err != nil {
+			return nil, err
+		}
+	}
+
 	if pset.Policy() == uint64(istanbul.WeightedRandom) {
 		// NewStakingManager is called with proper non-nil parameters
 		reward.NewStakingManager(cn.blockchain, governance, cn.chainDB)
 	}
 
+	if err := verifyChainIntegrity(bc, config.VerifyOnStart, pset.Policy() == uint64(istanbul.WeightedRandom)); err != nil {
+		return nil, err
+	}
+
 	// Governance states which are not yet applied to the db remains at in-memory storage
 	// It disappears during the node restart, so restoration is needed before the sync starts
 	// By calling CreateSnapshot, it restores the gov state snapshots and apply the votes in it
@@ -370,6 +523,18 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	// istanbul BFT
 	cn.miner.SetExtra(makeExtraData(config.ExtraData))
 
+	if ctx.NodeType() == common.CONSENSUSNODE {
+		if halter, ok := cn.miner.(proposalHalter); ok {
+			cn.maintenance = newMaintenanceMode(cn.blockchain, halter, nil)
+		}
+	} else if pauser, ok := cn.txPool.(admissionPauser); ok {
+		cn.maintenance = newMaintenanceMode(cn.blockchain, nil, pauser)
+	}
+	if cn.maintenance != nil {
+		ch := make(chan blockchain.ChainHeadEvent, 10)
+		go cn.maintenance.loop(ch, cn.blockchain.SubscribeChainHeadEvent(ch))
+	}
+
 	cn.APIBackend = &CNAPIBackend{cn, nil}
 
 	gpoParams := config.GPO
@@ -385,6 +550,7 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	cn.addComponent(cn.APIs())
 	cn.addComponent(cn.ChainDB())
 	cn.addComponent(cn.engine)
+	cn.addComponent(cn.governance)
 
 	if config.AutoRestartFlag {
 		daemonPath := config.DaemonPathFlag
@@ -443,6 +609,30 @@ func (s *CN) setAcceptTxs() error {
 	return nil
 }
 
+// verifyChainIntegrity cross-checks the recent chain data before the node serves traffic.
+// The staking snapshot is verified only if the weighted random proposer policy is used.
+// In strict mode, it returns an error if any issue is found.
+func verifyChainIntegrity(bc *blockchain.BlockChain, mode blockchain.IntegrityCheckMode, weightedRandom bool) error {
+	if mode == "" || mode == blockchain.IntegrityCheckOff {
+		return nil
+	}
+
+	report := bc.VerifyIntegrity(mode, blockchain.DefaultIntegrityCheckDepth)
+	if weightedRandom {
+		// Staking information of the next block is needed to create or verify the block.
+		next := report.Head + 1
+		if err := reward.CheckStakingInfoStored(next); err != nil {
+			report.AddIssue(params.CalcStakingBlockNumber(next), common.Hash{}, blockchain.IntegrityCheckStaking, err.Error())
+		}
+	}
+	report.Log()
+
+	if mode == blockchain.IntegrityCheckStrict && !report.OK() {
+		return fmt.Errorf("chain data integrity check failed with %d issue(s)", len(report.Issues))
+	}
+	return nil
+}
+
 // add component which may be used in another service component
 func (s *CN) addComponent(component interface{}) {
 	s.components = append(s.components, component)
@@ -480,6 +670,7 @@ func CreateDB(ctx *node.ServiceContext, config *Config, name string) database.DB
 		Dir: name, DBType: config.DBType, ParallelDBWrite: config.ParallelDBWrite, SingleDB: config.SingleDB, NumStateTrieShards: config.NumStateTrieShards,
 		LevelDBCacheSize: config.LevelDBCacheSize, OpenFilesLimit: database.GetOpenFilesLimit(), LevelDBCompression: config.LevelDBCompression,
 		LevelDBBufferPool: config.LevelDBBufferPool, EnableDBPerfMetrics: config.EnableDBPerfMetrics, RocksDBConfig: &config.RocksDBConfig, DynamoDBConfig: &config.DynamoDBConfig,
+		HeaderMmapCacheSize: config.HeaderMmapCacheSize,
 	}
 	return ctx.OpenDatabase(dbc)
 }
@@ -502,8 +693,26 @@ func (s *CN) APIs() []rpc.API {
 	apis = append(apis, s.engine.APIs(s.BlockChain())...)
 
 	publicFilterAPI := filters.NewPublicFilterAPI(s.APIBackend, false)
+	debug.RegisterSubscriptionCounter("filters", filters.InstalledSubscriptions)
 	governanceKlayAPI := governance.NewGovernanceKlayAPI(s.governance, s.blockchain)
 	governanceAPI := governance.NewGovernanceAPI(s.governance)
+	if s.addressLabels != nil {
+		governanceKlayAPI.SetAddressLabels(s.addressLabels)
+		governanceAPI.SetAddressLabels(s.addressLabels)
+	}
+	if s.config.RewardHistory {
+		governanceKlayAPI.SetRewardCheckpoints(s.chainDB)
+	}
+	if s.config.RewardIndexing {
+		governanceKlayAPI.SetRewardIndex(s.chainDB)
+	}
+	if s.config.RewardReportFormat != nil {
+		governanceKlayAPI.SetRewardReportFormat(s.config.RewardReportFormat)
+	}
+	if istBackend, ok := s.engine.(istanbul.Backend); ok {
+		governanceKlayAPI.SetStatementSigner(istBackend.Sign)
+	}
+	governanceKlayAPI.SetPendingBlock(s.miner.PendingBlock)
 	publicDownloaderAPI := downloader.NewPublicDownloaderAPI(s.protocolManager.Downloader(), s.eventMux)
 	privateDownloaderAPI := downloader.NewPrivateDownloaderAPI(s.protocolManager.Downloader())
 
@@ -525,6 +734,27 @@ func (s *CN) APIs() []rpc.API {
 			},
 		}...)
 	}
+	tracerAPI.SetSinkConfig(s.config.TraceSinks)
+
+	if s.preconfs != nil {
+		apis = append(apis, rpc.API{
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   &PrivatePreconfirmationAPI{s.preconfs},
+		})
+	}
+	if s.maintenance != nil {
+		apis = append(apis, rpc.API{
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   &PrivateMaintenanceAPI{s.maintenance},
+		}, rpc.API{
+			Namespace: "klay",
+			Version:   "1.0",
+			Service:   &PublicMaintenanceAPI{s.maintenance},
+			Public:    true,
+		})
+	}
 
 	// Append all the local APIs and return
 	return append(apis, []rpc.API{
@@ -543,6 +773,11 @@ func (s *CN) APIs() []rpc.API {
 			Version:   "1.0",
 			Service:   publicFilterAPI,
 			Public:    true,
+		}, {
+			Namespace: "klay",
+			Version:   "1.0",
+			Service:   contractmeta.NewPublicContractMetadataAPI(s.contractMetadata),
+			Public:    true,
 		}, {
 			Namespace: "eth",
 			Version:   "1.0",
@@ -556,6 +791,10 @@ func (s *CN) APIs() []rpc.API {
 			Namespace: "admin",
 			Version:   "1.0",
 			Service:   NewPrivateAdminAPI(s),
+		}, {
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   contractmeta.NewPrivateContractMetadataAPI(s.contractMetadata),
 		}, {
 			Namespace: "debug",
 			Version:   "1.0",
@@ -581,6 +820,11 @@ func (s *CN) APIs() []rpc.API {
 			Version:   "1.0",
 			Service:   governanceKlayAPI,
 			Public:    true,
+		}, {
+			Namespace: "debug",
+			Version:   "1.0",
+			Service:   governance.NewGovernanceDebugAPI(governanceKlayAPI),
+			Public:    false,
 		}, {
 			Namespace: "eth",
 			Version:   "1.0",
@@ -646,6 +890,20 @@ func (s *CN) NetVersion() uint64                      { return s.networkId }
 func (s *CN) Progress() klaytn.SyncProgress           { return s.protocolManager.Downloader().Progress() }
 func (s *CN) Governance() governance.Engine           { return s.governance }
 
+// currentBlockNumber returns the number of the current head block.
+func (s *CN) currentBlockNumber() uint64 {
+	return s.blockchain.CurrentBlock().NumberU64()
+}
+
+// compactionWorkload returns the workload observed by the compaction scheduler.
+func (s *CN) compactionWorkload() database.CompactionWorkload {
+	progress := s.protocolManager.Downloader().Progress()
+	return database.CompactionWorkload{
+		PendingRequests: rpc.PendingRequests(),
+		Syncing:         progress.CurrentBlock < progress.HighestBlock,
+	}
+}
+
 func (s *CN) ReBroadcastTxs(transactions types.Transactions) {
 	s.protocolManager.ReBroadcastTxs(transactions)
 }
@@ -678,6 +936,10 @@ func (s *CN) Start(srvr p2p.Server) error {
 
 	reward.StakingManagerSubscribe()
 
+	if s.compactionScheduler != nil {
+		s.compactionScheduler.Start()
+	}
+
 	return nil
 }
 
@@ -696,6 +958,9 @@ func (s *CN) Stop() error {
 	s.txPool.Stop()
 	s.miner.Stop()
 	reward.StakingManagerUnsubscribe()
+	if s.compactionScheduler != nil {
+		s.compactionScheduler.Stop()
+	}
 	s.blockchain.Stop()
 	s.chainDB.Close()
 	s.eventMux.Stop()
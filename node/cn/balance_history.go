@@ -0,0 +1,129 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// balanceHistoryIndexer subscribes chainEvent and stores the balance changes of the accounts
+// touched by each block, including the reward recipients. The balances are compared between
+// the states of the block and its parent, so an account whose balance is changed only by an
+// internal transaction of a contract is not indexed unless it is a candidate of the block.
+func balanceHistoryIndexer(db database.DBManager, bc *blockchain.BlockChain, gov governance.Engine, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+
+	for {
+		select {
+		case event := <-chainEvent:
+			if err := indexBalanceChanges(db, bc, gov, event.Block, event.Receipts); err != nil {
+				logger.Error("Failed to index balance changes", "blockNum", event.Block.Number(), "err", err)
+			}
+
+		case <-subscription.Err():
+			return
+		}
+	}
+}
+
+// indexBalanceChanges stores the balance changes made by the given block.
+func indexBalanceChanges(db database.DBManager, bc *blockchain.BlockChain, gov governance.Engine, block *types.Block, receipts types.Receipts) error {
+	number := block.NumberU64()
+	if number == 0 {
+		return nil
+	}
+	if _, ok := db.ReadBalanceHistoryIndexStart(); !ok {
+		db.WriteBalanceHistoryIndexStart(number)
+		logger.Info("Started indexing the balance history", "blockNum", number)
+	}
+
+	parent := bc.GetHeader(block.ParentHash(), number-1)
+	if parent == nil {
+		return consensus.ErrUnknownAncestor
+	}
+	prevState, err := bc.StateAt(parent.Root)
+	if err != nil {
+		return err
+	}
+	state, err := bc.StateAt(block.Root())
+	if err != nil {
+		return err
+	}
+
+	batch := db.NewBalanceHistoryBatch()
+	defer batch.Release()
+	for _, addr := range balanceChangeCandidates(bc, gov, block, receipts) {
+		prev, balance := prevState.GetBalance(addr), state.GetBalance(addr)
+		if prev.Cmp(balance) == 0 {
+			continue
+		}
+		change := &database.BalanceChange{Number: number, Prev: prev, Balance: balance}
+		if err := db.PutBalanceChangeToBatch(batch, addr, change); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// balanceChangeCandidates returns the accounts whose balance can be changed by the given block:
+// the senders, recipients and fee payers of the transactions, the created contracts,
+// the rewardbase and the reward recipients of the block.
+func balanceChangeCandidates(bc *blockchain.BlockChain, gov governance.Engine, block *types.Block, receipts types.Receipts) []common.Address {
+	seen := make(map[common.Address]struct{})
+	var candidates []common.Address
+	add := func(addr common.Address) {
+		if _, ok := seen[addr]; !ok {
+			seen[addr] = struct{}{}
+			candidates = append(candidates, addr)
+		}
+	}
+
+	for _, tx := range block.Transactions() {
+		add(tx.ValidatedSender())
+		if tx.To() != nil {
+			add(*tx.To())
+		}
+		if tx.IsFeeDelegatedTransaction() {
+			add(tx.ValidatedFeePayer())
+		}
+	}
+	for _, receipt := range receipts {
+		if receipt.ContractAddress != (common.Address{}) {
+			add(receipt.ContractAddress)
+		}
+	}
+
+	header := block.Header()
+	add(header.Rewardbase)
+	if pset, err := gov.EffectiveParams(header.Number.Uint64()); err != nil {
+		logger.Debug("Failed to get the governance parameters for the reward recipients", "blockNum", header.Number, "err", err)
+	} else if spec, err := reward.GetBlockReward(header, bc.Config().Rules(header.Number), pset); err != nil {
+		logger.Debug("Failed to get the reward recipients", "blockNum", header.Number, "err", err)
+	} else {
+		for addr := range spec.Rewards {
+			add(addr)
+		}
+	}
+	return candidates
+}
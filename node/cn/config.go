@@ -36,7 +36,9 @@ import (
 	"github.com/klaytn/klaytn/datasync/downloader"
 	"github.com/klaytn/klaytn/log"
 	"github.com/klaytn/klaytn/node/cn/gasprice"
+	"github.com/klaytn/klaytn/node/cn/tracers"
 	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
 	"github.com/klaytn/klaytn/storage/database"
 )
 
@@ -54,6 +56,8 @@ func GetDefaultConfig() *Config {
 		TrieNodeCacheConfig:  *statedb.GetEmptyTrieNodeCacheConfig(),
 		TriesInMemory:        blockchain.DefaultTriesInMemory,
 		LivePruningRetention: blockchain.DefaultLivePruningRetention,
+		VerifyOnStart:        blockchain.IntegrityCheckOff,
+		CompactionScheduler:  database.DefaultCompactionSchedulerConfig,
 		GasPrice:             big.NewInt(18 * params.Ston),
 
 		TxPool: blockchain.DefaultTxPoolConfig,
@@ -67,6 +71,9 @@ func GetDefaultConfig() *Config {
 
 		Istanbul:      *istanbul.DefaultConfig,
 		RPCEVMTimeout: 5 * time.Second,
+
+		RewardMonitorThresholds: reward.DefaultRewardAnomalyThresholds,
+		RewardReportFormat:      reward.DefaultRewardReportFormat,
 	}
 }
 
@@ -86,6 +93,10 @@ type Config struct {
 	// If nil, the Klaytn main net block is used.
 	Genesis *blockchain.Genesis `toml:",omitempty"`
 
+	// The activation blocks of the hardforks overridden locally for testing by --override.<fork>.
+	// They are not a part of the consensus, see params.ChainConfig.OverrideForks.
+	OverrideForks map[string]uint64 `toml:",omitempty"`
+
 	// Protocol options
 	NetworkId     uint64 // Network ID to use for selecting peers to connect to
 	SyncMode      downloader.SyncMode
@@ -109,27 +120,34 @@ type Config struct {
 	StartBlockNumber uint64
 
 	// Database options
-	DBType               database.DBType
-	SkipBcVersionCheck   bool `toml:"-"`
-	SingleDB             bool
-	NumStateTrieShards   uint
-	EnableDBPerfMetrics  bool
-	LevelDBCompression   database.LevelDBCompressionType
-	LevelDBBufferPool    bool
-	LevelDBCacheSize     int
-	DynamoDBConfig       database.DynamoDBConfig
-	RocksDBConfig        database.RocksDBConfig
-	TrieCacheSize        int
-	TrieTimeout          time.Duration
-	TrieBlockInterval    uint
-	TriesInMemory        uint64
-	LivePruning          bool
-	LivePruningRetention uint64
-	SenderTxHashIndexing bool
-	ParallelDBWrite      bool
-	TrieNodeCacheConfig  statedb.TrieNodeCacheConfig
-	SnapshotCacheSize    int
-	SnapshotAsyncGen     bool
+	DBType                 database.DBType
+	SkipBcVersionCheck     bool `toml:"-"`
+	SingleDB               bool
+	NumStateTrieShards     uint
+	EnableDBPerfMetrics    bool
+	LevelDBCompression     database.LevelDBCompressionType
+	LevelDBBufferPool      bool
+	LevelDBCacheSize       int
+	HeaderMmapCacheSize    int // Size of the memory-mapped header cache in MiB, 0 to disable
+	DynamoDBConfig         database.DynamoDBConfig
+	RocksDBConfig          database.RocksDBConfig
+	TrieCacheSize          int
+	TrieTimeout            time.Duration
+	TrieBlockInterval      uint
+	TriesInMemory          uint64
+	LivePruning            bool
+	LivePruningRetention   uint64
+	SenderTxHashIndexing   bool
+	BalanceHistoryIndexing bool // Index the balance changes of accounts for klay_getBalanceHistory
+	EpochSummaryIndexing   bool // Summarize the epochs for klay_getEpochSummary
+	RewardIndexing         bool // Accumulate the rewards of the recipients for klay_getAccumulatedRewards
+	ParallelDBWrite        bool
+	TrieNodeCacheConfig    statedb.TrieNodeCacheConfig
+	SnapshotCacheSize      int
+	SnapshotAsyncGen       bool
+	VerifyOnStart          blockchain.IntegrityCheckMode // Integrity check mode of the chain data on startup
+	AutoCompaction         bool                          // Compact the cold block data during the scheduled low-traffic windows
+	CompactionScheduler    database.CompactionSchedulerConfig
 
 	// Mining-related options
 	ServiceChainSigner common.Address `toml:",omitempty"`
@@ -139,6 +157,35 @@ type Config struct {
 	// Reward
 	Rewardbase common.Address `toml:",omitempty"`
 
+	// RewardPolicy is the name of the custom reward policy registered by reward.RegisterRewardPolicy,
+	// which replaces the built-in ones from RewardPolicyBlock. It is allowed on private networks only.
+	RewardPolicy      string `toml:",omitempty"`
+	RewardPolicyBlock uint64 `toml:",omitempty"`
+
+	// RewardVerify makes the rewards paid in each imported block verified against the reward spec.
+	RewardVerify bool
+
+	// RewardCheckInvariants makes a block rejected if its reward spec violates the invariants
+	// checked by reward.CheckRewardSpec, instead of distributing the rewards.
+	RewardCheckInvariants bool
+
+	// RewardAuditFee makes the total fee of each imported block audited against the fees collected from its transactions.
+	RewardAuditFee bool
+
+	// RewardHistory makes the reward spec of each imported block persisted as a reward checkpoint,
+	// which serves klay_getRewards after the staking information of the block is pruned.
+	// The checkpoints older than RewardHistoryRetention blocks are pruned unless it is 0.
+	RewardHistory          bool
+	RewardHistoryRetention uint64
+
+	// RewardMonitor makes the reward spec of each imported block compared with the previous one,
+	// alerting the changes beyond RewardMonitorThresholds.
+	RewardMonitor           bool
+	RewardMonitorThresholds reward.RewardAnomalyThresholds
+
+	// RewardReportFormat is the default format re-expressing the reward amounts served by klay_getRewardsReport.
+	RewardReportFormat *reward.RewardReportFormat
+
 	// Transaction pool options
 	TxPool blockchain.TxPoolConfig
 
@@ -160,6 +207,9 @@ type Config struct {
 
 	WsEndpoint string `toml:",omitempty"`
 
+	// Attestation of the node sent at the handshake and verified from the peers
+	Attestation AttestationPolicy
+
 	// Tx Resending options
 	TxResendInterval  uint64
 	TxResendCount     int
@@ -187,6 +237,26 @@ type Config struct {
 	// This is used by eth namespace RPC APIs
 	RPCTxFeeCap float64
 
+	// AnnotateAddressLabels makes reward and staking RPCs annotate addresses with labels
+	// read from AddressLabelsFile and the staking information.
+	AnnotateAddressLabels bool   `toml:",omitempty"`
+	AddressLabelsFile     string `toml:",omitempty"`
+
+	// ContractRegistry is the address of the registry contract whose ContractRegistered
+	// events are synced into the contract metadata store, nil if not synced.
+	ContractRegistry *common.Address `toml:",omitempty"`
+
+	// EventMetricsFile is the JSON file of the rules whose matching logs of the imported blocks
+	// update the operator-defined metrics, empty if no such metric is exported.
+	EventMetricsFile string `toml:",omitempty"`
+
+	// PreconfMaxBlocks is the maximum number of blocks within which a CN promises to include
+	// a transaction by a preconfirmation. The preconfirmation APIs are disabled if zero.
+	PreconfMaxBlocks uint64 `toml:",omitempty"`
+
+	// TraceSinks configures the sinks the debug traces can be streamed to.
+	TraceSinks tracers.SinkConfig `toml:",omitempty"`
+
 	// Disable option for unsafe debug APIs
 	DisableUnsafeDebug         bool          `toml:",omitempty"`
 	StateRegenerationTimeLimit time.Duration `toml:",omitempty"`
@@ -0,0 +1,50 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/node/cn/contractmeta"
+)
+
+// contractMetadataSyncer subscribes chainEvent and registers the contract metadata carried by
+// the ContractRegistered events of the registry contract. Only the events of the blocks
+// inserted after the node starts syncing the registry are imported.
+func contractMetadataSyncer(registry *contractmeta.Registry, registryAddr common.Address, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+
+	for {
+		select {
+		case event := <-chainEvent:
+			for _, log := range event.Logs {
+				if log.Address != registryAddr || len(log.Topics) == 0 || log.Topics[0] != contractmeta.RegistryEventTopic {
+					continue
+				}
+				if addr, err := registry.ImportRegistryLog(log); err != nil {
+					logger.Warn("Failed to import the contract metadata", "blockNum", log.BlockNumber, "contract", addr, "err", err)
+				} else {
+					logger.Info("Imported the contract metadata", "blockNum", log.BlockNumber, "contract", addr)
+				}
+			}
+
+		case <-subscription.Err():
+			return
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package contractmeta
+
+import (
+	"errors"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+var errUndecodableRevert = errors.New("the revert data is not decodable")
+
+// PublicContractMetadataAPI provides the registered contract metadata and the decoding by them.
+type PublicContractMetadataAPI struct {
+	registry *Registry
+}
+
+// NewPublicContractMetadataAPI creates a new public contract metadata API.
+func NewPublicContractMetadataAPI(registry *Registry) *PublicContractMetadataAPI {
+	return &PublicContractMetadataAPI{registry: registry}
+}
+
+// GetContractMetadata returns the metadata registered for the contract, or nil if not registered.
+func (api *PublicContractMetadataAPI) GetContractMetadata(addr common.Address) *Metadata {
+	return api.registry.Metadata(addr)
+}
+
+// DecodedCallData is a call input decoded by the ABI of the called contract.
+type DecodedCallData struct {
+	Method string                 `json:"method"`
+	Args   map[string]interface{} `json:"args"`
+}
+
+// DecodeCallData decodes the input of a call to the contract by its registered ABI.
+// It returns nil if the input does not call a method of the ABI.
+func (api *PublicContractMetadataAPI) DecodeCallData(addr common.Address, input hexutil.Bytes) (*DecodedCallData, error) {
+	contractABI := api.registry.ABI(addr)
+	if contractABI == nil {
+		return nil, nil
+	}
+	method, args, err := DecodeCall(contractABI, input)
+	if err != nil || method == nil {
+		return nil, err
+	}
+	return &DecodedCallData{Method: method.Sig, Args: args}, nil
+}
+
+// DecodeRevertReason decodes the revert data returned by the contract. The custom errors
+// are decoded by the registered ABI of the contract.
+func (api *PublicContractMetadataAPI) DecodeRevertReason(addr common.Address, data hexutil.Bytes) (string, error) {
+	if reason, ok := DecodeRevert(api.registry.ABI(addr), data); ok {
+		return reason, nil
+	}
+	return "", errUndecodableRevert
+}
+
+// PrivateContractMetadataAPI provides the management of the contract metadata.
+type PrivateContractMetadataAPI struct {
+	registry *Registry
+}
+
+// NewPrivateContractMetadataAPI creates a new private contract metadata API.
+func NewPrivateContractMetadataAPI(registry *Registry) *PrivateContractMetadataAPI {
+	return &PrivateContractMetadataAPI{registry: registry}
+}
+
+// RegisterContractMetadata verifies and stores the source and ABI bundle of a contract.
+func (api *PrivateContractMetadataAPI) RegisterContractMetadata(meta Metadata) (*Metadata, error) {
+	if err := api.registry.Register(&meta, OriginRPC); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// RemoveContractMetadata removes the metadata and the ABI of the contract.
+func (api *PrivateContractMetadataAPI) RemoveContractMetadata(addr common.Address) bool {
+	return api.registry.Remove(addr)
+}
@@ -0,0 +1,256 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package contractmeta
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/klaytn/klaytn/accounts/abi"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+// panicSelector is the selector of Panic(uint256) returned by the failed assertions since solidity v0.8.0.
+var panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+// panicReasons are the descriptions of the solidity panic codes.
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array accessed",
+	0x31: "out-of-bounds array access; popping on an empty array",
+	0x32: "out-of-bounds access of an array or bytesN",
+	0x41: "out of memory",
+	0x51: "uninitialized function",
+}
+
+// normalizeABI returns the JSON array of the ABI given as a JSON array or a string of the JSON array.
+func normalizeABI(data json.RawMessage) (json.RawMessage, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		return json.RawMessage(s), nil
+	}
+	return data, nil
+}
+
+// ParseABI parses the ABI given as a JSON array or a string of the JSON array.
+func ParseABI(data json.RawMessage) (*abi.ABI, error) {
+	data, err := normalizeABI(data)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ABI: %v", err)
+	}
+	return &parsed, nil
+}
+
+// DecodeLog decodes the event and the arguments of the log. It returns a nil event if the
+// log is not emitted by an event of the ABI. Anonymous events are not decoded.
+func DecodeLog(contractABI *abi.ABI, log *types.Log) (*abi.Event, map[string]interface{}, error) {
+	if len(log.Topics) == 0 {
+		return nil, nil, nil
+	}
+	event, err := contractABI.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	args := make(map[string]interface{})
+	if err := event.Inputs.NonIndexed().UnpackIntoMap(args, log.Data); err != nil {
+		return nil, nil, err
+	}
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:]); err != nil {
+		return nil, nil, err
+	}
+	return event, formatArgs(args), nil
+}
+
+// DecodeCall decodes the method and the arguments of the call input. It returns a nil method
+// if the input does not call a method of the ABI.
+func DecodeCall(contractABI *abi.ABI, input []byte) (*abi.Method, map[string]interface{}, error) {
+	method, err := contractABI.MethodById(input)
+	if err != nil {
+		return nil, nil, nil
+	}
+	args := make(map[string]interface{})
+	if err := method.Inputs.UnpackIntoMap(args, input[4:]); err != nil {
+		return nil, nil, err
+	}
+	return method, formatArgs(args), nil
+}
+
+// DecodeRevert decodes the revert data returned by Error(string), Panic(uint256) or a custom
+// error of the ABI, which can be nil. It returns false if the revert data is not decodable.
+func DecodeRevert(contractABI *abi.ABI, data []byte) (string, bool) {
+	if len(data) < 4 {
+		return "", false
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason, true
+	}
+	if bytes.Equal(data[:4], panicSelector) && len(data) == 36 {
+		code := new(big.Int).SetBytes(data[4:])
+		desc, ok := "", false
+		if code.IsUint64() {
+			desc, ok = panicReasons[code.Uint64()]
+		}
+		if !ok {
+			desc = "unknown panic code"
+		}
+		return fmt.Sprintf("panic: 0x%x (%s)", code, desc), true
+	}
+	if contractABI == nil {
+		return "", false
+	}
+	customErr, err := contractABI.ErrorByID(data)
+	if err != nil {
+		return "", false
+	}
+	values, err := customErr.Unpack(data)
+	if err != nil {
+		return "", false
+	}
+	args := make([]string, len(values))
+	for i, value := range values {
+		encoded, _ := json.Marshal(FormatValue(value))
+		args[i] = customErr.Inputs[i].Name + ": " + string(encoded)
+	}
+	return fmt.Sprintf("%s(%s)", customErr.Name, strings.Join(args, ", ")), true
+}
+
+// DecodedCall is a call trace decoded by the ABIs of the called contracts.
+type DecodedCall struct {
+	Type         string                 `json:"type"`
+	From         *common.Address        `json:"from,omitempty"`
+	To           *common.Address        `json:"to,omitempty"`
+	Value        string                 `json:"value,omitempty"`
+	Contract     string                 `json:"contract,omitempty"` // the name of the called contract
+	Method       string                 `json:"method,omitempty"`
+	Args         map[string]interface{} `json:"args,omitempty"`
+	Input        string                 `json:"input,omitempty"`
+	Output       string                 `json:"output,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+	RevertReason string                 `json:"revertReason,omitempty"`
+	Calls        []*DecodedCall         `json:"calls,omitempty"`
+}
+
+// DecodeTrace decodes the calls of the trace made by the fastCallTracer by the registered ABIs.
+func (r *Registry) DecodeTrace(trace *vm.InternalTxTrace) *DecodedCall {
+	cache := make(map[common.Address]*Metadata)
+	return r.decodeTrace(trace, cache)
+}
+
+func (r *Registry) decodeTrace(trace *vm.InternalTxTrace, cache map[common.Address]*Metadata) *DecodedCall {
+	decoded := &DecodedCall{
+		Type:   trace.Type,
+		From:   trace.From,
+		To:     trace.To,
+		Value:  trace.Value,
+		Input:  trace.Input,
+		Output: trace.Output,
+	}
+	if trace.Error != nil {
+		decoded.Error = trace.Error.Error()
+	}
+
+	var contractABI *abi.ABI
+	if trace.To != nil {
+		meta, ok := cache[*trace.To]
+		if !ok {
+			meta = r.Metadata(*trace.To)
+			cache[*trace.To] = meta
+		}
+		if meta != nil {
+			decoded.Contract = meta.Name
+			contractABI, _ = ParseABI(meta.ABI)
+		}
+	}
+	if contractABI != nil && trace.Type != vm.CREATE.String() && trace.Type != vm.CREATE2.String() {
+		if method, args, err := DecodeCall(contractABI, common.FromHex(trace.Input)); err == nil && method != nil {
+			decoded.Method = method.Sig
+			decoded.Args = args
+		}
+	}
+	if trace.Error != nil && trace.Output != "" {
+		decoded.RevertReason, _ = DecodeRevert(contractABI, common.FromHex(trace.Output))
+	}
+	for _, call := range trace.Calls {
+		decoded.Calls = append(decoded.Calls, r.decodeTrace(call, cache))
+	}
+	return decoded
+}
+
+// formatArgs formats the decoded arguments by FormatValue.
+func formatArgs(args map[string]interface{}) map[string]interface{} {
+	for name, value := range args {
+		args[name] = FormatValue(value)
+	}
+	return args
+}
+
+// FormatValue converts the big integers and byte arrays of a decoded value into hex strings
+// so that they are not truncated by JSON clients.
+func FormatValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *big.Int:
+		return (*hexutil.Big)(v)
+	case []byte:
+		return hexutil.Bytes(v)
+	case common.Address, common.Hash, string, bool:
+		return v
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Bytes(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		values := make([]interface{}, rv.Len())
+		for i := range values {
+			values[i] = FormatValue(rv.Index(i).Interface())
+		}
+		return values
+	}
+	return value
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package contractmeta
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/accounts/abi"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func packRevert(t *testing.T, sig string, typeNames []string, values ...interface{}) []byte {
+	var args abi.Arguments
+	for _, name := range typeNames {
+		typ, err := abi.NewType(name, "", nil)
+		require.NoError(t, err)
+		args = append(args, abi.Argument{Type: typ})
+	}
+	data, err := args.Pack(values...)
+	require.NoError(t, err)
+	return append(crypto.Keccak256([]byte(sig))[:4], data...)
+}
+
+func TestDecodeRevert(t *testing.T) {
+	parsed, err := ParseABI(json.RawMessage(tokenABI))
+	require.NoError(t, err)
+
+	reason, ok := DecodeRevert(nil, packRevert(t, "Error(string)", []string{"string"}, "not owner"))
+	assert.True(t, ok)
+	assert.Equal(t, "not owner", reason)
+
+	reason, ok = DecodeRevert(nil, packRevert(t, "Panic(uint256)", []string{"uint256"}, big.NewInt(0x11)))
+	assert.True(t, ok)
+	assert.Equal(t, "panic: 0x11 (arithmetic underflow or overflow)", reason)
+
+	custom := packRevert(t, "InsufficientBalance(uint256,uint256)", []string{"uint256", "uint256"}, big.NewInt(1), big.NewInt(256))
+	reason, ok = DecodeRevert(parsed, custom)
+	assert.True(t, ok)
+	assert.Equal(t, `InsufficientBalance(available: "0x1", required: "0x100")`, reason)
+
+	// custom errors are not decodable without the ABI
+	_, ok = DecodeRevert(nil, custom)
+	assert.False(t, ok)
+	_, ok = DecodeRevert(parsed, []byte{0x01, 0x02})
+	assert.False(t, ok)
+}
+
+func TestDecodeCallAndLog(t *testing.T) {
+	parsed, err := ParseABI(json.RawMessage(tokenABI))
+	require.NoError(t, err)
+	to := common.HexToAddress("0xbbbb")
+
+	input, err := parsed.Pack("transfer", to, big.NewInt(100))
+	require.NoError(t, err)
+	method, args, err := DecodeCall(parsed, input)
+	require.NoError(t, err)
+	assert.Equal(t, "transfer(address,uint256)", method.Sig)
+	assert.Equal(t, map[string]interface{}{"to": to, "amount": (*hexutil.Big)(big.NewInt(100))}, args)
+
+	method, _, err = DecodeCall(parsed, []byte{0x01, 0x02, 0x03, 0x04})
+	assert.NoError(t, err)
+	assert.Nil(t, method)
+
+	from := common.HexToAddress("0xaaaa")
+	log := &types.Log{
+		Topics: []common.Hash{parsed.Events["Transfer"].ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:   common.LeftPadBytes([]byte{0x64}, 32),
+	}
+	event, args, err := DecodeLog(parsed, log)
+	require.NoError(t, err)
+	assert.Equal(t, "Transfer", event.Name)
+	assert.Equal(t, map[string]interface{}{"from": from, "to": to, "value": (*hexutil.Big)(big.NewInt(100))}, args)
+
+	log.Topics[0] = common.Hash{}
+	event, _, err = DecodeLog(parsed, log)
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+}
+
+func TestRegistry_DecodeTrace(t *testing.T) {
+	var (
+		registry = NewRegistry(database.NewMemoryDBManager(), nil)
+		sender   = common.HexToAddress("0xaaaa")
+		wallet   = common.HexToAddress("0x3000")
+		token    = common.HexToAddress("0x1000")
+	)
+	require.NoError(t, registry.Register(&Metadata{Address: token, Name: "Token", ABI: json.RawMessage(tokenABI)}, OriginRPC))
+	parsed := registry.ABI(token)
+	input, err := parsed.Pack("transfer", sender, big.NewInt(256))
+	require.NoError(t, err)
+	revert := packRevert(t, "InsufficientBalance(uint256,uint256)", []string{"uint256", "uint256"}, big.NewInt(1), big.NewInt(256))
+
+	trace := &vm.InternalTxTrace{
+		Type:   "CALL",
+		From:   &sender,
+		To:     &wallet,
+		Input:  "0x12345678",
+		Output: hexutil.Encode(revert),
+		Error:  errors.New("execution reverted"),
+		Calls: []*vm.InternalTxTrace{{
+			Type:   "CALL",
+			From:   &wallet,
+			To:     &token,
+			Input:  hexutil.Encode(input),
+			Output: hexutil.Encode(revert),
+			Error:  errors.New("execution reverted"),
+		}},
+	}
+	decoded := registry.DecodeTrace(trace)
+
+	// the unregistered contract is not decoded except the standard revert data
+	assert.Empty(t, decoded.Contract)
+	assert.Empty(t, decoded.Method)
+	assert.Empty(t, decoded.RevertReason)
+	assert.Equal(t, "execution reverted", decoded.Error)
+
+	require.Len(t, decoded.Calls, 1)
+	call := decoded.Calls[0]
+	assert.Equal(t, "Token", call.Contract)
+	assert.Equal(t, "transfer(address,uint256)", call.Method)
+	assert.Equal(t, map[string]interface{}{"to": sender, "amount": (*hexutil.Big)(big.NewInt(256))}, call.Args)
+	assert.Equal(t, `InsufficientBalance(available: "0x1", required: "0x100")`, call.RevertReason)
+}
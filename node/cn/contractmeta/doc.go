@@ -0,0 +1,27 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package contractmeta implements a node-side store of contract metadata, the verified
+source and ABI bundles of contracts, and decodes calls, logs and revert reasons by them.
+
+Source Files
+
+  - registry.go : stores the metadata registered via RPC or synced from a registry contract
+  - decode.go   : decodes calls, logs, revert data and call traces by the contract ABIs
+  - api.go      : provides the RPC APIs to register, query and decode by the metadata
+*/
+package contractmeta
@@ -0,0 +1,194 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package contractmeta
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/klaytn/klaytn/accounts/abi"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// The origins of the contract metadata.
+const (
+	OriginRPC      = "rpc"
+	OriginRegistry = "registry"
+)
+
+// RegistryEventTopic is the topic of the event emitted by a registry contract when a contract
+// is registered: ContractRegistered(address indexed contractAddress, string metadata), where
+// the metadata is the JSON of the Metadata.
+var RegistryEventTopic = crypto.Keccak256Hash([]byte("ContractRegistered(address,string)"))
+
+var (
+	errNoABI            = errors.New("the ABI of the contract is required")
+	errNoCode           = errors.New("no contract is deployed at the address")
+	errBytecodeMismatch = errors.New("the runtime bytecode does not match the deployed code")
+	errNotRegistryEvent = errors.New("not a ContractRegistered event")
+)
+
+// Metadata is the source and ABI bundle of a contract. A bundle is verified if its runtime
+// bytecode matches the code deployed at the address except the compiler metadata hash.
+type Metadata struct {
+	Address         common.Address    `json:"address"`
+	Name            string            `json:"name,omitempty"`
+	Compiler        string            `json:"compiler,omitempty"`
+	Sources         map[string]string `json:"sources,omitempty"` // file name -> source code
+	ABI             json.RawMessage   `json:"abi"`
+	RuntimeBytecode hexutil.Bytes     `json:"runtimeBytecode,omitempty"`
+	Verified        bool              `json:"verified"`
+	Origin          string            `json:"origin,omitempty"`
+}
+
+// Registry stores the contract metadata in the database. The ABIs are stored in the contract
+// ABI store as well, so the logs of a registered contract are decoded by klay_getDecodedLogs.
+type Registry struct {
+	db   database.DBManager
+	code func(addr common.Address) []byte // returns the deployed code of the address
+}
+
+// NewRegistry returns a registry on the database. The code function is used to verify the
+// runtime bytecode of the bundles; the bundles with bytecode are rejected if it is nil.
+func NewRegistry(db database.DBManager, code func(addr common.Address) []byte) *Registry {
+	return &Registry{db: db, code: code}
+}
+
+// Register verifies and stores the metadata. The bundle is rejected if its runtime bytecode
+// does not match the deployed code, and is stored unverified if it has no bytecode.
+func (r *Registry) Register(meta *Metadata, origin string) error {
+	if len(bytes.TrimSpace(meta.ABI)) == 0 {
+		return errNoABI
+	}
+	abiJSON, err := normalizeABI(meta.ABI)
+	if err != nil {
+		return err
+	}
+	if _, err := ParseABI(abiJSON); err != nil {
+		return err
+	}
+
+	meta.ABI = abiJSON
+	meta.Origin = origin
+	meta.Verified = false
+	if len(meta.RuntimeBytecode) > 0 {
+		if err := r.verify(meta.Address, meta.RuntimeBytecode); err != nil {
+			return err
+		}
+		meta.Verified = true
+	}
+
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	r.db.WriteContractMetadata(meta.Address, data)
+	r.db.WriteContractABI(meta.Address, abiJSON)
+	return nil
+}
+
+func (r *Registry) verify(addr common.Address, bytecode []byte) error {
+	if r.code == nil {
+		return errors.New("the bytecode verification is not available")
+	}
+	code := r.code(addr)
+	if len(code) == 0 {
+		return errNoCode
+	}
+	if !bytes.Equal(stripMetadataHash(code), stripMetadataHash(bytecode)) {
+		return errBytecodeMismatch
+	}
+	return nil
+}
+
+// stripMetadataHash removes the CBOR-encoded compiler metadata appended to the bytecode by
+// solc, whose length is given by the last 2 bytes, so that the bytecodes compiled from the
+// same source with different metadata are regarded as the same.
+func stripMetadataHash(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	n := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	start := len(code) - 2 - n
+	// the metadata is a CBOR map, whose major type is 5
+	if n == 0 || start < 0 || code[start]&0xe0 != 0xa0 {
+		return code
+	}
+	return code[:start]
+}
+
+// Metadata returns the metadata of the contract, or nil if not registered.
+func (r *Registry) Metadata(addr common.Address) *Metadata {
+	data := r.db.ReadContractMetadata(addr)
+	if data == nil {
+		return nil
+	}
+	meta := new(Metadata)
+	if err := json.Unmarshal(data, meta); err != nil {
+		return nil
+	}
+	return meta
+}
+
+// ABI returns the parsed ABI registered for the contract, or nil if not registered.
+func (r *Registry) ABI(addr common.Address) *abi.ABI {
+	data := r.db.ReadContractABI(addr)
+	if data == nil {
+		return nil
+	}
+	parsed, err := ParseABI(data)
+	if err != nil {
+		return nil
+	}
+	return parsed
+}
+
+// Remove removes the metadata and the ABI of the contract.
+func (r *Registry) Remove(addr common.Address) bool {
+	if r.db.ReadContractMetadata(addr) == nil && r.db.ReadContractABI(addr) == nil {
+		return false
+	}
+	r.db.DeleteContractMetadata(addr)
+	r.db.DeleteContractABI(addr)
+	return true
+}
+
+// ImportRegistryLog registers the metadata carried by a ContractRegistered event of a
+// registry contract. The contract address of the event overrides the one in the metadata.
+func (r *Registry) ImportRegistryLog(log *types.Log) (common.Address, error) {
+	if len(log.Topics) != 2 || log.Topics[0] != RegistryEventTopic {
+		return common.Address{}, errNotRegistryEvent
+	}
+	addr := common.BytesToAddress(log.Topics[1].Bytes())
+	typ, _ := abi.NewType("string", "", nil)
+	unpacked, err := (abi.Arguments{{Type: typ}}).Unpack(log.Data)
+	if err != nil {
+		return addr, err
+	}
+	meta := new(Metadata)
+	if err := json.Unmarshal([]byte(unpacked[0].(string)), meta); err != nil {
+		return addr, fmt.Errorf("invalid metadata: %v", err)
+	}
+	meta.Address = addr
+	return addr, r.Register(meta, OriginRegistry)
+}
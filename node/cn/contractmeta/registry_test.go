@@ -0,0 +1,128 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package contractmeta
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/klaytn/klaytn/accounts/abi"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tokenABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256"}]},
+	{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}
+]`
+
+var (
+	runtimeCode = common.FromHex("0x6080604052600080fd")
+	// runtimeCode with the CBOR metadata {1: 2} appended by the compiler
+	deployedCode = append(common.CopyBytes(runtimeCode), 0xa1, 0x01, 0x02, 0x00, 0x03)
+)
+
+func TestStripMetadataHash(t *testing.T) {
+	assert.Equal(t, runtimeCode, stripMetadataHash(deployedCode))
+	assert.Equal(t, runtimeCode, stripMetadataHash(append(common.CopyBytes(runtimeCode), 0xa1, 0x03, 0x04, 0x00, 0x03)))
+	// not a metadata trailer
+	assert.Equal(t, runtimeCode, stripMetadataHash(runtimeCode))
+	assert.Equal(t, []byte{0x00}, stripMetadataHash([]byte{0x00}))
+}
+
+func TestRegistry_Register(t *testing.T) {
+	var (
+		dbm      = database.NewMemoryDBManager()
+		token    = common.HexToAddress("0x1000")
+		registry = NewRegistry(dbm, func(addr common.Address) []byte {
+			if addr == token {
+				return deployedCode
+			}
+			return nil
+		})
+	)
+
+	assert.Equal(t, errNoABI, registry.Register(&Metadata{Address: token}, OriginRPC))
+	assert.Error(t, registry.Register(&Metadata{Address: token, ABI: json.RawMessage(`{}`)}, OriginRPC))
+
+	// the bundle without bytecode is stored unverified
+	quoted, _ := json.Marshal(tokenABI)
+	require.NoError(t, registry.Register(&Metadata{Address: token, Name: "Token", ABI: quoted}, OriginRPC))
+	meta := registry.Metadata(token)
+	require.NotNil(t, meta)
+	assert.Equal(t, "Token", meta.Name)
+	assert.False(t, meta.Verified)
+	assert.Equal(t, OriginRPC, meta.Origin)
+	require.NotNil(t, registry.ABI(token))
+	assert.Contains(t, registry.ABI(token).Methods, "transfer")
+
+	// the bundle with bytecode is verified against the deployed code
+	bundle := &Metadata{
+		Address:         token,
+		Name:            "Token",
+		Compiler:        "0.8.19",
+		Sources:         map[string]string{"Token.sol": "contract Token {}"},
+		ABI:             json.RawMessage(tokenABI),
+		RuntimeBytecode: append(common.CopyBytes(runtimeCode), 0xa1, 0x03, 0x04, 0x00, 0x03),
+	}
+	require.NoError(t, registry.Register(bundle, OriginRPC))
+	meta = registry.Metadata(token)
+	assert.True(t, meta.Verified)
+	assert.Equal(t, bundle.Sources, meta.Sources)
+
+	bundle.RuntimeBytecode = common.FromHex("0x6080604052600160")
+	assert.Equal(t, errBytecodeMismatch, registry.Register(bundle, OriginRPC))
+	bundle.Address = common.HexToAddress("0x2000")
+	assert.Equal(t, errNoCode, registry.Register(bundle, OriginRPC))
+	assert.Error(t, NewRegistry(dbm, nil).Register(bundle, OriginRPC))
+	assert.Nil(t, registry.Metadata(bundle.Address))
+
+	assert.True(t, registry.Remove(token))
+	assert.Nil(t, registry.Metadata(token))
+	assert.Nil(t, registry.ABI(token))
+	assert.False(t, registry.Remove(token))
+}
+
+func TestRegistry_ImportRegistryLog(t *testing.T) {
+	var (
+		dbm      = database.NewMemoryDBManager()
+		token    = common.HexToAddress("0x1000")
+		registry = NewRegistry(dbm, nil)
+	)
+	metadata, err := json.Marshal(&Metadata{Address: common.HexToAddress("0xdead"), Name: "Token", ABI: json.RawMessage(tokenABI)})
+	require.NoError(t, err)
+	typ, _ := abi.NewType("string", "", nil)
+	data, err := (abi.Arguments{{Type: typ}}).Pack(string(metadata))
+	require.NoError(t, err)
+
+	log := &types.Log{Topics: []common.Hash{RegistryEventTopic, common.BytesToHash(token.Bytes())}, Data: data}
+	addr, err := registry.ImportRegistryLog(log)
+	require.NoError(t, err)
+	assert.Equal(t, token, addr)
+	meta := registry.Metadata(token)
+	require.NotNil(t, meta)
+	assert.Equal(t, token, meta.Address)
+	assert.Equal(t, OriginRegistry, meta.Origin)
+	assert.Nil(t, registry.Metadata(common.HexToAddress("0xdead")))
+
+	_, err = registry.ImportRegistryLog(&types.Log{Topics: []common.Hash{{}}})
+	assert.Equal(t, errNotRegistryEvent, err)
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// epochSummaryIndexer subscribes chainEvent and accumulates the rewards and the burnt fees
+// of each block into the summary of its epoch. An epoch is summarized only if its first
+// block is inserted while the indexer is running, so the epoch in progress when the
+// indexing is enabled is not summarized.
+func epochSummaryIndexer(db database.DBManager, bc *blockchain.BlockChain, gov governance.Engine, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+
+	for {
+		select {
+		case event := <-chainEvent:
+			if err := summarizeEpoch(db, bc, gov, event.Block.Header()); err != nil {
+				logger.Error("Failed to summarize the epoch", "blockNum", event.Block.Number(), "err", err)
+			}
+
+		case <-subscription.Err():
+			return
+		}
+	}
+}
+
+// summarizeEpoch adds the given block to the summary of its epoch. The summary is created
+// at the first block of the epoch with the governance parameters and the validators of the block.
+// If the summarized blocks have been abandoned by a chain reorganization, the epoch is summarized
+// again along the canonical chain.
+func summarizeEpoch(db database.DBManager, bc *blockchain.BlockChain, gov governance.Engine, header *types.Header) error {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return nil
+	}
+	pset, err := gov.EffectiveParams(number)
+	if err != nil {
+		return err
+	}
+	epochLen := pset.Epoch()
+	epoch := number / epochLen
+	start := epoch * epochLen
+	if start == 0 {
+		start = 1 // the genesis block is not inserted
+	}
+
+	summary := db.ReadEpochSummary(epoch)
+	switch {
+	case summary == nil && number != start:
+		return nil // the start of the epoch has been missed
+	case summary == nil:
+		summary = newEpochSummary(epoch, start, pset, header)
+	case summary.EndBlock >= number || (summary.EndBlock+1 == number && summary.EndHash != header.ParentHash):
+		return resummarizeEpoch(db, bc, gov, summary, number)
+	case summary.EndBlock+1 != number:
+		return nil // a block has been missed
+	}
+
+	if err := addToEpochSummary(summary, bc, gov, header, epochLen); err != nil {
+		return err
+	}
+	db.WriteEpochSummary(summary)
+	return nil
+}
+
+// resummarizeEpoch summarizes the epoch of the given summary again from its start block up to the
+// given block number along the canonical chain.
+func resummarizeEpoch(db database.DBManager, bc *blockchain.BlockChain, gov governance.Engine, summary *database.EpochSummary, number uint64) error {
+	logger.Info("Summarizing the epoch again after a chain reorganization", "epoch", summary.Epoch, "from", summary.StartBlock, "to", number)
+	var rebuilt *database.EpochSummary
+	for num := summary.StartBlock; num <= number; num++ {
+		header := bc.GetHeaderByNumber(num)
+		if header == nil {
+			return consensus.ErrUnknownAncestor
+		}
+		pset, err := gov.EffectiveParams(num)
+		if err != nil {
+			return err
+		}
+		if rebuilt == nil {
+			rebuilt = newEpochSummary(summary.Epoch, summary.StartBlock, pset, header)
+		}
+		if err := addToEpochSummary(rebuilt, bc, gov, header, pset.Epoch()); err != nil {
+			return err
+		}
+	}
+	db.WriteEpochSummary(rebuilt)
+	return nil
+}
+
+// newEpochSummary returns an empty summary of the epoch starting at the given block.
+func newEpochSummary(epoch, start uint64, pset *params.GovParamSet, header *types.Header) *database.EpochSummary {
+	summary := &database.EpochSummary{
+		Epoch:      epoch,
+		StartBlock: start,
+		Params:     pset.StrMap(),
+		Minted:     new(big.Int),
+		TotalFee:   new(big.Int),
+		Rewards:    new(big.Int),
+		Burnt:      new(big.Int),
+	}
+	if extra, err := types.ExtractIstanbulExtra(header); err == nil {
+		summary.Validators = extra.Validators
+	}
+	return summary
+}
+
+// addToEpochSummary adds the rewards and the burnt fees of the given block to the summary.
+func addToEpochSummary(summary *database.EpochSummary, bc *blockchain.BlockChain, gov governance.Engine, header *types.Header, epochLen uint64) error {
+	number := header.Number.Uint64()
+	rules := bc.Config().Rules(header.Number)
+	pset, err := gov.EffectiveParams(reward.CalcRewardParamBlock(number, epochLen, rules))
+	if err != nil {
+		return err
+	}
+	spec, err := reward.GetBlockReward(header, rules, pset)
+	if err != nil {
+		return err
+	}
+	summary.Minted.Add(summary.Minted, spec.Minted)
+	summary.TotalFee.Add(summary.TotalFee, spec.TotalFee)
+	summary.Burnt.Add(summary.Burnt, spec.BurntFee)
+	if spec.BurntRemainder != nil {
+		summary.Burnt.Add(summary.Burnt, spec.BurntRemainder)
+	}
+	for _, amount := range spec.Rewards {
+		summary.Rewards.Add(summary.Rewards, amount)
+	}
+	summary.EndBlock = number
+	summary.EndHash = header.Hash()
+	summary.Completed = (number+1)%epochLen == 0
+	return nil
+}
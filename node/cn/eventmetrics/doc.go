@@ -0,0 +1,27 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package eventmetrics implements operator-defined metrics updated by the events of the imported
+blocks, e.g. the number of bridge deposits or the sum of the values transferred by a token,
+so that basic business metrics are exported by the node without an external indexer.
+
+Source Files
+
+  - rule.go     : defines the rules matching the logs and the values read from them
+  - registry.go : loads the rules and updates their metrics with the logs of imported blocks
+*/
+package eventmetrics
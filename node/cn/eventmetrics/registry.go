@@ -0,0 +1,115 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package eventmetrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/node/cn/filters"
+	"github.com/rcrowley/go-metrics"
+)
+
+// metricPrefix is prepended to the names of the rules to register their metrics.
+const metricPrefix = "klay/events/"
+
+// metric is a rule with the metric it updates.
+type metric struct {
+	rule    Rule
+	crit    filters.FilterCriteria
+	counter metrics.Counter      // updated by counter rules
+	gauge   metrics.GaugeFloat64 // updated by sum and gauge rules
+}
+
+// Registry updates the metrics of the rules with the logs of the imported blocks. The logs of
+// the blocks abandoned by chain reorganizations are not subtracted from the metrics.
+type Registry struct {
+	metrics []*metric
+}
+
+// Load reads the rules from the JSON file of a rule array, e.g.
+//
+//	[{"name": "bridge_deposits", "type": "counter", "addresses": ["0x..."], "event": "Deposit(address,uint256)"},
+//	 {"name": "token_transferred", "type": "sum", "addresses": ["0x..."], "event": "Transfer(address,address,uint256)",
+//	  "value": {"word": 0, "decimals": 18}}]
+//
+// and registers their metrics to the default metrics registry.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("invalid event metric file %s: %v", path, err)
+	}
+	return New(rules, metrics.DefaultRegistry)
+}
+
+// New validates the rules and registers their metrics to the given metrics registry.
+func New(rules []Rule, r metrics.Registry) (*Registry, error) {
+	names := make(map[string]bool)
+	for i := range rules {
+		if err := rules[i].validate(); err != nil {
+			return nil, err
+		}
+		if names[rules[i].Name] {
+			return nil, fmt.Errorf("duplicate metric name %s", rules[i].Name)
+		}
+		names[rules[i].Name] = true
+	}
+
+	registry := &Registry{}
+	for _, rule := range rules {
+		m := &metric{rule: rule, crit: rule.criteria()}
+		name := metricPrefix + rule.Name
+		if rule.Type == TypeCounter {
+			m.counter = metrics.NewRegisteredCounter(name, r)
+		} else {
+			m.gauge = metrics.NewRegisteredGaugeFloat64(name, r)
+		}
+		registry.metrics = append(registry.metrics, m)
+	}
+	return registry, nil
+}
+
+// Len returns the number of the rules.
+func (r *Registry) Len() int {
+	return len(r.metrics)
+}
+
+// Process updates the metrics with the logs of an imported block.
+func (r *Registry) Process(logs []*types.Log) {
+	for _, m := range r.metrics {
+		for _, log := range filters.FilterLogs(logs, m.crit) {
+			if m.counter != nil {
+				m.counter.Inc(1)
+				continue
+			}
+			value, ok := m.rule.Value.value(log)
+			if !ok {
+				continue
+			}
+			if m.rule.Type == TypeSum {
+				value += m.gauge.Value()
+			}
+			m.gauge.Update(value)
+		}
+	}
+}
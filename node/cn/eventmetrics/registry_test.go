@@ -0,0 +1,136 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package eventmetrics
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	token       = common.HexToAddress("0x7070")
+	bridge      = common.HexToAddress("0xb1d9e")
+	alice       = common.HexToAddress("0xa11ce")
+	transferSig = "Transfer(address,address,uint256)"
+	depositSig  = "Deposit(address,uint256)"
+)
+
+func intPtr(i int) *int { return &i }
+
+func transferLog(to common.Address, amount *big.Int) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics:  []common.Hash{crypto.Keccak256Hash([]byte(transferSig)), common.BytesToHash(bridge.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.BigToHash(amount).Bytes(),
+	}
+}
+
+func depositLog(amount int64) *types.Log {
+	return &types.Log{
+		Address: bridge,
+		Topics:  []common.Hash{crypto.Keccak256Hash([]byte(depositSig)), common.BigToHash(big.NewInt(amount))},
+	}
+}
+
+func TestRegistry_Process(t *testing.T) {
+	r := metrics.NewRegistry()
+	registry, err := New([]Rule{
+		{Name: "deposits", Type: TypeCounter, Addresses: []common.Address{bridge}, Event: depositSig},
+		{Name: "deposited", Type: TypeSum, Event: depositSig, Value: &ValueSource{Topic: intPtr(1)}},
+		{Name: "transferred", Type: TypeSum, Addresses: []common.Address{token}, Event: transferSig, Value: &ValueSource{Word: intPtr(0), Decimals: 18}},
+		{Name: "to_alice", Type: TypeCounter, Event: transferSig, Topics: [][]common.Hash{nil, nil, {common.BytesToHash(alice.Bytes())}}},
+		{Name: "last_deposit", Type: TypeGauge, Addresses: []common.Address{bridge}, Value: &ValueSource{Topic: intPtr(1)}},
+	}, r)
+	require.NoError(t, err)
+	assert.Equal(t, 5, registry.Len())
+
+	klay := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	registry.Process([]*types.Log{
+		depositLog(3),
+		transferLog(alice, new(big.Int).Mul(big.NewInt(2), klay)),
+		transferLog(bridge, new(big.Int).Div(klay, big.NewInt(2))),
+		depositLog(5),
+	})
+	registry.Process(nil)
+	registry.Process([]*types.Log{depositLog(4)})
+
+	assert.Equal(t, int64(3), r.Get("klay/events/deposits").(metrics.Counter).Count())
+	assert.Equal(t, float64(12), r.Get("klay/events/deposited").(metrics.GaugeFloat64).Value())
+	assert.Equal(t, 2.5, r.Get("klay/events/transferred").(metrics.GaugeFloat64).Value())
+	assert.Equal(t, int64(1), r.Get("klay/events/to_alice").(metrics.Counter).Count())
+	assert.Equal(t, float64(4), r.Get("klay/events/last_deposit").(metrics.GaugeFloat64).Value())
+}
+
+func TestRegistry_ProcessMissingValue(t *testing.T) {
+	r := metrics.NewRegistry()
+	registry, err := New([]Rule{
+		{Name: "word", Type: TypeSum, Value: &ValueSource{Word: intPtr(1)}},
+		{Name: "topic", Type: TypeGauge, Value: &ValueSource{Topic: intPtr(5)}},
+	}, r)
+	require.NoError(t, err)
+
+	// the logs without the word or the topic are skipped
+	registry.Process([]*types.Log{depositLog(1), transferLog(alice, big.NewInt(1))})
+	assert.Equal(t, float64(0), r.Get("klay/events/word").(metrics.GaugeFloat64).Value())
+	assert.Equal(t, float64(0), r.Get("klay/events/topic").(metrics.GaugeFloat64).Value())
+}
+
+func TestNew_InvalidRules(t *testing.T) {
+	for name, rules := range map[string][]Rule{
+		"empty name":     {{Name: "", Type: TypeCounter}},
+		"invalid name":   {{Name: "a/b", Type: TypeCounter}},
+		"unknown type":   {{Name: "a", Type: "histogram"}},
+		"no value":       {{Name: "a", Type: TypeSum}},
+		"both sources":   {{Name: "a", Type: TypeGauge, Value: &ValueSource{Topic: intPtr(1), Word: intPtr(0)}}},
+		"no source":      {{Name: "a", Type: TypeGauge, Value: &ValueSource{Decimals: 18}}},
+		"negative index": {{Name: "a", Type: TypeGauge, Value: &ValueSource{Word: intPtr(-1)}}},
+		"duplicate name": {{Name: "a", Type: TypeCounter}, {Name: "a", Type: TypeCounter}},
+	} {
+		_, err := New(rules, metrics.NewRegistry())
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"name": "load_test_deposits", "type": "counter", "addresses": ["0x00000000000000000000000000000000000b1d9e"], "event": "Deposit(address,uint256)"},
+		{"name": "load_test_deposited", "type": "sum", "event": "Deposit(address,uint256)", "value": {"topic": 1, "decimals": 1}}
+	]`), 0o644))
+
+	registry, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, registry.Len())
+	registry.Process([]*types.Log{depositLog(15)})
+	assert.Equal(t, int64(1), metrics.DefaultRegistry.Get("klay/events/load_test_deposits").(metrics.Counter).Count())
+	assert.Equal(t, 1.5, metrics.DefaultRegistry.Get("klay/events/load_test_deposited").(metrics.GaugeFloat64).Value())
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"name": "not an array"}`), 0o644))
+	_, err = Load(path)
+	assert.Error(t, err)
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package eventmetrics
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"github.com/klaytn/klaytn"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/node/cn/filters"
+)
+
+// The types of the metrics updated by the matching logs.
+const (
+	TypeCounter = "counter" // counts the matching logs
+	TypeSum     = "sum"     // sums the values of the matching logs
+	TypeGauge   = "gauge"   // holds the value of the last matching log
+)
+
+var (
+	validName = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+	errNoValue      = errors.New("the value is required for sum and gauge metrics")
+	errInvalidValue = errors.New("the value should be read from either a topic or a data word")
+)
+
+// Rule defines a metric updated by the logs matching the addresses and the topics, which are
+// applied in the way of klay_getLogs. If Event is set, its signature hash is required as the
+// first topic in place of Topics[0], e.g. "Transfer(address,address,uint256)".
+type Rule struct {
+	Name      string           `json:"name"` // registered as klay/events/<name>
+	Type      string           `json:"type"`
+	Event     string           `json:"event,omitempty"`
+	Addresses []common.Address `json:"addresses,omitempty"`
+	Topics    [][]common.Hash  `json:"topics,omitempty"`
+	Value     *ValueSource     `json:"value,omitempty"` // required for sum and gauge metrics
+}
+
+// ValueSource locates the unsigned 256-bit integer read from a matching log, which is either
+// the topic of the index or the 32-byte word of the index in the data. The value is divided
+// by 10^Decimals, e.g. 18 for the token amounts in peb.
+type ValueSource struct {
+	Topic    *int `json:"topic,omitempty"`
+	Word     *int `json:"word,omitempty"`
+	Decimals uint `json:"decimals,omitempty"`
+}
+
+// criteria returns the filter criteria of the rule.
+func (r *Rule) criteria() filters.FilterCriteria {
+	topics := r.Topics
+	if r.Event != "" {
+		topics = [][]common.Hash{{crypto.Keccak256Hash([]byte(r.Event))}}
+		if len(r.Topics) > 1 {
+			topics = append(topics, r.Topics[1:]...)
+		}
+	}
+	return filters.FilterCriteria(klaytn.FilterQuery{Addresses: r.Addresses, Topics: topics})
+}
+
+// validate returns an error if the rule cannot be applied.
+func (r *Rule) validate() error {
+	if !validName.MatchString(r.Name) {
+		return fmt.Errorf("invalid metric name %q: only letters, digits and underscores are allowed", r.Name)
+	}
+	switch r.Type {
+	case TypeCounter:
+		return nil
+	case TypeSum, TypeGauge:
+	default:
+		return fmt.Errorf("invalid type %q of metric %s: expected %s, %s or %s", r.Type, r.Name, TypeCounter, TypeSum, TypeGauge)
+	}
+	if r.Value == nil {
+		return fmt.Errorf("metric %s: %v", r.Name, errNoValue)
+	}
+	if (r.Value.Topic == nil) == (r.Value.Word == nil) {
+		return fmt.Errorf("metric %s: %v", r.Name, errInvalidValue)
+	}
+	if (r.Value.Topic != nil && *r.Value.Topic < 0) || (r.Value.Word != nil && *r.Value.Word < 0) {
+		return fmt.Errorf("metric %s: negative value index", r.Name)
+	}
+	return nil
+}
+
+// value returns the value read from the log, or false if the log has no such topic or word.
+func (s *ValueSource) value(log *types.Log) (float64, bool) {
+	var word []byte
+	switch {
+	case s.Topic != nil:
+		if *s.Topic >= len(log.Topics) {
+			return 0, false
+		}
+		word = log.Topics[*s.Topic].Bytes()
+	case s.Word != nil:
+		start := *s.Word * common.HashLength
+		if start+common.HashLength > len(log.Data) {
+			return 0, false
+		}
+		word = log.Data[start : start+common.HashLength]
+	}
+	value := new(big.Float).SetInt(new(big.Int).SetBytes(word))
+	if s.Decimals > 0 {
+		unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(s.Decimals)), nil)
+		value.Quo(value, new(big.Float).SetInt(unit))
+	}
+	f, _ := value.Float64()
+	return f, true
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+)
+
+// feeAuditor subscribes chainEvent and cross-checks the total fee of each block estimated
+// by the reward module with the fees collected from its transactions after the refunds.
+// The diverged blocks are logged and counted by feeDivergenceMeter to be alerted.
+func feeAuditor(bc *blockchain.BlockChain, gov governance.Engine, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+
+	for {
+		select {
+		case event := <-chainEvent:
+			header := event.Block.Header()
+			audit, err := auditBlockFee(bc.Config(), gov, header, event.Block.Transactions(), event.Receipts)
+			if err != nil {
+				logger.Error("Failed to audit the block fee", "blockNum", header.Number, "err", err)
+				continue
+			}
+			if audit.Diverged() {
+				logger.Error("The estimated block fee diverged from the collected fees", "blockNum", header.Number,
+					"hash", header.Hash(), "gasUsed", audit.GasUsed, "receiptGasUsed", audit.ReceiptGasUsed,
+					"estimated", audit.EstimatedFee, "collected", audit.CollectedFee, "difference", audit.Difference())
+				feeDivergenceMeter.Mark(1)
+			}
+
+		case <-subscription.Err():
+			return
+		}
+	}
+}
+
+// auditBlockFee audits the fees of the block with the parameters with which its reward is calculated.
+func auditBlockFee(config *params.ChainConfig, gov governance.Engine, header *types.Header, txs types.Transactions, receipts types.Receipts) (*reward.FeeAudit, error) {
+	pset, err := rewardParams(config, gov, header.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	return reward.AuditTxFee(config, header, txs, receipts, pset)
+}
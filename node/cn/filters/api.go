@@ -41,7 +41,7 @@ import (
 )
 
 var (
-	defaultFilterDeadline = 5 * time.Minute // consider a filter inactive if it has not been polled for within deadline
+	FilterTimeout = 5 * time.Minute // consider a filter inactive if it has not been polled for within the timeout
 
 	getLogsCxtKeyMaxItems = "maxItems"       // the value of the context key should have the type of GetLogsMaxItems
 	GetLogsDeadline       = 10 * time.Second // execution deadlines for getLogs and getFilterLogs APIs
@@ -57,6 +57,7 @@ type filter struct {
 	crit     FilterCriteria
 	logs     []*types.Log
 	s        *Subscription // associated subscription in event system
+	client   string        // host of the client which installed the filter
 }
 
 // PublicFilterAPI offers support to create and manage filters. This will allow external clients to retrieve various
@@ -70,8 +71,15 @@ type PublicFilterAPI struct {
 	filtersMu sync.Mutex
 	filters   map[rpc.ID]*filter
 
-	// this field is for test. it makes the filter timeout more flexible when testing
-	timeout time.Duration
+	clients    map[string]*clientUsage // filters and subscriptions of each client
+	expired    map[rpc.ID]string       // reasons of the recently expired filters
+	expiredIDs []rpc.ID                // recently expired filters in the order of expiration
+
+	// these fields are for test. they make the filter timeout and limits more flexible when testing
+	timeout          time.Duration
+	maxItems         int // maximum number of the unpolled items of a filter
+	maxFilters       int // maximum number of the filters of a client
+	maxSubscriptions int // maximum number of the subscriptions of a client
 }
 
 // NewPublicFilterAPI returns a new PublicFilterAPI instance.
@@ -82,18 +90,29 @@ func NewPublicFilterAPI(backend Backend, lightMode bool) *PublicFilterAPI {
 		chainDB: backend.ChainDB(),
 		events:  NewEventSystem(backend.EventMux(), backend, lightMode),
 		filters: make(map[rpc.ID]*filter),
-		timeout: defaultFilterDeadline,
+		clients: make(map[string]*clientUsage),
+		expired: make(map[rpc.ID]string),
+		timeout: FilterTimeout,
+
+		maxItems:         MaxFilterItems,
+		maxFilters:       MaxFiltersPerClient,
+		maxSubscriptions: MaxSubscriptionsPerClient,
 	}
 	go api.timeoutLoop()
 
 	return api
 }
 
-// timeoutLoop runs every 5 minutes and deletes filters that have not been recently used.
-// Tt is started when the api is created.
+// timeoutLoop deletes filters that have not been recently used. It sweeps the filters ten
+// times per timeout, so that a stale filter does not outlive the timeout for long.
+// It is started when the api is created.
 func (api *PublicFilterAPI) timeoutLoop() {
 	var toUninstall []*Subscription
-	ticker := time.NewTicker(api.timeout)
+	interval := api.timeout / 10
+	if interval <= 0 {
+		interval = api.timeout
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 	for {
 		<-ticker.C
@@ -101,8 +120,8 @@ func (api *PublicFilterAPI) timeoutLoop() {
 		for id, f := range api.filters {
 			select {
 			case <-f.deadline.C:
+				api.uninstallLocked(id, expiredByTimeout)
 				toUninstall = append(toUninstall, f.s)
-				delete(api.filters, id)
 			default:
 				continue
 			}
@@ -119,40 +138,94 @@ func (api *PublicFilterAPI) timeoutLoop() {
 	}
 }
 
+// installFilter registers a polling filter of the client of the call. The subscription of
+// the filter is made by subscribe only if the client has not reached the limit.
+func (api *PublicFilterAPI) installFilter(ctx context.Context, f *filter, subscribe func() (*Subscription, error)) (rpc.ID, error) {
+	f.client = clientHost(ctx)
+
+	api.filtersMu.Lock()
+	err := api.reserve(f.client, false)
+	api.filtersMu.Unlock()
+	if err != nil {
+		return rpc.ID(""), err
+	}
+
+	s, err := subscribe()
+	if err != nil {
+		api.filtersMu.Lock()
+		api.release(f.client, false)
+		api.filtersMu.Unlock()
+		return rpc.ID(""), err
+	}
+	f.s = s
+	f.deadline = time.NewTimer(api.timeout)
+
+	api.filtersMu.Lock()
+	api.filters[s.ID] = f
+	activeFiltersCounter.Inc(1)
+	api.filtersMu.Unlock()
+	return s.ID, nil
+}
+
+// buffer adds the received items to the filter of the given id by add, which returns the
+// number of the unpolled items. It returns true if the filter expired by having more than
+// the limit unpolled, in which case the caller has to unsubscribe it.
+func (api *PublicFilterAPI) buffer(id rpc.ID, add func(f *filter) int) bool {
+	api.filtersMu.Lock()
+	defer api.filtersMu.Unlock()
+
+	f, found := api.filters[id]
+	if !found || add(f) <= api.maxItems || api.maxItems <= 0 {
+		return false
+	}
+	api.uninstallLocked(id, expiredByOverflow)
+	return true
+}
+
+// removeFilter removes the filter of the given id whose subscription has ended.
+func (api *PublicFilterAPI) removeFilter(id rpc.ID) {
+	api.filtersMu.Lock()
+	api.uninstallLocked(id, "")
+	api.filtersMu.Unlock()
+}
+
 // NewPendingTransactionFilter creates a filter that fetches pending transaction hashes
 // as transactions enter the pending state.
 //
 // It is part of the filter package because this filter can be used through the
 // `klay_getFilterChanges` polling method that is also used for log filters.
-func (api *PublicFilterAPI) NewPendingTransactionFilter() rpc.ID {
+func (api *PublicFilterAPI) NewPendingTransactionFilter(ctx context.Context) (rpc.ID, error) {
 	var (
 		pendingTxs   = make(chan []common.Hash)
-		pendingTxSub = api.events.SubscribePendingTxs(pendingTxs)
+		pendingTxSub *Subscription
 	)
-
-	api.filtersMu.Lock()
-	api.filters[pendingTxSub.ID] = &filter{typ: PendingTransactionsSubscription, deadline: time.NewTimer(api.timeout), hashes: make([]common.Hash, 0), s: pendingTxSub}
-	api.filtersMu.Unlock()
+	id, err := api.installFilter(ctx, &filter{typ: PendingTransactionsSubscription, hashes: make([]common.Hash, 0)}, func() (*Subscription, error) {
+		pendingTxSub = api.events.SubscribePendingTxs(pendingTxs)
+		return pendingTxSub, nil
+	})
+	if err != nil {
+		return id, err
+	}
 
 	go func() {
 		for {
 			select {
 			case ph := <-pendingTxs:
-				api.filtersMu.Lock()
-				if f, found := api.filters[pendingTxSub.ID]; found {
+				if api.buffer(id, func(f *filter) int {
 					f.hashes = append(f.hashes, ph...)
+					return len(f.hashes)
+				}) {
+					pendingTxSub.Unsubscribe()
+					return
 				}
-				api.filtersMu.Unlock()
 			case <-pendingTxSub.Err():
-				api.filtersMu.Lock()
-				delete(api.filters, pendingTxSub.ID)
-				api.filtersMu.Unlock()
+				api.removeFilter(id)
 				return
 			}
 		}
 	}()
 
-	return pendingTxSub.ID
+	return id, nil
 }
 
 // NewPendingTransactions creates a subscription that is triggered each time a transaction
@@ -162,10 +235,15 @@ func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Su
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	release, err := api.ReserveSubscription(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	rpcSub := notifier.CreateSubscription()
 
 	go func() {
+		defer release()
 		txHashes := make(chan []common.Hash, 128)
 		pendingTxSub := api.events.SubscribePendingTxs(txHashes)
 
@@ -175,7 +253,11 @@ func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Su
 				// To keep the original behaviour, send a single tx hash in one notification.
 				// TODO(rjl493456442) Send a batch of tx hashes in one notification
 				for _, h := range hashes {
-					notifier.Notify(rpcSub.ID, h)
+					if err := notifier.Notify(rpcSub.ID, h); err != nil {
+						subscriptionDeadCounter.Inc(1)
+						pendingTxSub.Unsubscribe()
+						return
+					}
 				}
 			case <-rpcSub.Err():
 				pendingTxSub.Unsubscribe()
@@ -192,35 +274,38 @@ func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Su
 
 // NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
 // It is part of the filter package since polling goes with eth_getFilterChanges.
-func (api *PublicFilterAPI) NewBlockFilter() rpc.ID {
+func (api *PublicFilterAPI) NewBlockFilter(ctx context.Context) (rpc.ID, error) {
 	var (
 		headers   = make(chan *types.Header)
-		headerSub = api.events.SubscribeNewHeads(headers)
+		headerSub *Subscription
 	)
-
-	api.filtersMu.Lock()
-	api.filters[headerSub.ID] = &filter{typ: BlocksSubscription, deadline: time.NewTimer(api.timeout), hashes: make([]common.Hash, 0), s: headerSub}
-	api.filtersMu.Unlock()
+	id, err := api.installFilter(ctx, &filter{typ: BlocksSubscription, hashes: make([]common.Hash, 0)}, func() (*Subscription, error) {
+		headerSub = api.events.SubscribeNewHeads(headers)
+		return headerSub, nil
+	})
+	if err != nil {
+		return id, err
+	}
 
 	go func() {
 		for {
 			select {
 			case h := <-headers:
-				api.filtersMu.Lock()
-				if f, found := api.filters[headerSub.ID]; found {
+				if api.buffer(id, func(f *filter) int {
 					f.hashes = append(f.hashes, h.Hash())
+					return len(f.hashes)
+				}) {
+					headerSub.Unsubscribe()
+					return
 				}
-				api.filtersMu.Unlock()
 			case <-headerSub.Err():
-				api.filtersMu.Lock()
-				delete(api.filters, headerSub.ID)
-				api.filtersMu.Unlock()
+				api.removeFilter(id)
 				return
 			}
 		}
 	}()
 
-	return headerSub.ID
+	return id, nil
 }
 
 // RPCMarshalHeader converts the given header to the RPC output that includes Klaytn-specific fields.
@@ -255,6 +340,9 @@ func RPCMarshalHeader(head *types.Header, rules params.Rules) map[string]interfa
 		result["randomReveal"] = hexutil.Bytes(head.RandomReveal)
 		result["mixhash"] = hexutil.Bytes(head.MixHash)
 	}
+	if rules.IsStakingCommitment {
+		result["stakingInfoHash"] = head.StakingInfoHash
+	}
 
 	return result
 }
@@ -265,10 +353,15 @@ func (api *PublicFilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, er
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	release, err := api.ReserveSubscription(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	rpcSub := notifier.CreateSubscription()
 
 	go func() {
+		defer release()
 		headers := make(chan *types.Header)
 		headersSub := api.events.SubscribeNewHeads(headers)
 
@@ -276,7 +369,11 @@ func (api *PublicFilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, er
 			select {
 			case h := <-headers:
 				header := RPCMarshalHeader(h, api.backend.ChainConfig().Rules(h.Number))
-				notifier.Notify(rpcSub.ID, header)
+				if err := notifier.Notify(rpcSub.ID, header); err != nil {
+					subscriptionDeadCounter.Inc(1)
+					headersSub.Unsubscribe()
+					return
+				}
 			case <-rpcSub.Err():
 				headersSub.Unsubscribe()
 				return
@@ -296,6 +393,10 @@ func (api *PublicFilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	release, err := api.ReserveSubscription(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	var (
 		rpcSub      = notifier.CreateSubscription()
@@ -304,15 +405,21 @@ func (api *PublicFilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc
 
 	logsSub, err := api.events.SubscribeLogs(klaytn.FilterQuery(crit), matchedLogs)
 	if err != nil {
+		release()
 		return nil, err
 	}
 
 	go func() {
+		defer release()
 		for {
 			select {
 			case logs := <-matchedLogs:
 				for _, log := range logs {
-					notifier.Notify(rpcSub.ID, &log)
+					if err := notifier.Notify(rpcSub.ID, &log); err != nil {
+						subscriptionDeadCounter.Inc(1)
+						logsSub.Unsubscribe()
+						return
+					}
 				}
 			case <-rpcSub.Err(): // client send an unsubscribe request
 				logsSub.Unsubscribe()
@@ -342,36 +449,38 @@ type FilterCriteria klaytn.FilterQuery
 // again but with the removed property set to true.
 //
 // In case "fromBlock" > "toBlock" an error is returned.
-func (api *PublicFilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
-	logs := make(chan []*types.Log)
-	logsSub, err := api.events.SubscribeLogs(klaytn.FilterQuery(crit), logs)
+func (api *PublicFilterAPI) NewFilter(ctx context.Context, crit FilterCriteria) (rpc.ID, error) {
+	var (
+		logs    = make(chan []*types.Log)
+		logsSub *Subscription
+	)
+	id, err := api.installFilter(ctx, &filter{typ: LogsSubscription, crit: crit, logs: make([]*types.Log, 0)}, func() (s *Subscription, err error) {
+		logsSub, err = api.events.SubscribeLogs(klaytn.FilterQuery(crit), logs)
+		return logsSub, err
+	})
 	if err != nil {
-		return rpc.ID(""), err
+		return id, err
 	}
 
-	api.filtersMu.Lock()
-	api.filters[logsSub.ID] = &filter{typ: LogsSubscription, crit: crit, deadline: time.NewTimer(api.timeout), logs: make([]*types.Log, 0), s: logsSub}
-	api.filtersMu.Unlock()
-
 	go func() {
 		for {
 			select {
 			case l := <-logs:
-				api.filtersMu.Lock()
-				if f, found := api.filters[logsSub.ID]; found {
+				if api.buffer(id, func(f *filter) int {
 					f.logs = append(f.logs, l...)
+					return len(f.logs)
+				}) {
+					logsSub.Unsubscribe()
+					return
 				}
-				api.filtersMu.Unlock()
 			case <-logsSub.Err():
-				api.filtersMu.Lock()
-				delete(api.filters, logsSub.ID)
-				api.filtersMu.Unlock()
+				api.removeFilter(id)
 				return
 			}
 		}
 	}()
 
-	return logsSub.ID, nil
+	return id, nil
 }
 
 // GetLogs returns logs matching the given argument that are stored within the state.
@@ -406,13 +515,105 @@ func (api *PublicFilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([
 	return returnLogs(logs), err
 }
 
+// getLogsPageBlocks is the number of the blocks searched at once by GetLogsPaged.
+const getLogsPageBlocks = 1000
+
+// logCursor is the position of the first log of the next page of GetLogsPaged.
+type logCursor struct {
+	BlockNumber uint64
+	Index       uint64 // index of the log in the block
+}
+
+// before returns true if the given log precedes the cursor.
+func (c *logCursor) before(log *types.Log) bool {
+	return log.BlockNumber < c.BlockNumber || (log.BlockNumber == c.BlockNumber && uint64(log.Index) < c.Index)
+}
+
+// GetLogsPaged returns a page of the logs matching the given argument. The block range is
+// searched in chunks, and a page can have less logs than the limit with HasMore set if the
+// search takes more than a half of the deadline, so that a wide range never times out.
+func (api *PublicFilterAPI) GetLogsPaged(ctx context.Context, crit FilterCriteria, page *rpc.PageArgs) (*rpc.Page, error) {
+	limit, err := page.PageLimit()
+	if err != nil {
+		return nil, err
+	}
+	cursor := new(logCursor)
+	if _, err := page.DecodeCursor(cursor); err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, getLogsCxtKeyMaxItems, GetLogsMaxItems)
+	ctx, cancelFnc := context.WithTimeout(ctx, GetLogsDeadline)
+	defer cancelFnc()
+
+	var logs []*types.Log
+	if crit.BlockHash != nil {
+		found, err := NewBlockFilter(api.backend, *crit.BlockHash, crit.Addresses, crit.Topics).Logs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range found {
+			if uint64(log.Index) >= cursor.Index {
+				logs = append(logs, log)
+			}
+		}
+		if len(logs) > limit {
+			next := &logCursor{BlockNumber: logs[limit].BlockNumber, Index: uint64(logs[limit].Index)}
+			return rpc.NewPage(logs[:limit], next)
+		}
+		return rpc.NewPage(returnLogs(logs), nil)
+	}
+
+	header, err := api.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	head := header.Number.Uint64()
+	begin, end := head, head
+	if crit.FromBlock != nil && crit.FromBlock.Sign() >= 0 { // negative numbers are the latest or pending block
+		begin = uint64(crit.FromBlock.Int64())
+	}
+	if crit.ToBlock != nil && crit.ToBlock.Sign() >= 0 {
+		end = uint64(crit.ToBlock.Int64())
+	}
+	if cursor.BlockNumber > begin {
+		begin = cursor.BlockNumber
+	}
+
+	started := time.Now()
+	for from := begin; from <= end; {
+		to := from + getLogsPageBlocks - 1
+		if to > end || to < from {
+			to = end
+		}
+		found, err := NewRangeFilter(api.backend, int64(from), int64(to), crit.Addresses, crit.Topics).Logs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range found {
+			if !cursor.before(log) {
+				logs = append(logs, log)
+			}
+		}
+		if len(logs) > limit {
+			next := &logCursor{BlockNumber: logs[limit].BlockNumber, Index: uint64(logs[limit].Index)}
+			return rpc.NewPage(logs[:limit], next)
+		}
+		if to == end {
+			break
+		}
+		from = to + 1
+		if time.Since(started) > GetLogsDeadline/2 {
+			return rpc.NewPage(returnLogs(logs), &logCursor{BlockNumber: from})
+		}
+	}
+	return rpc.NewPage(returnLogs(logs), nil)
+}
+
 // UninstallFilter removes the filter with the given filter id.
 func (api *PublicFilterAPI) UninstallFilter(id rpc.ID) bool {
 	api.filtersMu.Lock()
-	f, found := api.filters[id]
-	if found {
-		delete(api.filters, id)
-	}
+	f, found := api.uninstallLocked(id, "")
 	api.filtersMu.Unlock()
 	if found {
 		f.s.Unsubscribe()
@@ -429,11 +630,14 @@ func (api *PublicFilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*ty
 	defer cancelFnc()
 
 	api.filtersMu.Lock()
-	f, found := api.filters[id]
+	f, err := api.lookupLocked(id)
 	api.filtersMu.Unlock()
 
-	if !found || f.typ != LogsSubscription {
-		return nil, fmt.Errorf("filter not found")
+	if err != nil {
+		return nil, err
+	}
+	if f.typ != LogsSubscription {
+		return nil, errFilterNotFound
 	}
 
 	begin := rpc.LatestBlockNumber.Int64()
@@ -458,32 +662,34 @@ func (api *PublicFilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*ty
 // last time it was called. This can be used for polling.
 //
 // For pending transaction and block filters the result is []common.Hash.
-// (pending)Log filters return []Log.
+// (pending)Log filters return []Log. A recently expired filter fails with
+// errFilterExpired rather than errFilterNotFound, telling the client to reinstall it.
 func (api *PublicFilterAPI) GetFilterChanges(id rpc.ID) (interface{}, error) {
 	api.filtersMu.Lock()
 	defer api.filtersMu.Unlock()
 
-	if f, found := api.filters[id]; found {
-		if !f.deadline.Stop() {
-			// timer expired but filter is not yet removed in timeout loop
-			// receive timer value and reset timer
-			<-f.deadline.C
-		}
-		f.deadline.Reset(api.timeout)
-
-		switch f.typ {
-		case PendingTransactionsSubscription, BlocksSubscription:
-			hashes := f.hashes
-			f.hashes = nil
-			return returnHashes(hashes), nil
-		case LogsSubscription:
-			logs := f.logs
-			f.logs = nil
-			return returnLogs(logs), nil
-		}
+	f, err := api.lookupLocked(id)
+	if err != nil {
+		return []interface{}{}, err
 	}
-
-	return []interface{}{}, fmt.Errorf("filter not found")
+	if !f.deadline.Stop() {
+		// timer expired but filter is not yet removed in timeout loop
+		// receive timer value and reset timer
+		<-f.deadline.C
+	}
+	f.deadline.Reset(api.timeout)
+
+	switch f.typ {
+	case PendingTransactionsSubscription, BlocksSubscription:
+		hashes := f.hashes
+		f.hashes = nil
+		return returnHashes(hashes), nil
+	case LogsSubscription:
+		logs := f.logs
+		f.logs = nil
+		return returnLogs(logs), nil
+	}
+	return []interface{}{}, errFilterNotFound
 }
 
 // Events return private field events of PublicFilterAPI.
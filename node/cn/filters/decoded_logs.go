@@ -0,0 +1,107 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/klaytn/klaytn/accounts/abi"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/node/cn/contractmeta"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// DecodedLog is a log with the event and the arguments decoded by the contract ABI.
+// The event is empty if the ABI of the contract is unknown or does not match the log.
+type DecodedLog struct {
+	Log       *types.Log             `json:"log"`
+	Event     string                 `json:"event,omitempty"`
+	Signature string                 `json:"signature,omitempty"`
+	Args      map[string]interface{} `json:"args,omitempty"`
+	Error     string                 `json:"decodeError,omitempty"`
+}
+
+// GetDecodedLogs returns the logs matching the given filter criteria with the decoded events.
+// The logs are decoded by the given ABI if any, otherwise by the ABI registered for the
+// address of each log.
+func (api *PublicFilterAPI) GetDecodedLogs(ctx context.Context, crit FilterCriteria, contractABI *json.RawMessage) ([]*DecodedLog, error) {
+	var parsed *abi.ABI
+	if contractABI != nil && len(*contractABI) > 0 && string(*contractABI) != "null" {
+		var err error
+		if parsed, err = contractmeta.ParseABI(*contractABI); err != nil {
+			return nil, err
+		}
+	}
+	logs, err := api.GetLogs(ctx, crit)
+	if err != nil {
+		return nil, err
+	}
+	return decodeLogs(api.chainDB, logs, parsed), nil
+}
+
+// GetContractABI returns the ABI registered for the contract, or nil if not registered.
+func (api *PublicFilterAPI) GetContractABI(addr common.Address) json.RawMessage {
+	return api.chainDB.ReadContractABI(addr)
+}
+
+// decodeLogs decodes the logs by the given ABI, or the registered ABIs if it is nil.
+func decodeLogs(db database.DBManager, logs []*types.Log, contractABI *abi.ABI) []*DecodedLog {
+	registered := make(map[common.Address]*abi.ABI)
+	lookup := func(addr common.Address) *abi.ABI {
+		if contractABI != nil {
+			return contractABI
+		}
+		if parsed, ok := registered[addr]; ok {
+			return parsed
+		}
+		var parsed *abi.ABI
+		if data := db.ReadContractABI(addr); data != nil {
+			var err error
+			if parsed, err = contractmeta.ParseABI(data); err != nil {
+				logger.Warn("Failed to parse the registered contract ABI", "addr", addr, "err", err)
+			}
+		}
+		registered[addr] = parsed
+		return parsed
+	}
+
+	decoded := make([]*DecodedLog, len(logs))
+	for i, log := range logs {
+		decoded[i] = &DecodedLog{Log: log}
+		if parsed := lookup(log.Address); parsed != nil {
+			decodeLog(parsed, decoded[i])
+		}
+	}
+	return decoded
+}
+
+// decodeLog fills the event and the arguments of the log.
+func decodeLog(contractABI *abi.ABI, decoded *DecodedLog) {
+	event, args, err := contractmeta.DecodeLog(contractABI, decoded.Log)
+	if err != nil {
+		decoded.Error = err.Error()
+		return
+	}
+	if event == nil {
+		return // the log is emitted by an event unknown to the ABI
+	}
+	decoded.Event = event.Name
+	decoded.Signature = event.Sig
+	decoded.Args = args
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/node/cn/contractmeta"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transferABI = `[{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256"}]}]`
+
+func transferLog(contract, from, to common.Address, value int64) *types.Log {
+	return &types.Log{
+		Address: contract,
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.LeftPadBytes(big.NewInt(value).Bytes(), 32),
+	}
+}
+
+func TestDecodeLogs(t *testing.T) {
+	var (
+		dbm      = database.NewMemoryDBManager()
+		token    = common.HexToAddress("0x1000")
+		unknown  = common.HexToAddress("0x2000")
+		from     = common.HexToAddress("0xaaaa")
+		to       = common.HexToAddress("0xbbbb")
+		logs     = []*types.Log{transferLog(token, from, to, 100), transferLog(unknown, from, to, 200)}
+		expected = map[string]interface{}{"from": from, "to": to, "value": (*hexutil.Big)(big.NewInt(100))}
+	)
+
+	// decoded by the given ABI regardless of the address
+	parsed, err := contractmeta.ParseABI(json.RawMessage(transferABI))
+	require.NoError(t, err)
+	decoded := decodeLogs(dbm, logs, parsed)
+	require.Len(t, decoded, 2)
+	for _, log := range decoded {
+		assert.Equal(t, "Transfer", log.Event)
+		assert.Equal(t, "Transfer(address,address,uint256)", log.Signature)
+	}
+	assert.Equal(t, expected, decoded[0].Args)
+
+	// decoded by the registered ABI only
+	registry := contractmeta.NewRegistry(dbm, nil)
+	require.NoError(t, registry.Register(&contractmeta.Metadata{Address: token, ABI: json.RawMessage(transferABI)}, contractmeta.OriginRPC))
+	decoded = decodeLogs(dbm, logs, nil)
+	assert.Equal(t, "Transfer", decoded[0].Event)
+	assert.Equal(t, expected, decoded[0].Args)
+	assert.Empty(t, decoded[1].Event)
+	assert.Nil(t, decoded[1].Args)
+	assert.Equal(t, logs[1], decoded[1].Log)
+
+	// malformed data is reported without failing the other logs
+	logs[0].Data = logs[0].Data[:8]
+	decoded = decodeLogs(dbm, logs, nil)
+	assert.Empty(t, decoded[0].Event)
+	assert.NotEmpty(t, decoded[0].Error)
+}
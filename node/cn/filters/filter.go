@@ -311,6 +311,12 @@ func includes(addresses []common.Address, a common.Address) bool {
 	return false
 }
 
+// FilterLogs returns the logs matching the block range, the addresses and the topics of crit,
+// in the way the log subscriptions do. It is used to filter the logs relayed from another node.
+func FilterLogs(logs []*types.Log, crit FilterCriteria) []*types.Log {
+	return filterLogs(logs, crit.FromBlock, crit.ToBlock, crit.Addresses, crit.Topics)
+}
+
 // filterLogs creates a slice of logs matching the given criteria.
 func filterLogs(logs []*types.Log, fromBlock, toBlock *big.Int, addresses []common.Address, topics [][]common.Hash) []*types.Log {
 	var ret []*types.Log
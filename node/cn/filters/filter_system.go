@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/klaytn/klaytn"
@@ -36,6 +37,15 @@ import (
 	"github.com/klaytn/klaytn/networks/rpc"
 )
 
+// installedSubscriptionCount is the number of subscriptions installed in all event systems.
+var installedSubscriptionCount int64
+
+// InstalledSubscriptions returns the number of the filters and the subscriptions installed
+// in the event systems.
+func InstalledSubscriptions() int64 {
+	return atomic.LoadInt64(&installedSubscriptionCount)
+}
+
 // Type determines the kind of filter and is used to put the filter in to
 // the correct bucket when added.
 type Type byte
@@ -459,6 +469,16 @@ func (es *EventSystem) eventLoop() {
 	for i := UnknownSubscription; i < LastIndexSubscription; i++ {
 		index[i] = make(map[rpc.ID]*subscription)
 	}
+	// The subscriptions left installed are no longer served
+	defer func() {
+		installed := make(map[rpc.ID]struct{})
+		for _, subs := range index {
+			for id := range subs {
+				installed[id] = struct{}{}
+			}
+		}
+		atomic.AddInt64(&installedSubscriptionCount, -int64(len(installed)))
+	}()
 
 	for {
 		select {
@@ -485,6 +505,7 @@ func (es *EventSystem) eventLoop() {
 			} else {
 				index[f.typ][f.id] = f
 			}
+			atomic.AddInt64(&installedSubscriptionCount, 1)
 			close(f.installed)
 
 		case f := <-es.uninstall:
@@ -495,6 +516,7 @@ func (es *EventSystem) eventLoop() {
 			} else {
 				delete(index[f.typ], f.id)
 			}
+			atomic.AddInt64(&installedSubscriptionCount, -1)
 			close(f.err)
 
 			// System stopped
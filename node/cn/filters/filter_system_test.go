@@ -254,7 +254,7 @@ func TestPendingTxFilter(t *testing.T) {
 		hashes []common.Hash
 	)
 
-	fid0 := api.NewPendingTransactionFilter()
+	fid0, _ := api.NewPendingTransactionFilter(context.Background())
 
 	time.Sleep(1 * time.Second)
 	txFeed.Send(blockchain.NewTxsEvent{Txs: transactions})
@@ -327,7 +327,7 @@ func TestLogFilterCreation(t *testing.T) {
 	)
 
 	for i, test := range testCases {
-		_, err := api.NewFilter(test.crit)
+		_, err := api.NewFilter(context.Background(), test.crit)
 		if test.success && err != nil {
 			t.Errorf("expected filter creation for case %d to success, got %v", i, err)
 		}
@@ -362,7 +362,7 @@ func TestInvalidLogFilterCreation(t *testing.T) {
 	}
 
 	for i, test := range testCases {
-		if _, err := api.NewFilter(test); err == nil {
+		if _, err := api.NewFilter(context.Background(), test); err == nil {
 			t.Errorf("Expected NewFilter for case #%d to fail", i)
 		}
 	}
@@ -465,7 +465,7 @@ func TestLogFilter(t *testing.T) {
 
 	// create all filters
 	for i := range testCases {
-		testCases[i].id, _ = api.NewFilter(testCases[i].crit)
+		testCases[i].id, _ = api.NewFilter(context.Background(), testCases[i].crit)
 	}
 
 	// raise events
@@ -657,6 +657,8 @@ func TestPendingTxFilterDeadlock(t *testing.T) {
 		chainDB: backend.ChainDB(),
 		events:  NewEventSystem(backend.EventMux(), backend, false),
 		filters: make(map[rpc.ID]*filter),
+		clients: make(map[string]*clientUsage),
+		expired: make(map[rpc.ID]string),
 		timeout: timeout,
 	}
 	go api.timeoutLoop()
@@ -680,7 +682,7 @@ func TestPendingTxFilterDeadlock(t *testing.T) {
 	// Create a bunch of filters
 	fids := make([]rpc.ID, 20)
 	for i := 0; i < len(fids); i++ {
-		fid := api.NewPendingTransactionFilter()
+		fid, _ := api.NewPendingTransactionFilter(context.Background())
 		fids[i] = fid
 		// Wait for at least one tx to arrive in filter
 		for {
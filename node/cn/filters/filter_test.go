@@ -29,6 +29,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus/gxhash"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/event"
@@ -357,3 +358,75 @@ func TestFilters(t *testing.T) {
 		t.Error("expected 0 log, got", len(logs))
 	}
 }
+
+func TestGetLogsPaged(t *testing.T) {
+	var (
+		db         = database.NewMemoryDBManager()
+		mux        = new(event.TypeMux)
+		txFeed     = new(event.Feed)
+		rmLogsFeed = new(event.Feed)
+		logsFeed   = new(event.Feed)
+		chainFeed  = new(event.Feed)
+		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed, params.TestChainConfig}
+		addr       = common.HexToAddress("0x1234")
+		topic      = common.BytesToHash([]byte("topic"))
+		logBlocks  = []int{1, 2, 1500, 2499} // block numbers minus one of the logs
+	)
+	defer db.Close()
+
+	genesis := blockchain.GenesisBlockForTesting(db, addr, big.NewInt(1000000))
+	chain, receipts := blockchain.GenerateChain(params.TestChainConfig, genesis, gxhash.NewFaker(), db, 2500, func(i int, gen *blockchain.BlockGen) {
+		for _, n := range logBlocks {
+			if i != n {
+				continue
+			}
+			receipt := genReceipt(false, 0)
+			receipt.Logs = []*types.Log{
+				{Address: addr, Topics: []common.Hash{topic}, BlockNumber: uint64(i + 1), Index: 0},
+				{Address: addr, Topics: []common.Hash{topic}, BlockNumber: uint64(i + 1), Index: 1},
+			}
+			gen.AddUncheckedReceipt(receipt)
+			gen.AddUncheckedTx(types.NewTransaction(uint64(i), common.HexToAddress("0x1"), big.NewInt(1), 1, big.NewInt(1), nil))
+		}
+	})
+	for i, block := range chain {
+		db.WriteBlock(block)
+		db.WriteCanonicalHash(block.Hash(), block.NumberU64())
+		db.WriteHeadBlockHash(block.Hash())
+		db.WriteReceipts(block.Hash(), block.NumberU64(), receipts[i])
+	}
+
+	api := NewPublicFilterAPI(backend, false)
+	crit := FilterCriteria{FromBlock: big.NewInt(0), Addresses: []common.Address{addr}}
+	limit := hexutil.Uint64(3)
+	args := &rpc.PageArgs{Limit: &limit}
+
+	var logs []*types.Log
+	for pages := 1; ; pages++ {
+		page, err := api.GetLogsPaged(context.Background(), crit, args)
+		assert.NoError(t, err)
+		items := page.Items.([]*types.Log)
+		assert.LessOrEqual(t, len(items), int(limit))
+		logs = append(logs, items...)
+		if !page.HasMore {
+			assert.Equal(t, 3, pages)
+			break
+		}
+		args.Cursor = page.Cursor
+	}
+	assert.Len(t, logs, 2*len(logBlocks))
+	for i, log := range logs {
+		assert.Equal(t, uint64(logBlocks[i/2]+1), log.BlockNumber)
+		assert.Equal(t, uint(i%2), log.Index)
+	}
+
+	// a block filter is paged by the log index
+	blockHash := chain[1].Hash()
+	page, err := api.GetLogsPaged(context.Background(), FilterCriteria{BlockHash: &blockHash}, &rpc.PageArgs{Limit: new(hexutil.Uint64)})
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.False(t, page.HasMore)
+
+	_, err = api.GetLogsPaged(context.Background(), crit, &rpc.PageArgs{Cursor: "invalid"})
+	assert.Error(t, err)
+}
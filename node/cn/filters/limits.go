@@ -0,0 +1,169 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"net"
+
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+var (
+	errFilterNotFound       = rpc.NewAPIError(rpc.CodeResourceNotFound, "filterNotFound", "filter not found")
+	errFilterExpired        = rpc.NewAPIError(rpc.CodeResourceNotFound, "filterExpired", "filter expired")
+	errTooManyFilters       = rpc.NewAPIError(rpc.CodeLimitExceeded, "tooManyFilters", "too many filters")
+	errTooManySubscriptions = rpc.NewAPIError(rpc.CodeLimitExceeded, "tooManySubscriptions", "too many subscriptions")
+)
+
+var (
+	MaxFilterItems            = 10000 // maximum number of the hashes or logs left unpolled in a filter before it expires
+	MaxFiltersPerClient       = 100   // maximum number of the polling filters of a client, unlimited if zero
+	MaxSubscriptionsPerClient = 100   // maximum number of the subscriptions of a client, unlimited if zero
+)
+
+// maxExpiredFilters is the number of the recently expired filters remembered for telling
+// a client that its filter has expired rather than it has never existed.
+const maxExpiredFilters = 4096
+
+// The reasons a polling filter expires.
+const (
+	expiredByTimeout  = "not polled within the filter timeout"
+	expiredByOverflow = "too many unpolled items"
+)
+
+// clientUsage is the number of the filters and the subscriptions of a client.
+type clientUsage struct {
+	filters       int
+	subscriptions int
+}
+
+// clientHost returns the host of the client of an RPC call, so that the connections of a
+// client from different ports share the limits. It is empty for the in-process calls,
+// which are not limited.
+func clientHost(ctx context.Context) string {
+	remote, _ := ctx.Value("remote").(string)
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		return host
+	}
+	return remote
+}
+
+// reserve counts a filter or a subscription of the client, unless the client already has
+// as many as the limit. It must be called with filtersMu held.
+func (api *PublicFilterAPI) reserve(client string, subscription bool) error {
+	if client == "" {
+		return nil
+	}
+	usage, ok := api.clients[client]
+	if !ok {
+		usage = new(clientUsage)
+		api.clients[client] = usage
+	}
+	if subscription {
+		if api.maxSubscriptions > 0 && usage.subscriptions >= api.maxSubscriptions {
+			subscriptionRejectCounter.Inc(1)
+			return errTooManySubscriptions.Errorf("too many subscriptions of the client (limit %d)", api.maxSubscriptions)
+		}
+		usage.subscriptions++
+		return nil
+	}
+	if api.maxFilters > 0 && usage.filters >= api.maxFilters {
+		filterRejectedCounter.Inc(1)
+		return errTooManyFilters.Errorf("too many filters of the client (limit %d)", api.maxFilters)
+	}
+	usage.filters++
+	return nil
+}
+
+// release uncounts a filter or a subscription of the client. It must be called with
+// filtersMu held.
+func (api *PublicFilterAPI) release(client string, subscription bool) {
+	usage, ok := api.clients[client]
+	if !ok {
+		return
+	}
+	if subscription {
+		usage.subscriptions--
+	} else {
+		usage.filters--
+	}
+	if usage.filters <= 0 && usage.subscriptions <= 0 {
+		delete(api.clients, client)
+	}
+}
+
+// ReserveSubscription counts a subscription of the client of the call, returning the
+// function to be called when the subscription ends. It fails if the client already has
+// as many subscriptions as the limit.
+func (api *PublicFilterAPI) ReserveSubscription(ctx context.Context) (func(), error) {
+	client := clientHost(ctx)
+
+	api.filtersMu.Lock()
+	defer api.filtersMu.Unlock()
+	if err := api.reserve(client, true); err != nil {
+		return nil, err
+	}
+	activeSubscriptionsCounter.Inc(1)
+	return func() {
+		api.filtersMu.Lock()
+		api.release(client, true)
+		api.filtersMu.Unlock()
+		activeSubscriptionsCounter.Dec(1)
+	}, nil
+}
+
+// uninstallLocked removes the polling filter of the given id. If the filter expired, the
+// reason is remembered so that a later poll fails with errFilterExpired. The caller has
+// to unsubscribe the returned filter outside the lock.
+func (api *PublicFilterAPI) uninstallLocked(id rpc.ID, reason string) (*filter, bool) {
+	f, found := api.filters[id]
+	if !found {
+		return nil, false
+	}
+	delete(api.filters, id)
+	api.release(f.client, false)
+	activeFiltersCounter.Dec(1)
+
+	switch reason {
+	case expiredByTimeout:
+		filterTimeoutCounter.Inc(1)
+	case expiredByOverflow:
+		filterOverflowCounter.Inc(1)
+	default:
+		return f, true
+	}
+	if len(api.expiredIDs) >= maxExpiredFilters {
+		delete(api.expired, api.expiredIDs[0])
+		api.expiredIDs = api.expiredIDs[1:]
+	}
+	api.expired[id] = reason
+	api.expiredIDs = append(api.expiredIDs, id)
+	return f, true
+}
+
+// lookupLocked returns the polling filter of the given id, or the error telling whether
+// the filter has expired or has never existed.
+func (api *PublicFilterAPI) lookupLocked(id rpc.ID) (*filter, error) {
+	if f, found := api.filters[id]; found {
+		return f, nil
+	}
+	if reason, expired := api.expired[id]; expired {
+		return nil, errFilterExpired.Errorf("filter expired: %s", reason)
+	}
+	return nil, errFilterNotFound
+}
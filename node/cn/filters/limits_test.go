@@ -0,0 +1,138 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestFilterAPI returns a filter API of the given timeout and limits whose timeout
+// loop is running.
+func newTestFilterAPI(timeout time.Duration, maxItems, maxFilters, maxSubscriptions int) (*PublicFilterAPI, *event.Feed) {
+	var (
+		mux       = new(event.TypeMux)
+		db        = database.NewMemoryDBManager()
+		chainFeed = new(event.Feed)
+		backend   = &testBackend{mux, db, 0, new(event.Feed), new(event.Feed), new(event.Feed), chainFeed, params.TestChainConfig}
+	)
+	api := &PublicFilterAPI{
+		backend:          backend,
+		mux:              backend.EventMux(),
+		chainDB:          backend.ChainDB(),
+		events:           NewEventSystem(backend.EventMux(), backend, false),
+		filters:          make(map[rpc.ID]*filter),
+		clients:          make(map[string]*clientUsage),
+		expired:          make(map[rpc.ID]string),
+		timeout:          timeout,
+		maxItems:         maxItems,
+		maxFilters:       maxFilters,
+		maxSubscriptions: maxSubscriptions,
+	}
+	go api.timeoutLoop()
+	return api, chainFeed
+}
+
+func TestPublicFilterAPI_ClientLimits(t *testing.T) {
+	t.Parallel()
+	api, _ := newTestFilterAPI(time.Minute, 0, 2, 1)
+
+	var (
+		local   = context.Background()
+		client1 = context.WithValue(local, "remote", "10.0.0.1:1000")
+		client2 = context.WithValue(local, "remote", "10.0.0.1:2000") // same host as client1
+		other   = context.WithValue(local, "remote", "10.0.0.2:1000")
+	)
+
+	id, err := api.NewBlockFilter(client1)
+	require.NoError(t, err)
+	_, err = api.NewFilter(client2, FilterCriteria{})
+	require.NoError(t, err)
+	_, err = api.NewPendingTransactionFilter(client1)
+	assert.ErrorIs(t, err, errTooManyFilters)
+
+	// other hosts and the in-process calls are not limited by the filters of the host
+	_, err = api.NewBlockFilter(other)
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = api.NewBlockFilter(local)
+		assert.NoError(t, err)
+	}
+
+	// an uninstalled filter frees its slot
+	assert.True(t, api.UninstallFilter(id))
+	_, err = api.NewBlockFilter(client2)
+	assert.NoError(t, err)
+
+	release, err := api.ReserveSubscription(client1)
+	require.NoError(t, err)
+	_, err = api.ReserveSubscription(client2)
+	assert.ErrorIs(t, err, errTooManySubscriptions)
+	release()
+	_, err = api.ReserveSubscription(client2)
+	assert.NoError(t, err)
+}
+
+func TestPublicFilterAPI_FilterExpired(t *testing.T) {
+	t.Parallel()
+
+	// a filter not polled within the timeout expires
+	timeout := 100 * time.Millisecond
+	api, _ := newTestFilterAPI(timeout, 0, 0, 0)
+
+	_, err := api.GetFilterChanges(rpc.ID("0x1"))
+	assert.ErrorIs(t, err, errFilterNotFound)
+
+	id, err := api.NewFilter(context.Background(), FilterCriteria{})
+	require.NoError(t, err)
+	_, err = api.GetFilterChanges(id)
+	require.NoError(t, err)
+
+	time.Sleep(3 * timeout)
+	_, err = api.GetFilterChanges(id)
+	assert.ErrorIs(t, err, errFilterExpired)
+	_, err = api.GetFilterLogs(context.Background(), id)
+	assert.ErrorIs(t, err, errFilterExpired)
+	assert.False(t, api.UninstallFilter(id))
+
+	// a filter having too many unpolled items expires
+	api, chainFeed := newTestFilterAPI(time.Minute, 2, 0, 0)
+	id, err = api.NewBlockFilter(context.Background())
+	require.NoError(t, err)
+	for i := int64(1); i <= 3; i++ {
+		block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(i)})
+		chainFeed.Send(blockchain.ChainEvent{Hash: block.Hash(), Block: block})
+	}
+	require.Eventually(t, func() bool {
+		_, err := api.GetFilterChanges(id)
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+	_, err = api.GetFilterChanges(id)
+	assert.ErrorIs(t, err, errFilterExpired)
+	assert.Contains(t, err.Error(), expiredByOverflow)
+}
@@ -0,0 +1,30 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import "github.com/rcrowley/go-metrics"
+
+var (
+	activeFiltersCounter       = metrics.NewRegisteredCounter("klay/filters/active", nil)
+	activeSubscriptionsCounter = metrics.NewRegisteredCounter("klay/filters/subscriptions/active", nil)
+
+	filterTimeoutCounter      = metrics.NewRegisteredCounter("klay/filters/expired/timeout/counter", nil)
+	filterOverflowCounter     = metrics.NewRegisteredCounter("klay/filters/expired/overflow/counter", nil)
+	filterRejectedCounter     = metrics.NewRegisteredCounter("klay/filters/rejected/counter", nil)
+	subscriptionDeadCounter   = metrics.NewRegisteredCounter("klay/filters/subscriptions/dead/counter", nil)
+	subscriptionRejectCounter = metrics.NewRegisteredCounter("klay/filters/subscriptions/rejected/counter", nil)
+)
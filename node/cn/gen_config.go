@@ -11,6 +11,8 @@ import (
 	"github.com/klaytn/klaytn/consensus/istanbul"
 	"github.com/klaytn/klaytn/datasync/downloader"
 	"github.com/klaytn/klaytn/node/cn/gasprice"
+	"github.com/klaytn/klaytn/node/cn/tracers"
+	"github.com/klaytn/klaytn/reward"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/klaytn/klaytn/storage/statedb"
 )
@@ -19,6 +21,7 @@ import (
 func (c Config) MarshalTOML() (interface{}, error) {
 	type Config struct {
 		Genesis                 *blockchain.Genesis `toml:",omitempty"`
+		OverrideForks           map[string]uint64   `toml:",omitempty"`
 		NetworkId               uint64
 		SyncMode                downloader.SyncMode
 		NoPruning               bool
@@ -38,20 +41,37 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		LevelDBCompression      database.LevelDBCompressionType
 		LevelDBBufferPool       bool
 		LevelDBCacheSize        int
+		HeaderMmapCacheSize     int
 		DynamoDBConfig          database.DynamoDBConfig
 		TrieCacheSize           int
 		TrieTimeout             time.Duration
 		TrieBlockInterval       uint
 		TriesInMemory           uint64
 		SenderTxHashIndexing    bool
+		BalanceHistoryIndexing  bool
+		EpochSummaryIndexing    bool
+		RewardIndexing          bool
 		ParallelDBWrite         bool
 		TrieNodeCacheConfig     statedb.TrieNodeCacheConfig
 		SnapshotCacheSize       int
 		SnapshotAsyncGen        bool
+		VerifyOnStart           blockchain.IntegrityCheckMode
+		AutoCompaction          bool
+		CompactionScheduler     database.CompactionSchedulerConfig
 		ServiceChainSigner      common.Address `toml:",omitempty"`
 		ExtraData               []byte         `toml:",omitempty"`
 		GasPrice                *big.Int
 		Rewardbase              common.Address `toml:",omitempty"`
+		RewardPolicy            string         `toml:",omitempty"`
+		RewardPolicyBlock       uint64         `toml:",omitempty"`
+		RewardVerify            bool
+		RewardCheckInvariants   bool
+		RewardAuditFee          bool
+		RewardHistory           bool
+		RewardHistoryRetention  uint64
+		RewardMonitor           bool
+		RewardMonitorThresholds reward.RewardAnomalyThresholds
+		RewardReportFormat      *reward.RewardReportFormat
 		TxPool                  blockchain.TxPoolConfig
 		GPO                     gasprice.Config
 		EnablePreimageRecording bool
@@ -59,6 +79,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Istanbul                istanbul.Config
 		DocRoot                 string `toml:"-"`
 		WsEndpoint              string `toml:",omitempty"`
+		Attestation             AttestationPolicy
 		TxResendInterval        uint64
 		TxResendCount           int
 		TxResendUseLegacy       bool
@@ -70,9 +91,16 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RPCGasCap               *big.Int `toml:",omitempty"`
 		RPCEVMTimeout           time.Duration
 		RPCTxFeeCap             float64
+		AnnotateAddressLabels   bool               `toml:",omitempty"`
+		AddressLabelsFile       string             `toml:",omitempty"`
+		ContractRegistry        *common.Address    `toml:",omitempty"`
+		EventMetricsFile        string             `toml:",omitempty"`
+		PreconfMaxBlocks        uint64             `toml:",omitempty"`
+		TraceSinks              tracers.SinkConfig `toml:",omitempty"`
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
+	enc.OverrideForks = c.OverrideForks
 	enc.NetworkId = c.NetworkId
 	enc.SyncMode = c.SyncMode
 	enc.NoPruning = c.NoPruning
@@ -92,20 +120,37 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.LevelDBCompression = c.LevelDBCompression
 	enc.LevelDBBufferPool = c.LevelDBBufferPool
 	enc.LevelDBCacheSize = c.LevelDBCacheSize
+	enc.HeaderMmapCacheSize = c.HeaderMmapCacheSize
 	enc.DynamoDBConfig = c.DynamoDBConfig
 	enc.TrieCacheSize = c.TrieCacheSize
 	enc.TrieTimeout = c.TrieTimeout
 	enc.TrieBlockInterval = c.TrieBlockInterval
 	enc.TriesInMemory = c.TriesInMemory
 	enc.SenderTxHashIndexing = c.SenderTxHashIndexing
+	enc.BalanceHistoryIndexing = c.BalanceHistoryIndexing
+	enc.EpochSummaryIndexing = c.EpochSummaryIndexing
+	enc.RewardIndexing = c.RewardIndexing
 	enc.ParallelDBWrite = c.ParallelDBWrite
 	enc.TrieNodeCacheConfig = c.TrieNodeCacheConfig
 	enc.SnapshotCacheSize = c.SnapshotCacheSize
 	enc.SnapshotAsyncGen = c.SnapshotAsyncGen
+	enc.VerifyOnStart = c.VerifyOnStart
+	enc.AutoCompaction = c.AutoCompaction
+	enc.CompactionScheduler = c.CompactionScheduler
 	enc.ServiceChainSigner = c.ServiceChainSigner
 	enc.ExtraData = c.ExtraData
 	enc.GasPrice = c.GasPrice
 	enc.Rewardbase = c.Rewardbase
+	enc.RewardPolicy = c.RewardPolicy
+	enc.RewardPolicyBlock = c.RewardPolicyBlock
+	enc.RewardVerify = c.RewardVerify
+	enc.RewardCheckInvariants = c.RewardCheckInvariants
+	enc.RewardAuditFee = c.RewardAuditFee
+	enc.RewardHistory = c.RewardHistory
+	enc.RewardHistoryRetention = c.RewardHistoryRetention
+	enc.RewardMonitor = c.RewardMonitor
+	enc.RewardMonitorThresholds = c.RewardMonitorThresholds
+	enc.RewardReportFormat = c.RewardReportFormat
 	enc.TxPool = c.TxPool
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
@@ -113,6 +158,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Istanbul = c.Istanbul
 	enc.DocRoot = c.DocRoot
 	enc.WsEndpoint = c.WsEndpoint
+	enc.Attestation = c.Attestation
 	enc.TxResendInterval = c.TxResendInterval
 	enc.TxResendCount = c.TxResendCount
 	enc.TxResendUseLegacy = c.TxResendUseLegacy
@@ -124,6 +170,12 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RPCGasCap = c.RPCGasCap
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
+	enc.AnnotateAddressLabels = c.AnnotateAddressLabels
+	enc.AddressLabelsFile = c.AddressLabelsFile
+	enc.ContractRegistry = c.ContractRegistry
+	enc.EventMetricsFile = c.EventMetricsFile
+	enc.PreconfMaxBlocks = c.PreconfMaxBlocks
+	enc.TraceSinks = c.TraceSinks
 	return &enc, nil
 }
 
@@ -131,6 +183,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	type Config struct {
 		Genesis                 *blockchain.Genesis `toml:",omitempty"`
+		OverrideForks           map[string]uint64   `toml:",omitempty"`
 		NetworkId               *uint64
 		SyncMode                *downloader.SyncMode
 		NoPruning               *bool
@@ -150,20 +203,37 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		LevelDBCompression      *database.LevelDBCompressionType
 		LevelDBBufferPool       *bool
 		LevelDBCacheSize        *int
+		HeaderMmapCacheSize     *int
 		DynamoDBConfig          *database.DynamoDBConfig
 		TrieCacheSize           *int
 		TrieTimeout             *time.Duration
 		TrieBlockInterval       *uint
 		TriesInMemory           *uint64
 		SenderTxHashIndexing    *bool
+		BalanceHistoryIndexing  *bool
+		EpochSummaryIndexing    *bool
+		RewardIndexing          *bool
 		ParallelDBWrite         *bool
 		TrieNodeCacheConfig     *statedb.TrieNodeCacheConfig
 		SnapshotCacheSize       *int
 		SnapshotAsyncGen        *bool
+		VerifyOnStart           *blockchain.IntegrityCheckMode
+		AutoCompaction          *bool
+		CompactionScheduler     *database.CompactionSchedulerConfig
 		ServiceChainSigner      *common.Address `toml:",omitempty"`
 		ExtraData               []byte          `toml:",omitempty"`
 		GasPrice                *big.Int
 		Rewardbase              *common.Address `toml:",omitempty"`
+		RewardPolicy            *string         `toml:",omitempty"`
+		RewardPolicyBlock       *uint64         `toml:",omitempty"`
+		RewardVerify            *bool
+		RewardCheckInvariants   *bool
+		RewardAuditFee          *bool
+		RewardHistory           *bool
+		RewardHistoryRetention  *uint64
+		RewardMonitor           *bool
+		RewardMonitorThresholds *reward.RewardAnomalyThresholds
+		RewardReportFormat      *reward.RewardReportFormat
 		TxPool                  *blockchain.TxPoolConfig
 		GPO                     *gasprice.Config
 		EnablePreimageRecording *bool
@@ -171,6 +241,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Istanbul                *istanbul.Config
 		DocRoot                 *string `toml:"-"`
 		WsEndpoint              *string `toml:",omitempty"`
+		Attestation             *AttestationPolicy
 		TxResendInterval        *uint64
 		TxResendCount           *int
 		TxResendUseLegacy       *bool
@@ -182,6 +253,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RPCGasCap               *big.Int `toml:",omitempty"`
 		RPCEVMTimeout           *time.Duration
 		RPCTxFeeCap             *float64
+		AnnotateAddressLabels   *bool               `toml:",omitempty"`
+		AddressLabelsFile       *string             `toml:",omitempty"`
+		ContractRegistry        *common.Address     `toml:",omitempty"`
+		EventMetricsFile        *string             `toml:",omitempty"`
+		PreconfMaxBlocks        *uint64             `toml:",omitempty"`
+		TraceSinks              *tracers.SinkConfig `toml:",omitempty"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -190,6 +267,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Genesis != nil {
 		c.Genesis = dec.Genesis
 	}
+	if dec.OverrideForks != nil {
+		c.OverrideForks = dec.OverrideForks
+	}
 	if dec.NetworkId != nil {
 		c.NetworkId = *dec.NetworkId
 	}
@@ -247,6 +327,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.LevelDBCacheSize != nil {
 		c.LevelDBCacheSize = *dec.LevelDBCacheSize
 	}
+	if dec.HeaderMmapCacheSize != nil {
+		c.HeaderMmapCacheSize = *dec.HeaderMmapCacheSize
+	}
 	if dec.DynamoDBConfig != nil {
 		c.DynamoDBConfig = *dec.DynamoDBConfig
 	}
@@ -265,6 +348,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SenderTxHashIndexing != nil {
 		c.SenderTxHashIndexing = *dec.SenderTxHashIndexing
 	}
+	if dec.BalanceHistoryIndexing != nil {
+		c.BalanceHistoryIndexing = *dec.BalanceHistoryIndexing
+	}
+	if dec.EpochSummaryIndexing != nil {
+		c.EpochSummaryIndexing = *dec.EpochSummaryIndexing
+	}
+	if dec.RewardIndexing != nil {
+		c.RewardIndexing = *dec.RewardIndexing
+	}
 	if dec.ParallelDBWrite != nil {
 		c.ParallelDBWrite = *dec.ParallelDBWrite
 	}
@@ -277,6 +369,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SnapshotAsyncGen != nil {
 		c.SnapshotAsyncGen = *dec.SnapshotAsyncGen
 	}
+	if dec.VerifyOnStart != nil {
+		c.VerifyOnStart = *dec.VerifyOnStart
+	}
+	if dec.AutoCompaction != nil {
+		c.AutoCompaction = *dec.AutoCompaction
+	}
+	if dec.CompactionScheduler != nil {
+		c.CompactionScheduler = *dec.CompactionScheduler
+	}
 	if dec.ServiceChainSigner != nil {
 		c.ServiceChainSigner = *dec.ServiceChainSigner
 	}
@@ -289,6 +390,36 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Rewardbase != nil {
 		c.Rewardbase = *dec.Rewardbase
 	}
+	if dec.RewardPolicy != nil {
+		c.RewardPolicy = *dec.RewardPolicy
+	}
+	if dec.RewardPolicyBlock != nil {
+		c.RewardPolicyBlock = *dec.RewardPolicyBlock
+	}
+	if dec.RewardVerify != nil {
+		c.RewardVerify = *dec.RewardVerify
+	}
+	if dec.RewardCheckInvariants != nil {
+		c.RewardCheckInvariants = *dec.RewardCheckInvariants
+	}
+	if dec.RewardAuditFee != nil {
+		c.RewardAuditFee = *dec.RewardAuditFee
+	}
+	if dec.RewardHistory != nil {
+		c.RewardHistory = *dec.RewardHistory
+	}
+	if dec.RewardHistoryRetention != nil {
+		c.RewardHistoryRetention = *dec.RewardHistoryRetention
+	}
+	if dec.RewardMonitor != nil {
+		c.RewardMonitor = *dec.RewardMonitor
+	}
+	if dec.RewardMonitorThresholds != nil {
+		c.RewardMonitorThresholds = *dec.RewardMonitorThresholds
+	}
+	if dec.RewardReportFormat != nil {
+		c.RewardReportFormat = dec.RewardReportFormat
+	}
 	if dec.TxPool != nil {
 		c.TxPool = *dec.TxPool
 	}
@@ -310,6 +441,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.WsEndpoint != nil {
 		c.WsEndpoint = *dec.WsEndpoint
 	}
+	if dec.Attestation != nil {
+		c.Attestation = *dec.Attestation
+	}
 	if dec.TxResendInterval != nil {
 		c.TxResendInterval = *dec.TxResendInterval
 	}
@@ -343,5 +477,23 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
+	if dec.AnnotateAddressLabels != nil {
+		c.AnnotateAddressLabels = *dec.AnnotateAddressLabels
+	}
+	if dec.AddressLabelsFile != nil {
+		c.AddressLabelsFile = *dec.AddressLabelsFile
+	}
+	if dec.ContractRegistry != nil {
+		c.ContractRegistry = dec.ContractRegistry
+	}
+	if dec.EventMetricsFile != nil {
+		c.EventMetricsFile = *dec.EventMetricsFile
+	}
+	if dec.PreconfMaxBlocks != nil {
+		c.PreconfMaxBlocks = *dec.PreconfMaxBlocks
+	}
+	if dec.TraceSinks != nil {
+		c.TraceSinks = *dec.TraceSinks
+	}
 	return nil
 }
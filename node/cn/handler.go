@@ -129,6 +129,9 @@ type ProtocolManager struct {
 
 	wsendpoint string
 
+	attestation       *Attestation // sent at the handshake, nil if disabled
+	attestationPolicy AttestationPolicy
+
 	nodetype          common.ConnType
 	txResendUseLegacy bool
 
@@ -493,10 +496,14 @@ func (pm *ProtocolManager) handle(p Peer) error {
 		td      = pm.blockchain.GetTd(hash, number)
 	)
 
-	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash()); err != nil {
+	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash(), pm.attestation); err != nil {
 		p.GetP2PPeer().Log().Debug("Klaytn peer handshake failed", "err", err)
 		return err
 	}
+	if err := pm.verifyAttestation(p); err != nil {
+		p.GetP2PPeer().Log().Info("Klaytn peer attestation rejected", "err", err)
+		return err
+	}
 	reject := false
 	if atomic.LoadUint32(&pm.snapSync) == 1 {
 		if snap == nil {
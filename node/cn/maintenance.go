@@ -0,0 +1,256 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	MaintenanceNone      = "none"      // No maintenance is scheduled
+	MaintenanceScheduled = "scheduled" // The node halts when the head reaches the halt block
+	MaintenanceHalted    = "halted"    // The node is halted until the resume time
+
+	MaintenanceTargetProposal    = "proposal"    // A CN halts proposing blocks
+	MaintenanceTargetTxAdmission = "txAdmission" // The other nodes halt admitting new transactions
+
+	maxMaintenanceDuration = 24 * time.Hour
+)
+
+var (
+	errMaintenanceNoAdminCaller = errors.New("maintenance is only scheduled through the authenticated admin endpoint")
+	errMaintenanceScheduled     = errors.New("maintenance is already scheduled")
+	errMaintenanceNotScheduled  = errors.New("no maintenance is scheduled")
+	errMaintenanceDuration      = fmt.Errorf("the duration must be between 1 and %d seconds", uint64(maxMaintenanceDuration/time.Second))
+
+	maintenanceHaltedGauge = metrics.NewRegisteredGauge("klay/maintenance/halted", nil)
+)
+
+// MaintenanceStatus is the state of the maintenance of the node.
+type MaintenanceStatus struct {
+	State     string     `json:"state"`
+	Target    string     `json:"target"`              // what the node halts, proposal or txAdmission
+	HaltBlock uint64     `json:"haltBlock,omitempty"` // the last block before the halt
+	Duration  uint64     `json:"duration,omitempty"`  // in seconds
+	Requester string     `json:"requester,omitempty"` // the authenticated caller, e.g. "token:ops"
+	HaltedAt  *time.Time `json:"haltedAt,omitempty"`
+	ResumeAt  *time.Time `json:"resumeAt,omitempty"`
+	ResumedAt *time.Time `json:"resumedAt,omitempty"` // the time the last maintenance ended
+}
+
+type maintenanceChain interface {
+	CurrentBlock() *types.Block
+}
+
+// proposalHalter is implemented by work.Miner.
+type proposalHalter interface {
+	HaltProposal(number uint64)
+	ResumeProposal()
+}
+
+type admissionPauser interface {
+	SetAdmissionPaused(paused bool)
+}
+
+// maintenanceMode halts the node at a block for a coordinated maintenance, and resumes it
+// when the duration has passed since the halt. A CN stops proposing the blocks after the
+// halt block while it keeps validating, and the other nodes stop admitting new transactions.
+// The maintenance is not persisted, so a restarted node is resumed.
+type maintenanceMode struct {
+	chain   maintenanceChain
+	halter  proposalHalter  // nil if the node does not propose
+	pauser  admissionPauser // nil if the node proposes
+	afterFn func(time.Duration, func()) *time.Timer
+
+	mu        sync.Mutex
+	haltBlock uint64 // zero if no maintenance is scheduled
+	duration  time.Duration
+	requester string
+	haltedAt  time.Time // zero if the node is not halted
+	resumedAt time.Time
+	timer     *time.Timer
+}
+
+func newMaintenanceMode(chain maintenanceChain, halter proposalHalter, pauser admissionPauser) *maintenanceMode {
+	return &maintenanceMode{chain: chain, halter: halter, pauser: pauser, afterFn: time.AfterFunc}
+}
+
+func (m *maintenanceMode) target() string {
+	if m.halter != nil {
+		return MaintenanceTargetProposal
+	}
+	return MaintenanceTargetTxAdmission
+}
+
+func (m *maintenanceMode) loop(chainHead <-chan blockchain.ChainHeadEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-chainHead:
+			m.update(ev.Block.NumberU64(), time.Now())
+		case <-subscription.Err():
+			return
+		}
+	}
+}
+
+// schedule schedules the node to halt after the given block for the duration.
+func (m *maintenanceMode) schedule(requester string, haltBlock uint64, duration time.Duration) (*MaintenanceStatus, error) {
+	if duration < time.Second || duration > maxMaintenanceDuration {
+		return nil, errMaintenanceDuration
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.haltBlock != 0 {
+		return nil, errMaintenanceScheduled
+	}
+	if current := m.chain.CurrentBlock().NumberU64(); haltBlock <= current {
+		return nil, fmt.Errorf("the halt block must be above the current block %d", current)
+	}
+	m.haltBlock, m.duration, m.requester = haltBlock, duration, requester
+	if m.halter != nil {
+		// the worker halts exactly after the block, before the head event is delivered here
+		m.halter.HaltProposal(haltBlock)
+	}
+	logger.Warn("[Maintenance] Scheduled", "haltBlock", haltBlock, "duration", duration, "target", m.target(), "requester", requester)
+	return m.statusLocked(), nil
+}
+
+// update halts the node if the head has reached the halt block.
+func (m *maintenanceMode) update(head uint64, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.haltBlock == 0 || !m.haltedAt.IsZero() || head < m.haltBlock {
+		return
+	}
+	if m.pauser != nil {
+		m.pauser.SetAdmissionPaused(true)
+	}
+	m.haltedAt = now
+	m.timer = m.afterFn(m.duration, func() { m.resume(time.Now()) })
+	maintenanceHaltedGauge.Update(1)
+	logger.Warn("[Maintenance] Halted", "head", head, "haltBlock", m.haltBlock, "target", m.target(), "resumeAt", now.Add(m.duration))
+}
+
+// resume ends the maintenance, whether the node is halted or not yet.
+func (m *maintenanceMode) resume(now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.haltBlock == 0 {
+		return errMaintenanceNotScheduled
+	}
+	if m.timer != nil {
+		m.timer.Stop()
+	}
+	if m.halter != nil {
+		m.halter.ResumeProposal()
+	}
+	if m.pauser != nil {
+		m.pauser.SetAdmissionPaused(false)
+	}
+	logger.Warn("[Maintenance] Resumed", "haltBlock", m.haltBlock, "haltedAt", m.haltedAt, "target", m.target())
+	m.haltBlock, m.duration, m.requester, m.haltedAt, m.timer = 0, 0, "", time.Time{}, nil
+	m.resumedAt = now
+	maintenanceHaltedGauge.Update(0)
+	return nil
+}
+
+func (m *maintenanceMode) status() *MaintenanceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.statusLocked()
+}
+
+func (m *maintenanceMode) statusLocked() *MaintenanceStatus {
+	status := &MaintenanceStatus{State: MaintenanceNone, Target: m.target()}
+	if !m.resumedAt.IsZero() {
+		resumedAt := m.resumedAt
+		status.ResumedAt = &resumedAt
+	}
+	if m.haltBlock == 0 {
+		return status
+	}
+	status.State = MaintenanceScheduled
+	status.HaltBlock = m.haltBlock
+	status.Duration = uint64(m.duration / time.Second)
+	status.Requester = m.requester
+	if !m.haltedAt.IsZero() {
+		haltedAt, resumeAt := m.haltedAt, m.haltedAt.Add(m.duration)
+		status.State = MaintenanceHalted
+		status.HaltedAt, status.ResumeAt = &haltedAt, &resumeAt
+	}
+	return status
+}
+
+// PrivateMaintenanceAPI schedules the maintenance halts of the node.
+type PrivateMaintenanceAPI struct {
+	maintenance *maintenanceMode
+}
+
+// ScheduleMaintenance halts the node after the given block for the duration in seconds, and
+// resumes it automatically. A CN stops proposing blocks, and the other nodes stop admitting
+// new transactions. It is only allowed through the authenticated admin endpoint, and the
+// caller is recorded.
+func (api *PrivateMaintenanceAPI) ScheduleMaintenance(ctx context.Context, haltBlock rpc.BlockNumber, duration hexutil.Uint64) (*MaintenanceStatus, error) {
+	caller, ok := rpc.AdminCaller(ctx)
+	if !ok {
+		return nil, errMaintenanceNoAdminCaller
+	}
+	if haltBlock < 0 {
+		return nil, errors.New("the halt block must be a block number")
+	}
+	return api.maintenance.schedule(caller, uint64(haltBlock), time.Duration(duration)*time.Second)
+}
+
+// ResumeMaintenance ends the scheduled or ongoing maintenance before the resume time.
+// It is only allowed through the authenticated admin endpoint.
+func (api *PrivateMaintenanceAPI) ResumeMaintenance(ctx context.Context) (*MaintenanceStatus, error) {
+	caller, ok := rpc.AdminCaller(ctx)
+	if !ok {
+		return nil, errMaintenanceNoAdminCaller
+	}
+	logger.Info("[Maintenance] Resume requested", "requester", caller)
+	if err := api.maintenance.resume(time.Now()); err != nil {
+		return nil, err
+	}
+	return api.maintenance.status(), nil
+}
+
+// PublicMaintenanceAPI reports the maintenance status of the node.
+type PublicMaintenanceAPI struct {
+	maintenance *maintenanceMode
+}
+
+// MaintenanceStatus returns whether the node is scheduled to halt or halted for maintenance.
+func (api *PublicMaintenanceAPI) MaintenanceStatus() *MaintenanceStatus {
+	return api.maintenance.status()
+}
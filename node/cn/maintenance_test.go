@@ -0,0 +1,123 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testProposalHalter struct {
+	haltAfter uint64
+}
+
+func (h *testProposalHalter) HaltProposal(number uint64) { h.haltAfter = number }
+
+func (h *testProposalHalter) ResumeProposal() { h.haltAfter = 0 }
+
+type testAdmissionPauser struct {
+	paused bool
+}
+
+func (p *testAdmissionPauser) SetAdmissionPaused(paused bool) { p.paused = paused }
+
+func TestMaintenanceMode_Proposal(t *testing.T) {
+	chain := &testPreconfChain{head: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10)})}
+	halter := &testProposalHalter{}
+	m := newMaintenanceMode(chain, halter, nil)
+
+	var (
+		resume   func()
+		duration time.Duration
+	)
+	m.afterFn = func(d time.Duration, f func()) *time.Timer {
+		duration, resume = d, f
+		return time.NewTimer(time.Hour)
+	}
+	api := &PrivateMaintenanceAPI{m}
+	adminCtx := rpc.WithAdminCaller(context.Background(), "token:operator")
+
+	// only through the admin endpoint, with a future block and a valid duration
+	_, err := api.ScheduleMaintenance(context.Background(), 12, 60)
+	assert.Equal(t, errMaintenanceNoAdminCaller, err)
+	_, err = api.ScheduleMaintenance(adminCtx, 10, 60)
+	assert.Error(t, err)
+	_, err = api.ScheduleMaintenance(adminCtx, 12, 0)
+	assert.Equal(t, errMaintenanceDuration, err)
+
+	status, err := api.ScheduleMaintenance(adminCtx, 12, 60)
+	require.NoError(t, err)
+	assert.Equal(t, MaintenanceScheduled, status.State)
+	assert.Equal(t, MaintenanceTargetProposal, status.Target)
+	assert.Equal(t, "token:operator", status.Requester)
+	assert.Equal(t, uint64(12), halter.haltAfter)
+	_, err = api.ScheduleMaintenance(adminCtx, 13, 60)
+	assert.Equal(t, errMaintenanceScheduled, err)
+
+	// halted when the head reaches the halt block
+	now := time.Now()
+	m.update(11, now)
+	assert.Equal(t, MaintenanceScheduled, m.status().State)
+	m.update(12, now)
+	status = m.status()
+	assert.Equal(t, MaintenanceHalted, status.State)
+	require.NotNil(t, status.ResumeAt)
+	assert.Equal(t, now.Add(time.Minute), *status.ResumeAt)
+	assert.Equal(t, time.Minute, duration)
+
+	// resumed automatically after the duration
+	require.NotNil(t, resume)
+	resume()
+	status = m.status()
+	assert.Equal(t, MaintenanceNone, status.State)
+	assert.NotNil(t, status.ResumedAt)
+	assert.Zero(t, halter.haltAfter)
+}
+
+func TestMaintenanceMode_TxAdmission(t *testing.T) {
+	chain := &testPreconfChain{head: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10)})}
+	pauser := &testAdmissionPauser{}
+	m := newMaintenanceMode(chain, nil, pauser)
+	api := &PrivateMaintenanceAPI{m}
+	adminCtx := rpc.WithAdminCaller(context.Background(), "token:operator")
+
+	status, err := api.ScheduleMaintenance(adminCtx, 11, 60)
+	require.NoError(t, err)
+	assert.Equal(t, MaintenanceTargetTxAdmission, status.Target)
+	assert.False(t, pauser.paused)
+
+	m.update(11, time.Now())
+	assert.True(t, pauser.paused)
+	assert.Equal(t, MaintenanceHalted, (&PublicMaintenanceAPI{m}).MaintenanceStatus().State)
+
+	// resumed by the admin before the resume time
+	_, err = api.ResumeMaintenance(context.Background())
+	assert.Equal(t, errMaintenanceNoAdminCaller, err)
+	status, err = api.ResumeMaintenance(adminCtx)
+	require.NoError(t, err)
+	assert.Equal(t, MaintenanceNone, status.State)
+	assert.False(t, pauser.paused)
+	_, err = api.ResumeMaintenance(adminCtx)
+	assert.Equal(t, errMaintenanceNotScheduled, err)
+}
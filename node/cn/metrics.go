@@ -72,8 +72,17 @@ var (
 	propConsensusIstanbulInTrafficMeter  = metrics.NewRegisteredMeter("klay/prop/consensus/istanbul/in/traffic", nil)
 	propConsensusIstanbulOutPacketsMeter = metrics.NewRegisteredMeter("klay/prop/consensus/istanbul/out/packets", nil)
 	propConsensusIstanbulOutTrafficMeter = metrics.NewRegisteredMeter("klay/prop/consensus/istanbul/out/traffic", nil)
+	rewardDivergenceMeter                = metrics.NewRegisteredMeter("klay/reward/verify/divergence", nil)
+	feeDivergenceMeter                   = metrics.NewRegisteredMeter("klay/reward/auditfee/divergence", nil)
+	rewardCheckpointWriteMeter           = metrics.NewRegisteredMeter("klay/reward/checkpoint/write", nil)
+	rewardCheckpointPruneMeter           = metrics.NewRegisteredMeter("klay/reward/checkpoint/prune", nil)
+	attestationVerifiedCounter           = metrics.NewRegisteredCounter("klay/attestation/verified", nil)
+	attestationRejectedCounter           = metrics.NewRegisteredCounter("klay/attestation/rejected", nil)
 )
 
+// rewardAnomalyMeterPrefix is prepended to the kinds of the reward anomalies to name their meters.
+const rewardAnomalyMeterPrefix = "klay/reward/monitor/anomaly/"
+
 // meteredMsgReadWriter is a wrapper around a p2p.MsgReadWriter, capable of
 // accumulating the above defined metrics based on the data stream contents.
 type meteredMsgReadWriter struct {
@@ -0,0 +1,216 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/rcrowley/go-metrics"
+)
+
+// nodeOverviewBlocks is the number of the recent blocks looked up for the last proposed
+// block and the reward earnings.
+const nodeOverviewBlocks = 128
+
+// NodeOverview is the status of the node aggregated for the operator dashboards.
+type NodeOverview struct {
+	Sync         SyncOverview       `json:"sync"`
+	Peers        PeerOverview       `json:"peers"`
+	TxPool       TxPoolOverview     `json:"txpool"`
+	LastProposed *ProposedBlock     `json:"lastProposed"` // nil if not proposed in the recent blocks
+	Rewards      RewardOverview     `json:"rewards"`
+	Staking      *StakingOverview   `json:"staking"` // nil if the node is not in the staking information
+	DiskUsage    map[string]uint64  `json:"diskUsage"`
+	CacheHitRate map[string]float64 `json:"cacheHitRate"`
+}
+
+type SyncOverview struct {
+	Syncing       bool   `json:"syncing"`
+	StartingBlock uint64 `json:"startingBlock"`
+	CurrentBlock  uint64 `json:"currentBlock"`
+	HighestBlock  uint64 `json:"highestBlock"`
+	HeadAge       uint64 `json:"headAge"` // Seconds since the head block is created
+}
+
+type PeerOverview struct {
+	Total  uint            `json:"total"`
+	ByType map[string]uint `json:"byType"`
+}
+
+type TxPoolOverview struct {
+	Pending int `json:"pending"`
+	Queued  int `json:"queued"`
+}
+
+type ProposedBlock struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	Time   uint64      `json:"time"`
+}
+
+// RewardOverview is the sum of the rewards paid to the rewardbase and the reward address
+// of the node in the recent blocks.
+type RewardOverview struct {
+	FromBlock  uint64           `json:"fromBlock"`
+	ToBlock    uint64           `json:"toBlock"`
+	Recipients []common.Address `json:"recipients"`
+	Amount     *big.Int         `json:"amount"`
+}
+
+type StakingOverview struct {
+	StakingBlock  uint64         `json:"stakingBlock"`
+	RewardAddress common.Address `json:"rewardAddress"`
+	Amount        uint64         `json:"amount"`       // KLAY staked by the node
+	MinimumStake  *big.Int       `json:"minimumStake"` // KLAY required to be a validator
+	Sufficient    bool           `json:"sufficient"`
+}
+
+// NodeOverview returns the sync status, the peers, the transaction pool, the recent
+// proposals and rewards, the staking status, the disk usage and the cache hit rates
+// of the node in a single call.
+func (api *PrivateAdminAPI) NodeOverview() (*NodeOverview, error) {
+	s := api.cn
+	head := s.blockchain.CurrentBlock().Header()
+	pending, queued := s.txPool.Stats()
+
+	overview := &NodeOverview{
+		Sync:         s.syncOverview(head),
+		TxPool:       TxPoolOverview{Pending: pending, Queued: queued},
+		DiskUsage:    database.DiskUsage(s.chainDB),
+		CacheHitRate: cacheHitRates(metrics.DefaultRegistry),
+	}
+	if s.netRPCService != nil {
+		overview.Peers = PeerOverview{Total: uint(s.netRPCService.PeerCount()), ByType: s.netRPCService.PeerCountByType()}
+	}
+
+	nodeAddr := s.governance.NodeAddress()
+	recipients := make([]common.Address, 0, 2)
+	if rewardbase, err := s.Rewardbase(); err == nil {
+		recipients = append(recipients, rewardbase)
+	}
+	var si *reward.StakingInfo
+	if reward.GetStakingManager() != nil {
+		si = reward.GetStakingInfo(head.Number.Uint64() + 1)
+	}
+	if si != nil {
+		if idx, err := si.GetIndexByNodeAddress(nodeAddr); err == nil {
+			pset, err := s.governance.EffectiveParams(head.Number.Uint64() + 1)
+			if err != nil {
+				return nil, err
+			}
+			staking := &StakingOverview{
+				StakingBlock:  si.BlockNum,
+				RewardAddress: si.CouncilRewardAddrs[idx],
+				Amount:        si.CouncilStakingAmounts[idx],
+				MinimumStake:  pset.MinimumStakeBig(),
+			}
+			staking.Sufficient = staking.MinimumStake.Cmp(new(big.Int).SetUint64(staking.Amount)) <= 0
+			overview.Staking = staking
+			if len(recipients) == 0 || staking.RewardAddress != recipients[0] {
+				recipients = append(recipients, staking.RewardAddress)
+			}
+		}
+	}
+
+	var err error
+	if overview.Rewards, overview.LastProposed, err = s.recentRewards(head, nodeAddr, recipients); err != nil {
+		return nil, err
+	}
+	return overview, nil
+}
+
+func (s *CN) syncOverview(head *types.Header) SyncOverview {
+	progress := s.Progress()
+	overview := SyncOverview{
+		Syncing:       progress.CurrentBlock < progress.HighestBlock,
+		StartingBlock: progress.StartingBlock,
+		CurrentBlock:  head.Number.Uint64(),
+		HighestBlock:  progress.HighestBlock,
+	}
+	if now := uint64(time.Now().Unix()); now > head.Time.Uint64() {
+		overview.HeadAge = now - head.Time.Uint64()
+	}
+	return overview
+}
+
+// recentRewards sums the rewards paid to the recipients and finds the last block proposed
+// by the node in the recent blocks up to the head.
+func (s *CN) recentRewards(head *types.Header, nodeAddr common.Address, recipients []common.Address) (RewardOverview, *ProposedBlock, error) {
+	ret := RewardOverview{ToBlock: head.Number.Uint64(), Recipients: recipients, Amount: new(big.Int)}
+	if head.Number.Uint64() >= nodeOverviewBlocks {
+		ret.FromBlock = head.Number.Uint64() - nodeOverviewBlocks + 1
+	}
+
+	var lastProposed *ProposedBlock
+	for num := ret.ToBlock; num >= ret.FromBlock && num > 0; num-- {
+		header := s.blockchain.GetHeaderByNumber(num)
+		if header == nil {
+			break
+		}
+		if lastProposed == nil {
+			if proposer, err := s.engine.Author(header); err == nil && proposer == nodeAddr {
+				lastProposed = &ProposedBlock{Number: num, Hash: header.Hash(), Time: header.Time.Uint64()}
+			}
+		}
+
+		rules := s.chainConfig.Rules(header.Number)
+		pset, err := s.governance.EffectiveParams(num)
+		if err != nil {
+			return ret, nil, err
+		}
+		if pset, err = s.governance.EffectiveParams(reward.CalcRewardParamBlock(num, pset.Epoch(), rules)); err != nil {
+			return ret, nil, err
+		}
+		spec, err := reward.GetBlockReward(header, rules, pset)
+		if err != nil {
+			return ret, nil, err
+		}
+		for _, addr := range recipients {
+			if amount, ok := spec.Rewards[addr]; ok {
+				ret.Amount.Add(ret.Amount, amount)
+			}
+		}
+	}
+	return ret, lastProposed, nil
+}
+
+// cacheHitRates returns the ratio of the hits to the lookups of each cache which reports
+// the "<name>/hit" and "<name>/miss" meters. The caches never looked up are omitted.
+func cacheHitRates(registry metrics.Registry) map[string]float64 {
+	rates := make(map[string]float64)
+	registry.Each(func(name string, i interface{}) {
+		hit, ok := i.(metrics.Meter)
+		if !ok || !strings.HasSuffix(name, "/hit") {
+			return
+		}
+		prefix := strings.TrimSuffix(name, "/hit")
+		miss, ok := registry.Get(prefix + "/miss").(metrics.Meter)
+		if !ok {
+			return
+		}
+		if total := hit.Count() + miss.Count(); total > 0 {
+			rates[prefix] = float64(hit.Count()) / float64(total)
+		}
+	})
+	return rates
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"testing"
+
+	"github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheHitRates(t *testing.T) {
+	registry := metrics.NewRegistry()
+	metrics.NewRegisteredMeter("klay/cache/get/header/hit", registry).Mark(3)
+	metrics.NewRegisteredMeter("klay/cache/get/header/miss", registry).Mark(1)
+	metrics.NewRegisteredMeter("klay/cache/get/td/hit", registry)
+	metrics.NewRegisteredMeter("klay/cache/get/td/miss", registry)
+	metrics.NewRegisteredMeter("trie/memcache/clean/hit", registry).Mark(1)
+	metrics.NewRegisteredCounter("klay/other/hit", registry).Inc(1)
+
+	// the caches never looked up and the ones without the misses are omitted
+	assert.Equal(t, map[string]float64{"klay/cache/get/header": 0.75}, cacheHitRates(registry))
+}
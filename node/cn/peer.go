@@ -78,6 +78,8 @@ type PeerInfo struct {
 	Version    int      `json:"version"`    // Klaytn protocol version negotiated
 	BlockScore *big.Int `json:"blockscore"` // Total blockscore of the peer's blockchain
 	Head       string   `json:"head"`       // SHA3 hash of the peer's best owned block
+
+	Attestation *Attestation `json:"attestation,omitempty"` // Attestation sent at the handshake
 }
 
 // propEvent is a block propagation, waiting for its turn in the broadcast queue.
@@ -180,7 +182,8 @@ type Peer interface {
 
 	// Handshake executes the Klaytn protocol handshake, negotiating version number,
 	// network IDs, difficulties, head, and genesis blocks and returning error.
-	Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash) error
+	// The attestation of the node is sent along if it is not nil.
+	Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash, attestation *Attestation) error
 
 	// ConnType returns the conntype of the peer.
 	ConnType() common.ConnType
@@ -194,6 +197,9 @@ type Peer interface {
 	// GetChainID returns the chain id of the peer.
 	GetChainID() *big.Int
 
+	// GetAttestation returns the attestation sent by the peer at the handshake, nil if not sent.
+	GetAttestation() *Attestation
+
 	// GetAddr returns the address of the peer.
 	GetAddr() common.Address
 
@@ -268,6 +274,8 @@ type basePeer struct {
 
 	chainID *big.Int // ChainID to sign a transaction
 
+	attestation *Attestation // Attestation sent at the handshake, nil if not sent
+
 	snapExt *snap.Peer // Satellite `snap` connection
 }
 
@@ -412,6 +420,8 @@ func (p *basePeer) Info() *PeerInfo {
 		Version:    p.version,
 		BlockScore: td,
 		Head:       hash.Hex(),
+
+		Attestation: p.attestation,
 	}
 }
 
@@ -630,8 +640,9 @@ func (p *basePeer) RequestStakingInfo(hashes []common.Hash) error {
 }
 
 // Handshake executes the Klaytn protocol handshake, negotiating version number,
-// network IDs, difficulties, head and genesis blocks.
-func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash) error {
+// network IDs, difficulties, head and genesis blocks. The attestation of the node is
+// sent along if it is not nil.
+func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash, attestation *Attestation) error {
 	// Send out own handshake in a new thread
 	errc := make(chan error, 2)
 	var status statusData // safe to read after two values have been received from errc
@@ -644,6 +655,7 @@ func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.H
 			CurrentBlock:    head,
 			GenesisBlock:    genesis,
 			ChainID:         chainID,
+			Attestation:     attestation,
 		})
 	}()
 	go func() {
@@ -661,7 +673,7 @@ func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.H
 			return p2p.DiscReadTimeout
 		}
 	}
-	p.td, p.head, p.chainID = status.TD, status.CurrentBlock, status.ChainID
+	p.td, p.head, p.chainID, p.attestation = status.TD, status.CurrentBlock, status.ChainID, status.Attestation
 	return nil
 }
 
@@ -722,6 +734,11 @@ func (p *basePeer) GetChainID() *big.Int {
 	return p.chainID
 }
 
+// GetAttestation returns the attestation sent by the peer at the handshake, nil if not sent.
+func (p *basePeer) GetAttestation() *Attestation {
+	return p.attestation
+}
+
 // GetAddr returns the address of the peer.
 func (p *basePeer) GetAddr() common.Address {
 	return p.addr
@@ -1108,10 +1125,14 @@ func (p *multiChannelPeer) Handle(pm *ProtocolManager) error {
 		td      = pm.blockchain.GetTd(hash, number)
 	)
 
-	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash()); err != nil {
+	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash(), pm.attestation); err != nil {
 		p.GetP2PPeer().Log().Debug("Klaytn peer handshake failed", "err", err)
 		return err
 	}
+	if err := pm.verifyAttestation(p); err != nil {
+		p.GetP2PPeer().Log().Info("Klaytn peer attestation rejected", "err", err)
+		return err
+	}
 	reject := false
 	if atomic.LoadUint32(&pm.snapSync) == 1 {
 		if snap == nil {
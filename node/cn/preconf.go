@@ -0,0 +1,297 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	PreconfPending     = "pending"     // The transaction is not included yet
+	PreconfIncluded    = "included"    // The transaction is included by the deadline
+	PreconfBreached    = "breached"    // The transaction is not included by the deadline
+	PreconfInvalidated = "invalidated" // The sender used the nonce for another transaction, not a breach
+
+	preconfDomain       = "klaytn-preconfirmation"
+	maxPreconfirmations = 4096 // The number of the preconfirmations kept in memory
+)
+
+var (
+	errPreconfNoAdminCaller = errors.New("preconfirmations are only issued through the authenticated admin endpoint")
+	errPreconfUnknownTx     = errors.New("the transaction is not in the transaction pool")
+	errPreconfNotExecutable = errors.New("the transaction is not executable, e.g. there is a nonce gap")
+	errPreconfNotFound      = errors.New("no preconfirmation is issued for the transaction")
+	errPreconfSignature     = errors.New("the preconfirmation is not signed by the signer")
+
+	preconfIssuedCounter   = metrics.NewRegisteredCounter("klay/preconf/issued", nil)
+	preconfIncludedCounter = metrics.NewRegisteredCounter("klay/preconf/included", nil)
+	preconfBreachedCounter = metrics.NewRegisteredCounter("klay/preconf/breached", nil)
+)
+
+// Preconfirmation is a promise signed by a CN that a transaction will be included
+// by the deadline block, as long as the transaction stays valid.
+type Preconfirmation struct {
+	TxHash      common.Hash    `json:"txHash"`
+	IssuedBlock uint64         `json:"issuedBlock"` // The head block number when issued
+	Deadline    uint64         `json:"deadline"`    // The transaction is included at or before this block number
+	Signer      common.Address `json:"signer"`
+	Signature   hexutil.Bytes  `json:"signature"` // The signature of Keccak256(SigningData)
+	Requester   string         `json:"requester"` // The authenticated caller, e.g. "token:ops"
+
+	Status        string `json:"status"`
+	IncludedBlock uint64 `json:"includedBlock,omitempty"`
+
+	sender common.Address
+	nonce  uint64
+}
+
+// SigningData returns the data signed by the signer of the preconfirmation.
+func (p *Preconfirmation) SigningData(chainID *big.Int) []byte {
+	data, _ := rlp.EncodeToBytes([]interface{}{preconfDomain, chainID, p.TxHash, p.IssuedBlock, p.Deadline})
+	return data
+}
+
+// Verify checks that the preconfirmation is signed by its signer.
+func (p *Preconfirmation) Verify(chainID *big.Int) error {
+	pub, err := crypto.SigToPub(crypto.Keccak256(p.SigningData(chainID)), p.Signature)
+	if err != nil {
+		return err
+	}
+	if crypto.PubkeyToAddress(*pub) != p.Signer {
+		return errPreconfSignature
+	}
+	return nil
+}
+
+type preconfChain interface {
+	CurrentBlock() *types.Block
+	StateAt(root common.Hash) (*state.StateDB, error)
+}
+
+type preconfTxPool interface {
+	Get(hash common.Hash) *types.Transaction
+	GetPendingNonce(addr common.Address) uint64
+}
+
+// preconfTracker issues the preconfirmations and tracks whether they are kept.
+type preconfTracker struct {
+	chainID   *big.Int
+	maxBlocks uint64
+	signer    common.Address
+	sign      func(data []byte) ([]byte, error)
+	chain     preconfChain
+	txPool    preconfTxPool
+
+	mu       sync.Mutex
+	preconfs map[common.Hash]*Preconfirmation
+	order    []common.Hash // The issuing order to evict the oldest ones
+}
+
+func newPreconfTracker(chainID *big.Int, maxBlocks uint64, signer common.Address, sign func([]byte) ([]byte, error), chain preconfChain, txPool preconfTxPool) *preconfTracker {
+	return &preconfTracker{
+		chainID:   chainID,
+		maxBlocks: maxBlocks,
+		signer:    signer,
+		sign:      sign,
+		chain:     chain,
+		txPool:    txPool,
+		preconfs:  make(map[common.Hash]*Preconfirmation),
+	}
+}
+
+// issue signs a preconfirmation of a pending transaction to be included within the given
+// number of blocks. The existing preconfirmation is returned if it is still pending.
+func (t *preconfTracker) issue(requester string, txHash common.Hash, blocks uint64) (*Preconfirmation, error) {
+	if blocks == 0 || blocks > t.maxBlocks {
+		return nil, fmt.Errorf("the number of blocks must be between 1 and %d", t.maxBlocks)
+	}
+	tx := t.txPool.Get(txHash)
+	if tx == nil {
+		return nil, errPreconfUnknownTx
+	}
+	sender := tx.ValidatedSender()
+	if tx.Nonce() >= t.txPool.GetPendingNonce(sender) {
+		return nil, errPreconfNotExecutable
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if p, ok := t.preconfs[txHash]; ok && p.Status == PreconfPending {
+		ret := *p
+		return &ret, nil
+	}
+
+	head := t.chain.CurrentBlock().NumberU64()
+	p := &Preconfirmation{
+		TxHash:      txHash,
+		IssuedBlock: head,
+		Deadline:    head + blocks,
+		Signer:      t.signer,
+		Requester:   requester,
+		Status:      PreconfPending,
+		sender:      sender,
+		nonce:       tx.Nonce(),
+	}
+	sig, err := t.sign(p.SigningData(t.chainID))
+	if err != nil {
+		return nil, err
+	}
+	p.Signature = sig
+
+	if _, ok := t.preconfs[txHash]; !ok {
+		t.order = append(t.order, txHash)
+	}
+	t.preconfs[txHash] = p
+	for len(t.order) > maxPreconfirmations {
+		delete(t.preconfs, t.order[0])
+		t.order = t.order[1:]
+	}
+	preconfIssuedCounter.Inc(1)
+	logger.Info("[Preconf] Issued a transaction preconfirmation", "tx", txHash, "deadline", p.Deadline, "requester", requester)
+
+	ret := *p
+	return &ret, nil
+}
+
+// get returns a copy of the preconfirmation of the transaction.
+func (t *preconfTracker) get(txHash common.Hash) (*Preconfirmation, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.preconfs[txHash]
+	if !ok {
+		return nil, errPreconfNotFound
+	}
+	ret := *p
+	return &ret, nil
+}
+
+// list returns the copies of the preconfirmations with the given status in the issuing order.
+func (t *preconfTracker) list(status string) []Preconfirmation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ret := make([]Preconfirmation, 0)
+	for _, txHash := range t.order {
+		if p := t.preconfs[txHash]; p.Status == status {
+			ret = append(ret, *p)
+		}
+	}
+	return ret
+}
+
+// loop updates the preconfirmations with the new blocks until the subscription ends.
+func (t *preconfTracker) loop(chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-chainEvent:
+			t.update(ev.Block)
+		case <-subscription.Err():
+			return
+		}
+	}
+}
+
+// update marks the preconfirmations included by the block, and reports the ones whose
+// deadline has passed without the inclusion. A preconfirmation is not reverted by a reorg.
+func (t *preconfTracker) update(block *types.Block) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	number := block.NumberU64()
+	for _, tx := range block.Transactions() {
+		if p, ok := t.preconfs[tx.Hash()]; ok && p.Status == PreconfPending {
+			p.Status = PreconfIncluded
+			p.IncludedBlock = number
+			preconfIncludedCounter.Inc(1)
+		}
+	}
+
+	var statedb *state.StateDB
+	for _, txHash := range t.order {
+		p := t.preconfs[txHash]
+		if p.Status != PreconfPending || number < p.Deadline {
+			continue
+		}
+		if statedb == nil {
+			var err error
+			if statedb, err = t.chain.StateAt(block.Root()); err != nil {
+				logger.Error("[Preconf] Failed to read the state to check the preconfirmations", "blockNum", number, "err", err)
+				return
+			}
+		}
+		if statedb.GetNonce(p.sender) > p.nonce {
+			p.Status = PreconfInvalidated
+			logger.Info("[Preconf] The preconfirmed transaction is replaced by the sender", "tx", txHash, "sender", p.sender)
+			continue
+		}
+		p.Status = PreconfBreached
+		preconfBreachedCounter.Inc(1)
+		logger.Warn("[Preconf] Breached a transaction preconfirmation", "tx", txHash, "deadline", p.Deadline,
+			"blockNum", number, "requester", p.Requester)
+	}
+}
+
+// PrivatePreconfirmationAPI issues the transaction inclusion preconfirmations of the CN.
+type PrivatePreconfirmationAPI struct {
+	tracker *preconfTracker
+}
+
+// PreconfirmTransaction issues a signed preconfirmation that the pending transaction will be
+// included within the given number of blocks. It is only allowed through the authenticated
+// admin endpoint, and the caller is recorded.
+func (api *PrivatePreconfirmationAPI) PreconfirmTransaction(ctx context.Context, txHash common.Hash, blocks hexutil.Uint64) (*Preconfirmation, error) {
+	caller, ok := rpc.AdminCaller(ctx)
+	if !ok {
+		return nil, errPreconfNoAdminCaller
+	}
+	return api.tracker.issue(caller, txHash, uint64(blocks))
+}
+
+// GetPreconfirmation returns the preconfirmation of the transaction and its status.
+func (api *PrivatePreconfirmationAPI) GetPreconfirmation(txHash common.Hash) (*Preconfirmation, error) {
+	return api.tracker.get(txHash)
+}
+
+// PendingPreconfirmations returns the preconfirmations whose transactions are not included yet.
+func (api *PrivatePreconfirmationAPI) PendingPreconfirmations() []Preconfirmation {
+	return api.tracker.list(PreconfPending)
+}
+
+// PreconfirmationBreaches returns the preconfirmations whose transactions are not included
+// by the deadline.
+func (api *PrivatePreconfirmationAPI) PreconfirmationBreaches() []Preconfirmation {
+	return api.tracker.list(PreconfBreached)
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPreconfChain struct {
+	head    *types.Block
+	statedb *state.StateDB
+}
+
+func (c *testPreconfChain) CurrentBlock() *types.Block { return c.head }
+
+func (c *testPreconfChain) StateAt(root common.Hash) (*state.StateDB, error) { return c.statedb, nil }
+
+type testPreconfTxPool struct {
+	txs          map[common.Hash]*types.Transaction
+	pendingNonce uint64
+}
+
+func (p *testPreconfTxPool) Get(hash common.Hash) *types.Transaction { return p.txs[hash] }
+
+func (p *testPreconfTxPool) GetPendingNonce(addr common.Address) uint64 { return p.pendingNonce }
+
+func TestPreconfTracker(t *testing.T) {
+	var (
+		chainID      = big.NewInt(1000)
+		nodeKey, _   = crypto.GenerateKey()
+		senderKey, _ = crypto.GenerateKey()
+		signer       = types.LatestSignerForChainID(chainID)
+		sign         = func(data []byte) ([]byte, error) { return crypto.Sign(crypto.Keccak256(data), nodeKey) }
+	)
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	require.NoError(t, err)
+
+	txs := make([]*types.Transaction, 4)
+	pool := &testPreconfTxPool{txs: make(map[common.Hash]*types.Transaction), pendingNonce: 3}
+	for i := range txs {
+		nonce := uint64(i)
+		if i == 3 {
+			nonce = 5 // nonce gap
+		}
+		tx, err := types.SignTx(types.NewTransaction(nonce, common.Address{0x1}, common.Big0, 21000, big.NewInt(25e9), nil), signer, senderKey)
+		require.NoError(t, err)
+		_, err = tx.ValidateSender(signer, statedb, 0)
+		require.NoError(t, err)
+		txs[i] = tx
+		pool.txs[tx.Hash()] = tx
+	}
+	chain := &testPreconfChain{head: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10)}), statedb: statedb}
+	tracker := newPreconfTracker(chainID, 5, crypto.PubkeyToAddress(nodeKey.PublicKey), sign, chain, pool)
+	api := &PrivatePreconfirmationAPI{tracker}
+	adminCtx := rpc.WithAdminCaller(context.Background(), "token:operator")
+
+	// only allowed through the admin endpoint
+	_, err = api.PreconfirmTransaction(context.Background(), txs[0].Hash(), 2)
+	assert.Equal(t, errPreconfNoAdminCaller, err)
+
+	// the request is validated
+	_, err = api.PreconfirmTransaction(adminCtx, txs[0].Hash(), 0)
+	assert.Error(t, err)
+	_, err = api.PreconfirmTransaction(adminCtx, txs[0].Hash(), 6)
+	assert.Error(t, err)
+	_, err = api.PreconfirmTransaction(adminCtx, common.Hash{0x1}, 2)
+	assert.Equal(t, errPreconfUnknownTx, err)
+	_, err = api.PreconfirmTransaction(adminCtx, txs[3].Hash(), 2)
+	assert.Equal(t, errPreconfNotExecutable, err)
+
+	p, err := api.PreconfirmTransaction(adminCtx, txs[0].Hash(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), p.IssuedBlock)
+	assert.Equal(t, uint64(12), p.Deadline)
+	assert.Equal(t, "token:operator", p.Requester)
+	assert.NoError(t, p.Verify(chainID))
+	assert.Equal(t, errPreconfSignature, p.Verify(big.NewInt(1001)))
+
+	// the pending preconfirmation is returned again
+	again, err := api.PreconfirmTransaction(adminCtx, txs[0].Hash(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, p.Deadline, again.Deadline)
+
+	_, err = api.PreconfirmTransaction(adminCtx, txs[1].Hash(), 1)
+	require.NoError(t, err)
+	_, err = api.PreconfirmTransaction(adminCtx, txs[2].Hash(), 1)
+	require.NoError(t, err)
+	assert.Len(t, api.PendingPreconfirmations(), 3)
+
+	// txs[1] is replaced by the sender, and txs[2] is not included by the deadline
+	statedb.SetNonce(crypto.PubkeyToAddress(senderKey.PublicKey), 2)
+	tracker.update(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(11)}).WithBody([]*types.Transaction{txs[0]}))
+
+	included, err := api.GetPreconfirmation(txs[0].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, PreconfIncluded, included.Status)
+	assert.Equal(t, uint64(11), included.IncludedBlock)
+
+	replaced, err := api.GetPreconfirmation(txs[1].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, PreconfInvalidated, replaced.Status)
+
+	breaches := api.PreconfirmationBreaches()
+	require.Len(t, breaches, 1)
+	assert.Equal(t, txs[2].Hash(), breaches[0].TxHash)
+	assert.Empty(t, api.PendingPreconfirmations())
+
+	_, err = api.GetPreconfirmation(txs[3].Hash())
+	assert.Equal(t, errPreconfNotFound, err)
+}
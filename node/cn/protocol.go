@@ -105,6 +105,7 @@ const (
 	ErrUnexpectedTxType
 	ErrFailedToGetStateDB
 	ErrUnsupportedEnginePolicy
+	ErrInvalidAttestation
 )
 
 func (e errCode) String() string {
@@ -126,6 +127,7 @@ var errorToString = map[int]string{
 	ErrUnexpectedTxType:        "Unexpected tx type",
 	ErrFailedToGetStateDB:      "Failed to get stateDB",
 	ErrUnsupportedEnginePolicy: "Unsupported engine or policy",
+	ErrInvalidAttestation:      "Invalid attestation",
 }
 
 //go:generate mockgen -destination=node/cn/mocks/downloader_mock.go -package=mocks github.com/klaytn/klaytn/node/cn ProtocolManagerDownloader
@@ -170,6 +172,9 @@ type statusData struct {
 	CurrentBlock    common.Hash
 	GenesisBlock    common.Hash
 	ChainID         *big.Int // ChainID to sign a transaction.
+
+	// Attestation is sent only if enabled, as the peers not knowing it reject the extra field.
+	Attestation *Attestation `rlp:"optional"`
 }
 
 // newBlockHashesData is the network packet for the block announcements.
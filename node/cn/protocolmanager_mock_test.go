@@ -153,6 +153,18 @@ func (mr *MockBackendProtocolManagerMockRecorder) SetTmpStop(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSyncStop", reflect.TypeOf((*MockBackendProtocolManager)(nil).SetSyncStop), arg0)
 }
 
+// SetAttestation mocks base method.
+func (m *MockBackendProtocolManager) SetAttestation(arg0 *Attestation, arg1 AttestationPolicy) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAttestation", arg0, arg1)
+}
+
+// SetAttestation indicates an expected call of SetAttestation.
+func (mr *MockBackendProtocolManagerMockRecorder) SetAttestation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttestation", reflect.TypeOf((*MockBackendProtocolManager)(nil).SetAttestation), arg0, arg1)
+}
+
 // SetWsEndPoint mocks base method.
 func (m *MockBackendProtocolManager) SetWsEndPoint(arg0 string) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,93 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"encoding/json"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// rewardCheckpointer subscribes chainEvent and persists the reward spec of each block as a reward
+// checkpoint, so that klay_getRewards serves the block after its staking information is pruned.
+// The checkpoints older than the given retention are pruned, while 0 retention keeps all of them.
+func rewardCheckpointer(db database.DBManager, bc *blockchain.BlockChain, gov governance.Engine, retention uint64, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+
+	for {
+		select {
+		case event := <-chainEvent:
+			header := event.Block.Header()
+			if err := writeRewardCheckpoint(db, bc.Config(), bc, gov, header); err != nil {
+				logger.Error("Failed to write the reward checkpoint", "blockNum", header.Number, "err", err)
+				continue
+			}
+			pruneRewardCheckpoints(db, header.Number.Uint64(), retention)
+
+		case <-subscription.Err():
+			return
+		}
+	}
+}
+
+// writeRewardCheckpoint stores the reward spec of the block calculated as klay_getRewards does.
+func writeRewardCheckpoint(db database.DBManager, config *params.ChainConfig, chain blockchain.ChainContext, gov governance.Engine, header *types.Header) error {
+	number := header.Number.Uint64()
+	pset, err := rewardParams(config, gov, number)
+	if err != nil {
+		return err
+	}
+	spec, err := reward.GetBlockReward(header, config.Rules(header.Number), pset)
+	if err != nil {
+		return err
+	}
+	// the checkpoint is written without the proposer slot rather than not at all
+	if spec.Slot, err = reward.GetRewardSlot(chain, header); err != nil {
+		logger.Warn("Failed to get the proposer slot", "blockNum", number, "err", err)
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	db.WriteRewardCheckpoint(&database.RewardCheckpoint{Number: number, Hash: header.Hash(), Spec: data})
+	if _, ok := db.ReadRewardCheckpointTail(); !ok {
+		db.WriteRewardCheckpointTail(number)
+	}
+	rewardCheckpointWriteMeter.Mark(1)
+	return nil
+}
+
+// pruneRewardCheckpoints removes the checkpoints of the blocks older than the retention from the head.
+func pruneRewardCheckpoints(db database.DBManager, head, retention uint64) {
+	if retention == 0 || head < retention {
+		return
+	}
+	tail, ok := db.ReadRewardCheckpointTail()
+	oldest := head - retention + 1 // the oldest block whose checkpoint is kept
+	if !ok || tail >= oldest {
+		return
+	}
+	db.DeleteRewardCheckpoints(tail, oldest)
+	db.WriteRewardCheckpointTail(oldest)
+	rewardCheckpointPruneMeter.Mark(int64(oldest - tail))
+}
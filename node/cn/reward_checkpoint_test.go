@@ -0,0 +1,72 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPruneRewardCheckpoints(t *testing.T) {
+	db := database.NewMemoryDBManager()
+	write := func(from, to uint64) {
+		for num := from; num <= to; num++ {
+			db.WriteRewardCheckpoint(&database.RewardCheckpoint{Number: num, Spec: []byte("{}")})
+		}
+	}
+	kept := func(num uint64) bool { return db.ReadRewardCheckpoint(num) != nil }
+
+	// nothing is pruned before the first checkpoint is written
+	pruneRewardCheckpoints(db, 100, 10)
+	_, ok := db.ReadRewardCheckpointTail()
+	assert.False(t, ok)
+
+	write(5, 20)
+	db.WriteRewardCheckpointTail(5)
+
+	// 0 retention keeps all of the checkpoints
+	pruneRewardCheckpoints(db, 20, 0)
+	assert.True(t, kept(5))
+
+	// the head not beyond the retention keeps all of the checkpoints
+	pruneRewardCheckpoints(db, 20, 30)
+	assert.True(t, kept(5))
+
+	// the latest 10 blocks of [11, 20] are kept
+	pruneRewardCheckpoints(db, 20, 10)
+	assert.False(t, kept(5))
+	assert.False(t, kept(10))
+	assert.True(t, kept(11))
+	assert.True(t, kept(20))
+	tail, ok := db.ReadRewardCheckpointTail()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(11), tail)
+
+	// pruning again at the same head is no-op
+	pruneRewardCheckpoints(db, 20, 10)
+	tail, _ = db.ReadRewardCheckpointTail()
+	assert.Equal(t, uint64(11), tail)
+
+	write(21, 25)
+	pruneRewardCheckpoints(db, 25, 10)
+	assert.False(t, kept(15))
+	assert.True(t, kept(16))
+	tail, _ = db.ReadRewardCheckpointTail()
+	assert.Equal(t, uint64(16), tail)
+}
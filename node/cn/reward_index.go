@@ -0,0 +1,207 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// rewardIndexChain is the part of the blockchain used by the reward index.
+type rewardIndexChain interface {
+	CurrentHeader() *types.Header
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// rewardIndex accumulates the minted and fee rewards of each canonical block per recipient.
+type rewardIndex struct {
+	db      database.DBManager
+	chain   rewardIndexChain
+	rewards func(header *types.Header) (*blockRewards, error)
+}
+
+// blockRewards is the rewards of a block to be indexed.
+type blockRewards struct {
+	minted   map[common.Address]*big.Int // the portion of each recipient from the minted amount
+	fee      map[common.Address]*big.Int // the portion of each recipient from the tx fees
+	proposer *big.Int                    // the proposer portion
+	burntFee *big.Int                    // the tx fee burnt
+}
+
+// rewardIndexer subscribes chainEvent and accumulates the minted and fee rewards of each block
+// per recipient. The blocks inserted while the indexer was not running are indexed on start and
+// before the next block, so that the accumulated rewards have no gap since the start of the index.
+func rewardIndexer(db database.DBManager, bc *blockchain.BlockChain, gov governance.Engine, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+
+	index := &rewardIndex{
+		db:    db,
+		chain: bc,
+		rewards: func(header *types.Header) (*blockRewards, error) {
+			return splitBlockRewards(bc, gov, header)
+		},
+	}
+	if _, ok := db.ReadRewardIndexHead(); ok {
+		if err := index.update(bc.CurrentHeader().Number.Uint64()); err != nil {
+			logger.Error("Failed to index the missed rewards", "err", err)
+		}
+	}
+	for {
+		select {
+		case event := <-chainEvent:
+			if err := index.update(event.Block.NumberU64()); err != nil {
+				logger.Error("Failed to index rewards", "blockNum", event.Block.Number(), "err", err)
+			}
+
+		case <-subscription.Err():
+			return
+		}
+	}
+}
+
+// update makes the index follow the canonical chain up to the given block number. The indexed
+// blocks abandoned by a chain reorganization are reverted from the head of the index down to the
+// last indexed block still in the canonical chain, and then the canonical blocks after it are indexed.
+func (idx *rewardIndex) update(number uint64) error {
+	if number == 0 {
+		return nil
+	}
+	head, ok := idx.db.ReadRewardIndexHead()
+	if !ok {
+		idx.db.WriteRewardIndexStart(number)
+		logger.Info("Started indexing the accumulated rewards", "blockNum", number)
+		head = number - 1
+	} else if fork := idx.forkPoint(head); fork < head {
+		logger.Info("Reverting the accumulated rewards of the abandoned blocks", "from", fork+1, "to", head)
+		for num := head; num > fork; num-- {
+			idx.db.DeleteRewardIndexBlock(num)
+		}
+		idx.db.WriteRewardIndexHead(fork)
+		head = fork
+	}
+
+	for num := head + 1; num <= number; num++ {
+		header := idx.chain.GetHeaderByNumber(num)
+		if header == nil {
+			return fmt.Errorf("the block does not exist (block number: %d)", num)
+		}
+		if err := idx.index(header); err != nil {
+			return err
+		}
+		idx.db.WriteRewardIndexHead(num)
+	}
+	return nil
+}
+
+// forkPoint returns the last indexed block number at or before head which is still in the
+// canonical chain. It returns the block before the start of the index if there is none.
+func (idx *rewardIndex) forkPoint(head uint64) uint64 {
+	start, _ := idx.db.ReadRewardIndexStart()
+	num := head
+	for ; num >= start && num > 0; num-- {
+		block := idx.db.ReadRewardIndexBlock(num)
+		header := idx.chain.GetHeaderByNumber(num)
+		if block != nil && header != nil && block.Hash == header.Hash() {
+			break
+		}
+	}
+	return num
+}
+
+// index adds the rewards of the given block to the accumulated rewards of the recipients.
+func (idx *rewardIndex) index(header *types.Header) error {
+	number := header.Number.Uint64()
+	rewards, err := idx.rewards(header)
+	if err != nil {
+		return err
+	}
+
+	batch := idx.db.NewRewardIndexBatch()
+	defer batch.Release()
+	block := &database.RewardIndexBlock{Number: number, Hash: header.Hash(), Proposer: rewards.proposer, BurntFee: rewards.burntFee}
+	for addr, amount := range rewards.minted {
+		acc := &database.AccumulatedReward{Number: number, Minted: new(big.Int).Set(amount), Fee: new(big.Int).Set(rewards.fee[addr])}
+		if prev := idx.db.ReadAccumulatedReward(addr, number-1); prev != nil {
+			acc.Minted.Add(acc.Minted, prev.Minted)
+			acc.Fee.Add(acc.Fee, prev.Fee)
+		}
+		if err := idx.db.PutAccumulatedRewardToBatch(batch, addr, acc); err != nil {
+			return err
+		}
+		block.Recipients = append(block.Recipients, addr)
+	}
+	sort.Slice(block.Recipients, func(i, j int) bool {
+		return bytes.Compare(block.Recipients[i][:], block.Recipients[j][:]) < 0
+	})
+	if err := idx.db.PutRewardIndexBlockToBatch(batch, block); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// splitBlockRewards returns the rewards of the given block per recipient, split into the portions
+// from the minted amount and from the tx fees. The minted portion of a recipient is what it would
+// have got from the block without any tx fee, and the rest is the fee portion.
+func splitBlockRewards(bc *blockchain.BlockChain, gov governance.Engine, header *types.Header) (*blockRewards, error) {
+	number := header.Number.Uint64()
+	rules := bc.Config().Rules(header.Number)
+	pset, err := gov.EffectiveParams(number)
+	if err != nil {
+		return nil, err
+	}
+	if pset, err = gov.EffectiveParams(reward.CalcRewardParamBlock(number, pset.Epoch(), rules)); err != nil {
+		return nil, err
+	}
+	spec, err := reward.GetBlockReward(header, rules, pset)
+	if err != nil {
+		return nil, err
+	}
+	noFeeHeader := types.CopyHeader(header)
+	noFeeHeader.GasUsed = 0
+	noFeeSpec, err := reward.GetBlockReward(noFeeHeader, rules, pset)
+	if err != nil {
+		return nil, err
+	}
+
+	minted := make(map[common.Address]*big.Int)
+	fee := make(map[common.Address]*big.Int)
+	for addr, amount := range spec.Rewards {
+		if amount.Sign() == 0 {
+			continue
+		}
+		m := big.NewInt(0)
+		if noFee, ok := noFeeSpec.Rewards[addr]; ok {
+			m.Set(noFee)
+		}
+		if m.Cmp(amount) > 0 {
+			m.Set(amount)
+		}
+		minted[addr] = m
+		fee[addr] = new(big.Int).Sub(amount, m)
+	}
+	return &blockRewards{minted: minted, fee: fee, proposer: spec.Proposer, burntFee: spec.BurntFee}, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rewardIndexKCF = common.HexToAddress("0xc0f")
+
+// testRewardChain is a canonical chain of headers indexed by their block numbers.
+type testRewardChain struct {
+	headers []*types.Header
+}
+
+func (c *testRewardChain) CurrentHeader() *types.Header {
+	return c.headers[len(c.headers)-1]
+}
+
+func (c *testRewardChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number >= uint64(len(c.headers)) {
+		return nil
+	}
+	return c.headers[number]
+}
+
+// forkRewardChain returns a chain which shares the blocks up to fork with the given chain
+// and is extended up to head with the rewardbases chosen by seed.
+func forkRewardChain(base *testRewardChain, fork, head uint64, seed byte) *testRewardChain {
+	chain := &testRewardChain{}
+	if base == nil {
+		chain.headers = []*types.Header{{Number: big.NewInt(0)}}
+	} else {
+		chain.headers = append(chain.headers, base.headers[:fork+1]...)
+	}
+	for num := uint64(len(chain.headers)); num <= head; num++ {
+		chain.headers = append(chain.headers, &types.Header{
+			ParentHash: chain.headers[num-1].Hash(),
+			Number:     new(big.Int).SetUint64(num),
+			Rewardbase: common.BytesToAddress([]byte{seed, byte(num % 3)}),
+			GasUsed:    num * uint64(seed),
+			Extra:      []byte{seed},
+		})
+	}
+	return chain
+}
+
+// testBlockRewards pays 10 minted and the gas used as the fee to the rewardbase, and 1 minted to KCF.
+// The same amount of the fee is burnt.
+func testBlockRewards(header *types.Header) (*blockRewards, error) {
+	return &blockRewards{
+		minted:   map[common.Address]*big.Int{header.Rewardbase: big.NewInt(10), rewardIndexKCF: big.NewInt(1)},
+		fee:      map[common.Address]*big.Int{header.Rewardbase: new(big.Int).SetUint64(header.GasUsed), rewardIndexKCF: big.NewInt(0)},
+		proposer: new(big.Int).SetUint64(10 + header.GasUsed),
+		burntFee: new(big.Int).SetUint64(header.GasUsed),
+	}, nil
+}
+
+func newTestRewardIndex(chain *testRewardChain) *rewardIndex {
+	return &rewardIndex{db: database.NewMemoryDBManager(), chain: chain, rewards: testBlockRewards}
+}
+
+// assertRewardIndex checks that the index has the same accumulated rewards as the index built
+// from scratch along the canonical chain from the same start block.
+func assertRewardIndex(t *testing.T, idx *rewardIndex, chain *testRewardChain, addrs []common.Address) {
+	start, ok := idx.db.ReadRewardIndexStart()
+	require.True(t, ok)
+	head, ok := idx.db.ReadRewardIndexHead()
+	require.True(t, ok)
+	assert.Equal(t, chain.CurrentHeader().Number.Uint64(), head)
+
+	expected := newTestRewardIndex(chain)
+	require.NoError(t, expected.update(start))
+	require.NoError(t, expected.update(head))
+
+	for num := start; num <= head+5; num++ {
+		for _, addr := range addrs {
+			assert.Equal(t, expected.db.ReadAccumulatedReward(addr, num), idx.db.ReadAccumulatedReward(addr, num), "addr %x, block %d", addr, num)
+		}
+		assert.Equal(t, expected.db.ReadRewardIndexBlock(num), idx.db.ReadRewardIndexBlock(num), "block %d", num)
+	}
+}
+
+func rewardIndexAddrs(seeds ...byte) []common.Address {
+	addrs := []common.Address{rewardIndexKCF}
+	for _, seed := range seeds {
+		for i := byte(0); i < 3; i++ {
+			addrs = append(addrs, common.BytesToAddress([]byte{seed, i}))
+		}
+	}
+	return addrs
+}
+
+func TestRewardIndex_Accumulate(t *testing.T) {
+	chain := forkRewardChain(nil, 0, 10, 1)
+	idx := newTestRewardIndex(chain)
+	for num := uint64(3); num <= 10; num++ {
+		require.NoError(t, idx.update(num))
+	}
+
+	// blocks 3, 6 and 9 are rewarded to the rewardbase {1, 0}
+	acc := idx.db.ReadAccumulatedReward(common.BytesToAddress([]byte{1, 0}), 10)
+	require.NotNil(t, acc)
+	assert.Equal(t, uint64(9), acc.Number)
+	assert.Equal(t, big.NewInt(30), acc.Minted)
+	assert.Equal(t, big.NewInt(3+6+9), acc.Fee)
+
+	acc = idx.db.ReadAccumulatedReward(rewardIndexKCF, 10)
+	require.NotNil(t, acc)
+	assert.Equal(t, big.NewInt(8), acc.Minted)
+	block := idx.db.ReadRewardIndexBlock(6)
+	require.NotNil(t, block)
+	assert.Equal(t, big.NewInt(16), block.Proposer)
+	assert.Equal(t, big.NewInt(6), block.BurntFee)
+	assertRewardIndex(t, idx, chain, rewardIndexAddrs(1))
+
+	// an event of an indexed block does not change the index
+	require.NoError(t, idx.update(5))
+	assertRewardIndex(t, idx, chain, rewardIndexAddrs(1))
+}
+
+func TestRewardIndex_DeepReorg(t *testing.T) {
+	chainA := forkRewardChain(nil, 0, 40, 1)
+	idx := newTestRewardIndex(chainA)
+	for num := uint64(1); num <= 40; num++ {
+		require.NoError(t, idx.update(num))
+	}
+	assertRewardIndex(t, idx, chainA, rewardIndexAddrs(1))
+
+	// a longer chain forked 30 blocks below the head
+	chainB := forkRewardChain(chainA, 10, 50, 2)
+	idx.chain = chainB
+	require.NoError(t, idx.update(50))
+	assertRewardIndex(t, idx, chainB, rewardIndexAddrs(1, 2))
+
+	// a shorter chain forked 45 blocks below the head
+	chainC := forkRewardChain(chainB, 5, 20, 3)
+	idx.chain = chainC
+	require.NoError(t, idx.update(20))
+	assertRewardIndex(t, idx, chainC, rewardIndexAddrs(1, 2, 3))
+
+	// the rewards of the abandoned chains are not accumulated anymore
+	for _, addr := range rewardIndexAddrs(2)[1:] {
+		assert.Nil(t, idx.db.ReadAccumulatedReward(addr, 100))
+	}
+	for _, addr := range rewardIndexAddrs(1)[1:] {
+		acc := idx.db.ReadAccumulatedReward(addr, 100)
+		require.NotNil(t, acc)
+		assert.LessOrEqual(t, acc.Number, uint64(5))
+	}
+
+	// the chain grows again after the reorganization
+	chainD := forkRewardChain(chainC, 20, 30, 3)
+	idx.chain = chainD
+	for num := uint64(21); num <= 30; num++ {
+		require.NoError(t, idx.update(num))
+	}
+	assertRewardIndex(t, idx, chainD, rewardIndexAddrs(1, 2, 3))
+}
+
+func TestRewardIndex_ReorgBelowStart(t *testing.T) {
+	chainA := forkRewardChain(nil, 0, 20, 1)
+	idx := newTestRewardIndex(chainA)
+	for num := uint64(10); num <= 20; num++ {
+		require.NoError(t, idx.update(num))
+	}
+
+	// all the indexed blocks are abandoned
+	chainB := forkRewardChain(chainA, 3, 25, 2)
+	idx.chain = chainB
+	require.NoError(t, idx.update(25))
+
+	start, _ := idx.db.ReadRewardIndexStart()
+	assert.Equal(t, uint64(10), start)
+	assertRewardIndex(t, idx, chainB, rewardIndexAddrs(1, 2))
+	for _, addr := range rewardIndexAddrs(1)[1:] {
+		assert.Nil(t, idx.db.ReadAccumulatedReward(addr, 100))
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/rcrowley/go-metrics"
+)
+
+// rewardMonitor subscribes chainEvent and compares the reward spec of each block with the one of
+// the previous block, to give early warnings of misconfigured staking contracts or governance
+// parameters. The anomalies are logged and counted by the klay/reward/monitor/anomaly/<kind> meters.
+func rewardMonitor(bc *blockchain.BlockChain, gov governance.Engine, thresholds reward.RewardAnomalyThresholds, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+
+	var prev *reward.RewardSpec
+	for {
+		select {
+		case event := <-chainEvent:
+			header := event.Block.Header()
+			config := bc.Config()
+			pset, err := rewardParams(config, gov, header.Number.Uint64())
+			if err != nil {
+				logger.Error("Failed to monitor the block reward", "blockNum", header.Number, "err", err)
+				continue
+			}
+			spec, err := reward.GetBlockReward(header, config.Rules(header.Number), pset)
+			if err != nil {
+				logger.Error("Failed to monitor the block reward", "blockNum", header.Number, "err", err)
+				continue
+			}
+			for _, a := range reward.DetectRewardAnomalies(prev, spec, thresholds) {
+				logger.Error("Detected a block reward anomaly", "blockNum", header.Number, "hash", header.Hash(),
+					"kind", a.Kind, "previous", a.Previous, "current", a.Current)
+				metrics.GetOrRegisterMeter(rewardAnomalyMeterPrefix+a.Kind, nil).Mark(1)
+			}
+			prev = spec
+
+		case <-subscription.Err():
+			return
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+)
+
+// rewardDivergence is a recipient whose reward paid in a block differs from the reward spec.
+type rewardDivergence struct {
+	Recipient common.Address
+	Expected  *big.Int
+	Paid      *big.Int
+}
+
+// rewardVerifier subscribes chainEvent and compares the rewards paid in each block, recorded
+// when the block was finalized, with the reward spec calculated as klay_getRewards does.
+// The divergences are logged and counted by rewardDivergenceMeter to be alerted.
+func rewardVerifier(bc *blockchain.BlockChain, gov governance.Engine, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+	defer reward.SetPaidRewardRecording(false)
+
+	for {
+		select {
+		case event := <-chainEvent:
+			header := event.Block.Header()
+			divergences, verified, err := verifyBlockReward(bc.Config(), gov, header)
+			if err != nil {
+				logger.Error("Failed to verify the block reward", "blockNum", header.Number, "err", err)
+				continue
+			}
+			if !verified {
+				logger.Debug("Skipped verifying the block reward not recorded", "blockNum", header.Number)
+				continue
+			}
+			for _, d := range divergences {
+				logger.Error("The paid block reward diverged from the reward spec", "blockNum", header.Number,
+					"hash", header.Hash(), "recipient", d.Recipient, "expected", d.Expected, "paid", d.Paid)
+			}
+			if len(divergences) > 0 {
+				rewardDivergenceMeter.Mark(int64(len(divergences)))
+			}
+
+		case <-subscription.Err():
+			return
+		}
+	}
+}
+
+// verifyBlockReward returns the recipients whose rewards paid in the block of the given header
+// differ from the reward spec. It returns false if the paid rewards of the block are not recorded,
+// e.g. the block was finalized before the recording was enabled or was evicted from the record.
+func verifyBlockReward(config *params.ChainConfig, gov governance.Engine, header *types.Header) ([]rewardDivergence, bool, error) {
+	number := header.Number.Uint64()
+	paid, ok := reward.GetPaidRewards(number, header.Root)
+	if !ok {
+		return nil, false, nil
+	}
+
+	// The reward spec is calculated from scratch with the parameters klay_getRewards uses,
+	// not reusing the one calculated when the block was finalized.
+	rules := config.Rules(header.Number)
+	pset, err := rewardParams(config, gov, number)
+	if err != nil {
+		return nil, false, err
+	}
+	policy := reward.GetRewardPolicy(header.Number, pset)
+	var stakingInfo *reward.StakingInfo
+	if policy.UseStakingInfo() {
+		stakingInfo = reward.GetStakingInfo(number)
+	}
+	spec, err := policy.CalcDeferredReward(header, rules, pset, stakingInfo)
+	if err != nil {
+		return nil, false, err
+	}
+	return compareRewards(spec.Rewards, paid), true, nil
+}
+
+// rewardParams returns the governance parameters with which the reward of the block is calculated.
+func rewardParams(config *params.ChainConfig, gov governance.Engine, number uint64) (*params.GovParamSet, error) {
+	pset, err := gov.EffectiveParams(number)
+	if err != nil {
+		return nil, err
+	}
+	rules := config.Rules(new(big.Int).SetUint64(number))
+	return gov.EffectiveParams(reward.CalcRewardParamBlock(number, pset.Epoch(), rules))
+}
+
+// compareRewards returns the recipients whose paid rewards differ from the expected ones,
+// in the order of the addresses. A recipient missing in either map is regarded as zero.
+func compareRewards(expected, paid map[common.Address]*big.Int) []rewardDivergence {
+	amount := func(rewards map[common.Address]*big.Int, addr common.Address) *big.Int {
+		if v, ok := rewards[addr]; ok && v != nil {
+			return v
+		}
+		return common.Big0
+	}
+
+	var divergences []rewardDivergence
+	for _, rewards := range []map[common.Address]*big.Int{expected, paid} {
+		for addr := range rewards {
+			e, p := amount(expected, addr), amount(paid, addr)
+			if e.Cmp(p) == 0 || containsRecipient(divergences, addr) {
+				continue
+			}
+			divergences = append(divergences, rewardDivergence{Recipient: addr, Expected: e, Paid: p})
+		}
+	}
+	sort.Slice(divergences, func(i, j int) bool {
+		return bytes.Compare(divergences[i].Recipient[:], divergences[j].Recipient[:]) < 0
+	})
+	return divergences
+}
+
+func containsRecipient(divergences []rewardDivergence, addr common.Address) bool {
+	for _, d := range divergences {
+		if d.Recipient == addr {
+			return true
+		}
+	}
+	return false
+}
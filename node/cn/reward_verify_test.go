@@ -0,0 +1,47 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareRewards(t *testing.T) {
+	var (
+		addr1 = common.HexToAddress("0x1")
+		addr2 = common.HexToAddress("0x2")
+		addr3 = common.HexToAddress("0x3")
+	)
+
+	assert.Empty(t, compareRewards(
+		map[common.Address]*big.Int{addr1: big.NewInt(10), addr2: big.NewInt(0)},
+		map[common.Address]*big.Int{addr1: big.NewInt(10)},
+	))
+
+	assert.Equal(t, []rewardDivergence{
+		{Recipient: addr1, Expected: big.NewInt(10), Paid: big.NewInt(9)},
+		{Recipient: addr2, Expected: big.NewInt(5), Paid: big.NewInt(0)},
+		{Recipient: addr3, Expected: big.NewInt(0), Paid: big.NewInt(1)},
+	}, compareRewards(
+		map[common.Address]*big.Int{addr2: big.NewInt(5), addr1: big.NewInt(10)},
+		map[common.Address]*big.Int{addr3: big.NewInt(1), addr1: big.NewInt(9)},
+	))
+}
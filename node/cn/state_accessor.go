@@ -185,7 +185,7 @@ func (cn *CN) stateAtTransaction(block *types.Block, txIndex int, reexec uint64)
 		}
 
 		txContext := blockchain.NewEVMTxContext(msg, block.Header())
-		blockContext := blockchain.NewEVMBlockContext(block.Header(), cn.blockchain, nil)
+		blockContext := blockchain.NewEVMBlockContextWithBurnRatio(block.Header(), cn.blockchain, nil)
 		if idx == txIndex {
 			return msg, blockContext, txContext, statedb, nil
 		}
@@ -24,6 +24,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -44,6 +45,7 @@ import (
 	"github.com/klaytn/klaytn/consensus"
 	"github.com/klaytn/klaytn/log"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/contractmeta"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
 	"github.com/klaytn/klaytn/storage/database"
@@ -102,6 +104,7 @@ type Backend interface {
 type API struct {
 	backend     Backend
 	unsafeTrace bool
+	sinks       SinkConfig
 }
 
 // NewAPIUnsafeDisabled creates a new API definition for the tracing methods of the CN service,
@@ -116,6 +119,11 @@ func NewAPI(backend Backend) *API {
 	return &API{backend: backend, unsafeTrace: true}
 }
 
+// SetSinkConfig sets the sinks the traces can be streamed to by the Sink of TraceConfig.
+func (api *API) SetSinkConfig(cfg SinkConfig) {
+	api.sinks = cfg
+}
+
 type chainContext struct {
 	backend Backend
 	ctx     context.Context
@@ -178,6 +186,13 @@ type TraceConfig struct {
 	Timeout       *string
 	LoggerTimeout *string
 	Reexec        *uint64
+	// Decode makes the calls traced by the fastCallTracer decoded by the registered contract
+	// metadata. The fastCallTracer is used if no tracer is given.
+	Decode *bool
+	// Sink streams the trace to the sink of the given kind (file, socket or otlp) configured
+	// in the node instead of returning it, and a SinkResult is returned. The structured logs
+	// are streamed step by step, while the result of the other tracers is written as a record.
+	Sink *string
 }
 
 // StdTraceConfig holds extra parameters to standard-json trace functions.
@@ -306,9 +321,9 @@ func (api *API) traceChain(start, end *types.Block, config *TraceConfig, notifie
 					}
 
 					txCtx := blockchain.NewEVMTxContext(msg, task.block.Header())
-					blockCtx := blockchain.NewEVMBlockContext(task.block.Header(), newChainContext(localctx, api.backend), nil)
+					blockCtx := blockchain.NewEVMBlockContextWithBurnRatio(task.block.Header(), newChainContext(localctx, api.backend), nil)
 
-					res, err := api.traceTx(localctx, msg, blockCtx, txCtx, task.statedb, config)
+					res, err := api.traceTx(localctx, tx.Hash(), msg, blockCtx, txCtx, task.statedb, config)
 					if err != nil {
 						task.results[i] = &txTraceResult{TxHash: tx.Hash(), Error: err.Error()}
 						logger.Warn("Tracing failed", "hash", tx.Hash(), "block", task.block.NumberU64(), "err", err)
@@ -478,6 +493,40 @@ func (api *API) TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber,
 	return api.traceBlock(ctx, block, config)
 }
 
+// TraceBlockByNumberPaged returns the tracing results of a page of the transactions in the
+// block, e.g. the internal transactions with the call tracers. The cursor is the index of
+// the next transaction.
+func (api *API) TraceBlockByNumberPaged(ctx context.Context, number rpc.BlockNumber, config *TraceConfig, page *rpc.PageArgs) (*rpc.Page, error) {
+	limit, err := page.PageLimit()
+	if err != nil {
+		return nil, err
+	}
+	var from uint64
+	if _, err := page.DecodeCursor(&from); err != nil {
+		return nil, err
+	}
+	block, err := api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	txCount := uint64(len(block.Transactions()))
+	if from > txCount {
+		from = txCount
+	}
+	to := from + uint64(limit)
+	if to > txCount {
+		to = txCount
+	}
+	results, err := api.traceBlockTxs(ctx, block, config, int(from), int(to))
+	if err != nil {
+		return nil, err
+	}
+	if to < txCount {
+		return rpc.NewPage(results, to)
+	}
+	return rpc.NewPage(results, nil)
+}
+
 // TraceBlockByNumberRange returns the ranged blocks tracing results
 // TODO-tracer: limit the result by the size of the return
 func (api *API) TraceBlockByNumberRange(ctx context.Context, start, end rpc.BlockNumber, config *TraceConfig) (map[uint64]*blockTraceResult, error) {
@@ -579,6 +628,12 @@ func (api *API) StandardTraceBadBlockToFile(ctx context.Context, hash common.Has
 // executes all the transactions contained within. The return value will be one item
 // per transaction, dependent on the requestd tracer.
 func (api *API) traceBlock(ctx context.Context, block *types.Block, config *TraceConfig) ([]*txTraceResult, error) {
+	return api.traceBlockTxs(ctx, block, config, 0, len(block.Transactions()))
+}
+
+// traceBlockTxs is the same as traceBlock except that only the transactions in the index
+// range of [from, to) are traced. The transactions after the range are not executed.
+func (api *API) traceBlockTxs(ctx context.Context, block *types.Block, config *TraceConfig, from, to int) ([]*txTraceResult, error) {
 	if !api.unsafeTrace {
 		if atomic.LoadInt32(&heavyAPIRequestCount) >= HeavyAPIRequestLimit {
 			return nil, fmt.Errorf("heavy debug api requests exceed the limit: %d", int64(HeavyAPIRequestLimit))
@@ -607,14 +662,14 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 	var (
 		signer  = types.MakeSigner(api.backend.ChainConfig(), block.Number())
 		txs     = block.Transactions()
-		results = make([]*txTraceResult, len(txs))
+		results = make([]*txTraceResult, to-from)
 
 		pend = new(sync.WaitGroup)
-		jobs = make(chan *txTraceTask, len(txs))
+		jobs = make(chan *txTraceTask, to-from)
 	)
 	threads := runtime.NumCPU()
-	if threads > len(txs) {
-		threads = len(txs)
+	if threads > to-from {
+		threads = to - from
 	}
 	for th := 0; th < threads; th++ {
 		pend.Add(1)
@@ -626,26 +681,31 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 				msg, err := txs[task.index].AsMessageWithAccountKeyPicker(signer, task.statedb, block.NumberU64())
 				if err != nil {
 					logger.Warn("Tracing failed", "tx idx", task.index, "block", block.NumberU64(), "err", err)
-					results[task.index] = &txTraceResult{TxHash: txs[task.index].Hash(), Error: err.Error()}
+					results[task.index-from] = &txTraceResult{TxHash: txs[task.index].Hash(), Error: err.Error()}
 					continue
 				}
 
 				txCtx := blockchain.NewEVMTxContext(msg, block.Header())
-				blockCtx := blockchain.NewEVMBlockContext(block.Header(), newChainContext(ctx, api.backend), nil)
-				res, err := api.traceTx(ctx, msg, blockCtx, txCtx, task.statedb, config)
+				blockCtx := blockchain.NewEVMBlockContextWithBurnRatio(block.Header(), newChainContext(ctx, api.backend), nil)
+				res, err := api.traceTx(ctx, txs[task.index].Hash(), msg, blockCtx, txCtx, task.statedb, config)
 				if err != nil {
-					results[task.index] = &txTraceResult{TxHash: txs[task.index].Hash(), Error: err.Error()}
+					results[task.index-from] = &txTraceResult{TxHash: txs[task.index].Hash(), Error: err.Error()}
 					continue
 				}
-				results[task.index] = &txTraceResult{TxHash: txs[task.index].Hash(), Result: res}
+				results[task.index-from] = &txTraceResult{TxHash: txs[task.index].Hash(), Result: res}
 			}
 		}()
 	}
 	// Feed the transactions into the tracers and return
 	var failed error
-	for i, tx := range txs {
+	for i, tx := range txs[:to] {
 		// Send the trace task over for execution
-		jobs <- &txTraceTask{statedb: statedb.Copy(), index: i}
+		if i >= from {
+			jobs <- &txTraceTask{statedb: statedb.Copy(), index: i}
+		}
+		if i == to-1 {
+			break // the state after the last traced transaction is not needed
+		}
 
 		// Generate the next state snapshot fast without tracing
 		msg, err := tx.AsMessageWithAccountKeyPicker(signer, statedb, block.NumberU64())
@@ -656,7 +716,7 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 		}
 
 		txCtx := blockchain.NewEVMTxContext(msg, block.Header())
-		blockCtx := blockchain.NewEVMBlockContext(block.Header(), newChainContext(ctx, api.backend), nil)
+		blockCtx := blockchain.NewEVMBlockContextWithBurnRatio(block.Header(), newChainContext(ctx, api.backend), nil)
 		vmenv := vm.NewEVM(blockCtx, txCtx, statedb, api.backend.ChainConfig(), &vm.Config{UseOpcodeComputationCost: true})
 		if _, err = blockchain.ApplyMessage(vmenv, msg); err != nil {
 			failed = err
@@ -728,7 +788,7 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 
 		var (
 			txCtx    = blockchain.NewEVMTxContext(msg, block.Header())
-			blockCtx = blockchain.NewEVMBlockContext(block.Header(), newChainContext(ctx, api.backend), nil)
+			blockCtx = blockchain.NewEVMBlockContextWithBurnRatio(block.Header(), newChainContext(ctx, api.backend), nil)
 
 			vmConf vm.Config
 			dump   *os.File
@@ -818,7 +878,7 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 		return nil, err
 	}
 	// Trace the transaction and return
-	return api.traceTx(ctx, msg, blockCtx, txCtx, statedb, config)
+	return api.traceTx(ctx, hash, msg, blockCtx, txCtx, statedb, config)
 }
 
 // TraceCall lets you trace a given klay_call. It collects the structured logs
@@ -879,15 +939,39 @@ func (api *API) TraceCall(ctx context.Context, args klaytnapi.CallArgs, blockNrO
 	statedb.AddBalance(msg.ValidatedSender(), new(big.Int).Mul(new(big.Int).SetUint64(msg.Gas()), basefee))
 
 	txCtx := blockchain.NewEVMTxContext(msg, block.Header())
-	blockCtx := blockchain.NewEVMBlockContext(block.Header(), newChainContext(ctx, api.backend), nil)
+	blockCtx := blockchain.NewEVMBlockContextWithBurnRatio(block.Header(), newChainContext(ctx, api.backend), nil)
 
-	return api.traceTx(ctx, msg, blockCtx, txCtx, statedb, config)
+	return api.traceTx(ctx, common.Hash{}, msg, blockCtx, txCtx, statedb, config)
 }
 
 // traceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
-func (api *API) traceTx(ctx context.Context, message blockchain.Message, blockCtx vm.BlockContext, txCtx vm.TxContext, statedb *state.StateDB, config *TraceConfig) (interface{}, error) {
+func (api *API) traceTx(ctx context.Context, txHash common.Hash, message blockchain.Message, blockCtx vm.BlockContext, txCtx vm.TxContext, statedb *state.StateDB, config *TraceConfig) (interface{}, error) {
+	decode := config != nil && config.Decode != nil && *config.Decode
+	if decode {
+		if config.Tracer == nil {
+			tracerName := fastCallTracer
+			decodeConfig := *config
+			decodeConfig.Tracer = &tracerName
+			config = &decodeConfig
+		} else if *config.Tracer != fastCallTracer {
+			return nil, fmt.Errorf("decoding is supported only by the %s", fastCallTracer)
+		}
+	}
+	// Open the sink the trace is streamed to, named after the transaction
+	var sink Sink
+	if config != nil && config.Sink != nil {
+		name := fmt.Sprintf("call-%d", time.Now().UnixNano())
+		if txHash != (common.Hash{}) {
+			name = txHash.Hex()
+		}
+		var err error
+		if sink, err = api.sinks.openSink(*config.Sink, name); err != nil {
+			return nil, err
+		}
+		defer sink.Close()
+	}
 	// Assemble the structured logger or the JavaScript tracer
 	var (
 		tracer vm.Tracer
@@ -931,18 +1015,34 @@ func (api *API) traceTx(ctx context.Context, message blockchain.Message, blockCt
 	case config == nil:
 		tracer = vm.NewStructLogger(nil)
 
+	case sink != nil:
+		logConfig := config.LogConfig
+		if logConfig == nil {
+			logConfig = &vm.LogConfig{}
+		}
+		tracer = vm.NewJSONLogger(logConfig, sink)
+
 	default:
 		tracer = vm.NewStructLogger(config.LogConfig)
 	}
 	// Run the transaction with tracing enabled.
-	vmenv := vm.NewEVM(blockCtx, txCtx, statedb, api.backend.ChainConfig(), &vm.Config{Debug: true, Tracer: tracer, UseOpcodeComputationCost: true})
+	budget := rpc.ComputeBudget(ctx)
+	vmenv := vm.NewEVM(blockCtx, txCtx, statedb, api.backend.ChainConfig(), &vm.Config{Debug: true, Tracer: tracer, UseOpcodeComputationCost: true, ComputationCostBudget: budget})
 
 	ret, err := blockchain.ApplyMessage(vmenv, message)
+	if err == vm.ErrComputationCostBudgetExceeded {
+		return nil, &rpc.ComputeBudgetExceededError{Budget: budget}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("tracing failed: %v", err)
 	}
 	// Depending on the tracer type, format and return the output
+	var result interface{}
 	switch tracer := tracer.(type) {
+	case *vm.JSONLogger:
+		// The steps and the end of the execution were streamed already
+		return closeSink(sink, *config.Sink, ret)
+
 	case *vm.StructLogger:
 		loggerTimeout := defaultLoggerTimeout
 		if config != nil && config.LoggerTimeout != nil {
@@ -962,11 +1062,27 @@ func (api *API) traceTx(ctx context.Context, message blockchain.Message, blockCt
 		}
 
 	case *Tracer:
-		return tracer.GetResult()
+		result, err = tracer.GetResult()
 	case *vm.InternalTxTracer:
-		return tracer.GetResult()
+		trace, traceErr := tracer.GetResult()
+		if traceErr == nil && decode {
+			result = contractmeta.NewRegistry(api.backend.ChainDB(), nil).DecodeTrace(trace)
+		} else {
+			result, err = trace, traceErr
+		}
 
 	default:
 		panic(fmt.Sprintf("bad tracer type %T", tracer))
 	}
+	if err != nil || sink == nil {
+		return result, err
+	}
+	record, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sink.Write(append(record, '\n')); err != nil {
+		return nil, fmt.Errorf("trace sink failed: %v", err)
+	}
+	return closeSink(sink, *config.Sink, ret)
 }
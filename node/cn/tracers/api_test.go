@@ -23,6 +23,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -41,10 +42,12 @@ import (
 	"github.com/klaytn/klaytn/consensus/gxhash"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/contractmeta"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/klaytn/klaytn/storage/statedb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -326,6 +329,38 @@ func TestTraceTransaction(t *testing.T) {
 	}
 }
 
+func TestTraceTransactionDecoded(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &blockchain.Genesis{Alloc: blockchain.GenesisAlloc{
+		accounts[0].addr: {Balance: big.NewInt(params.KLAY)},
+		accounts[1].addr: {Balance: big.NewInt(params.KLAY)},
+	}}
+	target := common.Hash{}
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	backend := newTestBackend(t, 1, genesis, func(i int, b *blockchain.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), accounts[1].addr, big.NewInt(1000), params.TxGas, big.NewInt(1), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	})
+	registry := contractmeta.NewRegistry(backend.ChainDB(), nil)
+	require.NoError(t, registry.Register(&contractmeta.Metadata{Address: accounts[1].addr, Name: "Receiver", ABI: json.RawMessage(`[]`)}, contractmeta.OriginRPC))
+	api := NewAPI(backend)
+
+	decode := true
+	result, err := api.TraceTransaction(context.Background(), target, &TraceConfig{Decode: &decode})
+	require.NoError(t, err)
+	decoded, ok := result.(*contractmeta.DecodedCall)
+	require.True(t, ok)
+	assert.Equal(t, "CALL", decoded.Type)
+	assert.Equal(t, "Receiver", decoded.Contract)
+
+	tracer := "callTracer"
+	_, err = api.TraceTransaction(context.Background(), target, &TraceConfig{Tracer: &tracer, Decode: &decode})
+	assert.Error(t, err)
+}
+
 func TestTraceBlock(t *testing.T) {
 	t.Parallel()
 
@@ -442,6 +477,47 @@ func TestTraceBlock(t *testing.T) {
 	}
 }
 
+func TestTraceBlockByNumberPaged(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &blockchain.Genesis{Alloc: blockchain.GenesisAlloc{
+		accounts[0].addr: {Balance: big.NewInt(params.KLAY)},
+	}}
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	var txHashes []common.Hash
+	api := NewAPI(newTestBackend(t, 1, genesis, func(i int, b *blockchain.BlockGen) {
+		for nonce := uint64(0); nonce < 5; nonce++ {
+			tx, _ := types.SignTx(types.NewTransaction(nonce, accounts[1].addr, big.NewInt(1000), params.TxGas, big.NewInt(0), nil), signer, accounts[0].key)
+			b.AddTx(tx)
+			txHashes = append(txHashes, tx.Hash())
+		}
+	}))
+
+	limit := hexutil.Uint64(2)
+	args := &rpc.PageArgs{Limit: &limit}
+	var results []*txTraceResult
+	for {
+		page, err := api.TraceBlockByNumberPaged(context.Background(), rpc.BlockNumber(1), nil, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, page.Items.([]*txTraceResult)...)
+		if !page.HasMore {
+			break
+		}
+		args.Cursor = page.Cursor
+	}
+	if len(results) != len(txHashes) {
+		t.Fatalf("Result length mismatch, want %v, get %v", len(txHashes), len(results))
+	}
+	for i, r := range results {
+		if r.TxHash != txHashes[i] || r.Error != "" {
+			t.Errorf("Result mismatch at %d, want %v, get %v (err: %v)", i, txHashes[i], r.TxHash, r.Error)
+		}
+	}
+}
+
 type Account struct {
 	key  *ecdsa.PrivateKey
 	addr common.Address
@@ -23,5 +23,6 @@ Source Files
   - tracer.go  : implementation of Tracer
   - tracers.go : provides managing functions of tracers
   - api.go     : provides private debug API related to trace chain, block and state
+  - sink.go    : provides the sinks the traces can be streamed to instead of the RPC response
 */
 package tracers
@@ -0,0 +1,388 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+)
+
+const (
+	SinkFile   = "file"
+	SinkSocket = "socket"
+	SinkOTLP   = "otlp"
+
+	// DefaultSinkMaxFileSize is the size of a trace file after which the file sink rotates it.
+	DefaultSinkMaxFileSize = 256 * 1024 * 1024
+
+	// otlpBatchSize is the number of records exported by an OTLP request.
+	otlpBatchSize = 1024
+
+	otlpTimeout = 10 * time.Second
+)
+
+var (
+	errUnknownSink = errors.New("unknown trace sink")
+	errSinkClosed  = errors.New("trace sink closed")
+)
+
+// SinkConfig configures the sinks the traces can be streamed to instead of being buffered
+// for the RPC response. A sink is available only if its destination is configured.
+type SinkConfig struct {
+	Dir          string `toml:",omitempty"` // directory of the rotating trace files
+	MaxFileSize  uint64 `toml:",omitempty"` // size of a trace file to be rotated, DefaultSinkMaxFileSize if zero
+	MaxFiles     int    `toml:",omitempty"` // number of the rotated files kept per trace, unlimited if zero
+	Socket       string `toml:",omitempty"` // path of the Unix socket the traces are written to
+	OTLPEndpoint string `toml:",omitempty"` // URL of the OTLP/HTTP logs endpoint, e.g. http://localhost:4318/v1/logs
+}
+
+// Sink receives the records of a single trace. Every Write carries one JSON record.
+type Sink interface {
+	Write(record []byte) (int, error)
+	// Close flushes the pending records and returns the first error of the sink.
+	Close() error
+	// Location describes where the records were delivered.
+	Location() string
+	// Records returns the number of the written records.
+	Records() uint64
+}
+
+// SinkResult is returned by the trace APIs in place of the trace streamed to a sink.
+type SinkResult struct {
+	Sink        string `json:"sink"`
+	Location    string `json:"location"`
+	Records     uint64 `json:"records"`
+	Gas         uint64 `json:"gas,omitempty"`
+	Failed      bool   `json:"failed,omitempty"`
+	ReturnValue string `json:"returnValue,omitempty"`
+}
+
+// openSink opens the sink of the given kind for the trace of the given name.
+func (cfg *SinkConfig) openSink(kind, name string) (Sink, error) {
+	switch kind {
+	case SinkFile:
+		if cfg.Dir == "" {
+			return nil, fmt.Errorf("%s trace sink is not configured", kind)
+		}
+		return newFileSink(cfg.Dir, name, cfg.MaxFileSize, cfg.MaxFiles)
+	case SinkSocket:
+		if cfg.Socket == "" {
+			return nil, fmt.Errorf("%s trace sink is not configured", kind)
+		}
+		return newSocketSink(cfg.Socket)
+	case SinkOTLP:
+		if cfg.OTLPEndpoint == "" {
+			return nil, fmt.Errorf("%s trace sink is not configured", kind)
+		}
+		return newOTLPSink(cfg.OTLPEndpoint, name), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownSink, kind)
+	}
+}
+
+// fileSink writes the records to the files <dir>/<name>-<seq>.jsonl, moving to the next
+// file when the current one exceeds maxSize and removing the oldest beyond maxFiles.
+type fileSink struct {
+	dir      string
+	name     string
+	maxSize  uint64
+	maxFiles int
+
+	file    *os.File
+	writer  *bufio.Writer
+	size    uint64
+	seq     int
+	records uint64
+	err     error
+}
+
+func newFileSink(dir, name string, maxSize uint64, maxFiles int) (*fileSink, error) {
+	if maxSize == 0 {
+		maxSize = DefaultSinkMaxFileSize
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	s := &fileSink{dir: dir, name: name, maxSize: maxSize, maxFiles: maxFiles}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileSink) path(seq int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s-%04d.jsonl", s.name, seq))
+}
+
+func (s *fileSink) open() error {
+	file, err := os.OpenFile(s.path(s.seq), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	s.file, s.writer, s.size = file, bufio.NewWriter(file), 0
+	return nil
+}
+
+func (s *fileSink) rotate() error {
+	if err := s.closeFile(); err != nil {
+		return err
+	}
+	s.seq++
+	if s.maxFiles > 0 && s.seq >= s.maxFiles {
+		if err := os.Remove(s.path(s.seq - s.maxFiles)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return s.open()
+}
+
+func (s *fileSink) closeFile() error {
+	if err := s.writer.Flush(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
+
+func (s *fileSink) Write(record []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.size > 0 && s.size+uint64(len(record)) > s.maxSize {
+		if s.err = s.rotate(); s.err != nil {
+			return 0, s.err
+		}
+	}
+	n, err := s.writer.Write(record)
+	s.size += uint64(n)
+	if err != nil {
+		s.err = err
+		return n, err
+	}
+	s.records++
+	return n, nil
+}
+
+func (s *fileSink) Close() error {
+	if s.file == nil {
+		return s.err
+	}
+	if err := s.closeFile(); err != nil && s.err == nil {
+		s.err = err
+	}
+	s.file = nil
+	return s.err
+}
+
+func (s *fileSink) Location() string { return s.path(0) }
+func (s *fileSink) Records() uint64  { return s.records }
+
+// socketSink writes the records of a trace to its own connection to a Unix socket,
+// so the reader can tell the traces apart by the connections.
+type socketSink struct {
+	path    string
+	conn    net.Conn
+	writer  *bufio.Writer
+	records uint64
+	err     error
+}
+
+func newSocketSink(path string) (*socketSink, error) {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return &socketSink{path: path, conn: conn, writer: bufio.NewWriter(conn)}, nil
+}
+
+func (s *socketSink) Write(record []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	n, err := s.writer.Write(record)
+	if err != nil {
+		s.err = err
+		return n, err
+	}
+	s.records++
+	return n, nil
+}
+
+func (s *socketSink) Close() error {
+	if s.conn == nil {
+		return s.err
+	}
+	if err := s.writer.Flush(); err != nil && s.err == nil {
+		s.err = err
+	}
+	if err := s.conn.Close(); err != nil && s.err == nil {
+		s.err = err
+	}
+	s.conn = nil
+	return s.err
+}
+
+func (s *socketSink) Location() string { return s.path }
+func (s *socketSink) Records() uint64  { return s.records }
+
+// otlpSink exports the records as the log records of the OTLP/HTTP JSON protocol in
+// batches of otlpBatchSize. The records of a trace share the trace.name attribute and
+// are ordered by the trace.seq attribute.
+type otlpSink struct {
+	endpoint string
+	name     string
+	client   *http.Client
+
+	batch   []json.RawMessage
+	records uint64
+	err     error
+	closed  bool
+}
+
+func newOTLPSink(endpoint, name string) *otlpSink {
+	return &otlpSink{
+		endpoint: endpoint,
+		name:     name,
+		client:   &http.Client{Timeout: otlpTimeout},
+		batch:    make([]json.RawMessage, 0, otlpBatchSize),
+	}
+}
+
+func (s *otlpSink) Write(record []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.closed {
+		return 0, errSinkClosed
+	}
+	s.batch = append(s.batch, append(json.RawMessage{}, bytes.TrimSpace(record)...))
+	if len(s.batch) == otlpBatchSize {
+		s.err = s.export()
+	}
+	return len(record), s.err
+}
+
+func (s *otlpSink) Close() error {
+	if !s.closed && s.err == nil && len(s.batch) > 0 {
+		s.err = s.export()
+	}
+	s.closed = true
+	return s.err
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"` // int64 is a string in the OTLP JSON encoding
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano string          `json:"timeUnixNano"`
+	Body         otlpValue       `json:"body"`
+	Attributes   []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      map[string]string `json:"scope"`
+	LogRecords []otlpLogRecord   `json:"logRecords"`
+}
+
+type otlpResourceLogs struct {
+	Resource  map[string][]otlpAttribute `json:"resource"`
+	ScopeLogs []otlpScopeLogs            `json:"scopeLogs"`
+}
+
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+func stringAttribute(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{StringValue: &value}}
+}
+
+func intAttribute(key string, value uint64) otlpAttribute {
+	v := strconv.FormatUint(value, 10)
+	return otlpAttribute{Key: key, Value: otlpValue{IntValue: &v}}
+}
+
+func (s *otlpSink) export() error {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+	logs := make([]otlpLogRecord, len(s.batch))
+	for i, record := range s.batch {
+		body := string(record)
+		logs[i] = otlpLogRecord{
+			TimeUnixNano: now,
+			Body:         otlpValue{StringValue: &body},
+			Attributes: []otlpAttribute{
+				stringAttribute("trace.name", s.name),
+				intAttribute("trace.seq", s.records+uint64(i)),
+			},
+		}
+	}
+	req := otlpLogsRequest{ResourceLogs: []otlpResourceLogs{{
+		Resource:  map[string][]otlpAttribute{"attributes": {stringAttribute("service.name", "klaytn")}},
+		ScopeLogs: []otlpScopeLogs{{Scope: map[string]string{"name": "klaytn/tracers"}, LogRecords: logs}},
+	}}}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("otlp export failed: %s", resp.Status)
+	}
+	s.records += uint64(len(s.batch))
+	s.batch = s.batch[:0]
+	return nil
+}
+
+func (s *otlpSink) Location() string { return s.endpoint }
+func (s *otlpSink) Records() uint64  { return s.records }
+
+// closeSink closes the sink of the given kind and summarizes the streamed trace.
+func closeSink(sink Sink, kind string, ret *blockchain.ExecutionResult) (interface{}, error) {
+	if err := sink.Close(); err != nil {
+		return nil, fmt.Errorf("trace sink failed: %v", err)
+	}
+	return &SinkResult{
+		Sink:        kind,
+		Location:    sink.Location(),
+		Records:     sink.Records(),
+		Gas:         ret.UsedGas,
+		Failed:      ret.Failed(),
+		ReturnValue: fmt.Sprintf("%x", ret.Return()),
+	}, nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceTransactionSink(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(1)
+	contract := common.HexToAddress("0x00000000000000000000000000000000000c0de")
+	genesis := &blockchain.Genesis{Alloc: blockchain.GenesisAlloc{
+		accounts[0].addr: {Balance: big.NewInt(params.KLAY)},
+		contract:         {Balance: common.Big0, Code: hexutil.MustDecode("0x600160020100")}, // PUSH1 1 PUSH1 2 ADD STOP
+	}}
+	target := common.Hash{}
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	api := NewAPI(newTestBackend(t, 1, genesis, func(i int, b *blockchain.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), contract, common.Big0, 100000, big.NewInt(1), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	}))
+	dir := t.TempDir()
+	api.SetSinkConfig(SinkConfig{Dir: dir, MaxFileSize: 1, MaxFiles: 2})
+
+	// The 4 steps and the end of the execution are streamed, a file for each record
+	sink := SinkFile
+	result, err := api.TraceTransaction(context.Background(), target, &TraceConfig{Sink: &sink})
+	require.NoError(t, err)
+	res, ok := result.(*SinkResult)
+	require.True(t, ok)
+	assert.Equal(t, SinkFile, res.Sink)
+	assert.Equal(t, uint64(5), res.Records)
+	assert.False(t, res.Failed)
+	assert.Equal(t, filepath.Join(dir, target.Hex()+"-0000.jsonl"), res.Location)
+
+	files, err := filepath.Glob(filepath.Join(dir, target.Hex()+"-*.jsonl"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, target.Hex()+"-0003.jsonl"),
+		filepath.Join(dir, target.Hex()+"-0004.jsonl"),
+	}, files)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	var step map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &step))
+	assert.Equal(t, "STOP", step["opName"])
+
+	// The result of a tracer is written as a single record
+	api.SetSinkConfig(SinkConfig{Dir: dir})
+	tracer := "callTracer"
+	result, err = api.TraceTransaction(context.Background(), target, &TraceConfig{Tracer: &tracer, Sink: &sink})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), result.(*SinkResult).Records)
+	data, err = os.ReadFile(filepath.Join(dir, target.Hex()+"-0000.jsonl"))
+	require.NoError(t, err)
+	var call map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &call))
+	assert.Equal(t, "CALL", call["type"])
+
+	// The sinks not configured are rejected
+	sink = SinkSocket
+	_, err = api.TraceTransaction(context.Background(), target, &TraceConfig{Sink: &sink})
+	assert.Error(t, err)
+	sink = "unknown"
+	_, err = api.TraceTransaction(context.Background(), target, &TraceConfig{Sink: &sink})
+	assert.ErrorIs(t, err, errUnknownSink)
+}
+
+func TestSocketSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	lines := make(chan []string)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(lines)
+			return
+		}
+		defer conn.Close()
+		var read []string
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			read = append(read, scanner.Text())
+		}
+		lines <- read
+	}()
+
+	sink, err := (&SinkConfig{Socket: path}).openSink(SinkSocket, "trace")
+	require.NoError(t, err)
+	for _, record := range []string{`{"a":1}`, `{"b":2}`} {
+		_, err := sink.Write([]byte(record + "\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, sink.Close())
+	assert.Equal(t, uint64(2), sink.Records())
+	assert.Equal(t, []string{`{"a":1}`, `{"b":2}`}, <-lines)
+}
+
+func TestOTLPSink(t *testing.T) {
+	var requests []otlpLogsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req otlpLogsRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, req)
+	}))
+	defer server.Close()
+
+	sink, err := (&SinkConfig{OTLPEndpoint: server.URL}).openSink(SinkOTLP, "trace")
+	require.NoError(t, err)
+	for i := 0; i < otlpBatchSize+1; i++ {
+		_, err := sink.Write([]byte("{}\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, sink.Close())
+	assert.Equal(t, uint64(otlpBatchSize+1), sink.Records())
+
+	require.Len(t, requests, 2)
+	logs := requests[1].ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, logs, 1)
+	assert.Equal(t, "{}", *logs[0].Body.StringValue)
+	assert.Equal(t, "trace", *logs[0].Attributes[0].Value.StringValue)
+	assert.Equal(t, "1024", *logs[0].Attributes[1].Value.IntValue)
+
+	// The export failure is reported by the sink
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	sink, err = (&SinkConfig{OTLPEndpoint: server.URL}).openSink(SinkOTLP, "trace")
+	require.NoError(t, err)
+	_, err = sink.Write([]byte("{}\n"))
+	require.NoError(t, err)
+	assert.Error(t, sink.Close())
+}
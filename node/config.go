@@ -99,6 +99,27 @@ type Config struct {
 	// relative), then that specific path is enforced. An empty path disables IPC.
 	IPCPath string `toml:",omitempty"`
 
+	// AdminPath is the requested location of the authenticated admin endpoint. If the
+	// path is empty, the admin endpoint is disabled. Like IPCPath, a simple file name is
+	// placed in the data directory.
+	AdminPath string `toml:",omitempty"`
+
+	// AdminModules is a list of API modules to expose via the admin endpoint.
+	// If the module list is empty, rpc.DefaultAdminModules are exposed.
+	AdminModules []string `toml:",omitempty"`
+
+	// AdminTokenFile is the JSON file of the bearer tokens authenticating the admin callers.
+	AdminTokenFile string `toml:",omitempty"`
+
+	// AdminTLSCert, AdminTLSKey and AdminTLSClientCA enable mutual TLS on the admin endpoint.
+	AdminTLSCert     string `toml:",omitempty"`
+	AdminTLSKey      string `toml:",omitempty"`
+	AdminTLSClientCA string `toml:",omitempty"`
+
+	// AdminExclusive removes the admin modules from the IPC, HTTP, WebSocket and gRPC
+	// endpoints so that they can be invoked only via the admin endpoint.
+	AdminExclusive bool `toml:",omitempty"`
+
 	// HTTP module type is http server module type (fasthttp and http)
 	HTTPServerType string `toml:",omitempty"`
 
@@ -130,10 +151,20 @@ type Config struct {
 	// exposed.
 	HTTPModules []string `toml:",omitempty"`
 
+	// HTTPVirtualEndpoints is a list of virtual endpoints served by the HTTP RPC server,
+	// each with its own modules, CORS domains and rate limit. The requests not routed
+	// to any virtual endpoint are served with the settings above.
+	HTTPVirtualEndpoints []rpc.VirtualEndpoint `toml:",omitempty"`
+
 	// HTTPTimeouts allows for customization of the timeout values used by the HTTP RPC
 	// interface.
 	HTTPTimeouts rpc.HTTPTimeouts
 
+	// HTTPComputeBudget limits the sum of computation cost of opcodes of an EVM execution
+	// served by a call over the HTTP RPC interface, e.g. klay_call and the traces. It does
+	// not apply to the virtual endpoints, which have their own budgets. Zero means no budget.
+	HTTPComputeBudget uint64 `toml:",omitempty"`
+
 	// WSHost is the host interface on which to start the websocket RPC server. If
 	// this field is empty, no websocket API endpoint will be started.
 	WSHost string `toml:",omitempty"`
@@ -153,6 +184,14 @@ type Config struct {
 	// exposed.
 	WSModules []string `toml:",omitempty"`
 
+	// WSVirtualEndpoints is a list of virtual endpoints served by the websocket RPC server.
+	// The CORS domains of a virtual endpoint are used as its allowed origins.
+	WSVirtualEndpoints []rpc.VirtualEndpoint `toml:",omitempty"`
+
+	// WSComputeBudget is the compute budget of the calls served over the websocket RPC
+	// interface like HTTPComputeBudget.
+	WSComputeBudget uint64 `toml:",omitempty"`
+
 	// WSExposeAll exposes all API modules via the WebSocket RPC interface rather
 	// than just the public ones.
 	//
@@ -186,25 +225,34 @@ type Config struct {
 // account the set data folders as well as the designated platform we're currently
 // running on.
 func (c *Config) IPCEndpoint() string {
+	return c.resolveIPCPath(c.IPCPath)
+}
+
+// AdminEndpoint resolves the admin endpoint path like IPCEndpoint.
+func (c *Config) AdminEndpoint() string {
+	return c.resolveIPCPath(c.AdminPath)
+}
+
+func (c *Config) resolveIPCPath(path string) string {
 	// Short circuit if IPC has not been enabled
-	if c.IPCPath == "" {
+	if path == "" {
 		return ""
 	}
 	// On windows we can only use plain top-level pipes
 	if runtime.GOOS == "windows" {
-		if strings.HasPrefix(c.IPCPath, `\\.\pipe\`) {
-			return c.IPCPath
+		if strings.HasPrefix(path, `\\.\pipe\`) {
+			return path
 		}
-		return `\\.\pipe\` + c.IPCPath
+		return `\\.\pipe\` + path
 	}
 	// Resolve names into the data directory full paths otherwise
-	if filepath.Base(c.IPCPath) == c.IPCPath {
+	if filepath.Base(path) == path {
 		if c.DataDir == "" {
-			return filepath.Join(os.TempDir(), c.IPCPath)
+			return filepath.Join(os.TempDir(), path)
 		}
-		return filepath.Join(c.DataDir, c.IPCPath)
+		return filepath.Join(c.DataDir, path)
 	}
-	return c.IPCPath
+	return path
 }
 
 // NodeDB returns the path to the discovery node database.
@@ -0,0 +1,69 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package faucet
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+// PublicFaucetAPI provides the APIs to request the KLAY of the faucet.
+type PublicFaucetAPI struct {
+	f *Faucet
+}
+
+func NewPublicFaucetAPI(f *Faucet) *PublicFaucetAPI {
+	return &PublicFaucetAPI{f: f}
+}
+
+// Request sends the KLAY to the address and returns the hash of the transaction.
+// The captcha is the response of the captcha widget, which can be omitted if the
+// faucet does not require the captcha.
+func (api *PublicFaucetAPI) Request(ctx context.Context, to common.Address, captcha *string) (common.Hash, error) {
+	var response string
+	if captcha != nil {
+		response = *captcha
+	}
+	return api.f.dispense(ctx, to, response)
+}
+
+type Status struct {
+	Account common.Address `json:"account"`
+	Balance *hexutil.Big   `json:"balance"`
+	Amount  *hexutil.Big   `json:"amount"` // peb dispensed per request
+	Period  hexutil.Uint64 `json:"period"` // Seconds between the requests of a recipient or a remote address
+	Captcha bool           `json:"captcha"`
+}
+
+// Status returns the funding account, its balance and the dispensing policy of the faucet.
+func (api *PublicFaucetAPI) Status() (*Status, error) {
+	f := api.f
+	statedb, err := f.blockchain.State()
+	if err != nil {
+		return nil, err
+	}
+	return &Status{
+		Account: f.account.Address,
+		Balance: (*hexutil.Big)(statedb.GetBalance(f.account.Address)),
+		Amount:  (*hexutil.Big)(new(big.Int).Set(f.amount)),
+		Period:  hexutil.Uint64(f.config.Period.Seconds()),
+		Captcha: f.captcha != nil,
+	}, nil
+}
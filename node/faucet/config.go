@@ -0,0 +1,55 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package faucet
+
+import (
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+const (
+	DefaultAmount = 5 // in KLAY
+	DefaultPeriod = 24 * time.Hour
+)
+
+type Config struct {
+	Enabled bool
+
+	// The funding account in the keystore and the file of its password
+	Account      common.Address
+	PasswordFile string
+
+	Amount uint64        // KLAY dispensed per request
+	Period time.Duration // Minimum interval between the requests of a recipient or a remote address
+
+	// The siteverify endpoint of a captcha service such as reCAPTCHA and hCaptcha.
+	// The captcha is not required if it is empty.
+	CaptchaURL    string
+	CaptchaSecret string
+
+	// The URL to which the dispensed transactions are posted. Nothing is posted if it is empty.
+	WebhookURL string
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled: false,
+		Amount:  DefaultAmount,
+		Period:  DefaultPeriod,
+	}
+}
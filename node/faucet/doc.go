@@ -0,0 +1,29 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package faucet implements an optional service dispensing the KLAY of a funding account in
+the keystore to the users of a testnet. The requests are rate-limited by the recipient
+and the remote address, and can be guarded by a captcha verifier and reported to a webhook.
+
+Source Files
+
+  - api.go    : the faucet APIs served under the faucet namespace
+  - config.go : the faucet configurations
+  - faucet.go : implements Faucet which signs and sends the dispensing transactions
+  - hooks.go  : the captcha verifier and the webhook called for the requests
+*/
+package faucet
@@ -0,0 +1,264 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package faucet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node"
+	"github.com/klaytn/klaytn/params"
+	"github.com/rcrowley/go-metrics"
+)
+
+var logger = log.NewModuleLogger(log.NodeFaucet)
+
+var (
+	errMainnet          = errors.New("the faucet is not allowed on the mainnet")
+	errNoFullNode       = errors.New("the faucet requires the blockchain and the transaction pool")
+	errInvalidRecipient = errors.New("invalid recipient")
+	errRateLimited      = errors.New("too many requests")
+)
+
+var (
+	dispensedCounter = metrics.NewRegisteredCounter("faucet/dispensed", nil)
+	rejectedCounter  = metrics.NewRegisteredCounter("faucet/rejected", nil)
+)
+
+// BlockChain is the interface of the blockchain used by the faucet.
+type BlockChain interface {
+	Config() *params.ChainConfig
+	CurrentBlock() *types.Block
+	State() (*state.StateDB, error)
+}
+
+// TxPool is the interface of the transaction pool used by the faucet.
+type TxPool interface {
+	GasPrice() *big.Int
+	GetPendingNonce(addr common.Address) uint64
+	AddLocal(tx *types.Transaction) error
+}
+
+// Faucet sends the KLAY of the funding account to the requesting users.
+type Faucet struct {
+	config     *Config
+	amount     *big.Int // peb dispensed per request
+	account    accounts.Account
+	wallet     accounts.Wallet
+	passphrase string
+
+	blockchain BlockChain
+	txPool     TxPool
+
+	captcha CaptchaVerifier // nil if the captcha is not required
+	webhook *webhook        // nil if the webhook is not set
+
+	mu        sync.Mutex           // serializes the requests to assign the nonces in order
+	last      map[string]time.Time // the last dispensing time by the recipient and the remote address
+	nextPrune time.Time
+}
+
+func NewFaucet(ctx *node.ServiceContext, cfg *Config) (*Faucet, error) {
+	account := accounts.Account{Address: cfg.Account}
+	wallet, err := ctx.AccountManager.Find(account)
+	if err != nil {
+		return nil, fmt.Errorf("the funding account %v is not found in the keystore: %w", cfg.Account.String(), err)
+	}
+	passphrase, err := readPassphrase(cfg.PasswordFile)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Faucet{
+		config:     cfg,
+		amount:     new(big.Int).Mul(new(big.Int).SetUint64(cfg.Amount), big.NewInt(params.KLAY)),
+		account:    account,
+		wallet:     wallet,
+		passphrase: passphrase,
+		last:       make(map[string]time.Time),
+	}
+	if cfg.CaptchaURL != "" {
+		f.captcha = newSiteVerifier(cfg.CaptchaURL, cfg.CaptchaSecret)
+	}
+	if cfg.WebhookURL != "" {
+		f.webhook = newWebhook(cfg.WebhookURL)
+	}
+	return f, nil
+}
+
+// readPassphrase returns the first line of the password file.
+func readPassphrase(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the password file of the funding account: %w", err)
+	}
+	return strings.TrimRight(strings.SplitN(string(text), "\n", 2)[0], "\r"), nil
+}
+
+func (f *Faucet) Protocols() []p2p.Protocol {
+	return []p2p.Protocol{}
+}
+
+func (f *Faucet) APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "faucet",
+			Version:   "1.0",
+			Service:   NewPublicFaucetAPI(f),
+			Public:    true,
+		},
+	}
+}
+
+func (f *Faucet) Start(server p2p.Server) error {
+	if f.blockchain == nil || f.txPool == nil {
+		return errNoFullNode
+	}
+	if f.blockchain.Config().ChainID.Uint64() == params.CypressNetworkId {
+		return errMainnet
+	}
+	logger.Info("Faucet is started", "account", f.account.Address, "amount", f.config.Amount,
+		"period", f.config.Period, "captcha", f.captcha != nil, "webhook", f.webhook != nil)
+	return nil
+}
+
+func (f *Faucet) Stop() error {
+	if f.webhook != nil {
+		f.webhook.wait()
+	}
+	logger.Info("Faucet is stopped")
+	return nil
+}
+
+func (f *Faucet) Components() []interface{} {
+	return nil
+}
+
+func (f *Faucet) SetComponents(components []interface{}) {
+	for _, component := range components {
+		switch v := component.(type) {
+		case BlockChain:
+			f.blockchain = v
+		case TxPool:
+			f.txPool = v
+		}
+	}
+}
+
+// dispense sends the KLAY to the recipient if neither the recipient nor the remote
+// address has been dispensed within the period.
+func (f *Faucet) dispense(ctx context.Context, to common.Address, captcha string) (common.Hash, error) {
+	if to == (common.Address{}) {
+		return common.Hash{}, errInvalidRecipient
+	}
+	remote := remoteHost(ctx)
+	if f.captcha != nil {
+		if err := f.captcha.Verify(ctx, captcha, remote); err != nil {
+			rejectedCounter.Inc(1)
+			return common.Hash{}, err
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	f.prune(now)
+	keys := []string{"address:" + strings.ToLower(to.Hex())}
+	if remote != "" {
+		keys = append(keys, "remote:"+remote)
+	}
+	for _, key := range keys {
+		if last, ok := f.last[key]; ok && now.Sub(last) < f.config.Period {
+			rejectedCounter.Inc(1)
+			return common.Hash{}, fmt.Errorf("%w, try again in %v", errRateLimited, (f.config.Period - now.Sub(last)).Round(time.Second))
+		}
+	}
+
+	gasPrice := f.txPool.GasPrice()
+	if f.blockchain.Config().IsMagmaForkEnabled(new(big.Int).Add(f.blockchain.CurrentBlock().Number(), common.Big1)) {
+		// The twice of the base fee as a buffer, as the gas price oracle suggests
+		gasPrice.Mul(gasPrice, common.Big2)
+	}
+	nonce := f.txPool.GetPendingNonce(f.account.Address)
+	tx := types.NewTransaction(nonce, to, f.amount, params.TxGas, gasPrice, nil)
+	signed, err := f.wallet.SignTxWithPassphrase(f.account, f.passphrase, tx, f.blockchain.Config().ChainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := f.txPool.AddLocal(signed); err != nil {
+		logger.Warn("Failed to send a faucet transaction", "to", to, "nonce", nonce, "err", err)
+		return common.Hash{}, err
+	}
+	for _, key := range keys {
+		f.last[key] = now
+	}
+
+	dispensedCounter.Inc(1)
+	logger.Info("Dispensed KLAY", "to", to, "amount", f.config.Amount, "tx", signed.Hash(), "remote", remote)
+	if f.webhook != nil {
+		f.webhook.post(&Event{
+			To:     to,
+			Amount: (*hexutil.Big)(f.amount),
+			TxHash: signed.Hash(),
+			Remote: remote,
+			Time:   now.Unix(),
+		})
+	}
+	return signed.Hash(), nil
+}
+
+// prune removes the dispensing times older than the period once in a period.
+func (f *Faucet) prune(now time.Time) {
+	if now.Before(f.nextPrune) {
+		return
+	}
+	for key, last := range f.last {
+		if now.Sub(last) >= f.config.Period {
+			delete(f.last, key)
+		}
+	}
+	f.nextPrune = now.Add(f.config.Period)
+}
+
+// remoteHost returns the host of the remote address of the RPC request, or an empty
+// string if the request is not made over HTTP or WebSocket.
+func remoteHost(ctx context.Context) string {
+	remote, _ := ctx.Value("remote").(string)
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		return host
+	}
+	return remote
+}
@@ -0,0 +1,188 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package faucet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/accounts/keystore"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/node"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBlockChain struct {
+	config  *params.ChainConfig
+	statedb *state.StateDB
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig { return bc.config }
+
+func (bc *testBlockChain) CurrentBlock() *types.Block {
+	return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})
+}
+
+func (bc *testBlockChain) State() (*state.StateDB, error) { return bc.statedb, nil }
+
+type testTxPool struct {
+	txs []*types.Transaction
+}
+
+func (pool *testTxPool) GasPrice() *big.Int { return big.NewInt(25 * params.Ston) }
+
+func (pool *testTxPool) GetPendingNonce(addr common.Address) uint64 { return uint64(len(pool.txs)) }
+
+func (pool *testTxPool) AddLocal(tx *types.Transaction) error {
+	pool.txs = append(pool.txs, tx)
+	return nil
+}
+
+func newTestFaucet(t *testing.T, cfg *Config) (*Faucet, *testTxPool) {
+	dir := t.TempDir()
+	ks := keystore.NewKeyStore(filepath.Join(dir, "keystore"), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.NewAccount("secret")
+	require.NoError(t, err)
+	cfg.Account = account.Address
+	cfg.PasswordFile = filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(cfg.PasswordFile, []byte("secret\n"), 0o600))
+
+	ctx := node.NewServiceContext(&node.Config{}, nil, nil, accounts.NewManager(ks))
+	f, err := NewFaucet(ctx, cfg)
+	require.NoError(t, err)
+
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	require.NoError(t, err)
+	statedb.SetBalance(account.Address, big.NewInt(params.KLAY))
+	pool := &testTxPool{}
+	f.SetComponents([]interface{}{&testBlockChain{params.TestChainConfig, statedb}, pool})
+	require.NoError(t, f.Start(nil))
+	t.Cleanup(func() { f.Stop() })
+	return f, pool
+}
+
+func withRemote(remote string) context.Context {
+	return context.WithValue(context.Background(), "remote", remote)
+}
+
+func TestFaucet_Request(t *testing.T) {
+	f, pool := newTestFaucet(t, DefaultConfig())
+	api := NewPublicFaucetAPI(f)
+	alice, bob := common.HexToAddress("0xa1"), common.HexToAddress("0xb0b")
+
+	hash, err := api.Request(withRemote("1.2.3.4:5678"), alice, nil)
+	require.NoError(t, err)
+	require.Len(t, pool.txs, 1)
+	tx := pool.txs[0]
+	assert.Equal(t, hash, tx.Hash())
+	assert.Equal(t, alice, *tx.To())
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(DefaultAmount), big.NewInt(params.KLAY)), tx.Value())
+	sender, err := types.Sender(types.LatestSignerForChainID(params.TestChainConfig.ChainID), tx)
+	require.NoError(t, err)
+	assert.Equal(t, f.account.Address, sender)
+
+	// both the recipient and the remote address are limited
+	_, err = api.Request(withRemote("5.6.7.8:5678"), alice, nil)
+	assert.True(t, errors.Is(err, errRateLimited), err)
+	_, err = api.Request(withRemote("1.2.3.4:1234"), bob, nil)
+	assert.True(t, errors.Is(err, errRateLimited), err)
+	_, err = api.Request(withRemote("5.6.7.8:5678"), bob, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), pool.txs[1].Nonce())
+
+	// the limits are lifted after the period
+	for key := range f.last {
+		f.last[key] = time.Now().Add(-f.config.Period)
+	}
+	_, err = api.Request(withRemote("1.2.3.4:5678"), alice, nil)
+	assert.NoError(t, err)
+	assert.Len(t, pool.txs, 3)
+
+	_, err = api.Request(context.Background(), common.Address{}, nil)
+	assert.Equal(t, errInvalidRecipient, err)
+
+	status, err := api.Status()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(params.KLAY), status.Balance.ToInt())
+	assert.Equal(t, f.amount, status.Amount.ToInt())
+	assert.False(t, status.Captcha)
+}
+
+func TestFaucet_Hooks(t *testing.T) {
+	captcha := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("secret") == "captcha-secret" && r.FormValue("response") == "human" {
+			w.Write([]byte(`{"success": true}`))
+		} else {
+			w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+		}
+	}))
+	defer captcha.Close()
+	events := make(chan *Event, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ev := new(Event)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(ev))
+		events <- ev
+	}))
+	defer webhook.Close()
+
+	cfg := DefaultConfig()
+	cfg.CaptchaURL, cfg.CaptchaSecret, cfg.WebhookURL = captcha.URL, "captcha-secret", webhook.URL
+	f, pool := newTestFaucet(t, cfg)
+	api := NewPublicFaucetAPI(f)
+	alice := common.HexToAddress("0xa1")
+
+	_, err := api.Request(withRemote("1.2.3.4:5678"), alice, nil)
+	assert.Equal(t, errCaptchaRequired, err)
+	robot := "robot"
+	_, err = api.Request(withRemote("1.2.3.4:5678"), alice, &robot)
+	assert.True(t, errors.Is(err, errCaptchaFailed), err)
+	assert.Empty(t, pool.txs)
+
+	human := "human"
+	hash, err := api.Request(withRemote("1.2.3.4:5678"), alice, &human)
+	require.NoError(t, err)
+	select {
+	case ev := <-events:
+		assert.Equal(t, alice, ev.To)
+		assert.Equal(t, hash, ev.TxHash)
+		assert.Equal(t, "1.2.3.4", ev.Remote)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the webhook is not called")
+	}
+}
+
+func TestFaucet_Mainnet(t *testing.T) {
+	f := &Faucet{config: DefaultConfig()}
+	f.SetComponents([]interface{}{&testBlockChain{config: params.CypressChainConfig}, &testTxPool{}})
+	assert.Equal(t, errMainnet, f.Start(nil))
+
+	assert.Equal(t, errNoFullNode, (&Faucet{config: DefaultConfig()}).Start(nil))
+}
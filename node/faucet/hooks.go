@@ -0,0 +1,141 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package faucet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+const (
+	hookTimeout         = 10 * time.Second
+	maxCaptchaRespBytes = 1024 * 1024
+)
+
+var (
+	errCaptchaRequired = errors.New("captcha required")
+	errCaptchaFailed   = errors.New("captcha verification failed")
+)
+
+// CaptchaVerifier verifies the captcha response submitted with a request.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, response, remote string) error
+}
+
+// siteVerifier verifies the captcha responses with the siteverify endpoint, which is
+// compatible among reCAPTCHA, hCaptcha and Turnstile.
+type siteVerifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func newSiteVerifier(url, secret string) *siteVerifier {
+	return &siteVerifier{url: url, secret: secret, client: &http.Client{Timeout: hookTimeout}}
+}
+
+func (v *siteVerifier) Verify(ctx context.Context, response, remote string) error {
+	if response == "" {
+		return errCaptchaRequired
+	}
+	form := url.Values{"secret": {v.secret}, "response": {response}}
+	if remote != "" {
+		form.Set("remoteip", remote)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := v.client.Do(req)
+	if err != nil {
+		logger.Warn("Failed to verify a captcha", "err", err)
+		return errCaptchaFailed
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxCaptchaRespBytes)).Decode(&result); err != nil {
+		logger.Warn("Failed to decode a captcha verification", "status", resp.StatusCode, "err", err)
+		return errCaptchaFailed
+	}
+	if !result.Success {
+		return fmt.Errorf("%w: %v", errCaptchaFailed, strings.Join(result.ErrorCodes, ", "))
+	}
+	return nil
+}
+
+// Event is posted to the webhook for each dispensing transaction.
+type Event struct {
+	To     common.Address `json:"to"`
+	Amount *hexutil.Big   `json:"amount"`
+	TxHash common.Hash    `json:"txHash"`
+	Remote string         `json:"remote,omitempty"`
+	Time   int64          `json:"time"`
+}
+
+// webhook posts the events in the background, so that a slow webhook never delays the requests.
+type webhook struct {
+	url    string
+	client *http.Client
+	wg     sync.WaitGroup
+}
+
+func newWebhook(url string) *webhook {
+	return &webhook{url: url, client: &http.Client{Timeout: hookTimeout}}
+}
+
+func (w *webhook) post(ev *Event) {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		logger.Error("Failed to encode a faucet event", "err", err)
+		return
+	}
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			logger.Warn("Failed to post a faucet event to the webhook", "tx", ev.TxHash, "err", err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			logger.Warn("The webhook rejected a faucet event", "tx", ev.TxHash, "status", resp.StatusCode)
+		}
+	}()
+}
+
+// wait blocks until the events being posted are done.
+func (w *webhook) wait() {
+	w.wg.Wait()
+}
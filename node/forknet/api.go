@@ -0,0 +1,284 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package forknet
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+)
+
+// APIs returns the APIs of the forked network. The klay APIs are served under the eth
+// namespace as well for the tools of Ethereum, and the dev APIs under the evm namespace
+// as well for the test suites of Hardhat.
+func (f *Fork) APIs() []rpc.API {
+	publicAPI := &PublicForkAPI{f}
+	devAPI := &DevAPI{f}
+	return []rpc.API{
+		{Namespace: "klay", Version: "1.0", Service: publicAPI, Public: true},
+		{Namespace: "eth", Version: "1.0", Service: publicAPI, Public: true},
+		{Namespace: "fork", Version: "1.0", Service: &CheatcodeAPI{f}, Public: true},
+		{Namespace: "klay", Version: "1.0", Service: devAPI, Public: true},
+		{Namespace: "evm", Version: "1.0", Service: devAPI, Public: true},
+	}
+}
+
+// PublicForkAPI provides the subset of the klay APIs used by the dapps. The requests for
+// the blocks and the transactions before the fork block are forwarded to the remote network.
+type PublicForkAPI struct {
+	f *Fork
+}
+
+// ChainID returns the chain ID of the remote network.
+func (s *PublicForkAPI) ChainID() *hexutil.Big {
+	return (*hexutil.Big)(s.f.config.ChainID)
+}
+
+// ChainId returns the chain ID of the remote network.
+// This is for compatibility with ethereum client
+func (s *PublicForkAPI) ChainId() *hexutil.Big {
+	return s.ChainID()
+}
+
+// BlockNumber returns the number of the head block.
+func (s *PublicForkAPI) BlockNumber() hexutil.Uint64 {
+	s.f.mu.Lock()
+	defer s.f.mu.Unlock()
+
+	return hexutil.Uint64(s.f.head().header.Number.Uint64())
+}
+
+// GasPrice returns the gas price of the remote network at the fork block.
+func (s *PublicForkAPI) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(s.f.gasPrice)
+}
+
+// GetBalance returns the balance of the account at the given block.
+func (s *PublicForkAPI) GetBalance(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	var balance *big.Int
+	local, err := s.f.readState(blockNrOrHash, func(st *state.StateDB, _ *types.Header) {
+		balance = st.GetBalance(address)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !local {
+		result := new(hexutil.Big)
+		return result, s.f.forward(ctx, result, "klay_getBalance", address, remoteBlockArg(blockNrOrHash))
+	}
+	return (*hexutil.Big)(balance), nil
+}
+
+// GetTransactionCount returns the nonce of the account at the given block.
+func (s *PublicForkAPI) GetTransactionCount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Uint64, error) {
+	var nonce uint64
+	local, err := s.f.readState(blockNrOrHash, func(st *state.StateDB, _ *types.Header) {
+		nonce = st.GetNonce(address)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !local {
+		result := new(hexutil.Uint64)
+		return result, s.f.forward(ctx, result, "klay_getTransactionCount", address, remoteBlockArg(blockNrOrHash))
+	}
+	return (*hexutil.Uint64)(&nonce), nil
+}
+
+// GetCode returns the code of the account at the given block.
+func (s *PublicForkAPI) GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	var code []byte
+	local, err := s.f.readState(blockNrOrHash, func(st *state.StateDB, _ *types.Header) {
+		code = st.GetCode(address)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !local {
+		var result hexutil.Bytes
+		return result, s.f.forward(ctx, &result, "klay_getCode", address, remoteBlockArg(blockNrOrHash))
+	}
+	return code, nil
+}
+
+// GetStorageAt returns the value of the storage slot of the account at the given block.
+func (s *PublicForkAPI) GetStorageAt(ctx context.Context, address common.Address, key string, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	var value common.Hash
+	local, err := s.f.readState(blockNrOrHash, func(st *state.StateDB, _ *types.Header) {
+		value = st.GetState(address, common.HexToHash(key))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !local {
+		var result hexutil.Bytes
+		return result, s.f.forward(ctx, &result, "klay_getStorageAt", address, key, remoteBlockArg(blockNrOrHash))
+	}
+	return value[:], nil
+}
+
+// Call executes the call on the state of the given block without mining it.
+func (s *PublicForkAPI) Call(ctx context.Context, args api.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	result, local, err := s.f.call(args, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if !local {
+		var data hexutil.Bytes
+		return data, s.f.forward(ctx, &data, "klay_call", args, remoteBlockArg(blockNrOrHash))
+	}
+	if len(result.Revert()) > 0 {
+		return nil, blockchain.NewRevertError(result)
+	}
+	return result.Return(), result.Unwrap()
+}
+
+// EstimateGas returns the lowest gas limit with which the call on the head succeeds.
+func (s *PublicForkAPI) EstimateGas(ctx context.Context, args api.CallArgs) (hexutil.Uint64, error) {
+	gas, err := s.f.estimateGas(args)
+	return hexutil.Uint64(gas), err
+}
+
+// SendTransaction executes the transaction from an impersonated account and mines it.
+// The transaction is not signed, and the nonce is the next nonce of the sender.
+func (s *PublicForkAPI) SendTransaction(ctx context.Context, args api.CallArgs) (common.Hash, error) {
+	return s.f.sendTransaction(args)
+}
+
+// SendRawTransaction executes the signed transaction and mines it.
+func (s *PublicForkAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	return s.f.sendRawTransaction(input)
+}
+
+// GetTransactionReceipt returns the receipt of the transaction.
+func (s *PublicForkAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	s.f.mu.Lock()
+	b, ok := s.f.txs[hash]
+	s.f.mu.Unlock()
+	if !ok {
+		var result map[string]interface{}
+		return result, s.f.forward(ctx, &result, "klay_getTransactionReceipt", hash)
+	}
+	fields := api.RpcOutputReceipt(b.header, b.tx, b.header.Hash(), b.header.Number.Uint64(), 0, b.receipt)
+	fields["from"] = b.from
+	return fields, nil
+}
+
+// GetBlockByNumber returns the block of the given number.
+func (s *PublicForkAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTxs bool) (map[string]interface{}, error) {
+	s.f.mu.Lock()
+	b, local, err := s.f.resolve(rpc.NewBlockNumberOrHashWithNumber(number))
+	s.f.mu.Unlock()
+	if err == errBlockNotFound {
+		return nil, nil
+	}
+	if !local {
+		var result map[string]interface{}
+		return result, s.f.forward(ctx, &result, "klay_getBlockByNumber", hexutil.EncodeUint64(uint64(number)), fullTxs)
+	}
+	header := b.header
+	fields := map[string]interface{}{
+		"number":       (*hexutil.Big)(header.Number),
+		"hash":         header.Hash(),
+		"parentHash":   header.ParentHash,
+		"logsBloom":    header.Bloom,
+		"stateRoot":    header.Root,
+		"reward":       header.Rewardbase,
+		"blockscore":   (*hexutil.Big)(header.BlockScore),
+		"gasUsed":      hexutil.Uint64(header.GasUsed),
+		"timestamp":    (*hexutil.Big)(header.Time),
+		"timestampFoS": hexutil.Uint(header.TimeFoS),
+		"extraData":    hexutil.Bytes(header.Extra),
+	}
+	if header.BaseFee != nil {
+		fields["baseFeePerGas"] = (*hexutil.Big)(header.BaseFee)
+	}
+	transactions := []interface{}{}
+	if b.tx != nil {
+		if fullTxs {
+			tx := b.tx.MakeRPCOutput()
+			tx["hash"] = b.tx.Hash()
+			tx["from"] = b.from
+			tx["blockHash"] = header.Hash()
+			tx["blockNumber"] = (*hexutil.Big)(header.Number)
+			tx["transactionIndex"] = hexutil.Uint(0)
+			transactions = append(transactions, tx)
+		} else {
+			transactions = append(transactions, b.tx.Hash())
+		}
+	}
+	fields["transactions"] = transactions
+	return fields, nil
+}
+
+// CheatcodeAPI provides the cheatcodes to manipulate the forked network for testing.
+type CheatcodeAPI struct {
+	f *Fork
+}
+
+// ImpersonateAccount allows sending the transactions from the account without signatures.
+func (s *CheatcodeAPI) ImpersonateAccount(address common.Address) {
+	s.f.impersonate(address, true)
+}
+
+// StopImpersonatingAccount disallows sending the transactions from the account without signatures.
+func (s *CheatcodeAPI) StopImpersonatingAccount(address common.Address) {
+	s.f.impersonate(address, false)
+}
+
+// SetBalance sets the balance of the account in the head state.
+func (s *CheatcodeAPI) SetBalance(address common.Address, balance hexutil.Big) error {
+	return s.f.modifyHead(func(st *state.StateDB) error {
+		st.SetBalance(address, balance.ToInt())
+		return nil
+	})
+}
+
+// SetNonce sets the nonce of the account in the head state.
+func (s *CheatcodeAPI) SetNonce(address common.Address, nonce hexutil.Uint64) error {
+	return s.f.modifyHead(func(st *state.StateDB) error {
+		st.SetNonce(address, uint64(nonce))
+		return nil
+	})
+}
+
+// SetCode sets the code of the account in the head state. The account is replaced by a
+// smart contract account keeping the balance if it is not a program account.
+func (s *CheatcodeAPI) SetCode(address common.Address, code hexutil.Bytes) error {
+	return s.f.modifyHead(func(st *state.StateDB) error {
+		if !st.IsProgramAccount(address) {
+			st.CreateSmartContractAccount(address, params.CodeFormatEVM, s.f.config.Rules(s.f.head().header.Number))
+		}
+		return st.SetCode(address, code)
+	})
+}
+
+// SetStorageAt sets the value of the storage slot of the account in the head state.
+func (s *CheatcodeAPI) SetStorageAt(address common.Address, key common.Hash, value common.Hash) error {
+	return s.f.modifyHead(func(st *state.StateDB) error {
+		st.SetState(address, key, value)
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package forknet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+// DevAPI provides the APIs to control the blocks and the clock of the forked network
+// in tests, such as klay_snapshot or its Hardhat equivalent evm_snapshot.
+type DevAPI struct {
+	f *Fork
+}
+
+// Snapshot saves the chain, the head state and the clock, and returns the id to revert to them.
+func (s *DevAPI) Snapshot() hexutil.Uint64 {
+	return hexutil.Uint64(s.f.snapshot())
+}
+
+// Revert restores the chain, the head state and the clock saved by the snapshot. The
+// snapshot and the ones taken after it are discarded. It returns false if the snapshot
+// is unknown.
+func (s *DevAPI) Revert(id hexutil.Uint64) bool {
+	return s.f.revert(uint64(id))
+}
+
+// IncreaseTime moves the clock of the next blocks forward by the given seconds, and
+// returns the total number of seconds the clock has been moved.
+func (s *DevAPI) IncreaseTime(seconds Quantity) int64 {
+	return s.f.increaseTime(uint64(seconds))
+}
+
+// Mine mines an empty block and returns its number. If the timestamp is given, the block
+// is timestamped with it and the clock of the next blocks continues from it.
+func (s *DevAPI) Mine(timestamp *Quantity) (hexutil.Uint64, error) {
+	var t uint64
+	if timestamp != nil {
+		t = uint64(*timestamp)
+	}
+	b, err := s.f.mineEmpty(t)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(b.header.Number.Uint64()), nil
+}
+
+// Quantity is an unsigned integer given as either a JSON number or a hex string, since
+// the test suites send both forms.
+type Quantity uint64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (q *Quantity) UnmarshalJSON(input []byte) error {
+	if len(input) > 0 && input[0] == '"' {
+		return (*hexutil.Uint64)(q).UnmarshalJSON(input)
+	}
+	var n uint64
+	if err := json.Unmarshal(input, &n); err != nil {
+		return fmt.Errorf("invalid quantity %s: %v", input, err)
+	}
+	*q = Quantity(n)
+	return nil
+}
@@ -0,0 +1,29 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package forknet implements a local network whose state is lazily forked from a remote
+Klaytn network at a block, so that the dapps can be tested against the live state. The
+local blocks are mined instantly on top of the fork block, and the cheatcodes such as the
+impersonation, the balance setting and the snapshot/revert are served for the tests.
+
+Source Files
+
+  - api.go    : the klay APIs of the forked network and the cheatcodes served under the fork namespace
+  - fork.go   : implements Fork which executes the transactions and manages the local blocks
+  - remote.go : implements the remote state which loads the accounts and the storage of the fork block
+*/
+package forknet
@@ -0,0 +1,578 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package forknet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/client"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/fork"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// callGasCap is the gas limit of the calls and the gas estimations without the gas given.
+const callGasCap = uint64(50000000)
+
+var (
+	logger = log.NewModuleLogger(log.NodeForkNet)
+
+	errBlockNotFound   = errors.New("block not found")
+	errNotImpersonated = errors.New("sender is not impersonated, call fork_impersonateAccount or send a signed transaction")
+	errNoChainConfig   = errors.New("remote network did not return the chain config")
+	errPastTimestamp   = errors.New("timestamp must be later than the head block")
+)
+
+// Config is the configuration of a forked network.
+type Config struct {
+	RemoteURL   string // endpoint of the remote network
+	BlockNumber uint64 // block the state is forked at, the latest block of the remote network if zero
+}
+
+// forkBlock is a block of the forked network. The first block is the fork block, and the
+// others are mined locally with at most one transaction each.
+type forkBlock struct {
+	header  *types.Header
+	state   *state.StateDB // state after the block, replaced by a modified copy on a cheatcode
+	tx      *types.Transaction
+	from    common.Address
+	receipt *types.Receipt
+}
+
+// forkSnapshot is the chain, the head state and the clock saved by a snapshot.
+type forkSnapshot struct {
+	blocks     int
+	state      *state.StateDB
+	timeOffset int64
+}
+
+// Fork is a local network whose state is lazily forked from a remote network at a block.
+// The accounts and the storage are requested to the remote network on the first access,
+// and the transactions are executed on top of the fork block and mined instantly.
+//
+// The state of a block is never modified in place; the transactions and the cheatcodes
+// are applied to a copy of the head state, so the snapshots can be reverted cheaply.
+type Fork struct {
+	client   *rpc.Client
+	remote   *client.Client
+	config   *params.ChainConfig
+	pset     *params.GovParamSet
+	gasPrice *big.Int
+
+	// timeOffset is the number of seconds the clock of the local blocks is ahead of the
+	// system clock, which is increased to travel in time.
+	timeOffset int64
+
+	blocks       []*forkBlock
+	txs          map[common.Hash]*forkBlock
+	impersonated map[common.Address]bool
+	snapshots    map[uint64]*forkSnapshot
+	nextSnapshot uint64
+
+	// mu serializes all the accesses, since even reading a state loads the accounts into it.
+	mu sync.Mutex
+}
+
+// New connects to the remote network and forks its state at the configured block.
+func New(ctx context.Context, config Config) (*Fork, error) {
+	c, err := rpc.DialContext(ctx, config.RemoteURL)
+	if err != nil {
+		return nil, err
+	}
+	f, err := newFork(ctx, c, config.BlockNumber)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func newFork(ctx context.Context, c *rpc.Client, number uint64) (*Fork, error) {
+	remote := client.NewClient(c)
+
+	var num *big.Int
+	if number != 0 {
+		num = new(big.Int).SetUint64(number)
+	}
+	header, err := remote.HeaderByNumber(ctx, num)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the fork block: %v", err)
+	}
+	var config *params.ChainConfig
+	if err := c.CallContext(ctx, &config, "klay_getChainConfig", hexutil.EncodeBig(header.Number)); err != nil {
+		return nil, fmt.Errorf("failed to get the chain config: %v", err)
+	}
+	if config == nil || config.ChainID == nil {
+		return nil, errNoChainConfig
+	}
+	// The hard fork rules are looked up by the transactions as well as the blockchain.
+	if err := fork.SetHardForkBlockNumberConfig(config); err != nil {
+		return nil, err
+	}
+	// The block reward is not minted if the remote network does not configure it.
+	var pset *params.GovParamSet
+	if config.Governance != nil && config.Governance.Reward != nil {
+		if pset, err = params.NewGovParamSetChainConfig(config); err != nil {
+			return nil, err
+		}
+	}
+	gasPrice, err := remote.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the gas price: %v", err)
+	}
+
+	st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	st.SetRemoteState(newRemoteState(c, header.Number.Uint64()))
+
+	logger.Info("Forked the remote network", "chainID", config.ChainID, "number", header.Number, "hash", header.Hash())
+	return &Fork{
+		client:       c,
+		remote:       remote,
+		config:       config,
+		pset:         pset,
+		gasPrice:     gasPrice,
+		blocks:       []*forkBlock{{header: header, state: st}},
+		txs:          make(map[common.Hash]*forkBlock),
+		impersonated: make(map[common.Address]bool),
+		snapshots:    make(map[uint64]*forkSnapshot),
+	}, nil
+}
+
+// Close closes the connection to the remote network.
+func (f *Fork) Close() {
+	f.client.Close()
+}
+
+// ChainConfig returns the chain config of the remote network at the fork block.
+func (f *Fork) ChainConfig() *params.ChainConfig {
+	return f.config
+}
+
+// Engine implements blockchain.ChainContext. The forked network has no consensus engine,
+// and the author of the blocks is always given explicitly.
+func (f *Fork) Engine() consensus.Engine {
+	return nil
+}
+
+// GetHeader implements blockchain.ChainContext. The headers before the fork block are
+// requested to the remote network.
+func (f *Fork) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if base := f.blocks[0].header.Number.Uint64(); number >= base {
+		if idx := number - base; idx < uint64(len(f.blocks)) && f.blocks[idx].header.Hash() == hash {
+			return f.blocks[idx].header
+		}
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+
+	header, err := f.remote.HeaderByHash(ctx, hash)
+	if err != nil {
+		logger.Warn("Failed to get the header from the remote network", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	return header
+}
+
+func (f *Fork) head() *forkBlock {
+	return f.blocks[len(f.blocks)-1]
+}
+
+// resolve returns the block of the given number or hash. It returns false if the block
+// precedes the fork block, so that the request is forwarded to the remote network.
+func (f *Fork) resolve(blockNrOrHash rpc.BlockNumberOrHash) (*forkBlock, bool, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		for _, b := range f.blocks {
+			if b.header.Hash() == hash {
+				return b, true, nil
+			}
+		}
+		return nil, false, nil
+	}
+	number, _ := blockNrOrHash.Number()
+	if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
+		return f.head(), true, nil
+	}
+	base := f.blocks[0].header.Number.Uint64()
+	if uint64(number) < base {
+		return nil, false, nil
+	}
+	if idx := uint64(number) - base; idx < uint64(len(f.blocks)) {
+		return f.blocks[idx], true, nil
+	}
+	return nil, true, errBlockNotFound
+}
+
+// forward sends the request to the remote network.
+func (f *Fork) forward(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return f.client.CallContext(ctx, result, method, args...)
+}
+
+// remoteBlockArg returns the block parameter of a request forwarded to the remote network.
+func remoteBlockArg(blockNrOrHash rpc.BlockNumberOrHash) interface{} {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		return hash
+	}
+	number, _ := blockNrOrHash.Number()
+	return hexutil.EncodeUint64(uint64(number))
+}
+
+// readState calls the read function with the state of the given block. It returns false
+// if the block precedes the fork block.
+func (f *Fork) readState(blockNrOrHash rpc.BlockNumberOrHash, read func(st *state.StateDB, header *types.Header)) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	b, local, err := f.resolve(blockNrOrHash)
+	if !local || err != nil {
+		return local, err
+	}
+	read(b.state, b.header)
+	return true, b.state.Error()
+}
+
+// applyMessage executes the message on the state in the context of the header.
+func (f *Fork) applyMessage(st *state.StateDB, header *types.Header, msg *types.Transaction) (*blockchain.ExecutionResult, error) {
+	blockCtx := blockchain.NewEVMBlockContext(header, f, &header.Rewardbase)
+	txCtx := blockchain.NewEVMTxContext(msg, header)
+	evm := vm.NewEVM(blockCtx, txCtx, st, f.config, &vm.Config{})
+	return blockchain.ApplyMessage(evm, msg)
+}
+
+// call executes the call on a copy of the state of the given block. It returns false if
+// the block precedes the fork block.
+func (f *Fork) call(args api.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (*blockchain.ExecutionResult, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	b, local, err := f.resolve(blockNrOrHash)
+	if !local || err != nil {
+		return nil, local, err
+	}
+	result, err := f.doCall(b, args)
+	return result, true, err
+}
+
+func (f *Fork) doCall(b *forkBlock, args api.CallArgs) (*blockchain.ExecutionResult, error) {
+	header := b.header
+	intrinsicGas, err := types.IntrinsicGas(args.InputData(), nil, args.To == nil, f.config.Rules(header.Number))
+	if err != nil {
+		return nil, err
+	}
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		baseFee = new(big.Int).SetUint64(params.ZeroBaseFee)
+	}
+	msg, err := args.ToMessage(callGasCap, baseFee, intrinsicGas)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Gas() < intrinsicGas {
+		return nil, fmt.Errorf("%w: msg.gas %d, want %d", blockchain.ErrIntrinsicGas, msg.Gas(), intrinsicGas)
+	}
+	// Add the gas fee to the sender so that a sender without balance can call.
+	price := msg.GasPrice()
+	if header.BaseFee != nil {
+		price = header.BaseFee
+	}
+	st := b.state.Copy()
+	st.AddBalance(msg.ValidatedSender(), new(big.Int).Mul(new(big.Int).SetUint64(msg.Gas()), price))
+	return f.applyMessage(st, header, msg)
+}
+
+// estimateGas returns the lowest gas limit with which the call on the head succeeds.
+func (f *Fork) estimateGas(args api.CallArgs) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	hi := callGasCap
+	if args.Gas >= hexutil.Uint64(params.TxGas) {
+		hi = uint64(args.Gas)
+	}
+	executable := func(gas uint64) (bool, *blockchain.ExecutionResult, error) {
+		args.Gas = hexutil.Uint64(gas)
+		result, err := f.doCall(f.head(), args)
+		if err != nil {
+			if errors.Is(err, blockchain.ErrIntrinsicGas) {
+				return false, nil, nil
+			}
+			return false, nil, err
+		}
+		return !result.Failed(), result, nil
+	}
+	ok, result, err := executable(hi)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		if result != nil && len(result.Revert()) > 0 {
+			return 0, blockchain.NewRevertError(result)
+		}
+		if result != nil {
+			return 0, result.Unwrap()
+		}
+		return 0, fmt.Errorf("gas required exceeds allowance (%d)", hi)
+	}
+	lo := params.TxGas - 1
+	for lo+1 < hi {
+		mid := (lo + hi) / 2
+		ok, _, err := executable(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi, nil
+}
+
+// sendTransaction executes the transaction from the impersonated sender and mines it.
+func (f *Fork) sendTransaction(args api.CallArgs) (common.Hash, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.impersonated[args.From] {
+		return common.Hash{}, fmt.Errorf("%w: %s", errNotImpersonated, args.From.Hex())
+	}
+	head := f.head()
+	number := new(big.Int).Add(head.header.Number, common.Big1)
+	intrinsicGas, err := types.IntrinsicGas(args.InputData(), nil, args.To == nil, f.config.Rules(number))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	gas := uint64(args.Gas)
+	if gas == 0 {
+		gas = callGasCap
+	}
+	gasPrice := f.gasPrice
+	if args.GasPrice != nil {
+		gasPrice = args.GasPrice.ToInt()
+	}
+	nonce := head.state.GetNonce(args.From)
+	msg := types.NewMessage(args.From, args.To, nonce, args.Value.ToInt(), gas, gasPrice, args.InputData(), true, intrinsicGas, nil)
+	b, err := f.mine(msg, args.From, 0)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return b.tx.Hash(), nil
+}
+
+// sendRawTransaction executes the signed transaction and mines it.
+func (f *Fork) sendRawTransaction(encoded []byte) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(encoded); err != nil {
+		return common.Hash{}, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	head := f.head()
+	signer := types.LatestSignerForChainID(f.config.ChainID)
+	msg, err := tx.AsMessageWithAccountKeyPicker(signer, head.state, head.header.Number.Uint64()+1)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if _, err := f.mine(msg, msg.ValidatedSender(), 0); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// mine appends a block on top of the head, which includes the transaction if not nil.
+// The transaction is not included if it is invalid for the head state. The block is
+// timestamped by the clock if the timestamp is zero.
+func (f *Fork) mine(tx *types.Transaction, from common.Address, timestamp uint64) (*forkBlock, error) {
+	parent := f.head()
+	if timestamp == 0 {
+		timestamp = uint64(time.Now().Unix() + f.timeOffset)
+		if parentTime := parent.header.Time.Uint64(); timestamp <= parentTime {
+			timestamp = parentTime + 1
+		}
+	}
+	header := &types.Header{
+		ParentHash: parent.header.Hash(),
+		Rewardbase: parent.header.Rewardbase,
+		BlockScore: common.Big1,
+		Number:     new(big.Int).Add(parent.header.Number, common.Big1),
+		Time:       new(big.Int).SetUint64(timestamp),
+		Extra:      []byte{},
+		Governance: []byte{},
+		BaseFee:    parent.header.BaseFee,
+	}
+	b := &forkBlock{header: header, state: parent.state.Copy(), tx: tx, from: from}
+	if tx != nil {
+		b.state.SetTxContext(tx.Hash(), common.Hash{}, 0)
+		result, err := f.applyMessage(b.state, header, tx)
+		if err != nil {
+			return nil, err
+		}
+		b.receipt = types.NewReceipt(result.VmExecutionStatus, tx.Hash(), result.UsedGas)
+		b.receipt.Logs = b.state.GetLogs(tx.Hash())
+		b.receipt.Bloom = types.CreateBloom(types.Receipts{b.receipt})
+		if tx.To() == nil {
+			b.receipt.ContractAddress = crypto.CreateAddress(from, tx.Nonce())
+		}
+		header.GasUsed = result.UsedGas
+		header.Bloom = b.receipt.Bloom
+	}
+	if err := f.mintReward(b.state, header); err != nil {
+		return nil, err
+	}
+	header.Root = b.state.IntermediateRoot(true)
+	if err := b.state.Error(); err != nil {
+		return nil, err
+	}
+	if b.receipt != nil {
+		for _, l := range b.receipt.Logs {
+			l.BlockHash = header.Hash()
+			l.BlockNumber = header.Number.Uint64()
+		}
+		f.txs[tx.Hash()] = b
+	}
+	f.blocks = append(f.blocks, b)
+	logger.Info("Mined a block", "number", header.Number, "hash", header.Hash(), "gasUsed", header.GasUsed)
+	return b, nil
+}
+
+// mintReward mints the block reward as configured at the fork block. All the reward is
+// paid to the rewardbase, since the staking information is not available locally.
+func (f *Fork) mintReward(st *state.StateDB, header *types.Header) error {
+	if f.pset == nil {
+		return nil
+	}
+	spec, err := reward.CalcDeferredRewardSimple(header, f.config.Rules(header.Number), f.pset)
+	if err != nil {
+		return err
+	}
+	reward.DistributeBlockReward(st, spec.Rewards)
+	return nil
+}
+
+// mineEmpty appends a block without transactions. If the timestamp is not zero, the block
+// is timestamped with it and the clock continues from it.
+func (f *Fork) mineEmpty(timestamp uint64) (*forkBlock, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if timestamp != 0 {
+		if timestamp <= f.head().header.Time.Uint64() {
+			return nil, errPastTimestamp
+		}
+		f.timeOffset = int64(timestamp) - time.Now().Unix()
+	}
+	return f.mine(nil, common.Address{}, timestamp)
+}
+
+// increaseTime moves the clock of the next blocks forward, and returns the total number
+// of seconds the clock is ahead of the system clock.
+func (f *Fork) increaseTime(seconds uint64) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.timeOffset += int64(seconds)
+	return f.timeOffset
+}
+
+// modifyHead applies the change to a copy of the head state, which replaces the head state.
+func (f *Fork) modifyHead(change func(st *state.StateDB) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	st := f.head().state.Copy()
+	if err := change(st); err != nil {
+		return err
+	}
+	st.Finalise(false, true)
+	if err := st.Error(); err != nil {
+		return err
+	}
+	f.head().state = st
+	return nil
+}
+
+// impersonate allows or disallows sending the transactions from the account without signatures.
+func (f *Fork) impersonate(addr common.Address, enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if enabled {
+		f.impersonated[addr] = true
+	} else {
+		delete(f.impersonated, addr)
+	}
+}
+
+// snapshot saves the chain, the head state and the clock, and returns the id to revert to them.
+func (f *Fork) snapshot() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.nextSnapshot
+	f.nextSnapshot++
+	f.snapshots[id] = &forkSnapshot{blocks: len(f.blocks), state: f.head().state, timeOffset: f.timeOffset}
+	return id
+}
+
+// revert restores the chain, the head state and the clock saved by the snapshot. The snapshot and
+// the ones taken after it are discarded. It returns false if the snapshot is unknown.
+func (f *Fork) revert(id uint64) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, ok := f.snapshots[id]
+	if !ok {
+		return false
+	}
+	for _, b := range f.blocks[s.blocks:] {
+		if b.tx != nil {
+			delete(f.txs, b.tx.Hash())
+		}
+	}
+	f.blocks = f.blocks[:s.blocks]
+	f.head().state = s.state
+	f.timeOffset = s.timeOffset
+	for sid := range f.snapshots {
+		if sid >= id {
+			delete(f.snapshots, sid)
+		}
+	}
+	return true
+}
@@ -0,0 +1,275 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package forknet
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAlice = common.HexToAddress("0xa11ce")
+	testToken = common.HexToAddress("0x70ce")
+	testBob   = common.HexToAddress("0xb0b")
+
+	testRewardbase    = common.HexToAddress("0x5e3a")
+	testMintingAmount = new(big.Int).Mul(big.NewInt(96), big.NewInt(params.KLAY/10))
+
+	// testCode returns the value of the storage slot 0.
+	testCode = hexutil.MustDecode("0x60005460005260206000f3")
+)
+
+// testRemoteAPI is the klay API of a remote network at the block 100.
+type testRemoteAPI struct {
+	header   *types.Header
+	accounts map[common.Address]account.Account
+	storage  map[common.Hash]common.Hash
+}
+
+func newTestRemoteAPI(t *testing.T) *testRemoteAPI {
+	alice, err := account.NewAccountWithMap(account.ExternallyOwnedAccountType, map[account.AccountValueKeyType]interface{}{
+		account.AccountValueKeyNonce:      uint64(5),
+		account.AccountValueKeyBalance:    big.NewInt(params.KLAY),
+		account.AccountValueKeyAccountKey: accountkey.NewAccountKeyLegacy(),
+	})
+	require.NoError(t, err)
+	token, err := account.NewAccountWithMap(account.SmartContractAccountType, map[account.AccountValueKeyType]interface{}{
+		account.AccountValueKeyNonce:       uint64(1),
+		account.AccountValueKeyBalance:     new(big.Int),
+		account.AccountValueKeyAccountKey:  accountkey.NewAccountKeyFail(),
+		account.AccountValueKeyStorageRoot: common.HexToHash("0x1234"),
+		account.AccountValueKeyCodeHash:    crypto.Keccak256(testCode),
+		account.AccountValueKeyCodeInfo:    params.CodeInfo(0),
+	})
+	require.NoError(t, err)
+	return &testRemoteAPI{
+		header: &types.Header{
+			Number:     big.NewInt(100),
+			BlockScore: common.Big1,
+			Rewardbase: testRewardbase,
+			Time:       big.NewInt(1700000000),
+			Extra:      []byte{},
+			Governance: []byte{},
+		},
+		accounts: map[common.Address]account.Account{testAlice: alice, testToken: token},
+		storage:  map[common.Hash]common.Hash{{}: common.HexToHash("0x2a")},
+	}
+}
+
+func (api *testRemoteAPI) GetBlockByNumber(number rpc.BlockNumber, fullTxs bool) *types.Header {
+	return api.header
+}
+
+func (api *testRemoteAPI) GetChainConfig(number *rpc.BlockNumber) *params.ChainConfig {
+	config := params.TestChainConfig.Copy()
+	config.Governance = params.GetDefaultGovernanceConfig()
+	config.Governance.Reward.MintingAmount = testMintingAmount
+	return config
+}
+
+func (api *testRemoteAPI) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(25 * params.Ston))
+}
+
+func (api *testRemoteAPI) GetAccount(address common.Address, block string) *account.AccountSerializer {
+	if acc, ok := api.accounts[address]; ok {
+		return account.NewAccountSerializerWithAccount(acc)
+	}
+	return nil
+}
+
+func (api *testRemoteAPI) GetCode(address common.Address, block string) hexutil.Bytes {
+	if address == testToken {
+		return testCode
+	}
+	return nil
+}
+
+func (api *testRemoteAPI) GetStorageAt(address common.Address, key string, block string) hexutil.Bytes {
+	value := api.storage[common.HexToHash(key)]
+	return value[:]
+}
+
+func (api *testRemoteAPI) GetBalance(address common.Address, block string) *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(7)) // the balance before the fork block
+}
+
+func newTestFork(t *testing.T) *rpc.Client {
+	remote := rpc.NewServer()
+	require.NoError(t, remote.RegisterName("klay", newTestRemoteAPI(t)))
+	f, err := newFork(context.Background(), rpc.DialInProc(remote), 0)
+	require.NoError(t, err)
+
+	server := rpc.NewServer()
+	for _, api := range f.APIs() {
+		require.NoError(t, server.RegisterName(api.Namespace, api.Service))
+	}
+	return rpc.DialInProc(server)
+}
+
+func TestFork(t *testing.T) {
+	c := newTestFork(t)
+	call := func(result interface{}, method string, args ...interface{}) {
+		require.NoError(t, c.Call(result, method, args...), method)
+	}
+	balanceOf := func(addr common.Address, block string) *big.Int {
+		var balance hexutil.Big
+		call(&balance, "klay_getBalance", addr, block)
+		return balance.ToInt()
+	}
+	slot0 := func() common.Hash {
+		var value hexutil.Bytes
+		call(&value, "eth_call", map[string]interface{}{"to": testToken}, "latest")
+		return common.BytesToHash(value)
+	}
+
+	var number hexutil.Uint64
+	call(&number, "klay_blockNumber")
+	assert.Equal(t, hexutil.Uint64(100), number)
+
+	// the state is loaded from the remote network
+	assert.Equal(t, big.NewInt(params.KLAY), balanceOf(testAlice, "latest"))
+	assert.Equal(t, big.NewInt(7), balanceOf(testAlice, "0x32"))
+	assert.Equal(t, common.HexToHash("0x2a"), slot0())
+
+	call(nil, "fork_setStorageAt", testToken, common.Hash{}, common.HexToHash("0x2b"))
+	assert.Equal(t, common.HexToHash("0x2b"), slot0())
+
+	var snapshot hexutil.Uint64
+	call(&snapshot, "evm_snapshot")
+
+	// the impersonated account sends a transaction without the signature
+	transfer := map[string]interface{}{"from": testBob, "to": testAlice, "value": "0x3e8", "gas": "0x5208"}
+	assert.Error(t, c.Call(nil, "klay_sendTransaction", transfer))
+	call(nil, "fork_impersonateAccount", testBob)
+	call(nil, "fork_setBalance", testBob, hexutil.EncodeBig(big.NewInt(params.KLAY)))
+	var gas hexutil.Uint64
+	call(&gas, "klay_estimateGas", transfer)
+	assert.Equal(t, hexutil.Uint64(params.TxGas), gas)
+	var hash common.Hash
+	call(&hash, "klay_sendTransaction", transfer)
+
+	var receipt map[string]interface{}
+	call(&receipt, "klay_getTransactionReceipt", hash)
+	assert.Equal(t, "0x1", receipt["status"])
+	assert.Equal(t, "0x65", receipt["blockNumber"])
+	assert.Equal(t, testBob.Hex(), common.HexToAddress(receipt["from"].(string)).Hex())
+	assert.Equal(t, new(big.Int).Add(big.NewInt(params.KLAY), big.NewInt(1000)), balanceOf(testAlice, "latest"))
+	assert.Equal(t, big.NewInt(params.KLAY), balanceOf(testAlice, "0x64"))
+
+	// a signed transaction is executed as well
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	call(nil, "fork_setBalance", sender, hexutil.EncodeBig(big.NewInt(params.KLAY)))
+	tx, err := types.SignTx(types.NewTransaction(0, testAlice, big.NewInt(1), params.TxGas, big.NewInt(25*params.Ston), nil),
+		types.LatestSignerForChainID(params.TestChainConfig.ChainID), key)
+	require.NoError(t, err)
+	encoded, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	call(&hash, "eth_sendRawTransaction", hexutil.Bytes(encoded))
+	assert.Equal(t, tx.Hash(), hash)
+	call(&number, "klay_blockNumber")
+	assert.Equal(t, hexutil.Uint64(102), number)
+
+	// the blocks and the state changes after the snapshot are reverted
+	var reverted bool
+	call(&reverted, "evm_revert", snapshot)
+	assert.True(t, reverted)
+	call(&number, "klay_blockNumber")
+	assert.Equal(t, hexutil.Uint64(100), number)
+	assert.Equal(t, big.NewInt(params.KLAY), balanceOf(testAlice, "latest"))
+	assert.Zero(t, balanceOf(testBob, "latest").Sign())
+	assert.Equal(t, common.HexToHash("0x2b"), slot0())
+	call(&reverted, "evm_revert", snapshot)
+	assert.False(t, reverted)
+}
+
+func TestFork_DevAPI(t *testing.T) {
+	c := newTestFork(t)
+	call := func(result interface{}, method string, args ...interface{}) {
+		require.NoError(t, c.Call(result, method, args...), method)
+	}
+	head := func() (uint64, uint64) {
+		var block map[string]interface{}
+		call(&block, "klay_getBlockByNumber", "latest", false)
+		number, time := hexutil.MustDecodeUint64(block["number"].(string)), hexutil.MustDecodeUint64(block["timestamp"].(string))
+		return number, time
+	}
+	rewardbaseBalance := func() *big.Int {
+		var balance hexutil.Big
+		call(&balance, "klay_getBalance", testRewardbase, "latest")
+		return balance.ToInt()
+	}
+	minting := testMintingAmount
+
+	var snapshot hexutil.Uint64
+	call(&snapshot, "klay_snapshot")
+
+	// the clock travels in time by both the number and the hex quantity
+	var offset int64
+	call(&offset, "evm_increaseTime", 3600)
+	assert.Equal(t, int64(3600), offset)
+	call(&offset, "klay_increaseTime", "0xe10")
+	assert.Equal(t, int64(7200), offset)
+
+	// each mined block mints the reward to the rewardbase
+	var number hexutil.Uint64
+	call(&number, "evm_mine")
+	assert.Equal(t, hexutil.Uint64(101), number)
+	_, timestamp := head()
+	assert.GreaterOrEqual(t, timestamp, uint64(time.Now().Unix()+7200))
+	assert.Equal(t, minting, rewardbaseBalance())
+
+	// the block is timestamped as requested and the clock continues from it
+	next := timestamp + 100000
+	call(&number, "evm_mine", next)
+	assert.Equal(t, hexutil.Uint64(102), number)
+	_, timestamp = head()
+	assert.Equal(t, next, timestamp)
+	assert.Equal(t, new(big.Int).Mul(minting, big.NewInt(2)), rewardbaseBalance())
+	assert.Error(t, c.Call(nil, "evm_mine", next))
+	call(nil, "klay_mine")
+	_, timestamp = head()
+	assert.GreaterOrEqual(t, timestamp, next+1)
+	assert.Less(t, timestamp, next+100)
+
+	// the blocks, the minted rewards and the clock are reverted
+	var reverted bool
+	call(&reverted, "klay_revert", snapshot)
+	assert.True(t, reverted)
+	number100, timestamp := head()
+	assert.Equal(t, uint64(100), number100)
+	assert.Equal(t, uint64(1700000000), timestamp)
+	assert.Zero(t, rewardbaseBalance().Sign())
+	call(nil, "evm_mine")
+	_, timestamp = head()
+	assert.Less(t, timestamp, uint64(time.Now().Unix()+3600))
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package forknet
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// remoteTimeout is the timeout of a request to the remote network.
+const remoteTimeout = 30 * time.Second
+
+type remoteAccount struct {
+	acc  account.Account // nil if the account does not exist
+	code []byte
+}
+
+// remoteState implements state.RemoteState by requesting the accounts and the storage at
+// the fork block to the remote network. The responses are cached, since the state of the
+// fork block never changes.
+type remoteState struct {
+	client *rpc.Client
+	block  string
+
+	accounts map[common.Address]*remoteAccount
+	storage  map[common.Address]map[common.Hash]common.Hash
+	mu       sync.Mutex
+}
+
+func newRemoteState(client *rpc.Client, block uint64) *remoteState {
+	return &remoteState{
+		client:   client,
+		block:    hexutil.EncodeUint64(block),
+		accounts: make(map[common.Address]*remoteAccount),
+		storage:  make(map[common.Address]map[common.Hash]common.Hash),
+	}
+}
+
+// Account returns a copy of the account at the fork block, since the account is modified
+// by the state which loads it.
+func (r *remoteState) Account(addr common.Address) (account.Account, []byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cached, ok := r.accounts[addr]
+	if !ok {
+		ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+		defer cancel()
+
+		var raw json.RawMessage
+		if err := r.client.CallContext(ctx, &raw, "klay_getAccount", addr, r.block); err != nil {
+			return nil, nil, err
+		}
+		cached = &remoteAccount{}
+		if len(raw) > 0 && string(raw) != "null" {
+			serializer := account.NewAccountSerializer()
+			if err := json.Unmarshal(raw, serializer); err != nil {
+				return nil, nil, err
+			}
+			cached.acc = serializer.GetAccount()
+			if account.GetProgramAccount(cached.acc) != nil {
+				var code hexutil.Bytes
+				if err := r.client.CallContext(ctx, &code, "klay_getCode", addr, r.block); err != nil {
+					return nil, nil, err
+				}
+				cached.code = code
+			}
+		}
+		r.accounts[addr] = cached
+	}
+	if cached.acc == nil {
+		return nil, nil, nil
+	}
+	return cached.acc.DeepCopy(), common.CopyBytes(cached.code), nil
+}
+
+// Storage returns the value of the storage slot at the fork block.
+func (r *remoteState) Storage(addr common.Address, key common.Hash) (common.Hash, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if value, ok := r.storage[addr][key]; ok {
+		return value, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+
+	var value hexutil.Bytes
+	if err := r.client.CallContext(ctx, &value, "klay_getStorageAt", addr, key, r.block); err != nil {
+		return common.Hash{}, err
+	}
+	if r.storage[addr] == nil {
+		r.storage[addr] = make(map[common.Hash]common.Hash)
+	}
+	r.storage[addr][key] = common.BytesToHash(value)
+	return r.storage[addr][key], nil
+}
@@ -74,14 +74,20 @@ type Node struct {
 	ipcListener net.Listener // IPC RPC listener socket to serve API requests
 	ipcHandler  *rpc.Server  // IPC RPC request handler to process the API requests
 
-	httpEndpoint  string       // HTTP endpoint (interface + port) to listen at (empty = HTTP disabled)
-	httpWhitelist []string     // HTTP RPC modules to allow through this endpoint
-	httpListener  net.Listener // HTTP RPC listener socket to server API requests
-	httpHandler   *rpc.Server  // HTTP RPC request handler to process the API requests
+	adminEndpoint string       // Admin endpoint to listen at (empty = admin endpoint disabled)
+	adminListener net.Listener // Admin RPC listener socket to serve the authenticated API requests
+	adminHandler  *rpc.Server  // Admin RPC request handler to process the API requests
 
-	wsEndpoint string       // Websocket endpoint (interface + port) to listen at (empty = websocket disabled)
-	wsListener net.Listener // Websocket RPC listener socket to server API requests
-	wsHandler  *rpc.Server  // Websocket RPC request handler to process the API requests
+	httpEndpoint  string                // HTTP endpoint (interface + port) to listen at (empty = HTTP disabled)
+	httpWhitelist []string              // HTTP RPC modules to allow through this endpoint
+	httpListener  net.Listener          // HTTP RPC listener socket to server API requests
+	httpHandler   *rpc.Server           // HTTP RPC request handler to process the API requests
+	httpVirtuals  *rpc.VirtualEndpoints // HTTP RPC virtual endpoints served by the HTTP listener
+
+	wsEndpoint string                // Websocket endpoint (interface + port) to listen at (empty = websocket disabled)
+	wsListener net.Listener          // Websocket RPC listener socket to server API requests
+	wsHandler  *rpc.Server           // Websocket RPC request handler to process the API requests
+	wsVirtuals *rpc.VirtualEndpoints // Websocket RPC virtual endpoints served by the websocket listener
 
 	grpcEndpoint string         // gRPC endpoint (interface + port) to listen at (empty = gRPC disabled)
 	grpcListener *grpc.Listener // gRPC listener socket to server API requests
@@ -137,6 +143,7 @@ func New(conf *Config) (*Node, error) {
 		coreServiceFuncs:  []ServiceConstructor{},
 		serviceFuncs:      []ServiceConstructor{},
 		ipcEndpoint:       conf.IPCEndpoint(),
+		adminEndpoint:     conf.AdminEndpoint(),
 		httpEndpoint:      conf.HTTPEndpoint(),
 		wsEndpoint:        conf.WSEndpoint(),
 		grpcEndpoint:      conf.GRPCEndpoint(),
@@ -337,23 +344,35 @@ func (n *Node) startRPC(services map[reflect.Type]Service) error {
 	for _, service := range services {
 		apis = append(apis, service.APIs()...)
 	}
+	debug.RegisterSubscriptionCounter("rpc", rpc.ActiveSubscriptions)
 	// Start the various API endpoints, terminating all in case of errors
 	if err := n.startInProc(apis); err != nil {
 		return err
 	}
+	if err := n.startAdmin(apis); err != nil {
+		n.stopInProc()
+		return err
+	}
+	// The admin modules are served only by the authenticated admin endpoint if exclusive
+	if n.adminEndpoint != "" && n.config.AdminExclusive {
+		apis = n.excludeAdminAPIs(apis)
+	}
 	if err := n.startIPC(apis); err != nil {
+		n.stopAdmin()
 		n.stopInProc()
 		return err
 	}
 
 	if err := n.startHTTP(n.httpEndpoint, apis, n.config.HTTPModules, n.config.HTTPCors, n.config.HTTPVirtualHosts, n.config.HTTPTimeouts); err != nil {
 		n.stopIPC()
+		n.stopAdmin()
 		n.stopInProc()
 		return err
 	}
 	if err := n.startWS(n.wsEndpoint, apis, n.config.WSModules, n.config.WSOrigins, n.config.WSExposeAll); err != nil {
 		n.stopHTTP()
 		n.stopIPC()
+		n.stopAdmin()
 		n.stopInProc()
 		return err
 	}
@@ -362,6 +381,7 @@ func (n *Node) startRPC(services map[reflect.Type]Service) error {
 	if err := n.startgRPC(apis); err != nil {
 		n.stopHTTP()
 		n.stopIPC()
+		n.stopAdmin()
 		n.stopInProc()
 		return err
 	}
@@ -422,6 +442,66 @@ func (n *Node) stopIPC() {
 	}
 }
 
+// startAdmin initializes and starts the authenticated admin endpoint.
+func (n *Node) startAdmin(apis []rpc.API) error {
+	if n.adminEndpoint == "" {
+		return nil // Admin endpoint disabled.
+	}
+	auth := rpc.AdminAuthConfig{
+		TLSCert:     n.config.AdminTLSCert,
+		TLSKey:      n.config.AdminTLSKey,
+		TLSClientCA: n.config.AdminTLSClientCA,
+	}
+	if n.config.AdminTokenFile != "" {
+		tokens, err := rpc.LoadAdminTokens(n.config.AdminTokenFile)
+		if err != nil {
+			return err
+		}
+		auth.Tokens = tokens
+	}
+	listener, handler, err := rpc.StartAdminEndpoint(n.adminEndpoint, apis, n.config.AdminModules, auth, n.config.HTTPTimeouts)
+	if err != nil {
+		return err
+	}
+	n.adminListener = listener
+	n.adminHandler = handler
+	n.logger.Info("Admin endpoint opened", "url", n.adminEndpoint, "tokens", len(auth.Tokens), "mtls", auth.TLSClientCA != "")
+	return nil
+}
+
+// stopAdmin terminates the admin endpoint.
+func (n *Node) stopAdmin() {
+	if n.adminListener != nil {
+		n.adminListener.Close()
+		n.adminListener = nil
+
+		n.logger.Info("Admin endpoint closed", "endpoint", n.adminEndpoint)
+	}
+	if n.adminHandler != nil {
+		n.adminHandler.Stop()
+		n.adminHandler = nil
+	}
+}
+
+// excludeAdminAPIs returns the APIs except the ones served by the admin endpoint.
+func (n *Node) excludeAdminAPIs(apis []rpc.API) []rpc.API {
+	modules := n.config.AdminModules
+	if len(modules) == 0 {
+		modules = rpc.DefaultAdminModules
+	}
+	excluded := make(map[string]bool)
+	for _, module := range modules {
+		excluded[module] = true
+	}
+	filtered := make([]rpc.API, 0, len(apis))
+	for _, api := range apis {
+		if !excluded[api.Namespace] {
+			filtered = append(filtered, api)
+		}
+	}
+	return filtered
+}
+
 // startgRPC initializes and starts the gRPC endpoint.
 func (n *Node) startgRPC(apis []rpc.API) error {
 	if n.grpcEndpoint == "" {
@@ -454,11 +534,26 @@ func (n *Node) startHTTP(endpoint string, apis []rpc.API, modules []string, cors
 	if endpoint == "" {
 		return nil
 	}
+	if len(n.config.HTTPVirtualEndpoints) > 0 {
+		listener, handler, virtuals, err := rpc.StartVirtualHTTPEndpoint(endpoint, apis, modules, cors, vhosts, timeouts, n.config.HTTPVirtualEndpoints)
+		if err != nil {
+			return err
+		}
+		n.logger.Info("HTTP endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "cors", strings.Join(cors, ","), "vhosts", strings.Join(vhosts, ","),
+			"virtualEndpoints", strings.Join(virtuals.Names(), ","))
+		handler.SetComputeBudget(n.config.HTTPComputeBudget)
+		n.httpEndpoint = endpoint
+		n.httpListener = listener
+		n.httpHandler = handler
+		n.httpVirtuals = virtuals
+		return nil
+	}
 	listener, handler, err := rpc.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts, timeouts)
 	if err != nil {
 		return err
 	}
 	n.logger.Info("HTTP endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "cors", strings.Join(cors, ","), "vhosts", strings.Join(vhosts, ","))
+	handler.SetComputeBudget(n.config.HTTPComputeBudget)
 	// All listeners booted successfully
 	n.httpEndpoint = endpoint
 	n.httpListener = listener
@@ -478,6 +573,7 @@ func (n *Node) startFastHTTP(endpoint string, apis []rpc.API, modules []string,
 		return err
 	}
 	n.logger.Info("FastHTTP endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "cors", strings.Join(cors, ","), "vhosts", strings.Join(vhosts, ","))
+	handler.SetComputeBudget(n.config.HTTPComputeBudget)
 	// All listeners booted successfully
 	n.httpEndpoint = endpoint
 	n.httpListener = listener
@@ -498,6 +594,10 @@ func (n *Node) stopHTTP() {
 		n.httpHandler.Stop()
 		n.httpHandler = nil
 	}
+	if n.httpVirtuals != nil {
+		n.httpVirtuals.Stop()
+		n.httpVirtuals = nil
+	}
 }
 
 // startWS initializes and starts the websocket RPC endpoint.
@@ -506,11 +606,25 @@ func (n *Node) startWS(endpoint string, apis []rpc.API, modules []string, wsOrig
 	if endpoint == "" {
 		return nil
 	}
+	if len(n.config.WSVirtualEndpoints) > 0 {
+		listener, handler, virtuals, err := rpc.StartVirtualWSEndpoint(endpoint, apis, modules, wsOrigins, exposeAll, n.config.WSVirtualEndpoints)
+		if err != nil {
+			return err
+		}
+		n.logger.Info("WebSocket endpoint opened", "url", fmt.Sprintf("ws://%s", listener.Addr()), "virtualEndpoints", strings.Join(virtuals.Names(), ","))
+		handler.SetComputeBudget(n.config.WSComputeBudget)
+		n.wsEndpoint = endpoint
+		n.wsListener = listener
+		n.wsHandler = handler
+		n.wsVirtuals = virtuals
+		return nil
+	}
 	listener, handler, err := rpc.StartWSEndpoint(endpoint, apis, modules, wsOrigins, exposeAll)
 	if err != nil {
 		return err
 	}
 	n.logger.Info("WebSocket endpoint opened", "url", fmt.Sprintf("ws://%s", listener.Addr()))
+	handler.SetComputeBudget(n.config.WSComputeBudget)
 	// All listeners booted successfully
 	n.wsEndpoint = endpoint
 	n.wsListener = listener
@@ -530,6 +644,7 @@ func (n *Node) startFastWS(endpoint string, apis []rpc.API, modules []string, ws
 		return err
 	}
 	n.logger.Info("FastWebSocket endpoint opened", "url", fmt.Sprintf("ws://%s", listener.Addr()))
+	handler.SetComputeBudget(n.config.WSComputeBudget)
 	// All listeners booted successfully
 	n.wsEndpoint = endpoint
 	n.wsListener = listener
@@ -550,6 +665,10 @@ func (n *Node) stopWS() {
 		n.wsHandler.Stop()
 		n.wsHandler = nil
 	}
+	if n.wsVirtuals != nil {
+		n.wsVirtuals.Stop()
+		n.wsVirtuals = nil
+	}
 }
 
 func (n *Node) stopgRPC() {
@@ -581,6 +700,7 @@ func (n *Node) Stop() error {
 	n.stopWS()
 	n.stopHTTP()
 	n.stopIPC()
+	n.stopAdmin()
 	n.stopgRPC()
 	n.rpcAPIs = nil
 	failure := &StopError{
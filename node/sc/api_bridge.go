@@ -124,6 +124,10 @@ func (sb *SubBridgeAPI) RegisterOperator(bridgeAddr, operatorAddr common.Address
 	return sb.subBridge.bridgeManager.RegisterOperator(bridgeAddr, operatorAddr)
 }
 
+func (sb *SubBridgeAPI) DeregisterOperator(bridgeAddr, operatorAddr common.Address) (common.Hash, error) {
+	return sb.subBridge.bridgeManager.DeregisterOperator(bridgeAddr, operatorAddr)
+}
+
 func (sb *SubBridgeAPI) GetRegisteredOperators(bridgeAddr common.Address) ([]common.Address, error) {
 	return sb.subBridge.bridgeManager.GetOperators(bridgeAddr)
 }
@@ -136,6 +140,38 @@ func (sb *SubBridgeAPI) GetValueTransferOperatorThreshold(bridgeAddr common.Addr
 	return sb.subBridge.bridgeManager.GetValueTransferOperatorThreshold(bridgeAddr)
 }
 
+func (sb *SubBridgeAPI) SetConfigurationOperatorThreshold(bridgeAddr common.Address, threshold uint8) (common.Hash, error) {
+	return sb.subBridge.bridgeManager.SetConfigurationOperatorThreshold(bridgeAddr, threshold)
+}
+
+func (sb *SubBridgeAPI) GetConfigurationOperatorThreshold(bridgeAddr common.Address) (uint8, error) {
+	return sb.subBridge.bridgeManager.GetConfigurationOperatorThreshold(bridgeAddr)
+}
+
+func (sb *SubBridgeAPI) IsValueTransferVoteClosed(bridgeAddr common.Address, requestNonce uint64) (bool, error) {
+	return sb.subBridge.bridgeManager.IsValueTransferVoteClosed(bridgeAddr, requestNonce)
+}
+
+func (sb *SubBridgeAPI) RegisterMessageBridge(cBridgeAddr, pBridgeAddr common.Address) error {
+	return sb.subBridge.messageBridgeManager.RegisterMessageBridge(cBridgeAddr, pBridgeAddr)
+}
+
+func (sb *SubBridgeAPI) DeregisterMessageBridge(cBridgeAddr, pBridgeAddr common.Address) error {
+	return sb.subBridge.messageBridgeManager.DeregisterMessageBridge(cBridgeAddr, pBridgeAddr)
+}
+
+func (sb *SubBridgeAPI) GetMessageBridges() []MessageBridgePair {
+	return sb.subBridge.messageBridgeManager.GetMessageBridges()
+}
+
+func (sb *SubBridgeAPI) RegisterMessageHandler(bridgeAddr, handlerAddr common.Address) (common.Hash, error) {
+	return sb.subBridge.messageBridgeManager.RegisterMessageHandler(bridgeAddr, handlerAddr)
+}
+
+func (sb *SubBridgeAPI) DeregisterMessageHandler(bridgeAddr, handlerAddr common.Address) (common.Hash, error) {
+	return sb.subBridge.messageBridgeManager.DeregisterMessageHandler(bridgeAddr, handlerAddr)
+}
+
 func (sb *SubBridgeAPI) DeployBridge() ([]common.Address, error) {
 	cAcc := sb.subBridge.bridgeAccounts.cAccount
 	pAcc := sb.subBridge.bridgeAccounts.pAccount
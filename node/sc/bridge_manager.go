@@ -155,6 +155,20 @@ func NewBridgeInfo(sb *SubBridge, addr common.Address, bridge *bridgecontract.Br
 	return bi, nil
 }
 
+// valueTransferVoteClosed returns true if the value transfer of the request nonce has been
+// executed by reaching the operator threshold. The bridge removes the closed votes below its
+// lower handle nonce, so those are reported as closed by the nonce.
+func (bi *BridgeInfo) valueTransferVoteClosed(requestNonce uint64) (bool, error) {
+	lowerHandleNonce, err := bi.bridge.LowerHandleNonce(nil)
+	if err != nil {
+		return false, err
+	}
+	if requestNonce < lowerHandleNonce {
+		return true, nil
+	}
+	return bi.bridge.ClosedValueTransferVotes(nil, requestNonce)
+}
+
 // handleValueTransferLog records value transfer transaction's log
 func handleValueTransferLog(onChild bool, funcName, txHash string, reqNonce uint64, from, to common.Address, valueOrTokenId *big.Int) {
 	// Note the `onChild` should be interpreted as reverse. Refer `ProcessRequestEvent()` in sub_event_handler.go
@@ -316,6 +330,22 @@ func (bi *BridgeInfo) handleRequestValueTransferEvent(ev IRequestValueTransferEv
 		logger.Info("Register counter part token address.", "addr", ctpartTokenAddr.Hex(), "cpAddr", ctTokenAddr.Hex())
 	}
 
+	// With multiple operators, a value transfer is executed once the operator threshold of
+	// votes is reached. Skip the request if the vote is already closed by other operators or
+	// this node is not an operator, since the handle transaction would be reverted.
+	if closed, err := bi.valueTransferVoteClosed(requestNonce); err != nil {
+		return err
+	} else if closed {
+		logger.Debug("Skip the value transfer already handled by other operators", "bridge", contractAddr.String(), "nonce", requestNonce)
+		return nil
+	}
+	if isOperator, err := bi.bridge.Operators(nil, bi.account.address); err != nil {
+		return err
+	} else if !isOperator {
+		logger.Warn("Skip the value transfer since the bridge account is not an operator", "bridge", contractAddr.String(), "account", bi.account.address.String(), "nonce", requestNonce)
+		return nil
+	}
+
 	bridgeAcc := bi.account
 
 	bridgeAcc.Lock()
@@ -856,6 +886,25 @@ func (bm *BridgeManager) RegisterOperator(bridgeAddr, operatorAddr common.Addres
 	return tx.Hash(), nil
 }
 
+// DeregisterOperator deregisters the operator from the bridge. Only the owner of the bridge can deregister an operator.
+func (bm *BridgeManager) DeregisterOperator(bridgeAddr, operatorAddr common.Address) (common.Hash, error) {
+	bi, exist := bm.GetBridgeInfo(bridgeAddr)
+
+	if !exist {
+		return common.Hash{}, ErrNoBridgeInfo
+	}
+
+	bi.account.Lock()
+	defer bi.account.UnLock()
+	tx, err := bi.bridge.DeregisterOperator(bi.account.GenerateTransactOpts(), operatorAddr)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	bi.account.IncNonce()
+
+	return tx.Hash(), nil
+}
+
 func (bm *BridgeManager) GetOperators(bridgeAddr common.Address) ([]common.Address, error) {
 	bi, exist := bm.GetBridgeInfo(bridgeAddr)
 
@@ -899,6 +948,51 @@ func (bm *BridgeManager) GetValueTransferOperatorThreshold(bridgeAddr common.Add
 	return threshold, nil
 }
 
+func (bm *BridgeManager) SetConfigurationOperatorThreshold(bridgeAddr common.Address, threshold uint8) (common.Hash, error) {
+	bi, exist := bm.GetBridgeInfo(bridgeAddr)
+
+	if !exist {
+		return common.Hash{}, ErrNoBridgeInfo
+	}
+
+	bi.account.Lock()
+	defer bi.account.UnLock()
+	tx, err := bi.bridge.SetOperatorThreshold(bi.account.GenerateTransactOpts(), voteTypeConfiguration, threshold)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	bi.account.IncNonce()
+
+	return tx.Hash(), nil
+}
+
+func (bm *BridgeManager) GetConfigurationOperatorThreshold(bridgeAddr common.Address) (uint8, error) {
+	bi, exist := bm.GetBridgeInfo(bridgeAddr)
+
+	if !exist {
+		return 0, ErrNoBridgeInfo
+	}
+
+	threshold, err := bi.bridge.OperatorThresholds(nil, voteTypeConfiguration)
+	if err != nil {
+		return 0, err
+	}
+
+	return threshold, nil
+}
+
+// IsValueTransferVoteClosed returns true if the value transfer of the given request nonce has
+// been executed by reaching the operator threshold.
+func (bm *BridgeManager) IsValueTransferVoteClosed(bridgeAddr common.Address, requestNonce uint64) (bool, error) {
+	bi, exist := bm.GetBridgeInfo(bridgeAddr)
+
+	if !exist {
+		return false, ErrNoBridgeInfo
+	}
+
+	return bi.valueTransferVoteClosed(requestNonce)
+}
+
 // Deploy Bridge SmartContract on same node or remote node
 func (bm *BridgeManager) DeployBridge(auth *bind.TransactOpts, backend bind.ContractBackend, local bool) (*bridgecontract.Bridge, common.Address, error) {
 	var acc *accountInfo
@@ -2730,3 +2730,156 @@ func TestGetBridgeContractBalance(t *testing.T) {
 		}
 	}
 }
+
+// TestBridgeManagerOperatorThreshold tests that a value transfer is held until the votes of the
+// operators reach the threshold, and that it is skipped once the threshold is reached.
+func TestBridgeManagerOperatorThreshold(t *testing.T) {
+	tempDir, err := os.MkdirTemp(os.TempDir(), "sc")
+	assert.NoError(t, err)
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Fatalf("fail to delete file %v", err)
+		}
+	}()
+
+	// Config Bridge Account Manager
+	config := &SCConfig{}
+	config.DataDir = tempDir
+	bacc, _ := NewBridgeAccounts(nil, config.DataDir, database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB}), DefaultBridgeTxGasLimit, DefaultBridgeTxGasLimit)
+	bacc.pAccount.chainID = big.NewInt(0)
+	bacc.cAccount.chainID = big.NewInt(0)
+
+	aliceKey, _ := crypto.GenerateKey()
+	alice := bind.NewKeyedTransactor(aliceKey)
+	bobKey, _ := crypto.GenerateKey()
+	bob := bind.NewKeyedTransactor(bobKey)
+	carolKey, _ := crypto.GenerateKey()
+	carol := bind.NewKeyedTransactor(carolKey)
+
+	// Create Simulated backend
+	alloc := blockchain.GenesisAlloc{
+		alice.From:            {Balance: big.NewInt(params.KLAY)},
+		carol.From:            {Balance: big.NewInt(params.KLAY)},
+		bacc.pAccount.address: {Balance: big.NewInt(params.KLAY)},
+		bacc.cAccount.address: {Balance: big.NewInt(params.KLAY)},
+	}
+	sim := backends.NewSimulatedBackend(alloc)
+	defer sim.Close()
+
+	sc := &SubBridge{
+		chainDB:        database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB}),
+		config:         config,
+		peers:          newBridgePeerSet(),
+		bridgeAccounts: bacc,
+		localBackend:   sim,
+		remoteBackend:  sim,
+	}
+	sc.APIBackend = &SubBridgeAPI{sc}
+	sc.handler, err = NewSubBridgeHandler(sc)
+	if err != nil {
+		log.Fatalf("Failed to initialize bridgeHandler : %v", err)
+		return
+	}
+
+	bm, err := NewBridgeManager(sc)
+	assert.NoError(t, err)
+	sc.bridgeManager = bm
+	defer bm.Stop()
+
+	// The owner (the parent bridge account) is an operator of the bridge deployed by itself.
+	addr, err := bm.DeployBridgeTest(sim, 10000, false)
+	assert.NoError(t, err)
+	bi, _ := bm.GetBridgeInfo(addr)
+	sim.Commit()
+
+	// Register the child bridge account and carol as the other operators, with 2-of-3 votes.
+	_, err = sc.APIBackend.RegisterOperator(addr, bacc.cAccount.address)
+	assert.NoError(t, err)
+	_, err = sc.APIBackend.RegisterOperator(addr, carol.From)
+	assert.NoError(t, err)
+	sim.Commit()
+	_, err = sc.APIBackend.SetValueTransferOperatorThreshold(addr, 2)
+	assert.NoError(t, err)
+	_, err = sc.APIBackend.SetConfigurationOperatorThreshold(addr, 3)
+	assert.NoError(t, err)
+	sim.Commit()
+
+	operators, err := sc.APIBackend.GetRegisteredOperators(addr)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []common.Address{bacc.pAccount.address, bacc.cAccount.address, carol.From}, operators)
+	threshold, err := sc.APIBackend.GetValueTransferOperatorThreshold(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), threshold)
+	threshold, err = sc.APIBackend.GetConfigurationOperatorThreshold(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(3), threshold)
+
+	// A threshold larger than the number of the operators is rejected by the bridge.
+	txHash, err := sc.APIBackend.SetValueTransferOperatorThreshold(addr, 4)
+	assert.NoError(t, err)
+	sim.Commit()
+	receipt, err := sim.TransactionReceipt(context.Background(), txHash)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusErrExecutionReverted, receipt.Status)
+	threshold, err = sc.APIBackend.GetValueTransferOperatorThreshold(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), threshold)
+
+	_, err = sc.APIBackend.GetConfigurationOperatorThreshold(common.Address{})
+	assert.Equal(t, ErrNoBridgeInfo, err)
+	_, err = sc.APIBackend.IsValueTransferVoteClosed(common.Address{}, 0)
+	assert.Equal(t, ErrNoBridgeInfo, err)
+
+	// Request a KLAY transfer from Alice to Bob.
+	testKLAY := big.NewInt(321)
+	tx, err := bi.bridge.RequestKLAYTransfer(&bind.TransactOpts{From: alice.From, Signer: alice.Signer, Value: testKLAY, GasLimit: testGasLimit}, bob.From, testKLAY, nil)
+	assert.NoError(t, err)
+	sim.Commit()
+	CheckReceipt(sim, tx, time.Second, types.ReceiptStatusSuccessful, t)
+
+	it, err := bi.bridge.FilterRequestValueTransfer(&bind.FilterOpts{Start: 0}, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, it.Next())
+	ev := RequestValueTransferEvent{it.Event}
+	assert.NoError(t, it.Close())
+	nonce := ev.GetRequestNonce()
+
+	balanceOf := func() *big.Int {
+		balance, err := sim.BalanceAt(context.Background(), bob.From, nil)
+		assert.NoError(t, err)
+		return balance
+	}
+	isClosed := func() bool {
+		closed, err := sc.APIBackend.IsValueTransferVoteClosed(addr, nonce)
+		assert.NoError(t, err)
+		return closed
+	}
+
+	// Below the threshold: the transfer voted by carol is held by the bridge.
+	tx, err = bi.bridge.HandleKLAYTransfer(&bind.TransactOpts{From: carol.From, Signer: carol.Signer, GasLimit: testGasLimit},
+		ev.GetRaw().TxHash, ev.GetFrom(), ev.GetTo(), ev.GetValueOrTokenId(), nonce, ev.GetRaw().BlockNumber, ev.GetExtraData())
+	assert.NoError(t, err)
+	sim.Commit()
+	CheckReceipt(sim, tx, time.Second, types.ReceiptStatusSuccessful, t)
+	assert.False(t, isClosed())
+	assert.Zero(t, balanceOf().Sign())
+
+	// At the threshold: the held vote is kept in the bridge, so the vote of another operator
+	// node, started after carol voted, executes the transfer.
+	cBridge, err := bridge.NewBridge(addr, sim)
+	assert.NoError(t, err)
+	other, err := NewBridgeInfo(sc, addr, cBridge, common.Address{}, nil, bacc.cAccount, false, false, sim)
+	assert.NoError(t, err)
+	defer close(other.closed)
+	assert.NoError(t, other.handleRequestValueTransferEvent(ev))
+	sim.Commit()
+	assert.True(t, isClosed())
+	assert.Equal(t, testKLAY.String(), balanceOf().String())
+
+	// Above the threshold: the vote is closed, so the node skips the transfer without a tx.
+	accNonce := bi.account.GetNonce()
+	assert.NoError(t, bi.handleRequestValueTransferEvent(ev))
+	sim.Commit()
+	assert.Equal(t, accNonce, bi.account.GetNonce())
+	assert.Equal(t, testKLAY.String(), balanceOf().String())
+}
@@ -47,6 +47,13 @@ func DefaultServiceChainConfig() *SCConfig {
 	return &SCConfig{
 		NetworkId: 1,
 		MaxPeer:   1, // Only a single main-bridge and sub-bridge pair is allowed.
+
+		ParentRPCHedgeSize:     DefaultParentRPCHedgeSize,
+		ParentRPCHealthTimeout: DefaultParentRPCHealthTimeout,
+
+		ParentGasPriceSyncInterval:  DefaultParentGasPriceSyncInterval,
+		ParentGasPriceSyncSmoothing: DefaultParentGasPriceSyncSmoothing,
+		ParentGasPriceSyncMaxChange: DefaultParentGasPriceSyncMaxChange,
 	}
 }
 
@@ -99,6 +106,18 @@ type SCConfig struct {
 	ServiceChainParentOperatorGasLimit uint64
 	ServiceChainChildOperatorGasLimit  uint64
 
+	// Parent chain RPC failover
+	ParentRPCHedgeSize     int           // number of main-bridges which a read request is sent to at once
+	ParentRPCHealthTimeout time.Duration // a main-bridge not responding for this duration is failed over
+
+	// Parent chain gas price synchronization
+	ParentGasPriceSync          bool   // vote the gas price parameters following the parent chain
+	ParentGasPriceSyncInterval  uint64 // interval in blocks to request the parent chain's gas price
+	ParentGasPriceSyncSmoothing uint64 // percentage of the gap to the parent chain's value applied at a time
+	ParentGasPriceSyncMaxChange uint64 // maximum percentage change of a parameter at a time (0 = unlimited)
+	ParentGasPriceSyncMin       uint64 // lower cap of the synced gas prices (0 = no cap)
+	ParentGasPriceSyncMax       uint64 // upper cap of the synced gas prices (0 = no cap)
+
 	// KAS
 	KASAnchor               bool
 	KASAnchorUrl            string
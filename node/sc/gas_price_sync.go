@@ -0,0 +1,155 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+)
+
+const (
+	DefaultParentGasPriceSyncInterval  = 3600 // blocks
+	DefaultParentGasPriceSyncSmoothing = 50   // percent
+	DefaultParentGasPriceSyncMaxChange = 20   // percent
+)
+
+// gasPriceGovernance is the governance of the child chain which the synced gas prices are voted to.
+// Declare only the methods used by the syncer, which are implemented by governance.Engine.
+type gasPriceGovernance interface {
+	AddVote(key string, val interface{}) bool
+	CurrentParams() *params.GovParamSet
+}
+
+type gasPriceChain interface {
+	Config() *params.ChainConfig
+	CurrentHeader() *types.Header
+}
+
+// gasPriceSyncer derives the gas price parameters of the child chain from the parent chain's
+// ones received by the sub-bridge and votes them to the child chain governance, so that the
+// operators need not re-vote them whenever the parent chain changes its gas price.
+// Each parameter moves toward the parent chain's value by `smoothing` percent of the gap,
+// at most by `maxChange` percent of the current value, and is capped by [min, max].
+// The votes only take effect if the node is allowed to vote, e.g. the governing node.
+type gasPriceSyncer struct {
+	gov   gasPriceGovernance
+	chain gasPriceChain
+
+	smoothing uint64
+	maxChange uint64
+	min       uint64 // 0 = no lower cap
+	max       uint64 // 0 = no upper cap
+}
+
+func newGasPriceSyncer(gov gasPriceGovernance, chain gasPriceChain, config *SCConfig) *gasPriceSyncer {
+	smoothing := config.ParentGasPriceSyncSmoothing
+	if smoothing == 0 || smoothing > 100 {
+		smoothing = DefaultParentGasPriceSyncSmoothing
+	}
+	return &gasPriceSyncer{
+		gov:       gov,
+		chain:     chain,
+		smoothing: smoothing,
+		maxChange: config.ParentGasPriceSyncMaxChange,
+		min:       config.ParentGasPriceSyncMin,
+		max:       config.ParentGasPriceSyncMax,
+	}
+}
+
+// sync votes the gas price parameters of the child chain derived from the parent chain info.
+// It returns the votes which have been cast.
+func (s *gasPriceSyncer) sync(pcInfo parentChainInfo) map[string]uint64 {
+	parentLower, parentUpper := pcInfo.GasPrice, pcInfo.GasPrice
+	if pcInfo.IsMagmaEnabled {
+		parentLower, parentUpper = pcInfo.KIP71Config.LowerBoundBaseFee, pcInfo.KIP71Config.UpperBoundBaseFee
+	}
+
+	pset := s.gov.CurrentParams()
+	next := new(big.Int).Add(s.chain.CurrentHeader().Number, common.Big1)
+	targets := make(map[string]uint64)
+	if s.chain.Config().IsMagmaForkEnabled(next) {
+		lower := s.follow(pset.LowerBoundBaseFee(), parentLower)
+		upper := s.follow(pset.UpperBoundBaseFee(), parentUpper)
+		if lower > upper {
+			lower = upper
+		}
+		targets["kip71.lowerboundbasefee"] = lower
+		targets["kip71.upperboundbasefee"] = upper
+	} else {
+		targets["governance.unitprice"] = s.follow(pset.UnitPrice(), pcInfo.GasPrice)
+	}
+
+	current := map[string]uint64{
+		"kip71.lowerboundbasefee": pset.LowerBoundBaseFee(),
+		"kip71.upperboundbasefee": pset.UpperBoundBaseFee(),
+		"governance.unitprice":    pset.UnitPrice(),
+	}
+	votes := make(map[string]uint64)
+	for key, value := range targets {
+		if value == current[key] {
+			continue
+		}
+		if !s.gov.AddVote(key, value) {
+			logger.Warn("[SC][GasPriceSync] Failed to vote the synced gas price", "key", key, "value", value)
+			continue
+		}
+		votes[key] = value
+	}
+	if len(votes) > 0 {
+		logger.Info("[SC][GasPriceSync] Voted the gas prices following the parent chain", "votes", votes,
+			"parentGasPrice", pcInfo.GasPrice, "parentLowerBoundBaseFee", parentLower, "parentUpperBoundBaseFee", parentUpper)
+	}
+	return votes
+}
+
+// follow returns the value moved from cur toward the parent chain's value.
+func (s *gasPriceSyncer) follow(cur, parent uint64) uint64 {
+	var (
+		curBig = new(big.Int).SetUint64(cur)
+		gap    = new(big.Int).Sub(new(big.Int).SetUint64(parent), curBig)
+		step   = new(big.Int).Quo(new(big.Int).Mul(gap, new(big.Int).SetUint64(s.smoothing)), big.NewInt(100))
+	)
+	// make progress even if the gap is smaller than the smoothing resolution
+	if step.Sign() == 0 {
+		step.Set(gap)
+	}
+	if s.maxChange > 0 {
+		limit := new(big.Int).Quo(new(big.Int).Mul(curBig, new(big.Int).SetUint64(s.maxChange)), big.NewInt(100))
+		if limit.Sign() == 0 {
+			limit.SetUint64(1)
+		}
+		if step.CmpAbs(limit) > 0 {
+			if step.Sign() > 0 {
+				step.Set(limit)
+			} else {
+				step.Neg(limit)
+			}
+		}
+	}
+
+	value := new(big.Int).Add(curBig, step).Uint64()
+	if s.min > 0 && value < s.min {
+		value = s.min
+	}
+	if s.max > 0 && value > s.max {
+		value = s.max
+	}
+	return value
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testGasPriceGovernance struct {
+	pset    *params.GovParamSet
+	votes   map[string]interface{}
+	canVote bool
+}
+
+func (g *testGasPriceGovernance) AddVote(key string, val interface{}) bool {
+	if !g.canVote {
+		return false
+	}
+	g.votes[key] = val
+	return true
+}
+
+func (g *testGasPriceGovernance) CurrentParams() *params.GovParamSet {
+	return g.pset
+}
+
+type testGasPriceChain struct {
+	config *params.ChainConfig
+}
+
+func (c *testGasPriceChain) Config() *params.ChainConfig {
+	return c.config
+}
+
+func (c *testGasPriceChain) CurrentHeader() *types.Header {
+	return &types.Header{Number: big.NewInt(100)}
+}
+
+func newTestGasPriceGovernance(t *testing.T, unitPrice, lower, upper uint64) *testGasPriceGovernance {
+	pset, err := params.NewGovParamSetIntMap(map[int]interface{}{
+		params.UnitPrice:         unitPrice,
+		params.LowerBoundBaseFee: lower,
+		params.UpperBoundBaseFee: upper,
+	})
+	require.Nil(t, err)
+	return &testGasPriceGovernance{pset: pset, votes: make(map[string]interface{}), canVote: true}
+}
+
+func TestGasPriceSyncer_Follow(t *testing.T) {
+	testcases := []struct {
+		smoothing, maxChange, min, max uint64
+		cur, parent                    uint64
+		expected                       uint64
+	}{
+		{100, 0, 0, 0, 25e9, 50e9, 50e9},   // follows the parent chain at once
+		{50, 0, 0, 0, 25e9, 50e9, 37.5e9},  // half of the gap
+		{50, 0, 0, 0, 50e9, 25e9, 37.5e9},  // decreasing
+		{50, 20, 0, 0, 25e9, 50e9, 30e9},   // capped by the maximum change
+		{50, 20, 0, 0, 50e9, 25e9, 40e9},   // capped by the maximum change, decreasing
+		{50, 0, 0, 30e9, 25e9, 50e9, 30e9}, // capped by the maximum
+		{50, 0, 30e9, 0, 25e9, 20e9, 30e9}, // capped by the minimum
+		{50, 0, 0, 0, 100, 101, 101},       // the gap smaller than the resolution is closed
+		{50, 20, 0, 0, 0, 25e9, 1},         // the maximum change of zero moves by one
+		{50, 20, 0, 0, 25e9, 25e9, 25e9},   // already synced
+	}
+
+	for i, tc := range testcases {
+		s := &gasPriceSyncer{smoothing: tc.smoothing, maxChange: tc.maxChange, min: tc.min, max: tc.max}
+		assert.Equal(t, tc.expected, s.follow(tc.cur, tc.parent), "testcases[%d] failed", i)
+	}
+}
+
+func TestGasPriceSyncer_Sync(t *testing.T) {
+	var (
+		magma    = params.TestChainConfig.Copy()
+		preMagma = params.TestChainConfig.Copy()
+		config   = &SCConfig{ParentGasPriceSyncSmoothing: 100}
+
+		parentMagma = parentChainInfo{
+			GasPrice:       50e9,
+			KIP71Config:    params.KIP71Config{LowerBoundBaseFee: 50e9, UpperBoundBaseFee: 500e9},
+			IsMagmaEnabled: true,
+		}
+		parentPreMagma = parentChainInfo{GasPrice: 50e9}
+	)
+	magma.MagmaCompatibleBlock = big.NewInt(0)
+	preMagma.MagmaCompatibleBlock = nil
+
+	// the base fee bounds follow the parent chain's ones
+	gov := newTestGasPriceGovernance(t, 25e9, 25e9, 750e9)
+	votes := newGasPriceSyncer(gov, &testGasPriceChain{magma}, config).sync(parentMagma)
+	assert.Equal(t, map[string]uint64{"kip71.lowerboundbasefee": 50e9, "kip71.upperboundbasefee": 500e9}, votes)
+	assert.Equal(t, map[string]interface{}{"kip71.lowerboundbasefee": uint64(50e9), "kip71.upperboundbasefee": uint64(500e9)}, gov.votes)
+
+	// the base fee bounds are fixed to the gas price of the parent chain before magma
+	gov = newTestGasPriceGovernance(t, 25e9, 25e9, 750e9)
+	votes = newGasPriceSyncer(gov, &testGasPriceChain{magma}, config).sync(parentPreMagma)
+	assert.Equal(t, map[string]uint64{"kip71.lowerboundbasefee": 50e9, "kip71.upperboundbasefee": 50e9}, votes)
+
+	// the unit price follows the gas price of the parent chain if the child chain is before magma
+	gov = newTestGasPriceGovernance(t, 25e9, 25e9, 750e9)
+	votes = newGasPriceSyncer(gov, &testGasPriceChain{preMagma}, config).sync(parentMagma)
+	assert.Equal(t, map[string]uint64{"governance.unitprice": 50e9}, votes)
+
+	// no vote is cast if already synced
+	gov = newTestGasPriceGovernance(t, 50e9, 50e9, 500e9)
+	votes = newGasPriceSyncer(gov, &testGasPriceChain{magma}, config).sync(parentMagma)
+	assert.Empty(t, votes)
+	assert.Empty(t, gov.votes)
+
+	// the lower bound does not exceed the upper bound
+	gov = newTestGasPriceGovernance(t, 25e9, 25e9, 30e9)
+	votes = newGasPriceSyncer(gov, &testGasPriceChain{magma}, &SCConfig{ParentGasPriceSyncSmoothing: 100, ParentGasPriceSyncMax: 40e9}).sync(parentMagma)
+	assert.Equal(t, map[string]uint64{"kip71.lowerboundbasefee": 40e9, "kip71.upperboundbasefee": 40e9}, votes)
+
+	// the votes rejected by the governance are not reported
+	gov = newTestGasPriceGovernance(t, 25e9, 25e9, 750e9)
+	gov.canVote = false
+	votes = newGasPriceSyncer(gov, &testGasPriceChain{magma}, config).sync(parentMagma)
+	assert.Empty(t, votes)
+}
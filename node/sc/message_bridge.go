@@ -0,0 +1,488 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/klaytn/klaytn/accounts/abi"
+	"github.com/klaytn/klaytn/accounts/abi/bind"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/contracts/bridge/message"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// handleMessageGasOverhead is the gas spent by handleMessage besides the handler call and its margin.
+const handleMessageGasOverhead = 300000
+
+var (
+	ErrNoMessageBridge         = errors.New("message bridge does not exist")
+	ErrDuplicatedMessageBridge = errors.New("message bridge is duplicated")
+	ErrInvalidMessageProof     = errors.New("invalid message proof")
+)
+
+var messageHashArgs abi.Arguments
+
+func init() {
+	addressTy, _ := abi.NewType("address", "", nil)
+	uint64Ty, _ := abi.NewType("uint64", "", nil)
+	bytesTy, _ := abi.NewType("bytes", "", nil)
+	messageHashArgs = abi.Arguments{{Type: addressTy}, {Type: uint64Ty}, {Type: addressTy}, {Type: addressTy}, {Type: bytesTy}}
+}
+
+// messageHash returns the hash committed by a message bridge for a requested message,
+// which is keccak256(abi.encode(bridge, requestNonce, from, to, data)) in MessageBridge.messageHash.
+func messageHash(bridge common.Address, requestNonce uint64, from, to common.Address, data []byte) (common.Hash, error) {
+	packed, err := messageHashArgs.Pack(bridge, requestNonce, from, to, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(packed), nil
+}
+
+// messageBridgeInfo is a message bridge contract whose requests are relayed to its counterpart.
+type messageBridgeInfo struct {
+	addr         common.Address
+	bridge       *message.MessageBridge
+	backend      Backend
+	account      *accountInfo // the operator account which sends transactions to this bridge
+	onChildChain bool
+	chainDB      database.DBManager
+
+	counterpart *messageBridgeInfo
+	sub         event.Subscription
+	closed      chan struct{}
+
+	// below fields are accessed only by the event loop of the bridge.
+	sent         map[uint64]bool // the request nonces whose messages have been sent to the counterpart
+	relayedBlock uint64          // the block number up to which the requests have been relayed
+	relayFailed  bool            // whether relaying a request has failed since the pair is registered
+}
+
+// MessageBridgePair is the RPC output of a registered pair of message bridges.
+type MessageBridgePair struct {
+	Child  common.Address `json:"child"`
+	Parent common.Address `json:"parent"`
+}
+
+// MessageBridgeManager relays arbitrary messages between message bridge contracts of the
+// child chain and the parent chain. A message requested on a bridge is handled on its
+// counterpart by the bridge operator of this node once it is proved by the receipt of the
+// request transaction and the message hash committed by the requesting bridge.
+// Registered pairs are persisted and restored after a restart, and the messages requested
+// since the last relayed block are replayed when a pair is registered or restored.
+type MessageBridgeManager struct {
+	subBridge *SubBridge
+
+	mu      sync.RWMutex
+	bridges map[common.Address]*messageBridgeInfo
+}
+
+func NewMessageBridgeManager(sb *SubBridge) *MessageBridgeManager {
+	return &MessageBridgeManager{
+		subBridge: sb,
+		bridges:   make(map[common.Address]*messageBridgeInfo),
+	}
+}
+
+// RegisterMessageBridge registers a pair of message bridges and starts relaying their messages.
+func (mbm *MessageBridgeManager) RegisterMessageBridge(cBridgeAddr, pBridgeAddr common.Address) error {
+	mbm.mu.Lock()
+	defer mbm.mu.Unlock()
+
+	if err := mbm.register(cBridgeAddr, pBridgeAddr); err != nil {
+		return err
+	}
+	mbm.writePairs()
+	return nil
+}
+
+// RestoreMessageBridges registers the pairs of message bridges persisted before a restart.
+func (mbm *MessageBridgeManager) RestoreMessageBridges() error {
+	mbm.mu.Lock()
+	defer mbm.mu.Unlock()
+
+	var failure error
+	for _, pair := range mbm.subBridge.chainDB.ReadMessageBridgePairs() {
+		if _, ok := mbm.bridges[pair[0]]; ok {
+			continue
+		}
+		if err := mbm.register(pair[0], pair[1]); err != nil {
+			logger.Error("Failed to restore a message bridge pair", "child", pair[0].String(), "parent", pair[1].String(), "err", err)
+			failure = err
+		}
+	}
+	return failure
+}
+
+// register subscribes the events of a pair of message bridges. It should be called with mbm.mu held.
+func (mbm *MessageBridgeManager) register(cBridgeAddr, pBridgeAddr common.Address) error {
+	if _, ok := mbm.bridges[cBridgeAddr]; ok {
+		return ErrDuplicatedMessageBridge
+	}
+	if _, ok := mbm.bridges[pBridgeAddr]; ok {
+		return ErrDuplicatedMessageBridge
+	}
+
+	sb := mbm.subBridge
+	cBridge, err := message.NewMessageBridge(cBridgeAddr, sb.localBackend)
+	if err != nil {
+		return err
+	}
+	pBridge, err := message.NewMessageBridge(pBridgeAddr, sb.remoteBackend)
+	if err != nil {
+		return err
+	}
+
+	child := newMessageBridgeInfo(cBridgeAddr, cBridge, sb.localBackend, sb.bridgeAccounts.cAccount, true, sb.chainDB)
+	parent := newMessageBridgeInfo(pBridgeAddr, pBridge, sb.remoteBackend, sb.bridgeAccounts.pAccount, false, sb.chainDB)
+	child.counterpart, parent.counterpart = parent, child
+
+	if err := mbm.subscribe(child); err != nil {
+		return err
+	}
+	if err := mbm.subscribe(parent); err != nil {
+		mbm.unsubscribe(child)
+		return err
+	}
+
+	mbm.bridges[cBridgeAddr] = child
+	mbm.bridges[pBridgeAddr] = parent
+	logger.Info("Registered a message bridge pair", "child", cBridgeAddr.String(), "parent", pBridgeAddr.String())
+	return nil
+}
+
+// DeregisterMessageBridge stops relaying the messages of the given pair of message bridges.
+func (mbm *MessageBridgeManager) DeregisterMessageBridge(cBridgeAddr, pBridgeAddr common.Address) error {
+	mbm.mu.Lock()
+	defer mbm.mu.Unlock()
+
+	child, ok := mbm.bridges[cBridgeAddr]
+	if !ok || !child.onChildChain || child.counterpart.addr != pBridgeAddr {
+		return ErrNoMessageBridge
+	}
+
+	mbm.unsubscribe(child)
+	mbm.unsubscribe(child.counterpart)
+	delete(mbm.bridges, cBridgeAddr)
+	delete(mbm.bridges, pBridgeAddr)
+	mbm.writePairs()
+	return nil
+}
+
+// GetMessageBridges returns all registered pairs of message bridges.
+func (mbm *MessageBridgeManager) GetMessageBridges() []MessageBridgePair {
+	mbm.mu.RLock()
+	defer mbm.mu.RUnlock()
+
+	pairs := make([]MessageBridgePair, 0, len(mbm.bridges)/2)
+	for _, bi := range mbm.bridges {
+		if bi.onChildChain {
+			pairs = append(pairs, MessageBridgePair{Child: bi.addr, Parent: bi.counterpart.addr})
+		}
+	}
+	return pairs
+}
+
+// writePairs persists the registered pairs of message bridges. It should be called with mbm.mu held.
+func (mbm *MessageBridgeManager) writePairs() {
+	pairs := make([][2]common.Address, 0, len(mbm.bridges)/2)
+	for _, bi := range mbm.bridges {
+		if bi.onChildChain {
+			pairs = append(pairs, [2]common.Address{bi.addr, bi.counterpart.addr})
+		}
+	}
+	mbm.subBridge.chainDB.WriteMessageBridgePairs(pairs)
+}
+
+// RegisterMessageHandler allows the handler contract to receive messages through the given message bridge.
+// The bridge account of this node should be the owner of the bridge.
+func (mbm *MessageBridgeManager) RegisterMessageHandler(bridgeAddr, handler common.Address) (common.Hash, error) {
+	bi, err := mbm.getBridge(bridgeAddr)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	bi.account.Lock()
+	defer bi.account.UnLock()
+	tx, err := bi.bridge.RegisterHandler(bi.account.GenerateTransactOpts(), handler)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	bi.account.IncNonce()
+
+	return tx.Hash(), nil
+}
+
+// DeregisterMessageHandler disallows the handler contract to receive messages through the given message bridge.
+func (mbm *MessageBridgeManager) DeregisterMessageHandler(bridgeAddr, handler common.Address) (common.Hash, error) {
+	bi, err := mbm.getBridge(bridgeAddr)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	bi.account.Lock()
+	defer bi.account.UnLock()
+	tx, err := bi.bridge.DeregisterHandler(bi.account.GenerateTransactOpts(), handler)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	bi.account.IncNonce()
+
+	return tx.Hash(), nil
+}
+
+func (mbm *MessageBridgeManager) getBridge(addr common.Address) (*messageBridgeInfo, error) {
+	mbm.mu.RLock()
+	defer mbm.mu.RUnlock()
+
+	bi, ok := mbm.bridges[addr]
+	if !ok {
+		return nil, ErrNoMessageBridge
+	}
+	return bi, nil
+}
+
+// subscribe watches the RequestMessage events of the bridge. The messages requested since the last
+// relayed block are replayed before the watched events. It should be called with mbm.mu held.
+func (mbm *MessageBridgeManager) subscribe(bi *messageBridgeInfo) error {
+	ch := make(chan *message.MessageBridgeRequestMessage, TokenEventChanSize)
+	sub, err := bi.bridge.WatchRequestMessage(nil, ch, nil, nil, nil)
+	if err != nil {
+		logger.Error("Failed to watch RequestMessage event", "bridge", bi.addr.String(), "err", err)
+		return err
+	}
+	bi.sub = sub
+
+	go func() {
+		defer sub.Unsubscribe()
+		if err := bi.replay(); err != nil {
+			logger.Error("Failed to replay messages", "bridge", bi.addr.String(), "err", err)
+		}
+		for {
+			select {
+			case <-bi.closed:
+				return
+			case ev := <-ch:
+				bi.relay(ev)
+			case err := <-sub.Err():
+				logger.Info("Message bridge event loop stopped by the subscription error", "bridge", bi.addr.String(), "err", err)
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// unsubscribe stops watching the events of the bridge. It should be called with mbm.mu held.
+func (mbm *MessageBridgeManager) unsubscribe(bi *messageBridgeInfo) {
+	select {
+	case <-bi.closed:
+	default:
+		close(bi.closed)
+	}
+}
+
+// Stop stops relaying messages of all message bridges.
+func (mbm *MessageBridgeManager) Stop() {
+	mbm.mu.Lock()
+	defer mbm.mu.Unlock()
+
+	for addr, bi := range mbm.bridges {
+		mbm.unsubscribe(bi)
+		delete(mbm.bridges, addr)
+	}
+}
+
+func newMessageBridgeInfo(addr common.Address, bridge *message.MessageBridge, backend Backend, account *accountInfo, onChildChain bool, chainDB database.DBManager) *messageBridgeInfo {
+	return &messageBridgeInfo{
+		addr:         addr,
+		bridge:       bridge,
+		backend:      backend,
+		account:      account,
+		onChildChain: onChildChain,
+		chainDB:      chainDB,
+		closed:       make(chan struct{}),
+		sent:         make(map[uint64]bool),
+		relayedBlock: chainDB.ReadMessageBridgeRelayedBlock(addr),
+	}
+}
+
+// replay relays the messages requested on the bridge from the last relayed block, skipping
+// the ones whose nonces are lower than the lower handle nonce of the counterpart.
+func (bi *messageBridgeInfo) replay() error {
+	lowerHandleNonce, err := bi.counterpart.bridge.LowerHandleNonce(nil)
+	if err != nil {
+		return err
+	}
+	it, err := bi.bridge.FilterRequestMessage(&bind.FilterOpts{Start: bi.relayedBlock}, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	replayed := 0
+	for it.Next() {
+		if it.Event.RequestNonce < lowerHandleNonce {
+			continue
+		}
+		bi.relay(it.Event)
+		replayed++
+	}
+	logger.Info("Replayed the messages of a message bridge", "bridge", bi.addr.String(),
+		"fromBlock", bi.relayedBlock, "lowerHandleNonce", lowerHandleNonce, "messages", replayed)
+	return it.Error()
+}
+
+// relay handles the message requested on the bridge on its counterpart. The relayed block is
+// not advanced after a failure, so that the failed message is replayed after a restart.
+func (bi *messageBridgeInfo) relay(ev *message.MessageBridgeRequestMessage) {
+	if ev.Raw.Removed || bi.sent[ev.RequestNonce] {
+		return
+	}
+	if err := bi.verifyRequestMessage(ev); err != nil {
+		logger.Error("Failed to verify a message", "bridge", bi.addr.String(),
+			"nonce", ev.RequestNonce, "txHash", ev.Raw.TxHash.String(), "err", err)
+		bi.relayFailed = true
+		return
+	}
+	if err := bi.counterpart.handleRequestMessage(ev); err != nil {
+		logger.Error("Failed to handle a message", "bridge", bi.counterpart.addr.String(),
+			"nonce", ev.RequestNonce, "txHash", ev.Raw.TxHash.String(), "err", err)
+		bi.relayFailed = true
+		return
+	}
+	bi.sent[ev.RequestNonce] = true
+
+	if !bi.relayFailed && ev.Raw.BlockNumber > bi.relayedBlock {
+		bi.relayedBlock = ev.Raw.BlockNumber
+		bi.chainDB.WriteMessageBridgeRelayedBlock(bi.addr, bi.relayedBlock)
+	}
+}
+
+// verifyRequestMessage proves that the message has been requested on the bridge. The receipt of the
+// successful request transaction should contain the event, and the message hash committed by the
+// bridge for the request nonce should match the message.
+func (bi *messageBridgeInfo) verifyRequestMessage(ev *message.MessageBridgeRequestMessage) error {
+	receipt, err := bi.backend.TransactionReceipt(context.Background(), ev.Raw.TxHash)
+	if err != nil {
+		return err
+	}
+	if receipt == nil || receipt.Status != types.ReceiptStatusSuccessful || !containsLog(receipt.Logs, &ev.Raw) {
+		return ErrInvalidMessageProof
+	}
+
+	committed, err := bi.bridge.RequestedMessageHashes(nil, ev.RequestNonce)
+	if err != nil {
+		return err
+	}
+	hash, err := messageHash(bi.addr, ev.RequestNonce, ev.From, ev.To, ev.Data)
+	if err != nil {
+		return err
+	}
+	if common.Hash(committed) != hash {
+		return ErrInvalidMessageProof
+	}
+	return nil
+}
+
+func containsLog(logs []*types.Log, target *types.Log) bool {
+	for _, l := range logs {
+		if l.Address != target.Address || !bytes.Equal(l.Data, target.Data) || len(l.Topics) != len(target.Topics) {
+			continue
+		}
+		matched := true
+		for i := range l.Topics {
+			if l.Topics[i] != target.Topics[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// handleRequestMessage sends a transaction handling the message requested on the counterpart bridge.
+// The message is skipped if it has already been handled, which is the replay protection of the bridge.
+// The gas limit of the transaction covers the handler gas limit of the bridge, since the bridge reverts
+// the closing vote which cannot afford the handler call.
+func (bi *messageBridgeInfo) handleRequestMessage(ev *message.MessageBridgeRequestMessage) error {
+	if closed, err := bi.bridge.ClosedValueTransferVotes(nil, ev.RequestNonce); err != nil {
+		return err
+	} else if closed {
+		logger.Debug("Skip the message already handled", "bridge", bi.addr.String(), "nonce", ev.RequestNonce)
+		return nil
+	}
+	if handled, err := bi.bridge.HandledRequestTx(nil, ev.Raw.TxHash); err != nil {
+		return err
+	} else if handled {
+		logger.Debug("Skip the message of the handled request tx", "bridge", bi.addr.String(), "txHash", ev.Raw.TxHash.String())
+		return nil
+	}
+	gasLimit, err := bi.handleMessageGasLimit()
+	if err != nil {
+		return err
+	}
+
+	bi.account.Lock()
+	defer bi.account.UnLock()
+
+	opts := bi.account.GenerateTransactOpts()
+	if opts.GasLimit < gasLimit {
+		opts.GasLimit = gasLimit
+	}
+	tx, err := bi.bridge.HandleMessage(opts, ev.Raw.TxHash, ev.From, ev.To, ev.Data, ev.RequestNonce, ev.Raw.BlockNumber)
+	if err != nil {
+		return err
+	}
+	bi.account.IncNonce()
+
+	logger.Trace("Bridge sent a message handle transaction", "onChildChain", bi.onChildChain,
+		"bridge", bi.addr.String(), "nonce", ev.RequestNonce, "from", ev.From.String(), "to", ev.To.String(), "txHash", tx.Hash().String())
+	return nil
+}
+
+// handleMessageGasLimit returns the gas limit of a handleMessage transaction closing the vote.
+func (bi *messageBridgeInfo) handleMessageGasLimit() (uint64, error) {
+	handlerGasLimit, err := bi.bridge.HandlerGasLimit(nil)
+	if err != nil {
+		return 0, err
+	}
+	margin, err := bi.bridge.HANDLERGASMARGIN(nil)
+	if err != nil {
+		return 0, err
+	}
+	// the same as handlerGasLimit * 64 / 63 + HANDLER_GAS_MARGIN in the bridge
+	gas := new(big.Int).Mul(handlerGasLimit, big.NewInt(64))
+	gas.Div(gas, big.NewInt(63))
+	gas.Add(gas, margin)
+	gas.Add(gas, big.NewInt(handleMessageGasOverhead))
+	if !gas.IsUint64() {
+		return 0, errors.New("too large handler gas limit")
+	}
+	return gas.Uint64(), nil
+}
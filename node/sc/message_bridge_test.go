@@ -0,0 +1,303 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/accounts/abi"
+	"github.com/klaytn/klaytn/accounts/abi/bind"
+	"github.com/klaytn/klaytn/accounts/abi/bind/backends"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/contracts/bridge/message"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockMessageBridgeCode is the bytecode of a stand-in for MessageBridge, since the bridge itself
+// is not compiled in this repository. The stand-in
+//   - stores calldata[36:68] at the slot calldata[4:36] if the selector is 0xffffffff,
+//   - emits a log with the topics of calldata[4:132] and the data of calldata[132:] if the selector is 0xfffffffe,
+//   - otherwise returns the word stored at the slot of keccak256(calldata).
+//
+// Thus a call of the bridge returns the word set for the calldata, or zero by default.
+var mockMessageBridgeCode = hexutil.MustDecode("0x606f80600b6000396000f3" +
+	"6000357c010000000000000000000000000000000000000000000000000000000090048063ffffffff14604a57" +
+	"8063fffffffe14605357366000600037366000205460005260206000f35b60243560043555005b608436038060" +
+	"84600037606435604435602435600435846000a400")
+
+var (
+	mockSetSelector = []byte{0xff, 0xff, 0xff, 0xff}
+	mockLogSelector = []byte{0xff, 0xff, 0xff, 0xfe}
+)
+
+type messageBridgeTestEnv struct {
+	sim   *backends.SimulatedBackend
+	sc    *SubBridge
+	mbm   *MessageBridgeManager
+	user  *bind.TransactOpts
+	abi   abi.ABI
+	child *bind.BoundContract
+	cAddr common.Address
+
+	parent *bind.BoundContract
+	pAddr  common.Address
+}
+
+func newMessageBridgeTestEnv(t *testing.T) *messageBridgeTestEnv {
+	bacc, err := NewBridgeAccounts(nil, t.TempDir(), database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB}), DefaultBridgeTxGasLimit, DefaultBridgeTxGasLimit)
+	require.NoError(t, err)
+	bacc.pAccount.chainID = big.NewInt(0)
+	bacc.cAccount.chainID = big.NewInt(0)
+
+	userKey, _ := crypto.GenerateKey()
+	user := bind.NewKeyedTransactor(userKey)
+
+	alloc := blockchain.GenesisAlloc{
+		user.From:             {Balance: big.NewInt(params.KLAY)},
+		bacc.pAccount.address: {Balance: big.NewInt(params.KLAY)},
+		bacc.cAccount.address: {Balance: big.NewInt(params.KLAY)},
+	}
+	sim := backends.NewSimulatedBackend(alloc)
+	t.Cleanup(func() { sim.Close() })
+
+	sc := &SubBridge{
+		chainDB:        database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB}),
+		config:         &SCConfig{},
+		peers:          newBridgePeerSet(),
+		bridgeAccounts: bacc,
+		localBackend:   sim,
+		remoteBackend:  sim,
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(message.MessageBridgeABI))
+	require.NoError(t, err)
+
+	env := &messageBridgeTestEnv{sim: sim, sc: sc, user: user, abi: parsed}
+	env.cAddr, env.child = env.deployMock(t)
+	env.pAddr, env.parent = env.deployMock(t)
+	env.newManager(t)
+	return env
+}
+
+// newManager replaces the message bridge manager as if the node is restarted.
+func (env *messageBridgeTestEnv) newManager(t *testing.T) {
+	if env.mbm != nil {
+		env.mbm.Stop()
+	}
+	env.mbm = NewMessageBridgeManager(env.sc)
+	env.sc.messageBridgeManager = env.mbm
+	t.Cleanup(env.mbm.Stop)
+}
+
+func (env *messageBridgeTestEnv) deployMock(t *testing.T) (common.Address, *bind.BoundContract) {
+	addr, _, contract, err := bind.DeployContract(env.user, env.abi, mockMessageBridgeCode, env.sim)
+	require.NoError(t, err)
+	env.sim.Commit()
+	return addr, contract
+}
+
+// setReturn makes the bridge return ret for the call of the method with the args.
+func (env *messageBridgeTestEnv) setReturn(t *testing.T, bridge *bind.BoundContract, ret common.Hash, method string, args ...interface{}) {
+	calldata, err := env.abi.Pack(method, args...)
+	require.NoError(t, err)
+
+	input := append(append(common.CopyBytes(mockSetSelector), crypto.Keccak256(calldata)...), ret.Bytes()...)
+	_, err = bridge.RawTransact(env.user, input)
+	require.NoError(t, err)
+	env.sim.Commit()
+}
+
+// requestMessage makes the bridge emit a RequestMessage event. The message hash is committed
+// for the nonce only if commit is true.
+func (env *messageBridgeTestEnv) requestMessage(t *testing.T, bridge *bind.BoundContract, bridgeAddr common.Address, nonce uint64, to common.Address, data []byte, commit bool) {
+	if commit {
+		hash, err := messageHash(bridgeAddr, nonce, env.user.From, to, data)
+		require.NoError(t, err)
+		env.setReturn(t, bridge, hash, "requestedMessageHashes", nonce)
+	}
+
+	packed, err := env.abi.Events["RequestMessage"].Inputs.NonIndexed().Pack(data)
+	require.NoError(t, err)
+
+	input := common.CopyBytes(mockLogSelector)
+	input = append(input, env.abi.Events["RequestMessage"].ID.Bytes()...)
+	input = append(input, common.BigToHash(new(big.Int).SetUint64(nonce)).Bytes()...)
+	input = append(input, common.BytesToHash(env.user.From.Bytes()).Bytes()...)
+	input = append(input, common.BytesToHash(to.Bytes()).Bytes()...)
+	input = append(input, packed...)
+	_, err = bridge.RawTransact(env.user, input)
+	require.NoError(t, err)
+	env.sim.Commit()
+}
+
+// handledMessages commits the pending transactions and returns the handleMessage transactions
+// sent to the bridge in the blocks after the given block, waiting until count of them are found.
+func (env *messageBridgeTestEnv) handledMessages(t *testing.T, bridgeAddr common.Address, after uint64, count int) []*types.Transaction {
+	var txs []*types.Transaction
+	next := after + 1
+	for deadline := time.Now().Add(3 * time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+		env.sim.Commit()
+		for ; ; next++ {
+			block, err := env.sim.BlockByNumber(nil, new(big.Int).SetUint64(next))
+			if err != nil || block == nil {
+				break
+			}
+			for _, tx := range block.Transactions() {
+				if tx.To() == nil || *tx.To() != bridgeAddr {
+					continue
+				}
+				if method, err := env.abi.MethodById(tx.Data()); err == nil && method.Name == "handleMessage" {
+					txs = append(txs, tx)
+				}
+			}
+		}
+		if len(txs) >= count {
+			break
+		}
+	}
+	return txs
+}
+
+func (env *messageBridgeTestEnv) currentBlock() uint64 {
+	return env.sim.BlockChain().CurrentBlock().NumberU64()
+}
+
+func (env *messageBridgeTestEnv) unpackHandleMessage(t *testing.T, tx *types.Transaction) (uint64, common.Address, []byte) {
+	args, err := env.abi.Methods["handleMessage"].Inputs.Unpack(tx.Data()[4:])
+	require.NoError(t, err)
+	return args[4].(uint64), args[2].(common.Address), args[3].([]byte)
+}
+
+func TestMessageBridgeManager_RelayMessage(t *testing.T) {
+	env := newMessageBridgeTestEnv(t)
+	handler := common.HexToAddress("0x1000")
+	handlerGasLimit := big.NewInt(9900000)
+	env.setReturn(t, env.parent, common.BigToHash(handlerGasLimit), "handlerGasLimit")
+	env.setReturn(t, env.parent, common.BigToHash(big.NewInt(50000)), "HANDLER_GAS_MARGIN")
+
+	require.NoError(t, env.mbm.RegisterMessageBridge(env.cAddr, env.pAddr))
+	assert.Equal(t, ErrDuplicatedMessageBridge, env.mbm.RegisterMessageBridge(env.cAddr, env.pAddr))
+	assert.Equal(t, []MessageBridgePair{{Child: env.cAddr, Parent: env.pAddr}}, env.mbm.GetMessageBridges())
+
+	start := env.currentBlock()
+	env.requestMessage(t, env.child, env.cAddr, 0, handler, []byte("hello"), true)
+
+	txs := env.handledMessages(t, env.pAddr, start, 1)
+	require.Len(t, txs, 1)
+	nonce, to, data := env.unpackHandleMessage(t, txs[0])
+	assert.Equal(t, uint64(0), nonce)
+	assert.Equal(t, handler, to)
+	assert.Equal(t, []byte("hello"), data)
+
+	// The transaction affords the handler call even if the bridge account gas limit is lower.
+	expectedGas := handlerGasLimit.Uint64()*64/63 + 50000 + handleMessageGasOverhead
+	assert.Equal(t, expectedGas, txs[0].Gas())
+	assert.True(t, expectedGas > DefaultBridgeTxGasLimit)
+
+	// The relayed block is persisted for the replay.
+	assert.NotZero(t, env.sc.chainDB.ReadMessageBridgeRelayedBlock(env.cAddr))
+}
+
+func TestMessageBridgeManager_RejectUnprovedMessage(t *testing.T) {
+	env := newMessageBridgeTestEnv(t)
+	handler := common.HexToAddress("0x1000")
+	require.NoError(t, env.mbm.RegisterMessageBridge(env.cAddr, env.pAddr))
+
+	// The event of a message which is not committed by the bridge is not relayed.
+	start := env.currentBlock()
+	env.requestMessage(t, env.child, env.cAddr, 0, handler, []byte("forged"), false)
+	assert.Empty(t, env.handledMessages(t, env.pAddr, start, 1))
+	assert.Zero(t, env.sc.chainDB.ReadMessageBridgeRelayedBlock(env.cAddr))
+
+	// Neither is the message whose data differs from the committed one.
+	hash, err := messageHash(env.cAddr, 1, env.user.From, handler, []byte("committed"))
+	require.NoError(t, err)
+	env.setReturn(t, env.child, hash, "requestedMessageHashes", uint64(1))
+	env.requestMessage(t, env.child, env.cAddr, 1, handler, []byte("tampered"), false)
+	assert.Empty(t, env.handledMessages(t, env.pAddr, start, 1))
+}
+
+func TestMessageBridgeManager_SkipHandledMessage(t *testing.T) {
+	env := newMessageBridgeTestEnv(t)
+	handler := common.HexToAddress("0x1000")
+	require.NoError(t, env.mbm.RegisterMessageBridge(env.cAddr, env.pAddr))
+
+	env.setReturn(t, env.parent, common.BigToHash(common.Big1), "closedValueTransferVotes", uint64(0))
+
+	start := env.currentBlock()
+	env.requestMessage(t, env.child, env.cAddr, 0, handler, []byte("closed"), true)
+	env.requestMessage(t, env.child, env.cAddr, 1, handler, []byte("open"), true)
+
+	txs := env.handledMessages(t, env.pAddr, start, 2)
+	require.Len(t, txs, 1)
+	nonce, _, data := env.unpackHandleMessage(t, txs[0])
+	assert.Equal(t, uint64(1), nonce)
+	assert.Equal(t, []byte("open"), data)
+}
+
+func TestMessageBridgeManager_RestoreAndReplay(t *testing.T) {
+	env := newMessageBridgeTestEnv(t)
+	handler := common.HexToAddress("0x1000")
+	require.NoError(t, env.mbm.RegisterMessageBridge(env.cAddr, env.pAddr))
+
+	start := env.currentBlock()
+	env.requestMessage(t, env.child, env.cAddr, 0, handler, []byte("first"), true)
+	require.Len(t, env.handledMessages(t, env.pAddr, start, 1), 1)
+
+	// Messages are requested while the node is down.
+	env.mbm.Stop()
+	start = env.currentBlock()
+	env.requestMessage(t, env.child, env.cAddr, 1, handler, []byte("handled by another operator"), true)
+	env.requestMessage(t, env.child, env.cAddr, 2, handler, []byte("missed"), true)
+	env.requestMessage(t, env.parent, env.pAddr, 0, handler, []byte("to the child"), true)
+	// The messages before nonce 2 have been handled on the parent bridge.
+	env.setReturn(t, env.parent, common.BigToHash(big.NewInt(2)), "lowerHandleNonce")
+
+	// The pair is restored after a restart, and the missed messages are replayed.
+	env.newManager(t)
+	require.NoError(t, env.mbm.RestoreMessageBridges())
+	assert.Equal(t, []MessageBridgePair{{Child: env.cAddr, Parent: env.pAddr}}, env.mbm.GetMessageBridges())
+
+	txs := env.handledMessages(t, env.pAddr, start, 1)
+	require.Len(t, txs, 1)
+	nonce, _, data := env.unpackHandleMessage(t, txs[0])
+	assert.Equal(t, uint64(2), nonce)
+	assert.Equal(t, []byte("missed"), data)
+
+	txs = env.handledMessages(t, env.cAddr, start, 1)
+	require.Len(t, txs, 1)
+	nonce, _, data = env.unpackHandleMessage(t, txs[0])
+	assert.Equal(t, uint64(0), nonce)
+	assert.Equal(t, []byte("to the child"), data)
+
+	// A deregistered pair is not restored.
+	require.NoError(t, env.mbm.DeregisterMessageBridge(env.cAddr, env.pAddr))
+	assert.Equal(t, ErrNoMessageBridge, env.mbm.DeregisterMessageBridge(env.cAddr, env.pAddr))
+	env.newManager(t)
+	require.NoError(t, env.mbm.RestoreMessageBridges())
+	assert.Empty(t, env.mbm.GetMessageBridges())
+}
@@ -0,0 +1,239 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// maxParentPeerSendFailures is the number of consecutive send failures after which
+	// a parent peer is considered unhealthy.
+	maxParentPeerSendFailures = 3
+
+	DefaultParentRPCHedgeSize     = 1
+	DefaultParentRPCHealthTimeout = 10 * time.Second
+)
+
+// healthCheckRequest is sent to the parent peers which have not responded for a while.
+// Its response has an id unknown to the RPC client, so it is dropped after updating the health.
+var healthCheckRequest = []byte(`{"jsonrpc":"2.0","id":"parent-health-check","method":"klay_blockNumber","params":[]}`)
+
+// stickyRPCMethods are the nonce-sensitive or stateful methods which should always be
+// served by the same parent peer. Other read methods can be hedged across multiple peers.
+var stickyRPCMethods = map[string]bool{
+	"klay_getTransactionCount": true,
+	"klay_sendRawTransaction":  true,
+	"klay_sendTransaction":     true,
+	"klay_subscribe":           true,
+	"klay_unsubscribe":         true,
+}
+
+// parentPeerHealth tracks the health of a parent peer.
+type parentPeerHealth struct {
+	sendFailures  int       // consecutive send failures
+	awaitingSince time.Time // when the oldest unanswered request was sent, zero if none
+	lastResponse  time.Time
+}
+
+func (h *parentPeerHealth) healthy(now time.Time, timeout time.Duration) bool {
+	if h.sendFailures >= maxParentPeerSendFailures {
+		return false
+	}
+	return h.awaitingSince.IsZero() || now.Sub(h.awaitingSince) < timeout
+}
+
+// parentPeerSelector chooses the parent peers (main-bridges) which RPC requests of the
+// sub-bridge are sent to. It keeps a sticky primary peer for nonce-sensitive requests and
+// fails over to another healthy peer when the primary stops responding. Read requests are
+// hedged to up to hedgeSize healthy peers and the first response is taken by the RPC client.
+type parentPeerSelector struct {
+	mu      sync.Mutex
+	peers   *bridgePeerSet
+	health  map[string]*parentPeerHealth
+	primary string
+	// switched is set when the primary has been changed, so that subscriptions can be reset.
+	switched bool
+
+	hedgeSize int
+	timeout   time.Duration
+}
+
+func newParentPeerSelector(peers *bridgePeerSet, hedgeSize int, timeout time.Duration) *parentPeerSelector {
+	if hedgeSize < 1 {
+		hedgeSize = DefaultParentRPCHedgeSize
+	}
+	if timeout <= 0 {
+		timeout = DefaultParentRPCHealthTimeout
+	}
+	return &parentPeerSelector{
+		peers:     peers,
+		health:    make(map[string]*parentPeerHealth),
+		hedgeSize: hedgeSize,
+		timeout:   timeout,
+	}
+}
+
+// getHealth returns the health of the given peer. It should be called with s.mu held.
+func (s *parentPeerSelector) getHealth(id string) *parentPeerHealth {
+	h, ok := s.health[id]
+	if !ok {
+		h = &parentPeerHealth{}
+		s.health[id] = h
+	}
+	return h
+}
+
+// selectPeers returns the peers which the given RPC data should be sent to.
+// The first peer is always the primary peer.
+func (s *parentPeerSelector) selectPeers(data []byte) []BridgePeer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	peers := s.peers.Peers()
+	ids := make([]string, 0, len(peers))
+	for id := range peers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	// drop the health of disconnected peers
+	for id := range s.health {
+		if _, ok := peers[id]; !ok {
+			delete(s.health, id)
+		}
+	}
+
+	var healthy []string
+	for _, id := range ids {
+		if s.getHealth(id).healthy(now, s.timeout) {
+			healthy = append(healthy, id)
+		}
+	}
+	if len(healthy) == 0 {
+		// Every peer is unhealthy. Try all of them rather than giving up.
+		healthy = ids
+	}
+	if len(healthy) == 0 {
+		return nil
+	}
+
+	if !containsString(healthy, s.primary) {
+		if s.primary != "" {
+			logger.Warn("Switch the primary parent peer", "from", s.primary, "to", healthy[0])
+			s.switched = true
+		}
+		s.primary = healthy[0]
+	}
+
+	selected := []BridgePeer{peers[s.primary]}
+	if s.hedgeSize <= 1 || !isHedgeableRPC(data) {
+		return selected
+	}
+	for _, id := range healthy {
+		if len(selected) >= s.hedgeSize {
+			break
+		}
+		if id != s.primary {
+			selected = append(selected, peers[id])
+		}
+	}
+	return selected
+}
+
+// markSent records that a request has been sent to the peer.
+func (s *parentPeerSelector) markSent(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := s.getHealth(id)
+	h.sendFailures = 0
+	if h.awaitingSince.IsZero() {
+		h.awaitingSince = time.Now()
+	}
+}
+
+// markFailure records that sending a request to the peer has failed.
+func (s *parentPeerSelector) markFailure(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.getHealth(id).sendFailures++
+}
+
+// markResponse records that a response has been received from the peer.
+func (s *parentPeerSelector) markResponse(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := s.getHealth(id)
+	h.awaitingSince = time.Time{}
+	h.lastResponse = time.Now()
+}
+
+// idlePeers returns the peers which have not responded during the health timeout
+// and are not waiting for any response, so they need a health check request.
+func (s *parentPeerSelector) idlePeers() []BridgePeer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var idle []BridgePeer
+	for id, p := range s.peers.Peers() {
+		h := s.getHealth(id)
+		if h.awaitingSince.IsZero() && now.Sub(h.lastResponse) >= s.timeout {
+			idle = append(idle, p)
+		}
+	}
+	return idle
+}
+
+// takeSwitched reports whether the primary peer has been changed since the last call.
+func (s *parentPeerSelector) takeSwitched() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switched := s.switched
+	s.switched = false
+	return switched
+}
+
+// isHedgeableRPC reports whether the given RPC data is a complete single request
+// which does not need to be served by the primary peer.
+// A partial message split by the RPC pipe buffer is not hedgeable.
+func isHedgeableRPC(data []byte) bool {
+	var msg struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil || msg.Method == "" {
+		return false
+	}
+	return !stickyRPCMethods[msg.Method]
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestParentPeerSet(t *testing.T, ctrl *gomock.Controller, ids ...string) *bridgePeerSet {
+	peers := newBridgePeerSet()
+	for _, id := range ids {
+		p := NewMockBridgePeer(ctrl)
+		p.EXPECT().GetID().Return(id).AnyTimes()
+		if err := peers.Register(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return peers
+}
+
+func selectedIDs(peers []BridgePeer) []string {
+	ids := make([]string, len(peers))
+	for i, p := range peers {
+		ids[i] = p.GetID()
+	}
+	return ids
+}
+
+func TestParentPeerSelector_Hedging(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	selector := newParentPeerSelector(newTestParentPeerSet(t, mockCtrl, "a", "b", "c"), 2, time.Minute)
+
+	read := []byte(`{"jsonrpc":"2.0","id":1,"method":"klay_getBalance","params":[]}`)
+	nonce := []byte(`{"jsonrpc":"2.0","id":2,"method":"klay_getTransactionCount","params":[]}`)
+	partial := []byte(`{"jsonrpc":"2.0","id":3,"method":"klay_getBal`)
+
+	assert.Equal(t, []string{"a", "b"}, selectedIDs(selector.selectPeers(read)))
+	assert.Equal(t, []string{"a"}, selectedIDs(selector.selectPeers(nonce)))
+	assert.Equal(t, []string{"a"}, selectedIDs(selector.selectPeers(partial)))
+}
+
+func TestParentPeerSelector_Failover(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	selector := newParentPeerSelector(newTestParentPeerSet(t, mockCtrl, "a", "b"), 1, time.Minute)
+	data := []byte(`{"jsonrpc":"2.0","id":1,"method":"klay_getTransactionCount","params":[]}`)
+
+	assert.Equal(t, []string{"a"}, selectedIDs(selector.selectPeers(data)))
+	assert.False(t, selector.takeSwitched())
+
+	// the primary peer is kept until it becomes unhealthy
+	for i := 0; i < maxParentPeerSendFailures-1; i++ {
+		selector.markFailure("a")
+	}
+	assert.Equal(t, []string{"a"}, selectedIDs(selector.selectPeers(data)))
+
+	selector.markFailure("a")
+	assert.Equal(t, []string{"b"}, selectedIDs(selector.selectPeers(data)))
+	assert.True(t, selector.takeSwitched())
+	assert.False(t, selector.takeSwitched())
+
+	// the new primary is sticky even if the previous one becomes healthy again
+	selector.markSent("a")
+	selector.markResponse("a")
+	assert.Equal(t, []string{"b"}, selectedIDs(selector.selectPeers(data)))
+
+	// a peer not responding during the timeout is failed over
+	selector.health["b"].awaitingSince = time.Now().Add(-2 * time.Minute)
+	assert.Equal(t, []string{"a"}, selectedIDs(selector.selectPeers(data)))
+}
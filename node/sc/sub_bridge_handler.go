@@ -199,6 +199,9 @@ func (sbh *SubBridgeHandler) setRemoteChainValues(pcInfo parentChainInfo) {
 	} else {
 		logger.Info("Updated parent chain's gas price", "gasPrice", sbh.subbridge.bridgeAccounts.GetParentGasPrice())
 	}
+	if sbh.subbridge.gasPriceSyncer != nil {
+		sbh.subbridge.gasPriceSyncer.sync(pcInfo)
+	}
 }
 
 // GetParentOperatorAddr returns a pointer of a hex address of an account used for parent chain.
@@ -236,6 +239,7 @@ func (sbh *SubBridgeHandler) HandleMainMsg(p BridgePeer, msg p2p.Msg) error {
 			logger.Error("failed to decode the p2p ServiceChainResponse message", "err", err)
 			return nil
 		}
+		sbh.subbridge.parentPeers.markResponse(p.GetID())
 		logger.Trace("send rpc response to the rpc client")
 		_, err = sbh.subbridge.rpcConn.Write(data)
 		if err != nil {
@@ -363,6 +367,11 @@ func (sbh *SubBridgeHandler) LocalChainHeadEvent(block *types.Block) {
 		sbh.broadcastServiceChainTx()
 		sbh.broadcastServiceChainReceiptRequest()
 
+		// request the parent chain's gas price periodically to follow it
+		if sbh.subbridge.gasPriceSyncer != nil && block.NumberU64()%sbh.gasPriceSyncInterval() == 0 {
+			sbh.SyncNonceAndGasPrice()
+		}
+
 		sbh.skipSyncBlockCount = 0
 	} else {
 		sbh.txCountStartingBlockNumber = 0
@@ -376,6 +385,14 @@ func (sbh *SubBridgeHandler) LocalChainHeadEvent(block *types.Block) {
 	}
 }
 
+// gasPriceSyncInterval returns the interval in blocks to request the parent chain's gas price.
+func (sbh *SubBridgeHandler) gasPriceSyncInterval() uint64 {
+	if interval := sbh.subbridge.config.ParentGasPriceSyncInterval; interval > 0 {
+		return interval
+	}
+	return DefaultParentGasPriceSyncInterval
+}
+
 // handleParentChainInvalidTxResponseMsg receives unexecuted txs which were not executed by some of the reasons (e.g., lower gas price)
 // and removes them from bridgeTxPool to prevent resending **as it is without necessary modification**
 func (sbh *SubBridgeHandler) handleParentChainInvalidTxResponseMsg(msg p2p.Msg) error {
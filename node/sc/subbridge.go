@@ -40,6 +40,7 @@ import (
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/governance"
 	"github.com/klaytn/klaytn/networks/p2p"
 	"github.com/klaytn/klaytn/networks/p2p/discover"
 	"github.com/klaytn/klaytn/networks/rpc"
@@ -74,6 +75,7 @@ type Backend interface {
 	bind.ContractBackend
 	CurrentBlockNumber(context.Context) (uint64, error)
 	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 }
 
 // NodeInfo represents a short summary of the ServiceChain sub-protocol metadata
@@ -154,6 +156,9 @@ type SubBridge struct {
 	remoteBackend Backend
 	bridgeManager *BridgeManager
 
+	// messageBridgeManager relays arbitrary messages between message bridges
+	messageBridgeManager *MessageBridgeManager
+
 	chanReqVTev        chan RequestValueTransferEvent
 	chanReqVTencodedEv chan RequestValueTransferEncodedEvent
 	reqVTevSub         event.Subscription
@@ -163,13 +168,17 @@ type SubBridge struct {
 
 	bridgeAccounts *BridgeAccounts
 
+	// gasPriceSyncer votes the gas prices following the parent chain, nil if disabled
+	gasPriceSyncer *gasPriceSyncer
+
 	bootFail bool
 
 	// service on/off
 	onAnchoringTx bool
 
-	rpcConn   net.Conn
-	rpcSendCh chan []byte
+	rpcConn     net.Conn
+	rpcSendCh   chan []byte
+	parentPeers *parentPeerSelector
 
 	// KAS Anchor
 	kasAnchor *kas.Anchor
@@ -204,6 +213,11 @@ func NewSubBridge(ctx *node.ServiceContext, config *SCConfig) (*SubBridge, error
 		bootFail:           false,
 		rpcSendCh:          make(chan []byte),
 	}
+	// Every configured main-bridge can be connected for the failover of the parent chain RPC.
+	if n := len(config.MainBridges()); n > sb.maxPeers {
+		sb.maxPeers = n
+	}
+	sb.parentPeers = newParentPeerSelector(sb.peers, config.ParentRPCHedgeSize, config.ParentRPCHealthTimeout)
 	// TODO-Klaytn change static config to user define config
 	bridgetxConfig := bridgepool.BridgeTxPoolConfig{
 		ParentChainID: new(big.Int).SetUint64(config.ParentChainID),
@@ -258,19 +272,48 @@ func (sb *SubBridge) SetRPCConn(conn net.Conn) {
 	}()
 }
 
+// SendRPCData sends the RPC data to the parent peers chosen by the parent peer selector.
+// If every chosen peer fails, it retries with newly chosen peers until all peers are tried.
 func (sb *SubBridge) SendRPCData(data []byte) error {
-	peers := sb.BridgePeerSet().peers
-	logger.Trace("send rpc message from the subbridge", "len", len(data), "peers", len(peers))
-	for _, peer := range peers {
-		err := peer.SendRequestRPC(data)
-		if err != nil {
-			logger.Error("SendRPCData Error", "err", err)
+	logger.Trace("send rpc message from the subbridge", "len", len(data))
+	var err error
+	for i := 0; i < sb.peers.Len(); i++ {
+		peers := sb.parentPeers.selectPeers(data)
+		if len(peers) == 0 {
+			break
 		}
-		return err
+		sent := false
+		for _, peer := range peers {
+			if err = peer.SendRequestRPC(data); err != nil {
+				logger.Error("SendRPCData Error", "peer", peer.GetID(), "err", err)
+				sb.parentPeers.markFailure(peer.GetID())
+				continue
+			}
+			sb.parentPeers.markSent(peer.GetID())
+			sent = true
+		}
+		if sent {
+			logger.Trace("send rpc message from the subbridge, done", "peers", len(peers))
+			return nil
+		}
+	}
+	if err == nil {
+		err = NoParentPeerErr
 	}
-	logger.Trace("send rpc message from the subbridge, done")
+	return err
+}
 
-	return nil
+// checkParentPeersHealth sends a health check request to the parent peers which have been idle.
+// A peer which does not respond to it is excluded by the parent peer selector.
+func (sb *SubBridge) checkParentPeersHealth() {
+	for _, peer := range sb.parentPeers.idlePeers() {
+		if err := peer.SendRequestRPC(healthCheckRequest); err != nil {
+			logger.Debug("failed to send a health check request", "peer", peer.GetID(), "err", err)
+			sb.parentPeers.markFailure(peer.GetID())
+			continue
+		}
+		sb.parentPeers.markSent(peer.GetID())
+	}
 }
 
 // implement PeerSetManager
@@ -326,6 +369,7 @@ func (sb *SubBridge) Components() []interface{} {
 }
 
 func (sb *SubBridge) SetComponents(components []interface{}) {
+	var gov governance.Engine
 	for _, component := range components {
 		switch v := component.(type) {
 		case *blockchain.BlockChain:
@@ -353,6 +397,16 @@ func (sb *SubBridge) SetComponents(components []interface{}) {
 			// sb.txSub = sb.txPool.SubscribeNewTxsEvent(sb.txCh)
 		// TODO-Klaytn if need pending block, should use miner
 		case *work.Miner:
+		case governance.Engine:
+			gov = v
+		}
+	}
+
+	if sb.config.ParentGasPriceSync {
+		if gov != nil && sb.blockchain != nil {
+			sb.gasPriceSyncer = newGasPriceSyncer(gov, sb.blockchain, sb.config)
+		} else {
+			logger.Warn("Parent chain gas price synchronization is disabled since the governance is not available")
 		}
 	}
 
@@ -378,6 +432,7 @@ func (sb *SubBridge) SetComponents(components []interface{}) {
 	sb.reqVTevSub = sb.bridgeManager.SubscribeReqVTev(sb.chanReqVTev)
 	sb.reqVTencodedEvSub = sb.bridgeManager.SubscribeReqVTencodedEv(sb.chanReqVTencodedEv)
 	sb.handleVTevSub = sb.bridgeManager.SubscribeHandleVTev(sb.chanHandleVTev)
+	sb.messageBridgeManager = NewMessageBridgeManager(sb)
 
 	sb.pmwg.Add(1)
 	go sb.restoreBridgeLoop()
@@ -573,6 +628,11 @@ func (sb *SubBridge) resetBridgeLoop() {
 				sb.handler.setParentOperatorNonceSynced(false)
 			}
 		case <-ticker.C:
+			sb.checkParentPeersHealth()
+			if sb.parentPeers.takeSwitched() {
+				// subscriptions were made through the previous primary parent peer.
+				needResetSubscription = true
+			}
 			if needResetSubscription && peerCount > 0 {
 				err := sb.bridgeManager.ResetAllSubscribedEvents()
 				if err == nil {
@@ -598,6 +658,9 @@ func (sb *SubBridge) restoreBridgeLoop() {
 				logger.Debug("failed to sb.bridgeManager.RestoreBridges()", "err", err)
 				continue
 			}
+			if err := sb.messageBridgeManager.RestoreMessageBridges(); err != nil {
+				logger.Error("failed to restore message bridges", "err", err)
+			}
 			return
 		}
 	}
@@ -770,6 +833,9 @@ func (sb *SubBridge) Stop() error {
 	sb.chainDB.Close()
 
 	sb.bridgeManager.Stop()
+	if sb.messageBridgeManager != nil {
+		sb.messageBridgeManager.Stop()
+	}
 	sb.bridgeTxPool.Stop()
 	sb.bridgeServer.Stop()
 
@@ -29,6 +29,7 @@ import (
 	"github.com/klaytn/klaytn/event"
 	"github.com/klaytn/klaytn/networks/p2p"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/storage/database"
 )
 
@@ -76,6 +77,14 @@ func (ctx *ServiceContext) NodeKey() *ecdsa.PrivateKey {
 	return ctx.config.NodeKey()
 }
 
+// NodeVersion returns the version of the node with the commit if known.
+func (ctx *ServiceContext) NodeVersion() string {
+	if ctx.config.Version != "" {
+		return ctx.config.Version
+	}
+	return params.Version
+}
+
 func (ctx *ServiceContext) NodeType() common.ConnType {
 	return ctx.config.P2P.ConnectionType
 }
@@ -0,0 +1,79 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package subproxy
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/filters"
+)
+
+// PublicSubProxyAPI provides the subscriptions relayed from the upstream node.
+type PublicSubProxyAPI struct {
+	p *Proxy
+}
+
+func NewPublicSubProxyAPI(p *Proxy) *PublicSubProxyAPI {
+	return &PublicSubProxyAPI{p: p}
+}
+
+// NewHeads sends a notification each time a new head is relayed from the upstream node.
+func (api *PublicSubProxyAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return api.p.subscribe(ctx, &subscriber{kind: headsSubscription})
+}
+
+// Logs sends a notification for each log relayed from the upstream node that matches the given filter criteria.
+func (api *PublicSubProxyAPI) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error) {
+	return api.p.subscribe(ctx, &subscriber{kind: logsSubscription, crit: crit})
+}
+
+// Rewards sends a notification with the block rewards of each new head relayed from the upstream node.
+// If addresses are given, only the block rewards paid to any of them are notified.
+func (api *PublicSubProxyAPI) Rewards(ctx context.Context, addresses *[]common.Address) (*rpc.Subscription, error) {
+	s := &subscriber{kind: rewardsSubscription}
+	if addresses != nil {
+		s.addrs = *addresses
+	}
+	return api.p.subscribe(ctx, s)
+}
+
+type Status struct {
+	Upstream      string `json:"upstream"`
+	Connected     bool   `json:"connected"`
+	Heads         int    `json:"heads"`   // number of the newHeads subscriptions
+	Logs          int    `json:"logs"`    // number of the logs subscriptions
+	Rewards       int    `json:"rewards"` // number of the rewards subscriptions
+	MaxClients    int    `json:"maxClients"`
+	DroppedEvents uint64 `json:"droppedEvents"` // notifications dropped for the slow subscriptions
+}
+
+// Status returns the upstream connection and the downstream subscriptions of the proxy.
+func (api *PublicSubProxyAPI) Status() *Status {
+	p := api.p
+	return &Status{
+		Upstream:      p.config.Upstream,
+		Connected:     atomic.LoadInt32(&p.connected) == 1,
+		Heads:         p.hub.count(headsSubscription),
+		Logs:          p.hub.count(logsSubscription),
+		Rewards:       p.hub.count(rewardsSubscription),
+		MaxClients:    p.config.MaxClients,
+		DroppedEvents: atomic.LoadUint64(&p.hub.dropped),
+	}
+}
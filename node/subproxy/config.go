@@ -0,0 +1,45 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package subproxy
+
+import "time"
+
+const (
+	DefaultMaxClients     = 5000
+	DefaultClientBuffer   = 256
+	DefaultReconnectDelay = 5 * time.Second
+)
+
+type Config struct {
+	Enabled bool
+
+	// The WebSocket or IPC endpoint of the full node whose subscriptions are fanned out
+	Upstream string
+
+	MaxClients     int           // Maximum number of downstream subscriptions
+	ClientBuffer   int           // Notifications buffered per downstream subscription, the excess is dropped
+	ReconnectDelay time.Duration // Delay before reconnecting to the upstream node
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:        false,
+		MaxClients:     DefaultMaxClients,
+		ClientBuffer:   DefaultClientBuffer,
+		ReconnectDelay: DefaultReconnectDelay,
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package subproxy implements an optional service with which an EN acts as a subscription
+fan-out proxy. It keeps a single subscription to the new heads and the logs of an upstream
+full node, fetches the block rewards once per new head, and relays them to the downstream
+clients subscribed under the subproxy namespace, evaluating the filter of each client
+locally. Thus the upstream node evaluates one filter regardless of the number of clients.
+
+A slow client does not block the others: the notifications exceeding its buffer are
+dropped and counted.
+
+Source Files
+
+  - api.go    : the subscription APIs served under the subproxy namespace
+  - config.go : the subscription proxy configurations
+  - hub.go    : the downstream subscribers and the fan-out of the notifications
+  - proxy.go  : implements Proxy which maintains the upstream subscriptions
+*/
+package subproxy
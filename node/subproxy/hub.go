@@ -0,0 +1,155 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package subproxy
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/node/cn/filters"
+	"github.com/rcrowley/go-metrics"
+)
+
+var errTooManyClients = errors.New("too many subscriptions")
+
+var (
+	subscriptionsGauge = metrics.NewRegisteredGauge("subproxy/subscriptions", nil)
+	droppedCounter     = metrics.NewRegisteredCounter("subproxy/dropped", nil)
+)
+
+type subscriptionKind int
+
+const (
+	headsSubscription subscriptionKind = iota
+	logsSubscription
+	rewardsSubscription
+	numSubscriptionKinds
+)
+
+// subscriber is a downstream subscription with its filter.
+type subscriber struct {
+	kind    subscriptionKind
+	crit    filters.FilterCriteria // filter of the logs subscription
+	addrs   []common.Address       // recipients of the rewards subscription, any recipient if empty
+	ch      chan interface{}
+	dropped uint64 // notifications dropped since the buffer is full, accessed atomically
+}
+
+// matchLog returns whether the log passes the filter of the logs subscription.
+func (s *subscriber) matchLog(log *types.Log) bool {
+	return len(filters.FilterLogs([]*types.Log{log}, s.crit)) > 0
+}
+
+// matchReward returns whether the block rewards are paid to any recipient of the rewards subscription.
+func (s *subscriber) matchReward(ev *RewardEvent) bool {
+	if len(s.addrs) == 0 {
+		return true
+	}
+	for _, addr := range s.addrs {
+		if _, ok := ev.Reward.Rewards[addr]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hub holds the downstream subscribers and fans out the upstream notifications to them.
+type hub struct {
+	maxClients int
+
+	mu      sync.RWMutex
+	subs    map[*subscriber]struct{}
+	counts  [numSubscriptionKinds]int
+	dropped uint64 // total notifications dropped, accessed atomically
+}
+
+func newHub(maxClients int) *hub {
+	return &hub{
+		maxClients: maxClients,
+		subs:       make(map[*subscriber]struct{}),
+	}
+}
+
+func (h *hub) subscribe(s *subscriber) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.maxClients > 0 && len(h.subs) >= h.maxClients {
+		return errTooManyClients
+	}
+	h.subs[s] = struct{}{}
+	h.counts[s.kind]++
+	subscriptionsGauge.Update(int64(len(h.subs)))
+	return nil
+}
+
+func (h *hub) unsubscribe(s *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subs[s]; !ok {
+		return
+	}
+	delete(h.subs, s)
+	h.counts[s.kind]--
+	subscriptionsGauge.Update(int64(len(h.subs)))
+}
+
+// count returns the number of the subscribers of the kind.
+func (h *hub) count(kind subscriptionKind) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.counts[kind]
+}
+
+// publish sends the notification to the subscribers of the kind for which match returns true.
+// It never blocks: the notification is dropped for a subscriber whose buffer is full.
+func (h *hub) publish(kind subscriptionKind, ev interface{}, match func(s *subscriber) bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.counts[kind] == 0 {
+		return
+	}
+	for s := range h.subs {
+		if s.kind != kind || (match != nil && !match(s)) {
+			continue
+		}
+		select {
+		case s.ch <- ev:
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+			atomic.AddUint64(&h.dropped, 1)
+			droppedCounter.Inc(1)
+		}
+	}
+}
+
+func (h *hub) publishHead(head interface{}) {
+	h.publish(headsSubscription, head, nil)
+}
+
+func (h *hub) publishLog(log *types.Log) {
+	h.publish(logsSubscription, log, func(s *subscriber) bool { return s.matchLog(log) })
+}
+
+func (h *hub) publishReward(ev *RewardEvent) {
+	h.publish(rewardsSubscription, ev, func(s *subscriber) bool { return s.matchReward(ev) })
+}
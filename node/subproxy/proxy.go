@@ -0,0 +1,263 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package subproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/rcrowley/go-metrics"
+)
+
+var logger = log.NewModuleLogger(log.NodeSubProxy)
+
+const (
+	upstreamBuffer = 1024            // notifications buffered from the upstream subscriptions
+	rewardsTimeout = 5 * time.Second // timeout of fetching the block rewards from the upstream node
+)
+
+var errNoUpstream = errors.New("the upstream endpoint of the subscription proxy is not set")
+
+var (
+	upstreamHeadsCounter   = metrics.NewRegisteredCounter("subproxy/upstream/heads", nil)
+	upstreamLogsCounter    = metrics.NewRegisteredCounter("subproxy/upstream/logs", nil)
+	upstreamRewardsCounter = metrics.NewRegisteredCounter("subproxy/upstream/rewards", nil)
+	reconnectCounter       = metrics.NewRegisteredCounter("subproxy/upstream/reconnects", nil)
+)
+
+// upstream is the RPC client of the upstream node.
+type upstream interface {
+	Subscribe(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (*rpc.ClientSubscription, error)
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+	Close()
+}
+
+// RewardEvent is the notification of the rewards subscription.
+type RewardEvent struct {
+	BlockNumber *hexutil.Big       `json:"blockNumber"`
+	BlockHash   common.Hash        `json:"blockHash"`
+	Reward      *reward.RewardSpec `json:"reward"`
+}
+
+// Proxy relays the new heads, the logs and the block rewards of the upstream node to the downstream subscribers.
+type Proxy struct {
+	config *Config
+	hub    *hub
+	dial   func(ctx context.Context, url string) (upstream, error)
+
+	connected int32 // 1 if the upstream subscriptions are alive, accessed atomically
+	quit      chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewProxy(ctx *node.ServiceContext, cfg *Config) (*Proxy, error) {
+	if cfg.Upstream == "" {
+		return nil, errNoUpstream
+	}
+	return newProxy(cfg, func(ctx context.Context, url string) (upstream, error) {
+		return rpc.DialContext(ctx, url)
+	}), nil
+}
+
+func newProxy(cfg *Config, dial func(ctx context.Context, url string) (upstream, error)) *Proxy {
+	return &Proxy{
+		config: cfg,
+		hub:    newHub(cfg.MaxClients),
+		dial:   dial,
+		quit:   make(chan struct{}),
+	}
+}
+
+func (p *Proxy) Protocols() []p2p.Protocol {
+	return []p2p.Protocol{}
+}
+
+func (p *Proxy) APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "subproxy",
+			Version:   "1.0",
+			Service:   NewPublicSubProxyAPI(p),
+			Public:    true,
+		},
+	}
+}
+
+func (p *Proxy) Start(server p2p.Server) error {
+	p.wg.Add(1)
+	go p.loop()
+	logger.Info("Subscription proxy is started", "upstream", p.config.Upstream, "maxClients", p.config.MaxClients,
+		"clientBuffer", p.config.ClientBuffer)
+	return nil
+}
+
+func (p *Proxy) Stop() error {
+	close(p.quit)
+	p.wg.Wait()
+	logger.Info("Subscription proxy is stopped")
+	return nil
+}
+
+func (p *Proxy) Components() []interface{} {
+	return nil
+}
+
+func (p *Proxy) SetComponents(components []interface{}) {}
+
+// loop relays the upstream subscriptions, reconnecting to the upstream node when they are lost.
+func (p *Proxy) loop() {
+	defer p.wg.Done()
+
+	for {
+		err := p.relay()
+		select {
+		case <-p.quit:
+			return
+		default:
+		}
+		reconnectCounter.Inc(1)
+		logger.Warn("Lost the upstream subscriptions, reconnecting", "upstream", p.config.Upstream,
+			"delay", p.config.ReconnectDelay, "err", err)
+		select {
+		case <-p.quit:
+			return
+		case <-time.After(p.config.ReconnectDelay):
+		}
+	}
+}
+
+// relay subscribes to the new heads and the logs of the upstream node and publishes
+// them to the hub until the subscriptions fail or the proxy is stopped.
+func (p *Proxy) relay() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := p.dial(ctx, p.config.Upstream)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	heads := make(chan json.RawMessage, upstreamBuffer)
+	headsSub, err := client.Subscribe(ctx, "klay", heads, "newHeads")
+	if err != nil {
+		return err
+	}
+	defer headsSub.Unsubscribe()
+
+	logs := make(chan *types.Log, upstreamBuffer)
+	logsSub, err := client.Subscribe(ctx, "klay", logs, "logs", map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+	defer logsSub.Unsubscribe()
+
+	atomic.StoreInt32(&p.connected, 1)
+	defer atomic.StoreInt32(&p.connected, 0)
+	logger.Info("Subscribed to the upstream node", "upstream", p.config.Upstream)
+
+	for {
+		select {
+		case <-p.quit:
+			return nil
+		case err := <-headsSub.Err():
+			return err
+		case err := <-logsSub.Err():
+			return err
+		case head := <-heads:
+			upstreamHeadsCounter.Inc(1)
+			p.hub.publishHead(head)
+			if p.hub.count(rewardsSubscription) > 0 {
+				p.relayRewards(ctx, client, head)
+			}
+		case l := <-logs:
+			upstreamLogsCounter.Inc(1)
+			p.hub.publishLog(l)
+		}
+	}
+}
+
+// relayRewards fetches the block rewards of the new head from the upstream node once
+// and publishes them to the rewards subscribers.
+func (p *Proxy) relayRewards(ctx context.Context, client upstream, head json.RawMessage) {
+	var header struct {
+		Number *hexutil.Big `json:"number"`
+		Hash   common.Hash  `json:"hash"`
+	}
+	if err := json.Unmarshal(head, &header); err != nil || header.Number == nil {
+		logger.Debug("Failed to decode the upstream head", "err", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rewardsTimeout)
+	defer cancel()
+
+	spec := new(reward.RewardSpec)
+	if err := client.CallContext(ctx, spec, "klay_getRewards", header.Number); err != nil {
+		logger.Debug("Failed to fetch the upstream block rewards", "number", (*hexutil.Big)(header.Number), "err", err)
+		return
+	}
+	upstreamRewardsCounter.Inc(1)
+	p.hub.publishReward(&RewardEvent{BlockNumber: header.Number, BlockHash: header.Hash, Reward: spec})
+}
+
+// subscribe creates an RPC subscription which notifies the events published to s.
+func (p *Proxy) subscribe(ctx context.Context, s *subscriber) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	s.ch = make(chan interface{}, p.config.ClientBuffer)
+	if err := p.hub.subscribe(s); err != nil {
+		return nil, err
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer func() {
+			p.hub.unsubscribe(s)
+			if dropped := atomic.LoadUint64(&s.dropped); dropped > 0 {
+				logger.Debug("Closed a subscription with dropped notifications", "id", rpcSub.ID, "dropped", dropped)
+			}
+		}()
+		for {
+			select {
+			case ev := <-s.ch:
+				notifier.Notify(rpcSub.ID, ev)
+			case <-rpcSub.Err(): // client send an unsubscribe request
+				return
+			case <-notifier.Closed(): // connection dropped
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package subproxy
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/filters"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testHead struct {
+	Number *hexutil.Big `json:"number"`
+	Hash   common.Hash  `json:"hash"`
+}
+
+// testUpstreamAPI serves the klay subscriptions of the upstream node.
+type testUpstreamAPI struct {
+	headFeed     event.Feed
+	logFeed      event.Feed
+	rewardsCalls int32
+}
+
+func (api *testUpstreamAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		heads := make(chan *testHead)
+		sub := api.headFeed.Subscribe(heads)
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case h := <-heads:
+				notifier.Notify(rpcSub.ID, h)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+func (api *testUpstreamAPI) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		logs := make(chan *types.Log)
+		sub := api.logFeed.Subscribe(logs)
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case l := <-logs:
+				notifier.Notify(rpcSub.ID, l)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+func (api *testUpstreamAPI) GetRewards(num *rpc.BlockNumber) (*reward.RewardSpec, error) {
+	atomic.AddInt32(&api.rewardsCalls, 1)
+	spec := reward.NewRewardSpec()
+	spec.Minted = big.NewInt(num.Int64())
+	spec.Rewards[common.HexToAddress("0x100")] = big.NewInt(num.Int64())
+	return spec, nil
+}
+
+// newTestProxy returns a proxy relaying the subscriptions of the upstream API and a client of its downstream API.
+func newTestProxy(t *testing.T, api *testUpstreamAPI, cfg *Config) (*Proxy, *rpc.Client) {
+	upstreamServer := rpc.NewServer()
+	require.NoError(t, upstreamServer.RegisterName("klay", api))
+	t.Cleanup(upstreamServer.Stop)
+
+	p := newProxy(cfg, func(ctx context.Context, url string) (upstream, error) {
+		return rpc.DialInProc(upstreamServer), nil
+	})
+	require.NoError(t, p.Start(nil))
+	t.Cleanup(func() { p.Stop() })
+
+	downstreamServer := rpc.NewServer()
+	for _, api := range p.APIs() {
+		require.NoError(t, downstreamServer.RegisterName(api.Namespace, api.Service))
+	}
+	t.Cleanup(downstreamServer.Stop)
+	client := rpc.DialInProc(downstreamServer)
+	t.Cleanup(client.Close)
+
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&p.connected) == 1 }, 5*time.Second, 10*time.Millisecond)
+	return p, client
+}
+
+// send sends the event to the upstream subscriptions, retrying until the subscriptions are made.
+func send(t *testing.T, feed *event.Feed, ev interface{}) {
+	require.Eventually(t, func() bool { return feed.Send(ev) > 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestProxy_Relay(t *testing.T) {
+	var (
+		api       = &testUpstreamAPI{}
+		p, client = newTestProxy(t, api, DefaultConfig())
+		ctx       = context.Background()
+		addr1     = common.HexToAddress("0x1")
+		addr2     = common.HexToAddress("0x2")
+	)
+
+	heads := make(chan testHead, 10)
+	headsSub, err := client.Subscribe(ctx, "subproxy", heads, "newHeads")
+	require.NoError(t, err)
+	defer headsSub.Unsubscribe()
+
+	logs := make(chan types.Log, 10)
+	logsSub, err := client.Subscribe(ctx, "subproxy", logs, "logs", map[string]interface{}{"address": addr2})
+	require.NoError(t, err)
+	defer logsSub.Unsubscribe()
+
+	rewards := make(chan RewardEvent, 10)
+	rewardsSub, err := client.Subscribe(ctx, "subproxy", rewards, "rewards", []common.Address{common.HexToAddress("0x100")})
+	require.NoError(t, err)
+	defer rewardsSub.Unsubscribe()
+
+	unpaid := make(chan RewardEvent, 10)
+	unpaidSub, err := client.Subscribe(ctx, "subproxy", unpaid, "rewards", []common.Address{addr1})
+	require.NoError(t, err)
+	defer unpaidSub.Unsubscribe()
+
+	status := NewPublicSubProxyAPI(p).Status()
+	assert.True(t, status.Connected)
+	assert.Equal(t, []int{1, 1, 2}, []int{status.Heads, status.Logs, status.Rewards})
+
+	// the logs are filtered by the address of the subscription
+	send(t, &api.logFeed, &types.Log{Address: addr1, Topics: []common.Hash{}, BlockNumber: 7})
+	send(t, &api.logFeed, &types.Log{Address: addr2, Topics: []common.Hash{}, BlockNumber: 7})
+	select {
+	case l := <-logs:
+		assert.Equal(t, addr2, l.Address)
+	case <-time.After(5 * time.Second):
+		t.Fatal("log is not relayed")
+	}
+
+	// the rewards are fetched once for the new head and relayed to the subscribers paid
+	head := &testHead{Number: (*hexutil.Big)(big.NewInt(7)), Hash: common.HexToHash("0x7")}
+	send(t, &api.headFeed, head)
+	select {
+	case h := <-heads:
+		assert.Equal(t, *head, h)
+	case <-time.After(5 * time.Second):
+		t.Fatal("head is not relayed")
+	}
+	select {
+	case ev := <-rewards:
+		assert.Equal(t, head.Number, ev.BlockNumber)
+		assert.Equal(t, head.Hash, ev.BlockHash)
+		assert.Equal(t, big.NewInt(7), ev.Reward.Minted)
+	case <-time.After(5 * time.Second):
+		t.Fatal("rewards are not relayed")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&api.rewardsCalls))
+	assert.Empty(t, logs)
+	assert.Empty(t, unpaid)
+}
+
+func TestProxy_Reconnect(t *testing.T) {
+	var (
+		server = rpc.NewServer()
+		dials  int32
+	)
+	require.NoError(t, server.RegisterName("klay", &testUpstreamAPI{}))
+	defer server.Stop()
+
+	cfg := DefaultConfig()
+	cfg.ReconnectDelay = 10 * time.Millisecond
+	p := newProxy(cfg, func(ctx context.Context, url string) (upstream, error) {
+		if atomic.AddInt32(&dials, 1) == 1 {
+			return nil, errors.New("connection refused")
+		}
+		return rpc.DialInProc(server), nil
+	})
+	require.NoError(t, p.Start(nil))
+	defer p.Stop()
+
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&p.connected) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&dials))
+}
+
+func TestHub_Publish(t *testing.T) {
+	h := newHub(2)
+
+	slow := &subscriber{kind: logsSubscription, ch: make(chan interface{}, 1)}
+	other := &subscriber{kind: logsSubscription, crit: filters.FilterCriteria{Addresses: []common.Address{{0x1}}}, ch: make(chan interface{}, 3)}
+	require.NoError(t, h.subscribe(slow))
+	require.NoError(t, h.subscribe(other))
+	assert.Equal(t, errTooManyClients, h.subscribe(&subscriber{kind: headsSubscription}))
+
+	// publishing does not block on the slow subscriber
+	for i := 0; i < 3; i++ {
+		h.publishLog(&types.Log{Address: common.Address{0x1}})
+	}
+	h.publishLog(&types.Log{Address: common.Address{0x2}})
+	assert.Equal(t, 1, len(slow.ch))
+	assert.Equal(t, uint64(3), atomic.LoadUint64(&slow.dropped))
+	assert.Equal(t, 3, len(other.ch))
+	assert.Equal(t, uint64(0), atomic.LoadUint64(&other.dropped))
+	assert.Equal(t, uint64(3), atomic.LoadUint64(&h.dropped))
+
+	h.unsubscribe(slow)
+	h.unsubscribe(slow)
+	assert.Equal(t, 1, h.count(logsSubscription))
+	assert.NoError(t, h.subscribe(&subscriber{kind: headsSubscription}))
+}
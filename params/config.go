@@ -22,6 +22,7 @@ package params
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -191,12 +192,44 @@ type ChainConfig struct {
 	// Both Kip103CompatibleBlock and Kip103ContractAddress should be specified to enable KIP103
 	Kip103CompatibleBlock *big.Int       `json:"kip103CompatibleBlock,omitempty"` // Kip103Compatible activate block (nil = no fork)
 	Kip103ContractAddress common.Address `json:"kip103ContractAddress,omitempty"` // Kip103 contract address already deployed on the network
+	Kip103RecordMemo      bool           `json:"kip103RecordMemo,omitempty"`      // Finalize the Kip103 contract with the result as the memo on the fork block
 
 	// Randao is an optional hardfork
 	// RandaoCompatibleBlock, RandaoRegistryRecords and RandaoRegistryOwner all must be specified to enable Randao
 	RandaoCompatibleBlock *big.Int        `json:"randaoCompatibleBlock,omitempty"` // RandaoCompatible activate block (nil = no fork)
 	RandaoRegistry        *RegistryConfig `json:"randaoRegistry,omitempty"`        // Registry initial states
 
+	// RewardRedirect is an optional hardfork for the emergency governance.
+	// After the fork, all block rewards are paid to reward.redirectaddress if it is set by governance.
+	RewardRedirectCompatibleBlock *big.Int `json:"rewardRedirectCompatibleBlock,omitempty"` // RewardRedirectCompatible activate block (nil = no fork)
+
+	// StakingCommitment is an optional hardfork committing the staking information in block headers.
+	// After the fork, a block header carries the hash of the staking information used for its reward.
+	StakingCommitmentCompatibleBlock *big.Int `json:"stakingCommitmentCompatibleBlock,omitempty"` // StakingCommitmentCompatible activate block (nil = no fork)
+
+	// RewardPayout is an optional hardfork for the contract reward recipients.
+	// After the fork, a contract recipient is paid by calling it with reward.payoutgasstipend if it is set by governance.
+	RewardPayoutCompatibleBlock *big.Int `json:"rewardPayoutCompatibleBlock,omitempty"` // RewardPayoutCompatible activate block (nil = no fork)
+
+	// PebStaking is an optional hardfork for the staking reward.
+	// After the fork, the staking reward is split by the staking amounts in peb instead of the amounts truncated to KLAY.
+	PebStakingCompatibleBlock *big.Int `json:"pebStakingCompatibleBlock,omitempty"` // PebStakingCompatible activate block (nil = no fork)
+
+	// RewardSplit is an optional hardfork for the treasury governance.
+	// After the fork, the KFF, KCF and proposer rewards are split by reward.kffsplit, reward.kcfsplit,
+	// reward.proposersplit and the remainder is handled by reward.remainderpolicy if they are set by governance.
+	RewardSplitCompatibleBlock *big.Int `json:"rewardSplitCompatibleBlock,omitempty"` // RewardSplitCompatible activate block (nil = no fork)
+
+	// RewardVesting is an optional hardfork for the treasury governance.
+	// After the fork, the block rewards of the RewardVesting recipients are locked in the vesting vault and vested over time.
+	RewardVestingCompatibleBlock *big.Int             `json:"rewardVestingCompatibleBlock,omitempty"` // RewardVestingCompatible activate block (nil = no fork)
+	RewardVesting                *RewardVestingConfig `json:"rewardVesting,omitempty"`                // Vesting recipients and schedule
+
+	// Gasless makes the gas free of charge for the private service chains billing out-of-band.
+	// The unit price and the base fee are fixed to zero, so no tx fee is paid or burnt and
+	// the block rewards consist of the minted amount only. It must be set from the genesis.
+	Gasless bool `json:"gasless,omitempty"`
+
 	// Various consensus engines
 	Gxhash   *GxhashConfig   `json:"gxhash,omitempty"` // (deprecated) not supported engine
 	Clique   *CliqueConfig   `json:"clique,omitempty"`
@@ -220,16 +253,32 @@ func (g *GovernanceConfig) DeferredTxFee() bool {
 	return g.Reward.DeferredTxFee
 }
 
+// BurnRatio returns the percentage of the tx fee burnt since Magma.
+func (g *GovernanceConfig) BurnRatio() uint64 {
+	if g == nil || g.Reward == nil || g.Reward.BurnRatio == nil {
+		return DefaultBurnRatio
+	}
+	return *g.Reward.BurnRatio
+}
+
 // RewardConfig stores information about the network's token economy
 type RewardConfig struct {
-	MintingAmount          *big.Int `json:"mintingAmount"`
-	Ratio                  string   `json:"ratio"`                  // Define how much portion of reward be distributed to CN/KFF/KCF
-	Kip82Ratio             string   `json:"kip82ratio,omitempty"`   // Define how much portion of reward be distributed to proposer/stakers
-	UseGiniCoeff           bool     `json:"useGiniCoeff"`           // Decide if Gini Coefficient will be used or not
-	DeferredTxFee          bool     `json:"deferredTxFee"`          // Decide if TX fee will be handled instantly or handled later at block finalization
-	StakingUpdateInterval  uint64   `json:"stakingUpdateInterval"`  // Interval when staking information is updated
-	ProposerUpdateInterval uint64   `json:"proposerUpdateInterval"` // Interval when proposer information is updated
-	MinimumStake           *big.Int `json:"minimumStake"`           // Minimum amount of peb to join CCO
+	MintingAmount          *big.Int       `json:"mintingAmount"`
+	Ratio                  string         `json:"ratio"`                       // Define how much portion of reward be distributed to CN/KFF/KCF
+	Kip82Ratio             string         `json:"kip82ratio,omitempty"`        // Define how much portion of reward be distributed to proposer/stakers
+	UseGiniCoeff           bool           `json:"useGiniCoeff"`                // Decide if Gini Coefficient will be used or not
+	DeferredTxFee          bool           `json:"deferredTxFee"`               // Decide if TX fee will be handled instantly or handled later at block finalization
+	StakingUpdateInterval  uint64         `json:"stakingUpdateInterval"`       // Interval when staking information is updated
+	ProposerUpdateInterval uint64         `json:"proposerUpdateInterval"`      // Interval when proposer information is updated
+	MinimumStake           *big.Int       `json:"minimumStake"`                // Minimum amount of peb to join CCO
+	RedirectAddress        common.Address `json:"redirectAddress,omitempty"`   // Recipient of all block rewards after the RewardRedirect fork (zero = no redirection)
+	KFFSplit               string         `json:"kffSplit,omitempty"`          // Define how the KFF portion is split among multiple funds (empty = paid to the KFF address)
+	BurnRatio              *uint64        `json:"burnRatio,omitempty"`         // Percentage of the tx fee burnt since Magma (nil = DefaultBurnRatio)
+	PayoutGasStipend       uint64         `json:"payoutGasStipend,omitempty"`  // Gas given to a contract recipient to handle its reward after the RewardPayout fork (zero = credited to the balance)
+	KCFSplit               string         `json:"kcfSplit,omitempty"`          // Define how the KCF portion is split among multiple funds (empty = paid to the KCF address)
+	RemainderPolicy        string         `json:"remainderPolicy,omitempty"`   // Define where the rounding remainders of the reward go (empty = split remainder to KFF, share remainder to the proposer)
+	ProposerSplit          string         `json:"proposerSplit,omitempty"`     // Define how the proposer portion of each rewardbase is split among multiple funds (empty = paid to the rewardbase)
+	MinStakeInclusive      bool           `json:"minStakeInclusive,omitempty"` // Whether a CN staking exactly MinimumStake is eligible for the staking share (false = a stake above MinimumStake is required)
 }
 
 // Magma governance parameters
@@ -261,6 +310,24 @@ type RegistryConfig struct {
 	Owner   common.Address            `json:"owner"`
 }
 
+// RewardVestingConfig is the vesting schedule of the block rewards after the RewardVesting fork.
+// The rewards of the recipients in a period of blocks are locked, and vested linearly block by
+// block over the next period. The vested rewards are credited to the recipients.
+type RewardVestingConfig struct {
+	Recipients []common.Address `json:"recipients"`
+	Period     uint64           `json:"period"` // in blocks
+}
+
+// IsRecipient returns whether the rewards of the address are vested.
+func (c *RewardVestingConfig) IsRecipient(addr common.Address) bool {
+	for _, recipient := range c.Recipients {
+		if recipient == addr {
+			return true
+		}
+	}
+	return false
+}
+
 // GxhashConfig is the consensus engine configs for proof-of-work based sealing.
 // Deprecated: Use IstanbulConfig or CliqueConfig.
 type GxhashConfig struct{}
@@ -383,6 +450,36 @@ func (c *ChainConfig) IsRandaoForkEnabled(num *big.Int) bool {
 	return isForked(c.RandaoCompatibleBlock, num)
 }
 
+// IsRewardRedirectForkEnabled returns whether num is either equal to the reward redirect block or greater.
+func (c *ChainConfig) IsRewardRedirectForkEnabled(num *big.Int) bool {
+	return isForked(c.RewardRedirectCompatibleBlock, num)
+}
+
+// IsStakingCommitmentForkEnabled returns whether num is either equal to the staking commitment block or greater.
+func (c *ChainConfig) IsStakingCommitmentForkEnabled(num *big.Int) bool {
+	return isForked(c.StakingCommitmentCompatibleBlock, num)
+}
+
+// IsRewardPayoutForkEnabled returns whether num is either equal to the reward payout block or greater.
+func (c *ChainConfig) IsRewardPayoutForkEnabled(num *big.Int) bool {
+	return isForked(c.RewardPayoutCompatibleBlock, num)
+}
+
+// IsPebStakingForkEnabled returns whether num is either equal to the peb staking block or greater.
+func (c *ChainConfig) IsPebStakingForkEnabled(num *big.Int) bool {
+	return isForked(c.PebStakingCompatibleBlock, num)
+}
+
+// IsRewardSplitForkEnabled returns whether num is either equal to the reward split block or greater.
+func (c *ChainConfig) IsRewardSplitForkEnabled(num *big.Int) bool {
+	return isForked(c.RewardSplitCompatibleBlock, num)
+}
+
+// IsRewardVestingForkEnabled returns whether num is either equal to the reward vesting block or greater.
+func (c *ChainConfig) IsRewardVestingForkEnabled(num *big.Int) bool {
+	return isForked(c.RewardVestingCompatibleBlock, num)
+}
+
 // IsKIP103ForkBlock returns whether num is equal to the kip103 block.
 func (c *ChainConfig) IsKIP103ForkBlock(num *big.Int) bool {
 	if c.Kip103CompatibleBlock == nil || num == nil {
@@ -458,6 +555,23 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 	return nil
 }
 
+// CheckGasless checks that a gasless chain is configured with no price for the gas.
+func (c *ChainConfig) CheckGasless() error {
+	if !c.Gasless {
+		return nil
+	}
+	if c.UnitPrice != 0 {
+		return fmt.Errorf("gasless chain with non-zero unitPrice %d", c.UnitPrice)
+	}
+	if c.MagmaCompatibleBlock != nil {
+		if c.Governance == nil || c.Governance.KIP71 == nil ||
+			c.Governance.KIP71.LowerBoundBaseFee != 0 || c.Governance.KIP71.UpperBoundBaseFee != 0 {
+			return errors.New("gasless chain must have zero lowerBoundBaseFee and upperBoundBaseFee")
+		}
+	}
+	return nil
+}
+
 func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *ConfigCompatError {
 	if isForkIncompatible(c.IstanbulCompatibleBlock, newcfg.IstanbulCompatibleBlock, head) {
 		return newCompatError("Istanbul Block", c.IstanbulCompatibleBlock, newcfg.IstanbulCompatibleBlock)
@@ -485,6 +599,30 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.RandaoCompatibleBlock, newcfg.RandaoCompatibleBlock, head) {
 		return newCompatError("Randao Block", c.RandaoCompatibleBlock, newcfg.RandaoCompatibleBlock)
 	}
+	// The rewardRedirectBlock is also skipped in the fork ordering check since it is an optional hardfork.
+	if isForkIncompatible(c.RewardRedirectCompatibleBlock, newcfg.RewardRedirectCompatibleBlock, head) {
+		return newCompatError("RewardRedirect Block", c.RewardRedirectCompatibleBlock, newcfg.RewardRedirectCompatibleBlock)
+	}
+	// The stakingCommitmentBlock is also skipped in the fork ordering check since it is an optional hardfork.
+	if isForkIncompatible(c.StakingCommitmentCompatibleBlock, newcfg.StakingCommitmentCompatibleBlock, head) {
+		return newCompatError("StakingCommitment Block", c.StakingCommitmentCompatibleBlock, newcfg.StakingCommitmentCompatibleBlock)
+	}
+	// The rewardPayoutBlock is also skipped in the fork ordering check since it is an optional hardfork.
+	if isForkIncompatible(c.RewardPayoutCompatibleBlock, newcfg.RewardPayoutCompatibleBlock, head) {
+		return newCompatError("RewardPayout Block", c.RewardPayoutCompatibleBlock, newcfg.RewardPayoutCompatibleBlock)
+	}
+	// The pebStakingBlock is also skipped in the fork ordering check since it is an optional hardfork.
+	if isForkIncompatible(c.PebStakingCompatibleBlock, newcfg.PebStakingCompatibleBlock, head) {
+		return newCompatError("PebStaking Block", c.PebStakingCompatibleBlock, newcfg.PebStakingCompatibleBlock)
+	}
+	// The rewardSplitBlock is also skipped in the fork ordering check since it is an optional hardfork.
+	if isForkIncompatible(c.RewardSplitCompatibleBlock, newcfg.RewardSplitCompatibleBlock, head) {
+		return newCompatError("RewardSplit Block", c.RewardSplitCompatibleBlock, newcfg.RewardSplitCompatibleBlock)
+	}
+	// The rewardVestingBlock is also skipped in the fork ordering check since it is an optional hardfork.
+	if isForkIncompatible(c.RewardVestingCompatibleBlock, newcfg.RewardVestingCompatibleBlock, head) {
+		return newCompatError("RewardVesting Block", c.RewardVestingCompatibleBlock, newcfg.RewardVestingCompatibleBlock)
+	}
 	return nil
 }
 
@@ -606,6 +744,13 @@ type Rules struct {
 	IsShanghai  bool
 	IsCancun    bool
 	IsRandao    bool
+
+	IsRewardRedirect    bool
+	IsStakingCommitment bool
+	IsRewardPayout      bool
+	IsPebStaking        bool
+	IsRewardSplit       bool
+	IsRewardVesting     bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -624,6 +769,13 @@ func (c *ChainConfig) Rules(num *big.Int) Rules {
 		IsShanghai:  c.IsShanghaiForkEnabled(num),
 		IsCancun:    c.IsCancunForkEnabled(num),
 		IsRandao:    c.IsRandaoForkEnabled(num),
+
+		IsRewardRedirect:    c.IsRewardRedirectForkEnabled(num),
+		IsStakingCommitment: c.IsStakingCommitmentForkEnabled(num),
+		IsRewardPayout:      c.IsRewardPayoutForkEnabled(num),
+		IsPebStaking:        c.IsPebStakingForkEnabled(num),
+		IsRewardSplit:       c.IsRewardSplitForkEnabled(num),
+		IsRewardVesting:     c.IsRewardVestingForkEnabled(num),
 	}
 }
 
@@ -28,6 +28,26 @@ func TestChainConfig_CheckConfigForkOrder(t *testing.T) {
 	assert.Nil(t, CypressChainConfig.CheckConfigForkOrder())
 }
 
+func TestChainConfig_CheckGasless(t *testing.T) {
+	assert.Nil(t, CypressChainConfig.CheckGasless())
+
+	c := CypressChainConfig.Copy()
+	c.Gasless = true
+	assert.NotNil(t, c.CheckGasless()) // non-zero unit price
+
+	c.UnitPrice = 0
+	assert.NotNil(t, c.CheckGasless()) // non-zero base fee after magma
+
+	c.Governance = &GovernanceConfig{KIP71: GetDefaultKIP71Config()}
+	c.Governance.KIP71.LowerBoundBaseFee = 0
+	c.Governance.KIP71.UpperBoundBaseFee = 0
+	assert.Nil(t, c.CheckGasless())
+
+	c.MagmaCompatibleBlock = nil
+	c.Governance = nil
+	assert.Nil(t, c.CheckGasless())
+}
+
 func TestChainConfig_Copy(t *testing.T) {
 	// Temporarily modify CypressChainConfig to simulate copying `nil` field.
 	savedBlock := CypressChainConfig.LondonCompatibleBlock
@@ -0,0 +1,93 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// OverridableForks are the names of the hardforks whose activation blocks can be overridden
+// locally by the --override.<fork> flags.
+var OverridableForks = []string{
+	"istanbul", "london", "ethtxtype", "magma", "kore", "shanghai", "cancun",
+	"kip103", "randao", "rewardredirect", "stakingcommitment", "rewardpayout", "pebstaking",
+	"rewardsplit", "rewardvesting",
+}
+
+// forkBlock returns the activation block field of the named hardfork, nil if the name is unknown.
+func (c *ChainConfig) forkBlock(name string) **big.Int {
+	switch name {
+	case "istanbul":
+		return &c.IstanbulCompatibleBlock
+	case "london":
+		return &c.LondonCompatibleBlock
+	case "ethtxtype":
+		return &c.EthTxTypeCompatibleBlock
+	case "magma":
+		return &c.MagmaCompatibleBlock
+	case "kore":
+		return &c.KoreCompatibleBlock
+	case "shanghai":
+		return &c.ShanghaiCompatibleBlock
+	case "cancun":
+		return &c.CancunCompatibleBlock
+	case "kip103":
+		return &c.Kip103CompatibleBlock
+	case "randao":
+		return &c.RandaoCompatibleBlock
+	case "rewardredirect":
+		return &c.RewardRedirectCompatibleBlock
+	case "stakingcommitment":
+		return &c.StakingCommitmentCompatibleBlock
+	case "rewardpayout":
+		return &c.RewardPayoutCompatibleBlock
+	case "pebstaking":
+		return &c.PebStakingCompatibleBlock
+	case "rewardsplit":
+		return &c.RewardSplitCompatibleBlock
+	case "rewardvesting":
+		return &c.RewardVestingCompatibleBlock
+	}
+	return nil
+}
+
+// OverrideForks returns a copy of the config with the activation blocks of the given hardforks
+// overridden. The overrides are local to the node and not a part of the consensus: a node with
+// an overridden fork rejects the blocks of the others, so the copy must never be stored as the
+// chain config. It fails on an unknown hardfork or if the overrides break the fork order.
+func (c *ChainConfig) OverrideForks(overrides map[string]uint64) (*ChainConfig, error) {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cfg := c.Copy()
+	for _, name := range names {
+		block := cfg.forkBlock(name)
+		if block == nil {
+			return nil, fmt.Errorf("unknown hardfork %q to override, want one of %v", name, OverridableForks)
+		}
+		*block = new(big.Int).SetUint64(overrides[name])
+	}
+	if err := cfg.CheckConfigForkOrder(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainConfig_OverrideForks(t *testing.T) {
+	config := CypressChainConfig.Copy()
+	overridden, err := config.OverrideForks(map[string]uint64{"cancun": 140000000, "pebstaking": 100})
+	require.NoError(t, err)
+
+	// the overrides are applied to the copy only
+	assert.Equal(t, big.NewInt(140000000), overridden.CancunCompatibleBlock)
+	assert.Equal(t, big.NewInt(100), overridden.PebStakingCompatibleBlock)
+	assert.True(t, overridden.Rules(big.NewInt(100)).IsPebStaking)
+	assert.Nil(t, config.PebStakingCompatibleBlock)
+	assert.Nil(t, config.CancunCompatibleBlock)
+
+	// every overridable fork is known
+	for _, name := range OverridableForks {
+		assert.NotNil(t, config.forkBlock(name), name)
+	}
+
+	_, err = config.OverrideForks(map[string]uint64{"prague": 1})
+	assert.Error(t, err)
+	_, err = config.OverrideForks(map[string]uint64{"cancun": 1})
+	assert.Error(t, err, "cancun before shanghai breaks the fork order")
+}
@@ -17,8 +17,14 @@
 package params
 
 import (
+	"errors"
+	"math"
 	"math/big"
+	"strconv"
+	"strings"
 	"sync/atomic"
+
+	"github.com/klaytn/klaytn/common"
 )
 
 var (
@@ -30,6 +36,9 @@ const (
 	// Block reward will be separated by three pieces and distributed
 	RewardSliceCount      = 3
 	RewardKip82SliceCount = 2
+	// The parts of a reward ratio sum up to either of below
+	RatioPercentTotal     = 100
+	RatioBasisPointsTotal = 10000
 	// GovernanceConfig is stored in a cache which has below capacity
 	GovernanceCacheLimit    = 512
 	GovernanceIdxCacheLimit = 1000
@@ -67,6 +76,14 @@ const (
 	GovParamContract
 	Kip82Ratio
 	DeriveShaImpl
+	RewardRedirectAddress
+	KFFSplit
+	BurnRatio
+	PayoutGasStipend
+	KCFSplit
+	RemainderPolicy
+	ProposerSplit
+	MinStakeInclusive
 )
 
 const (
@@ -89,6 +106,7 @@ var (
 	DefaultGovernanceMode            = "none"
 	DefaultGoverningNode             = "0x0000000000000000000000000000000000000000"
 	DefaultGovParamContract          = "0x0000000000000000000000000000000000000000"
+	DefaultRewardRedirectAddress     = "0x0000000000000000000000000000000000000000" // no redirection
 	DefaultEpoch                     = uint64(604800)
 	DefaultProposerPolicy            = uint64(RoundRobin)
 	DefaultSubGroupSize              = uint64(21)
@@ -101,9 +119,16 @@ var (
 	DefaultMintingAmount             = big.NewInt(0)
 	DefaultRatio                     = "100/0/0"
 	DefaultKip82Ratio                = "20/80"
+	DefaultKFFSplit                  = ""         // KFF portion is paid to the KFF address of the staking info
+	DefaultKCFSplit                  = ""         // KCF portion is paid to the KCF address of the staking info
+	DefaultRemainderPolicy           = ""         // split remainder goes to KFF and share remainder to the proposer
+	DefaultProposerSplit             = ""         // proposer portion is paid to the rewardbase of the proposer
+	DefaultBurnRatio                 = uint64(50) // percentage of the tx fee burnt since Magma
+	DefaultPayoutGasStipend          = uint64(0)  // rewards are credited to the balance of contract recipients
 	DefaultUseGiniCoeff              = false
 	DefaultDeferredTxFee             = false
 	DefaultMinimumStake              = big.NewInt(2000000)
+	DefaultMinStakeInclusive         = false // a CN needs a stake above the minimum stake to have a staking share
 	DefaultStakeUpdateInterval       = uint64(86400) // 1 day
 	DefaultProposerRefreshInterval   = uint64(3600)  // 1 hour
 	DefaultPeriod                    = uint64(1)
@@ -131,6 +156,176 @@ func CalcStakingBlockNumber(blockNum uint64) uint64 {
 	return number
 }
 
+// CalcGovernanceBlockNumber returns the number of the epoch block whose governance data is
+// effective at blockNum. The data written at an epoch block takes effect after the next
+// epoch block, so the data of the epoch block before the previous one is used. The genesis
+// data is used in the first two epochs. The rewards of the epoch blocks before Kore are
+// calculated with the parameters of the previous epoch block; see reward.CalcRewardParamBlock.
+func CalcGovernanceBlockNumber(blockNum, epoch uint64) uint64 {
+	if epoch == 0 || blockNum < 2*epoch {
+		return 0
+	}
+	return blockNum - blockNum%epoch - epoch
+}
+
+var (
+	errInvalidKFFSplit        = errors.New("invalid kffsplit format")
+	errInvalidKCFSplit        = errors.New("invalid kcfsplit format")
+	errInvalidRemainderPolicy = errors.New("invalid remainderpolicy")
+	errInvalidProposerSplit   = errors.New("invalid proposersplit format")
+)
+
+// The destinations of the rounding remainders of the block reward set by `reward.remainderpolicy`.
+// The empty policy is the legacy one, giving the remainder of the (CN, KFF, KCF) split to KFF
+// and the remainder of the staker shares to the proposer.
+const (
+	RemainderPolicyLegacy   = ""
+	RemainderPolicyBurn     = "burn"     // the remainders are burnt
+	RemainderPolicyProposer = "proposer" // the remainders go to the proposer
+	RemainderPolicyKFF      = "kff"      // the remainders go to KFF
+	RemainderPolicyCarry    = "carry"    // the remainders are carried over to the stakers of the next block
+)
+
+// ValidateRemainderPolicy returns an error if `reward.remainderpolicy` is not one of the policies.
+func ValidateRemainderPolicy(s string) error {
+	switch s {
+	case RemainderPolicyLegacy, RemainderPolicyBurn, RemainderPolicyProposer, RemainderPolicyKFF, RemainderPolicyCarry:
+		return nil
+	}
+	return errInvalidRemainderPolicy
+}
+
+// RewardFund is a fund receiving a part of the KFF or KCF portion of the block reward.
+type RewardFund struct {
+	Addr   common.Address
+	Weight uint64
+}
+
+// ParseKFFSplit parses `reward.kffsplit`, a comma-separated list of "address:weight"
+// pairs, e.g. "0x0000000000000000000000000000000000000401:70,0x0000000000000000000000000000000000000402:30".
+// An empty string means that the KFF portion is not split.
+func ParseKFFSplit(s string) ([]RewardFund, error) {
+	return parseFundSplit(s, errInvalidKFFSplit)
+}
+
+// ParseKCFSplit parses `reward.kcfsplit` in the same format as `reward.kffsplit`.
+// An empty string means that the KCF portion is not split.
+func ParseKCFSplit(s string) ([]RewardFund, error) {
+	return parseFundSplit(s, errInvalidKCFSplit)
+}
+
+// ParseProposerSplit parses `reward.proposersplit`, a semicolon-separated list of the split
+// schedules of the rewardbases, each of which is "rewardbase=" followed by the funds in the
+// same format as `reward.kffsplit`, e.g. "0x...0101=0x...0401:80,0x...0402:20".
+// An empty string means that no proposer portion is split.
+func ParseProposerSplit(s string) (map[common.Address][]RewardFund, error) {
+	if s == "" {
+		return nil, nil
+	}
+	schedules := make(map[common.Address][]RewardFund)
+	for _, item := range strings.Split(s, ";") {
+		pair := strings.Split(item, "=")
+		if len(pair) != 2 || !common.IsHexAddress(pair[0]) {
+			return nil, errInvalidProposerSplit
+		}
+		rewardbase := common.HexToAddress(pair[0])
+		if _, ok := schedules[rewardbase]; ok || common.EmptyAddress(rewardbase) {
+			return nil, errInvalidProposerSplit
+		}
+		funds, err := parseFundSplit(pair[1], errInvalidProposerSplit)
+		if err != nil || len(funds) == 0 {
+			return nil, errInvalidProposerSplit
+		}
+		schedules[rewardbase] = funds
+	}
+	return schedules, nil
+}
+
+func parseFundSplit(s string, errInvalid error) ([]RewardFund, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var (
+		funds []RewardFund
+		seen  = make(map[common.Address]bool)
+	)
+	for _, item := range strings.Split(s, ",") {
+		pair := strings.Split(item, ":")
+		if len(pair) != 2 || !common.IsHexAddress(pair[0]) {
+			return nil, errInvalid
+		}
+		addr := common.HexToAddress(pair[0])
+		weight, err := strconv.ParseUint(pair[1], 10, 64)
+		if err != nil || weight == 0 || common.EmptyAddress(addr) || seen[addr] {
+			return nil, errInvalid
+		}
+		seen[addr] = true
+		funds = append(funds, RewardFund{Addr: addr, Weight: weight})
+	}
+	return funds, nil
+}
+
+var errInvalidRatio = errors.New("invalid ratio format")
+
+// ParseRatio parses the parts of a ratio separated by "/" such as `reward.ratio` and `reward.kip82ratio`.
+// A part is a non-negative integer, e.g. "34/54/12" in percents or "3400/5400/1200" in basis points,
+// or a decimal with up to two decimal places, e.g. "34.5/54/11.5" in percents. If any part is a decimal,
+// all the parts are converted to basis points, e.g. [3450, 5400, 1150].
+func ParseRatio(s string) ([]int64, error) {
+	var (
+		strs    = strings.Split(s, "/")
+		parts   = make([]int64, len(strs))
+		decimal = false
+	)
+	for i, str := range strs {
+		whole, frac, hasFrac := strings.Cut(str, ".")
+		n, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil || n < 0 || n > math.MaxInt64/RatioPercentTotal {
+			return nil, errInvalidRatio
+		}
+		parts[i] = n * RatioPercentTotal
+		if !hasFrac {
+			continue
+		}
+		f, err := strconv.ParseUint(frac, 10, 64)
+		if err != nil || len(frac) == 0 || len(frac) > 2 {
+			return nil, errInvalidRatio
+		}
+		if len(frac) == 1 {
+			f *= 10
+		}
+		parts[i] += int64(f)
+		decimal = true
+	}
+	if !decimal {
+		for i := range parts {
+			parts[i] /= RatioPercentTotal
+		}
+	}
+	return parts, nil
+}
+
+// IsValidRatio returns whether the ratio has the given number of parts
+// summing up to 100 in percents or 10000 in basis points. A ratio with
+// decimal parts is always in basis points and must sum up to 10000.
+func IsValidRatio(s string, count int) bool {
+	parts, err := ParseRatio(s)
+	if err != nil || len(parts) != count {
+		return false
+	}
+	sum := int64(0)
+	for _, n := range parts {
+		if n > RatioBasisPointsTotal {
+			return false
+		}
+		sum += n
+	}
+	if strings.Contains(s, ".") {
+		return sum == RatioBasisPointsTotal
+	}
+	return sum == RatioPercentTotal || sum == RatioBasisPointsTotal
+}
+
 func IsProposerUpdateInterval(blockNum uint64) (bool, uint64) {
 	proposerInterval := ProposerUpdateInterval()
 	return (blockNum % proposerInterval) == 0, proposerInterval
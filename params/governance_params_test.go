@@ -17,7 +17,10 @@
 package params
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/klaytn/klaytn/common"
 )
 
 func TestSetProposerUpdateInterval(t *testing.T) {
@@ -189,3 +192,172 @@ func TestCalcStakingBlockNumber(t *testing.T) {
 		}
 	}
 }
+
+func TestCalcGovernanceBlockNumber(t *testing.T) {
+	for _, epoch := range []uint64{1, 2, 3, 30, 1024} {
+		for blockNum := uint64(0); blockNum <= 5*epoch+1; blockNum++ {
+			// The data written at an epoch block takes effect after the next epoch block.
+			expected := uint64(0)
+			for written := epoch; written+epoch <= blockNum; written += epoch {
+				expected = written
+			}
+
+			result := CalcGovernanceBlockNumber(blockNum, epoch)
+			if result != expected {
+				t.Errorf("The result is different from the expected result. Result : %v, Expected : %v, block number : %v, epoch : %v",
+					result, expected, blockNum, epoch)
+			}
+		}
+	}
+
+	// The genesis data is used if the epoch is not set.
+	if result := CalcGovernanceBlockNumber(100, 0); result != 0 {
+		t.Errorf("The result is different from the expected result. Result : %v, Expected : 0", result)
+	}
+}
+
+func TestParseKFFSplit(t *testing.T) {
+	var (
+		fund1 = common.HexToAddress("0x0000000000000000000000000000000000000401")
+		fund2 = common.HexToAddress("0x0000000000000000000000000000000000000402")
+	)
+
+	testcases := []struct {
+		split    string
+		expected []RewardFund
+		ok       bool
+	}{
+		{"", nil, true},
+		{fund1.Hex() + ":1", []RewardFund{{fund1, 1}}, true},
+		{fund1.Hex() + ":70," + fund2.Hex() + ":30", []RewardFund{{fund1, 70}, {fund2, 30}}, true},
+		{fund2.Hex() + ":2," + fund1.Hex() + ":5", []RewardFund{{fund2, 2}, {fund1, 5}}, true},
+		{fund1.Hex() + ":0", nil, false},                       // zero weight
+		{fund1.Hex() + ":-1", nil, false},                      // negative weight
+		{fund1.Hex() + ":1.5", nil, false},                     // fractional weight
+		{fund1.Hex() + ":1," + fund1.Hex() + ":2", nil, false}, // duplicated fund
+		{common.Address{}.Hex() + ":1", nil, false},            // empty address
+		{"0x401:1", nil, false},                                // short address
+		{fund1.Hex(), nil, false},                              // missing weight
+		{fund1.Hex() + ":1,", nil, false},                      // trailing comma
+		{"70/30", nil, false},
+	}
+
+	for _, tc := range testcases {
+		funds, err := ParseKFFSplit(tc.split)
+		if ok := err == nil; ok != tc.ok {
+			t.Errorf("Want ok %v, got %v for %q", tc.ok, ok, tc.split)
+		}
+		if tc.ok && !reflect.DeepEqual(tc.expected, funds) {
+			t.Errorf("Want %v, got %v for %q", tc.expected, funds, tc.split)
+		}
+	}
+}
+
+func TestParseRatio(t *testing.T) {
+	testcases := []struct {
+		ratio    string
+		expected []int64
+		ok       bool
+		valid    bool // as a ratio of three parts
+	}{
+		{"34/54/12", []int64{34, 54, 12}, true, true},
+		{"3400/5400/1200", []int64{3400, 5400, 1200}, true, true},
+		{"34.5/54/11.5", []int64{3450, 5400, 1150}, true, true},
+		{"34.05/54/11.95", []int64{3405, 5400, 1195}, true, true},
+		{"100/0/0", []int64{100, 0, 0}, true, true},
+		{"10000/0/0", []int64{10000, 0, 0}, true, true},
+		{"34/54/11", []int64{34, 54, 11}, true, false},             // sum is not 100
+		{"3400/5400/1201", []int64{3400, 5400, 1201}, true, false}, // sum is not 10000
+		{"50/50", []int64{50, 50}, true, false},                    // two parts
+		{"0.34/0.54/0.12", []int64{34, 54, 12}, true, false},       // sum is 100 in basis points
+		{"34.005/54/11.995", nil, false, false},                    // more than two decimal places
+		{"34./54/12", nil, false, false},
+		{".5/54/45.5", nil, false, false},
+		{"-34/54/80", nil, false, false},
+		{"34/-5.5/71.5", nil, false, false},
+		{"34/54/1e1", nil, false, false},
+		{"34,54,12", nil, false, false},
+		{"", nil, false, false},
+	}
+
+	for _, tc := range testcases {
+		parts, err := ParseRatio(tc.ratio)
+		if ok := err == nil; ok != tc.ok {
+			t.Errorf("Want ok %v, got %v for %q", tc.ok, ok, tc.ratio)
+		}
+		if tc.ok && !reflect.DeepEqual(tc.expected, parts) {
+			t.Errorf("Want %v, got %v for %q", tc.expected, parts, tc.ratio)
+		}
+		if valid := IsValidRatio(tc.ratio, RewardSliceCount); valid != tc.valid {
+			t.Errorf("Want valid %v, got %v for %q", tc.valid, valid, tc.ratio)
+		}
+	}
+}
+
+func TestParseKCFSplit(t *testing.T) {
+	fund := common.HexToAddress("0x0000000000000000000000000000000000000401")
+
+	funds, err := ParseKCFSplit(fund.Hex() + ":3")
+	if err != nil || !reflect.DeepEqual([]RewardFund{{fund, 3}}, funds) {
+		t.Errorf("Unexpected result %v, %v", funds, err)
+	}
+	if _, err := ParseKCFSplit(fund.Hex() + ":0"); err != errInvalidKCFSplit {
+		t.Errorf("Want %v, got %v", errInvalidKCFSplit, err)
+	}
+}
+
+func TestValidateRemainderPolicy(t *testing.T) {
+	for _, policy := range []string{"", "burn", "proposer", "kff", "carry"} {
+		if err := ValidateRemainderPolicy(policy); err != nil {
+			t.Errorf("Unexpected error %v for %q", err, policy)
+		}
+	}
+	for _, policy := range []string{"kgf", "KFF", "carry-over", " "} {
+		if err := ValidateRemainderPolicy(policy); err != errInvalidRemainderPolicy {
+			t.Errorf("Want %v for %q, got %v", errInvalidRemainderPolicy, policy, err)
+		}
+	}
+}
+
+func TestParseProposerSplit(t *testing.T) {
+	var (
+		rewardbase1 = common.HexToAddress("0x0000000000000000000000000000000000000101")
+		rewardbase2 = common.HexToAddress("0x0000000000000000000000000000000000000102")
+		fund1       = common.HexToAddress("0x0000000000000000000000000000000000000401")
+		fund2       = common.HexToAddress("0x0000000000000000000000000000000000000402")
+	)
+
+	testcases := []struct {
+		split    string
+		expected map[common.Address][]RewardFund
+		ok       bool
+	}{
+		{"", nil, true},
+		{rewardbase1.Hex() + "=" + fund1.Hex() + ":80," + fund2.Hex() + ":20", map[common.Address][]RewardFund{
+			rewardbase1: {{fund1, 80}, {fund2, 20}},
+		}, true},
+		{rewardbase1.Hex() + "=" + fund1.Hex() + ":1;" + rewardbase2.Hex() + "=" + rewardbase2.Hex() + ":1," + fund1.Hex() + ":1", map[common.Address][]RewardFund{
+			rewardbase1: {{fund1, 1}},
+			rewardbase2: {{rewardbase2, 1}, {fund1, 1}},
+		}, true},
+		{rewardbase1.Hex() + "=", nil, false},                                                                      // no fund
+		{rewardbase1.Hex() + "=" + fund1.Hex() + ":0", nil, false},                                                 // zero weight
+		{rewardbase1.Hex() + "=" + fund1.Hex() + ":1;" + rewardbase1.Hex() + "=" + fund2.Hex() + ":1", nil, false}, // duplicated rewardbase
+		{common.Address{}.Hex() + "=" + fund1.Hex() + ":1", nil, false},                                            // empty rewardbase
+		{fund1.Hex() + ":1", nil, false},                                                                           // missing rewardbase
+		{rewardbase1.Hex() + "=" + fund1.Hex() + ":1;", nil, false},                                                // trailing semicolon
+	}
+
+	for _, tc := range testcases {
+		schedules, err := ParseProposerSplit(tc.split)
+		if ok := err == nil; ok != tc.ok {
+			t.Errorf("Want ok %v, got %v for %q", tc.ok, ok, tc.split)
+		}
+		if tc.ok && !reflect.DeepEqual(tc.expected, schedules) {
+			t.Errorf("Want %v, got %v for %q", tc.expected, schedules, tc.split)
+		}
+		if !tc.ok && err != errInvalidProposerSplit {
+			t.Errorf("Want %v, got %v for %q", errInvalidProposerSplit, err, tc.split)
+		}
+	}
+}
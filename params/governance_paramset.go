@@ -5,8 +5,6 @@ import (
 	"errors"
 	"math/big"
 	"reflect"
-	"strconv"
-	"strings"
 
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
@@ -163,19 +161,7 @@ var (
 		parseValue:    parseValueString,
 		parseBytes:    parseBytesString,
 		validate: func(v interface{}) bool {
-			strs := strings.Split(v.(string), "/")
-			if len(strs) != RewardSliceCount {
-				return false
-			}
-			sum := 0
-			for _, s := range strs {
-				n, err := strconv.Atoi(s)
-				if err != nil || n < 0 {
-					return false
-				}
-				sum += n
-			}
-			return sum == 100
+			return IsValidRatio(v.(string), RewardSliceCount)
 		},
 	}
 
@@ -184,19 +170,55 @@ var (
 		parseValue:    parseValueString,
 		parseBytes:    parseBytesString,
 		validate: func(v interface{}) bool {
-			strs := strings.Split(v.(string), "/")
-			if len(strs) != RewardKip82SliceCount {
-				return false
-			}
-			sum := 0
-			for _, s := range strs {
-				n, err := strconv.Atoi(s)
-				if err != nil || n < 0 {
-					return false
-				}
-				sum += n
-			}
-			return sum == 100
+			return IsValidRatio(v.(string), RewardKip82SliceCount)
+		},
+	}
+
+	govParamTypeKFFSplit = &govParamType{
+		canonicalType: reflect.TypeOf(""),
+		parseValue:    parseValueString,
+		parseBytes:    parseBytesString,
+		validate: func(v interface{}) bool {
+			_, err := ParseKFFSplit(v.(string))
+			return err == nil
+		},
+	}
+
+	govParamTypeKCFSplit = &govParamType{
+		canonicalType: reflect.TypeOf(""),
+		parseValue:    parseValueString,
+		parseBytes:    parseBytesString,
+		validate: func(v interface{}) bool {
+			_, err := ParseKCFSplit(v.(string))
+			return err == nil
+		},
+	}
+
+	govParamTypeProposerSplit = &govParamType{
+		canonicalType: reflect.TypeOf(""),
+		parseValue:    parseValueString,
+		parseBytes:    parseBytesString,
+		validate: func(v interface{}) bool {
+			_, err := ParseProposerSplit(v.(string))
+			return err == nil
+		},
+	}
+
+	govParamTypeRemainderPolicy = &govParamType{
+		canonicalType: reflect.TypeOf(""),
+		parseValue:    parseValueString,
+		parseBytes:    parseBytesString,
+		validate: func(v interface{}) bool {
+			return ValidateRemainderPolicy(v.(string)) == nil
+		},
+	}
+
+	govParamTypeBurnRatio = &govParamType{
+		canonicalType: govParamTypeUint64.canonicalType,
+		parseValue:    govParamTypeUint64.parseValue,
+		parseBytes:    govParamTypeUint64.parseBytes,
+		validate: func(v interface{}) bool {
+			return v.(uint64) <= 100
 		},
 	}
 
@@ -241,6 +263,14 @@ var govParamTypes = map[int]*govParamType{
 	BaseFeeDenominator:        govParamTypeUint64,
 	GovParamContract:          govParamTypeAddress,
 	DeriveShaImpl:             govParamTypeUint64,
+	RewardRedirectAddress:     govParamTypeAddress,
+	KFFSplit:                  govParamTypeKFFSplit,
+	BurnRatio:                 govParamTypeBurnRatio,
+	PayoutGasStipend:          govParamTypeUint64,
+	KCFSplit:                  govParamTypeKCFSplit,
+	RemainderPolicy:           govParamTypeRemainderPolicy,
+	ProposerSplit:             govParamTypeProposerSplit,
+	MinStakeInclusive:         govParamTypeBool,
 }
 
 var govParamNames = map[string]int{
@@ -257,8 +287,16 @@ var govParamNames = map[string]int{
 	"reward.useginicoeff":             UseGiniCoeff,
 	"reward.deferredtxfee":            DeferredTxFee,
 	"reward.minimumstake":             MinimumStake,
+	"reward.minstakeinclusive":        MinStakeInclusive,
 	"reward.stakingupdateinterval":    StakeUpdateInterval,
 	"reward.proposerupdateinterval":   ProposerRefreshInterval,
+	"reward.redirectaddress":          RewardRedirectAddress,
+	"reward.kffsplit":                 KFFSplit,
+	"reward.burnratio":                BurnRatio,
+	"reward.payoutgasstipend":         PayoutGasStipend,
+	"reward.kcfsplit":                 KCFSplit,
+	"reward.remainderpolicy":          RemainderPolicy,
+	"reward.proposersplit":            ProposerSplit,
 	"kip71.lowerboundbasefee":         LowerBoundBaseFee,
 	"kip71.upperboundbasefee":         UpperBoundBaseFee,
 	"kip71.gastarget":                 GasTarget,
@@ -390,6 +428,30 @@ func NewGovParamSetChainConfig(config *ChainConfig) (*GovParamSet, error) {
 			if config.Governance.Reward.MinimumStake != nil {
 				items[MinimumStake] = config.Governance.Reward.MinimumStake.String()
 			}
+			if !common.EmptyAddress(config.Governance.Reward.RedirectAddress) {
+				items[RewardRedirectAddress] = config.Governance.Reward.RedirectAddress
+			}
+			if config.Governance.Reward.KFFSplit != "" {
+				items[KFFSplit] = config.Governance.Reward.KFFSplit
+			}
+			if config.Governance.Reward.BurnRatio != nil {
+				items[BurnRatio] = *config.Governance.Reward.BurnRatio
+			}
+			if config.Governance.Reward.PayoutGasStipend != 0 {
+				items[PayoutGasStipend] = config.Governance.Reward.PayoutGasStipend
+			}
+			if config.Governance.Reward.KCFSplit != "" {
+				items[KCFSplit] = config.Governance.Reward.KCFSplit
+			}
+			if config.Governance.Reward.RemainderPolicy != "" {
+				items[RemainderPolicy] = config.Governance.Reward.RemainderPolicy
+			}
+			if config.Governance.Reward.ProposerSplit != "" {
+				items[ProposerSplit] = config.Governance.Reward.ProposerSplit
+			}
+			if config.Governance.Reward.MinStakeInclusive {
+				items[MinStakeInclusive] = config.Governance.Reward.MinStakeInclusive
+			}
 		}
 		if config.Governance.KIP71 != nil {
 			items[LowerBoundBaseFee] = config.Governance.KIP71.LowerBoundBaseFee
@@ -507,6 +569,31 @@ func (p *GovParamSet) ToRewardConfig() *RewardConfig {
 	if _, ok := p.Get(MinimumStake); ok {
 		ret.MinimumStake = p.MinimumStakeBig()
 	}
+	if _, ok := p.Get(RewardRedirectAddress); ok {
+		ret.RedirectAddress = p.RewardRedirectAddress()
+	}
+	if _, ok := p.Get(KFFSplit); ok {
+		ret.KFFSplit = p.KFFSplit()
+	}
+	if _, ok := p.Get(BurnRatio); ok {
+		burnRatio := p.BurnRatio()
+		ret.BurnRatio = &burnRatio
+	}
+	if _, ok := p.Get(PayoutGasStipend); ok {
+		ret.PayoutGasStipend = p.PayoutGasStipend()
+	}
+	if _, ok := p.Get(KCFSplit); ok {
+		ret.KCFSplit = p.KCFSplit()
+	}
+	if _, ok := p.Get(RemainderPolicy); ok {
+		ret.RemainderPolicy = p.RemainderPolicy()
+	}
+	if _, ok := p.Get(ProposerSplit); ok {
+		ret.ProposerSplit = p.ProposerSplit()
+	}
+	if _, ok := p.Get(MinStakeInclusive); ok {
+		ret.MinStakeInclusive = p.MinStakeInclusive()
+	}
 
 	return &ret
 }
@@ -639,6 +726,38 @@ func (p *GovParamSet) ProposerRefreshInterval() uint64 {
 	return p.MustGet(ProposerRefreshInterval).(uint64)
 }
 
+func (p *GovParamSet) RewardRedirectAddress() common.Address {
+	return p.MustGet(RewardRedirectAddress).(common.Address)
+}
+
+func (p *GovParamSet) KFFSplit() string {
+	return p.MustGet(KFFSplit).(string)
+}
+
+func (p *GovParamSet) BurnRatio() uint64 {
+	return p.MustGet(BurnRatio).(uint64)
+}
+
+func (p *GovParamSet) PayoutGasStipend() uint64 {
+	return p.MustGet(PayoutGasStipend).(uint64)
+}
+
+func (p *GovParamSet) KCFSplit() string {
+	return p.MustGet(KCFSplit).(string)
+}
+
+func (p *GovParamSet) RemainderPolicy() string {
+	return p.MustGet(RemainderPolicy).(string)
+}
+
+func (p *GovParamSet) ProposerSplit() string {
+	return p.MustGet(ProposerSplit).(string)
+}
+
+func (p *GovParamSet) MinStakeInclusive() bool {
+	return p.MustGet(MinStakeInclusive).(bool)
+}
+
 func (p *GovParamSet) Timeout() uint64 {
 	return p.MustGet(Timeout).(uint64)
 }
@@ -57,6 +57,29 @@ func TestGovParamSet_ParseValue(t *testing.T) {
 		{govParamTypeRatio, "1/2/3", nil, false},
 		{govParamTypeRatio, "", nil, false},
 
+		{govParamTypeKFFSplit, "", "", true},
+		{govParamTypeKFFSplit, "0x0000000000000000000000000000000000000401:70,0x0000000000000000000000000000000000000402:30", "0x0000000000000000000000000000000000000401:70,0x0000000000000000000000000000000000000402:30", true},
+		{govParamTypeKFFSplit, "0x0000000000000000000000000000000000000401:0", nil, false},
+		{govParamTypeKFFSplit, "70/30", nil, false},
+		{govParamTypeKFFSplit, 1, nil, false},
+
+		{govParamTypeKCFSplit, "", "", true},
+		{govParamTypeKCFSplit, "0x0000000000000000000000000000000000000401:1", "0x0000000000000000000000000000000000000401:1", true},
+		{govParamTypeKCFSplit, "0x0000000000000000000000000000000000000401:0", nil, false},
+
+		{govParamTypeRemainderPolicy, "", "", true},
+		{govParamTypeRemainderPolicy, "proposer", "proposer", true},
+		{govParamTypeRemainderPolicy, "Proposer", nil, false},
+
+		{govParamTypeProposerSplit, "", "", true},
+		{govParamTypeProposerSplit, "0x0000000000000000000000000000000000000101=0x0000000000000000000000000000000000000401:1", "0x0000000000000000000000000000000000000101=0x0000000000000000000000000000000000000401:1", true},
+		{govParamTypeProposerSplit, "0x0000000000000000000000000000000000000401:1", nil, false},
+
+		{govParamTypeBurnRatio, 0, uint64(0), true},
+		{govParamTypeBurnRatio, uint64(100), uint64(100), true},
+		{govParamTypeBurnRatio, 101, nil, false},
+		{govParamTypeBurnRatio, "50", nil, false},
+
 		{govParamTypeBool, true, true, true},
 		{govParamTypeBool, 0, nil, false},
 		{govParamTypeBool, "", nil, false},
@@ -190,6 +190,7 @@ const (
 	DefaultBlockGenerationInterval    = int64(1) // unit: seconds
 	DefaultBlockGenerationTimeLimit   = 250 * time.Millisecond
 	DefaultOpcodeComputationCostLimit = uint64(100000000)
+	DefaultIdleBlockInterval          = time.Duration(0) // zero means that an empty block is never sealed
 )
 
 var (
@@ -213,6 +214,15 @@ var (
 	BlockGenerationInterval = DefaultBlockGenerationInterval
 	// Computation cost limit for a tx. For now, it is approximately 100 ms
 	OpcodeComputationCostLimit = DefaultOpcodeComputationCostLimit
+
+	// Idle block suppression for service chains. If it is enabled, a CN does not seal a block
+	// without transactions until IdleBlockInterval has passed since the parent block. It only
+	// takes effect on a chain with a single validator, because the other validators would
+	// change the round when the proposal is skipped.
+	// Suppressed slots are simply not produced, so no reward is minted for them and
+	// block numbers stay contiguous.
+	IdleBlockSuppression = false
+	IdleBlockInterval    = DefaultIdleBlockInterval
 )
 
 // istanbul BFT
@@ -0,0 +1,136 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// Roles of the addresses derived from the staking information.
+const (
+	AddressRoleValidatorNode    = "validator-node"
+	AddressRoleValidatorStaking = "validator-staking"
+	AddressRoleProposerPool     = "proposer-pool" // reward address of a validator
+	AddressRoleKFF              = "kff"           // formerly known as KGF or PoC
+	AddressRoleKCF              = "kcf"           // formerly known as KIR
+)
+
+// AddressLabel is a human-readable label of an address.
+type AddressLabel struct {
+	Name string `json:"name,omitempty"`
+	Role string `json:"role,omitempty"`
+}
+
+// AddressLabelRegistry is a node-local registry of address labels. The labels are sourced
+// from a file and the staking information read from the AddressBook contract.
+// A label from the file takes precedence over the role derived from the staking information.
+type AddressLabelRegistry struct {
+	labels map[common.Address]AddressLabel
+}
+
+func NewAddressLabelRegistry(labels map[common.Address]AddressLabel) *AddressLabelRegistry {
+	if labels == nil {
+		labels = make(map[common.Address]AddressLabel)
+	}
+	return &AddressLabelRegistry{labels: labels}
+}
+
+// LoadAddressLabelRegistry reads the labels from the JSON file which maps addresses to labels, e.g.
+//
+//	{"0x...": {"name": "Foundation", "role": "kff"}}
+//
+// An empty path returns a registry with the labels derived from the staking information only.
+func LoadAddressLabelRegistry(path string) (*AddressLabelRegistry, error) {
+	if path == "" {
+		return NewAddressLabelRegistry(nil), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[common.Address]AddressLabel)
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return nil, fmt.Errorf("invalid address label file %s: %v", path, err)
+	}
+	return NewAddressLabelRegistry(labels), nil
+}
+
+// Labels returns the labels of the given addresses. The staking information can be nil.
+// Addresses without any label are omitted.
+func (r *AddressLabelRegistry) Labels(stakingInfo *StakingInfo, addrs ...common.Address) map[common.Address]AddressLabel {
+	roles := stakingRoles(stakingInfo)
+	labels := make(map[common.Address]AddressLabel)
+	for _, addr := range addrs {
+		label := r.labels[addr]
+		if label.Role == "" {
+			label.Role = roles[addr]
+		}
+		if label != (AddressLabel{}) {
+			labels[addr] = label
+		}
+	}
+	return labels
+}
+
+// stakingRoles returns the roles of the addresses found in the staking information.
+func stakingRoles(stakingInfo *StakingInfo) map[common.Address]string {
+	roles := make(map[common.Address]string)
+	if stakingInfo == nil {
+		return roles
+	}
+	for _, addr := range stakingInfo.CouncilNodeAddrs {
+		roles[addr] = AddressRoleValidatorNode
+	}
+	for _, addr := range stakingInfo.CouncilStakingAddrs {
+		roles[addr] = AddressRoleValidatorStaking
+	}
+	for _, addr := range stakingInfo.CouncilRewardAddrs {
+		roles[addr] = AddressRoleProposerPool
+	}
+	if !common.EmptyAddress(stakingInfo.KFFAddr) {
+		roles[stakingInfo.KFFAddr] = AddressRoleKFF
+	}
+	if !common.EmptyAddress(stakingInfo.KCFAddr) {
+		roles[stakingInfo.KCFAddr] = AddressRoleKCF
+	}
+	return roles
+}
+
+// StakingInfoAddrs returns all addresses in the staking information.
+func StakingInfoAddrs(stakingInfo *StakingInfo) []common.Address {
+	if stakingInfo == nil {
+		return nil
+	}
+	addrs := make([]common.Address, 0, 3*len(stakingInfo.CouncilNodeAddrs)+2)
+	addrs = append(addrs, stakingInfo.CouncilNodeAddrs...)
+	addrs = append(addrs, stakingInfo.CouncilStakingAddrs...)
+	addrs = append(addrs, stakingInfo.CouncilRewardAddrs...)
+	return append(addrs, stakingInfo.KFFAddr, stakingInfo.KCFAddr)
+}
+
+// All returns the labels from the file and the staking information.
+func (r *AddressLabelRegistry) All(stakingInfo *StakingInfo) map[common.Address]AddressLabel {
+	addrs := StakingInfoAddrs(stakingInfo)
+	for addr := range r.labels {
+		addrs = append(addrs, addr)
+	}
+	return r.Labels(stakingInfo, addrs...)
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAddressLabelRegistry(t *testing.T) {
+	registry, err := LoadAddressLabelRegistry("")
+	require.NoError(t, err)
+	assert.Empty(t, registry.labels)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "labels.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"0x0000000000000000000000000000000000000001": {"name": "Foundation", "role": "kff"}}`), 0o600))
+	registry, err = LoadAddressLabelRegistry(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[common.Address]AddressLabel{
+		common.HexToAddress("0x1"): {Name: "Foundation", Role: AddressRoleKFF},
+	}, registry.labels)
+
+	require.NoError(t, os.WriteFile(path, []byte(`["not a map"]`), 0o600))
+	_, err = LoadAddressLabelRegistry(path)
+	assert.Error(t, err)
+
+	_, err = LoadAddressLabelRegistry(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestAddressLabelRegistry_Labels(t *testing.T) {
+	var (
+		node    = common.HexToAddress("0x11")
+		staking = common.HexToAddress("0x12")
+		pool    = common.HexToAddress("0x13")
+		kff     = common.HexToAddress("0x14")
+		kcf     = common.HexToAddress("0x15")
+		custom  = common.HexToAddress("0x16")
+		unknown = common.HexToAddress("0x17")
+	)
+	stakingInfo := &StakingInfo{
+		CouncilNodeAddrs:    []common.Address{node},
+		CouncilStakingAddrs: []common.Address{staking},
+		CouncilRewardAddrs:  []common.Address{pool},
+		KFFAddr:             kff,
+		KCFAddr:             kcf,
+	}
+	registry := NewAddressLabelRegistry(map[common.Address]AddressLabel{
+		pool:   {Name: "Validator A"},
+		custom: {Name: "Exchange", Role: "custom"},
+	})
+
+	// the name from the file is kept and the role is filled from the staking information
+	labels := registry.Labels(stakingInfo, pool, kff, custom, unknown)
+	assert.Equal(t, map[common.Address]AddressLabel{
+		pool:   {Name: "Validator A", Role: AddressRoleProposerPool},
+		kff:    {Role: AddressRoleKFF},
+		custom: {Name: "Exchange", Role: "custom"},
+	}, labels)
+
+	// without the staking information, only the labels from the file are returned
+	assert.Equal(t, map[common.Address]AddressLabel{
+		pool: {Name: "Validator A"},
+	}, registry.Labels(nil, pool, kff))
+
+	all := registry.All(stakingInfo)
+	assert.Len(t, all, 6)
+	assert.Equal(t, AddressLabel{Role: AddressRoleValidatorNode}, all[node])
+	assert.Equal(t, AddressLabel{Role: AddressRoleValidatorStaking}, all[staking])
+	assert.Equal(t, AddressLabel{Role: AddressRoleKCF}, all[kcf])
+}
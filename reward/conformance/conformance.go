@@ -0,0 +1,360 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package conformance provides the test vectors of the block reward, so that alternative
+// implementations and forks of klaytn can prove that they distribute the same rewards.
+//
+// A vector consists of a chain config, the header fields used by the reward calculation
+// and the staking information, together with the expected RewardSpec of the deferred
+// rewards, i.e. the rewards distributed at the end of the block processing. The vectors
+// cover every combination of the hardforks changing the reward with the reward policies
+// and governance parameters. Generate creates the vectors from this implementation, and
+// Run validates this implementation against a vector.
+//
+// The vectors are stored in testdata/vectors.json, which is regenerated by `go generate`.
+// Other implementations are expected to compare their RewardSpec with the expected one
+// field by field, since the JSON is canonical: all the maps are sorted by the address.
+// Note that the amounts are JSON numbers in peb, which exceed the precision of a float64.
+package conformance
+
+//go:generate go run gen_vectors.go
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+)
+
+var (
+	errNoConfig       = errors.New("the vector has no config")
+	errNoHeader       = errors.New("the vector has no header")
+	errRewardMismatch = errors.New("the reward does not match the vector")
+)
+
+// Header is the fields of a header used by the reward calculation.
+// The JSON field names are the same as types.Header.
+type Header struct {
+	Number     *hexutil.Big   `json:"number"`
+	Rewardbase common.Address `json:"reward"`
+	GasUsed    hexutil.Uint64 `json:"gasUsed"`
+	BaseFee    *hexutil.Big   `json:"baseFeePerGas,omitempty"`
+}
+
+func (h *Header) toHeader() *types.Header {
+	header := &types.Header{
+		Number:     (*big.Int)(h.Number),
+		Rewardbase: h.Rewardbase,
+		GasUsed:    uint64(h.GasUsed),
+	}
+	if h.BaseFee != nil {
+		header.BaseFee = (*big.Int)(h.BaseFee)
+	}
+	return header
+}
+
+// Vector is a test vector of the block reward.
+type Vector struct {
+	Name        string              `json:"name"`
+	Config      *params.ChainConfig `json:"config"`
+	Header      *Header             `json:"header"`
+	StakingInfo *reward.StakingInfo `json:"stakingInfo,omitempty"` // nil if not used or not available
+	Expected    *reward.RewardSpec  `json:"expected"`
+}
+
+// Run calculates the reward of the vector and compares it with the expected one.
+func (v *Vector) Run() error {
+	spec, err := v.calc()
+	if err != nil {
+		return err
+	}
+	want, err := json.Marshal(v.Expected)
+	if err != nil {
+		return err
+	}
+	got, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(want, got) {
+		return fmt.Errorf("%w: %s\nwant %s\ngot  %s", errRewardMismatch, v.Name, want, got)
+	}
+	return nil
+}
+
+func (v *Vector) calc() (*reward.RewardSpec, error) {
+	if v.Config == nil {
+		return nil, errNoConfig
+	}
+	if v.Header == nil || v.Header.Number == nil {
+		return nil, errNoHeader
+	}
+	pset, err := params.NewGovParamSetChainConfig(v.Config)
+	if err != nil {
+		return nil, err
+	}
+	header := v.Header.toHeader()
+	rules := v.Config.Rules(header.Number)
+	return reward.GetRewardPolicy(header.Number, pset).CalcDeferredReward(header, rules, pset, v.StakingInfo)
+}
+
+// ReadVectors reads the vectors written by WriteVectors.
+func ReadVectors(r io.Reader) ([]*Vector, error) {
+	var vectors []*Vector
+	if err := json.NewDecoder(r).Decode(&vectors); err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
+
+// WriteVectors writes the vectors in the canonical JSON.
+func WriteVectors(w io.Writer, vectors []*Vector) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(vectors)
+}
+
+// hardforks is a combination of the hardforks changing the reward.
+type hardforks struct {
+	name           string
+	magma          bool
+	kore           bool
+	rewardRedirect bool
+	pebStaking     bool
+	rewardSplit    bool
+}
+
+var allHardforks = []hardforks{
+	{name: "istanbul"},
+	{name: "magma", magma: true},
+	{name: "kore", magma: true, kore: true},
+	{name: "istanbul+redirect", rewardRedirect: true},
+	{name: "magma+redirect", magma: true, rewardRedirect: true},
+	{name: "kore+redirect", magma: true, kore: true, rewardRedirect: true},
+	{name: "kore+pebstaking", magma: true, kore: true, pebStaking: true},
+	{name: "kore+split", magma: true, kore: true, rewardSplit: true},
+}
+
+// scenario is a set of the reward policy, governance parameters and staking information.
+type scenario struct {
+	name        string
+	policy      uint64
+	modify      func(config *params.RewardConfig)
+	stakingInfo func() *reward.StakingInfo
+}
+
+var (
+	testRewardbase = common.HexToAddress("0x0000000000000000000000000000000000000100")
+	testRedirect   = common.HexToAddress("0x0000000000000000000000000000000000000200")
+	testKFF        = common.HexToAddress("0x0000000000000000000000000000000000000301")
+	testKCF        = common.HexToAddress("0x0000000000000000000000000000000000000302")
+	testFund1      = common.HexToAddress("0x0000000000000000000000000000000000000401")
+	testFund2      = common.HexToAddress("0x0000000000000000000000000000000000000402")
+	testFund3      = common.HexToAddress("0x0000000000000000000000000000000000000403")
+)
+
+// testStakingInfo has a node below the minimum stake and two nodes sharing a reward address.
+// Since the PebStaking hardfork, the exact staking amounts in peb are filled by withPebAmounts.
+func testStakingInfo() *reward.StakingInfo {
+	addr := func(n int64) common.Address { return common.BigToAddress(big.NewInt(n)) }
+	return &reward.StakingInfo{
+		BlockNum:              86400,
+		CouncilNodeAddrs:      []common.Address{addr(0x1001), addr(0x1002), addr(0x1003), addr(0x1004)},
+		CouncilStakingAddrs:   []common.Address{addr(0x2001), addr(0x2002), addr(0x2003), addr(0x2004)},
+		CouncilRewardAddrs:    []common.Address{addr(0x3001), addr(0x3002), addr(0x3003), addr(0x3002)},
+		KCFAddr:               testKCF,
+		KFFAddr:               testKFF,
+		CouncilStakingAmounts: []uint64{10000000, 7000000, 3000000, 6000000},
+	}
+}
+
+// withPebAmounts fills the peb staking amounts of stakingInfo as newStakingInfo does after
+// the PebStaking hardfork. Each node stakes a fraction of KLAY more than its KLAY amount.
+func withPebAmounts(stakingInfo *reward.StakingInfo) *reward.StakingInfo {
+	for i, amount := range stakingInfo.CouncilStakingAmounts {
+		peb := new(big.Int).Mul(new(big.Int).SetUint64(amount), big.NewInt(params.KLAY))
+		peb = peb.Add(peb, big.NewInt(int64(i+1)*params.KLAY/10))
+		stakingInfo.CouncilStakingAmountsPeb = append(stakingInfo.CouncilStakingAmountsPeb, peb)
+	}
+	return stakingInfo
+}
+
+var allScenarios = []scenario{
+	{
+		name:   "simple/deferred-fee",
+		policy: uint64(istanbul.RoundRobin),
+		modify: func(config *params.RewardConfig) { config.DeferredTxFee = true },
+	},
+	{
+		name:   "simple/immediate-fee",
+		policy: uint64(istanbul.RoundRobin),
+	},
+	{
+		name:   "simple/burn-ratio",
+		policy: uint64(istanbul.RoundRobin),
+		modify: func(config *params.RewardConfig) {
+			burnRatio := uint64(30)
+			config.DeferredTxFee = true
+			config.BurnRatio = &burnRatio
+		},
+	},
+	{
+		name:        "staking/deferred-fee",
+		policy:      uint64(istanbul.WeightedRandom),
+		modify:      func(config *params.RewardConfig) { config.DeferredTxFee = true },
+		stakingInfo: testStakingInfo,
+	},
+	{
+		name:        "staking/immediate-fee",
+		policy:      uint64(istanbul.WeightedRandom),
+		stakingInfo: testStakingInfo,
+	},
+	{
+		name:   "staking/no-staking-info",
+		policy: uint64(istanbul.WeightedRandom),
+		modify: func(config *params.RewardConfig) { config.DeferredTxFee = true },
+	},
+	{
+		name:   "staking/no-kff-kcf",
+		policy: uint64(istanbul.WeightedRandom),
+		modify: func(config *params.RewardConfig) { config.DeferredTxFee = true },
+		stakingInfo: func() *reward.StakingInfo {
+			stakingInfo := testStakingInfo()
+			stakingInfo.KFFAddr = common.Address{}
+			stakingInfo.KCFAddr = common.Address{}
+			return stakingInfo
+		},
+	},
+	{
+		name:   "staking/decimal-ratio",
+		policy: uint64(istanbul.WeightedRandom),
+		modify: func(config *params.RewardConfig) {
+			config.DeferredTxFee = true
+			config.Ratio = "34.5/54.5/11"
+			config.Kip82Ratio = "20.25/79.75"
+		},
+		stakingInfo: testStakingInfo,
+	},
+	{
+		name:   "staking/fund-split",
+		policy: uint64(istanbul.WeightedRandom),
+		modify: func(config *params.RewardConfig) {
+			config.DeferredTxFee = true
+			config.KFFSplit = testFund1.Hex() + ":70," + testFund2.Hex() + ":30"
+			config.KCFSplit = testFund3.Hex() + ":3," + testFund1.Hex() + ":1"
+		},
+		stakingInfo: testStakingInfo,
+	},
+}
+
+func newTestConfig(forks hardforks, s scenario) *params.ChainConfig {
+	rewardConfig := &params.RewardConfig{
+		MintingAmount:          big.NewInt(6.4e18),
+		Ratio:                  "50/40/10",
+		Kip82Ratio:             "20/80",
+		StakingUpdateInterval:  86400,
+		ProposerUpdateInterval: 3600,
+		MinimumStake:           big.NewInt(5000000),
+	}
+	if forks.rewardRedirect {
+		rewardConfig.RedirectAddress = testRedirect
+	}
+	if s.modify != nil {
+		s.modify(rewardConfig)
+	}
+
+	config := &params.ChainConfig{
+		ChainID:                  big.NewInt(1000),
+		IstanbulCompatibleBlock:  big.NewInt(0),
+		LondonCompatibleBlock:    big.NewInt(0),
+		EthTxTypeCompatibleBlock: big.NewInt(0),
+		UnitPrice:                25000000000,
+		Istanbul: &params.IstanbulConfig{
+			Epoch:          604800,
+			ProposerPolicy: s.policy,
+			SubGroupSize:   22,
+		},
+		Governance: &params.GovernanceConfig{
+			GovernanceMode: "none",
+			Reward:         rewardConfig,
+		},
+	}
+	if forks.magma {
+		config.MagmaCompatibleBlock = big.NewInt(0)
+	}
+	if forks.kore {
+		config.KoreCompatibleBlock = big.NewInt(0)
+	}
+	if forks.rewardRedirect {
+		config.RewardRedirectCompatibleBlock = big.NewInt(0)
+	}
+	if forks.pebStaking {
+		config.PebStakingCompatibleBlock = big.NewInt(0)
+	}
+	if forks.rewardSplit {
+		config.RewardSplitCompatibleBlock = big.NewInt(0)
+	}
+	return config
+}
+
+func newTestHeader(forks hardforks) *Header {
+	header := &Header{
+		Number:     (*hexutil.Big)(big.NewInt(100000)),
+		Rewardbase: testRewardbase,
+		GasUsed:    hexutil.Uint64(10 * params.TxGas),
+	}
+	if forks.magma {
+		header.BaseFee = (*hexutil.Big)(big.NewInt(30000000000))
+	}
+	return header
+}
+
+// Generate creates the vectors of every combination of the hardforks and the scenarios,
+// with the rewards calculated by this implementation.
+func Generate() ([]*Vector, error) {
+	var vectors []*Vector
+	for _, forks := range allHardforks {
+		for _, s := range allScenarios {
+			v := &Vector{
+				Name:   forks.name + "/" + s.name,
+				Config: newTestConfig(forks, s),
+				Header: newTestHeader(forks),
+			}
+			if s.stakingInfo != nil {
+				v.StakingInfo = s.stakingInfo()
+				if forks.pebStaking {
+					v.StakingInfo = withPebAmounts(v.StakingInfo)
+				}
+			}
+			spec, err := v.calc()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", v.Name, err)
+			}
+			v.Expected = spec
+			vectors = append(vectors, v)
+		}
+	}
+	return vectors, nil
+}
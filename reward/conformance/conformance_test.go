@@ -0,0 +1,69 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package conformance
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const vectorsFile = "testdata/vectors.json"
+
+func readTestVectors(t *testing.T) []*Vector {
+	f, err := os.Open(vectorsFile)
+	require.Nil(t, err)
+	defer f.Close()
+
+	vectors, err := ReadVectors(f)
+	require.Nil(t, err)
+	require.NotEmpty(t, vectors)
+	return vectors
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range readTestVectors(t) {
+		assert.Nil(t, v.Run(), v.Name)
+	}
+}
+
+// TestVectors_UpToDate fails if the vectors file is not regenerated after the scenarios
+// or the reward calculation are changed. Run `go generate` in this directory to update it.
+func TestVectors_UpToDate(t *testing.T) {
+	vectors, err := Generate()
+	require.Nil(t, err)
+	assert.Equal(t, len(allHardforks)*len(allScenarios), len(vectors))
+
+	var buf bytes.Buffer
+	require.Nil(t, WriteVectors(&buf, vectors))
+	expected, err := os.ReadFile(vectorsFile)
+	require.Nil(t, err)
+	assert.Equal(t, string(expected), buf.String())
+}
+
+func TestVector_Run_Mismatch(t *testing.T) {
+	v := readTestVectors(t)[0]
+	v.Expected.Minted = new(big.Int).Add(v.Expected.Minted, big.NewInt(1))
+	assert.ErrorIs(t, v.Run(), errRewardMismatch)
+
+	v.Header = nil
+	assert.ErrorIs(t, v.Run(), errNoHeader)
+}
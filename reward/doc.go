@@ -42,8 +42,13 @@ StakingInfo is made every 86400 blocks (stakingInterval) and used in a next inte
 		UseGini               bool             // configure whether Gini is used or not
 		Gini                  float64          // Gini coefficient
 		CouncilStakingAmounts []uint64         // StakingAmounts of Council. They are derived from Staking addresses of council
+
+		CouncilStakingAmountsPeb []*big.Int // Exact StakingAmounts of Council in peb, filled after the PebStaking hardfork
 	}
 
+After the PebStaking hardfork, the staking reward is split by CouncilStakingAmountsPeb instead of
+CouncilStakingAmounts, which are truncated to KLAY and capped.
+
 StakingInfo is managed by a StakingManager which has a cache for saving StakingInfos.
 The StakingManager calculates block number with interval to find a stakingInfo for current block
 and returns correct stakingInfo to use.
@@ -54,6 +59,11 @@ and returns correct stakingInfo to use.
  - StakingManager
  - addressBookConnector
  - stakingInfoCache
+ - rewardSpecCache
+ - paidRewards
+ - FeeAudit
+ - RewardAnomaly
+ - RewardSlot
  - stakingInfo
 
 
@@ -65,16 +75,38 @@ The detail information of KFF and KCF is available on Klaytn docs.
 Token Economy - https://docs.klaytn.foundation/content/klaytn/design/token-economy
 
 Configurations related to the reward system such as mintingAmount, ratio and unitPrice are determined by the Klaytn governance.
-All configurations are saved as rewardConfig on every epoch block (default 604,800 blocks) and managed by a rewardConfigCache.
+All configurations are saved by the governance on every epoch block (default 604,800 blocks) where they are changed.
+The configuration effective for a block is resolved by params.CalcGovernanceBlockNumber:
+the configuration saved on an epoch block takes effect after the next epoch block.
+Before the Kore hardfork, the configuration effective at the parent block is used.
 
 A proposer which has made a current block will get the reward of the block.
 A block reward is calculated by following steps.
 First, calculate totalReward by adding mintingAmount and totalTxFee (unitPrice * gasUsed).
 Second, divide totalReward by ratio (default 34/54/12 - proposer/KFF/KCF).
 Last, distribute reward to each address (proposer, KFF, KCF).
+After the RewardSplit hardfork, if reward.kffsplit or reward.kcfsplit is set by the governance, the KFF
+or KCF portion is further split among the funds by their weights instead of being paid to the KFF or
+KCF address.
+Likewise, if reward.proposersplit has a split schedule of the rewardbase of the proposer, the proposer
+portion is split among the funds of the schedule. The tx fee paid during the tx execution when it is
+not deferred is not split, since it is credited to the rewardbase before the block reward is paid.
+How the tx fee is burnt and how the reward is split depend on the hardfork in effect at the block.
+Each hardfork changing them registers its rewardStrategy in rewardStrategies, the latest first.
+The rounding remainders of the split and the staker shares go by reward.remainderpolicy after the
+RewardSplit hardfork: by default to KFF and the proposer respectively, or all burnt, paid to the
+proposer, paid to KFF, or carried over.
+The carried remainder is kept in the storage of system.RemainderCarryAddr, which only the block
+finalization writes, and shared by the stakers of the next block. When the policy is changed from
+carry, the remainder left is shared by the stakers of the next block likewise.
+The stakers portion is shared by the CNs staking more than the minimum stake in proportion to their
+stakes above it. The stakes are compared in peb. After the PebStaking hardfork, a CN staking exactly
+the minimum stake is also eligible if reward.minstakeinclusive is set by the governance, and then the
+stakes are counted from one KLAY below the minimum stake, so that the CN has a share.
 
  related struct
  - RewardDistributor
- - rewardConfigCache
+ - rewardConfig
+ - rewardStrategy
 */
 package reward
@@ -0,0 +1,80 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+)
+
+// NodeEffectiveStake is the stake of a consolidated CN and the part of it above the minimum stake.
+type NodeEffectiveStake struct {
+	RewardAddr     common.Address   `json:"rewardAddr"`
+	NodeAddrs      []common.Address `json:"nodeAddrs"`
+	Stake          *big.Int         `json:"stake"`
+	MinimumStake   *big.Int         `json:"minimumStake"`
+	EffectiveStake *big.Int         `json:"effectiveStake"` // the stake above the minimum stake, zero if not above
+	Eligible       bool             `json:"eligible"`       // whether the CN is eligible for the staking share
+}
+
+// EffectiveStakes is the effective stakes of the CNs by which the stakers portion of the block
+// reward is shared. The amounts are in the stake unit, KLAY or peb after the PebStaking hardfork.
+type EffectiveStakes struct {
+	BlockNumber         uint64                `json:"blockNumber"`
+	StakingInfoBlock    uint64                `json:"stakingInfoBlock"` // the block the staking information is fetched at
+	StakeUnit           string                `json:"stakeUnit"`
+	MinimumStake        *big.Int              `json:"minimumStake"`
+	MinStakeInclusive   bool                  `json:"minStakeInclusive"` // whether a stake equal to the minimum stake is eligible
+	TotalEffectiveStake *big.Int              `json:"totalEffectiveStake"`
+	Nodes               []*NodeEffectiveStake `json:"nodes"`
+}
+
+// CalcEffectiveStakes returns the effective stakes of the CNs of the staking information as
+// calcShares uses them. minStake is in KLAY. The block number is left for the caller to set.
+func CalcEffectiveStakes(stakingInfo *StakingInfo, minStake uint64, inclusive bool) *EffectiveStakes {
+	nodes, effectiveStakes, totalStakes, usePeb := calcEffectiveStakes(stakingInfo, minStake, inclusive)
+
+	result := &EffectiveStakes{
+		StakingInfoBlock:    stakingInfo.BlockNum,
+		StakeUnit:           "KLAY",
+		MinimumStake:        new(big.Int).SetUint64(minStake),
+		MinStakeInclusive:   inclusive,
+		TotalEffectiveStake: totalStakes,
+		Nodes:               make([]*NodeEffectiveStake, 0, len(nodes)),
+	}
+	if usePeb {
+		result.StakeUnit = "peb"
+		result.MinimumStake.Mul(result.MinimumStake, big.NewInt(params.KLAY))
+	}
+	for i, node := range nodes {
+		stake := &NodeEffectiveStake{
+			RewardAddr:     node.RewardAddr,
+			NodeAddrs:      node.NodeAddrs,
+			Stake:          nodeStake(node, usePeb),
+			MinimumStake:   new(big.Int).Set(result.MinimumStake),
+			EffectiveStake: big.NewInt(0),
+		}
+		if effectiveStakes[i] != nil {
+			stake.EffectiveStake = effectiveStakes[i]
+			stake.Eligible = true
+		}
+		result.Nodes = append(result.Nodes, stake)
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcEffectiveStakes(t *testing.T) {
+	// in KLAY, a CN at the minimum stake has no effective stake
+	stakes := CalcEffectiveStakes(genStakingInfo(3, nil, map[int]uint64{0: minStaking + 4, 1: minStaking + 1}), minStaking, false)
+	assert.Equal(t, "KLAY", stakes.StakeUnit)
+	assert.Equal(t, new(big.Int).SetUint64(minStaking), stakes.MinimumStake)
+	assert.Equal(t, big.NewInt(5), stakes.TotalEffectiveStake)
+	require.Len(t, stakes.Nodes, 3)
+	for i, effective := range []int64{4, 1, 0} {
+		assert.Equal(t, big.NewInt(effective), stakes.Nodes[i].EffectiveStake)
+		assert.Equal(t, stakes.MinimumStake, stakes.Nodes[i].MinimumStake)
+		assert.Equal(t, effective > 0, stakes.Nodes[i].Eligible)
+	}
+
+	// in peb after the PebStaking hardfork, the minimum stake is converted
+	stakes = CalcEffectiveStakes(genStakingInfoPeb(2, map[int]*big.Int{1: big.NewInt(7)}), minStaking, false)
+	minPeb := new(big.Int).Mul(new(big.Int).SetUint64(minStaking), big.NewInt(params.KLAY))
+	assert.Equal(t, "peb", stakes.StakeUnit)
+	assert.Equal(t, minPeb, stakes.MinimumStake)
+	assert.Equal(t, big.NewInt(7), stakes.TotalEffectiveStake)
+	assert.Equal(t, minPeb, stakes.Nodes[0].Stake)
+	assert.Equal(t, big.NewInt(0), stakes.Nodes[0].EffectiveStake)
+	assert.Equal(t, new(big.Int).Add(minPeb, big.NewInt(7)), stakes.Nodes[1].Stake)
+	assert.Equal(t, big.NewInt(7), stakes.Nodes[1].EffectiveStake)
+}
+
+func TestCalcEffectiveStakes_Inclusive(t *testing.T) {
+	// the stakes are counted from one KLAY below the minimum stake, so a CN at it has one KLAY
+	stakes := CalcEffectiveStakes(genStakingInfo(3, nil, map[int]uint64{0: minStaking + 4, 2: minStaking - 1}), minStaking, true)
+	assert.True(t, stakes.MinStakeInclusive)
+	assert.Equal(t, big.NewInt(6), stakes.TotalEffectiveStake)
+	require.Len(t, stakes.Nodes, 3)
+	for i, eligible := range []bool{true, true, false} {
+		assert.Equal(t, eligible, stakes.Nodes[i].Eligible, "node %d", i)
+	}
+	assert.Equal(t, big.NewInt(5), stakes.Nodes[0].EffectiveStake)
+	assert.Equal(t, big.NewInt(1), stakes.Nodes[1].EffectiveStake)
+
+	// in peb, one KLAY below the minimum stake is converted as well
+	stakes = CalcEffectiveStakes(genStakingInfoPeb(2, map[int]*big.Int{1: big.NewInt(-1)}), minStaking, true)
+	assert.True(t, stakes.Nodes[0].Eligible)
+	assert.False(t, stakes.Nodes[1].Eligible)
+	assert.Equal(t, big.NewInt(params.KLAY), stakes.TotalEffectiveStake)
+
+	// the boundary is decided in peb, so a peb above the minimum stake is enough
+	stakes = CalcEffectiveStakes(genStakingInfoPeb(2, map[int]*big.Int{1: big.NewInt(1)}), minStaking, false)
+	assert.False(t, stakes.Nodes[0].Eligible)
+	assert.True(t, stakes.Nodes[1].Eligible)
+	assert.Equal(t, big.NewInt(1), stakes.TotalEffectiveStake)
+}
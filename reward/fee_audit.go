@@ -0,0 +1,87 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/params"
+)
+
+var errReceiptsMismatch = errors.New("the numbers of the transactions and the receipts differ")
+
+// FeeAudit is the result of cross-checking the transaction fees of a block.
+// The total fee of the reward spec is estimated from the header by GetTotalTxFee, while the
+// fees are collected from each transaction by the gas used after the refund and the gas price
+// the transaction actually paid. They diverge, for example, if the unit price is changed while
+// transactions of the old price are still included before Magma.
+type FeeAudit struct {
+	GasUsed        uint64   // gas used in the header
+	ReceiptGasUsed uint64   // sum of the gas used in the receipts, after the refunds
+	EstimatedFee   *big.Int // total fee estimated by GetTotalTxFee
+	CollectedFee   *big.Int // sum of the fees paid by the transactions
+}
+
+// Diverged returns whether the estimated fee or the gas used differs from the collected one.
+func (a *FeeAudit) Diverged() bool {
+	return a.GasUsed != a.ReceiptGasUsed || a.EstimatedFee.Cmp(a.CollectedFee) != 0
+}
+
+// Difference returns the estimated fee minus the collected fee.
+func (a *FeeAudit) Difference() *big.Int {
+	return new(big.Int).Sub(a.EstimatedFee, a.CollectedFee)
+}
+
+func (a *FeeAudit) String() string {
+	return fmt.Sprintf("gasUsed=%d receiptGasUsed=%d estimatedFee=%v collectedFee=%v",
+		a.GasUsed, a.ReceiptGasUsed, a.EstimatedFee, a.CollectedFee)
+}
+
+// AuditTxFee compares the total fee of the block estimated by GetTotalTxFee with the sum
+// of the fees paid by its transactions, charged in the same way as the state transition.
+func AuditTxFee(config *params.ChainConfig, header *types.Header, txs types.Transactions, receipts types.Receipts, pset *params.GovParamSet) (*FeeAudit, error) {
+	if len(txs) != len(receipts) {
+		return nil, errReceiptsMismatch
+	}
+	rules := config.Rules(header.Number)
+
+	audit := &FeeAudit{
+		GasUsed:      header.GasUsed,
+		EstimatedFee: GetTotalTxFee(header, rules, pset),
+		CollectedFee: big.NewInt(0),
+	}
+	for i, tx := range txs {
+		gasUsed := receipts[i].GasUsed
+		audit.ReceiptGasUsed += gasUsed
+
+		// a gasless chain charges nothing for the gas
+		if config.Gasless {
+			continue
+		}
+		// before Magma, the gas price of the transaction is charged, and the base fee after Magma
+		price := tx.EffectiveGasPrice(nil)
+		if rules.IsMagma {
+			price = header.BaseFee
+		}
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), price)
+		audit.CollectedFee = audit.CollectedFee.Add(audit.CollectedFee, fee)
+	}
+	return audit, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditTxFee(t *testing.T) {
+	newTxs := func(gasPrices ...int64) types.Transactions {
+		var txs types.Transactions
+		for i, price := range gasPrices {
+			txs = append(txs, types.NewTransaction(uint64(i), common.Address{0x1}, common.Big0, 100000, big.NewInt(price), nil))
+		}
+		return txs
+	}
+	receipts := types.Receipts{{GasUsed: 21000}, {GasUsed: 9000}}
+
+	testcases := []struct {
+		desc      string
+		config    *params.ChainConfig
+		header    *types.Header
+		txs       types.Transactions
+		estimated int64
+		collected int64
+		diverged  bool
+	}{
+		{
+			desc:      "magma",
+			config:    getTestConfig(),
+			header:    &types.Header{Number: big.NewInt(1), GasUsed: 30000, BaseFee: big.NewInt(10)},
+			txs:       newTxs(20, 30),
+			estimated: 300000,
+			collected: 300000,
+		},
+		{
+			desc:      "before magma with the unit price",
+			config:    noMagma(getTestConfig()),
+			header:    &types.Header{Number: big.NewInt(1), GasUsed: 30000},
+			txs:       newTxs(1, 1),
+			estimated: 30000,
+			collected: 30000,
+		},
+		{
+			desc:      "before magma with a stale gas price",
+			config:    noMagma(getTestConfig()),
+			header:    &types.Header{Number: big.NewInt(1), GasUsed: 30000},
+			txs:       newTxs(1, 2),
+			estimated: 30000,
+			collected: 39000,
+			diverged:  true,
+		},
+		{
+			desc:      "gas used differs from the receipts",
+			config:    getTestConfig(),
+			header:    &types.Header{Number: big.NewInt(1), GasUsed: 31000, BaseFee: big.NewInt(10)},
+			txs:       newTxs(10, 10),
+			estimated: 310000,
+			collected: 300000,
+			diverged:  true,
+		},
+		{
+			desc: "gasless",
+			config: func() *params.ChainConfig {
+				config := noMagma(getTestConfig())
+				config.Gasless = true
+				config.UnitPrice = 0
+				return config
+			}(),
+			header:    &types.Header{Number: big.NewInt(1), GasUsed: 30000},
+			txs:       newTxs(0, 0),
+			estimated: 0,
+			collected: 0,
+		},
+	}
+
+	for _, tc := range testcases {
+		pset, err := params.NewGovParamSetChainConfig(tc.config)
+		require.NoError(t, err)
+
+		audit, err := AuditTxFee(tc.config, tc.header, tc.txs, receipts, pset)
+		require.NoError(t, err, tc.desc)
+		assert.Equal(t, uint64(30000), audit.ReceiptGasUsed, tc.desc)
+		assert.Equal(t, big.NewInt(tc.estimated).String(), audit.EstimatedFee.String(), tc.desc)
+		assert.Equal(t, big.NewInt(tc.collected).String(), audit.CollectedFee.String(), tc.desc)
+		assert.Equal(t, big.NewInt(tc.estimated-tc.collected).String(), audit.Difference().String(), tc.desc)
+		assert.Equal(t, tc.diverged, audit.Diverged(), tc.desc)
+	}
+
+	pset, err := params.NewGovParamSetChainConfig(getTestConfig())
+	require.NoError(t, err)
+	_, err = AuditTxFee(getTestConfig(), &types.Header{Number: big.NewInt(1)}, newTxs(1), receipts, pset)
+	assert.Equal(t, errReceiptsMismatch, err)
+}
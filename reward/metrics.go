@@ -0,0 +1,62 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+
+	metricutils "github.com/klaytn/klaytn/metrics/utils"
+	"github.com/klaytn/klaytn/params"
+	"github.com/rcrowley/go-metrics"
+)
+
+var (
+	calcDeferredRewardTimer = metrics.NewRegisteredTimer("reward/distribute/calcdeferredreward", nil)
+
+	// The amounts are counted in ston, as the amounts in peb overflow the counters.
+	// Their lifetime values are persisted across restarts.
+	mintedCounter   = metricutils.NewRegisteredPersistentCounter("reward/distribute/minted")
+	burntFeeCounter = metricutils.NewRegisteredPersistentCounter("reward/distribute/burntfee")
+	stakersGauge    = metrics.NewRegisteredGauge("reward/distribute/stakers", nil)
+
+	rewardSpecCacheHitMeter   = metrics.NewRegisteredMeter("reward/cache/rewardspec/hits", nil)
+	rewardSpecCacheMissMeter  = metrics.NewRegisteredMeter("reward/cache/rewardspec/misses", nil)
+	stakingInfoCacheHitMeter  = metrics.NewRegisteredMeter("reward/cache/stakinginfo/hits", nil)
+	stakingInfoCacheMissMeter = metrics.NewRegisteredMeter("reward/cache/stakinginfo/misses", nil)
+)
+
+var ston = big.NewInt(params.Ston)
+
+// UpdateDistributionMetrics updates the metrics of the rewards distributed by a block.
+// It should be called once the block is processed, not while the block is being mined,
+// because a block being mined is finalized again in every round.
+func UpdateDistributionMetrics(spec *RewardSpec) {
+	if spec.Minted != nil {
+		mintedCounter.Inc(new(big.Int).Div(spec.Minted, ston).Int64())
+	}
+	if spec.BurntFee != nil {
+		burntFeeCounter.Inc(new(big.Int).Div(spec.BurntFee, ston).Int64())
+	}
+
+	stakers := 0
+	for _, breakdown := range spec.Breakdown {
+		if breakdown.Stakers != nil && breakdown.Stakers.Sign() > 0 {
+			stakers++
+		}
+	}
+	stakersGauge.Update(int64(stakers))
+}
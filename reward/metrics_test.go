@@ -0,0 +1,67 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateDistributionMetrics(t *testing.T) {
+	minted, burnt := mintedCounter.Count(), burntFeeCounter.Count()
+
+	spec := NewRewardSpec()
+	spec.Minted = new(big.Int).Mul(big.NewInt(9_600_000_000), ston) // 9.6 KLAY
+	spec.BurntFee = new(big.Int).Add(big.NewInt(3*params.Ston), big.NewInt(1))
+	incrementBreakdown(spec.Breakdown, proposerAddr, &RewardBreakdown{Proposer: big.NewInt(1), Stakers: big.NewInt(0)})
+	incrementBreakdown(spec.Breakdown, common.HexToAddress("0x1"), &RewardBreakdown{Stakers: big.NewInt(1)})
+	incrementBreakdown(spec.Breakdown, common.HexToAddress("0x2"), &RewardBreakdown{Stakers: big.NewInt(2)})
+	UpdateDistributionMetrics(spec)
+
+	assert.Equal(t, int64(9_600_000_000), mintedCounter.Count()-minted)
+	assert.Equal(t, int64(3), burntFeeCounter.Count()-burnt)
+	assert.Equal(t, int64(2), stakersGauge.Value())
+}
+
+func TestCalcDeferredRewardCached_Metrics(t *testing.T) {
+	PurgeRewardSpecCache()
+	defer PurgeRewardSpecCache()
+
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		BaseFee:    big.NewInt(1),
+		Rewardbase: proposerAddr,
+		Root:       common.HexToHash("0x1"),
+	}
+	config := getTestConfig()
+	pset, err := params.NewGovParamSetChainConfig(config)
+	require.Nil(t, err)
+
+	hits, misses := rewardSpecCacheHitMeter.Count(), rewardSpecCacheMissMeter.Count()
+	for i := 0; i < 3; i++ {
+		_, err := CalcDeferredRewardCached(SimpleRewardPolicy{}, header, config.Rules(header.Number), pset, nil)
+		require.Nil(t, err)
+	}
+	assert.Equal(t, int64(2), rewardSpecCacheHitMeter.Count()-hits)
+	assert.Equal(t, int64(1), rewardSpecCacheMissMeter.Count()-misses)
+}
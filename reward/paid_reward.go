@@ -0,0 +1,104 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/klaytn/klaytn/common"
+)
+
+const (
+	maxPaidRewards = 512
+)
+
+// BalanceReader reads the balances of the accounts, e.g. state.StateDB.
+type BalanceReader interface {
+	GetBalance(addr common.Address) *big.Int
+}
+
+type paidRewardKey struct {
+	number uint64
+	root   common.Hash
+}
+
+var (
+	// paidRewardRecording enables recording the rewards actually paid in the recent blocks,
+	// which are compared with the reward specs to verify the block rewards.
+	paidRewardRecording int32 // 1 if enabled
+
+	// paidRewards keeps the balance changes made by the reward distribution of the recent
+	// blocks by the block number and state root. The block hash is not used because it is
+	// not final when a block being mined is finalized.
+	paidRewards, _ = lru.New(maxPaidRewards)
+)
+
+// SetPaidRewardRecording enables or disables recording the paid rewards.
+func SetPaidRewardRecording(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&paidRewardRecording, 1)
+	} else {
+		atomic.StoreInt32(&paidRewardRecording, 0)
+		paidRewards.Purge()
+	}
+}
+
+// SnapshotRecipientBalances returns the balances of the reward recipients before the
+// rewards are distributed. It returns nil if the paid rewards are not recorded.
+func SnapshotRecipientBalances(state BalanceReader, rewards map[common.Address]*big.Int) map[common.Address]*big.Int {
+	if atomic.LoadInt32(&paidRewardRecording) == 0 {
+		return nil
+	}
+	balances := make(map[common.Address]*big.Int, len(rewards))
+	for addr := range rewards {
+		balances[addr] = new(big.Int).Set(state.GetBalance(addr))
+	}
+	return balances
+}
+
+// BalanceChanges returns the balance changes of the recipients since the snapshot.
+// It returns nil if the snapshot is nil.
+func BalanceChanges(state BalanceReader, before map[common.Address]*big.Int) map[common.Address]*big.Int {
+	if before == nil {
+		return nil
+	}
+	changes := make(map[common.Address]*big.Int, len(before))
+	for addr, balance := range before {
+		changes[addr] = new(big.Int).Sub(state.GetBalance(addr), balance)
+	}
+	return changes
+}
+
+// RecordPaidRewards records the rewards paid in the block of the given number and state root.
+// Nil rewards, meaning that the recording was disabled at the snapshot, are ignored.
+func RecordPaidRewards(number uint64, root common.Hash, paid map[common.Address]*big.Int) {
+	if paid == nil {
+		return
+	}
+	paidRewards.Add(paidRewardKey{number, root}, paid)
+}
+
+// GetPaidRewards returns the rewards paid in the block of the given number and state root,
+// as recorded when the block was finalized.
+func GetPaidRewards(number uint64, root common.Hash) (map[common.Address]*big.Int, bool) {
+	if paid, ok := paidRewards.Get(paidRewardKey{number, root}); ok {
+		return copyRewardsMap(paid.(map[common.Address]*big.Int)), true
+	}
+	return nil, false
+}
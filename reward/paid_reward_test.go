@@ -0,0 +1,72 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaidRewards(t *testing.T) {
+	var (
+		proposer = common.HexToAddress("0x1")
+		kcf      = common.HexToAddress("0x2")
+		root     = common.HexToHash("0xabcd")
+		rewards  = map[common.Address]*big.Int{proposer: big.NewInt(10), kcf: big.NewInt(3)}
+	)
+	defer SetPaidRewardRecording(false)
+
+	state := newTestBalanceAdder()
+	state.AddBalance(proposer, big.NewInt(100))
+	state.AddBalance(kcf, big.NewInt(0))
+
+	// Nothing is recorded if disabled.
+	balances := SnapshotRecipientBalances(state, rewards)
+	assert.Nil(t, balances)
+	RecordPaidRewards(1, root, BalanceChanges(state, balances))
+	_, ok := GetPaidRewards(1, root)
+	assert.False(t, ok)
+
+	SetPaidRewardRecording(true)
+	balances = SnapshotRecipientBalances(state, rewards)
+	DistributeBlockReward(state, rewards)
+	state.AddBalance(kcf, big.NewInt(1)) // e.g. a bug paying more than the spec
+	RecordPaidRewards(1, root, BalanceChanges(state, balances))
+
+	paid, ok := GetPaidRewards(1, root)
+	assert.True(t, ok)
+	assert.Equal(t, map[common.Address]*big.Int{proposer: big.NewInt(10), kcf: big.NewInt(4)}, paid)
+
+	// The records are keyed by both the number and the state root.
+	_, ok = GetPaidRewards(2, root)
+	assert.False(t, ok)
+	_, ok = GetPaidRewards(1, common.Hash{})
+	assert.False(t, ok)
+
+	// The returned rewards are copies.
+	paid[proposer].SetUint64(0)
+	paid, _ = GetPaidRewards(1, root)
+	assert.Equal(t, big.NewInt(10), paid[proposer])
+
+	// Disabling the recording drops the records.
+	SetPaidRewardRecording(false)
+	_, ok = GetPaidRewards(1, root)
+	assert.False(t, ok)
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+var (
+	errNoReward           = errors.New("the address is not rewarded in the block")
+	errInvalidMerkleProof = errors.New("the merkle proof is invalid")
+	errMerkleRootMismatch = errors.New("the merkle proof does not match the rewards root")
+)
+
+// The prefixes of the hashed data separating the leaves from the inner nodes,
+// so that an inner node cannot be proven as a reward.
+const (
+	leafPrefix byte = 0x00
+	nodePrefix byte = 0x01
+)
+
+// RewardMerkleProof proves the reward of an address in a block against the rewards root,
+// the merkle root over the rewards of the block. See RewardsRoot.
+type RewardMerkleProof struct {
+	Address  common.Address `json:"address"`
+	Amount   *hexutil.Big   `json:"amount"`
+	Index    hexutil.Uint64 `json:"index"`    // The position of the reward among the rewards sorted by address
+	Leaves   hexutil.Uint64 `json:"leaves"`   // The number of the rewards in the block
+	Siblings []common.Hash  `json:"siblings"` // The sibling hashes from the leaf to the root
+	Root     common.Hash    `json:"root"`
+}
+
+// RewardsRoot returns the merkle root over the rewards, e.g. RewardSpec.Rewards.
+// The leaves are the hashes of the (address, amount) pairs sorted by address, and a node
+// without a sibling is promoted to the upper level as it is. It returns the zero hash if empty.
+func RewardsRoot(rewards map[common.Address]*big.Int) common.Hash {
+	addrs := sortedAddresses(rewards)
+	if len(addrs) == 0 {
+		return common.Hash{}
+	}
+	level := make([]common.Hash, len(addrs))
+	for i, addr := range addrs {
+		level[i] = leafHash(addr, rewards[addr])
+	}
+	for len(level) > 1 {
+		level = nextLevel(level)
+	}
+	return level[0]
+}
+
+// NewRewardMerkleProof returns the proof of the reward of the address among the rewards.
+func NewRewardMerkleProof(rewards map[common.Address]*big.Int, addr common.Address) (*RewardMerkleProof, error) {
+	amount, ok := rewards[addr]
+	if !ok {
+		return nil, errNoReward
+	}
+	addrs := sortedAddresses(rewards)
+	index := sort.Search(len(addrs), func(i int) bool { return bytes.Compare(addrs[i][:], addr[:]) >= 0 })
+
+	level := make([]common.Hash, len(addrs))
+	for i, a := range addrs {
+		level[i] = leafHash(a, rewards[a])
+	}
+	siblings := []common.Hash{}
+	for pos := index; len(level) > 1; pos /= 2 {
+		if sibling := pos ^ 1; sibling < len(level) {
+			siblings = append(siblings, level[sibling])
+		}
+		level = nextLevel(level)
+	}
+
+	return &RewardMerkleProof{
+		Address:  addr,
+		Amount:   (*hexutil.Big)(new(big.Int).Set(amount)),
+		Index:    hexutil.Uint64(index),
+		Leaves:   hexutil.Uint64(len(addrs)),
+		Siblings: siblings,
+		Root:     level[0],
+	}, nil
+}
+
+// Verify checks the proof against the given rewards root, which the verifier must obtain from a
+// source it trusts, e.g. by recomputing the rewards of the block with RewardProof.Verify.
+func (p *RewardMerkleProof) Verify(root common.Hash) error {
+	if p.Amount == nil || uint64(p.Index) >= uint64(p.Leaves) {
+		return errInvalidMerkleProof
+	}
+	hash := leafHash(p.Address, p.Amount.ToInt())
+	siblings := p.Siblings
+	for pos, size := uint64(p.Index), uint64(p.Leaves); size > 1; pos, size = pos/2, (size+1)/2 {
+		if pos%2 == 0 && pos+1 == size {
+			continue // promoted without a sibling
+		}
+		if len(siblings) == 0 {
+			return errInvalidMerkleProof
+		}
+		if pos%2 == 0 {
+			hash = nodeHash(hash, siblings[0])
+		} else {
+			hash = nodeHash(siblings[0], hash)
+		}
+		siblings = siblings[1:]
+	}
+	if len(siblings) != 0 {
+		return errInvalidMerkleProof
+	}
+	if hash != root || hash != p.Root {
+		return errMerkleRootMismatch
+	}
+	return nil
+}
+
+func sortedAddresses(rewards map[common.Address]*big.Int) []common.Address {
+	addrs := make([]common.Address, 0, len(rewards))
+	for addr := range rewards {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+	return addrs
+}
+
+func nextLevel(level []common.Hash) []common.Hash {
+	next := make([]common.Hash, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 == len(level) {
+			next = append(next, level[i])
+		} else {
+			next = append(next, nodeHash(level[i], level[i+1]))
+		}
+	}
+	return next
+}
+
+func leafHash(addr common.Address, amount *big.Int) common.Hash {
+	return crypto.Keccak256Hash([]byte{leafPrefix}, addr[:], common.BigToHash(amount).Bytes())
+}
+
+func nodeHash(left, right common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte{nodePrefix}, left[:], right[:])
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRewards(n int) map[common.Address]*big.Int {
+	rewards := make(map[common.Address]*big.Int, n)
+	for i := 0; i < n; i++ {
+		rewards[common.BigToAddress(big.NewInt(int64(1000+i)))] = big.NewInt(int64(i+1) * 1e9)
+	}
+	return rewards
+}
+
+func TestRewardsRoot(t *testing.T) {
+	assert.Equal(t, common.Hash{}, RewardsRoot(nil))
+
+	// the root of a single reward is its leaf
+	addr := common.HexToAddress("0x1")
+	assert.Equal(t, leafHash(addr, big.NewInt(1)), RewardsRoot(map[common.Address]*big.Int{addr: big.NewInt(1)}))
+
+	// the root commits to every amount
+	rewards := newTestRewards(5)
+	root := RewardsRoot(rewards)
+	rewards[common.BigToAddress(big.NewInt(1002))] = big.NewInt(1)
+	assert.NotEqual(t, root, RewardsRoot(rewards))
+}
+
+func TestRewardMerkleProof(t *testing.T) {
+	// odd and even numbers of the leaves at each level are covered
+	for n := 1; n <= 9; n++ {
+		rewards := newTestRewards(n)
+		root := RewardsRoot(rewards)
+		for addr, amount := range rewards {
+			p, err := NewRewardMerkleProof(rewards, addr)
+			require.NoError(t, err)
+			assert.Equal(t, amount, p.Amount.ToInt())
+			assert.Equal(t, root, p.Root)
+			assert.NoError(t, p.Verify(root), "n=%d addr=%s", n, addr.String())
+		}
+	}
+
+	_, err := NewRewardMerkleProof(newTestRewards(3), common.HexToAddress("0x1"))
+	assert.Equal(t, errNoReward, err)
+}
+
+func TestRewardMerkleProof_Tampered(t *testing.T) {
+	rewards := newTestRewards(5)
+	root := RewardsRoot(rewards)
+	addr := common.BigToAddress(big.NewInt(1003))
+
+	testcases := []struct {
+		tamper func(p *RewardMerkleProof)
+		err    error
+	}{
+		{func(p *RewardMerkleProof) { p.Amount = (*hexutil.Big)(big.NewInt(1)) }, errMerkleRootMismatch},
+		{func(p *RewardMerkleProof) { p.Address = common.HexToAddress("0x1") }, errMerkleRootMismatch},
+		{func(p *RewardMerkleProof) { p.Index = 2 }, errMerkleRootMismatch},
+		{func(p *RewardMerkleProof) { p.Index = 5 }, errInvalidMerkleProof},
+		{func(p *RewardMerkleProof) { p.Siblings = p.Siblings[1:] }, errInvalidMerkleProof},
+		{func(p *RewardMerkleProof) { p.Siblings = append(p.Siblings, common.Hash{}) }, errInvalidMerkleProof},
+		{func(p *RewardMerkleProof) { p.Root = common.Hash{0x1} }, errMerkleRootMismatch},
+	}
+	for i, tc := range testcases {
+		p, err := NewRewardMerkleProof(rewards, addr)
+		require.NoError(t, err)
+		tc.tamper(p)
+		assert.Equal(t, tc.err, p.Verify(root), "testcases[%d]", i)
+	}
+
+	// the proof is checked against the trusted root, not the claimed one
+	p, err := NewRewardMerkleProof(rewards, addr)
+	require.NoError(t, err)
+	assert.Equal(t, errMerkleRootMismatch, p.Verify(common.Hash{0x1}))
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package proof verifies the block reward of a block without the chain database.
+//
+// A full node assembles a RewardProof with klay_getRewardProof, and a light client
+// recomputes the RewardSpec from it with Verify. The light client must check the header
+// against its own header chain, and the reward parameters against the values it trusts,
+// since the header does not commit to them. The staking information is bound to the
+// StakingInfoHash of the header after the StakingCommitment hardfork; before the fork,
+// the light client must also check the staking commitment against the value it trusts.
+//
+// An exchange verifying the payout to a single address takes a RewardMerkleProof from
+// klay_getRewardMerkleProof instead, and checks it against the RewardsRoot of the rewards
+// it trusts, e.g. recomputed once per block and shared among its services.
+package proof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/derivesha"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+var (
+	errNoHeader            = errors.New("the header is missing")
+	errReceiptRootMismatch = errors.New("the receipts do not match the receipt root of the header")
+	errGasUsedMismatch     = errors.New("the gas used by the receipts does not match the header")
+	errStakingMismatch     = errors.New("the staking information does not match the staking commitment")
+	errStakingHashMismatch = errors.New("the staking information does not match the staking info hash of the header")
+	errRewardMismatch      = errors.New("the recomputed reward does not match the claimed reward")
+)
+
+// Receipt is the consensus fields of a receipt, which are committed by the receipt root.
+type Receipt struct {
+	Status  hexutil.Uint   `json:"status"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Logs    []*types.Log   `json:"logs"`
+}
+
+// RewardProof is the data needed to recompute and verify the reward of a block.
+type RewardProof struct {
+	Header        *types.Header `json:"header"`
+	Receipts      []*Receipt    `json:"receipts"`
+	DeriveShaImpl int           `json:"deriveShaImpl"` // The implementation which derives the receipt root
+
+	// Governance parameters with which the reward is calculated, see reward.CalcRewardParamBlock
+	RewardParams map[string]interface{} `json:"rewardParams"`
+
+	// Staking information used to split the staking reward, nil if not used
+	StakingInfo       *reward.StakingInfo `json:"stakingInfo,omitempty"`
+	StakingCommitment common.Hash         `json:"stakingCommitment"`
+
+	// Reward claimed by the full node, not verified if nil
+	Rewards *reward.RewardSpec `json:"rewards,omitempty"`
+}
+
+// NewReceipts converts the receipts to the consensus fields.
+func NewReceipts(receipts types.Receipts) []*Receipt {
+	ret := make([]*Receipt, len(receipts))
+	for i, r := range receipts {
+		ret[i] = &Receipt{Status: hexutil.Uint(r.Status), GasUsed: hexutil.Uint64(r.GasUsed), Logs: r.Logs}
+	}
+	return ret
+}
+
+// StakingCommitment returns the hash committing to the staking information.
+// It returns the zero hash if the staking information is nil.
+func StakingCommitment(stakingInfo *reward.StakingInfo) (common.Hash, error) {
+	if stakingInfo == nil {
+		return common.Hash{}, nil
+	}
+	enc, err := rlp.EncodeToBytes(stakingInfo)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(enc), nil
+}
+
+// Verify recomputes the reward of the block with the chain config, and returns it
+// if the proof is consistent.
+func (p *RewardProof) Verify(config *params.ChainConfig) (*reward.RewardSpec, error) {
+	if p.Header == nil {
+		return nil, errNoHeader
+	}
+
+	receipts := make(types.Receipts, len(p.Receipts))
+	gasUsed := uint64(0)
+	for i, r := range p.Receipts {
+		receipt := &types.Receipt{Status: uint(r.Status), GasUsed: uint64(r.GasUsed), Logs: r.Logs}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		receipts[i] = receipt
+		gasUsed += receipt.GasUsed
+	}
+	if root := derivesha.DeriveShaWithImpl(p.DeriveShaImpl, receipts); root != p.Header.ReceiptHash {
+		return nil, fmt.Errorf("%w: got %s, want %s", errReceiptRootMismatch, root.String(), p.Header.ReceiptHash.String())
+	}
+	if gasUsed != p.Header.GasUsed {
+		return nil, fmt.Errorf("%w: got %d, want %d", errGasUsedMismatch, gasUsed, p.Header.GasUsed)
+	}
+
+	commitment, err := StakingCommitment(p.StakingInfo)
+	if err != nil {
+		return nil, err
+	}
+	if commitment != p.StakingCommitment {
+		return nil, errStakingMismatch
+	}
+	if config.IsStakingCommitmentForkEnabled(p.Header.Number) {
+		if p.Header.StakingInfoHash == nil || reward.StakingInfoHash(p.StakingInfo) != *p.Header.StakingInfoHash {
+			return nil, errStakingHashMismatch
+		}
+	}
+
+	pset, err := params.NewGovParamSetStrMap(p.RewardParams)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := reward.GetBlockRewardWithStakingInfo(p.Header, config.Rules(p.Header.Number), pset, p.StakingInfo)
+	if err != nil {
+		return nil, err
+	}
+	if p.Rewards != nil && !equalRewards(spec, p.Rewards) {
+		return nil, errRewardMismatch
+	}
+	return spec, nil
+}
+
+// equalRewards compares the amounts of the reward specs, ignoring the labels.
+func equalRewards(a, b *reward.RewardSpec) bool {
+	amounts := [][2]*big.Int{
+		{a.Minted, b.Minted}, {a.TotalFee, b.TotalFee}, {a.BurntFee, b.BurntFee},
+		{a.Proposer, b.Proposer}, {a.Stakers, b.Stakers}, {a.KFF, b.KFF}, {a.KCF, b.KCF},
+	}
+	for _, pair := range amounts {
+		if pair[0].Cmp(pair[1]) != 0 {
+			return false
+		}
+	}
+	for _, pair := range [][2]*big.Int{
+		{a.BurntRemainder, b.BurntRemainder}, {a.CarriedIn, b.CarriedIn}, {a.CarriedOver, b.CarriedOver},
+	} {
+		if (pair[0] == nil) != (pair[1] == nil) || (pair[0] != nil && pair[0].Cmp(pair[1]) != 0) {
+			return false
+		}
+	}
+	if (a.RedirectedTo == nil) != (b.RedirectedTo == nil) || (a.RedirectedTo != nil && *a.RedirectedTo != *b.RedirectedTo) {
+		return false
+	}
+	return equalAmounts(a.Rewards, b.Rewards) && equalAmounts(a.KFFFunds, b.KFFFunds) &&
+		equalAmounts(a.KCFFunds, b.KCFFunds) && equalAmounts(a.ProposerFunds, b.ProposerFunds)
+}
+
+func equalAmounts(a, b map[common.Address]*big.Int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for addr, amount := range a {
+		if other, ok := b[addr]; !ok || amount.Cmp(other) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package proof
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/derivesha"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProof(t *testing.T) (*params.ChainConfig, *RewardProof) {
+	config := &params.ChainConfig{}
+	config.SetDefaults()
+	config.MagmaCompatibleBlock = big.NewInt(0)
+	config.KoreCompatibleBlock = big.NewInt(0)
+	config.Governance.Reward.MintingAmount = big.NewInt(6.4e18)
+	config.Governance.Reward.Ratio = "34/54/12"
+	config.Governance.Reward.Kip82Ratio = "20/80"
+	config.Governance.Reward.DeferredTxFee = true
+	config.Governance.Reward.MinimumStake = big.NewInt(5000000)
+	config.Istanbul.ProposerPolicy = 2
+	pset, err := params.NewGovParamSetChainConfig(config)
+	require.Nil(t, err)
+
+	receipts := types.Receipts{
+		{Status: types.ReceiptStatusSuccessful, GasUsed: 21000, Logs: []*types.Log{}},
+		{Status: types.ReceiptStatusSuccessful, GasUsed: 50000, Logs: []*types.Log{{Address: common.HexToAddress("0x1"), Topics: []common.Hash{{0x2}}, Data: []byte{0x3}}}},
+	}
+	for _, r := range receipts {
+		r.Bloom = types.CreateBloom(types.Receipts{r})
+	}
+	header := &types.Header{
+		BlockScore:  common.Big1,
+		Number:      big.NewInt(100),
+		GasUsed:     71000,
+		Time:        big.NewInt(1700000000),
+		Extra:       []byte{},
+		Governance:  []byte{},
+		BaseFee:     big.NewInt(25e9),
+		Rewardbase:  common.HexToAddress("0xaaaa"),
+		ReceiptHash: derivesha.DeriveShaWithImpl(types.ImplDeriveShaOriginal, receipts),
+	}
+	stakingInfo := &reward.StakingInfo{
+		CouncilNodeAddrs:      []common.Address{{0x11}, {0x12}},
+		CouncilStakingAddrs:   []common.Address{{0x21}, {0x22}},
+		CouncilRewardAddrs:    []common.Address{{0x31}, {0x32}},
+		KCFAddr:               common.HexToAddress("0xcccc"),
+		KFFAddr:               common.HexToAddress("0xffff"),
+		CouncilStakingAmounts: []uint64{10000000, 20000000},
+	}
+	commitment, err := StakingCommitment(stakingInfo)
+	require.Nil(t, err)
+	spec, err := reward.GetBlockRewardWithStakingInfo(header, config.Rules(header.Number), pset, stakingInfo)
+	require.Nil(t, err)
+
+	p := &RewardProof{
+		Header:            header,
+		Receipts:          NewReceipts(receipts),
+		DeriveShaImpl:     types.ImplDeriveShaOriginal,
+		RewardParams:      pset.StrMap(),
+		StakingInfo:       stakingInfo,
+		StakingCommitment: commitment,
+		Rewards:           spec,
+	}
+
+	// the light client receives the proof in JSON
+	enc, err := json.Marshal(p)
+	require.Nil(t, err)
+	dec := new(RewardProof)
+	require.Nil(t, json.Unmarshal(enc, dec))
+	return config, dec
+}
+
+func TestRewardProof_Verify(t *testing.T) {
+	config, p := newTestProof(t)
+	spec, err := p.Verify(config)
+	require.Nil(t, err)
+	assert.True(t, equalRewards(p.Rewards, spec))
+	assert.Len(t, spec.Rewards, 5) // proposer, two stakers, KFF and KCF
+}
+
+// newTestProofWithStakingCommitment returns a proof of a block after the StakingCommitment
+// hardfork, whose header commits to the staking information.
+func newTestProofWithStakingCommitment(t *testing.T) (*params.ChainConfig, *RewardProof) {
+	config, p := newTestProof(t)
+	config.StakingCommitmentCompatibleBlock = big.NewInt(0)
+	stakingInfoHash := reward.StakingInfoHash(p.StakingInfo)
+	p.Header.StakingInfoHash = &stakingInfoHash
+	return config, p
+}
+
+func TestRewardProof_Verify_StakingInfoHash(t *testing.T) {
+	config, p := newTestProofWithStakingCommitment(t)
+	spec, err := p.Verify(config)
+	require.Nil(t, err)
+	assert.True(t, equalRewards(p.Rewards, spec))
+
+	testcases := []struct {
+		desc   string
+		tamper func(p *RewardProof)
+	}{
+		{"staking amount changed with the commitment", func(p *RewardProof) {
+			p.StakingInfo.CouncilStakingAmounts[0]++
+			p.StakingCommitment, _ = StakingCommitment(p.StakingInfo)
+		}},
+		{"staking info omitted with the commitment", func(p *RewardProof) {
+			p.StakingInfo = nil
+			p.StakingCommitment = common.Hash{}
+		}},
+		{"staking info hash missing in the header", func(p *RewardProof) { p.Header.StakingInfoHash = nil }},
+	}
+
+	for _, tc := range testcases {
+		config, p := newTestProofWithStakingCommitment(t)
+		tc.tamper(p)
+		_, err := p.Verify(config)
+		assert.ErrorIs(t, err, errStakingHashMismatch, tc.desc)
+	}
+}
+
+func TestRewardProof_Verify_Tampered(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		tamper func(p *RewardProof)
+		err    error
+	}{
+		{"receipt removed", func(p *RewardProof) { p.Receipts = p.Receipts[:1] }, errReceiptRootMismatch},
+		{"gas used changed", func(p *RewardProof) { p.Receipts[0].GasUsed++ }, errReceiptRootMismatch},
+		{"wrong derive sha", func(p *RewardProof) { p.DeriveShaImpl = types.ImplDeriveShaConcat }, errReceiptRootMismatch},
+		{"header gas used changed", func(p *RewardProof) { p.Header.GasUsed++ }, errGasUsedMismatch},
+		{"staking amount changed", func(p *RewardProof) { p.StakingInfo.CouncilStakingAmounts[0]++ }, errStakingMismatch},
+		{"staking info omitted", func(p *RewardProof) { p.StakingInfo = nil }, errStakingMismatch},
+		{"reward claimed", func(p *RewardProof) { p.Rewards.Proposer.Add(p.Rewards.Proposer, common.Big1) }, errRewardMismatch},
+		{"recipient claimed", func(p *RewardProof) { p.Rewards.Rewards[common.HexToAddress("0xbbbb")] = common.Big1 }, errRewardMismatch},
+	}
+
+	for _, tc := range testcases {
+		config, p := newTestProof(t)
+		tc.tamper(p)
+		_, err := p.Verify(config)
+		assert.ErrorIs(t, err, tc.err, tc.desc)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// The kinds of the reward anomalies.
+const (
+	AnomalyMinted        = "minted"        // the minted amount changed beyond the threshold
+	AnomalyBurnRatio     = "burnRatio"     // the ratio of the burnt fee to the total fee changed beyond the threshold
+	AnomalyProposerShare = "proposerShare" // the share of the proposer portion changed beyond the threshold
+	AnomalyStakersShare  = "stakersShare"  // the share of the staker portion changed beyond the threshold
+	AnomalyKFFShare      = "kffShare"      // the share of the KFF portion changed beyond the threshold
+	AnomalyKCFShare      = "kcfShare"      // the share of the KCF portion changed beyond the threshold
+	AnomalyKFFAbsorbed   = "kffAbsorbed"   // the KFF portion started to be paid to the proposer
+	AnomalyKCFAbsorbed   = "kcfAbsorbed"   // the KCF portion started to be paid to the proposer
+)
+
+// RewardAnomalyThresholds are the changes of a reward spec from the previous one regarded as
+// anomalies. A non-positive threshold disables the check.
+type RewardAnomalyThresholds struct {
+	Minted    float64 // relative change of the minted amount, in percent
+	BurnRatio float64 // change of the burnt fee ratio, in percentage points
+	Share     float64 // change of the shares of the portions in the distributed amount, in percentage points
+}
+
+// DefaultRewardAnomalyThresholds tolerates the fluctuation of the shares by the tx fees.
+var DefaultRewardAnomalyThresholds = RewardAnomalyThresholds{
+	Minted:    1,
+	BurnRatio: 5,
+	Share:     5,
+}
+
+// RewardAnomaly is a change of a reward spec from the previous one. The values are the minted
+// amount in peb, the ratios and the shares in percent, or the absorbed portions in peb.
+type RewardAnomaly struct {
+	Kind     string
+	Previous float64
+	Current  float64
+}
+
+func (a RewardAnomaly) String() string {
+	return fmt.Sprintf("%s: %v -> %v", a.Kind, a.Previous, a.Current)
+}
+
+// DetectRewardAnomalies compares the reward spec with the previous one, e.g. of the parent
+// block, and returns the changes beyond the thresholds. It also reports the KFF and KCF
+// portions newly paid to the proposer, which happens if their addresses are missing in the
+// address book. Only the absorbed portions are checked if the previous spec is nil.
+func DetectRewardAnomalies(prev, cur *RewardSpec, thresholds RewardAnomalyThresholds) []RewardAnomaly {
+	var anomalies []RewardAnomaly
+	if prev != nil {
+		if thresholds.Minted > 0 {
+			p, c := toFloat(prev.Minted), toFloat(cur.Minted)
+			if p != c && (p == 0 || math.Abs(c-p)/p*100 > thresholds.Minted) {
+				anomalies = append(anomalies, RewardAnomaly{AnomalyMinted, p, c})
+			}
+		}
+		if thresholds.BurnRatio > 0 && sign(prev.TotalFee) > 0 && sign(cur.TotalFee) > 0 {
+			p, c := percent(prev.BurntFee, prev.TotalFee), percent(cur.BurntFee, cur.TotalFee)
+			if math.Abs(c-p) > thresholds.BurnRatio {
+				anomalies = append(anomalies, RewardAnomaly{AnomalyBurnRatio, p, c})
+			}
+		}
+		if thresholds.Share > 0 {
+			anomalies = append(anomalies, shareAnomalies(prev, cur, thresholds.Share)...)
+		}
+	}
+
+	prevKFF, prevKCF := absorbedPortions(prev)
+	curKFF, curKCF := absorbedPortions(cur)
+	if sign(curKFF) > 0 && sign(prevKFF) == 0 {
+		anomalies = append(anomalies, RewardAnomaly{AnomalyKFFAbsorbed, toFloat(prevKFF), toFloat(curKFF)})
+	}
+	if sign(curKCF) > 0 && sign(prevKCF) == 0 {
+		anomalies = append(anomalies, RewardAnomaly{AnomalyKCFAbsorbed, toFloat(prevKCF), toFloat(curKCF)})
+	}
+	return anomalies
+}
+
+// shareAnomalies returns the portions whose shares in the distributed amount changed beyond the threshold.
+func shareAnomalies(prev, cur *RewardSpec, threshold float64) []RewardAnomaly {
+	prevTotal, curTotal := distributed(prev), distributed(cur)
+	if prevTotal.Sign() == 0 || curTotal.Sign() == 0 {
+		return nil
+	}
+	var anomalies []RewardAnomaly
+	for _, portion := range []struct {
+		kind      string
+		prev, cur *big.Int
+	}{
+		{AnomalyProposerShare, prev.Proposer, cur.Proposer},
+		{AnomalyStakersShare, prev.Stakers, cur.Stakers},
+		{AnomalyKFFShare, prev.KFF, cur.KFF},
+		{AnomalyKCFShare, prev.KCF, cur.KCF},
+	} {
+		p, c := percent(portion.prev, prevTotal), percent(portion.cur, curTotal)
+		if math.Abs(c-p) > threshold {
+			anomalies = append(anomalies, RewardAnomaly{portion.kind, p, c})
+		}
+	}
+	return anomalies
+}
+
+// distributed returns the sum of the portions of the spec.
+func distributed(spec *RewardSpec) *big.Int {
+	total := new(big.Int)
+	for _, amount := range []*big.Int{spec.Proposer, spec.Stakers, spec.KFF, spec.KCF} {
+		if amount != nil {
+			total.Add(total, amount)
+		}
+	}
+	return total
+}
+
+// absorbedPortions returns the KFF and KCF portions paid to the recipients other than KFF and KCF.
+func absorbedPortions(spec *RewardSpec) (*big.Int, *big.Int) {
+	kff, kcf := new(big.Int), new(big.Int)
+	if spec == nil {
+		return kff, kcf
+	}
+	// the portions are recorded in the breakdown of the proposer if they are not paid to KFF and KCF
+	for _, b := range spec.Breakdown {
+		if sign(spec.KFF) == 0 && b.KFF != nil {
+			kff.Add(kff, b.KFF)
+		}
+		if sign(spec.KCF) == 0 && b.KCF != nil {
+			kcf.Add(kcf, b.KCF)
+		}
+	}
+	return kff, kcf
+}
+
+// percent returns a / b in percent.
+func percent(a, b *big.Int) float64 {
+	if a == nil {
+		return 0
+	}
+	ratio, _ := new(big.Rat).SetFrac(a, b).Float64()
+	return ratio * 100
+}
+
+func toFloat(a *big.Int) float64 {
+	if a == nil {
+		return 0
+	}
+	f, _ := new(big.Float).SetInt(a).Float64()
+	return f
+}
+
+func sign(a *big.Int) int {
+	if a == nil {
+		return 0
+	}
+	return a.Sign()
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAnomalySpec returns a spec whose portions are given in KLAY, with 10 KLAY of the fee half burnt.
+func testAnomalySpec(minted, proposer, stakers, kff, kcf int64) *RewardSpec {
+	klay := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18)) }
+	spec := NewRewardSpec()
+	spec.Minted, spec.TotalFee, spec.BurntFee = klay(minted), klay(10), klay(5)
+	spec.Proposer, spec.Stakers, spec.KFF, spec.KCF = klay(proposer), klay(stakers), klay(kff), klay(kcf)
+	return spec
+}
+
+func anomalyKinds(anomalies []RewardAnomaly) []string {
+	kinds := []string{}
+	for _, a := range anomalies {
+		kinds = append(kinds, a.Kind)
+	}
+	return kinds
+}
+
+func TestDetectRewardAnomalies(t *testing.T) {
+	var (
+		rewardbase = common.HexToAddress("0xb0b")
+		kffAddr    = common.HexToAddress("0xf0f")
+		th         = DefaultRewardAnomalyThresholds
+		normal     = testAnomalySpec(100, 10, 50, 25, 15)
+	)
+
+	// the same spec is not an anomaly
+	assert.Empty(t, DetectRewardAnomalies(normal, testAnomalySpec(100, 10, 50, 25, 15), th))
+	assert.Empty(t, DetectRewardAnomalies(nil, normal, th))
+
+	// the changes within the thresholds are tolerated
+	assert.Empty(t, DetectRewardAnomalies(normal, testAnomalySpec(100, 12, 48, 25, 15), th))
+
+	// the minted amount
+	anomalies := DetectRewardAnomalies(normal, testAnomalySpec(98, 10, 48, 25, 15), th)
+	assert.Equal(t, []string{AnomalyMinted}, anomalyKinds(anomalies))
+	assert.Equal(t, 1e20, anomalies[0].Previous)
+	assert.Equal(t, 9.8e19, anomalies[0].Current)
+
+	// the burn ratio
+	burnt := testAnomalySpec(100, 10, 50, 25, 15)
+	burnt.BurntFee = big.NewInt(0)
+	anomalies = DetectRewardAnomalies(normal, burnt, th)
+	assert.Equal(t, []string{AnomalyBurnRatio}, anomalyKinds(anomalies))
+	assert.Equal(t, RewardAnomaly{AnomalyBurnRatio, 50, 0}, anomalies[0])
+
+	// the burn ratio of the blocks without fee is not compared
+	noFee := testAnomalySpec(100, 10, 50, 25, 15)
+	noFee.TotalFee, noFee.BurntFee = big.NewInt(0), big.NewInt(0)
+	assert.Empty(t, DetectRewardAnomalies(normal, noFee, th))
+	assert.Empty(t, DetectRewardAnomalies(noFee, normal, th))
+
+	// the shares of the portions
+	anomalies = DetectRewardAnomalies(normal, testAnomalySpec(100, 30, 30, 25, 15), th)
+	assert.Equal(t, []string{AnomalyProposerShare, AnomalyStakersShare}, anomalyKinds(anomalies))
+	assert.Equal(t, RewardAnomaly{AnomalyProposerShare, 10, 30}, anomalies[0])
+
+	// the KFF portion paid to the proposer as the address book lost the KFF address
+	normal.Breakdown[kffAddr] = &RewardBreakdown{KFF: normal.KFF}
+	absorbed := testAnomalySpec(100, 35, 50, 0, 15)
+	absorbed.Breakdown[rewardbase] = &RewardBreakdown{Proposer: big.NewInt(10), KFF: new(big.Int).Mul(big.NewInt(25), big.NewInt(1e18))}
+	anomalies = DetectRewardAnomalies(normal, absorbed, th)
+	assert.Equal(t, []string{AnomalyProposerShare, AnomalyKFFShare, AnomalyKFFAbsorbed}, anomalyKinds(anomalies))
+	assert.Equal(t, RewardAnomaly{AnomalyKFFAbsorbed, 0, 2.5e19}, anomalies[2])
+
+	// the absorption is reported when it starts, including the first block checked
+	assert.Equal(t, []string{AnomalyKFFAbsorbed}, anomalyKinds(DetectRewardAnomalies(nil, absorbed, th)))
+	assert.Empty(t, DetectRewardAnomalies(absorbed, absorbed, th))
+
+	// the non-positive thresholds disable the checks
+	assert.Empty(t, DetectRewardAnomalies(normal, testAnomalySpec(50, 30, 0, 10, 10), RewardAnomalyThresholds{}))
+}
@@ -19,7 +19,7 @@ package reward
 import (
 	"errors"
 	"math/big"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 
@@ -32,8 +32,6 @@ import (
 	"github.com/klaytn/klaytn/rlp"
 )
 
-var CalcDeferredRewardTimer time.Duration
-
 var logger = log.NewModuleLogger(log.Reward)
 
 var (
@@ -41,6 +39,8 @@ var (
 	errParsingRatio  = errors.New("parsing ratio fail")
 )
 
+var ratioBasisPointsTotal = big.NewInt(params.RatioBasisPointsTotal)
+
 type BalanceAdder interface {
 	AddBalance(addr common.Address, v *big.Int)
 }
@@ -56,6 +56,10 @@ type rewardConfig struct {
 	// hardfork rules
 	rules params.Rules
 
+	// the reward strategy of the latest hardfork enabled by the rules
+	strategyVersion string
+	strategy        rewardStrategy
+
 	// values calculated from block header
 	totalFee *big.Int
 
@@ -64,6 +68,23 @@ type rewardConfig struct {
 	minimumStake  *big.Int
 	deferredTxFee bool
 
+	// whether a CN staking exactly the minimum stake is eligible for the staking share
+	minStakeInclusive bool
+	burnRatio         uint64
+
+	// recipient of all rewards after the RewardRedirect fork (zero = no redirection)
+	redirectAddress common.Address
+
+	// funds sharing the KFF and KCF portions (empty = paid to the KFF and KCF addresses)
+	kffFunds []params.RewardFund
+	kcfFunds []params.RewardFund
+
+	// funds sharing the proposer portion of each rewardbase (empty = paid to the rewardbase)
+	proposerFunds map[common.Address][]params.RewardFund
+
+	// destination of the rounding remainders, one of params.RemainderPolicy*
+	remainderPolicy string
+
 	// parsed ratio
 	cnRatio    *big.Int
 	kffRatio   *big.Int
@@ -85,18 +106,76 @@ type RewardSpec struct {
 	KFF      *big.Int                    `json:"kff"`      // the amount allocated to KFF
 	KCF      *big.Int                    `json:"kcf"`      // the amount allocated to KCF
 	Rewards  map[common.Address]*big.Int `json:"rewards"`  // mapping from reward recipient to amounts
+
+	Breakdown map[common.Address]*RewardBreakdown `json:"breakdown,omitempty"` // mapping from reward recipient to the portions of its amount
+
+	KFFFunds map[common.Address]*big.Int `json:"kffFunds,omitempty"` // mapping from KFF sub-fund to amounts, only set if the KFF portion is split
+	KCFFunds map[common.Address]*big.Int `json:"kcfFunds,omitempty"` // mapping from KCF sub-fund to amounts, only set if the KCF portion is split
+
+	ProposerFunds map[common.Address]*big.Int `json:"proposerFunds,omitempty"` // mapping from proposer beneficiary to amounts, only set if the rewardbase splits the proposer portion
+
+	RedirectedTo *common.Address `json:"redirectedTo,omitempty"` // the address all rewards are redirected to by governance, if any
+
+	Slot *RewardSlot `json:"slot,omitempty"` // the proposer slot if the block was produced after a round change, not accumulated by Add
+
+	BurntRemainder *big.Int `json:"burntRemainder,omitempty"` // the rounding remainder burnt, only set by the burn remainder policy
+	CarriedIn      *big.Int `json:"carriedIn,omitempty"`      // the remainder carried over from the previous block to the stakers, only set by the carry remainder policy
+	CarriedOver    *big.Int `json:"carriedOver,omitempty"`    // the rounding remainder carried over to the next block, only set by the carry remainder policy
+
+	Labels map[common.Address]AddressLabel `json:"labels,omitempty"` // labels of the reward recipients, only set for RPC responses
+}
+
+// RewardBreakdown splits the reward of a recipient by the portions it is paid from,
+// e.g. a proposer who is also a staker, or who gets the KFF portion because KFF is not set.
+// The sum of the portions is equal to the amount in RewardSpec.Rewards. Empty portions are nil.
+type RewardBreakdown struct {
+	Proposer *big.Int `json:"proposer,omitempty"` // the proposer portion, including the tx fee split by ratio before Kore
+	Stakers  *big.Int `json:"stakers,omitempty"`  // the staker portion, including the remainder of the staker shares
+	KFF      *big.Int `json:"kff,omitempty"`      // the KFF portion
+	KCF      *big.Int `json:"kcf,omitempty"`      // the KCF portion
+	Fee      *big.Int `json:"fee,omitempty"`      // the tx fee paid to the proposer as a whole
+}
+
+// Total returns the sum of the portions.
+func (b *RewardBreakdown) Total() *big.Int {
+	total := big.NewInt(0)
+	for _, portion := range []*big.Int{b.Proposer, b.Stakers, b.KFF, b.KCF, b.Fee} {
+		if portion != nil {
+			total.Add(total, portion)
+		}
+	}
+	return total
+}
+
+func (b *RewardBreakdown) add(delta *RewardBreakdown) {
+	addPortion(&b.Proposer, delta.Proposer)
+	addPortion(&b.Stakers, delta.Stakers)
+	addPortion(&b.KFF, delta.KFF)
+	addPortion(&b.KCF, delta.KCF)
+	addPortion(&b.Fee, delta.Fee)
+}
+
+func addPortion(portion **big.Int, amount *big.Int) {
+	if amount == nil || amount.Sign() == 0 {
+		return
+	}
+	if *portion == nil {
+		*portion = big.NewInt(0)
+	}
+	(*portion).Add(*portion, amount)
 }
 
 func NewRewardSpec() *RewardSpec {
 	return &RewardSpec{
-		Minted:   big.NewInt(0),
-		TotalFee: big.NewInt(0),
-		BurntFee: big.NewInt(0),
-		Proposer: big.NewInt(0),
-		Stakers:  big.NewInt(0),
-		KFF:      big.NewInt(0),
-		KCF:      big.NewInt(0),
-		Rewards:  make(map[common.Address]*big.Int),
+		Minted:    big.NewInt(0),
+		TotalFee:  big.NewInt(0),
+		BurntFee:  big.NewInt(0),
+		Proposer:  big.NewInt(0),
+		Stakers:   big.NewInt(0),
+		KFF:       big.NewInt(0),
+		KCF:       big.NewInt(0),
+		Rewards:   make(map[common.Address]*big.Int),
+		Breakdown: make(map[common.Address]*RewardBreakdown),
 	}
 }
 
@@ -112,6 +191,33 @@ func (spec *RewardSpec) Add(delta *RewardSpec) {
 	for addr, amount := range delta.Rewards {
 		incrementRewardsMap(spec.Rewards, addr, amount)
 	}
+	if len(delta.Breakdown) > 0 && spec.Breakdown == nil {
+		spec.Breakdown = make(map[common.Address]*RewardBreakdown)
+	}
+	for addr, breakdown := range delta.Breakdown {
+		incrementBreakdown(spec.Breakdown, addr, breakdown)
+	}
+	if len(delta.KFFFunds) > 0 && spec.KFFFunds == nil {
+		spec.KFFFunds = make(map[common.Address]*big.Int)
+	}
+	for addr, amount := range delta.KFFFunds {
+		incrementRewardsMap(spec.KFFFunds, addr, amount)
+	}
+	if len(delta.KCFFunds) > 0 && spec.KCFFunds == nil {
+		spec.KCFFunds = make(map[common.Address]*big.Int)
+	}
+	for addr, amount := range delta.KCFFunds {
+		incrementRewardsMap(spec.KCFFunds, addr, amount)
+	}
+	if len(delta.ProposerFunds) > 0 && spec.ProposerFunds == nil {
+		spec.ProposerFunds = make(map[common.Address]*big.Int)
+	}
+	for addr, amount := range delta.ProposerFunds {
+		incrementRewardsMap(spec.ProposerFunds, addr, amount)
+	}
+	addPortion(&spec.BurntRemainder, delta.BurntRemainder)
+	addPortion(&spec.CarriedIn, delta.CarriedIn)
+	addPortion(&spec.CarriedOver, delta.CarriedOver)
 }
 
 // TODO: this is for legacy, will be removed
@@ -142,10 +248,52 @@ func NewRewardConfig(header *types.Header, rules params.Rules, pset *params.GovP
 		}
 	}
 
+	var redirectAddress common.Address
+	if rules.IsRewardRedirect {
+		if v, ok := pset.Get(params.RewardRedirectAddress); ok {
+			redirectAddress = v.(common.Address)
+		}
+	}
+
+	var kffFunds, kcfFunds []params.RewardFund
+	var proposerFunds map[common.Address][]params.RewardFund
+	if rules.IsRewardSplit {
+		if v, ok := pset.Get(params.KFFSplit); ok {
+			kffFunds, err = params.ParseKFFSplit(v.(string))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if v, ok := pset.Get(params.KCFSplit); ok {
+			kcfFunds, err = params.ParseKCFSplit(v.(string))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if v, ok := pset.Get(params.ProposerSplit); ok {
+			proposerFunds, err = params.ParseProposerSplit(v.(string))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var remainderPolicy string
+	if rules.IsRewardSplit {
+		if v, ok := pset.Get(params.RemainderPolicy); ok {
+			remainderPolicy = v.(string)
+		}
+	}
+
+	strategyVersion, strategy := getRewardStrategy(rules)
+
 	return &rewardConfig{
 		// hardfork rules
 		rules: rules,
 
+		strategyVersion: strategyVersion,
+		strategy:        strategy,
+
 		// values calculated from block header
 		totalFee: GetTotalTxFee(header, rules, pset),
 
@@ -153,6 +301,15 @@ func NewRewardConfig(header *types.Header, rules params.Rules, pset *params.GovP
 		mintingAmount: new(big.Int).Set(pset.MintingAmountBig()),
 		minimumStake:  new(big.Int).Set(pset.MinimumStakeBig()),
 		deferredTxFee: pset.DeferredTxFee(),
+		burnRatio:     GetBurnRatio(pset),
+
+		minStakeInclusive: GetMinStakeInclusive(rules, pset),
+
+		redirectAddress: redirectAddress,
+		kffFunds:        kffFunds,
+		kcfFunds:        kcfFunds,
+		proposerFunds:   proposerFunds,
+		remainderPolicy: remainderPolicy,
 
 		// parsed ratio
 		cnRatio:    big.NewInt(cnRatio),
@@ -185,7 +342,7 @@ func IsRewardSimple(pset *params.GovParamSet) bool {
 // CalcRewardParamBlock returns the block number with which governance parameters must be fetched
 // This mimics the legacy reward config cache before Kore
 func CalcRewardParamBlock(num, epoch uint64, rules params.Rules) uint64 {
-	if !rules.IsKore && num%epoch == 0 {
+	if !rules.IsKore && num%epoch == 0 && num >= epoch {
 		return num - epoch
 	}
 	return num
@@ -194,51 +351,120 @@ func CalcRewardParamBlock(num, epoch uint64, rules params.Rules) uint64 {
 // GetBlockReward returns the actual reward amounts paid in this block
 // Used in klay_getReward RPC API
 func GetBlockReward(header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, error) {
-	var spec *RewardSpec
-	var err error
+	policy := GetRewardPolicy(header.Number, pset)
+	var stakingInfo *StakingInfo
+	if policy.UseStakingInfo() {
+		stakingInfo = GetStakingInfo(header.Number.Uint64())
+	}
+	spec, err := CalcDeferredRewardCached(policy, header, rules, pset, stakingInfo)
+	if err != nil {
+		return nil, err
+	}
+	return addPaidTxFee(spec, header, rules, pset, ecrecover)
+}
 
-	if IsRewardSimple(pset) {
-		spec, err = CalcDeferredRewardSimple(header, rules, pset)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		spec, err = CalcDeferredReward(header, rules, pset)
-		if err != nil {
-			return nil, err
-		}
+// GetBlockRewardWithStakingInfo is GetBlockReward with the given staking information
+// instead of the one managed by the staking manager, e.g. the one received by a light client.
+func GetBlockRewardWithStakingInfo(header *types.Header, rules params.Rules, pset *params.GovParamSet, stakingInfo *StakingInfo) (*RewardSpec, error) {
+	spec, err := GetRewardPolicy(header.Number, pset).CalcDeferredReward(header, rules, pset, stakingInfo)
+	if err != nil {
+		return nil, err
 	}
+	return addPaidTxFee(spec, header, rules, pset, ecrecover)
+}
 
+// GetPendingBlockReward is GetBlockRewardWithStakingInfo for the pending block which is not sealed yet.
+// Since the proposer cannot be recovered from the seal, the tx fee paid before Magma is credited to the given proposer.
+func GetPendingBlockReward(header *types.Header, rules params.Rules, pset *params.GovParamSet, stakingInfo *StakingInfo, proposer common.Address) (*RewardSpec, error) {
+	spec, err := GetRewardPolicy(header.Number, pset).CalcDeferredReward(header, rules, pset, stakingInfo)
+	if err != nil {
+		return nil, err
+	}
+	return addPaidTxFee(spec, header, rules, pset, func(*types.Header) (common.Address, error) {
+		return proposer, nil
+	})
+}
+
+func addPaidTxFee(spec *RewardSpec, header *types.Header, rules params.Rules, pset *params.GovParamSet, getProposer func(*types.Header) (common.Address, error)) (*RewardSpec, error) {
 	// Compensate the difference between CalcDeferredReward() and actual payment.
 	// If not DeferredTxFee, CalcDeferredReward() assumes 0 total_fee, but
 	// some non-zero fee already has been paid to the proposer.
+	// The fee is paid during TX execution, so it is not redirected even if the rewards are.
 	if !pset.DeferredTxFee() {
 		if rules.IsMagma {
 			txFee := GetTotalTxFee(header, rules, pset)
-			txFeeBurn := getBurnAmountMagma(txFee)
+			txFeeBurn := getBurnAmountMagma(txFee, GetBurnRatio(pset))
 			txFeeRemained := new(big.Int).Sub(txFee, txFeeBurn)
 			spec.BurntFee = txFeeBurn
 
 			spec.Proposer = spec.Proposer.Add(spec.Proposer, txFeeRemained)
 			spec.TotalFee = spec.TotalFee.Add(spec.TotalFee, txFee)
 			incrementRewardsMap(spec.Rewards, header.Rewardbase, txFeeRemained)
+			incrementBreakdown(spec.Breakdown, header.Rewardbase, &RewardBreakdown{Fee: txFeeRemained})
 		} else {
 			txFee := GetTotalTxFee(header, rules, pset)
 			spec.Proposer = spec.Proposer.Add(spec.Proposer, txFee)
 			spec.TotalFee = spec.TotalFee.Add(spec.TotalFee, txFee)
 			// get the proposer of this block.
-			proposer, err := ecrecover(header)
+			proposer, err := getProposer(header)
 			if err != nil {
 				return nil, err
 			}
 			incrementRewardsMap(spec.Rewards, proposer, txFee)
-
+			incrementBreakdown(spec.Breakdown, proposer, &RewardBreakdown{Fee: txFee})
 		}
 	}
 
 	return spec, nil
 }
 
+// CalcTxFeeSplit returns how the tx fee of the given header is burnt and paid to the proposer,
+// the stakers, KFF and KCF, as if it was the fee of the only tx in the block. Since the fees
+// burnt after Kore depend on the total fee of the block, the burnt fee is an upper bound.
+// The minted amount is not included.
+func CalcTxFeeSplit(header *types.Header, rules params.Rules, pset *params.GovParamSet, stakingInfo *StakingInfo) (*RewardSpec, error) {
+	totalFee := GetTotalTxFee(header, rules, pset)
+	split := NewRewardSpec()
+	split.TotalFee = totalFee
+
+	// The fee is paid to the proposer during the tx execution after burning a part of it since Magma.
+	if !pset.DeferredTxFee() {
+		if rules.IsMagma {
+			split.BurntFee = getBurnAmountMagma(totalFee, GetBurnRatio(pset))
+		}
+		split.Proposer = new(big.Int).Sub(totalFee, split.BurntFee)
+		return split, nil
+	}
+
+	policy := GetRewardPolicy(header.Number, pset)
+	spec, err := policy.CalcDeferredReward(header, rules, pset, stakingInfo)
+	if err != nil {
+		return nil, err
+	}
+	noFeeHeader := types.CopyHeader(header)
+	noFeeHeader.GasUsed = 0
+	noFeeSpec, err := policy.CalcDeferredReward(noFeeHeader, rules, pset, stakingInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	split.BurntFee = new(big.Int).Sub(spec.BurntFee, noFeeSpec.BurntFee)
+	split.Proposer = new(big.Int).Sub(spec.Proposer, noFeeSpec.Proposer)
+	split.Stakers = new(big.Int).Sub(spec.Stakers, noFeeSpec.Stakers)
+	split.KFF = new(big.Int).Sub(spec.KFF, noFeeSpec.KFF)
+	split.KCF = new(big.Int).Sub(spec.KCF, noFeeSpec.KCF)
+	for addr, amount := range spec.Rewards {
+		diff := new(big.Int).Set(amount)
+		if prev, ok := noFeeSpec.Rewards[addr]; ok {
+			diff.Sub(diff, prev)
+		}
+		if diff.Sign() != 0 {
+			split.Rewards[addr] = diff
+		}
+	}
+	return split, nil
+}
+
 // CalcDeferredRewardSimple distributes rewards to proposer after optional fee burning
 // this behaves similar to the previous MintKLAY
 // MintKLAY has been superseded because we need to split reward distribution
@@ -270,7 +496,8 @@ func CalcDeferredRewardSimple(header *types.Header, rules params.Rules, pset *pa
 		spec.TotalFee = big.NewInt(0)
 		spec.BurntFee = big.NewInt(0)
 		spec.Proposer = proposer
-		incrementRewardsMap(spec.Rewards, header.Rewardbase, proposer)
+		payProposer(rc, spec, header.Rewardbase, proposer, &RewardBreakdown{Proposer: minted})
+		redirectRewards(rc, spec)
 		return spec, nil
 	}
 
@@ -279,7 +506,7 @@ func CalcDeferredRewardSimple(header *types.Header, rules params.Rules, pset *pa
 	burntFee := big.NewInt(0)
 
 	if rc.rules.IsMagma {
-		burnt := getBurnAmountMagma(rewardFee)
+		burnt := getBurnAmountMagma(rewardFee, rc.burnRatio)
 		rewardFee = rewardFee.Sub(rewardFee, burnt)
 		burntFee = burntFee.Add(burntFee, burnt)
 	}
@@ -297,47 +524,99 @@ func CalcDeferredRewardSimple(header *types.Header, rules params.Rules, pset *pa
 	spec.TotalFee = totalFee
 	spec.BurntFee = burntFee
 	spec.Proposer = proposer
-	incrementRewardsMap(spec.Rewards, header.Rewardbase, proposer)
+	payProposer(rc, spec, header.Rewardbase, proposer, &RewardBreakdown{Proposer: minted, Fee: rewardFee})
+	redirectRewards(rc, spec)
 	return spec, nil
 }
 
 // CalcDeferredReward calculates the deferred rewards,
 // which are determined at the end of block processing.
 func CalcDeferredReward(header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, error) {
-	defer func(start time.Time) {
-		CalcDeferredRewardTimer = time.Since(start)
-	}(time.Now())
+	return CalcDeferredRewardWithStakingInfo(header, rules, pset, GetStakingInfo(header.Number.Uint64()))
+}
+
+// CalcDeferredRewardWithStakingInfo is CalcDeferredReward with the given staking information.
+// If stakingInfo is nil, the staking reward goes to the proposer.
+func CalcDeferredRewardWithStakingInfo(header *types.Header, rules params.Rules, pset *params.GovParamSet, stakingInfo *StakingInfo) (*RewardSpec, error) {
+	defer calcDeferredRewardTimer.UpdateSince(time.Now())
 
+	return calcDeferredReward(header, rules, pset, stakingInfo, maxCarryLookback)
+}
+
+// calcDeferredReward is CalcDeferredRewardWithStakingInfo recalculating up to lookback ancestors
+// to find the carried remainder.
+func calcDeferredReward(header *types.Header, rules params.Rules, pset *params.GovParamSet, stakingInfo *StakingInfo, lookback int) (*RewardSpec, error) {
 	rc, err := NewRewardConfig(header, rules, pset)
 	if err != nil {
 		return nil, err
 	}
 
-	var (
-		minted      = rc.mintingAmount
-		stakingInfo = GetStakingInfo(header.Number.Uint64())
-	)
+	minted := rc.mintingAmount
 
 	totalFee, rewardFee, burntFee := calcDeferredFee(rc)
 	proposer, stakers, kff, kcf, splitRem := calcSplit(rc, minted, rewardFee)
-	shares, shareRem := calcShares(stakingInfo, stakers, rc.minimumStake.Uint64())
 
-	// Remainder from (CN, KFF, KCF) split goes to KFF
-	kff = kff.Add(kff, splitRem)
-	// Remainder from staker shares goes to Proposer
-	// Then, deduct it from stakers so that `minted + totalFee - burntFee = proposer + stakers + kff + kcf`
-	proposer = proposer.Add(proposer, shareRem)
+	// The remainder carried over from the previous block is shared by the stakers of this block.
+	carriedIn, err := getCarriedRemainder(rc, header, lookback)
+	if err != nil {
+		return nil, err
+	}
+	if carriedIn != nil {
+		stakers = stakers.Add(stakers, carriedIn)
+	}
+	shares, shareRem := calcShares(stakingInfo, stakers, rc.minimumStake.Uint64(), rc.minStakeInclusive)
+
+	// The proposer may get the portions of the others as well, so keep track of them separately.
+	// After Kore, the fee is not split by ratio but added to the proposer portion as a whole.
+	proposerBreakdown := &RewardBreakdown{Proposer: new(big.Int).Set(proposer), Stakers: big.NewInt(0)}
+	if rc.strategy.feeToProposer() {
+		proposerBreakdown.Proposer.Sub(proposerBreakdown.Proposer, rewardFee)
+		proposerBreakdown.Fee = new(big.Int).Set(rewardFee)
+	}
+
+	// Remainders from (CN, KFF, KCF) split and staker shares go by the remainder policy.
+	splitDest, shareDest := remainderDestinations(rc.remainderPolicy)
+	if len(shares) == 0 {
+		// Not a rounding remainder but the staker portion not shared at all, e.g. without staking information.
+		shareDest = params.RemainderPolicyProposer
+	}
+	burntRem, carriedOver := big.NewInt(0), big.NewInt(0)
+	for _, rem := range []struct {
+		amount  *big.Int
+		dest    string
+		portion **big.Int
+	}{
+		{splitRem, splitDest, &proposerBreakdown.Proposer},
+		{shareRem, shareDest, &proposerBreakdown.Stakers},
+	} {
+		switch rem.dest {
+		case params.RemainderPolicyProposer:
+			proposer = proposer.Add(proposer, rem.amount)
+			addPortion(rem.portion, rem.amount)
+		case params.RemainderPolicyKFF:
+			kff = kff.Add(kff, rem.amount)
+		case params.RemainderPolicyBurn:
+			burntRem = burntRem.Add(burntRem, rem.amount)
+		case params.RemainderPolicyCarry:
+			carriedOver = carriedOver.Add(carriedOver, rem.amount)
+		}
+	}
+	// Then, deduct the share remainder from stakers so that
+	// `minted + carriedIn + totalFee - burntFee - burntRemainder - carriedOver = proposer + stakers + kff + kcf`
 	stakers = stakers.Sub(stakers, shareRem)
 
 	// if KFF or KCF is not set, proposer gets the portion
-	if stakingInfo == nil || common.EmptyAddress(stakingInfo.KFFAddr) {
+	// unless the portion is split among the funds set by governance.
+	if len(rc.kffFunds) == 0 && (stakingInfo == nil || common.EmptyAddress(stakingInfo.KFFAddr)) {
 		logger.Debug("KFF empty, proposer gets its portion", "kff", kff)
 		proposer = proposer.Add(proposer, kff)
+		proposerBreakdown.KFF = kff
 		kff = big.NewInt(0)
 	}
-	if stakingInfo == nil || common.EmptyAddress(stakingInfo.KCFAddr) {
+	if len(rc.kcfFunds) == 0 && (stakingInfo == nil || common.EmptyAddress(stakingInfo.KCFAddr)) {
 		logger.Debug("KCF empty, proposer gets its portion", "kcf", kcf)
 		proposer = proposer.Add(proposer, kcf)
+		proposerBreakdown.KCF = kcf
 		kcf = big.NewInt(0)
 	}
 
@@ -349,24 +628,142 @@ func CalcDeferredReward(header *types.Header, rules params.Rules, pset *params.G
 	spec.Stakers = stakers
 	spec.KFF = kff
 	spec.KCF = kcf
+	spec.CarriedIn = carriedIn
+	switch rc.remainderPolicy {
+	case params.RemainderPolicyBurn:
+		spec.BurntRemainder = burntRem
+	case params.RemainderPolicyCarry:
+		spec.CarriedOver = carriedOver
+	}
 
-	incrementRewardsMap(spec.Rewards, header.Rewardbase, proposer)
+	payProposer(rc, spec, header.Rewardbase, proposer, proposerBreakdown)
 
-	if stakingInfo != nil && !common.EmptyAddress(stakingInfo.KFFAddr) {
+	if len(rc.kffFunds) > 0 {
+		spec.KFFFunds = splitFunds(rc.kffFunds, kff)
+		for fundAddr, fundAmount := range spec.KFFFunds {
+			incrementRewardsMap(spec.Rewards, fundAddr, fundAmount)
+			incrementBreakdown(spec.Breakdown, fundAddr, &RewardBreakdown{KFF: fundAmount})
+		}
+	} else if stakingInfo != nil && !common.EmptyAddress(stakingInfo.KFFAddr) {
 		incrementRewardsMap(spec.Rewards, stakingInfo.KFFAddr, kff)
+		incrementBreakdown(spec.Breakdown, stakingInfo.KFFAddr, &RewardBreakdown{KFF: kff})
 	}
-	if stakingInfo != nil && !common.EmptyAddress(stakingInfo.KCFAddr) {
+	if len(rc.kcfFunds) > 0 {
+		spec.KCFFunds = splitFunds(rc.kcfFunds, kcf)
+		for fundAddr, fundAmount := range spec.KCFFunds {
+			incrementRewardsMap(spec.Rewards, fundAddr, fundAmount)
+			incrementBreakdown(spec.Breakdown, fundAddr, &RewardBreakdown{KCF: fundAmount})
+		}
+	} else if stakingInfo != nil && !common.EmptyAddress(stakingInfo.KCFAddr) {
 		incrementRewardsMap(spec.Rewards, stakingInfo.KCFAddr, kcf)
+		incrementBreakdown(spec.Breakdown, stakingInfo.KCFAddr, &RewardBreakdown{KCF: kcf})
 	}
 
 	for rewardAddr, rewardAmount := range shares {
 		incrementRewardsMap(spec.Rewards, rewardAddr, rewardAmount)
+		incrementBreakdown(spec.Breakdown, rewardAddr, &RewardBreakdown{Stakers: rewardAmount})
 	}
+	redirectRewards(rc, spec)
 	logger.Debug("CalcDeferredReward() returns", "spec", spec)
 
 	return spec, nil
 }
 
+// splitFunds splits the KFF or KCF portion among the funds by their weights.
+// The remainder goes to the first fund so that the sum of the output is equal to portion.
+func splitFunds(funds []params.RewardFund, portion *big.Int) map[common.Address]*big.Int {
+	totalWeight := big.NewInt(0)
+	for _, fund := range funds {
+		totalWeight.Add(totalWeight, new(big.Int).SetUint64(fund.Weight))
+	}
+
+	remaining := new(big.Int).Set(portion)
+	amounts := make(map[common.Address]*big.Int)
+	for _, fund := range funds {
+		amount := new(big.Int).Mul(portion, new(big.Int).SetUint64(fund.Weight))
+		amount = amount.Div(amount, totalWeight)
+		remaining = remaining.Sub(remaining, amount)
+		amounts[fund.Addr] = amount
+	}
+	first := funds[0].Addr
+	amounts[first] = amounts[first].Add(amounts[first], remaining)
+
+	logger.Debug("splitFunds()",
+		"[in] portion", portion.Uint64(),
+		"[out] amounts", amounts,
+	)
+	return amounts
+}
+
+// payProposer adds the proposer reward of the given breakdown to the spec. If the rewardbase
+// has a split schedule set by reward.proposersplit, the reward is split among its funds by
+// their weights, each portion of the breakdown separately so that the breakdowns of the funds
+// add up to the given one. The remainder of a portion goes to the first fund.
+func payProposer(rc *rewardConfig, spec *RewardSpec, rewardbase common.Address, amount *big.Int, breakdown *RewardBreakdown) {
+	funds := rc.proposerFunds[rewardbase]
+	if len(funds) == 0 {
+		incrementRewardsMap(spec.Rewards, rewardbase, amount)
+		incrementBreakdown(spec.Breakdown, rewardbase, breakdown)
+		return
+	}
+
+	breakdowns := make(map[common.Address]*RewardBreakdown, len(funds))
+	for _, fund := range funds {
+		breakdowns[fund.Addr] = &RewardBreakdown{}
+	}
+	for _, portion := range []struct {
+		amount *big.Int
+		get    func(b *RewardBreakdown) **big.Int
+	}{
+		{breakdown.Proposer, func(b *RewardBreakdown) **big.Int { return &b.Proposer }},
+		{breakdown.Stakers, func(b *RewardBreakdown) **big.Int { return &b.Stakers }},
+		{breakdown.KFF, func(b *RewardBreakdown) **big.Int { return &b.KFF }},
+		{breakdown.KCF, func(b *RewardBreakdown) **big.Int { return &b.KCF }},
+		{breakdown.Fee, func(b *RewardBreakdown) **big.Int { return &b.Fee }},
+	} {
+		if portion.amount == nil || portion.amount.Sign() == 0 {
+			continue
+		}
+		for fundAddr, fundAmount := range splitFunds(funds, portion.amount) {
+			addPortion(portion.get(breakdowns[fundAddr]), fundAmount)
+		}
+	}
+
+	if spec.ProposerFunds == nil {
+		spec.ProposerFunds = make(map[common.Address]*big.Int)
+	}
+	for fundAddr, fundBreakdown := range breakdowns {
+		fundAmount := fundBreakdown.Total()
+		incrementRewardsMap(spec.ProposerFunds, fundAddr, fundAmount)
+		incrementRewardsMap(spec.Rewards, fundAddr, fundAmount)
+		incrementBreakdown(spec.Breakdown, fundAddr, fundBreakdown)
+	}
+	logger.Debug("Split the proposer reward", "rewardbase", rewardbase, "amount", amount, "funds", spec.ProposerFunds)
+}
+
+// redirectRewards pays all the rewards of the spec to the redirect address set by governance,
+// e.g. to keep the rewards in a treasury during a validator compromise. The amounts allocated
+// to the proposer, stakers, KFF and KCF are left in the spec as they would have been paid,
+// and so are the portions in the breakdown of the redirect address.
+func redirectRewards(rc *rewardConfig, spec *RewardSpec) {
+	if common.EmptyAddress(rc.redirectAddress) {
+		return
+	}
+	total := big.NewInt(0)
+	for _, amount := range spec.Rewards {
+		total.Add(total, amount)
+	}
+	breakdown := &RewardBreakdown{}
+	for _, b := range spec.Breakdown {
+		breakdown.add(b)
+	}
+	redirect := rc.redirectAddress
+	spec.Rewards = map[common.Address]*big.Int{redirect: total}
+	spec.Breakdown = map[common.Address]*RewardBreakdown{redirect: breakdown}
+	spec.RedirectedTo = &redirect
+	logger.Debug("Redirected the block rewards", "redirect", redirect, "amount", total)
+}
+
 // calcDeferredFee splits fee into (total, reward, burnt)
 func calcDeferredFee(rc *rewardConfig) (*big.Int, *big.Int, *big.Int) {
 	// If not DeferredTxFee, fees are already added to the proposer during TX execution.
@@ -377,24 +774,11 @@ func calcDeferredFee(rc *rewardConfig) (*big.Int, *big.Int, *big.Int) {
 	}
 
 	totalFee := rc.totalFee
-	rewardFee := new(big.Int).Set(totalFee)
-	burntFee := big.NewInt(0)
-
-	// after magma, burn half of gas
-	if rc.rules.IsMagma {
-		burnt := getBurnAmountMagma(rewardFee)
-		rewardFee = rewardFee.Sub(rewardFee, burnt)
-		burntFee = burntFee.Add(burntFee, burnt)
-	}
-
-	// after kore, burn fees up to proposer's minted reward
-	if rc.rules.IsKore {
-		burnt := getBurnAmountKore(rc, rewardFee)
-		rewardFee = rewardFee.Sub(rewardFee, burnt)
-		burntFee = burntFee.Add(burntFee, burnt)
-	}
+	burntFee := rc.strategy.burnFee(rc, totalFee)
+	rewardFee := new(big.Int).Sub(totalFee, burntFee)
 
 	logger.Debug("calcDeferredFee()",
+		"strategy", rc.strategyVersion,
 		"totalFee", totalFee.Uint64(),
 		"rewardFee", rewardFee.Uint64(),
 		"burntFee", burntFee.Uint64(),
@@ -402,8 +786,31 @@ func calcDeferredFee(rc *rewardConfig) (*big.Int, *big.Int, *big.Int) {
 	return totalFee, rewardFee, burntFee
 }
 
-func getBurnAmountMagma(fee *big.Int) *big.Int {
-	return new(big.Int).Div(fee, big.NewInt(2))
+// getBurnAmountMagma returns the part of the fee burnt by the burn ratio in percentage.
+func getBurnAmountMagma(fee *big.Int, burnRatio uint64) *big.Int {
+	burnt := new(big.Int).Mul(fee, new(big.Int).SetUint64(burnRatio))
+	return burnt.Div(burnt, big.NewInt(100))
+}
+
+// GetBurnRatio returns the burn ratio of the parameter set, which may not have the parameter
+// if it is made from a chain config without the parameter.
+func GetBurnRatio(pset *params.GovParamSet) uint64 {
+	if v, ok := pset.Get(params.BurnRatio); ok {
+		return v.(uint64)
+	}
+	return params.DefaultBurnRatio
+}
+
+// GetMinStakeInclusive returns whether a stake equal to the minimum stake is eligible for the staking
+// share, which can be set only after the PebStaking hardfork.
+func GetMinStakeInclusive(rules params.Rules, pset *params.GovParamSet) bool {
+	if !rules.IsPebStaking {
+		return false
+	}
+	if v, ok := pset.Get(params.MinStakeInclusive); ok {
+		return v.(bool)
+	}
+	return params.DefaultMinStakeInclusive
 }
 
 func getBurnAmountKore(rc *rewardConfig, fee *big.Int) *big.Int {
@@ -425,53 +832,17 @@ func getBurnAmountKore(rc *rewardConfig, fee *big.Int) *big.Int {
 // calcSplit splits fee into (proposer, stakers, kff, kcf, remaining)
 // the sum of the output must be equal to (minted + fee)
 func calcSplit(rc *rewardConfig, minted, fee *big.Int) (*big.Int, *big.Int, *big.Int, *big.Int, *big.Int) {
-	totalResource := big.NewInt(0)
-	totalResource = totalResource.Add(minted, fee)
-
-	if rc.rules.IsKore {
-		cn, kff, kcf := splitByRatio(rc, minted)
-		proposer, stakers := splitByKip82Ratio(rc, cn)
-
-		proposer = proposer.Add(proposer, fee)
-
-		remaining := new(big.Int).Set(totalResource)
-		remaining = remaining.Sub(remaining, kff)
-		remaining = remaining.Sub(remaining, kcf)
-		remaining = remaining.Sub(remaining, proposer)
-		remaining = remaining.Sub(remaining, stakers)
-
-		logger.Debug("calcSplit() after kore",
-			"[in] minted", minted.Uint64(),
-			"[in] fee", fee.Uint64(),
-			"[out] proposer", proposer.Uint64(),
-			"[out] stakers", stakers.Uint64(),
-			"[out] kff", kff.Uint64(),
-			"[out] kcf", kcf.Uint64(),
-			"[out] remaining", remaining.Uint64(),
-		)
-		return proposer, stakers, kff, kcf, remaining
-	} else {
-		cn, kff, kcf := splitByRatio(rc, totalResource)
-
-		remaining := new(big.Int).Set(totalResource)
-		remaining = remaining.Sub(remaining, kff)
-		remaining = remaining.Sub(remaining, kcf)
-		remaining = remaining.Sub(remaining, cn)
-
-		logger.Debug("calcSplit() before kore",
-			"[in] minted", minted.Uint64(),
-			"[in] fee", fee.Uint64(),
-			"[out] cn", cn.Uint64(),
-			"[out] kff", kff.Uint64(),
-			"[out] kcf", kcf.Uint64(),
-			"[out] remaining", remaining.Uint64(),
-		)
-		return cn, big.NewInt(0), kff, kcf, remaining
-	}
+	return rc.strategy.split(rc, minted, fee)
 }
 
-// splitByRatio splits by `ratio`. It ignores any remaining amounts.
+// splitByRatio splits by `ratio`. It ignores any remaining amounts,
+// except that the ratio in basis points is split without any remaining amount.
 func splitByRatio(rc *rewardConfig, source *big.Int) (*big.Int, *big.Int, *big.Int) {
+	if rc.totalRatio.Cmp(ratioBasisPointsTotal) == 0 {
+		parts := splitByLargestRemainder(source, rc.totalRatio, rc.cnRatio, rc.kffRatio, rc.kcfRatio)
+		return parts[0], parts[1], parts[2]
+	}
+
 	cn := new(big.Int).Mul(source, rc.cnRatio)
 	cn = cn.Div(cn, rc.totalRatio)
 
@@ -484,8 +855,14 @@ func splitByRatio(rc *rewardConfig, source *big.Int) (*big.Int, *big.Int, *big.I
 	return cn, kff, kcf
 }
 
-// splitByKip82Ratio splits by `kip82ratio`. It ignores any remaining amounts.
+// splitByKip82Ratio splits by `kip82ratio`. It ignores any remaining amounts,
+// except that the ratio in basis points is split without any remaining amount.
 func splitByKip82Ratio(rc *rewardConfig, source *big.Int) (*big.Int, *big.Int) {
+	if rc.cnTotalRatio.Cmp(ratioBasisPointsTotal) == 0 {
+		parts := splitByLargestRemainder(source, rc.cnTotalRatio, rc.cnProposerRatio, rc.cnStakingRatio)
+		return parts[0], parts[1]
+	}
+
 	proposer := new(big.Int).Mul(source, rc.cnProposerRatio)
 	proposer = proposer.Div(proposer, rc.cnTotalRatio)
 
@@ -495,81 +872,177 @@ func splitByKip82Ratio(rc *rewardConfig, source *big.Int) (*big.Int, *big.Int) {
 	return proposer, stakers
 }
 
-// calcShares distributes stake reward among staked CNs
-func calcShares(stakingInfo *StakingInfo, stakeReward *big.Int, minStake uint64) (map[common.Address]*big.Int, *big.Int) {
+// splitByLargestRemainder splits source by the ratios summing up to total so that the parts sum up to source.
+// The parts are rounded down first, then the remaining amount is given one by one to the parts with
+// the largest remainders of the division, to the former part in a tie. Unlike splitting the ratio in percents,
+// which leaves the remaining amount to KFF or the proposer, no part gets more than its share rounded up.
+func splitByLargestRemainder(source, total *big.Int, ratios ...*big.Int) []*big.Int {
+	parts := make([]*big.Int, len(ratios))
+	rems := make([]*big.Int, len(ratios))
+	remaining := new(big.Int).Set(source)
+	for i, ratio := range ratios {
+		parts[i], rems[i] = new(big.Int).QuoRem(new(big.Int).Mul(source, ratio), total, new(big.Int))
+		remaining = remaining.Sub(remaining, parts[i])
+	}
+
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return rems[order[i]].Cmp(rems[order[j]]) > 0
+	})
+	for _, i := range order {
+		if remaining.Sign() <= 0 {
+			break
+		}
+		parts[i] = parts[i].Add(parts[i], common.Big1)
+		remaining = remaining.Sub(remaining, common.Big1)
+	}
+	return parts
+}
+
+// calcShares distributes stake reward among staked CNs.
+// The stakes are summed in big.Int so that the total cannot overflow. If the staking information
+// carries the peb staking amounts (after the PebStaking hardfork), the shares are calculated in peb;
+// otherwise they are calculated in KLAY as before. See calcEffectiveStakes for inclusive.
+func calcShares(stakingInfo *StakingInfo, stakeReward *big.Int, minStake uint64, inclusive bool) (map[common.Address]*big.Int, *big.Int) {
 	// if stakingInfo is nil, stakeReward goes to proposer
 	if stakingInfo == nil {
 		return make(map[common.Address]*big.Int), stakeReward
 	}
 
-	cns := stakingInfo.GetConsolidatedStakingInfo()
-
-	totalStakesInt := uint64(0)
-
-	for _, node := range cns.GetAllNodes() {
-		if node.StakingAmount > minStake { // comparison in Klay
-			totalStakesInt += (node.StakingAmount - minStake)
+	nodes, nodeShares, remaining := calcNodeShares(stakingInfo, stakeReward, minStake, inclusive)
+	shares := make(map[common.Address]*big.Int)
+	for i, node := range nodes {
+		if nodeShares[i] != nil {
+			shares[node.RewardAddr] = nodeShares[i]
 		}
 	}
+	logger.Debug("calcShares()",
+		"[in] stakeReward", stakeReward.Uint64(),
+		"[out] remaining", remaining.Uint64(),
+		"[out] shares", shares,
+	)
+
+	return shares, remaining
+}
+
+// calcNodeShares returns the share of each consolidated CN of the stake reward, nil if the CN has
+// no share, and the remainder of the stake reward.
+func calcNodeShares(stakingInfo *StakingInfo, stakeReward *big.Int, minStake uint64, inclusive bool) ([]consolidatedNode, []*big.Int, *big.Int) {
+	nodes, effectiveStakes, totalStakes, _ := calcEffectiveStakes(stakingInfo, minStake, inclusive)
 
-	totalStakes := new(big.Int).SetUint64(totalStakesInt)
 	remaining := new(big.Int).Set(stakeReward)
-	shares := make(map[common.Address]*big.Int)
+	nodeShares := make([]*big.Int, len(nodes))
 
-	for _, node := range cns.GetAllNodes() {
-		if node.StakingAmount > minStake {
-			effectiveStake := new(big.Int).SetUint64(node.StakingAmount - minStake)
-			// The KLAY unit will cancel out:
-			// rewardAmount (peb) = stakeReward (peb) * effectiveStake (KLAY) / totalStakes (KLAY)
-			rewardAmount := new(big.Int).Mul(stakeReward, effectiveStake)
+	// only CNs staking exactly the minimum stake are eligible, which leaves nothing to share
+	if totalStakes.Sign() == 0 {
+		return nodes, nodeShares, remaining
+	}
+	for i := range nodes {
+		if effectiveStakes[i] != nil {
+			// The staking unit will cancel out:
+			// rewardAmount (peb) = stakeReward (peb) * effectiveStake (KLAY or peb) / totalStakes (KLAY or peb)
+			rewardAmount := new(big.Int).Mul(stakeReward, effectiveStakes[i])
 			rewardAmount = rewardAmount.Div(rewardAmount, totalStakes)
 			remaining = remaining.Sub(remaining, rewardAmount)
-			if rewardAmount.Cmp(big.NewInt(0)) > 0 {
-				shares[node.RewardAddr] = rewardAmount
+			if rewardAmount.Sign() > 0 {
+				nodeShares[i] = rewardAmount
 			}
 		}
 	}
-	logger.Debug("calcShares()",
-		"[in] stakeReward", stakeReward.Uint64(),
-		"[out] remaining", remaining.Uint64(),
-		"[out] shares", shares,
-	)
+	return nodes, nodeShares, remaining
+}
 
-	return shares, remaining
+// calcEffectiveStakes returns the consolidated CNs with their effective stakes, which are the stakes
+// above the minimum stake (nil if not eligible), and the total of the effective stakes. The stakes are
+// in peb if usePeb is true, in KLAY otherwise. The eligibility is always decided in peb: a CN is
+// eligible if its stake is above the minimum stake, or equal to it as well if inclusive is true.
+// If inclusive, the effective stakes are counted from one KLAY below the minimum stake, so that
+// a CN at the minimum stake has the weight of one KLAY.
+func calcEffectiveStakes(stakingInfo *StakingInfo, minStake uint64, inclusive bool) (nodes []consolidatedNode, effectiveStakes []*big.Int, totalStakes *big.Int, usePeb bool) {
+	nodes = stakingInfo.GetConsolidatedStakingInfo().GetAllNodes()
+
+	minStakePeb := new(big.Int).Mul(new(big.Int).SetUint64(minStake), big.NewInt(params.KLAY))
+	usePeb = stakingInfo.hasPebAmounts()
+
+	// effective stakes, in the same unit as stakeFloor
+	stakeFloor := new(big.Int).SetUint64(minStake)
+	if inclusive && minStake > 0 {
+		stakeFloor = stakeFloor.Sub(stakeFloor, common.Big1)
+	}
+	if usePeb {
+		stakeFloor = stakeFloor.Mul(stakeFloor, big.NewInt(params.KLAY))
+	}
+	effectiveStakes = make([]*big.Int, len(nodes))
+	totalStakes = big.NewInt(0)
+	for i, node := range nodes {
+		if !isAboveMinStake(nodeStakePeb(node), minStakePeb, inclusive) {
+			continue
+		}
+		stake := nodeStake(node, usePeb)
+		effectiveStakes[i] = stake.Sub(stake, stakeFloor)
+		totalStakes = totalStakes.Add(totalStakes, effectiveStakes[i])
+	}
+	return nodes, effectiveStakes, totalStakes, usePeb
+}
+
+// isAboveMinStake returns whether the stake is eligible for the staking share against the minimum
+// stake, both in peb. The stake equal to the minimum stake is eligible only if inclusive is true.
+func isAboveMinStake(stakePeb, minStakePeb *big.Int, inclusive bool) bool {
+	cmp := stakePeb.Cmp(minStakePeb)
+	return cmp > 0 || (inclusive && cmp == 0)
+}
+
+// nodeStakePeb returns a copy of the stake of the consolidated CN in peb.
+func nodeStakePeb(node consolidatedNode) *big.Int {
+	if node.StakingAmountPeb != nil {
+		return new(big.Int).Set(node.StakingAmountPeb)
+	}
+	stake := new(big.Int).SetUint64(node.StakingAmount)
+	return stake.Mul(stake, big.NewInt(params.KLAY))
 }
 
-// parseRewardRatio parses string `ratio` into ints
+// nodeStake returns a copy of the stake of the consolidated CN in peb if usePeb is true, in KLAY otherwise.
+func nodeStake(node consolidatedNode, usePeb bool) *big.Int {
+	if usePeb {
+		return new(big.Int).Set(node.StakingAmountPeb)
+	}
+	return new(big.Int).SetUint64(node.StakingAmount)
+}
+
+// parseRewardRatio parses string `ratio` into ints, in basis points if any part is a decimal.
+// See params.ParseRatio.
 func parseRewardRatio(ratio string) (int64, int64, int64, int64, error) {
 	s := strings.Split(ratio, "/")
 	if len(s) != params.RewardSliceCount {
 		logger.Error("Invalid ratio format", "ratio", ratio)
 		return 0, 0, 0, 0, errInvalidFormat
 	}
-	cn, err1 := strconv.ParseInt(s[0], 10, 64)
-	kff, err2 := strconv.ParseInt(s[1], 10, 64)
-	kcf, err3 := strconv.ParseInt(s[2], 10, 64)
-
-	if err1 != nil || err2 != nil || err3 != nil {
+	parts, err := params.ParseRatio(ratio)
+	if err != nil {
 		logger.Error("Could not parse ratio", "ratio", ratio)
 		return 0, 0, 0, 0, errParsingRatio
 	}
+	cn, kff, kcf := parts[0], parts[1], parts[2]
 	return cn, kff, kcf, cn + kff + kcf, nil
 }
 
-// parseRewardKip82Ratio parses string `kip82ratio` into ints
+// parseRewardKip82Ratio parses string `kip82ratio` into ints, in basis points if any part is a decimal.
+// See params.ParseRatio.
 func parseRewardKip82Ratio(ratio string) (int64, int64, int64, error) {
 	s := strings.Split(ratio, "/")
 	if len(s) != params.RewardKip82SliceCount {
 		logger.Error("Invalid kip82ratio format", "ratio", ratio)
 		return 0, 0, 0, errInvalidFormat
 	}
-	proposer, err1 := strconv.ParseInt(s[0], 10, 64)
-	stakers, err2 := strconv.ParseInt(s[1], 10, 64)
-
-	if err1 != nil || err2 != nil {
+	parts, err := params.ParseRatio(ratio)
+	if err != nil {
 		logger.Error("Could not parse kip82ratio", "ratio", ratio)
 		return 0, 0, 0, errParsingRatio
 	}
+	proposer, stakers := parts[0], parts[1]
 	return proposer, stakers, proposer + stakers, nil
 }
 
@@ -582,6 +1055,16 @@ func incrementRewardsMap(m map[common.Address]*big.Int, addr common.Address, amo
 	m[addr] = m[addr].Add(m[addr], amount)
 }
 
+func incrementBreakdown(m map[common.Address]*RewardBreakdown, addr common.Address, delta *RewardBreakdown) {
+	if m == nil {
+		return
+	}
+	if _, ok := m[addr]; !ok {
+		m[addr] = &RewardBreakdown{}
+	}
+	m[addr].add(delta)
+}
+
 // ecrecover extracts the Klaytn account address from a signed header.
 func ecrecover(header *types.Header) (common.Address, error) {
 	// Retrieve the signature from the header extra-data
@@ -19,6 +19,7 @@ package reward
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 
@@ -43,21 +44,37 @@ func (governance *testGovernance) setTestGovernance(intMap map[int]interface{})
 	governance.p = p
 }
 
+// assertEqualRewardSpecs compares the specs, and the breakdowns only if the expected one has them.
+// The breakdown of each recipient must sum up to its reward in any case.
 func assertEqualRewardSpecs(t *testing.T, expected, actual *RewardSpec, msgAndArgs ...interface{}) {
 	expectedJson, err := json.MarshalIndent(expected, "", "  ")
 	require.Nil(t, err)
 
-	actualJson, err := json.MarshalIndent(actual, "", "  ")
+	actualCopy := *actual
+	if expected.Breakdown == nil {
+		actualCopy.Breakdown = nil
+	}
+	actualJson, err := json.MarshalIndent(&actualCopy, "", "  ")
 	require.Nil(t, err)
 
 	assert.Equal(t, string(expectedJson), string(actualJson), msgAndArgs...)
 
 	lhs := new(big.Int).Add(actual.Minted, actual.TotalFee)
 	lhs = lhs.Sub(lhs, actual.BurntFee)
+	lhs = lhs.Add(lhs, zeroIfNil(actual.CarriedIn))
+	lhs = lhs.Sub(lhs, zeroIfNil(actual.BurntRemainder))
+	lhs = lhs.Sub(lhs, zeroIfNil(actual.CarriedOver))
 	rhs := new(big.Int).Add(actual.Proposer, actual.Stakers)
 	rhs = rhs.Add(rhs, actual.KFF)
 	rhs = rhs.Add(rhs, actual.KCF)
 	assert.True(t, lhs.Cmp(rhs) == 0, msgAndArgs...)
+
+	assert.Equal(t, len(actual.Rewards), len(actual.Breakdown), msgAndArgs...)
+	for addr, amount := range actual.Rewards {
+		if assert.Contains(t, actual.Breakdown, addr, msgAndArgs...) {
+			assert.Equal(t, amount.String(), actual.Breakdown[addr].Total().String(), msgAndArgs...)
+		}
+	}
 }
 
 var (
@@ -111,6 +128,20 @@ func genStakingInfo(cnNum int, rewardOverride map[int]int, amountOverride map[in
 	}
 }
 
+// genStakingInfoPeb generates a staking information with the peb staking amounts, where every node
+// stakes minStaking KLAY plus the peb given by extraOverride. The KLAY staking amounts are all minStaking.
+func genStakingInfoPeb(cnNum int, extraOverride map[int]*big.Int) *StakingInfo {
+	stakingInfo := genStakingInfo(cnNum, nil, nil)
+	for i := 0; i < cnNum; i++ {
+		amount := new(big.Int).Mul(new(big.Int).SetUint64(minStaking), big.NewInt(params.KLAY))
+		if extra, ok := extraOverride[i]; ok {
+			amount = amount.Add(amount, extra)
+		}
+		stakingInfo.CouncilStakingAmountsPeb = append(stakingInfo.CouncilStakingAmountsPeb, amount)
+	}
+	return stakingInfo
+}
+
 type testBalanceAdder struct {
 	accounts map[common.Address]*big.Int
 }
@@ -287,12 +318,79 @@ func TestRewardDistributor_getBurnAmountMagma(t *testing.T) {
 		header.GasUsed = testCase.gasUsed
 		header.BaseFee = testCase.baseFee
 		txFee := GetTotalTxFee(header, rules, pset)
-		burnedTxFee := getBurnAmountMagma(txFee)
+		burnedTxFee := getBurnAmountMagma(txFee, GetBurnRatio(pset))
 		// expectedTotalTxFee = GetTotalTxFee / 2 = BurnedTxFee
 		assert.Equal(t, testCase.expectedTotalTxFee.Uint64(), burnedTxFee.Uint64())
 	}
 }
 
+func TestRewardDistributor_BurnRatio(t *testing.T) {
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		GasUsed:    1000,
+		BaseFee:    big.NewInt(1),
+		Rewardbase: proposerAddr,
+	}
+
+	testcases := []struct {
+		burnRatio uint64
+		deferred  bool
+		burnt     uint64
+	}{
+		{0, false, 0},
+		{30, false, 300},
+		{100, false, 1000},
+		{0, true, 0},
+		{30, true, 300},
+		{100, true, 1000},
+	}
+
+	for i, tc := range testcases {
+		config := noKore(getTestConfig())
+		config.Governance.Reward.DeferredTxFee = tc.deferred
+		config.Governance.Reward.BurnRatio = &tc.burnRatio
+		rules := config.Rules(header.Number)
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+
+		spec, err := GetBlockRewardWithStakingInfo(header, rules, pset, nil)
+		require.Nil(t, err, "testcases[%d]", i)
+		assert.Equal(t, tc.burnt, spec.BurntFee.Uint64(), "testcases[%d]", i)
+		assert.Equal(t, 1000-tc.burnt, new(big.Int).Sub(spec.Proposer, spec.Minted).Uint64(), "testcases[%d]", i)
+	}
+
+	// the burn ratio is 50 without the parameter
+	pset, err := params.NewGovParamSetChainConfig(noKore(getTestConfig()))
+	require.Nil(t, err)
+	spec, err := GetBlockRewardWithStakingInfo(header, noKore(getTestConfig()).Rules(header.Number), pset, nil)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(500), spec.BurntFee.Uint64())
+}
+
+func TestRewardDistributor_GetPendingBlockReward(t *testing.T) {
+	var (
+		header = &types.Header{
+			Number:     big.NewInt(1),
+			GasUsed:    1000,
+			Rewardbase: proposerAddr,
+		}
+		proposer = common.HexToAddress("0x0000000000000000000000000000000000000bbb")
+		config   = roundrobin(noDeferred(noMagma(getTestConfig())))
+		rules    = config.Rules(header.Number)
+	)
+	pset, err := params.NewGovParamSetChainConfig(config)
+	require.Nil(t, err)
+
+	// the tx fee paid during the tx execution is credited to the given proposer since the pending block is not sealed.
+	// The fee is also distributed to the rewardbase before Magma (see CalcDeferredRewardSimple).
+	spec, err := GetPendingBlockReward(header, rules, pset, nil, proposer)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(2000), spec.TotalFee)
+	assert.Equal(t, new(big.Int).Add(minted, big.NewInt(2000)), spec.Proposer)
+	assert.Equal(t, big.NewInt(1000), spec.Rewards[proposer])
+	assert.Equal(t, new(big.Int).Add(minted, big.NewInt(1000)), spec.Rewards[proposerAddr])
+}
+
 func TestRewardDistributor_GetBlockReward(t *testing.T) {
 	oldStakingManager := GetStakingManager()
 	defer SetTestStakingManager(oldStakingManager)
@@ -411,6 +509,94 @@ func TestRewardDistributor_GetBlockReward(t *testing.T) {
 	}
 }
 
+func TestRewardDistributor_GetBlockReward_Redirect(t *testing.T) {
+	oldStakingManager := GetStakingManager()
+	defer SetTestStakingManager(oldStakingManager)
+
+	var (
+		header = &types.Header{
+			Number:     big.NewInt(1),
+			GasUsed:    1000,
+			BaseFee:    big.NewInt(1),
+			Rewardbase: proposerAddr,
+		}
+		stakingInfo = genStakingInfo(5, nil, map[int]uint64{
+			0: minStaking + 4,
+			1: minStaking + 3,
+		})
+		redirect = intToAddress(3000)
+	)
+
+	testcases := []struct {
+		policy        istanbul.ProposerPolicy
+		deferredTxFee bool
+		forked        bool
+		expected      map[common.Address]*big.Int
+	}{
+		{
+			policy:        istanbul.RoundRobin,
+			deferredTxFee: true,
+			forked:        true,
+			expected: map[common.Address]*big.Int{
+				redirect: new(big.Int).SetUint64(9.6e18 + 500),
+			},
+		},
+		{
+			policy:        istanbul.WeightedRandom,
+			deferredTxFee: true,
+			forked:        true,
+			expected: map[common.Address]*big.Int{
+				redirect: new(big.Int).SetUint64(9.6e18),
+			},
+		},
+		{
+			// the fee paid to the proposer during TX execution is not redirected
+			policy:        istanbul.WeightedRandom,
+			deferredTxFee: false,
+			forked:        true,
+			expected: map[common.Address]*big.Int{
+				redirect:     new(big.Int).SetUint64(9.6e18),
+				proposerAddr: new(big.Int).SetUint64(500),
+			},
+		},
+		{
+			// the redirect address is ignored before the fork
+			policy:        istanbul.RoundRobin,
+			deferredTxFee: true,
+			forked:        false,
+			expected: map[common.Address]*big.Int{
+				proposerAddr: new(big.Int).SetUint64(9.6e18 + 500),
+			},
+		},
+	}
+
+	SetTestStakingManagerWithStakingInfoCache(stakingInfo)
+
+	for i, tc := range testcases {
+		config := getTestConfig()
+		if !tc.deferredTxFee {
+			config = noDeferred(config)
+		}
+		config.Istanbul.ProposerPolicy = uint64(tc.policy)
+		config.Governance.Reward.RedirectAddress = redirect
+		if tc.forked {
+			config.RewardRedirectCompatibleBlock = big.NewInt(0)
+		}
+
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+
+		spec, err := GetBlockReward(header, config.Rules(header.Number), pset)
+		require.Nil(t, err, "testcases[%d] failed", i)
+		assert.Equal(t, tc.expected, spec.Rewards, "testcases[%d] failed", i)
+		if tc.forked {
+			assert.Equal(t, &redirect, spec.RedirectedTo, "testcases[%d] failed", i)
+		} else {
+			assert.Nil(t, spec.RedirectedTo, "testcases[%d] failed", i)
+		}
+	}
+}
+
 func TestRewardDistributor_CalcDeferredRewardSimple(t *testing.T) {
 	header := &types.Header{
 		Number:     big.NewInt(1),
@@ -838,7 +1024,73 @@ func TestRewardDistributor_CalcDeferredReward_StakingInfos(t *testing.T) {
 	}
 }
 
-func TestRewardDistributor_CalcDeferredReward_Remainings(t *testing.T) {
+// Tests that the breakdown tells the portions of a proposer who is also a staker
+// and gets the KFF portion, as well as the tx fee paid during the tx execution.
+func TestRewardDistributor_GetBlockReward_Breakdown(t *testing.T) {
+	var (
+		header = &types.Header{
+			Number:     big.NewInt(1),
+			GasUsed:    1000,
+			BaseFee:    big.NewInt(1),
+			Rewardbase: intToAddress(rewardBaseAddr),
+		}
+		config  = noDeferred(getTestConfig())
+		rules   = config.Rules(header.Number)
+		pset, _ = params.NewGovParamSetChainConfig(config)
+
+		stakingInfo = genStakingInfo(2, nil, map[int]uint64{
+			0: minStaking + 3,
+			1: minStaking + 4,
+		})
+		amount = func(s string) *big.Int {
+			n, _ := new(big.Int).SetString(s, 10)
+			return n
+		}
+	)
+	stakingInfo.KFFAddr = common.Address{}
+
+	expected := &RewardSpec{
+		Minted:   minted,
+		TotalFee: big.NewInt(1000),
+		BurntFee: big.NewInt(500),
+		Proposer: amount("5836800000000000501"), // proposer + share remainder + kff + fee
+		Stakers:  amount("2611199999999999999"),
+		KFF:      big.NewInt(0),
+		KCF:      amount("1152000000000000000"),
+		Rewards: map[common.Address]*big.Int{
+			intToAddress(rewardBaseAddr):     amount("6955885714285714786"),
+			intToAddress(rewardBaseAddr + 1): amount("1492114285714285714"),
+			kcfAddr:                          amount("1152000000000000000"),
+		},
+		Breakdown: map[common.Address]*RewardBreakdown{
+			intToAddress(rewardBaseAddr): {
+				Proposer: amount("652800000000000000"),
+				Stakers:  amount("1119085714285714286"), // its share and the remainder
+				KFF:      amount("5184000000000000000"),
+				Fee:      big.NewInt(500),
+			},
+			intToAddress(rewardBaseAddr + 1): {
+				Stakers: amount("1492114285714285714"),
+			},
+			kcfAddr: {
+				KCF: amount("1152000000000000000"),
+			},
+		},
+	}
+
+	spec, err := GetBlockRewardWithStakingInfo(header, rules, pset, stakingInfo)
+	require.Nil(t, err)
+	assertEqualRewardSpecs(t, expected, spec)
+
+	total := NewRewardSpec()
+	total.Add(spec)
+	total.Add(spec)
+	assert.Equal(t, amount("1305600000000000000"), total.Breakdown[intToAddress(rewardBaseAddr)].Proposer)
+	assert.Equal(t, big.NewInt(1000), total.Breakdown[intToAddress(rewardBaseAddr)].Fee)
+	assert.Nil(t, total.Breakdown[intToAddress(rewardBaseAddr)].KCF)
+}
+
+func TestRewardDistributor_CalcDeferredReward_KFFSplit(t *testing.T) {
 	oldStakingManager := GetStakingManager()
 	defer SetTestStakingManager(oldStakingManager)
 
@@ -849,121 +1101,587 @@ func TestRewardDistributor_CalcDeferredReward_Remainings(t *testing.T) {
 			BaseFee:    big.NewInt(1),
 			Rewardbase: proposerAddr,
 		}
-
-		stakingInfo = genStakingInfo(5, nil, map[int]uint64{
-			0: minStaking + 4,
-			1: minStaking + 3,
-		})
-		splitRemainingConfig = getTestConfig()
+		fund1 = intToAddress(3001)
+		fund2 = intToAddress(3002)
 	)
-	splitRemainingConfig.Governance.Reward.MintingAmount = big.NewInt(333)
 
 	testcases := []struct {
-		desc     string
-		config   *params.ChainConfig
-		expected *RewardSpec
+		desc        string
+		split       string
+		notForked   bool
+		stakingInfo *StakingInfo
+		expected    *RewardSpec
 	}{
 		{
-			desc:   "split remaining goes to kff",
-			config: splitRemainingConfig,
+			desc:  "the kff portion is split instead of paid to the kff address",
+			split: fund1.Hex() + ":70," + fund2.Hex() + ":30",
+			stakingInfo: &StakingInfo{
+				KCFAddr: kcfAddr,
+				KFFAddr: kffAddr,
+			},
 			expected: &RewardSpec{
-				Minted:   big.NewInt(333),
+				Minted:   minted,
 				TotalFee: big.NewInt(1000),
-				BurntFee: big.NewInt(522),
-				Proposer: big.NewInt(501), // proposer=22, rewardFee=478, shareRem=1
-				Stakers:  big.NewInt(89),
-				KFF:      big.NewInt(182), // splitRem=3
-				KCF:      big.NewInt(39),
+				BurntFee: big.NewInt(1000),
+				Proposer: big.NewInt(3.264e18),
+				Stakers:  big.NewInt(0),
+				KFF:      big.NewInt(5.184e18),
+				KCF:      big.NewInt(1.152e18),
 				Rewards: map[common.Address]*big.Int{
-					proposerAddr:                     big.NewInt(501),
-					kffAddr:                          big.NewInt(182),
-					kcfAddr:                          big.NewInt(39),
-					intToAddress(rewardBaseAddr):     big.NewInt(51), // stakers * 4/7
-					intToAddress(rewardBaseAddr + 1): big.NewInt(38), // stakers * 3/7
+					proposerAddr: big.NewInt(3.264e18),
+					kcfAddr:      big.NewInt(1.152e18),
+					fund1:        big.NewInt(3.6288e18),
+					fund2:        big.NewInt(1.5552e18),
+				},
+				KFFFunds: map[common.Address]*big.Int{
+					fund1: big.NewInt(3.6288e18),
+					fund2: big.NewInt(1.5552e18),
 				},
 			},
 		},
 		{
-			desc:   "share remaining goes to proposer",
-			config: getTestConfig(),
+			desc:        "the kff portion is split even if stakingInfo is nil",
+			split:       fund1.Hex() + ":70," + fund2.Hex() + ":30",
+			stakingInfo: nil,
 			expected: &RewardSpec{
 				Minted:   minted,
 				TotalFee: big.NewInt(1000),
 				BurntFee: big.NewInt(1000),
-				Proposer: big.NewInt(0.6528e18 + 1),
-				Stakers:  big.NewInt(2.6112e18 - 1),
+				Proposer: big.NewInt(4.416e18),
+				Stakers:  big.NewInt(0),
 				KFF:      big.NewInt(5.184e18),
-				KCF:      big.NewInt(1.152e18),
+				KCF:      big.NewInt(0),
 				Rewards: map[common.Address]*big.Int{
-					proposerAddr:                     big.NewInt(0.6528e18 + 1),
-					kffAddr:                          big.NewInt(5.184e18),
-					kcfAddr:                          big.NewInt(1.152e18),
-					intToAddress(rewardBaseAddr):     big.NewInt(1492114285714285714), // stakers * 4/7
-					intToAddress(rewardBaseAddr + 1): big.NewInt(1119085714285714285), // stakers * 3/7
+					proposerAddr: big.NewInt(4.416e18),
+					fund1:        big.NewInt(3.6288e18),
+					fund2:        big.NewInt(1.5552e18),
+				},
+				KFFFunds: map[common.Address]*big.Int{
+					fund1: big.NewInt(3.6288e18),
+					fund2: big.NewInt(1.5552e18),
 				},
 			},
 		},
-	}
-
-	SetTestStakingManagerWithStakingInfoCache(stakingInfo)
-
-	for _, tc := range testcases {
-		rules := tc.config.Rules(header.Number)
-		pset, err := params.NewGovParamSetChainConfig(tc.config)
-		require.Nil(t, err)
-
-		spec, err := CalcDeferredReward(header, rules, pset)
-		require.Nil(t, err, "failed tc: %s", tc.desc)
-		assertEqualRewardSpecs(t, tc.expected, spec, "failed tc: %s", tc.desc)
-	}
-}
-
-func TestRewardDistributor_calcDeferredFee(t *testing.T) {
-	type Result struct{ total, reward, burnt uint64 }
-
-	testcases := []struct {
-		desc     string
-		isKore   bool
-		isMagma  bool
-		fee      uint64
-		expected *Result
-	}{
 		{
-			desc:    "isKore=false, isMagma=false, fee=1000 [000]",
-			isKore:  false,
-			isMagma: false,
-			fee:     1000,
-			expected: &Result{
-				total:  1000,
-				reward: 1000,
-				burnt:  0,
+			desc:  "the remainder of the split goes to the first fund",
+			split: fund2.Hex() + ":3," + fund1.Hex() + ":4",
+			stakingInfo: &StakingInfo{
+				KCFAddr: kcfAddr,
+				KFFAddr: kffAddr,
 			},
-		},
-		{
-			desc:    "isKore=false, isMagma=false, fee=10e18 [001]",
-			isKore:  false,
-			isMagma: false,
-			fee:     10e18,
-			expected: &Result{
-				total:  10e18,
-				reward: 10e18,
-				burnt:  0,
+			expected: &RewardSpec{
+				Minted:   minted,
+				TotalFee: big.NewInt(1000),
+				BurntFee: big.NewInt(1000),
+				Proposer: big.NewInt(3.264e18),
+				Stakers:  big.NewInt(0),
+				KFF:      big.NewInt(5.184e18),
+				KCF:      big.NewInt(1.152e18),
+				Rewards: map[common.Address]*big.Int{
+					proposerAddr: big.NewInt(3.264e18),
+					kcfAddr:      big.NewInt(1.152e18),
+					fund1:        big.NewInt(2962285714285714285),
+					fund2:        big.NewInt(2221714285714285715),
+				},
+				KFFFunds: map[common.Address]*big.Int{
+					fund1: big.NewInt(2962285714285714285),
+					fund2: big.NewInt(2221714285714285715),
+				},
 			},
 		},
 		{
-			desc:    "isKore=false, isMagma=true, fee=1000 [010]",
-			isKore:  false,
-			isMagma: true,
-			fee:     1000,
-			expected: &Result{
-				total:  1000,
-				reward: 500,
-				burnt:  500,
+			desc:      "the split is ignored before the RewardSplit hardfork",
+			split:     fund1.Hex() + ":70," + fund2.Hex() + ":30",
+			notForked: true,
+			stakingInfo: &StakingInfo{
+				KCFAddr: kcfAddr,
+				KFFAddr: kffAddr,
 			},
-		},
-		{
-			desc:    "isKore=false, isMagma=true, fee=10e18 [011]",
-			isKore:  false,
+			expected: &RewardSpec{
+				Minted:   minted,
+				TotalFee: big.NewInt(1000),
+				BurntFee: big.NewInt(1000),
+				Proposer: big.NewInt(3.264e18),
+				Stakers:  big.NewInt(0),
+				KFF:      big.NewInt(5.184e18),
+				KCF:      big.NewInt(1.152e18),
+				Rewards: map[common.Address]*big.Int{
+					proposerAddr: big.NewInt(3.264e18),
+					kcfAddr:      big.NewInt(1.152e18),
+					kffAddr:      big.NewInt(5.184e18),
+				},
+			},
+		},
+		{
+			desc:  "the kff portion is paid to the kff address if not split",
+			split: "",
+			stakingInfo: &StakingInfo{
+				KCFAddr: kcfAddr,
+				KFFAddr: kffAddr,
+			},
+			expected: &RewardSpec{
+				Minted:   minted,
+				TotalFee: big.NewInt(1000),
+				BurntFee: big.NewInt(1000),
+				Proposer: big.NewInt(3.264e18),
+				Stakers:  big.NewInt(0),
+				KFF:      big.NewInt(5.184e18),
+				KCF:      big.NewInt(1.152e18),
+				Rewards: map[common.Address]*big.Int{
+					proposerAddr: big.NewInt(3.264e18),
+					kcfAddr:      big.NewInt(1.152e18),
+					kffAddr:      big.NewInt(5.184e18),
+				},
+			},
+		},
+	}
+
+	for i, tc := range testcases {
+		if tc.stakingInfo == nil {
+			SetTestStakingManager(nil)
+		} else {
+			SetTestStakingManagerWithStakingInfoCache(tc.stakingInfo)
+		}
+		config := getTestConfig()
+		config.Governance.Reward.KFFSplit = tc.split
+		if !tc.notForked {
+			config.RewardSplitCompatibleBlock = big.NewInt(0)
+		}
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+
+		spec, err := CalcDeferredReward(header, config.Rules(header.Number), pset)
+		require.Nil(t, err, "testcases[%d] failed", i)
+		assertEqualRewardSpecs(t, tc.expected, spec, "testcases[%d] failed: %s", i, tc.desc)
+	}
+}
+
+func TestRewardDistributor_CalcDeferredReward_MinStakeInclusive(t *testing.T) {
+	oldStakingManager := GetStakingManager()
+	defer SetTestStakingManager(oldStakingManager)
+
+	var (
+		header = &types.Header{
+			Number:     big.NewInt(1),
+			GasUsed:    1000,
+			BaseFee:    big.NewInt(1),
+			Rewardbase: proposerAddr,
+		}
+		aboveAddr = intToAddress(rewardBaseAddr)
+		atMinAddr = intToAddress(rewardBaseAddr + 1)
+	)
+	SetTestStakingManagerWithStakingInfoCache(genStakingInfo(2, nil, map[int]uint64{0: minStaking + 3}))
+
+	testcases := []struct {
+		desc          string
+		forked        bool
+		expectedAbove *big.Int
+		expectedAtMin *big.Int
+	}{
+		{
+			desc:          "the option is ignored before the PebStaking hardfork",
+			expectedAbove: big.NewInt(2.6112e18),
+		},
+		{
+			desc:          "the CN at the minimum stake has the weight of one KLAY",
+			forked:        true,
+			expectedAbove: big.NewInt(2.08896e18),
+			expectedAtMin: big.NewInt(0.52224e18),
+		},
+	}
+
+	for i, tc := range testcases {
+		config := getTestConfig()
+		config.Governance.Reward.MinStakeInclusive = true
+		if tc.forked {
+			config.PebStakingCompatibleBlock = big.NewInt(0)
+		}
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+
+		spec, err := CalcDeferredReward(header, config.Rules(header.Number), pset)
+		require.Nil(t, err, "testcases[%d] failed", i)
+		assert.Equal(t, tc.expectedAbove, spec.Rewards[aboveAddr], "testcases[%d] failed: %s", i, tc.desc)
+		assert.Equal(t, tc.expectedAtMin, spec.Rewards[atMinAddr], "testcases[%d] failed: %s", i, tc.desc)
+	}
+}
+
+func TestRewardDistributor_CalcDeferredReward_KCFSplit(t *testing.T) {
+	oldStakingManager := GetStakingManager()
+	defer SetTestStakingManager(oldStakingManager)
+
+	var (
+		header = &types.Header{
+			Number:     big.NewInt(1),
+			GasUsed:    1000,
+			BaseFee:    big.NewInt(1),
+			Rewardbase: proposerAddr,
+		}
+		fund1 = intToAddress(3001)
+		fund2 = intToAddress(3002)
+		fund3 = intToAddress(3003)
+	)
+
+	testcases := []struct {
+		desc        string
+		kffSplit    string
+		kcfSplit    string
+		stakingInfo *StakingInfo
+		expected    *RewardSpec
+	}{
+		{
+			desc:     "the kcf portion is split instead of paid to the kcf address",
+			kcfSplit: fund1.Hex() + ":1," + fund2.Hex() + ":1",
+			stakingInfo: &StakingInfo{
+				KCFAddr: kcfAddr,
+				KFFAddr: kffAddr,
+			},
+			expected: &RewardSpec{
+				Minted:   minted,
+				TotalFee: big.NewInt(1000),
+				BurntFee: big.NewInt(1000),
+				Proposer: big.NewInt(3.264e18),
+				Stakers:  big.NewInt(0),
+				KFF:      big.NewInt(5.184e18),
+				KCF:      big.NewInt(1.152e18),
+				Rewards: map[common.Address]*big.Int{
+					proposerAddr: big.NewInt(3.264e18),
+					kffAddr:      big.NewInt(5.184e18),
+					fund1:        big.NewInt(0.576e18),
+					fund2:        big.NewInt(0.576e18),
+				},
+				KCFFunds: map[common.Address]*big.Int{
+					fund1: big.NewInt(0.576e18),
+					fund2: big.NewInt(0.576e18),
+				},
+			},
+		},
+		{
+			desc:        "the kcf portion is split even if stakingInfo is nil",
+			kcfSplit:    fund1.Hex() + ":1," + fund2.Hex() + ":1",
+			stakingInfo: nil,
+			expected: &RewardSpec{
+				Minted:   minted,
+				TotalFee: big.NewInt(1000),
+				BurntFee: big.NewInt(1000),
+				Proposer: big.NewInt(8.448e18),
+				Stakers:  big.NewInt(0),
+				KFF:      big.NewInt(0),
+				KCF:      big.NewInt(1.152e18),
+				Rewards: map[common.Address]*big.Int{
+					proposerAddr: big.NewInt(8.448e18),
+					fund1:        big.NewInt(0.576e18),
+					fund2:        big.NewInt(0.576e18),
+				},
+				KCFFunds: map[common.Address]*big.Int{
+					fund1: big.NewInt(0.576e18),
+					fund2: big.NewInt(0.576e18),
+				},
+			},
+		},
+		{
+			desc:     "both portions are split and a fund may receive from both",
+			kffSplit: fund1.Hex() + ":70," + fund2.Hex() + ":30",
+			kcfSplit: fund3.Hex() + ":3," + fund1.Hex() + ":1",
+			stakingInfo: &StakingInfo{
+				KCFAddr: kcfAddr,
+				KFFAddr: kffAddr,
+			},
+			expected: &RewardSpec{
+				Minted:   minted,
+				TotalFee: big.NewInt(1000),
+				BurntFee: big.NewInt(1000),
+				Proposer: big.NewInt(3.264e18),
+				Stakers:  big.NewInt(0),
+				KFF:      big.NewInt(5.184e18),
+				KCF:      big.NewInt(1.152e18),
+				Rewards: map[common.Address]*big.Int{
+					proposerAddr: big.NewInt(3.264e18),
+					fund1:        big.NewInt(3.9168e18),
+					fund2:        big.NewInt(1.5552e18),
+					fund3:        big.NewInt(0.864e18),
+				},
+				KFFFunds: map[common.Address]*big.Int{
+					fund1: big.NewInt(3.6288e18),
+					fund2: big.NewInt(1.5552e18),
+				},
+				KCFFunds: map[common.Address]*big.Int{
+					fund3: big.NewInt(0.864e18),
+					fund1: big.NewInt(0.288e18),
+				},
+			},
+		},
+	}
+
+	for i, tc := range testcases {
+		if tc.stakingInfo == nil {
+			SetTestStakingManager(nil)
+		} else {
+			SetTestStakingManagerWithStakingInfoCache(tc.stakingInfo)
+		}
+		config := getTestConfig()
+		config.RewardSplitCompatibleBlock = big.NewInt(0)
+		config.Governance.Reward.KFFSplit = tc.kffSplit
+		config.Governance.Reward.KCFSplit = tc.kcfSplit
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+
+		spec, err := CalcDeferredReward(header, config.Rules(header.Number), pset)
+		require.Nil(t, err, "testcases[%d] failed", i)
+		assertEqualRewardSpecs(t, tc.expected, spec, "testcases[%d] failed: %s", i, tc.desc)
+	}
+}
+
+func TestRewardDistributor_CalcDeferredReward_ProposerSplit(t *testing.T) {
+	oldStakingManager := GetStakingManager()
+	defer SetTestStakingManager(oldStakingManager)
+	SetTestStakingManagerWithStakingInfoCache(&StakingInfo{
+		KCFAddr: kcfAddr,
+		KFFAddr: kffAddr,
+	})
+
+	var (
+		header = &types.Header{
+			Number:     big.NewInt(1),
+			GasUsed:    1000,
+			BaseFee:    big.NewInt(1),
+			Rewardbase: proposerAddr,
+		}
+		fund1 = intToAddress(3001)
+		fund2 = intToAddress(3002)
+	)
+
+	testcases := []struct {
+		desc          string
+		proposerSplit string
+		expected      *RewardSpec
+	}{
+		{
+			desc:          "the proposer portion is split among the funds of the rewardbase",
+			proposerSplit: proposerAddr.Hex() + "=" + fund1.Hex() + ":80," + fund2.Hex() + ":20",
+			expected: &RewardSpec{
+				Minted:   minted,
+				TotalFee: big.NewInt(1000),
+				BurntFee: big.NewInt(1000),
+				Proposer: big.NewInt(3.264e18),
+				Stakers:  big.NewInt(0),
+				KFF:      big.NewInt(5.184e18),
+				KCF:      big.NewInt(1.152e18),
+				Rewards: map[common.Address]*big.Int{
+					fund1:   big.NewInt(2.6112e18),
+					fund2:   big.NewInt(0.6528e18),
+					kffAddr: big.NewInt(5.184e18),
+					kcfAddr: big.NewInt(1.152e18),
+				},
+				ProposerFunds: map[common.Address]*big.Int{
+					fund1: big.NewInt(2.6112e18),
+					fund2: big.NewInt(0.6528e18),
+				},
+			},
+		},
+		{
+			desc:          "a fund may be the rewardbase itself",
+			proposerSplit: proposerAddr.Hex() + "=" + proposerAddr.Hex() + ":7," + fund1.Hex() + ":3",
+			expected: &RewardSpec{
+				Minted:   minted,
+				TotalFee: big.NewInt(1000),
+				BurntFee: big.NewInt(1000),
+				Proposer: big.NewInt(3.264e18),
+				Stakers:  big.NewInt(0),
+				KFF:      big.NewInt(5.184e18),
+				KCF:      big.NewInt(1.152e18),
+				Rewards: map[common.Address]*big.Int{
+					proposerAddr: big.NewInt(2.2848e18),
+					fund1:        big.NewInt(0.9792e18),
+					kffAddr:      big.NewInt(5.184e18),
+					kcfAddr:      big.NewInt(1.152e18),
+				},
+				ProposerFunds: map[common.Address]*big.Int{
+					proposerAddr: big.NewInt(2.2848e18),
+					fund1:        big.NewInt(0.9792e18),
+				},
+			},
+		},
+		{
+			desc:          "the schedules of the other rewardbases do not apply",
+			proposerSplit: intToAddress(3003).Hex() + "=" + fund1.Hex() + ":1",
+			expected: &RewardSpec{
+				Minted:   minted,
+				TotalFee: big.NewInt(1000),
+				BurntFee: big.NewInt(1000),
+				Proposer: big.NewInt(3.264e18),
+				Stakers:  big.NewInt(0),
+				KFF:      big.NewInt(5.184e18),
+				KCF:      big.NewInt(1.152e18),
+				Rewards: map[common.Address]*big.Int{
+					proposerAddr: big.NewInt(3.264e18),
+					kffAddr:      big.NewInt(5.184e18),
+					kcfAddr:      big.NewInt(1.152e18),
+				},
+			},
+		},
+	}
+
+	for i, tc := range testcases {
+		config := getTestConfig()
+		config.RewardSplitCompatibleBlock = big.NewInt(0)
+		config.Governance.Reward.ProposerSplit = tc.proposerSplit
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+
+		spec, err := CalcDeferredReward(header, config.Rules(header.Number), pset)
+		require.Nil(t, err, "testcases[%d] failed", i)
+		assertEqualRewardSpecs(t, tc.expected, spec, "testcases[%d] failed: %s", i, tc.desc)
+		assert.Nil(t, CheckRewardSpec(spec), "testcases[%d] failed", i)
+	}
+}
+
+func TestRewardSpec_Add_KFFFunds(t *testing.T) {
+	var (
+		fund1 = intToAddress(3001)
+		fund2 = intToAddress(3002)
+	)
+
+	total := NewRewardSpec()
+	delta := NewRewardSpec()
+	delta.KFF = big.NewInt(10)
+	delta.KFFFunds = map[common.Address]*big.Int{fund1: big.NewInt(7), fund2: big.NewInt(3)}
+
+	total.Add(delta)
+	total.Add(delta)
+	total.Add(NewRewardSpec())
+
+	assert.Equal(t, big.NewInt(20), total.KFF)
+	assert.Equal(t, map[common.Address]*big.Int{fund1: big.NewInt(14), fund2: big.NewInt(6)}, total.KFFFunds)
+	assert.Nil(t, NewRewardSpec().KFFFunds)
+}
+
+func TestRewardDistributor_CalcDeferredReward_Remainings(t *testing.T) {
+	oldStakingManager := GetStakingManager()
+	defer SetTestStakingManager(oldStakingManager)
+
+	var (
+		header = &types.Header{
+			Number:     big.NewInt(1),
+			GasUsed:    1000,
+			BaseFee:    big.NewInt(1),
+			Rewardbase: proposerAddr,
+		}
+
+		stakingInfo = genStakingInfo(5, nil, map[int]uint64{
+			0: minStaking + 4,
+			1: minStaking + 3,
+		})
+		splitRemainingConfig = getTestConfig()
+	)
+	splitRemainingConfig.Governance.Reward.MintingAmount = big.NewInt(333)
+
+	testcases := []struct {
+		desc     string
+		config   *params.ChainConfig
+		expected *RewardSpec
+	}{
+		{
+			desc:   "split remaining goes to kff",
+			config: splitRemainingConfig,
+			expected: &RewardSpec{
+				Minted:   big.NewInt(333),
+				TotalFee: big.NewInt(1000),
+				BurntFee: big.NewInt(522),
+				Proposer: big.NewInt(501), // proposer=22, rewardFee=478, shareRem=1
+				Stakers:  big.NewInt(89),
+				KFF:      big.NewInt(182), // splitRem=3
+				KCF:      big.NewInt(39),
+				Rewards: map[common.Address]*big.Int{
+					proposerAddr:                     big.NewInt(501),
+					kffAddr:                          big.NewInt(182),
+					kcfAddr:                          big.NewInt(39),
+					intToAddress(rewardBaseAddr):     big.NewInt(51), // stakers * 4/7
+					intToAddress(rewardBaseAddr + 1): big.NewInt(38), // stakers * 3/7
+				},
+			},
+		},
+		{
+			desc:   "share remaining goes to proposer",
+			config: getTestConfig(),
+			expected: &RewardSpec{
+				Minted:   minted,
+				TotalFee: big.NewInt(1000),
+				BurntFee: big.NewInt(1000),
+				Proposer: big.NewInt(0.6528e18 + 1),
+				Stakers:  big.NewInt(2.6112e18 - 1),
+				KFF:      big.NewInt(5.184e18),
+				KCF:      big.NewInt(1.152e18),
+				Rewards: map[common.Address]*big.Int{
+					proposerAddr:                     big.NewInt(0.6528e18 + 1),
+					kffAddr:                          big.NewInt(5.184e18),
+					kcfAddr:                          big.NewInt(1.152e18),
+					intToAddress(rewardBaseAddr):     big.NewInt(1492114285714285714), // stakers * 4/7
+					intToAddress(rewardBaseAddr + 1): big.NewInt(1119085714285714285), // stakers * 3/7
+				},
+			},
+		},
+	}
+
+	SetTestStakingManagerWithStakingInfoCache(stakingInfo)
+
+	for _, tc := range testcases {
+		rules := tc.config.Rules(header.Number)
+		pset, err := params.NewGovParamSetChainConfig(tc.config)
+		require.Nil(t, err)
+
+		spec, err := CalcDeferredReward(header, rules, pset)
+		require.Nil(t, err, "failed tc: %s", tc.desc)
+		assertEqualRewardSpecs(t, tc.expected, spec, "failed tc: %s", tc.desc)
+	}
+}
+
+func TestRewardDistributor_calcDeferredFee(t *testing.T) {
+	type Result struct{ total, reward, burnt uint64 }
+
+	testcases := []struct {
+		desc     string
+		isKore   bool
+		isMagma  bool
+		fee      uint64
+		expected *Result
+	}{
+		{
+			desc:    "isKore=false, isMagma=false, fee=1000 [000]",
+			isKore:  false,
+			isMagma: false,
+			fee:     1000,
+			expected: &Result{
+				total:  1000,
+				reward: 1000,
+				burnt:  0,
+			},
+		},
+		{
+			desc:    "isKore=false, isMagma=false, fee=10e18 [001]",
+			isKore:  false,
+			isMagma: false,
+			fee:     10e18,
+			expected: &Result{
+				total:  10e18,
+				reward: 10e18,
+				burnt:  0,
+			},
+		},
+		{
+			desc:    "isKore=false, isMagma=true, fee=1000 [010]",
+			isKore:  false,
+			isMagma: true,
+			fee:     1000,
+			expected: &Result{
+				total:  1000,
+				reward: 500,
+				burnt:  500,
+			},
+		},
+		{
+			desc:    "isKore=false, isMagma=true, fee=10e18 [011]",
+			isKore:  false,
 			isMagma: true,
 			fee:     10e18,
 			expected: &Result{
@@ -1054,6 +1772,45 @@ func TestRewardDistributor_calcDeferredFee_nodeferred(t *testing.T) {
 	assert.Equal(t, uint64(0), burnt.Uint64())
 }
 
+func TestRewardDistributor_CalcTxFeeSplit(t *testing.T) {
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		GasUsed:    1000,
+		BaseFee:    big.NewInt(1),
+		Rewardbase: proposerAddr,
+	}
+	stakingInfo := genStakingInfo(5, nil, map[int]uint64{
+		0: minStaking + 4,
+		1: minStaking + 3,
+	})
+
+	testcases := []struct {
+		config *params.ChainConfig
+		burnt  uint64
+	}{
+		{noDeferred(noKore(getTestConfig())), 500},
+		{noKore(getTestConfig()), 500},
+		{getTestConfig(), 1000},
+		{roundrobin(getTestConfig()), 500},
+	}
+
+	for i, tc := range testcases {
+		rules := tc.config.Rules(header.Number)
+		pset, err := params.NewGovParamSetChainConfig(tc.config)
+		require.Nil(t, err)
+
+		split, err := CalcTxFeeSplit(header, rules, pset, stakingInfo)
+		require.Nil(t, err, "testcases[%d]", i)
+		assert.Equal(t, uint64(1000), split.TotalFee.Uint64(), "testcases[%d]", i)
+		assert.Equal(t, tc.burnt, split.BurntFee.Uint64(), "testcases[%d]", i)
+		assert.Equal(t, uint64(0), split.Minted.Uint64(), "testcases[%d]", i)
+
+		sum := new(big.Int).Add(split.BurntFee, split.Proposer)
+		sum.Add(sum, split.Stakers).Add(sum, split.KFF).Add(sum, split.KCF)
+		assert.Equal(t, split.TotalFee, sum, "testcases[%d]", i)
+	}
+}
+
 func TestRewardDistributor_calcSplit(t *testing.T) {
 	type Result struct{ proposer, stakers, kff, kcf, remaining uint64 }
 
@@ -1155,6 +1912,37 @@ func TestRewardDistributor_calcSplit(t *testing.T) {
 	}
 }
 
+func TestRewardDistributor_splitByLargestRemainder(t *testing.T) {
+	testcases := []struct {
+		source   int64
+		ratios   []int64
+		expected []int64
+	}{
+		{0, []int64{3333, 3333, 3334}, []int64{0, 0, 0}},
+		{100, []int64{3333, 3333, 3334}, []int64{33, 33, 34}},
+		{10, []int64{3333, 3333, 3334}, []int64{3, 3, 4}},
+		{2, []int64{3333, 3333, 3334}, []int64{1, 0, 1}}, // the largest remainder first, then the former part in a tie
+		{1e18, []int64{3450, 5400, 1150}, []int64{0.345e18, 0.54e18, 0.115e18}},
+		{7, []int64{2000, 8000}, []int64{1, 6}},
+		{1, []int64{5000, 5000}, []int64{1, 0}},
+		{1, []int64{0, 10000}, []int64{0, 1}},
+	}
+
+	for _, tc := range testcases {
+		ratios := make([]*big.Int, len(tc.ratios))
+		for i, r := range tc.ratios {
+			ratios[i] = big.NewInt(r)
+		}
+		parts := splitByLargestRemainder(big.NewInt(tc.source), ratioBasisPointsTotal, ratios...)
+
+		actual := make([]int64, len(parts))
+		for i, part := range parts {
+			actual[i] = part.Int64()
+		}
+		assert.Equal(t, tc.expected, actual, "source: %d, ratios: %v", tc.source, tc.ratios)
+	}
+}
+
 func TestRewardDistributor_calcShares(t *testing.T) {
 	type Result struct {
 		shares    map[common.Address]*big.Int
@@ -1247,10 +2035,40 @@ func TestRewardDistributor_calcShares(t *testing.T) {
 				remaining: 3,
 			},
 		},
+		{
+			desc: "CN0, CN1: 50% with stakes overflowing uint64",
+			stakingInfo: genStakingInfo(5, nil, map[int]uint64{
+				0: math.MaxUint64,
+				1: math.MaxUint64,
+			}),
+			stakeReward: big.NewInt(500),
+			expected: &Result{
+				shares: map[common.Address]*big.Int{
+					intToAddress(rewardBaseAddr):     big.NewInt(250),
+					intToAddress(rewardBaseAddr + 1): big.NewInt(250),
+				},
+				remaining: 0,
+			},
+		},
+		{
+			desc: "CN0: 66%, CN1: 33% in peb",
+			stakingInfo: genStakingInfoPeb(5, map[int]*big.Int{
+				0: big.NewInt(2),
+				1: big.NewInt(1),
+			}),
+			stakeReward: big.NewInt(500),
+			expected: &Result{
+				shares: map[common.Address]*big.Int{
+					intToAddress(rewardBaseAddr):     big.NewInt(333),
+					intToAddress(rewardBaseAddr + 1): big.NewInt(166),
+				},
+				remaining: 1,
+			},
+		},
 	}
 
 	for _, tc := range testcases {
-		shares, remaining := calcShares(tc.stakingInfo, tc.stakeReward, minStaking)
+		shares, remaining := calcShares(tc.stakingInfo, tc.stakeReward, minStaking, false)
 		actual := &Result{
 			shares:    shares,
 			remaining: remaining.Uint64(),
@@ -1259,6 +2077,66 @@ func TestRewardDistributor_calcShares(t *testing.T) {
 	}
 }
 
+func TestRewardDistributor_calcShares_MinStakeInclusive(t *testing.T) {
+	testcases := []struct {
+		desc              string
+		stakingInfo       *StakingInfo
+		inclusive         bool
+		expectedShares    map[common.Address]*big.Int
+		expectedRemaining *big.Int
+	}{
+		{
+			desc:              "all at the minimum stake, exclusive",
+			stakingInfo:       genStakingInfo(2, nil, nil),
+			expectedShares:    map[common.Address]*big.Int{},
+			expectedRemaining: big.NewInt(1000),
+		},
+		{
+			desc:        "all at the minimum stake, inclusive",
+			stakingInfo: genStakingInfo(2, nil, nil),
+			inclusive:   true,
+			expectedShares: map[common.Address]*big.Int{
+				intToAddress(rewardBaseAddr):     big.NewInt(500),
+				intToAddress(rewardBaseAddr + 1): big.NewInt(500),
+			},
+			expectedRemaining: big.NewInt(0),
+		},
+		{
+			desc:        "one above and one at the minimum stake, exclusive",
+			stakingInfo: genStakingInfo(2, nil, map[int]uint64{0: minStaking + 3}),
+			expectedShares: map[common.Address]*big.Int{
+				intToAddress(rewardBaseAddr): big.NewInt(1000),
+			},
+			expectedRemaining: big.NewInt(0),
+		},
+		{
+			desc:        "one above and one at the minimum stake, inclusive",
+			stakingInfo: genStakingInfo(2, nil, map[int]uint64{0: minStaking + 3}),
+			inclusive:   true,
+			expectedShares: map[common.Address]*big.Int{
+				intToAddress(rewardBaseAddr):     big.NewInt(800),
+				intToAddress(rewardBaseAddr + 1): big.NewInt(200),
+			},
+			expectedRemaining: big.NewInt(0),
+		},
+		{
+			desc:        "below the minimum stake by a peb, inclusive",
+			stakingInfo: genStakingInfoPeb(2, map[int]*big.Int{0: big.NewInt(3), 1: big.NewInt(-1)}),
+			inclusive:   true,
+			expectedShares: map[common.Address]*big.Int{
+				intToAddress(rewardBaseAddr): big.NewInt(1000),
+			},
+			expectedRemaining: big.NewInt(0),
+		},
+	}
+
+	for _, tc := range testcases {
+		shares, remaining := calcShares(tc.stakingInfo, big.NewInt(1000), minStaking, tc.inclusive)
+		assert.Equal(t, tc.expectedShares, shares, "failed tc: %s", tc.desc)
+		assert.Equal(t, tc.expectedRemaining.Uint64(), remaining.Uint64(), "failed tc: %s", tc.desc)
+	}
+}
+
 func benchSetup() (*types.Header, params.Rules, *params.GovParamSet) {
 	// in the worst case, distribute stake shares among N
 	amounts := make(map[int]uint64)
@@ -1289,12 +2167,33 @@ func Benchmark_CalcDeferredReward(b *testing.B) {
 
 	header, rules, pset := benchSetup()
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		CalcDeferredReward(header, rules, pset)
 	}
 }
 
+func TestCalcRewardParamBlock(t *testing.T) {
+	epoch := uint64(30)
+	legacy := params.Rules{}
+	kore := params.Rules{IsKore: true}
+
+	for num := uint64(epoch); num <= 4*epoch; num++ {
+		// Before Kore, the parameters of an epoch block are fetched at the previous epoch block
+		// like the legacy reward config cache.
+		expected := num
+		if num%epoch == 0 {
+			expected = num - epoch
+		}
+		assert.Equal(t, expected, CalcRewardParamBlock(num, epoch, legacy), "block %d", num)
+
+		// After Kore, the parameters are fetched at the block itself.
+		assert.Equal(t, num, CalcRewardParamBlock(num, epoch, kore), "block %d", num)
+	}
+	assert.Equal(t, uint64(0), CalcRewardParamBlock(0, epoch, legacy))
+}
+
 func TestRewardConfigCache_parseRewardRatio(t *testing.T) {
 	testCases := []struct {
 		s   string
@@ -1313,7 +2212,13 @@ func TestRewardConfigCache_parseRewardRatio(t *testing.T) {
 		{"/1/", 0, 0, 0, errParsingRatio},
 		{"//1", 0, 0, 0, errParsingRatio},
 		{"1/2/3/4/", 0, 0, 0, errInvalidFormat},
-		{"3.3/3.3/3.3", 0, 0, 0, errParsingRatio},
+		{"3.3/3.3/3.3", 330, 330, 330, nil},
+		{"3400/5400/1200", 3400, 5400, 1200, nil},
+		{"34.5/54/11.5", 3450, 5400, 1150, nil},
+		{"34.55/54/11.45", 3455, 5400, 1145, nil},
+		{"34.555/54/11.445", 0, 0, 0, errParsingRatio},
+		{"34./54/12", 0, 0, 0, errParsingRatio},
+		{"-1/54/47", 0, 0, 0, errParsingRatio},
 		{"a/b/c", 0, 0, 0, errParsingRatio},
 	}
 
@@ -1346,7 +2251,10 @@ func TestRewardConfigCache_parseRewardKip82Ratio(t *testing.T) {
 		{"1/", 0, 0, errParsingRatio},
 		{"/1", 0, 0, errParsingRatio},
 		{"1/2/", 0, 0, errInvalidFormat},
-		{"3.3/3.3", 0, 0, errParsingRatio},
+		{"3.3/3.3", 330, 330, nil},
+		{"2000/8000", 2000, 8000, nil},
+		{"20.5/79.5", 2050, 7950, nil},
+		{"20.5.5/79.5", 0, 0, errParsingRatio},
 		{"a/b", 0, 0, errParsingRatio},
 	}
 
@@ -0,0 +1,167 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+)
+
+// The file formats the rewards are exported in.
+const (
+	ExportFormatCSV     = "csv"
+	ExportFormatParquet = "parquet"
+)
+
+// RewardExportRow is the reward paid to a recipient in a block, split by the portions it is
+// paid from. The amounts are re-expressed by a report format.
+type RewardExportRow struct {
+	BlockNumber uint64
+	BlockTime   uint64 // in unix seconds
+	BlockHash   common.Hash
+	Recipient   common.Address
+	Amount      string
+	Proposer    string
+	Stakers     string
+	KFF         string
+	KCF         string
+	Fee         string
+}
+
+// exportColumn is a column of the exported rows, either an integer or a string.
+type exportColumn struct {
+	name   string
+	int64  func(row *RewardExportRow) int64 // nil if the column is a string
+	string func(row *RewardExportRow) string
+}
+
+var exportColumns = []exportColumn{
+	{name: "block_number", int64: func(r *RewardExportRow) int64 { return int64(r.BlockNumber) }},
+	{name: "block_time", int64: func(r *RewardExportRow) int64 { return int64(r.BlockTime) }},
+	{name: "block_hash", string: func(r *RewardExportRow) string { return r.BlockHash.Hex() }},
+	{name: "recipient", string: func(r *RewardExportRow) string { return r.Recipient.Hex() }},
+	{name: "amount", string: func(r *RewardExportRow) string { return r.Amount }},
+	{name: "proposer", string: func(r *RewardExportRow) string { return r.Proposer }},
+	{name: "stakers", string: func(r *RewardExportRow) string { return r.Stakers }},
+	{name: "kff", string: func(r *RewardExportRow) string { return r.KFF }},
+	{name: "kcf", string: func(r *RewardExportRow) string { return r.KCF }},
+	{name: "fee", string: func(r *RewardExportRow) string { return r.Fee }},
+}
+
+// NewRewardExportRows returns a row for each recipient of the rewards of the block, in the
+// order of the addresses. The portions of a recipient are zero if the spec has no breakdown.
+func NewRewardExportRows(header *types.Header, spec *RewardSpec, format *RewardReportFormat) []*RewardExportRow {
+	recipients := make([]common.Address, 0, len(spec.Rewards))
+	for addr := range spec.Rewards {
+		recipients = append(recipients, addr)
+	}
+	sort.Slice(recipients, func(i, j int) bool {
+		return bytes.Compare(recipients[i].Bytes(), recipients[j].Bytes()) < 0
+	})
+
+	rows := make([]*RewardExportRow, 0, len(recipients))
+	for _, addr := range recipients {
+		breakdown, ok := spec.Breakdown[addr]
+		if !ok {
+			breakdown = &RewardBreakdown{}
+		}
+		rows = append(rows, &RewardExportRow{
+			BlockNumber: header.Number.Uint64(),
+			BlockTime:   header.Time.Uint64(),
+			BlockHash:   header.Hash(),
+			Recipient:   addr,
+			Amount:      format.FormatAmount(spec.Rewards[addr]),
+			Proposer:    format.FormatAmount(breakdown.Proposer),
+			Stakers:     format.FormatAmount(breakdown.Stakers),
+			KFF:         format.FormatAmount(breakdown.KFF),
+			KCF:         format.FormatAmount(breakdown.KCF),
+			Fee:         format.FormatAmount(breakdown.Fee),
+		})
+	}
+	return rows
+}
+
+// RewardExportWriter writes the exported rows in a file format.
+type RewardExportWriter interface {
+	Write(row *RewardExportRow) error
+	// Close flushes the rows written. It does not close the underlying writer.
+	Close() error
+}
+
+// NewRewardExportWriter returns the writer of the given file format, either csv or parquet.
+func NewRewardExportWriter(w io.Writer, format string) (RewardExportWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return newCSVExportWriter(w), nil
+	case ExportFormatParquet:
+		return newParquetExportWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown reward export format %q, want one of csv and parquet", format)
+	}
+}
+
+// csvExportWriter writes the rows as CSV with a header line.
+type csvExportWriter struct {
+	w      *csv.Writer
+	record []string
+	header bool
+}
+
+func newCSVExportWriter(w io.Writer) *csvExportWriter {
+	return &csvExportWriter{w: csv.NewWriter(w), record: make([]string, len(exportColumns))}
+}
+
+// writeHeader writes the header line unless it is written.
+func (w *csvExportWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	for i, col := range exportColumns {
+		w.record[i] = col.name
+	}
+	return w.w.Write(w.record)
+}
+
+func (w *csvExportWriter) Write(row *RewardExportRow) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	for i, col := range exportColumns {
+		if col.int64 != nil {
+			w.record[i] = strconv.FormatInt(col.int64(row), 10)
+		} else {
+			w.record[i] = col.string(row)
+		}
+	}
+	return w.w.Write(w.record)
+}
+
+func (w *csvExportWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
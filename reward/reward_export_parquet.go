@@ -0,0 +1,290 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// parquetRowGroupSize is the number of the rows buffered in memory before they are written
+// as a row group.
+const parquetRowGroupSize = 10000
+
+var parquetMagic = []byte("PAR1")
+
+// The values of the Parquet format enums used by the writer.
+const (
+	parquetTypeInt64      = 2
+	parquetTypeByteArray  = 6
+	parquetRequired       = 0
+	parquetConvertedUTF8  = 0
+	parquetEncodingPlain  = 0
+	parquetEncodingRLE    = 3
+	parquetCodecNone      = 0
+	parquetPageTypeData   = 0
+	parquetFormatVersion  = 1
+	parquetWriterIdentity = "klaytn"
+)
+
+// parquetColumnChunk is the location of a column of a row group in the file.
+type parquetColumnChunk struct {
+	offset int64 // the offset of the page header
+	size   int64 // the size of the page header and the values
+}
+
+// parquetRowGroup is a row group written to the file.
+type parquetRowGroup struct {
+	rows    int64
+	columns []parquetColumnChunk
+}
+
+// parquetExportWriter writes the rows as a Parquet file of the required flat columns. Each
+// column of a row group is a single uncompressed data page of the plain encoded values,
+// which any Parquet reader accepts without a dependency on a Parquet library.
+type parquetExportWriter struct {
+	w      io.Writer
+	offset int64
+	err    error
+
+	rows   []*RewardExportRow
+	groups []parquetRowGroup
+}
+
+func newParquetExportWriter(w io.Writer) *parquetExportWriter {
+	return &parquetExportWriter{w: w, rows: make([]*RewardExportRow, 0, parquetRowGroupSize)}
+}
+
+func (w *parquetExportWriter) write(data []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.Write(data)
+	w.offset += int64(n)
+	w.err = err
+}
+
+func (w *parquetExportWriter) Write(row *RewardExportRow) error {
+	w.rows = append(w.rows, row)
+	if len(w.rows) == parquetRowGroupSize {
+		w.flush()
+	}
+	return w.err
+}
+
+// flush writes the buffered rows as a row group.
+func (w *parquetExportWriter) flush() {
+	if w.offset == 0 {
+		w.write(parquetMagic)
+	}
+	if len(w.rows) == 0 {
+		return
+	}
+	group := parquetRowGroup{rows: int64(len(w.rows))}
+	for _, col := range exportColumns {
+		var values bytes.Buffer
+		for _, row := range w.rows {
+			if col.int64 != nil {
+				binary.Write(&values, binary.LittleEndian, col.int64(row))
+			} else {
+				s := col.string(row)
+				binary.Write(&values, binary.LittleEndian, uint32(len(s)))
+				values.WriteString(s)
+			}
+		}
+
+		header := new(thriftCompactWriter)
+		header.i32(1, parquetPageTypeData)
+		header.i32(2, int32(values.Len()))
+		header.i32(3, int32(values.Len()))
+		header.structBegin(5)
+		header.i32(1, int32(len(w.rows)))
+		header.i32(2, parquetEncodingPlain)
+		header.i32(3, parquetEncodingRLE)
+		header.i32(4, parquetEncodingRLE)
+		header.structEnd()
+		header.structEnd()
+
+		chunk := parquetColumnChunk{offset: w.offset, size: int64(header.buf.Len() + values.Len())}
+		w.write(header.buf.Bytes())
+		w.write(values.Bytes())
+		group.columns = append(group.columns, chunk)
+	}
+	w.groups = append(w.groups, group)
+	w.rows = w.rows[:0]
+}
+
+// Close writes the buffered rows and the footer describing the row groups.
+func (w *parquetExportWriter) Close() error {
+	w.flush()
+
+	meta := new(thriftCompactWriter)
+	meta.i32(1, parquetFormatVersion)
+	meta.listBegin(2, thriftStruct, len(exportColumns)+1)
+	meta.elemBegin()
+	meta.binary(4, []byte("schema"))
+	meta.i32(5, int32(len(exportColumns)))
+	meta.structEnd()
+	for _, col := range exportColumns {
+		meta.elemBegin()
+		if col.int64 != nil {
+			meta.i32(1, parquetTypeInt64)
+		} else {
+			meta.i32(1, parquetTypeByteArray)
+		}
+		meta.i32(3, parquetRequired)
+		meta.binary(4, []byte(col.name))
+		if col.int64 == nil {
+			meta.i32(6, parquetConvertedUTF8)
+		}
+		meta.structEnd()
+	}
+	var rows int64
+	for _, group := range w.groups {
+		rows += group.rows
+	}
+	meta.i64(3, rows)
+	meta.listBegin(4, thriftStruct, len(w.groups))
+	for _, group := range w.groups {
+		meta.elemBegin()
+		meta.listBegin(1, thriftStruct, len(group.columns))
+		var size int64
+		for i, chunk := range group.columns {
+			col := exportColumns[i]
+			meta.elemBegin()
+			meta.i64(2, chunk.offset)
+			meta.structBegin(3)
+			if col.int64 != nil {
+				meta.i32(1, parquetTypeInt64)
+			} else {
+				meta.i32(1, parquetTypeByteArray)
+			}
+			meta.listBegin(2, thriftI32, 1)
+			meta.elemI32(parquetEncodingPlain)
+			meta.listBegin(3, thriftBinary, 1)
+			meta.elemBinary([]byte(col.name))
+			meta.i32(4, parquetCodecNone)
+			meta.i64(5, group.rows)
+			meta.i64(6, chunk.size)
+			meta.i64(7, chunk.size)
+			meta.i64(9, chunk.offset)
+			meta.structEnd()
+			meta.structEnd()
+			size += chunk.size
+		}
+		meta.i64(2, size)
+		meta.i64(3, group.rows)
+		meta.structEnd()
+	}
+	meta.binary(6, []byte(parquetWriterIdentity))
+	meta.structEnd()
+
+	w.write(meta.buf.Bytes())
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(meta.buf.Len()))
+	w.write(length[:])
+	w.write(parquetMagic)
+	return w.err
+}
+
+// The types of the Thrift compact protocol used by the Parquet metadata.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftCompactWriter encodes the Parquet metadata structs by the Thrift compact protocol.
+// The fields of a struct have to be written in the increasing order of their ids.
+type thriftCompactWriter struct {
+	buf   bytes.Buffer
+	last  int16   // the id of the last field of the current struct
+	stack []int16 // the ids of the last fields of the enclosing structs
+}
+
+func (t *thriftCompactWriter) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	t.buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func (t *thriftCompactWriter) field(id int16, typ byte) {
+	if delta := id - t.last; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.uvarint(uint64(uint16((id << 1) ^ (id >> 15))))
+	}
+	t.last = id
+}
+
+func (t *thriftCompactWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.elemI32(v)
+}
+
+func (t *thriftCompactWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.uvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (t *thriftCompactWriter) binary(id int16, v []byte) {
+	t.field(id, thriftBinary)
+	t.elemBinary(v)
+}
+
+// structBegin starts a struct field, which is ended by structEnd.
+func (t *thriftCompactWriter) structBegin(id int16) {
+	t.field(id, thriftStruct)
+	t.elemBegin()
+}
+
+// elemBegin starts a struct element of a list, which is ended by structEnd.
+func (t *thriftCompactWriter) elemBegin() {
+	t.stack = append(t.stack, t.last)
+	t.last = 0
+}
+
+// structEnd ends the current struct. It ends the top-level struct if no struct is started.
+func (t *thriftCompactWriter) structEnd() {
+	t.buf.WriteByte(0)
+	if n := len(t.stack); n > 0 {
+		t.last, t.stack = t.stack[n-1], t.stack[:n-1]
+	}
+}
+
+// listBegin starts a list field of the given number of the elements of the type.
+func (t *thriftCompactWriter) listBegin(id int16, elemType byte, size int) {
+	t.field(id, thriftList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		t.buf.WriteByte(0xf0 | elemType)
+		t.uvarint(uint64(size))
+	}
+}
+
+func (t *thriftCompactWriter) elemI32(v int32) {
+	t.uvarint(uint64(uint32((v << 1) ^ (v >> 31))))
+}
+
+func (t *thriftCompactWriter) elemBinary(v []byte) {
+	t.uvarint(uint64(len(v)))
+	t.buf.Write(v)
+}
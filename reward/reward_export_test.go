@@ -0,0 +1,206 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExportRows(t *testing.T) []*RewardExportRow {
+	var (
+		proposer = common.HexToAddress("0x2")
+		staker   = common.HexToAddress("0x1")
+		header   = &types.Header{Number: big.NewInt(7), Time: big.NewInt(1700000000)}
+		spec     = &RewardSpec{
+			Rewards: map[common.Address]*big.Int{
+				proposer: big.NewInt(1.5e18),
+				staker:   big.NewInt(0.25e18),
+			},
+			Breakdown: map[common.Address]*RewardBreakdown{
+				proposer: {Proposer: big.NewInt(1e18), Fee: big.NewInt(0.5e18)},
+			},
+		}
+	)
+	format, err := ParseRewardReportFormat("klay:2")
+	require.NoError(t, err)
+	return NewRewardExportRows(header, spec, format)
+}
+
+func TestNewRewardExportRows(t *testing.T) {
+	rows := newTestExportRows(t)
+	require.Len(t, rows, 2)
+
+	// sorted by the recipients, and the portions are zero without a breakdown
+	assert.Equal(t, common.HexToAddress("0x1"), rows[0].Recipient)
+	assert.Equal(t, "0.25", rows[0].Amount)
+	assert.Equal(t, "0.00", rows[0].Stakers)
+	assert.Equal(t, common.HexToAddress("0x2"), rows[1].Recipient)
+	assert.Equal(t, "1.50", rows[1].Amount)
+	assert.Equal(t, "1.00", rows[1].Proposer)
+	assert.Equal(t, "0.50", rows[1].Fee)
+	assert.Equal(t, uint64(7), rows[1].BlockNumber)
+	assert.Equal(t, uint64(1700000000), rows[1].BlockTime)
+}
+
+func TestRewardExportWriter_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewRewardExportWriter(&buf, ExportFormatCSV)
+	require.NoError(t, err)
+	for _, row := range newTestExportRows(t) {
+		require.NoError(t, w.Write(row))
+	}
+	require.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "block_number,block_time,block_hash,recipient,amount,proposer,stakers,kff,kcf,fee", lines[0])
+	assert.True(t, strings.HasPrefix(lines[2], "7,1700000000,0x"))
+	assert.True(t, strings.HasSuffix(lines[2], ",0x0000000000000000000000000000000000000002,1.50,1.00,0.00,0.00,0.00,0.50"))
+
+	_, err = NewRewardExportWriter(&buf, "xlsx")
+	assert.Error(t, err)
+}
+
+// thriftCompactReader decodes the Thrift compact structs written by thriftCompactWriter.
+// The integers are decoded as int64, the binaries as []byte, the lists as []interface{}
+// and the structs as map[int16]interface{}.
+type thriftCompactReader struct {
+	r *bytes.Reader
+}
+
+func (t *thriftCompactReader) zigzag() int64 {
+	v, _ := binary.ReadUvarint(t.r)
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (t *thriftCompactReader) value(typ byte) interface{} {
+	switch typ {
+	case thriftI32, thriftI64:
+		return t.zigzag()
+	case thriftBinary:
+		n, _ := binary.ReadUvarint(t.r)
+		b := make([]byte, n)
+		t.r.Read(b)
+		return b
+	case thriftList:
+		header, _ := t.r.ReadByte()
+		size, elemType := int(header>>4), header&0x0f
+		if size == 15 {
+			n, _ := binary.ReadUvarint(t.r)
+			size = int(n)
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = t.value(elemType)
+		}
+		return list
+	case thriftStruct:
+		fields := make(map[int16]interface{})
+		var last int16
+		for {
+			header, err := t.r.ReadByte()
+			if err != nil || header == 0 {
+				return fields
+			}
+			id := last + int16(header>>4)
+			if header>>4 == 0 {
+				id = int16(t.zigzag())
+			}
+			fields[id], last = t.value(header&0x0f), id
+		}
+	}
+	panic("unexpected thrift type")
+}
+
+func TestRewardExportWriter_Parquet(t *testing.T) {
+	rows := newTestExportRows(t)
+	var buf bytes.Buffer
+	w, err := NewRewardExportWriter(&buf, ExportFormatParquet)
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, w.Write(row))
+	}
+	require.NoError(t, w.Close())
+
+	file := buf.Bytes()
+	require.Equal(t, parquetMagic, file[:4])
+	require.Equal(t, parquetMagic, file[len(file)-4:])
+	metaLen := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	meta := (&thriftCompactReader{bytes.NewReader(file[len(file)-8-metaLen : len(file)-8])}).value(thriftStruct).(map[int16]interface{})
+
+	assert.Equal(t, int64(len(rows)), meta[3])
+	schema := meta[2].([]interface{})
+	require.Len(t, schema, len(exportColumns)+1)
+	assert.Equal(t, int64(len(exportColumns)), schema[0].(map[int16]interface{})[5])
+
+	groups := meta[4].([]interface{})
+	require.Len(t, groups, 1)
+	chunks := groups[0].(map[int16]interface{})[1].([]interface{})
+	require.Len(t, chunks, len(exportColumns))
+	for i, col := range exportColumns {
+		assert.Equal(t, []byte(col.name), schema[i+1].(map[int16]interface{})[4])
+
+		// each column is a page of the plain encoded values at the data page offset
+		colMeta := chunks[i].(map[int16]interface{})[3].(map[int16]interface{})
+		assert.Equal(t, []interface{}{[]byte(col.name)}, colMeta[3])
+		page := bytes.NewReader(file[colMeta[9].(int64):])
+		header := (&thriftCompactReader{page}).value(thriftStruct).(map[int16]interface{})
+		assert.Equal(t, int64(len(rows)), header[5].(map[int16]interface{})[1])
+		values := make([]byte, header[3].(int64))
+		page.Read(values)
+
+		for _, row := range rows {
+			if col.int64 != nil {
+				assert.Equal(t, col.int64(row), int64(binary.LittleEndian.Uint64(values)))
+				values = values[8:]
+			} else {
+				n := binary.LittleEndian.Uint32(values)
+				assert.Equal(t, col.string(row), string(values[4:4+n]))
+				values = values[4+n:]
+			}
+		}
+		assert.Empty(t, values)
+	}
+}
+
+func TestRewardExportWriter_ParquetRowGroups(t *testing.T) {
+	row := newTestExportRows(t)[0]
+	var buf bytes.Buffer
+	w := newParquetExportWriter(&buf)
+	for i := 0; i < parquetRowGroupSize+1; i++ {
+		require.NoError(t, w.Write(row))
+	}
+	require.NoError(t, w.Close())
+
+	file := buf.Bytes()
+	metaLen := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	meta := (&thriftCompactReader{bytes.NewReader(file[len(file)-8-metaLen : len(file)-8])}).value(thriftStruct).(map[int16]interface{})
+	assert.Equal(t, int64(parquetRowGroupSize+1), meta[3])
+	groups := meta[4].([]interface{})
+	require.Len(t, groups, 2)
+	assert.Equal(t, int64(parquetRowGroupSize), groups[0].(map[int16]interface{})[3])
+	assert.Equal(t, int64(1), groups[1].(map[int16]interface{})[3])
+}
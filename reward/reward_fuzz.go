@@ -0,0 +1,124 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build gofuzz
+// +build gofuzz
+
+package reward
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/params"
+)
+
+// Fuzz calculates the reward of a configuration decoded from data and panics if the
+// reward spec violates the invariants checked by CheckRewardSpec.
+//
+// The data is a sequence of little-endian uint64: the minting amount, the gas used,
+// the base fee, the minimum stake, the CN and KFF parts of the ratio and the proposer
+// part of the kip82 ratio in basis points, the burn ratio, the flags of the hardforks
+// and the options, followed by the staking amounts of the nodes.
+func Fuzz(data []byte) int {
+	const header = 9
+	if len(data) < 8*header || len(data)%8 != 0 {
+		return 0
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	flags := words[8]
+
+	config := &params.ChainConfig{}
+	config.SetDefaults()
+	if flags&1 != 0 {
+		config.MagmaCompatibleBlock = big.NewInt(0)
+		if flags&2 != 0 {
+			config.KoreCompatibleBlock = big.NewInt(0)
+		}
+	}
+	if flags&4 != 0 {
+		config.RewardRedirectCompatibleBlock = big.NewInt(0)
+		config.Governance.Reward.RedirectAddress = common.HexToAddress("0x0000000000000000000000000000000000000200")
+	}
+	if flags&8 != 0 {
+		config.Istanbul.ProposerPolicy = uint64(istanbul.WeightedRandom)
+	} else {
+		config.Istanbul.ProposerPolicy = uint64(istanbul.RoundRobin)
+	}
+	config.Governance.Reward.DeferredTxFee = flags&16 != 0
+	config.Governance.Reward.MintingAmount = new(big.Int).SetUint64(words[0])
+	config.Governance.Reward.MinimumStake = new(big.Int).SetUint64(words[3])
+	cn := words[4] % (params.RatioBasisPointsTotal + 1)
+	kff := words[5] % (params.RatioBasisPointsTotal + 1 - cn)
+	config.Governance.Reward.Ratio = fmt.Sprintf("%d/%d/%d", cn, kff, params.RatioBasisPointsTotal-cn-kff)
+	proposer := words[6] % (params.RatioBasisPointsTotal + 1)
+	config.Governance.Reward.Kip82Ratio = fmt.Sprintf("%d/%d", proposer, params.RatioBasisPointsTotal-proposer)
+	burnRatio := words[7] % 101
+	config.Governance.Reward.BurnRatio = &burnRatio
+
+	pset, err := params.NewGovParamSetChainConfig(config)
+	if err != nil {
+		return 0
+	}
+	head := &types.Header{
+		Number:     big.NewInt(1),
+		GasUsed:    words[1],
+		Rewardbase: common.HexToAddress("0x0000000000000000000000000000000000000100"),
+	}
+	if config.IsMagmaForkEnabled(head.Number) {
+		head.BaseFee = new(big.Int).SetUint64(words[2])
+	}
+
+	var stakingInfo *StakingInfo
+	if amounts := words[header:]; len(amounts) > 0 {
+		stakingInfo = &StakingInfo{
+			KCFAddr: common.HexToAddress("0x0000000000000000000000000000000000000301"),
+			KFFAddr: common.HexToAddress("0x0000000000000000000000000000000000000302"),
+		}
+		for i, amount := range amounts {
+			// the peb amounts are exact while the KLAY amounts are capped as done by newStakingInfo
+			if flags&32 != 0 {
+				peb := new(big.Int).Mul(new(big.Int).SetUint64(amount), big.NewInt(params.KLAY))
+				peb = peb.Add(peb, new(big.Int).SetUint64(amount%params.KLAY))
+				stakingInfo.CouncilStakingAmountsPeb = append(stakingInfo.CouncilStakingAmountsPeb, peb)
+			}
+			if amount > maxStakingLimit {
+				amount = maxStakingLimit
+			}
+			stakingInfo.CouncilNodeAddrs = append(stakingInfo.CouncilNodeAddrs, common.BigToAddress(big.NewInt(int64(0x1000+i))))
+			stakingInfo.CouncilStakingAddrs = append(stakingInfo.CouncilStakingAddrs, common.BigToAddress(big.NewInt(int64(0x2000+i))))
+			stakingInfo.CouncilRewardAddrs = append(stakingInfo.CouncilRewardAddrs, common.BigToAddress(big.NewInt(int64(0x3000+i%3))))
+			stakingInfo.CouncilStakingAmounts = append(stakingInfo.CouncilStakingAmounts, amount)
+		}
+	}
+
+	rules := config.Rules(head.Number)
+	spec, err := GetRewardPolicy(head.Number, pset).CalcDeferredReward(head, rules, pset, stakingInfo)
+	if err != nil {
+		return 0
+	}
+	if err := CheckRewardSpec(spec); err != nil {
+		panic(err)
+	}
+	return 1
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// ErrRewardInvariant is wrapped by InvariantError.
+var ErrRewardInvariant = errors.New("reward invariant violated")
+
+// invariantCheck makes the reward spec of each block checked by CheckRewardSpec
+// before the rewards are distributed.
+var invariantCheck int32 // 1 if enabled
+
+// InvariantError describes an invariant of the reward spec which is not satisfied.
+type InvariantError struct {
+	Invariant string   // the violated invariant
+	Expected  *big.Int // the amount expected by the invariant, if any
+	Actual    *big.Int // the amount in the reward spec
+}
+
+func (e *InvariantError) Error() string {
+	if e.Expected == nil {
+		return fmt.Sprintf("%v: %s (actual %v)", ErrRewardInvariant, e.Invariant, e.Actual)
+	}
+	return fmt.Sprintf("%v: %s (expected %v, actual %v)", ErrRewardInvariant, e.Invariant, e.Expected, e.Actual)
+}
+
+func (e *InvariantError) Unwrap() error {
+	return ErrRewardInvariant
+}
+
+// SetInvariantCheck enables or disables checking the reward spec of each block before the
+// rewards are distributed. If enabled, a block whose reward spec violates an invariant is
+// rejected with an InvariantError, e.g. one produced by a custom reward policy.
+func SetInvariantCheck(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&invariantCheck, 1)
+	} else {
+		atomic.StoreInt32(&invariantCheck, 0)
+	}
+}
+
+// IsInvariantCheckEnabled returns true if the reward spec of each block must be checked.
+func IsInvariantCheckEnabled() bool {
+	return atomic.LoadInt32(&invariantCheck) == 1
+}
+
+// CheckRewardSpec returns an InvariantError if the reward spec violates any of the invariants:
+//   - all the amounts are non-negative and the burnt fee does not exceed the total fee,
+//   - minted + carriedIn + totalFee - burntFee - burntRemainder - carriedOver
+//     == proposer + stakers + kff + kcf == sum(rewards), where the remainders are zero if not set,
+//   - the KFF and KCF sub-funds sum up to the KFF and KCF portions if they are split.
+func CheckRewardSpec(spec *RewardSpec) error {
+	if spec == nil {
+		return &InvariantError{Invariant: "the reward spec is nil"}
+	}
+
+	for _, portion := range []struct {
+		name   string
+		amount *big.Int
+	}{
+		{"minted", spec.Minted}, {"totalFee", spec.TotalFee}, {"burntFee", spec.BurntFee},
+		{"proposer", spec.Proposer}, {"stakers", spec.Stakers}, {"kff", spec.KFF}, {"kcf", spec.KCF},
+		{"burntRemainder", zeroIfNil(spec.BurntRemainder)}, {"carriedIn", zeroIfNil(spec.CarriedIn)},
+		{"carriedOver", zeroIfNil(spec.CarriedOver)},
+	} {
+		if err := checkNonNegative(portion.name, portion.amount); err != nil {
+			return err
+		}
+	}
+	if spec.BurntFee.Cmp(spec.TotalFee) > 0 {
+		return &InvariantError{Invariant: "burntFee <= totalFee", Expected: spec.TotalFee, Actual: spec.BurntFee}
+	}
+	for _, recipients := range []struct {
+		name    string
+		amounts map[common.Address]*big.Int
+	}{
+		{"rewards", spec.Rewards}, {"kffFunds", spec.KFFFunds}, {"kcfFunds", spec.KCFFunds}, {"proposerFunds", spec.ProposerFunds},
+	} {
+		for addr, amount := range recipients.amounts {
+			if err := checkNonNegative(fmt.Sprintf("%s[%s]", recipients.name, addr.Hex()), amount); err != nil {
+				return err
+			}
+		}
+	}
+
+	total := new(big.Int).Add(spec.Minted, spec.TotalFee)
+	total.Sub(total, spec.BurntFee)
+	total.Add(total, zeroIfNil(spec.CarriedIn))
+	total.Sub(total, zeroIfNil(spec.BurntRemainder))
+	total.Sub(total, zeroIfNil(spec.CarriedOver))
+
+	portions := new(big.Int).Add(spec.Proposer, spec.Stakers)
+	portions.Add(portions, spec.KFF)
+	portions.Add(portions, spec.KCF)
+	if portions.Cmp(total) != 0 {
+		return &InvariantError{Invariant: "minted + totalFee - burntFee == proposer + stakers + kff + kcf", Expected: total, Actual: portions}
+	}
+	if sum := sumAmounts(spec.Rewards); sum.Cmp(total) != 0 {
+		return &InvariantError{Invariant: "minted + totalFee - burntFee == sum(rewards)", Expected: total, Actual: sum}
+	}
+	if len(spec.KFFFunds) > 0 {
+		if sum := sumAmounts(spec.KFFFunds); sum.Cmp(spec.KFF) != 0 {
+			return &InvariantError{Invariant: "kff == sum(kffFunds)", Expected: spec.KFF, Actual: sum}
+		}
+	}
+	if len(spec.KCFFunds) > 0 {
+		if sum := sumAmounts(spec.KCFFunds); sum.Cmp(spec.KCF) != 0 {
+			return &InvariantError{Invariant: "kcf == sum(kcfFunds)", Expected: spec.KCF, Actual: sum}
+		}
+	}
+	return nil
+}
+
+func checkNonNegative(name string, amount *big.Int) error {
+	if amount == nil {
+		return &InvariantError{Invariant: name + " is set"}
+	}
+	if amount.Sign() < 0 {
+		return &InvariantError{Invariant: name + " >= 0", Actual: amount}
+	}
+	return nil
+}
+
+func sumAmounts(m map[common.Address]*big.Int) *big.Int {
+	sum := big.NewInt(0)
+	for _, amount := range m {
+		sum.Add(sum, amount)
+	}
+	return sum
+}
+
+func zeroIfNil(amount *big.Int) *big.Int {
+	if amount == nil {
+		return common.Big0
+	}
+	return amount
+}
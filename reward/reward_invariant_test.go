@@ -0,0 +1,116 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRewardSpec(t *testing.T) {
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		GasUsed:    1000,
+		BaseFee:    big.NewInt(1),
+		Rewardbase: proposerAddr,
+	}
+	stakingInfo := genStakingInfo(5, map[int]int{1: 0}, map[int]uint64{0: minStaking + 4, 2: minStaking + 7})
+
+	for i, config := range []*params.ChainConfig{
+		getTestConfig(), noKore(getTestConfig()), noMagma(getTestConfig()), noDeferred(getTestConfig()),
+	} {
+		config.RewardSplitCompatibleBlock = big.NewInt(0)
+		config.Governance.Reward.KFFSplit = intToAddress(3001).Hex() + ":2," + intToAddress(3002).Hex() + ":1"
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+		rules := config.Rules(header.Number)
+
+		spec, err := CalcDeferredRewardWithStakingInfo(header, rules, pset, stakingInfo)
+		require.Nil(t, err)
+		assert.Nil(t, CheckRewardSpec(spec), "configs[%d]", i)
+
+		spec, err = CalcDeferredRewardSimple(header, rules, pset)
+		require.Nil(t, err)
+		assert.Nil(t, CheckRewardSpec(spec), "configs[%d]", i)
+	}
+}
+
+func TestCheckRewardSpec_Violations(t *testing.T) {
+	valid := func() *RewardSpec {
+		spec := NewRewardSpec()
+		spec.Minted = big.NewInt(100)
+		spec.TotalFee = big.NewInt(10)
+		spec.BurntFee = big.NewInt(4)
+		spec.Proposer = big.NewInt(56)
+		spec.KFF = big.NewInt(30)
+		spec.KCF = big.NewInt(20)
+		spec.Rewards[proposerAddr] = big.NewInt(56)
+		spec.Rewards[kcfAddr] = big.NewInt(20)
+		spec.Rewards[intToAddress(3001)] = big.NewInt(30)
+		spec.KFFFunds = map[common.Address]*big.Int{intToAddress(3001): big.NewInt(30)}
+		return spec
+	}
+	require.Nil(t, CheckRewardSpec(valid()))
+
+	testcases := []struct {
+		modify    func(spec *RewardSpec)
+		invariant string
+	}{
+		{func(spec *RewardSpec) { spec.Stakers = nil }, "stakers is set"},
+		{func(spec *RewardSpec) { spec.KCF = big.NewInt(-1) }, "kcf >= 0"},
+		{func(spec *RewardSpec) { spec.BurntFee = big.NewInt(11) }, "burntFee <= totalFee"},
+		{
+			func(spec *RewardSpec) { spec.Rewards[kcfAddr] = big.NewInt(-20) },
+			"rewards[" + kcfAddr.Hex() + "] >= 0",
+		},
+		{func(spec *RewardSpec) { spec.Proposer = big.NewInt(57) }, "minted + totalFee - burntFee == proposer + stakers + kff + kcf"},
+		{func(spec *RewardSpec) { spec.Rewards[proposerAddr] = big.NewInt(55) }, "minted + totalFee - burntFee == sum(rewards)"},
+		{func(spec *RewardSpec) { spec.KFFFunds[intToAddress(3001)] = big.NewInt(29) }, "kff == sum(kffFunds)"},
+		{
+			func(spec *RewardSpec) {
+				spec.KCFFunds = map[common.Address]*big.Int{kcfAddr: big.NewInt(21)}
+			},
+			"kcf == sum(kcfFunds)",
+		},
+	}
+	for i, tc := range testcases {
+		spec := valid()
+		tc.modify(spec)
+		err := CheckRewardSpec(spec)
+		assert.ErrorIs(t, err, ErrRewardInvariant, "testcases[%d]", i)
+		if invErr, ok := err.(*InvariantError); assert.True(t, ok, "testcases[%d]", i) {
+			assert.Equal(t, tc.invariant, invErr.Invariant, "testcases[%d]", i)
+		}
+	}
+	assert.ErrorIs(t, CheckRewardSpec(nil), ErrRewardInvariant)
+}
+
+func TestSetInvariantCheck(t *testing.T) {
+	defer SetInvariantCheck(false)
+
+	assert.False(t, IsInvariantCheckEnabled())
+	SetInvariantCheck(true)
+	assert.True(t, IsInvariantCheckEnabled())
+	SetInvariantCheck(false)
+	assert.False(t, IsInvariantCheckEnabled())
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/params"
+)
+
+var (
+	errNilRewardPolicy        = errors.New("reward policy is nil")
+	errRewardPolicyNameExists = errors.New("reward policy of the name is already registered")
+)
+
+// RewardPolicy calculates the deferred rewards of a block, which are distributed at the end of
+// the block processing. The tx fees paid during the tx execution are not part of its calculation
+// if deferredTxFee is false.
+type RewardPolicy interface {
+	// UseStakingInfo returns true if the rewards are distributed by the staking information.
+	// Otherwise, CalcDeferredReward is called with nil staking information.
+	UseStakingInfo() bool
+
+	// CalcDeferredReward returns the deferred rewards of the block.
+	CalcDeferredReward(header *types.Header, rules params.Rules, pset *params.GovParamSet, stakingInfo *StakingInfo) (*RewardSpec, error)
+}
+
+// SimpleRewardPolicy pays all rewards to the proposer. It is the policy of the networks
+// whose proposer policy is not WeightedRandom.
+type SimpleRewardPolicy struct{}
+
+func (SimpleRewardPolicy) UseStakingInfo() bool { return false }
+
+func (SimpleRewardPolicy) CalcDeferredReward(header *types.Header, rules params.Rules, pset *params.GovParamSet, _ *StakingInfo) (*RewardSpec, error) {
+	return CalcDeferredRewardSimple(header, rules, pset)
+}
+
+// StakingRewardPolicy splits the rewards among the proposer, the stakers, KFF and KCF.
+// It is the policy of the networks whose proposer policy is WeightedRandom.
+type StakingRewardPolicy struct{}
+
+func (StakingRewardPolicy) UseStakingInfo() bool { return true }
+
+func (StakingRewardPolicy) CalcDeferredReward(header *types.Header, rules params.Rules, pset *params.GovParamSet, stakingInfo *StakingInfo) (*RewardSpec, error) {
+	return CalcDeferredRewardWithStakingInfo(header, rules, pset, stakingInfo)
+}
+
+// customRewardPolicy is the policy replacing the built-in ones from a block.
+type customRewardPolicy struct {
+	name   string
+	policy RewardPolicy
+	block  *big.Int
+}
+
+var (
+	rewardPoliciesMu sync.RWMutex
+	rewardPolicies   = make(map[string]RewardPolicy)
+	customPolicy     *customRewardPolicy
+)
+
+// RegisterRewardPolicy registers a reward policy with the given name so that it can be
+// selected by SetCustomRewardPolicy. It is meant to be called by the binaries of private
+// networks running alternate tokenomics, before the node is started.
+func RegisterRewardPolicy(name string, policy RewardPolicy) error {
+	if policy == nil {
+		return errNilRewardPolicy
+	}
+	rewardPoliciesMu.Lock()
+	defer rewardPoliciesMu.Unlock()
+
+	if _, ok := rewardPolicies[name]; ok {
+		return fmt.Errorf("%w: %s", errRewardPolicyNameExists, name)
+	}
+	rewardPolicies[name] = policy
+	return nil
+}
+
+// SetCustomRewardPolicy makes the registered reward policy of the given name used from the given
+// block instead of the built-in ones. An empty name restores the built-in policies.
+// All the nodes of the network must be set with the same policy and block.
+func SetCustomRewardPolicy(name string, block *big.Int) error {
+	rewardPoliciesMu.Lock()
+	defer rewardPoliciesMu.Unlock()
+	defer PurgeRewardSpecCache()
+
+	if name == "" {
+		customPolicy = nil
+		return nil
+	}
+	policy, ok := rewardPolicies[name]
+	if !ok {
+		return fmt.Errorf("unknown reward policy: %s", name)
+	}
+	if block == nil {
+		block = big.NewInt(0)
+	}
+	customPolicy = &customRewardPolicy{name: name, policy: policy, block: new(big.Int).Set(block)}
+	logger.Info("Custom reward policy is set", "name", name, "block", block)
+	return nil
+}
+
+// GetRewardPolicy returns the reward policy of the given block number: the custom policy if it
+// is in effect at the block, or the built-in policy selected by the proposer policy otherwise.
+func GetRewardPolicy(num *big.Int, pset *params.GovParamSet) RewardPolicy {
+	rewardPoliciesMu.RLock()
+	custom := customPolicy
+	rewardPoliciesMu.RUnlock()
+
+	if custom != nil && num.Cmp(custom.block) >= 0 {
+		return custom.policy
+	}
+	if IsRewardSimple(pset) {
+		return SimpleRewardPolicy{}
+	}
+	return StakingRewardPolicy{}
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// treasuryRewardPolicy pays the minting amount and the fee to a treasury.
+type treasuryRewardPolicy struct {
+	treasury common.Address
+}
+
+func (p *treasuryRewardPolicy) UseStakingInfo() bool { return false }
+
+func (p *treasuryRewardPolicy) CalcDeferredReward(header *types.Header, rules params.Rules, pset *params.GovParamSet, _ *StakingInfo) (*RewardSpec, error) {
+	spec := NewRewardSpec()
+	spec.Minted = pset.MintingAmountBig()
+	spec.TotalFee = GetTotalTxFee(header, rules, pset)
+	spec.KFF = new(big.Int).Add(spec.Minted, spec.TotalFee)
+	spec.Rewards[p.treasury] = new(big.Int).Set(spec.KFF)
+	return spec, nil
+}
+
+func TestGetRewardPolicy(t *testing.T) {
+	defer SetCustomRewardPolicy("", nil)
+
+	config := getTestConfig()
+	config.Istanbul.ProposerPolicy = uint64(istanbul.RoundRobin)
+	simple, err := params.NewGovParamSetChainConfig(config)
+	require.NoError(t, err)
+	config.Istanbul.ProposerPolicy = uint64(istanbul.WeightedRandom)
+	staking, err := params.NewGovParamSetChainConfig(config)
+	require.NoError(t, err)
+
+	// the built-in policies are selected by the proposer policy
+	assert.Equal(t, SimpleRewardPolicy{}, GetRewardPolicy(big.NewInt(10), simple))
+	assert.Equal(t, StakingRewardPolicy{}, GetRewardPolicy(big.NewInt(10), staking))
+
+	policy := &treasuryRewardPolicy{treasury: intToAddress(3000)}
+	assert.Equal(t, errNilRewardPolicy, RegisterRewardPolicy("nil", nil))
+	require.NoError(t, RegisterRewardPolicy("treasury", policy))
+	assert.True(t, errors.Is(RegisterRewardPolicy("treasury", policy), errRewardPolicyNameExists))
+	assert.Error(t, SetCustomRewardPolicy("unknown", nil))
+
+	// the custom policy replaces the built-in ones from the block
+	require.NoError(t, SetCustomRewardPolicy("treasury", big.NewInt(10)))
+	assert.Equal(t, StakingRewardPolicy{}, GetRewardPolicy(big.NewInt(9), staking))
+	assert.Equal(t, policy, GetRewardPolicy(big.NewInt(10), staking))
+	assert.Equal(t, policy, GetRewardPolicy(big.NewInt(11), simple))
+
+	header := &types.Header{Number: big.NewInt(10), GasUsed: 1000, BaseFee: big.NewInt(1), Rewardbase: proposerAddr}
+	spec, err := GetBlockReward(header, params.Rules{IsMagma: true, IsKore: true}, staking)
+	require.NoError(t, err)
+	assert.Equal(t, map[common.Address]*big.Int{intToAddress(3000): new(big.Int).Add(minted, big.NewInt(1000))}, spec.Rewards)
+
+	// the built-in policies are restored
+	require.NoError(t, SetCustomRewardPolicy("", nil))
+	assert.Equal(t, StakingRewardPolicy{}, GetRewardPolicy(big.NewInt(10), staking))
+}
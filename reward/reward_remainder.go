@@ -0,0 +1,124 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/params"
+)
+
+// remainderDestinations returns where the remainder of the (CN, KFF, KCF) split and
+// the remainder of the staker shares go by the remainder policy.
+func remainderDestinations(policy string) (string, string) {
+	if policy == params.RemainderPolicyLegacy {
+		return params.RemainderPolicyKFF, params.RemainderPolicyProposer
+	}
+	return policy, policy
+}
+
+// maxCarryLookback is the number of the ancestors whose rewards are recalculated at most to find
+// the carried remainder when their states are not available, e.g. on a pruned node.
+const maxCarryLookback = 128
+
+var errCarriedRemainderUnavailable = errors.New("the carried remainder is not available")
+
+// getCarriedRemainder returns the remainder carried in to the block of the given header, which
+// is shared by the stakers of the block. It is nil if nothing is carried in, i.e. neither the
+// block nor its parent keeps the carry remainder policy. When the block leaves the policy, the
+// remainder carried over by the parent is swept in to the stakers of the block.
+func getCarriedRemainder(rc *rewardConfig, header *types.Header, lookback int) (*big.Int, error) {
+	if !rc.rules.IsRewardSplit || header.Number.Sign() == 0 {
+		return nil, nil
+	}
+	if rc.remainderPolicy != params.RemainderPolicyCarry {
+		carried, err := parentCarriesRemainder(header)
+		if err != nil || !carried {
+			return nil, err
+		}
+	}
+	return readCarriedRemainder(header, lookback)
+}
+
+// parentCarriesRemainder returns whether the parent of the given header carried over its remainder.
+func parentCarriesRemainder(header *types.Header) (bool, error) {
+	if stakingManager == nil || stakingManager.blockchain == nil || stakingManager.governanceHelper == nil {
+		return false, nil
+	}
+	num := header.Number.Uint64() - 1
+	if !stakingManager.blockchain.Config().IsRewardSplitForkEnabled(new(big.Int).SetUint64(num)) {
+		return false, nil
+	}
+	pset, err := stakingManager.governanceHelper.EffectiveParams(num)
+	if err != nil {
+		return false, err
+	}
+	v, ok := pset.Get(params.RemainderPolicy)
+	return ok && v.(string) == params.RemainderPolicyCarry, nil
+}
+
+// readCarriedRemainder returns the remainder carried over by the parent of the given header,
+// which is kept by system.RemainderCarryAddr in the state of the parent block. If the state is
+// not available, it is taken from the reward of the parent block, which is recalculated if not
+// cached, up to lookback ancestors.
+func readCarriedRemainder(header *types.Header, lookback int) (*big.Int, error) {
+	if stakingManager == nil || stakingManager.blockchain == nil {
+		return nil, ErrStakingManagerNotSet
+	}
+	bc := stakingManager.blockchain
+	parent := bc.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("the parent of block %d is not found", header.Number.Uint64())
+	}
+	if statedb, err := bc.StateAt(parent.Root); err == nil {
+		return system.ReadCarriedRemainder(statedb), nil
+	}
+
+	if cached, ok := rewardSpecCache.Get(parent.Hash()); ok {
+		return new(big.Int).Set(zeroIfNil(cached.(*rewardSpecCacheEntry).spec.CarriedOver)), nil
+	}
+	if lookback == 0 || stakingManager.governanceHelper == nil {
+		return nil, fmt.Errorf("%w: block %d", errCarriedRemainderUnavailable, header.Number.Uint64())
+	}
+	pset, err := stakingManager.governanceHelper.EffectiveParams(parent.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	if !GetRewardPolicy(parent.Number, pset).UseStakingInfo() {
+		return big.NewInt(0), nil
+	}
+	spec, err := calcDeferredReward(parent, bc.Config().Rules(parent.Number), pset, GetStakingInfo(parent.Number.Uint64()), lookback-1)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(zeroIfNil(spec.CarriedOver)), nil
+}
+
+// SettleCarriedRemainder moves the remainders of the spec set by the carry remainder policy.
+// The remainder carried in is withdrawn from system.RemainderCarryAddr and the one carried
+// over to the next block is kept there.
+func SettleCarriedRemainder(state *state.StateDB, rules params.Rules, spec *RewardSpec) error {
+	if spec.CarriedIn == nil && spec.CarriedOver == nil {
+		return nil
+	}
+	return system.SettleRemainderCarry(state, rules, zeroIfNil(spec.CarriedIn), zeroIfNil(spec.CarriedOver))
+}
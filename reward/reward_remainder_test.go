@@ -0,0 +1,266 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// remainderTestChain serves the state of the parent block keeping the carried remainder.
+// Without the state, the state of any block is missing.
+type remainderTestChain struct {
+	blockChain
+	parent *types.Header
+	state  *state.StateDB
+	config *params.ChainConfig
+}
+
+func (c *remainderTestChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	for h := c.parent; h != nil; h = c.genesis(h) {
+		if hash == h.Hash() && number == h.Number.Uint64() {
+			return h
+		}
+	}
+	return nil
+}
+
+// genesis returns the genesis block as the parent of the first block.
+func (c *remainderTestChain) genesis(header *types.Header) *types.Header {
+	if header.Number.Uint64() != 1 {
+		return nil
+	}
+	return &types.Header{Number: big.NewInt(0), BaseFee: big.NewInt(1)}
+}
+
+func (c *remainderTestChain) StateAt(root common.Hash) (*state.StateDB, error) {
+	if c.state == nil {
+		return nil, errors.New("missing state")
+	}
+	return c.state, nil
+}
+
+func (c *remainderTestChain) Config() *params.ChainConfig {
+	return c.config
+}
+
+func newRemainderTestState(t *testing.T) *state.StateDB {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	require.NoError(t, err)
+	return statedb
+}
+
+func TestRewardDistributor_CalcDeferredReward_RemainderPolicy(t *testing.T) {
+	// The (CN, KFF, KCF) split of the minted amount leaves 1 peb, and
+	// the stakers portion 2.72e17 shared by three equal stakers leaves 2 peb.
+	var (
+		minted = new(big.Int).Add(big.NewInt(1e18), common.Big1)
+		parent = &types.Header{Number: big.NewInt(1)}
+		header = &types.Header{
+			Number:     big.NewInt(2),
+			ParentHash: parent.Hash(),
+			BaseFee:    big.NewInt(1),
+			Rewardbase: proposerAddr,
+		}
+		stakingInfo = genStakingInfo(3, nil, map[int]uint64{0: minStaking + 1, 1: minStaking + 1, 2: minStaking + 1})
+		share       = big.NewInt(90666666666666666)
+	)
+
+	testcases := []struct {
+		policy         string
+		carriedIn      int64
+		proposer       *big.Int
+		stakers        *big.Int
+		kff            *big.Int
+		share          *big.Int
+		burntRemainder *big.Int
+		carriedOver    *big.Int
+	}{
+		{params.RemainderPolicyLegacy, 0, big.NewInt(6.8e16 + 2), big.NewInt(2.72e17 - 2), big.NewInt(5.4e17 + 1), share, nil, nil},
+		{params.RemainderPolicyProposer, 0, big.NewInt(6.8e16 + 3), big.NewInt(2.72e17 - 2), big.NewInt(5.4e17), share, nil, nil},
+		{params.RemainderPolicyKFF, 0, big.NewInt(6.8e16), big.NewInt(2.72e17 - 2), big.NewInt(5.4e17 + 3), share, nil, nil},
+		{params.RemainderPolicyBurn, 0, big.NewInt(6.8e16), big.NewInt(2.72e17 - 2), big.NewInt(5.4e17), share, big.NewInt(3), nil},
+		// The carried 4 peb is shared by the stakers without the share remainder, leaving only the split remainder.
+		{params.RemainderPolicyCarry, 4, big.NewInt(6.8e16), big.NewInt(2.72e17 + 4), big.NewInt(5.4e17), big.NewInt(90666666666666668), nil, big.NewInt(1)},
+	}
+
+	for _, tc := range testcases {
+		statedb := newRemainderTestState(t)
+		require.NoError(t, system.SettleRemainderCarry(statedb, params.Rules{}, big.NewInt(0), big.NewInt(tc.carriedIn)))
+		cache := newStakingInfoCache()
+		cache.add(stakingInfo)
+		SetTestStakingManager(&StakingManager{
+			stakingInfoCache: cache,
+			blockchain:       &remainderTestChain{parent: parent, state: statedb},
+		})
+
+		config := getTestConfig()
+		config.RewardSplitCompatibleBlock = big.NewInt(0)
+		config.Governance.Reward.MintingAmount = minted
+		config.Governance.Reward.RemainderPolicy = tc.policy
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+
+		spec, err := CalcDeferredRewardWithStakingInfo(header, config.Rules(header.Number), pset, stakingInfo)
+		require.Nil(t, err, tc.policy)
+		require.Nil(t, CheckRewardSpec(spec), tc.policy)
+		assert.Equal(t, tc.proposer, spec.Proposer, tc.policy)
+		assert.Equal(t, tc.stakers, spec.Stakers, tc.policy)
+		assert.Equal(t, tc.kff, spec.KFF, tc.policy)
+		assert.Equal(t, tc.burntRemainder, spec.BurntRemainder, tc.policy)
+		assert.Equal(t, tc.carriedOver, spec.CarriedOver, tc.policy)
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, tc.share, spec.Rewards[intToAddress(rewardBaseAddr+i)], tc.policy)
+		}
+		if tc.policy == params.RemainderPolicyCarry {
+			assert.Equal(t, big.NewInt(tc.carriedIn), spec.CarriedIn)
+
+			require.NoError(t, SettleCarriedRemainder(statedb, config.Rules(header.Number), spec))
+			assert.Equal(t, tc.carriedOver, system.ReadCarriedRemainder(statedb))
+			assert.Equal(t, tc.carriedOver, statedb.GetBalance(system.RemainderCarryAddr))
+		} else {
+			assert.Nil(t, spec.CarriedIn, tc.policy)
+		}
+	}
+
+	// The carried remainder cannot be calculated without the state of the parent block.
+	SetTestStakingManager(nil)
+	config := getTestConfig()
+	config.RewardSplitCompatibleBlock = big.NewInt(0)
+	config.Governance.Reward.RemainderPolicy = params.RemainderPolicyCarry
+	pset, err := params.NewGovParamSetChainConfig(config)
+	require.Nil(t, err)
+	_, err = CalcDeferredRewardWithStakingInfo(header, config.Rules(header.Number), pset, stakingInfo)
+	assert.Equal(t, ErrStakingManagerNotSet, err)
+
+	// The remainder policy is ignored before the RewardSplit hardfork.
+	config.RewardSplitCompatibleBlock = nil
+	spec, err := CalcDeferredRewardWithStakingInfo(header, config.Rules(header.Number), pset, stakingInfo)
+	require.Nil(t, err)
+	assert.Nil(t, spec.CarriedIn)
+	assert.Nil(t, spec.CarriedOver)
+}
+
+func TestRewardDistributor_CalcDeferredReward_RemainderPolicyUnshared(t *testing.T) {
+	// Without the staking information, the stakers portion goes to the proposer
+	// regardless of the remainder policy since it is not a rounding remainder.
+	SetTestStakingManager(nil)
+	header := &types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(1), Rewardbase: proposerAddr}
+	for _, policy := range []string{params.RemainderPolicyBurn, params.RemainderPolicyKFF} {
+		config := getTestConfig()
+		config.RewardSplitCompatibleBlock = big.NewInt(0)
+		config.Governance.Reward.RemainderPolicy = policy
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+
+		spec, err := CalcDeferredRewardWithStakingInfo(header, config.Rules(header.Number), pset, nil)
+		require.Nil(t, err, policy)
+		assert.Equal(t, minted.String(), spec.Proposer.String(), policy)
+		assert.Zero(t, spec.Stakers.Sign(), policy)
+	}
+}
+
+func TestRewardDistributor_CalcDeferredReward_CarriedRemainder(t *testing.T) {
+	defer PurgeRewardSpecCache()
+	defer SetTestStakingManager(nil)
+
+	var (
+		genesis = &types.Header{Number: big.NewInt(0), BaseFee: big.NewInt(1)}
+		parent  = &types.Header{Number: big.NewInt(1), ParentHash: genesis.Hash(), BaseFee: big.NewInt(1)}
+		header  = &types.Header{
+			Number:     big.NewInt(2),
+			ParentHash: parent.Hash(),
+			BaseFee:    big.NewInt(1),
+			Rewardbase: proposerAddr,
+		}
+		stakingInfo = genStakingInfo(3, nil, map[int]uint64{0: minStaking + 1, 1: minStaking + 1, 2: minStaking + 1})
+	)
+	newPset := func(config *params.ChainConfig, policy string) *params.GovParamSet {
+		config.Governance.Reward.RemainderPolicy = policy
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+		return pset
+	}
+	setChain := func(config *params.ChainConfig, statedb *state.StateDB, parentPolicy string) {
+		cache := newStakingInfoCache()
+		cache.add(stakingInfo)
+		SetTestStakingManager(&StakingManager{
+			stakingInfoCache: cache,
+			governanceHelper: &testGovernance{newPset(config.Copy(), parentPolicy)},
+			blockchain:       &remainderTestChain{parent: parent, state: statedb, config: config},
+		})
+	}
+
+	config := getTestConfig()
+	config.RewardSplitCompatibleBlock = big.NewInt(0)
+	carry, burn := newPset(config.Copy(), params.RemainderPolicyCarry), newPset(config.Copy(), params.RemainderPolicyBurn)
+
+	// The KLAY sent to the carry address is not carried in.
+	statedb := newRemainderTestState(t)
+	statedb.AddBalance(system.RemainderCarryAddr, big.NewInt(100))
+	setChain(config, statedb, params.RemainderPolicyCarry)
+	spec, err := CalcDeferredRewardWithStakingInfo(header, config.Rules(header.Number), carry, stakingInfo)
+	require.Nil(t, err)
+	assert.Zero(t, spec.CarriedIn.Sign())
+
+	// The remainder carried over is swept in to the stakers when the policy is changed.
+	require.NoError(t, system.SettleRemainderCarry(statedb, config.Rules(header.Number), big.NewInt(0), big.NewInt(4)))
+	spec, err = CalcDeferredRewardWithStakingInfo(header, config.Rules(header.Number), burn, stakingInfo)
+	require.Nil(t, err)
+	require.Nil(t, CheckRewardSpec(spec))
+	assert.Equal(t, big.NewInt(4), spec.CarriedIn)
+	assert.Nil(t, spec.CarriedOver)
+	swept := new(big.Int).Add(spec.Stakers, spec.BurntRemainder)
+	require.NoError(t, SettleCarriedRemainder(statedb, config.Rules(header.Number), spec))
+	assert.Zero(t, system.ReadCarriedRemainder(statedb).Sign())
+
+	// Nothing is swept in if the parent does not carry over the remainder.
+	setChain(config, statedb, params.RemainderPolicyBurn)
+	spec, err = CalcDeferredRewardWithStakingInfo(header, config.Rules(header.Number), burn, stakingInfo)
+	require.Nil(t, err)
+	assert.Nil(t, spec.CarriedIn)
+	notSwept := new(big.Int).Add(spec.Stakers, spec.BurntRemainder)
+	assert.Equal(t, notSwept.Add(notSwept, big.NewInt(4)), swept)
+
+	// Without the state of the parent block, the carried remainder is taken from the reward of the parent block.
+	setChain(config, nil, params.RemainderPolicyCarry)
+	parentSpec, err := CalcDeferredRewardWithStakingInfo(parent, config.Rules(parent.Number), carry, stakingInfo)
+	require.Nil(t, err)
+	require.NotNil(t, parentSpec.CarriedOver)
+	spec, err = CalcDeferredRewardWithStakingInfo(header, config.Rules(header.Number), carry, stakingInfo)
+	require.Nil(t, err)
+	assert.Equal(t, parentSpec.CarriedOver, spec.CarriedIn)
+
+	rewardSpecCache.Add(parent.Hash(), &rewardSpecCacheEntry{spec: &RewardSpec{CarriedOver: big.NewInt(7)}})
+	spec, err = CalcDeferredRewardWithStakingInfo(header, config.Rules(header.Number), carry, stakingInfo)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(7), spec.CarriedIn)
+
+	PurgeRewardSpecCache()
+	_, err = calcDeferredReward(header, config.Rules(header.Number), carry, stakingInfo, 0)
+	assert.ErrorIs(t, err, errCarriedRemainderUnavailable)
+}
@@ -0,0 +1,197 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// The units the reward amounts are reported in.
+const (
+	ReportUnitPeb  = "peb"
+	ReportUnitSton = "ston"
+	ReportUnitKLAY = "klay"
+)
+
+// reportUnitExps are the decimal exponents of the report units in peb.
+var reportUnitExps = map[string]int{
+	ReportUnitPeb:  0,
+	ReportUnitSton: 9,
+	ReportUnitKLAY: 18,
+}
+
+// RoundingMode is how the reward amounts are rounded to the decimals of a report.
+type RoundingMode string
+
+const (
+	RoundDown     RoundingMode = "down"      // toward zero, never reporting more than paid
+	RoundUp       RoundingMode = "up"        // away from zero
+	RoundHalfUp   RoundingMode = "half-up"   // to the nearest, ties away from zero
+	RoundHalfEven RoundingMode = "half-even" // to the nearest, ties to the even digit
+)
+
+// DefaultRewardReportFormat reports the amounts in peb as they are.
+var DefaultRewardReportFormat = &RewardReportFormat{Unit: ReportUnitPeb, Decimals: 0, Rounding: RoundDown}
+
+// RewardReportFormat is the convention re-expressing the reward amounts for reporting, e.g.
+// in KLAY with 6 decimals rounded half-even. It does not affect the rewards paid.
+type RewardReportFormat struct {
+	Unit     string       `json:"unit"`
+	Decimals int          `json:"decimals"`
+	Rounding RoundingMode `json:"rounding"`
+}
+
+// ParseRewardReportFormat parses the format of "unit[:decimals[:rounding]]", e.g. "klay:6:half-even".
+// The decimals default to the exponent of the unit, which expresses the amounts exactly,
+// and the rounding defaults to down.
+func ParseRewardReportFormat(s string) (*RewardReportFormat, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(s)), ":")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid reward report format %q, want unit[:decimals[:rounding]]", s)
+	}
+	exp, ok := reportUnitExps[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown reward report unit %q, want one of peb, ston and klay", parts[0])
+	}
+	format := &RewardReportFormat{Unit: parts[0], Decimals: exp, Rounding: RoundDown}
+	if len(parts) > 1 {
+		decimals, err := strconv.Atoi(parts[1])
+		if err != nil || decimals < 0 || decimals > exp {
+			return nil, fmt.Errorf("invalid decimals %q of the reward report unit %s, want 0 to %d", parts[1], parts[0], exp)
+		}
+		format.Decimals = decimals
+	}
+	if len(parts) > 2 {
+		switch mode := RoundingMode(parts[2]); mode {
+		case RoundDown, RoundUp, RoundHalfUp, RoundHalfEven:
+			format.Rounding = mode
+		default:
+			return nil, fmt.Errorf("unknown rounding mode %q, want one of down, up, half-up and half-even", parts[2])
+		}
+	}
+	return format, nil
+}
+
+func (f *RewardReportFormat) String() string {
+	return fmt.Sprintf("%s:%d:%s", f.Unit, f.Decimals, f.Rounding)
+}
+
+// round returns the amount in peb rounded to the decimals of the unit, as the number of
+// the smallest reported fraction, e.g. 10^-6 KLAY.
+func (f *RewardReportFormat) round(amount *big.Int) *big.Int {
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(reportUnitExps[f.Unit]-f.Decimals)), nil)
+	q, r := new(big.Int).QuoRem(new(big.Int).Abs(amount), divisor, new(big.Int))
+	if r.Sign() != 0 {
+		half := new(big.Int).Lsh(r, 1).Cmp(divisor)
+		switch f.Rounding {
+		case RoundUp:
+			q.Add(q, common.Big1)
+		case RoundHalfUp:
+			if half >= 0 {
+				q.Add(q, common.Big1)
+			}
+		case RoundHalfEven:
+			if half > 0 || (half == 0 && q.Bit(0) == 1) {
+				q.Add(q, common.Big1)
+			}
+		}
+	}
+	if amount.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q
+}
+
+// format returns the decimal representation of the rounded amount.
+func (f *RewardReportFormat) format(rounded *big.Int) string {
+	digits := new(big.Int).Abs(rounded).String()
+	if f.Decimals > 0 {
+		if len(digits) <= f.Decimals {
+			digits = strings.Repeat("0", f.Decimals-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-f.Decimals] + "." + digits[len(digits)-f.Decimals:]
+	}
+	if rounded.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// FormatAmount re-expresses the amount in peb by the format. A nil amount is formatted as zero.
+func (f *RewardReportFormat) FormatAmount(amount *big.Int) string {
+	if amount == nil {
+		amount = common.Big0
+	}
+	return f.format(f.round(amount))
+}
+
+// RewardReport is a reward spec re-expressed by a report format. The amounts are rounded
+// one by one, so the residual is reported to reconcile the rounded rewards with the
+// rounded total distributed (minted + totalFee - burntFee).
+type RewardReport struct {
+	Format   *RewardReportFormat       `json:"format"`
+	Minted   string                    `json:"minted"`
+	TotalFee string                    `json:"totalFee"`
+	BurntFee string                    `json:"burntFee"`
+	Proposer string                    `json:"proposer"`
+	Stakers  string                    `json:"stakers"`
+	KFF      string                    `json:"kff"`
+	KCF      string                    `json:"kcf"`
+	Rewards  map[common.Address]string `json:"rewards"`
+	Residual string                    `json:"residual"` // the rounded total distributed minus the sum of the rounded rewards
+
+	Labels map[common.Address]AddressLabel `json:"labels,omitempty"`
+}
+
+// NewRewardReport re-expresses the amounts of the spec by the format.
+func NewRewardReport(spec *RewardSpec, format *RewardReportFormat) *RewardReport {
+	report := &RewardReport{
+		Format:   format,
+		Minted:   format.FormatAmount(spec.Minted),
+		TotalFee: format.FormatAmount(spec.TotalFee),
+		BurntFee: format.FormatAmount(spec.BurntFee),
+		Proposer: format.FormatAmount(spec.Proposer),
+		Stakers:  format.FormatAmount(spec.Stakers),
+		KFF:      format.FormatAmount(spec.KFF),
+		KCF:      format.FormatAmount(spec.KCF),
+		Rewards:  make(map[common.Address]string, len(spec.Rewards)),
+		Labels:   spec.Labels,
+	}
+
+	distributed := new(big.Int)
+	for _, amount := range []*big.Int{spec.Minted, spec.TotalFee} {
+		if amount != nil {
+			distributed.Add(distributed, amount)
+		}
+	}
+	if spec.BurntFee != nil {
+		distributed.Sub(distributed, spec.BurntFee)
+	}
+	residual := format.round(distributed)
+	for addr, amount := range spec.Rewards {
+		rounded := format.round(amount)
+		report.Rewards[addr] = format.format(rounded)
+		residual.Sub(residual, rounded)
+	}
+	report.Residual = format.format(residual)
+	return report
+}
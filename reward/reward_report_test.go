@@ -0,0 +1,121 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRewardReportFormat(t *testing.T) {
+	testcases := []struct {
+		s      string
+		format *RewardReportFormat
+	}{
+		{"peb", &RewardReportFormat{ReportUnitPeb, 0, RoundDown}},
+		{"ston", &RewardReportFormat{ReportUnitSton, 9, RoundDown}},
+		{"KLAY:6", &RewardReportFormat{ReportUnitKLAY, 6, RoundDown}},
+		{"klay:2:half-even", &RewardReportFormat{ReportUnitKLAY, 2, RoundHalfEven}},
+		{"ston:0:up", &RewardReportFormat{ReportUnitSton, 0, RoundUp}},
+		{"wei", nil},
+		{"peb:1", nil},
+		{"klay:-1", nil},
+		{"klay:19", nil},
+		{"klay:6:ceil", nil},
+		{"klay:6:down:extra", nil},
+	}
+	for _, tc := range testcases {
+		format, err := ParseRewardReportFormat(tc.s)
+		if tc.format == nil {
+			assert.Error(t, err, tc.s)
+			continue
+		}
+		require.NoError(t, err, tc.s)
+		assert.Equal(t, tc.format, format, tc.s)
+	}
+}
+
+func TestRewardReportFormat_FormatAmount(t *testing.T) {
+	testcases := []struct {
+		format string
+		amount int64
+		want   string
+	}{
+		{"peb", 1234, "1234"},
+		{"ston", 1500000000, "1.500000000"},
+		{"ston:0:down", 1500000000, "1"},
+		{"ston:0:up", 1000000001, "2"},
+		{"ston:0:half-up", 1500000000, "2"},
+		{"ston:0:half-up", 1499999999, "1"},
+		{"ston:0:half-even", 1500000000, "2"},
+		{"ston:0:half-even", 2500000000, "2"},
+		{"ston:0:half-even", 2500000001, "3"},
+		{"ston:3:half-up", 5e5, "0.001"},
+		{"ston:3:down", 5e5, "0.000"},
+		{"ston:0:half-up", -1500000000, "-2"},
+		{"klay:6", 0, "0.000000"},
+	}
+	for _, tc := range testcases {
+		format, err := ParseRewardReportFormat(tc.format)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, format.FormatAmount(big.NewInt(tc.amount)), "%s %d", tc.format, tc.amount)
+	}
+}
+
+func TestNewRewardReport(t *testing.T) {
+	var (
+		proposer = common.HexToAddress("0x1")
+		staker1  = common.HexToAddress("0x2")
+		staker2  = common.HexToAddress("0x3")
+	)
+	// 0.64 KLAY and two of 0.18 KLAY rewards, each off by 0.4 ston from whole ston
+	spec := &RewardSpec{
+		Minted:   big.NewInt(1e18),
+		TotalFee: big.NewInt(2e9),
+		BurntFee: big.NewInt(1e9 + 800000000),
+		Proposer: big.NewInt(640000000400000000),
+		Stakers:  big.NewInt(359999999800000000),
+		KFF:      big.NewInt(0),
+		KCF:      big.NewInt(0),
+		Rewards: map[common.Address]*big.Int{
+			proposer: big.NewInt(640000000400000000),
+			staker1:  big.NewInt(179999999900000000),
+			staker2:  big.NewInt(179999999900000000),
+		},
+	}
+	format, err := ParseRewardReportFormat("ston:0:half-even")
+	require.NoError(t, err)
+	report := NewRewardReport(spec, format)
+
+	assert.Equal(t, "1000000000", report.Minted)
+	assert.Equal(t, "2", report.BurntFee)
+	assert.Equal(t, "640000000", report.Rewards[proposer])
+	assert.Equal(t, "180000000", report.Rewards[staker1])
+	assert.Equal(t, "180000000", report.Rewards[staker2])
+	// 1000000000.2 ston distributed is rounded to 1000000000, while the rewards sum up to 1000000000
+	assert.Equal(t, "0", report.Residual)
+
+	format, err = ParseRewardReportFormat("ston:0:down")
+	require.NoError(t, err)
+	report = NewRewardReport(spec, format)
+	assert.Equal(t, "179999999", report.Rewards[staker1])
+	assert.Equal(t, "2", report.Residual)
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+)
+
+// RewardSlot is the proposer slot of a block produced after a round change. The proposer reward
+// goes to the actual sealer, while the proposer of round 0 missed the slot. It lets the uptime and
+// penalty systems attribute the missed slots from the block rewards.
+type RewardSlot struct {
+	Round          uint8          `json:"round"`          // the round at which the block was committed
+	OriginProposer common.Address `json:"originProposer"` // the proposer of round 0 which missed the slot
+	Proposer       common.Address `json:"proposer"`       // the proposer which sealed the block
+}
+
+// GetRewardSlot returns the proposer slot of the block of the given header if it was produced
+// after a round change, or nil otherwise. The consensus engine is only consulted in the former case.
+func GetRewardSlot(chain blockchain.ChainContext, header *types.Header) (*RewardSlot, error) {
+	if len(header.Extra) < types.IstanbulExtraVanity || header.Round() == 0 {
+		return nil, nil
+	}
+	engine := chain.Engine()
+	if engine == nil {
+		return nil, nil
+	}
+	cInfo, err := engine.GetConsensusInfo(types.NewBlockWithHeader(header))
+	if err != nil {
+		return nil, err
+	}
+	return &RewardSlot{
+		Round:          cInfo.Round,
+		OriginProposer: cInfo.OriginProposer,
+		Proposer:       cInfo.Proposer,
+	}, nil
+}
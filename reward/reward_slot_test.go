@@ -0,0 +1,87 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/consensus/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// testChainContext is a blockchain.ChainContext with the given consensus engine.
+type testChainContext struct {
+	engine consensus.Engine
+}
+
+func (c *testChainContext) Engine() consensus.Engine                    { return c.engine }
+func (c *testChainContext) GetHeader(common.Hash, uint64) *types.Header { return nil }
+
+func TestGetRewardSlot(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	engine := mocks.NewMockEngine(mockCtrl)
+	chain := &testChainContext{engine: engine}
+
+	var (
+		origin   = common.HexToAddress("0x0000000000000000000000000000000000000aaa")
+		proposer = common.HexToAddress("0x0000000000000000000000000000000000000bbb")
+	)
+	newHeader := func(round byte) *types.Header {
+		extra := make([]byte, types.IstanbulExtraVanity)
+		extra[types.IstanbulExtraVanity-1] = round
+		return &types.Header{Number: big.NewInt(10), Extra: extra}
+	}
+
+	// the block produced at round 0 has no slot to report, without consulting the engine
+	slot, err := GetRewardSlot(chain, newHeader(0))
+	assert.NoError(t, err)
+	assert.Nil(t, slot)
+
+	// neither does the header without the istanbul extra, e.g. of the genesis block
+	slot, err = GetRewardSlot(chain, &types.Header{Number: big.NewInt(0)})
+	assert.NoError(t, err)
+	assert.Nil(t, slot)
+
+	// the block produced after a round change reports the proposer which missed the slot
+	engine.EXPECT().GetConsensusInfo(gomock.Any()).Return(consensus.ConsensusInfo{
+		Proposer:       proposer,
+		OriginProposer: origin,
+		Round:          2,
+	}, nil)
+	slot, err = GetRewardSlot(chain, newHeader(2))
+	assert.NoError(t, err)
+	assert.Equal(t, &RewardSlot{Round: 2, OriginProposer: origin, Proposer: proposer}, slot)
+
+	errNoSnapshot := errors.New("no snapshot")
+	engine.EXPECT().GetConsensusInfo(gomock.Any()).Return(consensus.ConsensusInfo{}, errNoSnapshot)
+	_, err = GetRewardSlot(chain, newHeader(1))
+	assert.Equal(t, errNoSnapshot, err)
+
+	// the slot is kept by copying the spec, e.g. from the cache
+	spec := NewRewardSpec()
+	spec.Slot = &RewardSlot{Round: 1, OriginProposer: origin, Proposer: proposer}
+	cpy := spec.copy()
+	assert.Equal(t, spec.Slot, cpy.Slot)
+	assert.NotSame(t, spec.Slot, cpy.Slot)
+}
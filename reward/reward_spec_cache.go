@@ -0,0 +1,131 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"fmt"
+	"math/big"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+)
+
+const (
+	maxRewardSpecCache = 128
+)
+
+// rewardSpecCache keeps the deferred rewards of recent blocks by block hash, so that
+// the block processing and the RPC calls on the same blocks do not repeat the calculation.
+var rewardSpecCache, _ = lru.New(maxRewardSpecCache)
+
+type rewardSpecCacheEntry struct {
+	params string // the governance parameters with which the spec is calculated
+	spec   *RewardSpec
+}
+
+// CalcDeferredRewardCached returns policy.CalcDeferredReward of the given header, reusing the
+// result of the same block calculated with the same governance parameters. Headers without
+// the state root, i.e. the ones being mined, are not cached because their hashes are not final.
+// The staking information must be the one managed by the staking manager.
+func CalcDeferredRewardCached(policy RewardPolicy, header *types.Header, rules params.Rules, pset *params.GovParamSet, stakingInfo *StakingInfo) (*RewardSpec, error) {
+	if common.EmptyHash(header.Root) || (policy.UseStakingInfo() && stakingInfo == nil) {
+		return policy.CalcDeferredReward(header, rules, pset, stakingInfo)
+	}
+
+	hash := header.Hash()
+	paramsStr := fmt.Sprint(pset.StrMap())
+	if cached, ok := rewardSpecCache.Get(hash); ok {
+		if entry := cached.(*rewardSpecCacheEntry); entry.params == paramsStr {
+			rewardSpecCacheHitMeter.Mark(1)
+			return entry.spec.copy(), nil
+		}
+	}
+	rewardSpecCacheMissMeter.Mark(1)
+
+	spec, err := policy.CalcDeferredReward(header, rules, pset, stakingInfo)
+	if err != nil {
+		return nil, err
+	}
+	rewardSpecCache.Add(hash, &rewardSpecCacheEntry{params: paramsStr, spec: spec.copy()})
+	return spec, nil
+}
+
+// PurgeRewardSpecCache removes all the cached reward specs.
+func PurgeRewardSpecCache() {
+	rewardSpecCache.Purge()
+}
+
+// copy returns a deep copy of the spec so that the cached one is not modified by the callers.
+func (spec *RewardSpec) copy() *RewardSpec {
+	cpy := &RewardSpec{
+		Minted:   new(big.Int).Set(spec.Minted),
+		TotalFee: new(big.Int).Set(spec.TotalFee),
+		BurntFee: new(big.Int).Set(spec.BurntFee),
+		Proposer: new(big.Int).Set(spec.Proposer),
+		Stakers:  new(big.Int).Set(spec.Stakers),
+		KFF:      new(big.Int).Set(spec.KFF),
+		KCF:      new(big.Int).Set(spec.KCF),
+		Rewards:  copyRewardsMap(spec.Rewards),
+		KFFFunds: copyRewardsMap(spec.KFFFunds),
+		KCFFunds: copyRewardsMap(spec.KCFFunds),
+
+		ProposerFunds: copyRewardsMap(spec.ProposerFunds),
+	}
+	if spec.Breakdown != nil {
+		cpy.Breakdown = make(map[common.Address]*RewardBreakdown, len(spec.Breakdown))
+		for addr, breakdown := range spec.Breakdown {
+			b := &RewardBreakdown{}
+			b.add(breakdown)
+			cpy.Breakdown[addr] = b
+		}
+	}
+	if spec.RedirectedTo != nil {
+		redirectedTo := *spec.RedirectedTo
+		cpy.RedirectedTo = &redirectedTo
+	}
+	if spec.Slot != nil {
+		slot := *spec.Slot
+		cpy.Slot = &slot
+	}
+	for _, amount := range []struct{ dst, src **big.Int }{
+		{&cpy.BurntRemainder, &spec.BurntRemainder}, {&cpy.CarriedIn, &spec.CarriedIn}, {&cpy.CarriedOver, &spec.CarriedOver},
+	} {
+		if *amount.src != nil {
+			*amount.dst = new(big.Int).Set(*amount.src)
+		}
+	}
+	if spec.Labels != nil {
+		cpy.Labels = make(map[common.Address]AddressLabel, len(spec.Labels))
+		for addr, label := range spec.Labels {
+			cpy.Labels[addr] = label
+		}
+	}
+	return cpy
+}
+
+func copyRewardsMap(m map[common.Address]*big.Int) map[common.Address]*big.Int {
+	if m == nil {
+		return nil
+	}
+	cpy := make(map[common.Address]*big.Int, len(m))
+	for addr, amount := range m {
+		cpy[addr] = new(big.Int).Set(amount)
+	}
+	return cpy
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingRewardPolicy counts the calculations of the wrapped policy.
+type countingRewardPolicy struct {
+	RewardPolicy
+	count int
+}
+
+func (p *countingRewardPolicy) CalcDeferredReward(header *types.Header, rules params.Rules, pset *params.GovParamSet, stakingInfo *StakingInfo) (*RewardSpec, error) {
+	p.count++
+	return p.RewardPolicy.CalcDeferredReward(header, rules, pset, stakingInfo)
+}
+
+func TestCalcDeferredRewardCached(t *testing.T) {
+	PurgeRewardSpecCache()
+	defer PurgeRewardSpecCache()
+
+	var (
+		header = &types.Header{
+			Number:     big.NewInt(1),
+			GasUsed:    1000,
+			BaseFee:    big.NewInt(1),
+			Rewardbase: proposerAddr,
+			Root:       common.HexToHash("0x1"),
+		}
+		config = getTestConfig()
+		rules  = config.Rules(header.Number)
+	)
+	pset, err := params.NewGovParamSetChainConfig(config)
+	require.Nil(t, err)
+
+	// the spec of the same block is calculated once
+	policy := &countingRewardPolicy{RewardPolicy: SimpleRewardPolicy{}}
+	spec, err := CalcDeferredRewardCached(policy, header, rules, pset, nil)
+	require.Nil(t, err)
+	expected, err := SimpleRewardPolicy{}.CalcDeferredReward(header, rules, pset, nil)
+	require.Nil(t, err)
+	assert.Equal(t, expected, spec)
+	assert.Equal(t, 1, policy.count)
+
+	// the cached spec is not affected by the modification of the returned one
+	spec.Proposer.SetUint64(0)
+	spec.Rewards[proposerAddr].SetUint64(0)
+	spec, err = CalcDeferredRewardCached(policy, header, rules, pset, nil)
+	require.Nil(t, err)
+	assert.Equal(t, expected, spec)
+	assert.Equal(t, 1, policy.count)
+
+	// different parameters are not served from the cache
+	otherPset, err := params.NewGovParamSetChainConfig(noDeferred(getTestConfig()))
+	require.Nil(t, err)
+	_, err = CalcDeferredRewardCached(policy, header, rules, otherPset, nil)
+	require.Nil(t, err)
+	assert.Equal(t, 2, policy.count)
+
+	// different blocks are not served from the cache
+	other := types.CopyHeader(header)
+	other.GasUsed = 2000
+	_, err = CalcDeferredRewardCached(policy, other, rules, pset, nil)
+	require.Nil(t, err)
+	assert.Equal(t, 3, policy.count)
+
+	// the blocks being mined are not cached
+	mining := types.CopyHeader(header)
+	mining.Root = common.Hash{}
+	for i := 0; i < 2; i++ {
+		_, err = CalcDeferredRewardCached(policy, mining, rules, pset, nil)
+		require.Nil(t, err)
+	}
+	assert.Equal(t, 5, policy.count)
+
+	// the spec without the staking information is not cached
+	stakingPolicy := &countingRewardPolicy{RewardPolicy: StakingRewardPolicy{}}
+	for i := 0; i < 2; i++ {
+		_, err = CalcDeferredRewardCached(stakingPolicy, other, rules, pset, nil)
+		require.Nil(t, err)
+	}
+	assert.Equal(t, 2, stakingPolicy.count)
+
+	// the cache is purged when the reward policy changes
+	require.Nil(t, SetCustomRewardPolicy("", nil))
+	_, err = CalcDeferredRewardCached(policy, header, rules, pset, nil)
+	require.Nil(t, err)
+	assert.Equal(t, 6, policy.count)
+}
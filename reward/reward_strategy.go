@@ -0,0 +1,141 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/params"
+)
+
+// rewardStrategy is the hardfork-dependent part of the deferred reward calculation:
+// how the deferred tx fee is burnt, and how the minted amount and the rest of the fee
+// are split into the proposer, stakers, KFF and KCF portions.
+type rewardStrategy interface {
+	// burnFee returns the part of the deferred tx fee burnt.
+	burnFee(rc *rewardConfig, fee *big.Int) *big.Int
+
+	// split splits (minted + fee) into (proposer, stakers, kff, kcf, remaining).
+	// The sum of the output must be equal to (minted + fee).
+	split(rc *rewardConfig, minted, fee *big.Int) (*big.Int, *big.Int, *big.Int, *big.Int, *big.Int)
+
+	// feeToProposer returns true if the fee is added to the proposer portion as a whole
+	// instead of being split by the ratio.
+	feeToProposer() bool
+}
+
+// rewardStrategyEntry is a reward strategy registered for a hardfork.
+type rewardStrategyEntry struct {
+	version  string
+	active   func(rules params.Rules) bool
+	strategy rewardStrategy
+}
+
+// rewardStrategies are the reward strategies of the hardforks, the latest first.
+// A new hardfork changing the reward calculation registers its strategy at the top
+// instead of adding conditions to the existing ones.
+var rewardStrategies = []rewardStrategyEntry{
+	{"kore", func(rules params.Rules) bool { return rules.IsKore }, koreStrategy{}},
+	{"magma", func(rules params.Rules) bool { return rules.IsMagma }, magmaStrategy{}},
+	{"genesis", func(params.Rules) bool { return true }, genesisStrategy{}},
+}
+
+// getRewardStrategy returns the reward strategy of the latest hardfork enabled by the rules,
+// which are those of the block number of the header being rewarded.
+func getRewardStrategy(rules params.Rules) (string, rewardStrategy) {
+	for _, entry := range rewardStrategies {
+		if entry.active(rules) {
+			return entry.version, entry.strategy
+		}
+	}
+	panic("no reward strategy") // unreachable since the genesis strategy is always active
+}
+
+// genesisStrategy does not burn the fee, and splits (minted + fee) by the ratio.
+type genesisStrategy struct{}
+
+func (genesisStrategy) burnFee(*rewardConfig, *big.Int) *big.Int { return big.NewInt(0) }
+
+func (genesisStrategy) split(rc *rewardConfig, minted, fee *big.Int) (*big.Int, *big.Int, *big.Int, *big.Int, *big.Int) {
+	totalResource := new(big.Int).Add(minted, fee)
+	cn, kff, kcf := splitByRatio(rc, totalResource)
+
+	remaining := new(big.Int).Set(totalResource)
+	remaining = remaining.Sub(remaining, kff)
+	remaining = remaining.Sub(remaining, kcf)
+	remaining = remaining.Sub(remaining, cn)
+
+	logger.Debug("calcSplit() before kore",
+		"[in] minted", minted.Uint64(),
+		"[in] fee", fee.Uint64(),
+		"[out] cn", cn.Uint64(),
+		"[out] kff", kff.Uint64(),
+		"[out] kcf", kcf.Uint64(),
+		"[out] remaining", remaining.Uint64(),
+	)
+	return cn, big.NewInt(0), kff, kcf, remaining
+}
+
+func (genesisStrategy) feeToProposer() bool { return false }
+
+// magmaStrategy burns the fee by the burn ratio, and splits the rest as before.
+type magmaStrategy struct {
+	genesisStrategy
+}
+
+func (magmaStrategy) burnFee(rc *rewardConfig, fee *big.Int) *big.Int {
+	return getBurnAmountMagma(fee, rc.burnRatio)
+}
+
+// koreStrategy additionally burns the fee up to the proposer portion of the minted amount (KIP-82),
+// and splits the minted amount by the ratio and the KIP-82 ratio. The fee goes to the proposer.
+type koreStrategy struct {
+	magmaStrategy
+}
+
+func (s koreStrategy) burnFee(rc *rewardConfig, fee *big.Int) *big.Int {
+	burnt := s.magmaStrategy.burnFee(rc, fee)
+	rest := new(big.Int).Sub(fee, burnt)
+	return burnt.Add(burnt, getBurnAmountKore(rc, rest))
+}
+
+func (koreStrategy) split(rc *rewardConfig, minted, fee *big.Int) (*big.Int, *big.Int, *big.Int, *big.Int, *big.Int) {
+	totalResource := new(big.Int).Add(minted, fee)
+	cn, kff, kcf := splitByRatio(rc, minted)
+	proposer, stakers := splitByKip82Ratio(rc, cn)
+
+	proposer = proposer.Add(proposer, fee)
+
+	remaining := new(big.Int).Set(totalResource)
+	remaining = remaining.Sub(remaining, kff)
+	remaining = remaining.Sub(remaining, kcf)
+	remaining = remaining.Sub(remaining, proposer)
+	remaining = remaining.Sub(remaining, stakers)
+
+	logger.Debug("calcSplit() after kore",
+		"[in] minted", minted.Uint64(),
+		"[in] fee", fee.Uint64(),
+		"[out] proposer", proposer.Uint64(),
+		"[out] stakers", stakers.Uint64(),
+		"[out] kff", kff.Uint64(),
+		"[out] kcf", kcf.Uint64(),
+		"[out] remaining", remaining.Uint64(),
+	)
+	return proposer, stakers, kff, kcf, remaining
+}
+
+func (koreStrategy) feeToProposer() bool { return true }
@@ -0,0 +1,80 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRewardStrategy(t *testing.T) {
+	config := getTestConfig()
+	config.MagmaCompatibleBlock = big.NewInt(10)
+	config.KoreCompatibleBlock = big.NewInt(20)
+
+	testcases := []struct {
+		num     int64
+		version string
+	}{
+		{0, "genesis"},
+		{9, "genesis"},
+		{10, "magma"},
+		{19, "magma"},
+		{20, "kore"},
+		{100, "kore"},
+	}
+	for _, tc := range testcases {
+		version, strategy := getRewardStrategy(config.Rules(big.NewInt(tc.num)))
+		assert.Equal(t, tc.version, version, "block %d", tc.num)
+		assert.NotNil(t, strategy)
+	}
+
+	// the versions are unique and the last one is always active
+	versions := make(map[string]bool)
+	for _, entry := range rewardStrategies {
+		assert.False(t, versions[entry.version], entry.version)
+		versions[entry.version] = true
+	}
+	assert.True(t, rewardStrategies[len(rewardStrategies)-1].active(params.Rules{}))
+}
+
+func TestRewardStrategy_BurnFee(t *testing.T) {
+	rc := &rewardConfig{
+		mintingAmount:   big.NewInt(1000),
+		burnRatio:       50,
+		cnRatio:         big.NewInt(50),
+		kffRatio:        big.NewInt(40),
+		kcfRatio:        big.NewInt(10),
+		totalRatio:      big.NewInt(100),
+		cnProposerRatio: big.NewInt(20),
+		cnStakingRatio:  big.NewInt(80),
+		cnTotalRatio:    big.NewInt(100),
+	}
+	fee := big.NewInt(1000)
+
+	// the proposer portion of the minted amount is 1000 * 50% * 20% = 100
+	assert.Equal(t, big.NewInt(0), genesisStrategy{}.burnFee(rc, fee))
+	assert.Equal(t, big.NewInt(500), magmaStrategy{}.burnFee(rc, fee))
+	assert.Equal(t, big.NewInt(600), koreStrategy{}.burnFee(rc, fee))
+	assert.Equal(t, big.NewInt(1000), fee)
+
+	// the fee less than the proposer portion after the magma burn is burnt as a whole
+	assert.Equal(t, big.NewInt(150), koreStrategy{}.burnFee(rc, big.NewInt(150)))
+}
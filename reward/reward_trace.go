@@ -0,0 +1,265 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+)
+
+// The reward policies reported by a reward trace.
+const (
+	TracePolicySimple  = "simple"
+	TracePolicyStaking = "staking"
+	TracePolicyCustom  = "custom" // registered by RegisterRewardPolicy, whose steps are not traced
+)
+
+// RewardTraceParams are the governance parameters the rewards of a block are calculated with.
+type RewardTraceParams struct {
+	MintingAmount     *big.Int `json:"mintingAmount"`
+	MinimumStake      *big.Int `json:"minimumStake"` // in KLAY
+	MinStakeInclusive bool     `json:"minStakeInclusive"`
+	DeferredTxFee     bool     `json:"deferredTxFee"`
+	BurnRatio         uint64   `json:"burnRatio"`
+	Ratio             string   `json:"ratio"`
+	Kip82Ratio        string   `json:"kip82Ratio,omitempty"`
+	RemainderPolicy   string   `json:"remainderPolicy,omitempty"`
+}
+
+// FeeTrace is how the tx fee of a block is burnt. If the fee is not deferred, it is paid to
+// the proposer during the tx execution instead of being split with the minted amount.
+type FeeTrace struct {
+	TotalFee  *big.Int `json:"totalFee"`
+	MagmaBurn *big.Int `json:"magmaBurn"` // burnt by the burn ratio after Magma
+	KoreBurn  *big.Int `json:"koreBurn"`  // burnt up to the proposer portion of the minted amount after Kore
+	BurntFee  *big.Int `json:"burntFee"`
+	RewardFee *big.Int `json:"rewardFee"` // the fee left to be paid
+}
+
+// RatioSplitTrace is the split of a source amount by the ratio of CN/KFF/KCF.
+type RatioSplitTrace struct {
+	Source *big.Int `json:"source"` // minted + deferred reward fee before Kore, minted after Kore
+	CN     *big.Int `json:"cn"`
+	KFF    *big.Int `json:"kff"`
+	KCF    *big.Int `json:"kcf"`
+}
+
+// Kip82SplitTrace is the split of the CN portion by the KIP-82 ratio of proposer/stakers.
+type Kip82SplitTrace struct {
+	Source   *big.Int `json:"source"`
+	Proposer *big.Int `json:"proposer"`
+	Stakers  *big.Int `json:"stakers"`
+}
+
+// SplitTrace is the portions split from the minted amount and the deferred reward fee,
+// before the remainders are paid and the portions of the unset KFF and KCF go to the proposer.
+type SplitTrace struct {
+	Proposer  *big.Int `json:"proposer"`
+	Stakers   *big.Int `json:"stakers"`
+	KFF       *big.Int `json:"kff"`
+	KCF       *big.Int `json:"kcf"`
+	Remainder *big.Int `json:"remainder"`
+}
+
+// StakeShareTrace is the staking reward share of a consolidated CN.
+type StakeShareTrace struct {
+	RewardAddr     common.Address   `json:"rewardAddr"`
+	NodeAddrs      []common.Address `json:"nodeAddrs"`
+	Stake          *big.Int         `json:"stake"`
+	EffectiveStake *big.Int         `json:"effectiveStake"` // the stake above the minimum stake, zero if not above
+	Share          *big.Int         `json:"share"`
+}
+
+// SharesTrace is the distribution of the stakers portion by the effective stakes of the CNs.
+type SharesTrace struct {
+	StakeUnit           string             `json:"stakeUnit"` // KLAY, or peb after the PebStaking hardfork
+	StakeReward         *big.Int           `json:"stakeReward"`
+	CarriedIn           *big.Int           `json:"carriedIn,omitempty"` // the remainder carried in, included in the stake reward
+	TotalEffectiveStake *big.Int           `json:"totalEffectiveStake"`
+	Nodes               []*StakeShareTrace `json:"nodes"`
+	Remainder           *big.Int           `json:"remainder"`
+}
+
+// RemainderTrace is where a rounding remainder goes, one of params.RemainderPolicy*.
+type RemainderTrace struct {
+	Amount      *big.Int `json:"amount"`
+	Destination string   `json:"destination"`
+}
+
+// RewardTrace is each intermediate value of the reward calculation of a block, for finding the
+// step where the rewards diverged from the expected. The steps are traced for the built-in
+// policies only; the result of any policy is in Spec.
+type RewardTrace struct {
+	BlockNumber uint64             `json:"blockNumber"`
+	Policy      string             `json:"policy"`
+	Strategy    string             `json:"strategy"` // the reward strategy of the latest hardfork, e.g. kore
+	Params      *RewardTraceParams `json:"params"`
+
+	Fee        *FeeTrace        `json:"fee,omitempty"`
+	RatioSplit *RatioSplitTrace `json:"ratioSplit,omitempty"`
+	Kip82Split *Kip82SplitTrace `json:"kip82Split,omitempty"`
+	Split      *SplitTrace      `json:"split,omitempty"`
+	Shares     *SharesTrace     `json:"shares,omitempty"`
+
+	SplitRemainder *RemainderTrace `json:"splitRemainder,omitempty"`
+	ShareRemainder *RemainderTrace `json:"shareRemainder,omitempty"`
+
+	Spec *RewardSpec `json:"spec"` // the rewards paid
+}
+
+// TraceBlockReward recalculates the rewards paid in the block of the given header, tracing each
+// intermediate value. Unlike GetBlockReward, it neither reads nor writes the reward spec cache.
+func TraceBlockReward(header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardTrace, error) {
+	rc, err := NewRewardConfig(header, rules, pset)
+	if err != nil {
+		return nil, err
+	}
+	policy := GetRewardPolicy(header.Number, pset)
+	var stakingInfo *StakingInfo
+	if policy.UseStakingInfo() {
+		stakingInfo = GetStakingInfo(header.Number.Uint64())
+	}
+	spec, err := policy.CalcDeferredReward(header, rules, pset, stakingInfo)
+	if err != nil {
+		return nil, err
+	}
+	if spec, err = addPaidTxFee(spec, header, rules, pset, ecrecover); err != nil {
+		return nil, err
+	}
+
+	trace := &RewardTrace{
+		BlockNumber: header.Number.Uint64(),
+		Strategy:    rc.strategyVersion,
+		Params: &RewardTraceParams{
+			MintingAmount:     rc.mintingAmount,
+			MinimumStake:      rc.minimumStake,
+			MinStakeInclusive: rc.minStakeInclusive,
+			DeferredTxFee:     rc.deferredTxFee,
+			BurnRatio:         rc.burnRatio,
+			Ratio:             pset.Ratio(),
+			RemainderPolicy:   rc.remainderPolicy,
+		},
+		Spec: spec,
+	}
+	if rules.IsKore {
+		trace.Params.Kip82Ratio = pset.Kip82Ratio()
+	}
+
+	switch policy.(type) {
+	case SimpleRewardPolicy:
+		trace.Policy = TracePolicySimple
+		trace.Fee = traceFee(rc, false)
+	case StakingRewardPolicy:
+		trace.Policy = TracePolicyStaking
+		trace.Fee = traceFee(rc, true)
+		if err := trace.traceSplit(rc, header, stakingInfo); err != nil {
+			return nil, err
+		}
+	default:
+		trace.Policy = TracePolicyCustom
+	}
+	return trace, nil
+}
+
+// traceFee traces the burn of the tx fee. The fee is burnt further after Kore only if it is
+// deferred and split by the staking policy.
+func traceFee(rc *rewardConfig, staking bool) *FeeTrace {
+	fee := &FeeTrace{
+		TotalFee:  new(big.Int).Set(rc.totalFee),
+		MagmaBurn: big.NewInt(0),
+		KoreBurn:  big.NewInt(0),
+	}
+	if rc.rules.IsMagma {
+		fee.MagmaBurn = getBurnAmountMagma(rc.totalFee, rc.burnRatio)
+	}
+	if rc.rules.IsKore && rc.deferredTxFee && staking {
+		fee.KoreBurn = getBurnAmountKore(rc, new(big.Int).Sub(rc.totalFee, fee.MagmaBurn))
+	}
+	fee.BurntFee = new(big.Int).Add(fee.MagmaBurn, fee.KoreBurn)
+	fee.RewardFee = new(big.Int).Sub(fee.TotalFee, fee.BurntFee)
+	return fee
+}
+
+// traceSplit traces the split of the minted amount and the deferred reward fee, and the
+// distribution of the stakers portion, as done by CalcDeferredRewardWithStakingInfo.
+func (trace *RewardTrace) traceSplit(rc *rewardConfig, header *types.Header, stakingInfo *StakingInfo) error {
+	minted := rc.mintingAmount
+	_, rewardFee, _ := calcDeferredFee(rc)
+
+	source := new(big.Int).Add(minted, rewardFee)
+	if rc.rules.IsKore {
+		source = new(big.Int).Set(minted)
+	}
+	cn, kff, kcf := splitByRatio(rc, source)
+	trace.RatioSplit = &RatioSplitTrace{Source: source, CN: cn, KFF: kff, KCF: kcf}
+	if rc.rules.IsKore {
+		proposer, stakers := splitByKip82Ratio(rc, cn)
+		trace.Kip82Split = &Kip82SplitTrace{Source: new(big.Int).Set(cn), Proposer: proposer, Stakers: stakers}
+	}
+
+	proposer, stakers, kff, kcf, splitRem := calcSplit(rc, minted, rewardFee)
+	trace.Split = &SplitTrace{Proposer: proposer, Stakers: stakers, KFF: kff, KCF: kcf, Remainder: splitRem}
+
+	shares := &SharesTrace{StakeReward: new(big.Int).Set(stakers), Nodes: []*StakeShareTrace{}}
+	carriedIn, err := getCarriedRemainder(rc, header, maxCarryLookback)
+	if err != nil {
+		return err
+	}
+	if carriedIn != nil {
+		shares.CarriedIn = carriedIn
+		shares.StakeReward.Add(shares.StakeReward, carriedIn)
+	}
+	paid, shareRem := calcShares(stakingInfo, shares.StakeReward, rc.minimumStake.Uint64(), rc.minStakeInclusive)
+	shares.Remainder = shareRem
+	shares.StakeUnit, shares.TotalEffectiveStake = "KLAY", big.NewInt(0)
+	if stakingInfo != nil {
+		nodes, effectiveStakes, totalStakes, usePeb := calcEffectiveStakes(stakingInfo, rc.minimumStake.Uint64(), rc.minStakeInclusive)
+		_, nodeShares, _ := calcNodeShares(stakingInfo, shares.StakeReward, rc.minimumStake.Uint64(), rc.minStakeInclusive)
+		if usePeb {
+			shares.StakeUnit = "peb"
+		}
+		shares.TotalEffectiveStake = totalStakes
+		for i, node := range nodes {
+			share := &StakeShareTrace{
+				RewardAddr:     node.RewardAddr,
+				NodeAddrs:      node.NodeAddrs,
+				Stake:          nodeStake(node, usePeb),
+				EffectiveStake: big.NewInt(0),
+				Share:          big.NewInt(0),
+			}
+			if effectiveStakes[i] != nil {
+				share.EffectiveStake = effectiveStakes[i]
+			}
+			if nodeShares[i] != nil {
+				share.Share = nodeShares[i]
+			}
+			shares.Nodes = append(shares.Nodes, share)
+		}
+	}
+	trace.Shares = shares
+
+	splitDest, shareDest := remainderDestinations(rc.remainderPolicy)
+	if len(paid) == 0 {
+		shareDest = params.RemainderPolicyProposer
+	}
+	trace.SplitRemainder = &RemainderTrace{Amount: splitRem, Destination: splitDest}
+	trace.ShareRemainder = &RemainderTrace{Amount: shareRem, Destination: shareDest}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceBlockReward(t *testing.T) {
+	oldStakingManager := GetStakingManager()
+	defer SetTestStakingManager(oldStakingManager)
+
+	var (
+		header = &types.Header{
+			Number:     big.NewInt(1),
+			GasUsed:    1000,
+			BaseFee:    big.NewInt(1),
+			Rewardbase: proposerAddr,
+		}
+		stakingInfo = genStakingInfo(5, nil, map[int]uint64{
+			0: minStaking + 4,
+			1: minStaking + 3,
+		})
+		rules = params.Rules{
+			IsMagma: true,
+			IsKore:  true,
+		}
+	)
+	SetTestStakingManagerWithStakingInfoCache(stakingInfo)
+
+	// the staking policy traces every step
+	pset, err := params.NewGovParamSetChainConfig(getTestConfig())
+	require.NoError(t, err)
+	trace, err := TraceBlockReward(header, rules, pset)
+	require.NoError(t, err)
+
+	assert.Equal(t, TracePolicyStaking, trace.Policy)
+	assert.Equal(t, "kore", trace.Strategy)
+	assert.Equal(t, "34/54/12", trace.Params.Ratio)
+	assert.Equal(t, "20/80", trace.Params.Kip82Ratio)
+
+	assert.Equal(t, big.NewInt(1000), trace.Fee.TotalFee)
+	assert.Equal(t, big.NewInt(500), trace.Fee.MagmaBurn)
+	assert.Equal(t, big.NewInt(500), trace.Fee.KoreBurn)
+	assert.Equal(t, big.NewInt(1000), trace.Fee.BurntFee)
+	assert.Equal(t, 0, trace.Fee.RewardFee.Sign())
+	assert.Equal(t, &RatioSplitTrace{
+		Source: minted,
+		CN:     new(big.Int).SetUint64(3.264e18),
+		KFF:    new(big.Int).SetUint64(5.184e18),
+		KCF:    new(big.Int).SetUint64(1.152e18),
+	}, trace.RatioSplit)
+	assert.Equal(t, &Kip82SplitTrace{
+		Source:   new(big.Int).SetUint64(3.264e18),
+		Proposer: new(big.Int).SetUint64(0.6528e18),
+		Stakers:  new(big.Int).SetUint64(2.6112e18),
+	}, trace.Kip82Split)
+	assert.Equal(t, 0, trace.Split.Remainder.Sign())
+
+	shares := trace.Shares
+	assert.Equal(t, "KLAY", shares.StakeUnit)
+	assert.Equal(t, big.NewInt(7), shares.TotalEffectiveStake)
+	require.Len(t, shares.Nodes, 5)
+	assert.Equal(t, big.NewInt(4), shares.Nodes[0].EffectiveStake)
+	assert.Equal(t, new(big.Int).SetUint64(1492114285714285714), shares.Nodes[0].Share)
+	assert.Equal(t, new(big.Int).SetUint64(1119085714285714285), shares.Nodes[1].Share)
+	assert.Equal(t, 0, shares.Nodes[2].EffectiveStake.Sign())
+	assert.Equal(t, 0, shares.Nodes[2].Share.Sign())
+	assert.Equal(t, &RemainderTrace{Amount: big.NewInt(1), Destination: params.RemainderPolicyProposer}, trace.ShareRemainder)
+
+	expected, err := GetBlockReward(header, rules, pset)
+	require.NoError(t, err)
+	assertEqualRewardSpecs(t, expected, trace.Spec)
+
+	// the simple policy traces the fee only, which is not burnt by Kore
+	config := getTestConfig()
+	config.Istanbul.ProposerPolicy = uint64(istanbul.RoundRobin)
+	pset, err = params.NewGovParamSetChainConfig(config)
+	require.NoError(t, err)
+	trace, err = TraceBlockReward(header, rules, pset)
+	require.NoError(t, err)
+
+	assert.Equal(t, TracePolicySimple, trace.Policy)
+	assert.Equal(t, big.NewInt(500), trace.Fee.BurntFee)
+	assert.Equal(t, 0, trace.Fee.KoreBurn.Sign())
+	assert.Nil(t, trace.RatioSplit)
+	assert.Nil(t, trace.Shares)
+	assert.Equal(t, new(big.Int).SetUint64(9.6e18+500), trace.Spec.Proposer)
+}
@@ -26,6 +26,7 @@ import (
 	"sort"
 
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
 )
@@ -59,6 +60,9 @@ type StakingInfo struct {
 
 	// Derived from CouncilStakingAddrs
 	CouncilStakingAmounts []uint64 `json:"councilStakingAmounts"` // Staking amounts of Council
+
+	// Exact staking amounts of Council in peb, filled after the PebStaking hardfork
+	CouncilStakingAmountsPeb []*big.Int `json:"councilStakingAmountsPeb,omitempty"`
 }
 
 // MarshalJSON supports json marshalling for both oldStakingInfo and StakingInfo
@@ -75,6 +79,8 @@ func (st StakingInfo) MarshalJSON() ([]byte, error) {
 		Gini                  float64          `json:"gini"`
 		CouncilStakingAmounts []uint64         `json:"councilStakingAmounts"`
 
+		CouncilStakingAmountsPeb []*big.Int `json:"councilStakingAmountsPeb,omitempty"`
+
 		// legacy fields of StakingInfo
 		KIRAddr common.Address `json:"KIRAddr"` // KIRAddr -> KCFAddr from v1.10.2
 		PoCAddr common.Address `json:"PoCAddr"` // PoCAddr -> KFFAddr from v1.10.2
@@ -90,6 +96,7 @@ func (st StakingInfo) MarshalJSON() ([]byte, error) {
 	ext.UseGini = st.UseGini
 	ext.Gini = st.Gini
 	ext.CouncilStakingAmounts = st.CouncilStakingAmounts
+	ext.CouncilStakingAmountsPeb = st.CouncilStakingAmountsPeb
 
 	// KIRAddr and PoCAddr are for backward-compatibility of database
 	ext.KIRAddr = st.KCFAddr
@@ -111,6 +118,8 @@ func (st *StakingInfo) UnmarshalJSON(input []byte) error {
 		Gini                  float64          `json:"gini"`
 		CouncilStakingAmounts []uint64         `json:"councilStakingAmounts"`
 
+		CouncilStakingAmountsPeb []*big.Int `json:"councilStakingAmountsPeb,omitempty"`
+
 		// legacy fields of StakingInfo
 		KIRAddr common.Address `json:"KIRAddr"` // KIRAddr -> KCFAddr from v1.10.2
 		PoCAddr common.Address `json:"PoCAddr"` // PoCAddr -> KFFAddr from v1.10.2
@@ -132,6 +141,7 @@ func (st *StakingInfo) UnmarshalJSON(input []byte) error {
 	st.UseGini = ext.UseGini
 	st.Gini = ext.Gini
 	st.CouncilStakingAmounts = ext.CouncilStakingAmounts
+	st.CouncilStakingAmountsPeb = ext.CouncilStakingAmountsPeb
 
 	if st.KCFAddr == emptyAddr {
 		st.KCFAddr = ext.KIRAddr
@@ -164,6 +174,8 @@ type consolidatedNode struct {
 	StakingAddrs  []common.Address
 	RewardAddr    common.Address // common reward address
 	StakingAmount uint64         // sum of staking amounts
+	// sum of staking amounts in peb, nil if the staking information has no peb amounts
+	StakingAmountPeb *big.Int
 }
 
 type ConsolidatedStakingInfo struct {
@@ -181,6 +193,9 @@ type stakingInfoRLP struct {
 	UseGini               bool
 	Gini                  uint64
 	CouncilStakingAmounts []uint64
+
+	// Omitted before the PebStaking hardfork, so that the legacy encoding is kept.
+	CouncilStakingAmountsPeb []*big.Int `rlp:"optional"`
 }
 
 func newEmptyStakingInfo(blockNum uint64) *StakingInfo {
@@ -220,6 +235,15 @@ func newStakingInfo(bc blockChain, helper governanceHelper, blockNum uint64, nod
 		stakingAmounts[i] = tempStakingAmount.Uint64()
 	}
 
+	// After the PebStaking hardfork, the exact balances are kept as well
+	var stakingAmountsPeb []*big.Int
+	if bc.Config().IsPebStakingForkEnabled(new(big.Int).SetUint64(blockNum)) {
+		stakingAmountsPeb = make([]*big.Int, len(stakingAddrs))
+		for i, stakingAddr := range stakingAddrs {
+			stakingAmountsPeb[i] = new(big.Int).Set(statedb.GetBalance(stakingAddr))
+		}
+	}
+
 	pset, err := helper.EffectiveParams(blockNum)
 	if err != nil {
 		return nil, err
@@ -237,6 +261,8 @@ func newStakingInfo(bc blockChain, helper governanceHelper, blockNum uint64, nod
 		CouncilStakingAmounts: stakingAmounts,
 		Gini:                  gini,
 		UseGini:               useGini,
+
+		CouncilStakingAmountsPeb: stakingAmountsPeb,
 	}
 	return stakingInfo, nil
 }
@@ -268,7 +294,7 @@ func (s *StakingInfo) String() string {
 
 func (s *StakingInfo) EncodeRLP(w io.Writer) error {
 	// float64 is not rlp serializable, so it converts to bytes
-	return rlp.Encode(w, &stakingInfoRLP{s.BlockNum, s.CouncilNodeAddrs, s.CouncilStakingAddrs, s.CouncilRewardAddrs, s.KCFAddr, s.KFFAddr, s.UseGini, math.Float64bits(s.Gini), s.CouncilStakingAmounts})
+	return rlp.Encode(w, &stakingInfoRLP{s.BlockNum, s.CouncilNodeAddrs, s.CouncilStakingAddrs, s.CouncilRewardAddrs, s.KCFAddr, s.KFFAddr, s.UseGini, math.Float64bits(s.Gini), s.CouncilStakingAmounts, s.CouncilStakingAmountsPeb})
 }
 
 func (s *StakingInfo) DecodeRLP(st *rlp.Stream) error {
@@ -280,9 +306,27 @@ func (s *StakingInfo) DecodeRLP(st *rlp.Stream) error {
 	s.CouncilNodeAddrs, s.CouncilStakingAddrs, s.CouncilRewardAddrs = dec.CouncilNodeAddrs, dec.CouncilStakingAddrs, dec.CouncilRewardAddrs
 	s.KCFAddr, s.KFFAddr, s.UseGini, s.Gini = dec.KCFAddr, dec.KFFAddr, dec.UseGini, math.Float64frombits(dec.Gini)
 	s.CouncilStakingAmounts = dec.CouncilStakingAmounts
+	s.CouncilStakingAmountsPeb = dec.CouncilStakingAmountsPeb
 	return nil
 }
 
+// StakingInfoHash returns the hash of the staking information committed in the block headers after
+// the StakingCommitment hardfork, or the zero hash if the staking information is nil. The gini
+// coefficient is excluded since it is derived from the staking amounts by floating point operations.
+// The peb staking amounts are only hashed after the PebStaking hardfork, where they are filled.
+func StakingInfoHash(s *StakingInfo) common.Hash {
+	if s == nil {
+		return common.Hash{}
+	}
+	enc, _ := rlp.EncodeToBytes(&stakingInfoRLP{s.BlockNum, s.CouncilNodeAddrs, s.CouncilStakingAddrs, s.CouncilRewardAddrs, s.KCFAddr, s.KFFAddr, s.UseGini, 0, s.CouncilStakingAmounts, s.CouncilStakingAmountsPeb})
+	return crypto.Keccak256Hash(enc)
+}
+
+// hasPebAmounts returns whether the staking information carries a peb amount for every staking address.
+func (s *StakingInfo) hasPebAmounts() bool {
+	return len(s.CouncilStakingAmountsPeb) > 0 && len(s.CouncilStakingAmountsPeb) == len(s.CouncilStakingAmounts)
+}
+
 func (s *StakingInfo) GetConsolidatedStakingInfo() *ConsolidatedStakingInfo {
 	c := &ConsolidatedStakingInfo{
 		nodes:     make([]consolidatedNode, 0),
@@ -290,6 +334,7 @@ func (s *StakingInfo) GetConsolidatedStakingInfo() *ConsolidatedStakingInfo {
 	}
 
 	rewardIndex := make(map[common.Address]int) // temporarily map rewardAddr -> index in []nodes
+	hasPeb := s.hasPebAmounts()
 
 	for j := 0; j < len(s.CouncilNodeAddrs); j++ {
 		var (
@@ -305,12 +350,18 @@ func (s *StakingInfo) GetConsolidatedStakingInfo() *ConsolidatedStakingInfo {
 				RewardAddr:    rewardAddr,
 				StakingAmount: stakingAmount,
 			})
+			if hasPeb {
+				c.nodes[len(c.nodes)-1].StakingAmountPeb = new(big.Int).Set(s.CouncilStakingAmountsPeb[j])
+			}
 			c.nodeIndex[nodeAddr] = len(c.nodes) - 1 // point to new element
 			rewardIndex[rewardAddr] = len(c.nodes) - 1
 		} else {
 			c.nodes[idx].NodeAddrs = append(c.nodes[idx].NodeAddrs, nodeAddr)
 			c.nodes[idx].StakingAddrs = append(c.nodes[idx].StakingAddrs, stakingAddr)
 			c.nodes[idx].StakingAmount += stakingAmount
+			if hasPeb {
+				c.nodes[idx].StakingAmountPeb.Add(c.nodes[idx].StakingAmountPeb, s.CouncilStakingAmountsPeb[j])
+			}
 			c.nodeIndex[nodeAddr] = idx // point to existing element
 		}
 	}
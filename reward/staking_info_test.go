@@ -19,11 +19,14 @@ package reward
 import (
 	"encoding/json"
 	"math"
+	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -95,7 +98,7 @@ func generateStakingInfoTestCases() []stakingInfoTestCase {
 			},
 			expectedConsolidated: &ConsolidatedStakingInfo{
 				nodes: []consolidatedNode{
-					{[]common.Address{n1}, []common.Address{s1}, r1, a1},
+					{[]common.Address{n1}, []common.Address{s1}, r1, a1, nil},
 				},
 				nodeIndex: map[common.Address]int{n1: 0},
 			},
@@ -117,10 +120,10 @@ func generateStakingInfoTestCases() []stakingInfoTestCase {
 			},
 			expectedConsolidated: &ConsolidatedStakingInfo{
 				nodes: []consolidatedNode{
-					{[]common.Address{n1}, []common.Address{s1}, r1, a1},
-					{[]common.Address{n2}, []common.Address{s2}, r2, a2},
-					{[]common.Address{n3}, []common.Address{s3}, r3, a3},
-					{[]common.Address{n4}, []common.Address{s4}, r4, a4},
+					{[]common.Address{n1}, []common.Address{s1}, r1, a1, nil},
+					{[]common.Address{n2}, []common.Address{s2}, r2, a2, nil},
+					{[]common.Address{n3}, []common.Address{s3}, r3, a3, nil},
+					{[]common.Address{n4}, []common.Address{s4}, r4, a4, nil},
 				},
 				nodeIndex: map[common.Address]int{n1: 0, n2: 1, n3: 2, n4: 3},
 			},
@@ -142,8 +145,8 @@ func generateStakingInfoTestCases() []stakingInfoTestCase {
 			},
 			expectedConsolidated: &ConsolidatedStakingInfo{
 				nodes: []consolidatedNode{
-					{[]common.Address{n1, n3}, []common.Address{s1, s3}, r1, a1 + a3}, // n1 & n3
-					{[]common.Address{n2, n4}, []common.Address{s2, s4}, r2, a2 + a4}, // n2 & n4
+					{[]common.Address{n1, n3}, []common.Address{s1, s3}, r1, a1 + a3, nil}, // n1 & n3
+					{[]common.Address{n2, n4}, []common.Address{s2, s4}, r2, a2 + a4, nil}, // n2 & n4
 				},
 				nodeIndex: map[common.Address]int{n1: 0, n2: 1, n3: 0, n4: 1},
 			},
@@ -165,10 +168,10 @@ func generateStakingInfoTestCases() []stakingInfoTestCase {
 			},
 			expectedConsolidated: &ConsolidatedStakingInfo{
 				nodes: []consolidatedNode{
-					{[]common.Address{n1}, []common.Address{s1}, r1, a2},
-					{[]common.Address{n2}, []common.Address{s2}, r2, aM},
-					{[]common.Address{n3}, []common.Address{s3}, r3, aL},
-					{[]common.Address{n4}, []common.Address{s4}, r4, a0},
+					{[]common.Address{n1}, []common.Address{s1}, r1, a2, nil},
+					{[]common.Address{n2}, []common.Address{s2}, r2, aM, nil},
+					{[]common.Address{n3}, []common.Address{s3}, r3, aL, nil},
+					{[]common.Address{n4}, []common.Address{s4}, r4, a0, nil},
 				},
 				nodeIndex: map[common.Address]int{n1: 0, n2: 1, n3: 2, n4: 3},
 			},
@@ -460,6 +463,7 @@ var newInfo = StakingInfo{
 	false,
 	0.3,
 	[]uint64{15000000, 4000000, 25000000, 35000000},
+	nil,
 }
 
 // TestGetStakingInfoFromDB tests whether the node can read oldStakingInfo and StakingInfo data or not.
@@ -482,13 +486,7 @@ func TestGetStakingInfoFromDB(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		vInfo := reflect.ValueOf(info)
-		vRetriedInfo := reflect.ValueOf(*retrievedInfo)
-
-		assert.Equal(t, vInfo.NumField(), vRetriedInfo.NumField())
-		for i := 0; i < vInfo.NumField(); i++ {
-			assert.Equal(t, vInfo.Field(i).Interface(), vRetriedInfo.Field(i).Interface())
-		}
+		checkStakingInfoValues(t, info, *retrievedInfo)
 	}
 }
 
@@ -537,13 +535,96 @@ func TestStakingInfo_MarshalJSON(t *testing.T) {
 	}
 }
 
+// checkStakingInfoValues checks that the fields of info and stakingInfo with the same name are equal.
+// A field that only one of them has, such as CouncilStakingAmountsPeb which oldStakingInfo lacks, must be empty.
 func checkStakingInfoValues(t *testing.T, info interface{}, stakingInfo interface{}) {
 	vOld := reflect.ValueOf(info)
 	vNew := reflect.ValueOf(stakingInfo)
-	assert.Equal(t, vOld.NumField(), vNew.NumField())
 
-	for i := 0; i < vOld.NumField(); i++ {
-		field := reflect.TypeOf(info).Field(i).Name
-		assert.Equal(t, vOld.FieldByName(field).Interface(), vNew.FieldByName(field).Interface())
+	for _, pair := range [][2]reflect.Value{{vOld, vNew}, {vNew, vOld}} {
+		for i := 0; i < pair[0].NumField(); i++ {
+			field := pair[0].Type().Field(i).Name
+			other := pair[1].FieldByName(field)
+			if !other.IsValid() {
+				assert.True(t, pair[0].Field(i).IsZero(), "field %s", field)
+				continue
+			}
+			assert.Equal(t, pair[0].Field(i).Interface(), other.Interface())
+		}
+	}
+}
+
+func TestStakingInfoHash(t *testing.T) {
+	info := &StakingInfo{
+		BlockNum:              86400,
+		CouncilNodeAddrs:      []common.Address{{0x1}},
+		CouncilStakingAddrs:   []common.Address{{0x2}},
+		CouncilRewardAddrs:    []common.Address{{0x3}},
+		UseGini:               true,
+		Gini:                  DefaultGiniCoefficient,
+		CouncilStakingAmounts: []uint64{5000000},
+	}
+	hash := StakingInfoHash(info)
+	assert.NotEqual(t, common.Hash{}, hash)
+	assert.Equal(t, common.Hash{}, StakingInfoHash(nil))
+
+	// the gini coefficient is not committed
+	info.Gini = 0.5
+	assert.Equal(t, hash, StakingInfoHash(info))
+
+	info.CouncilStakingAmounts = []uint64{5000001}
+	assert.NotEqual(t, hash, StakingInfoHash(info))
+}
+
+func TestStakingInfo_PebAmounts(t *testing.T) {
+	info := &StakingInfo{
+		BlockNum:              86400,
+		CouncilNodeAddrs:      []common.Address{{0x1}, {0x2}},
+		CouncilStakingAddrs:   []common.Address{{0x3}, {0x4}},
+		CouncilRewardAddrs:    []common.Address{{0x5}, {0x5}},
+		Gini:                  DefaultGiniCoefficient,
+		CouncilStakingAmounts: []uint64{5000000, 6000000},
+	}
+
+	// without the peb amounts, the encoding is the same as before the PebStaking hardfork
+	legacy := struct {
+		BlockNum              uint64
+		CouncilNodeAddrs      []common.Address
+		CouncilStakingAddrs   []common.Address
+		CouncilRewardAddrs    []common.Address
+		KCFAddr               common.Address
+		KFFAddr               common.Address
+		UseGini               bool
+		Gini                  uint64
+		CouncilStakingAmounts []uint64
+	}{info.BlockNum, info.CouncilNodeAddrs, info.CouncilStakingAddrs, info.CouncilRewardAddrs, info.KCFAddr, info.KFFAddr, info.UseGini, 0, info.CouncilStakingAmounts}
+	legacyEnc, err := rlp.EncodeToBytes(&legacy)
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256Hash(legacyEnc), StakingInfoHash(info))
+
+	hash := StakingInfoHash(info)
+	info.CouncilStakingAmountsPeb = []*big.Int{
+		new(big.Int).Add(new(big.Int).Mul(big.NewInt(5000000), big.NewInt(params.KLAY)), big.NewInt(1)),
+		new(big.Int).Add(new(big.Int).Mul(big.NewInt(6000000), big.NewInt(params.KLAY)), big.NewInt(2)),
 	}
+	assert.NotEqual(t, hash, StakingInfoHash(info))
+
+	// rlp and json round trips keep the peb amounts
+	enc, err := rlp.EncodeToBytes(info)
+	assert.NoError(t, err)
+	var rlpDecoded StakingInfo
+	assert.NoError(t, rlp.DecodeBytes(enc, &rlpDecoded))
+	assert.Equal(t, info.CouncilStakingAmountsPeb, rlpDecoded.CouncilStakingAmountsPeb)
+
+	j, err := json.Marshal(info)
+	assert.NoError(t, err)
+	var jsonDecoded StakingInfo
+	assert.NoError(t, json.Unmarshal(j, &jsonDecoded))
+	assert.Equal(t, info.CouncilStakingAmountsPeb, jsonDecoded.CouncilStakingAmountsPeb)
+
+	// the peb amounts of the nodes sharing a reward address are summed
+	nodes := info.GetConsolidatedStakingInfo().GetAllNodes()
+	assert.Equal(t, 1, len(nodes))
+	assert.Equal(t, uint64(11000000), nodes[0].StakingAmount)
+	assert.Equal(t, new(big.Int).Add(new(big.Int).Mul(big.NewInt(11000000), big.NewInt(params.KLAY)), big.NewInt(3)), nodes[0].StakingAmountPeb)
 }
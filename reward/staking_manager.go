@@ -153,6 +153,7 @@ func GetStakingInfoOnStakingBlock(stakingBlockNumber uint64) *StakingInfo {
 
 	// Get staking info from cache
 	if cachedStakingInfo := stakingManager.stakingInfoCache.get(stakingBlockNumber); cachedStakingInfo != nil {
+		stakingInfoCacheHitMeter.Mark(1)
 		logger.Debug("StakingInfoCache hit.", "staking block number", stakingBlockNumber, "stakingInfo", cachedStakingInfo)
 		// Fill in Gini coeff if not set. Modifies the cached object.
 		if err := fillMissingGiniCoefficient(cachedStakingInfo, stakingBlockNumber); err != nil {
@@ -160,6 +161,7 @@ func GetStakingInfoOnStakingBlock(stakingBlockNumber uint64) *StakingInfo {
 		}
 		return cachedStakingInfo
 	}
+	stakingInfoCacheMissMeter.Mark(1)
 
 	// Get staking info from DB
 	if storedStakingInfo, err := getStakingInfoFromDB(stakingBlockNumber); storedStakingInfo != nil && err == nil {
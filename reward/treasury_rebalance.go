@@ -1,7 +1,8 @@
-package backend
+package reward
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"math/big"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/klaytn/klaytn/accounts/abi/bind"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/system"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
@@ -29,6 +31,11 @@ type Kip103ContractCaller struct {
 	header *types.Header         // the header of a new block that is under process
 }
 
+// NewKip103ContractCaller returns a caller executing the KIP-103 contract on the given state.
+func NewKip103ContractCaller(state *state.StateDB, chain consensus.ChainReader, header *types.Header) *Kip103ContractCaller {
+	return &Kip103ContractCaller{state, chain, header}
+}
+
 func (caller *Kip103ContractCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
 	return caller.state.GetCode(contract), nil
 }
@@ -55,15 +62,17 @@ func (caller *Kip103ContractCaller) CallContract(ctx context.Context, call klayt
 	return result.Return(), err
 }
 
-type kip103result struct {
+// TreasuryRebalanceResult is the result of the treasury rebalancing (KIP-103).
+// Its JSON encoding is the memo of the rebalancing.
+type TreasuryRebalanceResult struct {
 	Retired map[common.Address]*big.Int `json:"retired"`
 	Newbie  map[common.Address]*big.Int `json:"newbie"`
 	Burnt   *big.Int                    `json:"burnt"`
 	Success bool                        `json:"success"`
 }
 
-func newKip103Receipt() *kip103result {
-	return &kip103result{
+func newTreasuryRebalanceResult() *TreasuryRebalanceResult {
+	return &TreasuryRebalanceResult{
 		Retired: make(map[common.Address]*big.Int),
 		Newbie:  make(map[common.Address]*big.Int),
 		Burnt:   big.NewInt(0),
@@ -71,7 +80,7 @@ func newKip103Receipt() *kip103result {
 	}
 }
 
-func (result *kip103result) fillRetired(contract *kip103.TreasuryRebalanceCaller, state *state.StateDB) error {
+func (result *TreasuryRebalanceResult) fillRetired(contract *kip103.TreasuryRebalanceCaller, state *state.StateDB) error {
 	numRetiredBigInt, err := contract.GetRetiredCount(nil)
 	if err != nil {
 		logger.Error("Failed to get RetiredCount from TreasuryRebalance contract", "err", err)
@@ -89,7 +98,7 @@ func (result *kip103result) fillRetired(contract *kip103.TreasuryRebalanceCaller
 	return nil
 }
 
-func (result *kip103result) fillNewbie(contract *kip103.TreasuryRebalanceCaller) error {
+func (result *TreasuryRebalanceResult) fillNewbie(contract *kip103.TreasuryRebalanceCaller) error {
 	numNewbieBigInt, err := contract.GetNewbieCount(nil)
 	if err != nil {
 		logger.Error("Failed to get NewbieCount from TreasuryRebalance contract", "err", err)
@@ -107,7 +116,7 @@ func (result *kip103result) fillNewbie(contract *kip103.TreasuryRebalanceCaller)
 	return nil
 }
 
-func (result *kip103result) totalRetriedBalance() *big.Int {
+func (result *TreasuryRebalanceResult) totalRetriedBalance() *big.Int {
 	total := big.NewInt(0)
 	for _, bal := range result.Retired {
 		total.Add(total, bal)
@@ -115,7 +124,7 @@ func (result *kip103result) totalRetriedBalance() *big.Int {
 	return total
 }
 
-func (result *kip103result) totalNewbieBalance() *big.Int {
+func (result *TreasuryRebalanceResult) totalNewbieBalance() *big.Int {
 	total := big.NewInt(0)
 	for _, bal := range result.Newbie {
 		total.Add(total, bal)
@@ -126,10 +135,12 @@ func (result *kip103result) totalNewbieBalance() *big.Int {
 // RebalanceTreasury reads data from a contract, validates stored values, and executes treasury rebalancing (KIP-103).
 // It can change the global state by removing old treasury balances and allocating new treasury balances.
 // The new allocation can be larger than the removed amount, and the difference between two amounts will be burnt.
-func RebalanceTreasury(state *state.StateDB, chain consensus.ChainReader, header *types.Header, c bind.ContractCaller) (*kip103result, error) {
-	result := newKip103Receipt()
+// If Kip103RecordMemo is set in the chain config, the result is recorded as the memo of the contract.
+func RebalanceTreasury(state *state.StateDB, chain consensus.ChainReader, header *types.Header, c bind.ContractCaller) (*TreasuryRebalanceResult, error) {
+	result := newTreasuryRebalanceResult()
+	config := chain.Config()
 
-	caller, err := kip103.NewTreasuryRebalanceCaller(chain.Config().Kip103ContractAddress, c)
+	caller, err := kip103.NewTreasuryRebalanceCaller(config.Kip103ContractAddress, c)
 	if err != nil {
 		return result, err
 	}
@@ -184,5 +195,14 @@ func RebalanceTreasury(state *state.StateDB, chain consensus.ChainReader, header
 	result.Burnt.Add(result.Burnt, remainder)
 	result.Success = true
 
+	// Execution 3) Finalize the contract with the result as the memo
+	if config.Kip103RecordMemo {
+		memo, err := json.Marshal(result)
+		if err != nil {
+			return result, err
+		}
+		system.WriteKip103Memo(state, config.Kip103ContractAddress, memo)
+	}
+
 	return result, nil
 }
@@ -1,4 +1,4 @@
-package backend
+package reward
 
 import (
 	"context"
@@ -7,14 +7,19 @@ import (
 	"math/big"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/klaytn/klaytn"
 	"github.com/klaytn/klaytn/accounts/abi"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/system"
+	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/consensus/gxhash"
 	"github.com/klaytn/klaytn/contracts/kip103"
 	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,25 +47,36 @@ func (caller *mockKip103ContractCaller) CallContract(ctx context.Context, call k
 	return caller.abi.Methods[funcName].Outputs.Pack(mockRet...)
 }
 
-func TestRebalanceTreasury(t *testing.T) {
-	bc, istBackend := newBlockChain(1)
-	defer func() {
-		istBackend.Stop()
-		bc.Stop()
-	}()
+// testKip103Chain is a chain with the given config for the KIP-103 tests.
+type testKip103Chain struct {
+	consensus.ChainReader
+	config *params.ChainConfig
+}
 
-	parsed, err := abi.JSON(strings.NewReader(kip103.TreasuryRebalanceABI))
+func (c *testKip103Chain) Config() *params.ChainConfig                          { return c.config }
+func (c *testKip103Chain) Engine() consensus.Engine                             { return gxhash.NewFaker() }
+func (c *testKip103Chain) GetHeader(hash common.Hash, num uint64) *types.Header { return nil }
+
+func newKip103TestState(t *testing.T) *state.StateDB {
+	state, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
+	return state
+}
 
-	// time to generate blocks
-	time.Sleep(2 * time.Second)
-
-	block := bc.CurrentBlock()
+func TestRebalanceTreasury(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(kip103.TreasuryRebalanceABI))
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	bc.Config().Kip103CompatibleBlock = block.Number()
-	bc.Config().Kip103ContractAddress = common.Address{}
+	header := &types.Header{Number: big.NewInt(100)}
+	config := &params.ChainConfig{
+		Kip103CompatibleBlock: header.Number,
+		Kip103ContractAddress: common.HexToAddress("0x1030"),
+	}
+	chain := &testKip103Chain{config: config}
 
 	retireds := []struct {
 		addr    common.Address
@@ -96,25 +112,35 @@ func TestRebalanceTreasury(t *testing.T) {
 	defaultReturnMap["newbies1"] = []interface{}{common.HexToAddress("0x75c3098be5e4b63fbac05838daaee378dd48098d"), new(big.Int).Mul(big.NewInt(500000), big.NewInt(params.KLAY))}
 	defaultReturnMap["newbies2"] = []interface{}{common.HexToAddress("0xceB7ADDFBa9665d8767173D47dE4453D7b7B900D"), new(big.Int).Mul(big.NewInt(123412), big.NewInt(params.KLAY))}
 
-	defaultReturnMap["rebalanceBlockNumber"] = []interface{}{block.Number()}
+	defaultReturnMap["rebalanceBlockNumber"] = []interface{}{header.Number}
 	defaultReturnMap["status"] = []interface{}{uint8(2)}
 
 	testCases := []struct {
 		modifier func(retMap map[string][]interface{})
 		// TODO-aidn: add result checker also
 		expectedErr error
+		recordMemo  bool
 	}{
 		{
 			func(retMap map[string][]interface{}) {
 				// do nothing
 			},
 			nil,
+			false,
+		},
+		{
+			func(retMap map[string][]interface{}) {
+				// do nothing
+			},
+			nil,
+			true,
 		},
 		{
 			func(retMap map[string][]interface{}) {
 				retMap["status"] = []interface{}{uint8(1)}
 			},
 			errNotProperStatus,
+			true,
 		},
 		{
 			func(retMap map[string][]interface{}) {
@@ -123,6 +149,7 @@ func TestRebalanceTreasury(t *testing.T) {
 				retMap["newbies2"][1] = big.NewInt(0)
 			},
 			nil,
+			false,
 		},
 		{
 			func(retMap map[string][]interface{}) {
@@ -131,15 +158,14 @@ func TestRebalanceTreasury(t *testing.T) {
 				retMap["newbies2"][1] = big.NewInt(1)
 			},
 			errNotEnoughRetiredBal,
+			false,
 		},
 	}
 
 	for _, tc := range testCases {
 		// reset state
-		state, err := bc.StateAt(block.Root())
-		if err != nil {
-			t.Fatal(err)
-		}
+		state := newKip103TestState(t)
+		config.Kip103RecordMemo = tc.recordMemo
 
 		// initializing retireds' assets
 		for i := range retireds {
@@ -155,7 +181,7 @@ func TestRebalanceTreasury(t *testing.T) {
 		tc.modifier(mockRetMap)
 
 		c := &mockKip103ContractCaller{abi: parsed, funcSigMap: kip103.TreasuryRebalanceFuncSigs, retMap: mockRetMap}
-		ret, err := RebalanceTreasury(state, bc, block.Header(), c)
+		ret, err := RebalanceTreasury(state, chain, header, c)
 		assert.Equal(t, tc.expectedErr, err)
 
 		// balance check
@@ -176,5 +202,44 @@ func TestRebalanceTreasury(t *testing.T) {
 
 		memo, _ := json.Marshal(ret)
 		t.Log(string(memo))
+
+		// memo check
+		if ret.Success && tc.recordMemo {
+			assert.Equal(t, memo, system.ReadKip103Memo(state, config.Kip103ContractAddress))
+			assert.Equal(t, system.Kip103StatusFinalized, system.ReadKip103Status(state, config.Kip103ContractAddress))
+		} else {
+			assert.Empty(t, system.ReadKip103Memo(state, config.Kip103ContractAddress))
+		}
+	}
+}
+
+// Test that the recorded memo is read by the KIP-103 contract.
+func TestRebalanceTreasury_Memo(t *testing.T) {
+	var (
+		header = &types.Header{Number: big.NewInt(100), Time: big.NewInt(0), BlockScore: big.NewInt(0)}
+		chain  = &testKip103Chain{config: params.TestChainConfig}
+		addr   = common.HexToAddress("0x1030")
+	)
+
+	for _, memo := range []string{
+		"",
+		`{"success":true}`,
+		`{"retired":{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed":700000000000000000000000},"newbie":{},"burnt":700000000000000000000000,"success":true}`,
+	} {
+		state := newKip103TestState(t)
+		state.SetCode(addr, common.FromHex(kip103.TreasuryRebalanceBinRuntime))
+		system.WriteKip103Memo(state, addr, []byte(memo))
+
+		caller, err := kip103.NewTreasuryRebalanceCaller(addr, NewKip103ContractCaller(state, chain, header))
+		assert.Nil(t, err)
+
+		recorded, err := caller.Memo(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, memo, recorded)
+		assert.Equal(t, memo, string(system.ReadKip103Memo(state, addr)))
+
+		status, err := caller.Status(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, system.Kip103StatusFinalized, status)
 	}
 }
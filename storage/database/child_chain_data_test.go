@@ -218,3 +218,35 @@ func TestChildChainData_ReadAndWrite_OperatorFeePayer(t *testing.T) {
 		assert.Equal(t, common.Address{}, feePayer)
 	}
 }
+
+func TestChildChainData_ReadAndWrite_MessageBridge(t *testing.T) {
+	dir, err := os.MkdirTemp("", "klaytn-test-child-chain-data")
+	if err != nil {
+		t.Fatalf("cannot create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dbc := &DBConfig{Dir: dir, DBType: LevelDB, LevelDBCacheSize: 32, OpenFilesLimit: 32}
+	dbm := NewDBManager(dbc)
+	defer dbm.Close()
+
+	child := common.HexToAddress("0x0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a")
+	parent := common.HexToAddress("0x0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b")
+
+	// Before writing the data into DB, nothing should be returned.
+	assert.Empty(t, dbm.ReadMessageBridgePairs())
+	assert.Equal(t, uint64(0), dbm.ReadMessageBridgeRelayedBlock(child))
+
+	// After writing the data into DB, data should be returned.
+	pairs := [][2]common.Address{{child, parent}}
+	dbm.WriteMessageBridgePairs(pairs)
+	assert.Equal(t, pairs, dbm.ReadMessageBridgePairs())
+
+	dbm.WriteMessageBridgeRelayedBlock(child, 10)
+	assert.Equal(t, uint64(10), dbm.ReadMessageBridgeRelayedBlock(child))
+	assert.Equal(t, uint64(0), dbm.ReadMessageBridgeRelayedBlock(parent))
+
+	// Deregistering all pairs leaves no pair.
+	dbm.WriteMessageBridgePairs(nil)
+	assert.Empty(t, dbm.ReadMessageBridgePairs())
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/rcrowley/go-metrics"
+)
+
+var (
+	compactionBacklogGauge   = metrics.NewRegisteredGauge("klay/db/compaction/scheduler/backlog", nil)
+	compactionChunksMeter    = metrics.NewRegisteredMeter("klay/db/compaction/scheduler/chunks", nil)
+	compactionSkippedMeter   = metrics.NewRegisteredMeter("klay/db/compaction/scheduler/skipped", nil)
+	compactionDurationGauge  = metrics.NewRegisteredGauge("klay/db/compaction/scheduler/duration", nil)
+	compactionFailuresMeter  = metrics.NewRegisteredMeter("klay/db/compaction/scheduler/failures", nil)
+	errInvalidCompactionSize = errors.New("the number of blocks compacted at once must be positive")
+)
+
+// coldBlockPrefixes are the prefixes of the block data keyed by the block number.
+var coldBlockPrefixes = []struct {
+	dbType DBEntryType
+	prefix []byte
+}{
+	{headerDB, headerPrefix},
+	{BodyDB, blockBodyPrefix},
+	{ReceiptsDB, blockReceiptsPrefix},
+}
+
+// CompactionSchedulerConfig is the configuration of the compaction scheduler.
+type CompactionSchedulerConfig struct {
+	Schedule           string // cron expression of the minutes when a chunk of cold blocks can be compacted
+	MaxPendingRequests int64  // the compaction is skipped if more RPC requests are in flight
+	RecentBlocks       uint64 // the blocks within this distance from the head are hot and not compacted
+	ChunkBlocks        uint64 // the number of blocks compacted at once
+}
+
+var DefaultCompactionSchedulerConfig = CompactionSchedulerConfig{
+	Schedule:           "*/10 2-5 * * *",
+	MaxPendingRequests: 10,
+	RecentBlocks:       86400,
+	ChunkBlocks:        100000,
+}
+
+// CompactionWorkload is the workload of the node observed by the compaction scheduler.
+type CompactionWorkload struct {
+	PendingRequests int64 // the number of RPC requests in flight
+	Syncing         bool  // whether the node is catching up the network
+}
+
+// CompactionScheduler compacts the headers, bodies and receipts of the cold blocks chunk by
+// chunk during the scheduled low-traffic windows, so that a long-running node does not suffer
+// from read latency spikes caused by the background compactions. A chunk is compacted only if
+// the workload is below the thresholds when the schedule matches.
+type CompactionScheduler struct {
+	dbm      DBManager
+	config   CompactionSchedulerConfig
+	schedule *cronSchedule
+	head     func() uint64
+	workload func() CompactionWorkload
+
+	compacted uint64 // the blocks below this number are compacted
+	mu        sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewCompactionScheduler returns a compaction scheduler of the given database. The head and
+// workload functions are called whenever the schedule matches.
+func NewCompactionScheduler(dbm DBManager, config CompactionSchedulerConfig, head func() uint64, workload func() CompactionWorkload) (*CompactionScheduler, error) {
+	schedule, err := parseCronSchedule(config.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	if config.ChunkBlocks == 0 {
+		return nil, errInvalidCompactionSize
+	}
+	for _, cold := range coldBlockPrefixes {
+		if _, ok := dbm.getDatabase(cold.dbType).(Compacter); !ok {
+			return nil, errCompactionNotSupported
+		}
+	}
+	return &CompactionScheduler{
+		dbm:      dbm,
+		config:   config,
+		schedule: schedule,
+		head:     head,
+		workload: workload,
+		quit:     make(chan struct{}),
+	}, nil
+}
+
+// Start starts checking the schedule every minute.
+func (s *CompactionScheduler) Start() {
+	s.wg.Add(1)
+	go s.loop()
+	logger.Info("Started the compaction scheduler", "schedule", s.config.Schedule, "maxPendingRequests", s.config.MaxPendingRequests,
+		"recentBlocks", s.config.RecentBlocks, "chunkBlocks", s.config.ChunkBlocks)
+}
+
+// Stop stops the scheduler. A running compaction is completed before it returns.
+func (s *CompactionScheduler) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+func (s *CompactionScheduler) loop() {
+	defer s.wg.Done()
+
+	// align the ticks to the minutes the schedule is evaluated at
+	timer := time.NewTimer(time.Until(time.Now().Truncate(time.Minute).Add(time.Minute)))
+	defer timer.Stop()
+	for {
+		select {
+		case now := <-timer.C:
+			if s.schedule.match(now) {
+				s.compactNext()
+			} else {
+				s.updateBacklog()
+			}
+			timer.Reset(time.Until(time.Now().Truncate(time.Minute).Add(time.Minute)))
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// Backlog returns the number of cold blocks which are not compacted yet.
+func (s *CompactionScheduler) Backlog() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.backlog()
+}
+
+func (s *CompactionScheduler) backlog() uint64 {
+	cold := s.coldLimit()
+	if cold <= s.compacted {
+		return 0
+	}
+	return cold - s.compacted
+}
+
+// coldLimit returns the number of the first hot block.
+func (s *CompactionScheduler) coldLimit() uint64 {
+	head := s.head()
+	if head < s.config.RecentBlocks {
+		return 0
+	}
+	return head - s.config.RecentBlocks
+}
+
+func (s *CompactionScheduler) updateBacklog() {
+	compactionBacklogGauge.Update(int64(s.Backlog()))
+}
+
+// compactNext compacts the next chunk of the cold blocks if the workload is low.
+// It returns true if a chunk is compacted.
+func (s *CompactionScheduler) compactNext() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer func() { compactionBacklogGauge.Update(int64(s.backlog())) }()
+
+	if s.backlog() == 0 {
+		return false
+	}
+	if workload := s.workload(); workload.Syncing || workload.PendingRequests > s.config.MaxPendingRequests {
+		compactionSkippedMeter.Mark(1)
+		logger.Debug("Skipped the scheduled compaction due to the workload", "syncing", workload.Syncing, "pendingRequests", workload.PendingRequests)
+		return false
+	}
+
+	from := s.compacted
+	to := from + s.config.ChunkBlocks
+	if cold := s.coldLimit(); to > cold {
+		to = cold
+	}
+	start := time.Now()
+	for _, cold := range coldBlockPrefixes {
+		begin := append(common.CopyBytes(cold.prefix), common.Int64ToByteBigEndian(from)...)
+		end := append(common.CopyBytes(cold.prefix), common.Int64ToByteBigEndian(to)...)
+		if err := s.dbm.CompactRange(cold.dbType, begin, end); err != nil {
+			compactionFailuresMeter.Mark(1)
+			logger.Warn("Failed to compact the cold blocks", "db", cold.dbType, "from", from, "to", to, "err", err)
+			return false
+		}
+	}
+	elapsed := time.Since(start)
+	s.compacted = to
+	compactionChunksMeter.Mark(1)
+	compactionDurationGauge.Update(int64(elapsed))
+	logger.Info("Compacted the cold blocks", "from", from, "to", to, "elapsed", common.PrettyDuration(elapsed))
+	return true
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronSchedule(t *testing.T) {
+	schedule, err := parseCronSchedule("*/10 2-5 * * 1-5")
+	require.NoError(t, err)
+
+	// 2023-06-05 is a Monday
+	assert.True(t, schedule.match(time.Date(2023, 6, 5, 2, 0, 0, 0, time.Local)))
+	assert.True(t, schedule.match(time.Date(2023, 6, 5, 5, 50, 0, 0, time.Local)))
+	assert.False(t, schedule.match(time.Date(2023, 6, 5, 2, 5, 0, 0, time.Local)))
+	assert.False(t, schedule.match(time.Date(2023, 6, 5, 6, 0, 0, 0, time.Local)))
+	assert.False(t, schedule.match(time.Date(2023, 6, 4, 3, 0, 0, 0, time.Local)))
+
+	schedule, err = parseCronSchedule("0,30 23 1 1,7 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.match(time.Date(2023, 7, 1, 23, 30, 0, 0, time.Local)))
+	assert.False(t, schedule.match(time.Date(2023, 8, 1, 23, 30, 0, 0, time.Local)))
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		_, err := parseCronSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCompactionScheduler_CompactNext(t *testing.T) {
+	var (
+		dbm      = NewMemoryDBManager()
+		head     = uint64(250)
+		workload CompactionWorkload
+	)
+	config := CompactionSchedulerConfig{Schedule: "* * * * *", MaxPendingRequests: 5, RecentBlocks: 50, ChunkBlocks: 100}
+	scheduler, err := NewCompactionScheduler(dbm, config, func() uint64 { return head }, func() CompactionWorkload { return workload })
+	require.NoError(t, err)
+	assert.Equal(t, uint64(200), scheduler.Backlog())
+
+	// skipped due to the workload
+	workload = CompactionWorkload{PendingRequests: 6}
+	assert.False(t, scheduler.compactNext())
+	workload = CompactionWorkload{Syncing: true}
+	assert.False(t, scheduler.compactNext())
+	assert.Equal(t, uint64(200), scheduler.Backlog())
+
+	// compacted chunk by chunk up to the hot blocks
+	workload = CompactionWorkload{PendingRequests: 5}
+	assert.True(t, scheduler.compactNext())
+	assert.Equal(t, uint64(100), scheduler.Backlog())
+	assert.True(t, scheduler.compactNext())
+	assert.Equal(t, uint64(0), scheduler.Backlog())
+	assert.False(t, scheduler.compactNext())
+
+	// the new cold blocks are compacted later
+	head = 300
+	assert.Equal(t, uint64(50), scheduler.Backlog())
+	assert.True(t, scheduler.compactNext())
+	assert.Equal(t, uint64(250), scheduler.compacted)
+
+	_, err = NewCompactionScheduler(dbm, CompactionSchedulerConfig{Schedule: "* * * * *"}, nil, nil)
+	assert.Equal(t, errInvalidCompactionSize, err)
+}
@@ -41,7 +41,8 @@ import (
 var (
 	logger = log.NewModuleLogger(log.StorageDatabase)
 
-	errGovIdxAlreadyExist = errors.New("a governance idx of the more recent or the same block exist")
+	errGovIdxAlreadyExist     = errors.New("a governance idx of the more recent or the same block exist")
+	errCompactionNotSupported = errors.New("the database does not support compaction")
 
 	HeadBlockQ backupHashQueue
 	FastBlockQ backupHashQueue
@@ -69,6 +70,7 @@ type DBManager interface {
 	GetMiscDB() Database
 	GetSnapshotDB() Database
 	GetProperty(dt DBEntryType, name string) string
+	CompactRange(dt DBEntryType, start, limit []byte) error
 
 	// from accessors_chain.go
 	ReadCanonicalHash(number uint64) common.Hash
@@ -176,6 +178,8 @@ type DBManager interface {
 	PruneTrieNodes(marks []PruningMark)
 	WriteLastPrunedBlockNumber(blockNumber uint64)
 	ReadLastPrunedBlockNumber() (uint64, error)
+	WriteTrieNodeDeduplicated()
+	ReadTrieNodeDeduplicated() bool
 
 	// from accessors_indexes.go
 	ReadTxLookupEntry(hash common.Hash) (common.Hash, uint64, uint64)
@@ -190,6 +194,45 @@ type DBManager interface {
 	PutSenderTxHashToTxHashToBatch(batch Batch, senderTxHash, txHash common.Hash) error
 	ReadTxHashFromSenderTxHash(senderTxHash common.Hash) common.Hash
 
+	NewBalanceHistoryBatch() Batch
+	PutBalanceChangeToBatch(batch Batch, addr common.Address, change *BalanceChange) error
+	ReadBalanceChanges(addr common.Address, from, to uint64) []*BalanceChange
+	ReadBalanceChangeAfter(addr common.Address, number uint64) *BalanceChange
+	WriteBalanceHistoryIndexStart(number uint64)
+	ReadBalanceHistoryIndexStart() (uint64, bool)
+
+	WriteEpochSummary(summary *EpochSummary)
+	ReadEpochSummary(epoch uint64) *EpochSummary
+
+	NewRewardIndexBatch() Batch
+	PutAccumulatedRewardToBatch(batch Batch, addr common.Address, reward *AccumulatedReward) error
+	ReadAccumulatedReward(addr common.Address, number uint64) *AccumulatedReward
+	CountAccumulatedRewards(addr common.Address, from, to uint64) uint64
+	PutRewardIndexBlockToBatch(batch Batch, block *RewardIndexBlock) error
+	ReadRewardIndexBlock(number uint64) *RewardIndexBlock
+	DeleteRewardIndexBlock(number uint64)
+	WriteRewardIndexStart(number uint64)
+	ReadRewardIndexStart() (uint64, bool)
+	WriteRewardIndexHead(number uint64)
+	ReadRewardIndexHead() (uint64, bool)
+
+	WriteRewardCheckpoint(checkpoint *RewardCheckpoint)
+	ReadRewardCheckpoint(number uint64) *RewardCheckpoint
+	DeleteRewardCheckpoints(from, to uint64)
+	WriteRewardCheckpointTail(number uint64)
+	ReadRewardCheckpointTail() (uint64, bool)
+
+	WriteContractABI(addr common.Address, abi []byte)
+	ReadContractABI(addr common.Address) []byte
+	DeleteContractABI(addr common.Address)
+
+	WriteContractMetadata(addr common.Address, metadata []byte)
+	ReadContractMetadata(addr common.Address) []byte
+	DeleteContractMetadata(addr common.Address)
+
+	WritePersistentCounter(name string, value int64)
+	ReadPersistentCounter(name string) (int64, bool)
+
 	ReadBloomBits(bloomBitsKey []byte) ([]byte, error)
 	WriteBloomBits(bloomBitsKey []byte, bits []byte) error
 
@@ -266,6 +309,11 @@ type DBManager interface {
 	ReadParentOperatorFeePayer() common.Address
 	ReadChildOperatorFeePayer() common.Address
 
+	WriteMessageBridgePairs(pairs [][2]common.Address)
+	ReadMessageBridgePairs() [][2]common.Address
+	WriteMessageBridgeRelayedBlock(bridge common.Address, blockNum uint64)
+	ReadMessageBridgeRelayedBlock(bridge common.Address) uint64
+
 	// cacheManager related functions.
 	ClearHeaderChainCache()
 	ClearBlockChainCache()
@@ -417,6 +465,7 @@ type databaseManager struct {
 	config *DBConfig
 	dbs    []Database
 	cm     *cacheManager
+	hc     *mmapHeaderCache // nil if disabled
 
 	// TODO-Klaytn need to refine below.
 	// -merge status variable
@@ -454,6 +503,10 @@ type DBConfig struct {
 	LevelDBCompression LevelDBCompressionType
 	LevelDBBufferPool  bool
 
+	// HeaderMmapCacheSize is the size in MiB of the memory-mapped header and
+	// canonical hash cache. The cache is disabled if it is zero.
+	HeaderMmapCacheSize int
+
 	// RocksDB related configurations
 	RocksDBConfig *RocksDBConfig
 
@@ -561,7 +614,32 @@ func newDatabaseManager(dbc *DBConfig) *databaseManager {
 		config: dbc,
 		dbs:    make([]Database, databaseEntryTypeSize),
 		cm:     newCacheManager(),
+		hc:     newHeaderMmapCache(dbc),
+	}
+}
+
+// newHeaderMmapCache returns the memory-mapped header cache configured by dbc.
+// It returns nil if the cache is disabled or cannot be mapped.
+func newHeaderMmapCache(dbc *DBConfig) *mmapHeaderCache {
+	if dbc.HeaderMmapCacheSize <= 0 {
+		return nil
+	}
+	path := ""
+	if dbc.Dir != "" {
+		path = filepath.Join(dbc.Dir, mmapHeaderCacheFile)
+		if err := os.MkdirAll(dbc.Dir, 0o755); err != nil {
+			logger.Error("Failed to create the directory of the header mmap cache", "dir", dbc.Dir, "err", err)
+			return nil
+		}
+	}
+	hc, err := newMmapHeaderCache(path, dbc.HeaderMmapCacheSize*1024*1024)
+	if err != nil {
+		logger.Error("Failed to map the header cache, running without it", "path", path, "err", err)
+		return nil
 	}
+	logger.Info("Mapped the header cache", "path", path, "size(MiB)", dbc.HeaderMmapCacheSize,
+		"headers", hc.headerSlots, "canonicalHashes", hc.hashSlots)
+	return hc
 }
 
 // NewDBManager returns DBManager interface.
@@ -848,7 +926,7 @@ func removeDB(dbPath string, endCheck chan struct{}) {
 }
 
 func (dbm *databaseManager) GetStateTrieDB() Database {
-	return dbm.dbs[StateTrieDB]
+	return dbm.getDatabase(StateTrieDB)
 }
 
 func (dbm *databaseManager) GetStateTrieMigrationDB() Database {
@@ -867,6 +945,15 @@ func (dbm *databaseManager) GetProperty(dt DBEntryType, name string) string {
 	return dbm.getDatabase(dt).GetProperty(name)
 }
 
+// CompactRange compacts the given key range of the database.
+func (dbm *databaseManager) CompactRange(dt DBEntryType, start, limit []byte) error {
+	compacter, ok := dbm.getDatabase(dt).(Compacter)
+	if !ok {
+		return errCompactionNotSupported
+	}
+	return compacter.Compact(start, limit)
+}
+
 func (dbm *databaseManager) TryCatchUpWithPrimary() error {
 	for _, db := range dbm.dbs {
 		if db != nil {
@@ -905,6 +992,8 @@ func (dbm *databaseManager) getDatabase(dbEntryType DBEntryType) Database {
 }
 
 func (dbm *databaseManager) Close() {
+	dbm.hc.close()
+
 	// If single DB, only close the first database.
 	if dbm.config.SingleDB {
 		dbm.dbs[0].Close()
@@ -926,6 +1015,10 @@ func (dbm *databaseManager) ReadCanonicalHash(number uint64) common.Hash {
 	if cached := dbm.cm.readCanonicalHashCache(number); !common.EmptyHash(cached) {
 		return cached
 	}
+	if cached := dbm.hc.readCanonicalHash(number); !common.EmptyHash(cached) {
+		dbm.cm.writeCanonicalHashCache(number, cached)
+		return cached
+	}
 
 	db := dbm.getDatabase(headerDB)
 	data, _ := db.Get(headerHashKey(number))
@@ -935,6 +1028,7 @@ func (dbm *databaseManager) ReadCanonicalHash(number uint64) common.Hash {
 
 	hash := common.BytesToHash(data)
 	dbm.cm.writeCanonicalHashCache(number, hash)
+	dbm.hc.writeCanonicalHash(number, hash)
 	return hash
 }
 
@@ -945,6 +1039,7 @@ func (dbm *databaseManager) WriteCanonicalHash(hash common.Hash, number uint64)
 		logger.Crit("Failed to store number to hash mapping", "err", err)
 	}
 	dbm.cm.writeCanonicalHashCache(number, hash)
+	dbm.hc.writeCanonicalHash(number, hash)
 }
 
 // DeleteCanonicalHash removes the number to hash canonical mapping.
@@ -954,6 +1049,7 @@ func (dbm *databaseManager) DeleteCanonicalHash(number uint64) {
 		logger.Crit("Failed to delete number to hash mapping", "err", err)
 	}
 	dbm.cm.writeCanonicalHashCache(number, common.Hash{})
+	dbm.hc.deleteCanonicalHash(number)
 }
 
 // Head Header Hash operations.
@@ -1111,8 +1207,13 @@ func (dbm *databaseManager) ReadHeader(hash common.Hash, number uint64) *types.H
 
 // ReadHeaderRLP retrieves a block header in its raw RLP database encoding.
 func (dbm *databaseManager) ReadHeaderRLP(hash common.Hash, number uint64) rlp.RawValue {
+	if cached := dbm.hc.readHeaderRLP(hash, number); cached != nil {
+		return cached
+	}
+
 	db := dbm.getDatabase(headerDB)
 	data, _ := db.Get(headerKey(number, hash))
+	dbm.hc.writeHeaderRLP(hash, number, data)
 	return data
 }
 
@@ -1143,6 +1244,7 @@ func (dbm *databaseManager) WriteHeader(header *types.Header) {
 	// Write to cache at the end of successful write.
 	dbm.cm.writeHeaderCache(hash, header)
 	dbm.cm.writeBlockNumberCache(hash, number)
+	dbm.hc.writeHeaderRLP(hash, number, data)
 }
 
 // DeleteHeader removes all block header data associated with a hash.
@@ -1158,6 +1260,7 @@ func (dbm *databaseManager) DeleteHeader(hash common.Hash, number uint64) {
 	// Delete cache at the end of successful delete.
 	dbm.cm.deleteHeaderCache(hash)
 	dbm.cm.deleteBlockNumberCache(hash)
+	dbm.hc.deleteHeader(hash, number)
 }
 
 // Head Number operations.
@@ -1853,7 +1956,12 @@ func (dbm *databaseManager) ReadPreimageFromNew(hash common.Hash) []byte {
 
 func (dbm *databaseManager) ReadTrieNodeFromOld(hash common.ExtHash) ([]byte, error) {
 	db := dbm.getDatabase(StateTrieDB)
-	return db.Get(TrieNodeKey(hash))
+	val, err := db.Get(TrieNodeKey(hash))
+	if err == dataNotFoundErr && !hash.IsZeroExtended() && dbm.ReadTrieNodeDeduplicated() {
+		// The node may have been deduplicated to the copy under its merkle hash.
+		return db.Get(TrieNodeKey(hash.Unextend().ExtendZero()))
+	}
+	return val, err
 }
 
 func (dbm *databaseManager) HasTrieNodeFromOld(hash common.ExtHash) (bool, error) {
@@ -2014,6 +2122,22 @@ func (dbm *databaseManager) ReadLastPrunedBlockNumber() (uint64, error) {
 	return binary.LittleEndian.Uint64(lastPruned), nil
 }
 
+// WriteTrieNodeDeduplicated records that the copies of the trie nodes under the ExtHash keys
+// have been deleted, so that the nodes not found by the ExtHash are read by the merkle hash.
+func (dbm *databaseManager) WriteTrieNodeDeduplicated() {
+	db := dbm.getDatabase(MiscDB)
+	if err := db.Put(trieNodeDeduplicatedKey, []byte{0x01}); err != nil {
+		logger.Crit("Failed to store the trie node deduplication flag", "err", err)
+	}
+}
+
+// ReadTrieNodeDeduplicated returns whether the trie nodes have been deduplicated.
+func (dbm *databaseManager) ReadTrieNodeDeduplicated() bool {
+	db := dbm.getDatabase(MiscDB)
+	ok, _ := db.Has(trieNodeDeduplicatedKey)
+	return ok
+}
+
 // ReadTxLookupEntry retrieves the positional metadata associated with a transaction
 // hash to allow retrieving the transaction or receipt by hash.
 func (dbm *databaseManager) ReadTxLookupEntry(hash common.Hash) (common.Hash, uint64, uint64) {
@@ -2144,6 +2268,437 @@ func (dbm *databaseManager) ReadTxHashFromSenderTxHash(senderTxHash common.Hash)
 	return txHash
 }
 
+// BalanceChange is a change of an account balance made by a block.
+type BalanceChange struct {
+	Number  uint64
+	Prev    *big.Int // balance before the block
+	Balance *big.Int // balance after the block
+}
+
+type balanceChangeRLP struct {
+	Prev    *big.Int
+	Balance *big.Int
+}
+
+// NewBalanceHistoryBatch returns a batch to write balance changes.
+func (dbm *databaseManager) NewBalanceHistoryBatch() Batch {
+	return dbm.NewBatch(MiscDB) // batch.Release should be called from caller
+}
+
+// PutBalanceChangeToBatch puts the given balance change of the account to the given batch.
+func (dbm *databaseManager) PutBalanceChangeToBatch(batch Batch, addr common.Address, change *BalanceChange) error {
+	data, err := rlp.EncodeToBytes(balanceChangeRLP{Prev: change.Prev, Balance: change.Balance})
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(balanceHistoryKey(addr, change.Number), data); err != nil {
+		return err
+	}
+
+	if batch.ValueSize() > IdealBatchSize {
+		batch.Write()
+		batch.Reset()
+	}
+	return nil
+}
+
+// ReadBalanceChanges returns the balance changes of the account in the block number range [from, to].
+// The database should support iterators.
+func (dbm *databaseManager) ReadBalanceChanges(addr common.Address, from, to uint64) []*BalanceChange {
+	var changes []*BalanceChange
+	dbm.iterateBalanceChanges(addr, from, func(change *BalanceChange) bool {
+		if change.Number > to {
+			return false
+		}
+		changes = append(changes, change)
+		return true
+	})
+	return changes
+}
+
+// ReadBalanceChangeAfter returns the first balance change of the account after the given block number.
+// It returns nil if the balance has not been changed after the block.
+func (dbm *databaseManager) ReadBalanceChangeAfter(addr common.Address, number uint64) *BalanceChange {
+	var found *BalanceChange
+	dbm.iterateBalanceChanges(addr, number+1, func(change *BalanceChange) bool {
+		found = change
+		return false
+	})
+	return found
+}
+
+// iterateBalanceChanges calls fn with the balance changes of the account from the given block number
+// in ascending order until fn returns false.
+func (dbm *databaseManager) iterateBalanceChanges(addr common.Address, from uint64, fn func(*BalanceChange) bool) {
+	prefix := append(append([]byte{}, balanceHistoryPrefix...), addr.Bytes()...)
+	it := dbm.getDatabase(MiscDB).NewIterator(prefix, common.Int64ToByteBigEndian(from))
+	defer it.Release()
+
+	for it.Next() {
+		var dec balanceChangeRLP
+		if err := rlp.DecodeBytes(it.Value(), &dec); err != nil {
+			logger.Error("Invalid balance change RLP", "addr", addr, "err", err)
+			return
+		}
+		number := binary.BigEndian.Uint64(it.Key()[len(prefix):])
+		if !fn(&BalanceChange{Number: number, Prev: dec.Prev, Balance: dec.Balance}) {
+			return
+		}
+	}
+}
+
+// WriteBalanceHistoryIndexStart stores the block number from which balance changes are indexed.
+func (dbm *databaseManager) WriteBalanceHistoryIndexStart(number uint64) {
+	if err := dbm.getDatabase(MiscDB).Put(balanceHistoryIndexStartKey, common.Int64ToByteBigEndian(number)); err != nil {
+		logger.Crit("Failed to store the start of the balance history index", "err", err)
+	}
+}
+
+// ReadBalanceHistoryIndexStart returns the block number from which balance changes are indexed.
+// It returns false if the balance history has never been indexed.
+func (dbm *databaseManager) ReadBalanceHistoryIndexStart() (uint64, bool) {
+	data, _ := dbm.getDatabase(MiscDB).Get(balanceHistoryIndexStartKey)
+	if len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+// EpochSummary is a compact record of an epoch, which is the block range [Epoch*epochLen,
+// (Epoch+1)*epochLen-1] where epochLen is the istanbul epoch applied to the range.
+type EpochSummary struct {
+	Epoch      uint64                 `json:"epoch"`
+	StartBlock uint64                 `json:"startBlock"`
+	EndBlock   uint64                 `json:"endBlock"`   // the last block summarized
+	EndHash    common.Hash            `json:"endHash"`    // the hash of EndBlock to detect chain reorganizations
+	Completed  bool                   `json:"completed"`  // true if EndBlock is the last block of the epoch
+	Params     map[string]interface{} `json:"params"`     // governance parameters applied to the epoch
+	Validators []common.Address       `json:"validators"` // validators at the start of the epoch
+	Minted     *big.Int               `json:"minted"`
+	TotalFee   *big.Int               `json:"totalFee"`
+	Rewards    *big.Int               `json:"rewards"` // total amount distributed to the reward recipients
+	Burnt      *big.Int               `json:"burnt"`
+}
+
+// WriteEpochSummary stores the given epoch summary, overwriting the previous one of the epoch.
+func (dbm *databaseManager) WriteEpochSummary(summary *EpochSummary) {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		logger.Crit("Failed to encode the epoch summary", "epoch", summary.Epoch, "err", err)
+	}
+	if err := dbm.getDatabase(MiscDB).Put(epochSummaryKey(summary.Epoch), data); err != nil {
+		logger.Crit("Failed to store the epoch summary", "epoch", summary.Epoch, "err", err)
+	}
+}
+
+// ReadEpochSummary returns the summary of the given epoch. It returns nil if the epoch has not been summarized.
+func (dbm *databaseManager) ReadEpochSummary(epoch uint64) *EpochSummary {
+	data, _ := dbm.getDatabase(MiscDB).Get(epochSummaryKey(epoch))
+	if len(data) == 0 {
+		return nil
+	}
+	summary := new(EpochSummary)
+	if err := json.Unmarshal(data, summary); err != nil {
+		logger.Error("Invalid epoch summary JSON", "epoch", epoch, "err", err)
+		return nil
+	}
+	return summary
+}
+
+// AccumulatedReward is the total rewards paid to an address from the start of the reward index
+// up to a block in which the address has been rewarded.
+type AccumulatedReward struct {
+	Number uint64   // the last block whose rewards are accumulated
+	Minted *big.Int // accumulated rewards from the minted amount
+	Fee    *big.Int // accumulated rewards from the tx fees
+}
+
+type accumulatedRewardRLP struct {
+	Minted *big.Int
+	Fee    *big.Int
+}
+
+// NewRewardIndexBatch returns a batch to write accumulated rewards.
+func (dbm *databaseManager) NewRewardIndexBatch() Batch {
+	return dbm.NewBatch(MiscDB) // batch.Release should be called from caller
+}
+
+// PutAccumulatedRewardToBatch puts the given accumulated reward of the address to the given batch.
+func (dbm *databaseManager) PutAccumulatedRewardToBatch(batch Batch, addr common.Address, reward *AccumulatedReward) error {
+	data, err := rlp.EncodeToBytes(accumulatedRewardRLP{Minted: reward.Minted, Fee: reward.Fee})
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(rewardIndexKey(addr, reward.Number), data); err != nil {
+		return err
+	}
+
+	if batch.ValueSize() > IdealBatchSize {
+		batch.Write()
+		batch.Reset()
+	}
+	return nil
+}
+
+// ReadAccumulatedReward returns the latest accumulated reward of the address at or before the given
+// block number. It returns nil if the address has not been rewarded since the start of the index.
+// The database should support iterators.
+func (dbm *databaseManager) ReadAccumulatedReward(addr common.Address, number uint64) *AccumulatedReward {
+	prefix := append(append([]byte{}, rewardIndexPrefix...), addr.Bytes()...)
+	it := dbm.getDatabase(MiscDB).NewIterator(prefix, common.Int64ToByteBigEndian(^number))
+	defer it.Release()
+
+	if !it.Next() {
+		return nil
+	}
+	var dec accumulatedRewardRLP
+	if err := rlp.DecodeBytes(it.Value(), &dec); err != nil {
+		logger.Error("Invalid accumulated reward RLP", "addr", addr, "err", err)
+		return nil
+	}
+	return &AccumulatedReward{
+		Number: ^binary.BigEndian.Uint64(it.Key()[len(prefix):]),
+		Minted: dec.Minted,
+		Fee:    dec.Fee,
+	}
+}
+
+// CountAccumulatedRewards returns the number of the blocks in the range of [from, to] rewarding the address.
+// The database should support iterators.
+func (dbm *databaseManager) CountAccumulatedRewards(addr common.Address, from, to uint64) uint64 {
+	prefix := append(append([]byte{}, rewardIndexPrefix...), addr.Bytes()...)
+	it := dbm.getDatabase(MiscDB).NewIterator(prefix, common.Int64ToByteBigEndian(^to))
+	defer it.Release()
+
+	count := uint64(0)
+	for it.Next() && ^binary.BigEndian.Uint64(it.Key()[len(prefix):]) >= from {
+		count++
+	}
+	return count
+}
+
+// RewardIndexBlock is the record of a block whose rewards are accumulated, which is used
+// to revert the accumulation if the block is abandoned by a chain reorganization.
+type RewardIndexBlock struct {
+	Number     uint64
+	Hash       common.Hash
+	Recipients []common.Address // addresses whose accumulated rewards are written at the block
+	Proposer   *big.Int         // the proposer portion of the block reward, nil if indexed without it
+	BurntFee   *big.Int         // the tx fee burnt in the block, nil if indexed without it
+}
+
+type rewardIndexBlockRLP struct {
+	Hash       common.Hash
+	Recipients []common.Address
+	Proposer   *big.Int `rlp:"optional"`
+	BurntFee   *big.Int `rlp:"optional"`
+}
+
+// PutRewardIndexBlockToBatch puts the record of the given indexed block to the given batch.
+func (dbm *databaseManager) PutRewardIndexBlockToBatch(batch Batch, block *RewardIndexBlock) error {
+	data, err := rlp.EncodeToBytes(rewardIndexBlockRLP{Hash: block.Hash, Recipients: block.Recipients, Proposer: block.Proposer, BurntFee: block.BurntFee})
+	if err != nil {
+		return err
+	}
+	return batch.Put(rewardIndexBlockKey(block.Number), data)
+}
+
+// ReadRewardIndexBlock returns the record of the indexed block of the given number.
+// It returns nil if the block has not been indexed.
+func (dbm *databaseManager) ReadRewardIndexBlock(number uint64) *RewardIndexBlock {
+	data, _ := dbm.getDatabase(MiscDB).Get(rewardIndexBlockKey(number))
+	if len(data) == 0 {
+		return nil
+	}
+	var dec rewardIndexBlockRLP
+	if err := rlp.DecodeBytes(data, &dec); err != nil {
+		logger.Error("Invalid reward index block RLP", "blockNum", number, "err", err)
+		return nil
+	}
+	return &RewardIndexBlock{Number: number, Hash: dec.Hash, Recipients: dec.Recipients, Proposer: dec.Proposer, BurntFee: dec.BurntFee}
+}
+
+// DeleteRewardIndexBlock removes the accumulated rewards written at the indexed block of the given
+// number and the record of the block, so that the block is no longer accumulated.
+func (dbm *databaseManager) DeleteRewardIndexBlock(number uint64) {
+	block := dbm.ReadRewardIndexBlock(number)
+	if block == nil {
+		return
+	}
+	batch := dbm.NewBatch(MiscDB)
+	defer batch.Release()
+	for _, addr := range block.Recipients {
+		if err := batch.Delete(rewardIndexKey(addr, number)); err != nil {
+			logger.Crit("Failed to delete the accumulated reward", "addr", addr, "blockNum", number, "err", err)
+		}
+	}
+	if err := batch.Delete(rewardIndexBlockKey(number)); err != nil {
+		logger.Crit("Failed to delete the reward index block", "blockNum", number, "err", err)
+	}
+	if err := batch.Write(); err != nil {
+		logger.Crit("Failed to revert the reward index block", "blockNum", number, "err", err)
+	}
+}
+
+// WriteRewardIndexStart stores the block number from which rewards are accumulated.
+func (dbm *databaseManager) WriteRewardIndexStart(number uint64) {
+	if err := dbm.getDatabase(MiscDB).Put(rewardIndexStartKey, common.Int64ToByteBigEndian(number)); err != nil {
+		logger.Crit("Failed to store the start of the reward index", "err", err)
+	}
+}
+
+// ReadRewardIndexStart returns the block number from which rewards are accumulated.
+// It returns false if the rewards have never been indexed.
+func (dbm *databaseManager) ReadRewardIndexStart() (uint64, bool) {
+	data, _ := dbm.getDatabase(MiscDB).Get(rewardIndexStartKey)
+	if len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+// WriteRewardIndexHead stores the last block number whose rewards are accumulated.
+func (dbm *databaseManager) WriteRewardIndexHead(number uint64) {
+	if err := dbm.getDatabase(MiscDB).Put(rewardIndexHeadKey, common.Int64ToByteBigEndian(number)); err != nil {
+		logger.Crit("Failed to store the head of the reward index", "err", err)
+	}
+}
+
+// ReadRewardIndexHead returns the last block number whose rewards are accumulated.
+// It returns false if the rewards have never been indexed.
+func (dbm *databaseManager) ReadRewardIndexHead() (uint64, bool) {
+	data, _ := dbm.getDatabase(MiscDB).Get(rewardIndexHeadKey)
+	if len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+// RewardCheckpoint is the reward spec of a block persisted at import time, which serves
+// the reward of the block after the staking information it is calculated with is pruned.
+type RewardCheckpoint struct {
+	Number uint64          `json:"number"`
+	Hash   common.Hash     `json:"hash"` // the hash of the block to detect chain reorganizations
+	Spec   json.RawMessage `json:"spec"` // the JSON encoded reward spec
+}
+
+// WriteRewardCheckpoint stores the given reward checkpoint, overwriting the previous one of the block number.
+func (dbm *databaseManager) WriteRewardCheckpoint(checkpoint *RewardCheckpoint) {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		logger.Crit("Failed to encode the reward checkpoint", "blockNum", checkpoint.Number, "err", err)
+	}
+	if err := dbm.getDatabase(MiscDB).Put(rewardCheckpointKey(checkpoint.Number), data); err != nil {
+		logger.Crit("Failed to store the reward checkpoint", "blockNum", checkpoint.Number, "err", err)
+	}
+}
+
+// ReadRewardCheckpoint returns the reward checkpoint of the given block number.
+// It returns nil if the block has not been checkpointed or its checkpoint has been pruned.
+func (dbm *databaseManager) ReadRewardCheckpoint(number uint64) *RewardCheckpoint {
+	data, _ := dbm.getDatabase(MiscDB).Get(rewardCheckpointKey(number))
+	if len(data) == 0 {
+		return nil
+	}
+	checkpoint := new(RewardCheckpoint)
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		logger.Error("Invalid reward checkpoint JSON", "blockNum", number, "err", err)
+		return nil
+	}
+	return checkpoint
+}
+
+// DeleteRewardCheckpoints removes the reward checkpoints of the block range [from, to).
+func (dbm *databaseManager) DeleteRewardCheckpoints(from, to uint64) {
+	batch := dbm.NewBatch(MiscDB)
+	defer batch.Release()
+	for number := from; number < to; number++ {
+		if err := batch.Delete(rewardCheckpointKey(number)); err != nil {
+			logger.Crit("Failed to delete the reward checkpoint", "blockNum", number, "err", err)
+		}
+		if _, err := WriteBatchesOverThreshold(batch); err != nil {
+			logger.Crit("Failed to prune the reward checkpoints", "blockNum", number, "err", err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		logger.Crit("Failed to prune the reward checkpoints", "from", from, "to", to, "err", err)
+	}
+}
+
+// WriteRewardCheckpointTail stores the oldest block number whose reward checkpoint is kept.
+func (dbm *databaseManager) WriteRewardCheckpointTail(number uint64) {
+	if err := dbm.getDatabase(MiscDB).Put(rewardCheckpointTailKey, common.Int64ToByteBigEndian(number)); err != nil {
+		logger.Crit("Failed to store the tail of the reward checkpoints", "err", err)
+	}
+}
+
+// ReadRewardCheckpointTail returns the oldest block number whose reward checkpoint is kept.
+// It returns false if no reward checkpoint has been written.
+func (dbm *databaseManager) ReadRewardCheckpointTail() (uint64, bool) {
+	data, _ := dbm.getDatabase(MiscDB).Get(rewardCheckpointTailKey)
+	if len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+// WriteContractABI stores the ABI JSON of the contract.
+func (dbm *databaseManager) WriteContractABI(addr common.Address, abi []byte) {
+	if err := dbm.getDatabase(MiscDB).Put(contractABIKey(addr), abi); err != nil {
+		logger.Crit("Failed to store the contract ABI", "addr", addr, "err", err)
+	}
+}
+
+// ReadContractABI returns the ABI JSON of the contract, or nil if not registered.
+func (dbm *databaseManager) ReadContractABI(addr common.Address) []byte {
+	data, _ := dbm.getDatabase(MiscDB).Get(contractABIKey(addr))
+	return data
+}
+
+// DeleteContractABI removes the ABI JSON of the contract.
+func (dbm *databaseManager) DeleteContractABI(addr common.Address) {
+	if err := dbm.getDatabase(MiscDB).Delete(contractABIKey(addr)); err != nil {
+		logger.Crit("Failed to delete the contract ABI", "addr", addr, "err", err)
+	}
+}
+
+// WriteContractMetadata stores the metadata bundle of the contract.
+func (dbm *databaseManager) WriteContractMetadata(addr common.Address, metadata []byte) {
+	if err := dbm.getDatabase(MiscDB).Put(contractMetadataKey(addr), metadata); err != nil {
+		logger.Crit("Failed to store the contract metadata", "addr", addr, "err", err)
+	}
+}
+
+// ReadContractMetadata returns the metadata bundle of the contract, or nil if not registered.
+func (dbm *databaseManager) ReadContractMetadata(addr common.Address) []byte {
+	data, _ := dbm.getDatabase(MiscDB).Get(contractMetadataKey(addr))
+	return data
+}
+
+// DeleteContractMetadata removes the metadata bundle of the contract.
+func (dbm *databaseManager) DeleteContractMetadata(addr common.Address) {
+	if err := dbm.getDatabase(MiscDB).Delete(contractMetadataKey(addr)); err != nil {
+		logger.Crit("Failed to delete the contract metadata", "addr", addr, "err", err)
+	}
+}
+
+// WritePersistentCounter stores the lifetime value of the counter metric of the name.
+func (dbm *databaseManager) WritePersistentCounter(name string, value int64) {
+	if err := dbm.getDatabase(MiscDB).Put(persistentCounterKey(name), common.Int64ToByteBigEndian(uint64(value))); err != nil {
+		logger.Error("Failed to store the persistent counter", "name", name, "err", err)
+	}
+}
+
+// ReadPersistentCounter returns the lifetime value of the counter metric of the name.
+// It returns false if the value has not been written.
+func (dbm *databaseManager) ReadPersistentCounter(name string) (int64, bool) {
+	data, _ := dbm.getDatabase(MiscDB).Get(persistentCounterKey(name))
+	if len(data) != 8 {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(data)), true
+}
+
 // BloomBits operations.
 // ReadBloomBits retrieves the compressed bloom bit vector belonging to the given
 // section and bit index from the.
@@ -2611,9 +3166,58 @@ func (dbm *databaseManager) ReadChildOperatorFeePayer() common.Address {
 	return common.BytesToAddress(data)
 }
 
+// WriteMessageBridgePairs writes the registered pairs of child and parent message bridges.
+func (dbm *databaseManager) WriteMessageBridgePairs(pairs [][2]common.Address) {
+	db := dbm.getDatabase(bridgeServiceDB)
+	data, err := rlp.EncodeToBytes(pairs)
+	if err != nil {
+		logger.Crit("Failed to RLP encode message bridge pairs", "err", err)
+	}
+	if err := db.Put(messageBridgePairsKey, data); err != nil {
+		logger.Crit("Failed to store message bridge pairs", "err", err)
+	}
+}
+
+// ReadMessageBridgePairs returns the registered pairs of child and parent message bridges.
+func (dbm *databaseManager) ReadMessageBridgePairs() [][2]common.Address {
+	db := dbm.getDatabase(bridgeServiceDB)
+	data, _ := db.Get(messageBridgePairsKey)
+	if len(data) == 0 {
+		return nil
+	}
+	var pairs [][2]common.Address
+	if err := rlp.DecodeBytes(data, &pairs); err != nil {
+		logger.Error("Invalid message bridge pairs RLP", "err", err)
+		return nil
+	}
+	return pairs
+}
+
+// WriteMessageBridgeRelayedBlock writes the block number up to which the messages requested
+// on the message bridge have been relayed.
+func (dbm *databaseManager) WriteMessageBridgeRelayedBlock(bridge common.Address, blockNum uint64) {
+	db := dbm.getDatabase(bridgeServiceDB)
+	if err := db.Put(messageBridgeRelayedBlockKey(bridge), common.Int64ToByteBigEndian(blockNum)); err != nil {
+		logger.Crit("Failed to store the relayed block number of a message bridge", "bridge", bridge.String(), "blockNumber", blockNum, "err", err)
+	}
+}
+
+// ReadMessageBridgeRelayedBlock returns the block number up to which the messages requested
+// on the message bridge have been relayed.
+func (dbm *databaseManager) ReadMessageBridgeRelayedBlock(bridge common.Address) uint64 {
+	db := dbm.getDatabase(bridgeServiceDB)
+	data, _ := db.Get(messageBridgeRelayedBlockKey(bridge))
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
 // ClearHeaderChainCache calls cacheManager.clearHeaderChainCache to flush out caches of HeaderChain.
+// The memory-mapped header cache is emptied as well.
 func (dbm *databaseManager) ClearHeaderChainCache() {
 	dbm.cm.clearHeaderChainCache()
+	dbm.hc.purge()
 }
 
 // ClearBlockChainCache calls cacheManager.clearBlockChainCache to flush out caches of BlockChain.
@@ -2759,10 +3363,7 @@ func (dbm *databaseManager) ReadRecentGovernanceIdx(count int) ([]uint64, error)
 
 // ReadGovernanceAtNumber returns the block number and governance information which to be used for the block `num`
 func (dbm *databaseManager) ReadGovernanceAtNumber(num uint64, epoch uint64) (uint64, map[string]interface{}, error) {
-	minimum := num - (num % epoch)
-	if minimum >= epoch {
-		minimum -= epoch
-	}
+	minimum := params.CalcGovernanceBlockNumber(num, epoch)
 	totalIdx, _ := dbm.ReadRecentGovernanceIdx(0)
 	for i := len(totalIdx) - 1; i >= 0; i-- {
 		if totalIdx[i] <= minimum {
@@ -50,6 +50,9 @@ var (
 		{DBType: LevelDB, SingleDB: true, NumStateTrieShards: 1, ParallelDBWrite: true},
 		{DBType: LevelDB, SingleDB: true, NumStateTrieShards: 4, ParallelDBWrite: false},
 		{DBType: LevelDB, SingleDB: true, NumStateTrieShards: 4, ParallelDBWrite: true},
+
+		{DBType: LevelDB, SingleDB: false, NumStateTrieShards: 1, ParallelDBWrite: false, HeaderMmapCacheSize: 1},
+		{DBType: LevelDB, SingleDB: true, NumStateTrieShards: 1, ParallelDBWrite: false, HeaderMmapCacheSize: 1},
 	}
 )
 
@@ -1283,3 +1286,175 @@ func genRandomData() (common.Hash, []byte) {
 	data := common.MakeRandomBytes(100)
 	return hash, data
 }
+
+// TestDBManager_BalanceHistory tests read and write operations of the balance history index.
+// BadgerDB is not tested since it does not support iterators.
+func TestDBManager_BalanceHistory(t *testing.T) {
+	dbm := NewMemoryDBManager()
+	other := common.HexToAddress("0x1234")
+
+	_, ok := dbm.ReadBalanceHistoryIndexStart()
+	assert.False(t, ok)
+	dbm.WriteBalanceHistoryIndexStart(5)
+	start, ok := dbm.ReadBalanceHistoryIndexStart()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), start)
+
+	changes := []*BalanceChange{
+		{Number: 5, Prev: big.NewInt(0), Balance: big.NewInt(10)},
+		{Number: 8, Prev: big.NewInt(10), Balance: big.NewInt(7)},
+		{Number: 300, Prev: big.NewInt(7), Balance: big.NewInt(20)},
+	}
+	batch := dbm.NewBalanceHistoryBatch()
+	for _, change := range changes {
+		assert.NoError(t, dbm.PutBalanceChangeToBatch(batch, addr, change))
+	}
+	assert.NoError(t, dbm.PutBalanceChangeToBatch(batch, other, &BalanceChange{Number: 6, Prev: big.NewInt(1), Balance: big.NewInt(2)}))
+	assert.NoError(t, batch.Write())
+	batch.Release()
+
+	assert.Equal(t, changes, dbm.ReadBalanceChanges(addr, 0, 1000))
+	assert.Equal(t, changes[1:2], dbm.ReadBalanceChanges(addr, 6, 299))
+	assert.Empty(t, dbm.ReadBalanceChanges(addr, 9, 299))
+
+	assert.Equal(t, changes[1], dbm.ReadBalanceChangeAfter(addr, 5))
+	assert.Equal(t, changes[2], dbm.ReadBalanceChangeAfter(addr, 8))
+	assert.Nil(t, dbm.ReadBalanceChangeAfter(addr, 300))
+}
+
+// TestDBManager_RewardIndex tests read and write operations of the reward index.
+// BadgerDB is not tested since it does not support iterators.
+func TestDBManager_RewardIndex(t *testing.T) {
+	dbm := NewMemoryDBManager()
+	other := common.HexToAddress("0x1234")
+
+	_, ok := dbm.ReadRewardIndexStart()
+	assert.False(t, ok)
+	_, ok = dbm.ReadRewardIndexHead()
+	assert.False(t, ok)
+	dbm.WriteRewardIndexStart(5)
+	dbm.WriteRewardIndexHead(300)
+	start, ok := dbm.ReadRewardIndexStart()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), start)
+	head, ok := dbm.ReadRewardIndexHead()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(300), head)
+
+	rewards := []*AccumulatedReward{
+		{Number: 5, Minted: big.NewInt(10), Fee: big.NewInt(1)},
+		{Number: 8, Minted: big.NewInt(20), Fee: big.NewInt(1)},
+		{Number: 256, Minted: big.NewInt(30), Fee: big.NewInt(4)},
+	}
+	batch := dbm.NewRewardIndexBatch()
+	for _, reward := range rewards {
+		assert.NoError(t, dbm.PutAccumulatedRewardToBatch(batch, addr, reward))
+	}
+	assert.NoError(t, dbm.PutAccumulatedRewardToBatch(batch, other, &AccumulatedReward{Number: 7, Minted: big.NewInt(1), Fee: big.NewInt(0)}))
+	assert.NoError(t, batch.Write())
+	batch.Release()
+
+	assert.Nil(t, dbm.ReadAccumulatedReward(addr, 4))
+	assert.Equal(t, rewards[0], dbm.ReadAccumulatedReward(addr, 5))
+	assert.Equal(t, rewards[0], dbm.ReadAccumulatedReward(addr, 7))
+	assert.Equal(t, rewards[1], dbm.ReadAccumulatedReward(addr, 255))
+	assert.Equal(t, rewards[2], dbm.ReadAccumulatedReward(addr, 256))
+	assert.Equal(t, rewards[2], dbm.ReadAccumulatedReward(addr, 1000))
+	assert.Nil(t, dbm.ReadAccumulatedReward(other, 6))
+	assert.Equal(t, uint64(7), dbm.ReadAccumulatedReward(other, 1000).Number)
+	assert.Equal(t, uint64(3), dbm.CountAccumulatedRewards(addr, 0, 1000))
+	assert.Equal(t, uint64(2), dbm.CountAccumulatedRewards(addr, 6, 256))
+	assert.Equal(t, uint64(0), dbm.CountAccumulatedRewards(addr, 9, 255))
+	assert.Equal(t, uint64(1), dbm.CountAccumulatedRewards(other, 7, 7))
+
+	// deleting a block reverts the entries of its recipients at that block
+	block := &RewardIndexBlock{Number: 256, Hash: common.HexToHash("0x256"), Recipients: []common.Address{addr}, Proposer: big.NewInt(3), BurntFee: big.NewInt(2)}
+	assert.Nil(t, dbm.ReadRewardIndexBlock(256))
+	batch = dbm.NewRewardIndexBatch()
+	assert.NoError(t, dbm.PutRewardIndexBlockToBatch(batch, block))
+	assert.NoError(t, batch.Write())
+	batch.Release()
+	assert.Equal(t, block, dbm.ReadRewardIndexBlock(256))
+
+	dbm.DeleteRewardIndexBlock(256)
+	assert.Nil(t, dbm.ReadRewardIndexBlock(256))
+	assert.Equal(t, rewards[1], dbm.ReadAccumulatedReward(addr, 1000))
+	assert.Equal(t, uint64(7), dbm.ReadAccumulatedReward(other, 1000).Number)
+}
+
+// TestDBManager_RewardCheckpoint tests read, write and prune operations of the reward checkpoints.
+func TestDBManager_RewardCheckpoint(t *testing.T) {
+	for _, dbm := range dbManagers {
+		_, ok := dbm.ReadRewardCheckpointTail()
+		assert.False(t, ok)
+		assert.Nil(t, dbm.ReadRewardCheckpoint(10))
+
+		for num := uint64(10); num < 15; num++ {
+			dbm.WriteRewardCheckpoint(&RewardCheckpoint{
+				Number: num,
+				Hash:   common.BigToHash(new(big.Int).SetUint64(num)),
+				Spec:   []byte(`{"minted":6400000000000000000}`),
+			})
+		}
+		dbm.WriteRewardCheckpointTail(10)
+		tail, ok := dbm.ReadRewardCheckpointTail()
+		assert.True(t, ok)
+		assert.Equal(t, uint64(10), tail)
+
+		checkpoint := dbm.ReadRewardCheckpoint(12)
+		if assert.NotNil(t, checkpoint) {
+			assert.Equal(t, uint64(12), checkpoint.Number)
+			assert.Equal(t, common.BigToHash(big.NewInt(12)), checkpoint.Hash)
+			assert.JSONEq(t, `{"minted":6400000000000000000}`, string(checkpoint.Spec))
+		}
+
+		// the checkpoints of [from, to) are deleted
+		dbm.DeleteRewardCheckpoints(10, 13)
+		assert.Nil(t, dbm.ReadRewardCheckpoint(10))
+		assert.Nil(t, dbm.ReadRewardCheckpoint(12))
+		assert.NotNil(t, dbm.ReadRewardCheckpoint(13))
+		assert.NotNil(t, dbm.ReadRewardCheckpoint(14))
+	}
+}
+
+// TestDBManager_EpochSummary tests read and write operations of the epoch summaries.
+func TestDBManager_EpochSummary(t *testing.T) {
+	for _, dbm := range dbManagers {
+		assert.Nil(t, dbm.ReadEpochSummary(3))
+
+		summary := &EpochSummary{
+			Epoch:      3,
+			StartBlock: 1812,
+			EndBlock:   1900,
+			Params:     map[string]interface{}{"istanbul.epoch": float64(604), "reward.ratio": "34/54/12"},
+			Validators: []common.Address{addr},
+			Minted:     big.NewInt(6400),
+			TotalFee:   big.NewInt(25),
+			Rewards:    big.NewInt(6413),
+			Burnt:      big.NewInt(12),
+		}
+		dbm.WriteEpochSummary(summary)
+		assert.Equal(t, summary, dbm.ReadEpochSummary(3))
+		assert.Nil(t, dbm.ReadEpochSummary(4))
+
+		summary.EndBlock, summary.Completed = 2415, true
+		dbm.WriteEpochSummary(summary)
+		assert.Equal(t, summary, dbm.ReadEpochSummary(3))
+	}
+}
+
+func TestDBManager_PersistentCounter(t *testing.T) {
+	dbm := NewMemoryDBManager()
+
+	_, ok := dbm.ReadPersistentCounter("blockchain/reorg/counter")
+	assert.False(t, ok)
+
+	dbm.WritePersistentCounter("blockchain/reorg/counter", 3)
+	dbm.WritePersistentCounter("reward/distribute/minted", 1<<40)
+	value, ok := dbm.ReadPersistentCounter("blockchain/reorg/counter")
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), value)
+	value, ok = dbm.ReadPersistentCounter("reward/distribute/minted")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1<<40), value)
+}
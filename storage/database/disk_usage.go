@@ -0,0 +1,75 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// singleDBUsageKey is the key of DiskUsage when all the entries share a single database.
+const singleDBUsageKey = "single"
+
+// DiskUsage returns the bytes of the files of each database on the local disk, keyed by
+// the database name such as "header" or "statetrie". It returns nil if the databases are
+// not stored on the local disk, e.g. MemoryDB and DynamoDB.
+func DiskUsage(dbm DBManager) map[string]uint64 {
+	dbc := dbm.GetDBConfig()
+	if dbc == nil || dbc.Dir == "" || dbc.DBType == MemoryDB || dbc.DBType == DynamoDB {
+		return nil
+	}
+
+	if dbm.IsSingle() {
+		return map[string]uint64{singleDBUsageKey: dirSize(dbc.Dir)}
+	}
+
+	usage := make(map[string]uint64, databaseEntryTypeSize)
+	for et := MiscDB; et < databaseEntryTypeSize; et++ {
+		if et == StateTrieMigrationDB && !dbm.InMigration() {
+			continue
+		}
+		dir := dbBaseDirs[et]
+		if et != MiscDB {
+			dir = dbm.getDBDir(et)
+		}
+		usage[et.String()] = dirSize(filepath.Join(dbc.Dir, dir))
+	}
+	return usage
+}
+
+// dirSize returns the total size of the regular files under the directory.
+// The files removed during the walk, e.g. by compaction, are ignored.
+func dirSize(dir string) uint64 {
+	var size uint64
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += uint64(info.Size())
+		}
+		return nil
+	})
+	return size
+}
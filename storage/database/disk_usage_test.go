@@ -0,0 +1,42 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskUsage(t *testing.T) {
+	dbm := NewDBManager(&DBConfig{DBType: LevelDB, Dir: t.TempDir(), NumStateTrieShards: 4})
+	defer dbm.Close()
+
+	usage := DiskUsage(dbm)
+	assert.Len(t, usage, int(databaseEntryTypeSize)-1) // except the migration database
+	assert.NotContains(t, usage, dbBaseDirs[StateTrieMigrationDB])
+	for _, name := range []string{"misc", "header", "body", "receipts", "statetrie", "txlookup", "snapshot"} {
+		assert.NotZero(t, usage[name], name) // the manifest and the log files of each database
+	}
+
+	single := NewDBManager(&DBConfig{DBType: LevelDB, Dir: t.TempDir(), SingleDB: true, NumStateTrieShards: 1})
+	defer single.Close()
+	assert.Len(t, DiskUsage(single), 1)
+	assert.NotZero(t, DiskUsage(single)[singleDBUsageKey])
+
+	assert.Nil(t, DiskUsage(NewMemoryDBManager()))
+}
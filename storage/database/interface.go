@@ -83,6 +83,17 @@ type Database interface {
 	TryCatchUpWithPrimary() error
 }
 
+// Compacter wraps the Compact method of a backing data store.
+type Compacter interface {
+	// Compact flattens the underlying data store for the given key range. In essence,
+	// deleted and overwritten versions are discarded, and the data is rearranged to
+	// reduce the cost of operations needed to access them.
+	//
+	// A nil start is treated as a key before all keys in the data store; a nil limit
+	// is treated as a key after all keys in the data store.
+	Compact(start []byte, limit []byte) error
+}
+
 func WriteBatches(batches ...Batch) (int, error) {
 	bytes := 0
 	for _, batch := range batches {
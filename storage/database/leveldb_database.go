@@ -512,6 +512,11 @@ func (db *levelDB) TryCatchUpWithPrimary() error {
 	return nil
 }
 
+// Compact flattens the underlying data store for the given key range.
+func (db *levelDB) Compact(start []byte, limit []byte) error {
+	return db.db.CompactRange(util.Range{Start: start, Limit: limit})
+}
+
 func (db *levelDB) NewBatch() Batch {
 	return &ldbBatch{b: new(leveldb.Batch), ldb: db}
 }
@@ -50,4 +50,10 @@ var (
 
 	cacheGetCanonicalHashMissMeter = metrics.NewRegisteredMeter("klay/cache/get/canonicalhash/miss", nil)
 	cacheGetCanonicalHashHitMeter  = metrics.NewRegisteredMeter("klay/cache/get/canonicalhash/hit", nil)
+
+	mmapCacheGetHeaderMissMeter = metrics.NewRegisteredMeter("klay/mmapcache/get/header/miss", nil)
+	mmapCacheGetHeaderHitMeter  = metrics.NewRegisteredMeter("klay/mmapcache/get/header/hit", nil)
+
+	mmapCacheGetCanonicalHashMissMeter = metrics.NewRegisteredMeter("klay/mmapcache/get/canonicalhash/miss", nil)
+	mmapCacheGetCanonicalHashHitMeter  = metrics.NewRegisteredMeter("klay/mmapcache/get/canonicalhash/hit", nil)
 )
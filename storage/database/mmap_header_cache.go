@@ -0,0 +1,299 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"sync"
+
+	mmap "github.com/edsrzf/mmap-go"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+const (
+	// mmapHeaderCacheFile is the name of the file backing the mapped region.
+	// It lives in the chaindata directory and is recreated on every start.
+	mmapHeaderCacheFile = "header.mmapcache"
+
+	// Every slot starts with the block number plus one (zero marks an empty
+	// slot) followed by the block hash.
+	mmapSlotKeySize = 8 + common.HashLength
+
+	// A canonical hash slot only holds the key.
+	mmapHashSlotSize = mmapSlotKeySize
+
+	// A header slot holds the key, the length of the RLP and the RLP itself.
+	// Headers larger than the slot, e.g. with very large extra data, are
+	// simply not cached.
+	mmapHeaderSlotSize  = 4096
+	mmapHeaderMaxRLPLen = mmapHeaderSlotSize - mmapSlotKeySize - 4
+
+	// mmapHashRegionRatio is the inverse of the share of the mapped region
+	// given to canonical hashes. The rest is used for headers.
+	mmapHashRegionRatio = 16
+)
+
+var errMmapHeaderCacheTooSmall = errors.New("mapped size is too small for the header cache")
+
+// mmapHeaderCache is a fixed-size cache of block headers and canonical hashes
+// in a memory-mapped region. It backs up the in-memory caches of cacheManager
+// for random lookups of historical headers (e.g. getLogs, reward range queries
+// and tracing) which would otherwise hit the header database every time.
+//
+// Both tables are direct-mapped by block number, so storing an entry evicts
+// the one of a block number sharing its slot. Entries are validated against
+// the block number and hash on read, so an evicted or forked entry is a miss.
+// The content is not persisted; the backing file is truncated on open and
+// removed on close, letting the OS page it out instead of holding it in heap.
+type mmapHeaderCache struct {
+	lock sync.RWMutex
+
+	file *os.File // nil if the region is anonymous
+	mem  mmap.MMap
+
+	hashes      []byte // canonical hash slots
+	hashSlots   uint64
+	headers     []byte // header slots
+	headerSlots uint64
+}
+
+// newMmapHeaderCache maps a region of the given size in bytes and splits it
+// into canonical hash and header slots. The region is backed by the file at
+// path, or is anonymous if path is empty.
+func newMmapHeaderCache(path string, size int) (*mmapHeaderCache, error) {
+	hashSlots := uint64(size/mmapHashRegionRatio) / mmapHashSlotSize
+	headerSlots := (uint64(size) - hashSlots*mmapHashSlotSize) / mmapHeaderSlotSize
+	if hashSlots == 0 || headerSlots == 0 {
+		return nil, errMmapHeaderCacheTooSmall
+	}
+	length := int(hashSlots*mmapHashSlotSize + headerSlots*mmapHeaderSlotSize)
+
+	c := &mmapHeaderCache{hashSlots: hashSlots, headerSlots: headerSlots}
+	if path == "" {
+		mem, err := mmap.MapRegion(nil, length, mmap.RDWR, mmap.ANON, 0)
+		if err != nil {
+			return nil, err
+		}
+		c.mem = mem
+	} else {
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		// Grow the file sparsely so that all slots start out empty.
+		if err := file.Truncate(int64(length)); err != nil {
+			file.Close()
+			os.Remove(path)
+			return nil, err
+		}
+		mem, err := mmap.MapRegion(file, length, mmap.RDWR, 0, 0)
+		if err != nil {
+			file.Close()
+			os.Remove(path)
+			return nil, err
+		}
+		c.file, c.mem = file, mem
+	}
+	c.hashes = c.mem[:hashSlots*mmapHashSlotSize]
+	c.headers = c.mem[hashSlots*mmapHashSlotSize:]
+	return c, nil
+}
+
+func (c *mmapHeaderCache) hashSlot(number uint64) []byte {
+	offset := (number % c.hashSlots) * mmapHashSlotSize
+	return c.hashes[offset : offset+mmapHashSlotSize]
+}
+
+func (c *mmapHeaderCache) headerSlot(number uint64) []byte {
+	offset := (number % c.headerSlots) * mmapHeaderSlotSize
+	return c.headers[offset : offset+mmapHeaderSlotSize]
+}
+
+// matchSlot returns if the slot holds an entry of the given number, and the
+// hash of the entry.
+func matchSlot(slot []byte, number uint64) (common.Hash, bool) {
+	if binary.BigEndian.Uint64(slot[:8]) != number+1 {
+		return common.Hash{}, false
+	}
+	return common.BytesToHash(slot[8:mmapSlotKeySize]), true
+}
+
+func writeSlotKey(slot []byte, number uint64, hash common.Hash) {
+	binary.BigEndian.PutUint64(slot[:8], number+1)
+	copy(slot[8:mmapSlotKeySize], hash.Bytes())
+}
+
+// readCanonicalHash returns the cached canonical hash of the given number.
+// It returns empty hash if not found.
+func (c *mmapHeaderCache) readCanonicalHash(number uint64) common.Hash {
+	if c == nil {
+		return common.Hash{}
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.mem == nil {
+		return common.Hash{}
+	}
+	if hash, ok := matchSlot(c.hashSlot(number), number); ok {
+		mmapCacheGetCanonicalHashHitMeter.Mark(1)
+		return hash
+	}
+	mmapCacheGetCanonicalHashMissMeter.Mark(1)
+	return common.Hash{}
+}
+
+// writeCanonicalHash stores the canonical hash of the given number, evicting
+// the entry previously stored in the same slot.
+func (c *mmapHeaderCache) writeCanonicalHash(number uint64, hash common.Hash) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.mem == nil {
+		return
+	}
+	writeSlotKey(c.hashSlot(number), number, hash)
+}
+
+// deleteCanonicalHash removes the cached canonical hash of the given number.
+func (c *mmapHeaderCache) deleteCanonicalHash(number uint64) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.mem == nil {
+		return
+	}
+	if slot := c.hashSlot(number); binary.BigEndian.Uint64(slot[:8]) == number+1 {
+		clearSlot(slot)
+	}
+}
+
+// readHeaderRLP returns a copy of the cached header RLP of the given hash and
+// number. It returns nil if not found.
+func (c *mmapHeaderCache) readHeaderRLP(hash common.Hash, number uint64) rlp.RawValue {
+	if c == nil {
+		return nil
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.mem == nil {
+		return nil
+	}
+	slot := c.headerSlot(number)
+	if cached, ok := matchSlot(slot, number); !ok || cached != hash {
+		mmapCacheGetHeaderMissMeter.Mark(1)
+		return nil
+	}
+	length := binary.BigEndian.Uint32(slot[mmapSlotKeySize:])
+	data := make(rlp.RawValue, length)
+	copy(data, slot[mmapSlotKeySize+4:])
+	mmapCacheGetHeaderHitMeter.Mark(1)
+	return data
+}
+
+// writeHeaderRLP stores the header RLP of the given hash and number, evicting
+// the entry previously stored in the same slot. Headers not fitting in a slot
+// are ignored.
+func (c *mmapHeaderCache) writeHeaderRLP(hash common.Hash, number uint64, data rlp.RawValue) {
+	if c == nil || len(data) == 0 || len(data) > mmapHeaderMaxRLPLen {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.mem == nil {
+		return
+	}
+	slot := c.headerSlot(number)
+	writeSlotKey(slot, number, hash)
+	binary.BigEndian.PutUint32(slot[mmapSlotKeySize:], uint32(len(data)))
+	copy(slot[mmapSlotKeySize+4:], data)
+}
+
+// deleteHeader removes the cached header of the given hash and number.
+func (c *mmapHeaderCache) deleteHeader(hash common.Hash, number uint64) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.mem == nil {
+		return
+	}
+	slot := c.headerSlot(number)
+	if cached, ok := matchSlot(slot, number); ok && cached == hash {
+		clearSlot(slot[:mmapSlotKeySize])
+	}
+}
+
+func clearSlot(slot []byte) {
+	for i := range slot {
+		slot[i] = 0
+	}
+}
+
+// purge empties all slots.
+func (c *mmapHeaderCache) purge() {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.mem == nil {
+		return
+	}
+	for i := uint64(0); i < c.hashSlots; i++ {
+		clearSlot(c.hashSlot(i))
+	}
+	for i := uint64(0); i < c.headerSlots; i++ {
+		clearSlot(c.headerSlot(i)[:mmapSlotKeySize])
+	}
+}
+
+// close unmaps the region and removes its backing file.
+func (c *mmapHeaderCache) close() {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.mem == nil {
+		return
+	}
+	if err := c.mem.Unmap(); err != nil {
+		logger.Error("Failed to unmap the header cache", "err", err)
+	}
+	c.mem, c.hashes, c.headers = nil, nil, nil
+	if c.file != nil {
+		c.file.Close()
+		os.Remove(c.file.Name())
+		c.file = nil
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMmapHeaderCache(t *testing.T, path string) *mmapHeaderCache {
+	c, err := newMmapHeaderCache(path, 1024*1024)
+	require.NoError(t, err)
+	t.Cleanup(c.close)
+	return c
+}
+
+func encodeTestHeader(t *testing.T, number uint64, extra []byte) (common.Hash, rlp.RawValue) {
+	header := &types.Header{Number: new(big.Int).SetUint64(number), Extra: extra}
+	data, err := rlp.EncodeToBytes(header)
+	require.NoError(t, err)
+	return header.Hash(), data
+}
+
+func TestMmapHeaderCache_Size(t *testing.T) {
+	_, err := newMmapHeaderCache("", mmapHeaderSlotSize)
+	assert.Equal(t, errMmapHeaderCacheTooSmall, err)
+
+	c := newTestMmapHeaderCache(t, "")
+	assert.Equal(t, uint64(1024*1024/mmapHashRegionRatio/mmapHashSlotSize), c.hashSlots)
+	assert.Equal(t, uint64(240), c.headerSlots)
+	assert.LessOrEqual(t, c.hashSlots*mmapHashSlotSize+c.headerSlots*mmapHeaderSlotSize, uint64(1024*1024))
+}
+
+func TestMmapHeaderCache_CanonicalHash(t *testing.T) {
+	c := newTestMmapHeaderCache(t, "")
+
+	assert.Equal(t, common.Hash{}, c.readCanonicalHash(0))
+
+	c.writeCanonicalHash(0, hash1)
+	c.writeCanonicalHash(1, hash2)
+	assert.Equal(t, hash1, c.readCanonicalHash(0))
+	assert.Equal(t, hash2, c.readCanonicalHash(1))
+
+	// A number sharing the slot evicts the previous entry.
+	c.writeCanonicalHash(c.hashSlots, hash3)
+	assert.Equal(t, common.Hash{}, c.readCanonicalHash(0))
+	assert.Equal(t, hash3, c.readCanonicalHash(c.hashSlots))
+
+	// Deleting another number of the slot keeps the entry.
+	c.deleteCanonicalHash(0)
+	assert.Equal(t, hash3, c.readCanonicalHash(c.hashSlots))
+	c.deleteCanonicalHash(c.hashSlots)
+	assert.Equal(t, common.Hash{}, c.readCanonicalHash(c.hashSlots))
+
+	c.purge()
+	assert.Equal(t, common.Hash{}, c.readCanonicalHash(1))
+}
+
+func TestMmapHeaderCache_Header(t *testing.T) {
+	c := newTestMmapHeaderCache(t, "")
+
+	hash, data := encodeTestHeader(t, 1, nil)
+	assert.Nil(t, c.readHeaderRLP(hash, 1))
+
+	c.writeHeaderRLP(hash, 1, data)
+	assert.Equal(t, data, c.readHeaderRLP(hash, 1))
+
+	// Another header of the same number, e.g. a side chain, evicts the entry.
+	forkHash, forkData := encodeTestHeader(t, 1, []byte{0x01})
+	c.writeHeaderRLP(forkHash, 1, forkData)
+	assert.Nil(t, c.readHeaderRLP(hash, 1))
+	assert.Equal(t, forkData, c.readHeaderRLP(forkHash, 1))
+
+	// Deleting the header of another hash keeps the entry.
+	c.deleteHeader(hash, 1)
+	assert.Equal(t, forkData, c.readHeaderRLP(forkHash, 1))
+	c.deleteHeader(forkHash, 1)
+	assert.Nil(t, c.readHeaderRLP(forkHash, 1))
+
+	// A header not fitting in a slot is not cached.
+	bigHash, bigData := encodeTestHeader(t, 2, make([]byte, mmapHeaderSlotSize))
+	c.writeHeaderRLP(bigHash, 2, bigData)
+	assert.Nil(t, c.readHeaderRLP(bigHash, 2))
+
+	// The returned RLP does not alias the mapped region.
+	c.writeHeaderRLP(hash, 1, data)
+	cached := c.readHeaderRLP(hash, 1)
+	cached[0] = 0
+	assert.Equal(t, data, c.readHeaderRLP(hash, 1))
+
+	c.purge()
+	assert.Nil(t, c.readHeaderRLP(hash, 1))
+}
+
+func TestMmapHeaderCache_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), mmapHeaderCacheFile)
+	require.NoError(t, os.WriteFile(path, []byte("stale"), 0o644))
+
+	c, err := newMmapHeaderCache(path, 1024*1024)
+	require.NoError(t, err)
+
+	// A file left from a previous run is truncated.
+	assert.Equal(t, common.Hash{}, c.readCanonicalHash(0))
+	c.writeCanonicalHash(0, hash1)
+	assert.Equal(t, hash1, c.readCanonicalHash(0))
+
+	c.close()
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	// The closed cache misses without touching the unmapped region.
+	assert.Equal(t, common.Hash{}, c.readCanonicalHash(0))
+	c.writeCanonicalHash(0, hash1)
+}
+
+func TestDBManager_HeaderMmapCache(t *testing.T) {
+	dbm := NewDBManager(&DBConfig{Dir: t.TempDir(), DBType: LevelDB, HeaderMmapCacheSize: 1}).(*databaseManager)
+	defer dbm.Close()
+	require.NotNil(t, dbm.hc)
+
+	header := &types.Header{Number: big.NewInt(int64(num1))}
+	hash := header.Hash()
+	dbm.WriteHeader(header)
+	dbm.WriteCanonicalHash(hash, num1)
+
+	// Remove the entries from the database behind the manager's back, so that
+	// only the mapped cache can serve them.
+	db := dbm.getDatabase(headerDB)
+	require.NoError(t, db.Delete(headerKey(num1, hash)))
+	require.NoError(t, db.Delete(headerHashKey(num1)))
+	dbm.cm.clearHeaderChainCache()
+
+	assert.Equal(t, hash, dbm.ReadCanonicalHash(num1))
+	if cached := dbm.ReadHeader(hash, num1); assert.NotNil(t, cached) {
+		assert.Equal(t, hash, cached.Hash())
+	}
+
+	dbm.DeleteCanonicalHash(num1)
+	dbm.DeleteHeader(hash, num1)
+	dbm.cm.clearHeaderChainCache()
+	assert.Equal(t, common.Hash{}, dbm.ReadCanonicalHash(num1))
+	assert.Nil(t, dbm.ReadHeader(hash, num1))
+}
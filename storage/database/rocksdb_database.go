@@ -291,6 +291,12 @@ func (db *rocksDB) NewIterator(prefix []byte, start []byte) Iterator {
 	return &rdbIter{first: true, iter: iter, prefix: prefix, db: db}
 }
 
+// Compact flattens the underlying data store for the given key range.
+func (db *rocksDB) Compact(start []byte, limit []byte) error {
+	db.db.CompactRange(grocksdb.Range{Start: start, Limit: limit})
+	return nil
+}
+
 func (db *rocksDB) Close() {
 	close(db.quitCh)
 	db.db.CancelAllBackgroundWork(true)
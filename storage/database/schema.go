@@ -98,6 +98,7 @@ var (
 	pruningMarkValue         = []byte{0x01}                                      // A nonempty value to store a pruning mark
 	pruningMarkKeyLen        = len(pruningMarkPrefix) + 8 + common.ExtHashLength // prefix + num (uint64) + node hash
 	lastPrunedBlockNumberKey = []byte("lastPrunedBlockNumber")
+	trieNodeDeduplicatedKey  = []byte("trieNodeDeduplicated")
 
 	// Chain index prefixes (use `i` + single byte to avoid mixing data types).
 	BloomBitsIndexPrefix = []byte("iB") // BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
@@ -115,11 +116,32 @@ var (
 
 	valueTransferTxHashPrefix = []byte("vt-tx-hash-key-") // Prefix + hash -> hash
 
+	messageBridgePairsKey           = []byte("messageBridgePairs")
+	messageBridgeRelayedBlockPrefix = []byte("messageBridgeRelayedBlock") // Prefix + bridge address -> num (uint64 big endian)
+
 	// bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> bloom bits
 	bloomBitsPrefix = []byte("B")
 
 	senderTxHashToTxHashPrefix = []byte("SenderTxHash")
 
+	balanceHistoryPrefix        = []byte("balanceHistory-") // balanceHistoryPrefix + address + num (uint64 big endian) -> balance change
+	balanceHistoryIndexStartKey = []byte("balanceHistoryIndexStart")
+
+	epochSummaryPrefix = []byte("epochSummary-") // epochSummaryPrefix + epoch (uint64 big endian) -> epoch summary
+
+	rewardIndexPrefix      = []byte("rewardIndex-")      // rewardIndexPrefix + address + ^num (uint64 big endian) -> accumulated reward
+	rewardIndexBlockPrefix = []byte("rewardIndexBlock-") // rewardIndexBlockPrefix + num (uint64 big endian) -> indexed block
+	rewardIndexStartKey    = []byte("rewardIndexStart")
+	rewardIndexHeadKey     = []byte("rewardIndexHead")
+
+	rewardCheckpointPrefix  = []byte("rewardCheckpoint-") // rewardCheckpointPrefix + num (uint64 big endian) -> reward checkpoint
+	rewardCheckpointTailKey = []byte("rewardCheckpointTail")
+
+	contractABIPrefix      = []byte("contractABI-")      // contractABIPrefix + address -> contract ABI JSON
+	contractMetadataPrefix = []byte("contractMetadata-") // contractMetadataPrefix + address -> contract metadata JSON
+
+	persistentCounterPrefix = []byte("persistentCounter-") // persistentCounterPrefix + metric name -> lifetime value (int64 big endian)
+
 	governancePrefix     = []byte("governance")
 	governanceHistoryKey = []byte("governanceIdxHistory")
 	governanceStateKey   = []byte("governanceState")
@@ -194,6 +216,49 @@ func SenderTxHashToTxHashKey(senderTxHash common.Hash) []byte {
 	return append(senderTxHashToTxHashPrefix, senderTxHash.Bytes()...)
 }
 
+// balanceHistoryKey = balanceHistoryPrefix + address + num (uint64 big endian)
+func balanceHistoryKey(addr common.Address, number uint64) []byte {
+	key := append(append([]byte{}, balanceHistoryPrefix...), addr.Bytes()...)
+	return append(key, common.Int64ToByteBigEndian(number)...)
+}
+
+// rewardIndexKey = rewardIndexPrefix + address + ^num (uint64 big endian)
+// The block number is inverted so that iterating from a block finds the latest entry at or before it.
+func rewardIndexKey(addr common.Address, number uint64) []byte {
+	key := append(append([]byte{}, rewardIndexPrefix...), addr.Bytes()...)
+	return append(key, common.Int64ToByteBigEndian(^number)...)
+}
+
+// rewardIndexBlockKey = rewardIndexBlockPrefix + num (uint64 big endian)
+func rewardIndexBlockKey(number uint64) []byte {
+	return append(append([]byte{}, rewardIndexBlockPrefix...), common.Int64ToByteBigEndian(number)...)
+}
+
+// rewardCheckpointKey = rewardCheckpointPrefix + num (uint64 big endian)
+func rewardCheckpointKey(number uint64) []byte {
+	return append(append([]byte{}, rewardCheckpointPrefix...), common.Int64ToByteBigEndian(number)...)
+}
+
+// contractABIKey = contractABIPrefix + address
+func contractABIKey(addr common.Address) []byte {
+	return append(append([]byte{}, contractABIPrefix...), addr.Bytes()...)
+}
+
+// contractMetadataKey = contractMetadataPrefix + address
+func contractMetadataKey(addr common.Address) []byte {
+	return append(append([]byte{}, contractMetadataPrefix...), addr.Bytes()...)
+}
+
+// persistentCounterKey = persistentCounterPrefix + metric name
+func persistentCounterKey(name string) []byte {
+	return append(append([]byte{}, persistentCounterPrefix...), name...)
+}
+
+// epochSummaryKey = epochSummaryPrefix + epoch (uint64 big endian)
+func epochSummaryKey(epoch uint64) []byte {
+	return append(append([]byte{}, epochSummaryPrefix...), common.Int64ToByteBigEndian(epoch)...)
+}
+
 // preimageKey = preimagePrefix + hash
 func preimageKey(hash common.Hash) []byte {
 	return append(preimagePrefix, hash.Bytes()...)
@@ -238,6 +303,10 @@ func valueTransferTxHashKey(rTxHash common.Hash) []byte {
 	return append(valueTransferTxHashPrefix, rTxHash.Bytes()...)
 }
 
+func messageBridgeRelayedBlockKey(bridge common.Address) []byte {
+	return append(messageBridgeRelayedBlockPrefix, bridge.Bytes()...)
+}
+
 // bloomBitsKey = bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash
 func BloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
 	key := append(append(bloomBitsPrefix, make([]byte, 10)...), hash.Bytes()...)
@@ -164,6 +164,20 @@ func (db *shardedDB) Delete(key []byte) error {
 	}
 }
 
+// Compact flattens the shards for the given key range.
+func (db *shardedDB) Compact(start []byte, limit []byte) error {
+	for _, shard := range db.shards {
+		compacter, ok := shard.(Compacter)
+		if !ok {
+			return errCompactionNotSupported
+		}
+		if err := compacter.Compact(start, limit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (db *shardedDB) Close() {
 	close(db.sdbBatchTaskCh)
 
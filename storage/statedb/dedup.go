@@ -0,0 +1,289 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package statedb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// The trie nodes stored with live pruning are keyed by their ExtHash, so the same node
+// written by different blocks is stored once per block. The node deduplication keeps one
+// copy of each node under its merkle hash, which is also the key used without pruning.
+
+const compactedCacheSize = 1 << 20
+
+var (
+	errDedupInMigration  = errors.New("the state migration is in progress")
+	errDedupPrunedTrieDB = errors.New("the state trie database has been pruned")
+)
+
+// DedupReport is the number and the size of the trie nodes in the state trie database,
+// and of the copies sharing the merkle hash of another node. The size is the sum of the
+// key and value lengths.
+type DedupReport struct {
+	Nodes         uint64 `json:"nodes"`
+	Bytes         uint64 `json:"bytes"`
+	ExtendedNodes uint64 `json:"extendedNodes"` // Nodes stored under an ExtHash
+	UniqueNodes   uint64 `json:"uniqueNodes"`   // Nodes distinct by the merkle hash
+	DupNodes      uint64 `json:"dupNodes"`      // Nodes - UniqueNodes
+	DupBytes      uint64 `json:"dupBytes"`      // Size of the duplicate copies, an estimate of the reclaimable space
+}
+
+// DedupTrieNodes scans the state trie database for the trie nodes stored more than once.
+// Every copy of a node is counted as a duplicate except the largest one. If not nil,
+// progress is called with the report so far once every 2^20 nodes.
+func DedupTrieNodes(db database.Database, progress func(*DedupReport)) *DedupReport {
+	var (
+		report = new(DedupReport)
+		last   []byte // merkle hash of the previous node
+		kept   uint64 // size of the largest copy of the previous node
+	)
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+
+	// The copies of a node are adjacent because an ExtHash begins with the merkle hash.
+	for it.Next() {
+		key := it.Key()
+		if len(key) != common.HashLength && len(key) != common.ExtHashLength {
+			continue // not a trie node, e.g. code or preimage
+		}
+		size := uint64(len(key) + len(it.Value()))
+		report.Nodes++
+		report.Bytes += size
+		if len(key) == common.ExtHashLength {
+			report.ExtendedNodes++
+		}
+
+		if bytes.Equal(last, key[:common.HashLength]) {
+			report.DupNodes++
+			if size > kept {
+				report.DupBytes += kept
+				kept = size
+			} else {
+				report.DupBytes += size
+			}
+		} else {
+			report.UniqueNodes++
+			last = common.CopyBytes(key[:common.HashLength])
+			kept = size
+		}
+
+		if progress != nil && report.Nodes%(1<<20) == 0 {
+			progress(report)
+		}
+	}
+	return report
+}
+
+// CompactReport is the result of the trie node compaction.
+type CompactReport struct {
+	Roots          uint64 `json:"roots"`
+	WrittenNodes   uint64 `json:"writtenNodes"` // Nodes newly stored under the merkle hash
+	WrittenBytes   uint64 `json:"writtenBytes"`
+	RewrittenNodes uint64 `json:"rewrittenNodes"` // Nodes under the merkle hash whose children are shared now
+	DeletedNodes   uint64 `json:"deletedNodes"`   // Nodes deleted from the ExtHash keys
+	DeletedBytes   uint64 `json:"deletedBytes"`
+}
+
+// ReclaimedBytes returns the size of the deleted copies less the size of the copies written.
+func (r *CompactReport) ReclaimedBytes() int64 {
+	return int64(r.DeletedBytes) - int64(r.WrittenBytes)
+}
+
+// TrieCompactor rewrites the tries of the given state roots so that every node is stored
+// once under its merkle hash and refers to the children by their merkle hashes. Then the
+// copies under the ExtHash keys are deleted.
+//
+// Rewritten nodes are merkle-equivalent, so the state roots do not change. The children are
+// written before the parent, so the database stays consistent if the compaction stops in
+// the middle. It must not be used with a database under live pruning, because the nodes
+// under the merkle hash are never pruned.
+type TrieCompactor struct {
+	dbm       database.DBManager
+	batch     database.Batch
+	compacted *lru.Cache // merkle hashes of the compacted nodes
+	report    CompactReport
+}
+
+// NewTrieCompactor returns a compactor of the state trie database.
+func NewTrieCompactor(dbm database.DBManager) (*TrieCompactor, error) {
+	if dbm.InMigration() {
+		return nil, errDedupInMigration
+	}
+	if _, err := dbm.ReadLastPrunedBlockNumber(); err == nil {
+		return nil, errDedupPrunedTrieDB
+	}
+	compacted, _ := lru.New(compactedCacheSize)
+	return &TrieCompactor{
+		dbm:       dbm,
+		batch:     dbm.NewBatch(database.StateTrieDB),
+		compacted: compacted,
+	}, nil
+}
+
+// CompactRoot compacts the account trie of the state root and the storage tries of it.
+func (c *TrieCompactor) CompactRoot(root common.Hash) error {
+	if common.EmptyHash(root) || root == emptyRoot {
+		return nil
+	}
+	if err := c.compact(root.ExtendZero(), true); err != nil {
+		return err
+	}
+	c.report.Roots++
+	_, err := database.WriteBatchesOverThreshold(c.batch)
+	return err
+}
+
+// DeleteDuplicates deletes the copies of the compacted nodes under the ExtHash keys.
+// It must be called after all the state roots to be kept are compacted. The nodes not
+// compacted, e.g. of non-canonical blocks, are kept, and the deleted copies they refer
+// to are read from the copies under the merkle hash.
+func (c *TrieCompactor) DeleteDuplicates() (*CompactReport, error) {
+	if _, err := database.WriteBatches(c.batch); err != nil {
+		return nil, err
+	}
+	// Set before deleting any copy, as the nodes of other states may refer to them.
+	c.dbm.WriteTrieNodeDeduplicated()
+
+	var shared []byte // merkle hash of the last node stored under its merkle hash
+	it := c.dbm.GetStateTrieDB().NewIterator(nil, nil)
+	defer it.Release()
+
+	// The copies of a node follow the one under the merkle hash.
+	for it.Next() {
+		key := it.Key()
+		switch len(key) {
+		case common.HashLength:
+			shared = common.CopyBytes(key)
+		case common.ExtHashLength:
+			if !bytes.Equal(shared, key[:common.HashLength]) {
+				continue
+			}
+			if err := c.batch.Delete(common.CopyBytes(key)); err != nil {
+				return nil, err
+			}
+			c.report.DeletedNodes++
+			c.report.DeletedBytes += uint64(len(key) + len(it.Value()))
+			if _, err := database.WriteBatchesOverThreshold(c.batch); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, err := database.WriteBatches(c.batch); err != nil {
+		return nil, err
+	}
+	report := c.report
+	return &report, nil
+}
+
+// Report returns the result of the compaction so far.
+func (c *TrieCompactor) Report() CompactReport {
+	return c.report
+}
+
+// compact stores the node and its descendants under their merkle hashes.
+func (c *TrieCompactor) compact(ref common.ExtHash, accountTrie bool) error {
+	hash := ref.Unextend()
+	if c.compacted.Contains(hash) {
+		return nil
+	}
+
+	// A node under the merkle hash referring to the children by their merkle hashes
+	// is either compacted or written without pruning, and so are its descendants.
+	enc, _ := c.dbm.ReadTrieNodeFromOld(hash.ExtendZero())
+	shared := enc != nil
+	if !shared {
+		if enc, _ = c.dbm.ReadTrieNodeFromOld(ref); enc == nil {
+			return fmt.Errorf("missing trie node %x", ref)
+		}
+	}
+	n, err := decodeNode(hash[:], enc)
+	if err != nil {
+		return fmt.Errorf("trie node %x: %v", ref, err)
+	}
+	var children, storageRoots []common.ExtHash
+	collapsed := nodeToBytes(collapseShared(n, accountTrie, &children, &storageRoots))
+	if shared && bytes.Equal(collapsed, enc) {
+		c.compacted.Add(hash, struct{}{})
+		return nil
+	}
+
+	for _, child := range children {
+		if err := c.compact(child, accountTrie); err != nil {
+			return err
+		}
+	}
+	for _, root := range storageRoots {
+		if err := c.compact(root, false); err != nil {
+			return err
+		}
+	}
+
+	c.dbm.PutTrieNodeToBatch(c.batch, hash.ExtendZero(), collapsed)
+	if shared {
+		c.report.RewrittenNodes++
+	} else {
+		c.report.WrittenNodes++
+		c.report.WrittenBytes += uint64(common.HashLength + len(collapsed))
+	}
+	c.compacted.Add(hash, struct{}{})
+	_, err = database.WriteBatchesOverThreshold(c.batch)
+	return err
+}
+
+// collapseShared returns the node to be stored referring to the children by their merkle
+// hashes. The children and the storage roots of the accounts are appended to the slices.
+func collapseShared(original node, accountTrie bool, children, storageRoots *[]common.ExtHash) node {
+	switch n := original.(type) {
+	case *shortNode:
+		collapsed := n.copy()
+		collapsed.Key = hexToCompact(n.Key)
+		collapsed.Val = collapseShared(n.Val, accountTrie, children, storageRoots)
+		return collapsed
+	case *fullNode:
+		collapsed := n.copy()
+		for i, child := range n.Children {
+			if child != nil {
+				collapsed.Children[i] = collapseShared(child, accountTrie, children, storageRoots)
+			}
+		}
+		return collapsed
+	case hashNode:
+		exthash := common.BytesToExtHash(n)
+		*children = append(*children, exthash)
+		return hashNode(exthash.Unextend().Bytes())
+	case valueNode:
+		if !accountTrie {
+			return n
+		}
+		if pa := account.GetProgramAccount(account.DecodeSerializedAccount(n)); pa != nil {
+			if root := pa.GetStorageRoot(); !common.EmptyExtHash(root) && root.Unextend() != emptyRoot {
+				*storageRoots = append(*storageRoots, root)
+			}
+		}
+		return valueNode(account.UnextendSerializedAccount(n))
+	default:
+		return n
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package statedb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupTrieNodes(t *testing.T) {
+	dbm := database.NewMemoryDBManager()
+	db := NewDatabase(dbm)
+	opts := &TrieOpts{LivePruningEnabled: true}
+
+	commit := func(root common.Hash, updates map[string]string) common.Hash {
+		trie, err := NewTrie(root, db, opts)
+		require.NoError(t, err)
+		for k, v := range updates {
+			updateString(trie, k, v)
+		}
+		root, err = trie.Commit(nil)
+		require.NoError(t, err)
+		require.NoError(t, db.Cap(0))
+		return root
+	}
+
+	// Changing a value back writes the same nodes again under new ExtHashes.
+	values := make(map[string]string)
+	for i := 0; i < 100; i++ {
+		values[fmt.Sprintf("key%03d", i)] = fmt.Sprintf("value%03d", i)
+	}
+	root1 := commit(common.Hash{}, values)
+	root2 := commit(root1, map[string]string{"key042": "changed"})
+	assert.Equal(t, root1, commit(root2, map[string]string{"key042": "value042"}))
+
+	report := DedupTrieNodes(dbm.GetStateTrieDB(), nil)
+	assert.NotZero(t, report.ExtendedNodes)
+	assert.NotZero(t, report.DupNodes)
+	assert.Equal(t, report.Nodes, report.UniqueNodes+report.DupNodes)
+
+	var extended common.ExtHash
+	it := dbm.GetStateTrieDB().NewIterator(nil, nil)
+	for it.Next() {
+		if len(it.Key()) == common.ExtHashLength {
+			extended = common.BytesToExtHash(it.Key())
+			break
+		}
+	}
+	it.Release()
+
+	// Compact and delete the duplicates
+	compactor, err := NewTrieCompactor(dbm)
+	require.NoError(t, err)
+	require.NoError(t, compactor.CompactRoot(root1))
+	require.NoError(t, compactor.CompactRoot(root2))
+	compacted, err := compactor.DeleteDuplicates()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), compacted.Roots)
+	assert.Equal(t, report.ExtendedNodes, compacted.DeletedNodes)
+	assert.Positive(t, compacted.ReclaimedBytes())
+
+	after := DedupTrieNodes(dbm.GetStateTrieDB(), nil)
+	assert.Zero(t, after.ExtendedNodes)
+	assert.Zero(t, after.DupNodes)
+	assert.Equal(t, report.UniqueNodes, after.Nodes)
+
+	// The tries are intact and the nodes are still found by the ExtHash
+	for root, changed := range map[common.Hash]string{root1: "value042", root2: "changed"} {
+		trie, err := NewTrie(root, NewDatabase(dbm), nil)
+		require.NoError(t, err)
+		assert.Equal(t, root, trie.Hash())
+		for k, v := range values {
+			if k == "key042" {
+				v = changed
+			}
+			assert.Equal(t, v, string(getString(trie, k)))
+		}
+	}
+	assert.True(t, dbm.ReadTrieNodeDeduplicated())
+	enc, err := dbm.ReadTrieNode(extended)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, enc)
+
+	// Compacting again changes nothing
+	compactor, err = NewTrieCompactor(dbm)
+	require.NoError(t, err)
+	require.NoError(t, compactor.CompactRoot(root1))
+	assert.Equal(t, CompactReport{Roots: 1}, compactor.Report())
+
+	// A pruned database is not compacted
+	dbm.WriteLastPrunedBlockNumber(1)
+	_, err = NewTrieCompactor(dbm)
+	assert.Equal(t, errDedupPrunedTrieDB, err)
+}
@@ -165,6 +165,17 @@ func (self *Miner) Stop() {
 	atomic.StoreInt32(&self.shouldStart, 0)
 }
 
+// HaltProposal makes the node stop proposing the blocks after the given block, keeping it
+// validating the blocks proposed by the others. It is a no-op for the nodes other than CNs.
+func (self *Miner) HaltProposal(number uint64) {
+	self.worker.haltProposal(number)
+}
+
+// ResumeProposal resumes proposing the blocks halted by HaltProposal.
+func (self *Miner) ResumeProposal() {
+	self.worker.resumeProposal()
+}
+
 func (self *Miner) Register(agent Agent) {
 	if self.Mining() {
 		agent.Start()
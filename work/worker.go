@@ -36,7 +36,6 @@ import (
 	"github.com/klaytn/klaytn/event"
 	klaytnmetrics "github.com/klaytn/klaytn/metrics"
 	"github.com/klaytn/klaytn/params"
-	"github.com/klaytn/klaytn/reward"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/rcrowley/go-metrics"
 )
@@ -70,6 +69,9 @@ var (
 	gasLimitReachedTxsGauge = metrics.NewRegisteredGauge("miner/limitreached/gas/txs", nil)
 	strangeErrorTxsCounter  = metrics.NewRegisteredCounter("miner/strangeerror/txs", nil)
 
+	idleBlockSuppressedCounter = metrics.NewRegisteredCounter("miner/idle/suppressed", nil)
+	proposalHaltedCounter      = metrics.NewRegisteredCounter("miner/halted", nil)
+
 	blockBaseFee              = metrics.NewRegisteredGauge("miner/block/mining/basefee", nil)
 	blockMiningTimer          = klaytnmetrics.NewRegisteredHybridTimer("miner/block/mining/time", nil)
 	blockMiningExecuteTxTimer = klaytnmetrics.NewRegisteredHybridTimer("miner/block/execute/time", nil)
@@ -89,7 +91,6 @@ var (
 	snapshotAccountReadTimer = metrics.NewRegisteredTimer("miner/snapshot/account/reads", nil)
 	snapshotStorageReadTimer = metrics.NewRegisteredTimer("miner/snapshot/storage/reads", nil)
 	snapshotCommitTimer      = metrics.NewRegisteredTimer("miner/snapshot/commits", nil)
-	calcDeferredRewardTimer  = metrics.NewRegisteredTimer("reward/distribute/calcdeferredreward", nil)
 )
 
 // Agent can register themself with the worker
@@ -164,6 +165,15 @@ type worker struct {
 	mining int32
 	atWork int32
 
+	// idle is set when sealing an empty block is suppressed, and it is
+	// cleared when the suppressed work is resumed by new txs or idleTimer.
+	idle      int32
+	idleTimer *time.Timer
+
+	// haltAfter is the block after which no work is committed for a proposal, zero if
+	// the proposal is not halted. It is accessed atomically.
+	haltAfter uint64
+
 	nodetype common.ConnType
 }
 
@@ -298,6 +308,9 @@ func (self *worker) handleTxsCh(quitByErr chan bool) {
 				// If we're mining, but nothing is being processed, wake on new transactions
 				if self.config.Clique != nil && self.config.Clique.Period == 0 {
 					self.commitNewWork()
+				} else if atomic.CompareAndSwapInt32(&self.idle, 1, 0) {
+					// Resume the work suppressed by idle block suppression
+					self.commitNewWork()
 				}
 			}
 
@@ -512,7 +525,17 @@ func (self *worker) commitNewWork() {
 	self.currentMu.Lock()
 	defer self.currentMu.Unlock()
 
+	// New work is being committed, so any suppressed work is obsolete.
+	atomic.StoreInt32(&self.idle, 0)
+	if self.idleTimer != nil {
+		self.idleTimer.Stop()
+		self.idleTimer = nil
+	}
+
 	parent := self.chain.CurrentBlock()
+	if self.proposalHalted(parent) {
+		return
+	}
 	nextBlockNum := new(big.Int).Add(parent.Number(), common.Big1)
 	var nextBaseFee *big.Int
 	if self.nodetype == common.CONSENSUSNODE {
@@ -587,6 +610,12 @@ func (self *worker) commitNewWork() {
 		}
 		finishedFinalize := time.Now()
 
+		if len(work.txs) == 0 && self.suppressIdleBlock(parent, tstart) {
+			// Keep the pending block up to date even if the work is not sealed.
+			self.updateSnapshot()
+			return
+		}
+
 		// We only care about logging if we're actually mining.
 		if atomic.LoadInt32(&self.mining) == 1 {
 			// Update the metrics subsystem with all the measurements
@@ -604,8 +633,6 @@ func (self *worker) commitNewWork() {
 			snapshotStorageReadTimer.Update(work.state.SnapshotStorageReads)
 			snapshotCommitTimer.Update(work.state.SnapshotCommits)
 
-			calcDeferredRewardTimer.Update(reward.CalcDeferredRewardTimer)
-
 			trieAccess := work.state.AccountReads + work.state.AccountHashes + work.state.AccountUpdates + work.state.AccountCommits
 			trieAccess += work.state.StorageReads + work.state.StorageHashes + work.state.StorageUpdates + work.state.StorageCommits
 
@@ -632,6 +659,74 @@ func (self *worker) commitNewWork() {
 	self.updateSnapshot()
 }
 
+// validatorCounter is implemented by the consensus engines with a validator set, e.g. istanbul.
+type validatorCounter interface {
+	ValidatorCount(parent *types.Header) uint64
+}
+
+// suppressIdleBlock reports whether sealing an empty block on top of the parent
+// should be skipped by idle block suppression. If it returns true, the work is
+// resumed by new transactions or, if IdleBlockInterval is set, when the interval
+// since the parent block elapses. It should be called with self.mu held.
+func (self *worker) suppressIdleBlock(parent *types.Block, now time.Time) bool {
+	if !params.IdleBlockSuppression || self.nodetype != common.CONSENSUSNODE {
+		return false
+	}
+	// An epoch block should not be skipped because governance and staking
+	// information are updated at the block.
+	if istanbul := self.config.Istanbul; istanbul != nil && istanbul.Epoch != 0 && (parent.NumberU64()+1)%istanbul.Epoch == 0 {
+		return false
+	}
+	// The other validators would time out waiting for the skipped proposal and change the
+	// round, so an empty block is only suppressed on a chain with a single validator.
+	if counter, ok := self.engine.(validatorCounter); !ok || counter.ValidatorCount(parent.Header()) != 1 {
+		return false
+	}
+
+	if params.IdleBlockInterval > 0 {
+		deadline := time.Unix(parent.Time().Int64(), 0).Add(params.IdleBlockInterval)
+		if !now.Before(deadline) {
+			return false
+		}
+		self.idleTimer = time.AfterFunc(deadline.Sub(now), func() {
+			if atomic.CompareAndSwapInt32(&self.idle, 1, 0) {
+				self.commitNewWork()
+			}
+		})
+	}
+	atomic.StoreInt32(&self.idle, 1)
+	idleBlockSuppressedCounter.Inc(1)
+	logger.Debug("Suppressed sealing an empty block", "number", parent.NumberU64()+1)
+	return true
+}
+
+// proposalHalted returns true if the work on top of the parent should not be committed
+// because the proposal is halted after a block at or below the parent.
+func (self *worker) proposalHalted(parent *types.Block) bool {
+	if self.nodetype != common.CONSENSUSNODE {
+		return false
+	}
+	haltAfter := atomic.LoadUint64(&self.haltAfter)
+	if haltAfter == 0 || parent.NumberU64() < haltAfter {
+		return false
+	}
+	proposalHaltedCounter.Inc(1)
+	logger.Debug("Proposal is halted", "number", parent.NumberU64()+1, "haltAfter", haltAfter)
+	return true
+}
+
+// haltProposal stops committing the work for the blocks after the given block.
+func (self *worker) haltProposal(number uint64) {
+	atomic.StoreUint64(&self.haltAfter, number)
+}
+
+// resumeProposal resumes committing the work, starting with the work on the current block.
+func (self *worker) resumeProposal() {
+	if atomic.SwapUint64(&self.haltAfter, 0) != 0 && atomic.LoadInt32(&self.mining) == 1 {
+		self.commitNewWork()
+	}
+}
+
 func (self *worker) updateSnapshot() {
 	self.snapshotMu.Lock()
 	defer self.snapshotMu.Unlock()
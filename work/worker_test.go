@@ -0,0 +1,111 @@
+// Copyright 2023 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+)
+
+// testValidatorEngine is a consensus engine with a fixed number of validators.
+type testValidatorEngine struct {
+	consensus.Engine
+	validators uint64
+}
+
+func (e *testValidatorEngine) ValidatorCount(parent *types.Header) uint64 {
+	return e.validators
+}
+
+func setIdleBlockSuppression(t *testing.T, enabled bool, interval time.Duration) {
+	oldEnabled, oldInterval := params.IdleBlockSuppression, params.IdleBlockInterval
+	params.IdleBlockSuppression, params.IdleBlockInterval = enabled, interval
+	t.Cleanup(func() {
+		params.IdleBlockSuppression, params.IdleBlockInterval = oldEnabled, oldInterval
+	})
+}
+
+func newIdleTestWorker(engine consensus.Engine, nodetype common.ConnType) *worker {
+	config := params.TestChainConfig.Copy()
+	config.Istanbul = &params.IstanbulConfig{Epoch: 30}
+	return &worker{config: config, engine: engine, nodetype: nodetype}
+}
+
+func newIdleTestParent(number uint64, time time.Time) *types.Block {
+	return types.NewBlockWithHeader(&types.Header{
+		Number: new(big.Int).SetUint64(number),
+		Time:   big.NewInt(time.Unix()),
+	})
+}
+
+func TestWorker_SuppressIdleBlock(t *testing.T) {
+	now := time.Now()
+	single := &testValidatorEngine{validators: 1}
+
+	testcases := []struct {
+		desc     string
+		enabled  bool
+		engine   consensus.Engine
+		nodetype common.ConnType
+		parent   uint64
+		expected bool
+	}{
+		{"disabled", false, single, common.CONSENSUSNODE, 10, false},
+		{"single validator", true, single, common.CONSENSUSNODE, 10, true},
+		{"multiple validators", true, &testValidatorEngine{validators: 4}, common.CONSENSUSNODE, 10, false},
+		{"engine without validators", true, &testValidatorEngine{}, common.CONSENSUSNODE, 10, false},
+		{"engine not counting validators", true, nil, common.CONSENSUSNODE, 10, false},
+		{"not a CN", true, single, common.ENDPOINTNODE, 10, false},
+		{"epoch block", true, single, common.CONSENSUSNODE, 29, false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			setIdleBlockSuppression(t, tc.enabled, 0)
+			w := newIdleTestWorker(tc.engine, tc.nodetype)
+
+			assert.Equal(t, tc.expected, w.suppressIdleBlock(newIdleTestParent(tc.parent, now), now))
+			assert.Equal(t, tc.expected, atomic.LoadInt32(&w.idle) == 1)
+			assert.Nil(t, w.idleTimer)
+		})
+	}
+}
+
+func TestWorker_SuppressIdleBlock_Interval(t *testing.T) {
+	setIdleBlockSuppression(t, true, time.Hour)
+	now := time.Now()
+	w := newIdleTestWorker(&testValidatorEngine{validators: 1}, common.CONSENSUSNODE)
+
+	// an empty block is sealed once the interval has passed since the parent
+	assert.False(t, w.suppressIdleBlock(newIdleTestParent(10, now.Add(-time.Hour)), now))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&w.idle))
+	assert.Nil(t, w.idleTimer)
+
+	// otherwise the work is resumed when the interval passes
+	assert.True(t, w.suppressIdleBlock(newIdleTestParent(10, now.Add(-time.Minute)), now))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&w.idle))
+	if assert.NotNil(t, w.idleTimer) {
+		assert.True(t, w.idleTimer.Stop())
+	}
+}